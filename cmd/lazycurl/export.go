@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/import/hoppscotch"
+	"github.com/kbrdn1/LazyCurl/internal/import/httpfile"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+)
+
+// ExportCommand handles the export subcommand
+type ExportCommand struct {
+	Format      string // "dotenv", "http", "hoppscotch"
+	Environment string // Environment name or path to its file (dotenv)
+	Collection  string // Collection name or path to its file (http, hoppscotch)
+	Request     string // Only export this request of the collection: ID, name or folder path (http)
+	Output      string // File to write; stdout when empty
+	Workspace   string // Workspace holding .lazycurl/environments
+}
+
+// ParseExportArgs parses export command arguments
+func ParseExportArgs(args []string) (*ExportCommand, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("usage: lazycurl export dotenv <environment> [options]\n       lazycurl export http <collection> [options]\n       lazycurl export hoppscotch <collection> [options]\n\nFormats:\n  dotenv     Write the active variables of an environment to a .env file\n  http       Write the requests of a collection to a .http file (VS Code REST Client)\n  hoppscotch Write a collection to a Hoppscotch collections file\n\nOptions:\n  -r, --request REF  Only export this request: ID, name or folder path (http)\n  -o, --output PATH  Write to a file instead of stdout")
+	}
+	if args[0] != "dotenv" && args[0] != "http" && args[0] != "hoppscotch" {
+		return nil, fmt.Errorf("unsupported format: %s. Supported formats: dotenv, http, hoppscotch", args[0])
+	}
+	cmd := &ExportCommand{Format: args[0]}
+	var source string
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-o" || arg == "--output" || arg == "-r" || arg == "--request":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			if arg == "-r" || arg == "--request" {
+				if cmd.Format != "http" {
+					return nil, fmt.Errorf("%s applies to the http format", arg)
+				}
+				cmd.Request = args[i]
+			} else {
+				cmd.Output = args[i]
+			}
+		case arg == "" || arg[0] == '-':
+			return nil, fmt.Errorf("unknown option: %s", arg)
+		case source != "":
+			return nil, fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			source = arg
+		}
+	}
+	if cmd.Format == "http" || cmd.Format == "hoppscotch" {
+		if source == "" {
+			return nil, fmt.Errorf("collection required after format")
+		}
+		cmd.Collection = source
+	} else {
+		if source == "" {
+			return nil, fmt.Errorf("environment required after format")
+		}
+		cmd.Environment = source
+	}
+
+	workspacePath, err := config.GetWorkspacePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace path: %w", err)
+	}
+	cmd.Workspace = workspacePath
+	return cmd, nil
+}
+
+// RunExportCommand writes the environment in .env format, or the collection in .http or
+// Hoppscotch format, to the output file, or to w. Secret values are written too, so an
+// output .env file is only readable by its owner.
+func RunExportCommand(cmd *ExportCommand, w io.Writer) error {
+	switch cmd.Format {
+	case "http":
+		return runHTTPFileExport(cmd, w)
+	case "hoppscotch":
+		return runHoppscotchExport(cmd, w)
+	}
+	if err := useKeychain(cmd.Workspace); err != nil {
+		return err
+	}
+	envsDir := filepath.Join(cmd.Workspace, ".lazycurl", "environments")
+	env, err := findRunFile(cmd.Environment, envsDir, api.LoadEnvironment, api.LoadAllEnvironments,
+		func(e *api.EnvironmentFile) (string, string) { return e.Name, e.FilePath })
+	if err != nil {
+		return fmt.Errorf("environment: %w", err)
+	}
+
+	data := api.FormatDotenv(env)
+	if cmd.Output != "" {
+		if err := os.WriteFile(cmd.Output, data, 0600); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		return nil
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// runHTTPFileExport writes the requests of the collection, or its request cmd.Request,
+// in .http format after the collection variables. Requests of folders are named after
+// their folder path.
+func runHTTPFileExport(cmd *ExportCommand, w io.Writer) error {
+	collectionsDir := filepath.Join(cmd.Workspace, ".lazycurl", "collections")
+	col, err := findRunFile(cmd.Collection, collectionsDir, api.LoadCollection, api.LoadAllCollections,
+		func(c *api.CollectionFile) (string, string) { return c.Name, c.FilePath })
+	if err != nil {
+		return fmt.Errorf("collection: %w", err)
+	}
+
+	items := collectionItems(col.Requests, col.Folders, nil)
+	if cmd.Request != "" {
+		_, item, err := findSnapshotRequest([]*api.CollectionFile{col}, cmd.Request)
+		if err != nil {
+			return err
+		}
+		items = []runner.Item{item}
+	}
+	requests := make([]*api.CollectionRequest, len(items))
+	for i, item := range items {
+		req := item.Request
+		req.Name = item.Name()
+		requests[i] = &req
+	}
+
+	data := []byte(httpfile.Format(col.Variables, requests...))
+	if cmd.Output != "" {
+		if err := os.WriteFile(cmd.Output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		return nil
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// runHoppscotchExport writes the collection as a Hoppscotch collections file, ready for
+// Hoppscotch's import
+func runHoppscotchExport(cmd *ExportCommand, w io.Writer) error {
+	collectionsDir := filepath.Join(cmd.Workspace, ".lazycurl", "collections")
+	col, err := findRunFile(cmd.Collection, collectionsDir, api.LoadCollection, api.LoadAllCollections,
+		func(c *api.CollectionFile) (string, string) { return c.Name, c.FilePath })
+	if err != nil {
+		return fmt.Errorf("collection: %w", err)
+	}
+
+	if cmd.Output != "" {
+		return hoppscotch.ExportCollections([]*api.CollectionFile{col}, cmd.Output)
+	}
+	data, err := hoppscotch.ExportCollectionsToBytes([]*api.CollectionFile{col})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/import/hoppscotch"
+)
+
+func TestParseExportArgs(t *testing.T) {
+	cmd, err := ParseExportArgs([]string{"dotenv", "staging", "-o", ".env"})
+	if err != nil {
+		t.Fatalf("ParseExportArgs() error = %v", err)
+	}
+	if cmd.Format != "dotenv" || cmd.Environment != "staging" || cmd.Output != ".env" {
+		t.Errorf("got %+v", cmd)
+	}
+	for _, args := range [][]string{{"dotenv"}, {"postman", "staging"}, {"dotenv", "staging", "--output"}, {"dotenv", "a", "b"}} {
+		if _, err := ParseExportArgs(args); err == nil {
+			t.Errorf("ParseExportArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestRunExportCommand(t *testing.T) {
+	workspace := t.TempDir()
+	env := &api.EnvironmentFile{Name: "Staging", Variables: map[string]*api.EnvironmentVariable{
+		"base_url": {Value: "https://staging.example.com", Active: true},
+		"token":    {Value: "s3cr3t", Secret: true, Active: true},
+		"old":      {Value: "unused"},
+	}}
+	if err := api.SaveEnvironment(env, filepath.Join(workspace, ".lazycurl", "environments", "staging.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := RunExportCommand(&ExportCommand{Format: "dotenv", Environment: "staging", Workspace: workspace}, &out); err != nil {
+		t.Fatalf("RunExportCommand() error = %v", err)
+	}
+	want := "# Environment Staging, exported by LazyCurl\nbase_url=https://staging.example.com\ntoken=s3cr3t\n"
+	if out.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	output := filepath.Join(workspace, ".env")
+	if err := RunExportCommand(&ExportCommand{Format: "dotenv", Environment: "Staging", Output: output, Workspace: workspace}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+	if err := RunExportCommand(&ExportCommand{Format: "dotenv", Environment: "prod", Workspace: workspace}, &out); err == nil {
+		t.Error("RunExportCommand() should fail for an unknown environment")
+	}
+}
+
+func TestRunExportCommand_HTTP(t *testing.T) {
+	workspace := t.TempDir()
+	col := &api.CollectionFile{
+		Name:      "Shop",
+		Variables: map[string]string{"base_url": "https://shop.example.com"},
+		Requests:  []api.CollectionRequest{{ID: "r1", Name: "Ping", Method: "GET", URL: "{{base_url}}/ping"}},
+		Folders: []api.Folder{{Name: "Orders", Requests: []api.CollectionRequest{
+			{ID: "r2", Name: "Create order", Method: "POST", URL: "{{base_url}}/orders",
+				Body: &api.BodyConfig{Type: "json", Content: `{"sku": "A1"}`}},
+		}}},
+	}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, err := ParseExportArgs([]string{"http", "shop", "--request", "Orders/Create order"})
+	if err != nil {
+		t.Fatalf("ParseExportArgs() error = %v", err)
+	}
+	if cmd.Collection != "shop" || cmd.Request != "Orders/Create order" {
+		t.Errorf("got %+v", cmd)
+	}
+	if _, err := ParseExportArgs([]string{"dotenv", "staging", "-r", "x"}); err == nil {
+		t.Error("--request should only apply to the http format")
+	}
+
+	var out bytes.Buffer
+	if err := RunExportCommand(&ExportCommand{Format: "http", Collection: "Shop", Workspace: workspace}, &out); err != nil {
+		t.Fatalf("RunExportCommand() error = %v", err)
+	}
+	want := "@base_url = https://shop.example.com\n\n" +
+		"### Ping\nGET {{base_url}}/ping\n\n" +
+		"### Orders / Create order\nPOST {{base_url}}/orders\nContent-Type: application/json\n\n{\"sku\": \"A1\"}\n"
+	if out.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := RunExportCommand(&ExportCommand{Format: "http", Collection: "Shop", Request: "Ping", Workspace: workspace}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := "@base_url = https://shop.example.com\n\n### Ping\nGET {{base_url}}/ping\n"; out.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestRunExportCommand_Hoppscotch(t *testing.T) {
+	workspace := t.TempDir()
+	col := &api.CollectionFile{
+		Name:     "Shop",
+		Requests: []api.CollectionRequest{{ID: "r1", Name: "Ping", Method: "GET", URL: "{{base_url}}/ping"}},
+	}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(workspace, "hoppscotch.json")
+	if err := RunExportCommand(&ExportCommand{Format: "hoppscotch", Collection: "Shop", Output: output, Workspace: workspace}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("RunExportCommand() error = %v", err)
+	}
+	result, err := hoppscotch.ImportCollections(output)
+	if err != nil {
+		t.Fatalf("the export should read back as Hoppscotch collections: %v", err)
+	}
+	if len(result.Collections) != 1 || len(result.Collections[0].Requests) != 1 || result.Collections[0].Requests[0].URL != "{{base_url}}/ping" {
+		t.Errorf("read back %+v", result.Collections)
+	}
+}
@@ -9,17 +9,20 @@ import (
 
 	"github.com/kbrdn1/LazyCurl/internal/api"
 	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/import/hoppscotch"
+	"github.com/kbrdn1/LazyCurl/internal/import/httpfile"
 	"github.com/kbrdn1/LazyCurl/internal/import/postman"
 )
 
 // ImportCommand handles the import subcommand
 type ImportCommand struct {
-	Format     string // "auto", "openapi", "postman"
-	FilePath   string // Path to file to import
-	Name       string // Override collection name
-	Output     string // Custom output path
-	DryRun     bool   // Preview only, don't save
-	JSONOutput bool   // Output as JSON
+	Format     string   // "auto", "openapi", "postman", "dotenv", "http", "hoppscotch"
+	FilePath   string   // Path to file to import, or directory or glob pattern of files
+	Files      []string // Further files to import with FilePath, as expanded by the shell
+	Name       string   // Override collection name; for .env files, name of the environment to create or update
+	Output     string   // Custom output path
+	DryRun     bool     // Preview only, don't save
+	JSONOutput bool     // Output as JSON
 }
 
 // ParseImportArgs parses import command arguments
@@ -27,12 +30,12 @@ func ParseImportArgs(args []string) (*ImportCommand, error) {
 	cmd := &ImportCommand{Format: "auto"} // Default to auto-detection
 
 	if len(args) < 1 {
-		return nil, fmt.Errorf("usage: lazycurl import <file> [options]\n       lazycurl import <format> <file> [options]\n\nFormats:\n  auto       Auto-detect format (default)\n  openapi    Import OpenAPI 3.x specification (JSON/YAML)\n  postman    Import Postman collection or environment\n\nOptions:\n  --format FORMAT  Specify import format (auto, openapi, postman)\n  --name NAME      Override collection name\n  --output PATH    Custom output path\n  --dry-run        Preview without saving\n  --json           Output results as JSON")
+		return nil, fmt.Errorf("usage: lazycurl import <file> [options]\n       lazycurl import <format> <file> [options]\n       lazycurl import <directory|glob|files...> [options]\n\nFormats:\n  auto       Auto-detect format (default)\n  openapi    Import OpenAPI 3.x specification (JSON/YAML file or URL)\n  postman    Import Postman collection or environment\n  dotenv     Import a .env file into an environment\n  http       Import a .http/.rest file (VS Code REST Client)\n  hoppscotch Import Hoppscotch collections or environments\n\nOptions:\n  --format FORMAT  Specify import format (auto, openapi, postman, dotenv, http, hoppscotch)\n  --name NAME      Override collection name\n  --output PATH    Custom output path\n  --dry-run        Preview without saving\n  --json           Output results as JSON")
 	}
 
 	// Check if first arg is a format or a file
 	firstArg := args[0]
-	isKnownFormat := firstArg == "openapi" || firstArg == "postman" || firstArg == "auto"
+	isKnownFormat := firstArg == "openapi" || firstArg == "postman" || firstArg == "dotenv" || firstArg == "http" || firstArg == "hoppscotch" || firstArg == "auto"
 	// Treat as format only if it's a known format AND the file doesn't exist at that path
 	// This prevents files named "postman" or "openapi" from being misinterpreted
 	_, fileErr := os.Stat(firstArg)
@@ -59,8 +62,8 @@ func ParseImportArgs(args []string) (*ImportCommand, error) {
 			}
 			i++
 			format := args[i]
-			if format != "auto" && format != "openapi" && format != "postman" {
-				return nil, fmt.Errorf("invalid format %q; supported formats are: auto, openapi, postman", format)
+			if format != "auto" && format != "openapi" && format != "postman" && format != "dotenv" && format != "http" && format != "hoppscotch" {
+				return nil, fmt.Errorf("invalid format %q; supported formats are: auto, openapi, postman, dotenv, http, hoppscotch", format)
 			}
 			cmd.Format = format
 		case "--name":
@@ -83,6 +86,7 @@ func ParseImportArgs(args []string) (*ImportCommand, error) {
 			if args[i][0] == '-' {
 				return nil, fmt.Errorf("unknown option: %s", args[i])
 			}
+			cmd.Files = append(cmd.Files, args[i])
 		}
 	}
 
@@ -95,9 +99,11 @@ type ImportResult struct {
 	ImportType     string   `json:"import_type,omitempty"` // "collection" or "environment"
 	CollectionName string   `json:"collection_name,omitempty"`
 	FilePath       string   `json:"file_path,omitempty"`
+	Source         string   `json:"source,omitempty"` // File imported, in batch imports
 	FolderCount    int      `json:"folder_count,omitempty"`
 	RequestCount   int      `json:"request_count,omitempty"`
 	VariableCount  int      `json:"variable_count,omitempty"` // For environments
+	Updated        bool     `json:"updated,omitempty"`        // An existing environment took the variables of a .env file
 	Warnings       []string `json:"warnings,omitempty"`
 	Error          string   `json:"error,omitempty"`
 	ErrorLine      int      `json:"error_line,omitempty"`
@@ -106,6 +112,9 @@ type ImportResult struct {
 
 // RunImportCommand executes the import command
 func RunImportCommand(cmd *ImportCommand) error {
+	if cmd.isBatch() {
+		return runBatchImport(cmd)
+	}
 	switch cmd.Format {
 	case "auto":
 		return runAutoDetectImport(cmd)
@@ -113,13 +122,34 @@ func RunImportCommand(cmd *ImportCommand) error {
 		return runOpenAPIImport(cmd)
 	case "postman":
 		return runPostmanImport(cmd)
+	case "dotenv":
+		return runDotenvImport(cmd)
+	case "http":
+		return runHTTPFileImport(cmd)
+	case "hoppscotch":
+		return runHoppscotchImport(cmd)
 	default:
-		return fmt.Errorf("unsupported format: %s. Supported formats: auto, openapi, postman", cmd.Format)
+		return fmt.Errorf("unsupported format: %s. Supported formats: auto, openapi, postman, dotenv, http, hoppscotch", cmd.Format)
 	}
 }
 
 // runAutoDetectImport auto-detects file format and routes to appropriate importer
 func runAutoDetectImport(cmd *ImportCommand) error {
+	// Only OpenAPI specs are imported from URLs
+	if api.IsRemoteSpec(cmd.FilePath) {
+		return runOpenAPIImport(cmd)
+	}
+
+	// .env files are known by their name
+	if api.IsDotenvFile(cmd.FilePath) {
+		return runDotenvImport(cmd)
+	}
+
+	// .http and .rest files are known by their extension
+	if httpfile.IsHTTPFile(cmd.FilePath) {
+		return runHTTPFileImport(cmd)
+	}
+
 	// Try Postman detection first (faster)
 	fileType, postmanErr := postman.DetectFileType(cmd.FilePath)
 	if postmanErr == nil && fileType != postman.FileTypeUnknown {
@@ -127,6 +157,11 @@ func runAutoDetectImport(cmd *ImportCommand) error {
 		return runPostmanImportWithType(cmd, fileType)
 	}
 
+	// Then Hoppscotch exports, also JSON
+	if hoppscotchType, err := hoppscotch.DetectFileType(cmd.FilePath); err == nil && hoppscotchType != hoppscotch.FileTypeUnknown {
+		return runHoppscotchImportWithType(cmd, hoppscotchType)
+	}
+
 	// Fall back to OpenAPI
 	openapiErr := runOpenAPIImport(cmd)
 	if openapiErr == nil {
@@ -137,7 +172,7 @@ func runAutoDetectImport(cmd *ImportCommand) error {
 	if postmanErr != nil {
 		return handleImportError(cmd, fmt.Errorf("failed to auto-detect format: not a valid Postman file (%w) or OpenAPI spec (%w)", postmanErr, openapiErr))
 	}
-	return handleImportError(cmd, fmt.Errorf("failed to auto-detect format: file is neither a valid Postman collection/environment nor OpenAPI specification"))
+	return handleImportError(cmd, fmt.Errorf("failed to auto-detect format: file is neither a valid Postman collection/environment, Hoppscotch export nor OpenAPI specification"))
 }
 
 // runPostmanImport handles Postman collection/environment import
@@ -240,6 +275,9 @@ func runPostmanEnvironmentImport(cmd *ImportCommand) error {
 		if err != nil {
 			return handleImportError(cmd, fmt.Errorf("failed to get workspace path: %w", err))
 		}
+		if err := useKeychain(workspacePath); err != nil {
+			return handleImportError(cmd, err)
+		}
 		envsDir := filepath.Join(workspacePath, ".lazycurl", "environments")
 		if err := os.MkdirAll(envsDir, 0755); err != nil {
 			return handleImportError(cmd, fmt.Errorf("failed to create environments directory: %w", err))
@@ -266,10 +304,307 @@ func runPostmanEnvironmentImport(cmd *ImportCommand) error {
 	return outputResult(cmd, importResult)
 }
 
+// runDotenvImport imports a .env file into the environment of the workspace named
+// cmd.Name, or after the file, creating it when there is none by that name
+func runDotenvImport(cmd *ImportCommand) error {
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return handleImportError(cmd, fmt.Errorf("failed to read .env file: %w", err))
+	}
+	vars, err := api.ParseDotenv(data)
+	if err != nil {
+		return handleImportError(cmd, err)
+	}
+	name := cmd.Name
+	if name == "" {
+		name = api.DotenvEnvironmentName(cmd.FilePath)
+	}
+
+	// Update the environment at --output, or the one of the workspace with that name
+	var env *api.EnvironmentFile
+	outputPath := cmd.Output
+	if outputPath != "" {
+		if _, statErr := os.Stat(outputPath); statErr == nil {
+			if env, err = api.LoadEnvironment(outputPath); err != nil {
+				return handleImportError(cmd, err)
+			}
+		}
+	} else {
+		workspacePath, err := config.GetWorkspacePath()
+		if err != nil {
+			return handleImportError(cmd, fmt.Errorf("failed to get workspace path: %w", err))
+		}
+		if err := useKeychain(workspacePath); err != nil {
+			return handleImportError(cmd, err)
+		}
+		envsDir := filepath.Join(workspacePath, ".lazycurl", "environments")
+		existing, _ := api.LoadAllEnvironments(envsDir) // A missing directory has none
+		for _, e := range existing {
+			if e.Name == name {
+				env = e
+			}
+		}
+		outputPath = filepath.Join(envsDir, sanitizeFilename(name)+".json")
+		if env != nil {
+			outputPath = env.FilePath
+		}
+	}
+	created := env == nil
+	if created {
+		env = &api.EnvironmentFile{Name: name, Variables: make(map[string]*api.EnvironmentVariable)}
+	}
+	env.ImportDotenv(vars)
+
+	result := ImportResult{
+		Success:        true,
+		ImportType:     "environment",
+		CollectionName: env.Name,
+		FilePath:       outputPath,
+		VariableCount:  len(env.Variables),
+		Updated:        !created,
+	}
+	if cmd.DryRun {
+		return outputDotenvPreview(cmd, result)
+	}
+	if err := api.SaveEnvironment(env, outputPath); err != nil {
+		return handleImportError(cmd, fmt.Errorf("failed to save environment: %w", err))
+	}
+	return outputResult(cmd, result)
+}
+
+// outputDotenvPreview outputs the environment a .env import would save
+func outputDotenvPreview(cmd *ImportCommand, result ImportResult) error {
+	if cmd.JSONOutput {
+		preview := map[string]interface{}{
+			"type":      "dotenv",
+			"name":      result.CollectionName,
+			"file_path": result.FilePath,
+			"variables": result.VariableCount,
+			"updated":   result.Updated,
+		}
+		data, err := json.MarshalIndent(preview, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf(".env Import Preview\n")
+	fmt.Printf("===================\n\n")
+	fmt.Printf("Environment: %s\n", result.CollectionName)
+	fmt.Printf("File:        %s\n", result.FilePath)
+	fmt.Printf("Variables:   %d\n", result.VariableCount)
+	if result.Updated {
+		fmt.Printf("\nUpdates the existing environment\n")
+	}
+
+	fmt.Printf("\n(dry-run mode - no files created)\n")
+	return nil
+}
+
+// runHTTPFileImport imports the requests of a .http or .rest file into a collection
+func runHTTPFileImport(cmd *ImportCommand) error {
+	result, err := httpfile.ImportFile(cmd.FilePath)
+	if err != nil {
+		return handleImportError(cmd, err)
+	}
+	if cmd.Name != "" {
+		result.Collection.Name = cmd.Name
+	}
+
+	if cmd.DryRun {
+		return outputHTTPFilePreview(cmd, result)
+	}
+
+	outputPath := cmd.Output
+	if outputPath == "" {
+		workspacePath, err := config.GetWorkspacePath()
+		if err != nil {
+			return handleImportError(cmd, fmt.Errorf("failed to get workspace path: %w", err))
+		}
+		collectionsDir := filepath.Join(workspacePath, ".lazycurl", "collections")
+		if err := os.MkdirAll(collectionsDir, 0755); err != nil {
+			return handleImportError(cmd, fmt.Errorf("failed to create collections directory: %w", err))
+		}
+		outputPath = filepath.Join(collectionsDir, sanitizeFilename(result.Collection.Name)+".json")
+	}
+
+	result.Collection.FilePath = outputPath
+	if err := api.SaveCollection(result.Collection, outputPath); err != nil {
+		return handleImportError(cmd, fmt.Errorf("failed to save collection: %w", err))
+	}
+
+	return outputResult(cmd, ImportResult{
+		Success:        true,
+		ImportType:     "collection",
+		CollectionName: result.Collection.Name,
+		FilePath:       outputPath,
+		RequestCount:   len(result.Collection.Requests),
+		Warnings:       result.Warnings,
+	})
+}
+
+// outputHTTPFilePreview outputs the collection a .http import would save
+func outputHTTPFilePreview(cmd *ImportCommand, result *httpfile.ImportResult) error {
+	if cmd.JSONOutput {
+		preview := map[string]interface{}{
+			"type":           "http",
+			"name":           result.Collection.Name,
+			"requests_count": len(result.Collection.Requests),
+			"variables":      len(result.Collection.Variables),
+			"warnings":       result.Warnings,
+		}
+		data, err := json.MarshalIndent(preview, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf(".http File Import Preview\n")
+	fmt.Printf("=========================\n\n")
+	fmt.Printf("Name:        %s\n", result.Collection.Name)
+	fmt.Printf("Requests:    %d\n", len(result.Collection.Requests))
+	fmt.Printf("Variables:   %d\n", len(result.Collection.Variables))
+	for _, req := range result.Collection.Requests {
+		fmt.Printf("  %-7s %s\n", req.Method, req.Name)
+	}
+
+	if len(result.Warnings) > 0 {
+		fmt.Printf("\nWarnings:\n")
+		for _, w := range result.Warnings {
+			fmt.Printf("  ! %s\n", w)
+		}
+	}
+
+	fmt.Printf("\n(dry-run mode - no files created)\n")
+	return nil
+}
+
+// runHoppscotchImport imports the collections or environments of a Hoppscotch export
+func runHoppscotchImport(cmd *ImportCommand) error {
+	fileType, err := hoppscotch.DetectFileType(cmd.FilePath)
+	if err != nil {
+		return handleImportError(cmd, fmt.Errorf("failed to detect file type: %w", err))
+	}
+	return runHoppscotchImportWithType(cmd, fileType)
+}
+
+// runHoppscotchImportWithType handles Hoppscotch import with pre-detected file type. A
+// file of one collection or environment is reported as a single import, one of several
+// as a batch.
+func runHoppscotchImportWithType(cmd *ImportCommand, fileType hoppscotch.FileType) error {
+	var result *hoppscotch.ImportResult
+	var err error
+	switch fileType {
+	case hoppscotch.FileTypeCollection:
+		result, err = hoppscotch.ImportCollections(cmd.FilePath)
+	case hoppscotch.FileTypeEnvironment:
+		result, err = hoppscotch.ImportEnvironments(cmd.FilePath)
+	default:
+		return handleImportError(cmd, fmt.Errorf("unrecognized file format: not a valid Hoppscotch collection or environment export"))
+	}
+	if err != nil {
+		return handleImportError(cmd, err)
+	}
+
+	count := len(result.Collections) + len(result.Environments)
+	if count > 1 && (cmd.Name != "" || cmd.Output != "") {
+		return handleImportError(cmd, fmt.Errorf("--name and --output apply to a single collection or environment; %s holds %d", cmd.FilePath, count))
+	}
+	if cmd.Name != "" {
+		for _, collection := range result.Collections {
+			collection.Name = cmd.Name
+		}
+		for _, env := range result.Environments {
+			env.Name = cmd.Name
+		}
+	}
+
+	var workspacePath string
+	if !cmd.DryRun && cmd.Output == "" {
+		if workspacePath, err = config.GetWorkspacePath(); err != nil {
+			return handleImportError(cmd, fmt.Errorf("failed to get workspace path: %w", err))
+		}
+	}
+	if !cmd.DryRun && len(result.Environments) > 0 && workspacePath != "" {
+		if err := useKeychain(workspacePath); err != nil {
+			return handleImportError(cmd, err)
+		}
+	}
+
+	batch := BatchImportResult{Success: true, DryRun: cmd.DryRun, Warnings: result.Summary.Warnings}
+	used := make(map[string]bool) // Files written by the import, which never overwrites its own
+	for _, collection := range result.Collections {
+		imported := ImportResult{
+			Success:        true,
+			ImportType:     "collection",
+			CollectionName: collection.Name,
+			Source:         cmd.FilePath,
+			FolderCount:    countCollectionFolders(collection),
+			RequestCount:   countCollectionRequests(collection),
+		}
+		if !cmd.DryRun {
+			outputPath := cmd.Output
+			if outputPath == "" {
+				if outputPath, err = batchOutputPath(filepath.Join(workspacePath, ".lazycurl", "collections"), collection.Name, used); err != nil {
+					return handleImportError(cmd, err)
+				}
+			}
+			collection.FilePath = outputPath
+			if err := api.SaveCollection(collection, outputPath); err != nil {
+				return handleImportError(cmd, fmt.Errorf("failed to save collection: %w", err))
+			}
+			imported.FilePath = outputPath
+		}
+		batch.Collections = append(batch.Collections, imported)
+	}
+	for _, env := range result.Environments {
+		imported := ImportResult{
+			Success:        true,
+			ImportType:     "environment",
+			CollectionName: env.Name,
+			Source:         cmd.FilePath,
+			VariableCount:  len(env.Variables),
+		}
+		if !cmd.DryRun {
+			outputPath := cmd.Output
+			if outputPath == "" {
+				if outputPath, err = batchOutputPath(filepath.Join(workspacePath, ".lazycurl", "environments"), env.Name, used); err != nil {
+					return handleImportError(cmd, err)
+				}
+			}
+			if err := api.SaveEnvironment(env, outputPath); err != nil {
+				return handleImportError(cmd, fmt.Errorf("failed to save environment: %w", err))
+			}
+			imported.FilePath = outputPath
+		}
+		batch.Environments = append(batch.Environments, imported)
+	}
+
+	if count > 1 || cmd.DryRun {
+		return outputBatchResult(cmd, batch)
+	}
+	imported := batch.Environments
+	if len(batch.Collections) > 0 {
+		imported = batch.Collections
+	}
+	imported[0].Warnings = result.Summary.Warnings
+	return outputResult(cmd, imported[0])
+}
+
 // runOpenAPIImport handles OpenAPI import
 func runOpenAPIImport(cmd *ImportCommand) error {
-	// Load the OpenAPI file
-	importer, err := api.NewOpenAPIImporterFromFile(cmd.FilePath)
+	// Load the OpenAPI file or URL, recording it so the collection can be re-synced with :sync
+	source := api.OpenAPISource{Location: cmd.FilePath}
+	if !api.IsRemoteSpec(source.Location) {
+		if abs, err := filepath.Abs(source.Location); err == nil {
+			source.Location = abs
+		}
+	}
+	importer, err := api.NewOpenAPIImporterFromSource(source, nil)
 	if err != nil {
 		return handleImportError(cmd, err)
 	}
@@ -314,6 +649,7 @@ func runOpenAPIImport(cmd *ImportCommand) error {
 
 	// Save collection
 	collection.FilePath = outputPath
+	collection.OpenAPISource = &source
 	if err := api.SaveCollection(collection, outputPath); err != nil {
 		return handleImportError(cmd, fmt.Errorf("failed to save collection: %w", err))
 	}
@@ -335,6 +671,205 @@ func runOpenAPIImport(cmd *ImportCommand) error {
 	return outputResult(cmd, result)
 }
 
+// BatchImportResult is the consolidated result of the import of several files
+type BatchImportResult struct {
+	Success      bool           `json:"success"` // False when a file failed to import
+	DryRun       bool           `json:"dry_run,omitempty"`
+	Collections  []ImportResult `json:"collections"`
+	Environments []ImportResult `json:"environments"`
+	Skipped      []string       `json:"skipped,omitempty"` // Files that are neither a Postman collection nor an environment
+	Failed       []ImportResult `json:"failed,omitempty"`
+	Warnings     []string       `json:"warnings,omitempty"`
+}
+
+// isBatch reports whether the command imports several files: a directory, a glob pattern
+// or a list of files
+func (cmd *ImportCommand) isBatch() bool {
+	return len(cmd.Files) > 0 || (!api.IsRemoteSpec(cmd.FilePath) && postman.IsBatch(cmd.FilePath))
+}
+
+// runBatchImport imports the Postman collections and environments of a directory, a glob
+// pattern or a list of files, and prints a consolidated summary. A file that fails to
+// import does not stop the others, but fails the command.
+func runBatchImport(cmd *ImportCommand) error {
+	if cmd.Format == "openapi" || cmd.Format == "dotenv" || cmd.Format == "http" || cmd.Format == "hoppscotch" {
+		return handleImportError(cmd, fmt.Errorf("batch import supports Postman files; import OpenAPI specs, .env, .http and Hoppscotch files one at a time"))
+	}
+	if cmd.Name != "" || cmd.Output != "" {
+		return handleImportError(cmd, fmt.Errorf("--name and --output apply to a single file, not to a batch import"))
+	}
+
+	var files []string
+	for _, path := range append([]string{cmd.FilePath}, cmd.Files...) {
+		if !postman.IsBatch(path) {
+			files = append(files, path)
+			continue
+		}
+		expanded, err := postman.ExpandBatch(path)
+		if err != nil {
+			return handleImportError(cmd, err)
+		}
+		files = append(files, expanded...)
+	}
+	batch := postman.ImportBatch(files)
+
+	var workspacePath string
+	if !cmd.DryRun && len(batch.Collections())+len(batch.Environments()) > 0 {
+		var err error
+		if workspacePath, err = config.GetWorkspacePath(); err != nil {
+			return handleImportError(cmd, fmt.Errorf("failed to get workspace path: %w", err))
+		}
+		if len(batch.Environments()) > 0 {
+			if err := useKeychain(workspacePath); err != nil {
+				return handleImportError(cmd, err)
+			}
+		}
+	}
+
+	result := BatchImportResult{Success: true, DryRun: cmd.DryRun, Skipped: batch.Skipped, Warnings: batch.Warnings()}
+	fail := func(source string, err error) {
+		result.Success = false
+		result.Failed = append(result.Failed, ImportResult{Source: source, Error: err.Error()})
+	}
+	for _, f := range batch.Failed() {
+		fail(f.Path, f.Error)
+	}
+
+	used := make(map[string]bool) // Files written by the batch, which never overwrites its own
+	for _, f := range batch.Collections() {
+		collection := f.Result.Collection
+		imported := ImportResult{
+			Success:        true,
+			ImportType:     "collection",
+			CollectionName: collection.Name,
+			Source:         f.Path,
+			FolderCount:    f.Result.Summary.FoldersCount,
+			RequestCount:   f.Result.Summary.RequestsCount,
+			Warnings:       f.Result.Summary.Warnings,
+		}
+		if !cmd.DryRun {
+			outputPath, err := batchOutputPath(filepath.Join(workspacePath, ".lazycurl", "collections"), collection.Name, used)
+			if err == nil {
+				collection.FilePath = outputPath
+				err = api.SaveCollection(collection, outputPath)
+			}
+			if err != nil {
+				fail(f.Path, fmt.Errorf("failed to save collection: %w", err))
+				continue
+			}
+			imported.FilePath = outputPath
+		}
+		result.Collections = append(result.Collections, imported)
+	}
+	for _, f := range batch.Environments() {
+		env := f.Result.Environment
+		imported := ImportResult{
+			Success:        true,
+			ImportType:     "environment",
+			CollectionName: env.Name,
+			Source:         f.Path,
+			VariableCount:  f.Result.Summary.VariablesCount,
+			Warnings:       f.Result.Summary.Warnings,
+		}
+		if !cmd.DryRun {
+			outputPath, err := batchOutputPath(filepath.Join(workspacePath, ".lazycurl", "environments"), env.Name, used)
+			if err == nil {
+				err = api.SaveEnvironment(env, outputPath)
+			}
+			if err != nil {
+				fail(f.Path, fmt.Errorf("failed to save environment: %w", err))
+				continue
+			}
+			imported.FilePath = outputPath
+		}
+		result.Environments = append(result.Environments, imported)
+	}
+
+	if err := outputBatchResult(cmd, result); err != nil {
+		return err
+	}
+	if !result.Success {
+		return fmt.Errorf("%d of %d files failed to import", len(result.Failed), len(batch.Files))
+	}
+	return nil
+}
+
+// batchOutputPath returns the path of the file saving name in dir, numbered when another
+// file of the batch already took its name
+func batchOutputPath(dir, name string, used map[string]bool) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	base := sanitizeFilename(name)
+	outputPath := filepath.Join(dir, base+".json")
+	for i := 2; used[outputPath]; i++ {
+		outputPath = filepath.Join(dir, fmt.Sprintf("%s-%d.json", base, i))
+	}
+	used[outputPath] = true
+	return outputPath, nil
+}
+
+// outputBatchResult outputs the consolidated result of a batch import
+func outputBatchResult(cmd *ImportCommand, result BatchImportResult) error {
+	if cmd.JSONOutput {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if result.DryRun {
+		fmt.Printf("Batch Import Preview\n")
+		fmt.Printf("====================\n\n")
+	}
+	fmt.Printf("Collections:  %d\n", len(result.Collections))
+	fmt.Printf("Environments: %d\n", len(result.Environments))
+
+	if len(result.Collections) > 0 {
+		fmt.Printf("\nCollections:\n")
+		for _, r := range result.Collections {
+			fmt.Printf("  + %s (%d requests, %d folders) from %s\n", r.CollectionName, r.RequestCount, r.FolderCount, r.Source)
+			if r.FilePath != "" {
+				fmt.Printf("    %s\n", r.FilePath)
+			}
+		}
+	}
+	if len(result.Environments) > 0 {
+		fmt.Printf("\nEnvironments:\n")
+		for _, r := range result.Environments {
+			fmt.Printf("  + %s (%d variables) from %s\n", r.CollectionName, r.VariableCount, r.Source)
+			if r.FilePath != "" {
+				fmt.Printf("    %s\n", r.FilePath)
+			}
+		}
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("\nSkipped (not a Postman collection or environment):\n")
+		for _, path := range result.Skipped {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
+	if len(result.Failed) > 0 {
+		fmt.Printf("\nFailed:\n")
+		for _, r := range result.Failed {
+			fmt.Printf("  x %s: %s\n", r.Source, r.Error)
+		}
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Printf("\nWarnings:\n")
+		for _, w := range result.Warnings {
+			fmt.Printf("  ! %s\n", w)
+		}
+	}
+
+	if result.DryRun {
+		fmt.Printf("\n(dry-run mode - no files created)\n")
+	}
+	return nil
+}
+
 // handleImportError handles and formats import errors
 func handleImportError(cmd *ImportCommand, err error) error {
 	result := ImportResult{
@@ -518,7 +1053,11 @@ func outputResult(cmd *ImportCommand, result ImportResult) error {
 		}
 	}
 
-	fmt.Printf("Successfully imported %s\n\n", importType)
+	if result.Updated {
+		fmt.Printf("Successfully updated %s\n\n", importType)
+	} else {
+		fmt.Printf("Successfully imported %s\n\n", importType)
+	}
 	fmt.Printf("Name: %s\n", result.CollectionName)
 	fmt.Printf("File: %s\n", result.FilePath)
 
@@ -570,6 +1109,24 @@ func countCollectionRequests(c *api.CollectionFile) int {
 	return count
 }
 
+// countCollectionFolders counts all folders in a collection
+func countCollectionFolders(c *api.CollectionFile) int {
+	count := len(c.Folders)
+	for _, folder := range c.Folders {
+		count += countFolderFolders(&folder)
+	}
+	return count
+}
+
+// countFolderFolders counts the subfolders of a folder recursively
+func countFolderFolders(f *api.Folder) int {
+	count := len(f.Folders)
+	for _, subfolder := range f.Folders {
+		count += countFolderFolders(&subfolder)
+	}
+	return count
+}
+
 // countFolderRequests counts requests in a folder recursively
 func countFolderRequests(f *api.Folder) int {
 	count := len(f.Requests)
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
 )
 
 func TestParseImportArgs(t *testing.T) {
@@ -40,6 +44,13 @@ func TestParseImportArgs(t *testing.T) {
 			wantFile:   "spec.yaml",
 			wantErr:    false,
 		},
+		{
+			name:       "explicit hoppscotch format",
+			args:       []string{"hoppscotch", "hoppscotch.json"},
+			wantFormat: "hoppscotch",
+			wantFile:   "hoppscotch.json",
+			wantErr:    false,
+		},
 		{
 			name:       "format flag overrides",
 			args:       []string{"collection.json", "--format", "postman"},
@@ -151,3 +162,161 @@ func TestParseImportArgsWithFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseImportArgs_Files(t *testing.T) {
+	cmd, err := ParseImportArgs([]string{"a.json", "b.json", "--dry-run", "c.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.FilePath != "a.json" || len(cmd.Files) != 2 || !cmd.isBatch() {
+		t.Errorf("ParseImportArgs() = %q + %v, want a batch of 3 files", cmd.FilePath, cmd.Files)
+	}
+}
+
+func TestRunBatchImport(t *testing.T) {
+	testdata := filepath.Join("..", "..", "internal", "import", "postman", "testdata")
+	exports := t.TempDir()
+	for name, from := range map[string]string{
+		"shop.json":      "simple_collection.json",
+		"shop-copy.json": "simple_collection.json",
+		"env/dev.json":   "simple_environment.json",
+		"notes.json":     "not_postman.json",
+	} {
+		data, err := os.ReadFile(filepath.Join(testdata, from))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(exports, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+
+	if err := RunImportCommand(&ImportCommand{Format: "auto", FilePath: exports, DryRun: true, JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(workspace, ".lazycurl")); !os.IsNotExist(err) {
+		t.Error("a dry run should not write files")
+	}
+
+	if err := RunImportCommand(&ImportCommand{Format: "auto", FilePath: exports, JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	// Both collections are named "Simple API": the second one is numbered rather than overwriting the first
+	for _, path := range []string{"collections/Simple-API.json", "collections/Simple-API-2.json", "environments/Development.json"} {
+		if _, err := os.Stat(filepath.Join(workspace, ".lazycurl", path)); err != nil {
+			t.Errorf("the batch import should write %s: %v", path, err)
+		}
+	}
+}
+
+func TestRunDotenvImport(t *testing.T) {
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	if err := os.WriteFile(".env.staging", []byte("# Staging\nexport BASE_URL=https://staging.example.com\nAPI_TOKEN='t0k3n'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Auto-detected from the file name, into a new environment named after it
+	if err := RunImportCommand(&ImportCommand{Format: "auto", FilePath: ".env.staging", JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(workspace, ".lazycurl", "environments", "staging.json")
+	env, err := api.LoadEnvironment(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := env.Variables["API_TOKEN"]; env.Name != "staging" || v == nil || v.Value != "t0k3n" || !v.Secret {
+		t.Fatalf("the .env file should be imported as a new environment, got %+v", env)
+	}
+
+	// Importing again updates the environment, keeping its other variables
+	env.SetVariable("user", "ada")
+	if err := api.SaveEnvironment(env, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".env.staging", []byte("BASE_URL=https://staging2.example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunImportCommand(&ImportCommand{Format: "dotenv", FilePath: ".env.staging", JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	if env, _ = api.LoadEnvironment(path); env.Variables["BASE_URL"].Value != "https://staging2.example.com" || env.Variables["user"] == nil {
+		t.Errorf("the environment should be updated, got %+v", env.Variables)
+	}
+}
+
+func TestRunHTTPFileImport(t *testing.T) {
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	data := "@base_url = https://api.example.com\n\n### Get user\nGET {{base_url}}/users/1\n\n### Create user\nPOST {{base_url}}/users\nContent-Type: application/json\n\n{\"name\": \"Ada\"}\n"
+	if err := os.WriteFile("users.rest", []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Auto-detected from the extension, into a collection named after the file
+	if err := RunImportCommand(&ImportCommand{Format: "auto", FilePath: "users.rest", JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	col, err := api.LoadCollection(filepath.Join(workspace, ".lazycurl", "collections", "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Name != "users" || len(col.Requests) != 2 || col.Variables["base_url"] != "https://api.example.com" {
+		t.Fatalf("the .rest file should be imported as a collection, got %+v", col)
+	}
+	if req := col.Requests[1]; req.Name != "Create user" || req.Body == nil || req.Body.Type != "json" {
+		t.Errorf("second request = %+v", req)
+	}
+}
+
+func TestRunHoppscotchImport(t *testing.T) {
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	collections := `[
+		{"v": 2, "name": "Shop", "folders": [{"v": 2, "name": "Orders", "folders": [], "requests": []}], "requests": [
+			{"v": "1", "name": "Ping", "method": "GET", "endpoint": "<<base_url>>/ping", "params": [], "headers": [],
+			 "preRequestScript": "", "testScript": "", "auth": {"authType": "none", "authActive": true}, "body": {"contentType": null, "body": null}}
+		]},
+		{"v": 2, "name": "Status", "folders": [], "requests": []}
+	]`
+	if err := os.WriteFile("hoppscotch.json", []byte(collections), 0644); err != nil {
+		t.Fatal(err)
+	}
+	environment := `{"name": "Staging", "variables": [{"key": "base_url", "value": "https://staging.example.com", "secret": false}]}`
+	if err := os.WriteFile("staging.json", []byte(environment), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Auto-detected after Postman; every collection of the file is saved
+	if err := RunImportCommand(&ImportCommand{Format: "auto", FilePath: "hoppscotch.json", JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	col, err := api.LoadCollection(filepath.Join(workspace, ".lazycurl", "collections", "Shop.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Folders) != 1 || len(col.Requests) != 1 || col.Requests[0].URL != "{{base_url}}/ping" {
+		t.Errorf("imported collection = %+v", col)
+	}
+	if _, err := os.Stat(filepath.Join(workspace, ".lazycurl", "collections", "Status.json")); err != nil {
+		t.Errorf("the second collection should be saved: %v", err)
+	}
+
+	// A single environment takes --name
+	if err := RunImportCommand(&ImportCommand{Format: "hoppscotch", FilePath: "staging.json", Name: "staging", JSONOutput: true}); err != nil {
+		t.Fatal(err)
+	}
+	env, err := api.LoadEnvironment(filepath.Join(workspace, ".lazycurl", "environments", "staging.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Name != "staging" || env.Variables["base_url"] == nil || env.Variables["base_url"].Value != "https://staging.example.com" {
+		t.Errorf("imported environment = %+v", env)
+	}
+}
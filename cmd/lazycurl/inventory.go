@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/stats"
+)
+
+// InventoryCommand handles the inventory subcommand
+type InventoryCommand struct {
+	Collections []string // Collection names or paths to collection files; all of the workspace when empty
+	JSONOutput  bool     // Output as JSON instead of CSV
+	Output      string   // File to write; stdout when empty
+	Workspace   string   // Workspace holding .lazycurl/collections and the usage statistics
+}
+
+// ParseInventoryArgs parses inventory command arguments
+func ParseInventoryArgs(args []string) (*InventoryCommand, error) {
+	cmd := &InventoryCommand{}
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--json":
+			cmd.JSONOutput = true
+		case arg == "-o" || arg == "--output":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			cmd.Output = args[i]
+		case arg == "" || arg[0] == '-':
+			return nil, fmt.Errorf("unknown option: %s", arg)
+		default:
+			cmd.Collections = append(cmd.Collections, arg)
+		}
+	}
+	// A .json output file implies JSON
+	if strings.EqualFold(filepath.Ext(cmd.Output), ".json") {
+		cmd.JSONOutput = true
+	}
+
+	workspacePath, err := config.GetWorkspacePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace path: %w", err)
+	}
+	cmd.Workspace = workspacePath
+	return cmd, nil
+}
+
+// RunInventoryCommand writes the API inventory of the collections to the output file,
+// or to w. Last-tested dates come from the usage statistics of the workspace.
+func RunInventoryCommand(cmd *InventoryCommand, w io.Writer) error {
+	collections, err := loadWorkspaceCollections(cmd.Workspace, cmd.Collections)
+	if err != nil {
+		return err
+	}
+	usage, err := stats.Load(cmd.Workspace)
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+	entries := api.Inventory(collections, usage.LastSent())
+
+	if cmd.Output != "" {
+		file, err := os.Create(cmd.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if cmd.JSONOutput {
+		return api.WriteInventoryJSON(entries, w)
+	}
+	return api.WriteInventoryCSV(entries, w)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/stats"
+)
+
+func TestParseInventoryArgs(t *testing.T) {
+	cmd, err := ParseInventoryArgs([]string{"shop", "-o", "out/inventory.json", "orders"})
+	if err != nil {
+		t.Fatalf("ParseInventoryArgs() error = %v", err)
+	}
+	if !cmd.JSONOutput || cmd.Output != "out/inventory.json" || strings.Join(cmd.Collections, ",") != "shop,orders" {
+		t.Errorf("got %+v", cmd)
+	}
+	if _, err := ParseInventoryArgs([]string{"--output"}); err == nil {
+		t.Error("ParseInventoryArgs() should fail without an output path")
+	}
+	if _, err := ParseInventoryArgs([]string{"--csv"}); err == nil {
+		t.Error("ParseInventoryArgs() should fail for an unknown option")
+	}
+}
+
+func TestRunInventoryCommand(t *testing.T) {
+	workspace := t.TempDir()
+	col := &api.CollectionFile{Name: "Shop", Owner: "platform", Requests: []api.CollectionRequest{
+		{ID: "a", Name: "Health", Method: api.GET, URL: "{{base_url}}/health"},
+		{ID: "b", Name: "Orders", Method: api.POST, URL: "{{base_url}}/orders?dry=1", Auth: &api.AuthConfig{Type: "basic"}},
+	}}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+	sent := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	usage, _ := stats.Load(workspace)
+	usage.Add(stats.Entry{Time: sent, RequestID: "b", Method: "POST"})
+	if err := usage.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := RunInventoryCommand(&InventoryCommand{Workspace: workspace}, &out); err != nil {
+		t.Fatalf("RunInventoryCommand() error = %v", err)
+	}
+	want := "collection,folder,name,request_id,method,endpoint,auth,owner,last_tested\n" +
+		"Shop,,Health,a,GET,{{base_url}}/health,none,platform,\n" +
+		"Shop,,Orders,b,POST,{{base_url}}/orders,basic,platform,2026-03-10T15:00:00Z\n"
+	if out.String() != want {
+		t.Errorf("CSV output =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	output := filepath.Join(workspace, "inventory.json")
+	if err := RunInventoryCommand(&InventoryCommand{Workspace: workspace, JSONOutput: true, Output: output}, &out); err != nil {
+		t.Fatalf("RunInventoryCommand() error = %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []api.InventoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if len(entries) != 2 || entries[0].LastTested != nil || entries[1].LastTested == nil || !entries[1].LastTested.Equal(sent) {
+		t.Errorf("JSON entries = %+v", entries)
+	}
+}
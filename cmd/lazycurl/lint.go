@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui"
+)
+
+// LintCommand handles the lint subcommand
+type LintCommand struct {
+	Collections []string // Collection names or paths to collection files; all of the workspace when empty
+	JSONOutput  bool     // Output as JSON
+	Workspace   string   // Workspace holding .lazycurl/collections and the lint config
+}
+
+// LintResult is the JSON output of the lint subcommand
+type LintResult struct {
+	Collections int           `json:"collections"`
+	Requests    int           `json:"requests"`
+	Errors      int           `json:"errors"`
+	Warnings    int           `json:"warnings"`
+	Findings    []LintProblem `json:"findings"`
+}
+
+// LintProblem is a finding in JSON output
+type LintProblem struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Collection string `json:"collection"`
+	Request    string `json:"request"`
+	RequestID  string `json:"request_id"`
+	Message    string `json:"message"`
+}
+
+// ParseLintArgs parses lint command arguments
+func ParseLintArgs(args []string) (*LintCommand, error) {
+	cmd := &LintCommand{}
+	for _, arg := range args {
+		switch {
+		case arg == "--json":
+			cmd.JSONOutput = true
+		case arg == "" || arg[0] == '-':
+			return nil, fmt.Errorf("unknown option: %s", arg)
+		default:
+			cmd.Collections = append(cmd.Collections, arg)
+		}
+	}
+
+	workspacePath, err := config.GetWorkspacePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace path: %w", err)
+	}
+	cmd.Workspace = workspacePath
+	return cmd, nil
+}
+
+// RunLintCommand checks the collections against the lint rules of the workspace config
+// and writes a report to w. Returns false if any finding is an error.
+func RunLintCommand(cmd *LintCommand, w io.Writer) (bool, error) {
+	collections, err := loadWorkspaceCollections(cmd.Workspace, cmd.Collections)
+	if err != nil {
+		return false, err
+	}
+
+	workspaceConfig, err := config.LoadWorkspaceConfig(cmd.Workspace)
+	if err != nil {
+		return false, fmt.Errorf("workspace config: %w", err)
+	}
+	report, err := api.Lint(collections, ui.NewLintOptions(workspaceConfig.Lint))
+	if err != nil {
+		return false, fmt.Errorf("lint config: %w", err)
+	}
+
+	if cmd.JSONOutput {
+		return report.OK(), writeLintJSON(report, w)
+	}
+	for _, finding := range report.Findings {
+		fmt.Fprintln(w, finding)
+	}
+	if len(report.Findings) > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, report.Summary())
+	return report.OK(), nil
+}
+
+// loadWorkspaceCollections loads the collections refs name or point to, all of the
+// workspace when refs is empty
+func loadWorkspaceCollections(workspace string, refs []string) ([]*api.CollectionFile, error) {
+	collectionsDir := filepath.Join(workspace, ".lazycurl", "collections")
+	if len(refs) == 0 {
+		all, err := api.LoadAllCollections(collectionsDir)
+		if err != nil {
+			return nil, fmt.Errorf("collections: %w", err)
+		}
+		return all, nil
+	}
+	var collections []*api.CollectionFile
+	for _, ref := range refs {
+		col, err := findRunFile(ref, collectionsDir, api.LoadCollection, api.LoadAllCollections,
+			func(c *api.CollectionFile) (string, string) { return c.Name, c.FilePath })
+		if err != nil {
+			return nil, fmt.Errorf("collection: %w", err)
+		}
+		collections = append(collections, col)
+	}
+	return collections, nil
+}
+
+// writeLintJSON writes the report as a JSON object
+func writeLintJSON(report *api.LintReport, w io.Writer) error {
+	result := LintResult{
+		Collections: report.Collections,
+		Requests:    report.Requests,
+		Errors:      report.Count(api.LintError),
+		Warnings:    report.Count(api.LintWarning),
+		Findings:    []LintProblem{},
+	}
+	for _, finding := range report.Findings {
+		result.Findings = append(result.Findings, LintProblem{
+			Rule:       finding.Rule,
+			Severity:   finding.Severity.String(),
+			Collection: finding.Collection,
+			Request:    finding.Request,
+			RequestID:  finding.RequestID,
+			Message:    finding.Message,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
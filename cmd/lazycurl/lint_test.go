@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestParseLintArgs(t *testing.T) {
+	cmd, err := ParseLintArgs([]string{"shop", "--json", "orders"})
+	if err != nil {
+		t.Fatalf("ParseLintArgs() error = %v", err)
+	}
+	if !cmd.JSONOutput || strings.Join(cmd.Collections, ",") != "shop,orders" {
+		t.Errorf("got %+v", cmd)
+	}
+	if _, err := ParseLintArgs([]string{"--fix"}); err == nil {
+		t.Error("ParseLintArgs() should fail for an unknown option")
+	}
+}
+
+func TestRunLintCommand(t *testing.T) {
+	workspace := t.TempDir()
+	collectionsDir := filepath.Join(workspace, ".lazycurl", "collections")
+	save := func(col *api.CollectionFile, name string) {
+		if err := api.SaveCollection(col, filepath.Join(collectionsDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save(&api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "a", Name: "Health", Method: api.GET, URL: "http://localhost/health"},
+	}}, "shop.json")
+	save(&api.CollectionFile{Name: "Orders", Requests: []api.CollectionRequest{
+		{ID: "b", Name: "List", Method: api.GET, URL: "{{base_url}}/orders"},
+		{ID: "c", Name: "List", Method: api.GET, URL: "{{base_url}}/orders?page=2"},
+	}}, "orders.json")
+
+	var out bytes.Buffer
+	passed, err := RunLintCommand(&LintCommand{Workspace: workspace}, &out)
+	if err != nil {
+		t.Fatalf("RunLintCommand() error = %v", err)
+	}
+	if passed {
+		t.Error("RunLintCommand() passed with a duplicate name")
+	}
+	for _, want := range []string{
+		`error   Orders › List: another request of the folder is named "List" (unique-names)`,
+		"warning Shop › Health: URL does not start with {{base_url}} (base-url)",
+		"3 requests in 2 collections: 1 errors, 1 warnings",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// The workspace config sets the rules
+	config := "name: Test\nlint:\n  rules:\n    unique-names: warning\n"
+	if err := os.WriteFile(filepath.Join(workspace, ".lazycurl", "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	passed, err = RunLintCommand(&LintCommand{Workspace: workspace, Collections: []string{"orders"}, JSONOutput: true}, &out)
+	if err != nil || !passed {
+		t.Fatalf("RunLintCommand() = %v, %v", passed, err)
+	}
+	var result LintResult
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if result.Requests != 2 || result.Warnings != 1 || len(result.Findings) != 1 || result.Findings[0].RequestID != "c" {
+		t.Errorf("result = %+v", result)
+	}
+
+	if _, err := RunLintCommand(&LintCommand{Workspace: workspace, Collections: []string{"users"}}, &out); err == nil {
+		t.Error("RunLintCommand() should fail for an unknown collection")
+	}
+}
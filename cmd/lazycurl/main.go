@@ -3,9 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/kbrdn1/LazyCurl/internal/api"
 	"github.com/kbrdn1/LazyCurl/internal/config"
 	"github.com/kbrdn1/LazyCurl/internal/ui"
 )
@@ -44,11 +46,119 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Load global config
-	globalConfig, err := config.LoadGlobalConfig()
-	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
-		globalConfig = config.DefaultGlobalConfig()
+	// Handle export subcommand
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		cmd, err := ParseExportArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if err := RunExportCommand(cmd, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle test-scripts subcommand
+	if len(os.Args) > 1 && os.Args[1] == "test-scripts" {
+		cmd, err := ParseTestScriptsArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		passed, err := RunTestScriptsCommand(cmd, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle run subcommand
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		cmd, err := ParseRunArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		passed, err := RunRunCommand(cmd, os.Stdout)
+		api.RemoveSpooledBodies()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Run failed: %v\n", err)
+			os.Exit(1)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle lint subcommand
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		cmd, err := ParseLintArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		passed, err := RunLintCommand(cmd, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Lint failed: %v\n", err)
+			os.Exit(1)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle inventory subcommand
+	if len(os.Args) > 1 && os.Args[1] == "inventory" {
+		cmd, err := ParseInventoryArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if err := RunInventoryCommand(cmd, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Inventory failed: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle snapshot subcommand
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		cmd, err := ParseSnapshotArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if err := RunSnapshotCommand(cmd, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Snapshot failed: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle merge-collections subcommand (git merge driver)
+	if len(os.Args) > 1 && os.Args[1] == "merge-collections" {
+		cmd, err := ParseMergeCollectionsArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		clean, err := RunMergeCollectionsCommand(cmd, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Merge failed: %v\n", err)
+			os.Exit(1)
+		}
+		if !clean {
+			os.Exit(1)
+		}
+		os.Exit(0)
 	}
 
 	// Get workspace path
@@ -58,6 +168,40 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Handle setup subcommand (re-run the onboarding wizard)
+	if len(os.Args) > 1 && os.Args[1] == "setup" {
+		if _, err := RunSetup(workspacePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Setup failed: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Run the onboarding wizard on first run; skipping it saves the defaults so it is not shown again
+	if !config.GlobalConfigExists() && isInteractive() {
+		cfg, err := RunSetup(workspacePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Setup failed: %v\n", err)
+		} else if cfg == nil {
+			if err := config.DefaultGlobalConfig().Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
+			}
+		}
+	}
+
+	// Load global config
+	globalConfig, err := config.LoadGlobalConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		globalConfig = config.DefaultGlobalConfig()
+	} else if config.GlobalConfigExists() {
+		// Recent workspaces of :ws list; a broken config is not overwritten
+		globalConfig.TouchWorkspace(workspacePath, time.Now())
+		if err := globalConfig.Save(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+		}
+	}
+
 	// Load workspace config
 	workspaceConfig, err := config.LoadWorkspaceConfig(workspacePath)
 	if err != nil {
@@ -66,14 +210,19 @@ func main() {
 	}
 
 	// Initialize the Bubble Tea program
-	p := tea.NewProgram(
-		ui.NewModel(globalConfig, workspaceConfig, workspacePath),
+	options := []tea.ProgramOption{
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
-	)
+	}
+	if globalConfig.ProtectSecrets != "" {
+		options = append(options, tea.WithReportFocus()) // Hide secrets when the terminal loses focus
+	}
+	p := tea.NewProgram(ui.NewModel(globalConfig, workspaceConfig, workspacePath), options...)
 
-	// Run the program
-	if _, err := p.Run(); err != nil {
+	// Run the program, then delete the temporary files of large response bodies
+	_, err = p.Run()
+	api.RemoveSpooledBodies()
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
@@ -86,14 +235,55 @@ func printHelp() {
 Usage:
   lazycurl                         Start the TUI application
   lazycurl import <format> <file>  Import API specification
+  lazycurl export dotenv <env>     Export an environment as a .env file
+  lazycurl export http <collection>
+                                   Export a collection as a .http file
+  lazycurl export hoppscotch <collection>
+                                   Export a collection to Hoppscotch
+  lazycurl test-scripts [dir]      Run script unit tests (*_test.js)
+  lazycurl run <collection>        Run a collection's requests headlessly
+  lazycurl lint [collection...]    Check collections against the lint rules
+  lazycurl inventory [collection...]
+                                   Export an API inventory as CSV or JSON
+  lazycurl snapshot <panel>        Render a panel to plain text or SVG
+  lazycurl merge-collections <base> <ours> <theirs> [path]
+                                   Merge collection files (git merge driver)
+  lazycurl setup                   Run the setup wizard again
   lazycurl --version               Show version information
   lazycurl --help                  Show this help message
 
 Commands:
-  import    Import API specifications into collections
+  import        Import API specifications into collections; a directory, glob
+                pattern or several files import all their Postman collections
+                and environments at once. A .env file is imported into the
+                environment named after it (--name), created or updated
+  export        Write the active variables of an environment to stdout or a
+                file in .env format, secret values included, or the requests
+                of a collection in .http format (VS Code REST Client) or as
+                a Hoppscotch collections file
+  test-scripts  Run *_test.js files in .lazycurl/scripts against mocked
+                request/response objects (fixtures: <name>_test.json)
+  run           Send every request of a collection (or folder) in order with
+                its scripts; exits 1 if a request or assertion fails
+  lint          Check the requests of collections (all by default) for duplicate
+                names, URLs without {{base_url}}, hardcoded tokens and
+                mismatched Content-Type headers; exits 1 on errors
+  inventory     List the requests of collections (all by default) with their
+                endpoint, method, auth type, owner and last-tested date
+  snapshot      Render the tree, request or response panel as the TUI shows it,
+                for documentation and bug reports; secret values are masked
+  merge-collections
+                Three-way merge of collection files by request ID, writing the
+                result over <ours>; exits 1 if conflicts remain. --install sets
+                it up as the git merge driver of .lazycurl/collections
+  setup         Pick a theme and key bindings, import a Postman export and
+                create a sample workspace (runs automatically on first start)
 
 Import Formats:
   openapi   Import OpenAPI 3.x specification (JSON/YAML)
+  dotenv    Import a .env file (KEY=VALUE) into an environment
+  http      Import a .http/.rest file (VS Code REST Client) into a collection
+  hoppscotch Import Hoppscotch collections or environments (JSON export)
 
 Import Options:
   --name NAME      Override collection name
@@ -101,11 +291,60 @@ Import Options:
   --dry-run        Preview import without saving
   --json           Output results as JSON
 
+Export Options:
+  -r, --request REF  Only export this request of the collection (http)
+  -o, --output PATH  Write to a file instead of stdout
+
+Test Scripts Options:
+  --timeout DURATION  Per-file timeout (default 5s)
+  --json              Output results as JSON
+
+Run Options:
+  -e, --env NAME   Environment name or file
+  --folder PATH    Only run this folder (nested: "Users/Admin")
+  --job NAME       Run this async job of the collection instead
+  --prompt N=V     Value of the prompt variable {{?N}} (repeatable)
+
+Lint Options:
+  --json           Output results as JSON
+
+Inventory Options:
+  -o, --output PATH  Write to a file instead of stdout (.json implies --json)
+  --json             Output JSON instead of CSV
+
+Snapshot Options:
+  -r, --request REF  Request to select and load: ID, name or folder path
+                     ("Orders/Create order")
+  --tab NAME         Tab of the request or response panel
+  --send             Send the request first to show its response
+  -e, --env NAME     Environment name or file used by --send
+  --size WxH         Size in cells (default 100x30)
+  -o, --output PATH  Write to a file instead of stdout (.svg implies --svg)
+  --svg              Output an SVG image with the colors instead of plain text
+
 Examples:
   lazycurl import openapi api.yaml
   lazycurl import openapi api.json --name "My API"
   lazycurl import openapi spec.yaml --dry-run
   lazycurl import openapi spec.yaml --json
+  lazycurl import ./postman-exports
+  lazycurl import "exports/*.json" --dry-run
+  lazycurl import .env.staging --name staging
+  lazycurl export dotenv staging -o .env
+  lazycurl import requests.http
+  lazycurl export http "My API" -r "Users/Get user" -o user.http
+  lazycurl import hoppscotch hoppscotch-collections.json
+  lazycurl export hoppscotch "My API" -o hoppscotch.json
+  lazycurl test-scripts
+  lazycurl test-scripts ./scripts --json
+  lazycurl run "My API" -e staging
+  lazycurl run my-api --folder Users
+  lazycurl run reports --job Export
+  lazycurl lint
+  lazycurl lint "My API" --json
+  lazycurl inventory -o inventory.csv
+  lazycurl snapshot response -r "Get user" --send -e dev -o user.svg
+  lazycurl merge-collections --install
 
 Keyboard Shortcuts (TUI):
   Ctrl+O    Import OpenAPI specification
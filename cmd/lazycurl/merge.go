@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/vcs"
+)
+
+// MergeCollectionsCommand handles the merge-collections subcommand, a git merge driver
+// for collection files
+type MergeCollectionsCommand struct {
+	Base    string // Common ancestor (%O)
+	Ours    string // Current version (%A), replaced by the merge result
+	Theirs  string // Other branch's version (%B)
+	Path    string // Path of the file in the repository (%P), for messages
+	Install bool   // Set up the driver in the workspace's repository instead
+}
+
+// ParseMergeCollectionsArgs parses merge-collections command arguments
+func ParseMergeCollectionsArgs(args []string) (*MergeCollectionsCommand, error) {
+	cmd := &MergeCollectionsCommand{}
+	var files []string
+	for _, arg := range args {
+		switch {
+		case arg == "--install":
+			cmd.Install = true
+		case len(arg) > 1 && arg[0] == '-':
+			return nil, fmt.Errorf("unknown option: %s", arg)
+		default:
+			files = append(files, arg)
+		}
+	}
+
+	if cmd.Install {
+		if len(files) > 0 {
+			return nil, fmt.Errorf("usage: lazycurl merge-collections --install")
+		}
+		return cmd, nil
+	}
+	if len(files) < 3 || len(files) > 4 {
+		return nil, fmt.Errorf("usage: lazycurl merge-collections <base> <ours> <theirs> [path]")
+	}
+	cmd.Base, cmd.Ours, cmd.Theirs = files[0], files[1], files[2]
+	cmd.Path = cmd.Ours
+	if len(files) == 4 {
+		cmd.Path = files[3]
+	}
+	return cmd, nil
+}
+
+// RunMergeCollectionsCommand merges the collection files and writes the result over
+// Ours, reporting conflicts to w. Files that are not collections are merged as text.
+// Returns false when conflicts remain, for git to mark the file as conflicted.
+func RunMergeCollectionsCommand(cmd *MergeCollectionsCommand, w io.Writer) (bool, error) {
+	if cmd.Install {
+		workspacePath, err := config.GetWorkspacePath()
+		if err != nil {
+			return false, fmt.Errorf("failed to get workspace path: %w", err)
+		}
+		if err := vcs.InstallMergeDriver(workspacePath); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(w, "Collection files now merge with %q\n", vcs.MergeDriverCommand)
+		return true, nil
+	}
+
+	var versions [3][]byte
+	for i, path := range []string{cmd.Base, cmd.Ours, cmd.Theirs} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		versions[i] = data
+	}
+
+	merged, conflicts, err := api.MergeCollections(versions[0], versions[1], versions[2])
+	if err != nil {
+		fmt.Fprintf(w, "%s: %v; merging as text\n", cmd.Path, err)
+		count, err := vcs.MergeFile(cmd.Ours, cmd.Base, cmd.Theirs)
+		if err != nil {
+			return false, err
+		}
+		return count == 0, nil
+	}
+
+	if err := os.WriteFile(cmd.Ours, merged, 0644); err != nil {
+		return false, err
+	}
+	if len(conflicts) == 0 {
+		return true, nil
+	}
+	fmt.Fprintf(w, "Conflicts in %s:\n", cmd.Path)
+	for _, conflict := range conflicts {
+		fmt.Fprintf(w, "  %s\n", conflict)
+	}
+	return false, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestParseMergeCollectionsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantPath string
+		wantErr  bool
+	}{
+		{name: "driver arguments", args: []string{".merge_file_a", ".merge_file_b", ".merge_file_c", ".lazycurl/collections/shop.json"}, wantPath: ".lazycurl/collections/shop.json"},
+		{name: "without path", args: []string{"base.json", "ours.json", "theirs.json"}, wantPath: "ours.json"},
+		{name: "install", args: []string{"--install"}},
+		{name: "missing files", args: []string{"base.json", "ours.json"}, wantErr: true},
+		{name: "install with files", args: []string{"--install", "base.json"}, wantErr: true},
+		{name: "unknown option", args: []string{"--ours", "a", "b", "c"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := ParseMergeCollectionsArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cmd.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", cmd.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestRunMergeCollectionsCommand(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	cmd := &MergeCollectionsCommand{
+		Base:   write("base", `{"name": "Shop", "requests": [{"id": "a", "name": "A", "method": "GET", "url": "/a"}]}`),
+		Ours:   write("ours", `{"name": "Shop", "requests": [{"id": "a", "name": "A", "method": "GET", "url": "/a"}, {"id": "b", "name": "B", "method": "GET", "url": "/b"}]}`),
+		Theirs: write("theirs", `{"name": "Shop", "requests": [{"id": "a", "name": "A", "method": "POST", "url": "/a"}]}`),
+		Path:   "shop.json",
+	}
+
+	var out bytes.Buffer
+	clean, err := RunMergeCollectionsCommand(cmd, &out)
+	if err != nil || !clean {
+		t.Fatalf("RunMergeCollectionsCommand() = %v, %v\n%s", clean, err, out.String())
+	}
+	merged, err := api.LoadCollection(cmd.Ours)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Requests) != 2 || merged.Requests[0].Method != api.POST {
+		t.Errorf("merged requests = %+v", merged.Requests)
+	}
+
+	// A conflict is reported, and the merge is not clean
+	write("theirs", `{"name": "Shop", "requests": [{"id": "a", "name": "A", "method": "GET", "url": "/theirs"}, {"id": "b", "name": "B", "method": "GET", "url": "/c"}]}`)
+	out.Reset()
+	clean, err = RunMergeCollectionsCommand(cmd, &out)
+	if err != nil || clean {
+		t.Fatalf("RunMergeCollectionsCommand() = %v, %v, want conflicts", clean, err)
+	}
+	if !strings.Contains(out.String(), "Conflicts in shop.json:\n  requests[id=b].url: added on both sides") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
+	"github.com/kbrdn1/LazyCurl/internal/ui"
+)
+
+// RunCommand handles the run subcommand
+type RunCommand struct {
+	Collection  string            // Collection name or path to a collection file
+	Environment string            // Environment name or path to an environment file (optional)
+	Folder      []string          // Folder path inside the collection (optional)
+	Job         string            // Async job of the collection run instead of its requests (optional)
+	Prompts     map[string]string // Values of the prompt variables ({{?name}}), by name
+	Workspace   string            // Workspace holding .lazycurl/collections and .lazycurl/environments
+}
+
+const runUsage = "usage: lazycurl run <collection> [-e env] [--folder name | --job name] [--prompt name=value]"
+
+// ParseRunArgs parses run command arguments
+func ParseRunArgs(args []string) (*RunCommand, error) {
+	cmd := &RunCommand{}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-e", "--env":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", args[i])
+			}
+			i++
+			cmd.Environment = args[i]
+		case "--folder":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--folder requires a value")
+			}
+			i++
+			for _, name := range strings.Split(args[i], "/") {
+				if name = strings.TrimSpace(name); name != "" {
+					cmd.Folder = append(cmd.Folder, name)
+				}
+			}
+		case "--job":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--job requires a value")
+			}
+			i++
+			cmd.Job = args[i]
+		case "--prompt":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--prompt requires a value")
+			}
+			i++
+			name, value, ok := strings.Cut(args[i], "=")
+			name = strings.TrimPrefix(strings.TrimSpace(name), api.PromptVariablePrefix)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("--prompt expects name=value, got %q", args[i])
+			}
+			if cmd.Prompts == nil {
+				cmd.Prompts = make(map[string]string)
+			}
+			cmd.Prompts[name] = value
+		default:
+			if args[i] == "" || args[i][0] == '-' {
+				return nil, fmt.Errorf("unknown option: %s", args[i])
+			}
+			if cmd.Collection != "" {
+				return nil, fmt.Errorf(runUsage)
+			}
+			cmd.Collection = args[i]
+		}
+	}
+
+	if cmd.Collection == "" || (cmd.Job != "" && len(cmd.Folder) > 0) {
+		return nil, fmt.Errorf(runUsage)
+	}
+
+	workspacePath, err := config.GetWorkspacePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace path: %w", err)
+	}
+	cmd.Workspace = workspacePath
+
+	return cmd, nil
+}
+
+// useKeychain reads secret variables from the OS keychain when the workspace keeps them there
+func useKeychain(workspacePath string) error {
+	workspaceConfig, err := config.LoadWorkspaceConfig(workspacePath)
+	if err != nil || !workspaceConfig.Keychain {
+		return nil
+	}
+	store, err := secrets.Keychain()
+	if err != nil {
+		return fmt.Errorf("secret variables: %w", err)
+	}
+	api.SecretStore = store
+	return nil
+}
+
+// useProxy routes requests through the proxy of the workspace or global config, if any
+func useProxy(workspacePath string) error {
+	globalConfig, _ := config.LoadGlobalConfig()
+	workspaceConfig, _ := config.LoadWorkspaceConfig(workspacePath)
+	proxy := config.EffectiveProxy(globalConfig, workspaceConfig)
+	if proxy == nil {
+		return nil
+	}
+	p := &api.Proxy{URL: proxy.URL, NoProxy: proxy.NoProxy}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	api.DefaultProxy = p
+	return nil
+}
+
+// useTLS applies the CA files and client certificates of the workspace config, if any
+func useTLS(workspacePath string) error {
+	workspaceConfig, _ := config.LoadWorkspaceConfig(workspacePath)
+	if workspaceConfig == nil || workspaceConfig.TLS == nil {
+		return nil
+	}
+	t := ui.NewTLSConfig(workspacePath, workspaceConfig.TLS)
+	if err := t.Validate(); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	api.DefaultTLS = t
+	return nil
+}
+
+// RunRunCommand runs the requests of the collection (or folder) and writes a report to w.
+// Returns false if any request failed or any assertion did not pass.
+func RunRunCommand(cmd *RunCommand, w io.Writer) (bool, error) {
+	collectionsDir := filepath.Join(cmd.Workspace, ".lazycurl", "collections")
+	if err := useProxy(cmd.Workspace); err != nil {
+		return false, err
+	}
+	if err := useTLS(cmd.Workspace); err != nil {
+		return false, err
+	}
+	col, err := findRunFile(cmd.Collection, collectionsDir, api.LoadCollection, api.LoadAllCollections,
+		func(c *api.CollectionFile) (string, string) { return c.Name, c.FilePath })
+	if err != nil {
+		return false, fmt.Errorf("collection: %w", err)
+	}
+	// Linked requests may link to requests of the other collections of the workspace
+	if all, err := api.LoadAllCollections(collectionsDir); err == nil {
+		api.ResolveLinks(append([]*api.CollectionFile{col}, all...))
+	}
+
+	r, err := newRunner(cmd.Workspace, col, cmd.Environment)
+	if err != nil {
+		return false, err
+	}
+
+	var job *runner.Job
+	var items []runner.Item
+	if cmd.Job != "" {
+		if job, err = runner.NewJob(col, cmd.Job); err != nil {
+			return false, err
+		}
+		items = job.Items()
+	} else if items, err = runner.Collect(col, cmd.Folder); err != nil {
+		return false, err
+	}
+	if len(items) == 0 {
+		fmt.Fprintf(w, "No requests in %s\n", col.Name)
+		return true, nil
+	}
+
+	// Runs are not interactive: every prompt variable needs a --prompt value
+	var missing []string
+	for _, name := range runner.PromptVariables(items) {
+		if _, ok := cmd.Prompts[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return false, fmt.Errorf("prompt variables need a value (--prompt name=value): %s", strings.Join(missing, ", "))
+	}
+
+	r.Prompts = cmd.Prompts
+	onResult := func(result runner.RequestResult) {
+		writeRunResult(w, result)
+	}
+	var results []runner.RequestResult
+	if job != nil {
+		results = r.RunJob(job, onResult)
+	} else {
+		results = r.Run(items, onResult)
+	}
+
+	summary := runner.Summarize(results)
+	fmt.Fprintf(w, "\n%d requests, %d passed, %d failed (%s)\n",
+		summary.Total, summary.Passed, summary.Failed, summary.Duration.Round(time.Millisecond))
+	if job != nil {
+		if job.Err != nil {
+			fmt.Fprintf(w, "Job %s failed: %v\n", job.Spec.Name, job.Err)
+			return false, nil
+		}
+		fmt.Fprintf(w, "Job %s %s after %d polls\n", job.Spec.Name, job.Status, job.Polls())
+	}
+	return summary.Failed == 0, nil
+}
+
+// newRunner returns a runner sending the requests of col with the environment envRef names
+// or points to (none when empty), the globals and the shared scripts of the workspace
+func newRunner(workspace string, col *api.CollectionFile, envRef string) (*runner.Runner, error) {
+	var env *api.EnvironmentFile
+	if envRef != "" {
+		if err := useKeychain(workspace); err != nil {
+			return nil, err
+		}
+		envsDir := filepath.Join(workspace, ".lazycurl", "environments")
+		var err error
+		env, err = findRunFile(envRef, envsDir, api.LoadEnvironment, api.LoadAllEnvironments,
+			func(e *api.EnvironmentFile) (string, string) { return e.Name, e.FilePath })
+		if err != nil {
+			return nil, fmt.Errorf("environment: %w", err)
+		}
+	}
+
+	globals, err := api.LoadGlobals(workspace)
+	if err != nil {
+		return nil, err
+	}
+	if api.DefaultScriptStore, err = api.LoadScriptStore(workspace); err != nil {
+		return nil, err
+	}
+	api.DefaultScriptModulesDir = api.ScriptModulesDir(workspace)
+
+	r := runner.New(ui.BuildStoredHTTPRequest, api.NewScriptExecutor(), env)
+	r.Scopes = api.VariableScopes{Collection: col.Variables, Globals: globals.Variables}
+	r.Collection = col
+	return r, nil
+}
+
+// writeRunResult writes one line per request, followed by its error and failed assertions
+func writeRunResult(w io.Writer, result runner.RequestResult) {
+	label := "PASS"
+	if !result.Passed() {
+		label = "FAIL"
+	}
+	status := "ERR"
+	if result.StatusCode > 0 {
+		status = fmt.Sprintf("%d", result.StatusCode)
+	}
+	line := fmt.Sprintf("%s  %-3s %8s  %s %s", label, status, result.Duration.Round(time.Millisecond),
+		result.Item.Request.Method, result.Item.Name())
+	if len(result.Assertions) > 0 {
+		line += fmt.Sprintf(" (%d/%d assertions)", result.PassedAssertions(), len(result.Assertions))
+	}
+	fmt.Fprintln(w, line)
+
+	if result.Err != nil {
+		fmt.Fprintf(w, "      error: %v\n", result.Err)
+	}
+	for _, assertion := range result.Assertions {
+		if !assertion.Passed {
+			fmt.Fprintf(w, "      ✗ %s: %s\n", assertion.Name, assertion.Message)
+		}
+	}
+}
+
+// findRunFile loads ref as a file path if it exists, otherwise looks it up in dir
+// by name or file name (without .json), ignoring case
+func findRunFile[T any](ref, dir string, load func(string) (T, error), loadAll func(string) ([]T, error), names func(T) (string, string)) (T, error) {
+	var zero T
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		return load(ref)
+	}
+
+	all, err := loadAll(dir)
+	if err != nil {
+		return zero, err
+	}
+	for _, item := range all {
+		name, path := names(item)
+		base := strings.TrimSuffix(filepath.Base(path), ".json")
+		if strings.EqualFold(name, ref) || strings.EqualFold(base, ref) {
+			return item, nil
+		}
+	}
+	return zero, fmt.Errorf("%q not found in %s", ref, dir)
+}
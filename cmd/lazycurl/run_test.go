@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestParseRunArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCol    string
+		wantEnv    string
+		wantFolder []string
+		wantPrompt map[string]string
+		wantErr    bool
+	}{
+		{name: "collection only", args: []string{"shop"}, wantCol: "shop"},
+		{name: "env short flag", args: []string{"shop", "-e", "staging"}, wantCol: "shop", wantEnv: "staging"},
+		{name: "env long flag and folder", args: []string{"--env", "dev", "shop", "--folder", "Users"}, wantCol: "shop", wantEnv: "dev", wantFolder: []string{"Users"}},
+		{name: "nested folder", args: []string{"shop", "--folder", "Users / Admin"}, wantCol: "shop", wantFolder: []string{"Users", "Admin"}},
+		{name: "prompt values", args: []string{"shop", "--prompt", "ticket_id=42", "--prompt", "?note=a=b"}, wantCol: "shop", wantPrompt: map[string]string{"ticket_id": "42", "note": "a=b"}},
+		{name: "missing collection", args: []string{"-e", "dev"}, wantErr: true},
+		{name: "missing env value", args: []string{"shop", "-e"}, wantErr: true},
+		{name: "missing folder value", args: []string{"shop", "--folder"}, wantErr: true},
+		{name: "missing job value", args: []string{"shop", "--job"}, wantErr: true},
+		{name: "job and folder", args: []string{"shop", "--job", "Export", "--folder", "Users"}, wantErr: true},
+		{name: "unknown option", args: []string{"shop", "--bail"}, wantErr: true},
+		{name: "prompt without value", args: []string{"shop", "--prompt", "ticket_id"}, wantErr: true},
+		{name: "two collections", args: []string{"a", "b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := ParseRunArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cmd.Collection != tt.wantCol || cmd.Environment != tt.wantEnv || !reflect.DeepEqual(cmd.Folder, tt.wantFolder) ||
+				!reflect.DeepEqual(cmd.Prompts, tt.wantPrompt) {
+				t.Errorf("got %+v", cmd)
+			}
+		})
+	}
+}
+
+func TestRunRunCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			fmt.Fprint(w, "ok")
+		case "/users":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	workspace := t.TempDir()
+	col := &api.CollectionFile{
+		Name: "Shop",
+		Folders: []api.Folder{{
+			Name: "Users",
+			Requests: []api.CollectionRequest{{
+				ID: "users", Name: "List", Method: api.GET, URL: "{{base_url}}/users",
+				Scripts: &api.ScriptConfig{PostRequest: `lc.test("status is 200", function() { lc.expect(lc.response.status).toBe(200); });`},
+			}},
+		}},
+		Requests: []api.CollectionRequest{{
+			ID: "health", Name: "Health", Method: api.GET, URL: "{{base_url}}/health",
+			Scripts: &api.ScriptConfig{PostRequest: `lc.test("status is 200", function() { lc.expect(lc.response.status).toBe(200); });`},
+		}},
+	}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+	env := &api.EnvironmentFile{
+		Name:      "Local",
+		Variables: map[string]*api.EnvironmentVariable{"base_url": {Value: server.URL, Active: true}},
+	}
+	if err := api.SaveEnvironment(env, filepath.Join(workspace, ".lazycurl", "environments", "local.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		cmd        RunCommand
+		wantPassed bool
+		wantOut    []string
+		wantErr    bool
+	}{
+		{
+			name:    "whole collection fails on folder assertion",
+			cmd:     RunCommand{Collection: "shop", Environment: "local"},
+			wantOut: []string{"FAIL  500", "Users / List (0/1 assertions)", "✗ status is 200", "PASS  200", "Health (1/1 assertions)", "2 requests, 1 passed, 1 failed"},
+		},
+		{
+			name:    "collection and environment by name",
+			cmd:     RunCommand{Collection: "Shop", Environment: "Local"},
+			wantOut: []string{"2 requests"},
+		},
+		{
+			name:    "folder only",
+			cmd:     RunCommand{Collection: "shop", Environment: "local", Folder: []string{"Users"}},
+			wantOut: []string{"1 requests, 0 passed, 1 failed"},
+		},
+		{
+			name:    "unresolved variables without environment",
+			cmd:     RunCommand{Collection: "shop"},
+			wantOut: []string{"FAIL  ERR"},
+		},
+		{name: "unknown collection", cmd: RunCommand{Collection: "orders"}, wantErr: true},
+		{name: "unknown environment", cmd: RunCommand{Collection: "shop", Environment: "prod"}, wantErr: true},
+		{name: "unknown folder", cmd: RunCommand{Collection: "shop", Folder: []string{"Orders"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.Workspace = workspace
+			var out bytes.Buffer
+			passed, err := RunRunCommand(&tt.cmd, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v\n%s", passed, tt.wantPassed, out.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunRunCommand_AllPassed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "ping.json")
+	col := &api.CollectionFile{
+		Name:     "Ping",
+		Requests: []api.CollectionRequest{{ID: "ping", Name: "Ping", Method: api.GET, URL: server.URL}},
+	}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// A collection file path works without a workspace
+	var out bytes.Buffer
+	passed, err := RunRunCommand(&RunCommand{Collection: path, Workspace: t.TempDir()}, &out)
+	if err != nil || !passed {
+		t.Fatalf("RunRunCommand() = %v, %v\n%s", passed, err, out.String())
+	}
+	if !strings.Contains(out.String(), "1 requests, 1 passed, 0 failed") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestRunRunCommand_Job(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/exports":
+			fmt.Fprint(w, `{"id":"j1"}`)
+		case "/exports/j1":
+			fmt.Fprint(w, `{"status":"done"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "reports.json")
+	col := &api.CollectionFile{
+		Name: "Reports",
+		Requests: []api.CollectionRequest{
+			{
+				ID: "submit", Name: "Start export", Method: api.POST, URL: server.URL + "/exports",
+				Extract: []api.ExtractRule{{Variable: "job_id", Type: api.ExtractJSONPath, Expression: "$.id"}},
+			},
+			{ID: "poll", Name: "Export status", Method: api.GET, URL: server.URL + "/exports/{{job_id}}"},
+		},
+		Jobs: []api.AsyncJob{{Name: "Export", Submit: "submit", Poll: "poll", Until: "$.status", Equals: "done", Interval: "10ms"}},
+	}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	passed, err := RunRunCommand(&RunCommand{Collection: path, Job: "Export", Workspace: t.TempDir()}, &out)
+	if err != nil || !passed {
+		t.Fatalf("RunRunCommand() = %v, %v\n%s", passed, err, out.String())
+	}
+	for _, want := range []string{"POST Start export", "GET Export status #1", "2 requests, 2 passed", "Job Export done after 1 polls"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
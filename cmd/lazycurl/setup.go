@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui"
+)
+
+// RunSetup runs the onboarding wizard and applies the choices.
+// It returns the resulting global config, or nil if the wizard was skipped.
+func RunSetup(workspacePath string) (*config.GlobalConfig, error) {
+	final, err := tea.NewProgram(ui.NewOnboardingModel(workspacePath), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := final.(ui.OnboardingModel).Result()
+	if !ok {
+		return nil, nil
+	}
+	return ui.ApplyOnboarding(result, workspacePath)
+}
+
+// isInteractive reports whether stdin and stdout are terminals (the wizard needs both)
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/internal/ui"
+)
+
+// Default snapshot size, in cells
+const (
+	defaultSnapshotWidth  = 100
+	defaultSnapshotHeight = 30
+)
+
+// snapshotPanels are the panels the snapshot subcommand renders, by name
+var snapshotPanels = map[string]ui.PanelType{
+	"tree":     ui.CollectionsPanel,
+	"request":  ui.RequestPanel,
+	"response": ui.ResponsePanel,
+}
+
+// SnapshotCommand handles the snapshot subcommand
+type SnapshotCommand struct {
+	Panel       string // tree, request or response
+	Request     string // Request ID, name or path ("Orders/Create order"), selected and loaded (optional)
+	Tab         string // Tab of the request or response panel (optional)
+	Send        bool   // Send the request first, to show its response
+	Environment string // Environment name or file used to send the request (optional)
+	Width       int
+	Height      int
+	Output      string // File to write; stdout when empty
+	SVG         bool   // Write an SVG image instead of plain text
+	Workspace   string // Workspace holding .lazycurl/collections and .lazycurl/environments
+}
+
+const snapshotUsage = "usage: lazycurl snapshot <tree|request|response> [-r request] [--tab name] [--send [-e env]] [--size WxH] [-o file] [--svg]"
+
+// ParseSnapshotArgs parses snapshot command arguments
+func ParseSnapshotArgs(args []string) (*SnapshotCommand, error) {
+	cmd := &SnapshotCommand{Width: defaultSnapshotWidth, Height: defaultSnapshotHeight}
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "--send":
+			cmd.Send = true
+		case "--svg":
+			cmd.SVG = true
+		case "-r", "--request", "--tab", "-e", "--env", "--size", "-o", "--output":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			switch arg {
+			case "-r", "--request":
+				cmd.Request = args[i]
+			case "--tab":
+				cmd.Tab = args[i]
+			case "-e", "--env":
+				cmd.Environment = args[i]
+			case "-o", "--output":
+				cmd.Output = args[i]
+			case "--size":
+				width, height, ok := strings.Cut(args[i], "x")
+				w, errW := strconv.Atoi(width)
+				h, errH := strconv.Atoi(height)
+				if !ok || errW != nil || errH != nil {
+					return nil, fmt.Errorf("--size expects WIDTHxHEIGHT, got %q", args[i])
+				}
+				cmd.Width, cmd.Height = w, h
+			}
+		default:
+			if arg == "" || arg[0] == '-' {
+				return nil, fmt.Errorf("unknown option: %s", arg)
+			}
+			if cmd.Panel != "" {
+				return nil, fmt.Errorf(snapshotUsage)
+			}
+			cmd.Panel = strings.ToLower(arg)
+		}
+	}
+
+	if _, ok := snapshotPanels[cmd.Panel]; !ok {
+		return nil, fmt.Errorf(snapshotUsage)
+	}
+	if cmd.Send && cmd.Request == "" {
+		return nil, fmt.Errorf("--send needs the request to send (-r)")
+	}
+	// A .svg output file implies SVG
+	if strings.EqualFold(filepath.Ext(cmd.Output), ".svg") {
+		cmd.SVG = true
+	}
+
+	workspacePath, err := config.GetWorkspacePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace path: %w", err)
+	}
+	cmd.Workspace = workspacePath
+	return cmd, nil
+}
+
+// RunSnapshotCommand renders the panel as the TUI shows it in fullscreen and writes it
+// to the output file, or to w, as plain text or SVG
+func RunSnapshotCommand(cmd *SnapshotCommand, w io.Writer) error {
+	opts := ui.SnapshotOptions{
+		Panel:  snapshotPanels[cmd.Panel],
+		Tab:    cmd.Tab,
+		Width:  cmd.Width,
+		Height: cmd.Height,
+	}
+
+	if cmd.Request != "" {
+		collections, err := loadWorkspaceCollections(cmd.Workspace, nil)
+		if err != nil {
+			return err
+		}
+		col, item, err := findSnapshotRequest(collections, cmd.Request)
+		if err != nil {
+			return err
+		}
+		opts.RequestID = item.Request.ID
+
+		if cmd.Send {
+			result, err := sendSnapshotRequest(cmd, collections, col, item)
+			if err != nil {
+				return err
+			}
+			defer api.RemoveSpooledBodies()
+			opts.Response, opts.Err = result.Response, result.Err
+		}
+	}
+
+	globalConfig, err := config.LoadGlobalConfig()
+	if err != nil {
+		globalConfig = config.DefaultGlobalConfig()
+	}
+	workspaceConfig, err := config.LoadWorkspaceConfig(cmd.Workspace)
+	if err != nil {
+		workspaceConfig = config.DefaultWorkspaceConfig()
+	}
+	// Render with every color; the plain text output drops them
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	snapshot, err := ui.NewModel(globalConfig, workspaceConfig, cmd.Workspace).Snapshot(opts)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output != "" {
+		file, err := os.Create(cmd.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if cmd.SVG {
+		return ui.WriteSnapshotSVG(w, snapshot)
+	}
+	_, err = fmt.Fprintln(w, ui.PlainSnapshot(snapshot))
+	return err
+}
+
+// sendSnapshotRequest sends the request of item with its scripts, as the run subcommand
+// does. The result holds the response or the failure to show.
+func sendSnapshotRequest(cmd *SnapshotCommand, collections []*api.CollectionFile, col *api.CollectionFile, item runner.Item) (runner.RequestResult, error) {
+	if err := useProxy(cmd.Workspace); err != nil {
+		return runner.RequestResult{}, err
+	}
+	if err := useTLS(cmd.Workspace); err != nil {
+		return runner.RequestResult{}, err
+	}
+	api.ResolveLinks(collections)
+	if names := runner.PromptVariables([]runner.Item{item}); len(names) > 0 {
+		return runner.RequestResult{}, fmt.Errorf("prompt variables cannot be asked for in a snapshot: %s", strings.Join(names, ", "))
+	}
+
+	r, err := newRunner(cmd.Workspace, col, cmd.Environment)
+	if err != nil {
+		return runner.RequestResult{}, err
+	}
+	return r.RunRequest(item), nil
+}
+
+// findSnapshotRequest finds the request ref identifies by ID, name, or path from its
+// collection or folders ("Shop/Orders/Create order"), ignoring case
+func findSnapshotRequest(collections []*api.CollectionFile, ref string) (*api.CollectionFile, runner.Item, error) {
+	type match struct {
+		col  *api.CollectionFile
+		item runner.Item
+	}
+	var matches []match
+	for _, col := range collections {
+		for _, item := range collectionItems(col.Requests, col.Folders, nil) {
+			if item.Request.ID == ref {
+				return col, item, nil
+			}
+			path := strings.Join(append(append([]string{}, item.Path...), item.Request.Name), "/")
+			if strings.EqualFold(item.Request.Name, ref) || strings.EqualFold(path, ref) || strings.EqualFold(col.Name+"/"+path, ref) {
+				matches = append(matches, match{col, item})
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, runner.Item{}, fmt.Errorf("request %q not found", ref)
+	case 1:
+		return matches[0].col, matches[0].item, nil
+	}
+	return nil, runner.Item{}, fmt.Errorf("%d requests are named %q, use the ID or the folder path", len(matches), ref)
+}
+
+// collectionItems returns the requests of a collection or folder and of its subfolders,
+// with the folder path from the collection root
+func collectionItems(requests []api.CollectionRequest, folders []api.Folder, path []string) []runner.Item {
+	var items []runner.Item
+	for _, req := range requests {
+		items = append(items, runner.Item{Path: path, Request: req})
+	}
+	for _, folder := range folders {
+		items = append(items, collectionItems(folder.Requests, folder.Folders, append(append([]string{}, path...), folder.Name))...)
+	}
+	return items
+}
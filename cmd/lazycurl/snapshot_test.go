@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestParseSnapshotArgs(t *testing.T) {
+	cmd, err := ParseSnapshotArgs([]string{"Response", "-r", "Orders/Create order", "--send", "-e", "dev", "--size", "80x20", "-o", "order.svg"})
+	if err != nil {
+		t.Fatalf("ParseSnapshotArgs() error = %v", err)
+	}
+	if cmd.Panel != "response" || cmd.Request != "Orders/Create order" || !cmd.Send || cmd.Environment != "dev" ||
+		cmd.Width != 80 || cmd.Height != 20 || !cmd.SVG {
+		t.Errorf("got %+v", cmd)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"console"},
+		{"tree", "request"},
+		{"request", "--size", "80"},
+		{"response", "--send"},
+		{"tree", "--png"},
+	} {
+		if _, err := ParseSnapshotArgs(args); err == nil {
+			t.Errorf("ParseSnapshotArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestRunSnapshotCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "a", Name: "Health", Method: api.GET, URL: "{{base_url}}/health"},
+	}, Folders: []api.Folder{{Name: "Orders", Requests: []api.CollectionRequest{
+		{ID: "b", Name: "Create order", Method: api.POST, URL: "{{base_url}}/orders"},
+		{ID: "c", Name: "Health", Method: api.GET, URL: "{{base_url}}/orders/health"},
+	}}}}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	cmd := &SnapshotCommand{Panel: "request", Request: "shop/orders/create order", Width: 70, Height: 12, Workspace: workspace}
+	if err := RunSnapshotCommand(cmd, &out); err != nil {
+		t.Fatalf("RunSnapshotCommand() error = %v", err)
+	}
+	if text := out.String(); !strings.Contains(text, "{{base_url}}/orders") || strings.Contains(text, "\x1b") {
+		t.Errorf("plain snapshot should show the URL without styles:\n%s", text)
+	}
+
+	cmd.Request = "Health"
+	if err := RunSnapshotCommand(cmd, &out); err == nil || !strings.Contains(err.Error(), "2 requests") {
+		t.Errorf("an ambiguous name should fail, got %v", err)
+	}
+
+	cmd.Request = "c"
+	cmd.Output = filepath.Join(t.TempDir(), "health.svg")
+	cmd.SVG = true
+	if err := RunSnapshotCommand(cmd, &out); err != nil {
+		t.Fatalf("RunSnapshotCommand() error = %v", err)
+	}
+	if data, err := os.ReadFile(cmd.Output); err != nil || !bytes.HasPrefix(data, []byte("<svg")) {
+		t.Errorf("output should be an SVG image, got %.40q (%v)", data, err)
+	}
+}
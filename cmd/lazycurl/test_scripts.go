@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+// TestScriptsCommand handles the test-scripts subcommand
+type TestScriptsCommand struct {
+	Dir        string        // Scripts directory (default: .lazycurl/scripts in the workspace)
+	Timeout    time.Duration // Per-file timeout
+	JSONOutput bool          // Output as JSON
+}
+
+// TestScriptsResult represents the outcome of one test file in JSON output
+type TestScriptsResult struct {
+	File       string                `json:"file"`
+	Passed     bool                  `json:"passed"`
+	Assertions []api.AssertionResult `json:"assertions"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// ParseTestScriptsArgs parses test-scripts command arguments
+func ParseTestScriptsArgs(args []string) (*TestScriptsCommand, error) {
+	cmd := &TestScriptsCommand{Timeout: config.DefaultScriptTimeout}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--timeout":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			timeout, err := time.ParseDuration(args[i])
+			if err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("invalid timeout %q", args[i])
+			}
+			cmd.Timeout = timeout
+		case "--json":
+			cmd.JSONOutput = true
+		default:
+			if args[i][0] == '-' {
+				return nil, fmt.Errorf("unknown option: %s", args[i])
+			}
+			if cmd.Dir != "" {
+				return nil, fmt.Errorf("usage: lazycurl test-scripts [dir] [--timeout DURATION] [--json]")
+			}
+			cmd.Dir = args[i]
+		}
+	}
+
+	if cmd.Dir == "" {
+		workspacePath, err := config.GetWorkspacePath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace path: %w", err)
+		}
+		cmd.Dir = filepath.Join(workspacePath, ".lazycurl", "scripts")
+	}
+
+	return cmd, nil
+}
+
+// RunTestScriptsCommand runs the script tests and writes a report to w.
+// Returns false if any test file failed.
+func RunTestScriptsCommand(cmd *TestScriptsCommand, w io.Writer) (bool, error) {
+	results, err := api.RunScriptTests(cmd.Dir, cmd.Timeout)
+	if err != nil {
+		return false, fmt.Errorf("failed to read scripts directory: %w", err)
+	}
+
+	allPassed := true
+	for _, result := range results {
+		if !result.Passed() {
+			allPassed = false
+		}
+	}
+
+	if cmd.JSONOutput {
+		return allPassed, writeTestScriptsJSON(results, w)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(w, "No *%s files in %s\n", api.ScriptTestSuffix, cmd.Dir)
+		return true, nil
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Passed() {
+			fmt.Fprintf(w, "PASS  %s (%d assertions, %s)\n", result.File, len(result.Result.Assertions), result.Result.Duration.Round(time.Microsecond))
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(w, "FAIL  %s\n", result.File)
+		if result.Err != nil {
+			fmt.Fprintf(w, "      error: %v\n", result.Err)
+			continue
+		}
+		for _, assertion := range result.Result.Assertions {
+			if !assertion.Passed {
+				fmt.Fprintf(w, "      ✗ %s: %s\n", assertion.Name, assertion.Message)
+			}
+		}
+		if scriptErr := result.Result.Error; scriptErr != nil {
+			if scriptErr.Line > 0 {
+				fmt.Fprintf(w, "      error: %s (line %d)\n", scriptErr.Message, scriptErr.Line)
+			} else {
+				fmt.Fprintf(w, "      error: %s\n", scriptErr.Message)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d files, %d passed, %d failed\n", len(results), len(results)-failed, failed)
+	return allPassed, nil
+}
+
+// writeTestScriptsJSON writes the results as a JSON array
+func writeTestScriptsJSON(results []api.ScriptTestResult, w io.Writer) error {
+	output := make([]TestScriptsResult, 0, len(results))
+	for _, result := range results {
+		entry := TestScriptsResult{
+			File:       result.File,
+			Passed:     result.Passed(),
+			Assertions: []api.AssertionResult{},
+		}
+		switch {
+		case result.Err != nil:
+			entry.Error = result.Err.Error()
+		case result.Result != nil:
+			entry.Assertions = result.Result.Assertions
+			if result.Result.Error != nil {
+				entry.Error = result.Result.Error.Message
+			}
+		}
+		output = append(output, entry)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
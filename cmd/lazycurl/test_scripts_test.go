@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTestScriptsArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantDir     string
+		wantTimeout time.Duration
+		wantJSON    bool
+		wantErr     bool
+	}{
+		{name: "explicit dir", args: []string{"scripts"}, wantDir: "scripts", wantTimeout: 5 * time.Second},
+		{name: "json and timeout", args: []string{"scripts", "--json", "--timeout", "2s"}, wantDir: "scripts", wantTimeout: 2 * time.Second, wantJSON: true},
+		{name: "invalid timeout", args: []string{"--timeout", "soon"}, wantErr: true},
+		{name: "missing timeout value", args: []string{"--timeout"}, wantErr: true},
+		{name: "unknown option", args: []string{"--watch"}, wantErr: true},
+		{name: "two dirs", args: []string{"a", "b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := ParseTestScriptsArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cmd.Dir != tt.wantDir || cmd.Timeout != tt.wantTimeout || cmd.JSONOutput != tt.wantJSON {
+				t.Errorf("got %+v", cmd)
+			}
+		})
+	}
+}
+
+func TestParseTestScriptsArgs_DefaultDir(t *testing.T) {
+	cmd, err := ParseTestScriptsArgs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(cmd.Dir, filepath.Join(".lazycurl", "scripts")) {
+		t.Errorf("Dir = %q, want workspace .lazycurl/scripts", cmd.Dir)
+	}
+}
+
+func TestRunTestScriptsCommand(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"math.js":      `function add(a, b) { return a + b; }`,
+		"math_test.js": `lc.test("adds", function() { lc.expect(add(1, 2)).toBe(3); });`,
+		"bad_test.js":  `lc.test("subtracts", function() { lc.expect(add(1, 2)).toBe(0); });`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	passed, err := RunTestScriptsCommand(&TestScriptsCommand{Dir: dir, Timeout: time.Second}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passed {
+		t.Error("expected failure with a failing test file")
+	}
+	for _, want := range []string{"FAIL  bad_test.js", "✗ subtracts", "PASS  math_test.js (1 assertions", "2 files, 1 passed, 1 failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if _, err := RunTestScriptsCommand(&TestScriptsCommand{Dir: dir, Timeout: time.Second, JSONOutput: true}, &out); err != nil {
+		t.Fatal(err)
+	}
+	var results []TestScriptsResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+	}
+	if len(results) != 2 || results[0].Passed || !results[1].Passed {
+		t.Errorf("unexpected JSON results: %+v", results)
+	}
+}
+
+func TestRunTestScriptsCommand_NoTests(t *testing.T) {
+	var out bytes.Buffer
+	passed, err := RunTestScriptsCommand(&TestScriptsCommand{Dir: t.TempDir(), Timeout: time.Second}, &out)
+	if err != nil || !passed {
+		t.Fatalf("passed = %v, err = %v", passed, err)
+	}
+	if !strings.Contains(out.String(), "No *_test.js files") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Default waits of an async job
+const (
+	DefaultJobInterval    = time.Second
+	DefaultJobMaxInterval = 30 * time.Second
+	DefaultJobTimeout     = 5 * time.Minute
+)
+
+// AsyncJob is a submit, poll, fetch result workflow of a collection, for APIs that
+// answer long operations with a job to check on. The submit request starts the job,
+// the poll request is sent until the status it returns says the job is done, waiting
+// twice as long after each poll, then the result request fetches the outcome.
+// Requests pass values such as the job ID along with extraction rules or scripts.
+// Requests are referenced by ID or name.
+type AsyncJob struct {
+	Name        string   `json:"name"`
+	Submit      string   `json:"submit"`                 // Request starting the job
+	Poll        string   `json:"poll"`                   // Request returning the status of the job
+	Result      string   `json:"result,omitempty"`       // Request fetching the result; none stops once the job is done
+	Until       string   `json:"until"`                  // JSONPath of the status in the poll response
+	Equals      string   `json:"equals"`                 // Status of a completed job
+	FailsOn     []string `json:"fails_on,omitempty"`     // Statuses of a failed job, which stop the polling
+	Interval    string   `json:"interval,omitempty"`     // Wait before the first poll; DefaultJobInterval when empty
+	MaxInterval string   `json:"max_interval,omitempty"` // Longest wait between polls; DefaultJobMaxInterval when empty
+	Timeout     string   `json:"timeout,omitempty"`      // Time spent polling before giving up; DefaultJobTimeout when empty
+}
+
+// JobWaits holds the parsed waits of an async job
+type JobWaits struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Timeout     time.Duration
+}
+
+// Validate checks the job names its requests, its completion condition and valid waits
+func (j AsyncJob) Validate() error {
+	if strings.TrimSpace(j.Name) == "" {
+		return fmt.Errorf("job: missing name")
+	}
+	if strings.TrimSpace(j.Submit) == "" || strings.TrimSpace(j.Poll) == "" {
+		return fmt.Errorf("job %s: missing submit or poll request", j.Name)
+	}
+	if strings.TrimSpace(j.Until) == "" || j.Equals == "" {
+		return fmt.Errorf("job %s: missing until or equals", j.Name)
+	}
+	_, err := j.Waits()
+	return err
+}
+
+// Waits parses the waits of the job, defaulting the missing ones
+func (j AsyncJob) Waits() (JobWaits, error) {
+	waits := JobWaits{Interval: DefaultJobInterval, MaxInterval: DefaultJobMaxInterval, Timeout: DefaultJobTimeout}
+	for _, w := range []struct {
+		value string
+		into  *time.Duration
+	}{{j.Interval, &waits.Interval}, {j.MaxInterval, &waits.MaxInterval}, {j.Timeout, &waits.Timeout}} {
+		if strings.TrimSpace(w.value) == "" {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(w.value))
+		if err != nil || d <= 0 {
+			return waits, fmt.Errorf("job %s: invalid duration %q (use a duration such as 2s or 1m)", j.Name, w.value)
+		}
+		*w.into = d
+	}
+	if waits.MaxInterval < waits.Interval {
+		waits.MaxInterval = waits.Interval
+	}
+	return waits, nil
+}
+
+// Status returns the job status read from a poll response
+func (j AsyncJob) Status(resp *Response) (string, error) {
+	return ExtractRule{Type: ExtractJSONPath, Expression: j.Until}.Extract(resp)
+}
+
+// Done returns whether status is the status of a completed job
+func (j AsyncJob) Done(status string) bool {
+	return status == j.Equals
+}
+
+// Failed returns whether status is the status of a failed job
+func (j AsyncJob) Failed(status string) bool {
+	return slices.Contains(j.FailsOn, status)
+}
+
+// FindJob returns the async job named name, or nil
+func (c *CollectionFile) FindJob(name string) *AsyncJob {
+	for i := range c.Jobs {
+		if c.Jobs[i].Name == name {
+			return &c.Jobs[i]
+		}
+	}
+	return nil
+}
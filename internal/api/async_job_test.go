@@ -0,0 +1,51 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAsyncJob_Waits(t *testing.T) {
+	waits, err := AsyncJob{Name: "Export"}.Waits()
+	if err != nil || waits != (JobWaits{Interval: DefaultJobInterval, MaxInterval: DefaultJobMaxInterval, Timeout: DefaultJobTimeout}) {
+		t.Errorf("Waits() = %+v, %v, want defaults", waits, err)
+	}
+
+	// The max interval is never shorter than the first wait
+	waits, err = AsyncJob{Name: "Export", Interval: "45s", Timeout: "10m"}.Waits()
+	if err != nil || waits.Interval != 45*time.Second || waits.MaxInterval != 45*time.Second || waits.Timeout != 10*time.Minute {
+		t.Errorf("Waits() = %+v, %v", waits, err)
+	}
+
+	if _, err := (AsyncJob{Name: "Export", Timeout: "-1s"}).Waits(); err == nil {
+		t.Error("Waits() should reject a negative timeout")
+	}
+}
+
+func TestAsyncJob_Validate(t *testing.T) {
+	valid := AsyncJob{Name: "Export", Submit: "Start", Poll: "Status", Until: "$.status", Equals: "done"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	missingUntil := valid
+	missingUntil.Until = ""
+	missingPoll := valid
+	missingPoll.Poll = " "
+	for _, job := range []AsyncJob{{}, missingUntil, missingPoll} {
+		if err := job.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", job)
+		}
+	}
+}
+
+func TestAsyncJob_Status(t *testing.T) {
+	job := AsyncJob{Until: "$.job.status", Equals: "succeeded", FailsOn: []string{"failed"}}
+	status, err := job.Status(&Response{Body: []byte(`{"job":{"status":"failed"}}`)})
+	if err != nil || status != "failed" {
+		t.Fatalf("Status() = %q, %v, want failed", status, err)
+	}
+	if job.Done(status) || !job.Failed(status) || !job.Done("succeeded") {
+		t.Error("Done/Failed disagree with the job statuses")
+	}
+}
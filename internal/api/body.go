@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Body display limits
+const (
+	// MaxDisplayBodySize is the largest text body rendered in full in the response viewer
+	MaxDisplayBodySize = 1024 * 1024
+	// BinaryPreviewSize is the number of bytes shown in the hex preview of binary bodies
+	BinaryPreviewSize = 512
+	// binarySniffSize is the number of leading bytes inspected to detect binary content
+	binarySniffSize = 8000
+)
+
+// textContentTypes are media type fragments that always denote text content
+var textContentTypes = []string{
+	"text/",
+	"json",
+	"xml",
+	"javascript",
+	"ecmascript",
+	"yaml",
+	"x-www-form-urlencoded",
+	"graphql",
+}
+
+// binaryContentTypes are media type fragments that always denote binary content
+var binaryContentTypes = []string{
+	"image/",
+	"audio/",
+	"video/",
+	"font/",
+	"application/octet-stream",
+	"application/pdf",
+	"application/zip",
+	"application/gzip",
+	"application/x-tar",
+	"application/x-protobuf",
+	"application/protobuf",
+	"application/grpc",
+	"application/msgpack",
+	"application/x-msgpack",
+	"application/cbor",
+	"application/wasm",
+}
+
+// IsBinaryBody reports whether a body should be treated as binary data rather than text.
+// The Content-Type header decides when it is conclusive; otherwise the leading bytes are
+// sniffed for NUL bytes and invalid UTF-8.
+func IsBinaryBody(contentType string, body []byte) bool {
+	ct := strings.ToLower(contentType)
+	if ct != "" && !strings.Contains(ct, "svg") {
+		for _, t := range binaryContentTypes {
+			if strings.Contains(ct, t) {
+				return true
+			}
+		}
+	}
+	for _, t := range textContentTypes {
+		if strings.Contains(ct, t) {
+			return false
+		}
+	}
+
+	sample := body
+	if len(sample) > binarySniffSize {
+		sample = sample[:binarySniffSize]
+		// Don't let a rune cut at the sample boundary count as invalid UTF-8
+		for i := 0; i < utf8.UTFMax && len(sample) > 0 && !utf8.Valid(sample); i++ {
+			sample = sample[:len(sample)-1]
+		}
+	}
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	return !utf8.Valid(sample)
+}
+
+// FormatSize formats a byte count for display in DefaultUnits (e.g. "512B", "2.4KB",
+// "1.2MB")
+func FormatSize(size int64) string {
+	if size < 0 {
+		return "-"
+	}
+	return DefaultUnits.Size(size)
+}
+
+// BinarySummary returns a one-line description of a binary body
+func BinarySummary(contentType string, body []byte) string {
+	ct := contentType
+	if ct == "" {
+		ct = "unknown type"
+	}
+	return fmt.Sprintf("[binary data: %s, %s]", FormatSize(int64(len(body))), ct)
+}
+
+// BinaryPreview returns a summary line followed by a hex dump of the leading bytes
+func BinaryPreview(contentType string, body []byte) string {
+	var sb strings.Builder
+	sb.WriteString(BinarySummary(contentType, body))
+	sb.WriteString("\n\n")
+
+	preview := body
+	if len(preview) > BinaryPreviewSize {
+		preview = preview[:BinaryPreviewSize]
+	}
+	sb.WriteString(hex.Dump(preview))
+	if len(body) > len(preview) {
+		sb.WriteString(fmt.Sprintf("... %d more bytes\n", len(body)-len(preview)))
+	}
+	return sb.String()
+}
+
+// TruncateText cuts text to at most maxBytes without splitting a UTF-8 sequence.
+// Returns the text unchanged and false when no truncation was needed.
+func TruncateText(body []byte, maxBytes int) ([]byte, bool) {
+	if maxBytes < 0 || len(body) <= maxBytes {
+		return body, false
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut], true
+}
+
+// BodyText returns a body as display text: binary bodies are replaced by their summary
+func BodyText(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if IsBinaryBody(contentType, body) {
+		return BinarySummary(contentType, body)
+	}
+	return string(body)
+}
+
+// BodyText returns the request body as display text: encoded bodies are replaced by their summary
+func (r *Request) BodyText() string {
+	switch body := r.Body.(type) {
+	case nil:
+		return ""
+	case []byte:
+		contentType := ""
+		for key, value := range r.Headers {
+			if strings.EqualFold(key, "Content-Type") {
+				contentType = value
+				break
+			}
+		}
+		return BodyText(contentType, body)
+	case string:
+		return body
+	case *MultipartForm:
+		return body.String()
+	case *FileBody:
+		return body.String()
+	default:
+		return fmt.Sprintf("%v", body)
+	}
+}
+
+// ContentType returns the response Content-Type header value
+func (r *Response) ContentType() string {
+	for key, values := range r.Headers {
+		if strings.EqualFold(key, "Content-Type") && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// IsBinary reports whether the response body is binary data
+func (r *Response) IsBinary() bool {
+	return IsBinaryBody(r.ContentType(), r.Body)
+}
+
+// BodyString returns the whole response body as a string (for text consumers such as
+// scripts), read from its temporary file when spooled
+func (r *Response) BodyString() string {
+	body, err := r.FullBody()
+	if err != nil {
+		return string(r.Body)
+	}
+	return string(body)
+}
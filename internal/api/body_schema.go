@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+)
+
+// JSONSchema is the part of a JSON Schema the body builder walks: the type, enum,
+// default and description of a value, the properties of an object and the items of an
+// array. $ref and combinators are not followed; OpenAPI imports inline them.
+type JSONSchema struct {
+	Type        SchemaType             `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Default     interface{}            `json:"default,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"`
+}
+
+// SchemaType is the type of a JSON Schema value: "object", "array", "string",
+// "integer", "number", "boolean" or "null"
+type SchemaType string
+
+// UnmarshalJSON reads a type or a list of types, ["string", "null"], as its first type
+// other than null
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaType(single)
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = "null"
+	for _, typ := range types {
+		if typ != "null" {
+			*t = SchemaType(typ)
+			break
+		}
+	}
+	return nil
+}
+
+// ParseJSONSchema reads a JSON Schema document
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	var schema JSONSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// LoadJSONSchema reads a JSON Schema file
+func LoadJSONSchema(path string) (*JSONSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	return ParseJSONSchema(data)
+}
+
+// ValueType returns the type of the value, inferred from its properties, items or enum
+// when the schema has none. Empty when the value can be anything.
+func (s *JSONSchema) ValueType() SchemaType {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.Properties != nil:
+		return "object"
+	case s.Items != nil:
+		return "array"
+	case len(s.Enum) > 0:
+		switch s.Enum[0].(type) {
+		case string:
+			return "string"
+		case float64:
+			return "number"
+		case bool:
+			return "boolean"
+		}
+	}
+	return ""
+}
+
+// IsRequired reports whether the object property name is required
+func (s *JSONSchema) IsRequired(name string) bool {
+	return slices.Contains(s.Required, name)
+}
+
+// PropertyNames returns the names of the object properties: the required ones in the
+// order of required, then the others by name
+func (s *JSONSchema) PropertyNames() []string {
+	var names, optional []string
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for name := range s.Properties {
+		if !s.IsRequired(name) {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	return append(names, optional...)
+}
+
+// DefaultValue returns the value given to a required value left empty: its default, its
+// first enum value, or the zero value of its type
+func (s *JSONSchema) DefaultValue() interface{} {
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	switch s.ValueType() {
+	case "object":
+		return map[string]interface{}{}
+	case "array":
+		return []interface{}{}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "string":
+		return ""
+	}
+	return nil
+}
+
+// UpdateRequestBodySchema attaches a JSON Schema to the body of a request by ID, or
+// detaches it for nil
+func (c *CollectionFile) UpdateRequestBodySchema(id string, schema *JSONSchema) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.BodySchema = schema
+	return true
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONSchema(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(`{
+		"type": "object",
+		"required": ["name", "status"],
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"status": {"enum": ["available", "sold"]},
+			"name": {"type": "string"},
+			"age": {"type": ["null", "integer"]},
+			"owner": {"properties": {"id": {"type": "integer", "default": 7}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := schema.PropertyNames(), []string{"name", "status", "age", "owner", "tags"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PropertyNames() = %v, want %v (required first)", got, want)
+	}
+	types := map[string]SchemaType{"age": "integer", "status": "string", "owner": "object", "tags": "array"}
+	for name, want := range types {
+		if got := schema.Properties[name].ValueType(); got != want {
+			t.Errorf("%s ValueType() = %q, want %q", name, got, want)
+		}
+	}
+	defaults := map[string]interface{}{"status": "available", "name": "", "tags": []interface{}{}}
+	for name, want := range defaults {
+		if got := schema.Properties[name].DefaultValue(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s DefaultValue() = %#v, want %#v", name, got, want)
+		}
+	}
+	if got := schema.Properties["owner"].Properties["id"].DefaultValue(); got != float64(7) {
+		t.Errorf("DefaultValue() = %#v, want the default", got)
+	}
+
+	if _, err := ParseJSONSchema([]byte(`{"type": 3}`)); err == nil {
+		t.Error("a type that is not a string should fail")
+	}
+}
+
+func TestCollectionRequest_BodySchemaRoundTrip(t *testing.T) {
+	req := CollectionRequest{ID: "req_1", Name: "Create", Method: POST, BodySchema: &JSONSchema{
+		Type:       "object",
+		Properties: map[string]*JSONSchema{"name": {Type: "string"}},
+		Required:   []string{"name"},
+	}}
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded CollectionRequest
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.BodySchema, req.BodySchema) {
+		t.Errorf("BodySchema = %+v, want %+v", loaded.BodySchema, req.BodySchema)
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsBinaryBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        bool
+	}{
+		{name: "json header", contentType: "application/json", body: []byte(`{"a":1}`), want: false},
+		{name: "png header", contentType: "image/png", body: []byte("\x89PNG\r\n\x1a\n"), want: true},
+		{name: "svg is text", contentType: "image/svg+xml", body: []byte("<svg/>"), want: false},
+		{name: "protobuf header", contentType: "application/x-protobuf", body: []byte("\x08\x96\x01"), want: true},
+		{name: "octet-stream header", contentType: "application/octet-stream", body: []byte("plain"), want: true},
+		{name: "sniffed text", contentType: "", body: []byte("héllo world"), want: false},
+		{name: "sniffed NUL byte", contentType: "", body: []byte("abc\x00def"), want: true},
+		{name: "sniffed invalid UTF-8", contentType: "", body: []byte{0xff, 0xfe, 0xfd}, want: true},
+		{name: "empty body", contentType: "", body: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinaryBody(tt.contentType, tt.body); got != tt.want {
+				t.Errorf("IsBinaryBody(%q) = %v, want %v", tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBinaryBody_LongTextWithMultibyteBoundary(t *testing.T) {
+	// A multi-byte rune straddling the sniff boundary must not be reported as binary
+	body := []byte(strings.Repeat("a", binarySniffSize-1) + "é" + "tail")
+	if IsBinaryBody("", body) {
+		t.Error("IsBinaryBody() = true for long UTF-8 text")
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		max           int
+		want          string
+		wantTruncated bool
+	}{
+		{name: "short text unchanged", body: "hello", max: 10, want: "hello", wantTruncated: false},
+		{name: "ascii cut", body: "hello world", max: 5, want: "hello", wantTruncated: true},
+		{name: "does not split rune", body: "aé", max: 2, want: "a", wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateText([]byte(tt.body), tt.max)
+			if string(got) != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("TruncateText() = %q, %v; want %q, %v", got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBinaryPreview(t *testing.T) {
+	body := make([]byte, BinaryPreviewSize+10)
+	preview := BinaryPreview("image/png", body)
+
+	if !strings.HasPrefix(preview, "[binary data: 522B, image/png]") {
+		t.Errorf("BinaryPreview() summary = %q", strings.SplitN(preview, "\n", 2)[0])
+	}
+	if !strings.Contains(preview, "... 10 more bytes") {
+		t.Error("BinaryPreview() should report remaining bytes")
+	}
+}
+
+func TestEncodeRequestBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     interface{}
+		want     string
+		wantJSON bool
+	}{
+		{name: "raw bytes", body: []byte{0x00, 0x01}, want: "\x00\x01", wantJSON: false},
+		{name: "string sent as-is", body: "a=1&b=2", want: "a=1&b=2", wantJSON: false},
+		{name: "object as JSON", body: map[string]interface{}{"a": 1}, want: `{"a":1}`, wantJSON: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isJSON, err := encodeRequestBody(tt.body)
+			if err != nil {
+				t.Fatalf("encodeRequestBody() error = %v", err)
+			}
+			if string(got) != tt.want || isJSON != tt.wantJSON {
+				t.Errorf("encodeRequestBody() = %q, %v; want %q, %v", got, isJSON, tt.want, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestResponse_ContentType(t *testing.T) {
+	resp := &Response{Headers: map[string][]string{"content-type": {"text/plain"}}}
+	if got := resp.ContentType(); got != "text/plain" {
+		t.Errorf("ContentType() = %q, want text/plain", got)
+	}
+}
+
+func TestRequest_BodyText(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *Request
+		want string
+	}{
+		{name: "no body", req: &Request{}, want: ""},
+		{name: "string body", req: &Request{Body: "a=1"}, want: "a=1"},
+		{name: "encoded body", req: &Request{Headers: map[string]string{"Content-Type": "application/msgpack"}, Body: []byte{0x81, 0xa1}}, want: "[binary data: 2B, application/msgpack]"},
+		{name: "text bytes", req: &Request{Body: []byte("hello")}, want: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.BodyText(); got != tt.want {
+				t.Errorf("BodyText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ChaosFault is a kind of failure injected by chaos mode
+type ChaosFault string
+
+const (
+	ChaosNone    ChaosFault = ""
+	ChaosLatency ChaosFault = "latency" // Delay the request
+	ChaosDrop    ChaosFault = "drop"    // Fail with a reset connection
+	ChaosError   ChaosFault = "5xx"     // Answer with a 5xx response without sending
+)
+
+// ChaosFaults lists every fault in display order
+var ChaosFaults = []ChaosFault{ChaosLatency, ChaosDrop, ChaosError}
+
+// ErrChaosInjected marks errors produced by chaos mode
+var ErrChaosInjected = errors.New("chaos: injected failure")
+
+// chaosStatusCodes are the status codes chaos mode answers with
+var chaosStatusCodes = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// ChaosConfig configures client-side fault injection
+type ChaosConfig struct {
+	Rate    int           // Percentage of sends affected (0-100)
+	Latency time.Duration // Delay added by the latency fault
+	Faults  []ChaosFault  // Faults picked from (uniformly) for affected sends
+}
+
+// DefaultChaosConfig affects one send in five with any fault and 2s of latency
+func DefaultChaosConfig() ChaosConfig {
+	return ChaosConfig{
+		Rate:    20,
+		Latency: 2 * time.Second,
+		Faults:  append([]ChaosFault(nil), ChaosFaults...),
+	}
+}
+
+// ParseChaosArgs parses ":chaos" arguments on top of base:
+// an optional rate ("30" or "30%") followed by faults ("latency", "latency=500ms", "drop", "5xx").
+// Faults given replace the faults of base.
+func ParseChaosArgs(args []string, base ChaosConfig) (ChaosConfig, error) {
+	cfg := base
+	var faults []ChaosFault
+
+	for _, arg := range args {
+		name, value, hasValue := strings.Cut(strings.ToLower(arg), "=")
+		switch ChaosFault(name) {
+		case ChaosLatency:
+			if hasValue {
+				latency, err := time.ParseDuration(value)
+				if err != nil || latency <= 0 {
+					return base, fmt.Errorf("invalid latency %q", value)
+				}
+				cfg.Latency = latency
+			}
+			faults = append(faults, ChaosLatency)
+		case ChaosDrop, ChaosError:
+			if hasValue {
+				return base, fmt.Errorf("%s takes no value", name)
+			}
+			faults = append(faults, ChaosFault(name))
+		default:
+			rate, err := strconv.Atoi(strings.TrimSuffix(arg, "%"))
+			if err != nil {
+				return base, fmt.Errorf("unknown chaos option %q", arg)
+			}
+			if rate < 0 || rate > 100 {
+				return base, fmt.Errorf("rate must be between 0 and 100, got %d", rate)
+			}
+			cfg.Rate = rate
+		}
+	}
+
+	if len(faults) > 0 {
+		cfg.Faults = faults
+	}
+	return cfg, nil
+}
+
+// String describes the configuration (e.g. "20% latency=2s, drop, 5xx")
+func (c ChaosConfig) String() string {
+	names := make([]string, 0, len(c.Faults))
+	for _, fault := range c.Faults {
+		if fault == ChaosLatency {
+			names = append(names, fmt.Sprintf("%s=%s", fault, c.Latency))
+			continue
+		}
+		names = append(names, string(fault))
+	}
+	return fmt.Sprintf("%d%% %s", c.Rate, strings.Join(names, ", "))
+}
+
+// ChaosInjector decides which sends are affected. It is safe for concurrent use.
+type ChaosInjector struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewChaosInjector creates an injector using the given random seed
+func NewChaosInjector(seed int64) *ChaosInjector {
+	return &ChaosInjector{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Pick returns the fault to inject into the next send, or ChaosNone
+func (i *ChaosInjector) Pick(cfg ChaosConfig) ChaosFault {
+	if cfg.Rate <= 0 || len(cfg.Faults) == 0 {
+		return ChaosNone
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.rnd.Intn(100) >= cfg.Rate {
+		return ChaosNone
+	}
+	return cfg.Faults[i.rnd.Intn(len(cfg.Faults))]
+}
+
+// statusCode returns the 5xx status code injected by the 5xx fault
+func (i *ChaosInjector) statusCode() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return chaosStatusCodes[i.rnd.Intn(len(chaosStatusCodes))]
+}
+
+// SendWithChaos sends req through client with the fault applied.
+// Latency counts against req.Timeout: when it exceeds the timeout the send fails with a timeout.
+func (i *ChaosInjector) SendWithChaos(client *Client, req *Request, fault ChaosFault, cfg ChaosConfig) (*Response, error) {
+	switch fault {
+	case ChaosLatency:
+		if req.Timeout > 0 && cfg.Latency >= req.Timeout {
+			time.Sleep(req.Timeout)
+			return nil, fmt.Errorf("%w: latency of %s exceeded the %s timeout: %w", ErrChaosInjected, cfg.Latency, req.Timeout, context.DeadlineExceeded)
+		}
+		time.Sleep(cfg.Latency)
+		delayed := *req
+		if delayed.Timeout > 0 {
+			delayed.Timeout -= cfg.Latency
+		}
+		resp, err := client.Send(&delayed)
+		if resp != nil {
+			resp.Time += cfg.Latency
+		}
+		return resp, err
+
+	case ChaosDrop:
+		return nil, fmt.Errorf("%w: connection dropped: %w", ErrChaosInjected, syscall.ECONNRESET)
+
+	case ChaosError:
+		status := i.statusCode()
+		body := []byte(fmt.Sprintf("chaos: injected %d %s", status, http.StatusText(status)))
+		return &Response{
+			StatusCode: status,
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			Headers: map[string][]string{
+				"Content-Type":     {"text/plain; charset=utf-8"},
+				"X-Lazycurl-Chaos": {string(ChaosError)},
+			},
+			Body: body,
+			Size: int64(len(body)),
+		}, nil
+
+	default:
+		return client.Send(req)
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseChaosArgs(t *testing.T) {
+	base := DefaultChaosConfig()
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantRate    int
+		wantLatency time.Duration
+		wantFaults  []ChaosFault
+		wantErr     bool
+	}{
+		{name: "defaults", args: nil, wantRate: 20, wantLatency: 2 * time.Second, wantFaults: ChaosFaults},
+		{name: "rate only", args: []string{"50"}, wantRate: 50, wantLatency: 2 * time.Second, wantFaults: ChaosFaults},
+		{name: "rate with percent", args: []string{"5%"}, wantRate: 5, wantLatency: 2 * time.Second, wantFaults: ChaosFaults},
+		{name: "faults replace base", args: []string{"30", "drop", "5XX"}, wantRate: 30, wantLatency: 2 * time.Second, wantFaults: []ChaosFault{ChaosDrop, ChaosError}},
+		{name: "latency value", args: []string{"latency=500ms"}, wantRate: 20, wantLatency: 500 * time.Millisecond, wantFaults: []ChaosFault{ChaosLatency}},
+		{name: "invalid latency", args: []string{"latency=soon"}, wantErr: true},
+		{name: "value on drop", args: []string{"drop=1"}, wantErr: true},
+		{name: "rate out of range", args: []string{"150"}, wantErr: true},
+		{name: "unknown option", args: []string{"jitter"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseChaosArgs(tt.args, base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Rate != tt.wantRate || cfg.Latency != tt.wantLatency || !reflect.DeepEqual(cfg.Faults, tt.wantFaults) {
+				t.Errorf("got %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestChaosConfig_String(t *testing.T) {
+	cfg := ChaosConfig{Rate: 25, Latency: time.Second, Faults: []ChaosFault{ChaosLatency, ChaosError}}
+	if got, want := cfg.String(), "25% latency=1s, 5xx"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestChaosInjector_Pick(t *testing.T) {
+	injector := NewChaosInjector(1)
+
+	for i := 0; i < 50; i++ {
+		if fault := injector.Pick(ChaosConfig{Rate: 0, Faults: ChaosFaults}); fault != ChaosNone {
+			t.Fatalf("rate 0 picked %q", fault)
+		}
+		if fault := injector.Pick(ChaosConfig{Rate: 100}); fault != ChaosNone {
+			t.Fatalf("no faults picked %q", fault)
+		}
+		if fault := injector.Pick(ChaosConfig{Rate: 100, Faults: []ChaosFault{ChaosDrop}}); fault != ChaosDrop {
+			t.Fatalf("rate 100 picked %q, want drop", fault)
+		}
+	}
+
+	affected := 0
+	for i := 0; i < 1000; i++ {
+		if injector.Pick(ChaosConfig{Rate: 30, Faults: ChaosFaults}) != ChaosNone {
+			affected++
+		}
+	}
+	if affected < 200 || affected > 400 {
+		t.Errorf("rate 30 affected %d/1000 sends", affected)
+	}
+}
+
+func TestChaosInjector_SendWithChaos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	injector := NewChaosInjector(1)
+	client := NewClient()
+	cfg := ChaosConfig{Rate: 100, Latency: 20 * time.Millisecond, Faults: ChaosFaults}
+
+	t.Run("none", func(t *testing.T) {
+		resp, err := injector.SendWithChaos(client, &Request{Method: GET, URL: server.URL}, ChaosNone, cfg)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("resp = %+v, err = %v", resp, err)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		resp, err := injector.SendWithChaos(client, &Request{Method: GET, URL: server.URL, Timeout: time.Second}, ChaosLatency, cfg)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("resp = %+v, err = %v", resp, err)
+		}
+		if resp.Time < cfg.Latency {
+			t.Errorf("Time = %v, want at least %v", resp.Time, cfg.Latency)
+		}
+	})
+
+	t.Run("latency beyond timeout", func(t *testing.T) {
+		_, err := injector.SendWithChaos(client, &Request{Method: GET, URL: server.URL, Timeout: 5 * time.Millisecond}, ChaosLatency, cfg)
+		if !errors.Is(err, ErrChaosInjected) {
+			t.Fatalf("err = %v, want injected failure", err)
+		}
+		if ne := DiagnoseNetworkError(err, server.URL); ne.Kind != NetworkErrorTimeout {
+			t.Errorf("Kind = %v, want timeout", ne.Kind)
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		_, err := injector.SendWithChaos(client, &Request{Method: GET, URL: server.URL}, ChaosDrop, cfg)
+		if !errors.Is(err, ErrChaosInjected) || !errors.Is(err, syscall.ECONNRESET) {
+			t.Fatalf("err = %v, want injected connection reset", err)
+		}
+		ne := DiagnoseNetworkError(err, server.URL)
+		if ne.Kind != NetworkErrorConnectionReset {
+			t.Errorf("Kind = %v, want connection reset", ne.Kind)
+		}
+		if len(ne.Suggestions) != 1 || ne.Suggestions[0] != "Run :chaos off to send requests normally" {
+			t.Errorf("Suggestions = %v", ne.Suggestions)
+		}
+	})
+
+	t.Run("5xx", func(t *testing.T) {
+		resp, err := injector.SendWithChaos(client, &Request{Method: GET, URL: server.URL}, ChaosError, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode < 500 || resp.StatusCode > 599 {
+			t.Errorf("StatusCode = %d, want 5xx", resp.StatusCode)
+		}
+		if resp.Headers["X-Lazycurl-Chaos"][0] != "5xx" {
+			t.Errorf("missing chaos header: %v", resp.Headers)
+		}
+	})
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -18,7 +20,7 @@ type KeyValueEntry struct {
 
 // AuthConfig represents authentication configuration
 type AuthConfig struct {
-	Type   string `json:"type"`             // "none", "bearer", "basic", "api_key"
+	Type   string `json:"type"`             // "none", "bearer", "basic", "api_key", "jwt"
 	Token  string `json:"token,omitempty"`  // For bearer token
 	Prefix string `json:"prefix,omitempty"` // For bearer prefix (default: "Bearer")
 	// Basic auth
@@ -28,11 +30,16 @@ type AuthConfig struct {
 	APIKeyName     string `json:"api_key_name,omitempty"`
 	APIKeyValue    string `json:"api_key_value,omitempty"`
 	APIKeyLocation string `json:"api_key_location,omitempty"` // "header" or "query"
+	// JWT (signed at send time, sent as a bearer token using Prefix)
+	JWTAlgorithm string `json:"jwt_algorithm,omitempty"`  // "HS256", "RS256" or "ES256"
+	JWTKey       string `json:"jwt_key,omitempty"`        // HMAC secret, PEM private key, or path to a PEM file
+	JWTClaims    string `json:"jwt_claims,omitempty"`     // JSON claims template (supports {{variables}})
+	JWTExpiresIn string `json:"jwt_expires_in,omitempty"` // Token lifetime added as "exp" (e.g. "5m")
 }
 
 // BodyConfig represents request body configuration
 type BodyConfig struct {
-	Type    string      `json:"type"`              // "none", "json", "form-data", "raw", "binary"
+	Type    string      `json:"type"`              // "none", "json", "form-data", "raw", "binary", "msgpack", "cbor", "graphql"
 	Content interface{} `json:"content,omitempty"` // JSON object, string, or form data
 }
 
@@ -54,8 +61,28 @@ type CollectionRequest struct {
 	HeadersMap  map[string]string `json:"headers_map,omitempty"` // Legacy headers format
 	Auth        *AuthConfig       `json:"auth,omitempty"`        // Authentication config
 	Body        *BodyConfig       `json:"body,omitempty"`        // Request body config
+	BodySchema  *JSONSchema       `json:"body_schema,omitempty"` // Schema of a JSON body, walked by the body builder
 	Scripts     *ScriptConfig     `json:"scripts,omitempty"`     // Pre/post scripts
 	Tests       []Test            `json:"tests,omitempty"`
+	Mocks       []MockRule        `json:"mocks,omitempty"`        // Canned responses used in mock mode
+	Extract     []ExtractRule     `json:"extract,omitempty"`      // Response values stored in environment variables after each send
+	Examples    []RequestExample  `json:"examples,omitempty"`     // Frozen copies of the request as sent (see FreezeRequest)
+	Operation   string            `json:"operation,omitempty"`    // OpenAPI operation the request was imported from ("GET /pets/{id}")
+	Link        string            `json:"link,omitempty"`         // ID of the request this one links to, sharing its content (see ResolveLinks)
+	File        string            `json:"file,omitempty"`         // File holding the request, relative to its collection file (see SplitRequests)
+	SkipInRuns  bool              `json:"skip_in_runs,omitempty"` // Left out of collection runs (setup-only or manual-only requests)
+	Variables   map[string]string `json:"variables,omitempty"`    // Request variables, overriding every other scope (see VariableScopes)
+
+	NoFollowRedirects bool `json:"no_follow_redirects,omitempty"` // Return 3xx responses instead of following them
+	MaxRedirects      int  `json:"max_redirects,omitempty"`       // Redirects followed before failing; 0 uses DefaultMaxRedirects
+
+	Timeout string       `json:"timeout,omitempty"` // Duration such as "10s"; empty uses DefaultTimeout
+	Retry   *RetryPolicy `json:"retry,omitempty"`   // Resends on network errors and listed statuses
+
+	ServerName string `json:"server_name,omitempty"` // TLS server name (SNI) sent instead of the URL host
+	Host       string `json:"host,omitempty"`        // Host header sent instead of the URL host
+
+	Owner string `json:"owner,omitempty"` // Team or person owning the endpoint; empty inherits the folder's (see Inventory)
 }
 
 // Folder represents a folder in a collection
@@ -64,15 +91,46 @@ type Folder struct {
 	Description string              `json:"description,omitempty"`
 	Folders     []Folder            `json:"folders,omitempty"`
 	Requests    []CollectionRequest `json:"requests,omitempty"`
+	RunOrder    []string            `json:"run_order,omitempty"` // Run order of the folder's entries (see RunEntries)
+	Scripts     *ScriptConfig       `json:"scripts,omitempty"`   // Scripts run around each request of the folder (see InheritedScripts)
+	Owner       string              `json:"owner,omitempty"`     // Owner of the folder's requests; empty inherits the parent's
 }
 
 // CollectionFile represents a collection file structure
 type CollectionFile struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description,omitempty"`
-	Folders     []Folder            `json:"folders,omitempty"`
-	Requests    []CollectionRequest `json:"requests,omitempty"`
-	FilePath    string              `json:"-"` // Path to the file (not serialized)
+	Name              string                `json:"name"`
+	Description       string                `json:"description,omitempty"`
+	Folders           []Folder              `json:"folders,omitempty"`
+	Requests          []CollectionRequest   `json:"requests,omitempty"`
+	RequiredVariables []VariableRequirement `json:"required_variables,omitempty"` // Variables the active environment must provide
+	OpenAPISource     *OpenAPISource        `json:"openapi_source,omitempty"`     // Spec the collection was imported from (for :sync)
+	Session           string                `json:"session,omitempty"`            // SessionShared or SessionIsolated; empty follows the workspace
+	RunOrder          []string              `json:"run_order,omitempty"`          // Run order of the root entries (see RunEntries)
+	Jobs              []AsyncJob            `json:"jobs,omitempty"`               // Submit, poll, fetch result workflows (see AsyncJob)
+	Variables         map[string]string     `json:"variables,omitempty"`          // Collection variables (see VariableScopes)
+	Scripts           *ScriptConfig         `json:"scripts,omitempty"`            // Scripts run around each request of the collection (see InheritedScripts)
+	Owner             string                `json:"owner,omitempty"`              // Owner of the collection's requests (see Inventory)
+	SplitRequests     bool                  `json:"split_requests,omitempty"`     // Each request saved in its own file (see SaveCollection)
+	FilePath          string                `json:"-"`                            // Path to the file (not serialized)
+}
+
+// Session modes of a collection: whether its scripts share lc.globals and lc.cookies
+// with the other collections
+const (
+	SessionShared   = "shared"
+	SessionIsolated = "isolated"
+)
+
+// IsolatedSession returns whether the collection keeps its own script session,
+// isolatedByDefault being the workspace setting
+func (c *CollectionFile) IsolatedSession(isolatedByDefault bool) bool {
+	switch c.Session {
+	case SessionIsolated:
+		return true
+	case SessionShared:
+		return false
+	}
+	return isolatedByDefault
 }
 
 // Test represents a test assertion for a request
@@ -112,8 +170,8 @@ func (cr *CollectionRequest) UnmarshalJSON(data []byte) error {
 			var headersMap map[string]string
 			if err := json.Unmarshal(temp.HeadersRaw, &headersMap); err == nil {
 				cr.Headers = make([]KeyValueEntry, 0, len(headersMap))
-				for k, v := range headersMap {
-					cr.Headers = append(cr.Headers, KeyValueEntry{Key: k, Value: v, Enabled: true})
+				for _, k := range slices.Sorted(maps.Keys(headersMap)) {
+					cr.Headers = append(cr.Headers, KeyValueEntry{Key: k, Value: headersMap[k], Enabled: true})
 				}
 			}
 		}
@@ -149,6 +207,29 @@ func (cr *CollectionRequest) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON writes linked requests as their ID, name, link and run settings only:
+// their content is the original's, filled in by ResolveLinks. Requests saved in their
+// own file are written as their ID, name and file.
+func (cr CollectionRequest) MarshalJSON() ([]byte, error) {
+	type Alias CollectionRequest
+	if cr.File != "" {
+		return marshalUnescaped(struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			File string `json:"file"`
+		}{cr.ID, cr.Name, cr.File})
+	}
+	if cr.Link == "" {
+		return marshalUnescaped(Alias(cr))
+	}
+	return marshalUnescaped(struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Link       string `json:"link"`
+		SkipInRuns bool   `json:"skip_in_runs,omitempty"`
+	}{cr.ID, cr.Name, cr.Link, cr.SkipInRuns})
+}
+
 // LoadCollection loads a collection from a JSON file
 func LoadCollection(path string) (*CollectionFile, error) {
 	data, err := os.ReadFile(path)
@@ -162,22 +243,36 @@ func LoadCollection(path string) (*CollectionFile, error) {
 	}
 
 	collection.FilePath = path
+	if err := loadRequestFiles(&collection, path); err != nil {
+		return nil, err
+	}
 	return &collection, nil
 }
 
-// SaveCollection saves a collection to a JSON file
+// SaveCollection saves a collection to a JSON file. The output is stable, for reviews and
+// merges: fields in a fixed order, map keys sorted, characters such as & left unescaped,
+// and a final newline. A collection with SplitRequests saves each request in its own
+// file of RequestsDir(path) and lists them in the collection file.
 func SaveCollection(collection *CollectionFile, path string) error {
-	data, err := json.MarshalIndent(collection, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal collection: %w", err)
-	}
-
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	if collection.SplitRequests {
+		index, err := saveRequestFiles(collection, path)
+		if err != nil {
+			return err
+		}
+		collection = index
+	}
+
+	data, err := marshalCollectionJSON(collection)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collection: %w", err)
+	}
+
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write collection file: %w", err)
 	}
@@ -214,6 +309,7 @@ func LoadAllCollections(dir string) ([]*CollectionFile, error) {
 		collections = append(collections, collection)
 	}
 
+	ResolveLinks(collections)
 	return collections, nil
 }
 
@@ -245,8 +341,8 @@ func (cr *CollectionRequest) ToRequest() *Request {
 func FromRequest(req *Request, name string) *CollectionRequest {
 	// Convert map[string]string to []KeyValueEntry
 	headers := make([]KeyValueEntry, 0, len(req.Headers))
-	for k, v := range req.Headers {
-		headers = append(headers, KeyValueEntry{Key: k, Value: v, Enabled: true})
+	for _, k := range slices.Sorted(maps.Keys(req.Headers)) {
+		headers = append(headers, KeyValueEntry{Key: k, Value: req.Headers[k], Enabled: true})
 	}
 
 	// Convert body to BodyConfig
@@ -314,6 +410,19 @@ func (c *CollectionFile) AddRequest(req *CollectionRequest) {
 	c.Requests = append(c.Requests, *req)
 }
 
+// AddRequestAfter adds a request right after the request with ID afterID, in the same
+// folder, or at the top level when there is no such request
+func (c *CollectionFile) AddRequestAfter(afterID string, req *CollectionRequest) {
+	if req.ID == "" {
+		req.ID = GenerateID()
+	}
+	if c.FindRequest(afterID) == nil {
+		c.Requests = append(c.Requests, *req)
+		return
+	}
+	c.addRequestAfter(afterID, req)
+}
+
 // AddRequestToFolder adds a request to a specific folder
 func (c *CollectionFile) AddRequestToFolder(folderPath []string, req *CollectionRequest) error {
 	if req.ID == "" {
@@ -366,6 +475,10 @@ func ValidateCollection(collection *CollectionFile) error {
 		return fmt.Errorf("collection name is required")
 	}
 
+	if err := validateRequirements(collection.RequiredVariables); err != nil {
+		return err
+	}
+
 	// Validate requests
 	for _, req := range collection.Requests {
 		if err := validateRequest(&req); err != nil {
@@ -388,6 +501,10 @@ func validateRequest(req *CollectionRequest) error {
 	if req.Name == "" {
 		return fmt.Errorf("request name is required")
 	}
+	if req.Link != "" {
+		// The method and URL are the original's
+		return nil
+	}
 	if req.Method == "" {
 		return fmt.Errorf("request method is required")
 	}
@@ -545,9 +662,14 @@ func (c *CollectionFile) UpdateRequestBody(id, bodyType, content string) bool {
 	if req != nil {
 		if bodyType == "none" || content == "" {
 			req.Body = nil
+		} else if bodyType == BodyTypeGraphQL {
+			req.Body = &BodyConfig{Type: bodyType, Content: ParseGraphQLBody(content).Content()}
+		} else if bodyType == BodyTypeFormData {
+			// Form fields are stored as a list of {key, value, type, enabled}
+			req.Body = &BodyConfig{Type: bodyType, Content: ParseFormData(content)}
 		} else {
-			// For JSON body, try to parse as JSON object
-			if bodyType == "json" {
+			// For JSON-authored bodies (msgpack/cbor are encoded on send), try to parse as JSON object
+			if bodyType == "json" || bodyType == "msgpack" || bodyType == "cbor" {
 				var parsed interface{}
 				if err := json.Unmarshal([]byte(content), &parsed); err == nil {
 					req.Body = &BodyConfig{Type: bodyType, Content: parsed}
@@ -579,6 +701,16 @@ func (c *CollectionFile) UpdateRequestScripts(id, preRequest, postRequest string
 	return false
 }
 
+// UpdateRequestDescription updates the description (Markdown docs) of a request by ID
+func (c *CollectionFile) UpdateRequestDescription(id, description string) bool {
+	req := c.FindRequest(id)
+	if req != nil {
+		req.Description = description
+		return true
+	}
+	return false
+}
+
 // UpdateRequestAuth updates the auth configuration of a request by ID
 func (c *CollectionFile) UpdateRequestAuth(id string, auth *AuthConfig) bool {
 	req := c.FindRequest(id)
@@ -593,6 +725,85 @@ func (c *CollectionFile) UpdateRequestAuth(id string, auth *AuthConfig) bool {
 	return false
 }
 
+// UpdateRequestHeaders replaces the headers of a request by ID, dropping its legacy headers map
+func (c *CollectionFile) UpdateRequestHeaders(id string, headers []KeyValueEntry) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.Headers = headers
+	req.HeadersMap = nil
+	return true
+}
+
+// UpdateRequestExtract replaces the extraction rules of a request by ID
+func (c *CollectionFile) UpdateRequestExtract(id string, rules []ExtractRule) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.Extract = rules
+	return true
+}
+
+// UpdateRequestExamples replaces the frozen examples of a request by ID
+func (c *CollectionFile) UpdateRequestExamples(id string, examples []RequestExample) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.Examples = examples
+	return true
+}
+
+// UpdateRequestVariables replaces the variables of a request by ID
+func (c *CollectionFile) UpdateRequestVariables(id string, vars map[string]string) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.Variables = vars
+	return true
+}
+
+// UpdateRequestRedirects updates the redirect settings of a request by ID
+func (c *CollectionFile) UpdateRequestRedirects(id string, follow bool, maxRedirects int) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.NoFollowRedirects = !follow
+	req.MaxRedirects = maxRedirects
+	return true
+}
+
+// UpdateRequestSettings updates the timeout and retry policy of a request by ID.
+// A nil or zero-count policy removes retries.
+func (c *CollectionFile) UpdateRequestSettings(id string, timeout string, retry *RetryPolicy) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.Timeout = timeout
+	if retry != nil && retry.Count <= 0 {
+		retry = nil
+	}
+	req.Retry = retry
+	return true
+}
+
+// UpdateRequestOverrides updates the TLS server name and Host header a request sends
+// instead of its URL host. Returns true if the request was found.
+func (c *CollectionFile) UpdateRequestOverrides(id string, serverName, host string) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.ServerName = serverName
+	req.Host = host
+	return true
+}
+
 // RenameFolder renames a folder at the specified path
 func (c *CollectionFile) RenameFolder(folderPath []string, oldName, newName string) bool {
 	if len(folderPath) == 0 {
@@ -600,6 +811,7 @@ func (c *CollectionFile) RenameFolder(folderPath []string, oldName, newName stri
 		for i := range c.Folders {
 			if c.Folders[i].Name == oldName {
 				c.Folders[i].Name = newName
+				renameRunOrderKey(c.RunOrder, oldName, newName)
 				return true
 			}
 		}
@@ -615,6 +827,7 @@ func (c *CollectionFile) RenameFolder(folderPath []string, oldName, newName stri
 	for i := range parent.Folders {
 		if parent.Folders[i].Name == oldName {
 			parent.Folders[i].Name = newName
+			renameRunOrderKey(parent.RunOrder, oldName, newName)
 			return true
 		}
 	}
@@ -628,24 +841,35 @@ func (c *CollectionFile) DuplicateRequest(id string) *CollectionRequest {
 		return nil
 	}
 
-	duplicate := &CollectionRequest{
-		ID:          GenerateID(),
-		Name:        original.Name + " (copy)",
-		Description: original.Description,
-		Method:      original.Method,
-		URL:         original.URL,
-		Params:      copyParams(original.Params),
-		Headers:     copyHeaders(original.Headers),
-		Auth:        copyAuthConfig(original.Auth),
-		Body:        copyBodyConfig(original.Body),
-		Scripts:     copyScriptConfig(original.Scripts),
-	}
+	duplicate := CopyRequest(original)
+	duplicate.Name = original.Name + " (copy)"
 
 	// Add duplicate next to original - find where and add
 	c.addRequestAfter(id, duplicate)
 	return duplicate
 }
 
+// CopyRequest returns a deep copy of req with a new ID. The copy is not tied to the
+// OpenAPI operation of req, and the copy of a linked request links to the same original.
+func CopyRequest(req *CollectionRequest) *CollectionRequest {
+	duplicate := *req
+	duplicate.ID = GenerateID()
+	duplicate.Operation = ""
+	duplicate.Params = copyParams(req.Params)
+	duplicate.Headers = copyHeaders(req.Headers)
+	duplicate.HeadersMap = maps.Clone(req.HeadersMap)
+	duplicate.Auth = copyAuthConfig(req.Auth)
+	duplicate.Body = copyBodyConfig(req.Body)
+	duplicate.Scripts = copyScriptConfig(req.Scripts)
+	duplicate.Tests = slices.Clone(req.Tests)
+	duplicate.Mocks = slices.Clone(req.Mocks)
+	duplicate.Extract = slices.Clone(req.Extract)
+	duplicate.Examples = slices.Clone(req.Examples)
+	duplicate.Variables = maps.Clone(req.Variables)
+	duplicate.Retry = req.Retry.Clone()
+	return &duplicate
+}
+
 // copyHeaders creates a copy of headers slice
 func copyHeaders(h []KeyValueEntry) []KeyValueEntry {
 	if h == nil {
@@ -682,16 +906,8 @@ func copyAuthConfig(a *AuthConfig) *AuthConfig {
 	if a == nil {
 		return nil
 	}
-	return &AuthConfig{
-		Type:           a.Type,
-		Token:          a.Token,
-		Prefix:         a.Prefix,
-		Username:       a.Username,
-		Password:       a.Password,
-		APIKeyName:     a.APIKeyName,
-		APIKeyValue:    a.APIKeyValue,
-		APIKeyLocation: a.APIKeyLocation,
-	}
+	duplicate := *a
+	return &duplicate
 }
 
 // copyScriptConfig creates a copy of script config
@@ -774,6 +990,12 @@ func (c *CollectionFile) DuplicateFolder(folderPath []string, name string) *Fold
 
 // copyFolder creates a deep copy of a folder
 func copyFolder(f *Folder) *Folder {
+	return cloneFolder(f, CopyRequest)
+}
+
+// cloneFolder copies a folder and its subfolders, with the requests returned by
+// copyRequest for its requests
+func cloneFolder(f *Folder, copyRequest func(*CollectionRequest) *CollectionRequest) *Folder {
 	if f == nil {
 		return nil
 	}
@@ -781,32 +1003,20 @@ func copyFolder(f *Folder) *Folder {
 	duplicate := &Folder{
 		Name:        f.Name,
 		Description: f.Description,
+		Scripts:     copyScriptConfig(f.Scripts),
+		Owner:       f.Owner,
 		Requests:    make([]CollectionRequest, len(f.Requests)),
 		Folders:     make([]Folder, len(f.Folders)),
 	}
 
 	// Copy requests with new IDs
-	for i, req := range f.Requests {
-		duplicate.Requests[i] = CollectionRequest{
-			ID:          GenerateID(),
-			Name:        req.Name,
-			Description: req.Description,
-			Method:      req.Method,
-			URL:         req.URL,
-			Params:      copyParams(req.Params),
-			Headers:     copyHeaders(req.Headers),
-			Auth:        copyAuthConfig(req.Auth),
-			Body:        copyBodyConfig(req.Body),
-			Scripts:     copyScriptConfig(req.Scripts),
-		}
+	for i := range f.Requests {
+		duplicate.Requests[i] = *copyRequest(&f.Requests[i])
 	}
 
 	// Recursively copy subfolders
-	for i, subfolder := range f.Folders {
-		copied := copyFolder(&subfolder)
-		if copied != nil {
-			duplicate.Folders[i] = *copied
-		}
+	for i := range f.Folders {
+		duplicate.Folders[i] = *cloneFolder(&f.Folders[i], copyRequest)
 	}
 
 	return duplicate
@@ -840,18 +1050,27 @@ func (c *CollectionFile) CopyRequestToFolder(requestID string, targetFolderPath
 	if original == nil {
 		return nil
 	}
+	return c.PasteRequest(original, targetFolderPath, false)
+}
+
+// CopyFolderToFolder copies a folder to a target location
+func (c *CollectionFile) CopyFolderToFolder(sourcePath []string, sourceName string, targetFolderPath []string) *Folder {
+	original := c.FindFolderByName(sourcePath, sourceName)
+	if original == nil {
+		return nil
+	}
+	return c.PasteFolder(original, targetFolderPath, false)
+}
 
-	duplicate := &CollectionRequest{
-		ID:          GenerateID(),
-		Name:        original.Name + " (copy)",
-		Description: original.Description,
-		Method:      original.Method,
-		URL:         original.URL,
-		Params:      copyParams(original.Params),
-		Headers:     copyHeaders(original.Headers),
-		Auth:        copyAuthConfig(original.Auth),
-		Body:        copyBodyConfig(original.Body),
-		Scripts:     copyScriptConfig(original.Scripts),
+// PasteRequest adds a copy of a request of any collection to a target folder, or a
+// request linked to it when link is true
+func (c *CollectionFile) PasteRequest(original *CollectionRequest, targetFolderPath []string, link bool) *CollectionRequest {
+	var duplicate *CollectionRequest
+	if link {
+		duplicate = LinkRequest(original)
+	} else {
+		duplicate = CopyRequest(original)
+		duplicate.Name = original.Name + " (copy)"
 	}
 
 	// Add to target folder
@@ -867,16 +1086,17 @@ func (c *CollectionFile) CopyRequestToFolder(requestID string, targetFolderPath
 	return duplicate
 }
 
-// CopyFolderToFolder copies a folder to a target location
-func (c *CollectionFile) CopyFolderToFolder(sourcePath []string, sourceName string, targetFolderPath []string) *Folder {
-	original := c.FindFolderByName(sourcePath, sourceName)
-	if original == nil {
-		return nil
+// PasteFolder adds a copy of a folder of any collection to a target folder. When link
+// is true, the requests of the copy are linked to the requests of the original.
+func (c *CollectionFile) PasteFolder(original *Folder, targetFolderPath []string, link bool) *Folder {
+	var duplicate *Folder
+	if link {
+		duplicate = cloneFolder(original, LinkRequest)
+	} else {
+		duplicate = copyFolder(original)
+		duplicate.Name = original.Name + " (copy)"
 	}
 
-	duplicate := copyFolder(original)
-	duplicate.Name = original.Name + " (copy)"
-
 	// Add to target folder
 	if len(targetFolderPath) == 0 {
 		c.Folders = append(c.Folders, *duplicate)
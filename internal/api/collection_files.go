@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// unsafeFileChars matches the characters of a request ID left out of its file name
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// RequestsDir returns the directory holding the request files of the collection file at
+// path when it has SplitRequests: the path without its extension, "shop/" for "shop.json"
+func RequestsDir(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path))
+}
+
+// requestFileName returns the name of the file of the request with id. Names follow
+// the ID rather than the request name, so renaming a request does not move its file.
+func requestFileName(id string) string {
+	return unsafeFileChars.ReplaceAllString(id, "_") + ".json"
+}
+
+// marshalCollectionJSON encodes a collection or a request file: indented, without HTML
+// escaping, with a final newline
+func marshalCollectionJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalUnescaped encodes v like json.Marshal, leaving characters such as & unescaped.
+// Encoders escape them again when asked to.
+func marshalUnescaped(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// saveRequestFiles writes each request of collection to its file in RequestsDir(path),
+// removes the files of requests it no longer has, and returns a copy of collection
+// listing the files in place of the requests
+func saveRequestFiles(collection *CollectionFile, path string) (*CollectionFile, error) {
+	dir := RequestsDir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create requests directory: %w", err)
+	}
+	index := *collection
+	index.Folders = copyFolders(collection.Folders)
+	index.Requests = append([]CollectionRequest(nil), collection.Requests...)
+
+	written := make(map[string]bool)
+	var err error
+	walkCollectionRequests(index.Folders, index.Requests, nil, func(_ []string, req *CollectionRequest) {
+		if err != nil {
+			return
+		}
+		name := requestFileName(req.ID)
+		if written[name] {
+			err = fmt.Errorf("two requests would be saved in %s", name)
+			return
+		}
+		written[name] = true
+		data, marshalErr := marshalCollectionJSON(req)
+		if marshalErr != nil {
+			err = fmt.Errorf("failed to marshal request %s: %w", req.Name, marshalErr)
+			return
+		}
+		if writeErr := os.WriteFile(filepath.Join(dir, name), data, 0644); writeErr != nil {
+			err = fmt.Errorf("failed to write request file: %w", writeErr)
+			return
+		}
+		*req = CollectionRequest{ID: req.ID, Name: req.Name, File: filepath.Base(dir) + "/" + name}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := removeRequestFiles(dir, written); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// copyFolders returns a copy of folders whose requests can be replaced without changing
+// those of folders
+func copyFolders(folders []Folder) []Folder {
+	if folders == nil {
+		return nil
+	}
+	copied := make([]Folder, len(folders))
+	for i, folder := range folders {
+		copied[i] = folder
+		copied[i].Folders = copyFolders(folder.Folders)
+		copied[i].Requests = append([]CollectionRequest(nil), folder.Requests...)
+	}
+	return copied
+}
+
+// removeRequestFiles removes the request files of dir but those named in keep, and dir
+// itself once it is empty
+func removeRequestFiles(dir string, keep map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read requests directory: %w", err)
+	}
+	left := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") || keep[entry.Name()] {
+			left++
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove request file: %w", err)
+		}
+	}
+	if left == 0 {
+		_ = os.Remove(dir) // Left in place if something else was added meanwhile
+	}
+	return nil
+}
+
+// loadRequestFiles replaces the requests of collection listed by their file with the
+// content of the file, relative to the collection file at path
+func loadRequestFiles(collection *CollectionFile, path string) error {
+	var err error
+	walkCollectionRequests(collection.Folders, collection.Requests, nil, func(_ []string, req *CollectionRequest) {
+		if err != nil || req.File == "" {
+			return
+		}
+		file, pathErr := requestFilePath(path, req.File)
+		if pathErr != nil {
+			err = pathErr
+			return
+		}
+		data, readErr := os.ReadFile(file)
+		if readErr != nil {
+			err = fmt.Errorf("failed to read request file: %w", readErr)
+			return
+		}
+		var loaded CollectionRequest
+		if parseErr := json.Unmarshal(data, &loaded); parseErr != nil {
+			err = fmt.Errorf("failed to parse request file %s: %w", req.File, parseErr)
+			return
+		}
+		loaded.File = ""
+		*req = loaded
+	})
+	return err
+}
+
+// requestFilePath returns the path of a request file listed in the collection file at
+// path. Files must be in the requests directory of the collection, so a collection
+// cannot read files elsewhere.
+func requestFilePath(path, file string) (string, error) {
+	name := filepath.FromSlash(file)
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("request file %s must be relative to the collection file", file)
+	}
+	full := filepath.Join(filepath.Dir(path), name)
+	rel, err := filepath.Rel(RequestsDir(path), full)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("request file %s is outside the requests directory %s", file, filepath.Base(RequestsDir(path)))
+	}
+	return full, nil
+}
+
+// SetSplitRequests saves the collection with each request in its own file, or back in
+// the collection file, removing the request files
+func (c *CollectionFile) SetSplitRequests(split bool) error {
+	c.SplitRequests = split
+	if err := c.Save(); err != nil {
+		return err
+	}
+	if split {
+		return nil
+	}
+	return removeRequestFiles(RequestsDir(c.FilePath), nil)
+}
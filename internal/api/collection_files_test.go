@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveCollection_Stable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shop.json")
+	col := &CollectionFile{
+		Name:      "Shop",
+		Variables: map[string]string{"page": "1", "base": "https://shop.example.com", "limit": "20"},
+		Requests: []CollectionRequest{{
+			ID: "search", Name: "Search", Method: GET, URL: "{{base}}/search?q=<shoes>&page={{page}}",
+			Body: &BodyConfig{Type: "json", Content: map[string]interface{}{"z": 1, "a": 2}},
+		}},
+	}
+	if err := SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(path)
+	loaded, err := LoadCollection(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveCollection(loaded, path); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(path)
+
+	if string(first) != string(second) {
+		t.Errorf("saving a loaded collection should not change its file:\n%s\n%s", first, second)
+	}
+	if !strings.Contains(string(first), "?q=<shoes>&page=") || !strings.HasSuffix(string(first), "}\n") {
+		t.Errorf("the file should keep & and <> as is and end with a newline:\n%s", first)
+	}
+	if strings.Index(string(first), `"base"`) > strings.Index(string(first), `"limit"`) {
+		t.Errorf("map keys should be sorted:\n%s", first)
+	}
+}
+
+func TestSaveCollection_SplitRequests(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shop.json")
+	col := &CollectionFile{
+		Name:     "Shop",
+		Requests: []CollectionRequest{{ID: "list", Name: "List orders", Method: GET, URL: "https://shop.example.com/orders"}},
+		Folders: []Folder{{Name: "Carts", Requests: []CollectionRequest{
+			{ID: "cart/new", Name: "New cart", Method: POST, URL: "https://shop.example.com/carts"},
+		}}},
+		FilePath: path,
+	}
+	if err := col.SetSplitRequests(true); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	var index struct {
+		Requests []map[string]interface{} `json:"requests"`
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Requests) != 1 || index.Requests[0]["file"] != "shop/list.json" || index.Requests[0]["url"] != nil {
+		t.Errorf("the collection file should list the request files, got %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "shop", "cart_new.json")); err != nil {
+		t.Errorf("requests of folders should have their file: %v", err)
+	}
+	if col.Requests[0].URL == "" || col.Requests[0].File != "" {
+		t.Error("saving should not change the collection")
+	}
+
+	loaded, err := LoadCollection(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req := loaded.FindRequest("cart/new"); req == nil || req.URL != "https://shop.example.com/carts" || req.File != "" {
+		t.Fatalf("loading should read the request files, got %+v", req)
+	}
+
+	// Files of removed requests are removed
+	loaded.DeleteRequest("cart/new")
+	if err := loaded.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "shop", "cart_new.json")); !os.IsNotExist(err) {
+		t.Error("the file of a removed request should be removed")
+	}
+
+	// Back to a single file
+	if err := loaded.SetSplitRequests(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "shop")); !os.IsNotExist(err) {
+		t.Error("the request files should be removed")
+	}
+	if loaded, err := LoadCollection(path); err != nil || loaded.FindRequest("list") == nil || loaded.FindRequest("list").URL == "" {
+		t.Errorf("the collection file should hold the requests again, got %v", err)
+	}
+}
+
+func TestLoadCollection_RequestFileOutside(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret.json")
+	if err := os.WriteFile(secret, []byte(`{"id": "secret", "url": "https://evil.example.com"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "shop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "shop", "list.json"), []byte(`{"id": "list", "url": "https://shop.example.com/orders"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "in the requests directory", file: "shop/list.json"},
+		{name: "cleaned into the requests directory", file: "shop/../shop/list.json"},
+		{name: "parent directory", file: "../secret.json", wantErr: true},
+		{name: "next to the collection", file: "secret.json", wantErr: true},
+		{name: "escaping the requests directory", file: "shop/../secret.json", wantErr: true},
+		{name: "absolute", file: filepath.ToSlash(secret), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "shop.json")
+			data := `{"name": "Shop", "requests": [{"id": "x", "file": "` + tt.file + `"}]}`
+			if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			col, err := LoadCollection(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCollection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && col.FindRequest("list") == nil {
+				t.Errorf("the request file should be loaded, got %+v", col.Requests)
+			}
+		})
+	}
+}
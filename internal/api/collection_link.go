@@ -0,0 +1,85 @@
+package api
+
+import "slices"
+
+// LinkRequest returns a new request linked to original: it shares the content of
+// original (method, URL, headers, body, auth, scripts...) and only has its own ID and
+// name. A link to a linked request links to its original.
+func LinkRequest(original *CollectionRequest) *CollectionRequest {
+	target := original.ID
+	if original.Link != "" {
+		target = original.Link
+	}
+	link := &CollectionRequest{ID: GenerateID(), Name: original.Name, Link: target}
+	linkTo(link, original)
+	return link
+}
+
+// linkTo fills a linked request with the content of original; the run settings stay
+// the link's own
+func linkTo(link, original *CollectionRequest) {
+	resolved := CopyRequest(original)
+	resolved.ID, resolved.Name, resolved.Link = link.ID, link.Name, link.Link
+	resolved.SkipInRuns = link.SkipInRuns
+	*link = *resolved
+}
+
+// ResolveLinks fills the linked requests of collections with the content of the
+// requests they link to, searched in all collections. Returns the linked requests
+// whose original was not found; they keep their link and stay empty.
+func ResolveLinks(collections []*CollectionFile) []*CollectionRequest {
+	originals := make(map[string]*CollectionRequest)
+	var links []*CollectionRequest
+	for _, col := range collections {
+		walkCollectionRequests(col.Folders, col.Requests, nil, func(_ []string, req *CollectionRequest) {
+			if req.Link != "" {
+				links = append(links, req)
+			} else if _, exists := originals[req.ID]; !exists {
+				originals[req.ID] = req
+			}
+		})
+	}
+
+	var broken []*CollectionRequest
+	for _, link := range links {
+		original, ok := originals[link.Link]
+		if !ok {
+			broken = append(broken, link)
+			continue
+		}
+		linkTo(link, original)
+	}
+	return broken
+}
+
+// UnlinkRequests turns the requests linked to the requests with ids into independent
+// copies, before they are deleted. Returns the collections holding them, to save.
+func UnlinkRequests(collections []*CollectionFile, ids ...string) []*CollectionFile {
+	var changed []*CollectionFile
+	for _, col := range collections {
+		unlinked := false
+		walkCollectionRequests(col.Folders, col.Requests, nil, func(_ []string, req *CollectionRequest) {
+			if req.Link != "" && slices.Contains(ids, req.Link) {
+				req.Link = ""
+				unlinked = true
+			}
+		})
+		if unlinked {
+			changed = append(changed, col)
+		}
+	}
+	return changed
+}
+
+// LinkCount returns the number of requests of collections linked to the request with id
+func LinkCount(collections []*CollectionFile, id string) int {
+	count := 0
+	for _, col := range collections {
+		walkCollectionRequests(col.Folders, col.Requests, nil, func(_ []string, req *CollectionRequest) {
+			if req.Link == id {
+				count++
+			}
+		})
+	}
+	return count
+}
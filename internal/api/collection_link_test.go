@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newLoginRequest returns a shared login request, as linked to by test suites
+func newLoginRequest() *CollectionRequest {
+	return &CollectionRequest{
+		ID:      "req_login",
+		Name:    "Login",
+		Method:  POST,
+		URL:     "{{base_url}}/auth/login",
+		Headers: []KeyValueEntry{{Key: "Content-Type", Value: "application/json", Enabled: true}},
+		Body:    &BodyConfig{Type: "json", Content: `{"user": "{{user}}"}`},
+	}
+}
+
+func TestPasteRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     bool
+		wantName string
+		wantLink string
+	}{
+		{name: "copy", link: false, wantName: "Login (copy)", wantLink: ""},
+		{name: "link", link: true, wantName: "Login", wantLink: "req_login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := newLoginRequest()
+			suite := &CollectionFile{Name: "Suite", Folders: []Folder{{Name: "Setup"}}}
+
+			pasted := suite.PasteRequest(original, []string{"Setup"}, tt.link)
+			if pasted == nil || len(suite.Folders[0].Requests) != 1 {
+				t.Fatalf("PasteRequest() = %v, folder has %d requests", pasted, len(suite.Folders[0].Requests))
+			}
+			if pasted.ID == original.ID {
+				t.Error("pasted request should have a new ID")
+			}
+			if pasted.Name != tt.wantName || pasted.Link != tt.wantLink {
+				t.Errorf("pasted name, link = %q, %q, want %q, %q", pasted.Name, pasted.Link, tt.wantName, tt.wantLink)
+			}
+			if pasted.URL != original.URL || pasted.Body.Content != original.Body.Content {
+				t.Errorf("pasted content = %s %s, want the original's", pasted.URL, pasted.Body.Content)
+			}
+
+			// The content is copied, not shared with the original object
+			pasted.Headers[0].Value = "text/plain"
+			if original.Headers[0].Value != "application/json" {
+				t.Error("changing the pasted request changed the original")
+			}
+		})
+	}
+}
+
+func TestLinkRequest_ToLinkedRequest(t *testing.T) {
+	link := LinkRequest(newLoginRequest())
+	if got := LinkRequest(link).Link; got != "req_login" {
+		t.Errorf("link of a link = %q, want the original req_login", got)
+	}
+}
+
+func TestPasteFolder(t *testing.T) {
+	auth := &CollectionFile{Name: "Auth", Folders: []Folder{{
+		Name:     "Session",
+		Requests: []CollectionRequest{*newLoginRequest(), {ID: "req_logout", Name: "Logout", Method: POST, URL: "/logout"}},
+	}}}
+	suite := &CollectionFile{Name: "Suite"}
+
+	pasted := suite.PasteFolder(&auth.Folders[0], nil, true)
+	if pasted == nil || pasted.Name != "Session" || len(suite.Folders) != 1 {
+		t.Fatalf("PasteFolder() = %v", pasted)
+	}
+	for i, want := range []string{"req_login", "req_logout"} {
+		if got := suite.Folders[0].Requests[i].Link; got != want {
+			t.Errorf("request %d links to %q, want %q", i, got, want)
+		}
+	}
+
+	copied := suite.PasteFolder(&auth.Folders[0], nil, false)
+	if copied.Name != "Session (copy)" || copied.Requests[0].Link != "" {
+		t.Errorf("copied folder = %q with link %q, want an unlinked copy", copied.Name, copied.Requests[0].Link)
+	}
+}
+
+func TestResolveLinks(t *testing.T) {
+	dir := t.TempDir()
+	auth := &CollectionFile{Name: "Auth", Requests: []CollectionRequest{*newLoginRequest()}}
+	suite := &CollectionFile{Name: "Suite", Folders: []Folder{{Name: "Setup"}}}
+	suite.PasteRequest(&auth.Requests[0], []string{"Setup"}, true)
+	suite.Requests = append(suite.Requests, CollectionRequest{ID: "req_broken", Name: "Broken", Link: "req_deleted"})
+
+	if err := SaveCollection(auth, filepath.Join(dir, "auth.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveCollection(suite, filepath.Join(dir, "suite.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The original changes after the link was made
+	auth.Requests[0].URL = "{{base_url}}/v2/auth/login"
+	if err := SaveCollection(auth, filepath.Join(dir, "auth.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	collections, err := LoadAllCollections(dir)
+	if err != nil {
+		t.Fatalf("LoadAllCollections() error = %v", err)
+	}
+	var loaded *CollectionFile
+	for _, col := range collections {
+		if col.Name == "Suite" {
+			loaded = col
+		}
+	}
+	link := loaded.Folders[0].Requests[0]
+	if link.Method != POST || link.URL != "{{base_url}}/v2/auth/login" || link.Body == nil {
+		t.Errorf("linked request = %s %s, want the current content of the original", link.Method, link.URL)
+	}
+	if link.Name != "Login" || link.Link != "req_login" || link.ID == "req_login" {
+		t.Errorf("linked request lost its own identity: %+v", link)
+	}
+
+	broken := ResolveLinks(collections)
+	if len(broken) != 1 || broken[0].ID != "req_broken" {
+		t.Errorf("ResolveLinks() broken = %v, want req_broken", broken)
+	}
+}
+
+func TestCollectionRequest_MarshalJSON_Link(t *testing.T) {
+	link := LinkRequest(newLoginRequest())
+	data, err := json.Marshal(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"method"`, `"url"`, `"headers"`, `"body"`} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("linked request JSON %s should not hold %s", data, field)
+		}
+	}
+
+	var decoded CollectionRequest
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != link.ID || decoded.Name != "Login" || decoded.Link != "req_login" {
+		t.Errorf("decoded linked request = %+v", decoded)
+	}
+
+	data, _ = json.Marshal(newLoginRequest())
+	if !strings.Contains(string(data), `"url"`) {
+		t.Errorf("request JSON %s should hold its URL", data)
+	}
+}
+
+func TestUnlinkRequests(t *testing.T) {
+	auth := &CollectionFile{Name: "Auth", Requests: []CollectionRequest{*newLoginRequest()}}
+	suite := &CollectionFile{Name: "Suite"}
+	suite.PasteRequest(&auth.Requests[0], nil, true)
+	other := &CollectionFile{Name: "Other", Requests: []CollectionRequest{{ID: "req_other", Name: "Other", Method: GET, URL: "/"}}}
+	collections := []*CollectionFile{auth, suite, other}
+
+	if got := LinkCount(collections, "req_login"); got != 1 {
+		t.Errorf("LinkCount() = %d, want 1", got)
+	}
+
+	changed := UnlinkRequests(collections, "req_login")
+	if len(changed) != 1 || changed[0] != suite {
+		t.Fatalf("UnlinkRequests() changed %v, want the Suite collection", changed)
+	}
+	unlinked := suite.Requests[0]
+	if unlinked.Link != "" || unlinked.URL != "{{base_url}}/auth/login" {
+		t.Errorf("unlinked request = %+v, want a copy of the original", unlinked)
+	}
+	if got := LinkCount(collections, "req_login"); got != 0 {
+		t.Errorf("LinkCount() after unlinking = %d, want 0", got)
+	}
+}
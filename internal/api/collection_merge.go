@@ -0,0 +1,406 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// MergeConflict is a value of a collection changed differently on both sides of a
+// three-way merge
+type MergeConflict struct {
+	Path   string // Location in the collection, such as folders[name=Users].requests[id=abc].url
+	Reason string
+}
+
+// String describes the conflict
+func (c MergeConflict) String() string {
+	return c.Path + ": " + c.Reason
+}
+
+// Reasons of merge conflicts
+const (
+	conflictChanged        = "changed on both sides"
+	conflictDeletedOurs    = "deleted in ours, changed in theirs"
+	conflictDeletedTheirs  = "changed in ours, deleted in theirs"
+	conflictAddedDifferent = "added on both sides with different values"
+	conflictMoved          = "moved to different folders on both sides"
+)
+
+// mergeListKeys are the fields identifying the entries of a list, tried in order:
+// requests have an id, folders, jobs and required variables a name
+var mergeListKeys = []string{"id", "name"}
+
+// MergeCollections merges the changes made to the collection file base in ours and in
+// theirs, the way a git merge driver does. The files are compared as collections, not
+// as text: requests are matched by ID wherever they moved and folders by name, and
+// changes to different fields of the same request merge cleanly. A value changed on
+// both sides is a conflict; the merged collection keeps ours, or theirs when ours
+// deleted it. base may be empty when both sides added the file.
+func MergeCollections(base, ours, theirs []byte) ([]byte, []MergeConflict, error) {
+	baseValue, err := decodeMergeCollection(base, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("base: %w", err)
+	}
+	oursValue, err := decodeMergeCollection(ours, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ours: %w", err)
+	}
+	theirsValue, err := decodeMergeCollection(theirs, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("theirs: %w", err)
+	}
+
+	m := &collectionMerge{}
+	m.mergeRequests(baseValue, oursValue, theirsValue)
+	merged, _ := m.merge("", mergeValue{baseValue, baseValue != nil}, mergeValue{oursValue, true}, mergeValue{theirsValue, true})
+
+	// Read the merged value back as a collection, for the field order of saved files
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var collection CollectionFile
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, nil, fmt.Errorf("merged collection: %w", err)
+	}
+	data, err = json.MarshalIndent(&collection, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, m.conflicts, nil
+}
+
+// decodeMergeCollection reads a collection file as generic JSON values, through
+// CollectionFile so that both sides use the current format. Empty data is nil when
+// allowEmpty is set.
+func decodeMergeCollection(data []byte, allowEmpty bool) (interface{}, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		if allowEmpty {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("empty collection file")
+	}
+	var collection CollectionFile
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("not a collection: %w", err)
+	}
+	normalized, err := json.Marshal(&collection)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// mergeValue is a JSON value of one side of a merge, ok false when the side has none
+type mergeValue struct {
+	v  interface{}
+	ok bool
+}
+
+// collectionMerge collects the conflicts of a merge
+type collectionMerge struct {
+	conflicts []MergeConflict
+}
+
+// locatedRequest is a request of a collection, with the path of the list holding it
+type locatedRequest struct {
+	list  string
+	value interface{}
+}
+
+// mergeRequests merges the content of each request first, wherever each side put it,
+// so that merging the folders only has to place it. Every side then holds the merged
+// request; only the side that moved it keeps it elsewhere than base, ours when both
+// did. Nothing is done when two requests of a side share an ID: they are merged within
+// their folder.
+func (m *collectionMerge) mergeRequests(base, ours, theirs interface{}) {
+	baseRequests, oursRequests, theirsRequests := locateRequests(base), locateRequests(ours), locateRequests(theirs)
+	if baseRequests == nil || oursRequests == nil || theirsRequests == nil {
+		return
+	}
+
+	var ids []string
+	for _, requests := range []map[string]locatedRequest{baseRequests, oursRequests, theirsRequests} {
+		for id := range requests {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	merged := make(map[string]interface{}, len(ids))
+	dropBase, dropTheirs := make(map[string]bool), make(map[string]bool)
+	for _, id := range ids {
+		b, bok := baseRequests[id]
+		o, ook := oursRequests[id]
+		t, tok := theirsRequests[id]
+		list := o.list
+		if !ook {
+			list = t.list
+		}
+		value, ok := m.merge(fmt.Sprintf("%s[id=%s]", list, id), mergeValue{b.value, bok}, mergeValue{o.value, ook}, mergeValue{t.value, tok})
+		switch {
+		case !ok:
+			// Deleted, from every side
+			dropBase[id], dropTheirs[id] = true, true
+			continue
+		case bok && (!ook || !tok):
+			// Kept though one side deleted it: the other side added it back
+			dropBase[id] = true
+		case ook && tok && o.list != t.list && (!bok || (o.list != b.list && t.list != b.list)):
+			m.conflict(fmt.Sprintf("%s[id=%s]", o.list, id), conflictMoved)
+			dropTheirs[id] = true
+		}
+		merged[id] = value
+	}
+
+	replace := func(collection interface{}, drop map[string]bool) {
+		rewriteRequests(collection, func(id string) (interface{}, bool) {
+			value, ok := merged[id]
+			return value, ok && !drop[id]
+		})
+	}
+	replace(base, dropBase)
+	replace(ours, nil)
+	replace(theirs, dropTheirs)
+}
+
+// locateRequests indexes the requests of a collection by ID, nil when two requests
+// share an ID
+func locateRequests(collection interface{}) map[string]locatedRequest {
+	requests := make(map[string]locatedRequest)
+	var walk func(fields map[string]interface{}, path string) bool
+	walk = func(fields map[string]interface{}, path string) bool {
+		list := joinMergePath(path, "requests")
+		entries, _ := fields["requests"].([]interface{})
+		for _, entry := range entries {
+			id := entryKey(entry, "id")
+			if _, ok := requests[id]; ok || id == "" {
+				return false
+			}
+			requests[id] = locatedRequest{list: list, value: entry}
+		}
+		folders, _ := fields["folders"].([]interface{})
+		for _, folder := range folders {
+			folderFields, _ := folder.(map[string]interface{})
+			if !walk(folderFields, fmt.Sprintf("%s[name=%s]", joinMergePath(path, "folders"), entryKey(folder, "name"))) {
+				return false
+			}
+		}
+		return true
+	}
+	fields, _ := collection.(map[string]interface{})
+	if !walk(fields, "") {
+		return nil
+	}
+	return requests
+}
+
+// rewriteRequests replaces each request of a collection with the value replace returns
+// for its ID, removing it when replace returns false
+func rewriteRequests(collection interface{}, replace func(id string) (interface{}, bool)) {
+	fields, ok := collection.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if entries, ok := fields["requests"].([]interface{}); ok {
+		var rewritten []interface{}
+		for _, entry := range entries {
+			if value, ok := replace(entryKey(entry, "id")); ok {
+				rewritten = append(rewritten, value)
+			}
+		}
+		fields["requests"] = rewritten
+	}
+	folders, _ := fields["folders"].([]interface{})
+	for _, folder := range folders {
+		rewriteRequests(folder, replace)
+	}
+}
+
+// joinMergePath returns the path of field in the object at path
+func joinMergePath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// merge merges the values of ours and theirs at path, and returns whether the merged
+// value exists
+func (m *collectionMerge) merge(path string, base, ours, theirs mergeValue) (interface{}, bool) {
+	switch {
+	case ours.ok == theirs.ok && reflect.DeepEqual(ours.v, theirs.v):
+		return ours.v, ours.ok
+	case ours.ok == base.ok && reflect.DeepEqual(ours.v, base.v):
+		return theirs.v, theirs.ok
+	case theirs.ok == base.ok && reflect.DeepEqual(theirs.v, base.v):
+		return ours.v, ours.ok
+	}
+
+	if ours.ok && theirs.ok {
+		oursMap, oursIsMap := ours.v.(map[string]interface{})
+		theirsMap, theirsIsMap := theirs.v.(map[string]interface{})
+		baseMap, baseIsMap := base.v.(map[string]interface{})
+		if oursIsMap && theirsIsMap && (baseIsMap || !base.ok) {
+			return m.mergeObject(path, baseMap, oursMap, theirsMap), true
+		}
+		oursList, oursIsList := ours.v.([]interface{})
+		theirsList, theirsIsList := theirs.v.([]interface{})
+		baseList, baseIsList := base.v.([]interface{})
+		if oursIsList && theirsIsList && (baseIsList || !base.ok) {
+			if key, ok := listKey(baseList, oursList, theirsList); ok {
+				return m.mergeList(path, key, baseList, oursList, theirsList), true
+			}
+		}
+	}
+
+	// Conflict: keep ours, unless ours deleted the value
+	switch {
+	case !ours.ok:
+		m.conflict(path, conflictDeletedOurs)
+		return theirs.v, true
+	case !theirs.ok:
+		m.conflict(path, conflictDeletedTheirs)
+	case !base.ok:
+		m.conflict(path, conflictAddedDifferent)
+	default:
+		m.conflict(path, conflictChanged)
+	}
+	return ours.v, true
+}
+
+// conflict records a conflict at path
+func (m *collectionMerge) conflict(path, reason string) {
+	if path == "" {
+		path = "collection"
+	}
+	m.conflicts = append(m.conflicts, MergeConflict{Path: path, Reason: reason})
+}
+
+// mergeObject merges the fields of a JSON object; base is nil when both sides added it
+func (m *collectionMerge) mergeObject(path string, base, ours, theirs map[string]interface{}) map[string]interface{} {
+	keys := make(map[string]bool)
+	for _, fields := range []map[string]interface{}{base, ours, theirs} {
+		for key := range fields {
+			keys[key] = true
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	merged := make(map[string]interface{}, len(names))
+	for _, key := range names {
+		b, bok := base[key]
+		o, ook := ours[key]
+		t, tok := theirs[key]
+		if value, ok := m.merge(joinMergePath(path, key), mergeValue{b, bok}, mergeValue{o, ook}, mergeValue{t, tok}); ok {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// mergeList merges the entries of a list, matched by their key field. The merged list
+// follows the order of ours; entries added in theirs follow the entry before them in
+// theirs.
+func (m *collectionMerge) mergeList(path, key string, base, ours, theirs []interface{}) []interface{} {
+	baseEntries, oursEntries, theirsEntries := entriesByKey(base, key), entriesByKey(ours, key), entriesByKey(theirs, key)
+	mergeEntry := func(id string) (interface{}, bool) {
+		b, bok := baseEntries[id]
+		o, ook := oursEntries[id]
+		t, tok := theirsEntries[id]
+		return m.merge(fmt.Sprintf("%s[%s=%s]", path, key, id), mergeValue{b, bok}, mergeValue{o, ook}, mergeValue{t, tok})
+	}
+
+	var keys []string
+	var merged []interface{}
+	for _, entry := range ours {
+		id := entryKey(entry, key)
+		if value, ok := mergeEntry(id); ok {
+			keys = append(keys, id)
+			merged = append(merged, value)
+		}
+	}
+
+	theirsKeys := make([]string, len(theirs))
+	for i, entry := range theirs {
+		theirsKeys[i] = entryKey(entry, key)
+	}
+	for i, id := range theirsKeys {
+		if _, ok := oursEntries[id]; ok {
+			continue
+		}
+		value, ok := mergeEntry(id)
+		if !ok {
+			continue
+		}
+		at := 0
+		for j := i - 1; j >= 0; j-- {
+			if index := slices.Index(keys, theirsKeys[j]); index >= 0 {
+				at = index + 1
+				break
+			}
+		}
+		keys = slices.Insert(keys, at, id)
+		merged = slices.Insert(merged, at, value)
+	}
+	return merged
+}
+
+// listKey returns the field identifying the entries of lists: one that every entry has,
+// unique within each list
+func listKey(lists ...[]interface{}) (string, bool) {
+	for _, key := range mergeListKeys {
+		if slices.IndexFunc(lists, func(list []interface{}) bool { return !uniqueKeys(list, key) }) < 0 {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// uniqueKeys reports whether every entry of list has a distinct string value for key
+func uniqueKeys(list []interface{}, key string) bool {
+	seen := make(map[string]bool, len(list))
+	for _, entry := range list {
+		id := entryKey(entry, key)
+		if id == "" || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// entryKey returns the value of the key field of a list entry, "" when it has none
+func entryKey(entry interface{}, key string) string {
+	fields, ok := entry.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	id, _ := fields[key].(string)
+	return id
+}
+
+// entriesByKey indexes the entries of a list by their key field
+func entriesByKey(list []interface{}, key string) map[string]interface{} {
+	entries := make(map[string]interface{}, len(list))
+	for _, entry := range list {
+		entries[entryKey(entry, key)] = entry
+	}
+	return entries
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMergeCollections(t *testing.T) {
+	base := `{
+		"name": "Shop",
+		"folders": [{"name": "Users", "requests": [
+			{"id": "list", "name": "List users", "method": "GET", "url": "{{base}}/users"},
+			{"id": "create", "name": "Create user", "method": "POST", "url": "{{base}}/users"}
+		]}],
+		"requests": [{"id": "health", "name": "Health", "method": "GET", "url": "{{base}}/health"}]
+	}`
+	// Ours renames a request, changes a URL, adds a request and moves health into Users
+	ours := `{
+		"name": "Shop",
+		"folders": [{"name": "Users", "requests": [
+			{"id": "list", "name": "List all users", "method": "GET", "url": "{{base}}/users"},
+			{"id": "create", "name": "Create user", "method": "POST", "url": "{{base}}/v2/users"},
+			{"id": "health", "name": "Health", "method": "GET", "url": "{{base}}/health"},
+			{"id": "delete", "name": "Delete user", "method": "DELETE", "url": "{{base}}/users/1"}
+		]}]
+	}`
+	// Theirs changes the method and the URL of other requests, adds a folder and a request
+	theirs := `{
+		"name": "Shop",
+		"folders": [
+			{"name": "Users", "requests": [
+				{"id": "list", "name": "List users", "method": "GET", "url": "{{base}}/users?page=1"},
+				{"id": "get", "name": "Get user", "method": "GET", "url": "{{base}}/users/1"},
+				{"id": "create", "name": "Create user", "method": "PUT", "url": "{{base}}/users"}
+			]},
+			{"name": "Orders", "requests": [{"id": "orders", "name": "Orders", "method": "GET", "url": "{{base}}/orders"}]}
+		],
+		"requests": [{"id": "health", "name": "Health", "method": "GET", "url": "{{base}}/health"}]
+	}`
+
+	data, conflicts, err := MergeCollections([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeCollections() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %v, want none", conflicts)
+	}
+	var merged CollectionFile
+	if err := json.Unmarshal(data, &merged); err != nil {
+		t.Fatalf("merged collection is invalid: %v\n%s", err, data)
+	}
+
+	if len(merged.Requests) != 0 {
+		t.Errorf("root requests = %+v, want health moved into Users", merged.Requests)
+	}
+	if len(merged.Folders) != 2 || merged.Folders[1].Name != "Orders" {
+		t.Fatalf("folders = %+v, want Users and Orders", merged.Folders)
+	}
+	var got []string
+	for _, req := range merged.Folders[0].Requests {
+		got = append(got, req.ID+" "+string(req.Method)+" "+req.Name+" "+req.URL)
+	}
+	want := []string{
+		"list GET List all users {{base}}/users?page=1",
+		"get GET Get user {{base}}/users/1",
+		"create PUT Create user {{base}}/v2/users",
+		"health GET Health {{base}}/health",
+		"delete DELETE Delete user {{base}}/users/1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Users requests =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestMergeCollections_Conflicts(t *testing.T) {
+	base := `{"name": "Shop", "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/a"},
+		{"id": "b", "name": "B", "method": "GET", "url": "/b"}
+	]}`
+	ours := `{"name": "Shop", "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/ours"}
+	]}`
+	theirs := `{"name": "Shop", "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/theirs"},
+		{"id": "b", "name": "B", "method": "GET", "url": "/b2"}
+	]}`
+
+	data, conflicts, err := MergeCollections([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeCollections() error = %v", err)
+	}
+	var reasons []string
+	for _, conflict := range conflicts {
+		reasons = append(reasons, conflict.String())
+	}
+	want := "requests[id=a].url: changed on both sides; requests[id=b]: deleted in ours, changed in theirs"
+	if got := strings.Join(reasons, "; "); got != want {
+		t.Errorf("conflicts = %q, want %q", got, want)
+	}
+
+	// Ours is kept, and the request ours deleted but theirs changed is kept
+	var merged CollectionFile
+	if err := json.Unmarshal(data, &merged); err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Requests) != 2 || merged.Requests[0].URL != "/ours" || merged.Requests[1].URL != "/b2" {
+		t.Errorf("requests = %+v", merged.Requests)
+	}
+
+	if _, _, err := MergeCollections([]byte(base), []byte("<<<<<<< ours"), []byte(theirs)); err == nil {
+		t.Error("MergeCollections() of a file that is not a collection should fail")
+	}
+
+	// Without a base, both sides added the collection
+	if _, conflicts, _ := MergeCollections(nil, []byte(ours), []byte(theirs)); len(conflicts) != 1 || conflicts[0].Reason != conflictAddedDifferent {
+		t.Errorf("conflicts without base = %v", conflicts)
+	}
+}
+
+func TestMergeCollections_MovedAndEdited(t *testing.T) {
+	base := `{"name": "Shop", "folders": [{"name": "Users"}], "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/a"}
+	]}`
+	// Ours moves the request into Users, theirs changes its URL where it was
+	ours := `{"name": "Shop", "folders": [{"name": "Users", "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/a"}
+	]}]}`
+	theirs := `{"name": "Shop", "folders": [{"name": "Users"}], "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/a2"}
+	]}`
+
+	for _, tt := range []struct{ name, ours, theirs string }{
+		{"moved in ours", ours, theirs},
+		{"moved in theirs", theirs, ours},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, conflicts, err := MergeCollections([]byte(base), []byte(tt.ours), []byte(tt.theirs))
+			if err != nil {
+				t.Fatalf("MergeCollections() error = %v", err)
+			}
+			if len(conflicts) != 0 {
+				t.Errorf("conflicts = %v, want none", conflicts)
+			}
+			var merged CollectionFile
+			if err := json.Unmarshal(data, &merged); err != nil {
+				t.Fatal(err)
+			}
+			if len(merged.Requests) != 0 || len(merged.Folders) != 1 || len(merged.Folders[0].Requests) != 1 {
+				t.Fatalf("merged collection = %s, want the request in Users only", data)
+			}
+			if url := merged.Folders[0].Requests[0].URL; url != "/a2" {
+				t.Errorf("URL = %q, want /a2", url)
+			}
+		})
+	}
+
+	// Moved to different folders on both sides: ours wins
+	both := `{"name": "Shop", "folders": [{"name": "Users"}, {"name": "Admin", "requests": [
+		{"id": "a", "name": "A", "method": "GET", "url": "/a"}
+	]}]}`
+	data, conflicts, err := MergeCollections([]byte(base), []byte(ours), []byte(both))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Reason != conflictMoved {
+		t.Errorf("conflicts = %v, want a move conflict", conflicts)
+	}
+	if strings.Count(string(data), `"id": "a"`) != 1 {
+		t.Errorf("merged collection = %s, want the request once", data)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TakeRequest removes the request with id from the collection and returns it, with its
+// ID, to insert elsewhere. Returns nil when there is no such request.
+func (c *CollectionFile) TakeRequest(id string) *CollectionRequest {
+	req := c.FindRequest(id)
+	if req == nil {
+		return nil
+	}
+	taken := *req
+	c.DeleteRequest(id)
+	dropRunOrderKey(&c.RunOrder, c.Folders, id)
+	return &taken
+}
+
+// InsertRequest adds a request taken from a collection to the folder at folderPath, or
+// to the collection root for an empty path, after its requests
+func (c *CollectionFile) InsertRequest(req *CollectionRequest, folderPath []string) error {
+	if len(folderPath) == 0 {
+		c.Requests = append(c.Requests, *req)
+		return nil
+	}
+	folder := c.findFolder(c.Folders, folderPath, 0)
+	if folder == nil {
+		return fmt.Errorf("folder not found: %v", folderPath)
+	}
+	folder.Requests = append(folder.Requests, *req)
+	return nil
+}
+
+// TakeFolder removes the folder name of the folder at folderPath from the collection and
+// returns it with its requests, to insert elsewhere. Returns nil when there is no such
+// folder.
+func (c *CollectionFile) TakeFolder(folderPath []string, name string) *Folder {
+	folder := c.FindFolderByName(folderPath, name)
+	if folder == nil {
+		return nil
+	}
+	taken := *folder
+	c.DeleteFolder(folderPath, name)
+	if _, _, order := c.runLevel(folderPath); order != nil {
+		*order = slices.DeleteFunc(*order, func(key string) bool { return key == name })
+	}
+	return &taken
+}
+
+// InsertFolder adds a folder taken from a collection to the folder at folderPath, or to
+// the collection root for an empty path, after its sub-folders. Folder names are unique
+// within a folder.
+func (c *CollectionFile) InsertFolder(folder *Folder, folderPath []string) error {
+	folders := &c.Folders
+	if len(folderPath) > 0 {
+		parent := c.findFolder(c.Folders, folderPath, 0)
+		if parent == nil {
+			return fmt.Errorf("folder not found: %v", folderPath)
+		}
+		folders = &parent.Folders
+	}
+	for _, f := range *folders {
+		if f.Name == folder.Name {
+			return fmt.Errorf("a folder named %q already exists there", folder.Name)
+		}
+	}
+	*folders = append(*folders, *folder)
+	return nil
+}
+
+// MoveSibling moves the sub-folder named key, or the request with ID key, by offset
+// positions among the sub-folders or the requests of the folder at folderPath, stopping
+// at the first and last positions. This order is the tree order, used by runs and
+// exports unless the folder has a run order.
+func (c *CollectionFile) MoveSibling(folderPath []string, key string, folder bool, offset int) bool {
+	folders, requests := &c.Folders, &c.Requests
+	if len(folderPath) > 0 {
+		parent := c.findFolder(c.Folders, folderPath, 0)
+		if parent == nil {
+			return false
+		}
+		folders, requests = &parent.Folders, &parent.Requests
+	}
+	if folder {
+		return moveSibling(*folders, func(f Folder) bool { return f.Name == key }, offset)
+	}
+	return moveSibling(*requests, func(r CollectionRequest) bool { return r.ID == key }, offset)
+}
+
+// moveSibling moves the first item of items that match returns true for by offset
+// positions, stopping at the ends. Returns false when no item matches.
+func moveSibling[T any](items []T, match func(T) bool, offset int) bool {
+	from := slices.IndexFunc(items, match)
+	if from < 0 {
+		return false
+	}
+	to := min(max(from+offset, 0), len(items)-1)
+	item := items[from]
+	if to < from {
+		copy(items[to+1:from+1], items[to:from])
+	} else {
+		copy(items[from:to], items[from+1:to+1])
+	}
+	items[to] = item
+	return true
+}
+
+// dropRunOrderKey removes a request ID from a run order and the run orders of folders
+// and their sub-folders
+func dropRunOrderKey(order *[]string, folders []Folder, id string) {
+	*order = slices.DeleteFunc(*order, func(key string) bool { return key == id })
+	for i := range folders {
+		dropRunOrderKey(&folders[i].RunOrder, folders[i].Folders, id)
+	}
+}
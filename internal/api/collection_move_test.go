@@ -0,0 +1,97 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveSibling(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		folder bool
+		offset int
+		want   []string
+	}{
+		{name: "up", key: "req_c", offset: -1, want: []string{"req_a", "req_c", "req_b"}},
+		{name: "down", key: "req_a", offset: 1, want: []string{"req_b", "req_a", "req_c"}},
+		{name: "past the first", key: "req_b", offset: -3, want: []string{"req_b", "req_a", "req_c"}},
+		{name: "past the last", key: "req_a", offset: 3, want: []string{"req_b", "req_c", "req_a"}},
+		{name: "folder", key: "Users", folder: true, offset: -1, want: []string{"req_a", "req_b", "req_c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &CollectionFile{Folders: []Folder{{
+				Name:     "API",
+				Folders:  []Folder{{Name: "Orders"}, {Name: "Users"}},
+				Requests: []CollectionRequest{{ID: "req_a"}, {ID: "req_b"}, {ID: "req_c"}},
+			}}}
+			if !col.MoveSibling([]string{"API"}, tt.key, tt.folder, tt.offset) {
+				t.Fatalf("MoveSibling(%q) = false", tt.key)
+			}
+			api := col.Folders[0]
+			var ids []string
+			for _, r := range api.Requests {
+				ids = append(ids, r.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("requests = %v, want %v", ids, tt.want)
+			}
+			if tt.folder && (api.Folders[0].Name != "Users" || api.Folders[1].Name != "Orders") {
+				t.Errorf("folders = %+v, want Users first", api.Folders)
+			}
+		})
+	}
+
+	col := &CollectionFile{}
+	if col.MoveSibling([]string{"Missing"}, "req_a", false, 1) || col.MoveSibling(nil, "req_a", false, 1) {
+		t.Error("MoveSibling() of a missing request = true")
+	}
+}
+
+func TestTakeAndInsert(t *testing.T) {
+	col := &CollectionFile{
+		RunOrder: []string{"req_health", "Users"},
+		Folders: []Folder{
+			{Name: "Users", RunOrder: []string{"req_login", "Admin"}, Folders: []Folder{{Name: "Admin"}},
+				Requests: []CollectionRequest{{ID: "req_login", Name: "Login"}}},
+			{Name: "Orders"},
+		},
+		Requests: []CollectionRequest{{ID: "req_health", Name: "Health"}},
+	}
+
+	req := col.TakeRequest("req_login")
+	if req == nil || req.ID != "req_login" || col.FindRequest("req_login") != nil {
+		t.Fatalf("TakeRequest() = %+v, want the request removed with its ID", req)
+	}
+	if order := col.Folders[0].RunOrder; !reflect.DeepEqual(order, []string{"Admin"}) {
+		t.Errorf("RunOrder of Users = %v, want the request dropped", order)
+	}
+	if err := col.InsertRequest(req, []string{"Orders"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(col.Folders[1].Requests) != 1 || col.Folders[1].Requests[0].ID != "req_login" {
+		t.Errorf("Orders requests = %+v, want req_login", col.Folders[1].Requests)
+	}
+	if err := col.InsertRequest(req, []string{"Missing"}); err == nil {
+		t.Error("InsertRequest() into a missing folder should fail")
+	}
+
+	folder := col.TakeFolder([]string{"Users"}, "Admin")
+	if folder == nil || col.FindFolderByName([]string{"Users"}, "Admin") != nil {
+		t.Fatal("TakeFolder() should remove the folder")
+	}
+	if len(col.Folders[0].RunOrder) != 0 {
+		t.Errorf("RunOrder of Users = %v, want the folder dropped", col.Folders[0].RunOrder)
+	}
+	if err := col.InsertFolder(folder, nil); err != nil {
+		t.Fatal(err)
+	}
+	if col.FindFolderByName(nil, "Admin") == nil {
+		t.Error("InsertFolder() should add the folder at the root")
+	}
+	if err := col.InsertFolder(&Folder{Name: "Orders"}, nil); err == nil {
+		t.Error("InsertFolder() should refuse a duplicate name")
+	}
+}
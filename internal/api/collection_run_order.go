@@ -0,0 +1,134 @@
+package api
+
+import "slices"
+
+// RunEntry is a sub-folder or a request of a folder, in run order
+type RunEntry struct {
+	Folder  *Folder            // Set for a sub-folder
+	Request *CollectionRequest // Set for a request
+}
+
+// Key returns the run order key of the entry: the folder name or the request ID
+func (e RunEntry) Key() string {
+	if e.Folder != nil {
+		return e.Folder.Name
+	}
+	return e.Request.ID
+}
+
+// Name returns the folder or request name
+func (e RunEntry) Name() string {
+	if e.Folder != nil {
+		return e.Folder.Name
+	}
+	return e.Request.Name
+}
+
+// matches reports whether a run order key designates the entry. Requests match
+// their ID or, in hand-written run orders, their name.
+func (e RunEntry) matches(key string) bool {
+	if e.Folder != nil {
+		return e.Folder.Name == key
+	}
+	return e.Request.ID == key || e.Request.Name == key
+}
+
+// RunEntries returns the sub-folders and requests of a folder in run order: the
+// entries listed by order first, then the others in tree order (sub-folders first).
+// Skipped requests are included; keys matching no entry are ignored.
+func RunEntries(folders []Folder, requests []CollectionRequest, order []string) []RunEntry {
+	entries := make([]RunEntry, 0, len(folders)+len(requests))
+	for i := range folders {
+		entries = append(entries, RunEntry{Folder: &folders[i]})
+	}
+	for i := range requests {
+		entries = append(entries, RunEntry{Request: &requests[i]})
+	}
+
+	rank := func(e RunEntry) int {
+		if i := slices.IndexFunc(order, e.matches); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(entries, func(a, b RunEntry) int {
+		return rank(a) - rank(b)
+	})
+	return entries
+}
+
+// RunEntriesAt returns the run entries of the folder at folderPath, or of the
+// collection root for an empty path. ok is false when the folder does not exist.
+func (c *CollectionFile) RunEntriesAt(folderPath []string) (entries []RunEntry, ok bool) {
+	folders, requests, order := c.runLevel(folderPath)
+	if order == nil {
+		return nil, false
+	}
+	return RunEntries(folders, requests, *order), true
+}
+
+// runLevel returns the sub-folders, requests and run order of the folder at folderPath,
+// or of the collection root. order is nil when the folder does not exist.
+func (c *CollectionFile) runLevel(folderPath []string) (folders []Folder, requests []CollectionRequest, order *[]string) {
+	if len(folderPath) == 0 {
+		return c.Folders, c.Requests, &c.RunOrder
+	}
+	folder := c.findFolder(c.Folders, folderPath, 0)
+	if folder == nil {
+		return nil, nil, nil
+	}
+	return folder.Folders, folder.Requests, &folder.RunOrder
+}
+
+// MoveInRunOrder moves the entry with key by offset positions in the run order of the
+// folder at folderPath, stopping at the first and last positions. The run order then
+// lists every entry of the folder.
+func (c *CollectionFile) MoveInRunOrder(folderPath []string, key string, offset int) bool {
+	folders, requests, order := c.runLevel(folderPath)
+	if order == nil {
+		return false
+	}
+	entries := RunEntries(folders, requests, *order)
+	from := slices.IndexFunc(entries, func(e RunEntry) bool { return e.Key() == key })
+	if from < 0 {
+		return false
+	}
+
+	to := min(max(from+offset, 0), len(entries)-1)
+	entry := entries[from]
+	entries = slices.Insert(slices.Delete(entries, from, from+1), to, entry)
+
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key()
+	}
+	*order = keys
+	return true
+}
+
+// ClearRunOrder makes the folder at folderPath run in tree order again
+func (c *CollectionFile) ClearRunOrder(folderPath []string) bool {
+	_, _, order := c.runLevel(folderPath)
+	if order == nil {
+		return false
+	}
+	*order = nil
+	return true
+}
+
+// SetSkipInRuns sets whether the request with id is left out of collection runs
+func (c *CollectionFile) SetSkipInRuns(id string, skip bool) bool {
+	req := c.FindRequest(id)
+	if req == nil {
+		return false
+	}
+	req.SkipInRuns = skip
+	return true
+}
+
+// renameRunOrderKey replaces the key of a renamed folder in a run order
+func renameRunOrderKey(order []string, oldName, newName string) {
+	if i := slices.Index(order, oldName); i >= 0 {
+		order[i] = newName
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveInRunOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		offset int
+		want   []string
+	}{
+		{name: "up", key: "req_login", offset: -1, want: []string{"Users", "req_login", "req_health"}},
+		{name: "down", key: "Users", offset: 1, want: []string{"req_health", "Users", "req_login"}},
+		{name: "first", key: "req_login", offset: -3, want: []string{"req_login", "Users", "req_health"}},
+		{name: "last", key: "Users", offset: 3, want: []string{"req_health", "req_login", "Users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &CollectionFile{
+				Folders:  []Folder{{Name: "Users"}},
+				Requests: []CollectionRequest{{ID: "req_health", Name: "Health"}, {ID: "req_login", Name: "Login"}},
+			}
+			if !col.MoveInRunOrder(nil, tt.key, tt.offset) {
+				t.Fatalf("MoveInRunOrder(%q) = false", tt.key)
+			}
+			if !reflect.DeepEqual(col.RunOrder, tt.want) {
+				t.Errorf("RunOrder = %v, want %v", col.RunOrder, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunOrder_FolderChanges(t *testing.T) {
+	col := &CollectionFile{Folders: []Folder{{
+		Name:     "Users",
+		Folders:  []Folder{{Name: "Admin"}, {Name: "Setup"}},
+		Requests: []CollectionRequest{{ID: "req_list", Name: "List"}},
+	}}}
+
+	if col.MoveInRunOrder([]string{"Missing"}, "Setup", -1) {
+		t.Error("MoveInRunOrder() in a missing folder = true")
+	}
+	if !col.MoveInRunOrder([]string{"Users"}, "Setup", -1) {
+		t.Fatal("MoveInRunOrder() = false")
+	}
+	col.RenameFolder([]string{"Users"}, "Setup", "Fixtures")
+
+	entries, ok := col.RunEntriesAt([]string{"Users"})
+	if !ok {
+		t.Fatal("RunEntriesAt() ok = false")
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"Fixtures", "Admin", "List"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("run entries after rename = %v, want %v", names, want)
+	}
+
+	col.ClearRunOrder([]string{"Users"})
+	if col.Folders[0].RunOrder != nil {
+		t.Errorf("RunOrder after ClearRunOrder() = %v", col.Folders[0].RunOrder)
+	}
+}
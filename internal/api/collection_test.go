@@ -343,6 +343,33 @@ func TestAddRequest(t *testing.T) {
 	}
 }
 
+func TestAddRequestAfter(t *testing.T) {
+	collection := &CollectionFile{
+		Name:     "Test",
+		Requests: []CollectionRequest{{ID: "top", Name: "Top"}},
+		Folders: []Folder{
+			{
+				Name: "Folder 1",
+				Requests: []CollectionRequest{
+					{ID: "req1", Name: "Request 1"},
+					{ID: "req2", Name: "Request 2"},
+				},
+			},
+		},
+	}
+
+	collection.AddRequestAfter("req1", &CollectionRequest{Name: "Follow-up"})
+	folder := collection.Folders[0].Requests
+	if len(folder) != 3 || folder[1].Name != "Follow-up" || folder[1].ID == "" {
+		t.Errorf("Expected the request after req1 with an ID, got %+v", folder)
+	}
+
+	collection.AddRequestAfter("missing", &CollectionRequest{Name: "Orphan"})
+	if len(collection.Requests) != 2 || collection.Requests[1].Name != "Orphan" {
+		t.Errorf("Expected the request at the top level, got %+v", collection.Requests)
+	}
+}
+
 func TestCreateFolder(t *testing.T) {
 	collection := &CollectionFile{
 		Name:    "Test",
@@ -425,6 +452,28 @@ func TestUpdateRequestURLNested(t *testing.T) {
 	}
 }
 
+func TestUpdateRequestBodyBinaryFormats(t *testing.T) {
+	// msgpack and cbor bodies are authored as JSON and stored as JSON objects
+	for _, bodyType := range []string{"msgpack", "cbor"} {
+		collection := &CollectionFile{
+			Name:     "Test",
+			Requests: []CollectionRequest{{ID: "req1", Name: "Request 1", Method: POST, URL: "http://test.com"}},
+		}
+
+		if !collection.UpdateRequestBody("req1", bodyType, `{"id": 1}`) {
+			t.Fatalf("Expected UpdateRequestBody to return true for %s", bodyType)
+		}
+
+		body := collection.Requests[0].Body
+		if body == nil || body.Type != bodyType {
+			t.Fatalf("Expected body type %s, got %+v", bodyType, body)
+		}
+		if _, ok := body.Content.(map[string]interface{}); !ok {
+			t.Errorf("Expected %s content to be stored as a JSON object, got %T", bodyType, body.Content)
+		}
+	}
+}
+
 func TestValidateCollection(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -489,3 +538,26 @@ func TestValidateCollection(t *testing.T) {
 		})
 	}
 }
+
+func TestIsolatedSession(t *testing.T) {
+	tests := []struct {
+		name              string
+		session           string
+		isolatedByDefault bool
+		want              bool
+	}{
+		{name: "follows the workspace (shared)", session: "", isolatedByDefault: false, want: false},
+		{name: "follows the workspace (isolated)", session: "", isolatedByDefault: true, want: true},
+		{name: "isolated collection", session: SessionIsolated, isolatedByDefault: false, want: true},
+		{name: "shared collection", session: SessionShared, isolatedByDefault: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &CollectionFile{Name: "Tenant A", Session: tt.session}
+			if got := col.IsolatedSession(tt.isolatedByDefault); got != tt.want {
+				t.Errorf("IsolatedSession(%v) = %v, want %v", tt.isolatedByDefault, got, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ type ConsoleEntry struct {
 	Error     error
 	Duration  time.Duration
 	Status    ConsoleEntryStatus
+	Source    *CollectionRequest // Request before variable substitution (nil if unknown)
+	Variables *VariableSnapshot  // Variable values the request was sent with
+	Logs      []ConsoleLogEntry  // Console output of its scripts, pre-request then post-response
+	Hook      string             // Environment whose activation hook the entry logs, "" for requests
 }
 
 // NewConsoleEntry creates a new console entry from a completed request
@@ -98,13 +102,7 @@ func (e *ConsoleEntry) FormatDuration() string {
 	if e.Error != nil {
 		return "Err"
 	}
-	if e.Duration < time.Millisecond {
-		return fmt.Sprintf("%dµs", e.Duration.Microseconds())
-	}
-	if e.Duration < time.Second {
-		return fmt.Sprintf("%dms", e.Duration.Milliseconds())
-	}
-	return fmt.Sprintf("%.1fs", e.Duration.Seconds())
+	return DefaultUnits.Duration(e.Duration, 1)
 }
 
 // FormatSize returns human-readable response size (e.g., "2.4KB", "1.2MB")
@@ -112,17 +110,7 @@ func (e *ConsoleEntry) FormatSize() string {
 	if e.Response == nil {
 		return "-"
 	}
-	size := e.Response.Size
-	if size < 0 {
-		return "-"
-	}
-	if size < 1024 {
-		return fmt.Sprintf("%dB", size)
-	}
-	if size < 1024*1024 {
-		return fmt.Sprintf("%.1fKB", float64(size)/1024)
-	}
-	return fmt.Sprintf("%.1fMB", float64(size)/(1024*1024))
+	return FormatSize(e.Response.Size)
 }
 
 // CopyHeaders returns formatted headers string for clipboard
@@ -146,12 +134,13 @@ func (e *ConsoleEntry) CopyHeaders() string {
 	return sb.String()
 }
 
-// CopyBody returns response body for clipboard
+// CopyBody returns response body for clipboard.
+// Binary bodies are replaced by a short summary.
 func (e *ConsoleEntry) CopyBody() string {
 	if e.Response == nil {
 		return ""
 	}
-	return e.Response.Body
+	return BodyText(e.Response.ContentType(), e.Response.Body)
 }
 
 // CopyError returns formatted error message for clipboard
@@ -199,6 +188,18 @@ func (e *ConsoleEntry) CopyInfo() string {
 	return strings.TrimSpace(sb.String())
 }
 
+// SentWith describes the environment and variable values the request was sent with
+// (e.g. "dev: base_url=https://api.example.com token=***"), or "" when none were used
+func (e *ConsoleEntry) SentWith() string {
+	if e.Variables.IsEmpty() {
+		return ""
+	}
+	if e.Variables.Environment == "" {
+		return e.Variables.Summary()
+	}
+	return e.Variables.Environment + ": " + e.Variables.Summary()
+}
+
 // CopyAll returns complete request/response for clipboard
 func (e *ConsoleEntry) CopyAll() string {
 	var sb strings.Builder
@@ -207,6 +208,9 @@ func (e *ConsoleEntry) CopyAll() string {
 	sb.WriteString("=== REQUEST ===\n")
 	if e.Request != nil {
 		sb.WriteString(fmt.Sprintf("%s %s\n", e.Request.Method, e.Request.URL))
+		if sentWith := e.SentWith(); sentWith != "" {
+			sb.WriteString(fmt.Sprintf("Sent with: %s\n", sentWith))
+		}
 		if e.Request.Headers != nil {
 			sb.WriteString("\nHeaders:\n")
 			for key, value := range e.Request.Headers {
@@ -214,7 +218,7 @@ func (e *ConsoleEntry) CopyAll() string {
 			}
 		}
 		if e.Request.Body != nil {
-			sb.WriteString(fmt.Sprintf("\nBody:\n%v\n", e.Request.Body))
+			sb.WriteString(fmt.Sprintf("\nBody:\n%s\n", e.Request.BodyText()))
 		}
 	}
 
@@ -233,8 +237,8 @@ func (e *ConsoleEntry) CopyAll() string {
 				sb.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
 			}
 		}
-		if e.Response.Body != "" {
-			sb.WriteString(fmt.Sprintf("\nBody:\n%s\n", e.Response.Body))
+		if len(e.Response.Body) > 0 {
+			sb.WriteString(fmt.Sprintf("\nBody:\n%s\n", BodyText(e.Response.ContentType(), e.Response.Body)))
 		}
 	}
 
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ConsoleLogLevels lists the log levels, in the order they are shown
+var ConsoleLogLevels = []ConsoleLogLevel{LogLevelLog, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelDebug}
+
+// ParseConsoleLogLevels parses a comma-separated list of log levels, such as "warn,error"
+func ParseConsoleLogLevels(text string) ([]ConsoleLogLevel, error) {
+	var levels []ConsoleLogLevel
+	for _, name := range strings.Split(text, ",") {
+		level := ConsoleLogLevel(strings.ToLower(strings.TrimSpace(name)))
+		if level == "" {
+			continue
+		}
+		if !slices.Contains(ConsoleLogLevels, level) {
+			return nil, fmt.Errorf("unknown log level %q (log, info, warn, error or debug)", name)
+		}
+		if !slices.Contains(levels, level) {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no log level")
+	}
+	return levels, nil
+}
+
+// Level returns the log level of the request itself: error for failed requests and
+// server errors, warn for client errors, info otherwise
+func (e *ConsoleEntry) Level() ConsoleLogLevel {
+	switch e.Status {
+	case StatusNetworkError, StatusServerError:
+		return LogLevelError
+	case StatusClientError:
+		return LogLevelWarn
+	default:
+		return LogLevelInfo
+	}
+}
+
+// ConsoleFilter selects the console entries shown; zero fields do not filter
+type ConsoleFilter struct {
+	Levels  []ConsoleLogLevel // Levels of the requests or script output shown
+	Request string            // Text of the request name or URL, ignoring case
+	Text    string            // Text of the request, response status or script output, ignoring case
+}
+
+// IsZero returns true if the filter shows every entry
+func (f ConsoleFilter) IsZero() bool {
+	return len(f.Levels) == 0 && f.Request == "" && f.Text == ""
+}
+
+// String describes the filter, such as `level warn,error · request "users"`
+func (f ConsoleFilter) String() string {
+	var parts []string
+	if len(f.Levels) > 0 {
+		names := make([]string, len(f.Levels))
+		for i, level := range f.Levels {
+			names[i] = string(level)
+		}
+		parts = append(parts, "level "+strings.Join(names, ","))
+	}
+	if f.Request != "" {
+		parts = append(parts, "request "+strconv.Quote(f.Request))
+	}
+	if f.Text != "" {
+		parts = append(parts, "search "+strconv.Quote(f.Text))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Match reports whether the filter shows entry: a request of one of the levels or with
+// script output of one of them, matching the request and text filters
+func (f ConsoleFilter) Match(entry *ConsoleEntry) bool {
+	if f.Request != "" && !containsFold(entry.requestText(), f.Request) {
+		return false
+	}
+	if len(f.Levels) > 0 && !slices.Contains(f.Levels, entry.Level()) && len(f.levelLogs(entry.Logs)) == 0 {
+		return false
+	}
+	if f.Text == "" {
+		return true
+	}
+	if containsFold(entry.summaryText(), f.Text) {
+		return true
+	}
+	return slices.ContainsFunc(f.levelLogs(entry.Logs), func(log ConsoleLogEntry) bool {
+		return containsFold(log.Message, f.Text)
+	})
+}
+
+// Logs returns the script output of entry shown with the filter: the lines of its
+// levels, all of them when the text filter matches the request
+func (f ConsoleFilter) Logs(entry *ConsoleEntry) []ConsoleLogEntry {
+	logs := f.levelLogs(entry.Logs)
+	if f.Text == "" || containsFold(entry.summaryText(), f.Text) {
+		return logs
+	}
+	var matching []ConsoleLogEntry
+	for _, log := range logs {
+		if containsFold(log.Message, f.Text) {
+			matching = append(matching, log)
+		}
+	}
+	return matching
+}
+
+// levelLogs returns the lines of logs of the filter's levels
+func (f ConsoleFilter) levelLogs(logs []ConsoleLogEntry) []ConsoleLogEntry {
+	if len(f.Levels) == 0 {
+		return logs
+	}
+	var shown []ConsoleLogEntry
+	for _, log := range logs {
+		if slices.Contains(f.Levels, log.Level) {
+			shown = append(shown, log)
+		}
+	}
+	return shown
+}
+
+// requestText returns the name and URL of the request of the entry
+func (e *ConsoleEntry) requestText() string {
+	var parts []string
+	if e.Source != nil {
+		parts = append(parts, e.Source.Name)
+	}
+	if e.Request != nil {
+		parts = append(parts, e.Request.URL)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// summaryText returns the text of a console row: request, status and error
+func (e *ConsoleEntry) summaryText() string {
+	parts := []string{e.requestText()}
+	if e.Request != nil {
+		parts = append(parts, string(e.Request.Method))
+	}
+	if e.Response != nil {
+		parts = append(parts, e.Response.Status)
+	}
+	if e.Error != nil {
+		parts = append(parts, e.Error.Error())
+	}
+	return strings.Join(parts, "\n")
+}
+
+// containsFold reports whether text contains substr, ignoring case
+func containsFold(text, substr string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(substr))
+}
+
+// Filter returns the entries shown with f, newest first (thread-safe)
+func (h *ConsoleHistory) Filter(f ConsoleFilter) []ConsoleEntry {
+	entries := h.GetReversed()
+	if f.IsZero() {
+		return entries
+	}
+	var shown []ConsoleEntry
+	for i := range entries {
+		if f.Match(&entries[i]) {
+			shown = append(shown, entries[i])
+		}
+	}
+	return shown
+}
+
+// AddLogs appends script console output to the entry with id (thread-safe)
+func (h *ConsoleHistory) AddLogs(id string, logs []ConsoleLogEntry) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.entries {
+		if h.entries[i].ID == id {
+			h.entries[i].Logs = append(slices.Clip(h.entries[i].Logs), logs...)
+			return true
+		}
+	}
+	return false
+}
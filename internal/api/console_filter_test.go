@@ -0,0 +1,90 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsoleHistory_Filter(t *testing.T) {
+	history := NewConsoleHistory(10)
+	add := func(url string, status int, logs ...ConsoleLogEntry) string {
+		entry := NewConsoleEntry(&Request{Method: GET, URL: url}, &Response{StatusCode: status, Status: "status"}, nil, time.Millisecond)
+		entry.Logs = logs
+		return history.Add(*entry)
+	}
+	users := add("http://api.test/users", 200,
+		ConsoleLogEntry{Level: LogLevelLog, Message: "token refreshed"},
+		ConsoleLogEntry{Level: LogLevelWarn, Message: "slow response"})
+	missing := add("http://api.test/orders/42", 404)
+	failed := history.Add(*NewConsoleEntry(&Request{Method: GET, URL: "http://api.test/health"}, nil, errString("connection refused"), 0))
+
+	ids := func(f ConsoleFilter) []string {
+		var got []string
+		for _, entry := range history.Filter(f) {
+			got = append(got, entry.ID)
+		}
+		return got
+	}
+	equal := func(got []string, want ...string) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if got := ids(ConsoleFilter{}); !equal(got, failed, missing, users) {
+		t.Errorf("no filter = %v, want all entries newest first", got)
+	}
+	// Requests by their own level, or by the level of their script output
+	if got := ids(ConsoleFilter{Levels: []ConsoleLogLevel{LogLevelWarn}}); !equal(got, missing, users) {
+		t.Errorf("level warn = %v", got)
+	}
+	if got := ids(ConsoleFilter{Levels: []ConsoleLogLevel{LogLevelError}}); !equal(got, failed) {
+		t.Errorf("level error = %v", got)
+	}
+	if got := ids(ConsoleFilter{Request: "ORDERS"}); !equal(got, missing) {
+		t.Errorf("request = %v", got)
+	}
+	if got := ids(ConsoleFilter{Text: "refused"}); !equal(got, failed) {
+		t.Errorf("search error = %v", got)
+	}
+	if got := ids(ConsoleFilter{Text: "token", Levels: []ConsoleLogLevel{LogLevelWarn}}); len(got) != 0 {
+		t.Errorf("search outside the levels = %v, want none", got)
+	}
+
+	entry, _ := history.Get(users)
+	if logs := (ConsoleFilter{Levels: []ConsoleLogLevel{LogLevelWarn}}).Logs(entry); len(logs) != 1 || logs[0].Message != "slow response" {
+		t.Errorf("Logs() at warn = %+v", logs)
+	}
+	if logs := (ConsoleFilter{Text: "token"}).Logs(entry); len(logs) != 1 || logs[0].Message != "token refreshed" {
+		t.Errorf("Logs() matching the search = %+v", logs)
+	}
+	if logs := (ConsoleFilter{Text: "users"}).Logs(entry); len(logs) != 2 {
+		t.Errorf("Logs() of a matching request = %+v, want all", logs)
+	}
+
+	if !history.AddLogs(missing, []ConsoleLogEntry{{Level: LogLevelError, Message: "expected 200"}}) {
+		t.Fatal("AddLogs() did not find the entry")
+	}
+	if got := ids(ConsoleFilter{Text: "expected"}); !equal(got, missing) {
+		t.Errorf("search of added logs = %v", got)
+	}
+}
+
+func TestParseConsoleLogLevels(t *testing.T) {
+	levels, err := ParseConsoleLogLevels("WARN, error,warn")
+	if err != nil || len(levels) != 2 || levels[0] != LogLevelWarn || levels[1] != LogLevelError {
+		t.Errorf("ParseConsoleLogLevels() = %v, %v", levels, err)
+	}
+	if _, err := ParseConsoleLogLevels("verbose"); err == nil {
+		t.Error("ParseConsoleLogLevels() should fail for an unknown level")
+	}
+	if _, err := ParseConsoleLogLevels(" , "); err == nil {
+		t.Error("ParseConsoleLogLevels() should fail without a level")
+	}
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HistoryRetention limits the console history; zero fields do not limit it
+type HistoryRetention struct {
+	MaxEntries int           // Latest entries kept
+	MaxAge     time.Duration // Entries older than this are dropped
+	MaxBytes   int64         // Response bodies held, the latest entry is always kept
+}
+
+// IsZero returns true if the retention does not limit the history
+func (r HistoryRetention) IsZero() bool {
+	return r.MaxEntries <= 0 && r.MaxAge <= 0 && r.MaxBytes <= 0
+}
+
+// HistoryArchiver stores entries before they are dropped from the history
+type HistoryArchiver func(entries []ConsoleEntry) error
+
+// Prune drops the oldest entries beyond the retention, passing them to archive first
+// when it is not nil. Entries are kept when archive fails. Returns the dropped entries,
+// oldest first (thread-safe).
+func (h *ConsoleHistory) Prune(r HistoryRetention, now time.Time, archive HistoryArchiver) ([]ConsoleEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cut := 0
+	if r.MaxAge > 0 {
+		for cut < len(h.entries) && now.Sub(h.entries[cut].Timestamp) > r.MaxAge {
+			cut++
+		}
+	}
+	if r.MaxEntries > 0 && len(h.entries)-cut > r.MaxEntries {
+		cut = len(h.entries) - r.MaxEntries
+	}
+	if r.MaxBytes > 0 {
+		var size int64
+		for _, entry := range h.entries[cut:] {
+			size += entry.bodySize()
+		}
+		for cut < len(h.entries)-1 && size > r.MaxBytes {
+			size -= h.entries[cut].bodySize()
+			cut++
+		}
+	}
+	return h.dropOldest(cut, archive)
+}
+
+// ArchiveBefore drops the entries sent before t, passing them to archive first. Entries
+// are kept when archive fails (thread-safe).
+func (h *ConsoleHistory) ArchiveBefore(t time.Time, archive HistoryArchiver) ([]ConsoleEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cut := 0
+	for cut < len(h.entries) && h.entries[cut].Timestamp.Before(t) {
+		cut++
+	}
+	return h.dropOldest(cut, archive)
+}
+
+// BodySize returns the size of the response bodies held (thread-safe)
+func (h *ConsoleHistory) BodySize() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var size int64
+	for _, entry := range h.entries {
+		size += entry.bodySize()
+	}
+	return size
+}
+
+// dropOldest removes the first n entries once archive stored them; the lock must be held
+func (h *ConsoleHistory) dropOldest(n int, archive HistoryArchiver) ([]ConsoleEntry, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	dropped := make([]ConsoleEntry, n)
+	copy(dropped, h.entries[:n])
+	if archive != nil {
+		if err := archive(dropped); err != nil {
+			return nil, err
+		}
+	}
+	h.entries = append(make([]ConsoleEntry, 0, len(h.entries)-n), h.entries[n:]...)
+	return dropped, nil
+}
+
+// bodySize returns the size of the response body held in memory
+func (e *ConsoleEntry) bodySize() int64 {
+	if e.Response == nil {
+		return 0
+	}
+	return int64(len(e.Response.Body))
+}
+
+// HistoryArchiveDir returns the directory of the console history archives of a workspace
+func HistoryArchiveDir(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", "history")
+}
+
+// ArchivedEntry is a console entry in a history archive
+type ArchivedEntry struct {
+	ID              string              `json:"id"`
+	Timestamp       time.Time           `json:"timestamp"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestHeaders  map[string]string   `json:"request_headers,omitempty"`
+	RequestBody     string              `json:"request_body,omitempty"`
+	StatusCode      int                 `json:"status_code,omitempty"`
+	Status          string              `json:"status,omitempty"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	ResponseBody    string              `json:"response_body,omitempty"`
+	Error           string              `json:"error,omitempty"`
+	DurationMs      int64               `json:"duration_ms"`
+	Logs            []ConsoleLogEntry   `json:"logs,omitempty"`
+	Hook            string              `json:"hook,omitempty"`   // Environment of an activation hook entry
+	Source          *CollectionRequest  `json:"source,omitempty"` // Request before variable substitution
+}
+
+// newArchivedEntry converts a console entry for an archive, with its full response body
+func newArchivedEntry(e ConsoleEntry) ArchivedEntry {
+	var body []byte
+	if e.Response != nil {
+		var err error
+		if body, err = e.Response.FullBody(); err != nil {
+			body = e.Response.Body
+		}
+	}
+	return archivedEntry(e, body)
+}
+
+// archivedEntry converts a console entry, with body as its response body
+func archivedEntry(e ConsoleEntry, body []byte) ArchivedEntry {
+	archived := ArchivedEntry{
+		ID:         e.ID,
+		Timestamp:  e.Timestamp,
+		DurationMs: e.Duration.Milliseconds(),
+		Error:      e.CopyError(),
+		Logs:       e.Logs,
+		Hook:       e.Hook,
+		Source:     e.Source,
+	}
+	if e.Request != nil {
+		archived.Method = string(e.Request.Method)
+		archived.URL = e.Request.URL
+		archived.RequestHeaders = e.Request.Headers
+		if e.Request.Body != nil {
+			archived.RequestBody = e.Request.BodyText()
+		}
+	}
+	if e.Response != nil {
+		archived.StatusCode = e.Response.StatusCode
+		archived.Status = e.Response.Status
+		archived.ResponseHeaders = e.Response.Headers
+		archived.ResponseBody = BodyText(e.Response.ContentType(), body)
+	}
+	return archived
+}
+
+// ArchiveHistory appends entries to the gzipped JSON Lines bundles of dir, one per day
+// the entries were sent (history-2006-01-02.jsonl.gz). Returns the bundles written.
+func ArchiveHistory(dir string, entries []ConsoleEntry) ([]string, error) {
+	byDay := make(map[string][]ConsoleEntry)
+	for _, entry := range entries {
+		day := entry.Timestamp.Format("2006-01-02")
+		byDay[day] = append(byDay[day], entry)
+	}
+	if len(byDay) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create history archive directory: %w", err)
+	}
+
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	var paths []string
+	for _, day := range days {
+		path := filepath.Join(dir, "history-"+day+".jsonl.gz")
+		if err := appendArchive(path, byDay[day]); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// appendArchive adds entries to a bundle as a new gzip member, so earlier ones are
+// left untouched
+func appendArchive(path string, entries []ConsoleEntry) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history archive: %w", err)
+	}
+	zw := gzip.NewWriter(file)
+	enc := json.NewEncoder(zw)
+	for _, entry := range entries {
+		if err := enc.Encode(newArchivedEntry(entry)); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to write history archive: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write history archive: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadHistoryArchive reads the entries of a history archive bundle
+func ReadHistoryArchive(path string) ([]ArchivedEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history archive: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history archive: %w", err)
+	}
+	var entries []ArchivedEntry
+	dec := json.NewDecoder(bufio.NewReader(zr))
+	for {
+		var entry ArchivedEntry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return entries, fmt.Errorf("failed to read history archive: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConsoleHistory_Prune(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	newHistory := func() *ConsoleHistory {
+		h := NewConsoleHistory(10)
+		for i, age := range []time.Duration{48 * time.Hour, 3 * time.Hour, time.Hour, time.Minute} {
+			entry := NewConsoleEntry(&Request{Method: GET, URL: "http://test.com"}, &Response{StatusCode: 200, Body: make([]byte, 100*(i+1))}, nil, time.Millisecond)
+			entry.Timestamp = now.Add(-age)
+			h.Add(*entry)
+		}
+		return h
+	}
+
+	tests := []struct {
+		name      string
+		retention HistoryRetention
+		dropped   int
+	}{
+		{"no limit", HistoryRetention{}, 0},
+		{"max age", HistoryRetention{MaxAge: 2 * time.Hour}, 2},
+		{"max entries", HistoryRetention{MaxEntries: 3}, 1},
+		{"max bytes", HistoryRetention{MaxBytes: 750}, 2},
+		{"latest entry kept", HistoryRetention{MaxBytes: 10}, 3},
+		{"strictest limit", HistoryRetention{MaxAge: 100 * time.Hour, MaxEntries: 3, MaxBytes: 750}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHistory()
+			dropped, err := h.Prune(tt.retention, now, nil)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if len(dropped) != tt.dropped || h.Len() != 4-tt.dropped {
+				t.Errorf("Prune() dropped %d, kept %d, want %d dropped", len(dropped), h.Len(), tt.dropped)
+			}
+		})
+	}
+
+	h := newHistory()
+	if _, err := h.Prune(HistoryRetention{MaxEntries: 1}, now, func([]ConsoleEntry) error { return errors.New("disk full") }); err == nil || h.Len() != 4 {
+		t.Errorf("Prune() should keep the entries when archiving fails, kept %d", h.Len())
+	}
+}
+
+func TestArchiveHistory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	h := NewConsoleHistory(10)
+	day := time.Date(2026, 3, 1, 23, 0, 0, 0, time.Local)
+	for i, url := range []string{"http://test.com/a", "http://test.com/b", "http://test.com/c"} {
+		entry := NewConsoleEntry(&Request{Method: POST, URL: url, Body: `{"n": 1}`}, &Response{StatusCode: 201, Status: "201 Created", Body: []byte(`{"ok": true}`)}, nil, 12*time.Millisecond)
+		entry.Timestamp = day.Add(time.Duration(i) * time.Hour)
+		h.Add(*entry)
+	}
+
+	archive := func(entries []ConsoleEntry) error {
+		_, err := ArchiveHistory(dir, entries)
+		return err
+	}
+	if _, err := h.ArchiveBefore(day.Add(90*time.Minute), archive); err != nil {
+		t.Fatalf("ArchiveBefore() error = %v", err)
+	}
+	if _, err := h.ArchiveBefore(day.Add(24*time.Hour), archive); err != nil {
+		t.Fatalf("ArchiveBefore() error = %v", err)
+	}
+	if !h.IsEmpty() {
+		t.Fatalf("history should be empty, has %d entries", h.Len())
+	}
+
+	first, err := ReadHistoryArchive(filepath.Join(dir, "history-2026-03-01.jsonl.gz"))
+	if err != nil {
+		t.Fatalf("ReadHistoryArchive() error = %v", err)
+	}
+	if len(first) != 1 || first[0].URL != "http://test.com/a" || first[0].Method != "POST" || first[0].StatusCode != 201 ||
+		first[0].RequestBody != `{"n": 1}` || first[0].ResponseBody != `{"ok": true}` || first[0].DurationMs != 12 {
+		t.Errorf("first bundle = %+v", first)
+	}
+	// The second archive appended to the bundle of the next day
+	second, err := ReadHistoryArchive(filepath.Join(dir, "history-2026-03-02.jsonl.gz"))
+	if err != nil || len(second) != 2 || second[1].URL != "http://test.com/c" {
+		t.Errorf("second bundle = %+v, %v", second, err)
+	}
+}
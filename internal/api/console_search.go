@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// historySnippetContext is the number of characters shown on each side of a match
+const historySnippetContext = 30
+
+// HistoryMatch is a console entry whose response body matches a search, with the
+// text around its first match
+type HistoryMatch struct {
+	ID     string // Console entry ID
+	Count  int    // Matches in the body
+	Before string // Text before the first match, on one line
+	Match  string // First match
+	After  string // Text after the first match, on one line
+}
+
+// CompileHistorySearch compiles a search of response bodies: "/expr/" is a regular
+// expression, other text is matched literally, ignoring case
+func CompileHistorySearch(query string) (*regexp.Regexp, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty search")
+	}
+	if len(query) > 2 && strings.HasPrefix(query, "/") && strings.HasSuffix(query, "/") {
+		re, err := regexp.Compile(query[1 : len(query)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid search: %w", err)
+		}
+		return re, nil
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(query)), nil
+}
+
+// Search returns the entries whose response body matches re, newest first (thread-safe)
+func (h *ConsoleHistory) Search(re *regexp.Regexp) []HistoryMatch {
+	var matches []HistoryMatch
+	for _, entry := range h.GetReversed() {
+		if entry.Response == nil {
+			continue
+		}
+		body, err := entry.Response.FullBody()
+		if err != nil || len(body) == 0 {
+			continue
+		}
+		locs := re.FindAllIndex(body, -1)
+		if len(locs) == 0 || locs[0][0] == locs[0][1] {
+			continue
+		}
+		text := string(body)
+		start, end := locs[0][0], locs[0][1]
+		matches = append(matches, HistoryMatch{
+			ID:     entry.ID,
+			Count:  len(locs),
+			Before: snippetBefore(text[:start]),
+			Match:  oneLine(text[start:end]),
+			After:  snippetAfter(text[end:]),
+		})
+	}
+	return matches
+}
+
+// snippetBefore returns the last characters of text, on one line
+func snippetBefore(text string) string {
+	runes := []rune(text)
+	if len(runes) <= historySnippetContext {
+		return oneLine(text)
+	}
+	return "…" + oneLine(string(runes[len(runes)-historySnippetContext:]))
+}
+
+// snippetAfter returns the first characters of text, on one line
+func snippetAfter(text string) string {
+	runes := []rune(text)
+	if len(runes) <= historySnippetContext {
+		return oneLine(text)
+	}
+	return oneLine(string(runes[:historySnippetContext])) + "…"
+}
+
+// whitespaceRun matches the line breaks and runs of whitespace folded by oneLine
+var whitespaceRun = regexp.MustCompile(`\s+`)
+
+// oneLine replaces the line breaks and runs of whitespace of text with single spaces
+func oneLine(text string) string {
+	return whitespaceRun.ReplaceAllString(text, " ")
+}
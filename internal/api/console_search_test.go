@@ -0,0 +1,48 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleHistory_Search(t *testing.T) {
+	history := NewConsoleHistory(10)
+	add := func(body string) string {
+		entry := NewConsoleEntry(&Request{Method: GET, URL: "http://test.com"}, &Response{StatusCode: 200, Body: []byte(body)}, nil, time.Millisecond)
+		return history.Add(*entry)
+	}
+	older := add(`{"orders": [{"id": "ord_42"}]}`)
+	add(`{"status": "ok"}`)
+	newer := add("{\n  \"user\": \"ada\",\n  \"order\": \"ORD_42\",\n  \"note\": \"" + strings.Repeat("x", 40) + "\"\n}")
+	history.Add(*NewConsoleEntry(&Request{Method: GET, URL: "http://test.com"}, nil, errString("refused"), 0))
+
+	re, err := CompileHistorySearch("ord_42")
+	if err != nil {
+		t.Fatalf("CompileHistorySearch() error = %v", err)
+	}
+	matches := history.Search(re)
+	if len(matches) != 2 || matches[0].ID != newer || matches[1].ID != older {
+		t.Fatalf("Search() = %+v, want the two responses with the ID, newest first", matches)
+	}
+	m := matches[0]
+	if m.Match != "ORD_42" || !strings.HasSuffix(m.Before, `"ada", "order": "`) || !strings.HasPrefix(m.After, `", "note": "xxx`) || !strings.HasSuffix(m.After, "…") {
+		t.Errorf("snippet = %q %q %q", m.Before, m.Match, m.After)
+	}
+
+	re, _ = CompileHistorySearch(`/"id": "ord_\d+"/`)
+	if matches := history.Search(re); len(matches) != 1 || matches[0].ID != older || matches[0].Count != 1 {
+		t.Errorf("regex Search() = %+v", matches)
+	}
+
+	if _, err := CompileHistorySearch("/(/"); err == nil {
+		t.Error("CompileHistorySearch() should fail for an invalid regex")
+	}
+	if _, err := CompileHistorySearch("  "); err == nil {
+		t.Error("CompileHistorySearch() should fail for an empty search")
+	}
+}
+
+type errString string
+
+func (e errString) Error() string { return string(e) }
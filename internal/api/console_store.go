@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryFileName is the name of the console history file in the history directory
+const HistoryFileName = "console.jsonl"
+
+// HistoryFile returns the path of the console history file of a workspace
+func HistoryFile(workspacePath string) string {
+	return filepath.Join(HistoryArchiveDir(workspacePath), HistoryFileName)
+}
+
+// SaveHistory writes the console entries to the history file at path, one JSON line
+// per entry, oldest first. Response bodies are saved as held in memory, so only the
+// beginning of spooled bodies is kept. The file is replaced once fully written.
+func SaveHistory(path string, entries []ConsoleEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".console-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("failed to save console history: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		var body []byte
+		if entry.Response != nil {
+			body = entry.Response.Body
+		}
+		if err := enc.Encode(archivedEntry(entry, body)); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("failed to save console history: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save console history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save console history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save console history: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory reads the console entries of the history file at path, oldest first.
+// A missing file yields no entries.
+func LoadHistory(path string) ([]ConsoleEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read console history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []ConsoleEntry
+	dec := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var archived ArchivedEntry
+		if err := dec.Decode(&archived); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return entries, fmt.Errorf("failed to read console history: %w", err)
+		}
+		entries = append(entries, archived.ConsoleEntry())
+	}
+}
+
+// ConsoleEntry converts an archived entry back to a console entry. Entries read back
+// have no variable snapshot.
+func (a ArchivedEntry) ConsoleEntry() ConsoleEntry {
+	entry := ConsoleEntry{
+		ID:        a.ID,
+		Timestamp: a.Timestamp,
+		Request: &Request{
+			Method:  HTTPMethod(a.Method),
+			URL:     a.URL,
+			Headers: a.RequestHeaders,
+		},
+		Duration: time.Duration(a.DurationMs) * time.Millisecond,
+		Logs:     a.Logs,
+		Hook:     a.Hook,
+		Source:   a.Source,
+	}
+	if a.RequestBody != "" {
+		entry.Request.Body = a.RequestBody
+	}
+	if a.Error != "" {
+		entry.Error = errors.New(a.Error)
+	}
+	if a.StatusCode != 0 {
+		entry.Response = &Response{
+			StatusCode: a.StatusCode,
+			Status:     a.Status,
+			Headers:    a.ResponseHeaders,
+			Body:       []byte(a.ResponseBody),
+			Time:       entry.Duration,
+			Size:       int64(len(a.ResponseBody)),
+		}
+	}
+	entry.Status = entry.computeStatus()
+	if entry.Hook != "" && entry.Error == nil {
+		entry.Status = StatusSuccess
+	}
+	return entry
+}
+
+// Load replaces the entries of the history, oldest first (thread-safe)
+func (h *ConsoleHistory) Load(entries []ConsoleEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(entries) > h.maxSize {
+		entries = entries[len(entries)-h.maxSize:]
+	}
+	h.entries = append(make([]ConsoleEntry, 0, len(entries)), entries...)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveHistory_LoadHistory(t *testing.T) {
+	path := HistoryFile(t.TempDir())
+	if entries, err := LoadHistory(path); err != nil || entries != nil {
+		t.Fatalf("LoadHistory() of a missing file = %v, %v; want no entries", entries, err)
+	}
+
+	sent := NewConsoleEntry(
+		&Request{Method: POST, URL: "http://test.com/orders", Headers: map[string]string{"Content-Type": "application/json"}, Body: `{"qty": 2}`},
+		&Response{StatusCode: 201, Status: "201 Created", Headers: map[string][]string{"Content-Type": {"application/json"}}, Body: []byte(`{"id": "ord_42"}`)},
+		nil, 120*time.Millisecond)
+	sent.Source = &CollectionRequest{ID: "req_1", Name: "Create order", Method: POST, URL: "{{base_url}}/orders"}
+	sent.Logs = []ConsoleLogEntry{{Level: LogLevelWarn, Message: "slow", Timestamp: sent.Timestamp}}
+	failed := NewConsoleEntry(&Request{Method: GET, URL: "http://down.test"}, nil, errors.New("connection refused"), 0)
+	hook := NewHookConsoleEntry("dev", "vault login", nil, nil, time.Second)
+	if err := SaveHistory(path, []ConsoleEntry{*sent, *failed, *hook}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("LoadHistory() = %d entries, want 3", len(entries))
+	}
+	got := entries[0]
+	if got.ID != sent.ID || !got.Timestamp.Equal(sent.Timestamp) || got.Duration != sent.Duration || got.Status != StatusSuccess {
+		t.Errorf("entry = %+v, want %+v", got, sent)
+	}
+	if got.Request.Method != POST || got.Request.BodyText() != `{"qty": 2}` || got.Request.Headers["Content-Type"] != "application/json" {
+		t.Errorf("request = %+v", got.Request)
+	}
+	if got.Response.StatusCode != 201 || string(got.Response.Body) != `{"id": "ord_42"}` || got.Response.ContentType() != "application/json" {
+		t.Errorf("response = %+v", got.Response)
+	}
+	if got.Source == nil || got.Source.ID != "req_1" || got.Source.URL != "{{base_url}}/orders" {
+		t.Errorf("source = %+v", got.Source)
+	}
+	if len(got.Logs) != 1 || got.Logs[0].Message != "slow" {
+		t.Errorf("logs = %+v", got.Logs)
+	}
+	if got := entries[1]; got.Response != nil || got.Error == nil || got.Error.Error() != "connection refused" || got.Status != StatusNetworkError {
+		t.Errorf("failed entry = %+v", got)
+	}
+	if got := entries[2]; got.Hook != "dev" || got.Status != StatusSuccess || got.Request.Method != HookMethod {
+		t.Errorf("hook entry = %+v", got)
+	}
+
+	// Saving again replaces the file
+	if err := SaveHistory(path, []ConsoleEntry{*failed}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := LoadHistory(path); len(entries) != 1 || entries[0].ID != failed.ID {
+		t.Errorf("LoadHistory() after a second save = %+v, want the failed entry only", entries)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".console-*")); len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
+
+func TestConsoleHistory_Load(t *testing.T) {
+	h := NewConsoleHistory(2)
+	h.Add(*NewConsoleEntry(&Request{Method: GET, URL: "http://test.com/old"}, nil, nil, 0))
+	var entries []ConsoleEntry
+	for _, url := range []string{"http://test.com/a", "http://test.com/b", "http://test.com/c"} {
+		entries = append(entries, *NewConsoleEntry(&Request{Method: GET, URL: url}, nil, nil, 0))
+	}
+	h.Load(entries)
+	all := h.GetAll()
+	if len(all) != 2 || all[0].Request.URL != "http://test.com/b" || all[1].Request.URL != "http://test.com/c" {
+		t.Errorf("Load() kept %+v, want the latest 2 entries", all)
+	}
+}
@@ -200,7 +200,7 @@ func TestConsoleEntryCopyHeaders(t *testing.T) {
 
 func TestConsoleEntryCopyBody(t *testing.T) {
 	req := &Request{Method: GET, URL: "http://test.com"}
-	resp := &Response{StatusCode: 200, Body: `{"key": "value"}`}
+	resp := &Response{StatusCode: 200, Body: []byte(`{"key": "value"}`)}
 
 	entry := NewConsoleEntry(req, resp, nil, time.Second)
 	body := entry.CopyBody()
@@ -241,7 +241,7 @@ func TestConsoleEntryCopyAll(t *testing.T) {
 		StatusCode: 201,
 		Status:     "201 Created",
 		Headers:    map[string][]string{"Location": {"/api/1"}},
-		Body:       `{"id": 1}`,
+		Body:       []byte(`{"id": 1}`),
 		Size:       10,
 	}
 
@@ -62,7 +62,13 @@ func GenerateCurlCommandWithOptions(req *CollectionRequest, opts CurlGeneratorOp
 	}
 
 	// Body
-	if req.Body != nil && req.Body.Content != nil {
+	if req.Body != nil && req.Body.Type == BodyTypeFormData {
+		parts = append(parts, formCurlParts(ParseFormData(req.Body.Content), opts.QuoteStyle)...)
+	} else if req.Body != nil && req.Body.Type == BodyTypeBinary {
+		if path, _ := req.Body.Content.(string); path != "" {
+			parts = append(parts, "--data-binary", quote("@"+path, opts.QuoteStyle))
+		}
+	} else if req.Body != nil && req.Body.Content != nil {
 		bodyStr := formatBody(req.Body)
 		if bodyStr != "" {
 			// Use appropriate flag based on body type
@@ -97,6 +103,22 @@ func quote(value, style string) string {
 	return fmt.Sprintf("'%s'", escaped)
 }
 
+// formCurlParts returns the -F flags of the enabled form fields, "key=@path" for files
+func formCurlParts(fields []FormField, quoteStyle string) []string {
+	var parts []string
+	for _, field := range fields {
+		if !field.Enabled || field.Key == "" {
+			continue
+		}
+		value := field.Value
+		if field.IsFile() {
+			value = "@" + value
+		}
+		parts = append(parts, "-F", quote(field.Key+"="+value, quoteStyle))
+	}
+	return parts
+}
+
 // formatBody serializes body content to string
 func formatBody(body *BodyConfig) string {
 	if body == nil || body.Content == nil {
@@ -187,7 +209,11 @@ func GenerateCurlFromRequest(req *Request) string {
 	}
 
 	// Body
-	if req.Body != nil {
+	if form, ok := req.Body.(*MultipartForm); ok {
+		parts = append(parts, formCurlParts(form.Fields, "single")...)
+	} else if file, ok := req.Body.(*FileBody); ok {
+		parts = append(parts, "--data-binary", quote("@"+file.Path, "single"))
+	} else if req.Body != nil {
 		var bodyStr string
 		switch v := req.Body.(type) {
 		case string:
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -511,6 +512,34 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_FormData(t *testing.T) {
+	fields := []FormField{
+		{Key: "name", Value: "Ada", Type: FormFieldText, Enabled: true},
+		{Key: "avatar", Value: "/tmp/a.png", Type: FormFieldFile, Enabled: true},
+		{Key: "name", Value: "Lovelace", Type: FormFieldText, Enabled: true},
+	}
+	req := &CollectionRequest{
+		Method: POST,
+		URL:    "https://example.com/users",
+		Body:   &BodyConfig{Type: BodyTypeFormData, Content: fields},
+	}
+	generated := GenerateCurlCommand(req)
+	if !strings.Contains(generated, "-F 'avatar=@/tmp/a.png'") {
+		t.Fatalf("the file field should be exported with @, got %s", generated)
+	}
+
+	imported, err := ParseCurlCommand(generated)
+	if err != nil {
+		t.Fatalf("parse failed: %v\ngenerated: %s", err, generated)
+	}
+	if imported.Body == nil || imported.Body.Type != BodyTypeFormData {
+		t.Fatalf("body = %+v, want form-data", imported.Body)
+	}
+	if got := ParseFormData(imported.Body.Content); !reflect.DeepEqual(got, fields) {
+		t.Errorf("fields after round-trip = %+v, want %+v", got, fields)
+	}
+}
+
 func TestGenerateCurlFromRequest(t *testing.T) {
 	tests := []struct {
 		name  string
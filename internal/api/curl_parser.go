@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"unicode"
@@ -59,6 +60,7 @@ type ParsedCurlCommand struct {
 	BasicAuth *BasicAuthCreds
 	UserAgent string
 	Cookies   []string
+	Form      []FormField // Multipart form fields (-F/--form)
 	Insecure  bool
 	RawFlags  []string // Unrecognized flags
 }
@@ -369,7 +371,7 @@ func tokenize(cmd string) ([]Token, error) {
 
 		// Handle unquoted word
 		start := i
-		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '\'' && runes[i] != '"' && runes[i] != '=' {
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '\'' && runes[i] != '"' {
 			// Handle escaped character in unquoted word
 			if runes[i] == '\\' && i+1 < len(runes) {
 				i += 2
@@ -464,7 +466,7 @@ func parseTokens(tokens []Token) (*ParsedCurlCommand, error) {
 					"-u": true, "--user": true,
 					"-A": true, "--user-agent": true,
 					"--cookie": true, "-b": true,
-					"-F": true, "--form": true,
+					"-F": true, "--form": true, "--form-string": true,
 					"-o": true, "--output": true,
 				}
 				if needsValue[flag] {
@@ -510,10 +512,19 @@ func parseTokens(tokens []Token) (*ParsedCurlCommand, error) {
 				}
 			case "-k", "--insecure":
 				parsed.Insecure = true
-			case "-F", "--form":
-				// Form data - store as warning for now
+			case "-F", "--form", "--form-string":
 				if hasValue {
-					parsed.RawFlags = append(parsed.RawFlags, fmt.Sprintf("%s=%s (multipart form not fully supported)", flag, flagValue))
+					field := parseFormField(flagValue)
+					if field == nil {
+						parsed.RawFlags = append(parsed.RawFlags, fmt.Sprintf("%s=%s (invalid form field)", flag, flagValue))
+						break
+					}
+					if flag != "--form-string" {
+						if err := readFormFieldFile(field); err != nil {
+							parsed.RawFlags = append(parsed.RawFlags, fmt.Sprintf("%s=%s (%v)", flag, flagValue, err))
+						}
+					}
+					parsed.Form = append(parsed.Form, *field)
 				}
 			case "-s", "--silent", "-S", "--show-error", "-L", "--location", "--compressed", "-v", "--verbose":
 				// Silently ignored flags (don't affect request content)
@@ -555,10 +566,11 @@ func parseTokens(tokens []Token) (*ParsedCurlCommand, error) {
 	// Set body (concatenate multiple -d flags with &)
 	if len(bodies) > 0 {
 		parsed.Body = strings.Join(bodies, "&")
-		// If body is present and method is still GET, default to POST
-		if parsed.Method == GET {
-			parsed.Method = POST
-		}
+	}
+
+	// If a body or form is present and method is still GET, default to POST
+	if (len(bodies) > 0 || len(parsed.Form) > 0) && parsed.Method == GET {
+		parsed.Method = POST
 	}
 
 	// Convert User-Agent to header if set
@@ -595,6 +607,45 @@ func parseHeader(header string) *KeyValueEntry {
 	}
 }
 
+// parseFormField parses a form field in "name=value" format
+func parseFormField(field string) *FormField {
+	parts := strings.SplitN(field, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return nil
+	}
+	return &FormField{
+		Key:     strings.TrimSpace(parts[0]),
+		Value:   parts[1],
+		Type:    FormFieldText,
+		Enabled: true,
+	}
+}
+
+// readFormFieldFile resolves the files of a -F field: "@path" uploads the file, so the
+// field becomes a file field of that path, and "<path" sends the text of the file. The
+// ";type=" and ";filename=" options of curl are dropped.
+func readFormFieldFile(field *FormField) error {
+	if !strings.HasPrefix(field.Value, "@") && !strings.HasPrefix(field.Value, "<") {
+		return nil
+	}
+	path := field.Value[1:]
+	for _, option := range []string{";type=", ";filename=", ";headers=", ";encoder="} {
+		if i := strings.Index(path, option); i >= 0 {
+			path = path[:i]
+		}
+	}
+	if field.Value[0] == '@' {
+		field.Type, field.Value = FormFieldFile, path
+		return nil
+	}
+	data, err := os.ReadFile(ExpandHome(path))
+	if err != nil {
+		return fmt.Errorf("cannot read %s", path)
+	}
+	field.Value = string(data)
+	return nil
+}
+
 // parseBasicAuth parses "username:password" format
 func parseBasicAuth(auth string) *BasicAuthCreds {
 	parts := strings.SplitN(auth, ":", 2)
@@ -667,6 +718,21 @@ func (p *ParsedCurlCommand) ToCollectionRequest() *CollectionRequest {
 			Type:    bodyType,
 			Content: detectAndConvertVariables(p.Body),
 		}
+	} else if len(p.Form) > 0 {
+		// Form fields map to a form-data body, in order (a field may be repeated)
+		fields := make([]interface{}, 0, len(p.Form))
+		for _, f := range p.Form {
+			fields = append(fields, map[string]interface{}{
+				"key":     f.Key,
+				"value":   detectAndConvertVariables(f.Value),
+				"type":    f.Type,
+				"enabled": f.Enabled,
+			})
+		}
+		req.Body = &BodyConfig{
+			Type:    BodyTypeFormData,
+			Content: fields,
+		}
 	}
 
 	// Set auth if present
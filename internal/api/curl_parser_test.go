@@ -2,6 +2,9 @@ package api
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -676,10 +679,10 @@ func TestEdgeCases(t *testing.T) {
 			wantErr:    false,
 		},
 		{
-			name:       "form data warning",
+			name:       "form data file upload",
 			input:      "curl -F 'file=@test.txt' https://example.com/upload",
 			wantURL:    "https://example.com/upload",
-			wantMethod: GET, // -F doesn't auto-set POST (use -X POST -F for multipart)
+			wantMethod: POST, // -F implies POST, like curl
 			wantErr:    false,
 		},
 		{
@@ -768,3 +771,97 @@ func TestCookieHeader(t *testing.T) {
 		t.Error("Expected Cookie header from -b flag")
 	}
 }
+
+// TestFormFields verifies -F/--form flags convert to a form-data body, in order
+func TestFormFields(t *testing.T) {
+	text := func(key, value string) FormField {
+		return FormField{Key: key, Value: value, Type: FormFieldText, Enabled: true}
+	}
+	tests := []struct {
+		name       string
+		input      string
+		wantMethod HTTPMethod
+		wantFields []FormField
+	}{
+		{
+			name:       "unquoted fields",
+			input:      `curl -F name=Ada -F role=admin https://example.com/users?team=core`,
+			wantMethod: POST,
+			wantFields: []FormField{text("name", "Ada"), text("role", "admin")},
+		},
+		{
+			name:       "short and long form",
+			input:      `curl -F 'name=Ada' --form "role=admin" https://example.com/users`,
+			wantMethod: POST,
+			wantFields: []FormField{text("name", "Ada"), text("role", "admin")},
+		},
+		{
+			name:       "explicit method kept",
+			input:      `curl -X PUT -F avatar=@photo.png https://example.com/me`,
+			wantMethod: PUT,
+			wantFields: []FormField{{Key: "avatar", Value: "photo.png", Type: FormFieldFile, Enabled: true}},
+		},
+		{
+			name:       "file options dropped",
+			input:      `curl -F 'avatar=@/tmp/a.png;type=image/png;filename=me.png' https://example.com/me`,
+			wantMethod: POST,
+			wantFields: []FormField{{Key: "avatar", Value: "/tmp/a.png", Type: FormFieldFile, Enabled: true}},
+		},
+		{
+			name:       "form-string and shell variable",
+			input:      `curl --form-string 'note=@not-a-file' -F "token=$TOKEN" https://example.com`,
+			wantMethod: POST,
+			wantFields: []FormField{text("note", "@not-a-file"), text("token", "{{TOKEN}}")},
+		},
+		{
+			name:       "repeated and unsorted fields",
+			input:      `curl -F tag=b -F name=Ada -F tag=a https://example.com/users`,
+			wantMethod: POST,
+			wantFields: []FormField{text("tag", "b"), text("name", "Ada"), text("tag", "a")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ParseCurlCommand(tt.input)
+			if err != nil {
+				t.Fatalf("ParseCurlCommand() error = %v", err)
+			}
+			if req.Method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", req.Method, tt.wantMethod)
+			}
+			if req.Body == nil || req.Body.Type != BodyTypeFormData {
+				t.Fatalf("body = %+v, want form-data", req.Body)
+			}
+			if got := ParseFormData(req.Body.Content); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("fields = %+v, want %+v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+// TestFormFields_TextFile verifies "<path" fields send the text of the file
+func TestFormFields_TextFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := tokenize(`curl -F 'note=<` + path + `' -F 'other=<` + path + `.missing' https://example.com`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parseTokens(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FormField{
+		{Key: "note", Value: "hello", Type: FormFieldText, Enabled: true},
+		{Key: "other", Value: "<" + path + ".missing", Type: FormFieldText, Enabled: true},
+	}
+	if !reflect.DeepEqual(parsed.Form, want) {
+		t.Errorf("fields = %+v, want %+v", parsed.Form, want)
+	}
+	if len(parsed.RawFlags) != 1 || !strings.Contains(parsed.RawFlags[0], "cannot read") {
+		t.Errorf("a missing file should be reported, got %v", parsed.RawFlags)
+	}
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DoctorStatus is the outcome of a single connectivity check
+type DoctorStatus int
+
+const (
+	DoctorPass DoctorStatus = iota
+	DoctorWarn
+	DoctorFail
+	DoctorSkip
+)
+
+// String returns the display label for the status
+func (s DoctorStatus) String() string {
+	switch s {
+	case DoctorPass:
+		return "PASS"
+	case DoctorWarn:
+		return "WARN"
+	case DoctorFail:
+		return "FAIL"
+	default:
+		return "SKIP"
+	}
+}
+
+// DoctorStep is one check of a connectivity diagnosis
+type DoctorStep struct {
+	Name     string
+	Status   DoctorStatus
+	Detail   string
+	Duration time.Duration
+}
+
+// DoctorReport is the step-by-step result of RunDoctor
+type DoctorReport struct {
+	URL   string
+	Host  string // Host and port that was diagnosed
+	Steps []DoctorStep
+}
+
+// DoctorOptions configures RunDoctor
+type DoctorOptions struct {
+	Timeout      time.Duration    // Per-step timeout (default 5s)
+	MaxClockSkew time.Duration    // Clock difference reported as a warning (default 1m)
+	Now          func() time.Time // Local clock (default time.Now)
+}
+
+// Default doctor settings
+const (
+	DefaultDoctorTimeout      = 5 * time.Second
+	DefaultDoctorMaxClockSkew = time.Minute
+	certificateExpiryWarning  = 14 * 24 * time.Hour
+)
+
+// RunDoctor diagnoses connectivity to the host of rawURL: URL parsing, proxy
+// configuration, DNS resolution, TCP reachability, TLS handshake and clock skew.
+// Steps that depend on a failed step are skipped.
+func RunDoctor(rawURL string, opts DoctorOptions) *DoctorReport {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultDoctorTimeout
+	}
+	if opts.MaxClockSkew <= 0 {
+		opts.MaxClockSkew = DefaultDoctorMaxClockSkew
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	report := &DoctorReport{URL: rawURL}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		detail := "URL must start with http:// or https:// and include a host"
+		if err != nil {
+			detail = err.Error()
+		}
+		report.add(DoctorStep{Name: "URL", Status: DoctorFail, Detail: detail})
+		for _, name := range []string{"Proxy", "DNS", "TCP", "TLS", "Clock"} {
+			report.add(DoctorStep{Name: name, Status: DoctorSkip, Detail: "invalid URL"})
+		}
+		return report
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	hostname := u.Hostname()
+	address := net.JoinHostPort(hostname, port)
+	report.Host = address
+	report.add(DoctorStep{Name: "URL", Status: DoctorPass, Detail: fmt.Sprintf("%s request to %s", strings.ToUpper(u.Scheme), address)})
+
+	proxy := report.checkProxy(u, opts)
+	dnsOK := report.checkDNS(hostname, opts)
+
+	tcpOK := false
+	if dnsOK {
+		tcpOK = report.checkTCP(address, proxy, opts)
+	} else {
+		report.add(DoctorStep{Name: "TCP", Status: DoctorSkip, Detail: "DNS lookup failed"})
+	}
+
+	switch {
+	case u.Scheme != "https":
+		report.add(DoctorStep{Name: "TLS", Status: DoctorSkip, Detail: "plain HTTP"})
+	case !tcpOK:
+		report.add(DoctorStep{Name: "TLS", Status: DoctorSkip, Detail: "host not reachable"})
+	default:
+		report.checkTLS(address, hostname, opts)
+	}
+
+	if tcpOK || proxy != "" {
+		report.checkClock(u, opts)
+	} else {
+		report.add(DoctorStep{Name: "Clock", Status: DoctorSkip, Detail: "host not reachable"})
+	}
+
+	return report
+}
+
+// checkProxy reports the proxy requests to u go through and whether it is reachable.
+// Returns the proxy URL, or "" when connecting directly.
+func (r *DoctorReport) checkProxy(u *url.URL, opts DoctorOptions) string {
+	proxy := proxyFor(u)
+	if proxy == "" {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorPass, Detail: "no proxy configured, connecting directly"})
+		return ""
+	}
+	if DefaultProxy != nil && DefaultProxy.Bypasses(u) {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorPass, Detail: fmt.Sprintf("%s bypassed by no_proxy", proxy)})
+		return ""
+	}
+	if DefaultProxy == nil && noProxyMatches(u.Hostname()) {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorPass, Detail: fmt.Sprintf("%s bypassed by NO_PROXY", proxy)})
+		return ""
+	}
+	if isLoopbackHost(u.Hostname()) {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorPass, Detail: fmt.Sprintf("%s not used for local hosts", proxy)})
+		return ""
+	}
+
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		// Go accepts "host:port" without a scheme
+		proxyURL, err = url.Parse("http://" + proxy)
+	}
+	if err != nil || proxyURL.Host == "" {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorFail, Detail: fmt.Sprintf("invalid proxy URL %q", proxy)})
+		return proxy
+	}
+
+	proxyAddress := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := proxySchemes[proxyURL.Scheme]
+		if port == "" {
+			port = "80"
+		}
+		proxyAddress = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", proxyAddress, opts.Timeout)
+	elapsed := time.Since(start)
+	if err != nil {
+		r.add(DoctorStep{Name: "Proxy", Status: DoctorFail, Detail: fmt.Sprintf("%s is not reachable: %v", proxy, err), Duration: elapsed})
+		return proxy
+	}
+	conn.Close()
+	r.add(DoctorStep{Name: "Proxy", Status: DoctorPass, Detail: fmt.Sprintf("requests go through %s (reachable)", proxy), Duration: elapsed})
+	return proxy
+}
+
+// checkDNS resolves hostname
+func (r *DoctorReport) checkDNS(hostname string, opts DoctorOptions) bool {
+	if ip := net.ParseIP(hostname); ip != nil {
+		r.add(DoctorStep{Name: "DNS", Status: DoctorPass, Detail: "IP address, no lookup needed"})
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, hostname)
+	elapsed := time.Since(start)
+	if err != nil {
+		r.add(DoctorStep{Name: "DNS", Status: DoctorFail, Detail: err.Error(), Duration: elapsed})
+		return false
+	}
+	r.add(DoctorStep{Name: "DNS", Status: DoctorPass, Detail: "resolved to " + strings.Join(addrs, ", "), Duration: elapsed})
+	return true
+}
+
+// checkTCP opens a direct connection to address
+func (r *DoctorReport) checkTCP(address, proxy string, opts DoctorOptions) bool {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", address, opts.Timeout)
+	elapsed := time.Since(start)
+	if err != nil {
+		if proxy != "" {
+			// Direct connections are often blocked when a proxy is required
+			r.add(DoctorStep{Name: "TCP", Status: DoctorWarn, Detail: fmt.Sprintf("not reachable directly (%v); requests go through the proxy", err), Duration: elapsed})
+			return false
+		}
+		summary := DiagnoseNetworkError(err, "tcp://"+address).Summary
+		r.add(DoctorStep{Name: "TCP", Status: DoctorFail, Detail: fmt.Sprintf("%s (%v)", summary, err), Duration: elapsed})
+		return false
+	}
+	conn.Close()
+	r.add(DoctorStep{Name: "TCP", Status: DoctorPass, Detail: "connected to " + address, Duration: elapsed})
+	return true
+}
+
+// checkTLS performs a verified TLS handshake and inspects the server certificate
+func (r *DoctorReport) checkTLS(address, hostname string, opts DoctorOptions) {
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+
+	// Verify with the CA files of the TLS config, as requests do
+	config, err := TLSClientConfig(&url.URL{Scheme: "https", Host: address})
+	if err != nil {
+		r.add(DoctorStep{Name: "TLS", Status: DoctorFail, Detail: err.Error()})
+		return
+	}
+	if config == nil {
+		config = &tls.Config{}
+	}
+	config.ServerName = hostname
+	config.InsecureSkipVerify = false // The handshake reports certificate problems even when requests skip verification
+
+	start := time.Now()
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, config)
+	elapsed := time.Since(start)
+	if err != nil {
+		summary := DiagnoseNetworkError(err, "https://"+address).Summary
+		r.add(DoctorStep{Name: "TLS", Status: DoctorFail, Detail: fmt.Sprintf("%s (%v)", summary, err), Duration: elapsed})
+		return
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	detail := tls.VersionName(state.Version)
+	status := DoctorPass
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		remaining := cert.NotAfter.Sub(opts.Now())
+		detail += fmt.Sprintf(", certificate valid until %s", cert.NotAfter.Format("2006-01-02"))
+		if remaining < certificateExpiryWarning {
+			status = DoctorWarn
+			detail += fmt.Sprintf(" (expires in %d days)", int(remaining.Hours()/24))
+		}
+	}
+	r.add(DoctorStep{Name: "TLS", Status: status, Detail: detail, Duration: elapsed})
+}
+
+// checkClock compares the local clock with the server's Date header
+func (r *DoctorReport) checkClock(u *url.URL, opts DoctorOptions) {
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			Proxy: proxyFunc(),
+			// Only the Date header is read; skew is a common cause of certificate errors,
+			// so it must be measurable even when verification fails
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Head(u.String())
+	elapsed := time.Since(start)
+	if err != nil {
+		r.add(DoctorStep{Name: "Clock", Status: DoctorSkip, Detail: fmt.Sprintf("could not read server time: %v", err), Duration: elapsed})
+		return
+	}
+	resp.Body.Close()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		r.add(DoctorStep{Name: "Clock", Status: DoctorSkip, Detail: "server sent no Date header", Duration: elapsed})
+		return
+	}
+
+	// The Date header has one-second resolution and is set before the response travels back
+	skew := opts.Now().Add(-elapsed / 2).Sub(serverTime).Truncate(time.Second)
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs > opts.MaxClockSkew {
+		direction := "ahead of"
+		if skew < 0 {
+			direction = "behind"
+		}
+		r.add(DoctorStep{Name: "Clock", Status: DoctorWarn, Detail: fmt.Sprintf("local clock is %s %s the server (tokens and certificates may be rejected)", abs, direction), Duration: elapsed})
+		return
+	}
+	r.add(DoctorStep{Name: "Clock", Status: DoctorPass, Detail: fmt.Sprintf("in sync with the server (%s difference)", abs), Duration: elapsed})
+}
+
+func (r *DoctorReport) add(step DoctorStep) {
+	r.Steps = append(r.Steps, step)
+}
+
+// Count returns the number of steps with the given status
+func (r *DoctorReport) Count(status DoctorStatus) int {
+	count := 0
+	for _, step := range r.Steps {
+		if step.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// OK returns true if no step failed
+func (r *DoctorReport) OK() bool {
+	return r.Count(DoctorFail) == 0
+}
+
+// Summary returns a one-line result such as "4 passed, 1 warning, 0 failed"
+func (r *DoctorReport) Summary() string {
+	warnings := r.Count(DoctorWarn)
+	plural := "s"
+	if warnings == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("%d passed, %d warning%s, %d failed", r.Count(DoctorPass), warnings, plural, r.Count(DoctorFail))
+}
+
+// Text returns the report as plain text (for clipboard)
+func (r *DoctorReport) Text() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Connectivity report for %s\n\n", r.URL))
+	for _, step := range r.Steps {
+		sb.WriteString(fmt.Sprintf("[%s] %-5s %s", step.Status, step.Name, step.Detail))
+		if step.Duration > 0 {
+			sb.WriteString(fmt.Sprintf(" (%s)", FormatDuration(step.Duration)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(r.Summary())
+	return sb.String()
+}
+
+// noProxyMatches reports whether NO_PROXY excludes hostname from proxying
+func noProxyMatches(hostname string) bool {
+	value := os.Getenv("NO_PROXY")
+	if value == "" {
+		value = os.Getenv("no_proxy")
+	}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if hostname == entry || strings.HasSuffix(hostname, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoopbackHost reports whether hostname is a local address, which Go never proxies
+func isLoopbackHost(hostname string) bool {
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
+}
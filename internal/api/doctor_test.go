@@ -0,0 +1,148 @@
+package api
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func clearProxyEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(key, "")
+	}
+}
+
+func stepStatuses(report *DoctorReport) map[string]DoctorStatus {
+	statuses := make(map[string]DoctorStatus)
+	for _, step := range report.Steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+func TestRunDoctor(t *testing.T) {
+	clearProxyEnv(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0) // Silence the server-side handshake error
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		opts DoctorOptions
+		want map[string]DoctorStatus
+	}{
+		{
+			name: "reachable http server",
+			url:  server.URL + "/users",
+			want: map[string]DoctorStatus{"URL": DoctorPass, "Proxy": DoctorPass, "DNS": DoctorPass, "TCP": DoctorPass, "TLS": DoctorSkip, "Clock": DoctorPass},
+		},
+		{
+			name: "self-signed certificate",
+			url:  tlsServer.URL,
+			want: map[string]DoctorStatus{"TCP": DoctorPass, "TLS": DoctorFail, "Clock": DoctorPass},
+		},
+		{
+			name: "connection refused",
+			url:  closedURL,
+			want: map[string]DoctorStatus{"DNS": DoctorPass, "TCP": DoctorFail, "TLS": DoctorSkip, "Clock": DoctorSkip},
+		},
+		{
+			name: "clock skew",
+			url:  server.URL,
+			opts: DoctorOptions{Now: func() time.Time { return time.Now().Add(10 * time.Minute) }},
+			want: map[string]DoctorStatus{"Clock": DoctorWarn},
+		},
+		{
+			name: "invalid url",
+			url:  "example.test/users",
+			want: map[string]DoctorStatus{"URL": DoctorFail, "DNS": DoctorSkip, "TCP": DoctorSkip},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := RunDoctor(tt.url, tt.opts)
+			got := stepStatuses(report)
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("%s = %v, want %v\n%s", name, got[name], want, report.Text())
+				}
+			}
+		})
+	}
+}
+
+func TestRunDoctor_UnreachableProxy(t *testing.T) {
+	clearProxyEnv(t)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	proxyURL := closed.URL
+	closed.Close()
+	t.Setenv("HTTP_PROXY", proxyURL)
+
+	report := RunDoctor("http://192.0.2.1/", DoctorOptions{Timeout: 200 * time.Millisecond})
+	if got := stepStatuses(report)["Proxy"]; got != DoctorFail {
+		t.Errorf("Proxy = %v, want FAIL\n%s", got, report.Text())
+	}
+}
+
+func TestNoProxyMatches(t *testing.T) {
+	t.Setenv("NO_PROXY", "internal.test, .corp.example:8080")
+	t.Setenv("no_proxy", "")
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"internal.test", true},
+		{"api.internal.test", true},
+		{"api.corp.example", true},
+		{"example.test", false},
+		{"notinternal.test", false},
+	}
+
+	for _, tt := range tests {
+		if got := noProxyMatches(tt.host); got != tt.want {
+			t.Errorf("noProxyMatches(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDoctorReport_Text(t *testing.T) {
+	report := &DoctorReport{
+		URL: "https://example.test",
+		Steps: []DoctorStep{
+			{Name: "DNS", Status: DoctorPass, Detail: "resolved to 192.0.2.1", Duration: 12 * time.Millisecond},
+			{Name: "TCP", Status: DoctorFail, Detail: "connection refused"},
+			{Name: "Clock", Status: DoctorWarn, Detail: "local clock is 5m0s ahead of the server"},
+		},
+	}
+
+	if report.OK() {
+		t.Error("OK() = true, want false with a failed step")
+	}
+	if got := report.Summary(); got != "1 passed, 1 warning, 1 failed" {
+		t.Errorf("Summary() = %q", got)
+	}
+	text := report.Text()
+	for _, want := range []string{"Connectivity report for https://example.test", "[PASS] DNS   resolved to 192.0.2.1 (12ms)", "[FAIL] TCP   connection refused"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+}
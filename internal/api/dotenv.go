@@ -0,0 +1,188 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DotenvVariable is a variable of a .env file
+type DotenvVariable struct {
+	Name  string
+	Value string
+	Line  int // Line of the file defining the variable
+}
+
+// dotenvName matches the variable names of .env files
+var dotenvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// ParseDotenv parses the KEY=VALUE lines of a .env file, in file order. Blank lines and
+// lines starting with # are skipped, as is an "export " prefix. Unquoted values end at
+// a " #" comment; single-quoted values are taken as is, double-quoted values unescape
+// \n, \r, \t, \" and \\, and both can span lines. A variable defined twice is listed
+// twice, the last value winning once imported.
+func ParseDotenv(data []byte) ([]DotenvVariable, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff") // Byte order mark
+	lines := strings.Split(text, "\n")
+
+	var vars []DotenvVariable
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE, got %q", lineNumber, line)
+		}
+		if !dotenvName.MatchString(name) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, name)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = value[:comment]
+			} else if comment := strings.Index(value, "\t#"); comment >= 0 {
+				value = value[:comment]
+			}
+			vars = append(vars, DotenvVariable{Name: name, Value: strings.TrimSpace(value), Line: lineNumber})
+			continue
+		}
+
+		// A quoted value runs to its closing quote, on this line or a later one
+		quote := value[0]
+		quoted := value[1:]
+		for {
+			if end := closingQuote(quoted, quote); end >= 0 {
+				trailing := strings.TrimSpace(quoted[end+1:])
+				if trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return nil, fmt.Errorf("line %d: unexpected %q after the value of %s", i+1, trailing, name)
+				}
+				quoted = quoted[:end]
+				break
+			}
+			if i+1 >= len(lines) {
+				return nil, fmt.Errorf("line %d: unterminated quoted value of %s", lineNumber, name)
+			}
+			i++
+			quoted += "\n" + lines[i]
+		}
+		if quote == '"' {
+			quoted = unescapeDotenv(quoted)
+		}
+		vars = append(vars, DotenvVariable{Name: name, Value: quoted, Line: lineNumber})
+	}
+	return vars, nil
+}
+
+// closingQuote returns the index of the quote closing s, -1 when s has none. Double
+// quotes escaped with a backslash do not close a double-quoted value.
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeDotenv replaces the escape sequences of a double-quoted value
+func unescapeDotenv(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// DotenvEnvironmentName returns the name of the environment imported from the .env
+// file at path: "staging" for ".env.staging" or "staging.env", "env" for ".env"
+func DotenvEnvironmentName(path string) string {
+	base := filepath.Base(path)
+	switch {
+	case strings.HasPrefix(base, ".env."):
+		return strings.TrimPrefix(base, ".env.")
+	case base != ".env" && strings.HasSuffix(base, ".env"):
+		return strings.TrimSuffix(base, ".env")
+	}
+	if name := strings.TrimPrefix(base, "."); name != "" {
+		return name
+	}
+	return "env"
+}
+
+// IsDotenvFile reports whether the file name at path is the one of a .env file:
+// ".env", ".env.<name>" or "<name>.env"
+func IsDotenvFile(path string) bool {
+	base := filepath.Base(path)
+	return base == ".env" || strings.HasPrefix(base, ".env.") || strings.HasSuffix(base, ".env")
+}
+
+// ImportDotenv sets the variables of a .env file in the environment. New variables are
+// active, and secret when their name suggests it; existing ones keep their flags and take
+// the new value, dropping a type the value is not valid for. Returns the number of
+// variables added and the number whose value changed.
+func (e *EnvironmentFile) ImportDotenv(vars []DotenvVariable) (added, updated int) {
+	previous := make(map[string]string) // Values before the import of the existing variables
+	seen := make(map[string]bool)
+	for _, v := range vars {
+		if !seen[v.Name] {
+			seen[v.Name] = true
+			if existing, ok := e.Variables[v.Name]; ok {
+				previous[v.Name] = existing.Value
+			} else {
+				added++
+			}
+		}
+		e.SetVariable(v.Name, v.Value)
+	}
+	for name, value := range previous {
+		v := e.Variables[name]
+		if v.Value != value {
+			updated++
+		}
+		if v.Type != "" && ValidateVariableValue(v.Type, v.Value) != nil {
+			v.Type = ""
+		}
+	}
+	return added, updated
+}
+
+// FormatDotenv returns the active variables of env in .env format, sorted by name.
+// Values that need it are double-quoted, with newlines, quotes and backslashes escaped.
+// Secret values are written too: the file is meant for tools that need them.
+func FormatDotenv(env *EnvironmentFile) []byte {
+	names := make([]string, 0, len(env.Variables))
+	for name, v := range env.Variables {
+		if v.Active {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	if env.Name != "" {
+		fmt.Fprintf(&b, "# Environment %s, exported by LazyCurl\n", env.Name)
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%s\n", name, quoteDotenv(env.Variables[name].Value))
+	}
+	return []byte(b.String())
+}
+
+// quoteDotenv returns value as written in a .env file: as is when it reads back the
+// same unquoted, double-quoted otherwise
+func quoteDotenv(value string) string {
+	if value == strings.TrimSpace(value) && !strings.ContainsAny(value, "\"'\\\n\r\t#") {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(value) + `"`
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	data := "\ufeff# Shop API\r\n" +
+		"BASE_URL=https://shop.example.com # staging\r\n" +
+		"\n" +
+		"export API_TOKEN = abc#123\n" +
+		"GREETING=\"Hello \\\"you\\\"\\nsee you\"\n" +
+		"PATTERN='^\\d+$' # digits\n" +
+		"EMPTY=\n" +
+		"CERT=\"-----BEGIN-----\n" +
+		"MIIB\n" +
+		"-----END-----\"\n" +
+		"exported=1\n"
+	vars, err := ParseDotenv([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseDotenv() error = %v", err)
+	}
+	want := []DotenvVariable{
+		{Name: "BASE_URL", Value: "https://shop.example.com", Line: 2},
+		{Name: "API_TOKEN", Value: "abc#123", Line: 4},
+		{Name: "GREETING", Value: "Hello \"you\"\nsee you", Line: 5},
+		{Name: "PATTERN", Value: `^\d+$`, Line: 6},
+		{Name: "EMPTY", Value: "", Line: 7},
+		{Name: "CERT", Value: "-----BEGIN-----\nMIIB\n-----END-----", Line: 8},
+		{Name: "exported", Value: "1", Line: 11},
+	}
+	if len(vars) != len(want) {
+		t.Fatalf("ParseDotenv() = %+v, want %+v", vars, want)
+	}
+	for i := range want {
+		if vars[i] != want[i] {
+			t.Errorf("variable %d = %+v, want %+v", i, vars[i], want[i])
+		}
+	}
+}
+
+func TestParseDotenv_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"missing equals", "A=1\nB\n", "line 2: expected KEY=VALUE"},
+		{"invalid name", "1A=x\n", `line 1: invalid variable name "1A"`},
+		{"unterminated quote", "A=\"open\nB=2\n", "line 1: unterminated quoted value of A"},
+		{"text after quote", "A='x' y\n", `line 1: unexpected "y" after the value of A`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDotenv([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseDotenv() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDotenvEnvironmentName(t *testing.T) {
+	for path, want := range map[string]string{
+		".env":               "env",
+		"config/.env.local":  "local",
+		"staging.env":        "staging",
+		"/tmp/secrets.txt":   "secrets.txt",
+		"deploy/.production": "production",
+	} {
+		if got := DotenvEnvironmentName(path); got != want {
+			t.Errorf("DotenvEnvironmentName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestEnvironmentFile_ImportDotenv(t *testing.T) {
+	env := &EnvironmentFile{Name: "dev", Variables: map[string]*EnvironmentVariable{
+		"base_url": newVar("http://localhost", false, true),
+		"page":     {Value: "1", Active: false, Type: VariableTypeNumber},
+		"user":     newVar("ada", false, true),
+	}}
+	added, updated := env.ImportDotenv([]DotenvVariable{
+		{Name: "base_url", Value: "https://staging.example.com"},
+		{Name: "page", Value: "first"},
+		{Name: "user", Value: "ada"},
+		{Name: "API_KEY", Value: "k1"},
+		{Name: "API_KEY", Value: "k2"},
+	})
+	if added != 1 || updated != 2 {
+		t.Errorf("ImportDotenv() = %d added, %d updated, want 1, 2", added, updated)
+	}
+	if v := env.Variables["API_KEY"]; v.Value != "k2" || !v.Secret || !v.Active {
+		t.Errorf("a new variable should be active, secret for its name, and take the last value, got %+v", v)
+	}
+	if v := env.Variables["page"]; v.Value != "first" || v.Active || v.Type != "" {
+		t.Errorf("an existing variable should keep its flags but a type its value breaks, got %+v", v)
+	}
+}
+
+func TestFormatDotenv(t *testing.T) {
+	env := &EnvironmentFile{Name: "dev", Variables: map[string]*EnvironmentVariable{
+		"base_url": newVar("https://shop.example.com", false, true),
+		"token":    newVar("s3cr#t", true, true),
+		"motd":     newVar("line 1\nsay \"hi\"", false, true),
+		"old":      newVar("unused", false, false),
+	}}
+	got := string(FormatDotenv(env))
+	want := "# Environment dev, exported by LazyCurl\n" +
+		"base_url=https://shop.example.com\n" +
+		"motd=\"line 1\\nsay \\\"hi\\\"\"\n" +
+		"token=\"s3cr#t\"\n"
+	if got != want {
+		t.Errorf("FormatDotenv() =\n%s\nwant\n%s", got, want)
+	}
+
+	// The export reads back the same
+	vars, err := ParseDotenv([]byte(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range vars {
+		if v.Value != env.Variables[v.Name].Value {
+			t.Errorf("%s read back as %q, want %q", v.Name, v.Value, env.Variables[v.Name].Value)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// defaultDownloadName is the base name of a saved response without a better name
+const defaultDownloadName = "response"
+
+// downloadExtensions maps common response media types to a file extension, as
+// mime.ExtensionsByType depends on the system's MIME tables
+var downloadExtensions = map[string]string{
+	"application/json":         ".json",
+	"application/problem+json": ".json",
+	"application/xml":          ".xml",
+	"text/xml":                 ".xml",
+	"text/html":                ".html",
+	"text/plain":               ".txt",
+	"text/csv":                 ".csv",
+	"text/css":                 ".css",
+	"text/javascript":          ".js",
+	"application/javascript":   ".js",
+	"application/pdf":          ".pdf",
+	"application/zip":          ".zip",
+	"application/gzip":         ".gz",
+	"application/msgpack":      ".msgpack",
+	"application/x-msgpack":    ".msgpack",
+	"application/cbor":         ".cbor",
+	"application/octet-stream": ".bin",
+	"image/png":                ".png",
+	"image/jpeg":               ".jpg",
+	"image/gif":                ".gif",
+	"image/webp":               ".webp",
+	"image/svg+xml":            ".svg",
+}
+
+// DownloadFileName suggests a file name for a response body: the filename of its
+// Content-Disposition header, else the last segment of the request URL, else
+// "response". A name without extension gets the extension of contentType.
+func DownloadFileName(contentDisposition, contentType, rawURL string) string {
+	if _, params, err := mime.ParseMediaType(contentDisposition); err == nil {
+		// ParseMediaType decodes the RFC 5987 filename* parameter into filename
+		if name := cleanFileName(params["filename"]); name != "" {
+			return name
+		}
+	}
+
+	name := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		name = cleanFileName(path.Base(u.Path))
+	}
+	if name == "" {
+		name = defaultDownloadName
+	}
+	if filepath.Ext(name) == "" {
+		name += extensionForType(contentType)
+	}
+	return name
+}
+
+// cleanFileName returns the base name of a suggested file name, "" when it has none
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+// extensionForType returns the file extension of a media type, "" when unknown
+func extensionForType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := downloadExtensions[mediaType]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// SaveResponseBody streams a response body unchanged to the file at path, creating
+// its directory, and returns the number of bytes written. path may start with "~".
+func SaveResponseBody(path string, body io.Reader) (int64, error) {
+	path = ExpandHome(path)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return 0, fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return written, fmt.Errorf("failed to write file: %w", err)
+	}
+	return written, nil
+}
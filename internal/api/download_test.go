@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadFileName(t *testing.T) {
+	tests := []struct {
+		name        string
+		disposition string
+		contentType string
+		url         string
+		want        string
+	}{
+		{"disposition filename", `attachment; filename="report.pdf"`, "application/pdf", "https://api.example.com/reports/1", "report.pdf"},
+		{"disposition filename*", `attachment; filename*=UTF-8''r%C3%A9sum%C3%A9.txt`, "text/plain", "https://example.com/x", "résumé.txt"},
+		{"disposition path stripped", `attachment; filename="../../etc/passwd"`, "text/plain", "https://example.com/x", "passwd"},
+		{"inline without filename", "inline", "image/png", "https://example.com/logo.png", "logo.png"},
+		{"url segment with extension", "", "application/json", "https://example.com/data/users.json?page=2", "users.json"},
+		{"url segment without extension", "", "application/json; charset=utf-8", "https://example.com/api/users", "users.json"},
+		{"root url", "", "text/html", "https://example.com/", "response.html"},
+		{"unknown type", "", "", "https://example.com", "response"},
+		{"binary", "", "application/octet-stream", "https://example.com/download/", "download.bin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DownloadFileName(tt.disposition, tt.contentType, tt.url); got != tt.want {
+				t.Errorf("DownloadFileName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveResponseBody(t *testing.T) {
+	// Bytes that are not valid UTF-8 must be written unchanged
+	body := append([]byte("\x89PNG\r\n\x1a\n\x00\xff"), bytes.Repeat([]byte{0xfe}, 1024)...)
+	path := filepath.Join(t.TempDir(), "out", "image.png")
+
+	written, err := SaveResponseBody(path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("SaveResponseBody() error = %v", err)
+	}
+	if written != int64(len(body)) {
+		t.Errorf("SaveResponseBody() = %d bytes, want %d", written, len(body))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, body) {
+		t.Error("saved file differs from the response body")
+	}
+}
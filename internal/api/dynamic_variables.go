@@ -0,0 +1,218 @@
+package api
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Word lists of the dynamic variables
+var (
+	fakeFirstNames  = []string{"Alice", "Bob", "Chloe", "David", "Emma", "Felix", "Grace", "Hugo", "Iris", "Jack", "Kate", "Liam", "Mia", "Noah", "Olivia", "Paul", "Rose", "Sam", "Tara", "Victor"}
+	fakeLastNames   = []string{"Anderson", "Brown", "Carter", "Davis", "Evans", "Garcia", "Harris", "Johnson", "King", "Lopez", "Martin", "Miller", "Nelson", "Parker", "Roberts", "Smith", "Taylor", "Walker", "White", "Young"}
+	fakeNamePrefix  = []string{"Mr.", "Mrs.", "Ms.", "Miss", "Dr."}
+	fakeNameSuffix  = []string{"Jr.", "Sr.", "I", "II", "III", "IV", "V", "MD", "DDS", "PhD"}
+	fakeJobTitles   = []string{"Software Engineer", "Product Manager", "Data Analyst", "Designer", "Account Manager", "Support Specialist", "Marketing Director", "Sales Representative", "DevOps Engineer", "Technical Writer"}
+	fakeCities      = []string{"Amsterdam", "Berlin", "Chicago", "Dublin", "Lisbon", "London", "Madrid", "Montreal", "Paris", "Rome", "Seattle", "Sydney", "Tokyo", "Toronto", "Vienna"}
+	fakeStreets     = []string{"Main Street", "Oak Avenue", "Park Lane", "Cedar Road", "Elm Street", "Maple Drive", "Pine Court", "River Road", "Hill Street", "Lake View"}
+	fakeCountries   = []string{"Australia", "Belgium", "Brazil", "Canada", "France", "Germany", "India", "Ireland", "Italy", "Japan", "Netherlands", "Portugal", "Spain", "United Kingdom", "United States"}
+	fakeCountryCode = []string{"AU", "BE", "BR", "CA", "FR", "DE", "IN", "IE", "IT", "JP", "NL", "PT", "ES", "GB", "US"}
+	fakeCompanies   = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark", "Wayne", "Cyberdyne", "Soylent", "Vandelay"}
+	fakeCompanySuff = []string{"Inc", "LLC", "Group", "and Sons", "Ltd"}
+	fakeDomainSuff  = []string{"com", "net", "org", "io", "info", "biz"}
+	fakeColors      = []string{"red", "green", "blue", "yellow", "purple", "orange", "black", "white", "gray", "teal", "pink", "cyan"}
+	fakeCurrencies  = []string{"USD", "EUR", "GBP", "JPY", "CAD", "AUD", "CHF", "CNY"}
+	fakeCurrSymbols = []string{"$", "€", "£", "¥"}
+	fakeDepartments = []string{"Books", "Clothing", "Electronics", "Garden", "Grocery", "Health", "Home", "Music", "Sports", "Toys"}
+	fakeAdjectives  = []string{"Awesome", "Ergonomic", "Fantastic", "Handcrafted", "Intelligent", "Practical", "Rustic", "Sleek", "Small", "Tasty"}
+	fakeMaterials   = []string{"Cotton", "Concrete", "Fresh", "Frozen", "Granite", "Metal", "Plastic", "Rubber", "Steel", "Wooden"}
+	fakeProducts    = []string{"Bike", "Car", "Chair", "Computer", "Gloves", "Hat", "Keyboard", "Mouse", "Shirt", "Table"}
+	fakeFileExts    = []string{"txt", "json", "csv", "pdf", "png", "jpg", "xml", "zip"}
+	fakeMimeTypes   = []string{"text/plain", "application/json", "text/csv", "application/pdf", "image/png", "image/jpeg", "application/xml", "application/zip"}
+	fakeLocales     = []string{"en", "fr", "de", "es", "it", "pt", "nl", "ja"}
+	fakeWeekdays    = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	fakeMonths      = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	fakeUserAgents  = []string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_2) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+	}
+	fakeAbbreviations = []string{"API", "CSS", "HTML", "HTTP", "JSON", "SQL", "SSL", "TCP", "XML", "RAM"}
+	loremWords        = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim", "minim", "veniam", "quis", "nostrud"}
+)
+
+// dynamicVariables generate the values of the Postman dynamic variables ({{$randomEmail}}),
+// so that imported collections work unchanged
+var dynamicVariables = map[string]func() string{
+	// Text, numbers and colors
+	"$randomAlphaNumeric": func() string { return generateRandomString(1) },
+	"$randomBoolean":      func() string { return fmt.Sprintf("%t", rand.Intn(2) == 1) },
+	"$randomColor":        func() string { return pick(fakeColors) },
+	"$randomHexColor":     func() string { return fmt.Sprintf("#%06x", rand.Intn(0x1000000)) },
+	"$randomAbbreviation": func() string { return pick(fakeAbbreviations) },
+
+	// Internet
+	"$randomIP": func() string {
+		return fmt.Sprintf("%d.%d.%d.%d", rand.Intn(256), rand.Intn(256), rand.Intn(256), rand.Intn(256))
+	},
+	"$randomIPV6":       randomIPv6,
+	"$randomMACAddress": randomMACAddress,
+	"$randomPassword":   func() string { return generateRandomString(15) },
+	"$randomLocale":     func() string { return pick(fakeLocales) },
+	"$randomUserAgent":  func() string { return pick(fakeUserAgents) },
+	"$randomProtocol":   func() string { return pick([]string{"http", "https"}) },
+	"$randomSemver":     func() string { return fmt.Sprintf("%d.%d.%d", rand.Intn(10), rand.Intn(10), rand.Intn(10)) },
+
+	// Names
+	"$randomFirstName":  func() string { return pick(fakeFirstNames) },
+	"$randomLastName":   func() string { return pick(fakeLastNames) },
+	"$randomFullName":   func() string { return pick(fakeFirstNames) + " " + pick(fakeLastNames) },
+	"$randomNamePrefix": func() string { return pick(fakeNamePrefix) },
+	"$randomNameSuffix": func() string { return pick(fakeNameSuffix) },
+	"$randomJobTitle":   func() string { return pick(fakeJobTitles) },
+
+	// Phone, address and location
+	"$randomPhoneNumber":    randomPhoneNumber,
+	"$randomPhoneNumberExt": func() string { return fmt.Sprintf("%d-%s", rand.Intn(90)+10, randomPhoneNumber()) },
+	"$randomCity":           func() string { return pick(fakeCities) },
+	"$randomStreetName":     func() string { return pick(fakeStreets) },
+	"$randomStreetAddress":  func() string { return fmt.Sprintf("%d %s", rand.Intn(9999)+1, pick(fakeStreets)) },
+	"$randomCountry":        func() string { return pick(fakeCountries) },
+	"$randomCountryCode":    func() string { return pick(fakeCountryCode) },
+	"$randomLatitude":       func() string { return fmt.Sprintf("%.4f", rand.Float64()*180-90) },
+	"$randomLongitude":      func() string { return fmt.Sprintf("%.4f", rand.Float64()*360-180) },
+
+	// Images
+	"$randomAvatarImage": func() string { return fmt.Sprintf("https://i.pravatar.cc/150?img=%d", rand.Intn(70)+1) },
+	"$randomImageUrl":    func() string { return fmt.Sprintf("https://picsum.photos/640/480?random=%d", rand.Intn(1000)) },
+
+	// Finance and business
+	"$randomBankAccount":    func() string { return randomDigits(8) },
+	"$randomPrice":          func() string { return fmt.Sprintf("%d.%02d", rand.Intn(1000), rand.Intn(100)) },
+	"$randomCurrencyCode":   func() string { return pick(fakeCurrencies) },
+	"$randomCurrencySymbol": func() string { return pick(fakeCurrSymbols) },
+	"$randomCompanyName":    func() string { return pick(fakeCompanies) + " " + pick(fakeCompanySuff) },
+	"$randomCompanySuffix":  func() string { return pick(fakeCompanySuff) },
+
+	// Dates, in the format of JavaScript dates
+	"$randomDateFuture": func() string { return jsDate(time.Now().Add(randomDuration(365 * 24 * time.Hour))) },
+	"$randomDatePast":   func() string { return jsDate(time.Now().Add(-randomDuration(365 * 24 * time.Hour))) },
+	"$randomDateRecent": func() string { return jsDate(time.Now().Add(-randomDuration(24 * time.Hour))) },
+	"$randomWeekday":    func() string { return pick(fakeWeekdays) },
+	"$randomMonth":      func() string { return pick(fakeMonths) },
+
+	// Domains, emails and usernames
+	"$randomDomainName":   func() string { return randomDomainWord() + "." + pick(fakeDomainSuff) },
+	"$randomDomainSuffix": func() string { return pick(fakeDomainSuff) },
+	"$randomDomainWord":   randomDomainWord,
+	"$randomEmail":        func() string { return randomUserName() + "@" + randomDomainWord() + "." + pick(fakeDomainSuff) },
+	"$randomExampleEmail": func() string { return randomUserName() + "@example." + pick([]string{"com", "net", "org"}) },
+	"$randomUserName":     randomUserName,
+	"$randomUrl":          func() string { return "https://" + randomDomainWord() + "." + pick(fakeDomainSuff) },
+
+	// Files
+	"$randomFileName": func() string { return pick(loremWords) + "_" + pick(loremWords) + "." + pick(fakeFileExts) },
+	"$randomFileExt":  func() string { return pick(fakeFileExts) },
+	"$randomMimeType": func() string { return pick(fakeMimeTypes) },
+
+	// Products
+	"$randomDepartment":       func() string { return pick(fakeDepartments) },
+	"$randomProduct":          func() string { return pick(fakeProducts) },
+	"$randomProductAdjective": func() string { return pick(fakeAdjectives) },
+	"$randomProductMaterial":  func() string { return pick(fakeMaterials) },
+	"$randomProductName":      func() string { return pick(fakeAdjectives) + " " + pick(fakeMaterials) + " " + pick(fakeProducts) },
+
+	// Lorem ipsum
+	"$randomWord":           func() string { return pick(loremWords) },
+	"$randomWords":          func() string { return loremText(rand.Intn(3)+2, " ") },
+	"$randomLoremWord":      func() string { return pick(loremWords) },
+	"$randomLoremWords":     func() string { return loremText(3, " ") },
+	"$randomLoremSlug":      func() string { return loremText(3, "-") },
+	"$randomLoremSentence":  loremSentence,
+	"$randomLoremSentences": func() string { return loremSentences(rand.Intn(4)+2, " ") },
+	"$randomLoremParagraph": func() string { return loremSentences(3, " ") },
+	"$randomLoremText":      func() string { return loremSentences(rand.Intn(3)+1, " ") },
+	"$randomLoremLines":     func() string { return loremSentences(rand.Intn(4)+1, "\n") },
+}
+
+// pick returns a random item of values
+func pick(values []string) string {
+	return values[rand.Intn(len(values))]
+}
+
+// randomDigits returns n random decimal digits
+func randomDigits(n int) string {
+	digits := make([]byte, n)
+	for i := range digits {
+		digits[i] = byte('0' + rand.Intn(10))
+	}
+	return string(digits)
+}
+
+// randomDuration returns a random duration up to max
+func randomDuration(max time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+// jsDate formats t like the string of a JavaScript date
+func jsDate(t time.Time) string {
+	return t.Format("Mon Jan 02 2006 15:04:05 GMT-0700")
+}
+
+// randomIPv6 returns a random IPv6 address
+func randomIPv6() string {
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%x", rand.Intn(0x10000))
+	}
+	return strings.Join(groups, ":")
+}
+
+// randomMACAddress returns a random MAC address
+func randomMACAddress() string {
+	bytes := make([]string, 6)
+	for i := range bytes {
+		bytes[i] = fmt.Sprintf("%02x", rand.Intn(256))
+	}
+	return strings.Join(bytes, ":")
+}
+
+// randomPhoneNumber returns a random phone number in the North American format
+func randomPhoneNumber() string {
+	return fmt.Sprintf("%d-%s-%s", rand.Intn(800)+200, randomDigits(3), randomDigits(4))
+}
+
+// randomUserName returns a random username such as Alice.Smith42
+func randomUserName() string {
+	return fmt.Sprintf("%s.%s%d", pick(fakeFirstNames), pick(fakeLastNames), rand.Intn(100))
+}
+
+// randomDomainWord returns a random domain name without its suffix
+func randomDomainWord() string {
+	return strings.ToLower(pick(fakeFirstNames) + "-" + pick(fakeCompanies))
+}
+
+// loremText returns n lorem ipsum words joined by sep
+func loremText(n int, sep string) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = pick(loremWords)
+	}
+	return strings.Join(words, sep)
+}
+
+// loremSentence returns a capitalized lorem ipsum sentence
+func loremSentence() string {
+	sentence := loremText(rand.Intn(6)+4, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// loremSentences returns n lorem ipsum sentences joined by sep
+func loremSentences(n int, sep string) string {
+	sentences := make([]string, n)
+	for i := range sentences {
+		sentences[i] = loremSentence()
+	}
+	return strings.Join(sentences, sep)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDynamicVariables(t *testing.T) {
+	for name := range dynamicVariables {
+		if value := GetSystemVariable(name); value == "" {
+			t.Errorf("%s generated an empty value", name)
+		}
+	}
+
+	if email := ReplaceVariables("{{$randomEmail}}", nil); !regexp.MustCompile(`^[\w.]+@[\w-]+\.\w+$`).MatchString(email) {
+		t.Errorf("$randomEmail = %q, want an email address", email)
+	}
+	if _, err := time.Parse(time.RFC3339, ReplaceVariables("{{$isoTimestamp}}", nil)); err != nil {
+		t.Errorf("$isoTimestamp is not an ISO timestamp: %v", err)
+	}
+	if id := ReplaceVariables("{{$randomUUID}}", nil); len(id) != 36 || strings.Count(id, "-") != 4 {
+		t.Errorf("$randomUUID = %q, want a UUID", id)
+	}
+	if ip := GetSystemVariable("$randomIP"); !regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`).MatchString(ip) {
+		t.Errorf("$randomIP = %q, want an IPv4 address", ip)
+	}
+
+	// Unknown dynamic variables keep their placeholder
+	if got := ReplaceVariables("{{$randomUnicorn}}", nil); got != "{{$randomUnicorn}}" {
+		t.Errorf("unknown variable replaced with %q", got)
+	}
+}
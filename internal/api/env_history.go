@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// EnvHistoryFileName is the name of the variable history file in the .lazycurl directory
+	EnvHistoryFileName = "env_history.json"
+	// MaxEnvHistory is the number of values kept per variable; older values are dropped first
+	MaxEnvHistory = 10
+)
+
+// Sources of environment variable changes, as shown in the variable history
+const (
+	EnvSourceEdit       = "edit"
+	EnvSourcePreScript  = "pre-request script"
+	EnvSourcePostScript = "post-response script"
+	EnvSourceExtract    = "extraction"
+	EnvSourceRun        = "run"
+	EnvSourceQuery      = "query"
+	EnvSourceRequired   = "required"
+	EnvSourceRollback   = "rollback"
+	EnvSourceHook       = "activation hook"
+	EnvSourceImport     = "import"
+)
+
+// EnvValue is one recorded value of an environment variable
+type EnvValue struct {
+	Value  string    `json:"value"`
+	Time   time.Time `json:"time"`
+	Source string    `json:"source,omitempty"`
+}
+
+// EnvHistory holds the previous values of the environment variables of a workspace,
+// by environment name then variable name, oldest first.
+// Secret variables are not recorded: their values stay out of the history file.
+type EnvHistory struct {
+	Environments map[string]map[string][]EnvValue `json:"environments"`
+	path         string
+}
+
+// EnvHistoryPath returns the variable history file path of a workspace
+func EnvHistoryPath(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", EnvHistoryFileName)
+}
+
+// LoadEnvHistory reads the variable history of a workspace. A missing file yields an
+// empty history.
+func LoadEnvHistory(workspacePath string) (*EnvHistory, error) {
+	h := &EnvHistory{path: EnvHistoryPath(workspacePath)}
+	data, err := os.ReadFile(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return h, fmt.Errorf("failed to read variable history: %w", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return h, fmt.Errorf("failed to parse variable history: %w", err)
+	}
+	return h, nil
+}
+
+// Save writes the variable history file
+func (h *EnvHistory) Save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0600)
+}
+
+// Record adds a value of a variable, dropping the oldest values beyond MaxEnvHistory.
+// It returns false when the value is the latest one recorded.
+func (h *EnvHistory) Record(env, name string, value EnvValue) bool {
+	values := h.Values(env, name)
+	if n := len(values); n > 0 && values[n-1].Value == value.Value {
+		return false
+	}
+	values = append(values, value)
+	if over := len(values) - MaxEnvHistory; over > 0 {
+		values = append([]EnvValue(nil), values[over:]...)
+	}
+
+	if h.Environments == nil {
+		h.Environments = make(map[string]map[string][]EnvValue)
+	}
+	if h.Environments[env] == nil {
+		h.Environments[env] = make(map[string][]EnvValue)
+	}
+	h.Environments[env][name] = values
+	return true
+}
+
+// Values returns the recorded values of a variable, oldest first
+func (h *EnvHistory) Values(env, name string) []EnvValue {
+	return h.Environments[env][name]
+}
+
+// RenameEnvironment moves the history of an environment to its new name
+func (h *EnvHistory) RenameEnvironment(oldName, newName string) {
+	if vars, ok := h.Environments[oldName]; ok {
+		delete(h.Environments, oldName)
+		h.Environments[newName] = vars
+	}
+}
+
+// RenameVariable moves the history of a variable to its new name
+func (h *EnvHistory) RenameVariable(env, oldName, newName string) {
+	if values, ok := h.Environments[env][oldName]; ok {
+		delete(h.Environments[env], oldName)
+		h.Environments[env][newName] = values
+	}
+}
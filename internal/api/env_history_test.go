@@ -0,0 +1,48 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEnvHistory_Record(t *testing.T) {
+	dir := t.TempDir()
+	h, err := LoadEnvHistory(dir)
+	if err != nil {
+		t.Fatalf("LoadEnvHistory() error = %v", err)
+	}
+
+	now := time.Now()
+	for i := 0; i < MaxEnvHistory+3; i++ {
+		h.Record("dev", "token", EnvValue{Value: fmt.Sprintf("t%d", i), Time: now, Source: EnvSourcePostScript})
+	}
+	if h.Record("dev", "token", EnvValue{Value: fmt.Sprintf("t%d", MaxEnvHistory+2)}) {
+		t.Error("Record() should skip the latest value")
+	}
+
+	values := h.Values("dev", "token")
+	if len(values) != MaxEnvHistory {
+		t.Fatalf("Values() = %d values, want %d", len(values), MaxEnvHistory)
+	}
+	if values[0].Value != "t3" || values[len(values)-1].Value != fmt.Sprintf("t%d", MaxEnvHistory+2) {
+		t.Errorf("Values() should keep the latest values, got %q to %q", values[0].Value, values[len(values)-1].Value)
+	}
+
+	h.RenameVariable("dev", "token", "access_token")
+	h.RenameEnvironment("dev", "development")
+	if err := h.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadEnvHistory(dir)
+	if err != nil {
+		t.Fatalf("LoadEnvHistory() error = %v", err)
+	}
+	if got := loaded.Values("development", "access_token"); len(got) != MaxEnvHistory || got[0].Source != EnvSourcePostScript {
+		t.Errorf("loaded history = %v, want the renamed values", got)
+	}
+	if got := loaded.Values("dev", "token"); got != nil {
+		t.Errorf("old names should have no history, got %v", got)
+	}
+}
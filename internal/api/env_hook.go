@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnvironmentHook runs when its environment becomes active: the command first, then
+// the script
+type EnvironmentHook struct {
+	Command string `json:"command,omitempty"` // Shell command, run in the workspace directory
+	Script  string `json:"script,omitempty"`  // Script run like a pre-request script, without lc.request
+}
+
+// IsZero returns true if the hook runs nothing
+func (h *EnvironmentHook) IsZero() bool {
+	return h == nil || (strings.TrimSpace(h.Command) == "" && strings.TrimSpace(h.Script) == "")
+}
+
+// HookMethod is the method of the console entries of activation hooks
+const HookMethod HTTPMethod = "HOOK"
+
+// HookWait is how long an activation command is waited for. A command still running
+// after it, such as a port-forward, keeps running in the background.
+const HookWait = 10 * time.Second
+
+// NewHookConsoleEntry creates the console entry of an activation hook run of an
+// environment, with its output as logs
+func NewHookConsoleEntry(environment, description string, logs []ConsoleLogEntry, err error, duration time.Duration) *ConsoleEntry {
+	entry := &ConsoleEntry{
+		ID:        uuid.New().String(),
+		Timestamp: time.Now(),
+		Request:   &Request{Method: HookMethod, URL: description},
+		Error:     err,
+		Duration:  duration,
+		Status:    StatusSuccess,
+		Logs:      logs,
+		Hook:      environment,
+	}
+	if err != nil {
+		entry.Status = StatusNetworkError
+	}
+	return entry
+}
+
+// HookProcess is an activation command started with StartHookCommand
+type HookProcess struct {
+	cmd    *exec.Cmd
+	output *hookOutput
+	done   chan struct{}
+	err    error
+}
+
+// StartHookCommand starts a shell command in dir, with env added to the environment
+// variables of LazyCurl
+func StartHookCommand(command, dir string, env []string) (*HookProcess, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = time.Second // Children left holding the output do not block Wait
+	setProcessGroup(cmd)
+	output := &hookOutput{}
+	cmd.Stdout, cmd.Stderr = output, output
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start hook command: %w", err)
+	}
+
+	p := &HookProcess{cmd: cmd, output: output, done: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// Wait waits up to timeout for the command to exit. Returns its output, as log lines,
+// and whether it is still running; output written after is discarded.
+func (p *HookProcess) Wait(timeout time.Duration) ([]ConsoleLogEntry, bool, error) {
+	select {
+	case <-p.done:
+		return p.output.logs(), false, p.err
+	case <-time.After(timeout):
+		return p.output.logs(), true, nil
+	}
+}
+
+// Stop kills the command, and the processes it started, if it is still running
+func (p *HookProcess) Stop() {
+	select {
+	case <-p.done:
+	default:
+		killProcessGroup(p.cmd)
+		<-p.done
+	}
+}
+
+// hookOutput collects the output of a hook command until read
+type hookOutput struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+// Write keeps p until the output is read, and discards it after
+func (o *hookOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.closed {
+		o.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+// logs returns the output collected as log lines and stops collecting
+func (o *hookOutput) logs() []ConsoleLogEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+	now := time.Now()
+	var logs []ConsoleLogEntry
+	for _, line := range strings.Split(strings.TrimRight(o.buf.String(), "\r\n"), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			logs = append(logs, ConsoleLogEntry{Level: LogLevelLog, Message: line, Timestamp: now})
+		}
+	}
+	return logs
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestStartHookCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands are run by sh in this test")
+	}
+	dir := t.TempDir()
+
+	process, err := StartHookCommand(`echo "token for $LAZYCURL_ENVIRONMENT"; pwd; echo oops >&2; exit 3`, dir, []string{"LAZYCURL_ENVIRONMENT=dev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs, running, err := process.Wait(5 * time.Second)
+	var exitErr *exec.ExitError
+	if running || !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("Wait() = running %v, err %v; want exit status 3", running, err)
+	}
+	resolved, _ := filepath.EvalSymlinks(dir)
+	want := []string{"token for dev", resolved, "oops"}
+	if len(logs) != len(want) {
+		t.Fatalf("logs = %+v, want %v", logs, want)
+	}
+	for i, log := range logs {
+		if got, _ := filepath.EvalSymlinks(log.Message); log.Message != want[i] && got != want[i] {
+			t.Errorf("log %d = %q, want %q", i, log.Message, want[i])
+		}
+	}
+
+	// A command still running after the wait is left running until stopped
+	process, err = StartHookCommand("echo forwarding; sleep 30", dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs, running, err = process.Wait(500 * time.Millisecond)
+	if !running || err != nil || len(logs) != 1 || logs[0].Message != "forwarding" {
+		t.Fatalf("Wait() = %+v, running %v, err %v; want forwarding, still running", logs, running, err)
+	}
+	process.Stop()
+	select {
+	case <-process.done:
+	default:
+		t.Error("Stop() should end the command")
+	}
+}
+
+func TestEnvironmentHook_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.json")
+	env := &EnvironmentFile{
+		Name:       "dev",
+		Variables:  map[string]*EnvironmentVariable{"base_url": {Value: "http://localhost:8080", Active: true}},
+		OnActivate: &EnvironmentHook{Command: "kubectl port-forward svc/api 8080:80", Script: `lc.environment.set("token", "t")`},
+	}
+	if err := SaveEnvironment(env, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadEnvironment(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.OnActivate == nil || *loaded.OnActivate != *env.OnActivate {
+		t.Errorf("OnActivate = %+v, want %+v", loaded.OnActivate, env.OnActivate)
+	}
+	if clone := loaded.Clone(); clone.OnActivate == loaded.OnActivate || *clone.OnActivate != *loaded.OnActivate {
+		t.Error("Clone() should copy the activation hook")
+	}
+	if (&EnvironmentHook{Command: " "}).IsZero() != true || loaded.OnActivate.IsZero() {
+		t.Error("IsZero() should only be true for hooks running nothing")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package api
+
+import "os/exec"
+
+// setProcessGroup does nothing on Windows
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills cmd; the processes it started keep running
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
@@ -6,13 +6,23 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
 )
 
+// SecretStore keeps the values of secret variables out of the environment files when set
+// (see internal/secrets); nil stores them in the files
+var SecretStore secrets.Store
+
 // EnvironmentVariable represents a variable with metadata
 type EnvironmentVariable struct {
 	Value  string `json:"value"`
 	Secret bool   `json:"secret,omitempty"`
 	Active bool   `json:"active"`
+	// Keychain marks values kept in the SecretStore; Value is empty in the file
+	Keychain bool `json:"keychain,omitempty"`
+	// Type is one of the VariableType values; empty is a string
+	Type string `json:"type,omitempty"`
 }
 
 // EnvironmentFile represents an environment configuration file
@@ -20,7 +30,10 @@ type EnvironmentFile struct {
 	Name        string                          `json:"name"`
 	Description string                          `json:"description,omitempty"`
 	Variables   map[string]*EnvironmentVariable `json:"variables"`
-	FilePath    string                          `json:"-"` // Internal: path to the file
+	OnActivate  *EnvironmentHook                `json:"on_activate,omitempty"` // Run when the environment becomes active
+	FilePath    string                          `json:"-"`                     // Internal: path to the file
+
+	storedSecrets map[string]bool // Keys of the values read from or written to the SecretStore
 }
 
 // LoadEnvironment loads an environment from a JSON file
@@ -36,6 +49,7 @@ func LoadEnvironment(path string) (*EnvironmentFile, error) {
 		Name        string                     `json:"name"`
 		Description string                     `json:"description,omitempty"`
 		Variables   map[string]json.RawMessage `json:"variables"`
+		OnActivate  *EnvironmentHook           `json:"on_activate"`
 	}
 	if err := json.Unmarshal(data, &rawEnv); err != nil {
 		return nil, fmt.Errorf("failed to parse environment JSON: %w", err)
@@ -45,6 +59,7 @@ func LoadEnvironment(path string) (*EnvironmentFile, error) {
 		Name:        rawEnv.Name,
 		Description: rawEnv.Description,
 		Variables:   make(map[string]*EnvironmentVariable),
+		OnActivate:  rawEnv.OnActivate,
 		FilePath:    path,
 	}
 
@@ -53,6 +68,9 @@ func LoadEnvironment(path string) (*EnvironmentFile, error) {
 		// Try to parse as new format (object) first
 		var envVar EnvironmentVariable
 		if err := json.Unmarshal(rawValue, &envVar); err == nil {
+			if err := ValidateVariableType(envVar.Type); err != nil {
+				return nil, fmt.Errorf("variable '%s': %w", name, err)
+			}
 			env.Variables[name] = &envVar
 			continue
 		}
@@ -72,9 +90,103 @@ func LoadEnvironment(path string) (*EnvironmentFile, error) {
 		return nil, fmt.Errorf("invalid variable format for '%s'", name)
 	}
 
+	env.resolveSecrets(path)
 	return env, nil
 }
 
+// secretKey returns the key of a variable of the environment file at path in the SecretStore
+func secretKey(path, name string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path + "#" + name
+}
+
+// resolveSecrets reads the values kept in the SecretStore. Values that cannot be read stay
+// empty and keep their keychain reference, so saving the file does not lose them.
+func (e *EnvironmentFile) resolveSecrets(path string) {
+	e.storedSecrets = make(map[string]bool)
+	if SecretStore == nil {
+		return
+	}
+	for name, v := range e.Variables {
+		if !v.Keychain {
+			continue
+		}
+		key := secretKey(path, name)
+		if value, err := SecretStore.Get(key); err == nil {
+			v.Value = value
+			e.storedSecrets[key] = true
+		}
+	}
+}
+
+// storeSecrets writes the values of secret variables to the SecretStore and returns the
+// variables as saved in the file. Values of variables no longer secret, renamed or
+// deleted are removed from the store.
+func (e *EnvironmentFile) storeSecrets(path string) (map[string]*EnvironmentVariable, error) {
+	if e.storedSecrets == nil {
+		e.storedSecrets = make(map[string]bool)
+	}
+	saved := make(map[string]*EnvironmentVariable, len(e.Variables))
+	written := make(map[string]bool)
+	for name, v := range e.Variables {
+		key := secretKey(path, name)
+		file := &EnvironmentVariable{Value: v.Value, Secret: v.Secret, Active: v.Active, Type: v.Type}
+		switch {
+		case SecretStore != nil && v.Secret && v.Value != "":
+			if err := SecretStore.Set(key, v.Value); err != nil {
+				return nil, fmt.Errorf("failed to store %s in keychain: %w", name, err)
+			}
+			file.Value, file.Keychain = "", true
+			written[key] = true
+		case v.Keychain && v.Value == "" && !e.storedSecrets[key]:
+			// Unresolved reference: keep it for a store that can read it
+			file.Keychain = true
+		}
+		v.Keychain = file.Keychain
+		saved[name] = file
+	}
+
+	if SecretStore != nil {
+		for key := range e.storedSecrets {
+			if !written[key] {
+				if err := SecretStore.Delete(key); err != nil {
+					return nil, fmt.Errorf("failed to remove secret from keychain: %w", err)
+				}
+			}
+		}
+		e.storedSecrets = written
+	}
+	return saved, nil
+}
+
+// DeleteEnvironmentSecrets removes the values of env kept in the SecretStore, for a deleted
+// environment or after moving them back to the file with SecretStore unset
+func DeleteEnvironmentSecrets(env *EnvironmentFile, store secrets.Store) error {
+	if store == nil {
+		return nil
+	}
+	for key := range env.storedSecrets {
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+		delete(env.storedSecrets, key)
+	}
+	return nil
+}
+
+// KeychainVariableCount returns the number of variables of env kept in the SecretStore
+func (e *EnvironmentFile) KeychainVariableCount() int {
+	count := 0
+	for _, v := range e.Variables {
+		if v.Keychain {
+			count++
+		}
+	}
+	return count
+}
+
 // isSecretKey checks if a variable name suggests it should be secret
 func isSecretKey(name string) bool {
 	nameLower := strings.ToLower(name)
@@ -87,9 +199,16 @@ func isSecretKey(name string) bool {
 	return false
 }
 
-// SaveEnvironment saves an environment to a JSON file
+// SaveEnvironment saves an environment to a JSON file. Values of secret variables are
+// written to the SecretStore when set, and left empty in the file.
 func SaveEnvironment(env *EnvironmentFile, path string) error {
-	data, err := json.MarshalIndent(env, "", "  ")
+	variables, err := env.storeSecrets(path)
+	if err != nil {
+		return err
+	}
+	file := *env
+	file.Variables = variables
+	data, err := json.MarshalIndent(&file, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal environment: %w", err)
 	}
@@ -163,6 +282,7 @@ func MergeEnvironments(envs ...*EnvironmentFile) *EnvironmentFile {
 				Value:  value.Value,
 				Secret: value.Secret,
 				Active: value.Active,
+				Type:   value.Type,
 			}
 		}
 	}
@@ -251,12 +371,18 @@ func (e *EnvironmentFile) Clone() *EnvironmentFile {
 		FilePath:    e.FilePath,
 		Variables:   make(map[string]*EnvironmentVariable),
 	}
+	if e.OnActivate != nil {
+		hook := *e.OnActivate
+		clone.OnActivate = &hook
+	}
 
 	for k, v := range e.Variables {
 		clone.Variables[k] = &EnvironmentVariable{
-			Value:  v.Value,
-			Secret: v.Secret,
-			Active: v.Active,
+			Value:    v.Value,
+			Secret:   v.Secret,
+			Active:   v.Active,
+			Keychain: v.Keychain,
+			Type:     v.Type,
 		}
 	}
 
@@ -3,7 +3,10 @@ package api
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
 )
 
 // Helper to create environment variable
@@ -105,6 +108,92 @@ func TestSaveEnvironment(t *testing.T) {
 	}
 }
 
+func TestSaveEnvironment_SecretStore(t *testing.T) {
+	store := secrets.NewMemoryStore()
+	SecretStore = store
+	t.Cleanup(func() { SecretStore = nil })
+
+	path := filepath.Join(t.TempDir(), "dev.json")
+	readFile := func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	env := &EnvironmentFile{Name: "Dev", Variables: map[string]*EnvironmentVariable{
+		"base_url": newVar("http://localhost", false, true),
+		"api_key":  newVar("s3cr3t", true, true),
+	}}
+	if err := SaveEnvironment(env, path); err != nil {
+		t.Fatalf("SaveEnvironment() error = %v", err)
+	}
+	if file := readFile(); strings.Contains(file, "s3cr3t") || !strings.Contains(file, `"keychain": true`) {
+		t.Errorf("secret value should be kept out of the file:\n%s", file)
+	}
+	if value, _ := store.Get(secretKey(path, "api_key")); value != "s3cr3t" {
+		t.Errorf("stored value = %q", value)
+	}
+	if env.Variables["api_key"].Value != "s3cr3t" {
+		t.Error("the value in memory should be kept")
+	}
+
+	// Values are resolved on load
+	loaded, err := LoadEnvironment(path)
+	if err != nil {
+		t.Fatalf("LoadEnvironment() error = %v", err)
+	}
+	if v := loaded.Variables["api_key"]; v.Value != "s3cr3t" || !v.Keychain {
+		t.Errorf("api_key = %+v", v)
+	}
+	if loaded.KeychainVariableCount() != 1 {
+		t.Errorf("KeychainVariableCount() = %d, want 1", loaded.KeychainVariableCount())
+	}
+
+	// A variable no longer secret goes back to the file
+	loaded.ToggleVariableSecret("api_key")
+	if err := SaveEnvironment(loaded, path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(readFile(), "s3cr3t") || store.Len() != 0 {
+		t.Errorf("value should move back to the file, %d stored", store.Len())
+	}
+
+	// Deleted variables are removed from the store
+	loaded.ToggleVariableSecret("api_key")
+	_ = SaveEnvironment(loaded, path)
+	loaded.DeleteVariable("api_key")
+	_ = SaveEnvironment(loaded, path)
+	if store.Len() != 0 {
+		t.Errorf("deleted variable should be removed from the store, %d stored", store.Len())
+	}
+
+	// References the store cannot resolve are kept
+	loaded.SetVariableFull("token", newVar("abc", true, true))
+	_ = SaveEnvironment(loaded, path)
+	SecretStore = secrets.NewMemoryStore()
+	unresolved, _ := LoadEnvironment(path)
+	if err := SaveEnvironment(unresolved, path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(readFile(), `"keychain": true`) {
+		t.Errorf("unresolved reference should be kept:\n%s", readFile())
+	}
+
+	// Moving the values back to the file
+	SecretStore = nil
+	if err := SaveEnvironment(loaded, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteEnvironmentSecrets(loaded, store); err != nil {
+		t.Fatal(err)
+	}
+	if file := readFile(); !strings.Contains(file, `"abc"`) || strings.Contains(file, "keychain") || store.Len() != 0 {
+		t.Errorf("values should be back in the file, %d stored:\n%s", store.Len(), file)
+	}
+}
+
 func TestLoadAllEnvironments(t *testing.T) {
 	tmpDir := t.TempDir()
 	envsDir := filepath.Join(tmpDir, "envs")
@@ -14,6 +14,10 @@ type EditableField string
 const (
 	EditableFieldBody    EditableField = "body"
 	EditableFieldHeaders EditableField = "headers"
+
+	// Scripts of a collection or folder (see InheritedScripts)
+	EditableFieldPreRequestScript   EditableField = "pre_request_script"
+	EditableFieldPostResponseScript EditableField = "post_response_script"
 )
 
 // EditorSource indicates the origin of editor configuration
@@ -33,6 +37,7 @@ const (
 	ContentTypeXML  ContentType = "xml"
 	ContentTypeHTML ContentType = "html"
 	ContentTypeText ContentType = "text"
+	ContentTypeJS   ContentType = "javascript"
 )
 
 // EditorConfig holds the parsed editor command configuration
@@ -54,6 +59,7 @@ var ContentTypeExtensions = map[ContentType]string{
 	ContentTypeXML:  ".xml",
 	ContentTypeHTML: ".html",
 	ContentTypeText: ".txt",
+	ContentTypeJS:   ".js",
 }
 
 // ErrNoEditorAvailable is returned when no editor can be found
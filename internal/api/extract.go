@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/format"
+)
+
+// Extraction rule types
+const (
+	ExtractJSONPath = "jsonpath"
+	ExtractRegex    = "regex"
+	ExtractXPath    = "xpath"
+)
+
+// ExtractRule stores a value read from the responses of a request in an environment
+// variable. Rules are stored on the request and applied in order after every send.
+type ExtractRule struct {
+	Variable   string `json:"variable"`         // Environment variable set with the value
+	Type       string `json:"type"`             // ExtractJSONPath, ExtractRegex or ExtractXPath
+	Expression string `json:"expression"`       // JSONPath, regular expression or XPath
+	Header     string `json:"header,omitempty"` // Response header read instead of the body
+	Disabled   bool   `json:"disabled,omitempty"`
+}
+
+// Label describes the rule: "{{token}} ← regex token=(\w+)"
+func (r ExtractRule) Label() string {
+	source := r.Type
+	if r.Header != "" {
+		source += "@" + r.Header
+	}
+	return fmt.Sprintf("{{%s}} ← %s %s", r.Variable, source, r.Expression)
+}
+
+// Source returns the rule as edited in the Capture tab: the expression alone for a
+// JSONPath rule reading the body, else the type, header and expression
+// ("regex@Location /orders/(\d+)")
+func (r ExtractRule) Source() string {
+	if r.Type == ExtractJSONPath && r.Header == "" && strings.HasPrefix(r.Expression, "$") {
+		return r.Expression
+	}
+	source := r.Type
+	if r.Header != "" {
+		source += "@" + r.Header
+	}
+	return source + " " + r.Expression
+}
+
+// ParseExtractRule reads a rule storing a value in variable from its source: a JSONPath
+// expression starting with $, or a type with an optional @header followed by an expression
+func ParseExtractRule(variable, source string) (ExtractRule, error) {
+	rule := ExtractRule{Variable: strings.TrimSpace(variable)}
+	source = strings.TrimSpace(source)
+	if strings.HasPrefix(source, "$") {
+		rule.Type, rule.Expression = ExtractJSONPath, source
+	} else {
+		prefix, expression, _ := strings.Cut(source, " ")
+		ruleType, header, _ := strings.Cut(prefix, "@")
+		rule.Type, rule.Header, rule.Expression = strings.ToLower(ruleType), header, strings.TrimSpace(expression)
+	}
+	return rule, rule.Validate()
+}
+
+// Validate checks the rule has a variable, a known type and a valid expression
+func (r ExtractRule) Validate() error {
+	if strings.TrimSpace(r.Variable) == "" {
+		return fmt.Errorf("extract: missing variable name")
+	}
+	if strings.TrimSpace(r.Expression) == "" {
+		return fmt.Errorf("extract: missing expression")
+	}
+	switch r.Type {
+	case ExtractJSONPath, ExtractXPath:
+		return nil
+	case ExtractRegex:
+		if _, err := regexp.Compile(r.Expression); err != nil {
+			return fmt.Errorf("extract: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("extract: unknown type %q (use %s, %s or %s)", r.Type, ExtractJSONPath, ExtractRegex, ExtractXPath)
+	}
+}
+
+// Extract returns the value the rule reads from resp. Regular expressions return their
+// first capture group, or the whole match without groups; JSONPath and XPath
+// expressions return their first match.
+func (r ExtractRule) Extract(resp *Response) (string, error) {
+	source, err := resp.FullBody()
+	if err != nil {
+		return "", err
+	}
+	if r.Header != "" {
+		value := http.Header(resp.Headers).Get(r.Header)
+		if value == "" {
+			return "", fmt.Errorf("no %s header", r.Header)
+		}
+		source = []byte(value)
+	}
+
+	switch r.Type {
+	case ExtractJSONPath:
+		result, err := format.QueryJSONPath(source, r.Expression)
+		if err != nil {
+			return "", err
+		}
+		if matches, ok := result.([]interface{}); ok {
+			if len(matches) == 0 {
+				return "", fmt.Errorf("no match for %s", r.Expression)
+			}
+			result = matches[0]
+		}
+		return format.JSONValueText(result), nil
+
+	case ExtractXPath:
+		values, err := format.QueryXPath(source, r.Expression)
+		if err != nil {
+			return "", err
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("no match for %s", r.Expression)
+		}
+		return values[0], nil
+
+	case ExtractRegex:
+		re, err := regexp.Compile(r.Expression)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindSubmatch(source)
+		if match == nil {
+			return "", fmt.Errorf("no match for %s", r.Expression)
+		}
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+
+	default:
+		return "", fmt.Errorf("unknown type %q", r.Type)
+	}
+}
+
+// ExtractError reports a rule that found nothing in a response
+type ExtractError struct {
+	Rule ExtractRule
+	Err  error
+}
+
+// Error implements error
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("extract {{%s}}: %v", e.Rule.Variable, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyExtractRules runs the enabled rules against resp. Returns the environment
+// changes setting the extracted values, and an error per rule that found nothing.
+func ApplyExtractRules(rules []ExtractRule, resp *Response) ([]EnvChange, []*ExtractError) {
+	if resp == nil {
+		return nil, nil
+	}
+	var changes []EnvChange
+	var errs []*ExtractError
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		value, err := rule.Extract(resp)
+		if err != nil {
+			errs = append(errs, &ExtractError{Rule: rule, Err: err})
+			continue
+		}
+		changes = append(changes, EnvChange{Type: EnvChangeSet, Name: rule.Variable, Value: value})
+	}
+	return changes, errs
+}
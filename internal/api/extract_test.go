@@ -0,0 +1,116 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestExtractRule_Extract(t *testing.T) {
+	jsonResp := &Response{
+		Headers: map[string][]string{"Location": {"/orders/42"}},
+		Body:    []byte(`{"data":{"token":"abc","items":[{"id":7},{"id":8}]}}`),
+	}
+	htmlResp := &Response{
+		Body: []byte(`<html><body><form><input name="csrf" value="t0k3n"></form><p>Order #1234 placed</p></body></html>`),
+	}
+
+	tests := []struct {
+		name    string
+		rule    ExtractRule
+		resp    *Response
+		want    string
+		wantErr bool
+	}{
+		{name: "jsonpath", rule: ExtractRule{Type: ExtractJSONPath, Expression: "$.data.token"}, resp: jsonResp, want: "abc"},
+		{name: "jsonpath first match", rule: ExtractRule{Type: ExtractJSONPath, Expression: "$.data.items[*].id"}, resp: jsonResp, want: "7"},
+		{name: "jsonpath no match", rule: ExtractRule{Type: ExtractJSONPath, Expression: "$.data.missing[*]"}, resp: jsonResp, wantErr: true},
+		{name: "regex group", rule: ExtractRule{Type: ExtractRegex, Expression: `Order #(\d+)`}, resp: htmlResp, want: "1234"},
+		{name: "regex whole match", rule: ExtractRule{Type: ExtractRegex, Expression: `#\d+`}, resp: htmlResp, want: "#1234"},
+		{name: "regex no match", rule: ExtractRule{Type: ExtractRegex, Expression: `Invoice (\d+)`}, resp: htmlResp, wantErr: true},
+		{name: "xpath", rule: ExtractRule{Type: ExtractXPath, Expression: "//input[@name='csrf']/@value"}, resp: htmlResp, want: "t0k3n"},
+		{name: "header", rule: ExtractRule{Type: ExtractRegex, Header: "location", Expression: `/orders/(\d+)`}, resp: jsonResp, want: "42"},
+		{name: "missing header", rule: ExtractRule{Type: ExtractRegex, Header: "X-Id", Expression: `.+`}, resp: jsonResp, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Extract(tt.resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Extract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Extract() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ExtractRule
+		wantErr bool
+	}{
+		{name: "valid", rule: ExtractRule{Variable: "id", Type: ExtractRegex, Expression: `id=(\d+)`}},
+		{name: "missing variable", rule: ExtractRule{Type: ExtractXPath, Expression: "//a"}, wantErr: true},
+		{name: "missing expression", rule: ExtractRule{Variable: "id", Type: ExtractJSONPath}, wantErr: true},
+		{name: "unknown type", rule: ExtractRule{Variable: "id", Type: "css", Expression: "a"}, wantErr: true},
+		{name: "invalid regex", rule: ExtractRule{Variable: "id", Type: ExtractRegex, Expression: "("}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseExtractRule(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    ExtractRule
+		wantErr bool
+	}{
+		{source: "$.data.token", want: ExtractRule{Variable: "token", Type: ExtractJSONPath, Expression: "$.data.token"}},
+		{source: "jsonpath@X-Meta $.id", want: ExtractRule{Variable: "token", Type: ExtractJSONPath, Header: "X-Meta", Expression: "$.id"}},
+		{source: `Regex@Location /orders/(\d+)`, want: ExtractRule{Variable: "token", Type: ExtractRegex, Header: "Location", Expression: `/orders/(\d+)`}},
+		{source: "xpath //input[@name='csrf']/@value", want: ExtractRule{Variable: "token", Type: ExtractXPath, Expression: "//input[@name='csrf']/@value"}},
+		{source: "data.token", wantErr: true},
+		{source: "regex", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, err := ParseExtractRule("token", tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExtractRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseExtractRule() = %+v, want %+v", got, tt.want)
+			}
+			// Source gives back a text parsed into the same rule
+			if again, err := ParseExtractRule("token", got.Source()); err != nil || again != got {
+				t.Errorf("ParseExtractRule(Source()) = %+v, %v", again, err)
+			}
+		})
+	}
+}
+
+func TestApplyExtractRules(t *testing.T) {
+	resp := &Response{Body: []byte(`{"token":"abc"}`)}
+	rules := []ExtractRule{
+		{Variable: "token", Type: ExtractJSONPath, Expression: "$.token"},
+		{Variable: "off", Type: ExtractJSONPath, Expression: "$.token", Disabled: true},
+		{Variable: "id", Type: ExtractJSONPath, Expression: "$.id"},
+	}
+
+	changes, errs := ApplyExtractRules(rules, resp)
+	if len(changes) != 1 || changes[0] != (EnvChange{Type: EnvChangeSet, Name: "token", Value: "abc"}) {
+		t.Errorf("changes = %+v, want token=abc only", changes)
+	}
+	if len(errs) != 1 || errs[0].Rule.Variable != "id" {
+		t.Errorf("errs = %v, want one for id", errs)
+	}
+}
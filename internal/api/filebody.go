@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+)
+
+// BodyTypeBinary is the body type of bodies sent from a file. The body content is
+// the file path, which may contain {{variables}} and start with "~".
+const BodyTypeBinary = "binary"
+
+// FileBody is a request body streamed from a file. The file is opened again for
+// every attempt and redirect, so large files are never held in memory.
+type FileBody struct {
+	Path     string
+	Progress *UploadProgress // Updated with the bytes sent when set
+}
+
+// UploadProgress counts the bytes of a file body sent so far. It is safe to read
+// while the request is being sent.
+type UploadProgress struct {
+	sent  atomic.Int64
+	total atomic.Int64
+}
+
+// Sent returns the number of bytes sent by the current attempt
+func (p *UploadProgress) Sent() int64 {
+	return p.sent.Load()
+}
+
+// Total returns the size of the file being sent, 0 until it is opened
+func (p *UploadProgress) Total() int64 {
+	return p.total.Load()
+}
+
+// Percent returns the share of the file sent, from 0 to 100
+func (p *UploadProgress) Percent() int {
+	total := p.Total()
+	if total <= 0 {
+		return 0
+	}
+	return int(p.Sent() * 100 / total)
+}
+
+// String returns the progress for display ("1.2 MB / 4.0 MB (30%)")
+func (p *UploadProgress) String() string {
+	return fmt.Sprintf("%s / %s (%d%%)", FormatSize(p.Sent()), FormatSize(p.Total()), p.Percent())
+}
+
+// open opens the file of the body and returns it with its size and media type
+// detected from its extension or first bytes
+func (b *FileBody) open() (io.ReadCloser, int64, string, error) {
+	path := ExpandHome(b.Path)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("binary body: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, "", fmt.Errorf("binary body: %w", err)
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, 0, "", fmt.Errorf("binary body: %s is a directory", path)
+	}
+
+	// Sniff the first bytes when the extension does not tell the media type
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		file.Close()
+		return nil, 0, "", fmt.Errorf("binary body: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, 0, "", fmt.Errorf("binary body: %w", err)
+	}
+	contentType := DetectFileContentType(path, head[:n])
+
+	if b.Progress != nil {
+		b.Progress.sent.Store(0)
+		b.Progress.total.Store(info.Size())
+		return &progressReader{ReadCloser: file, progress: b.Progress}, info.Size(), contentType, nil
+	}
+	return file, info.Size(), contentType, nil
+}
+
+// setRequestBody streams the file as the body of httpReq, setting its length,
+// its Content-Type unless one is set, and reopening it for redirects
+func (b *FileBody) setRequestBody(httpReq *http.Request) error {
+	body, size, contentType, err := b.open()
+	if err != nil {
+		return err
+	}
+	httpReq.Body = body
+	httpReq.ContentLength = size
+	httpReq.GetBody = func() (io.ReadCloser, error) {
+		body, _, _, err := b.open()
+		return body, err
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	return nil
+}
+
+// String describes the body for display ("@path")
+func (b *FileBody) String() string {
+	return "@" + b.Path
+}
+
+// progressReader counts the bytes read from a file body
+type progressReader struct {
+	io.ReadCloser
+	progress *UploadProgress
+}
+
+// Read reads from the file and records the bytes read
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.progress.sent.Add(int64(n))
+	return n, err
+}
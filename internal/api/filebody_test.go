@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClient_SendFileBody(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "photo.png")
+	data := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{7}, 64*1024)...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request is redirected with 307, which resends the body
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/upload", http.StatusTemporaryRedirect)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s|%d|%d|%v", r.Header.Get("Content-Type"), r.ContentLength, len(body), bytes.Equal(body, data))
+	}))
+	defer server.Close()
+
+	progress := &UploadProgress{}
+	resp, err := NewClient().Send(&Request{
+		Method: PUT,
+		URL:    server.URL,
+		Body:   &FileBody{Path: path, Progress: progress},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := fmt.Sprintf("image/png|%d|%d|true", len(data), len(data))
+	if string(resp.Body) != want {
+		t.Errorf("server saw %q, want %q", resp.Body, want)
+	}
+	if progress.Total() != int64(len(data)) || progress.Sent() != progress.Total() || progress.Percent() != 100 {
+		t.Errorf("progress = %s", progress)
+	}
+}
+
+func TestClient_SendFileBodyContentType(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "payload")
+	if err := os.WriteFile(path, []byte("plain text"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Content-Type")))
+	}))
+	defer server.Close()
+
+	// Sniffed from the content without extension
+	resp, err := NewClient().Send(&Request{Method: POST, URL: server.URL, Body: &FileBody{Path: path}})
+	if err != nil || !strings.HasPrefix(string(resp.Body), "text/plain") {
+		t.Errorf("sniffed Content-Type = %q, err = %v", resp.Body, err)
+	}
+
+	// A Content-Type header is kept
+	resp, err = NewClient().Send(&Request{
+		Method:  POST,
+		URL:     server.URL,
+		Headers: map[string]string{"Content-Type": "application/octet-stream"},
+		Body:    &FileBody{Path: path},
+	})
+	if err != nil || string(resp.Body) != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, err = %v", resp.Body, err)
+	}
+}
+
+func TestClient_SendFileBodyErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{filepath.Join(dir, "missing.bin"), dir} {
+		_, err := NewClient().Send(&Request{Method: POST, URL: "http://127.0.0.1:1", Body: &FileBody{Path: path}})
+		if err == nil || !strings.Contains(err.Error(), "binary body") {
+			t.Errorf("Send(%s) error = %v, want binary body error", path, err)
+		}
+	}
+}
+
+func TestGenerateCurlCommand_BinaryBody(t *testing.T) {
+	got := GenerateCurlCommand(&CollectionRequest{
+		Method: POST,
+		URL:    "https://example.com/upload",
+		Body:   &BodyConfig{Type: BodyTypeBinary, Content: "~/photo.png"},
+	})
+	want := "curl -X POST --data-binary '@~/photo.png' 'https://example.com/upload'"
+	if got != want {
+		t.Errorf("GenerateCurlCommand() = %q, want %q", got, want)
+	}
+}
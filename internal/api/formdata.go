@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BodyTypeFormData is the body type of multipart/form-data bodies
+const BodyTypeFormData = "form-data"
+
+// Form field types of a form-data body
+const (
+	FormFieldText = "text"
+	FormFieldFile = "file"
+)
+
+// FormField is a field of a form-data body: a text value, or a file uploaded with its content
+type FormField struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`          // Text, or the file path of file fields
+	Type    string `json:"type,omitempty"` // FormFieldText (default) or FormFieldFile
+	Enabled bool   `json:"enabled"`
+}
+
+// IsFile returns true if the field uploads a file
+func (f FormField) IsFile() bool {
+	return f.Type == FormFieldFile
+}
+
+// ParseFormData returns the fields of a form-data body content: a list of fields, or an
+// object of text fields (as imported from OpenAPI), possibly as JSON text.
+// Fields without an "enabled" flag are enabled.
+func ParseFormData(content interface{}) []FormField {
+	switch v := content.(type) {
+	case nil:
+		return nil
+	case []FormField:
+		return v
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+			return nil
+		}
+		return ParseFormData(decoded)
+	case []interface{}:
+		fields := make([]FormField, 0, len(v))
+		for _, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field := FormField{Enabled: true}
+			field.Key, _ = obj["key"].(string)
+			field.Type, _ = obj["type"].(string)
+			field.Value = formValueString(obj["value"])
+			if enabled, ok := obj["enabled"].(bool); ok {
+				field.Enabled = enabled
+			}
+			fields = append(fields, field)
+		}
+		return fields
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]FormField, 0, len(keys))
+		for _, key := range keys {
+			fields = append(fields, FormField{Key: key, Value: formValueString(v[key]), Enabled: true})
+		}
+		return fields
+	}
+	return nil
+}
+
+// formValueString formats a decoded JSON value as a text field value
+func formValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
+
+// MultipartForm is a request body sent as multipart/form-data. File fields are read
+// from disk each time the body is encoded.
+type MultipartForm struct {
+	Fields []FormField
+}
+
+// Encode returns the multipart body of the enabled fields and its Content-Type, which
+// carries the boundary
+func (f *MultipartForm) Encode() ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, field := range f.Fields {
+		if !field.Enabled || field.Key == "" {
+			continue
+		}
+		if !field.IsFile() {
+			if err := writer.WriteField(field.Key, field.Value); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		path := ExpandHome(field.Value)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("form field %q: %w", field.Key, err)
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     field.Key,
+			"filename": filepath.Base(path),
+		}))
+		header.Set("Content-Type", DetectFileContentType(path, data))
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// String summarizes the form for display: one "key: value" line per enabled field,
+// "key: @path" for files
+func (f *MultipartForm) String() string {
+	var lines []string
+	for _, field := range f.Fields {
+		if !field.Enabled || field.Key == "" {
+			continue
+		}
+		if field.IsFile() {
+			lines = append(lines, field.Key+": @"+field.Value)
+		} else {
+			lines = append(lines, field.Key+": "+field.Value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DetectFileContentType returns the media type of a file from its extension, or
+// sniffed from its content
+func DetectFileContentType(path string, data []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// ExpandHome replaces a leading "~" of path with the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFormData(t *testing.T) {
+	want := []FormField{
+		{Key: "name", Value: "Ada", Enabled: true},
+		{Key: "avatar", Value: "/tmp/a.png", Type: FormFieldFile, Enabled: true},
+		{Key: "draft", Value: "1", Enabled: false},
+	}
+
+	tests := []struct {
+		name    string
+		content interface{}
+		want    []FormField
+	}{
+		{"fields", want, want},
+		{"json text", `[{"key":"name","value":"Ada"},{"key":"avatar","value":"/tmp/a.png","type":"file"},{"key":"draft","value":"1","enabled":false}]`, want},
+		{"decoded list", []interface{}{
+			map[string]interface{}{"key": "name", "value": "Ada", "enabled": true},
+			map[string]interface{}{"key": "avatar", "value": "/tmp/a.png", "type": "file"},
+			map[string]interface{}{"key": "draft", "value": float64(1), "enabled": false},
+		}, want},
+		{"object of text fields", map[string]interface{}{"role": "admin", "age": float64(36)}, []FormField{
+			{Key: "age", Value: "36", Enabled: true},
+			{Key: "role", Value: "admin", Enabled: true},
+		}},
+		{"empty", "", nil},
+		{"not form data", "a=1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseFormData(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFormData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_SendMultipartForm(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte("hello file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("doc")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		w.Write([]byte(strings.Join([]string{
+			r.FormValue("name"),
+			r.FormValue("skipped"),
+			header.Filename,
+			header.Header.Get("Content-Type"),
+			string(data),
+		}, "|")))
+	}))
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{
+		Method: POST,
+		URL:    server.URL,
+		// A JSON Content-Type header is replaced by the multipart one
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body: &MultipartForm{Fields: []FormField{
+			{Key: "name", Value: "Ada", Enabled: true},
+			{Key: "skipped", Value: "x", Enabled: false},
+			{Key: "doc", Value: path, Type: FormFieldFile, Enabled: true},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := "Ada||notes.txt|text/plain; charset=utf-8|hello file"
+	if resp.StatusCode != http.StatusOK || string(resp.Body) != want {
+		t.Errorf("got %d %q, want %q", resp.StatusCode, resp.Body, want)
+	}
+}
+
+func TestMultipartForm_MissingFile(t *testing.T) {
+	form := &MultipartForm{Fields: []FormField{
+		{Key: "doc", Value: filepath.Join(t.TempDir(), "missing.pdf"), Type: FormFieldFile, Enabled: true},
+	}}
+	if _, _, err := form.Encode(); err == nil || !strings.Contains(err.Error(), `form field "doc"`) {
+		t.Errorf("Encode() error = %v, want the field named", err)
+	}
+}
+
+func TestGenerateCurlCommand_FormData(t *testing.T) {
+	req := &CollectionRequest{
+		Method: POST,
+		URL:    "https://example.com/upload",
+		Body: &BodyConfig{Type: BodyTypeFormData, Content: []FormField{
+			{Key: "name", Value: "Ada", Enabled: true},
+			{Key: "avatar", Value: "~/a.png", Type: FormFieldFile, Enabled: true},
+		}},
+	}
+	want := `curl -X POST -F 'name=Ada' -F 'avatar=@~/a.png' 'https://example.com/upload'`
+	if got := GenerateCurlCommand(req); got != want {
+		t.Errorf("GenerateCurlCommand() = %s, want %s", got, want)
+	}
+}
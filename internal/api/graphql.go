@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BodyTypeGraphQL is the BodyConfig type of GraphQL requests
+const BodyTypeGraphQL = "graphql"
+
+// GraphQLBody is the content of a "graphql" body: the query document and its variables as JSON text.
+// It is stored in BodyConfig.Content as {"query": "...", "variables": {...}}.
+type GraphQLBody struct {
+	Query     string
+	Variables string
+}
+
+// ParseGraphQLBody reads the content of a "graphql" body. content is the stored object, its JSON
+// encoding, or a plain query document.
+func ParseGraphQLBody(content interface{}) GraphQLBody {
+	switch v := content.(type) {
+	case map[string]interface{}:
+		body := GraphQLBody{}
+		body.Query, _ = v["query"].(string)
+		switch vars := v["variables"].(type) {
+		case nil:
+		case string:
+			body.Variables = vars
+		default:
+			if data, err := json.MarshalIndent(vars, "", "  "); err == nil {
+				body.Variables = string(data)
+			}
+		}
+		return body
+	case string:
+		var stored map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &stored); err == nil {
+			if _, ok := stored["query"]; ok {
+				return ParseGraphQLBody(stored)
+			}
+		}
+		return GraphQLBody{Query: v}
+	default:
+		return GraphQLBody{}
+	}
+}
+
+// Content returns the value stored in BodyConfig.Content. Variables are kept as a JSON value
+// when they parse and as text otherwise (e.g. while they contain unquoted {{variables}}).
+func (g GraphQLBody) Content() map[string]interface{} {
+	content := map[string]interface{}{"query": g.Query}
+	if vars := strings.TrimSpace(g.Variables); vars != "" {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(vars), &parsed); err == nil {
+			content["variables"] = parsed
+		} else {
+			content["variables"] = g.Variables
+		}
+	}
+	return content
+}
+
+// Payload returns the JSON request body sent to the server: {"query": "...", "variables": {...}}
+func (g GraphQLBody) Payload() (map[string]interface{}, error) {
+	payload := map[string]interface{}{"query": g.Query}
+	vars := strings.TrimSpace(g.Variables)
+	if vars == "" || vars == "null" {
+		return payload, nil
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(vars), &parsed); err != nil {
+		return nil, fmt.Errorf("graphql: variables must be a JSON object: %w", err)
+	}
+	payload["variables"] = parsed
+	return payload, nil
+}
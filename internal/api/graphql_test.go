@@ -0,0 +1,95 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGraphQLBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		content interface{}
+		want    GraphQLBody
+	}{
+		{
+			name:    "stored object",
+			content: map[string]interface{}{"query": "query { me { id } }", "variables": map[string]interface{}{"id": float64(1)}},
+			want:    GraphQLBody{Query: "query { me { id } }", Variables: "{\n  \"id\": 1\n}"},
+		},
+		{
+			name:    "variables kept as text",
+			content: map[string]interface{}{"query": "query Q($id: ID!) { user(id: $id) { name } }", "variables": `{"id": {{user_id}}}`},
+			want:    GraphQLBody{Query: "query Q($id: ID!) { user(id: $id) { name } }", Variables: `{"id": {{user_id}}}`},
+		},
+		{
+			name:    "json encoded",
+			content: `{"query":"{ me { id } }"}`,
+			want:    GraphQLBody{Query: "{ me { id } }"},
+		},
+		{
+			name:    "plain query",
+			content: "{ me { id } }",
+			want:    GraphQLBody{Query: "{ me { id } }"},
+		},
+		{name: "nil", content: nil, want: GraphQLBody{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseGraphQLBody(tt.content); got != tt.want {
+				t.Errorf("ParseGraphQLBody() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLBody_Content(t *testing.T) {
+	tests := []struct {
+		name string
+		body GraphQLBody
+		want map[string]interface{}
+	}{
+		{name: "query only", body: GraphQLBody{Query: "{ a }", Variables: "  "}, want: map[string]interface{}{"query": "{ a }"}},
+		{name: "json variables", body: GraphQLBody{Query: "{ a }", Variables: `{"n": 2}`}, want: map[string]interface{}{"query": "{ a }", "variables": map[string]interface{}{"n": float64(2)}}},
+		{name: "templated variables", body: GraphQLBody{Query: "{ a }", Variables: `{"n": {{n}}}`}, want: map[string]interface{}{"query": "{ a }", "variables": `{"n": {{n}}}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.body.Content()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Content() = %#v, want %#v", got, tt.want)
+			}
+			if round := ParseGraphQLBody(got); round.Query != tt.body.Query {
+				t.Errorf("round trip query = %q", round.Query)
+			}
+		})
+	}
+}
+
+func TestGraphQLBody_Payload(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    GraphQLBody
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{name: "no variables", body: GraphQLBody{Query: "{ a }"}, want: map[string]interface{}{"query": "{ a }"}},
+		{name: "null variables", body: GraphQLBody{Query: "{ a }", Variables: "null"}, want: map[string]interface{}{"query": "{ a }"}},
+		{name: "variables", body: GraphQLBody{Query: "{ a }", Variables: `{"id": "7"}`}, want: map[string]interface{}{"query": "{ a }", "variables": map[string]interface{}{"id": "7"}}},
+		{name: "invalid variables", body: GraphQLBody{Query: "{ a }", Variables: `{"id": }`}, wantErr: true},
+		{name: "array variables", body: GraphQLBody{Query: "{ a }", Variables: `[1]`}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.body.Payload()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Payload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Payload() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
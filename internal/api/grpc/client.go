@@ -0,0 +1,251 @@
+// Package grpc calls gRPC services of servers with reflection enabled. Services are
+// described by the server reflection service and messages are authored and shown as JSON.
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// Target is a gRPC request URL: grpc://host:port/package.Service/Method, or grpcs://
+// for TLS. The service and method are empty for the URL of a server.
+type Target struct {
+	Address string // host:port
+	TLS     bool
+	Service string // Full name of the service
+	Method  string
+}
+
+// IsGRPCURL returns true if rawURL uses the grpc:// or grpcs:// scheme
+func IsGRPCURL(rawURL string) bool {
+	lower := strings.ToLower(rawURL)
+	return strings.HasPrefix(lower, "grpc://") || strings.HasPrefix(lower, "grpcs://")
+}
+
+// ParseTarget parses a grpc:// or grpcs:// URL
+func ParseTarget(rawURL string) (Target, error) {
+	if !IsGRPCURL(rawURL) {
+		return Target{}, fmt.Errorf("invalid gRPC URL %q: expected grpc://host:port/package.Service/Method (grpcs:// for TLS)", rawURL)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Target{}, fmt.Errorf("invalid gRPC URL %q: %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return Target{}, fmt.Errorf("invalid gRPC URL %q: missing host", rawURL)
+	}
+
+	t := Target{Address: u.Host, TLS: strings.EqualFold(u.Scheme, "grpcs")}
+	if u.Port() == "" {
+		if t.TLS {
+			t.Address += ":443"
+		} else {
+			t.Address += ":80"
+		}
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		service, method, ok := strings.Cut(path, "/")
+		if !ok || service == "" || method == "" || strings.Contains(method, "/") {
+			return Target{}, fmt.Errorf("invalid gRPC URL %q: the path must be /package.Service/Method", rawURL)
+		}
+		t.Service, t.Method = service, method
+	}
+	return t, nil
+}
+
+// ServerURL returns the URL of the server, without service and method
+func (t Target) ServerURL() string {
+	if t.TLS {
+		return "grpcs://" + t.Address
+	}
+	return "grpc://" + t.Address
+}
+
+// MethodURL returns the URL of the method named fullName (package.Service/Method) on the server
+func (t Target) MethodURL(fullName string) string {
+	return t.ServerURL() + "/" + fullName
+}
+
+// maxMessageSize bounds the size of a received message
+const maxMessageSize = 64 << 20
+
+// Client calls a gRPC server over HTTP/2. Descriptors fetched by reflection are kept
+// in the client's registry.
+type Client struct {
+	target         Target
+	httpClient     *http.Client
+	registry       *Registry
+	reflectionPath string // Reflection method supported by the server, once known
+}
+
+// NewClient creates a client of the server of target. Plain grpc:// targets use
+// HTTP/2 without TLS (h2c), grpcs:// targets the CA files and client certificates
+// of api.DefaultTLS.
+func NewClient(target Target) *Client {
+	protocols := new(http.Protocols)
+	transport := &http.Transport{}
+	if target.TLS {
+		protocols.SetHTTP2(true)
+		// An invalid TLS config is reported when it is configured; use the system CAs meanwhile
+		if config, err := api.TLSClientConfig(&url.URL{Scheme: "https", Host: target.Address}); err == nil && config != nil {
+			transport.TLSClientConfig = config
+		}
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	transport.Protocols = protocols
+
+	return &Client{
+		target:     target,
+		httpClient: &http.Client{Transport: transport},
+		registry:   NewRegistry(),
+	}
+}
+
+// Close closes the connections of the client
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// callResult is the outcome of a call
+type callResult struct {
+	Header   http.Header
+	Trailer  http.Header
+	Messages [][]byte
+	Code     Code
+	Message  string
+	Size     int64 // Bytes of the received messages
+}
+
+// err returns the status of the call as an error, nil for OK
+func (r *callResult) err() error {
+	if r.Code == OK {
+		return nil
+	}
+	return &StatusError{Code: r.Code, Message: r.Message}
+}
+
+// call sends messages to the method at path (/package.Service/Method) and reads the
+// messages of the response until the server ends the call
+func (c *Client) call(ctx context.Context, path string, messages [][]byte, metadata map[string]string) (*callResult, error) {
+	var body bytes.Buffer
+	for _, msg := range messages {
+		body.Write(frame(msg))
+	}
+
+	req, err := c.newRequest(ctx, path, &body, metadata)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &callResult{Header: resp.Header}
+	err = readMessages(resp.Body, func(msg []byte) error {
+		result.Messages = append(result.Messages, msg)
+		result.Size += int64(len(msg))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := readStatus(resp, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newRequest returns the request calling the method at path with the messages of body
+func (c *Client) newRequest(ctx context.Context, path string, body io.Reader, metadata map[string]string) (*http.Request, error) {
+	scheme := "http"
+	if c.target.TLS {
+		scheme = "https"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+"://"+c.target.Address+path, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range metadata {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	return req, nil
+}
+
+// frame returns msg with the prefix of a gRPC message
+func frame(msg []byte) []byte {
+	framed := make([]byte, 5+len(msg)) // Uncompressed flag and big endian length
+	binary.BigEndian.PutUint32(framed[1:5], uint32(len(msg)))
+	copy(framed[5:], msg)
+	return framed
+}
+
+// readMessages reads the messages of a response body until it ends, calling emit for
+// every message
+func readMessages(r io.Reader, emit func(msg []byte) error) error {
+	for {
+		var prefix [5]byte
+		if _, err := io.ReadFull(r, prefix[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("reading response: %w", err)
+		}
+		if prefix[0] != 0 {
+			return errors.New("compressed gRPC messages are not supported")
+		}
+		size := binary.BigEndian.Uint32(prefix[1:])
+		if size > maxMessageSize {
+			return fmt.Errorf("response message of %d bytes exceeds the %d bytes limit", size, maxMessageSize)
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			return fmt.Errorf("reading response: %w", err)
+		}
+		if err := emit(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// readStatus sets the trailers and the status of a call from its response, once the
+// body has been read
+func readStatus(resp *http.Response, result *callResult) error {
+	// The status is in the trailers, or in the headers of responses without messages
+	result.Trailer = resp.Trailer
+	status := resp.Trailer.Get("Grpc-Status")
+	message := resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		status, message = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	if status == "" {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("not a gRPC server: HTTP %s", resp.Status)
+		}
+		return errors.New("response without gRPC status")
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("invalid grpc-status %q", status)
+	}
+	result.Code = Code(code)
+	if unescaped, err := url.PathUnescape(message); err == nil {
+		message = unescaped
+	}
+	result.Message = message
+	return nil
+}
@@ -0,0 +1,418 @@
+package grpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// Descriptors of a test service, as protoc would encode them:
+//
+//	// demo/reply.proto
+//	package demo;
+//	message HelloReply { string message = 1; }
+//
+//	// demo/greeter.proto
+//	package demo;
+//	import "demo/reply.proto";
+//	enum Mood { CALM = 0; HAPPY = 1; }
+//	message HelloRequest {
+//	  message Inner { sint32 delta = 1; HelloRequest parent = 2; }
+//	  string name = 1; int32 times = 2; Mood mood = 3; repeated int64 ids = 4;
+//	  map<string, int32> tags = 5; Inner inner = 6; bytes blob = 7; double ratio = 8;
+//	}
+//	service Greeter {
+//	  rpc SayHello(HelloRequest) returns (HelloReply);
+//	  rpc Count(HelloRequest) returns (stream HelloReply);
+//	  rpc Collect(stream HelloRequest) returns (HelloReply);
+//	  rpc Chat(stream HelloRequest) returns (stream HelloReply);
+//	}
+
+func fieldProto(name string, number int, typ FieldType, typeName string, repeated bool) []byte {
+	b := appendStringField(nil, 1, name)
+	b = appendVarintField(b, 3, uint64(number))
+	label := uint64(1)
+	if repeated {
+		label = labelRepeated
+	}
+	b = appendVarintField(b, 4, label)
+	b = appendVarintField(b, 5, uint64(typ))
+	if typeName != "" {
+		b = appendStringField(b, 6, "."+typeName)
+	}
+	return b
+}
+
+func messageProto(name string, mapEntry bool, fields []byte, nested ...[]byte) []byte {
+	b := appendStringField(nil, 1, name)
+	b = append(b, fields...)
+	for _, n := range nested {
+		b = appendBytesField(b, 3, n)
+	}
+	if mapEntry {
+		b = appendBytesField(b, 7, appendVarintField(nil, 7, 1))
+	}
+	return b
+}
+
+func methodProto(name, input, output string, clientStreaming, serverStreaming bool) []byte {
+	b := appendStringField(nil, 1, name)
+	b = appendStringField(b, 2, "."+input)
+	b = appendStringField(b, 3, "."+output)
+	if clientStreaming {
+		b = appendVarintField(b, 5, 1)
+	}
+	if serverStreaming {
+		b = appendVarintField(b, 6, 1)
+	}
+	return b
+}
+
+func replyFile() []byte {
+	b := appendStringField(nil, 1, "demo/reply.proto")
+	b = appendStringField(b, 2, "demo")
+	return appendBytesField(b, 4, messageProto("HelloReply", false,
+		appendBytesField(nil, 2, fieldProto("message", 1, TypeString, "", false))))
+}
+
+func greeterFile() []byte {
+	var fields []byte
+	for _, f := range [][]byte{
+		fieldProto("name", 1, TypeString, "", false),
+		fieldProto("times", 2, TypeInt32, "", false),
+		fieldProto("mood", 3, TypeEnum, "demo.Mood", false),
+		fieldProto("ids", 4, TypeInt64, "", true),
+		fieldProto("tags", 5, TypeMessage, "demo.HelloRequest.TagsEntry", true),
+		fieldProto("inner", 6, TypeMessage, "demo.HelloRequest.Inner", false),
+		fieldProto("blob", 7, TypeBytes, "", false),
+		fieldProto("ratio", 8, TypeDouble, "", false),
+	} {
+		fields = appendBytesField(fields, 2, f)
+	}
+	entry := messageProto("TagsEntry", true, append(
+		appendBytesField(nil, 2, fieldProto("key", 1, TypeString, "", false)),
+		appendBytesField(nil, 2, fieldProto("value", 2, TypeInt32, "", false))...))
+	inner := messageProto("Inner", false, append(
+		appendBytesField(nil, 2, fieldProto("delta", 1, TypeSint32, "", false)),
+		appendBytesField(nil, 2, fieldProto("parent", 2, TypeMessage, "demo.HelloRequest", false))...))
+
+	enum := appendStringField(nil, 1, "Mood")
+	enum = appendBytesField(enum, 2, appendVarintField(appendStringField(nil, 1, "CALM"), 2, 0))
+	enum = appendBytesField(enum, 2, appendVarintField(appendStringField(nil, 1, "HAPPY"), 2, 1))
+
+	service := appendStringField(nil, 1, "Greeter")
+	service = appendBytesField(service, 2, methodProto("SayHello", "demo.HelloRequest", "demo.HelloReply", false, false))
+	service = appendBytesField(service, 2, methodProto("Count", "demo.HelloRequest", "demo.HelloReply", false, true))
+	service = appendBytesField(service, 2, methodProto("Collect", "demo.HelloRequest", "demo.HelloReply", true, false))
+	service = appendBytesField(service, 2, methodProto("Chat", "demo.HelloRequest", "demo.HelloReply", true, true))
+
+	b := appendStringField(nil, 1, "demo/greeter.proto")
+	b = appendStringField(b, 2, "demo")
+	b = appendStringField(b, 3, "demo/reply.proto")
+	b = appendBytesField(b, 4, messageProto("HelloRequest", false, fields, entry, inner))
+	b = appendBytesField(b, 5, enum)
+	return appendBytesField(b, 6, service)
+}
+
+// testRegistry returns a registry of the test service
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	for _, file := range [][]byte{replyFile(), greeterFile()} {
+		if err := r.AddFile(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Resolve(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// readFrames reads the length-prefixed messages of a gRPC request body
+func readFrames(r io.Reader) [][]byte {
+	var frames [][]byte
+	for {
+		msg, ok := readFrame(r)
+		if !ok {
+			return frames
+		}
+		frames = append(frames, msg)
+	}
+}
+
+// readFrame reads the next length-prefixed message of a gRPC request body
+func readFrame(r io.Reader) ([]byte, bool) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, false
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, false
+	}
+	return msg, true
+}
+
+func writeFrame(w io.Writer, msg []byte) {
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	_, _ = w.Write(prefix[:])
+	_, _ = w.Write(msg)
+}
+
+// stringField returns the first string field numbered num of msg
+func stringField(msg []byte, num int) string {
+	rd := wireReader{data: msg}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return ""
+		}
+		if f.Number == num {
+			return string(f.Bytes)
+		}
+	}
+	return ""
+}
+
+// newTestServer starts an h2c server implementing the test service, with reflection
+// when reflection is true
+func newTestServer(t *testing.T, reflection bool) *httptest.Server {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.Header.Get("Content-Type") != "application/grpc" {
+			http.Error(w, "gRPC requires HTTP/2", http.StatusHTTPVersionNotSupported)
+			return
+		}
+		if r.URL.Path == "/demo.Greeter/Chat" {
+			// Echoes the name of every message as it arrives
+			w.Header().Set("Content-Type", "application/grpc")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			for {
+				msg, ok := readFrame(r.Body)
+				if !ok {
+					break
+				}
+				writeFrame(w, appendStringField(nil, 1, stringField(msg, 1)))
+				w.(http.Flusher).Flush()
+			}
+			w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+			return
+		}
+		frames := readFrames(r.Body)
+		w.Header().Set("Content-Type", "application/grpc")
+		status, message := "0", ""
+
+		switch r.URL.Path {
+		case "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":
+			if !reflection {
+				status = "12"
+				break
+			}
+			req := frames[0]
+			var resp []byte
+			switch {
+			case stringField(req, reflectListServices) != "":
+				list := appendBytesField(nil, 1, appendStringField(nil, 1, "demo.Greeter"))
+				list = appendBytesField(list, 1, appendStringField(nil, 1, "grpc.reflection.v1.ServerReflection"))
+				resp = appendBytesField(nil, reflectListServicesResponse, list)
+			case stringField(req, reflectFileContainingSymbol) == "demo.Greeter":
+				// Dependencies are left for the client to request by name
+				resp = appendBytesField(nil, reflectFileDescriptorResponse, appendBytesField(nil, 1, greeterFile()))
+			case stringField(req, reflectFileByFilename) == "demo/reply.proto":
+				resp = appendBytesField(nil, reflectFileDescriptorResponse, appendBytesField(nil, 1, replyFile()))
+			default:
+				errResp := appendVarintField(nil, 1, uint64(NotFound))
+				errResp = appendStringField(errResp, 2, "symbol not found")
+				resp = appendBytesField(nil, reflectErrorResponse, errResp)
+			}
+			writeFrame(w, resp)
+		case "/demo.Greeter/SayHello":
+			name := stringField(frames[0], 1)
+			if name == "" {
+				status, message = "3", "name is required: 100%"
+				break
+			}
+			if token := r.Header.Get("Authorization"); token != "" {
+				name += " (" + token + ")"
+			}
+			writeFrame(w, appendStringField(nil, 1, "Hello "+name))
+		case "/demo.Greeter/Count":
+			for _, n := range []string{"one", "two"} {
+				writeFrame(w, appendStringField(nil, 1, n))
+			}
+		case "/demo.Greeter/Collect":
+			var names []string
+			for _, f := range frames {
+				names = append(names, stringField(f, 1))
+			}
+			writeFrame(w, appendStringField(nil, 1, strings.Join(names, ",")))
+		default:
+			status = "12"
+		}
+		// grpc-message is percent-encoded
+		w.Header().Set(http.TrailerPrefix+"Grpc-Status", status)
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", strings.ReplaceAll(message, "%", "%25"))
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func grpcURL(server *httptest.Server, path string) string {
+	return "grpc://" + strings.TrimPrefix(server.URL, "http://") + path
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    Target
+		wantErr bool
+	}{
+		{url: "grpc://localhost:50051/demo.Greeter/SayHello", want: Target{Address: "localhost:50051", Service: "demo.Greeter", Method: "SayHello"}},
+		{url: "grpcs://api.example.com/pkg.v1.Svc/Get", want: Target{Address: "api.example.com:443", TLS: true, Service: "pkg.v1.Svc", Method: "Get"}},
+		{url: "GRPC://localhost:9000", want: Target{Address: "localhost:9000"}},
+		{url: "grpc://localhost:9000/demo.Greeter", wantErr: true},
+		{url: "grpc://localhost:9000/a/b/c", wantErr: true},
+		{url: "http://localhost:9000/demo.Greeter/SayHello", wantErr: true},
+		{url: "grpc:///demo.Greeter/SayHello", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTarget(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTarget(%q) error = %v", tt.url, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTarget(%q) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestListMethods(t *testing.T) {
+	server := newTestServer(t, true)
+	target, _ := ParseTarget(grpcURL(server, ""))
+
+	methods, err := ListMethods(t.Context(), target)
+	if err != nil {
+		t.Fatalf("ListMethods() error = %v", err)
+	}
+	var names []string
+	for _, m := range methods {
+		names = append(names, m.FullName()+" "+m.Kind())
+	}
+	want := "demo.Greeter/SayHello unary,demo.Greeter/Count server streaming,demo.Greeter/Collect client streaming,demo.Greeter/Chat bidi streaming"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("methods = %s, want %s", got, want)
+	}
+	if methods[0].Output == nil || methods[0].Output.Name != "demo.HelloReply" {
+		t.Errorf("output type of SayHello not resolved from its dependency: %+v", methods[0].Output)
+	}
+
+	_, err = ListMethods(t.Context(), mustTarget(t, grpcURL(newTestServer(t, false), "")))
+	if !errors.Is(err, ErrNoReflection) {
+		t.Errorf("error without reflection = %v, want ErrNoReflection", err)
+	}
+}
+
+func mustTarget(t *testing.T, url string) Target {
+	t.Helper()
+	target, err := ParseTarget(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func TestSend(t *testing.T) {
+	server := newTestServer(t, true)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       interface{}
+		headers    map[string]string
+		wantStatus int
+		wantBody   string
+		wantErr    string
+	}{
+		{
+			name:       "unary with metadata",
+			path:       "/demo.Greeter/SayHello",
+			body:       `{"name": "Ada"}`,
+			headers:    map[string]string{"Authorization": "Bearer t", "Content-Type": "application/json"},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\n  \"message\": \"Hello Ada (Bearer t)\"\n}",
+		},
+		{
+			name:       "body parsed as JSON",
+			path:       "/demo.Greeter/SayHello",
+			body:       map[string]interface{}{"name": "Bob", "times": 2},
+			wantStatus: http.StatusOK,
+			wantBody:   "{\n  \"message\": \"Hello Bob\"\n}",
+		},
+		{
+			name:       "error status",
+			path:       "/demo.Greeter/SayHello",
+			body:       "",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "{\n  \"code\": \"INVALID_ARGUMENT\",\n  \"message\": \"name is required: 100%\"\n}",
+		},
+		{
+			name:       "server streaming",
+			path:       "/demo.Greeter/Count",
+			body:       `{}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "[\n  {\n    \"message\": \"one\"\n  },\n  {\n    \"message\": \"two\"\n  }\n]",
+		},
+		{
+			name:       "client streaming",
+			path:       "/demo.Greeter/Collect",
+			body:       `[{"name": "a"}, {"name": "b"}]`,
+			wantStatus: http.StatusOK,
+			wantBody:   "{\n  \"message\": \"a,b\"\n}",
+		},
+		{name: "client streaming without array", path: "/demo.Greeter/Collect", body: `{"name": "a"}`, wantErr: "expected a JSON array"},
+		{name: "unknown field", path: "/demo.Greeter/SayHello", body: `{"nom": "a"}`, wantErr: `has no field "nom"`},
+		{name: "unknown method", path: "/demo.Greeter/Nope", wantErr: "has no method Nope"},
+		{name: "unknown service", path: "/demo.Other/Nope", wantErr: "unknown service demo.Other"},
+		{name: "missing method", path: "", wantErr: "no method in gRPC URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Send(&api.Request{Method: api.GRPC, URL: grpcURL(server, tt.path), Headers: tt.headers, Body: tt.body, Timeout: 5 * time.Second})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d (%s), want %d", resp.StatusCode, resp.Status, tt.wantStatus)
+			}
+			if string(resp.Body) != tt.wantBody {
+				t.Errorf("Body = %s, want %s", resp.Body, tt.wantBody)
+			}
+			if len(resp.Headers["Grpc-Status"]) == 0 {
+				t.Errorf("trailers missing from headers: %v", resp.Headers)
+			}
+		})
+	}
+}
@@ -0,0 +1,658 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Messages are authored and shown in the proto3 JSON mapping
+// (https://protobuf.dev/programming-guides/json/): lowerCamelCase field names,
+// 64-bit integers as strings, enums by name and bytes as base64. Well-known
+// types such as google.protobuf.Timestamp use their regular message form.
+
+// EncodeJSON encodes the JSON object data as a msg
+func EncodeJSON(msg *Message, data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the message")
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok && value != nil {
+		return nil, fmt.Errorf("%s: expected a JSON object", msg.Name)
+	}
+	return encodeMessage(nil, msg, obj, 0)
+}
+
+// encodeMessage appends the fields of obj, in field number order
+func encodeMessage(b []byte, msg *Message, obj map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxDecodeDepth {
+		return nil, errTooDeep
+	}
+
+	fields := make([]*Field, 0, len(obj))
+	values := make(map[*Field]interface{}, len(obj))
+	for key, value := range obj {
+		f := msg.FieldByName(key)
+		if f == nil {
+			return nil, fmt.Errorf("%s has no field %q", msg.Name, key)
+		}
+		if value == nil {
+			continue
+		}
+		fields = append(fields, f)
+		values[f] = value
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number < fields[j].Number })
+
+	var err error
+	for _, f := range fields {
+		if b, err = encodeField(b, f, values[f], depth); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", msg.Name, f.JSONName, err)
+		}
+	}
+	return b, nil
+}
+
+// encodeField appends the value of a field, repeated values packed where allowed
+func encodeField(b []byte, f *Field, value interface{}, depth int) ([]byte, error) {
+	if f.IsMap() {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON object")
+		}
+		keyField, valueField := f.Message.FieldByNumber(1), f.Message.FieldByNumber(2)
+		if keyField == nil || valueField == nil {
+			return nil, fmt.Errorf("invalid map entry %s", f.Message.Name)
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key, err := mapKey(keyField.Type, k)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := encodeSingle(nil, keyField, key, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if obj[k] != nil {
+				if entry, err = encodeSingle(entry, valueField, obj[k], depth+1); err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+			}
+			b = appendBytesField(b, f.Number, entry)
+		}
+		return b, nil
+	}
+
+	if !f.Repeated {
+		return encodeSingle(b, f, value, depth)
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON array")
+	}
+	if isPackable(f.Type) {
+		var packed []byte
+		for i, item := range list {
+			var err error
+			if packed, err = appendScalar(packed, f, item); err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return appendBytesField(b, f.Number, packed), nil
+	}
+	for i, item := range list {
+		var err error
+		if b, err = encodeSingle(b, f, item, depth); err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return b, nil
+}
+
+// isPackable returns true for the scalar numeric types, which repeated fields pack
+func isPackable(t FieldType) bool {
+	switch t {
+	case TypeString, TypeBytes, TypeMessage, TypeGroup:
+		return false
+	}
+	return true
+}
+
+// wireTypeOf returns the wire type of a value of type t
+func wireTypeOf(t FieldType) int {
+	switch t {
+	case TypeDouble, TypeFixed64, TypeSfixed64:
+		return wireFixed64
+	case TypeFloat, TypeFixed32, TypeSfixed32:
+		return wireFixed32
+	case TypeString, TypeBytes, TypeMessage, TypeGroup:
+		return wireBytes
+	default:
+		return wireVarint
+	}
+}
+
+// encodeSingle appends one value of a field with its tag
+func encodeSingle(b []byte, f *Field, value interface{}, depth int) ([]byte, error) {
+	switch f.Type {
+	case TypeMessage, TypeGroup:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON object")
+		}
+		nested, err := encodeMessage(nil, f.Message, obj, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return appendBytesField(b, f.Number, nested), nil
+	case TypeString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string")
+		}
+		return appendStringField(b, f.Number, s), nil
+	case TypeBytes:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a base64 string")
+		}
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			if data, err = base64.URLEncoding.DecodeString(s); err != nil {
+				return nil, fmt.Errorf("invalid base64: %w", err)
+			}
+		}
+		return appendBytesField(b, f.Number, data), nil
+	}
+	return appendScalar(appendTag(b, f.Number, wireTypeOf(f.Type)), f, value)
+}
+
+// appendScalar appends a numeric, bool or enum value without tag
+func appendScalar(b []byte, f *Field, value interface{}) ([]byte, error) {
+	switch f.Type {
+	case TypeBool:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		if v {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case TypeEnum:
+		if name, ok := value.(string); ok {
+			num, ok := f.Enum.ValueNumber(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown %s value %q", f.Enum.Name, name)
+			}
+			return binary.AppendUvarint(b, uint64(int64(num))), nil
+		}
+		v, err := jsonInt(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case TypeDouble:
+		v, err := jsonFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v)), nil
+	case TypeFloat:
+		v, err := jsonFloat(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v))), nil
+	case TypeInt32, TypeInt64:
+		v, err := jsonInt(value, intBits(f.Type))
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendUvarint(b, uint64(v)), nil
+	case TypeSint32, TypeSint64:
+		v, err := jsonInt(value, intBits(f.Type))
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendUvarint(b, zigzag(v)), nil
+	case TypeSfixed32:
+		v, err := jsonInt(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(b, uint32(v)), nil
+	case TypeSfixed64:
+		v, err := jsonInt(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(b, uint64(v)), nil
+	case TypeUint32, TypeUint64:
+		v, err := jsonUint(value, intBits(f.Type))
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendUvarint(b, v), nil
+	case TypeFixed32:
+		v, err := jsonUint(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(b, uint32(v)), nil
+	case TypeFixed64:
+		v, err := jsonUint(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(b, v), nil
+	}
+	return nil, fmt.Errorf("unsupported field type %d", f.Type)
+}
+
+// intBits returns the size of the integer type t
+func intBits(t FieldType) int {
+	switch t {
+	case TypeInt32, TypeSint32, TypeUint32, TypeFixed32, TypeSfixed32:
+		return 32
+	default:
+		return 64
+	}
+}
+
+// numberText returns the text of a JSON number, or of a number quoted as a string
+func numberText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("expected a number")
+	}
+}
+
+// jsonInt converts a JSON number or numeric string to a signed integer of size bits
+func jsonInt(value interface{}, bits int) (int64, error) {
+	text, err := numberText(value)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(text, 10, bits)
+	if err != nil {
+		// Integral floats such as 1e3 or 2.0 are valid JSON for integer fields
+		f, ferr := strconv.ParseFloat(text, 64)
+		if ferr != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("invalid int%d %q", bits, text)
+		}
+		v = int64(f)
+		if bits == 32 && (v < math.MinInt32 || v > math.MaxInt32) {
+			return 0, fmt.Errorf("invalid int%d %q", bits, text)
+		}
+	}
+	return v, nil
+}
+
+// jsonUint converts a JSON number or numeric string to an unsigned integer of size bits
+func jsonUint(value interface{}, bits int) (uint64, error) {
+	text, err := numberText(value)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(text, 10, bits)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(text, 64)
+		if ferr != nil || f != math.Trunc(f) || f < 0 || f >= math.Exp2(float64(bits)) {
+			return 0, fmt.Errorf("invalid uint%d %q", bits, text)
+		}
+		v = uint64(f)
+	}
+	return v, nil
+}
+
+// jsonFloat converts a JSON number, a numeric string or "NaN"/"Infinity"/"-Infinity" to a float
+func jsonFloat(value interface{}, bits int) (float64, error) {
+	text, err := numberText(value)
+	if err != nil {
+		return 0, err
+	}
+	switch text {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	v, err := strconv.ParseFloat(text, bits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid float%d %q", bits, text)
+	}
+	return v, nil
+}
+
+// mapKey converts a JSON object key to the value of a map key of type t
+func mapKey(t FieldType, key string) (interface{}, error) {
+	switch t {
+	case TypeString:
+		return key, nil
+	case TypeBool:
+		switch key {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid bool map key %q", key)
+	default:
+		return json.Number(key), nil
+	}
+}
+
+// jsonMember is a field of a decoded message
+type jsonMember struct {
+	Key   string
+	Value interface{}
+}
+
+// jsonObject is a decoded message, marshaled with its fields in declaration order
+type jsonObject []jsonMember
+
+// MarshalJSON implements json.Marshaler
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// DecodeJSON returns the indented JSON of an encoded msg. Unknown fields are skipped.
+func DecodeJSON(msg *Message, data []byte) ([]byte, error) {
+	obj, err := decodeMessage(msg, data, 0)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(obj, "", "  ")
+}
+
+// decodeMessage decodes the fields of msg present in data
+func decodeMessage(msg *Message, data []byte, depth int) (jsonObject, error) {
+	if depth > maxDecodeDepth {
+		return nil, errTooDeep
+	}
+
+	values := make(map[*Field]interface{})
+	rd := wireReader{data: data}
+	for !rd.done() {
+		wf, err := rd.next()
+		if err != nil {
+			return nil, err
+		}
+		f := msg.FieldByNumber(wf.Number)
+		if f == nil {
+			continue
+		}
+
+		switch {
+		case f.IsMap():
+			entries, _ := values[f].(jsonObject)
+			key, value, err := decodeMapEntry(f.Message, wf.Bytes, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", msg.Name, f.JSONName, err)
+			}
+			values[f] = append(entries, jsonMember{Key: key, Value: value})
+		case f.Repeated:
+			list, _ := values[f].([]interface{})
+			if wf.WireType == wireBytes && isPackable(f.Type) {
+				// Packed repeated scalars: the values one after another
+				packed := wireReader{data: wf.Bytes}
+				for !packed.done() {
+					v, err := packed.scalar(wireTypeOf(f.Type))
+					if err != nil {
+						return nil, fmt.Errorf("%s.%s: %w", msg.Name, f.JSONName, err)
+					}
+					list = append(list, scalarJSON(f, v))
+				}
+			} else {
+				v, err := decodeValue(f, wf, depth)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", msg.Name, f.JSONName, err)
+				}
+				list = append(list, v)
+			}
+			values[f] = list
+		default:
+			v, err := decodeValue(f, wf, depth)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", msg.Name, f.JSONName, err)
+			}
+			values[f] = v
+		}
+	}
+
+	obj := make(jsonObject, 0, len(values))
+	for _, f := range msg.Fields {
+		if v, ok := values[f]; ok {
+			obj = append(obj, jsonMember{Key: f.JSONName, Value: v})
+		}
+	}
+	return obj, nil
+}
+
+// scalar reads a value of wire type wt without tag, as in packed fields
+func (r *wireReader) scalar(wt int) (uint64, error) {
+	switch wt {
+	case wireFixed64:
+		if len(r.data) < 8 {
+			return 0, errTruncated
+		}
+		v := binary.LittleEndian.Uint64(r.data)
+		r.data = r.data[8:]
+		return v, nil
+	case wireFixed32:
+		if len(r.data) < 4 {
+			return 0, errTruncated
+		}
+		v := uint64(binary.LittleEndian.Uint32(r.data))
+		r.data = r.data[4:]
+		return v, nil
+	default:
+		return r.varint()
+	}
+}
+
+// decodeMapEntry decodes the key and value of a map entry
+func decodeMapEntry(entry *Message, data []byte, depth int) (string, interface{}, error) {
+	obj, err := decodeMessage(entry, data, depth)
+	if err != nil {
+		return "", nil, err
+	}
+	keyField, valueField := entry.FieldByNumber(1), entry.FieldByNumber(2)
+	if keyField == nil || valueField == nil {
+		return "", nil, fmt.Errorf("invalid map entry %s", entry.Name)
+	}
+	// Absent keys and values are the default of their type
+	key, value := zeroJSON(keyField), zeroJSON(valueField)
+	for _, m := range obj {
+		switch m.Key {
+		case keyField.JSONName:
+			key = m.Value
+		case valueField.JSONName:
+			value = m.Value
+		}
+	}
+	return fmt.Sprint(key), value, nil
+}
+
+// decodeValue decodes a single (non-packed) value
+func decodeValue(f *Field, wf wireField, depth int) (interface{}, error) {
+	want := wireTypeOf(f.Type)
+	if wf.WireType != want {
+		return nil, fmt.Errorf("wire type %d, want %d", wf.WireType, want)
+	}
+	switch f.Type {
+	case TypeMessage, TypeGroup:
+		return decodeMessage(f.Message, wf.Bytes, depth+1)
+	case TypeString:
+		return string(wf.Bytes), nil
+	case TypeBytes:
+		return base64.StdEncoding.EncodeToString(wf.Bytes), nil
+	}
+	return scalarJSON(f, wf.Value), nil
+}
+
+// scalarJSON returns the JSON value of a numeric, bool or enum value
+func scalarJSON(f *Field, v uint64) interface{} {
+	switch f.Type {
+	case TypeBool:
+		return v != 0
+	case TypeEnum:
+		if name, ok := f.Enum.ValueName(int32(v)); ok {
+			return name
+		}
+		return int32(v)
+	case TypeDouble:
+		return floatJSON(math.Float64frombits(v))
+	case TypeFloat:
+		return floatJSON(float64(math.Float32frombits(uint32(v))))
+	case TypeInt32:
+		return int32(v)
+	case TypeSint32:
+		return int32(unzigzag(v))
+	case TypeSfixed32:
+		return int32(uint32(v))
+	case TypeUint32, TypeFixed32:
+		return uint32(v)
+	case TypeInt64, TypeSfixed64:
+		return strconv.FormatInt(int64(v), 10)
+	case TypeSint64:
+		return strconv.FormatInt(unzigzag(v), 10)
+	default:
+		// uint64, fixed64
+		return strconv.FormatUint(v, 10)
+	}
+}
+
+// floatJSON returns f, or its string form for the values JSON has no number for
+func floatJSON(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// zeroJSON returns the JSON of the default value of a non-repeated field
+func zeroJSON(f *Field) interface{} {
+	switch f.Type {
+	case TypeMessage, TypeGroup:
+		return jsonObject{}
+	case TypeString, TypeBytes:
+		return ""
+	case TypeBool:
+		return false
+	case TypeEnum:
+		if len(f.Enum.Values) > 0 {
+			return f.Enum.Values[0].Name
+		}
+		return 0
+	case TypeInt64, TypeUint64, TypeSint64, TypeFixed64, TypeSfixed64:
+		return "0"
+	default:
+		return 0
+	}
+}
+
+// Template returns an indented JSON object with every field of msg set to its default
+// value, used as the starting body of a request. Nested messages are expanded once
+// per type to stop at recursive types.
+func Template(msg *Message) []byte {
+	data, _ := json.MarshalIndent(templateObject(msg, map[string]bool{}), "", "  ")
+	return data
+}
+
+// templateObject returns the template of msg, skipping the types in expanding
+func templateObject(msg *Message, expanding map[string]bool) jsonObject {
+	expanding[msg.Name] = true
+	defer delete(expanding, msg.Name)
+
+	obj := make(jsonObject, 0, len(msg.Fields))
+	for _, f := range msg.Fields {
+		var value interface{}
+		switch {
+		case f.IsMap():
+			value = jsonObject{}
+		case f.Repeated:
+			value = []interface{}{}
+		case f.Type == TypeMessage || f.Type == TypeGroup:
+			if expanding[f.Message.Name] {
+				value = nil
+			} else {
+				value = templateObject(f.Message, expanding)
+			}
+		default:
+			value = zeroJSON(f)
+		}
+		obj = append(obj, jsonMember{Key: f.JSONName, Value: value})
+	}
+	return obj
+}
+
+// TypeString returns the type of the field as written in .proto files ("repeated string", "map<string, int32>")
+func (f *Field) TypeString() string {
+	if f.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", f.Message.FieldByNumber(1).TypeString(), f.Message.FieldByNumber(2).TypeString())
+	}
+	name := fieldTypeNames[f.Type]
+	if f.TypeName != "" {
+		name = f.TypeName
+	}
+	if f.Repeated {
+		return "repeated " + name
+	}
+	return name
+}
+
+// fieldTypeNames names the scalar field types
+var fieldTypeNames = map[FieldType]string{
+	TypeDouble: "double", TypeFloat: "float", TypeInt64: "int64", TypeUint64: "uint64",
+	TypeInt32: "int32", TypeFixed64: "fixed64", TypeFixed32: "fixed32", TypeBool: "bool",
+	TypeString: "string", TypeBytes: "bytes", TypeUint32: "uint32", TypeSfixed32: "sfixed32",
+	TypeSfixed64: "sfixed64", TypeSint32: "sint32", TypeSint64: "sint64",
+}
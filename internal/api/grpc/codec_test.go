@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	request := testRegistry(t).Message("demo.HelloRequest")
+
+	tests := []struct {
+		name    string
+		json    string
+		wantHex string
+		wantErr string
+	}{
+		{name: "string", json: `{"name": "hi"}`, wantHex: "0a026869"},
+		{name: "negative int32", json: `{"times": -1}`, wantHex: "10ffffffffffffffffff01"},
+		{name: "quoted and float integers", json: `{"times": "150", "ids": [1e2]}`, wantHex: "109601220164"},
+		{name: "enum by name", json: `{"mood": "HAPPY"}`, wantHex: "1801"},
+		{name: "enum number", json: `{"mood": 1}`, wantHex: "1801"},
+		{name: "packed repeated int64", json: `{"ids": [1, "300"]}`, wantHex: "220301ac02"},
+		{name: "map sorted by key", json: `{"tags": {"b": 2, "a": 1}}`, wantHex: "2a050a016110012a050a01621002"},
+		{name: "nested message with sint32", json: `{"inner": {"delta": -2}}`, wantHex: "32020803"},
+		{name: "proto field name", json: `{"blob": "AAE="}`, wantHex: "3a020001"},
+		{name: "double", json: `{"ratio": 1.5}`, wantHex: "41000000000000f83f"},
+		{name: "null fields skipped", json: `{"name": null}`, wantHex: ""},
+		{name: "unknown field", json: `{"x": 1}`, wantErr: `has no field "x"`},
+		{name: "int32 overflow", json: `{"times": 3000000000}`, wantErr: "invalid int32"},
+		{name: "unknown enum value", json: `{"mood": "SAD"}`, wantErr: "unknown demo.Mood value"},
+		{name: "wrong type", json: `{"name": 1}`, wantErr: "expected a string"},
+		{name: "not an object", json: `[1]`, wantErr: "expected a JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeJSON(request, []byte(tt.json))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EncodeJSON() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.wantHex {
+				t.Errorf("EncodeJSON() = %x, want %s", got, tt.wantHex)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	request := testRegistry(t).Message("demo.HelloRequest")
+
+	tests := []struct {
+		name string
+		hex  string
+		want string
+	}{
+		{name: "fields in declaration order", hex: "1801" + "0a026869", want: `{"name":"hi","mood":"HAPPY"}`},
+		{name: "64-bit integers as strings", hex: "220301ac02", want: `{"ids":["1","300"]}`},
+		{name: "unpacked repeated", hex: "2001" + "2002", want: `{"ids":["1","2"]}`},
+		{name: "map with default key", hex: "2a021005", want: `{"tags":{"":5}}`},
+		{name: "unknown enum number", hex: "1807", want: `{"mood":7}`},
+		{name: "unknown fields skipped", hex: "f80101" + "0a0161", want: `{"name":"a"}`},
+		{name: "recursive message", hex: "3206" + "1204" + "0a026869", want: `{"inner":{"parent":{"name":"hi"}}}`},
+		{name: "bytes as base64", hex: "3a020001", want: `{"blob":"AAE="}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.hex)
+			got, err := DecodeJSON(request, data)
+			if err != nil {
+				t.Fatalf("DecodeJSON() error = %v", err)
+			}
+			compact := strings.NewReplacer("\n", "", " ", "").Replace(string(got))
+			if compact != tt.want {
+				t.Errorf("DecodeJSON() = %s, want %s", compact, tt.want)
+			}
+		})
+	}
+
+	if _, err := DecodeJSON(request, []byte{0x0a, 0x05, 'a'}); err == nil {
+		t.Error("DecodeJSON() of a truncated message should fail")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	request := testRegistry(t).Message("demo.HelloRequest")
+	got := strings.NewReplacer("\n", "", " ", "").Replace(string(Template(request)))
+	want := `{"name":"","times":0,"mood":"CALM","ids":[],"tags":{},"inner":{"delta":0,"parent":null},"blob":"","ratio":0}`
+	if got != want {
+		t.Errorf("Template() = %s, want %s", got, want)
+	}
+
+	// The template is a valid body
+	if _, err := EncodeJSON(request, Template(request)); err != nil {
+		t.Errorf("EncodeJSON(Template()) error = %v", err)
+	}
+}
+
+func TestField_TypeString(t *testing.T) {
+	request := testRegistry(t).Message("demo.HelloRequest")
+	tests := map[string]string{
+		"ids":   "repeated int64",
+		"tags":  "map<string, int32>",
+		"mood":  "demo.Mood",
+		"ratio": "double",
+	}
+	for name, want := range tests {
+		if got := request.FieldByName(name).TypeString(); got != want {
+			t.Errorf("%s.TypeString() = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package grpc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FieldType is the type of a message field (FieldDescriptorProto.Type)
+type FieldType int
+
+const (
+	TypeDouble   FieldType = 1
+	TypeFloat    FieldType = 2
+	TypeInt64    FieldType = 3
+	TypeUint64   FieldType = 4
+	TypeInt32    FieldType = 5
+	TypeFixed64  FieldType = 6
+	TypeFixed32  FieldType = 7
+	TypeBool     FieldType = 8
+	TypeString   FieldType = 9
+	TypeGroup    FieldType = 10
+	TypeMessage  FieldType = 11
+	TypeBytes    FieldType = 12
+	TypeUint32   FieldType = 13
+	TypeEnum     FieldType = 14
+	TypeSfixed32 FieldType = 15
+	TypeSfixed64 FieldType = 16
+	TypeSint32   FieldType = 17
+	TypeSint64   FieldType = 18
+)
+
+// labelRepeated is the FieldDescriptorProto.Label of repeated fields
+const labelRepeated = 3
+
+// Field is a field of a message
+type Field struct {
+	Name     string
+	JSONName string // lowerCamelCase name used in JSON
+	Number   int
+	Type     FieldType
+	Repeated bool
+	TypeName string   // Full name of the message or enum type, without leading dot
+	Message  *Message // Resolved type of message fields
+	Enum     *Enum    // Resolved type of enum fields
+}
+
+// IsMap returns true if the field is a map (a repeated map entry message)
+func (f *Field) IsMap() bool {
+	return f.Repeated && f.Message != nil && f.Message.MapEntry
+}
+
+// Message is a message type
+type Message struct {
+	Name     string // Full name (package.Outer.Inner)
+	Fields   []*Field
+	MapEntry bool // Synthesized entry type of a map field (key = 1, value = 2)
+}
+
+// FieldByNumber returns the field numbered num
+func (m *Message) FieldByNumber(num int) *Field {
+	for _, f := range m.Fields {
+		if f.Number == num {
+			return f
+		}
+	}
+	return nil
+}
+
+// FieldByName returns the field with the JSON or proto name name
+func (m *Message) FieldByName(name string) *Field {
+	for _, f := range m.Fields {
+		if f.JSONName == name || f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Enum is an enum type
+type Enum struct {
+	Name   string // Full name
+	Values []EnumValue
+}
+
+// EnumValue is a value of an enum
+type EnumValue struct {
+	Name   string
+	Number int32
+}
+
+// ValueName returns the name of the value numbered num
+func (e *Enum) ValueName(num int32) (string, bool) {
+	for _, v := range e.Values {
+		if v.Number == num {
+			return v.Name, true
+		}
+	}
+	return "", false
+}
+
+// ValueNumber returns the number of the value named name
+func (e *Enum) ValueNumber(name string) (int32, bool) {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v.Number, true
+		}
+	}
+	return 0, false
+}
+
+// Service is a gRPC service
+type Service struct {
+	Name    string // Full name (package.Service)
+	Methods []*Method
+}
+
+// Method is a method of a service
+type Method struct {
+	Name            string
+	Service         string // Full name of the service
+	InputType       string
+	OutputType      string
+	Input           *Message
+	Output          *Message
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// FullName returns the path of the method: package.Service/Method
+func (m *Method) FullName() string {
+	return m.Service + "/" + m.Name
+}
+
+// Kind describes how messages are exchanged: unary, server streaming, client streaming or bidi streaming
+func (m *Method) Kind() string {
+	switch {
+	case m.ClientStreaming && m.ServerStreaming:
+		return "bidi streaming"
+	case m.ClientStreaming:
+		return "client streaming"
+	case m.ServerStreaming:
+		return "server streaming"
+	default:
+		return "unary"
+	}
+}
+
+// Registry holds the types declared by a set of file descriptors
+type Registry struct {
+	files    map[string][]string // File name to its dependencies
+	messages map[string]*Message
+	enums    map[string]*Enum
+	services map[string]*Service
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		files:    make(map[string][]string),
+		messages: make(map[string]*Message),
+		enums:    make(map[string]*Enum),
+		services: make(map[string]*Service),
+	}
+}
+
+// HasFile returns true if the file named name was added
+func (r *Registry) HasFile(name string) bool {
+	_, ok := r.files[name]
+	return ok
+}
+
+// MissingDependencies returns the dependencies of the added files that were not added
+func (r *Registry) MissingDependencies() []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, deps := range r.files {
+		for _, dep := range deps {
+			if !r.HasFile(dep) && !seen[dep] {
+				seen[dep] = true
+				missing = append(missing, dep)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// AddFile adds the types of an encoded FileDescriptorProto. Files already added are ignored.
+// Call Resolve once every file and its dependencies were added.
+func (r *Registry) AddFile(data []byte) error {
+	var name, pkg string
+	var deps []string
+	var messages, enums, services [][]byte
+
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return fmt.Errorf("invalid file descriptor: %w", err)
+		}
+		switch f.Number {
+		case 1:
+			name = string(f.Bytes)
+		case 2:
+			pkg = string(f.Bytes)
+		case 3:
+			deps = append(deps, string(f.Bytes))
+		case 4:
+			messages = append(messages, f.Bytes)
+		case 5:
+			enums = append(enums, f.Bytes)
+		case 6:
+			services = append(services, f.Bytes)
+		}
+	}
+	if r.HasFile(name) {
+		return nil
+	}
+
+	for _, m := range messages {
+		if err := r.addMessage(pkg, m); err != nil {
+			return fmt.Errorf("invalid file descriptor %s: %w", name, err)
+		}
+	}
+	for _, e := range enums {
+		if err := r.addEnum(pkg, e); err != nil {
+			return fmt.Errorf("invalid file descriptor %s: %w", name, err)
+		}
+	}
+	for _, s := range services {
+		if err := r.addService(pkg, s); err != nil {
+			return fmt.Errorf("invalid file descriptor %s: %w", name, err)
+		}
+	}
+	r.files[name] = deps
+	return nil
+}
+
+// qualify returns the full name of name declared in scope
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// addMessage adds an encoded DescriptorProto and its nested types
+func (r *Registry) addMessage(scope string, data []byte) error {
+	msg := &Message{}
+	var nested, enums [][]byte
+
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return err
+		}
+		switch f.Number {
+		case 1:
+			msg.Name = qualify(scope, string(f.Bytes))
+		case 2:
+			field, err := parseField(f.Bytes)
+			if err != nil {
+				return err
+			}
+			msg.Fields = append(msg.Fields, field)
+		case 3:
+			nested = append(nested, f.Bytes)
+		case 4:
+			enums = append(enums, f.Bytes)
+		case 7:
+			// MessageOptions.map_entry
+			opts := wireReader{data: f.Bytes}
+			for !opts.done() {
+				o, err := opts.next()
+				if err != nil {
+					return err
+				}
+				if o.Number == 7 {
+					msg.MapEntry = o.Value != 0
+				}
+			}
+		}
+	}
+
+	r.messages[msg.Name] = msg
+	for _, n := range nested {
+		if err := r.addMessage(msg.Name, n); err != nil {
+			return err
+		}
+	}
+	for _, e := range enums {
+		if err := r.addEnum(msg.Name, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseField parses an encoded FieldDescriptorProto
+func parseField(data []byte) (*Field, error) {
+	field := &Field{}
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return nil, err
+		}
+		switch f.Number {
+		case 1:
+			field.Name = string(f.Bytes)
+		case 3:
+			field.Number = int(f.Value)
+		case 4:
+			field.Repeated = f.Value == labelRepeated
+		case 5:
+			field.Type = FieldType(f.Value)
+		case 6:
+			field.TypeName = strings.TrimPrefix(string(f.Bytes), ".")
+		case 10:
+			field.JSONName = string(f.Bytes)
+		}
+	}
+	if field.JSONName == "" {
+		field.JSONName = jsonName(field.Name)
+	}
+	return field, nil
+}
+
+// jsonName returns the lowerCamelCase JSON name of a field, as protoc computes it
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// addEnum adds an encoded EnumDescriptorProto
+func (r *Registry) addEnum(scope string, data []byte) error {
+	enum := &Enum{}
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return err
+		}
+		switch f.Number {
+		case 1:
+			enum.Name = qualify(scope, string(f.Bytes))
+		case 2:
+			var value EnumValue
+			vr := wireReader{data: f.Bytes}
+			for !vr.done() {
+				vf, err := vr.next()
+				if err != nil {
+					return err
+				}
+				switch vf.Number {
+				case 1:
+					value.Name = string(vf.Bytes)
+				case 2:
+					value.Number = int32(vf.Value)
+				}
+			}
+			enum.Values = append(enum.Values, value)
+		}
+	}
+	r.enums[enum.Name] = enum
+	return nil
+}
+
+// addService adds an encoded ServiceDescriptorProto
+func (r *Registry) addService(scope string, data []byte) error {
+	svc := &Service{}
+	var methods [][]byte
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return err
+		}
+		switch f.Number {
+		case 1:
+			svc.Name = qualify(scope, string(f.Bytes))
+		case 2:
+			methods = append(methods, f.Bytes)
+		}
+	}
+
+	for _, data := range methods {
+		method := &Method{Service: svc.Name}
+		rd := wireReader{data: data}
+		for !rd.done() {
+			f, err := rd.next()
+			if err != nil {
+				return err
+			}
+			switch f.Number {
+			case 1:
+				method.Name = string(f.Bytes)
+			case 2:
+				method.InputType = strings.TrimPrefix(string(f.Bytes), ".")
+			case 3:
+				method.OutputType = strings.TrimPrefix(string(f.Bytes), ".")
+			case 5:
+				method.ClientStreaming = f.Value != 0
+			case 6:
+				method.ServerStreaming = f.Value != 0
+			}
+		}
+		svc.Methods = append(svc.Methods, method)
+	}
+	r.services[svc.Name] = svc
+	return nil
+}
+
+// Resolve links fields and methods to their message and enum types
+func (r *Registry) Resolve() error {
+	for _, msg := range r.messages {
+		for _, f := range msg.Fields {
+			switch f.Type {
+			case TypeMessage, TypeGroup:
+				if f.Message = r.messages[f.TypeName]; f.Message == nil {
+					return fmt.Errorf("unknown message type %s of field %s.%s", f.TypeName, msg.Name, f.Name)
+				}
+			case TypeEnum:
+				if f.Enum = r.enums[f.TypeName]; f.Enum == nil {
+					return fmt.Errorf("unknown enum type %s of field %s.%s", f.TypeName, msg.Name, f.Name)
+				}
+			}
+		}
+	}
+	for _, svc := range r.services {
+		for _, m := range svc.Methods {
+			m.Input, m.Output = r.messages[m.InputType], r.messages[m.OutputType]
+			if m.Input == nil || m.Output == nil {
+				return fmt.Errorf("unknown message types of method %s", m.FullName())
+			}
+		}
+	}
+	return nil
+}
+
+// Service returns the service with full name name
+func (r *Registry) Service(name string) *Service {
+	return r.services[name]
+}
+
+// Message returns the message type with full name name
+func (r *Registry) Message(name string) *Message {
+	return r.messages[name]
+}
@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Methods of the server reflection service, the current version first
+// (https://github.com/grpc/grpc/blob/master/doc/server-reflection.md)
+var reflectionPaths = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// reflectionServices are the services implementing reflection, hidden from the listed services
+var reflectionServices = map[string]bool{
+	"grpc.reflection.v1.ServerReflection":      true,
+	"grpc.reflection.v1alpha.ServerReflection": true,
+}
+
+// Fields of ServerReflectionRequest
+const (
+	reflectFileByFilename       = 3
+	reflectFileContainingSymbol = 4
+	reflectListServices         = 7
+)
+
+// Fields of ServerReflectionResponse
+const (
+	reflectFileDescriptorResponse = 4
+	reflectListServicesResponse   = 6
+	reflectErrorResponse          = 7
+)
+
+// ErrNoReflection is returned for servers without the reflection service
+var ErrNoReflection = errors.New("the server does not support reflection: enable the gRPC reflection service")
+
+// reflect sends one request to the reflection service and returns its response
+func (c *Client) reflect(ctx context.Context, request []byte) ([]byte, error) {
+	paths := reflectionPaths
+	if c.reflectionPath != "" {
+		paths = []string{c.reflectionPath}
+	}
+
+	for _, path := range paths {
+		result, err := c.call(ctx, path, [][]byte{request}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if result.Code == Unimplemented {
+			continue
+		}
+		if err := result.err(); err != nil {
+			return nil, err
+		}
+		if len(result.Messages) == 0 {
+			return nil, errors.New("empty reflection response")
+		}
+		c.reflectionPath = path
+		return result.Messages[0], nil
+	}
+	return nil, ErrNoReflection
+}
+
+// reflectionResponse reads the field number of a ServerReflectionResponse, failing
+// on error responses
+func reflectionResponse(data []byte, number int) ([]byte, error) {
+	rd := wireReader{data: data}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return nil, fmt.Errorf("invalid reflection response: %w", err)
+		}
+		switch f.Number {
+		case number:
+			return f.Bytes, nil
+		case reflectErrorResponse:
+			status := &StatusError{}
+			er := wireReader{data: f.Bytes}
+			for !er.done() {
+				ef, err := er.next()
+				if err != nil {
+					return nil, fmt.Errorf("invalid reflection response: %w", err)
+				}
+				switch ef.Number {
+				case 1:
+					status.Code = Code(ef.Value)
+				case 2:
+					status.Message = string(ef.Bytes)
+				}
+			}
+			return nil, status
+		}
+	}
+	return nil, errors.New("invalid reflection response")
+}
+
+// ListServices returns the full names of the services of the server, sorted
+func (c *Client) ListServices(ctx context.Context) ([]string, error) {
+	data, err := c.reflect(ctx, appendStringField(nil, reflectListServices, "*"))
+	if err != nil {
+		return nil, err
+	}
+	list, err := reflectionResponse(data, reflectListServicesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []string
+	rd := wireReader{data: list}
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return nil, fmt.Errorf("invalid reflection response: %w", err)
+		}
+		if f.Number != 1 {
+			continue
+		}
+		// ServiceResponse.name
+		sr := wireReader{data: f.Bytes}
+		for !sr.done() {
+			nf, err := sr.next()
+			if err != nil {
+				return nil, fmt.Errorf("invalid reflection response: %w", err)
+			}
+			if nf.Number == 1 && !reflectionServices[string(nf.Bytes)] {
+				services = append(services, string(nf.Bytes))
+			}
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// Describe returns the service named name, fetching the descriptors of the file
+// declaring it and their dependencies
+func (c *Client) Describe(ctx context.Context, name string) (*Service, error) {
+	if svc := c.registry.Service(name); svc != nil {
+		return svc, nil
+	}
+
+	if err := c.loadFiles(ctx, appendStringField(nil, reflectFileContainingSymbol, name)); err != nil {
+		if status, ok := err.(*StatusError); ok && status.Code == NotFound {
+			return nil, fmt.Errorf("unknown service %s", name)
+		}
+		return nil, err
+	}
+	// Servers may omit the dependencies sent earlier on the stream, so fetch missing ones
+	requested := make(map[string]bool)
+	for missing := c.registry.MissingDependencies(); len(missing) > 0; missing = c.registry.MissingDependencies() {
+		for _, file := range missing {
+			if requested[file] {
+				return nil, fmt.Errorf("reflection did not return %s", file)
+			}
+			requested[file] = true
+			if err := c.loadFiles(ctx, appendStringField(nil, reflectFileByFilename, file)); err != nil {
+				return nil, fmt.Errorf("loading %s: %w", file, err)
+			}
+		}
+	}
+	if err := c.registry.Resolve(); err != nil {
+		return nil, err
+	}
+
+	svc := c.registry.Service(name)
+	if svc == nil {
+		return nil, fmt.Errorf("unknown service %s", name)
+	}
+	return svc, nil
+}
+
+// loadFiles adds the file descriptors returned for a reflection request to the registry
+func (c *Client) loadFiles(ctx context.Context, request []byte) error {
+	data, err := c.reflect(ctx, request)
+	if err != nil {
+		return err
+	}
+	files, err := reflectionResponse(data, reflectFileDescriptorResponse)
+	if err != nil {
+		return err
+	}
+
+	rd := wireReader{data: files}
+	loaded := false
+	for !rd.done() {
+		f, err := rd.next()
+		if err != nil {
+			return fmt.Errorf("invalid reflection response: %w", err)
+		}
+		if f.Number == 1 {
+			if err := c.registry.AddFile(f.Bytes); err != nil {
+				return err
+			}
+			loaded = true
+		}
+	}
+	if !loaded {
+		return errors.New("reflection returned no file descriptors")
+	}
+	return nil
+}
+
+// Method returns the method named method of the service named service
+func (c *Client) Method(ctx context.Context, service, method string) (*Method, error) {
+	svc, err := c.Describe(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range svc.Methods {
+		if m.Name == method {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("service %s has no method %s", service, method)
+}
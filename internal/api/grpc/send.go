@@ -0,0 +1,223 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// defaultTimeout applies to requests without a timeout
+const defaultTimeout = 30 * time.Second
+
+// reservedHeaders are request headers set by the client, not sent as metadata
+var reservedHeaders = map[string]bool{
+	"content-type": true,
+	"te":           true,
+	"user-agent":   true,
+}
+
+// ListMethods returns the methods of every service of the server of target, sorted by service
+func ListMethods(ctx context.Context, target Target) ([]*Method, error) {
+	client := NewClient(target)
+	defer client.Close()
+
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var methods []*Method
+	for _, name := range services {
+		svc, err := client.Describe(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("describing %s: %w", name, err)
+		}
+		methods = append(methods, svc.Methods...)
+	}
+	return methods, nil
+}
+
+// Send calls the method of req.URL (grpc://host:port/package.Service/Method) with the
+// JSON body of req, and returns the response messages as JSON: an object for unary and
+// client streaming methods, an array for server and bidi streaming methods. Client
+// streaming methods take a JSON array of messages, all sent before reading the response.
+// Headers are sent as metadata. A status other than OK is returned as a response with
+// the equivalent HTTP status and a {"code", "message"} body.
+func Send(req *api.Request) (*api.Response, error) {
+	start := time.Now()
+
+	target, err := requestTarget(req)
+	if err != nil {
+		return nil, err
+	}
+	timeout := requestTimeout(req)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := NewClient(target)
+	defer client.Close()
+
+	method, err := client.Method(ctx, target.Service, target.Method)
+	if err != nil {
+		return nil, wrapDeadline(err, timeout)
+	}
+	return send(ctx, client, method, req, start, timeout)
+}
+
+// send calls method with the body of req and reads the whole response
+func send(ctx context.Context, client *Client, method *Method, req *api.Request, start time.Time, timeout time.Duration) (*api.Response, error) {
+	messages, err := encodeRequestBody(method, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.call(ctx, "/"+method.FullName(), messages, requestMetadata(req))
+	if err != nil {
+		return nil, wrapDeadline(err, timeout)
+	}
+	return newResponse(method, result, start)
+}
+
+// requestTarget returns the target of the URL of req, which must name a method
+func requestTarget(req *api.Request) (Target, error) {
+	target, err := ParseTarget(req.URL)
+	if err != nil {
+		return Target{}, err
+	}
+	if target.Method == "" {
+		return Target{}, fmt.Errorf("no method in gRPC URL %q: expected %s/package.Service/Method", req.URL, target.ServerURL())
+	}
+	return target, nil
+}
+
+// requestTimeout returns the timeout of req, the default one when it sets none
+func requestTimeout(req *api.Request) time.Duration {
+	if req.Timeout == 0 {
+		return defaultTimeout
+	}
+	return req.Timeout
+}
+
+// requestMetadata returns the headers of req sent as metadata
+func requestMetadata(req *api.Request) map[string]string {
+	metadata := make(map[string]string)
+	for key, value := range req.Headers {
+		if !reservedHeaders[strings.ToLower(key)] {
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
+// newResponse returns the response of a call to method, with its headers and trailers
+func newResponse(method *Method, result *callResult, start time.Time) (*api.Response, error) {
+	body, err := decodeResponseBody(method, result)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string][]string, len(result.Header)+len(result.Trailer))
+	for key, values := range result.Header {
+		headers[key] = values
+	}
+	for key, values := range result.Trailer {
+		headers[key] = values
+	}
+	status := result.Code.String()
+	if result.Message != "" {
+		status += ": " + result.Message
+	}
+	return &api.Response{
+		StatusCode: result.Code.HTTPStatus(),
+		Status:     status,
+		Headers:    headers,
+		Body:       body,
+		Time:       time.Since(start),
+		Size:       result.Size,
+	}, nil
+}
+
+// wrapDeadline reports calls cut by the timeout like HTTP requests that timed out
+func wrapDeadline(err error, timeout time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("request timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	return err
+}
+
+// encodeRequestBody encodes the JSON body of a request as the messages sent to method
+func encodeRequestBody(method *Method, body interface{}) ([][]byte, error) {
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		var err error
+		if data, err = json.Marshal(b); err != nil {
+			return nil, err
+		}
+	}
+	empty := len(bytes.TrimSpace(data)) == 0
+
+	if !method.ClientStreaming {
+		if empty {
+			data = []byte("{}")
+		}
+		msg, err := EncodeJSON(method.Input, data)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		return [][]byte{msg}, nil
+	}
+
+	// Without a body, no message is sent first
+	if empty {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("request body: %s is %s: expected a JSON array of %s messages", method.FullName(), method.Kind(), method.Input.Name)
+	}
+	messages := make([][]byte, len(items))
+	for i, item := range items {
+		msg, err := EncodeJSON(method.Input, item)
+		if err != nil {
+			return nil, fmt.Errorf("request body [%d]: %w", i, err)
+		}
+		messages[i] = msg
+	}
+	return messages, nil
+}
+
+// decodeResponseBody returns the JSON body showing the messages or status of a call
+func decodeResponseBody(method *Method, result *callResult) ([]byte, error) {
+	if result.Code != OK && (len(result.Messages) == 0 || !method.ServerStreaming) {
+		return json.MarshalIndent(jsonObject{
+			{Key: "code", Value: result.Code.String()},
+			{Key: "message", Value: result.Message},
+		}, "", "  ")
+	}
+
+	decoded := make([]interface{}, len(result.Messages))
+	for i, msg := range result.Messages {
+		obj, err := decodeMessage(method.Output, msg, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", method.Output.Name, err)
+		}
+		decoded[i] = obj
+	}
+	if method.ServerStreaming {
+		return json.MarshalIndent(decoded, "", "  ")
+	}
+	if len(decoded) != 1 {
+		return nil, fmt.Errorf("%s returned %d messages, want 1", method.FullName(), len(decoded))
+	}
+	return json.MarshalIndent(decoded[0], "", "  ")
+}
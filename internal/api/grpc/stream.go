@@ -0,0 +1,258 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// StreamMessage is a message of a streaming call, as JSON
+type StreamMessage struct {
+	Data string        // Message as compact JSON
+	Sent bool          // Whether the client sent the message
+	At   time.Duration // Time since the call started
+}
+
+// Stream is an open server or bidi streaming call. Received messages are delivered on
+// Messages, which is closed when the server ends the call, the connection fails or
+// Close is called. Bidi streaming calls take more messages with Send until CloseSend.
+type Stream struct {
+	Messages <-chan StreamMessage
+	Method   *Method
+
+	client  *Client
+	start   time.Time
+	cancel  context.CancelFunc
+	stopped atomic.Bool
+
+	sendMu     sync.Mutex
+	body       *io.PipeWriter // Request body, written as messages are sent
+	sendClosed bool
+
+	resp *api.Response
+	err  error
+	done chan struct{}
+}
+
+// SendStream calls the method of req.URL like Send, but returns server and bidi
+// streaming calls as soon as they start, with an open stream delivering the response
+// messages as they are received. The request timeout only applies to describing the
+// method for streams. The body of bidi streaming calls holds the messages sent first,
+// as a JSON array; it may be empty. Other calls are made whole and returned with a
+// nil stream.
+func SendStream(req *api.Request) (*api.Response, *Stream, error) {
+	start := time.Now()
+
+	target, err := requestTarget(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeout := requestTimeout(req)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := NewClient(target)
+	method, err := client.Method(ctx, target.Service, target.Method)
+	if err != nil {
+		client.Close()
+		return nil, nil, wrapDeadline(err, timeout)
+	}
+	if !method.ServerStreaming {
+		defer client.Close()
+		resp, err := send(ctx, client, method, req, start, timeout)
+		return resp, nil, err
+	}
+
+	messages, err := encodeRequestBody(method, req.Body)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	stream, err := newStream(client, method, messages, requestMetadata(req), start)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	// Headers arrive with the first message, the status with the trailers
+	head := &api.Response{
+		StatusCode: http.StatusOK,
+		Status:     method.Kind(),
+		Headers:    map[string][]string{},
+		Time:       time.Since(start),
+	}
+	return head, stream, nil
+}
+
+// newStream starts a streaming call of method, sending messages first
+func newStream(client *Client, method *Method, messages [][]byte, metadata map[string]string, start time.Time) (*Stream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	req, err := client.newRequest(ctx, "/"+method.FullName(), reader, metadata)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	received := make(chan StreamMessage, 64)
+	s := &Stream{
+		Messages: received,
+		Method:   method,
+		client:   client,
+		start:    start,
+		cancel:   cancel,
+		body:     writer,
+		done:     make(chan struct{}),
+	}
+
+	// Writes block until the transport reads them, so they cannot wait for the response
+	s.sendMu.Lock()
+	go func() {
+		defer s.sendMu.Unlock()
+		for _, msg := range messages {
+			if _, err := writer.Write(frame(msg)); err != nil {
+				return // The call failed, as reading its response reports
+			}
+		}
+		if !method.ClientStreaming {
+			s.sendClosed = true
+			writer.Close()
+		}
+	}()
+
+	go func() {
+		defer close(received)
+		defer client.Close()
+		defer cancel()
+		defer reader.Close()
+
+		result := &callResult{}
+		err := s.read(ctx, req, result, received)
+		if err != nil && s.stopped.Load() {
+			// Stopped by Close: the messages received so far are the response
+			err = nil
+			result.Code, result.Message = Canceled, "stopped by the client"
+		}
+		if err == nil {
+			s.resp, err = newResponse(method, result, start)
+		}
+		s.err = err
+		close(s.done)
+	}()
+	return s, nil
+}
+
+// read makes the call and delivers its messages until the server ends it
+func (s *Stream) read(ctx context.Context, req *http.Request, result *callResult, received chan<- StreamMessage) error {
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	result.Header = resp.Header
+	err = readMessages(resp.Body, func(msg []byte) error {
+		result.Messages = append(result.Messages, msg)
+		result.Size += int64(len(msg))
+		data, err := messageJSON(s.Method.Output, msg)
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", s.Method.Output.Name, err)
+		}
+		select {
+		case received <- StreamMessage{Data: data, At: time.Since(s.start)}:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return readStatus(resp, result)
+}
+
+// CanSend returns whether the call takes more messages from the client
+func (s *Stream) CanSend() bool {
+	if !s.Method.ClientStreaming {
+		return false
+	}
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return !s.sendClosed
+}
+
+// Send sends the JSON message body to the server of a bidi streaming call, and returns
+// the message as sent
+func (s *Stream) Send(body string) (StreamMessage, error) {
+	if !s.Method.ClientStreaming {
+		return StreamMessage{}, fmt.Errorf("%s is %s: the server takes a single message", s.Method.FullName(), s.Method.Kind())
+	}
+	msg, err := EncodeJSON(s.Method.Input, []byte(body))
+	if err != nil {
+		return StreamMessage{}, err
+	}
+	data, err := messageJSON(s.Method.Input, msg)
+	if err != nil {
+		return StreamMessage{}, err
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.sendClosed {
+		return StreamMessage{}, errors.New("sending is closed")
+	}
+	if _, err := s.body.Write(frame(msg)); err != nil {
+		return StreamMessage{}, errors.New("the call has ended")
+	}
+	return StreamMessage{Data: data, Sent: true, At: time.Since(s.start)}, nil
+}
+
+// CloseSend tells the server the client sends no more messages
+func (s *Stream) CloseSend() error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.sendClosed {
+		return nil
+	}
+	s.sendClosed = true
+	return s.body.Close()
+}
+
+// Close cancels the call. Messages already received stay in the response body.
+func (s *Stream) Close() {
+	s.stopped.Store(true)
+	s.cancel()
+}
+
+// Err returns the error that ended the call, nil if the server ended it or Close was
+// called. It blocks until the call has ended.
+func (s *Stream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Response returns the response with every received message as body, its status and
+// trailers, nil when the call failed. It blocks until the call has ended.
+func (s *Stream) Response() *api.Response {
+	<-s.done
+	return s.resp
+}
+
+// messageJSON decodes a message of type msg as compact JSON
+func messageJSON(msg *Message, data []byte) (string, error) {
+	obj, err := decodeMessage(msg, data, 0)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
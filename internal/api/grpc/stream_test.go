@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestSendStream(t *testing.T) {
+	server := newTestServer(t, true)
+	open := func(path, body string) (*api.Response, *Stream) {
+		t.Helper()
+		resp, stream, err := SendStream(&api.Request{Method: api.GRPC, URL: grpcURL(server, path), Body: body, Timeout: 5 * time.Second})
+		if err != nil {
+			t.Fatalf("SendStream(%s) error = %v", path, err)
+		}
+		return resp, stream
+	}
+	next := func(stream *Stream) string {
+		t.Helper()
+		select {
+		case msg := <-stream.Messages:
+			return msg.Data
+		case <-time.After(5 * time.Second):
+			t.Fatal("no message received")
+			return ""
+		}
+	}
+
+	// Server streaming: messages arrive one by one, and the response holds them all
+	head, stream := open("/demo.Greeter/Count", `{}`)
+	if stream == nil || head.Status != "server streaming" {
+		t.Fatalf("Count should open a stream, got %+v", head)
+	}
+	if stream.CanSend() {
+		t.Error("server streaming calls take no more messages")
+	}
+	var got []string
+	for msg := range stream.Messages {
+		got = append(got, msg.Data)
+	}
+	if strings.Join(got, " ") != `{"message":"one"} {"message":"two"}` {
+		t.Errorf("messages = %q", got)
+	}
+	if resp := stream.Response(); resp == nil || resp.StatusCode != http.StatusOK || !strings.HasPrefix(string(resp.Body), "[") {
+		t.Errorf("response should hold the messages, got %+v", resp)
+	}
+
+	// Bidi streaming: messages sent first, then with Send, are answered as they arrive
+	_, stream = open("/demo.Greeter/Chat", `[{"name": "first"}]`)
+	if data := next(stream); data != `{"message":"first"}` {
+		t.Errorf("first reply = %s", data)
+	}
+	sent, err := stream.Send(`{"name": "second"}`)
+	if err != nil || !sent.Sent || sent.Data != `{"name":"second"}` {
+		t.Errorf("Send() = %+v, %v", sent, err)
+	}
+	if data := next(stream); data != `{"message":"second"}` {
+		t.Errorf("second reply = %s", data)
+	}
+	if _, err := stream.Send(`{"nom": "x"}`); err == nil {
+		t.Error("Send() should reject unknown fields")
+	}
+	if err := stream.CloseSend(); err != nil || stream.CanSend() {
+		t.Errorf("CloseSend() = %v, CanSend() = %v", err, stream.CanSend())
+	}
+	if _, err := stream.Send(`{}`); err == nil {
+		t.Error("Send() should fail once sending is closed")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if resp := stream.Response(); resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("response = %+v", resp)
+	}
+
+	// Close cancels the call
+	_, stream = open("/demo.Greeter/Chat", "")
+	stream.Close()
+	for range stream.Messages {
+	}
+	if resp := stream.Response(); stream.Err() != nil || resp == nil || !strings.HasPrefix(resp.Status, "CANCELLED") {
+		t.Errorf("a closed call should be canceled, got %+v (%v)", resp, stream.Err())
+	}
+
+	// Unary methods are called whole
+	resp, stream := open("/demo.Greeter/SayHello", `{"name": "Ada"}`)
+	if stream != nil || !strings.Contains(string(resp.Body), "Hello Ada") {
+		t.Errorf("SayHello should answer without a stream, got %+v", resp)
+	}
+}
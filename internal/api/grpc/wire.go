@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Protocol Buffers wire types (https://protobuf.dev/programming-guides/encoding/)
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// maxDecodeDepth bounds message nesting to protect against malicious payloads
+const maxDecodeDepth = 100
+
+var (
+	errTruncated = errors.New("protobuf: truncated message")
+	errTooDeep   = errors.New("protobuf: nesting too deep")
+)
+
+// wireField is one field read from an encoded message. Varint, fixed32 and fixed64
+// values are in Value, length-delimited values in Bytes.
+type wireField struct {
+	Number   int
+	WireType int
+	Value    uint64
+	Bytes    []byte
+}
+
+// wireReader reads the fields of an encoded message in order
+type wireReader struct {
+	data []byte
+}
+
+// done returns true when every field was read
+func (r *wireReader) done() bool {
+	return len(r.data) == 0
+}
+
+// next reads the next field
+func (r *wireReader) next() (wireField, error) {
+	key, err := r.varint()
+	if err != nil {
+		return wireField{}, err
+	}
+	f := wireField{Number: int(key >> 3), WireType: int(key & 7)}
+	if f.Number <= 0 || key>>3 > 1<<29-1 {
+		return wireField{}, fmt.Errorf("protobuf: invalid field number %d", key>>3)
+	}
+
+	switch f.WireType {
+	case wireVarint:
+		f.Value, err = r.varint()
+	case wireFixed64:
+		if len(r.data) < 8 {
+			return wireField{}, errTruncated
+		}
+		f.Value = binary.LittleEndian.Uint64(r.data)
+		r.data = r.data[8:]
+	case wireFixed32:
+		if len(r.data) < 4 {
+			return wireField{}, errTruncated
+		}
+		f.Value = uint64(binary.LittleEndian.Uint32(r.data))
+		r.data = r.data[4:]
+	case wireBytes:
+		var n uint64
+		if n, err = r.varint(); err != nil {
+			return wireField{}, err
+		}
+		if n > uint64(len(r.data)) {
+			return wireField{}, errTruncated
+		}
+		f.Bytes = r.data[:n]
+		r.data = r.data[n:]
+	default:
+		// Groups are deprecated since proto2 and never used by proto3 services
+		return wireField{}, fmt.Errorf("protobuf: unsupported wire type %d for field %d", f.WireType, f.Number)
+	}
+	return f, err
+}
+
+// varint reads a base 128 varint
+func (r *wireReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	r.data = r.data[n:]
+	return v, nil
+}
+
+// appendTag appends the key of field num with wire type wt
+func appendTag(b []byte, num, wt int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(wt))
+}
+
+// appendVarintField appends field num as a varint
+func appendVarintField(b []byte, num int, v uint64) []byte {
+	return binary.AppendUvarint(appendTag(b, num, wireVarint), v)
+}
+
+// appendBytesField appends field num as a length-delimited value
+func appendBytesField(b []byte, num int, data []byte) []byte {
+	b = binary.AppendUvarint(appendTag(b, num, wireBytes), uint64(len(data)))
+	return append(b, data...)
+}
+
+// appendStringField appends field num as a string
+func appendStringField(b []byte, num int, s string) []byte {
+	b = binary.AppendUvarint(appendTag(b, num, wireBytes), uint64(len(s)))
+	return append(b, s...)
+}
+
+// zigzag encodes a signed integer for the sint32 and sint64 types
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// unzigzag decodes a sint32 or sint64 value
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
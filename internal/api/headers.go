@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -87,3 +88,93 @@ func ValidateHeaderText(text string) []string {
 
 	return warnings
 }
+
+// mimeTypes are the common values of Content-Type and Accept
+var mimeTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+	"text/plain",
+	"text/html",
+	"application/xml",
+	"text/xml",
+	"application/octet-stream",
+	"application/graphql",
+	"application/msgpack",
+	"application/cbor",
+}
+
+// commonHeaders are the request headers offered for completion, with their common values
+var commonHeaders = []struct {
+	Name   string
+	Values []string
+}{
+	{"Accept", slices.Concat([]string{"*/*"}, mimeTypes, []string{"text/event-stream"})},
+	{"Accept-Charset", []string{"utf-8"}},
+	{"Accept-Encoding", []string{"gzip, deflate, br", "gzip", "deflate", "br", "zstd", "identity"}},
+	{"Accept-Language", []string{"en-US,en;q=0.9", "en", "fr", "de", "es"}},
+	{"Authorization", []string{"Bearer {{token}}", "Basic ", "Bearer "}},
+	{"Cache-Control", []string{"no-cache", "no-store", "max-age=0", "must-revalidate"}},
+	{"Connection", []string{"keep-alive", "close"}},
+	{"Content-Encoding", []string{"gzip", "deflate", "br"}},
+	{"Content-Length", nil},
+	{"Content-Type", slices.Concat(mimeTypes, []string{"application/json; charset=utf-8"})},
+	{"Cookie", nil},
+	{"Host", nil},
+	{"Idempotency-Key", []string{"{{$uuid}}"}},
+	{"If-Match", nil},
+	{"If-Modified-Since", nil},
+	{"If-None-Match", nil},
+	{"Origin", nil},
+	{"Pragma", []string{"no-cache"}},
+	{"Range", []string{"bytes=0-"}},
+	{"Referer", nil},
+	{"User-Agent", []string{"LazyCurl/1.0"}},
+	{"X-API-Key", []string{"{{api_key}}"}},
+	{"X-Forwarded-For", nil},
+	{"X-Request-ID", []string{"{{$uuid}}"}},
+	{"X-Requested-With", []string{"XMLHttpRequest"}},
+}
+
+// HeaderNameCompletions returns the common request headers whose name starts with
+// prefix, ignoring case, the one named prefix first. An empty prefix completes nothing.
+func HeaderNameCompletions(prefix string) []string {
+	if prefix == "" {
+		return nil
+	}
+	var names []string
+	for _, header := range commonHeaders {
+		if !hasPrefixFold(header.Name, prefix) {
+			continue
+		}
+		if strings.EqualFold(header.Name, prefix) {
+			names = append([]string{header.Name}, names...)
+		} else {
+			names = append(names, header.Name)
+		}
+	}
+	return names
+}
+
+// HeaderValueCompletions returns the common values of the header name starting with
+// prefix, ignoring case
+func HeaderValueCompletions(name, prefix string) []string {
+	for _, header := range commonHeaders {
+		if !strings.EqualFold(header.Name, strings.TrimSpace(name)) {
+			continue
+		}
+		var values []string
+		for _, value := range header.Values {
+			if hasPrefixFold(value, prefix) {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -267,3 +268,26 @@ func TestHeadersToText_SortedOutput(t *testing.T) {
 		}
 	}
 }
+
+func TestHeaderCompletions(t *testing.T) {
+	if got := HeaderNameCompletions(""); got != nil {
+		t.Errorf("empty prefix completes %v, want nothing", got)
+	}
+	if got := HeaderNameCompletions("content-t"); !reflect.DeepEqual(got, []string{"Content-Type"}) {
+		t.Errorf("content-t completes %v", got)
+	}
+	// The header named by the prefix comes first
+	if got := HeaderNameCompletions("accept"); len(got) != 4 || got[0] != "Accept" {
+		t.Errorf("accept completes %v, want Accept first", got)
+	}
+
+	if got := HeaderValueCompletions("content-type", "application/j"); !reflect.DeepEqual(got, []string{"application/json", "application/json; charset=utf-8"}) {
+		t.Errorf("Content-Type values = %v", got)
+	}
+	if got := HeaderValueCompletions("Accept-Encoding", ""); len(got) == 0 || got[0] != "gzip, deflate, br" {
+		t.Errorf("Accept-Encoding values = %v", got)
+	}
+	if got := HeaderValueCompletions("X-Custom", ""); got != nil {
+		t.Errorf("unknown header values = %v, want none", got)
+	}
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
 )
 
@@ -19,6 +21,10 @@ const (
 	DELETE  HTTPMethod = "DELETE"
 	HEAD    HTTPMethod = "HEAD"
 	OPTIONS HTTPMethod = "OPTIONS"
+
+	// GRPC requests call a gRPC method: the URL is grpc://host:port/package.Service/Method
+	// and the body is the request message as JSON (see internal/api/grpc)
+	GRPC HTTPMethod = "GRPC"
 )
 
 // Request represents an HTTP request
@@ -28,6 +34,14 @@ type Request struct {
 	Headers map[string]string
 	Body    interface{}
 	Timeout time.Duration
+
+	NoFollowRedirects bool // Return 3xx responses instead of following them
+	MaxRedirects      int  // Redirects followed before failing; 0 uses DefaultMaxRedirects
+
+	Retry *RetryPolicy // Resends the request on network errors and listed statuses; nil sends once
+
+	ServerName string // TLS server name (SNI) sent instead of the URL host; empty uses the URL host
+	Host       string // Host header sent instead of the URL host; empty uses the URL host
 }
 
 // Response represents an HTTP response
@@ -35,68 +49,118 @@ type Response struct {
 	StatusCode int
 	Status     string
 	Headers    map[string][]string
-	Body       string
+	Body       []byte // Raw body bytes (may be binary); the beginning of the body when BodyFile is set
+	BodyFile   string // Temporary file holding bodies larger than SpoolBodySize, "" otherwise
 	Time       time.Duration
 	Size       int64
+	Redirects  []RedirectHop // Requests of the redirect chain, the final one last; empty when not redirected
+	Attempts   int           // Times the request was sent, more than 1 when retried
 }
 
 // Client handles HTTP requests
 type Client struct {
 	httpClient *http.Client
+	proxy      *Proxy
+	tls        *clientTLS
 }
 
-// NewClient creates a new HTTP client
+// NewClient creates a new HTTP client, using DefaultProxy and DefaultTLS when set
 func NewClient() *Client {
-	return &Client{
+	c := &Client{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
 	}
+	if DefaultProxy != nil {
+		// An invalid proxy is reported when it is configured; connect directly meanwhile
+		_ = c.SetProxy(DefaultProxy)
+	}
+	if DefaultTLS != nil {
+		// Likewise, an invalid TLS config falls back to the system CAs
+		_ = c.SetTLS(DefaultTLS)
+	}
+	return c
 }
 
-// Send sends an HTTP request and returns the response
+// Send sends an HTTP request and returns the response. With a retry policy, the
+// request is sent again after network errors and retried statuses, waiting the
+// policy's backoff delay between attempts.
 func (c *Client) Send(req *Request) (*Response, error) {
-	start := time.Now()
+	return sendWithRetries(req, func() (*Response, bool, error) {
+		resp, err := c.send(req)
+		return resp, true, err
+	})
+}
 
-	// Prepare body
-	var bodyReader io.Reader
-	if req.Body != nil {
-		jsonBody, err := json.Marshal(req.Body)
-		if err != nil {
-			return nil, err
-		}
-		bodyReader = bytes.NewBuffer(jsonBody)
+// sendWithRetries calls send, then calls it again while the retry policy of req
+// retries its outcome and attempts remain. Outcomes send reports as not retryable
+// are returned as is.
+func sendWithRetries(req *Request, send func() (resp *Response, retryable bool, err error)) (*Response, error) {
+	attempts := 1
+	if req.Retry != nil && req.Retry.Count > 0 {
+		attempts += req.Retry.Count
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequest(string(req.Method), req.URL, bodyReader)
+	attempt := 1
+	resp, retryable, err := send()
+	for attempt < attempts && retryable && shouldRetry(req.Retry, resp, err) {
+		time.Sleep(req.Retry.DelayBefore(attempt))
+		attempt++
+		resp, retryable, err = send()
+	}
 	if err != nil {
+		if attempt > 1 {
+			return nil, fmt.Errorf("%w (after %d attempts)", err, attempt)
+		}
 		return nil, err
 	}
+	resp.Attempts = attempt
+	return resp, nil
+}
 
-	// Set headers
-	for key, value := range req.Headers {
-		httpReq.Header.Set(key, value)
+// shouldRetry returns true if the outcome of an attempt is retried by policy
+func shouldRetry(policy *RetryPolicy, resp *Response, err error) bool {
+	if policy == nil {
+		return false
 	}
+	return err != nil || policy.RetriesStatus(resp.StatusCode)
+}
 
-	// Set default Content-Type if body exists and not set
-	if req.Body != nil && httpReq.Header.Get("Content-Type") == "" {
-		httpReq.Header.Set("Content-Type", "application/json")
+// send sends req once
+func (c *Client) send(req *Request) (*Response, error) {
+	start := time.Now()
+
+	httpReq, err := newHTTPRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
-	// Send request
+	// Copy the client so the timeout and redirect policy only apply to this send
+	var redirects []RedirectHop
+	client := *c.httpClient
 	if req.Timeout > 0 {
-		c.httpClient.Timeout = req.Timeout
+		client.Timeout = req.Timeout
+	}
+	client.CheckRedirect = redirectPolicy(req, &redirects)
+	if req.ServerName != "" {
+		client.Transport = c.serverNameTransport(req.ServerName, httpReq.URL)
 	}
 
-	httpResp, err := c.httpClient.Do(httpReq)
+	httpResp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, err
 	}
 	defer httpResp.Body.Close()
+	if len(redirects) > 0 {
+		redirects = append(redirects, RedirectHop{
+			URL:        httpResp.Request.URL.String(),
+			StatusCode: httpResp.StatusCode,
+			Status:     httpResp.Status,
+		})
+	}
 
-	// Read response body
-	bodyBytes, err := io.ReadAll(httpResp.Body)
+	// Read response body, streaming large bodies to a temporary file
+	bodyBytes, bodyFile, size, err := readResponseBody(httpResp.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -107,12 +171,91 @@ func (c *Client) Send(req *Request) (*Response, error) {
 		StatusCode: httpResp.StatusCode,
 		Status:     httpResp.Status,
 		Headers:    httpResp.Header,
-		Body:       string(bodyBytes),
+		Body:       bodyBytes,
+		BodyFile:   bodyFile,
 		Time:       elapsed,
-		Size:       int64(len(bodyBytes)),
+		Size:       size,
+		Redirects:  redirects,
 	}, nil
 }
 
+// newHTTPRequest builds the net/http request for req, with its headers and encoded body
+func newHTTPRequest(req *Request) (*http.Request, error) {
+	// Prepare body
+	var bodyReader io.Reader
+	isJSON := false
+	formContentType := ""
+	if form, ok := req.Body.(*MultipartForm); ok {
+		bodyBytes, contentType, err := form.Encode()
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+		formContentType = contentType
+	} else if _, ok := req.Body.(*FileBody); ok {
+		// Streamed from the file once the headers are set
+	} else if req.Body != nil {
+		bodyBytes, jsonBody, err := encodeRequestBody(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+		isJSON = jsonBody
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequest(string(req.Method), req.URL, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	if req.Host != "" {
+		httpReq.Host = req.Host
+	}
+
+	// Set default Content-Type if body exists and not set
+	if req.Body != nil && isJSON && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	// Multipart bodies always carry their own boundary
+	if formContentType != "" {
+		httpReq.Header.Set("Content-Type", formContentType)
+	}
+	if file, ok := req.Body.(*FileBody); ok {
+		if err := file.setRequestBody(httpReq); err != nil {
+			return nil, err
+		}
+	}
+	return httpReq, nil
+}
+
+// encodeRequestBody converts a request body to bytes. Raw bytes and strings are sent
+// as-is; any other value is serialized as JSON (reported by the second return value).
+func encodeRequestBody(body interface{}) ([]byte, bool, error) {
+	switch v := body.(type) {
+	case []byte:
+		return v, false, nil
+	case string:
+		return []byte(v), false, nil
+	case *MultipartForm:
+		data, _, err := v.Encode()
+		return data, false, err
+	case *FileBody:
+		data, err := os.ReadFile(ExpandHome(v.Path))
+		return data, false, err
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, false, err
+		}
+		return data, true, nil
+	}
+}
+
 // Collection represents a collection of requests
 type Collection struct {
 	Name        string
@@ -124,4 +267,5 @@ type Collection struct {
 type Environment struct {
 	Name      string
 	Variables map[string]string
+	Scopes    VariableScopes // Request, collection and workspace variables, for lc.variables
 }
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// InventoryEntry describes one request of the API inventory
+type InventoryEntry struct {
+	Collection string     `json:"collection"`
+	Folder     string     `json:"folder,omitempty"` // Folder path, "/" separated
+	Name       string     `json:"name"`
+	RequestID  string     `json:"request_id"`
+	Method     string     `json:"method"`
+	Endpoint   string     `json:"endpoint"`              // URL without its query string
+	Auth       string     `json:"auth"`                  // Auth type, "header" for an Authorization header, or "none"
+	Owner      string     `json:"owner,omitempty"`       // Owner of the request, its nearest folder or its collection
+	LastTested *time.Time `json:"last_tested,omitempty"` // Latest send, nil if never sent
+}
+
+// inventoryColumns are the header of the inventory CSV
+var inventoryColumns = []string{"collection", "folder", "name", "request_id", "method", "endpoint", "auth", "owner", "last_tested"}
+
+// Inventory lists the requests of the collections, in collection order. lastTested
+// holds the latest send of the requests by ID; it may be nil.
+func Inventory(collections []*CollectionFile, lastTested map[string]time.Time) []InventoryEntry {
+	var entries []InventoryEntry
+	for _, c := range collections {
+		var walk func(folders []Folder, requests []CollectionRequest, path []string, owner string)
+		walk = func(folders []Folder, requests []CollectionRequest, path []string, owner string) {
+			for _, req := range requests {
+				entry := InventoryEntry{
+					Collection: c.Name,
+					Folder:     strings.Join(path, "/"),
+					Name:       req.Name,
+					RequestID:  req.ID,
+					Method:     string(req.Method),
+					Endpoint:   inventoryEndpoint(req.URL),
+					Auth:       inventoryAuth(&req),
+					Owner:      firstNonEmpty(req.Owner, owner),
+				}
+				if t, ok := lastTested[req.ID]; ok {
+					entry.LastTested = &t
+				}
+				entries = append(entries, entry)
+			}
+			for _, f := range folders {
+				walk(f.Folders, f.Requests, append(path[:len(path):len(path)], f.Name), firstNonEmpty(f.Owner, owner))
+			}
+		}
+		walk(c.Folders, c.Requests, nil, c.Owner)
+	}
+	return entries
+}
+
+// inventoryEndpoint returns a URL without its query string and fragment
+func inventoryEndpoint(rawURL string) string {
+	endpoint, _, _ := strings.Cut(rawURL, "?")
+	endpoint, _, _ = strings.Cut(endpoint, "#")
+	return endpoint
+}
+
+// inventoryAuth returns the auth type of a request: its auth config, or "header" when
+// it sets an Authorization header itself
+func inventoryAuth(req *CollectionRequest) string {
+	if req.Auth != nil && req.Auth.Type != "" && req.Auth.Type != "none" {
+		return req.Auth.Type
+	}
+	for _, h := range req.Headers {
+		if h.Enabled && strings.EqualFold(h.Key, "Authorization") {
+			return "header"
+		}
+	}
+	for key := range req.HeadersMap {
+		if strings.EqualFold(key, "Authorization") {
+			return "header"
+		}
+	}
+	return "none"
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// WriteInventoryCSV writes the inventory as CSV with a header row. Last-tested dates are
+// RFC 3339, empty for requests never sent.
+func WriteInventoryCSV(entries []InventoryEntry, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(inventoryColumns); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		lastTested := ""
+		if e.LastTested != nil {
+			lastTested = e.LastTested.Format(time.RFC3339)
+		}
+		record := []string{e.Collection, e.Folder, e.Name, e.RequestID, e.Method, e.Endpoint, e.Auth, e.Owner, lastTested}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteInventoryJSON writes the inventory as an indented JSON array
+func WriteInventoryJSON(entries []InventoryEntry, w io.Writer) error {
+	if entries == nil {
+		entries = []InventoryEntry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
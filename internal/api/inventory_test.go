@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInventory(t *testing.T) {
+	sent := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	c := &CollectionFile{
+		Name:  "Shop",
+		Owner: "platform",
+		Folders: []Folder{{
+			Name:  "Users",
+			Owner: "identity",
+			Requests: []CollectionRequest{
+				{ID: "list", Name: "List", Method: GET, URL: "{{base_url}}/users?page=1",
+					Headers: []KeyValueEntry{{Key: "Authorization", Value: "Bearer {{token}}", Enabled: true}}},
+				{ID: "create", Name: "Create, bulk", Method: POST, URL: "{{base_url}}/users",
+					Auth: &AuthConfig{Type: "bearer", Token: "s3cr3t"}, Owner: "growth"},
+			},
+		}},
+		Requests: []CollectionRequest{
+			{ID: "health", Name: "Health", Method: GET, URL: "{{base_url}}/health#status",
+				Auth:    &AuthConfig{Type: "none"},
+				Headers: []KeyValueEntry{{Key: "Authorization", Value: "x", Enabled: false}}},
+		},
+	}
+
+	entries := Inventory([]*CollectionFile{c}, map[string]time.Time{"create": sent})
+	want := []InventoryEntry{
+		{Collection: "Shop", Name: "Health", RequestID: "health", Method: "GET", Endpoint: "{{base_url}}/health", Auth: "none", Owner: "platform"},
+		{Collection: "Shop", Folder: "Users", Name: "List", RequestID: "list", Method: "GET", Endpoint: "{{base_url}}/users", Auth: "header", Owner: "identity"},
+		{Collection: "Shop", Folder: "Users", Name: "Create, bulk", RequestID: "create", Method: "POST", Endpoint: "{{base_url}}/users", Auth: "bearer", Owner: "growth", LastTested: &sent},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Inventory() returned %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		got := entries[i]
+		if (got.LastTested == nil) != (w.LastTested == nil) || got.LastTested != nil && !got.LastTested.Equal(*w.LastTested) {
+			t.Errorf("entry %d LastTested = %v, want %v", i, got.LastTested, w.LastTested)
+		}
+		got.LastTested, w.LastTested = nil, nil
+		if got != w {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteInventoryCSV(entries, &buf); err != nil {
+		t.Fatalf("WriteInventoryCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "collection,folder,name,request_id,method,endpoint,auth,owner,last_tested" {
+		t.Fatalf("WriteInventoryCSV() =\n%s", buf.String())
+	}
+	if lines[3] != `Shop,Users,"Create, bulk",create,POST,{{base_url}}/users,bearer,growth,2026-03-10T15:00:00Z` {
+		t.Errorf("CSV row = %s", lines[3])
+	}
+
+	buf.Reset()
+	if err := WriteInventoryJSON(nil, &buf); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("WriteInventoryJSON(nil) = %q, %v", buf.String(), err)
+	}
+}
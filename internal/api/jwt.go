@@ -0,0 +1,217 @@
+package api
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// JWT signing algorithms supported by the "jwt" auth type
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmES256 = "ES256"
+)
+
+// JWTAlgorithms lists the supported signing algorithms in display order
+var JWTAlgorithms = []string{JWTAlgorithmHS256, JWTAlgorithmRS256, JWTAlgorithmES256}
+
+// JWTOptions configures JWT generation
+type JWTOptions struct {
+	Algorithm string        // HS256, RS256 or ES256 (default: HS256)
+	Key       string        // HMAC secret, PEM private key, or path to a PEM file
+	Claims    string        // JSON object of claims (variables already substituted)
+	ExpiresIn time.Duration // Adds an "exp" claim when > 0 and the claims don't set one
+	Now       time.Time     // Issue time (default: time.Now)
+}
+
+// GenerateJWT builds and signs a compact JWT.
+// An "iat" claim is added when the claims template doesn't set one.
+func GenerateJWT(opts JWTOptions) (string, error) {
+	signing, err := SignJWT(opts)
+	if err != nil {
+		return "", err
+	}
+	return signing.Token, nil
+}
+
+// SignJWT builds and signs a compact JWT like GenerateJWT, and returns every step of
+// the signing so signatures a server rejects can be debugged.
+func SignJWT(opts JWTOptions) (*JWTSigning, error) {
+	algorithm := strings.ToUpper(strings.TrimSpace(opts.Algorithm))
+	if algorithm == "" {
+		algorithm = JWTAlgorithmHS256
+	}
+	if opts.Key == "" {
+		return nil, errors.New("jwt: signing key is empty")
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	claims, err := buildJWTClaims(opts.Claims, now, opts.ExpiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	headerJSON := []byte(fmt.Sprintf(`{"alg":%q,"typ":"JWT"}`, algorithm))
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	signature, err := signJWT(algorithm, opts.Key, []byte(signingInput))
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTSigning{
+		Algorithm:    algorithm,
+		Header:       string(headerJSON),
+		Claims:       string(claims),
+		SigningInput: signingInput,
+		Signature:    signature,
+		Token:        signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		key:          opts.Key,
+	}, nil
+}
+
+// buildJWTClaims parses the claims template and fills in iat/exp when missing.
+// The original key order is preserved in the encoded payload.
+func buildJWTClaims(template string, now time.Time, expiresIn time.Duration) ([]byte, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		template = "{}"
+	}
+
+	var claims map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(template), &claims); err != nil || claims == nil {
+		return nil, errors.New("jwt: claims must be a JSON object")
+	}
+
+	var extra []string
+	if _, ok := claims["iat"]; !ok {
+		extra = append(extra, fmt.Sprintf(`"iat":%d`, now.Unix()))
+	}
+	if _, ok := claims["exp"]; !ok && expiresIn > 0 {
+		extra = append(extra, fmt.Sprintf(`"exp":%d`, now.Add(expiresIn).Unix()))
+	}
+
+	// Compact the template and append generated claims before the closing brace
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(template)); err != nil {
+		return nil, fmt.Errorf("jwt: invalid claims: %w", err)
+	}
+	if len(extra) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	payload := buf.Bytes()
+	payload = payload[:len(payload)-1]
+	if len(claims) > 0 {
+		payload = append(payload, ',')
+	}
+	payload = append(payload, strings.Join(extra, ",")...)
+	payload = append(payload, '}')
+	return payload, nil
+}
+
+// signJWT signs the signing input with the given algorithm and key
+func signJWT(algorithm, key string, input []byte) ([]byte, error) {
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(input)
+		return mac.Sum(nil), nil
+
+	case JWTAlgorithmRS256:
+		privateKey, err := loadJWTPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := privateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("jwt: RS256 requires an RSA private key")
+		}
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+
+	case JWTAlgorithmES256:
+		privateKey, err := loadJWTPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		ecKey, ok := privateKey.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, errors.New("jwt: ES256 requires a P-256 EC private key")
+		}
+		digest := sha256.Sum256(input)
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed-size R || S encoding rather than ASN.1
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature, nil
+
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q (use %s)", algorithm, strings.Join(JWTAlgorithms, ", "))
+	}
+}
+
+// loadJWTPrivateKey parses a PEM private key given inline or as a file path
+func loadJWTPrivateKey(key string) (crypto.Signer, error) {
+	data := []byte(key)
+	if !strings.Contains(key, "-----BEGIN") {
+		path := key
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: failed to read key file: %w", err)
+		}
+		data = content
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: signing key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: failed to parse private key: %w", err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("jwt: unsupported private key type")
+		}
+		return signer, nil
+	}
+}
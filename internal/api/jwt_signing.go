@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// jwtTimeClaims change with every token, so comparisons leave them out
+var jwtTimeClaims = []string{"iat", "exp", "nbf", "jti"}
+
+// JWTSigning is every step of signing a JWT, from the JSON header and claims to the
+// token sent
+type JWTSigning struct {
+	Algorithm    string
+	Header       string // JSON header
+	Claims       string // JSON claims, with the generated iat and exp
+	SigningInput string // base64url(header) "." base64url(claims), the string signed
+	Signature    []byte
+	Token        string
+	Check        *JWTCheck // Comparison with a token set by Compare, nil until then
+
+	key string
+}
+
+// JWTCheck compares a token, as issued or expected by a server, with the token the
+// request signs
+type JWTCheck struct {
+	Token       string
+	Header      string   // JSON header of the token
+	Claims      string   // JSON claims of the token
+	Valid       bool     // Whether the request's key verifies the token's signature
+	Differences []string // Header fields and claims differing from the request's, time claims aside
+}
+
+// Digest returns the hex SHA-256 digest of the signing input that RS256 and ES256 sign,
+// "" for HS256 where the HMAC is the signature
+func (s *JWTSigning) Digest() string {
+	if s.Algorithm == JWTAlgorithmHS256 {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(s.SigningInput))
+	return hex.EncodeToString(digest[:])
+}
+
+// KeyInfo describes the signing key without revealing it
+func (s *JWTSigning) KeyInfo() string {
+	if s.Algorithm == JWTAlgorithmHS256 {
+		return fmt.Sprintf("HMAC secret, %d bytes", len(s.key))
+	}
+	key, err := loadJWTPrivateKey(s.key)
+	if err != nil {
+		return err.Error()
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("RSA private key, %d bits", k.N.BitLen())
+	case *ecdsa.PrivateKey:
+		return fmt.Sprintf("EC private key, %s", k.Curve.Params().Name)
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
+
+// Compare checks token against the signing: whether the signing key verifies its
+// signature and which header fields and claims differ. The result is kept as Check.
+func (s *JWTSigning) Compare(token string) (*JWTCheck, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt: token must have three dot-separated parts")
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid token header: %w", err)
+	}
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid token claims: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid token signature: %w", err)
+	}
+
+	check := &JWTCheck{Token: strings.Join(parts, "."), Header: string(header), Claims: string(claims)}
+	headerDiffs, err := jsonFieldDifferences("header", []byte(s.Header), header, nil)
+	if err != nil {
+		return nil, err
+	}
+	claimDiffs, err := jsonFieldDifferences("claim", []byte(s.Claims), claims, jwtTimeClaims)
+	if err != nil {
+		return nil, err
+	}
+	check.Differences = append(headerDiffs, claimDiffs...)
+	check.Valid = verifyJWT(s.Algorithm, s.key, []byte(parts[0]+"."+parts[1]), signature)
+
+	s.Check = check
+	return check, nil
+}
+
+// Text returns the signing steps as plain text, followed by the comparison if any
+func (s *JWTSigning) Text() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("JWT signing (%s)\n\n", s.Algorithm))
+	sb.WriteString(fmt.Sprintf("Header:        %s\n", s.Header))
+	sb.WriteString(fmt.Sprintf("Claims:        %s\n", s.Claims))
+	sb.WriteString(fmt.Sprintf("Signing input: %s\n", s.SigningInput))
+	if digest := s.Digest(); digest != "" {
+		sb.WriteString(fmt.Sprintf("SHA-256:       %s\n", digest))
+	}
+	sb.WriteString(fmt.Sprintf("Key:           %s\n", s.KeyInfo()))
+	sb.WriteString(fmt.Sprintf("Signature:     %s\n", hex.EncodeToString(s.Signature)))
+	sb.WriteString(fmt.Sprintf("Token:         %s\n", s.Token))
+
+	if c := s.Check; c != nil {
+		sb.WriteString("\nCompared token\n\n")
+		sb.WriteString(fmt.Sprintf("Header:        %s\n", c.Header))
+		sb.WriteString(fmt.Sprintf("Claims:        %s\n", c.Claims))
+		sb.WriteString(fmt.Sprintf("Signature:     %s\n", c.Summary()))
+		for _, diff := range c.Differences {
+			sb.WriteString("- " + diff + "\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Summary tells whether the request's key verifies the token's signature
+func (c *JWTCheck) Summary() string {
+	if c.Valid {
+		return "verified by the request's key"
+	}
+	return "not verified by the request's key"
+}
+
+// verifyJWT reports whether key verifies signature over input with algorithm
+func verifyJWT(algorithm, key string, input, signature []byte) bool {
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(input)
+		return hmac.Equal(mac.Sum(nil), signature)
+
+	case JWTAlgorithmRS256:
+		privateKey, err := loadJWTPrivateKey(key)
+		if err != nil {
+			return false
+		}
+		rsaKey, ok := privateKey.(*rsa.PrivateKey)
+		if !ok {
+			return false
+		}
+		digest := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature) == nil
+
+	case JWTAlgorithmES256:
+		privateKey, err := loadJWTPrivateKey(key)
+		if err != nil {
+			return false
+		}
+		ecKey, ok := privateKey.(*ecdsa.PrivateKey)
+		if !ok || len(signature) != 64 {
+			return false
+		}
+		digest := sha256.Sum256(input)
+		r := new(big.Int).SetBytes(signature[:32])
+		sig := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(&ecKey.PublicKey, digest[:], r, sig)
+	}
+	return false
+}
+
+// jsonFieldDifferences lists the fields of the JSON objects signed and token that
+// differ, by name, leaving out the fields in skip
+func jsonFieldDifferences(kind string, signed, token []byte, skip []string) ([]string, error) {
+	var signedFields, tokenFields map[string]json.RawMessage
+	if err := json.Unmarshal(signed, &signedFields); err != nil {
+		return nil, fmt.Errorf("jwt: invalid %s: %w", kind, err)
+	}
+	if err := json.Unmarshal(token, &tokenFields); err != nil {
+		return nil, fmt.Errorf("jwt: token %s is not a JSON object", kind)
+	}
+
+	var names []string
+	for name := range signedFields {
+		names = append(names, name)
+	}
+	for name := range tokenFields {
+		if _, ok := signedFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var diffs []string
+	for _, name := range names {
+		if slices.Contains(skip, name) {
+			continue
+		}
+		signedValue, inSigned := signedFields[name]
+		tokenValue, inToken := tokenFields[name]
+		switch {
+		case !inSigned:
+			diffs = append(diffs, fmt.Sprintf("%s %q: %s in the token, not signed by the request", kind, name, compactJSON(tokenValue)))
+		case !inToken:
+			diffs = append(diffs, fmt.Sprintf("%s %q: %s signed by the request, not in the token", kind, name, compactJSON(signedValue)))
+		case compactJSON(signedValue) != compactJSON(tokenValue):
+			diffs = append(diffs, fmt.Sprintf("%s %q: %s in the token, %s signed by the request", kind, name, compactJSON(tokenValue), compactJSON(signedValue)))
+		}
+	}
+	return diffs, nil
+}
+
+// compactJSON returns value without insignificant whitespace
+func compactJSON(value json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return string(value)
+	}
+	return buf.String()
+}
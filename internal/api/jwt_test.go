@@ -0,0 +1,234 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateJWT_HS256KnownVector(t *testing.T) {
+	token, err := GenerateJWT(JWTOptions{
+		Algorithm: "HS256",
+		Key:       "your-256-bit-secret",
+		Claims:    `{"sub": "1234567890", "name": "John Doe", "iat": 1516239022}`,
+	})
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	want := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ." +
+		"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+	if token != want {
+		t.Errorf("GenerateJWT() = %s, want %s", token, want)
+	}
+}
+
+func TestGenerateJWT_GeneratedClaims(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name      string
+		claims    string
+		expiresIn time.Duration
+		want      map[string]float64
+	}{
+		{name: "empty template gets iat", claims: "", want: map[string]float64{"iat": 1700000000}},
+		{name: "exp from expires in", claims: `{"sub":"svc"}`, expiresIn: 5 * time.Minute, want: map[string]float64{"iat": 1700000000, "exp": 1700000300}},
+		{name: "template iat wins", claims: `{"iat": 1}`, want: map[string]float64{"iat": 1}},
+		{name: "template exp wins", claims: `{"exp": 2}`, expiresIn: time.Hour, want: map[string]float64{"iat": 1700000000, "exp": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateJWT(JWTOptions{Key: "secret", Claims: tt.claims, ExpiresIn: tt.expiresIn, Now: now})
+			if err != nil {
+				t.Fatalf("GenerateJWT() error = %v", err)
+			}
+			claims := decodeJWTPart(t, strings.Split(token, ".")[1])
+			for key, want := range tt.want {
+				if got, _ := claims[key].(float64); got != want {
+					t.Errorf("claim %s = %v, want %v", key, claims[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateJWT_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts JWTOptions
+	}{
+		{name: "empty key", opts: JWTOptions{Algorithm: "HS256"}},
+		{name: "claims not an object", opts: JWTOptions{Key: "secret", Claims: `["a"]`}},
+		{name: "claims null", opts: JWTOptions{Key: "secret", Claims: `null`}},
+		{name: "unsupported algorithm", opts: JWTOptions{Algorithm: "none", Key: "secret"}},
+		{name: "RS256 with missing key file", opts: JWTOptions{Algorithm: "RS256", Key: "/nonexistent/key.pem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateJWT(tt.opts); err == nil {
+				t.Error("GenerateJWT() expected error")
+			}
+		})
+	}
+}
+
+func TestGenerateJWT_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	// Key given as a file path
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := GenerateJWT(JWTOptions{Algorithm: "RS256", Key: path, Claims: `{"sub":"svc"}`})
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if alg := decodeJWTPart(t, parts[0])["alg"]; alg != "RS256" {
+		t.Errorf("alg = %v, want RS256", alg)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature verification failed: %v", err)
+	}
+}
+
+func TestGenerateJWT_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	token, err := GenerateJWT(JWTOptions{Algorithm: "es256", Key: keyPEM})
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	if len(signature) != 64 {
+		t.Fatalf("signature length = %d, want 64", len(signature))
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		t.Error("signature verification failed")
+	}
+
+	// An RSA algorithm with an EC key is rejected
+	if _, err := GenerateJWT(JWTOptions{Algorithm: "RS256", Key: keyPEM}); err == nil {
+		t.Error("GenerateJWT() expected error for RS256 with EC key")
+	}
+}
+
+func decodeJWTPart(t *testing.T, part string) map[string]interface{} {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatalf("invalid base64url segment: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON segment: %v", err)
+	}
+	return out
+}
+
+func TestSignJWT_Compare(t *testing.T) {
+	now := time.Unix(1516239022, 0)
+	signing, err := SignJWT(JWTOptions{Key: "your-256-bit-secret", Claims: `{"sub": "1234567890", "name": "John Doe"}`, Now: now})
+	if err != nil {
+		t.Fatalf("SignJWT() error = %v", err)
+	}
+	if signing.SigningInput != "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ" {
+		t.Errorf("SigningInput = %s", signing.SigningInput)
+	}
+	if signing.Digest() != "" || signing.KeyInfo() != "HMAC secret, 19 bytes" {
+		t.Errorf("Digest() = %q, KeyInfo() = %q", signing.Digest(), signing.KeyInfo())
+	}
+
+	// The same claims signed later verify, time claims aside
+	later, _ := GenerateJWT(JWTOptions{Key: "your-256-bit-secret", Claims: `{"sub":"1234567890","name":"John Doe"}`})
+	if _, err := signing.Compare("Bearer " + later); err == nil {
+		t.Error("Compare() should reject a token with a prefix")
+	}
+	check, err := signing.Compare(later)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !check.Valid || len(check.Differences) != 0 {
+		t.Errorf("check = %+v, want verified without differences", check)
+	}
+
+	other, _ := GenerateJWT(JWTOptions{Key: "other-secret", Claims: `{"sub":"42","scope":"read"}`})
+	check, err = signing.Compare(other)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	want := []string{
+		`claim "name": "John Doe" signed by the request, not in the token`,
+		`claim "scope": "read" in the token, not signed by the request`,
+		`claim "sub": "42" in the token, "1234567890" signed by the request`,
+	}
+	if check.Valid || strings.Join(check.Differences, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Differences = %q, Valid = %v", check.Differences, check.Valid)
+	}
+	if text := signing.Text(); !strings.Contains(text, "Signing input: "+signing.SigningInput) || !strings.Contains(text, "not verified by the request's key") {
+		t.Errorf("Text() = %s", text)
+	}
+}
+
+func TestSignJWT_CompareES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalECPrivateKey(key)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	signing, err := SignJWT(JWTOptions{Algorithm: "ES256", Key: keyPEM})
+	if err != nil {
+		t.Fatalf("SignJWT() error = %v", err)
+	}
+	if len(signing.Digest()) != 64 || signing.KeyInfo() != "EC private key, P-256" {
+		t.Errorf("Digest() = %q, KeyInfo() = %q", signing.Digest(), signing.KeyInfo())
+	}
+	check, err := signing.Compare(signing.Token)
+	if err != nil || !check.Valid {
+		t.Errorf("the signed token should verify, got %+v, %v", check, err)
+	}
+	if _, err := signing.Compare("abc"); err == nil {
+		t.Error("Compare() should reject a malformed token")
+	}
+}
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintSeverity is how a lint rule reports its findings
+type LintSeverity int
+
+const (
+	LintOff LintSeverity = iota
+	LintWarning
+	LintError
+)
+
+// String returns the config name of the severity
+func (s LintSeverity) String() string {
+	switch s {
+	case LintError:
+		return "error"
+	case LintWarning:
+		return "warning"
+	default:
+		return "off"
+	}
+}
+
+// ParseLintSeverity parses a severity: "error", "warning" (or "warn") or "off"
+func ParseLintSeverity(text string) (LintSeverity, error) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "error":
+		return LintError, nil
+	case "warning", "warn":
+		return LintWarning, nil
+	case "off":
+		return LintOff, nil
+	}
+	return LintOff, fmt.Errorf("invalid severity %q (error, warning or off)", text)
+}
+
+// Lint rule IDs
+const (
+	LintUniqueNames    = "unique-names"
+	LintBaseURL        = "base-url"
+	LintHardcodedToken = "hardcoded-token"
+	LintContentType    = "content-type"
+)
+
+// DefaultBaseURLVariable is the variable request URLs start with unless configured
+const DefaultBaseURLVariable = "base_url"
+
+// LintRule is a check of the requests of collections
+type LintRule struct {
+	ID          string
+	Description string
+	Default     LintSeverity
+	check       func(l *linter, req *CollectionRequest) []string
+}
+
+// LintRules lists the lint rules, in the order their findings are reported
+var LintRules = []LintRule{
+	{ID: LintUniqueNames, Description: "Requests of a folder have distinct names", Default: LintError},
+	{ID: LintBaseURL, Description: "URLs start with the base URL variable", Default: LintWarning, check: (*linter).checkBaseURL},
+	{ID: LintHardcodedToken, Description: "Tokens, passwords and API keys come from variables", Default: LintError, check: (*linter).checkHardcodedToken},
+	{ID: LintContentType, Description: "The Content-Type header matches the body type", Default: LintWarning, check: (*linter).checkContentType},
+}
+
+// LintOptions configures Lint
+type LintOptions struct {
+	Rules           map[string]string // Severity of rules by ID ("error", "warning" or "off"), overriding their default
+	BaseURLVariable string            // Variable URLs start with (default DefaultBaseURLVariable)
+}
+
+// LintFinding is a problem found in a request
+type LintFinding struct {
+	Rule       string
+	Severity   LintSeverity
+	Collection string
+	Request    string // Folder path and name of the request, such as "Users / List users"
+	RequestID  string
+	Message    string
+}
+
+// String describes the finding on one line
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%-7s %s › %s: %s (%s)", f.Severity, f.Collection, f.Request, f.Message, f.Rule)
+}
+
+// LintReport is the result of Lint
+type LintReport struct {
+	Collections int
+	Requests    int
+	Findings    []LintFinding
+}
+
+// Count returns the number of findings of a severity
+func (r *LintReport) Count(severity LintSeverity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// OK returns true if no finding is an error
+func (r *LintReport) OK() bool {
+	return r.Count(LintError) == 0
+}
+
+// Summary describes the outcome in one line
+func (r *LintReport) Summary() string {
+	if len(r.Findings) == 0 {
+		return fmt.Sprintf("%d requests in %d collections, no problems", r.Requests, r.Collections)
+	}
+	return fmt.Sprintf("%d requests in %d collections: %d errors, %d warnings",
+		r.Requests, r.Collections, r.Count(LintError), r.Count(LintWarning))
+}
+
+// Text returns the report as plain text, one finding per line
+func (r *LintReport) Text() string {
+	var sb strings.Builder
+	for _, finding := range r.Findings {
+		sb.WriteString(finding.String())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(r.Summary())
+	return sb.String()
+}
+
+// linter holds the settings of a lint run
+type linter struct {
+	severity map[string]LintSeverity
+	baseURL  string
+	report   *LintReport
+}
+
+// Lint checks the requests of collections against the lint rules. Linked requests are
+// checked where their content is defined. Fails when the options name an unknown rule
+// or severity.
+func Lint(collections []*CollectionFile, opts LintOptions) (*LintReport, error) {
+	l := &linter{
+		severity: make(map[string]LintSeverity, len(LintRules)),
+		baseURL:  opts.BaseURLVariable,
+		report:   &LintReport{Collections: len(collections)},
+	}
+	if l.baseURL == "" {
+		l.baseURL = DefaultBaseURLVariable
+	}
+	for _, rule := range LintRules {
+		l.severity[rule.ID] = rule.Default
+	}
+	ids := make([]string, 0, len(opts.Rules))
+	for id := range opts.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := l.severity[id]; !ok {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+		severity, err := ParseLintSeverity(opts.Rules[id])
+		if err != nil {
+			return nil, fmt.Errorf("lint rule %s: %w", id, err)
+		}
+		l.severity[id] = severity
+	}
+
+	for _, col := range collections {
+		l.lintFolder(col.Name, nil, col.Requests, col.Folders)
+	}
+	return l.report, nil
+}
+
+// lintFolder checks the requests of a folder, then its subfolders
+func (l *linter) lintFolder(collection string, path []string, requests []CollectionRequest, folders []Folder) {
+	names := make(map[string]bool, len(requests))
+	for i := range requests {
+		req := &requests[i]
+		l.report.Requests++
+		add := func(rule, message string) {
+			severity := l.severity[rule]
+			if severity == LintOff {
+				return
+			}
+			l.report.Findings = append(l.report.Findings, LintFinding{
+				Rule:       rule,
+				Severity:   severity,
+				Collection: collection,
+				Request:    strings.Join(append(append([]string{}, path...), req.Name), " / "),
+				RequestID:  req.ID,
+				Message:    message,
+			})
+		}
+
+		name := strings.ToLower(strings.TrimSpace(req.Name))
+		if names[name] {
+			add(LintUniqueNames, fmt.Sprintf("another request of the folder is named %q", req.Name))
+		}
+		names[name] = true
+
+		if req.Link != "" {
+			continue
+		}
+		for _, rule := range LintRules {
+			if rule.check == nil {
+				continue
+			}
+			for _, message := range rule.check(l, req) {
+				add(rule.ID, message)
+			}
+		}
+	}
+	for _, folder := range folders {
+		l.lintFolder(collection, append(append([]string{}, path...), folder.Name), folder.Requests, folder.Folders)
+	}
+}
+
+// checkBaseURL reports URLs that do not start with the base URL variable
+func (l *linter) checkBaseURL(req *CollectionRequest) []string {
+	prefix := "{{" + l.baseURL + "}}"
+	if strings.HasPrefix(strings.TrimSpace(req.URL), prefix) {
+		return nil
+	}
+	return []string{"URL does not start with " + prefix}
+}
+
+// jwtPattern matches JSON Web Tokens written out in full
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.`)
+
+// sensitiveNames are fragments of the names of headers and query parameters holding
+// credentials
+var sensitiveNames = []string{"authorization", "token", "secret", "password", "apikey", "api-key", "api_key", "cookie"}
+
+// isSensitiveName reports whether a header or parameter name holds credentials
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range sensitiveNames {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHardcoded reports whether a credential is written out instead of read from a variable
+func isHardcoded(value string) bool {
+	return strings.TrimSpace(value) != "" && !strings.Contains(value, "{{")
+}
+
+// checkHardcodedToken reports credentials written out in the auth settings, the
+// headers, the query parameters or the URL
+func (l *linter) checkHardcodedToken(req *CollectionRequest) []string {
+	var messages []string
+	if auth := req.Auth; auth != nil {
+		switch auth.Type {
+		case "bearer":
+			if isHardcoded(auth.Token) {
+				messages = append(messages, "bearer token is hardcoded")
+			}
+		case "basic":
+			if isHardcoded(auth.Password) {
+				messages = append(messages, "basic auth password is hardcoded")
+			}
+		case "api_key":
+			if isHardcoded(auth.APIKeyValue) {
+				messages = append(messages, "API key is hardcoded")
+			}
+		}
+	}
+
+	check := func(kind, name, value string) {
+		switch {
+		case isSensitiveName(name) && isHardcoded(value):
+			messages = append(messages, fmt.Sprintf("%s %q is hardcoded", kind, name))
+		case jwtPattern.MatchString(value):
+			messages = append(messages, fmt.Sprintf("%s %q holds a JWT", kind, name))
+		}
+	}
+	for _, header := range requestHeaders(req) {
+		check("header", header.Key, header.Value)
+	}
+	for _, param := range req.Params {
+		if param.Enabled {
+			check("query parameter", param.Key, param.Value)
+		}
+	}
+	if jwtPattern.MatchString(req.URL) {
+		messages = append(messages, "URL holds a JWT")
+	}
+	return messages
+}
+
+// bodyMediaTypes are the Content-Type media types matching each body type; body types
+// not listed accept any
+var bodyMediaTypes = map[string][]string{
+	"json":           {"application/json"},
+	BodyTypeGraphQL:  {"application/json", "application/graphql"},
+	BodyTypeFormData: {"multipart/form-data"},
+	"msgpack":        {"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"},
+	"cbor":           {"application/cbor"},
+}
+
+// checkContentType reports a Content-Type header that does not match the body type
+func (l *linter) checkContentType(req *CollectionRequest) []string {
+	if req.Body == nil {
+		return nil
+	}
+	expected, ok := bodyMediaTypes[req.Body.Type]
+	if !ok {
+		return nil
+	}
+	for _, header := range requestHeaders(req) {
+		if !strings.EqualFold(header.Key, "Content-Type") || strings.Contains(header.Value, "{{") {
+			continue
+		}
+		mediaType, _, err := mime.ParseMediaType(header.Value)
+		if err != nil {
+			mediaType = strings.ToLower(strings.TrimSpace(header.Value))
+		}
+		for _, want := range expected {
+			if mediaType == want || (want == "application/json" && strings.HasSuffix(mediaType, "+json")) {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("Content-Type %q does not match the %s body (%s)", header.Value, req.Body.Type, strings.Join(expected, " or "))}
+	}
+	return nil
+}
+
+// requestHeaders returns the enabled headers of a request, legacy ones included
+func requestHeaders(req *CollectionRequest) []KeyValueEntry {
+	var headers []KeyValueEntry
+	for _, header := range req.Headers {
+		if header.Enabled {
+			headers = append(headers, header)
+		}
+	}
+	keys := make([]string, 0, len(req.HeadersMap))
+	for key := range req.HeadersMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		headers = append(headers, KeyValueEntry{Key: key, Value: req.HeadersMap[key], Enabled: true})
+	}
+	return headers
+}
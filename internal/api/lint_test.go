@@ -0,0 +1,106 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func lintCollection() *CollectionFile {
+	return &CollectionFile{
+		Name: "Shop",
+		Folders: []Folder{{
+			Name: "Users",
+			Requests: []CollectionRequest{
+				{ID: "list", Name: "List", Method: GET, URL: "{{base_url}}/users",
+					Headers: []KeyValueEntry{{Key: "Authorization", Value: "Bearer {{token}}", Enabled: true}}},
+				{ID: "list2", Name: "list ", Method: GET, URL: "https://api.example.com/users",
+					Params: []KeyValueEntry{{Key: "api_key", Value: "abc123", Enabled: true}}},
+				{ID: "create", Name: "Create", Method: POST, URL: "{{base_url}}/users",
+					Headers: []KeyValueEntry{{Key: "Content-Type", Value: "text/plain", Enabled: true}},
+					Body:    &BodyConfig{Type: "json", Content: map[string]interface{}{"name": "Ada"}},
+					Auth:    &AuthConfig{Type: "bearer", Token: "s3cr3t"}},
+				{ID: "linked", Name: "Linked", Link: "list2"},
+			},
+		}},
+		Requests: []CollectionRequest{
+			// Same name as a request of Users, in another folder
+			{ID: "root-list", Name: "List", Method: GET, URL: "{{base_url}}/health",
+				HeadersMap: map[string]string{"Content-Type": "application/problem+json"},
+				Body:       &BodyConfig{Type: "json", Content: "{}"}},
+		},
+	}
+}
+
+func TestLint(t *testing.T) {
+	report, err := Lint([]*CollectionFile{lintCollection()}, LintOptions{})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range report.Findings {
+		got = append(got, finding.Severity.String()+" "+finding.Request+" "+finding.Rule+": "+finding.Message)
+	}
+	want := []string{
+		`error Users / list  unique-names: another request of the folder is named "list "`,
+		`warning Users / list  base-url: URL does not start with {{base_url}}`,
+		`error Users / list  hardcoded-token: query parameter "api_key" is hardcoded`,
+		`error Users / Create hardcoded-token: bearer token is hardcoded`,
+		`warning Users / Create content-type: Content-Type "text/plain" does not match the json body (application/json)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if report.Requests != 5 || report.OK() || report.Count(LintWarning) != 2 {
+		t.Errorf("report = %d requests, ok %v, %d warnings", report.Requests, report.OK(), report.Count(LintWarning))
+	}
+	if summary := report.Summary(); summary != "5 requests in 1 collections: 3 errors, 2 warnings" {
+		t.Errorf("Summary() = %q", summary)
+	}
+}
+
+func TestLint_Options(t *testing.T) {
+	report, err := Lint([]*CollectionFile{lintCollection()}, LintOptions{
+		Rules:           map[string]string{LintHardcodedToken: "warn", LintUniqueNames: "off", LintContentType: "OFF"},
+		BaseURLVariable: "api",
+	})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !report.OK() || report.Count(LintWarning) != 6 {
+		t.Errorf("findings = %v, want 4 base-url and 2 hardcoded-token warnings", report.Findings)
+	}
+
+	if _, err := Lint(nil, LintOptions{Rules: map[string]string{"no-such-rule": "error"}}); err == nil {
+		t.Error("Lint() should fail for an unknown rule")
+	}
+	if _, err := Lint(nil, LintOptions{Rules: map[string]string{LintBaseURL: "fatal"}}); err == nil {
+		t.Error("Lint() should fail for an unknown severity")
+	}
+}
+
+func TestLint_HardcodedToken(t *testing.T) {
+	tests := []struct {
+		name string
+		req  CollectionRequest
+		want string
+	}{
+		{name: "basic password", req: CollectionRequest{Auth: &AuthConfig{Type: "basic", Username: "ada", Password: "hunter2"}}, want: "basic auth password is hardcoded"},
+		{name: "api key", req: CollectionRequest{Auth: &AuthConfig{Type: "api_key", APIKeyName: "X-Key", APIKeyValue: "k"}}, want: "API key is hardcoded"},
+		{name: "api key header", req: CollectionRequest{Headers: []KeyValueEntry{{Key: "X-API-Key", Value: "k", Enabled: true}}}, want: `header "X-API-Key" is hardcoded`},
+		{name: "JWT in any header", req: CollectionRequest{Headers: []KeyValueEntry{{Key: "X-Forwarded", Value: "eyJhbGciOi.eyJzdWIiOi.sig", Enabled: true}}}, want: `header "X-Forwarded" holds a JWT`},
+		{name: "JWT in URL", req: CollectionRequest{URL: "{{base_url}}/?t=eyJhbGciOi.eyJzdWIiOi.sig"}, want: "URL holds a JWT"},
+		{name: "variable", req: CollectionRequest{Auth: &AuthConfig{Type: "bearer", Token: "{{token}}"}, Headers: []KeyValueEntry{{Key: "X-API-Key", Value: "{{key}}", Enabled: true}}}},
+		{name: "disabled header", req: CollectionRequest{Headers: []KeyValueEntry{{Key: "Authorization", Value: "Bearer abc", Enabled: false}}}},
+	}
+
+	l := &linter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(l.checkHardcodedToken(&tt.req), "; ")
+			if got != tt.want {
+				t.Errorf("checkHardcodedToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// MockRule answers a request with a canned response when mock mode is enabled.
+// Rules are stored on the request and evaluated in order; the first match wins.
+type MockRule struct {
+	Name     string       `json:"name,omitempty"`
+	Disabled bool         `json:"disabled,omitempty"`
+	Match    MockMatch    `json:"match,omitempty"`
+	Response MockResponse `json:"response"`
+}
+
+// MockMatch lists the conditions a request must meet. Empty conditions match anything.
+type MockMatch struct {
+	Method       string            `json:"method,omitempty"`        // HTTP method (case-insensitive)
+	Path         string            `json:"path,omitempty"`          // URL path, supports path.Match wildcards (e.g. "/users/*")
+	Query        map[string]string `json:"query,omitempty"`         // Required query values ("*" = present)
+	Headers      map[string]string `json:"headers,omitempty"`       // Required header values ("*" = present)
+	BodyContains string            `json:"body_contains,omitempty"` // Substring of the request body
+}
+
+// MockResponse is the canned response returned by a matching rule
+type MockResponse struct {
+	Status  int               `json:"status,omitempty"`  // Default: 200
+	Headers map[string]string `json:"headers,omitempty"` // Response headers
+	Body    interface{}       `json:"body,omitempty"`    // Strings are returned as-is, anything else as JSON
+	Delay   string            `json:"delay,omitempty"`   // Simulated latency (e.g. "250ms")
+}
+
+// Label returns the rule name, or a description of its match conditions
+func (r *MockRule) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	method := r.Match.Method
+	if method == "" {
+		method = "*"
+	}
+	target := r.Match.Path
+	if target == "" {
+		target = "*"
+	}
+	return strings.ToUpper(method) + " " + target
+}
+
+// FindMockRule returns the first enabled rule matching req, or nil
+func FindMockRule(rules []MockRule, req *Request) *MockRule {
+	if req == nil {
+		return nil
+	}
+	for i := range rules {
+		if !rules[i].Disabled && rules[i].Match.Matches(req) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// Matches reports whether req satisfies every condition
+func (m MockMatch) Matches(req *Request) bool {
+	if m.Method != "" && !strings.EqualFold(m.Method, string(req.Method)) {
+		return false
+	}
+
+	if m.Path != "" || len(m.Query) > 0 {
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			return false
+		}
+		if m.Path != "" {
+			requestPath := u.Path
+			if requestPath == "" {
+				requestPath = "/"
+			}
+			if ok, err := path.Match(m.Path, requestPath); err != nil || !ok {
+				return false
+			}
+		}
+		query := u.Query()
+		for key, want := range m.Query {
+			if !query.Has(key) || (want != "*" && query.Get(key) != want) {
+				return false
+			}
+		}
+	}
+
+	for key, want := range m.Headers {
+		value, ok := lookupHeader(req.Headers, key)
+		if !ok || (want != "*" && value != want) {
+			return false
+		}
+	}
+
+	if m.BodyContains != "" {
+		if req.Body == nil {
+			return false
+		}
+		body, _, err := encodeRequestBody(req.Body)
+		if err != nil || !strings.Contains(string(body), m.BodyContains) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupHeader finds a header value by case-insensitive name
+func lookupHeader(headers map[string]string, key string) (string, bool) {
+	for name, value := range headers {
+		if strings.EqualFold(name, key) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// DelayDuration parses the simulated latency (zero when unset)
+func (r MockResponse) DelayDuration() (time.Duration, error) {
+	if r.Delay == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(r.Delay)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("mock: invalid delay %q", r.Delay)
+	}
+	return delay, nil
+}
+
+// Build converts the canned response into a Response as returned by Client.Send
+func (r MockResponse) Build() (*Response, error) {
+	delay, err := r.DelayDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	headers := make(map[string][]string)
+	for key, value := range r.Headers {
+		headers[http.CanonicalHeaderKey(key)] = []string{value}
+	}
+
+	var body []byte
+	switch b := r.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(b)
+	default:
+		body, err = json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("mock: invalid body: %w", err)
+		}
+		if _, ok := headers["Content-Type"]; !ok {
+			headers["Content-Type"] = []string{"application/json"}
+		}
+	}
+
+	return &Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Headers:    headers,
+		Body:       body,
+		Time:       delay,
+		Size:       int64(len(body)),
+	}, nil
+}
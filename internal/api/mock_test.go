@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMockMatch_Matches(t *testing.T) {
+	req := &Request{
+		Method:  POST,
+		URL:     "https://api.test/users/42?expand=team&debug=1",
+		Headers: map[string]string{"Authorization": "Bearer abc"},
+		Body:    map[string]interface{}{"name": "Ada"},
+	}
+
+	tests := []struct {
+		name  string
+		match MockMatch
+		want  bool
+	}{
+		{name: "empty matches anything", match: MockMatch{}, want: true},
+		{name: "method case-insensitive", match: MockMatch{Method: "post"}, want: true},
+		{name: "method mismatch", match: MockMatch{Method: "GET"}, want: false},
+		{name: "exact path", match: MockMatch{Path: "/users/42"}, want: true},
+		{name: "wildcard path", match: MockMatch{Path: "/users/*"}, want: true},
+		{name: "path mismatch", match: MockMatch{Path: "/teams/*"}, want: false},
+		{name: "query value", match: MockMatch{Query: map[string]string{"expand": "team"}}, want: true},
+		{name: "query present", match: MockMatch{Query: map[string]string{"debug": "*"}}, want: true},
+		{name: "query missing", match: MockMatch{Query: map[string]string{"page": "*"}}, want: false},
+		{name: "header case-insensitive name", match: MockMatch{Headers: map[string]string{"authorization": "Bearer abc"}}, want: true},
+		{name: "header value mismatch", match: MockMatch{Headers: map[string]string{"Authorization": "Bearer xyz"}}, want: false},
+		{name: "header present", match: MockMatch{Headers: map[string]string{"Authorization": "*"}}, want: true},
+		{name: "body contains", match: MockMatch{BodyContains: `"name":"Ada"`}, want: true},
+		{name: "body does not contain", match: MockMatch{BodyContains: "Grace"}, want: false},
+		{name: "all conditions", match: MockMatch{Method: "POST", Path: "/users/*", BodyContains: "Ada"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.Matches(req); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMockRule(t *testing.T) {
+	rules := []MockRule{
+		{Name: "disabled", Disabled: true, Response: MockResponse{Status: 500}},
+		{Name: "not found", Match: MockMatch{Path: "/users/0"}, Response: MockResponse{Status: 404}},
+		{Name: "ok", Match: MockMatch{Method: "GET"}, Response: MockResponse{Status: 200}},
+	}
+
+	tests := []struct {
+		name string
+		req  *Request
+		want string
+	}{
+		{name: "first match wins", req: &Request{Method: GET, URL: "https://api.test/users/0"}, want: "not found"},
+		{name: "fallback rule", req: &Request{Method: GET, URL: "https://api.test/users/1"}, want: "ok"},
+		{name: "no match", req: &Request{Method: DELETE, URL: "https://api.test/users/1"}, want: ""},
+		{name: "nil request", req: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := FindMockRule(rules, tt.req)
+			got := ""
+			if rule != nil {
+				got = rule.Name
+			}
+			if got != tt.want {
+				t.Errorf("FindMockRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockRule_Label(t *testing.T) {
+	tests := []struct {
+		rule MockRule
+		want string
+	}{
+		{rule: MockRule{Name: "happy path"}, want: "happy path"},
+		{rule: MockRule{Match: MockMatch{Method: "post", Path: "/users"}}, want: "POST /users"},
+		{rule: MockRule{}, want: "* *"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rule.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMockResponse_Build(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   MockResponse
+		wantStatus string
+		wantBody   string
+		wantType   string
+		wantTime   time.Duration
+		wantErr    bool
+	}{
+		{name: "defaults", response: MockResponse{}, wantStatus: "200 OK"},
+		{name: "text body", response: MockResponse{Status: 404, Body: "missing", Headers: map[string]string{"content-type": "text/plain"}}, wantStatus: "404 Not Found", wantBody: "missing", wantType: "text/plain"},
+		{name: "json body", response: MockResponse{Status: 201, Body: map[string]interface{}{"id": 7}}, wantStatus: "201 Created", wantBody: `{"id":7}`, wantType: "application/json"},
+		{name: "delay", response: MockResponse{Delay: "150ms"}, wantStatus: "200 OK", wantTime: 150 * time.Millisecond},
+		{name: "invalid delay", response: MockResponse{Delay: "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.response.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", resp.Status, tt.wantStatus)
+			}
+			if string(resp.Body) != tt.wantBody || resp.Size != int64(len(tt.wantBody)) {
+				t.Errorf("Body = %q (size %d), want %q", resp.Body, resp.Size, tt.wantBody)
+			}
+			if got := resp.Headers["Content-Type"]; tt.wantType != "" && (len(got) != 1 || got[0] != tt.wantType) {
+				t.Errorf("Content-Type = %v, want %q", got, tt.wantType)
+			}
+			if resp.Time != tt.wantTime {
+				t.Errorf("Time = %v, want %v", resp.Time, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestCollectionRequest_MocksRoundTrip(t *testing.T) {
+	data := []byte(`{
+  "id": "req_1",
+  "name": "Get user",
+  "method": "GET",
+  "url": "{{base_url}}/users/1",
+  "mocks": [
+    {"name": "found", "match": {"path": "/users/*"}, "response": {"status": 200, "body": {"id": 1}, "delay": "100ms"}}
+  ]
+}`)
+
+	var req CollectionRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Mocks) != 1 || req.Mocks[0].Name != "found" || req.Mocks[0].Match.Path != "/users/*" || req.Mocks[0].Response.Delay != "100ms" {
+		t.Fatalf("unexpected mocks: %+v", req.Mocks)
+	}
+
+	out, err := json.Marshal(&req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again CollectionRequest
+	if err := json.Unmarshal(out, &again); err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Mocks) != 1 || again.Mocks[0].Response.Status != 200 {
+		t.Errorf("mocks lost in round trip: %s", out)
+	}
+}
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// NetworkErrorKind classifies why a request failed before a response was received
+type NetworkErrorKind int
+
+const (
+	NetworkErrorUnknown NetworkErrorKind = iota
+	NetworkErrorDNS
+	NetworkErrorConnectionRefused
+	NetworkErrorConnectionReset
+	NetworkErrorTimeout
+	NetworkErrorTLSCertificate
+	NetworkErrorTLSHandshake
+	NetworkErrorProxy
+	NetworkErrorInvalidURL
+)
+
+// String returns the display title for the error kind
+func (k NetworkErrorKind) String() string {
+	switch k {
+	case NetworkErrorDNS:
+		return "DNS lookup failed"
+	case NetworkErrorConnectionRefused:
+		return "Connection refused"
+	case NetworkErrorConnectionReset:
+		return "Connection reset"
+	case NetworkErrorTimeout:
+		return "Request timed out"
+	case NetworkErrorTLSCertificate:
+		return "TLS certificate verification failed"
+	case NetworkErrorTLSHandshake:
+		return "TLS handshake failed"
+	case NetworkErrorProxy:
+		return "Proxy connection failed"
+	case NetworkErrorInvalidURL:
+		return "Invalid URL"
+	default:
+		return "Request failed"
+	}
+}
+
+// NetworkError is a structured breakdown of a connection-level failure
+type NetworkError struct {
+	Kind        NetworkErrorKind
+	URL         string   // Request URL
+	Host        string   // Host (and port) the request was sent to
+	Summary     string   // One-sentence explanation of what went wrong
+	Details     string   // Underlying error message
+	Proxy       string   // Proxy in use, if any
+	Suggestions []string // Suggested fixes
+}
+
+// DiagnoseNetworkError classifies a request error into a NetworkError with suggested fixes
+func DiagnoseNetworkError(err error, rawURL string) *NetworkError {
+	if err == nil {
+		return nil
+	}
+
+	ne := &NetworkError{
+		URL:     rawURL,
+		Details: err.Error(),
+	}
+	if u, parseErr := url.Parse(rawURL); parseErr == nil {
+		ne.Host = u.Host
+		ne.Proxy = proxyFor(u)
+	}
+
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	var verifyErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &opErr) && opErr.Op == "proxyconnect":
+		ne.Kind = NetworkErrorProxy
+		ne.Summary = fmt.Sprintf("Could not connect through the proxy %s.", ne.Proxy)
+		ne.Suggestions = []string{
+			"Check that the proxy is running and reachable",
+			"Verify HTTP_PROXY / HTTPS_PROXY / NO_PROXY in your environment",
+			"Unset the proxy variables to connect directly",
+		}
+		if DefaultProxy != nil {
+			ne.Suggestions = []string{
+				"Check that the proxy is running and reachable",
+				"Verify proxy.url and proxy.no_proxy in the global or workspace config",
+				"Set proxy.url to \"\" in the workspace config to connect directly",
+			}
+		}
+
+	case errors.As(err, &dnsErr):
+		ne.Kind = NetworkErrorDNS
+		ne.Summary = fmt.Sprintf("The host name %q could not be resolved.", dnsErr.Name)
+		ne.Suggestions = []string{
+			"Check the host name for typos (and the {{base_url}} variable of the active environment)",
+			"Check your network connection and DNS settings",
+			"If the host is on a private network, connect to the VPN first",
+		}
+		if dnsErr.IsTimeout {
+			ne.Summary = fmt.Sprintf("The DNS lookup for %q timed out.", dnsErr.Name)
+		}
+
+	case errors.Is(err, syscall.ECONNREFUSED):
+		ne.Kind = NetworkErrorConnectionRefused
+		ne.Summary = fmt.Sprintf("Nothing is accepting connections on %s.", ne.Host)
+		ne.Suggestions = []string{
+			"Check that the server is running",
+			"Verify the port in the URL",
+			"Check firewall rules between you and the server",
+		}
+
+	case errors.Is(err, syscall.ECONNRESET):
+		ne.Kind = NetworkErrorConnectionReset
+		ne.Summary = "The server closed the connection unexpectedly."
+		ne.Suggestions = []string{
+			"Check whether the server expects HTTPS instead of HTTP (or the reverse)",
+			"Check server logs for crashes or request size limits",
+			"Retry the request: the failure may be transient",
+		}
+
+	case errors.As(err, &unknownAuthority):
+		ne.Kind = NetworkErrorTLSCertificate
+		ne.Summary = "The server certificate is signed by an unknown authority."
+		ne.Suggestions = []string{
+			"Add the issuing CA to tls.ca_certs in the workspace config (or to your system trust store)",
+			"For self-signed development servers, run :tls insecure on",
+			"Check whether a corporate proxy is intercepting TLS",
+		}
+
+	case errors.As(err, &hostnameErr):
+		ne.Kind = NetworkErrorTLSCertificate
+		ne.Summary = fmt.Sprintf("The server certificate is not valid for %s.", hostnameErr.Host)
+		ne.Suggestions = []string{
+			"Use the host name the certificate was issued for",
+			"Check the certificate's subject alternative names",
+		}
+
+	case errors.As(err, &invalidCert):
+		ne.Kind = NetworkErrorTLSCertificate
+		ne.Summary = "The server certificate is invalid."
+		if invalidCert.Reason == x509.Expired {
+			ne.Summary = "The server certificate has expired or is not yet valid."
+		}
+		ne.Suggestions = []string{
+			"Renew the server certificate",
+			"Check that your system clock is correct",
+		}
+
+	case errors.As(err, &verifyErr):
+		ne.Kind = NetworkErrorTLSCertificate
+		ne.Summary = "The server certificate could not be verified."
+		ne.Suggestions = []string{
+			"Add the issuing CA to tls.ca_certs in the workspace config (or to your system trust store)",
+			"Check that your system clock is correct",
+		}
+
+	case errors.As(err, &recordErr) || strings.Contains(err.Error(), "tls:") ||
+		strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"):
+		ne.Kind = NetworkErrorTLSHandshake
+		ne.Summary = "The TLS handshake with the server failed."
+		ne.Suggestions = []string{
+			"Check whether the server speaks plain HTTP (use http:// instead of https://)",
+			"Check that the server supports TLS 1.2 or later",
+		}
+		if strings.Contains(err.Error(), "certificate required") || strings.Contains(err.Error(), "bad certificate") {
+			ne.Summary = "The server requires a client certificate."
+			ne.Suggestions = []string{
+				"Add a client certificate for the host to tls.client_certs in the workspace config",
+				"Check that the certificate is issued by a CA the server trusts and has not expired",
+			}
+		}
+
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		ne.Kind = NetworkErrorTimeout
+		ne.Summary = "The server did not respond in time."
+		ne.Suggestions = []string{
+			"Check that the server is reachable from your network",
+			"The server may be overloaded: retry later",
+			"Check firewall or proxy settings that may drop traffic silently",
+		}
+
+	case isInvalidURLError(err, rawURL):
+		ne.Kind = NetworkErrorInvalidURL
+		ne.Summary = "The request URL could not be parsed."
+		ne.Suggestions = []string{
+			"Include the scheme (http:// or https://)",
+			"Check for unresolved {{variables}} in the URL",
+		}
+
+	default:
+		ne.Kind = NetworkErrorUnknown
+		ne.Summary = "The request failed before a response was received."
+		ne.Suggestions = []string{
+			"Check your network connection",
+			"Retry the request: the failure may be transient",
+		}
+	}
+
+	if ne.Proxy != "" && ne.Kind != NetworkErrorProxy && ne.Kind != NetworkErrorInvalidURL {
+		ne.Suggestions = append(ne.Suggestions, fmt.Sprintf("Requests go through the proxy %s: check that it can reach the host", ne.Proxy))
+	}
+	if errors.Is(err, ErrChaosInjected) {
+		// Nothing to fix on the network side
+		ne.Summary = "Chaos mode injected this failure. " + ne.Summary
+		ne.Suggestions = []string{"Run :chaos off to send requests normally"}
+	} else if ne.Kind != NetworkErrorInvalidURL {
+		ne.Suggestions = append(ne.Suggestions, "Run :doctor to check DNS, TCP, TLS, proxy and clock step by step")
+	}
+
+	return ne
+}
+
+// Title returns the display title for the error
+func (e *NetworkError) Title() string {
+	return e.Kind.String()
+}
+
+// Error implements the error interface with a one-line description
+func (e *NetworkError) Error() string {
+	if e.Host == "" {
+		return e.Title()
+	}
+	return fmt.Sprintf("%s (%s)", e.Title(), e.Host)
+}
+
+// Text returns the full breakdown as plain text (for clipboard)
+func (e *NetworkError) Text() string {
+	var sb strings.Builder
+	sb.WriteString(e.Title())
+	sb.WriteString("\n")
+	sb.WriteString(e.Summary)
+	sb.WriteString("\n\n")
+	if e.URL != "" {
+		sb.WriteString(fmt.Sprintf("URL: %s\n", e.URL))
+	}
+	if e.Proxy != "" {
+		sb.WriteString(fmt.Sprintf("Proxy: %s\n", e.Proxy))
+	}
+	sb.WriteString(fmt.Sprintf("Error: %s\n", e.Details))
+	if len(e.Suggestions) > 0 {
+		sb.WriteString("\nSuggestions:\n")
+		for _, s := range e.Suggestions {
+			sb.WriteString(fmt.Sprintf("  - %s\n", s))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// isInvalidURLError reports whether err was caused by an unusable request URL
+func isInvalidURLError(err error, rawURL string) bool {
+	if _, parseErr := url.ParseRequestURI(rawURL); parseErr != nil {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "unsupported protocol scheme") || strings.Contains(msg, "no Host in request URL")
+}
+
+// proxyFor returns the proxy configured for a URL's scheme, or "": DefaultProxy when
+// set, otherwise the environment variables
+func proxyFor(u *url.URL) string {
+	if DefaultProxy != nil {
+		return DefaultProxy.Label()
+	}
+	var keys []string
+	if u.Scheme == "https" {
+		keys = []string{"HTTPS_PROXY", "https_proxy"}
+	} else {
+		keys = []string{"HTTP_PROXY", "http_proxy"}
+	}
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
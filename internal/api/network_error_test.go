@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestDiagnoseNetworkError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://example.test", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		url  string
+		want NetworkErrorKind
+	}{
+		{
+			name: "dns not found",
+			err:  wrap(&net.OpError{Op: "dial", Err: &net.DNSError{Name: "api.invalid", Err: "no such host", IsNotFound: true}}),
+			url:  "http://api.invalid/users",
+			want: NetworkErrorDNS,
+		},
+		{
+			name: "connection refused",
+			err:  wrap(&net.OpError{Op: "dial", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}),
+			url:  "http://localhost:1/",
+			want: NetworkErrorConnectionRefused,
+		},
+		{
+			name: "connection reset",
+			err:  wrap(&net.OpError{Op: "read", Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}}),
+			url:  "http://localhost:8080/",
+			want: NetworkErrorConnectionReset,
+		},
+		{
+			name: "deadline exceeded",
+			err:  wrap(context.DeadlineExceeded),
+			url:  "http://example.test/",
+			want: NetworkErrorTimeout,
+		},
+		{
+			name: "unknown authority",
+			err:  wrap(x509.UnknownAuthorityError{}),
+			url:  "https://self-signed.test/",
+			want: NetworkErrorTLSCertificate,
+		},
+		{
+			name: "hostname mismatch",
+			err:  wrap(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "wrong.test"}),
+			url:  "https://wrong.test/",
+			want: NetworkErrorTLSCertificate,
+		},
+		{
+			name: "expired certificate",
+			err:  wrap(x509.CertificateInvalidError{Cert: &x509.Certificate{}, Reason: x509.Expired}),
+			url:  "https://expired.test/",
+			want: NetworkErrorTLSCertificate,
+		},
+		{
+			name: "https to plain http server",
+			err:  wrap(errors.New("http: server gave HTTP response to HTTPS client")),
+			url:  "https://localhost:8080/",
+			want: NetworkErrorTLSHandshake,
+		},
+		{
+			name: "proxy failure",
+			err:  wrap(&net.OpError{Op: "proxyconnect", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}),
+			url:  "https://example.test/",
+			want: NetworkErrorProxy,
+		},
+		{
+			name: "missing scheme",
+			err:  wrap(errors.New("unsupported protocol scheme \"\"")),
+			url:  "example.test/users",
+			want: NetworkErrorInvalidURL,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("something odd"),
+			url:  "http://example.test/",
+			want: NetworkErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiagnoseNetworkError(tt.err, tt.url)
+			if got.Kind != tt.want {
+				t.Errorf("Kind = %v, want %v", got.Kind, tt.want)
+			}
+			if got.Summary == "" || len(got.Suggestions) == 0 {
+				t.Errorf("expected summary and suggestions, got %+v", got)
+			}
+			if got.Details != tt.err.Error() {
+				t.Errorf("Details = %q, want %q", got.Details, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestDiagnoseNetworkError_Nil(t *testing.T) {
+	if got := DiagnoseNetworkError(nil, "http://example.test"); got != nil {
+		t.Errorf("DiagnoseNetworkError(nil) = %+v, want nil", got)
+	}
+}
+
+func TestDiagnoseNetworkError_RealFailures(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("http_proxy", "")
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+
+	// A closed server refuses connections
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	_, err := NewClient().Send(&Request{Method: GET, URL: closedURL})
+	if got := DiagnoseNetworkError(err, closedURL); got.Kind != NetworkErrorConnectionRefused {
+		t.Errorf("closed server: Kind = %v (%v), want connection refused", got.Kind, err)
+	}
+
+	// A self-signed TLS server fails certificate verification
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0) // Silence the server-side handshake error
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	_, err = NewClient().Send(&Request{Method: GET, URL: tlsServer.URL})
+	if got := DiagnoseNetworkError(err, tlsServer.URL); got.Kind != NetworkErrorTLSCertificate {
+		t.Errorf("self-signed server: Kind = %v (%v), want TLS certificate", got.Kind, err)
+	}
+}
+
+func TestNetworkError_Text(t *testing.T) {
+	ne := DiagnoseNetworkError(errors.New("boom"), "http://example.test/users")
+	text := ne.Text()
+
+	for _, want := range []string{"Request failed", "URL: http://example.test/users", "Error: boom", "Suggestions:"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+	if ne.Error() != "Request failed (example.test)" {
+		t.Errorf("Error() = %q", ne.Error())
+	}
+}
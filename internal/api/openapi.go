@@ -33,6 +33,9 @@ const (
 
 	// ErrConversionFailed indicates an error during collection building
 	ErrConversionFailed
+
+	// ErrFetchFailed indicates the spec could not be downloaded from its URL
+	ErrFetchFailed
 )
 
 // String returns a string representation of the error type.
@@ -52,6 +55,8 @@ func (t ImportErrorType) String() string {
 		return "ref_resolution_failed"
 	case ErrConversionFailed:
 		return "conversion_failed"
+	case ErrFetchFailed:
+		return "fetch_failed"
 	default:
 		return "unknown"
 	}
@@ -234,7 +234,9 @@ func operationToRequest(path string, method HTTPMethod, op *v3.Operation, baseUR
 		Params:      queryParams,
 		Headers:     headers,
 		Body:        body,
+		BodySchema:  requestBodySchema(op.RequestBody),
 		Auth:        auth,
+		Operation:   string(method) + " " + path,
 	}
 
 	// Store tag for folder organization (will be extracted later)
@@ -489,6 +491,91 @@ func requestBodyToBodyConfig(body *v3.RequestBody, headers []KeyValueEntry) (*Bo
 	return bodyConfig, headers
 }
 
+// requestBodySchema returns the schema of a JSON request body, for the body builder
+func requestBodySchema(body *v3.RequestBody) *JSONSchema {
+	if body == nil || body.Content == nil {
+		return nil
+	}
+	for pair := body.Content.First(); pair != nil; pair = pair.Next() {
+		if strings.Contains(pair.Key(), "json") && pair.Value() != nil && pair.Value().Schema != nil {
+			return schemaToJSONSchema(pair.Value().Schema.Schema(), 0)
+		}
+	}
+	return nil
+}
+
+// schemaToJSONSchema converts an OpenAPI schema to the JSON Schema of the body builder,
+// inlining references and merging allOf (with depth limit for circular refs)
+func schemaToJSONSchema(schema *base.Schema, depth int) *JSONSchema {
+	if schema == nil || depth > 5 {
+		return nil
+	}
+
+	// Handle allOf, oneOf, anyOf: merge allOf, take the first of oneOf and anyOf
+	if len(schema.Type) == 0 && schema.Properties == nil {
+		switch {
+		case len(schema.AllOf) > 0:
+			merged := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+			for _, proxy := range schema.AllOf {
+				if proxy == nil {
+					continue
+				}
+				if part := schemaToJSONSchema(proxy.Schema(), depth+1); part != nil {
+					for name, prop := range part.Properties {
+						merged.Properties[name] = prop
+					}
+					merged.Required = append(merged.Required, part.Required...)
+				}
+			}
+			return merged
+		case len(schema.OneOf) > 0:
+			return schemaToJSONSchema(schema.OneOf[0].Schema(), depth+1)
+		case len(schema.AnyOf) > 0:
+			return schemaToJSONSchema(schema.AnyOf[0].Schema(), depth+1)
+		}
+	}
+
+	result := &JSONSchema{
+		Format:      schema.Format,
+		Description: schema.Description,
+		Required:    schema.Required,
+	}
+	// Get type (handle OpenAPI 3.1 type arrays)
+	for _, t := range schema.Type {
+		if t != "null" {
+			result.Type = SchemaType(t)
+			break
+		}
+	}
+	for _, node := range schema.Enum {
+		var value interface{}
+		if node != nil && node.Decode(&value) == nil {
+			result.Enum = append(result.Enum, value)
+		}
+	}
+	if schema.Default != nil {
+		var value interface{}
+		if schema.Default.Decode(&value) == nil {
+			result.Default = value
+		}
+	}
+	if schema.Properties != nil {
+		result.Properties = make(map[string]*JSONSchema)
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			if pair.Value() == nil {
+				continue
+			}
+			if prop := schemaToJSONSchema(pair.Value().Schema(), depth+1); prop != nil {
+				result.Properties[pair.Key()] = prop
+			}
+		}
+	}
+	if schema.Items != nil && schema.Items.A != nil {
+		result.Items = schemaToJSONSchema(schema.Items.A.Schema(), depth+1)
+	}
+	return result
+}
+
 // addOrUpdateHeader adds or updates a header in the list
 func addOrUpdateHeader(headers []KeyValueEntry, key, value string) []KeyValueEntry {
 	for i, h := range headers {
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OpenAPISource records where a collection's OpenAPI spec was imported from, so the
+// collection can be re-synced when the spec changes
+type OpenAPISource struct {
+	Location string          `json:"location"`          // URL or file path of the spec
+	Headers  []KeyValueEntry `json:"headers,omitempty"` // Sent when fetching a URL; {{variables}} are resolved from the active environment
+}
+
+// IsRemoteSpec returns true if location is an http(s) URL rather than a file path
+func IsRemoteSpec(location string) bool {
+	lower := strings.ToLower(location)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// ParseHeaderLine parses a "Name: value" header as typed by the user
+func ParseHeaderLine(line string) (KeyValueEntry, error) {
+	name, value, ok := strings.Cut(line, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return KeyValueEntry{}, fmt.Errorf("invalid header %q: expected \"Name: value\"", line)
+	}
+	return KeyValueEntry{Key: name, Value: strings.TrimSpace(value), Enabled: true}, nil
+}
+
+// maxSpecPageHops bounds how many Swagger UI / Redoc pages and scripts are followed to find a spec
+const maxSpecPageHops = 2
+
+// specFetchTimeout is the timeout of each download of a spec or documentation page
+const specFetchTimeout = 30 * time.Second
+
+var (
+	// swaggerUIURLPattern matches the spec URL in a Swagger UI configuration (url: "..." or urls: [{url: "..."}])
+	swaggerUIURLPattern = regexp.MustCompile(`\burl\s*:\s*["']([^"']+)["']`)
+	// redocSpecURLPattern matches the spec URL of a Redoc element
+	redocSpecURLPattern = regexp.MustCompile(`\bspec-url\s*=\s*["']([^"']+)["']`)
+	// swaggerInitializerPattern matches the script holding the configuration of Swagger UI 4+
+	swaggerInitializerPattern = regexp.MustCompile(`\bsrc\s*=\s*["']([^"']*swagger-initializer\.js)["']`)
+)
+
+// NewOpenAPIImporterFromSource creates an importer from a spec file, a spec URL, or the
+// URL of a Swagger UI or Redoc page. Variables in the URL and headers are resolved from env.
+func NewOpenAPIImporterFromSource(source OpenAPISource, env *EnvironmentFile) (*OpenAPIImporter, error) {
+	if !IsRemoteSpec(source.Location) {
+		return NewOpenAPIImporterFromFile(source.Location)
+	}
+	data, err := FetchOpenAPISpec(source, env)
+	if err != nil {
+		return nil, err
+	}
+	return NewOpenAPIImporter(data)
+}
+
+// FetchOpenAPISpec downloads the spec at source.Location. When the URL serves a Swagger UI
+// or Redoc page, the spec it references is downloaded instead. Headers are only sent to
+// the host of source.Location.
+func FetchOpenAPISpec(source OpenAPISource, env *EnvironmentFile) ([]byte, error) {
+	location := ReplaceVariables(source.Location, env)
+	headers := make(map[string]string)
+	for _, h := range source.Headers {
+		if h.Enabled && h.Key != "" {
+			headers[h.Key] = ReplaceVariables(h.Value, env)
+		}
+	}
+
+	origin, err := url.Parse(location)
+	if err != nil {
+		return nil, &ImportError{Type: ErrFetchFailed, Message: "Invalid spec URL: " + location, Details: err.Error(), Cause: err}
+	}
+
+	client := &http.Client{Timeout: specFetchTimeout}
+	pageURL := location
+	target := location
+	for hop := 0; ; hop++ {
+		data, isPage, err := fetchSpecDocument(client, target, origin.Host, headers)
+		if err != nil {
+			return nil, err
+		}
+		if !isPage {
+			return data, nil
+		}
+
+		next, ok := findSpecReference(data, pageURL)
+		if !ok || hop == maxSpecPageHops {
+			return nil, &ImportError{
+				Type:    ErrFetchFailed,
+				Message: "No OpenAPI spec found at " + location,
+				Details: "The URL serves a web page without a Swagger UI or Redoc spec URL",
+			}
+		}
+		target = next
+	}
+}
+
+// fetchSpecDocument downloads target and reports whether it is a web page or script
+// (to search for a spec URL) rather than a spec
+func fetchSpecDocument(client *http.Client, target, originHost string, headers map[string]string) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, false, &ImportError{Type: ErrFetchFailed, Message: "Invalid spec URL: " + target, Details: err.Error(), Cause: err}
+	}
+	req.Header.Set("Accept", "application/json, application/yaml, text/yaml, */*")
+	if req.URL.Host == originHost {
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, &ImportError{Type: ErrFetchFailed, Message: "Cannot download " + target, Details: err.Error(), Cause: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, &ImportError{Type: ErrFetchFailed, Message: "Cannot download " + target, Details: err.Error(), Cause: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		details := ""
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			details = "Check the auth header and the variables of the active environment"
+		}
+		return nil, false, &ImportError{
+			Type:    ErrFetchFailed,
+			Message: fmt.Sprintf("Cannot download %s: %s", target, resp.Status),
+			Details: details,
+		}
+	}
+
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	isPage := strings.Contains(contentType, "html") || strings.Contains(contentType, "javascript") ||
+		strings.HasSuffix(req.URL.Path, ".js") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("<"))
+	return data, isPage, nil
+}
+
+// findSpecReference returns the URL of the spec referenced by a Swagger UI or Redoc page,
+// or of the Swagger UI initializer script that holds it, resolved against pageURL
+func findSpecReference(page []byte, pageURL string) (string, bool) {
+	for _, pattern := range []*regexp.Regexp{swaggerUIURLPattern, redocSpecURLPattern, swaggerInitializerPattern} {
+		if match := pattern.FindSubmatch(page); match != nil {
+			base, err := url.Parse(pageURL)
+			if err != nil {
+				return "", false
+			}
+			ref, err := url.Parse(string(match[1]))
+			if err != nil {
+				return "", false
+			}
+			return base.ResolveReference(ref).String(), true
+		}
+	}
+	return "", false
+}
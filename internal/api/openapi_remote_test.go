@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchOpenAPISpec(t *testing.T) {
+	spec := readTestFixture(t, "minimal-3.0.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.json":
+			if r.Header.Get("Authorization") != "Bearer s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(spec)
+		case "/docs":
+			// Swagger UI 4+: the spec URL is set in swagger-initializer.js
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><div id="swagger-ui"></div><script src="./swagger-initializer.js"></script></body></html>`))
+		case "/swagger-initializer.js":
+			w.Header().Set("Content-Type", "application/javascript")
+			_, _ = w.Write([]byte(`window.onload = function() { window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: '#swagger-ui' }); };`))
+		case "/redoc":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<redoc spec-url="openapi.json"></redoc>`))
+		case "/blank":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body>Hello</body></html>`))
+		}
+	}))
+	defer server.Close()
+
+	env := &EnvironmentFile{Variables: map[string]*EnvironmentVariable{
+		"token": {Value: "s3cret", Active: true},
+		"host":  {Value: server.URL, Active: true},
+	}}
+	auth := []KeyValueEntry{{Key: "Authorization", Value: "Bearer {{token}}", Enabled: true}}
+
+	tests := []struct {
+		name     string
+		source   OpenAPISource
+		wantErr  string
+		wantSpec bool
+	}{
+		{name: "spec URL with auth from environment", source: OpenAPISource{Location: "{{host}}/openapi.json", Headers: auth}, wantSpec: true},
+		{name: "missing auth", source: OpenAPISource{Location: server.URL + "/openapi.json"}, wantErr: "401"},
+		{name: "Swagger UI page", source: OpenAPISource{Location: server.URL + "/docs", Headers: auth}, wantSpec: true},
+		{name: "Redoc page", source: OpenAPISource{Location: server.URL + "/redoc", Headers: auth}, wantSpec: true},
+		{name: "page without spec", source: OpenAPISource{Location: server.URL + "/blank"}, wantErr: "No OpenAPI spec found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := FetchOpenAPISpec(tt.source, env)
+			if tt.wantErr != "" {
+				var importErr *ImportError
+				if !errors.As(err, &importErr) || importErr.Type != ErrFetchFailed || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want fetch error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchOpenAPISpec() error = %v", err)
+			}
+			if string(data) != string(spec) {
+				t.Errorf("got %q", data)
+			}
+		})
+	}
+}
+
+func TestFetchOpenAPISpec_HeadersOnlyToOrigin(t *testing.T) {
+	var leaked string
+	specServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("Authorization")
+		_, _ = w.Write(readTestFixture(t, "minimal-3.0.json"))
+	}))
+	defer specServer.Close()
+	docsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<script>SwaggerUIBundle({url: "` + specServer.URL + `/spec.json"})</script>`))
+	}))
+	defer docsServer.Close()
+
+	source := OpenAPISource{Location: docsServer.URL, Headers: []KeyValueEntry{{Key: "Authorization", Value: "Bearer x", Enabled: true}}}
+	if _, err := FetchOpenAPISpec(source, nil); err != nil {
+		t.Fatalf("FetchOpenAPISpec() error = %v", err)
+	}
+	if leaked != "" {
+		t.Errorf("auth header sent to another host: %q", leaked)
+	}
+}
+
+func TestParseHeaderLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    KeyValueEntry
+		wantErr bool
+	}{
+		{line: "Authorization: Bearer {{token}}", want: KeyValueEntry{Key: "Authorization", Value: "Bearer {{token}}", Enabled: true}},
+		{line: "X-Api-Key:abc", want: KeyValueEntry{Key: "X-Api-Key", Value: "abc", Enabled: true}},
+		{line: "no colon", wantErr: true},
+		{line: "Bad Name: x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHeaderLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHeaderLine(%q) error = %v", tt.line, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHeaderLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
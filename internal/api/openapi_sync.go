@@ -0,0 +1,134 @@
+package api
+
+// OpenAPISyncResult lists the operations added and removed by re-syncing a collection with its spec
+type OpenAPISyncResult struct {
+	Added   []CollectionRequest
+	Removed []CollectionRequest
+}
+
+// Empty returns true if the spec has the same operations as the collection
+func (r *OpenAPISyncResult) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0
+}
+
+// SyncOpenAPICollection returns a copy of current updated to the operations of fresh, a
+// collection converted from a newer version of its spec. Requests of operations still in
+// the spec are kept with their edits, requests of operations no longer in the spec are
+// removed and new operations are added to the folder of their tag. Requests added by hand
+// (without an operation) are kept. current is not modified.
+func SyncOpenAPICollection(current, fresh *CollectionFile) (*CollectionFile, OpenAPISyncResult) {
+	var result OpenAPISyncResult
+
+	freshOps := make(map[string]bool)
+	walkCollectionRequests(fresh.Folders, fresh.Requests, nil, func(_ []string, req *CollectionRequest) {
+		if req.Operation != "" {
+			freshOps[req.Operation] = true
+		}
+	})
+
+	keep := func(req CollectionRequest) bool {
+		if req.Operation == "" || freshOps[req.Operation] {
+			return true
+		}
+		result.Removed = append(result.Removed, req)
+		return false
+	}
+	synced := *current
+	synced.Requests = filterRequests(current.Requests, keep)
+	synced.Folders = filterFolders(current.Folders, keep)
+
+	// Collections imported before operations were recorded are matched by method and URL
+	currentOps := make(map[string]bool)
+	legacy := make(map[string]*CollectionRequest)
+	walkCollectionRequests(synced.Folders, synced.Requests, nil, func(_ []string, req *CollectionRequest) {
+		if req.Operation != "" {
+			currentOps[req.Operation] = true
+		} else {
+			legacy[string(req.Method)+" "+req.URL] = req
+		}
+	})
+
+	walkCollectionRequests(fresh.Folders, fresh.Requests, nil, func(path []string, req *CollectionRequest) {
+		if req.Operation == "" || currentOps[req.Operation] {
+			return
+		}
+		if match, ok := legacy[string(req.Method)+" "+req.URL]; ok {
+			match.Operation = req.Operation
+			delete(legacy, string(req.Method)+" "+req.URL)
+			return
+		}
+		result.Added = append(result.Added, *req)
+	})
+
+	// Added after matching, as appending may move the requests matched above
+	for _, req := range result.Added {
+		path := findRequestFolderPath(fresh, req.ID)
+		addRequestAt(&synced.Folders, &synced.Requests, path, req)
+	}
+
+	return &synced, result
+}
+
+// walkCollectionRequests calls fn for every request of folders and requests, depth first,
+// with the names of the folders holding it
+func walkCollectionRequests(folders []Folder, requests []CollectionRequest, path []string, fn func(path []string, req *CollectionRequest)) {
+	for i := range requests {
+		fn(path, &requests[i])
+	}
+	for i := range folders {
+		walkCollectionRequests(folders[i].Folders, folders[i].Requests, append(path[:len(path):len(path)], folders[i].Name), fn)
+	}
+}
+
+// findRequestFolderPath returns the names of the folders holding the request with id
+func findRequestFolderPath(c *CollectionFile, id string) []string {
+	var found []string
+	walkCollectionRequests(c.Folders, c.Requests, nil, func(path []string, req *CollectionRequest) {
+		if req.ID == id && found == nil {
+			found = append([]string{}, path...)
+		}
+	})
+	return found
+}
+
+// filterRequests returns a new slice of the requests keep returns true for
+func filterRequests(requests []CollectionRequest, keep func(CollectionRequest) bool) []CollectionRequest {
+	var kept []CollectionRequest
+	for _, req := range requests {
+		if keep(req) {
+			kept = append(kept, req)
+		}
+	}
+	return kept
+}
+
+// filterFolders returns a copy of folders with only the requests keep returns true for
+func filterFolders(folders []Folder, keep func(CollectionRequest) bool) []Folder {
+	if folders == nil {
+		return nil
+	}
+	copied := make([]Folder, len(folders))
+	for i, f := range folders {
+		copied[i] = f
+		copied[i].Requests = filterRequests(f.Requests, keep)
+		copied[i].Folders = filterFolders(f.Folders, keep)
+	}
+	return copied
+}
+
+// addRequestAt appends req to the folder at path, creating missing folders
+func addRequestAt(folders *[]Folder, requests *[]CollectionRequest, path []string, req CollectionRequest) {
+	if len(path) == 0 {
+		*requests = append(*requests, req)
+		return
+	}
+	for i := range *folders {
+		if (*folders)[i].Name == path[0] {
+			addRequestAt(&(*folders)[i].Folders, &(*folders)[i].Requests, path[1:], req)
+			return
+		}
+	}
+	*folders = append(*folders, Folder{Name: path[0]})
+	f := &(*folders)[len(*folders)-1]
+	addRequestAt(&f.Folders, &f.Requests, path[1:], req)
+}
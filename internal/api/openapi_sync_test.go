@@ -0,0 +1,143 @@
+package api
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// changedMinimalSpec is minimal-3.0.json without POST /users and with GET /users/{id}
+const changedMinimalSpec = `
+openapi: 3.0.3
+info:
+  title: Minimal API
+  version: 1.1.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /health:
+    get:
+      summary: Health check endpoint
+      tags: [System]
+      responses:
+        "200":
+          description: OK
+  /users:
+    get:
+      summary: List all users
+      tags: [Users]
+      responses:
+        "200":
+          description: OK
+  /users/{id}:
+    get:
+      summary: Get a user
+      tags: [Users]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+  /metrics:
+    get:
+      summary: Metrics
+      tags: [Monitoring]
+      responses:
+        "200":
+          description: OK
+`
+
+func importTestCollection(t *testing.T, data []byte) *CollectionFile {
+	t.Helper()
+	importer, err := NewOpenAPIImporter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := importer.ToCollection(ImportOptions{IncludeExamples: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func operations(requests []CollectionRequest) []string {
+	ops := make([]string, len(requests))
+	for i, r := range requests {
+		ops[i] = r.Operation
+	}
+	return ops
+}
+
+func TestSyncOpenAPICollection(t *testing.T) {
+	current := importTestCollection(t, readTestFixture(t, "minimal-3.0.json"))
+	fresh := importTestCollection(t, []byte(changedMinimalSpec))
+
+	// Edits to kept requests and requests added by hand survive the sync
+	var users *Folder
+	for i := range current.Folders {
+		if current.Folders[i].Name == "Users" {
+			users = &current.Folders[i]
+		}
+	}
+	if users == nil {
+		t.Fatalf("no Users folder in %+v", current.Folders)
+	}
+	for i := range users.Requests {
+		if users.Requests[i].Operation == "GET /users" {
+			users.Requests[i].Name = "Renamed list"
+		}
+	}
+	users.Requests = append(users.Requests, CollectionRequest{ID: "manual", Name: "Manual", Method: GET, URL: "/debug"})
+
+	synced, result := SyncOpenAPICollection(current, fresh)
+
+	added := operations(result.Added)
+	sort.Strings(added)
+	if len(added) != 2 || added[0] != "GET /metrics" || added[1] != "GET /users/{id}" {
+		t.Errorf("Added = %v", added)
+	}
+	if got := operations(result.Removed); len(got) != 1 || got[0] != "POST /users" {
+		t.Errorf("Removed = %v", got)
+	}
+
+	var names []string
+	walkCollectionRequests(synced.Folders, synced.Requests, nil, func(path []string, req *CollectionRequest) {
+		names = append(names, path[0]+"/"+req.Name)
+	})
+	sort.Strings(names)
+	want := []string{"Monitoring/Metrics", "System/Health check endpoint", "Users/Get a user", "Users/Manual", "Users/Renamed list"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("synced requests = %v, want %v", names, want)
+	}
+
+	if countRequests(current) != 4 {
+		t.Errorf("current should not be modified, has %d requests", countRequests(current))
+	}
+
+	// Syncing again with the same spec changes nothing
+	if _, again := SyncOpenAPICollection(synced, fresh); !again.Empty() {
+		t.Errorf("second sync = %+v, want no changes", again)
+	}
+}
+
+func TestSyncOpenAPICollection_LegacyImport(t *testing.T) {
+	current := importTestCollection(t, readTestFixture(t, "minimal-3.0.json"))
+	walkCollectionRequests(current.Folders, current.Requests, nil, func(_ []string, req *CollectionRequest) {
+		req.Operation = "" // Imported before operations were recorded
+	})
+	fresh := importTestCollection(t, readTestFixture(t, "minimal-3.0.json"))
+
+	synced, result := SyncOpenAPICollection(current, fresh)
+	if !result.Empty() {
+		t.Errorf("result = %+v, want requests matched by method and URL", result)
+	}
+	walkCollectionRequests(synced.Folders, synced.Requests, nil, func(_ []string, req *CollectionRequest) {
+		if req.Operation == "" {
+			t.Errorf("request %q should adopt its operation", req.Name)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import "strings"
+
+// PromptVariablePrefix marks the variables asked for at send time: {{?ticket_id}}.
+// Their values are never stored in an environment.
+const PromptVariablePrefix = "?"
+
+// IsPromptVariable reports whether a variable name is a prompt variable ("?ticket_id")
+func IsPromptVariable(name string) bool {
+	return strings.HasPrefix(name, PromptVariablePrefix) && len(name) > len(PromptVariablePrefix)
+}
+
+// FindPromptVariables returns the names, without prefix, of the prompt variables of
+// a request (URL, enabled headers, body and auth fields) in order of first use
+func FindPromptVariables(req *CollectionRequest) []string {
+	var names []string
+	for _, name := range requestVariableReferences(req) {
+		if IsPromptVariable(name) {
+			names = append(names, strings.TrimPrefix(name, PromptVariablePrefix))
+		}
+	}
+	return names
+}
+
+// WithPromptValues returns a copy of vars in which the prompt variables of values,
+// keyed by name without prefix, resolve
+func WithPromptValues(vars, values map[string]string) map[string]string {
+	merged := make(map[string]string, len(vars)+len(values))
+	for name, value := range vars {
+		merged[name] = value
+	}
+	for name, value := range values {
+		merged[PromptVariablePrefix+name] = value
+	}
+	return merged
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPromptVariables(t *testing.T) {
+	req := &CollectionRequest{
+		URL: "{{base_url}}/tickets/{{?ticket_id}}",
+		Headers: []KeyValueEntry{
+			{Key: "X-Reason", Value: "{{?reason}}", Enabled: true},
+			{Key: "X-Off", Value: "{{?disabled}}", Enabled: false},
+		},
+		Body: &BodyConfig{Type: "json", Content: map[string]interface{}{"id": "{{?ticket_id}}"}},
+	}
+
+	got := FindPromptVariables(req)
+	if want := []string{"ticket_id", "reason"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPromptVariables() = %v, want %v", got, want)
+	}
+}
+
+func TestIsPromptVariable(t *testing.T) {
+	tests := map[string]bool{"?ticket_id": true, "?": false, "ticket_id": false, "$uuid": false}
+	for name, want := range tests {
+		if got := IsPromptVariable(name); got != want {
+			t.Errorf("IsPromptVariable(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWithPromptValues(t *testing.T) {
+	vars := map[string]string{"base_url": "https://api.dev"}
+	got := WithPromptValues(vars, map[string]string{"ticket_id": "42"})
+
+	want := map[string]string{"base_url": "https://api.dev", "?ticket_id": "42"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithPromptValues() = %v, want %v", got, want)
+	}
+	if len(vars) != 1 {
+		t.Error("WithPromptValues() should not modify vars")
+	}
+}
+
+func TestFindUnresolvedVariables_SkipsPromptVariables(t *testing.T) {
+	if got := FindUnresolvedVariables("/tickets/{{?ticket_id}}", nil); len(got) != 0 {
+		t.Errorf("FindUnresolvedVariables() = %v, want none", got)
+	}
+}
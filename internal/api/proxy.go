@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Proxy routes requests through an HTTP, HTTPS or SOCKS5 proxy
+type Proxy struct {
+	URL     string   // http://, https://, socks5:// or socks5h:// URL, with optional user:password; empty connects directly
+	NoProxy []string // Hosts reached directly: "example.com" (and its subdomains), "host:port", "10.0.0.0/8" or "*"
+}
+
+// DefaultProxy applies to clients created by NewClient; nil uses the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables
+var DefaultProxy *Proxy
+
+// proxySchemes are the proxy URL schemes net/http supports
+var proxySchemes = map[string]string{
+	"http":    "80",
+	"https":   "443",
+	"socks5":  "1080",
+	"socks5h": "1080",
+}
+
+// Validate checks the proxy URL and the no-proxy entries
+func (p *Proxy) Validate() error {
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if _, ok := proxySchemes[u.Scheme]; !ok || u.Hostname() == "" {
+			return fmt.Errorf("invalid proxy URL %q: expected http://, https:// or socks5://host:port", u.Redacted())
+		}
+	}
+	for _, entry := range p.NoProxy {
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return fmt.Errorf("invalid no_proxy entry %q: %w", entry, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Label returns the proxy URL without its password, or "" when connecting directly
+func (p *Proxy) Label() string {
+	u, err := url.Parse(p.URL)
+	if err != nil || p.URL == "" {
+		return ""
+	}
+	return u.Redacted()
+}
+
+// ProxyURL returns the proxy requests to target go through, or nil when they connect
+// directly. Local hosts are never proxied, as with the environment variables.
+func (p *Proxy) ProxyURL(target *url.URL) *url.URL {
+	if p.URL == "" || isLoopbackHost(target.Hostname()) || p.Bypasses(target) {
+		return nil
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// Bypasses reports whether a no-proxy entry matches the host of target
+func (p *Proxy) Bypasses(target *url.URL) bool {
+	hostname := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = proxySchemes[target.Scheme]
+	}
+	ip := net.ParseIP(hostname)
+
+	for _, entry := range p.NoProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case strings.Contains(entry, "/"):
+			if _, network, err := net.ParseCIDR(entry); err == nil && ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, entryPort, err := net.SplitHostPort(entry); err == nil {
+			if entryPort != port {
+				continue
+			}
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if hostname == entry || strings.HasSuffix(hostname, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyFunc returns the proxy selection of the transports: DefaultProxy when set,
+// otherwise the environment variables
+func proxyFunc() func(*http.Request) (*url.URL, error) {
+	proxy := DefaultProxy
+	if proxy == nil {
+		return http.ProxyFromEnvironment
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy.ProxyURL(req.URL), nil
+	}
+}
+
+// SetProxy routes the requests of the client through proxy; nil goes back to the
+// environment variables
+func (c *Client) SetProxy(proxy *Proxy) error {
+	if proxy != nil {
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+	c.proxy = proxy
+	c.updateTransport()
+	return nil
+}
+
+// ActiveProxy returns the host of the proxy requests go through by default, from
+// DefaultProxy or the HTTPS_PROXY and HTTP_PROXY environment variables, or "" when
+// requests connect directly
+func ActiveProxy() string {
+	proxy := proxyFor(&url.URL{Scheme: "https"})
+	if proxy == "" {
+		proxy = proxyFor(&url.URL{Scheme: "http"})
+	}
+	if proxy == "" {
+		return ""
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		// Environment variables may omit the scheme
+		return proxy
+	}
+	return u.Host
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultMaxRedirects is the number of redirects followed when a request sets no limit
+const DefaultMaxRedirects = 10
+
+// RedirectHop is one request of a redirect chain and the status it got
+type RedirectHop struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+// MaxRedirectsOrDefault returns the redirects followed for a MaxRedirects setting
+func MaxRedirectsOrDefault(limit int) int {
+	if limit <= 0 {
+		return DefaultMaxRedirects
+	}
+	return limit
+}
+
+// redirectPolicy returns the http.Client CheckRedirect func applying the redirect
+// settings of req. Followed redirect responses are appended to chain.
+func redirectPolicy(req *Request, chain *[]RedirectHop) func(*http.Request, []*http.Request) error {
+	return func(next *http.Request, via []*http.Request) error {
+		if req.NoFollowRedirects {
+			return http.ErrUseLastResponse
+		}
+		limit := MaxRedirectsOrDefault(req.MaxRedirects)
+		if len(via) > limit {
+			return fmt.Errorf("stopped after %d redirects", limit)
+		}
+		if resp := next.Response; resp != nil {
+			*chain = append(*chain, RedirectHop{
+				URL:        via[len(via)-1].URL.String(),
+				StatusCode: resp.StatusCode,
+				Status:     resp.Status,
+			})
+		}
+		return nil
+	}
+}
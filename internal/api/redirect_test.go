@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newRedirectServer redirects /hop/n to /hop/n-1 until /hop/0, which answers 200
+func newRedirectServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := strings.TrimPrefix(r.URL.Path, "/hop/")
+		if n == "0" {
+			w.Write([]byte("done"))
+			return
+		}
+		next := string(rune(n[0] - 1))
+		http.Redirect(w, r, "/hop/"+next, http.StatusFound)
+	}))
+}
+
+func TestClient_SendFollowsRedirects(t *testing.T) {
+	server := newRedirectServer()
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{Method: GET, URL: server.URL + "/hop/2"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+
+	want := []RedirectHop{
+		{URL: server.URL + "/hop/2", StatusCode: http.StatusFound},
+		{URL: server.URL + "/hop/1", StatusCode: http.StatusFound},
+		{URL: server.URL + "/hop/0", StatusCode: http.StatusOK},
+	}
+	if len(resp.Redirects) != len(want) {
+		t.Fatalf("Redirects = %+v, want %d hops", resp.Redirects, len(want))
+	}
+	for i, hop := range want {
+		if got := resp.Redirects[i]; got.URL != hop.URL || got.StatusCode != hop.StatusCode {
+			t.Errorf("Redirects[%d] = %+v, want %+v", i, got, hop)
+		}
+	}
+}
+
+func TestClient_SendNoFollowRedirects(t *testing.T) {
+	server := newRedirectServer()
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{Method: GET, URL: server.URL + "/hop/2", NoFollowRedirects: true})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusFound || http.Header(resp.Headers).Get("Location") != "/hop/1" {
+		t.Errorf("got %d to %q, want 302 to /hop/1", resp.StatusCode, http.Header(resp.Headers).Get("Location"))
+	}
+	if len(resp.Redirects) != 0 {
+		t.Errorf("Redirects = %+v, want none", resp.Redirects)
+	}
+}
+
+func TestClient_SendMaxRedirects(t *testing.T) {
+	server := newRedirectServer()
+	defer server.Close()
+
+	_, err := NewClient().Send(&Request{Method: GET, URL: server.URL + "/hop/3", MaxRedirects: 2})
+	if err == nil || !strings.Contains(err.Error(), "stopped after 2 redirects") {
+		t.Errorf("Send() error = %v, want stopped after 2 redirects", err)
+	}
+
+	if _, err := NewClient().Send(&Request{Method: GET, URL: server.URL + "/hop/2", MaxRedirects: 2}); err != nil {
+		t.Errorf("Send() with 2 redirects error = %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// RedactedValue replaces the secrets of redacted examples
+const RedactedValue = "<redacted>"
+
+// RequestExample is a request frozen as it is sent: variables substituted and auth
+// computed. Examples are kept with their request for bug reports and as regression
+// baselines, and never change once frozen.
+type RequestExample struct {
+	Name        string            `json:"name"`
+	Frozen      time.Time         `json:"frozen"`
+	Environment string            `json:"environment,omitempty"` // Environment active when frozen
+	Method      HTTPMethod        `json:"method"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	BodyBase64  bool              `json:"body_base64,omitempty"` // Body holds binary data, base64 encoded
+	BodyFile    string            `json:"body_file,omitempty"`   // File sent as body
+	Form        []FormField       `json:"form,omitempty"`        // Fields of a multipart body
+	Redacted    bool              `json:"redacted,omitempty"`    // Secrets were replaced by RedactedValue
+}
+
+// Redaction lists what FreezeRequest keeps out of an example
+type Redaction struct {
+	Secrets []string // Values replaced wherever they appear, such as those of secret variables
+	Names   []string // Headers and query parameters holding credentials, besides those named like one
+}
+
+// FreezeRequest freezes req as an example. With a redaction, the secrets it lists and
+// the values of credential headers and query parameters are replaced by RedactedValue;
+// the scheme of an Authorization header is kept.
+func FreezeRequest(req *Request, redaction *Redaction) (*RequestExample, error) {
+	example := &RequestExample{
+		Method:  req.Method,
+		URL:     req.URL,
+		Headers: make(map[string]string, len(req.Headers)),
+	}
+	for name, value := range req.Headers {
+		example.Headers[name] = value
+	}
+
+	switch body := req.Body.(type) {
+	case nil:
+	case *FileBody:
+		example.BodyFile = body.Path
+	case *MultipartForm:
+		for _, field := range body.Fields {
+			if field.Enabled {
+				example.Form = append(example.Form, field)
+			}
+		}
+	default:
+		data, _, err := encodeRequestBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %w", err)
+		}
+		if utf8.Valid(data) {
+			example.Body = string(data)
+		} else {
+			example.Body = base64.StdEncoding.EncodeToString(data)
+			example.BodyBase64 = true
+		}
+	}
+
+	if redaction != nil {
+		example.redact(redaction)
+	}
+	return example, nil
+}
+
+// redact replaces the secrets of the example by RedactedValue
+func (e *RequestExample) redact(redaction *Redaction) {
+	e.Redacted = true
+	isCredential := func(name string) bool {
+		return isSensitiveName(name) || slices.ContainsFunc(redaction.Names, func(n string) bool {
+			return strings.EqualFold(n, name)
+		})
+	}
+
+	var pairs []string
+	for _, secret := range redaction.Secrets {
+		if secret != "" {
+			pairs = append(pairs, secret, RedactedValue)
+		}
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	e.URL = replacer.Replace(redactQuery(e.URL, isCredential))
+	for name, value := range e.Headers {
+		if isCredential(name) {
+			scheme, _, hasScheme := strings.Cut(value, " ")
+			value = RedactedValue
+			if hasScheme && strings.EqualFold(name, "Authorization") {
+				value = scheme + " " + RedactedValue
+			}
+		}
+		e.Headers[name] = replacer.Replace(value)
+	}
+	if !e.BodyBase64 {
+		e.Body = replacer.Replace(e.Body)
+	}
+	for i, field := range e.Form {
+		if isCredential(field.Key) && !field.IsFile() {
+			field.Value = RedactedValue
+		}
+		field.Value = replacer.Replace(field.Value)
+		e.Form[i] = field
+	}
+}
+
+// redactQuery replaces the values of the credential query parameters of rawURL
+func redactQuery(rawURL string, isCredential func(string) bool) string {
+	base, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	query, fragment, hasFragment := strings.Cut(query, "#")
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		if name, _, ok := strings.Cut(param, "="); ok && isCredential(name) {
+			params[i] = name + "=" + RedactedValue
+		}
+	}
+	redacted := base + "?" + strings.Join(params, "&")
+	if hasFragment {
+		redacted += "#" + fragment
+	}
+	return redacted
+}
+
+// Request returns the request the example froze, to send it again or copy it as cURL
+func (e *RequestExample) Request() *Request {
+	req := &Request{Method: e.Method, URL: e.URL, Headers: make(map[string]string, len(e.Headers))}
+	for name, value := range e.Headers {
+		req.Headers[name] = value
+	}
+	switch {
+	case e.BodyFile != "":
+		req.Body = &FileBody{Path: e.BodyFile}
+	case len(e.Form) > 0:
+		req.Body = &MultipartForm{Fields: slices.Clone(e.Form)}
+	case e.BodyBase64:
+		if data, err := base64.StdEncoding.DecodeString(e.Body); err == nil {
+			req.Body = data
+		}
+	case e.Body != "":
+		req.Body = e.Body
+	}
+	return req
+}
+
+// Label returns the name of the example with when it was frozen
+func (e *RequestExample) Label() string {
+	return fmt.Sprintf("%s (%s)", e.Name, e.Frozen.Local().Format("2006-01-02 15:04"))
+}
+
+// Text returns the example as an HTTP message, after a comment line telling when and
+// how it was frozen
+func (e *RequestExample) Text() string {
+	var sb strings.Builder
+	sb.WriteString("# " + e.Label())
+	if e.Environment != "" {
+		sb.WriteString(" · environment " + e.Environment)
+	}
+	if e.Redacted {
+		sb.WriteString(" · secrets redacted")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%s %s\n", e.Method, e.URL))
+
+	names := make([]string, 0, len(e.Headers))
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", name, e.Headers[name]))
+	}
+
+	switch {
+	case e.BodyFile != "":
+		sb.WriteString("\n@" + e.BodyFile + "\n")
+	case len(e.Form) > 0:
+		sb.WriteString("\n")
+		for _, field := range e.Form {
+			if field.IsFile() {
+				sb.WriteString(fmt.Sprintf("%s=@%s\n", field.Key, field.Value))
+			} else {
+				sb.WriteString(fmt.Sprintf("%s=%s\n", field.Key, field.Value))
+			}
+		}
+	case e.BodyBase64:
+		sb.WriteString(fmt.Sprintf("\n# binary body, base64 encoded\n%s\n", e.Body))
+	case e.Body != "":
+		sb.WriteString("\n" + e.Body + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
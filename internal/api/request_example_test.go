@@ -0,0 +1,83 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFreezeRequest(t *testing.T) {
+	req := &Request{
+		Method: POST,
+		URL:    "https://api.example.com/orders?key=k-123&page=2#top",
+		Headers: map[string]string{
+			"Authorization": "Bearer s3cr3t-token",
+			"X-Tenant":      "acme",
+			"Content-Type":  "application/json",
+		},
+		Body: `{"card": "4242-4242", "note": "uses s3cr3t-token"}`,
+	}
+
+	raw, err := FreezeRequest(req, nil)
+	if err != nil {
+		t.Fatalf("FreezeRequest() error = %v", err)
+	}
+	if raw.Redacted || raw.Headers["Authorization"] != "Bearer s3cr3t-token" || raw.Body != req.Body {
+		t.Errorf("an example without redaction should keep the request as is, got %+v", raw)
+	}
+
+	example, err := FreezeRequest(req, &Redaction{Secrets: []string{"s3cr3t-token", "4242-4242"}, Names: []string{"KEY"}})
+	if err != nil {
+		t.Fatalf("FreezeRequest() error = %v", err)
+	}
+	if got := example.Headers["Authorization"]; got != "Bearer <redacted>" {
+		t.Errorf("Authorization = %q, want the scheme kept", got)
+	}
+	if example.URL != "https://api.example.com/orders?key=<redacted>&page=2#top" {
+		t.Errorf("URL = %q", example.URL)
+	}
+	if example.Body != `{"card": "<redacted>", "note": "uses <redacted>"}` || example.Headers["X-Tenant"] != "acme" {
+		t.Errorf("secrets should be redacted everywhere and other values kept, got %+v", example)
+	}
+	if req.Headers["Authorization"] != "Bearer s3cr3t-token" {
+		t.Error("FreezeRequest should not change the request")
+	}
+
+	example.Name = "Checkout bug"
+	example.Frozen = time.Date(2026, 3, 1, 10, 30, 0, 0, time.Local)
+	example.Environment = "staging"
+	text := example.Text()
+	for _, want := range []string{
+		"# Checkout bug (2026-03-01 10:30) · environment staging · secrets redacted\nPOST https://api.example.com/orders",
+		"Authorization: Bearer <redacted>\nContent-Type: application/json\nX-Tenant: acme\n\n{",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() should contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestRequestExample_Request(t *testing.T) {
+	binary, err := FreezeRequest(&Request{Method: PUT, URL: "https://x", Body: []byte{0xff, 0x00}}, nil)
+	if err != nil {
+		t.Fatalf("FreezeRequest() error = %v", err)
+	}
+	if !binary.BodyBase64 || binary.Body != "/wA=" {
+		t.Errorf("binary bodies should be kept base64 encoded, got %+v", binary)
+	}
+	if body, ok := binary.Request().Body.([]byte); !ok || string(body) != "\xff\x00" {
+		t.Errorf("Request() body = %#v", binary.Request().Body)
+	}
+
+	form, _ := FreezeRequest(&Request{Method: POST, URL: "https://x", Body: &MultipartForm{Fields: []FormField{
+		{Key: "password", Value: "hunter22", Enabled: true},
+		{Key: "avatar", Value: "me.png", Type: FormFieldFile, Enabled: true},
+		{Key: "off", Value: "x"},
+	}}}, &Redaction{})
+	if len(form.Form) != 2 || form.Form[0].Value != RedactedValue || form.Form[1].Value != "me.png" {
+		t.Errorf("enabled fields should be kept, credentials redacted, got %+v", form.Form)
+	}
+	if curl := GenerateCurlFromRequest(form.Request()); !strings.Contains(curl, "avatar=@me.png") {
+		t.Errorf("the example should copy as cURL, got %s", curl)
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import "fmt"
+
+// VariableRequirement declares an environment variable a collection needs to run
+type VariableRequirement struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Secret      bool   `json:"secret,omitempty"` // Store the value as a secret when it is filled in
+}
+
+// MissingVariables returns the required variables of the collections that env does not provide.
+// A variable is missing when it is undefined, inactive or empty. Requirements are returned in
+// declaration order; a variable declared by several collections is reported once and is secret
+// if any of them marks it secret.
+func MissingVariables(env *EnvironmentFile, collections ...*CollectionFile) []VariableRequirement {
+	var missing []VariableRequirement
+	index := make(map[string]int)
+
+	for _, col := range collections {
+		if col == nil {
+			continue
+		}
+		for _, req := range col.RequiredVariables {
+			if req.Name == "" || hasVariableValue(env, req.Name) {
+				continue
+			}
+			if i, ok := index[req.Name]; ok {
+				missing[i].Secret = missing[i].Secret || req.Secret
+				if missing[i].Description == "" {
+					missing[i].Description = req.Description
+				}
+				continue
+			}
+			index[req.Name] = len(missing)
+			missing = append(missing, req)
+		}
+	}
+
+	return missing
+}
+
+// FillRequirement stores value for a required variable in env, keeping the secret flag of the requirement
+func FillRequirement(env *EnvironmentFile, req VariableRequirement, value string) {
+	env.SetVariable(req.Name, value)
+	v := env.Variables[req.Name]
+	v.Active = true
+	v.Secret = v.Secret || req.Secret
+}
+
+// hasVariableValue reports whether env has an active, non-empty value for name
+func hasVariableValue(env *EnvironmentFile, name string) bool {
+	if env == nil {
+		return false
+	}
+	v, ok := env.Variables[name]
+	return ok && v != nil && v.Active && v.Value != ""
+}
+
+// validateRequirements checks that required variables are named and declared once
+func validateRequirements(reqs []VariableRequirement) error {
+	seen := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		if req.Name == "" {
+			return fmt.Errorf("required variable name is required")
+		}
+		if seen[req.Name] {
+			return fmt.Errorf("required variable '%s' is declared twice", req.Name)
+		}
+		seen[req.Name] = true
+	}
+	return nil
+}
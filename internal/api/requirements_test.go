@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMissingVariables(t *testing.T) {
+	env := &EnvironmentFile{
+		Name: "dev",
+		Variables: map[string]*EnvironmentVariable{
+			"base_url": {Value: "http://localhost", Active: true},
+			"token":    {Value: "", Active: true},
+			"user":     {Value: "ada", Active: false},
+		},
+	}
+	users := &CollectionFile{
+		Name: "Users",
+		RequiredVariables: []VariableRequirement{
+			{Name: "base_url", Description: "API root"},
+			{Name: "token", Description: "Bearer token"},
+			{Name: "user"},
+			{Name: "tenant"},
+		},
+	}
+	admin := &CollectionFile{
+		Name: "Admin",
+		RequiredVariables: []VariableRequirement{
+			{Name: "user", Description: "Admin login", Secret: true},
+			{Name: "token", Secret: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		env         *EnvironmentFile
+		collections []*CollectionFile
+		want        []VariableRequirement
+	}{
+		{
+			name:        "undefined, inactive and empty",
+			env:         env,
+			collections: []*CollectionFile{users},
+			want: []VariableRequirement{
+				{Name: "token", Description: "Bearer token"},
+				{Name: "user"},
+				{Name: "tenant"},
+			},
+		},
+		{
+			name:        "merged across collections",
+			env:         env,
+			collections: []*CollectionFile{users, admin},
+			want: []VariableRequirement{
+				{Name: "token", Description: "Bearer token", Secret: true},
+				{Name: "user", Description: "Admin login", Secret: true},
+				{Name: "tenant"},
+			},
+		},
+		{
+			name:        "no environment",
+			env:         nil,
+			collections: []*CollectionFile{admin, nil},
+			want: []VariableRequirement{
+				{Name: "user", Description: "Admin login", Secret: true},
+				{Name: "token", Secret: true},
+			},
+		},
+		{
+			name:        "no manifest",
+			env:         env,
+			collections: []*CollectionFile{{Name: "Empty"}},
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MissingVariables(tt.env, tt.collections...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingVariables() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillRequirement(t *testing.T) {
+	env := &EnvironmentFile{Variables: map[string]*EnvironmentVariable{
+		"user": {Value: "", Active: false},
+	}}
+
+	FillRequirement(env, VariableRequirement{Name: "user"}, "ada")
+	FillRequirement(env, VariableRequirement{Name: "password", Secret: true}, "s3cret")
+
+	if v := env.Variables["user"]; v.Value != "ada" || !v.Active || v.Secret {
+		t.Errorf("user = %+v", v)
+	}
+	if v := env.Variables["password"]; v.Value != "s3cret" || !v.Active || !v.Secret {
+		t.Errorf("password = %+v", v)
+	}
+	if missing := MissingVariables(env, &CollectionFile{RequiredVariables: []VariableRequirement{{Name: "user"}, {Name: "password"}}}); len(missing) != 0 {
+		t.Errorf("still missing %+v", missing)
+	}
+}
+
+func TestValidateCollection_RequiredVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		reqs    []VariableRequirement
+		wantErr bool
+	}{
+		{name: "valid", reqs: []VariableRequirement{{Name: "a"}, {Name: "b", Secret: true}}},
+		{name: "empty name", reqs: []VariableRequirement{{Description: "?"}}, wantErr: true},
+		{name: "duplicate", reqs: []VariableRequirement{{Name: "a"}, {Name: "a"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCollection(&CollectionFile{Name: "c", RequiredVariables: tt.reqs})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCollection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCollectionFile_RequiredVariablesJSON(t *testing.T) {
+	data := []byte(`{"name":"Users","required_variables":[{"name":"token","description":"Bearer token","secret":true}]}`)
+
+	var col CollectionFile
+	if err := json.Unmarshal(data, &col); err != nil {
+		t.Fatal(err)
+	}
+	want := []VariableRequirement{{Name: "token", Description: "Bearer token", Secret: true}}
+	if !reflect.DeepEqual(col.RequiredVariables, want) {
+		t.Errorf("RequiredVariables = %+v, want %+v", col.RequiredVariables, want)
+	}
+}
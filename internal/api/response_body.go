@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// SpoolBodySize is the largest response body held in memory. Larger bodies are
+// streamed to a temporary file; Response.Body then keeps their first
+// MaxDisplayBodySize bytes for display.
+const SpoolBodySize = 4 * 1024 * 1024
+
+// spooledBodies are the temporary files of the spooled response bodies, removed by
+// RemoveSpooledBodies
+var spooledBodies struct {
+	sync.Mutex
+	paths []string
+}
+
+// readResponseBody reads a response body, streaming it to a temporary file once it
+// grows past SpoolBodySize. It returns the body, or its first MaxDisplayBodySize
+// bytes with the path of the file holding it whole, and the size of the body.
+func readResponseBody(r io.Reader) ([]byte, string, int64, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, r, SpoolBodySize+1)
+	if err == io.EOF {
+		return buf.Bytes(), "", n, nil
+	}
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	file, err := os.CreateTemp("", "lazycurl-response-*")
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to spool response body: %w", err)
+	}
+	spooledBodies.Lock()
+	spooledBodies.paths = append(spooledBodies.paths, file.Name())
+	spooledBodies.Unlock()
+
+	size, err := io.Copy(file, io.MultiReader(bytes.NewReader(buf.Bytes()), r))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, "", 0, err
+	}
+	preview, _ := TruncateText(buf.Bytes(), MaxDisplayBodySize)
+	return bytes.Clone(preview), file.Name(), size, nil
+}
+
+// RemoveSpooledBodies deletes the temporary files of spooled response bodies
+func RemoveSpooledBodies() {
+	spooledBodies.Lock()
+	defer spooledBodies.Unlock()
+	for _, path := range spooledBodies.paths {
+		_ = os.Remove(path)
+	}
+	spooledBodies.paths = nil
+}
+
+// Spooled reports whether the body was streamed to a temporary file, Body only
+// holding its beginning
+func (r *Response) Spooled() bool {
+	return r.BodyFile != ""
+}
+
+// OpenBody returns a reader of the whole body, read from its temporary file when
+// spooled
+func (r *Response) OpenBody() (io.ReadCloser, error) {
+	return OpenBodySource(r.Body, r.BodyFile)
+}
+
+// OpenBodySource returns a reader of a body held in memory, or in file when it is set
+func OpenBodySource(body []byte, file string) (io.ReadCloser, error) {
+	if file == "" {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return f, nil
+}
+
+// FullBody returns the whole body, reading it from its temporary file when spooled
+func (r *Response) FullBody() ([]byte, error) {
+	if !r.Spooled() {
+		return r.Body, nil
+	}
+	data, err := os.ReadFile(r.BodyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return data, nil
+}
+
+// ReadBodyRange reads up to n bytes from offset of a body held in memory, or in
+// file when it is set. It returns fewer bytes at the end of the body.
+func ReadBodyRange(body []byte, file string, offset int64, n int) ([]byte, error) {
+	if file == "" {
+		if offset >= int64(len(body)) {
+			return nil, nil
+		}
+		end := min(offset+int64(n), int64(len(body)))
+		return body[offset:end], nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	defer f.Close()
+	buf := make([]byte, n)
+	read, err := f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return buf[:read], nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestClient_SpoolsLargeBodies(t *testing.T) {
+	large := bytes.Repeat([]byte("0123456789abcdef"), SpoolBodySize/16+1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/small" {
+			_, _ = w.Write([]byte(`{"ok":true}`))
+			return
+		}
+		_, _ = w.Write(large)
+	}))
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{Method: GET, URL: server.URL + "/small"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.Spooled() || string(resp.Body) != `{"ok":true}` {
+		t.Errorf("small body should stay in memory, got spooled=%v body=%q", resp.Spooled(), resp.Body)
+	}
+
+	resp, err = NewClient().Send(&Request{Method: GET, URL: server.URL + "/large"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !resp.Spooled() {
+		t.Fatal("large body should be spooled to a file")
+	}
+	if resp.Size != int64(len(large)) {
+		t.Errorf("Size = %d, want %d", resp.Size, len(large))
+	}
+	if len(resp.Body) != MaxDisplayBodySize || !bytes.Equal(resp.Body, large[:MaxDisplayBodySize]) {
+		t.Errorf("Body should hold the first %d bytes, got %d", MaxDisplayBodySize, len(resp.Body))
+	}
+
+	full, err := resp.FullBody()
+	if err != nil || !bytes.Equal(full, large) {
+		t.Errorf("FullBody() = %d bytes, %v; want the whole body", len(full), err)
+	}
+	reader, err := resp.OpenBody()
+	if err != nil {
+		t.Fatalf("OpenBody() error = %v", err)
+	}
+	streamed, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(streamed, large) {
+		t.Error("OpenBody() should read the whole body")
+	}
+
+	page, err := ReadBodyRange(resp.Body, resp.BodyFile, int64(len(large))-10, 100)
+	if err != nil || string(page) != string(large[len(large)-10:]) {
+		t.Errorf("ReadBodyRange() at the end = %q, %v", page, err)
+	}
+
+	RemoveSpooledBodies()
+	if _, err := os.Stat(resp.BodyFile); !os.IsNotExist(err) {
+		t.Error("RemoveSpooledBodies() should delete the temporary file")
+	}
+}
+
+func TestReadBodyRange_InMemory(t *testing.T) {
+	body := []byte("hello world")
+	if got, _ := ReadBodyRange(body, "", 6, 100); string(got) != "world" {
+		t.Errorf("ReadBodyRange() = %q, want world", got)
+	}
+	if got, _ := ReadBodyRange(body, "", 20, 5); got != nil {
+		t.Errorf("ReadBodyRange() past the end = %q, want nil", got)
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/format"
+)
+
+// DiffResponses compares a response with a base response: the status line, the headers
+// by name and the bodies as format.DiffBodies does. Changes to the status have the path
+// "status", changes to a header "header Name". Only the part of spooled bodies held in
+// memory is compared.
+func DiffResponses(base, other *Response) *format.BodyDiff {
+	body := format.DiffBodies(base.Body, other.Body)
+	diff := &format.BodyDiff{Structural: body.Structural}
+
+	if statusLine(base) != statusLine(other) {
+		diff.Changes = append(diff.Changes, format.DiffChange{
+			Path: "status", Kind: format.DiffChanged,
+			Expected: statusLine(base), Actual: statusLine(other),
+		})
+	}
+
+	baseHeaders, otherHeaders := canonicalHeaders(base.Headers), canonicalHeaders(other.Headers)
+	names := make([]string, 0, len(baseHeaders)+len(otherHeaders))
+	for name := range baseHeaders {
+		names = append(names, name)
+	}
+	for name := range otherHeaders {
+		if _, ok := baseHeaders[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		before, inBase := baseHeaders[name]
+		after, inOther := otherHeaders[name]
+		change := format.DiffChange{Path: "header " + name, Expected: before, Actual: after}
+		switch {
+		case !inOther:
+			change.Kind = format.DiffRemoved
+		case !inBase:
+			change.Kind = format.DiffAdded
+		case before != after:
+			change.Kind = format.DiffChanged
+		default:
+			continue
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	diff.Changes = append(diff.Changes, body.Changes...)
+	return diff
+}
+
+// statusLine returns the status of a response as "200 OK"
+func statusLine(resp *Response) string {
+	code := strconv.Itoa(resp.StatusCode)
+	if strings.HasPrefix(resp.Status, code) {
+		return resp.Status
+	}
+	return strings.TrimSpace(code + " " + resp.Status)
+}
+
+// canonicalHeaders returns the headers by canonical name, values joined with ", "
+func canonicalHeaders(headers map[string][]string) map[string]string {
+	canonical := make(map[string]string, len(headers))
+	for name, values := range headers {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if existing, ok := canonical[name]; ok {
+			values = append([]string{existing}, values...)
+		}
+		canonical[name] = strings.Join(values, ", ")
+	}
+	return canonical
+}
+
+// ResponseBaseline is a response saved to compare the later responses of a request with
+type ResponseBaseline struct {
+	Saved      time.Time           `json:"saved"`
+	StatusCode int                 `json:"status_code"`
+	Status     string              `json:"status,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// Response returns the saved response
+func (b *ResponseBaseline) Response() *Response {
+	return &Response{StatusCode: b.StatusCode, Status: b.Status, Headers: b.Headers, Body: []byte(b.Body)}
+}
+
+// BaselinePath returns the file of the baseline response of a request in a workspace
+func BaselinePath(workspacePath, requestID string) string {
+	return filepath.Join(workspacePath, ".lazycurl", "baselines", requestID+".json")
+}
+
+// SaveBaseline writes a response to a baseline file
+func SaveBaseline(path string, resp *Response, now time.Time) error {
+	baseline := ResponseBaseline{
+		Saved:      now,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    resp.Headers,
+		Body:       string(resp.Body),
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create baselines directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// LoadBaseline reads a baseline file; the error satisfies os.IsNotExist when there is none
+func LoadBaseline(path string) (*ResponseBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var baseline ResponseBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", filepath.Base(path), err)
+	}
+	return &baseline, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/format"
+)
+
+func TestDiffResponses(t *testing.T) {
+	base := &Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Headers:    map[string][]string{"content-type": {"application/json"}, "X-Request-Id": {"a"}, "Etag": {"1"}},
+		Body:       []byte(`{"id":7,"name":"Ada"}`),
+	}
+	other := &Response{
+		StatusCode: 404,
+		Status:     "Not Found",
+		Headers:    map[string][]string{"Content-Type": {"application/json"}, "X-Request-Id": {"b"}, "Vary": {"Accept"}},
+		Body:       []byte(`{"id":7,"name":"Bob"}`),
+	}
+
+	diff := DiffResponses(base, other)
+	want := []format.DiffChange{
+		{Path: "status", Kind: format.DiffChanged, Expected: "200 OK", Actual: "404 Not Found"},
+		{Path: "header Etag", Kind: format.DiffRemoved, Expected: "1"},
+		{Path: "header Vary", Kind: format.DiffAdded, Actual: "Accept"},
+		{Path: "header X-Request-Id", Kind: format.DiffChanged, Expected: "a", Actual: "b"},
+		{Path: "$.name", Kind: format.DiffChanged, Expected: `"Ada"`, Actual: `"Bob"`},
+	}
+	if !diff.Structural || !reflect.DeepEqual(diff.Changes, want) {
+		t.Errorf("DiffResponses() = %+v\nwant %+v", diff.Changes, want)
+	}
+
+	if diff := DiffResponses(base, base); !diff.Equal() {
+		t.Errorf("DiffResponses(base, base) = %+v, want no changes", diff.Changes)
+	}
+}
+
+func TestSaveBaseline(t *testing.T) {
+	path := BaselinePath(t.TempDir(), "req-1")
+	if _, err := LoadBaseline(path); !os.IsNotExist(err) {
+		t.Fatalf("LoadBaseline() before saving: err = %v, want not exist", err)
+	}
+
+	resp := &Response{StatusCode: 201, Status: "201 Created", Headers: map[string][]string{"Location": {"/users/7"}}, Body: []byte(`{"id":7}`)}
+	saved := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := SaveBaseline(path, resp, saved); err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "baselines" {
+		t.Errorf("baseline path = %s", path)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !baseline.Saved.Equal(saved) {
+		t.Errorf("Saved = %v, want %v", baseline.Saved, saved)
+	}
+	if diff := DiffResponses(baseline.Response(), resp); !diff.Equal() {
+		t.Errorf("baseline differs from the saved response: %+v", diff.Changes)
+	}
+}
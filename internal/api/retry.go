@@ -0,0 +1,132 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout of requests that set none
+const DefaultTimeout = 30 * time.Second
+
+// DefaultRetryDelay is the base delay between attempts of a retry policy that sets none
+const DefaultRetryDelay = time.Second
+
+// Backoff strategies of a retry policy
+const (
+	BackoffFixed       = "fixed"       // The delay between every attempt
+	BackoffLinear      = "linear"      // The delay times the retry number
+	BackoffExponential = "exponential" // The delay doubled after every retry
+)
+
+// BackoffStrategies lists the backoff strategies in the order the Settings tab cycles them
+var BackoffStrategies = []string{BackoffFixed, BackoffLinear, BackoffExponential}
+
+// RetryPolicy resends a request that failed with a network error or one of the
+// OnStatus status codes
+type RetryPolicy struct {
+	Count    int    `json:"count"`               // Retries after the first attempt
+	Backoff  string `json:"backoff,omitempty"`   // BackoffFixed (default), BackoffLinear or BackoffExponential
+	Delay    string `json:"delay,omitempty"`     // Base delay between attempts ("500ms"); empty uses DefaultRetryDelay
+	OnStatus []int  `json:"on_status,omitempty"` // Status codes retried; network errors are always retried
+}
+
+// Validate checks the count, backoff strategy, delay and status codes of the policy
+func (p *RetryPolicy) Validate() error {
+	if p.Count < 0 {
+		return fmt.Errorf("retry count must not be negative")
+	}
+	if p.Backoff != "" && !slices.Contains(BackoffStrategies, p.Backoff) {
+		return fmt.Errorf("unknown backoff %q (use %s)", p.Backoff, strings.Join(BackoffStrategies, ", "))
+	}
+	if _, err := parseRetryDelay(p.Delay); err != nil {
+		return err
+	}
+	for _, code := range p.OnStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid status code %d", code)
+		}
+	}
+	return nil
+}
+
+// RetriesStatus returns true if a response with the status code is retried
+func (p *RetryPolicy) RetriesStatus(code int) bool {
+	return slices.Contains(p.OnStatus, code)
+}
+
+// DelayBefore returns the delay before retry number n (1 for the first retry)
+func (p *RetryPolicy) DelayBefore(n int) time.Duration {
+	delay, err := parseRetryDelay(p.Delay)
+	if err != nil || n < 1 {
+		return 0
+	}
+	switch p.Backoff {
+	case BackoffLinear:
+		return delay * time.Duration(n)
+	case BackoffExponential:
+		return delay << (n - 1)
+	default:
+		return delay
+	}
+}
+
+// Clone returns a copy of the policy, nil for nil
+func (p *RetryPolicy) Clone() *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	clone.OnStatus = slices.Clone(p.OnStatus)
+	return &clone
+}
+
+// parseRetryDelay parses the delay of a retry policy, DefaultRetryDelay when empty
+func parseRetryDelay(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultRetryDelay, nil
+	}
+	delay, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid retry delay %q (use a duration such as 500ms or 2s)", value)
+	}
+	return delay, nil
+}
+
+// ParseTimeout parses the timeout of a request, DefaultTimeout when empty
+func ParseTimeout(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q (use a duration such as 10s or 1m)", value)
+	}
+	return timeout, nil
+}
+
+// ParseStatusCodes parses a comma or space separated list of status codes ("429, 503")
+func ParseStatusCodes(value string) ([]int, error) {
+	var codes []int
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		code, err := strconv.Atoi(field)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q", field)
+		}
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// FormatStatusCodes formats status codes as ParseStatusCodes reads them
+func FormatStatusCodes(codes []int) string {
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = strconv.Itoa(code)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer answers 503 to the first failures requests, then 200
+func newFlakyServer(failures int32, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+}
+
+func TestClient_SendRetriesListedStatus(t *testing.T) {
+	var calls int32
+	server := newFlakyServer(2, &calls)
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{
+		Method: GET,
+		URL:    server.URL,
+		Retry:  &RetryPolicy{Count: 3, Delay: "1ms", OnStatus: []int{503}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Attempts != 3 || calls != 3 {
+		t.Errorf("got %d after %d attempts (%d calls), want 200 after 3", resp.StatusCode, resp.Attempts, calls)
+	}
+}
+
+func TestClient_SendStreamRetriesListedStatus(t *testing.T) {
+	var calls int32
+	server := newFlakyServer(1, &calls)
+	defer server.Close()
+
+	resp, stream, err := NewClient().SendStream(&Request{
+		Method: GET,
+		URL:    server.URL,
+		Retry:  &RetryPolicy{Count: 1, Delay: "1ms", OnStatus: []int{503}},
+	})
+	if err != nil {
+		t.Fatalf("SendStream() error = %v", err)
+	}
+	if stream != nil || resp.StatusCode != http.StatusOK || resp.Attempts != 2 {
+		t.Errorf("got %d after %d attempts, want 200 after 2 without stream", resp.StatusCode, resp.Attempts)
+	}
+}
+
+func TestClient_SendRetriesExhausted(t *testing.T) {
+	var calls int32
+	server := newFlakyServer(5, &calls)
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{
+		Method: GET,
+		URL:    server.URL,
+		Retry:  &RetryPolicy{Count: 2, Delay: "1ms", OnStatus: []int{503}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Attempts != 3 {
+		t.Errorf("got %d after %d attempts, want 503 after 3", resp.StatusCode, resp.Attempts)
+	}
+}
+
+func TestClient_SendDoesNotRetryUnlistedStatus(t *testing.T) {
+	var calls int32
+	server := newFlakyServer(1, &calls)
+	defer server.Close()
+
+	resp, err := NewClient().Send(&Request{
+		Method: GET,
+		URL:    server.URL,
+		Retry:  &RetryPolicy{Count: 3, Delay: "1ms", OnStatus: []int{429}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Attempts != 1 {
+		t.Errorf("got %d after %d attempts, want 503 after 1", resp.StatusCode, resp.Attempts)
+	}
+}
+
+func TestClient_SendRetriesNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	_, err := NewClient().Send(&Request{
+		Method: GET,
+		URL:    url,
+		Retry:  &RetryPolicy{Count: 2, Delay: "1ms"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Send() error = %v, want failure after 3 attempts", err)
+	}
+}
+
+func TestClient_SendTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL, Timeout: 20 * time.Millisecond}); err == nil {
+		t.Error("Send() succeeded, want a timeout")
+	}
+	if client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("client timeout = %s, want the request timeout not to stick", client.httpClient.Timeout)
+	}
+}
+
+func TestRetryPolicy_DelayBefore(t *testing.T) {
+	tests := []struct {
+		backoff string
+		want    []time.Duration
+	}{
+		{"", []time.Duration{100, 100, 100}},
+		{BackoffFixed, []time.Duration{100, 100, 100}},
+		{BackoffLinear, []time.Duration{100, 200, 300}},
+		{BackoffExponential, []time.Duration{100, 200, 400}},
+	}
+	for _, tt := range tests {
+		policy := &RetryPolicy{Count: 3, Backoff: tt.backoff, Delay: "100ms"}
+		for i, want := range tt.want {
+			if got := policy.DelayBefore(i + 1); got != want*time.Millisecond {
+				t.Errorf("%q DelayBefore(%d) = %s, want %s", tt.backoff, i+1, got, want*time.Millisecond)
+			}
+		}
+	}
+
+	if got := (&RetryPolicy{Count: 1}).DelayBefore(1); got != DefaultRetryDelay {
+		t.Errorf("default DelayBefore(1) = %s, want %s", got, DefaultRetryDelay)
+	}
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		wantErr bool
+	}{
+		{"valid", RetryPolicy{Count: 3, Backoff: BackoffExponential, Delay: "250ms", OnStatus: []int{429, 503}}, false},
+		{"negative count", RetryPolicy{Count: -1}, true},
+		{"unknown backoff", RetryPolicy{Count: 1, Backoff: "random"}, true},
+		{"bad delay", RetryPolicy{Count: 1, Delay: "soon"}, true},
+		{"bad status", RetryPolicy{Count: 1, OnStatus: []int{42}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	if got, err := ParseTimeout(""); err != nil || got != DefaultTimeout {
+		t.Errorf("ParseTimeout(\"\") = %s, %v, want %s", got, err, DefaultTimeout)
+	}
+	if got, err := ParseTimeout("1m30s"); err != nil || got != 90*time.Second {
+		t.Errorf("ParseTimeout(1m30s) = %s, %v, want 1m30s", got, err)
+	}
+	for _, value := range []string{"10", "-1s", "0s", "later"} {
+		if _, err := ParseTimeout(value); err == nil {
+			t.Errorf("ParseTimeout(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestParseStatusCodes(t *testing.T) {
+	codes, err := ParseStatusCodes("429, 502,503 503")
+	if err != nil {
+		t.Fatalf("ParseStatusCodes() error = %v", err)
+	}
+	if got := FormatStatusCodes(codes); got != "429, 502, 503" {
+		t.Errorf("codes = %q, want \"429, 502, 503\"", got)
+	}
+	if _, err := ParseStatusCodes("429, abc"); err == nil {
+		t.Error("ParseStatusCodes(\"429, abc\") succeeded, want an error")
+	}
+}
@@ -2,15 +2,17 @@ package api
 
 import (
 	"sync"
+	"time"
 )
 
 // AssertionResult represents the outcome of a test assertion
 type AssertionResult struct {
-	Name     string      `json:"name"`
-	Passed   bool        `json:"passed"`
-	Expected interface{} `json:"expected,omitempty"`
-	Actual   interface{} `json:"actual,omitempty"`
-	Message  string      `json:"message,omitempty"`
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Expected interface{}   `json:"expected,omitempty"`
+	Actual   interface{}   `json:"actual,omitempty"`
+	Message  string        `json:"message,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"` // Time the lc.test function took; 0 for lc.expect outside a test
 }
 
 // AssertionCollector gathers test results during script execution
@@ -39,6 +41,18 @@ func (c *AssertionCollector) RegisterTest(name string, passed bool, expected, ac
 	})
 }
 
+// RegisterTimedTest adds the result of an lc.test function with the time it took
+func (c *AssertionCollector) RegisterTimedTest(name string, passed bool, message string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = append(c.results, AssertionResult{
+		Name:     name,
+		Passed:   passed,
+		Message:  message,
+		Duration: duration,
+	})
+}
+
 // GetResults returns all assertion results
 func (c *AssertionCollector) GetResults() []AssertionResult {
 	c.mu.Lock()
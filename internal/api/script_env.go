@@ -82,6 +82,26 @@ func (e *ScriptEnvironment) Get(name string) string {
 	return ""
 }
 
+// Resolve returns the value of a variable from the most specific scope defining it:
+// the request, the environment (with the script's changes), the collection, then the
+// workspace globals
+func (e *ScriptEnvironment) Resolve(name string) (string, bool) {
+	if e.env == nil {
+		return "", false
+	}
+	if value, ok := e.env.Scopes.Request[name]; ok {
+		return value, true
+	}
+	if e.Has(name) {
+		return e.Get(name), true
+	}
+	if value, ok := e.env.Scopes.Collection[name]; ok {
+		return value, true
+	}
+	value, ok := e.env.Scopes.Globals[name]
+	return value, ok
+}
+
 // Set sets an environment variable value and tracks the change
 func (e *ScriptEnvironment) Set(name, value string) {
 	e.mu.Lock()
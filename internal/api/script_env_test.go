@@ -354,3 +354,31 @@ func TestScriptEnvironment_SetThenUnset(t *testing.T) {
 		t.Error("temp_var should not exist in env after Apply")
 	}
 }
+
+func TestScriptEnvironment_Resolve(t *testing.T) {
+	env := NewScriptEnvironment(&Environment{
+		Variables: map[string]string{"base_url": "https://staging", "token": "env"},
+		Scopes: VariableScopes{
+			Request:    map[string]string{"token": "request"},
+			Collection: map[string]string{"base_url": "https://collection", "page_size": "20"},
+			Globals:    map[string]string{"page_size": "50", "tenant": "acme"},
+		},
+	})
+	env.Set("user", "ada")
+
+	tests := map[string]string{
+		"token":     "request",
+		"base_url":  "https://staging",
+		"page_size": "20",
+		"tenant":    "acme",
+		"user":      "ada",
+	}
+	for name, want := range tests {
+		if got, ok := env.Resolve(name); !ok || got != want {
+			t.Errorf("Resolve(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+	if _, ok := env.Resolve("missing"); ok {
+		t.Error("Resolve() should not find an undefined variable")
+	}
+}
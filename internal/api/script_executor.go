@@ -32,10 +32,15 @@ type ScriptExecutor interface {
 
 // gojaExecutor implements ScriptExecutor using the Goja JavaScript runtime
 type gojaExecutor struct {
-	timeout   time.Duration
-	globals   *ScriptGlobals
-	client    *Client
-	cookieJar *ScriptCookieJar
+	timeout    time.Duration
+	globals    *ScriptGlobals
+	client     *Client
+	cookieJar  *ScriptCookieJar
+	store      *ScriptStore       // Backs lc.store; nil uses DefaultScriptStore
+	library    []ScriptSource     // Scripts run before each script (shared helpers)
+	modules    *scriptModuleCache // Modules compiled for lc.require
+	modulesDir string             // Directory of lc.require; empty uses DefaultScriptModulesDir
+	offline    bool               // Whether lc.sendRequest is disabled
 }
 
 // NewScriptExecutor creates a new script executor instance
@@ -45,6 +50,7 @@ func NewScriptExecutor() ScriptExecutor {
 		globals:   NewScriptGlobals(),
 		client:    NewClient(),
 		cookieJar: NewScriptCookieJar(),
+		modules:   &scriptModuleCache{},
 	}
 }
 
@@ -200,6 +206,12 @@ func (e *gojaExecutor) executeWithTimeout(vm *goja.Runtime, script string) error
 	defer timer.Stop()
 
 	go func() {
+		for _, lib := range e.library {
+			if err := runLibraryScript(vm, lib); err != nil {
+				done <- err
+				return
+			}
+		}
 		_, err := vm.RunString(script)
 		done <- err
 	}()
@@ -219,6 +231,26 @@ func (e *gojaExecutor) executeWithTimeout(vm *goja.Runtime, script string) error
 	return err
 }
 
+// runLibraryScript runs a shared helpers script. Files written as lc.require modules
+// get module, exports and require while they run, so they load too.
+//
+//nolint:errcheck // Goja Set operations are safe in this context
+func runLibraryScript(vm *goja.Runtime, lib ScriptSource) error {
+	global := vm.GlobalObject()
+	module := vm.NewObject()
+	module.Set("exports", vm.NewObject())                               // #nosec G104 -- Goja Set safe here
+	global.Set("module", module)                                        // #nosec G104 -- Goja Set safe here
+	global.Set("exports", module.Get("exports"))                        // #nosec G104 -- Goja Set safe here
+	global.Set("require", global.Get("lc").ToObject(vm).Get("require")) // #nosec G104 -- Goja Set safe here
+	defer func() {
+		global.Delete("module")  // #nosec G104 -- Goja Delete safe here
+		global.Delete("exports") // #nosec G104 -- Goja Delete safe here
+		global.Delete("require") // #nosec G104 -- Goja Delete safe here
+	}()
+	_, err := vm.RunScript(lib.Name, lib.Source)
+	return err
+}
+
 // setupConsole binds console object to the runtime
 //
 // #nosec G104 -- Goja Set returns error only for invalid types, safe here
@@ -292,6 +324,16 @@ func (e *gojaExecutor) setupLCObject(vm *goja.Runtime, req *ScriptRequest, resp
 		return err
 	}
 
+	// Setup lc.store
+	if err := e.setupLCStore(vm, lc); err != nil {
+		return err
+	}
+
+	// Setup lc.require
+	if err := e.setupLCRequire(vm, lc); err != nil {
+		return err
+	}
+
 	// Setup lc.sendRequest
 	if err := e.setupLCSendRequest(vm, lc, env); err != nil {
 		return err
@@ -313,7 +355,12 @@ func (e *gojaExecutor) setupLCObject(vm *goja.Runtime, req *ScriptRequest, resp
 	}
 
 	// Setup lc.variables
-	if err := e.setupLCVariables(vm, lc); err != nil {
+	if err := e.setupLCVariables(vm, lc, env); err != nil {
+		return err
+	}
+
+	// Setup lc.time
+	if err := e.setupLCTime(vm, lc); err != nil {
 		return err
 	}
 
@@ -742,6 +789,11 @@ func (e *gojaExecutor) setupLCSendRequest(vm *goja.Runtime, lc *goja.Object, env
 			return goja.Undefined()
 		}
 
+		if e.offline {
+			_, _ = callback(goja.Undefined(), vm.ToValue("lc.sendRequest is not available offline"), goja.Undefined())
+			return goja.Undefined()
+		}
+
 		reqMap, ok := reqArg.(map[string]interface{})
 		if !ok {
 			// Call callback with error
@@ -813,10 +865,10 @@ func (e *gojaExecutor) setupLCSendRequest(vm *goja.Runtime, lc *goja.Object, env
 
 		// Body with json() helper
 		bodyObj := vm.NewObject()
-		bodyObj.Set("raw", resp.Body)
+		bodyObj.Set("raw", resp.BodyString())
 		bodyObj.Set("json", func(call goja.FunctionCall) goja.Value {
 			var data interface{}
-			if err := json.Unmarshal([]byte(resp.Body), &data); err != nil {
+			if err := json.Unmarshal(resp.Body, &data); err != nil {
 				return goja.Undefined()
 			}
 			return vm.ToValue(data)
@@ -836,7 +888,7 @@ func (e *gojaExecutor) replaceEnvVars(s string, env *ScriptEnvironment) string {
 	re := regexp.MustCompile(`\{\{([^}]+)\}\}`)
 	return re.ReplaceAllStringFunc(s, func(match string) string {
 		varName := strings.TrimSpace(match[2 : len(match)-2])
-		if val := env.Get(varName); val != "" {
+		if val, ok := env.Resolve(varName); ok && val != "" {
 			return val
 		}
 		// Check globals
@@ -868,11 +920,12 @@ func (e *gojaExecutor) setupLCTest(vm *goja.Runtime, lc *goja.Object, assertions
 		}
 
 		// Execute test function
+		start := time.Now()
 		_, err := fn(goja.Undefined())
 		if err != nil {
-			assertions.RegisterTest(name, false, nil, nil, err.Error())
+			assertions.RegisterTimedTest(name, false, err.Error(), time.Since(start))
 		} else {
-			assertions.RegisterTest(name, true, nil, nil, "")
+			assertions.RegisterTimedTest(name, true, "", time.Since(start))
 		}
 
 		return goja.Undefined()
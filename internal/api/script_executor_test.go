@@ -945,6 +945,12 @@ func TestExecutePostResponse_TestAssertions(t *testing.T) {
 	if !result.Assertions[1].Passed || result.Assertions[1].Name != "Response has users array" {
 		t.Errorf("Second assertion failed: %+v", result.Assertions[1])
 	}
+
+	for _, a := range result.Assertions {
+		if a.Duration <= 0 {
+			t.Errorf("assertion %q has no duration", a.Name)
+		}
+	}
 }
 
 func TestExecutePostResponse_FailedAssertion(t *testing.T) {
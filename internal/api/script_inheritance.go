@@ -0,0 +1,107 @@
+package api
+
+import (
+	"strings"
+)
+
+// InheritedScript is the scripts of a collection or folder, run around the scripts of
+// each request it holds
+type InheritedScript struct {
+	Owner   string // "collection <name>" or "folder <path>"
+	Scripts ScriptConfig
+}
+
+// Empty reports whether neither script has content
+func (s *ScriptConfig) Empty() bool {
+	return s == nil || (strings.TrimSpace(s.PreRequest) == "" && strings.TrimSpace(s.PostRequest) == "")
+}
+
+// Script returns the pre-request script, or the post-response script when pre is false
+func (s *ScriptConfig) Script(pre bool) string {
+	if s == nil {
+		return ""
+	}
+	if pre {
+		return s.PreRequest
+	}
+	return s.PostRequest
+}
+
+// SetScript replaces the pre-request script, or the post-response script when pre is
+// false, and returns the resulting config: nil once both scripts are empty
+func (s *ScriptConfig) SetScript(pre bool, script string) *ScriptConfig {
+	updated := ScriptConfig{}
+	if s != nil {
+		updated = *s
+	}
+	if pre {
+		updated.PreRequest = script
+	} else {
+		updated.PostRequest = script
+	}
+	if updated.Empty() {
+		return nil
+	}
+	return &updated
+}
+
+// RequestFolderPath returns the names of the folders holding the request with id, nil
+// when the collection has no such request
+func (c *CollectionFile) RequestFolderPath(id string) []string {
+	return findRequestFolderPath(c, id)
+}
+
+// InheritedScripts returns the scripts of the collection and of each folder on
+// folderPath, outermost first, leaving out those without content. They run before
+// (pre-request) and after (post-response) the scripts of the requests in the folder.
+func (c *CollectionFile) InheritedScripts(folderPath []string) []InheritedScript {
+	var scripts []InheritedScript
+	if !c.Scripts.Empty() {
+		scripts = append(scripts, InheritedScript{Owner: "collection " + c.Name, Scripts: *c.Scripts})
+	}
+	folders := c.Folders
+	for i, name := range folderPath {
+		var folder *Folder
+		for j := range folders {
+			if folders[j].Name == name {
+				folder = &folders[j]
+				break
+			}
+		}
+		if folder == nil {
+			break
+		}
+		if !folder.Scripts.Empty() {
+			owner := "folder " + strings.Join(folderPath[:i+1], " / ")
+			scripts = append(scripts, InheritedScript{Owner: owner, Scripts: *folder.Scripts})
+		}
+		folders = folder.Folders
+	}
+	return scripts
+}
+
+// ChainScripts joins the pre-request (pre) or post-response scripts of inherited, then
+// script, the request's own, into one script: collection, folders, request. Each part
+// runs in its own function scope, so their declarations do not clash, and sees the
+// changes of the parts before it; an error stops the chain. script is returned as is
+// when no inherited script has content.
+func ChainScripts(inherited []InheritedScript, script string, pre bool) string {
+	var parts []string
+	for _, level := range inherited {
+		if part := strings.TrimSpace(level.Scripts.Script(pre)); part != "" {
+			parts = append(parts, chainPart(level.Owner, part))
+		}
+	}
+	if len(parts) == 0 {
+		return script
+	}
+	if strings.TrimSpace(script) != "" {
+		parts = append(parts, chainPart("request", script))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// chainPart wraps the script of owner in a function scope, under a comment naming owner
+func chainPart(owner, script string) string {
+	return "// " + owner + "\n(function () {\n" + script + "\n})();"
+}
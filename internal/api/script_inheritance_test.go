@@ -0,0 +1,64 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInheritedScripts(t *testing.T) {
+	col := &CollectionFile{
+		Name:    "Shop",
+		Scripts: &ScriptConfig{PreRequest: `lc.request.headers.set("X-Order", "collection");`},
+		Folders: []Folder{{
+			Name: "Users",
+			Folders: []Folder{{
+				Name:     "Admin",
+				Scripts:  &ScriptConfig{PreRequest: `const level = "folder"; lc.request.headers.set("X-Order", lc.request.headers.get("X-Order") + "," + level);`},
+				Requests: []CollectionRequest{{ID: "promote", Name: "Promote"}},
+			}},
+			Scripts: &ScriptConfig{PostRequest: "lc.test('folder', function () {});"},
+		}},
+	}
+
+	if got := col.RequestFolderPath("promote"); strings.Join(got, "/") != "Users/Admin" {
+		t.Fatalf("RequestFolderPath() = %v, want [Users Admin]", got)
+	}
+	inherited := col.InheritedScripts([]string{"Users", "Admin"})
+	var owners []string
+	for _, level := range inherited {
+		owners = append(owners, level.Owner)
+	}
+	if got := strings.Join(owners, "; "); got != "collection Shop; folder Users; folder Users / Admin" {
+		t.Errorf("InheritedScripts() owners = %q", got)
+	}
+
+	// Each level runs in its own scope, in order: collection, folders, request
+	script := ChainScripts(inherited, `const level = "request"; lc.request.headers.set("X-Order", lc.request.headers.get("X-Order") + "," + level);`, true)
+	req := NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"})
+	if _, err := NewScriptExecutor().ExecutePreRequest(script, req, nil); err != nil {
+		t.Fatalf("ExecutePreRequest() error = %v\n%s", err, script)
+	}
+	if got := req.GetHeader("X-Order"); got != "collection,folder,request" {
+		t.Errorf("X-Order = %q, want collection,folder,request", got)
+	}
+
+	// Levels without a script of the kind are left out
+	if got := ChainScripts(inherited, "lc.log(1)", false); !strings.Contains(got, "// folder Users\n") || strings.Contains(got, "collection") {
+		t.Errorf("ChainScripts(post) = %q, want the folder post-response script only", got)
+	}
+	// Without inherited scripts the request script is unchanged
+	if got := ChainScripts(nil, "lc.log(1)", true); got != "lc.log(1)" {
+		t.Errorf("ChainScripts(nil) = %q, want the request script", got)
+	}
+}
+
+func TestScriptConfig_SetScript(t *testing.T) {
+	var s *ScriptConfig
+	s = s.SetScript(true, "lc.log(1)")
+	if s == nil || s.PreRequest != "lc.log(1)" || s.Script(false) != "" {
+		t.Fatalf("SetScript() = %+v", s)
+	}
+	if s = s.SetScript(true, "  "); s != nil {
+		t.Errorf("SetScript() with empty scripts = %+v, want nil", s)
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// DefaultScriptModulesDir is the directory lc.require loads modules from. It is set to
+// the scripts directory of the workspace at startup.
+var DefaultScriptModulesDir string
+
+// ScriptModulesDir returns the script modules directory of a workspace
+func ScriptModulesDir(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", "scripts")
+}
+
+// scriptModule is a compiled module, with the file state it was compiled from
+type scriptModule struct {
+	program *goja.Program
+	modTime time.Time
+	size    int64
+}
+
+// scriptModuleCache holds the compiled modules of an executor. A module is compiled
+// again when its file changes.
+type scriptModuleCache struct {
+	modules map[string]scriptModule
+	mu      sync.Mutex
+}
+
+// load returns the compiled module at path, named name in stack traces
+func (c *scriptModuleCache) load(path, name string) (*goja.Program, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("module %s not found", name)
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if module, ok := c.modules[path]; ok && module.modTime.Equal(info.ModTime()) && module.size == info.Size() {
+		return module.program, nil
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// The wrapper starts on the first line of the module to keep its line numbers
+	program, err := goja.Compile(name, "(function (module, exports, require) {"+string(source)+"\n})", false)
+	if err != nil {
+		return nil, err
+	}
+	if c.modules == nil {
+		c.modules = make(map[string]scriptModule)
+	}
+	c.modules[path] = scriptModule{program: program, modTime: info.ModTime(), size: info.Size()}
+	return program, nil
+}
+
+// resolveModule returns the file of module name in dir, and its name relative to dir:
+// "auth-helpers" and "./auth-helpers.js" are dir/auth-helpers.js
+func resolveModule(dir, name string) (string, string, error) {
+	if dir == "" {
+		return "", "", fmt.Errorf("no scripts directory to load %s from", name)
+	}
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(name, "./")))
+	if filepath.Ext(rel) != ".js" {
+		rel += ".js"
+	}
+	if name == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("invalid module name %q", name)
+	}
+	return filepath.Join(dir, rel), filepath.ToSlash(rel), nil
+}
+
+// setupLCRequire creates lc.require, loading CommonJS modules from the scripts
+// directory. A module runs once per script; its compiled form is cached by the executor.
+//
+//nolint:errcheck,unparam // Goja Set operations are safe in this context, error for interface consistency
+func (e *gojaExecutor) setupLCRequire(vm *goja.Runtime, lc *goja.Object) error {
+	dir := e.modulesDir
+	if dir == "" {
+		dir = DefaultScriptModulesDir
+	}
+	if e.modules == nil {
+		e.modules = &scriptModuleCache{}
+	}
+	loaded := make(map[string]*goja.Object) // Modules run by this script, by path
+
+	var require goja.Value
+	require = vm.ToValue(func(call goja.FunctionCall) goja.Value {
+		path, name, err := resolveModule(dir, call.Argument(0).String())
+		if err != nil {
+			panic(vm.ToValue("lc.require: " + err.Error()))
+		}
+		// A module required again, including by a module it requires, gets its exports so far
+		if module, ok := loaded[path]; ok {
+			return module.Get("exports")
+		}
+		program, err := e.modules.load(path, name)
+		if err != nil {
+			panic(vm.ToValue("lc.require: " + err.Error()))
+		}
+
+		wrapper, err := vm.RunProgram(program)
+		if err != nil {
+			panic(err)
+		}
+		fn, _ := goja.AssertFunction(wrapper)
+		module := vm.NewObject()
+		exports := vm.NewObject()
+		module.Set("exports", exports) // #nosec G104 -- Goja Set safe here
+		loaded[path] = module
+		if _, err := fn(goja.Undefined(), module, exports, require); err != nil {
+			delete(loaded, path)
+			panic(err)
+		}
+		return module.Get("exports")
+	})
+
+	lc.Set("require", require)
+	return nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLCRequire(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, source string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("auth-helpers.js", `
+		var prefix = require("lib/prefix");
+		exports.bearer = function (token) { return prefix.scheme + " " + token; };
+		exports.loads = (exports.loads || 0) + 1;
+	`)
+	write("lib/prefix.js", `module.exports = { scheme: "Bearer" };`)
+	write("broken.js", "var x = 1;\nthrow new Error(\"boom\");")
+
+	DefaultScriptModulesDir = dir
+	t.Cleanup(func() { DefaultScriptModulesDir = "" })
+	executor := NewScriptExecutor()
+	req := NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"})
+
+	result, err := executor.ExecutePreRequest(`
+		var auth = lc.require("auth-helpers");
+		lc.request.headers.set("Authorization", auth.bearer("abc"));
+		lc.test("module runs once", function () {
+			lc.expect(lc.require("./auth-helpers.js") === auth).toBe(true);
+			lc.expect(auth.loads).toBe(1);
+		});
+	`, req, nil)
+	if err != nil || result.HasAssertionFailures() {
+		t.Fatalf("ExecutePreRequest() error = %v, assertions %+v", err, result.Assertions)
+	}
+	if got := req.GetHeader("Authorization"); got != "Bearer abc" {
+		t.Errorf("Authorization = %q, want Bearer abc", got)
+	}
+
+	// A changed module is compiled again
+	write("lib/prefix.js", `module.exports = { scheme: "Token" };`)
+	req = NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"})
+	if _, err := executor.ExecutePreRequest(`lc.request.headers.set("Authorization", lc.require("auth-helpers").bearer("abc"))`, req, nil); err != nil {
+		t.Fatalf("ExecutePreRequest() error = %v", err)
+	}
+	if got := req.GetHeader("Authorization"); got != "Token abc" {
+		t.Errorf("Authorization = %q after the module changed, want Token abc", got)
+	}
+
+	for script, want := range map[string]string{
+		`lc.require("missing")`:    "module missing.js not found",
+		`lc.require("../secrets")`: "invalid module name",
+		`lc.require("broken")`:     "boom",
+	} {
+		if _, err := executor.ExecutePreRequest(script, req, nil); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s error = %v, want %q", script, err, want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// ScriptStoreFileName is the name of the lc.store file in the .lazycurl directory
+const ScriptStoreFileName = "store.json"
+
+// DefaultScriptStore backs lc.store in scripts; nil keeps the values of each executor
+// in memory. It is set to the workspace store at startup.
+var DefaultScriptStore *ScriptStore
+
+// storeEntry is a value of the script store, with its expiry
+type storeEntry struct {
+	Value     interface{} `json:"value"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
+
+// scriptStoreFile is the JSON form of a script store file
+type scriptStoreFile struct {
+	Entries map[string]storeEntry `json:"entries"`
+}
+
+// ScriptStore is the key-value store of lc.store. Unlike ScriptGlobals, its values are
+// saved to a workspace file after every change, so they outlive the application, and
+// can expire.
+type ScriptStore struct {
+	entries map[string]storeEntry
+	path    string // Empty keeps the values in memory
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewScriptStore creates a store kept in memory
+func NewScriptStore() *ScriptStore {
+	return &ScriptStore{entries: make(map[string]storeEntry), now: time.Now}
+}
+
+// ScriptStorePath returns the script store file path of a workspace
+func ScriptStorePath(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", ScriptStoreFileName)
+}
+
+// LoadScriptStore reads the script store of a workspace. A missing file yields an
+// empty store.
+func LoadScriptStore(workspacePath string) (*ScriptStore, error) {
+	s := NewScriptStore()
+	s.path = ScriptStorePath(workspacePath)
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read script store: %w", err)
+	}
+	var file scriptStoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return s, fmt.Errorf("failed to parse script store: %w", err)
+	}
+	if file.Entries != nil {
+		s.entries = file.Entries
+	}
+	return s, nil
+}
+
+// Get returns the value stored under name, unless it expired
+func (s *ScriptStore) Get(name string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[name]
+	if !ok || s.expired(entry) {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set stores value under name and saves the store. A positive ttl expires the value
+// after that long.
+func (s *ScriptStore) Set(name string, value interface{}, ttl time.Duration) error {
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Errorf("value of %s cannot be stored: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := storeEntry{Value: value}
+	if ttl > 0 {
+		expiresAt := s.now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+	s.entries[name] = entry
+	return s.save()
+}
+
+// Delete removes the value stored under name and saves the store
+func (s *ScriptStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[name]; !ok {
+		return nil
+	}
+	delete(s.entries, name)
+	return s.save()
+}
+
+// expired reports whether entry is past its expiry
+func (s *ScriptStore) expired(entry storeEntry) bool {
+	return entry.ExpiresAt != nil && !s.now().Before(*entry.ExpiresAt)
+}
+
+// save drops the expired values and writes the store file, if the store has one
+func (s *ScriptStore) save() error {
+	for name, entry := range s.entries {
+		if s.expired(entry) {
+			delete(s.entries, name)
+		}
+	}
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(scriptStoreFile{Entries: s.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// parseStoreTTL reads the ttl argument of lc.store.set: seconds, or a duration such as "10m"
+func parseStoreTTL(value goja.Value) (time.Duration, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return 0, nil
+	}
+	if text, ok := value.Export().(string); ok {
+		return time.ParseDuration(text)
+	}
+	return time.Duration(value.ToFloat() * float64(time.Second)), nil
+}
+
+// setupLCStore creates the lc.store object, backed by DefaultScriptStore
+//
+//nolint:errcheck,unparam // Goja Set operations are safe in this context, error for interface consistency
+func (e *gojaExecutor) setupLCStore(vm *goja.Runtime, lc *goja.Object) error {
+	store := e.store
+	if store == nil {
+		store = DefaultScriptStore
+	}
+	if store == nil {
+		e.store = NewScriptStore()
+		store = e.store
+	}
+	storeObj := vm.NewObject()
+
+	// lc.store.get(name) - Stored value, undefined when missing or expired
+	storeObj.Set("get", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) == 0 {
+			return goja.Undefined()
+		}
+		value, ok := store.Get(call.Arguments[0].String())
+		if !ok {
+			return goja.Undefined()
+		}
+		return vm.ToValue(value)
+	})
+
+	// lc.store.has(name) - Whether a value is stored and not expired
+	storeObj.Set("has", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) == 0 {
+			return vm.ToValue(false)
+		}
+		_, ok := store.Get(call.Arguments[0].String())
+		return vm.ToValue(ok)
+	})
+
+	// lc.store.set(name, value, ttl) - Store a JSON value, expiring after ttl (seconds or "10m") if given
+	storeObj.Set("set", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) < 2 {
+			panic(vm.ToValue("lc.store.set expects a name and a value"))
+		}
+		ttl, err := parseStoreTTL(call.Argument(2))
+		if err != nil {
+			panic(vm.ToValue("lc.store.set: invalid ttl: " + err.Error()))
+		}
+		if err := store.Set(call.Arguments[0].String(), call.Arguments[1].Export(), ttl); err != nil {
+			panic(vm.ToValue("lc.store.set: " + err.Error()))
+		}
+		return goja.Undefined()
+	})
+
+	// lc.store.delete(name) - Remove a stored value
+	storeObj.Set("delete", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) == 0 {
+			return goja.Undefined()
+		}
+		if err := store.Delete(call.Arguments[0].String()); err != nil {
+			panic(vm.ToValue("lc.store.delete: " + err.Error()))
+		}
+		return goja.Undefined()
+	})
+
+	lc.Set("store", storeObj)
+	return nil
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScriptStore_Persists(t *testing.T) {
+	dir := t.TempDir()
+	store, err := LoadScriptStore(dir)
+	if err != nil {
+		t.Fatalf("LoadScriptStore() error = %v", err)
+	}
+	if err := store.Set("token", "abc", 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set("counter", map[string]interface{}{"n": 3}, time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set("fn", func() {}, 0); err == nil {
+		t.Error("Set() should fail for a value that is not JSON")
+	}
+
+	reloaded, err := LoadScriptStore(dir)
+	if err != nil {
+		t.Fatalf("LoadScriptStore() error = %v", err)
+	}
+	if value, ok := reloaded.Get("token"); !ok || value != "abc" {
+		t.Errorf("Get(token) = %v, %v after reload", value, ok)
+	}
+	if value, ok := reloaded.Get("counter"); !ok || value.(map[string]interface{})["n"] != float64(3) {
+		t.Errorf("Get(counter) = %v, %v after reload", value, ok)
+	}
+
+	if err := reloaded.Delete("token"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if again, _ := LoadScriptStore(dir); again != nil {
+		if _, ok := again.Get("token"); ok {
+			t.Error("deleted value is still stored")
+		}
+	}
+}
+
+func TestScriptStore_TTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewScriptStore()
+	store.now = func() time.Time { return now }
+
+	_ = store.Set("token", "abc", time.Minute)
+	if _, ok := store.Get("token"); !ok {
+		t.Fatal("value should be stored before its ttl")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := store.Get("token"); ok {
+		t.Error("value should expire after its ttl")
+	}
+	_ = store.Set("other", 1, 0)
+	if len(store.entries) != 1 {
+		t.Errorf("expired values should be dropped on save, got %v", store.entries)
+	}
+}
+
+func TestLCStore(t *testing.T) {
+	DefaultScriptStore = NewScriptStore()
+	t.Cleanup(func() { DefaultScriptStore = nil })
+
+	executor := NewScriptExecutor()
+	result, err := executor.ExecutePreRequest(`
+		lc.store.set("token", "abc", "10m");
+		lc.store.set("count", (lc.store.get("count") || 0) + 1);
+		lc.store.set("gone", true);
+		lc.store.delete("gone");
+	`, NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"}), nil)
+	if err != nil || result.Error != nil {
+		t.Fatalf("ExecutePreRequest() error = %v, %v", err, result.Error)
+	}
+
+	// A new executor reads the values stored by the first
+	result, err = NewScriptExecutor().ExecutePreRequest(`
+		lc.test("store", function() {
+			lc.expect(lc.store.get("token")).toBe("abc");
+			lc.expect(lc.store.get("count")).toBe(1);
+			lc.expect(lc.store.has("gone")).toBe(false);
+			lc.expect(lc.store.get("missing")).toBe(undefined);
+		});
+	`, NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"}), nil)
+	if err != nil || result.Error != nil || result.HasAssertionFailures() {
+		t.Errorf("stored values = %+v, %v", result.Assertions, err)
+	}
+
+	result, _ = executor.ExecutePreRequest(`lc.store.set("token", "abc", "soon")`, NewScriptRequest(&CollectionRequest{Method: GET, URL: "https://example.com"}), nil)
+	if result.Error == nil {
+		t.Error("lc.store.set should fail for an invalid ttl")
+	}
+}
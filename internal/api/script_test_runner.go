@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScriptTestSuffix identifies script unit-test files in the scripts directory
+const ScriptTestSuffix = "_test.js"
+
+// ScriptSource is a named JavaScript source file
+type ScriptSource struct {
+	Name   string
+	Source string
+}
+
+// ScriptTestFixture describes the mocked request, response and environment a
+// test file runs against. It is read from "<name>_test.json" next to the test file.
+type ScriptTestFixture struct {
+	Request struct {
+		Method  string            `json:"method"`
+		URL     string            `json:"url"`
+		Headers map[string]string `json:"headers"`
+		Body    interface{}       `json:"body"`
+	} `json:"request"`
+	Response struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers"`
+		Body    interface{}       `json:"body"`
+		Time    int64             `json:"time"`
+	} `json:"response"`
+	Environment map[string]string `json:"environment"`
+}
+
+// ScriptTestResult is the outcome of one test file
+type ScriptTestResult struct {
+	File   string        // Path relative to the scripts directory
+	Result *ScriptResult // Assertions, console output and script error
+	Err    error         // Fixture or file error (the script did not run)
+}
+
+// Passed returns true if the file ran without errors and all assertions passed
+func (r ScriptTestResult) Passed() bool {
+	return r.Err == nil && r.Result != nil && r.Result.Error == nil && !r.Result.HasAssertionFailures()
+}
+
+// RunScriptTests runs every *_test.js file under dir against mocked request and
+// response objects. The other .js files in dir are loaded first, so tests can
+// call the shared helpers they define. lc.sendRequest is disabled.
+func RunScriptTests(dir string, timeout time.Duration) ([]ScriptTestResult, error) {
+	library, tests, err := findScriptFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := &gojaExecutor{
+		timeout:    timeout,
+		globals:    NewScriptGlobals(),
+		client:     NewClient(),
+		cookieJar:  NewScriptCookieJar(),
+		library:    library,
+		modulesDir: dir,
+		offline:    true,
+	}
+	if executor.timeout <= 0 {
+		executor.timeout = 5 * time.Second
+	}
+
+	results := make([]ScriptTestResult, 0, len(tests))
+	for _, test := range tests {
+		results = append(results, executor.runScriptTest(dir, test))
+	}
+	return results, nil
+}
+
+// runScriptTest runs a single test file with a fresh cookie jar, globals and store
+func (e *gojaExecutor) runScriptTest(dir, file string) ScriptTestResult {
+	result := ScriptTestResult{File: file}
+	path := filepath.Join(dir, file)
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	fixture, err := loadScriptTestFixture(strings.TrimSuffix(path, ".js") + ".json")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	e.globals = NewScriptGlobals()
+	e.cookieJar = NewScriptCookieJar()
+	e.store = NewScriptStore()
+
+	req, resp, env := fixture.mocks(strings.TrimSuffix(filepath.Base(file), ScriptTestSuffix))
+	result.Result, _ = e.ExecutePostResponse(string(source), req, resp, env)
+	return result
+}
+
+// findScriptFiles returns the library sources and test file paths under dir, sorted by path
+func findScriptFiles(dir string) ([]ScriptSource, []string, error) {
+	var library []ScriptSource
+	var tests []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".js" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(path, ScriptTestSuffix) {
+			tests = append(tests, rel)
+			return nil
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		library = append(library, ScriptSource{Name: rel, Source: string(source)})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(library, func(i, j int) bool { return library[i].Name < library[j].Name })
+	sort.Strings(tests)
+	return library, tests, nil
+}
+
+// loadScriptTestFixture reads a fixture file; a missing file yields the defaults
+func loadScriptTestFixture(path string) (*ScriptTestFixture, error) {
+	fixture := &ScriptTestFixture{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fixture, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", filepath.Base(path), err)
+	}
+	return fixture, nil
+}
+
+// mocks builds the script objects described by the fixture.
+// Defaults: GET http://localhost/ answered with 200 OK and an empty body.
+func (f *ScriptTestFixture) mocks(name string) (*ScriptRequest, *ScriptResponse, *Environment) {
+	method := strings.ToUpper(f.Request.Method)
+	if method == "" {
+		method = "GET"
+	}
+	url := f.Request.URL
+	if url == "" {
+		url = "http://localhost/"
+	}
+	req := NewScriptRequest(&CollectionRequest{
+		Name:       name,
+		Method:     HTTPMethod(method),
+		URL:        url,
+		HeadersMap: f.Request.Headers,
+		Body:       &BodyConfig{Type: "raw", Content: fixtureBody(f.Request.Body)},
+	})
+
+	status := f.Response.Status
+	if status == 0 {
+		status = 200
+	}
+	headers := f.Response.Headers
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	resp := NewScriptResponseFromData(status, fmt.Sprintf("%d %s", status, http.StatusText(status)), headers, fixtureBody(f.Response.Body), f.Response.Time)
+
+	env := &Environment{Name: "test", Variables: make(map[string]string)}
+	for key, value := range f.Environment {
+		env.Variables[key] = value
+	}
+	return req, resp, env
+}
+
+// fixtureBody returns a fixture body as text: strings as-is, anything else as JSON
+func fixtureBody(body interface{}) string {
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeScriptFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRunScriptTests(t *testing.T) {
+	dir := writeScriptFiles(t, map[string]string{
+		"helpers.js":      `function bearer(token) { return "Bearer " + token; }`,
+		"auth/jwt.js":     `function isCreated(status) { return status === 201; }`,
+		"helpers_test.js": `lc.test("bearer prefixes the token", function() { lc.expect(bearer(lc.environment.get("token"))).toBe("Bearer abc"); });`,
+		"created_test.js": `
+lc.test("status is created", function() { lc.expect(isCreated(lc.response.status)).toBeTruthy(); });
+lc.test("body is parsed", function() { lc.expect(lc.response.body.json().id).toBe(7); });
+lc.test("request is mocked", function() { lc.expect(lc.request.method).toBe("POST"); });
+`,
+		"created_test.json": `{
+  "request": {"method": "post", "url": "https://api.test/users"},
+  "response": {"status": 201, "headers": {"Content-Type": "application/json"}, "body": {"id": 7}}
+}`,
+		"failing_test.js": `lc.test("wrong", function() { lc.expect(1).toBe(2); });`,
+		"broken_test.js":  `undefinedHelper();`,
+		"offline_test.js": `
+var failed = false;
+lc.sendRequest({url: "http://192.0.2.1/"}, function(err) { failed = !!err; });
+lc.test("sendRequest is disabled", function() { lc.expect(failed).toBeTruthy(); });
+`,
+		"helpers_test.json": `{"environment": {"token": "abc"}}`,
+		"notes.txt":         "ignored",
+	})
+
+	results, err := RunScriptTests(dir, time.Second)
+	if err != nil {
+		t.Fatalf("RunScriptTests() error = %v", err)
+	}
+
+	want := map[string]bool{
+		"broken_test.js":  false,
+		"created_test.js": true,
+		"failing_test.js": false,
+		"helpers_test.js": true,
+		"offline_test.js": true,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, result := range results {
+		passed, ok := want[result.File]
+		if !ok {
+			t.Errorf("unexpected test file %s", result.File)
+			continue
+		}
+		if result.Passed() != passed {
+			t.Errorf("%s: Passed() = %v, want %v (err=%v, result=%+v)", result.File, result.Passed(), passed, result.Err, result.Result)
+		}
+	}
+
+	// Results are sorted by file name
+	if results[0].File != "broken_test.js" || results[0].Result.Error == nil {
+		t.Errorf("expected broken_test.js first with a script error, got %+v", results[0])
+	}
+}
+
+func TestRunScriptTests_Modules(t *testing.T) {
+	dir := writeScriptFiles(t, map[string]string{
+		"auth-helpers.js":      `exports.bearer = function(token) { return require("scheme").name + " " + token; };`,
+		"scheme.js":            `module.exports = { name: "Bearer" };`,
+		"auth-helpers_test.js": `lc.test("bearer", function() { lc.expect(lc.require("auth-helpers").bearer("abc")).toBe("Bearer abc"); });`,
+	})
+
+	results, err := RunScriptTests(dir, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !results[0].Passed() {
+		t.Errorf("module test should pass, got %+v", results[0].Result)
+	}
+}
+
+func TestRunScriptTests_InvalidFixture(t *testing.T) {
+	dir := writeScriptFiles(t, map[string]string{
+		"a_test.js":   `lc.test("x", function() {});`,
+		"a_test.json": `{not json`,
+	})
+
+	results, err := RunScriptTests(dir, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Err == nil || results[0].Passed() {
+		t.Errorf("expected fixture error, got %+v", results)
+	}
+}
+
+func TestRunScriptTests_MissingDir(t *testing.T) {
+	if _, err := RunScriptTests(filepath.Join(t.TempDir(), "missing"), time.Second); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// setupLCTime creates the lc.time object for timezone and locale-aware dates
+// Layouts are Go layouts ("2006-01-02") or presets ("rfc3339", "rfc1123", "http", "unix", ...)
+//
+// #nosec G104 -- Goja Set returns error only for invalid types, safe here
+//
+//nolint:errcheck,unparam // Goja Set operations are safe in this context, error for interface consistency
+func (e *gojaExecutor) setupLCTime(vm *goja.Runtime, lc *goja.Object) error {
+	timeObj := vm.NewObject()
+
+	// argString returns the i-th argument as a string, "" when missing or undefined
+	argString := func(call goja.FunctionCall, i int) string {
+		arg := call.Argument(i)
+		if goja.IsUndefined(arg) || goja.IsNull(arg) {
+			return ""
+		}
+		return arg.String()
+	}
+
+	// argTime converts a Date, a millisecond timestamp or an RFC 3339 string to a time
+	argTime := func(arg goja.Value) time.Time {
+		if goja.IsUndefined(arg) || goja.IsNull(arg) {
+			return time.Now()
+		}
+		switch v := arg.Export().(type) {
+		case time.Time:
+			return v
+		case int64:
+			return time.UnixMilli(v)
+		case float64:
+			return time.UnixMilli(int64(v))
+		case string:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				panic(vm.ToValue("Invalid date " + v + ": expected RFC 3339"))
+			}
+			return t
+		}
+		panic(vm.ToValue("Invalid date " + arg.String()))
+	}
+
+	// toDate converts a time to a JavaScript Date
+	toDate := func(t time.Time) goja.Value {
+		date, err := vm.New(vm.Get("Date"), vm.ToValue(t.UnixMilli()))
+		if err != nil {
+			panic(err)
+		}
+		return date
+	}
+
+	format := func(t time.Time, layout, tz, locale string) goja.Value {
+		value, err := FormatTime(t, layout, tz, locale)
+		if err != nil {
+			panic(vm.ToValue(err.Error()))
+		}
+		return vm.ToValue(value)
+	}
+
+	// lc.time.now(layout?, timezone?, locale?) - Current time as a string (RFC 3339 by default)
+	timeObj.Set("now", func(call goja.FunctionCall) goja.Value {
+		return format(time.Now(), argString(call, 0), argString(call, 1), argString(call, 2))
+	})
+
+	// lc.time.format(date, layout?, timezone?, locale?) - Format a Date, timestamp (ms) or RFC 3339 string
+	timeObj.Set("format", func(call goja.FunctionCall) goja.Value {
+		return format(argTime(call.Argument(0)), argString(call, 1), argString(call, 2), argString(call, 3))
+	})
+
+	// lc.time.parse(value, layout?, timezone?) - Parse a string to a Date, the timezone applies when value has no offset
+	timeObj.Set("parse", func(call goja.FunctionCall) goja.Value {
+		t, err := ParseTime(argString(call, 0), argString(call, 1), argString(call, 2))
+		if err != nil {
+			panic(vm.ToValue(err.Error()))
+		}
+		return toDate(t)
+	})
+
+	// lc.time.add(date, duration) - Date shifted by a duration ("90m", "-1h", "7d")
+	timeObj.Set("add", func(call goja.FunctionCall) goja.Value {
+		offset, err := ParseTimeOffset(argString(call, 1))
+		if err != nil {
+			panic(vm.ToValue(err.Error()))
+		}
+		return toDate(argTime(call.Argument(0)).Add(offset))
+	})
+
+	// lc.time.unix(date?) - Unix timestamp in seconds of a date, now by default
+	timeObj.Set("unix", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(argTime(call.Argument(0)).Unix())
+	})
+
+	lc.Set("time", timeObj)
+	return nil
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dop251/goja"
+)
+
+func setupTimeVM(t *testing.T) *goja.Runtime {
+	t.Helper()
+	vm := goja.New()
+	executor := &gojaExecutor{globals: NewScriptGlobals()}
+
+	lc := vm.NewObject()
+	if err := executor.setupLCTime(vm, lc); err != nil {
+		t.Fatalf("setupLCTime failed: %v", err)
+	}
+	if err := vm.Set("lc", lc); err != nil {
+		t.Fatalf("Failed to set lc: %v", err)
+	}
+
+	return vm
+}
+
+func TestTimeFormat(t *testing.T) {
+	vm := setupTimeVM(t)
+
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			name:     "Date in timezone",
+			script:   `lc.time.format(new Date(Date.UTC(2024, 2, 10, 14, 5, 9)), "2006-01-02 15:04", "America/New_York")`,
+			expected: "2024-03-10 10:05",
+		},
+		{
+			name:     "Millisecond timestamp",
+			script:   `lc.time.format(1710079509000, "rfc3339", "UTC")`,
+			expected: "2024-03-10T14:05:09Z",
+		},
+		{
+			name:     "RFC 3339 string",
+			script:   `lc.time.format("2024-03-10T14:05:09Z", "http")`,
+			expected: "Sun, 10 Mar 2024 14:05:09 GMT",
+		},
+		{
+			name:     "Locale",
+			script:   `lc.time.format(1710079509000, "Monday 2 January", "UTC", "es")`,
+			expected: "domingo 10 marzo",
+		},
+		{
+			name:     "Parse then format",
+			script:   `lc.time.format(lc.time.parse("10/03/2024 15:05", "02/01/2006 15:04", "Europe/Paris"), "unix")`,
+			expected: "1710079500",
+		},
+		{
+			name:     "Parse returns a Date",
+			script:   `lc.time.parse("2024-03-10T14:05:09Z").getTime()`,
+			expected: "1710079509000",
+		},
+		{
+			name:     "Add days",
+			script:   `lc.time.format(lc.time.add("2024-03-10T14:05:09Z", "-7d"), "date", "UTC")`,
+			expected: "2024-03-03",
+		},
+		{
+			name:     "Unix",
+			script:   `lc.time.unix("2024-03-10T14:05:09Z")`,
+			expected: "1710079509",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := vm.RunString(tt.script)
+			if err != nil {
+				t.Fatalf("Script execution failed: %v", err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestTimeNow(t *testing.T) {
+	vm := setupTimeVM(t)
+
+	result, err := vm.RunString(`lc.time.now("2006", "UTC") === String(new Date().getUTCFullYear())`)
+	if err != nil {
+		t.Fatalf("Script execution failed: %v", err)
+	}
+	if !result.ToBoolean() {
+		t.Error("lc.time.now() should format the current time")
+	}
+
+	result, err = vm.RunString(`lc.time.unix() - Math.floor(Date.now() / 1000)`)
+	if err != nil {
+		t.Fatalf("Script execution failed: %v", err)
+	}
+	if d := result.ToInteger(); d < -1 || d > 1 {
+		t.Errorf("lc.time.unix() is %d seconds off", d)
+	}
+}
+
+func TestTimeErrors(t *testing.T) {
+	vm := setupTimeVM(t)
+
+	tests := []struct {
+		name   string
+		script string
+		errMsg string
+	}{
+		{name: "Unknown timezone", script: `lc.time.now("rfc3339", "Mars/Olympus")`, errMsg: "unknown timezone"},
+		{name: "Unknown locale", script: `lc.time.now("January", "UTC", "xx")`, errMsg: "unsupported locale"},
+		{name: "Invalid date", script: `lc.time.format("yesterday")`, errMsg: "Invalid date"},
+		{name: "Invalid duration", script: `lc.time.add(0, "soon")`, errMsg: "invalid duration"},
+		{name: "Invalid value", script: `lc.time.parse("10/03", "2006-01-02")`, errMsg: "cannot parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vm.RunString(tt.script)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
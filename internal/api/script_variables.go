@@ -24,12 +24,34 @@ var lastNames = []string{
 }
 
 // setupLCVariables creates the lc.variables object for dynamic variable generation
-// Provides UUID, timestamp, random numbers and strings for test data generation
+// Provides UUID, timestamp, random numbers and strings for test data generation,
+// and reads variables across scopes (request, environment, collection, globals)
 //
 //nolint:errcheck,unparam // Goja Set operations are safe in this context, error for interface consistency
-func (e *gojaExecutor) setupLCVariables(vm *goja.Runtime, lc *goja.Object) error {
+func (e *gojaExecutor) setupLCVariables(vm *goja.Runtime, lc *goja.Object, env *ScriptEnvironment) error {
 	varsObj := vm.NewObject()
 
+	// lc.variables.get(name) - Value of a variable from the most specific scope defining it
+	varsObj.Set("get", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) == 0 {
+			return goja.Undefined()
+		}
+		value, ok := env.Resolve(call.Arguments[0].String())
+		if !ok {
+			return goja.Undefined()
+		}
+		return vm.ToValue(value)
+	})
+
+	// lc.variables.has(name) - Whether any scope defines a variable
+	varsObj.Set("has", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
+		if len(call.Arguments) == 0 {
+			return vm.ToValue(false)
+		}
+		_, ok := env.Resolve(call.Arguments[0].String())
+		return vm.ToValue(ok)
+	})
+
 	// lc.variables.uuid() - Generate a new UUID v4
 	varsObj.Set("uuid", func(call goja.FunctionCall) goja.Value { // #nosec G104 -- Goja Set safe here
 		return vm.ToValue(uuid.New().String())
@@ -15,7 +15,7 @@ func setupVariablesVM(t *testing.T) *goja.Runtime {
 	executor := &gojaExecutor{globals: NewScriptGlobals()}
 
 	lc := vm.NewObject()
-	if err := executor.setupLCVariables(vm, lc); err != nil {
+	if err := executor.setupLCVariables(vm, lc, NewScriptEnvironment(nil)); err != nil {
 		t.Fatalf("setupLCVariables failed: %v", err)
 	}
 	if err := vm.Set("lc", lc); err != nil {
@@ -384,3 +384,27 @@ func TestVariablesRandomBoolean(t *testing.T) {
 		t.Error("Expected some false values")
 	}
 }
+
+func TestVariablesGetAcrossScopes(t *testing.T) {
+	vm := goja.New()
+	executor := &gojaExecutor{globals: NewScriptGlobals()}
+	lc := vm.NewObject()
+	env := NewScriptEnvironment(&Environment{
+		Variables: map[string]string{"base_url": "https://staging"},
+		Scopes:    VariableScopes{Globals: map[string]string{"tenant": "acme"}},
+	})
+	if err := executor.setupLCVariables(vm, lc, env); err != nil {
+		t.Fatalf("setupLCVariables failed: %v", err)
+	}
+	if err := vm.Set("lc", lc); err != nil {
+		t.Fatalf("Failed to set lc: %v", err)
+	}
+
+	result, err := vm.RunString(`[lc.variables.get("tenant"), lc.variables.get("base_url"), lc.variables.has("missing")].join(",")`)
+	if err != nil {
+		t.Fatalf("Script execution failed: %v", err)
+	}
+	if got := result.String(); got != "acme,https://staging,false" {
+		t.Errorf("lc.variables.get/has = %q", got)
+	}
+}
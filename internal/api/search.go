@@ -0,0 +1,127 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Parts of a request a workspace search matched
+const (
+	SearchFieldName   = "name"
+	SearchFieldURL    = "url"
+	SearchFieldHeader = "header"
+	SearchFieldBody   = "body"
+)
+
+// searchFieldBonus ranks the matches of a field above those of the fields after it,
+// so a request named like the query comes before one merely mentioning it in its body
+var searchFieldBonus = map[string]int{
+	SearchFieldName:   60,
+	SearchFieldURL:    40,
+	SearchFieldHeader: 20,
+	SearchFieldBody:   0,
+}
+
+// SearchResult is a request matching a workspace search
+type SearchResult struct {
+	Collection string
+	Folder     []string // Names of the folders holding the request
+	Request    *CollectionRequest
+	Field      string // Part of the request that matched, a SearchField constant
+	Snippet    string // Matching header ("Key: Value") or body line; the name or URL otherwise
+	Score      int
+}
+
+// SearchRequests searches the requests of the collections for query. Names and URLs
+// match fuzzily, holding the characters of the query in order; header values and
+// bodies must contain the query. Case is ignored. Each request is listed once, for its
+// best match, the best results first.
+func SearchRequests(collections []*CollectionFile, query string) []SearchResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	var results []SearchResult
+	for _, c := range collections {
+		walkCollectionRequests(c.Folders, c.Requests, nil, func(path []string, req *CollectionRequest) {
+			if result, ok := searchRequest(req, query); ok {
+				result.Collection = c.Name
+				result.Folder = path
+				results = append(results, result)
+			}
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// searchRequest returns the best match of query in req
+func searchRequest(req *CollectionRequest, query string) (SearchResult, bool) {
+	best := SearchResult{Request: req, Score: -1}
+	consider := func(field, snippet string, score int) {
+		if score += searchFieldBonus[field]; score > best.Score {
+			best.Field, best.Snippet, best.Score = field, snippet, score
+		}
+	}
+
+	if score, ok := FuzzyMatch(req.Name, query); ok {
+		consider(SearchFieldName, req.Name, score)
+	}
+	if score, ok := FuzzyMatch(req.URL, query); ok {
+		consider(SearchFieldURL, req.URL, score)
+	}
+	lowerQuery := strings.ToLower(query)
+	for _, h := range req.Headers {
+		if strings.Contains(strings.ToLower(h.Value), lowerQuery) {
+			consider(SearchFieldHeader, h.Key+": "+h.Value, len(query))
+			break
+		}
+	}
+	for _, line := range strings.Split(formatBody(req.Body), "\n") {
+		if strings.Contains(strings.ToLower(line), lowerQuery) {
+			consider(SearchFieldBody, strings.TrimSpace(line), len(query))
+			break
+		}
+	}
+	return best, best.Score >= 0
+}
+
+// FuzzyMatch returns the score of text for query, whose characters must all appear in
+// text in order, ignoring case; higher is better. Consecutive characters, characters
+// starting a word and text containing the whole query score higher, long texts lower.
+func FuzzyMatch(text, query string) (int, bool) {
+	lowerText := strings.ToLower(text)
+	lowerQuery := strings.ToLower(query)
+	runes := []rune(lowerText)
+
+	score, pos, last := 0, 0, -2
+	for _, q := range lowerQuery {
+		for pos < len(runes) && runes[pos] != q {
+			pos++
+		}
+		if pos == len(runes) {
+			return 0, false
+		}
+		score++
+		if pos == last+1 {
+			score += 4
+		}
+		if pos == 0 || !unicode.IsLetter(runes[pos-1]) && !unicode.IsDigit(runes[pos-1]) {
+			score += 3
+		}
+		last = pos
+		pos++
+	}
+
+	if i := strings.Index(lowerText, lowerQuery); i >= 0 {
+		score += 10
+		if i == 0 {
+			score += 5
+		}
+	}
+	return score - len(runes)/20, true
+}
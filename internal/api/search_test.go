@@ -0,0 +1,69 @@
+package api
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		text, query string
+		match       bool
+	}{
+		{"Get Users", "gu", true},
+		{"Get Users", "users", true},
+		{"Get Users", "USR", true},
+		{"Get Users", "ug", false},
+		{"Create order", "xyz", false},
+	}
+	for _, tt := range tests {
+		if _, ok := FuzzyMatch(tt.text, tt.query); ok != tt.match {
+			t.Errorf("FuzzyMatch(%q, %q) matched = %v, want %v", tt.text, tt.query, ok, tt.match)
+		}
+	}
+
+	substring, _ := FuzzyMatch("List users", "user")
+	scattered, _ := FuzzyMatch("Update secret rules", "user")
+	if substring <= scattered {
+		t.Errorf("a substring should score higher than scattered characters: %d <= %d", substring, scattered)
+	}
+}
+
+func TestSearchRequests(t *testing.T) {
+	shop := &CollectionFile{
+		Name: "Shop",
+		Requests: []CollectionRequest{
+			{ID: "health", Name: "Health", Method: GET, URL: "{{base_url}}/health"},
+		},
+		Folders: []Folder{{
+			Name: "Orders",
+			Requests: []CollectionRequest{
+				{ID: "create", Name: "Create order", Method: POST, URL: "{{base_url}}/orders",
+					Headers: []KeyValueEntry{{Key: "X-Tenant", Value: "acme-corp", Enabled: true}},
+					Body:    &BodyConfig{Type: "json", Content: "{\n  \"sku\": \"widget-42\"\n}"}},
+				{ID: "list", Name: "List orders", Method: GET, URL: "{{base_url}}/orders"},
+			},
+		}},
+	}
+	collections := []*CollectionFile{shop}
+
+	results := SearchRequests(collections, "widget")
+	if len(results) != 1 || results[0].Request.ID != "create" || results[0].Field != SearchFieldBody {
+		t.Fatalf("search for widget = %+v, want the body of create", results)
+	}
+	if results[0].Snippet != `"sku": "widget-42"` || results[0].Collection != "Shop" || len(results[0].Folder) != 1 {
+		t.Errorf("result = %+v", results[0])
+	}
+
+	results = SearchRequests(collections, "acme")
+	if len(results) != 1 || results[0].Field != SearchFieldHeader || results[0].Snippet != "X-Tenant: acme-corp" {
+		t.Errorf("search for acme = %+v, want the X-Tenant header", results)
+	}
+
+	// Names rank above URLs
+	results = SearchRequests(collections, "orders")
+	if len(results) != 2 || results[0].Request.ID != "list" || results[0].Field != SearchFieldName {
+		t.Errorf("search for orders = %+v, want List orders first by name", results)
+	}
+
+	if results := SearchRequests(collections, "  "); results != nil {
+		t.Errorf("an empty query should match nothing, got %d results", len(results))
+	}
+}
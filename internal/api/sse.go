@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// SSEEvent is one event of a Server-Sent Events (text/event-stream) response
+type SSEEvent struct {
+	ID    string        // Last event ID, kept across events as in the EventSource spec
+	Event string        // Event type ("message" when the server sets none)
+	Data  string        // Data lines joined with "\n"
+	Retry int           // Reconnection time in milliseconds sent with the event, 0 if none
+	At    time.Duration // Time since the request was sent
+}
+
+// IsEventStream returns true if the response headers declare a Server-Sent Events body
+func IsEventStream(headers map[string][]string) bool {
+	for key, values := range headers {
+		if strings.EqualFold(key, "Content-Type") && len(values) > 0 {
+			mediaType, _, err := mime.ParseMediaType(values[0])
+			return err == nil && mediaType == "text/event-stream"
+		}
+	}
+	return false
+}
+
+// ParseSSE reads a text/event-stream body until EOF, calling emit for every
+// dispatched event. An event that is not terminated by a blank line is discarded.
+func ParseSSE(r io.Reader, emit func(SSEEvent)) error {
+	reader := bufio.NewReader(r)
+	var (
+		lastID    string
+		eventType string
+		data      strings.Builder
+		retry     int
+	)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			// Blank line: dispatch the buffered event, if it has data
+			if data.Len() > 0 {
+				event := eventType
+				if event == "" {
+					event = "message"
+				}
+				emit(SSEEvent{
+					ID:    lastID,
+					Event: event,
+					Data:  strings.TrimSuffix(data.String(), "\n"),
+					Retry: retry,
+				})
+			}
+			eventType, retry = "", 0
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, often sent as a keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteString("\n")
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				retry = ms
+			}
+		}
+	}
+}
+
+// EventStream is an open Server-Sent Events response read in the background.
+// Events are delivered on Events, which is closed when the server ends the stream,
+// the connection fails or Close is called.
+type EventStream struct {
+	Events <-chan SSEEvent
+
+	resp    *Response
+	cancel  context.CancelFunc
+	stopped atomic.Bool
+	raw     bytes.Buffer
+	err     error
+	done    chan struct{}
+}
+
+// newEventStream starts reading the events of body; resp is the response head
+func newEventStream(ctx context.Context, cancel context.CancelFunc, resp *Response, body io.ReadCloser, start time.Time) *EventStream {
+	events := make(chan SSEEvent, 64)
+	head := *resp // The caller keeps reading resp while the stream fills in its copy
+	s := &EventStream{
+		Events: events,
+		resp:   &head,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(events)
+		defer cancel()
+		defer body.Close()
+
+		err := ParseSSE(io.TeeReader(body, &s.raw), func(event SSEEvent) {
+			event.At = time.Since(start)
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+		if s.stopped.Load() {
+			err = nil // Stopped by Close
+		} else if err == nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+
+		s.err = err
+		s.resp.Body = s.raw.Bytes()
+		s.resp.Size = int64(len(s.resp.Body))
+		s.resp.Time = time.Since(start)
+		close(s.done)
+	}()
+	return s
+}
+
+// Close stops the stream. Events already received stay in the response body.
+func (s *EventStream) Close() {
+	s.stopped.Store(true)
+	s.cancel()
+}
+
+// Err returns the error that ended the stream, nil if the server closed it or
+// Close was called. It blocks until the stream has ended.
+func (s *EventStream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Response returns the response with the whole stream as body, its size and total
+// duration. It blocks until the stream has ended.
+func (s *EventStream) Response() *Response {
+	<-s.done
+	return s.resp
+}
+
+// SendStream sends an HTTP request like Send, but returns Server-Sent Events
+// responses as soon as their headers arrive, with an open stream delivering the
+// events as they are received. The request timeout only applies until the headers
+// arrive for streams. Other responses are read whole and returned with a nil stream.
+// Like Send, failed attempts are retried by the request's retry policy; streams are not.
+func (c *Client) SendStream(req *Request) (*Response, *EventStream, error) {
+	var stream *EventStream
+	resp, err := sendWithRetries(req, func() (*Response, bool, error) {
+		var resp *Response
+		var err error
+		resp, stream, err = c.sendStream(req)
+		return resp, stream == nil, err
+	})
+	return resp, stream, err
+}
+
+// sendStream sends req once, see SendStream
+func (c *Client) sendStream(req *Request) (*Response, *EventStream, error) {
+	start := time.Now()
+
+	httpReq, err := newHTTPRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeout := c.httpClient.Timeout
+	if req.Timeout > 0 {
+		timeout = req.Timeout
+	}
+
+	// The client timeout would also cut the stream, so the deadline is enforced by
+	// canceling the request instead, and lifted once a stream is detected
+	ctx, cancel := context.WithCancel(context.Background())
+	var timedOut atomic.Bool
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			cancel()
+		})
+	}
+	stopTimer := func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	timeoutErr := func(err error) error {
+		if timedOut.Load() {
+			return fmt.Errorf("request timed out after %s: %w", timeout, context.DeadlineExceeded)
+		}
+		return err
+	}
+
+	var redirects []RedirectHop
+	client := *c.httpClient
+	client.Timeout = 0
+	client.CheckRedirect = redirectPolicy(req, &redirects)
+	if req.ServerName != "" {
+		client.Transport = c.serverNameTransport(req.ServerName, httpReq.URL)
+	}
+	httpResp, err := client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		stopTimer()
+		cancel()
+		return nil, nil, timeoutErr(err)
+	}
+	if len(redirects) > 0 {
+		redirects = append(redirects, RedirectHop{
+			URL:        httpResp.Request.URL.String(),
+			StatusCode: httpResp.StatusCode,
+			Status:     httpResp.Status,
+		})
+	}
+
+	resp := &Response{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Headers:    httpResp.Header,
+		Redirects:  redirects,
+	}
+
+	if IsEventStream(httpResp.Header) {
+		stopTimer()
+		resp.Time = time.Since(start)
+		return resp, newEventStream(ctx, cancel, resp, httpResp.Body, start), nil
+	}
+
+	defer cancel()
+	defer stopTimer()
+	defer httpResp.Body.Close()
+	bodyBytes, bodyFile, size, err := readResponseBody(httpResp.Body)
+	if err != nil {
+		return nil, nil, timeoutErr(err)
+	}
+	resp.Body = bodyBytes
+	resp.BodyFile = bodyFile
+	resp.Size = size
+	resp.Time = time.Since(start)
+	return resp, nil, nil
+}
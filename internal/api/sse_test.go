@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSSE(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []SSEEvent
+	}{
+		{
+			name:  "default message type",
+			input: "data: hello\n\n",
+			want:  []SSEEvent{{Event: "message", Data: "hello"}},
+		},
+		{
+			name:  "multi-line data with CRLF",
+			input: "event: update\r\ndata: line 1\r\ndata:line 2\r\n\r\n",
+			want:  []SSEEvent{{Event: "update", Data: "line 1\nline 2"}},
+		},
+		{
+			name:  "id persists and retry is per event",
+			input: "id: 1\nretry: 3000\ndata: a\n\ndata: b\n\n",
+			want: []SSEEvent{
+				{ID: "1", Event: "message", Data: "a", Retry: 3000},
+				{ID: "1", Event: "message", Data: "b"},
+			},
+		},
+		{
+			name:  "comments and empty events ignored",
+			input: ": keep-alive\n\nevent: ping\n\ndata: x\n\n",
+			want:  []SSEEvent{{Event: "message", Data: "x"}},
+		},
+		{
+			name:  "unterminated event discarded",
+			input: "data: done\n\ndata: partial\n",
+			want:  []SSEEvent{{Event: "message", Data: "done"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []SSEEvent
+			if err := ParseSSE(strings.NewReader(tt.input), func(e SSEEvent) { got = append(got, e) }); err != nil {
+				t.Fatalf("ParseSSE() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEventStream(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"text/event-stream", true},
+		{"text/event-stream; charset=utf-8", true},
+		{"application/json", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		headers := map[string][]string{"Content-Type": {tt.contentType}}
+		if got := IsEventStream(headers); got != tt.want {
+			t.Errorf("IsEventStream(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestClient_SendStream(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/json" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"ok":true}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\ndata: first\n\n")
+		w.(http.Flusher).Flush()
+		// The second event is only sent once the first was received by the client
+		<-release
+		fmt.Fprint(w, "event: end\ndata: second\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient()
+
+	t.Run("regular response", func(t *testing.T) {
+		resp, stream, err := client.SendStream(&Request{Method: GET, URL: server.URL + "/json"})
+		if err != nil {
+			t.Fatalf("SendStream() error = %v", err)
+		}
+		if stream != nil {
+			t.Error("stream should be nil for a JSON response")
+		}
+		if string(resp.Body) != `{"ok":true}` {
+			t.Errorf("Body = %q", resp.Body)
+		}
+	})
+
+	t.Run("events arrive incrementally", func(t *testing.T) {
+		resp, stream, err := client.SendStream(&Request{Method: GET, URL: server.URL + "/events", Timeout: 50 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("SendStream() error = %v", err)
+		}
+		if stream == nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected an open stream, got %+v", resp)
+		}
+
+		first := <-stream.Events
+		if first.Data != "first" || first.ID != "1" {
+			t.Errorf("first event = %+v", first)
+		}
+		// Longer than the request timeout, which only applies to the headers
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+
+		second, ok := <-stream.Events
+		if !ok || second.Event != "end" || second.Data != "second" {
+			t.Errorf("second event = %+v, ok %v", second, ok)
+		}
+		if _, ok := <-stream.Events; ok {
+			t.Error("Events should be closed when the server ends the stream")
+		}
+		if err := stream.Err(); err != nil {
+			t.Errorf("Err() = %v", err)
+		}
+		final := stream.Response()
+		if want := "id: 1\ndata: first\n\nevent: end\ndata: second\n\n"; string(final.Body) != want {
+			t.Errorf("Body = %q, want %q", final.Body, want)
+		}
+		if final.Size != int64(len(final.Body)) {
+			t.Errorf("Size = %d", final.Size)
+		}
+	})
+}
+
+func TestEventStream_Close(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: tick\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	_, stream, err := NewClient().SendStream(&Request{Method: GET, URL: server.URL})
+	if err != nil || stream == nil {
+		t.Fatalf("SendStream() = %v, %v", stream, err)
+	}
+	<-stream.Events
+	stream.Close()
+	for range stream.Events {
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err() after Close = %v, want nil", err)
+	}
+	if got := string(stream.Response().Body); got != "data: tick\n\n" {
+		t.Errorf("Body = %q", got)
+	}
+}
+
+func TestClient_SendStream_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	_, _, err := NewClient().SendStream(&Request{Method: GET, URL: server.URL, Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want a deadline error", err)
+	}
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Timezones resolve on systems without a zoneinfo database (Windows)
+)
+
+// TemplateNow is the template helper formatting the current time:
+// {{now}}, {{now "2006-01-02"}} or {{now "rfc1123" "Europe/Paris" "fr"}}
+const TemplateNow = "now"
+
+// Time layout presets accepted in place of a Go layout
+const (
+	TimeLayoutRFC3339   = "rfc3339"
+	TimeLayoutRFC3339Ms = "rfc3339ms"
+	TimeLayoutISO8601   = "iso8601"
+	TimeLayoutRFC1123   = "rfc1123"
+	TimeLayoutHTTP      = "http"
+	TimeLayoutDate      = "date"
+	TimeLayoutTime      = "time"
+	TimeLayoutUnix      = "unix"
+	TimeLayoutUnixMs    = "unixms"
+)
+
+var timeLayoutPresets = map[string]string{
+	"":                  time.RFC3339,
+	TimeLayoutRFC3339:   time.RFC3339,
+	TimeLayoutRFC3339Ms: "2006-01-02T15:04:05.000Z07:00",
+	TimeLayoutISO8601:   "2006-01-02T15:04:05.000Z07:00",
+	TimeLayoutRFC1123:   time.RFC1123,
+	TimeLayoutHTTP:      "Mon, 02 Jan 2006 15:04:05 GMT",
+	TimeLayoutDate:      "2006-01-02",
+	TimeLayoutTime:      "15:04:05",
+}
+
+// templateArgPattern matches one double-quoted template argument
+var templateArgPattern = regexp.MustCompile(`^\s*"((?:[^"\\]|\\.)*)"`)
+
+// timeLocale holds the month and weekday names of a locale
+type timeLocale struct {
+	months, shortMonths [12]string
+	days, shortDays     [7]string
+}
+
+var timeLocales = map[string]timeLocale{
+	"fr": {
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	"de": {
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	},
+	"es": {
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"it": {
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"pt": {
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		days:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortDays:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+	"nl": {
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+}
+
+// layoutNameTokens are the Go layout tokens translated by a locale, longest first
+var layoutNameTokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// LoadTimezone returns the location of an IANA timezone name ("Europe/Paris"),
+// "UTC" or "Local". An empty name is the local timezone.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+// FormatTime formats t in the timezone tz with a Go layout or a preset
+// ("rfc3339", "rfc1123", "http", "unix", ...). locale translates month and
+// weekday names ("fr", "de", "es", "it", "pt", "nl"); empty or "en" keeps English.
+func FormatTime(t time.Time, layout, tz, locale string) (string, error) {
+	loc, err := LoadTimezone(tz)
+	if err != nil {
+		return "", err
+	}
+	t = t.In(loc)
+
+	switch strings.ToLower(layout) {
+	case TimeLayoutUnix:
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case TimeLayoutUnixMs:
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case TimeLayoutHTTP:
+		// HTTP dates are always in GMT (RFC 9110)
+		t = t.UTC()
+	}
+	if preset, ok := timeLayoutPresets[strings.ToLower(layout)]; ok {
+		layout = preset
+	}
+
+	if locale == "" || strings.EqualFold(locale, "en") {
+		return t.Format(layout), nil
+	}
+	names, ok := timeLocales[strings.ToLower(locale)]
+	if !ok {
+		return "", fmt.Errorf("unsupported locale %q", locale)
+	}
+	return formatLocalized(t, layout, names), nil
+}
+
+// formatLocalized formats t, replacing the month and weekday names of the layout
+func formatLocalized(t time.Time, layout string, names timeLocale) string {
+	var b strings.Builder
+	for layout != "" {
+		token, index := "", len(layout)
+		for _, candidate := range layoutNameTokens {
+			if i := strings.Index(layout, candidate); i >= 0 && i < index {
+				token, index = candidate, i
+			}
+		}
+		b.WriteString(t.Format(layout[:index]))
+		switch token {
+		case "January":
+			b.WriteString(names.months[t.Month()-1])
+		case "Jan":
+			b.WriteString(names.shortMonths[t.Month()-1])
+		case "Monday":
+			b.WriteString(names.days[t.Weekday()])
+		case "Mon":
+			b.WriteString(names.shortDays[t.Weekday()])
+		}
+		layout = layout[index+len(token):]
+	}
+	return b.String()
+}
+
+// ParseTime parses value with a Go layout or a preset in the timezone tz,
+// used when value carries no offset. An empty layout is RFC 3339.
+func ParseTime(value, layout, tz string) (time.Time, error) {
+	loc, err := LoadTimezone(tz)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch strings.ToLower(layout) {
+	case TimeLayoutUnix, TimeLayoutUnixMs:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s timestamp %q", strings.ToLower(layout), value)
+		}
+		if strings.EqualFold(layout, TimeLayoutUnix) {
+			return time.Unix(n, 0).In(loc), nil
+		}
+		return time.UnixMilli(n).In(loc), nil
+	case TimeLayoutHTTP:
+		loc = time.UTC
+	}
+	if preset, ok := timeLayoutPresets[strings.ToLower(layout)]; ok {
+		layout = preset
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// ParseTimeOffset parses a duration such as "90m", "-1h30m" or "7d" (days)
+func ParseTimeOffset(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// EvalTemplateFunction evaluates a template helper call such as
+// `now "2006-01-02" "UTC"`. ok is false when expr is not a valid helper call,
+// in which case the placeholder is kept.
+func EvalTemplateFunction(expr string) (value string, ok bool) {
+	name, rest, _ := strings.Cut(strings.TrimSpace(expr), " ")
+	if name != TemplateNow {
+		return "", false
+	}
+
+	args, ok := parseTemplateArgs(rest)
+	if !ok || len(args) > 3 {
+		return "", false
+	}
+	args = append(args, "", "", "")
+
+	value, err := FormatTime(time.Now(), args[0], args[1], args[2])
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// parseTemplateArgs splits `"a" "b"` into its double-quoted arguments
+func parseTemplateArgs(s string) ([]string, bool) {
+	var args []string
+	for strings.TrimSpace(s) != "" {
+		match := templateArgPattern.FindStringSubmatch(s)
+		if match == nil {
+			return nil, false
+		}
+		arg, err := strconv.Unquote(`"` + match[1] + `"`)
+		if err != nil {
+			return nil, false
+		}
+		args = append(args, arg)
+		s = s[len(match[0]):]
+	}
+	return args, true
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	// Sunday 2024-03-10 14:05:09.123 UTC
+	ts := time.Date(2024, time.March, 10, 14, 5, 9, 123e6, time.UTC)
+
+	tests := []struct {
+		name     string
+		layout   string
+		tz       string
+		locale   string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Default is RFC 3339", tz: "UTC", expected: "2024-03-10T14:05:09Z"},
+		{name: "Go layout", layout: "2006-01-02", tz: "UTC", expected: "2024-03-10"},
+		{name: "Timezone", layout: "rfc3339", tz: "Asia/Tokyo", expected: "2024-03-10T23:05:09+09:00"},
+		{name: "Milliseconds", layout: "iso8601", tz: "UTC", expected: "2024-03-10T14:05:09.123Z"},
+		{name: "HTTP date is GMT", layout: "http", tz: "Europe/Paris", expected: "Sun, 10 Mar 2024 14:05:09 GMT"},
+		{name: "Unix", layout: "unix", expected: "1710079509"},
+		{name: "Unix milliseconds", layout: "unixms", expected: "1710079509123"},
+		{name: "Locale", layout: "Monday 2 January 2006", tz: "Europe/Paris", locale: "fr", expected: "dimanche 10 mars 2024"},
+		{name: "Short locale names", layout: "Mon, 02 Jan", tz: "UTC", locale: "de", expected: "So., 10 März"},
+		{name: "English locale", layout: "Mon Jan 2", tz: "UTC", locale: "en", expected: "Sun Mar 10"},
+		{name: "Unknown timezone", tz: "Mars/Olympus", wantErr: true},
+		{name: "Unknown locale", tz: "UTC", locale: "xx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatTime(ts, tt.layout, tt.tz, tt.locale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("FormatTime() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		layout   string
+		tz       string
+		expected int64
+		wantErr  bool
+	}{
+		{name: "RFC 3339", value: "2024-03-10T14:05:09Z", expected: 1710079509},
+		{name: "Offset wins over timezone", value: "2024-03-10T15:05:09+01:00", tz: "Asia/Tokyo", expected: 1710079509},
+		{name: "Layout in timezone", value: "2024-03-10 23:05:09", layout: "2006-01-02 15:04:05", tz: "Asia/Tokyo", expected: 1710079509},
+		{name: "HTTP date", value: "Sun, 10 Mar 2024 14:05:09 GMT", layout: "http", tz: "Asia/Tokyo", expected: 1710079509},
+		{name: "Unix", value: "1710079509", layout: "unix", expected: 1710079509},
+		{name: "Unix milliseconds", value: "1710079509123", layout: "unixms", expected: 1710079509},
+		{name: "Invalid value", value: "yesterday", wantErr: true},
+		{name: "Invalid unix", value: "abc", layout: "unix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseTime(tt.value, tt.layout, tt.tz)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result.Unix() != tt.expected {
+				t.Errorf("ParseTime() = %d, want %d", result.Unix(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTimeOffset(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{input: "90m", expected: 90 * time.Minute},
+		{input: "-1h30m", expected: -90 * time.Minute},
+		{input: "7d", expected: 7 * 24 * time.Hour},
+		{input: "-0.5d", expected: -12 * time.Hour},
+		{input: "soon", wantErr: true},
+		{input: "d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseTimeOffset(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeOffset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseTimeOffset() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReplaceVariables_Now(t *testing.T) {
+	env := &EnvironmentFile{
+		Name: "Test",
+		Variables: map[string]*EnvironmentVariable{
+			"day": {Value: "today", Active: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		pattern string
+	}{
+		{name: "Default", input: "{{now}}", pattern: `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`},
+		{name: "Layout and timezone", input: `from={{now "2006-01-02" "UTC"}}`, pattern: `^from=\d{4}-\d{2}-\d{2}$`},
+		{name: "Preset", input: `{{ now "http" }}`, pattern: `^\w{3}, \d{2} \w{3} \d{4} \d{2}:\d{2}:\d{2} GMT$`},
+		{name: "Locale", input: `{{now "January" "UTC" "nl"}}`, pattern: `^[a-z]+$`},
+		{name: "Mixed with environment", input: `{{day}} {{now "unix"}}`, pattern: `^today \d+$`},
+		{name: "Invalid timezone is kept", input: `{{now "unix" "Nowhere"}}`, pattern: `^\{\{now "unix" "Nowhere"\}\}$`},
+		{name: "Unquoted argument is kept", input: `{{now unix}}`, pattern: `^\{\{now unix\}\}$`},
+		{name: "Too many arguments are kept", input: `{{now "a" "b" "c" "d"}}`, pattern: `^\{\{now "a" "b" "c" "d"\}\}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReplaceVariables(tt.input, env)
+			if !regexp.MustCompile(tt.pattern).MatchString(result) {
+				t.Errorf("ReplaceVariables() = %q, want match %s", result, tt.pattern)
+			}
+		})
+	}
+
+	// An environment variable named now takes precedence
+	env.Variables["now"] = &EnvironmentVariable{Value: "fixed", Active: true}
+	if result := ReplaceVariables("{{now}}", env); result != "fixed" {
+		t.Errorf("ReplaceVariables() = %q, want the environment value", result)
+	}
+	if unresolved := FindUnresolvedVariables(`{{now "unix"}}`, nil); len(unresolved) != 0 {
+		t.Errorf("FindUnresolvedVariables() = %v, template helpers are always resolved", unresolved)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TLSConfig customizes the verification of server certificates and the client
+// certificates presented to servers
+type TLSConfig struct {
+	CACerts            []string     // PEM files of the CAs trusted in addition to the system ones
+	ClientCerts        []ClientCert // Client certificates, the first matching the host is presented
+	InsecureSkipVerify bool         // Accept any server certificate, for development servers only
+}
+
+// ClientCert is a client certificate and its private key, presented to one host
+type ClientCert struct {
+	Host string // "api.example.com", "api.example.com:8443" or "*.example.com" (subdomains)
+	Cert string // PEM certificate file
+	Key  string // PEM private key file
+}
+
+// DefaultTLS applies to clients created by NewClient; nil uses the system CAs
+// without client certificates
+var DefaultTLS *TLSConfig
+
+// Validate loads the CA files and client certificates
+func (t *TLSConfig) Validate() error {
+	_, _, err := t.load()
+	return err
+}
+
+// IsDefault reports whether the config changes nothing from the defaults
+func (t *TLSConfig) IsDefault() bool {
+	return len(t.CACerts) == 0 && len(t.ClientCerts) == 0 && !t.InsecureSkipVerify
+}
+
+// load builds the TLS client config and loads the client certificates, in the order
+// of t.ClientCerts
+func (t *TLSConfig) load() (*tls.Config, []tls.Certificate, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.InsecureSkipVerify, // #nosec G402 -- opt-in for development servers
+	}
+
+	if len(t.CACerts) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range t.CACerts {
+			data, err := os.ReadFile(path) // #nosec G304 -- CA file from the workspace config
+			if err != nil {
+				return nil, nil, fmt.Errorf("CA certificate: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, nil, fmt.Errorf("CA certificate %s: no PEM certificate found", path)
+			}
+		}
+		config.RootCAs = pool
+	}
+
+	certs := make([]tls.Certificate, 0, len(t.ClientCerts))
+	for _, cc := range t.ClientCerts {
+		if cc.Host == "" {
+			return nil, nil, fmt.Errorf("client certificate %s: host is required", cc.Cert)
+		}
+		cert, err := tls.LoadX509KeyPair(cc.Cert, cc.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("client certificate for %s: %w", cc.Host, err)
+		}
+		certs = append(certs, cert)
+	}
+	return config, certs, nil
+}
+
+// Matches reports whether the certificate is presented to the host of target
+func (cc ClientCert) Matches(target *url.URL) bool {
+	host := strings.ToLower(cc.Host)
+	hostname := strings.ToLower(target.Hostname())
+	if strings.Contains(host, ":") {
+		port := target.Port()
+		if port == "" {
+			port = proxySchemes[target.Scheme]
+		}
+		return host == hostname+":"+port
+	}
+	if suffix, ok := strings.CutPrefix(host, "*"); ok {
+		return strings.HasSuffix(hostname, suffix)
+	}
+	return host == hostname
+}
+
+// tlsTransport sends the requests to the hosts of client certificates through a
+// transport presenting the certificate, and other requests through base
+type tlsTransport struct {
+	base  *http.Transport
+	certs []ClientCert
+	hosts []*http.Transport // Transport of each of certs
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tlsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for i, cc := range t.certs {
+		if cc.Matches(req.URL) {
+			return t.hosts[i].RoundTrip(req)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of all transports
+func (t *tlsTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+	for _, host := range t.hosts {
+		host.CloseIdleConnections()
+	}
+}
+
+// SetTLS applies config to the requests of the client; nil goes back to the system CAs
+func (c *Client) SetTLS(config *TLSConfig) error {
+	if config == nil {
+		c.tls = nil
+		c.updateTransport()
+		return nil
+	}
+	tlsConfig, certs, err := config.load()
+	if err != nil {
+		return err
+	}
+	c.tls = &clientTLS{config: tlsConfig, certs: config.ClientCerts, loaded: certs}
+	c.updateTransport()
+	return nil
+}
+
+// clientTLS is the loaded TLS config of a client
+type clientTLS struct {
+	config *tls.Config
+	certs  []ClientCert
+	loaded []tls.Certificate // Certificate of each of certs
+}
+
+// updateTransport builds the transport of the client from its proxy and TLS config;
+// without either the default transport applies
+func (c *Client) updateTransport() {
+	if c.proxy == nil && c.tls == nil {
+		c.httpClient.Transport = nil
+		return
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy := c.proxy; proxy != nil {
+		base.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy.ProxyURL(req.URL), nil
+		}
+	}
+	if c.tls == nil {
+		c.httpClient.Transport = base
+		return
+	}
+
+	base.TLSClientConfig = c.tls.config.Clone()
+	transport := &tlsTransport{base: base, certs: c.tls.certs}
+	for _, cert := range c.tls.loaded {
+		host := base.Clone()
+		host.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		transport.hosts = append(transport.hosts, host)
+	}
+	c.httpClient.Transport = transport
+}
+
+// serverNameTransport returns a transport like the client's that sends serverName in
+// the TLS handshake (SNI) and verifies the server certificate against it, rather than
+// the host of target. Its connections are not reused by other requests.
+func (c *Client) serverNameTransport(serverName string, target *url.URL) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	if proxy := c.proxy; proxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy.ProxyURL(req.URL), nil
+		}
+	}
+	transport.TLSClientConfig = &tls.Config{}
+	if c.tls != nil {
+		transport.TLSClientConfig = c.tls.config.Clone()
+		for i, cc := range c.tls.certs {
+			if cc.Matches(target) {
+				transport.TLSClientConfig.Certificates = []tls.Certificate{c.tls.loaded[i]}
+				break
+			}
+		}
+	}
+	transport.TLSClientConfig.ServerName = serverName
+	return transport
+}
+
+// TLSClientConfig returns the TLS client config of DefaultTLS for target, with the
+// client certificate of its host, or nil without DefaultTLS. Used by the connections
+// not made by a Client (doctor checks, gRPC calls).
+func TLSClientConfig(target *url.URL) (*tls.Config, error) {
+	if DefaultTLS == nil {
+		return nil, nil
+	}
+	config, certs, err := DefaultTLS.load()
+	if err != nil {
+		return nil, err
+	}
+	for i, cc := range DefaultTLS.ClientCerts {
+		if cc.Matches(target) {
+			config.Certificates = []tls.Certificate{certs[i]}
+			break
+		}
+	}
+	return config, nil
+}
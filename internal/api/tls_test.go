@@ -0,0 +1,247 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeClientCert writes a self-signed client certificate and its key to dir
+func writeClientCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, commonName+".pem")
+	keyFile = filepath.Join(dir, commonName+"-key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// writeServerCA writes the certificate of a test TLS server to dir
+func writeServerCA(t *testing.T, dir string, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(dir, "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeClientCert(t, dir, "client")
+	notPEM := filepath.Join(dir, "ca.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  TLSConfig
+		wantErr string
+	}{
+		{name: "empty", config: TLSConfig{}},
+		{name: "insecure", config: TLSConfig{InsecureSkipVerify: true}},
+		{name: "client certificate", config: TLSConfig{ClientCerts: []ClientCert{{Host: "api.example.com", Cert: certFile, Key: keyFile}}}},
+		{name: "CA file is the client certificate", config: TLSConfig{CACerts: []string{certFile}}},
+		{name: "missing CA file", config: TLSConfig{CACerts: []string{filepath.Join(dir, "missing.pem")}}, wantErr: "CA certificate"},
+		{name: "CA file without PEM", config: TLSConfig{CACerts: []string{notPEM}}, wantErr: "no PEM certificate found"},
+		{name: "client certificate without host", config: TLSConfig{ClientCerts: []ClientCert{{Cert: certFile, Key: keyFile}}}, wantErr: "host is required"},
+		{name: "key is not the certificate's", config: TLSConfig{ClientCerts: []ClientCert{{Host: "api.example.com", Cert: certFile, Key: certFile}}}, wantErr: "client certificate for api.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientCert_Matches(t *testing.T) {
+	tests := []struct {
+		host   string
+		target string
+		want   bool
+	}{
+		{host: "api.example.com", target: "https://api.example.com/users", want: true},
+		{host: "API.example.com", target: "https://api.example.com:8443", want: true},
+		{host: "api.example.com", target: "https://www.example.com", want: false},
+		{host: "api.example.com:8443", target: "https://api.example.com:8443", want: true},
+		{host: "api.example.com:8443", target: "https://api.example.com", want: false},
+		{host: "api.example.com:443", target: "https://api.example.com", want: true},
+		{host: "*.example.com", target: "https://api.example.com", want: true},
+		{host: "*.example.com", target: "https://example.com", want: false},
+		{host: "*", target: "https://localhost:3000", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host+" "+tt.target, func(t *testing.T) {
+			target, _ := url.Parse(tt.target)
+			if got := (ClientCert{Host: tt.host}).Matches(target); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_SetTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	ca := writeServerCA(t, t.TempDir(), server)
+
+	client := NewClient()
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL}); err == nil {
+		t.Fatal("Send() should fail without the CA of the server")
+	}
+
+	if err := client.SetTLS(&TLSConfig{CACerts: []string{ca}}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	if resp, err := client.Send(&Request{Method: GET, URL: server.URL}); err != nil || string(resp.Body) != "ok" {
+		t.Errorf("Send() with the CA file = %v, %v", resp, err)
+	}
+
+	if err := client.SetTLS(&TLSConfig{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL}); err != nil {
+		t.Errorf("Send() skipping verification error = %v", err)
+	}
+
+	if err := client.SetTLS(nil); err != nil {
+		t.Fatalf("SetTLS(nil) error = %v", err)
+	}
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL}); err == nil {
+		t.Error("Send() should verify certificates again after SetTLS(nil)")
+	}
+}
+
+func TestClient_SetTLS_ClientCertificate(t *testing.T) {
+	// The server requires a client certificate and answers with its common name
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	ca := writeServerCA(t, dir, server)
+	certFile, keyFile := writeClientCert(t, dir, "lazycurl")
+	otherCert, otherKey := writeClientCert(t, dir, "other")
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	DefaultTLS = &TLSConfig{
+		CACerts: []string{ca},
+		ClientCerts: []ClientCert{
+			{Host: "api.example.com", Cert: otherCert, Key: otherKey},
+			{Host: host, Cert: certFile, Key: keyFile},
+		},
+	}
+	t.Cleanup(func() { DefaultTLS = nil })
+
+	resp, err := NewClient().Send(&Request{Method: GET, URL: server.URL})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := string(resp.Body); got != "lazycurl" {
+		t.Errorf("server saw client certificate %q, want lazycurl", got)
+	}
+
+	// Without a certificate for its host, the handshake fails
+	client := NewClient()
+	if err := client.SetTLS(&TLSConfig{CACerts: []string{ca}, ClientCerts: DefaultTLS.ClientCerts[:1]}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL}); err == nil {
+		t.Error("Send() without a client certificate should fail")
+	}
+
+	// Connections outside a Client present the certificate of the host
+	config, err := TLSClientConfig(&url.URL{Scheme: "https", Host: host})
+	if err != nil || len(config.Certificates) != 1 {
+		t.Errorf("TLSClientConfig() = %v, %v", config, err)
+	}
+}
+
+func TestClient_ServerNameAndHostOverrides(t *testing.T) {
+	// The server answers with the server name of the handshake and the Host header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.ServerName + " " + r.Host))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	if err := client.SetTLS(&TLSConfig{CACerts: []string{writeServerCA(t, t.TempDir(), server)}}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+
+	// The certificate of the test server is valid for example.com and its subdomains
+	resp, err := client.Send(&Request{Method: GET, URL: server.URL, ServerName: "example.com", Host: "api.example.com"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := string(resp.Body); got != "example.com api.example.com" {
+		t.Errorf("server saw %q, want SNI example.com and Host api.example.com", got)
+	}
+
+	if _, err := client.Send(&Request{Method: GET, URL: server.URL, ServerName: "api.lazycurl.test"}); err == nil {
+		t.Error("Send() should verify the server certificate against the overridden server name")
+	}
+
+	resp, err = client.Send(&Request{Method: GET, URL: server.URL})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got, want := string(resp.Body), " "+strings.TrimPrefix(server.URL, "https://"); got != want {
+		t.Errorf("without overrides server saw %q, want %q", got, want)
+	}
+}
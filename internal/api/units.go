@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Size units of Units.Sizes
+const (
+	SizesBinary = "binary" // Multiples of 1024 bytes written KB, MB, GB (default)
+	SizesIEC    = "iec"    // Multiples of 1024 bytes written KiB, MiB, GiB
+	SizesSI     = "si"     // Multiples of 1000 bytes written kB, MB, GB
+)
+
+// Units is how durations and sizes are displayed
+type Units struct {
+	SecondsFrom      time.Duration // Durations from this long are shown in seconds, shorter ones in ms; 0 uses 1s
+	Sizes            string        // SizesBinary (empty), SizesIEC or SizesSI
+	DecimalSeparator string        // "." when empty
+}
+
+// DefaultUnits are the units of FormatDuration and FormatSize. It is set from the
+// global config at startup.
+var DefaultUnits Units
+
+// Duration formats d as µs below a millisecond, as ms below SecondsFrom and as
+// seconds with the given number of decimals from then on
+func (u Units) Duration(d time.Duration, decimals int) string {
+	secondsFrom := u.SecondsFrom
+	if secondsFrom <= 0 {
+		secondsFrom = time.Second
+	}
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < secondsFrom:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return u.decimal(fmt.Sprintf("%.*fs", decimals, d.Seconds()))
+}
+
+// Size formats a byte count as bytes below a kilobyte and with one decimal above
+func (u Units) Size(size int64) string {
+	base, units := 1024.0, []string{"KB", "MB", "GB"}
+	switch u.Sizes {
+	case SizesIEC:
+		units = []string{"KiB", "MiB", "GiB"}
+	case SizesSI:
+		base, units = 1000, []string{"kB", "MB", "GB"}
+	}
+	if float64(size) < base {
+		return fmt.Sprintf("%dB", size)
+	}
+	value := float64(size) / base
+	unit := 0
+	for value >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+	return u.decimal(fmt.Sprintf("%.1f%s", value, units[unit]))
+}
+
+// decimal replaces the decimal point of a formatted number by the decimal separator
+func (u Units) decimal(s string) string {
+	if u.DecimalSeparator == "" || u.DecimalSeparator == "." {
+		return s
+	}
+	return strings.Replace(s, ".", u.DecimalSeparator, 1)
+}
+
+// FormatDuration formats a duration for display in DefaultUnits (e.g. "850µs",
+// "125ms", "1.25s")
+func FormatDuration(d time.Duration) string {
+	return DefaultUnits.Duration(d, 2)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnits_Duration(t *testing.T) {
+	tests := []struct {
+		name  string
+		units Units
+		d     time.Duration
+		want  string
+	}{
+		{"microseconds", Units{}, 850 * time.Microsecond, "850µs"},
+		{"milliseconds", Units{}, 125 * time.Millisecond, "125ms"},
+		{"seconds", Units{}, 1250 * time.Millisecond, "1.25s"},
+		{"seconds from 10s", Units{SecondsFrom: 10 * time.Second}, 2500 * time.Millisecond, "2500ms"},
+		{"past the threshold", Units{SecondsFrom: 10 * time.Second}, 12 * time.Second, "12.00s"},
+		{"decimal comma", Units{DecimalSeparator: ","}, 1250 * time.Millisecond, "1,25s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.units.Duration(tt.d, 2); got != tt.want {
+				t.Errorf("Duration(%s) = %s, want %s", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnits_Size(t *testing.T) {
+	tests := []struct {
+		name  string
+		units Units
+		size  int64
+		want  string
+	}{
+		{"bytes", Units{}, 512, "512B"},
+		{"binary", Units{}, 2048, "2.0KB"},
+		{"binary megabytes", Units{}, 3 * 1024 * 1024, "3.0MB"},
+		{"iec", Units{Sizes: SizesIEC}, 1536, "1.5KiB"},
+		{"si", Units{Sizes: SizesSI}, 1500, "1.5kB"},
+		{"si kilobyte", Units{Sizes: SizesSI}, 1000, "1.0kB"},
+		{"gigabytes", Units{Sizes: SizesSI}, 2_500_000_000, "2.5GB"},
+		{"decimal comma", Units{Sizes: SizesIEC, DecimalSeparator: ","}, 1536, "1,5KiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.units.Size(tt.size); got != tt.want {
+				t.Errorf("Size(%d) = %s, want %s", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize_DefaultUnits(t *testing.T) {
+	defer func(units Units) { DefaultUnits = units }(DefaultUnits)
+	DefaultUnits = Units{Sizes: SizesIEC, DecimalSeparator: ","}
+	if got := FormatSize(2560); got != "2,5KiB" {
+		t.Errorf("FormatSize = %s, want 2,5KiB", got)
+	}
+	if got := FormatSize(-1); got != "-" {
+		t.Errorf("FormatSize(-1) = %s, want -", got)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+// GlobalsFileName is the name of the workspace globals file in the .lazycurl directory
+const GlobalsFileName = "globals.json"
+
+// Variable scopes, from the most specific
+const (
+	ScopeRequest     = "request"
+	ScopeEnvironment = "environment"
+	ScopeCollection  = "collection"
+	ScopeGlobals     = "globals"
+)
+
+// VariableScopes holds the variables a request can use besides those of the active
+// environment. A name resolves from the most specific scope defining it: the request,
+// the environment, the collection, then the workspace globals.
+type VariableScopes struct {
+	Request    map[string]string // Variables of the request
+	Collection map[string]string // Variables of the collection holding the request
+	Globals    map[string]string // Workspace globals (.lazycurl/globals.json)
+}
+
+// Resolve returns the variables of every scope, env being the active variables of the
+// environment, each name taking its value from the most specific scope
+func (s VariableScopes) Resolve(env map[string]string) map[string]string {
+	vars := make(map[string]string, len(s.Globals)+len(s.Collection)+len(env)+len(s.Request))
+	maps.Copy(vars, s.Globals)
+	maps.Copy(vars, s.Collection)
+	maps.Copy(vars, env)
+	maps.Copy(vars, s.Request)
+	return vars
+}
+
+// Lookup returns the value of name and the scope it resolves from, env being the
+// active variables of the environment
+func (s VariableScopes) Lookup(name string, env map[string]string) (value, scope string, ok bool) {
+	for _, level := range []struct {
+		scope string
+		vars  map[string]string
+	}{{ScopeRequest, s.Request}, {ScopeEnvironment, env}, {ScopeCollection, s.Collection}, {ScopeGlobals, s.Globals}} {
+		if value, ok := level.vars[name]; ok {
+			return value, level.scope, true
+		}
+	}
+	return "", "", false
+}
+
+// IsEmpty returns whether no scope has variables
+func (s VariableScopes) IsEmpty() bool {
+	return len(s.Request) == 0 && len(s.Collection) == 0 && len(s.Globals) == 0
+}
+
+// EnvironmentWithScopes converts envFile for scripts, with the variables of the other
+// scopes for lc.variables to resolve
+func EnvironmentWithScopes(envFile *EnvironmentFile, scopes VariableScopes) *Environment {
+	env := EnvironmentFromFile(envFile)
+	if scopes.IsEmpty() {
+		return env
+	}
+	if env == nil {
+		env = &Environment{Variables: make(map[string]string)}
+	}
+	env.Scopes = scopes
+	return env
+}
+
+// Globals holds the workspace globals: variables available to every request of the
+// workspace, whatever its collection and the active environment
+type Globals struct {
+	Variables map[string]string `json:"variables"`
+	path      string
+}
+
+// GlobalsPath returns the globals file path of a workspace
+func GlobalsPath(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", GlobalsFileName)
+}
+
+// LoadGlobals reads the globals of a workspace. A missing file yields no globals.
+func LoadGlobals(workspacePath string) (*Globals, error) {
+	g := &Globals{Variables: make(map[string]string), path: GlobalsPath(workspacePath)}
+	data, err := os.ReadFile(g.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return g, nil
+		}
+		return g, fmt.Errorf("failed to read globals: %w", err)
+	}
+	if err := json.Unmarshal(data, g); err != nil {
+		return g, fmt.Errorf("failed to parse globals: %w", err)
+	}
+	if g.Variables == nil {
+		g.Variables = make(map[string]string)
+	}
+	return g, nil
+}
+
+// Save writes the globals file
+func (g *Globals) Save() error {
+	if err := os.MkdirAll(filepath.Dir(g.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(g.path, data, 0600)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVariableScopes_Resolve(t *testing.T) {
+	scopes := VariableScopes{
+		Request:    map[string]string{"id": "42"},
+		Collection: map[string]string{"id": "1", "base_url": "https://collection", "version": "v2"},
+		Globals:    map[string]string{"version": "v1", "tenant": "acme"},
+	}
+	env := map[string]string{"base_url": "https://staging"}
+
+	want := map[string]string{"id": "42", "base_url": "https://staging", "version": "v2", "tenant": "acme"}
+	if got := scopes.Resolve(env); !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v, want %v", got, want)
+	}
+
+	for name, wantScope := range map[string]string{"id": ScopeRequest, "base_url": ScopeEnvironment, "version": ScopeCollection, "tenant": ScopeGlobals} {
+		if _, scope, ok := scopes.Lookup(name, env); !ok || scope != wantScope {
+			t.Errorf("Lookup(%q) scope = %q, want %q", name, scope, wantScope)
+		}
+	}
+}
+
+func TestGlobals_SaveLoad(t *testing.T) {
+	workspace := t.TempDir()
+
+	globals, err := LoadGlobals(workspace)
+	if err != nil || len(globals.Variables) != 0 {
+		t.Fatalf("LoadGlobals() without file = %+v, %v", globals, err)
+	}
+
+	globals.Variables["tenant"] = "acme"
+	if err := globals.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadGlobals(workspace)
+	if err != nil || loaded.Variables["tenant"] != "acme" {
+		t.Errorf("LoadGlobals() = %+v, %v", loaded, err)
+	}
+}
+
+func TestEnvironmentWithScopes(t *testing.T) {
+	if env := EnvironmentWithScopes(nil, VariableScopes{}); env != nil {
+		t.Errorf("EnvironmentWithScopes() without environment or scopes = %+v, want nil", env)
+	}
+	env := EnvironmentWithScopes(nil, VariableScopes{Globals: map[string]string{"tenant": "acme"}})
+	if env == nil || env.Scopes.Globals["tenant"] != "acme" {
+		t.Errorf("EnvironmentWithScopes() = %+v, want the globals", env)
+	}
+}
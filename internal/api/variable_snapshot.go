@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VariableSnapshot records the environment variable values a request was sent with,
+// so history entries can show and replay the exact values used at that time
+type VariableSnapshot struct {
+	Environment string            // Active environment name ("" when none)
+	Values      map[string]string // Variable name -> value, for variables the request referenced
+	Secrets     map[string]bool   // Variables whose values are masked when displayed
+}
+
+// NewVariableSnapshot captures the values of the variables referenced by req
+// (URL, enabled headers, body and auth fields) from the active environment.
+// Variables that are undefined or inactive in env are not recorded.
+func NewVariableSnapshot(req *CollectionRequest, env *EnvironmentFile) *VariableSnapshot {
+	snapshot := &VariableSnapshot{
+		Values:  make(map[string]string),
+		Secrets: make(map[string]bool),
+	}
+	if env != nil {
+		snapshot.Environment = env.Name
+	}
+	if req == nil || env == nil {
+		return snapshot
+	}
+
+	for _, name := range requestVariableReferences(req) {
+		v, ok := env.Variables[name]
+		if !ok || v == nil || !v.Active {
+			continue
+		}
+		snapshot.Values[name] = v.Value
+		if v.Secret || isSecretKey(name) {
+			snapshot.Secrets[name] = true
+		}
+	}
+	return snapshot
+}
+
+// requestVariableReferences returns the variable names referenced by a request
+func requestVariableReferences(req *CollectionRequest) []string {
+	texts := []string{req.URL}
+	for _, h := range req.Headers {
+		if h.Enabled {
+			texts = append(texts, h.Key, h.Value)
+		}
+	}
+	if req.Body != nil && req.Body.Content != nil {
+		switch content := req.Body.Content.(type) {
+		case string:
+			texts = append(texts, content)
+		default:
+			if data, err := json.Marshal(content); err == nil {
+				texts = append(texts, string(data))
+			}
+		}
+	}
+	if auth := req.Auth; auth != nil {
+		texts = append(texts, auth.Token, auth.Username, auth.Password,
+			auth.APIKeyName, auth.APIKeyValue, auth.JWTKey, auth.JWTClaims, auth.JWTExpiresIn)
+	}
+
+	var names []string
+	for _, text := range texts {
+		names = append(names, FindVariables(text)...)
+	}
+	return uniqueStrings(names)
+}
+
+// IsEmpty returns true if no variable values were recorded
+func (s *VariableSnapshot) IsEmpty() bool {
+	return s == nil || len(s.Values) == 0
+}
+
+// Names returns the recorded variable names in sorted order
+func (s *VariableSnapshot) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.Values))
+	for name := range s.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DisplayValue returns a variable value for display, masking secrets as "***"
+func (s *VariableSnapshot) DisplayValue(name string) string {
+	if s.Secrets[name] {
+		return "***"
+	}
+	return s.Values[name]
+}
+
+// Summary returns a one-line description such as "base_url=https://api.example.com token=***"
+func (s *VariableSnapshot) Summary() string {
+	if s.IsEmpty() {
+		return ""
+	}
+	parts := make([]string, 0, len(s.Values))
+	for _, name := range s.Names() {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, s.DisplayValue(name)))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewVariableSnapshot(t *testing.T) {
+	env := &EnvironmentFile{
+		Name: "dev",
+		Variables: map[string]*EnvironmentVariable{
+			"base_url":  {Value: "https://api.dev", Active: true},
+			"token":     {Value: "abc123", Active: true},
+			"tenant":    {Value: "acme", Secret: true, Active: true},
+			"disabled":  {Value: "x", Active: false},
+			"unrelated": {Value: "y", Active: true},
+		},
+	}
+
+	req := &CollectionRequest{
+		URL: "{{base_url}}/users?tenant={{tenant}}",
+		Headers: []KeyValueEntry{
+			{Key: "X-Trace", Value: "{{disabled}}", Enabled: true},
+			{Key: "X-Off", Value: "{{unrelated}}", Enabled: false},
+		},
+		Body: &BodyConfig{Type: "json", Content: map[string]interface{}{"name": "{{missing}}"}},
+		Auth: &AuthConfig{Type: "bearer", Token: "{{token}}"},
+	}
+
+	snapshot := NewVariableSnapshot(req, env)
+
+	if snapshot.Environment != "dev" {
+		t.Errorf("Environment = %q, want dev", snapshot.Environment)
+	}
+	want := map[string]string{"base_url": "https://api.dev", "tenant": "acme", "token": "abc123"}
+	if !reflect.DeepEqual(snapshot.Values, want) {
+		t.Errorf("Values = %v, want %v", snapshot.Values, want)
+	}
+	if got := snapshot.Summary(); got != "base_url=https://api.dev tenant=*** token=***" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	// The snapshot must not change when the environment does
+	env.Variables["base_url"].Value = "https://api.prod"
+	if snapshot.Values["base_url"] != "https://api.dev" {
+		t.Error("snapshot should keep the value from send time")
+	}
+}
+
+func TestNewVariableSnapshot_NoEnvironment(t *testing.T) {
+	snapshot := NewVariableSnapshot(&CollectionRequest{URL: "{{base_url}}"}, nil)
+	if !snapshot.IsEmpty() {
+		t.Errorf("expected empty snapshot, got %v", snapshot.Values)
+	}
+	if snapshot.Summary() != "" {
+		t.Errorf("Summary() = %q, want empty", snapshot.Summary())
+	}
+}
+
+func TestConsoleEntry_SentWith(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables *VariableSnapshot
+		want      string
+	}{
+		{name: "no snapshot", variables: nil, want: ""},
+		{name: "empty snapshot", variables: &VariableSnapshot{Environment: "dev"}, want: ""},
+		{
+			name:      "with environment",
+			variables: &VariableSnapshot{Environment: "dev", Values: map[string]string{"base_url": "http://x", "api_key": "k"}, Secrets: map[string]bool{"api_key": true}},
+			want:      "dev: api_key=*** base_url=http://x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := NewConsoleEntry(&Request{Method: GET, URL: "http://x"}, nil, nil, 0)
+			entry.Variables = tt.variables
+			if got := entry.SentWith(); got != tt.want {
+				t.Errorf("SentWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
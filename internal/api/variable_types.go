@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Variable types: how the value of an environment variable is checked and templated into
+// JSON bodies. A placeholder standing for a whole JSON string, "{{page_size}}", is
+// replaced by the value of a number, boolean or JSON variable without the quotes.
+const (
+	VariableTypeString  = "string"
+	VariableTypeNumber  = "number"
+	VariableTypeBoolean = "boolean"
+	VariableTypeJSON    = "json"
+)
+
+// VariableTypes lists the variable types, the default first
+var VariableTypes = []string{VariableTypeString, VariableTypeNumber, VariableTypeBoolean, VariableTypeJSON}
+
+// quotedPlaceholderPattern matches a placeholder standing for a whole JSON string
+var quotedPlaceholderPattern = regexp.MustCompile(`"\{\{\s*([^{}"]+?)\s*\}\}"`)
+
+// ValidateVariableType returns an error for a type that is not one of VariableTypes.
+// Empty is a string.
+func ValidateVariableType(typ string) error {
+	if typ == "" {
+		return nil
+	}
+	for _, t := range VariableTypes {
+		if typ == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown type %q (want %s)", typ, strings.Join(VariableTypes, ", "))
+}
+
+// ValidateVariableValue returns an error when value is not a value of type typ: a JSON
+// number, true or false, or a JSON document
+func ValidateVariableValue(typ, value string) error {
+	switch typ {
+	case VariableTypeNumber:
+		var number interface{}
+		err := json.Unmarshal([]byte(value), &number)
+		if _, ok := number.(float64); err != nil || !ok {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case VariableTypeBoolean:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+	case VariableTypeJSON:
+		var doc interface{}
+		if err := json.Unmarshal([]byte(value), &doc); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		return ValidateVariableType(typ)
+	}
+	return nil
+}
+
+// ValueType returns the type of the variable, VariableTypeString when it has none
+func (v *EnvironmentVariable) ValueType() string {
+	if v.Type == "" {
+		return VariableTypeString
+	}
+	return v.Type
+}
+
+// VariableTypes returns the types of the active variables that are not strings, by name
+func (e *EnvironmentFile) VariableTypes() map[string]string {
+	if e == nil {
+		return nil
+	}
+	var types map[string]string
+	for name, v := range e.Variables {
+		if !v.Active || v.ValueType() == VariableTypeString {
+			continue
+		}
+		if types == nil {
+			types = make(map[string]string)
+		}
+		types[name] = v.Type
+	}
+	return types
+}
+
+// VariableTypes returns the types of the typed variables of env that a request takes
+// from the environment: a request variable of the same name overrides the environment
+// value, and its type with it
+func (s VariableScopes) VariableTypes(env *EnvironmentFile) map[string]string {
+	types := env.VariableTypes()
+	for name := range s.Request {
+		delete(types, name)
+	}
+	return types
+}
+
+// UnquoteTypedPlaceholders drops the quotes around the placeholders of JSON text that
+// stand for a whole string when their variable is a number, boolean or JSON and its
+// value in vars is one, so that "{{page_size}}" is templated as 20 rather than "20".
+// Other values stay quoted. types holds the variables vars takes from the environment.
+func UnquoteTypedPlaceholders(text string, types, vars map[string]string) string {
+	if len(types) == 0 {
+		return text
+	}
+	return quotedPlaceholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := strings.TrimSpace(match[3 : len(match)-3])
+		typ, ok := types[name]
+		if !ok {
+			return match
+		}
+		if value, ok := vars[name]; !ok || ValidateVariableValue(typ, value) != nil {
+			return match
+		}
+		return match[1 : len(match)-1]
+	})
+}
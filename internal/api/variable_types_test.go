@@ -0,0 +1,98 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateVariableValue(t *testing.T) {
+	tests := []struct {
+		typ, value string
+		valid      bool
+	}{
+		{"", "anything", true},
+		{VariableTypeString, "20", true},
+		{VariableTypeNumber, "20", true},
+		{VariableTypeNumber, "-1.5e3", true},
+		{VariableTypeNumber, `"20"`, false},
+		{VariableTypeNumber, "null", false},
+		{VariableTypeNumber, "twenty", false},
+		{VariableTypeBoolean, "false", true},
+		{VariableTypeBoolean, "yes", false},
+		{VariableTypeJSON, `{"sort": ["name"]}`, true},
+		{VariableTypeJSON, `{"sort": }`, false},
+		{"date", "2024-01-01", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateVariableValue(tt.typ, tt.value); (err == nil) != tt.valid {
+			t.Errorf("ValidateVariableValue(%q, %q) = %v, want valid %v", tt.typ, tt.value, err, tt.valid)
+		}
+	}
+}
+
+func TestUnquoteTypedPlaceholders(t *testing.T) {
+	types := map[string]string{"page_size": VariableTypeNumber, "filter": VariableTypeJSON, "debug": VariableTypeBoolean, "limit": VariableTypeNumber}
+	vars := map[string]string{"page_size": "20", "filter": `{"active": true}`, "debug": "yes", "name": "Ada"}
+	body := `{"size": "{{page_size}}", "filter": "{{ filter }}", "name": "{{name}}", "label": "page {{page_size}}", "debug": "{{debug}}", "limit": "{{limit}}"}`
+	// A value that is not of its type, or an undefined variable, stays quoted
+	want := `{"size": {{page_size}}, "filter": {{ filter }}, "name": "{{name}}", "label": "page {{page_size}}", "debug": "{{debug}}", "limit": "{{limit}}"}`
+	if got := UnquoteTypedPlaceholders(body, types, vars); got != want {
+		t.Errorf("UnquoteTypedPlaceholders() = %s, want %s", got, want)
+	}
+}
+
+func TestVariableScopes_VariableTypes(t *testing.T) {
+	env := &EnvironmentFile{Name: "dev", Variables: map[string]*EnvironmentVariable{
+		"page_size": {Value: "20", Active: true, Type: VariableTypeNumber},
+		"debug":     {Value: "true", Active: true, Type: VariableTypeBoolean},
+	}}
+	scopes := VariableScopes{
+		Request:    map[string]string{"page_size": "twenty"},
+		Collection: map[string]string{"debug": "no"},
+	}
+	// The request variable overrides the environment and its type; the collection one
+	// does not override the environment
+	types := scopes.VariableTypes(env)
+	if len(types) != 1 || types["debug"] != VariableTypeBoolean {
+		t.Errorf("VariableTypes() = %v, want debug only", types)
+	}
+	body := `{"size": "{{page_size}}", "debug": "{{debug}}"}`
+	want := `{"size": "{{page_size}}", "debug": {{debug}}}`
+	if got := UnquoteTypedPlaceholders(body, types, scopes.Resolve(EnvironmentFromFile(env).Variables)); got != want {
+		t.Errorf("UnquoteTypedPlaceholders() = %s, want %s", got, want)
+	}
+	if types := (VariableScopes{}).VariableTypes(nil); types != nil {
+		t.Errorf("VariableTypes() without environment = %v, want nil", types)
+	}
+}
+
+func TestEnvironmentFile_VariableTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.json")
+	env := &EnvironmentFile{Name: "dev", Variables: map[string]*EnvironmentVariable{
+		"page_size": {Value: "20", Active: true, Type: VariableTypeNumber},
+		"debug":     {Value: "true", Active: false, Type: VariableTypeBoolean},
+		"name":      {Value: "Ada", Active: true},
+	}}
+	if err := SaveEnvironment(env, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadEnvironment(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := loaded.VariableTypes()
+	if len(types) != 1 || types["page_size"] != VariableTypeNumber {
+		t.Errorf("VariableTypes() = %v, want the active typed variable only", types)
+	}
+	if loaded.Clone().Variables["debug"].Type != VariableTypeBoolean {
+		t.Error("Clone() should keep the variable types")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name": "dev", "variables": {"size": {"value": "1", "active": true, "type": "int"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEnvironment(path); err == nil {
+		t.Error("an unknown variable type should fail to load")
+	}
+}
@@ -17,14 +17,16 @@ var (
 
 // System variable prefixes
 const (
-	SystemVarTimestamp  = "$timestamp"
-	SystemVarDatetime   = "$datetime"
-	SystemVarDate       = "$date"
-	SystemVarTime       = "$time"
-	SystemVarRandomInt  = "$randomInt"
-	SystemVarRandomUUID = "$uuid"
-	SystemVarGUID       = "$guid"
-	SystemVarRandom     = "$random"
+	SystemVarTimestamp    = "$timestamp"
+	SystemVarISOTimestamp = "$isoTimestamp"
+	SystemVarDatetime     = "$datetime"
+	SystemVarDate         = "$date"
+	SystemVarTime         = "$time"
+	SystemVarRandomInt    = "$randomInt"
+	SystemVarRandomUUID   = "$uuid"
+	SystemVarPostmanUUID  = "$randomUUID"
+	SystemVarGUID         = "$guid"
+	SystemVarRandom       = "$random"
 )
 
 // ReplaceVariables replaces all variables in a string with their values from the environment
@@ -47,6 +49,11 @@ func ReplaceVariables(text string, env *EnvironmentFile) string {
 			}
 		}
 
+		// Template helpers such as {{now "2006-01-02" "UTC"}}
+		if value, ok := EvalTemplateFunction(varName); ok {
+			return value
+		}
+
 		// Return original if not found (keep the placeholder)
 		return match
 	})
@@ -60,6 +67,10 @@ func ReplaceVariablesInRequest(req *Request, env *EnvironmentFile) *Request {
 		Headers: make(map[string]string),
 		Body:    req.Body,
 		Timeout: req.Timeout,
+
+		NoFollowRedirects: req.NoFollowRedirects,
+		MaxRedirects:      req.MaxRedirects,
+		Retry:             req.Retry,
 	}
 
 	// Replace in headers
@@ -85,6 +96,9 @@ func replaceVariablesInBody(body interface{}, env *EnvironmentFile) interface{}
 	case string:
 		return ReplaceVariables(v, env)
 
+	case *FileBody:
+		return &FileBody{Path: ReplaceVariables(v.Path, env), Progress: v.Progress}
+
 	case map[string]interface{}:
 		result := make(map[string]interface{})
 		for key, value := range v {
@@ -105,7 +119,8 @@ func replaceVariablesInBody(body interface{}, env *EnvironmentFile) interface{}
 	}
 }
 
-// GetSystemVariable returns the value of a system variable
+// GetSystemVariable returns the value of a system variable or of a Postman dynamic
+// variable ($randomEmail, $randomFirstName...), or "" for an unknown name.
 // Exported for use by preview mode in editor
 func GetSystemVariable(name string) string {
 	now := time.Now()
@@ -114,6 +129,9 @@ func GetSystemVariable(name string) string {
 	case SystemVarTimestamp:
 		return fmt.Sprintf("%d", now.Unix())
 
+	case SystemVarISOTimestamp:
+		return now.UTC().Format("2006-01-02T15:04:05.000Z")
+
 	case SystemVarDatetime:
 		return now.Format(time.RFC3339)
 
@@ -126,7 +144,7 @@ func GetSystemVariable(name string) string {
 	case SystemVarRandomInt:
 		return fmt.Sprintf("%d", rand.Intn(1000000))
 
-	case SystemVarRandomUUID, SystemVarGUID:
+	case SystemVarRandomUUID, SystemVarPostmanUUID, SystemVarGUID:
 		return uuid.New().String()
 
 	case SystemVarRandom:
@@ -134,6 +152,9 @@ func GetSystemVariable(name string) string {
 		return generateRandomString(10)
 
 	default:
+		if generate, ok := dynamicVariables[name]; ok {
+			return generate()
+		}
 		return ""
 	}
 }
@@ -169,8 +190,11 @@ func FindUnresolvedVariables(text string, env *EnvironmentFile) []string {
 	unresolved := []string{}
 
 	for _, varName := range allVars {
-		// System variables are always resolved
-		if strings.HasPrefix(varName, "$") {
+		// System variables are always resolved, prompt variables at send time
+		if strings.HasPrefix(varName, "$") || IsPromptVariable(varName) {
+			continue
+		}
+		if _, ok := EvalTemplateFunction(varName); ok {
 			continue
 		}
 
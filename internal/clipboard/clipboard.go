@@ -0,0 +1,134 @@
+// Package clipboard copies text for LazyCurl. It writes to the system clipboard, or to
+// the terminal's through an OSC 52 escape sequence where the system clipboard cannot be
+// reached, such as over SSH. Copied values are also kept in numbered registers so
+// they can be pasted back later.
+package clipboard
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	system "golang.design/x/clipboard"
+)
+
+// Clipboard modes, the values of the clipboard option of the global config
+const (
+	ModeAuto   = "auto"   // System clipboard, OSC 52 over SSH or when it is unavailable
+	ModeSystem = "system" // System clipboard only
+	ModeOSC52  = "osc52"  // OSC 52 only
+)
+
+// Methods a copy was made with
+const (
+	MethodSystem = "system"
+	MethodOSC52  = "OSC 52"
+)
+
+var (
+	mu        sync.Mutex
+	mode      = ModeAuto
+	systemOK  bool
+	terminal  io.Writer = os.Stdout
+	getenv              = os.Getenv
+	registers Registers
+)
+
+// Init sets up the clipboard in mode (empty for ModeAuto). OSC 52 sequences are
+// written to out, the terminal.
+func Init(m string, out io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if m == "" {
+		m = ModeAuto
+	}
+	mode, terminal = m, out
+	systemOK = mode != ModeOSC52 && system.Init() == nil
+}
+
+// Method returns how copies are made in the current mode and environment
+func Method() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return method()
+}
+
+// method returns how copies are made; mu must be held
+func method() string {
+	switch mode {
+	case ModeSystem:
+		return MethodSystem
+	case ModeOSC52:
+		return MethodOSC52
+	}
+	if !systemOK || overSSH() {
+		return MethodOSC52
+	}
+	return MethodSystem
+}
+
+// overSSH returns true if LazyCurl runs in an SSH session, where the system clipboard
+// is the remote machine's
+func overSSH() bool {
+	return getenv("SSH_TTY") != "" || getenv("SSH_CONNECTION") != ""
+}
+
+// Copy copies text to the clipboard and keeps it in register 0 under label, shifting
+// the other registers. Returns the method used.
+func Copy(label, text string) string {
+	mu.Lock()
+	defer mu.Unlock()
+	registers.Push(Register{Label: label, Content: text, Time: time.Now()})
+	return write(text)
+}
+
+// Put copies register n back to the clipboard, to paste it with the terminal. Returns
+// false if the register is empty.
+func Put(n int) (Register, string, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	reg, ok := registers.Get(n)
+	if !ok {
+		return Register{}, "", false
+	}
+	return reg, write(reg.Content), true
+}
+
+// write copies text with the method of the mode; mu must be held
+func write(text string) string {
+	m := method()
+	if m == MethodSystem {
+		system.Write(system.FmtText, []byte(text))
+	} else {
+		io.WriteString(terminal, OSC52(text, getenv("TMUX") != ""))
+	}
+	return m
+}
+
+// OSC52 returns the escape sequence setting the terminal clipboard to text. In tmux,
+// the sequence is wrapped to pass through to the outer terminal (tmux needs
+// "set -g allow-passthrough on").
+func OSC52(text string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if tmux {
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// Get returns register n
+func Get(n int) (Register, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	return registers.Get(n)
+}
+
+// All returns the registers holding a value, register 0 first
+func All() []Register {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Register(nil), registers.entries...)
+}
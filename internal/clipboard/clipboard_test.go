@@ -0,0 +1,72 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestOSC52(t *testing.T) {
+	if got := OSC52("hi", false); got != "\x1b]52;c;aGk=\a" {
+		t.Errorf("OSC52() = %q", got)
+	}
+	if got := OSC52("hi", true); got != "\x1bPtmux;\x1b\x1b]52;c;aGk=\a\x1b\\" {
+		t.Errorf("OSC52(tmux) = %q", got)
+	}
+}
+
+func TestRegisters(t *testing.T) {
+	var r Registers
+	for i := range RegisterCount + 2 {
+		r.Push(Register{Label: "value", Content: fmt.Sprint(i)})
+	}
+	if r.Len() != RegisterCount {
+		t.Fatalf("Len() = %d", r.Len())
+	}
+	if reg, _ := r.Get(0); reg.Content != "11" {
+		t.Errorf("register 0 = %q", reg.Content)
+	}
+	if reg, _ := r.Get(9); reg.Content != "2" {
+		t.Errorf("register 9 = %q", reg.Content)
+	}
+
+	// Copying a value again moves it to register 0
+	r.Push(Register{Label: "again", Content: "5"})
+	if reg, _ := r.Get(0); reg.Label != "again" || r.Len() != RegisterCount {
+		t.Errorf("register 0 = %+v, %d registers", reg, r.Len())
+	}
+	if reg, _ := r.Get(7); reg.Content != "4" {
+		t.Errorf("register 7 = %q", reg.Content)
+	}
+	if _, ok := r.Get(RegisterCount); ok {
+		t.Error("Get() found a register beyond RegisterCount")
+	}
+}
+
+func TestCopy_OSC52(t *testing.T) {
+	defer func() { registers = Registers{} }()
+	getenv = func(key string) string {
+		if key == "SSH_TTY" {
+			return "/dev/pts/1"
+		}
+		return ""
+	}
+	defer func() { getenv = os.Getenv }()
+
+	var out bytes.Buffer
+	Init(ModeAuto, &out)
+	if m := Copy("URL", "hi"); m != MethodOSC52 || out.String() != OSC52("hi", false) {
+		t.Errorf("Copy() over SSH = %s, wrote %q", m, out.String())
+	}
+
+	out.Reset()
+	Copy("Body", "{}")
+	reg, m, ok := Put(1)
+	if !ok || reg.Label != "URL" || m != MethodOSC52 || out.String() != OSC52("{}", false)+OSC52("hi", false) {
+		t.Errorf("Put(1) = %+v, %s, %v; wrote %q", reg, m, ok, out.String())
+	}
+	if _, _, ok := Put(2); ok {
+		t.Error("Put() succeeded on an empty register")
+	}
+}
@@ -0,0 +1,43 @@
+package clipboard
+
+import "time"
+
+// RegisterCount is the number of registers, 0 to 9
+const RegisterCount = 10
+
+// Register is a copied value
+type Register struct {
+	Label   string // What was copied, such as "URL" or "Response body"
+	Content string
+	Time    time.Time
+}
+
+// Registers keeps the latest copied values, the newest in register 0
+type Registers struct {
+	entries []Register
+}
+
+// Push puts reg in register 0 and shifts the others, dropping the oldest beyond
+// RegisterCount. A value already held moves to register 0 instead of being kept twice.
+func (r *Registers) Push(reg Register) {
+	kept := []Register{reg}
+	for _, old := range r.entries {
+		if old.Content != reg.Content && len(kept) < RegisterCount {
+			kept = append(kept, old)
+		}
+	}
+	r.entries = kept
+}
+
+// Get returns register n
+func (r *Registers) Get(n int) (Register, bool) {
+	if n < 0 || n >= len(r.entries) {
+		return Register{}, false
+	}
+	return r.entries[n], true
+}
+
+// Len returns the number of registers holding a value
+func (r *Registers) Len() int {
+	return len(r.entries)
+}
@@ -0,0 +1,405 @@
+// Package codegen serializes resolved HTTP requests into code snippets
+// (cURL, JavaScript fetch, Python requests and HTTPie).
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// Language identifies a snippet output format
+type Language string
+
+const (
+	Curl   Language = "curl"
+	Fetch  Language = "fetch"
+	Python Language = "python"
+	HTTPie Language = "httpie"
+)
+
+// Languages lists the supported languages in display order
+var Languages = []Language{Curl, Fetch, Python, HTTPie}
+
+// ErrBinaryBody is returned for requests whose body is binary (msgpack, cbor)
+var ErrBinaryBody = errors.New("binary request bodies cannot be exported as code")
+
+// languageAliases maps accepted names to languages
+var languageAliases = map[string]Language{
+	"curl":       Curl,
+	"fetch":      Fetch,
+	"js":         Fetch,
+	"javascript": Fetch,
+	"python":     Python,
+	"py":         Python,
+	"requests":   Python,
+	"httpie":     HTTPie,
+	"http":       HTTPie,
+}
+
+// Label returns the human-readable name of the language
+func (l Language) Label() string {
+	switch l {
+	case Curl:
+		return "cURL"
+	case Fetch:
+		return "JavaScript fetch"
+	case Python:
+		return "Python requests"
+	case HTTPie:
+		return "HTTPie"
+	default:
+		return string(l)
+	}
+}
+
+// ParseLanguage parses a language name (case-insensitive, common aliases accepted)
+func ParseLanguage(name string) (Language, error) {
+	if lang, ok := languageAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return lang, nil
+	}
+	names := make([]string, len(Languages))
+	for i, lang := range Languages {
+		names[i] = string(lang)
+	}
+	return "", fmt.Errorf("unknown language %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// Generate serializes a resolved request into a snippet for the given language.
+// Variables and auth must already be applied to req.
+func Generate(lang Language, req *api.Request) (string, error) {
+	if req == nil || req.URL == "" {
+		return "", errors.New("no request to export")
+	}
+
+	s, err := newSnippet(req)
+	if err != nil {
+		return "", err
+	}
+
+	switch lang {
+	case Curl:
+		return s.curl(), nil
+	case Fetch:
+		return s.fetch(), nil
+	case Python:
+		return s.python(), nil
+	case HTTPie:
+		return s.httpie(), nil
+	default:
+		return "", fmt.Errorf("unknown language %q", lang)
+	}
+}
+
+// header is a single request header
+type header struct {
+	Key   string
+	Value string
+}
+
+// snippet is the language-independent form of a request
+type snippet struct {
+	method   string
+	url      string
+	headers  []header        // Sorted by key
+	body     string          // Body as sent on the wire
+	jsonBody interface{}     // Decoded JSON body, nil for text bodies
+	form     []api.FormField // Enabled fields of a multipart/form-data body
+}
+
+// newSnippet normalizes a request the way Client.Send encodes it:
+// strings are sent as-is, other values as JSON with a default Content-Type.
+func newSnippet(req *api.Request) (*snippet, error) {
+	s := &snippet{
+		method: strings.ToUpper(string(req.Method)),
+		url:    req.URL,
+	}
+	if s.method == "" {
+		s.method = string(api.GET)
+	}
+
+	for key, value := range req.Headers {
+		s.headers = append(s.headers, header{Key: key, Value: value})
+	}
+
+	switch body := req.Body.(type) {
+	case nil:
+	case []byte:
+		return nil, ErrBinaryBody
+	case *api.MultipartForm:
+		for _, field := range body.Fields {
+			if field.Enabled && field.Key != "" {
+				s.form = append(s.form, field)
+			}
+		}
+		// Every tool sets the multipart Content-Type itself, with the boundary it picks
+		headers := s.headers[:0]
+		for _, h := range s.headers {
+			if !strings.EqualFold(h.Key, "Content-Type") {
+				headers = append(headers, h)
+			}
+		}
+		s.headers = headers
+	case *api.FileBody:
+		return nil, ErrBinaryBody
+	case string:
+		s.body = body
+	default:
+		data, err := marshalJSON(body, "")
+		if err != nil {
+			return nil, err
+		}
+		s.body = data
+		s.jsonBody = body
+		if !s.hasHeader("Content-Type") {
+			s.headers = append(s.headers, header{Key: "Content-Type", Value: "application/json"})
+		}
+	}
+
+	sort.Slice(s.headers, func(i, j int) bool { return s.headers[i].Key < s.headers[j].Key })
+	return s, nil
+}
+
+// hasHeader reports whether the header is set (case-insensitive)
+func (s *snippet) hasHeader(key string) bool {
+	for _, h := range s.headers {
+		if strings.EqualFold(h.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// curl renders a multiline cURL command
+func (s *snippet) curl() string {
+	req := &api.CollectionRequest{
+		Method: api.HTTPMethod(s.method),
+		URL:    s.url,
+	}
+	for _, h := range s.headers {
+		req.Headers = append(req.Headers, api.KeyValueEntry{Key: h.Key, Value: h.Value, Enabled: true})
+	}
+	if s.body != "" {
+		req.Body = &api.BodyConfig{Type: "raw", Content: s.body}
+	}
+	if len(s.form) > 0 {
+		req.Body = &api.BodyConfig{Type: api.BodyTypeFormData, Content: s.form}
+	}
+
+	opts := api.DefaultGeneratorOptions()
+	opts.Multiline = true
+	return api.GenerateCurlCommandWithOptions(req, opts)
+}
+
+// fetch renders a JavaScript fetch call
+func (s *snippet) fetch() string {
+	var b strings.Builder
+	if len(s.form) > 0 {
+		s.fetchForm(&b)
+	}
+	b.WriteString("fetch(" + jsonString(s.url) + ", {\n")
+	b.WriteString("  method: " + jsonString(s.method) + ",\n")
+
+	if len(s.headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for i, h := range s.headers {
+			b.WriteString("    " + jsonString(h.Key) + ": " + jsonString(h.Value))
+			if i < len(s.headers)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("  },\n")
+	}
+
+	if len(s.form) > 0 {
+		b.WriteString("  body: form,\n")
+	} else if s.body != "" {
+		if s.jsonBody != nil {
+			data, _ := marshalJSON(s.jsonBody, "  ")
+			b.WriteString("  body: JSON.stringify(" + data + "),\n")
+		} else {
+			b.WriteString("  body: " + jsonString(s.body) + ",\n")
+		}
+	}
+
+	b.WriteString("})\n")
+	b.WriteString("  .then((response) => response.text())\n")
+	b.WriteString("  .then((text) => console.log(text));")
+	return b.String()
+}
+
+// fetchForm writes the FormData of a form-data body; files are read with Node's fs
+func (s *snippet) fetchForm(b *strings.Builder) {
+	for _, field := range s.form {
+		if field.IsFile() {
+			b.WriteString("const fs = require(\"fs\");\n\n")
+			break
+		}
+	}
+	b.WriteString("const form = new FormData();\n")
+	for _, field := range s.form {
+		if field.IsFile() {
+			b.WriteString("form.append(" + jsonString(field.Key) + ", new Blob([fs.readFileSync(" + jsonString(field.Value) + ")]), " + jsonString(filepath.Base(field.Value)) + ");\n")
+		} else {
+			b.WriteString("form.append(" + jsonString(field.Key) + ", " + jsonString(field.Value) + ");\n")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// python renders a Python requests call
+func (s *snippet) python() string {
+	var b strings.Builder
+	b.WriteString("import requests\n\n")
+	b.WriteString("url = " + jsonString(s.url) + "\n")
+
+	args := []string{jsonString(s.method), "url"}
+
+	if len(s.headers) > 0 {
+		b.WriteString("headers = {\n")
+		for _, h := range s.headers {
+			b.WriteString("    " + jsonString(h.Key) + ": " + jsonString(h.Value) + ",\n")
+		}
+		b.WriteString("}\n")
+		args = append(args, "headers=headers")
+	}
+
+	if len(s.form) > 0 {
+		// (None, value) sends text fields as parts too, so the body is always multipart
+		b.WriteString("files = [\n")
+		for _, field := range s.form {
+			if field.IsFile() {
+				b.WriteString("    (" + jsonString(field.Key) + ", (" + jsonString(filepath.Base(field.Value)) + ", open(" + jsonString(field.Value) + ", \"rb\"))),\n")
+			} else {
+				b.WriteString("    (" + jsonString(field.Key) + ", (None, " + jsonString(field.Value) + ")),\n")
+			}
+		}
+		b.WriteString("]\n")
+		args = append(args, "files=files")
+	} else if s.body != "" {
+		if s.jsonBody != nil {
+			b.WriteString("payload = " + pythonLiteral(s.jsonBody, "") + "\n")
+			args = append(args, "json=payload")
+		} else {
+			b.WriteString("payload = " + jsonString(s.body) + "\n")
+			args = append(args, "data=payload")
+		}
+	}
+
+	b.WriteString("\nresponse = requests.request(" + strings.Join(args, ", ") + ")\n")
+	b.WriteString("print(response.text)")
+	return b.String()
+}
+
+// httpie renders an HTTPie command
+func (s *snippet) httpie() string {
+	parts := []string{"http"}
+	if len(s.form) > 0 {
+		// --multipart, as --form alone sends text fields urlencoded
+		parts = append(parts, "--multipart")
+	} else if s.body != "" {
+		parts = append(parts, "--raw "+shellQuote(s.body))
+	}
+	parts = append(parts, s.method+" "+shellQuote(s.url))
+
+	for _, h := range s.headers {
+		// "Header:" unsets a header in HTTPie; "Header;" sends it empty
+		if h.Value == "" {
+			parts = append(parts, shellQuote(h.Key+";"))
+		} else {
+			parts = append(parts, shellQuote(h.Key+":"+h.Value))
+		}
+	}
+
+	for _, field := range s.form {
+		if field.IsFile() {
+			parts = append(parts, shellQuote(field.Key+"@"+field.Value))
+		} else {
+			parts = append(parts, shellQuote(field.Key+"="+field.Value))
+		}
+	}
+
+	return strings.Join(parts, " \\\n  ")
+}
+
+// marshalJSON encodes v without HTML escaping, indenting nested lines with prefix
+func marshalJSON(v interface{}, prefix string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if prefix != "" {
+		encoder.SetIndent(prefix, "  ")
+	}
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// jsonString quotes s as a JSON string, which is also a valid JavaScript and Python literal
+func jsonString(s string) string {
+	data, _ := marshalJSON(s, "")
+	return data
+}
+
+// pythonLiteral renders a decoded JSON value as a Python literal
+func pythonLiteral(v interface{}, indent string) string {
+	inner := indent + "    "
+	switch value := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if value {
+			return "True"
+		}
+		return "False"
+	case string:
+		return jsonString(value)
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range keys {
+			b.WriteString(inner + jsonString(key) + ": " + pythonLiteral(value[key], inner) + ",\n")
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case []interface{}:
+		if len(value) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range value {
+			b.WriteString(inner + pythonLiteral(item, inner) + ",\n")
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	default:
+		// Numbers: JSON number syntax is valid Python
+		data, err := marshalJSON(value, "")
+		if err != nil {
+			return "None"
+		}
+		return data
+	}
+}
+
+// shellQuote wraps s in single quotes for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
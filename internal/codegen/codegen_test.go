@@ -0,0 +1,228 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Language
+		wantErr bool
+	}{
+		{input: "curl", want: Curl},
+		{input: " FETCH ", want: Fetch},
+		{input: "js", want: Fetch},
+		{input: "py", want: Python},
+		{input: "requests", want: Python},
+		{input: "http", want: HTTPie},
+		{input: "go", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLanguage(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	jsonReq := &api.Request{
+		Method:  api.POST,
+		URL:     "https://api.test/users?q=it's",
+		Headers: map[string]string{"Authorization": "Bearer abc", "X-Empty": ""},
+		Body:    map[string]interface{}{"name": "Ada", "admin": true, "tags": []interface{}{"a"}, "age": float64(36), "team": nil},
+	}
+	textReq := &api.Request{
+		Method: api.PUT,
+		URL:    "https://api.test/notes/1",
+		Body:   "line 1\nline \"2\"",
+	}
+	getReq := &api.Request{Method: api.GET, URL: "https://api.test/health"}
+
+	tests := []struct {
+		name    string
+		lang    Language
+		req     *api.Request
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "curl json",
+			lang: Curl,
+			req:  jsonReq,
+			want: []string{
+				"curl \\\n  -X POST",
+				"-H 'Authorization: Bearer abc'",
+				"-H 'Content-Type: application/json'",
+				`--data-raw '{"admin":true,"age":36,"name":"Ada","tags":["a"],"team":null}'`,
+				`'https://api.test/users?q=it'\''s'`,
+			},
+		},
+		{
+			name:    "curl get",
+			lang:    Curl,
+			req:     getReq,
+			want:    []string{"curl \\\n  'https://api.test/health'"},
+			notWant: []string{"-X", "Content-Type"},
+		},
+		{
+			name: "fetch json",
+			lang: Fetch,
+			req:  jsonReq,
+			want: []string{
+				`fetch("https://api.test/users?q=it's", {`,
+				`method: "POST",`,
+				`"Authorization": "Bearer abc",`,
+				"body: JSON.stringify({\n    \"admin\": true,",
+				".then((response) => response.text())",
+			},
+		},
+		{
+			name:    "fetch text",
+			lang:    Fetch,
+			req:     textReq,
+			want:    []string{`body: "line 1\nline \"2\"",`},
+			notWant: []string{"headers:", "JSON.stringify"},
+		},
+		{
+			name: "python json",
+			lang: Python,
+			req:  jsonReq,
+			want: []string{
+				"import requests",
+				`    "Authorization": "Bearer abc",`,
+				"payload = {\n    \"admin\": True,\n    \"age\": 36,",
+				`    "team": None,`,
+				"    \"tags\": [\n        \"a\",\n    ],",
+				`requests.request("POST", url, headers=headers, json=payload)`,
+			},
+		},
+		{
+			name: "python text",
+			lang: Python,
+			req:  textReq,
+			want: []string{
+				`payload = "line 1\nline \"2\""`,
+				`requests.request("PUT", url, data=payload)`,
+			},
+		},
+		{
+			name: "httpie json",
+			lang: HTTPie,
+			req:  jsonReq,
+			want: []string{
+				`http \` + "\n" + `  --raw '{"admin":true,`,
+				`POST 'https://api.test/users?q=it'\''s'`,
+				`'Authorization:Bearer abc'`,
+				`'X-Empty;'`,
+			},
+		},
+		{
+			name:    "httpie get",
+			lang:    HTTPie,
+			req:     getReq,
+			want:    []string{"http \\\n  GET 'https://api.test/health'"},
+			notWant: []string{"--raw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(tt.lang, tt.req)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		lang Language
+		req  *api.Request
+	}{
+		{name: "nil request", lang: Curl, req: nil},
+		{name: "empty url", lang: Curl, req: &api.Request{Method: api.GET}},
+		{name: "binary body", lang: Fetch, req: &api.Request{Method: api.POST, URL: "https://api.test", Body: []byte{0x81}}},
+		{name: "unknown language", lang: Language("go"), req: &api.Request{Method: api.GET, URL: "https://api.test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.lang, tt.req); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestGenerate_FormData(t *testing.T) {
+	req := &api.Request{
+		Method:  api.POST,
+		URL:     "https://api.test/products",
+		Headers: map[string]string{"Content-Type": "multipart/form-data", "X-Team": "web"},
+		Body: &api.MultipartForm{Fields: []api.FormField{
+			{Key: "title", Value: "Lamp", Enabled: true},
+			{Key: "photo", Value: "img/lamp.png", Type: api.FormFieldFile, Enabled: true},
+			{Key: "draft", Value: "1", Enabled: false},
+		}},
+	}
+
+	tests := []struct {
+		lang Language
+		want []string
+	}{
+		{Curl, []string{"-F 'title=Lamp'", "-F 'photo=@img/lamp.png'", "-H 'X-Team: web'"}},
+		{Fetch, []string{
+			`const fs = require("fs");`,
+			`form.append("title", "Lamp");`,
+			`form.append("photo", new Blob([fs.readFileSync("img/lamp.png")]), "lamp.png");`,
+			"  body: form,\n",
+		}},
+		{Python, []string{
+			`("title", (None, "Lamp")),`,
+			`("photo", ("lamp.png", open("img/lamp.png", "rb"))),`,
+			"requests.request(\"POST\", url, headers=headers, files=files)",
+		}},
+		{HTTPie, []string{"http \\\n  --multipart \\\n  POST 'https://api.test/products'", "'title=Lamp'", "'photo@img/lamp.png'"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			got, err := Generate(tt.lang, req)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+			// Tools set the multipart Content-Type with their own boundary; disabled fields are left out
+			if strings.Contains(got, "multipart/form-data") || strings.Contains(got, "draft") {
+				t.Errorf("output should not contain the Content-Type or disabled fields:\n%s", got)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -32,13 +36,166 @@ func DefaultScriptConfig() ScriptConfig {
 
 // GlobalConfig represents the global configuration
 type GlobalConfig struct {
-	Theme         ThemeConfig             `yaml:"theme"`
-	KeyBindings   KeyBindings             `yaml:"keybindings"`
-	Editor        string                  `yaml:"editor"`
-	Workspaces    []string                `yaml:"workspaces"` // List of recent workspaces
-	LastWorkspace string                  `yaml:"last_workspace"`
-	Environments  map[string]*Environment `yaml:"global_environments,omitempty"`
-	Script        ScriptConfig            `yaml:"script"`
+	Theme            ThemeConfig             `yaml:"theme"`
+	KeyBindingPreset string                  `yaml:"keybinding_preset,omitempty"` // Preset KeyBindings were created from
+	KeyBindings      KeyBindings             `yaml:"keybindings"`
+	Editor           string                  `yaml:"editor"`
+	Workspaces       []string                `yaml:"workspaces"`                // Paths of the recent workspaces, most recent first
+	WorkspacesUsed   map[string]time.Time    `yaml:"workspaces_used,omitempty"` // When each recent workspace was last opened
+	LastWorkspace    string                  `yaml:"last_workspace"`
+	Environments     map[string]*Environment `yaml:"global_environments,omitempty"`
+	Script           ScriptConfig            `yaml:"script"`
+	Proxy            *ProxyConfig            `yaml:"proxy,omitempty"` // nil uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// DuplicateSends is what sending a request already in flight does: DuplicateSendsIgnore
+	// (empty) drops the send, DuplicateSendsQueue sends it once the send in flight completes
+	DuplicateSends string `yaml:"duplicate_sends,omitempty"`
+	// AutoSave saves the edits of a request to its collection as they are made; otherwise
+	// they are held in memory until :w
+	AutoSave bool `yaml:"auto_save,omitempty"`
+	// ProtectSecrets hides the values of secret variables from the screen while the
+	// terminal is not focused: ProtectSecretsMask overwrites them, ProtectSecretsClear
+	// hides the whole screen. The last frame before exiting is blank. Empty disables it.
+	ProtectSecrets string `yaml:"protect_secrets,omitempty"`
+	// Clipboard is where copies go: ClipboardAuto (empty) uses the system clipboard and
+	// falls back to OSC 52 over SSH or without one, ClipboardSystem and ClipboardOSC52
+	// use only that
+	Clipboard string `yaml:"clipboard,omitempty"`
+	// History limits the console history; nil keeps the latest 1000 entries
+	History *HistoryConfig `yaml:"history,omitempty"`
+	// StatusBar arranges the segments of the status bar; nil uses the default layout
+	StatusBar *StatusBarConfig `yaml:"status_bar,omitempty"`
+	// Units sets how durations and sizes are displayed; nil uses ms below 1s and KB
+	Units *UnitsConfig `yaml:"units,omitempty"`
+}
+
+// UnitsConfig sets how durations and sizes are displayed in the status bar, response
+// summary, console and reports
+type UnitsConfig struct {
+	// SecondsFrom shows durations from this long in seconds and shorter ones in ms; 1s when 0
+	SecondsFrom time.Duration `yaml:"seconds_from,omitempty"`
+	// Sizes is SizesBinary (empty), SizesIEC or SizesSI
+	Sizes string `yaml:"sizes,omitempty"`
+	// DecimalSeparator separates the decimals of durations and sizes, "." when empty
+	DecimalSeparator string `yaml:"decimal_separator,omitempty"`
+}
+
+// Values of UnitsConfig.Sizes
+const (
+	SizesBinary = "binary" // 1024 bytes a KB
+	SizesIEC    = "iec"    // 1024 bytes a KiB
+	SizesSI     = "si"     // 1000 bytes a kB
+)
+
+// StatusBarConfig arranges the segments of the status bar around the message. A
+// segment left out of both lists is hidden.
+type StatusBarConfig struct {
+	// Left lists the segments before the message, in order; empty uses the default
+	Left []string `yaml:"left,omitempty"`
+	// Right lists the segments after the message, in order; empty uses the default
+	Right []string `yaml:"right,omitempty"`
+	// TimeFormat is the Go layout of the time segment, "15:04" when empty
+	TimeFormat string `yaml:"time_format,omitempty"`
+}
+
+// Status bar segments
+const (
+	SegmentMode        = "mode"        // Current mode
+	SegmentMethod      = "method"      // HTTP method of the current request
+	SegmentFullscreen  = "fullscreen"  // FULLSCREEN badge
+	SegmentMock        = "mock"        // MOCK badge
+	SegmentChaos       = "chaos"       // CHAOS badge
+	SegmentSends       = "sends"       // Sends in flight and queued
+	SegmentProxy       = "proxy"       // PROXY badge
+	SegmentEnvironment = "environment" // Active environment
+	SegmentStatus      = "status"      // HTTP status of the last response
+	SegmentWorkspace   = "workspace"   // Workspace name
+	SegmentBranch      = "branch"      // Git branch of the workspace, marked when .lazycurl has changes
+	SegmentTime        = "time"        // Current time
+)
+
+// StatusBarSegments lists the status bar segments
+var StatusBarSegments = []string{
+	SegmentMode, SegmentMethod, SegmentFullscreen, SegmentMock, SegmentChaos, SegmentSends,
+	SegmentProxy, SegmentEnvironment, SegmentStatus, SegmentWorkspace, SegmentBranch, SegmentTime,
+}
+
+// DefaultStatusBarConfig returns the default status bar layout
+func DefaultStatusBarConfig() StatusBarConfig {
+	return StatusBarConfig{
+		Left:       []string{SegmentMode, SegmentMethod, SegmentFullscreen, SegmentMock, SegmentChaos, SegmentSends},
+		Right:      []string{SegmentProxy, SegmentBranch, SegmentEnvironment, SegmentStatus},
+		TimeFormat: "15:04",
+	}
+}
+
+// UnknownSegments returns the segments of the layout that are not status bar segments
+func (c StatusBarConfig) UnknownSegments() []string {
+	var unknown []string
+	for _, name := range append(slices.Clone(c.Left), c.Right...) {
+		if !slices.Contains(StatusBarSegments, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// EffectiveStatusBar returns the status bar layout of the global config, with the
+// defaults for what it leaves out
+func EffectiveStatusBar(global *GlobalConfig) StatusBarConfig {
+	layout := DefaultStatusBarConfig()
+	if global == nil || global.StatusBar == nil {
+		return layout
+	}
+	if len(global.StatusBar.Left) > 0 {
+		layout.Left = global.StatusBar.Left
+	}
+	if len(global.StatusBar.Right) > 0 {
+		layout.Right = global.StatusBar.Right
+	}
+	if global.StatusBar.TimeFormat != "" {
+		layout.TimeFormat = global.StatusBar.TimeFormat
+	}
+	return layout
+}
+
+// HistoryConfig sets how long the console history keeps entries. The oldest entries
+// are dropped once any limit is reached.
+type HistoryConfig struct {
+	// MaxEntries is the number of entries kept, 1000 when 0
+	MaxEntries int `yaml:"max_entries,omitempty"`
+	// MaxAge drops the entries older than this (e.g. 8h); 0 keeps them
+	MaxAge time.Duration `yaml:"max_age,omitempty"`
+	// MaxSizeMB drops the oldest entries once their response bodies take more megabytes
+	MaxSizeMB int `yaml:"max_size_mb,omitempty"`
+	// Archive writes the dropped entries to the .lazycurl/history bundles before dropping them
+	Archive bool `yaml:"archive,omitempty"`
+}
+
+// Values of GlobalConfig.DuplicateSends
+const (
+	DuplicateSendsIgnore = "ignore"
+	DuplicateSendsQueue  = "queue"
+)
+
+// Values of GlobalConfig.ProtectSecrets
+const (
+	ProtectSecretsMask  = "mask"
+	ProtectSecretsClear = "clear"
+)
+
+// Values of GlobalConfig.Clipboard
+const (
+	ClipboardAuto   = "auto"
+	ClipboardSystem = "system"
+	ClipboardOSC52  = "osc52"
+)
+
+// ProxyConfig routes requests through an HTTP, HTTPS or SOCKS5 proxy
+type ProxyConfig struct {
+	// URL is the http://, https://, socks5:// or socks5h:// proxy; empty connects directly
+	URL string `yaml:"url"`
+	// NoProxy lists the hosts, domains (and their subdomains) and CIDR ranges reached directly
+	NoProxy []string `yaml:"no_proxy,omitempty"`
 }
 
 // WorkspaceConfig represents a workspace configuration (.lazycurl/config.yaml)
@@ -47,19 +204,185 @@ type WorkspaceConfig struct {
 	Description string   `yaml:"description,omitempty"`
 	DefaultEnv  string   `yaml:"default_env,omitempty"`
 	Collections []string `yaml:"collections,omitempty"`
+	// Stats enables local usage statistics, stored in .lazycurl/stats.json
+	Stats bool `yaml:"stats,omitempty"`
+	// Keychain stores the values of secret environment variables in the OS keychain
+	Keychain bool `yaml:"keychain,omitempty"`
+	// IsolateSessions gives each collection its own script globals and cookies
+	IsolateSessions bool `yaml:"isolate_sessions,omitempty"`
+	// Proxy overrides the proxy of the global config for the workspace
+	Proxy *ProxyConfig `yaml:"proxy,omitempty"`
+	// TLS adds CA files and client certificates, or skips certificate verification
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// Lint customizes the collection lint rules (:lint, lazycurl lint)
+	Lint *LintConfig `yaml:"lint,omitempty"`
+	// Lock masks secret values after an idle time until the passphrase is entered (:lock)
+	Lock *LockConfig `yaml:"lock,omitempty"`
+}
+
+// LockConfig locks the workspace when it is left idle
+type LockConfig struct {
+	// Idle is how long without input before locking; 0 disables the auto-lock
+	Idle time.Duration `yaml:"idle,omitempty"`
+	// Passphrase is the salted hash of the passphrase unlocking the workspace
+	Passphrase string `yaml:"passphrase,omitempty"`
+}
+
+// AutoLock returns how long the workspace may stay idle before locking, 0 when it does
+// not lock: without a passphrase there would be no way to unlock it
+func (c *WorkspaceConfig) AutoLock() time.Duration {
+	if c.Lock == nil || c.Lock.Passphrase == "" {
+		return 0
+	}
+	return c.Lock.Idle
+}
+
+// LintConfig customizes the collection lint rules
+type LintConfig struct {
+	// Rules sets the severity of rules by ID: "error", "warning" or "off"
+	Rules map[string]string `yaml:"rules,omitempty"`
+	// BaseURLVariable is the variable request URLs must start with (default base_url)
+	BaseURLVariable string `yaml:"base_url_variable,omitempty"`
+}
+
+// TLSConfig customizes TLS connections; relative paths are relative to the workspace
+type TLSConfig struct {
+	// CACerts lists PEM files of the CAs trusted in addition to the system ones
+	CACerts []string `yaml:"ca_certs,omitempty"`
+	// ClientCerts are presented to their host, the first match wins
+	ClientCerts []ClientCertConfig `yaml:"client_certs,omitempty"`
+	// InsecureSkipVerify accepts any server certificate, for development servers only
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// ClientCertConfig is a client certificate presented to a host ("api.example.com",
+// "api.example.com:8443" or "*.example.com")
+type ClientCertConfig struct {
+	Host string `yaml:"host"`
+	Cert string `yaml:"cert"`
+	Key  string `yaml:"key"`
+}
+
+// ResolvePath returns path relative to the workspace when it is not absolute,
+// expanding a leading ~ to the home directory
+func ResolvePath(workspacePath, path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(workspacePath, path)
+}
+
+// EffectiveProxy returns the proxy of the workspace, or the global proxy when the
+// workspace does not set one. nil means the environment variables apply.
+func EffectiveProxy(global *GlobalConfig, workspace *WorkspaceConfig) *ProxyConfig {
+	if workspace != nil && workspace.Proxy != nil {
+		return workspace.Proxy
+	}
+	if global != nil {
+		return global.Proxy
+	}
+	return nil
 }
 
 // ThemeConfig represents theme configuration
 type ThemeConfig struct {
-	Name           string `yaml:"name"`
-	PrimaryColor   string `yaml:"primary_color"`
-	SecondaryColor string `yaml:"secondary_color"`
-	AccentColor    string `yaml:"accent_color"`
-	BorderColor    string `yaml:"border_color"`
-	ActiveColor    string `yaml:"active_color"`
+	Name string `yaml:"name"` // Built-in theme or theme file of GetThemesPath
+
+	// Colors set over those of the theme, left empty to keep them
+	PrimaryColor   string `yaml:"primary_color,omitempty"`
+	SecondaryColor string `yaml:"secondary_color,omitempty"`
+	AccentColor    string `yaml:"accent_color,omitempty"`
+	BorderColor    string `yaml:"border_color,omitempty"`
+	ActiveColor    string `yaml:"active_color,omitempty"`
+
+	// Badges of HTTP methods by name: overrides for built-in methods, new entries for
+	// custom ones
+	Methods map[string]MethodTheme `yaml:"methods,omitempty"`
+}
+
+// legacyThemeColors are the colors the theme presets of earlier releases wrote to the
+// config, which were never drawn with
+var legacyThemeColors = map[string]ThemeConfig{
+	ThemeDark:  {PrimaryColor: "#7D56F4", SecondaryColor: "#00D9FF", AccentColor: "#FF6B6B", BorderColor: "#3C3C3C", ActiveColor: "#00FF00"},
+	ThemeLight: {PrimaryColor: "#5C3FD1", SecondaryColor: "#0077AA", AccentColor: "#D7263D", BorderColor: "#BCC0CC", ActiveColor: "#2E8B57"},
+}
+
+// dropLegacyColors clears the colors written by the presets of earlier releases, so
+// they don't override the colors of the theme
+func (t *ThemeConfig) dropLegacyColors() {
+	legacy, ok := legacyThemeColors[t.Name]
+	if ok && t.PrimaryColor == legacy.PrimaryColor && t.SecondaryColor == legacy.SecondaryColor &&
+		t.AccentColor == legacy.AccentColor && t.BorderColor == legacy.BorderColor && t.ActiveColor == legacy.ActiveColor {
+		t.PrimaryColor, t.SecondaryColor, t.AccentColor, t.BorderColor, t.ActiveColor = "", "", "", "", ""
+	}
+}
+
+// ThemeFile is a user theme, loaded from a YAML file of GetThemesPath
+type ThemeFile struct {
+	Name    string            `yaml:"name"`    // Theme name, the file name without extension when empty
+	Extends string            `yaml:"extends"` // Built-in theme the colors left out are taken from
+	Colors  map[string]string `yaml:"colors"`  // Colors by palette role ("base", "text", "lavender"...)
+}
+
+// GetThemesPath returns the directory of the user theme files, next to the global config
+func GetThemesPath() string {
+	return filepath.Join(filepath.Dir(GetGlobalConfigPath()), "themes")
+}
+
+// LoadThemes loads the theme files (.yaml, .yml) of dir, sorted by file name. A missing
+// directory has no themes. Files that fail to load are reported in the error; the
+// others are still returned.
+func LoadThemes(dir string) ([]ThemeFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var themes []ThemeFile
+	var problems []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		var theme ThemeFile
+		if err := yaml.Unmarshal(data, &theme); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		if theme.Name == "" {
+			theme.Name = strings.TrimSuffix(entry.Name(), ext)
+		}
+		themes = append(themes, theme)
+	}
+	if len(problems) > 0 {
+		return themes, errors.New(strings.Join(problems, "; "))
+	}
+	return themes, nil
+}
+
+// MethodTheme is the badge of an HTTP method
+type MethodTheme struct {
+	Color     string `yaml:"color"`                // Background color
+	TextColor string `yaml:"text_color,omitempty"` // Foreground color
+	Label     string `yaml:"label,omitempty"`      // Short text of the badge
 }
 
-// KeyBindings represents customizable key bindings
+// KeyBindings represents customizable key bindings. Actions added after the first
+// release are omitted when unset, and take their default keys (see EffectiveKeyBindings).
 type KeyBindings struct {
 	Quit             []string `yaml:"quit"`
 	NavigateLeft     []string `yaml:"navigate_left"`
@@ -79,6 +402,166 @@ type KeyBindings struct {
 	ImportCurl       []string `yaml:"import_curl"`
 	ExportCurl       []string `yaml:"export_curl"`
 	ImportOpenAPI    []string `yaml:"import_openapi"`
+	SearchAll        []string `yaml:"search_all,omitempty"`
+	Redraw           []string `yaml:"redraw,omitempty"`
+	CommandPalette   []string `yaml:"command_palette,omitempty"`
+	CommandMode      []string `yaml:"command_mode,omitempty"`
+	InsertMode       []string `yaml:"insert_mode,omitempty"`
+	ViewMode         []string `yaml:"view_mode,omitempty"`
+	WhichKey         []string `yaml:"which_key,omitempty"`
+	Jump             []string `yaml:"jump,omitempty"`
+	JumpAll          []string `yaml:"jump_all,omitempty"`
+	Fullscreen       []string `yaml:"fullscreen,omitempty"`
+	NewFolder        []string `yaml:"new_folder,omitempty"`
+	Rename           []string `yaml:"rename,omitempty"`
+	Duplicate        []string `yaml:"duplicate,omitempty"`
+	RunFolder        []string `yaml:"run_folder,omitempty"`
+	SendFolder       []string `yaml:"send_folder,omitempty"`
+}
+
+// KeyAction is an action of KeyBindings
+type KeyAction struct {
+	Name string // Name in the keybindings section and the keymap file
+	Desc string
+	keys func(*KeyBindings) *[]string
+}
+
+// KeyActions lists every action key bindings can be set for
+var KeyActions = []KeyAction{
+	{"quit", "Quit", func(k *KeyBindings) *[]string { return &k.Quit }},
+	{"navigate_left", "Panel left", func(k *KeyBindings) *[]string { return &k.NavigateLeft }},
+	{"navigate_right", "Panel right", func(k *KeyBindings) *[]string { return &k.NavigateRight }},
+	{"navigate_up", "Up", func(k *KeyBindings) *[]string { return &k.NavigateUp }},
+	{"navigate_down", "Down", func(k *KeyBindings) *[]string { return &k.NavigateDown }},
+	{"select", "Open", func(k *KeyBindings) *[]string { return &k.Select }},
+	{"back", "Back", func(k *KeyBindings) *[]string { return &k.Back }},
+	{"new_request", "New request", func(k *KeyBindings) *[]string { return &k.NewRequest }},
+	{"send_request", "Send request", func(k *KeyBindings) *[]string { return &k.SendRequest }},
+	{"save_request", "Save request", func(k *KeyBindings) *[]string { return &k.SaveRequest }},
+	{"delete_request", "Delete", func(k *KeyBindings) *[]string { return &k.DeleteRequest }},
+	{"focus_collections", "Focus collections", func(k *KeyBindings) *[]string { return &k.FocusCollections }},
+	{"focus_request", "Focus request", func(k *KeyBindings) *[]string { return &k.FocusRequest }},
+	{"focus_response", "Focus response", func(k *KeyBindings) *[]string { return &k.FocusResponse }},
+	{"toggle_envs", "Toggle environments", func(k *KeyBindings) *[]string { return &k.ToggleEnvs }},
+	{"import_curl", "Import cURL", func(k *KeyBindings) *[]string { return &k.ImportCurl }},
+	{"export_curl", "Copy as cURL", func(k *KeyBindings) *[]string { return &k.ExportCurl }},
+	{"import_openapi", "Import OpenAPI", func(k *KeyBindings) *[]string { return &k.ImportOpenAPI }},
+	{"search_all", "Search all", func(k *KeyBindings) *[]string { return &k.SearchAll }},
+	{"redraw", "Redraw", func(k *KeyBindings) *[]string { return &k.Redraw }},
+	{"command_palette", "Commands", func(k *KeyBindings) *[]string { return &k.CommandPalette }},
+	{"command_mode", "Command", func(k *KeyBindings) *[]string { return &k.CommandMode }},
+	{"insert_mode", "Insert", func(k *KeyBindings) *[]string { return &k.InsertMode }},
+	{"view_mode", "View", func(k *KeyBindings) *[]string { return &k.ViewMode }},
+	{"which_key", "Key hints", func(k *KeyBindings) *[]string { return &k.WhichKey }},
+	{"jump", "Jump", func(k *KeyBindings) *[]string { return &k.Jump }},
+	{"jump_all", "Jump all panels", func(k *KeyBindings) *[]string { return &k.JumpAll }},
+	{"fullscreen", "Fullscreen", func(k *KeyBindings) *[]string { return &k.Fullscreen }},
+	{"new_folder", "New folder", func(k *KeyBindings) *[]string { return &k.NewFolder }},
+	{"rename", "Rename", func(k *KeyBindings) *[]string { return &k.Rename }},
+	{"duplicate", "Duplicate", func(k *KeyBindings) *[]string { return &k.Duplicate }},
+	{"run_folder", "Run folder", func(k *KeyBindings) *[]string { return &k.RunFolder }},
+	{"send_folder", "Send all", func(k *KeyBindings) *[]string { return &k.SendFolder }},
+}
+
+// ByAction returns the keys bound to every action, by action name
+func (k KeyBindings) ByAction() map[string][]string {
+	keys := make(map[string][]string, len(KeyActions))
+	for _, action := range KeyActions {
+		keys[action.Name] = *action.keys(&k)
+	}
+	return keys
+}
+
+// Keys returns the keys bound to the action named name
+func (k KeyBindings) Keys(name string) []string {
+	for _, action := range KeyActions {
+		if action.Name == name {
+			return *action.keys(&k)
+		}
+	}
+	return nil
+}
+
+// KeyConflict is a key bound to several actions
+type KeyConflict struct {
+	Key     string
+	Actions []string
+}
+
+// Conflicts returns the keys bound to more than one action, in key order
+func (k KeyBindings) Conflicts() []KeyConflict {
+	actions := make(map[string][]string)
+	for _, action := range KeyActions {
+		for _, key := range *action.keys(&k) {
+			if !slices.Contains(actions[key], action.Name) {
+				actions[key] = append(actions[key], action.Name)
+			}
+		}
+	}
+	var conflicts []KeyConflict
+	for key, names := range actions {
+		if len(names) > 1 {
+			conflicts = append(conflicts, KeyConflict{Key: key, Actions: names})
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b KeyConflict) int { return strings.Compare(a.Key, b.Key) })
+	return conflicts
+}
+
+// GetKeymapPath returns the path of the keymap file, next to the global config
+func GetKeymapPath() string {
+	return filepath.Join(filepath.Dir(GetGlobalConfigPath()), "keymap.yaml")
+}
+
+// LoadKeymap loads a keymap file: the keys of actions by name, as in the keybindings
+// section of the global config. A missing file is an empty keymap.
+func LoadKeymap(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keymap map[string][]string
+	if err := yaml.Unmarshal(data, &keymap); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return keymap, nil
+}
+
+// EffectiveKeyBindings returns the key bindings in effect: those of the global config,
+// the defaults of the actions it leaves unset, and the bindings of the keymap over them.
+// Unknown actions of the keymap are reported in the error; the others still apply.
+func EffectiveKeyBindings(global *GlobalConfig, keymap map[string][]string) (KeyBindings, error) {
+	kb := DefaultKeyBindings()
+	if global != nil {
+		kb = global.KeyBindings
+	}
+	defaults := DefaultKeyBindings()
+	for _, action := range KeyActions {
+		if keys := action.keys(&kb); *keys == nil {
+			*keys = *action.keys(&defaults)
+		}
+	}
+
+	var unknown []string
+	for name, keys := range keymap {
+		i := slices.IndexFunc(KeyActions, func(a KeyAction) bool { return a.Name == name })
+		if i < 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+		if keys == nil {
+			keys = []string{} // An empty entry unbinds the action
+		}
+		*KeyActions[i].keys(&kb) = keys
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return kb, fmt.Errorf("unknown keymap actions: %s", strings.Join(unknown, ", "))
+	}
+	return kb, nil
 }
 
 // Environment represents an environment with variables
@@ -88,22 +571,61 @@ type Environment struct {
 	Variables   map[string]string `yaml:"variables"`
 }
 
+// Theme and key binding preset names. "dark" and "light" are the Catppuccin Mocha and
+// Latte themes.
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+
+	KeyBindingPresetVim     = "vim"
+	KeyBindingPresetDefault = "default"
+)
+
+// ThemeNames lists the built-in themes in display order
+var ThemeNames = []string{ThemeDark, ThemeLight}
+
+// KeyBindingPresetNames lists the built-in key binding presets in display order
+var KeyBindingPresetNames = []string{KeyBindingPresetVim, KeyBindingPresetDefault}
+
 // DefaultGlobalConfig returns default global configuration
 func DefaultGlobalConfig() *GlobalConfig {
+	theme, _ := ThemePreset(ThemeDark)
 	return &GlobalConfig{
-		Theme: ThemeConfig{
-			Name:           "dark",
-			PrimaryColor:   "#7D56F4",
-			SecondaryColor: "#00D9FF",
-			AccentColor:    "#FF6B6B",
-			BorderColor:    "#3C3C3C",
-			ActiveColor:    "#00FF00",
-		},
-		KeyBindings: DefaultKeyBindings(),
-		Editor:      "vim",
-		Workspaces:  []string{},
-		Script:      DefaultScriptConfig(),
+		Theme:            theme,
+		KeyBindingPreset: KeyBindingPresetVim,
+		KeyBindings:      DefaultKeyBindings(),
+		Editor:           "vim",
+		Workspaces:       []string{},
+		Script:           DefaultScriptConfig(),
+	}
+}
+
+// ThemePreset returns the theme config of a setup choice: the theme with the given
+// name, with its own colors
+func ThemePreset(name string) (ThemeConfig, bool) {
+	switch name {
+	case ThemeDark, ThemeLight:
+		return ThemeConfig{Name: name}, true
 	}
+	return ThemeConfig{}, false
+}
+
+// KeyBindingPreset returns the key bindings of a built-in preset:
+// "vim" navigates panels with h/l, "default" with shift+arrows
+func KeyBindingPreset(name string) (KeyBindings, bool) {
+	switch name {
+	case KeyBindingPresetVim:
+		return DefaultKeyBindings(), true
+	case KeyBindingPresetDefault:
+		kb := DefaultKeyBindings()
+		kb.Quit = []string{"q", "ctrl+q"}
+		kb.NavigateLeft = []string{"shift+left"}
+		kb.NavigateRight = []string{"shift+right"}
+		kb.NavigateUp = []string{"up"}
+		kb.NavigateDown = []string{"down"}
+		return kb, true
+	}
+	return KeyBindings{}, false
 }
 
 // DefaultKeyBindings returns default vim-style key bindings
@@ -127,6 +649,21 @@ func DefaultKeyBindings() KeyBindings {
 		ImportCurl:       []string{"ctrl+i"},
 		ExportCurl:       []string{"ctrl+e"},
 		ImportOpenAPI:    []string{"ctrl+o"},
+		SearchAll:        []string{"ctrl+k"},
+		Redraw:           []string{"ctrl+l"},
+		CommandPalette:   []string{"ctrl+p"},
+		CommandMode:      []string{":"},
+		InsertMode:       []string{"i"},
+		ViewMode:         []string{"v"},
+		WhichKey:         []string{"?"},
+		Jump:             []string{"f"},
+		JumpAll:          []string{"F"},
+		Fullscreen:       []string{"Z"},
+		NewFolder:        []string{"N"},
+		Rename:           []string{"R"},
+		Duplicate:        []string{"D"},
+		RunFolder:        []string{"r"},
+		SendFolder:       []string{"S"},
 	}
 }
 
@@ -155,6 +692,7 @@ func LoadGlobalConfig() (*GlobalConfig, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
+	config.Theme.dropLegacyColors()
 
 	return &config, nil
 }
@@ -177,6 +715,39 @@ func (c *GlobalConfig) Save() error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// MaxRecentWorkspaces is the number of workspaces kept in the recent list
+const MaxRecentWorkspaces = 20
+
+// TouchWorkspace records the workspace at path as the last one opened, at now
+func (c *GlobalConfig) TouchWorkspace(path string, now time.Time) {
+	c.Workspaces = slices.DeleteFunc(c.Workspaces, func(p string) bool { return p == path })
+	c.Workspaces = slices.Insert(c.Workspaces, 0, path)
+	for _, old := range c.Workspaces[min(len(c.Workspaces), MaxRecentWorkspaces):] {
+		delete(c.WorkspacesUsed, old)
+	}
+	c.Workspaces = c.Workspaces[:min(len(c.Workspaces), MaxRecentWorkspaces)]
+	if c.WorkspacesUsed == nil {
+		c.WorkspacesUsed = make(map[string]time.Time)
+	}
+	c.WorkspacesUsed[path] = now
+	c.LastWorkspace = path
+}
+
+// ForgetWorkspace removes the workspace at path from the recent workspaces
+func (c *GlobalConfig) ForgetWorkspace(path string) {
+	c.Workspaces = slices.DeleteFunc(c.Workspaces, func(p string) bool { return p == path })
+	delete(c.WorkspacesUsed, path)
+	if c.LastWorkspace == path {
+		c.LastWorkspace = ""
+	}
+}
+
+// GlobalConfigExists reports whether the global config file has been created (false on first run)
+func GlobalConfigExists() bool {
+	_, err := os.Stat(GetGlobalConfigPath())
+	return err == nil
+}
+
 // LoadWorkspaceConfig loads workspace configuration
 func LoadWorkspaceConfig(workspacePath string) (*WorkspaceConfig, error) {
 	configPath := filepath.Join(workspacePath, ".lazycurl", "config.yaml")
@@ -197,6 +768,28 @@ func LoadWorkspaceConfig(workspacePath string) (*WorkspaceConfig, error) {
 	return &config, nil
 }
 
+// WorkspaceExists reports whether dir holds a workspace (.lazycurl/config.yaml)
+func WorkspaceExists(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".lazycurl", "config.yaml"))
+	return err == nil
+}
+
+// CreateWorkspace creates a workspace named name in dir, creating dir if needed. It
+// fails when dir already holds a workspace.
+func CreateWorkspace(dir, name string) (*WorkspaceConfig, error) {
+	if WorkspaceExists(dir) {
+		return nil, fmt.Errorf("%s is already a workspace", dir)
+	}
+	config := &WorkspaceConfig{
+		Name:        name,
+		Collections: []string{},
+	}
+	if err := config.Save(dir); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 // SaveWorkspaceConfig saves workspace configuration
 func (c *WorkspaceConfig) Save(workspacePath string) error {
 	lazycurlPath := filepath.Join(workspacePath, ".lazycurl")
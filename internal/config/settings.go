@@ -0,0 +1,457 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SettingKind is how a setting is edited
+type SettingKind int
+
+const (
+	SettingText   SettingKind = iota // Free text
+	SettingBool                      // SettingOn or SettingOff
+	SettingChoice                    // One of the setting's Options
+)
+
+// Values of SettingBool settings
+const (
+	SettingOn  = "on"
+	SettingOff = "off"
+)
+
+// Setting scopes: the file a setting is saved to
+const (
+	ScopeGlobal    = "global"    // GetGlobalConfigPath
+	ScopeWorkspace = "workspace" // .lazycurl/config.yaml
+)
+
+// Setting is a config value edited in the settings view
+type Setting struct {
+	Key     string // Path in the config file, such as "proxy.url"
+	Scope   string
+	Desc    string
+	Kind    SettingKind
+	Options []string // Values of a SettingChoice, the first being the default
+	get     func(*GlobalConfig, *WorkspaceConfig) string
+	set     func(*GlobalConfig, *WorkspaceConfig, string) error
+}
+
+// Get returns the value of the setting as edited: "" when unset, SettingOn or
+// SettingOff for a SettingBool
+func (s Setting) Get(global *GlobalConfig, workspace *WorkspaceConfig) string {
+	return s.get(global, workspace)
+}
+
+// Set parses value and sets the setting to it; the configs are left unchanged when
+// value is invalid
+func (s Setting) Set(global *GlobalConfig, workspace *WorkspaceConfig, value string) error {
+	value = strings.TrimSpace(value)
+	switch s.Kind {
+	case SettingBool:
+		if value != SettingOn && value != SettingOff {
+			return fmt.Errorf("%s: %q is not %s or %s", s.Key, value, SettingOn, SettingOff)
+		}
+	case SettingChoice:
+		if !slices.Contains(s.Options, value) {
+			return fmt.Errorf("%s: %q is not one of %s", s.Key, value, strings.Join(s.Options, ", "))
+		}
+	}
+	if err := s.set(global, workspace, value); err != nil {
+		return fmt.Errorf("%s: %w", s.Key, err)
+	}
+	return nil
+}
+
+// Settings lists the settings of the settings view, global ones first
+var Settings = []Setting{
+	{
+		Key: "theme.name", Scope: ScopeGlobal, Desc: "Built-in theme or theme file", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return g.Theme.Name },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			if v == "" {
+				return errors.New("a theme is required")
+			}
+			g.Theme.Name = strings.ToLower(v)
+			return nil
+		},
+	},
+	{
+		Key: "editor", Scope: ScopeGlobal, Desc: "External editor", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return g.Editor },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error { g.Editor = v; return nil },
+	},
+	{
+		Key: "script.enabled", Scope: ScopeGlobal, Desc: "Run pre-request and post-response scripts", Kind: SettingBool,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return onOff(g.Script.Enabled) },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Script.Enabled = v == SettingOn
+			return nil
+		},
+	},
+	{
+		Key: "script.timeout", Scope: ScopeGlobal, Desc: "Time a script may run (default 5s)", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return formatSettingDuration(g.Script.Timeout) },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			d, err := parseSettingDuration(v)
+			if err != nil {
+				return err
+			}
+			if d == 0 {
+				d = DefaultScriptTimeout
+			}
+			g.Script.Timeout = d
+			return nil
+		},
+	},
+	{
+		Key: "proxy.url", Scope: ScopeGlobal, Desc: "Proxy of every workspace; empty uses HTTP_PROXY", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return proxyURL(g.Proxy) },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Proxy = setProxyURL(g.Proxy, v)
+			return nil
+		},
+	},
+	{
+		Key: "proxy.no_proxy", Scope: ScopeGlobal, Desc: "Hosts reached directly, comma-separated", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return noProxy(g.Proxy) },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Proxy = setNoProxy(g.Proxy, v)
+			return nil
+		},
+	},
+	{
+		Key: "duplicate_sends", Scope: ScopeGlobal, Desc: "Sending a request already in flight", Kind: SettingChoice,
+		Options: []string{DuplicateSendsIgnore, DuplicateSendsQueue},
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			return orDefault(g.DuplicateSends, DuplicateSendsIgnore)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.DuplicateSends = unlessDefault(v, DuplicateSendsIgnore)
+			return nil
+		},
+	},
+	{
+		Key: "auto_save", Scope: ScopeGlobal, Desc: "Save request edits as they are made rather than on :w", Kind: SettingBool,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string { return onOff(g.AutoSave) },
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.AutoSave = v == SettingOn
+			return nil
+		},
+	},
+	{
+		Key: "protect_secrets", Scope: ScopeGlobal, Desc: "Hide secrets while the terminal is not focused", Kind: SettingChoice,
+		Options: []string{SettingOff, ProtectSecretsMask, ProtectSecretsClear},
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			return orDefault(g.ProtectSecrets, SettingOff)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.ProtectSecrets = unlessDefault(v, SettingOff)
+			return nil
+		},
+	},
+	{
+		Key: "clipboard", Scope: ScopeGlobal, Desc: "Where copies go", Kind: SettingChoice,
+		Options: []string{ClipboardAuto, ClipboardSystem, ClipboardOSC52},
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			return orDefault(g.Clipboard, ClipboardAuto)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Clipboard = unlessDefault(v, ClipboardAuto)
+			return nil
+		},
+	},
+	{
+		Key: "history.max_entries", Scope: ScopeGlobal, Desc: "Console entries kept (default 1000)", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			if g.History == nil || g.History.MaxEntries == 0 {
+				return ""
+			}
+			return strconv.Itoa(g.History.MaxEntries)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			n := 0
+			if v != "" {
+				var err error
+				if n, err = strconv.Atoi(v); err != nil || n < 0 {
+					return fmt.Errorf("%q is not a number of entries", v)
+				}
+			}
+			g.History = setHistory(g.History, func(h *HistoryConfig) { h.MaxEntries = n })
+			return nil
+		},
+	},
+	{
+		Key: "history.max_age", Scope: ScopeGlobal, Desc: "Console entries older than this are dropped", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			if g.History == nil {
+				return ""
+			}
+			return formatSettingDuration(g.History.MaxAge)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			d, err := parseSettingDuration(v)
+			if err != nil {
+				return err
+			}
+			g.History = setHistory(g.History, func(h *HistoryConfig) { h.MaxAge = d })
+			return nil
+		},
+	},
+	{
+		Key: "units.seconds_from", Scope: ScopeGlobal, Desc: "Durations from this long are shown in seconds (default 1s)", Kind: SettingText,
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			if g.Units == nil {
+				return ""
+			}
+			return formatSettingDuration(g.Units.SecondsFrom)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			d, err := parseSettingDuration(v)
+			if err != nil {
+				return err
+			}
+			g.Units = setUnits(g.Units, func(u *UnitsConfig) { u.SecondsFrom = d })
+			return nil
+		},
+	},
+	{
+		Key: "units.sizes", Scope: ScopeGlobal, Desc: "Size units", Kind: SettingChoice,
+		Options: []string{SizesBinary, SizesIEC, SizesSI},
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			if g.Units == nil {
+				return SizesBinary
+			}
+			return orDefault(g.Units.Sizes, SizesBinary)
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Units = setUnits(g.Units, func(u *UnitsConfig) { u.Sizes = unlessDefault(v, SizesBinary) })
+			return nil
+		},
+	},
+	{
+		Key: "units.decimal_separator", Scope: ScopeGlobal, Desc: "Decimal separator of durations and sizes", Kind: SettingChoice,
+		Options: []string{".", ","},
+		get: func(g *GlobalConfig, _ *WorkspaceConfig) string {
+			if g.Units == nil {
+				return "."
+			}
+			return orDefault(g.Units.DecimalSeparator, ".")
+		},
+		set: func(g *GlobalConfig, _ *WorkspaceConfig, v string) error {
+			g.Units = setUnits(g.Units, func(u *UnitsConfig) { u.DecimalSeparator = unlessDefault(v, ".") })
+			return nil
+		},
+	},
+	{
+		Key: "name", Scope: ScopeWorkspace, Desc: "Workspace name", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return w.Name },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			if v == "" {
+				return errors.New("a name is required")
+			}
+			w.Name = v
+			return nil
+		},
+	},
+	{
+		Key: "description", Scope: ScopeWorkspace, Desc: "Workspace description", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return w.Description },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error { w.Description = v; return nil },
+	},
+	{
+		Key: "default_env", Scope: ScopeWorkspace, Desc: "Default environment", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return w.DefaultEnv },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error { w.DefaultEnv = v; return nil },
+	},
+	{
+		Key: "stats", Scope: ScopeWorkspace, Desc: "Record local usage statistics", Kind: SettingBool,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return onOff(w.Stats) },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error { w.Stats = v == SettingOn; return nil },
+	},
+	{
+		Key: "isolate_sessions", Scope: ScopeWorkspace, Desc: "Own script globals and cookies per collection", Kind: SettingBool,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return onOff(w.IsolateSessions) },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			w.IsolateSessions = v == SettingOn
+			return nil
+		},
+	},
+	{
+		Key: "proxy.url", Scope: ScopeWorkspace, Desc: "Proxy of the workspace; empty uses the global one", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return proxyURL(w.Proxy) },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			w.Proxy = setProxyURL(w.Proxy, v)
+			return nil
+		},
+	},
+	{
+		Key: "proxy.no_proxy", Scope: ScopeWorkspace, Desc: "Hosts reached directly, comma-separated", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string { return noProxy(w.Proxy) },
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			w.Proxy = setNoProxy(w.Proxy, v)
+			return nil
+		},
+	},
+	{
+		Key: "tls.insecure_skip_verify", Scope: ScopeWorkspace, Desc: "Accept any server certificate", Kind: SettingBool,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string {
+			return onOff(w.TLS != nil && w.TLS.InsecureSkipVerify)
+		},
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			if w.TLS == nil {
+				w.TLS = &TLSConfig{}
+			}
+			w.TLS.InsecureSkipVerify = v == SettingOn
+			if !w.TLS.InsecureSkipVerify && len(w.TLS.CACerts) == 0 && len(w.TLS.ClientCerts) == 0 {
+				w.TLS = nil
+			}
+			return nil
+		},
+	},
+	{
+		Key: "lock.idle", Scope: ScopeWorkspace, Desc: "Idle time before locking, once :lock passphrase is set", Kind: SettingText,
+		get: func(_ *GlobalConfig, w *WorkspaceConfig) string {
+			if w.Lock == nil {
+				return ""
+			}
+			return formatSettingDuration(w.Lock.Idle)
+		},
+		set: func(_ *GlobalConfig, w *WorkspaceConfig, v string) error {
+			d, err := parseSettingDuration(v)
+			if err != nil {
+				return err
+			}
+			if w.Lock == nil {
+				w.Lock = &LockConfig{}
+			}
+			w.Lock.Idle = d
+			if d == 0 && w.Lock.Passphrase == "" {
+				w.Lock = nil
+			}
+			return nil
+		},
+	},
+}
+
+// onOff returns the SettingBool value of b
+func onOff(b bool) string {
+	if b {
+		return SettingOn
+	}
+	return SettingOff
+}
+
+// orDefault returns value, or def when value is empty
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// unlessDefault returns value, or "" when it is def so the config leaves it out
+func unlessDefault(value, def string) string {
+	if value == def {
+		return ""
+	}
+	return value
+}
+
+// formatSettingDuration returns d as edited, "" for 0
+func formatSettingDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// parseSettingDuration parses a duration such as "10s", 0 for ""
+func parseSettingDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a duration such as 10s or 8h", v)
+	}
+	return d, nil
+}
+
+// proxyURL returns the URL of proxy, "" when unset
+func proxyURL(proxy *ProxyConfig) string {
+	if proxy == nil {
+		return ""
+	}
+	return proxy.URL
+}
+
+// noProxy returns the hosts proxy reaches directly, comma-separated
+func noProxy(proxy *ProxyConfig) string {
+	if proxy == nil {
+		return ""
+	}
+	return strings.Join(proxy.NoProxy, ", ")
+}
+
+// setProxyURL returns proxy with the given URL; nil once it holds nothing
+func setProxyURL(proxy *ProxyConfig, url string) *ProxyConfig {
+	p := ProxyConfig{URL: url}
+	if proxy != nil {
+		p.NoProxy = proxy.NoProxy
+	}
+	return compactProxy(p)
+}
+
+// setNoProxy returns proxy reaching the comma-separated hosts directly; nil once it
+// holds nothing
+func setNoProxy(proxy *ProxyConfig, hosts string) *ProxyConfig {
+	var p ProxyConfig
+	if proxy != nil {
+		p.URL = proxy.URL
+	}
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			p.NoProxy = append(p.NoProxy, host)
+		}
+	}
+	return compactProxy(p)
+}
+
+// compactProxy returns &p, nil when p holds nothing
+func compactProxy(p ProxyConfig) *ProxyConfig {
+	if p.URL == "" && len(p.NoProxy) == 0 {
+		return nil
+	}
+	return &p
+}
+
+// setHistory returns a copy of history changed by set; nil once it holds the defaults
+func setHistory(history *HistoryConfig, set func(*HistoryConfig)) *HistoryConfig {
+	var h HistoryConfig
+	if history != nil {
+		h = *history
+	}
+	set(&h)
+	if h == (HistoryConfig{}) {
+		return nil
+	}
+	return &h
+}
+
+// setUnits returns a copy of units changed by set; nil once it holds the defaults
+func setUnits(units *UnitsConfig, set func(*UnitsConfig)) *UnitsConfig {
+	var u UnitsConfig
+	if units != nil {
+		u = *units
+	}
+	set(&u)
+	if u == (UnitsConfig{}) {
+		return nil
+	}
+	return &u
+}
@@ -0,0 +1,223 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BinaryFormat identifies a binary serialization authored as JSON
+type BinaryFormat string
+
+const (
+	FormatMsgpack BinaryFormat = "msgpack"
+	FormatCBOR    BinaryFormat = "cbor"
+)
+
+// maxDecodeDepth bounds container nesting to protect against malicious payloads
+const maxDecodeDepth = 512
+
+var (
+	errUnexpectedEnd = errors.New("unexpected end of data")
+	errTooDeep       = errors.New("nesting too deep")
+)
+
+// ContentType returns the media type sent for bodies encoded in this format
+func (f BinaryFormat) ContentType() string {
+	switch f {
+	case FormatMsgpack:
+		return "application/msgpack"
+	case FormatCBOR:
+		return "application/cbor"
+	default:
+		return ""
+	}
+}
+
+// DisplayName returns the human-readable format name
+func (f BinaryFormat) DisplayName() string {
+	switch f {
+	case FormatMsgpack:
+		return "MessagePack"
+	case FormatCBOR:
+		return "CBOR"
+	default:
+		return string(f)
+	}
+}
+
+// BinaryFormatFromContentType detects MessagePack or CBOR from a Content-Type header
+// (e.g. "application/msgpack", "application/x-msgpack", "application/cbor", "application/foo+cbor")
+func BinaryFormatFromContentType(contentType string) (BinaryFormat, bool) {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "msgpack"):
+		return FormatMsgpack, true
+	case strings.Contains(ct, "cbor"):
+		return FormatCBOR, true
+	default:
+		return "", false
+	}
+}
+
+// EncodeJSON converts JSON text into the given binary format.
+// Integers are encoded as integers and other numbers as 64-bit floats;
+// object keys are written in sorted order so the output is deterministic.
+func EncodeJSON(f BinaryFormat, data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON body: unexpected data after top-level value")
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch f {
+	case FormatMsgpack:
+		err = encodeMsgpack(&buf, value)
+	case FormatCBOR:
+		err = encodeCBOR(&buf, value)
+	default:
+		return nil, fmt.Errorf("unsupported body format: %s", f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s encode: %w", f.DisplayName(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeToJSON decodes a body in the given binary format into indented JSON.
+// Byte strings are rendered as base64 strings and non-string map keys are stringified.
+func DecodeToJSON(f BinaryFormat, data []byte) ([]byte, error) {
+	var value interface{}
+	var err error
+	switch f {
+	case FormatMsgpack:
+		value, err = DecodeMsgpack(data)
+	case FormatCBOR:
+		value, err = DecodeCBOR(data)
+	default:
+		return nil, fmt.Errorf("unsupported body format: %s", f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+// jsonNumber converts a JSON number into int64, uint64 or float64
+func jsonNumber(n json.Number) (interface{}, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u, nil
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number out of range: %s", s)
+	}
+	return f, nil
+}
+
+// sortedKeys returns the keys of a JSON object in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonFloat returns a float as a JSON-representable value (NaN and infinities become strings)
+func jsonFloat(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return f
+	}
+}
+
+// mapKey converts a decoded map key into a JSON object key
+func mapKey(key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return k
+	case nil:
+		return "null"
+	default:
+		if b, err := json.Marshal(k); err == nil {
+			return string(b)
+		}
+		return fmt.Sprint(k)
+	}
+}
+
+// byteReader is a bounds-checked cursor over encoded data
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *byteReader) remaining() int {
+	return len(r.data) - r.pos
+}
+
+func (r *byteReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *byteReader) readN(n uint64) ([]byte, error) {
+	if n > uint64(r.remaining()) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// readUint reads a big-endian unsigned integer of size bytes (1, 2, 4 or 8)
+func (r *byteReader) readUint(size int) (uint64, error) {
+	b, err := r.readN(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// checkLength rejects container lengths that cannot fit in the remaining data
+// (every element takes at least one byte), avoiding huge allocations
+func (r *byteReader) checkLength(n uint64) error {
+	if n > uint64(r.remaining()) {
+		return errUnexpectedEnd
+	}
+	return nil
+}
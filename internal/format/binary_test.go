@@ -0,0 +1,178 @@
+package format
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBinaryFormatFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        BinaryFormat
+		wantOK      bool
+	}{
+		{contentType: "application/msgpack", want: FormatMsgpack, wantOK: true},
+		{contentType: "application/x-msgpack; charset=binary", want: FormatMsgpack, wantOK: true},
+		{contentType: "application/cbor", want: FormatCBOR, wantOK: true},
+		{contentType: "application/senml+cbor", want: FormatCBOR, wantOK: true},
+		{contentType: "application/json", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			got, ok := BinaryFormatFromContentType(tt.contentType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("BinaryFormatFromContentType(%q) = %q, %v; want %q, %v", tt.contentType, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  BinaryFormat
+		json    string
+		wantHex string
+		wantErr bool
+	}{
+		// Examples from the MessagePack spec and RFC 8949 Appendix A
+		{name: "msgpack small map", format: FormatMsgpack, json: `{"compact": true, "schema": 0}`, wantHex: "82a7636f6d70616374c3a6736368656d6100"},
+		{name: "msgpack negative fixint", format: FormatMsgpack, json: `-1`, wantHex: "ff"},
+		{name: "msgpack int16", format: FormatMsgpack, json: `-200`, wantHex: "d1ff38"},
+		{name: "msgpack uint64", format: FormatMsgpack, json: `18446744073709551615`, wantHex: "cfffffffffffffffff"},
+		{name: "msgpack float", format: FormatMsgpack, json: `1.5`, wantHex: "cb3ff8000000000000"},
+		{name: "msgpack null array", format: FormatMsgpack, json: `[null, false]`, wantHex: "92c0c2"},
+		{name: "cbor array", format: FormatCBOR, json: `[1, [2, 3]]`, wantHex: "8201820203"},
+		{name: "cbor negative", format: FormatCBOR, json: `-1000`, wantHex: "3903e7"},
+		{name: "cbor map", format: FormatCBOR, json: `{"b": [2], "a": 1}`, wantHex: "a261610161628102"},
+		{name: "cbor simple values", format: FormatCBOR, json: `[true, null]`, wantHex: "82f5f6"},
+		{name: "invalid JSON", format: FormatCBOR, json: `{"a":`, wantErr: true},
+		{name: "trailing data", format: FormatMsgpack, json: `1 2`, wantErr: true},
+		{name: "unknown format", format: BinaryFormat("bson"), json: `1`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeJSON(tt.format, []byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := mustHex(t, tt.wantHex)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("EncodeJSON() = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeToJSON_RoundTrip(t *testing.T) {
+	input := `{"id": 42, "name": "café", "tags": ["a", "b"], "price": 9.99, "big": -9223372036854775808, "nested": {"ok": true, "none": null}}`
+
+	for _, f := range []BinaryFormat{FormatMsgpack, FormatCBOR} {
+		t.Run(string(f), func(t *testing.T) {
+			encoded, err := EncodeJSON(f, []byte(input))
+			if err != nil {
+				t.Fatalf("EncodeJSON() error = %v", err)
+			}
+			decoded, err := DecodeToJSON(f, encoded)
+			if err != nil {
+				t.Fatalf("DecodeToJSON() error = %v", err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(decoded, &got); err != nil {
+				t.Fatalf("DecodeToJSON() produced invalid JSON: %v", err)
+			}
+			_ = json.Unmarshal([]byte(input), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %s, want %s", decoded, input)
+			}
+		})
+	}
+}
+
+func TestDecodeMsgpack(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "int8", hex: "d080", want: int64(-128)},
+		{name: "uint16", hex: "cd0100", want: uint64(256)},
+		{name: "float32", hex: "ca3fc00000", want: 1.5},
+		{name: "str8", hex: "d90368656c", want: "hel"},
+		{name: "bin as base64", hex: "c403010203", want: "AQID"},
+		{name: "integer map key", hex: "8101a178", want: map[string]interface{}{"1": "x"}},
+		{name: "timestamp32", hex: "d6ff00000000", want: "1970-01-01T00:00:00Z"},
+		{name: "custom ext", hex: "d40501", want: map[string]interface{}{"type": int64(5), "data": "AQ=="}},
+		{name: "truncated string", hex: "a5616263", wantErr: true},
+		{name: "huge array length", hex: "ddffffffff", wantErr: true},
+		{name: "trailing bytes", hex: "0101", wantErr: true},
+		{name: "reserved byte", hex: "c1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMsgpack(mustHex(t, tt.hex))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeMsgpack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeMsgpack() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    interface{}
+		wantErr bool
+	}{
+		// Examples from RFC 8949 Appendix A
+		{name: "uint64 max", hex: "1bffffffffffffffff", want: uint64(18446744073709551615)},
+		{name: "negative bignum range", hex: "3bffffffffffffffff", want: json.Number("-18446744073709551616")},
+		{name: "bignum tag", hex: "c249010000000000000000", want: json.Number("18446744073709551616")},
+		{name: "half float", hex: "f93e00", want: 1.5},
+		{name: "half float infinity", hex: "f97c00", want: "+Inf"},
+		{name: "date tag keeps content", hex: "c074323031332d30332d32315432303a30343a30305a", want: "2013-03-21T20:04:00Z"},
+		{name: "byte string", hex: "4401020304", want: "AQIDBA=="},
+		{name: "indefinite text", hex: "7f657374726561646d696e67ff", want: "streaming"},
+		{name: "indefinite array", hex: "9f018202039f0405ffff", want: []interface{}{uint64(1), []interface{}{uint64(2), uint64(3)}, []interface{}{uint64(4), uint64(5)}}},
+		{name: "indefinite map", hex: "bf6346756ef563416d7421ff", want: map[string]interface{}{"Fun": true, "Amt": int64(-2)}},
+		{name: "undefined", hex: "f7", want: nil},
+		{name: "top-level break", hex: "ff", wantErr: true},
+		{name: "truncated", hex: "6568656c", wantErr: true},
+		{name: "huge map length", hex: "bbffffffffffffffff", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCBOR(mustHex(t, tt.hex))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeCBOR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCBOR() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad hex %q: %v", s, err)
+	}
+	return b
+}
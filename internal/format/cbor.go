@@ -0,0 +1,405 @@
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// CBOR major types (RFC 8949 section 3.1)
+const (
+	cborUnsigned byte = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// cborIndefinite is the additional-info value marking indefinite-length items
+const cborIndefinite = 31
+
+// cborBreak terminates an indefinite-length item
+const cborBreak = 0xff
+
+// EncodeCBOR encodes a JSON-like value (nil, bool, numbers, string, []interface{},
+// map[string]interface{}) as CBOR
+func EncodeCBOR(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeCBOR(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeCBOR(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xf6)
+	case bool:
+		if val {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+	case json.Number:
+		n, err := jsonNumber(val)
+		if err != nil {
+			return err
+		}
+		return encodeCBOR(buf, n)
+	case int:
+		writeCBORInt(buf, int64(val))
+	case int64:
+		writeCBORInt(buf, val)
+	case uint64:
+		writeCBORHead(buf, cborUnsigned, val)
+	case float64:
+		buf.WriteByte(0xfb)
+		writeBigEndian(buf, math.Float64bits(val), 8)
+	case string:
+		writeCBORHead(buf, cborText, uint64(len(val)))
+		buf.WriteString(val)
+	case []byte:
+		writeCBORHead(buf, cborBytes, uint64(len(val)))
+		buf.Write(val)
+	case []interface{}:
+		writeCBORHead(buf, cborArray, uint64(len(val)))
+		for _, item := range val {
+			if err := encodeCBOR(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		writeCBORHead(buf, cborMap, uint64(len(val)))
+		for _, key := range sortedKeys(val) {
+			if err := encodeCBOR(buf, key); err != nil {
+				return err
+			}
+			if err := encodeCBOR(buf, val[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+	return nil
+}
+
+func writeCBORInt(buf *bytes.Buffer, n int64) {
+	if n >= 0 {
+		writeCBORHead(buf, cborUnsigned, uint64(n))
+		return
+	}
+	writeCBORHead(buf, cborNegative, uint64(-1-n))
+}
+
+// writeCBORHead writes a major type and argument using the shortest encoding
+func writeCBORHead(buf *bytes.Buffer, major byte, n uint64) {
+	m := major << 5
+	switch {
+	case n < 24:
+		buf.WriteByte(m | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(m | 24)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(m | 25)
+		writeBigEndian(buf, n, 2)
+	case n <= math.MaxUint32:
+		buf.WriteByte(m | 26)
+		writeBigEndian(buf, n, 4)
+	default:
+		buf.WriteByte(m | 27)
+		writeBigEndian(buf, n, 8)
+	}
+}
+
+// DecodeCBOR decodes a single CBOR data item into JSON-compatible Go values.
+// Byte strings become base64 strings, bignums become JSON numbers and other
+// tags are dropped in favour of their content.
+func DecodeCBOR(data []byte) (interface{}, error) {
+	r := &byteReader{data: data}
+	v, err := decodeCBORItem(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CBOR: %w", err)
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("invalid CBOR: %d trailing bytes", r.remaining())
+	}
+	return v, nil
+}
+
+// cborBreakMarker is returned by decodeCBORValue when it reads a break byte
+type cborBreakMarker struct{}
+
+func decodeCBORValue(r *byteReader, depth int) (interface{}, error) {
+	if depth > maxDecodeDepth {
+		return nil, errTooDeep
+	}
+	c, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if c == cborBreak {
+		return cborBreakMarker{}, nil
+	}
+
+	major, info := c>>5, c&0x1f
+
+	if major == cborSimple {
+		return decodeCBORSimple(r, info)
+	}
+
+	if info == cborIndefinite {
+		switch major {
+		case cborBytes, cborText, cborArray, cborMap:
+			return decodeCBORIndefinite(r, major, depth)
+		default:
+			return nil, fmt.Errorf("indefinite length not allowed for major type %d", major)
+		}
+	}
+
+	n, err := readCBORArgument(r, info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return n, nil
+	case cborNegative:
+		if n > math.MaxInt64 {
+			neg := new(big.Int).SetUint64(n)
+			neg.Neg(neg).Sub(neg, big.NewInt(1))
+			return json.Number(neg.String()), nil
+		}
+		return -1 - int64(n), nil
+	case cborBytes:
+		b, err := r.readN(n)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case cborText:
+		b, err := r.readN(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case cborArray:
+		if err := r.checkLength(n); err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			item, err := decodeCBORItem(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		if err := r.checkLength(n); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			key, err := decodeCBORItem(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeCBORItem(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			m[mapKey(key)] = value
+		}
+		return m, nil
+	default: // cborTag
+		return decodeCBORTag(r, n, depth)
+	}
+}
+
+// decodeCBORItem decodes a value where a break byte is not allowed
+func decodeCBORItem(r *byteReader, depth int) (interface{}, error) {
+	v, err := decodeCBORValue(r, depth)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(cborBreakMarker); ok {
+		return nil, fmt.Errorf("unexpected break at offset %d", r.pos-1)
+	}
+	return v, nil
+}
+
+// readCBORArgument reads the argument that follows the initial byte
+func readCBORArgument(r *byteReader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return r.readUint(1 << (info - 24))
+	default:
+		return 0, fmt.Errorf("reserved additional info %d at offset %d", info, r.pos-1)
+	}
+}
+
+func decodeCBORIndefinite(r *byteReader, major byte, depth int) (interface{}, error) {
+	var chunks strings.Builder
+	var items []interface{}
+	m := make(map[string]interface{})
+
+	for {
+		if major == cborBytes || major == cborText {
+			// Chunks must be definite-length strings of the same major type
+			if r.remaining() > 0 && r.data[r.pos] == cborBreak {
+				r.pos++
+				break
+			}
+			c, err := r.readByte()
+			if err != nil {
+				return nil, err
+			}
+			if c>>5 != major || c&0x1f == cborIndefinite {
+				return nil, fmt.Errorf("invalid chunk in indefinite-length string at offset %d", r.pos-1)
+			}
+			n, err := readCBORArgument(r, c&0x1f)
+			if err != nil {
+				return nil, err
+			}
+			b, err := r.readN(n)
+			if err != nil {
+				return nil, err
+			}
+			chunks.Write(b)
+			continue
+		}
+
+		v, err := decodeCBORValue(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := v.(cborBreakMarker); ok {
+			break
+		}
+		if major == cborArray {
+			items = append(items, v)
+			continue
+		}
+		value, err := decodeCBORItem(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(v)] = value
+	}
+
+	switch major {
+	case cborBytes:
+		return base64.StdEncoding.EncodeToString([]byte(chunks.String())), nil
+	case cborText:
+		return chunks.String(), nil
+	case cborArray:
+		if items == nil {
+			items = []interface{}{}
+		}
+		return items, nil
+	default:
+		return m, nil
+	}
+}
+
+func decodeCBORTag(r *byteReader, tag uint64, depth int) (interface{}, error) {
+	content, err := decodeCBORItem(r, depth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags 2 and 3 are positive and negative bignums carried in a byte string
+	if tag == 2 || tag == 3 {
+		s, ok := content.(string)
+		if !ok {
+			return nil, fmt.Errorf("bignum tag %d must wrap a byte string", tag)
+		}
+		raw, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).SetBytes(raw)
+		if tag == 3 {
+			n.Neg(n).Sub(n, big.NewInt(1))
+		}
+		return json.Number(n.String()), nil
+	}
+
+	return content, nil
+}
+
+func decodeCBORSimple(r *byteReader, info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null, undefined
+		return nil, nil
+	case 24:
+		v, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("simple(%d)", v), nil
+	case 25:
+		u, err := r.readUint(2)
+		if err != nil {
+			return nil, err
+		}
+		return jsonFloat(halfToFloat(uint16(u))), nil
+	case 26:
+		u, err := r.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return jsonFloat(float64(math.Float32frombits(uint32(u)))), nil
+	case 27:
+		u, err := r.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return jsonFloat(math.Float64frombits(u)), nil
+	default:
+		if info < 20 {
+			return fmt.Sprintf("simple(%d)", info), nil
+		}
+		return nil, fmt.Errorf("reserved simple value %d at offset %d", info, r.pos-1)
+	}
+}
+
+// halfToFloat converts an IEEE 754 half-precision float to float64
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1.0
+	}
+	exp := int(h>>10) & 0x1f
+	frac := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(frac+1024, exp-25)
+	}
+}
@@ -0,0 +1,111 @@
+package format
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CSVDelimiter returns the field delimiter for a delimited-text content type.
+// Returns false when the content type is neither CSV nor TSV.
+func CSVDelimiter(contentType string) (rune, bool) {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "text/csv"), strings.Contains(ct, "application/csv"):
+		return ',', true
+	case strings.Contains(ct, "text/tab-separated-values"):
+		return '\t', true
+	default:
+		return 0, false
+	}
+}
+
+// ParseCSVTable parses delimited text into table data.
+// The first record is used as header when it looks like one (see looksLikeHeader);
+// otherwise columns are named "Column 1", "Column 2", ...
+func ParseCSVTable(data []byte, delimiter rune) (*TableData, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Allow ragged rows
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid CSV: no records")
+	}
+
+	width := 0
+	for _, record := range records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+
+	table := &TableData{}
+	if looksLikeHeader(records) {
+		table.Columns = padRecord(records[0], width)
+		for i, col := range table.Columns {
+			if col == "" {
+				table.Columns[i] = fmt.Sprintf("Column %d", i+1)
+			}
+		}
+		records = records[1:]
+	} else {
+		table.Columns = make([]string, width)
+		for i := range table.Columns {
+			table.Columns[i] = fmt.Sprintf("Column %d", i+1)
+		}
+	}
+
+	table.Rows = make([][]string, len(records))
+	for i, record := range records {
+		table.Rows[i] = padRecord(record, width)
+	}
+
+	return table, nil
+}
+
+// looksLikeHeader reports whether the first record is a header row: its cells
+// are non-empty, unique and non-numeric. A single record is never a header.
+func looksLikeHeader(records [][]string) bool {
+	if len(records) < 2 {
+		return false
+	}
+	seen := make(map[string]bool, len(records[0]))
+	for _, cell := range records[0] {
+		cell = strings.TrimSpace(cell)
+		if cell == "" || seen[cell] {
+			return false
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			return false
+		}
+		seen[cell] = true
+	}
+	return true
+}
+
+// padRecord returns a copy of record extended with empty cells up to width
+func padRecord(record []string, width int) []string {
+	row := make([]string, width)
+	copy(row, record)
+	return row
+}
+
+// ToCSV serializes the table (header + rows) as comma-separated values
+func (t *TableData) ToCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(t.Columns); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
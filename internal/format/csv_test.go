@@ -0,0 +1,111 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        rune
+		wantOK      bool
+	}{
+		{name: "text/csv", contentType: "text/csv; charset=utf-8", want: ',', wantOK: true},
+		{name: "application/csv", contentType: "application/csv", want: ',', wantOK: true},
+		{name: "tsv", contentType: "text/tab-separated-values", want: '\t', wantOK: true},
+		{name: "json", contentType: "application/json", wantOK: false},
+		{name: "empty", contentType: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CSVDelimiter(tt.contentType)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("CSVDelimiter(%q) = %q, %v; want %q, %v", tt.contentType, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseCSVTable(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		delimiter   rune
+		wantColumns []string
+		wantRows    [][]string
+		wantErr     bool
+	}{
+		{
+			name:        "with header",
+			body:        "id,name\n1,Alice\n2,Bob\n",
+			delimiter:   ',',
+			wantColumns: []string{"id", "name"},
+			wantRows:    [][]string{{"1", "Alice"}, {"2", "Bob"}},
+		},
+		{
+			name:        "numeric first row is data",
+			body:        "1,Alice\n2,Bob\n",
+			delimiter:   ',',
+			wantColumns: []string{"Column 1", "Column 2"},
+			wantRows:    [][]string{{"1", "Alice"}, {"2", "Bob"}},
+		},
+		{
+			name:        "ragged rows are padded",
+			body:        "a,b,c\n1\n2,3\n",
+			delimiter:   ',',
+			wantColumns: []string{"a", "b", "c"},
+			wantRows:    [][]string{{"1", "", ""}, {"2", "3", ""}},
+		},
+		{
+			name:        "tab separated",
+			body:        "key\tvalue\nx\t\"quoted, value\"\n",
+			delimiter:   '\t',
+			wantColumns: []string{"key", "value"},
+			wantRows:    [][]string{{"x", "quoted, value"}},
+		},
+		{
+			name:      "empty body",
+			body:      "",
+			delimiter: ',',
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ParseCSVTable([]byte(tt.body), tt.delimiter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCSVTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(table.Columns, tt.wantColumns) {
+				t.Errorf("Columns = %v, want %v", table.Columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(table.Rows, tt.wantRows) {
+				t.Errorf("Rows = %v, want %v", table.Rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestTableData_ToCSV(t *testing.T) {
+	table, ok := ParseJSONTable([]byte(`[{"id": 1, "note": "a, b"}, {"id": 2, "note": "say \"hi\""}]`))
+	if !ok {
+		t.Fatal("ParseJSONTable() failed")
+	}
+
+	got, err := table.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV() error = %v", err)
+	}
+
+	want := "id,note\n1,\"a, b\"\n2,\"say \"\"hi\"\"\"\n"
+	if string(got) != want {
+		t.Errorf("ToCSV() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,234 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DiffKind is the kind of a difference between two bodies
+type DiffKind int
+
+const (
+	DiffAdded   DiffKind = iota // Only in the actual body
+	DiffRemoved                 // Only in the expected body
+	DiffChanged                 // In both, with different values
+)
+
+// DiffChange is one difference between an expected and an actual body
+type DiffChange struct {
+	Path     string // JSONPath of the value ($.user.name) or "line N" for text bodies
+	Kind     DiffKind
+	Expected string // Expected value as compact JSON, or line; empty when added
+	Actual   string // Actual value as compact JSON, or line; empty when removed
+}
+
+// BodyDiff is the result of comparing two bodies
+type BodyDiff struct {
+	Structural bool // Both bodies are JSON and were compared value by value
+	Changes    []DiffChange
+}
+
+// Equal returns true if the bodies have no differences
+func (d *BodyDiff) Equal() bool {
+	return len(d.Changes) == 0
+}
+
+// Count returns the number of changes of kind
+func (d *BodyDiff) Count(kind DiffKind) int {
+	count := 0
+	for _, c := range d.Changes {
+		if c.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Text renders the changes as plain text, one per line
+func (d *BodyDiff) Text() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		switch c.Kind {
+		case DiffAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", c.Path, c.Actual)
+		case DiffRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", c.Path, c.Expected)
+		case DiffChanged:
+			fmt.Fprintf(&b, "~ %s: %s → %s\n", c.Path, c.Expected, c.Actual)
+		}
+	}
+	return b.String()
+}
+
+// DiffBodies compares an expected body (e.g. a golden fixture) with an actual body.
+// JSON bodies are compared structurally, ignoring formatting and key order; other
+// bodies are compared line by line.
+func DiffBodies(expected, actual []byte) *BodyDiff {
+	var exp, act interface{}
+	if json.Unmarshal(expected, &exp) == nil && json.Unmarshal(actual, &act) == nil {
+		d := &BodyDiff{Structural: true}
+		diffJSON("$", exp, act, &d.Changes)
+		return d
+	}
+	return &BodyDiff{Changes: diffLines(splitLines(expected), splitLines(actual))}
+}
+
+// diffJSON appends the differences between two decoded JSON values at path
+func diffJSON(path string, exp, act interface{}, changes *[]DiffChange) {
+	switch e := exp.(type) {
+	case map[string]interface{}:
+		a, ok := act.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(e)+len(a))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		for k := range a {
+			if _, ok := e[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ev, inExp := e[k]
+			av, inAct := a[k]
+			child := path + jsonPathKey(k)
+			switch {
+			case !inAct:
+				*changes = append(*changes, DiffChange{Path: child, Kind: DiffRemoved, Expected: compactJSON(ev)})
+			case !inExp:
+				*changes = append(*changes, DiffChange{Path: child, Kind: DiffAdded, Actual: compactJSON(av)})
+			default:
+				diffJSON(child, ev, av, changes)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := act.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(e), len(a)); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(a):
+				*changes = append(*changes, DiffChange{Path: child, Kind: DiffRemoved, Expected: compactJSON(e[i])})
+			case i >= len(e):
+				*changes = append(*changes, DiffChange{Path: child, Kind: DiffAdded, Actual: compactJSON(a[i])})
+			default:
+				diffJSON(child, e[i], a[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(exp, act) {
+		*changes = append(*changes, DiffChange{Path: path, Kind: DiffChanged, Expected: compactJSON(exp), Actual: compactJSON(act)})
+	}
+}
+
+// identifierPattern matches object keys usable in dot notation
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonPathKey returns the JSONPath segment selecting key
+func jsonPathKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return "[" + string(quoted) + "]"
+}
+
+// compactJSON renders a value as compact JSON, quoting strings
+func compactJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// splitLines splits a body into lines, ignoring a trailing newline
+func splitLines(data []byte) []string {
+	data = bytes.TrimSuffix(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\n"))
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// maxLineDiffCells bounds the memory of the line diff; larger bodies are compared line by line at the same index
+const maxLineDiffCells = 4_000_000
+
+// diffLines returns the lines removed from exp and added in act, using the longest common subsequence
+func diffLines(exp, act []string) []DiffChange {
+	var changes []DiffChange
+	if len(exp)*len(act) > maxLineDiffCells {
+		for i := 0; i < max(len(exp), len(act)); i++ {
+			path := fmt.Sprintf("line %d", i+1)
+			switch {
+			case i >= len(act):
+				changes = append(changes, DiffChange{Path: path, Kind: DiffRemoved, Expected: exp[i]})
+			case i >= len(exp):
+				changes = append(changes, DiffChange{Path: path, Kind: DiffAdded, Actual: act[i]})
+			case exp[i] != act[i]:
+				changes = append(changes, DiffChange{Path: path, Kind: DiffChanged, Expected: exp[i], Actual: act[i]})
+			}
+		}
+		return changes
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of exp[i:] and act[j:]
+	lcs := make([][]int, len(exp)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(act)+1)
+	}
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := len(act) - 1; j >= 0; j-- {
+			if exp[i] == act[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Line numbers refer to the expected body for removals and to the actual body for additions
+	i, j := 0, 0
+	for i < len(exp) || j < len(act) {
+		switch {
+		case i < len(exp) && j < len(act) && exp[i] == act[j]:
+			i++
+			j++
+		case i < len(exp) && (j == len(act) || lcs[i+1][j] >= lcs[i][j+1]):
+			changes = append(changes, DiffChange{Path: fmt.Sprintf("line %d", i+1), Kind: DiffRemoved, Expected: exp[i]})
+			i++
+		default:
+			changes = append(changes, DiffChange{Path: fmt.Sprintf("line %d", j+1), Kind: DiffAdded, Actual: act[j]})
+			j++
+		}
+	}
+	return changes
+}
+
+// ChangedLines returns the indexes of the lines of current that are not in previous,
+// in order, using the line diff of DiffBodies
+func ChangedLines(previous, current string) []int {
+	var rows []int
+	for _, c := range diffLines(splitLines([]byte(previous)), splitLines([]byte(current))) {
+		var line int
+		if c.Kind != DiffRemoved && strings.HasPrefix(c.Path, "line ") {
+			if _, err := fmt.Sscanf(c.Path, "line %d", &line); err == nil {
+				rows = append(rows, line-1)
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,98 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffBodies(t *testing.T) {
+	tests := []struct {
+		name           string
+		expected       string
+		actual         string
+		wantStructural bool
+		want           []DiffChange
+	}{
+		{
+			name:           "formatting and key order ignored",
+			expected:       "{\n  \"b\": 1,\n  \"a\": [1, 2]\n}\n",
+			actual:         `{"a":[1,2],"b":1}`,
+			wantStructural: true,
+		},
+		{
+			name:           "changed, added and removed values",
+			expected:       `{"user":{"name":"Ada","id":7},"tags":["a","b"],"old":true}`,
+			actual:         `{"user":{"name":"Bob","id":7,"email":"b@x.io"},"tags":["a"],"new key":null}`,
+			wantStructural: true,
+			want: []DiffChange{
+				{Path: `$["new key"]`, Kind: DiffAdded, Actual: "null"},
+				{Path: "$.old", Kind: DiffRemoved, Expected: "true"},
+				{Path: "$.tags[1]", Kind: DiffRemoved, Expected: `"b"`},
+				{Path: "$.user.email", Kind: DiffAdded, Actual: `"b@x.io"`},
+				{Path: "$.user.name", Kind: DiffChanged, Expected: `"Ada"`, Actual: `"Bob"`},
+			},
+		},
+		{
+			name:           "type change",
+			expected:       `{"items":[1]}`,
+			actual:         `{"items":{"count":1}}`,
+			wantStructural: true,
+			want:           []DiffChange{{Path: "$.items", Kind: DiffChanged, Expected: "[1]", Actual: `{"count":1}`}},
+		},
+		{
+			name:     "text lines",
+			expected: "alpha\nbeta\ngamma\n",
+			actual:   "alpha\ngamma\ndelta",
+			want: []DiffChange{
+				{Path: "line 2", Kind: DiffRemoved, Expected: "beta"},
+				{Path: "line 3", Kind: DiffAdded, Actual: "delta"},
+			},
+		},
+		{
+			name:     "JSON against text",
+			expected: `{"a":1}`,
+			actual:   "not json",
+			want: []DiffChange{
+				{Path: "line 1", Kind: DiffRemoved, Expected: `{"a":1}`},
+				{Path: "line 1", Kind: DiffAdded, Actual: "not json"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiffBodies([]byte(tt.expected), []byte(tt.actual))
+			if d.Structural != tt.wantStructural {
+				t.Errorf("Structural = %v, want %v", d.Structural, tt.wantStructural)
+			}
+			if !reflect.DeepEqual(d.Changes, tt.want) {
+				t.Errorf("Changes = %+v\nwant %+v", d.Changes, tt.want)
+			}
+			if d.Equal() != (len(tt.want) == 0) {
+				t.Errorf("Equal() = %v", d.Equal())
+			}
+		})
+	}
+}
+
+func TestBodyDiff_Text(t *testing.T) {
+	d := DiffBodies([]byte(`{"a":1,"b":2}`), []byte(`{"a":3,"c":4}`))
+	want := "~ $.a: 1 → 3\n- $.b: 2\n+ $.c: 4\n"
+	if got := d.Text(); got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	if d.Count(DiffAdded) != 1 || d.Count(DiffRemoved) != 1 || d.Count(DiffChanged) != 1 {
+		t.Errorf("Count() mismatch: %+v", d.Changes)
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	previous := "{\n  \"status\": \"pending\",\n  \"progress\": 10\n}"
+	current := "{\n  \"status\": \"running\",\n  \"progress\": 40,\n  \"eta\": 5\n}"
+	if got := ChangedLines(previous, current); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("ChangedLines() = %v, want [1 2 3]", got)
+	}
+	if got := ChangedLines(current, current); len(got) != 0 {
+		t.Errorf("ChangedLines() of equal bodies = %v, want none", got)
+	}
+}
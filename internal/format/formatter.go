@@ -1,9 +1,11 @@
 package format
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -27,7 +29,7 @@ func DetectContentType(contentType string, body []byte) ContentType {
 	if strings.Contains(contentTypeLower, "application/json") || strings.Contains(contentTypeLower, "text/json") {
 		return ContentTypeJSON
 	}
-	if strings.Contains(contentTypeLower, "application/xml") || strings.Contains(contentTypeLower, "text/xml") {
+	if IsXMLContentType(contentTypeLower) {
 		return ContentTypeXML
 	}
 	if strings.Contains(contentTypeLower, "text/html") {
@@ -87,23 +89,124 @@ func FormatJSON(data []byte, indent string) (string, error) {
 	return string(formatted), nil
 }
 
-// FormatXML formats XML with proper indentation
+// FormatXML formats XML with proper indentation. Namespace prefixes, comments,
+// processing instructions and directives are kept; elements holding only text
+// stay on one line and empty elements are self-closed.
 func FormatXML(data []byte, indent string) (string, error) {
-	if len(data) == 0 {
+	if len(bytes.TrimSpace(data)) == 0 {
 		return "", nil
 	}
 
-	var parsed interface{}
-	if err := xml.Unmarshal(data, &parsed); err != nil {
-		return "", fmt.Errorf("invalid XML: %w", err)
+	// RawToken keeps namespace prefixes as written
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var tokens []xml.Token
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid XML: %w", err)
+		}
+		tokens = append(tokens, xml.CopyToken(token))
 	}
 
-	formatted, err := xml.MarshalIndent(parsed, "", indent)
-	if err != nil {
-		return "", fmt.Errorf("failed to format XML: %w", err)
+	var out strings.Builder
+	depth := 0
+	open := 0
+	newline := func() {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(indent, depth))
+	}
+	for i := 0; i < len(tokens); i++ {
+		switch t := tokens[i].(type) {
+		case xml.StartElement:
+			newline()
+			out.WriteString("<" + xmlName(t.Name))
+			for _, attr := range t.Attr {
+				out.WriteString(" " + xmlName(attr.Name) + `="` + escapeXML(attr.Value) + `"`)
+			}
+			open++
+
+			// Look ahead for an empty element or an element holding only text
+			j := i + 1
+			text := ""
+			if j < len(tokens) {
+				if data, ok := tokens[j].(xml.CharData); ok {
+					text = string(data)
+					j++
+				}
+			}
+			if j < len(tokens) {
+				if _, ok := tokens[j].(xml.EndElement); ok {
+					open--
+					if strings.TrimSpace(text) == "" {
+						out.WriteString("/>")
+					} else {
+						out.WriteString(">" + escapeXML(strings.TrimSpace(text)) + "</" + xmlName(t.Name) + ">")
+					}
+					i = j
+					continue
+				}
+			}
+			out.WriteString(">")
+			depth++
+		case xml.EndElement:
+			depth = max(depth-1, 0)
+			open--
+			newline()
+			out.WriteString("</" + xmlName(t.Name) + ">")
+		case xml.CharData:
+			if text := strings.TrimSpace(string(t)); text != "" {
+				newline()
+				out.WriteString(escapeXML(text))
+			}
+		case xml.Comment:
+			newline()
+			out.WriteString("<!--" + string(t) + "-->")
+		case xml.ProcInst:
+			newline()
+			out.WriteString("<?" + t.Target)
+			if len(t.Inst) > 0 {
+				out.WriteString(" " + string(t.Inst))
+			}
+			out.WriteString("?>")
+		case xml.Directive:
+			newline()
+			out.WriteString("<!" + string(t) + ">")
+		}
+	}
+	if open != 0 {
+		return "", fmt.Errorf("invalid XML: unclosed element")
 	}
 
-	return string(formatted), nil
+	return out.String(), nil
+}
+
+// xmlName formats an element or attribute name with its namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// escapeXML escapes text for element content and attribute values
+func escapeXML(text string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(text)); err != nil {
+		return text
+	}
+	return b.String()
+}
+
+// IsXMLContentType returns true for XML media types, including SOAP and other
+// "+xml" types
+func IsXMLContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "/xml") || strings.Contains(contentType, "+xml")
 }
 
 // Format automatically detects content type and formats accordingly
@@ -135,6 +135,59 @@ func TestFormatJSON(t *testing.T) {
 	}
 }
 
+func TestFormatXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Nested elements",
+			input:    `<users><user id="1"><name>Ada</name><admin/></user></users>`,
+			expected: "<users>\n  <user id=\"1\">\n    <name>Ada</name>\n    <admin/>\n  </user>\n</users>",
+		},
+		{
+			name:  "SOAP envelope keeps prefixes and declaration",
+			input: `<?xml version="1.0"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><!-- result --><m:Price xmlns:m="urn:x">1 &lt; 2</m:Price></soap:Body></soap:Envelope>`,
+			expected: "<?xml version=\"1.0\"?>\n" +
+				"<soap:Envelope xmlns:soap=\"http://schemas.xmlsoap.org/soap/envelope/\">\n" +
+				"  <soap:Body>\n" +
+				"    <!-- result -->\n" +
+				"    <m:Price xmlns:m=\"urn:x\">1 &lt; 2</m:Price>\n" +
+				"  </soap:Body>\n" +
+				"</soap:Envelope>",
+		},
+		{
+			name:     "Empty",
+			input:    "  ",
+			expected: "",
+		},
+		{
+			name:    "Unclosed element",
+			input:   `<a><b>`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid",
+			input:   `<a></b`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatXML([]byte(tt.input), "  ")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatXML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("FormatXML() =\n%s\nwant\n%s", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestMinifyJSON(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -233,6 +286,13 @@ func TestFormat(t *testing.T) {
 			expectedType:     ContentTypeJSON,
 			expectedContains: "\"name\": \"John\"",
 		},
+		{
+			name:             "Format SOAP XML",
+			contentType:      "application/soap+xml; charset=utf-8",
+			body:             []byte(`<Envelope><Body><ok>true</ok></Body></Envelope>`),
+			expectedType:     ContentTypeXML,
+			expectedContains: "\n    <ok>true</ok>",
+		},
 		{
 			name:             "Format plain text",
 			contentType:      "text/plain",
@@ -0,0 +1,343 @@
+package format
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// HTMLPage holds the links and forms of an HTML page
+type HTMLPage struct {
+	Base  string // href of the <base> element, against which relative URLs resolve
+	Links []HTMLLink
+	Forms []HTMLForm
+}
+
+// HTMLLink is an <a href> link of an HTML page
+type HTMLLink struct {
+	Text string // Text of the link, or its title
+	Href string // href as written in the page
+}
+
+// HTMLForm is a <form> of an HTML page
+type HTMLForm struct {
+	Name    string // id or name of the form
+	Method  string // Upper-case method, GET by default
+	Action  string // action as written in the page, empty to submit to the page itself
+	Enctype string // Lower-case encoding, application/x-www-form-urlencoded by default
+	Fields  []HTMLFormField
+}
+
+// HTMLFormField is a named control of a form with its initial value
+type HTMLFormField struct {
+	Name  string
+	Value string
+	File  bool // <input type="file">, uploaded with its content
+}
+
+// Multipart returns true if the form is sent as multipart/form-data
+func (f HTMLForm) Multipart() bool {
+	return f.Enctype == "multipart/form-data"
+}
+
+// htmlRawTextPattern matches script and style elements, whose content is not markup
+var htmlRawTextPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)\s*>`)
+
+// IsHTML reports whether a body is an HTML page, from its Content-Type or its first tag
+func IsHTML(contentType string, body []byte) bool {
+	if strings.Contains(strings.ToLower(contentType), "html") {
+		return true
+	}
+	head := body[:min(len(body), 512)]
+	trimmed := strings.ToLower(strings.TrimSpace(string(head)))
+	return strings.HasPrefix(trimmed, "<!doctype html") || strings.HasPrefix(trimmed, "<html")
+}
+
+// ParseHTMLPage returns the links and forms of an HTML page in document order.
+// Fragment-only and javascript: links are skipped. Form fields keep their initial
+// values: checked boxes, the selected option, the text of text areas. Buttons and
+// unnamed controls are skipped.
+func ParseHTMLPage(data []byte) (*HTMLPage, error) {
+	doc, err := parseXMLDocument(htmlRawTextPattern.ReplaceAll(data, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &HTMLPage{}
+	forms := make(map[*xmlNode]int)
+	for _, node := range doc.descendants() {
+		if node.kind != xmlElement {
+			continue
+		}
+		switch strings.ToLower(node.name) {
+		case "base":
+			if href, ok := node.attr("href"); ok && page.Base == "" {
+				page.Base = strings.TrimSpace(href)
+			}
+		case "a":
+			href, _ := node.attr("href")
+			href = strings.TrimSpace(href)
+			if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+				continue
+			}
+			text := strings.Join(strings.Fields(node.text()), " ")
+			if text == "" {
+				text, _ = node.attr("title")
+			}
+			page.Links = append(page.Links, HTMLLink{Text: text, Href: href})
+		case "form":
+			form := HTMLForm{Method: "GET", Enctype: "application/x-www-form-urlencoded"}
+			if form.Name, _ = node.attr("id"); form.Name == "" {
+				form.Name, _ = node.attr("name")
+			}
+			if method, _ := node.attr("method"); strings.TrimSpace(method) != "" {
+				form.Method = strings.ToUpper(strings.TrimSpace(method))
+			}
+			if enctype, _ := node.attr("enctype"); strings.TrimSpace(enctype) != "" {
+				form.Enctype = strings.ToLower(strings.TrimSpace(enctype))
+			}
+			form.Action, _ = node.attr("action")
+			form.Action = strings.TrimSpace(form.Action)
+			forms[node] = len(page.Forms)
+			page.Forms = append(page.Forms, form)
+		case "input", "textarea", "select":
+			field, ok := htmlFormField(node)
+			if !ok {
+				continue
+			}
+			for parent := node.parent; parent != nil; parent = parent.parent {
+				if index, ok := forms[parent]; ok {
+					page.Forms[index].Fields = append(page.Forms[index].Fields, field)
+					break
+				}
+			}
+		}
+	}
+	return page, nil
+}
+
+// htmlFormField returns the field a form control submits, false for buttons,
+// unchecked boxes and unnamed controls
+func htmlFormField(node *xmlNode) (HTMLFormField, bool) {
+	name, _ := node.attr("name")
+	if name == "" {
+		return HTMLFormField{}, false
+	}
+	field := HTMLFormField{Name: name}
+
+	switch strings.ToLower(node.name) {
+	case "textarea":
+		field.Value = node.text()
+	case "select":
+		var options []*xmlNode
+		for _, child := range node.descendants() {
+			if child.kind == xmlElement && strings.EqualFold(child.name, "option") {
+				options = append(options, child)
+			}
+		}
+		if len(options) == 0 {
+			return HTMLFormField{}, false
+		}
+		option := options[0]
+		for _, candidate := range options {
+			if _, ok := candidate.attr("selected"); ok {
+				option = candidate
+				break
+			}
+		}
+		value, ok := option.attr("value")
+		if !ok {
+			value = option.text()
+		}
+		field.Value = value
+	default:
+		inputType, _ := node.attr("type")
+		switch strings.ToLower(inputType) {
+		case "submit", "button", "image", "reset":
+			return HTMLFormField{}, false
+		case "checkbox", "radio":
+			if _, ok := node.attr("checked"); !ok {
+				return HTMLFormField{}, false
+			}
+			value, ok := node.attr("value")
+			if !ok {
+				value = "on"
+			}
+			field.Value = value
+		case "file":
+			field.File = true
+		default:
+			field.Value, _ = node.attr("value")
+		}
+	}
+	return field, true
+}
+
+// ResolveURL returns the absolute URL of a link or form action of the page loaded
+// from pageURL, honoring its <base> element. An empty ref is the page itself.
+func (p *HTMLPage) ResolveURL(pageURL, ref string) string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ref
+	}
+	if p.Base != "" {
+		if baseRef, err := url.Parse(p.Base); err == nil {
+			base = base.ResolveReference(baseRef)
+		}
+	}
+	target, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	resolved := base.ResolveReference(target)
+	resolved.Fragment = ""
+	return resolved.String()
+}
+
+// attr returns the value of an attribute of an element, matched case-insensitively
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if strings.EqualFold(attr.name, name) {
+			return attr.value, true
+		}
+	}
+	return "", false
+}
+
+// htmlVoidElements are the elements without end tag
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlVerbatimElements are the elements whose content is kept as written
+var htmlVerbatimElements = map[string]bool{"script": true, "style": true, "pre": true, "textarea": true}
+
+// FormatHTML indents an HTML document with one tag per line. Elements holding only
+// text stay on one line; script, style, pre and textarea elements are kept as
+// written. Unlike FormatXML it accepts any markup: unclosed elements and stray end
+// tags only affect the indentation.
+func FormatHTML(data []byte, indent string) string {
+	src := string(data)
+	var out strings.Builder
+	var open []string // Names of the open elements
+	line := func(text string) {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(indent, len(open)))
+		out.WriteString(text)
+	}
+
+	for i := 0; i < len(src); {
+		rest := src[i:]
+		if rest[0] != '<' {
+			end := strings.IndexByte(rest, '<')
+			if end < 0 {
+				end = len(rest)
+			}
+			if text := strings.Join(strings.Fields(rest[:end]), " "); text != "" {
+				line(text)
+			}
+			i += end
+			continue
+		}
+
+		tag, name, closing := htmlTagAt(rest)
+		i += len(tag)
+		switch {
+		case name == "":
+			// Comment, doctype, processing instruction or a lone "<"
+			line(tag)
+		case closing:
+			for n := len(open) - 1; n >= 0; n-- {
+				if open[n] == name {
+					open = open[:n]
+					break
+				}
+			}
+			line(tag)
+		case htmlVoidElements[name] || strings.HasSuffix(tag, "/>"):
+			line(tag)
+		case htmlVerbatimElements[name]:
+			end := htmlIndexEndTag(src[i:], name)
+			line(tag + strings.TrimRight(src[i:i+end], " \t\r\n"))
+			i += end
+		default:
+			// Keep an element holding only text on one line
+			text := src[i:]
+			if end := strings.IndexByte(text, '<'); end >= 0 {
+				if endTag, endName, closing := htmlTagAt(text[end:]); closing && endName == name {
+					line(tag + strings.Join(strings.Fields(text[:end]), " ") + endTag)
+					i += end + len(endTag)
+					continue
+				}
+			}
+			line(tag)
+			open = append(open, name)
+		}
+	}
+	return out.String()
+}
+
+// htmlTagAt returns the markup starting at the "<" beginning s with the lower-case
+// name of its element, "" for comments and declarations, and whether it is an end tag
+func htmlTagAt(s string) (tag, name string, closing bool) {
+	if strings.HasPrefix(s, "<!--") {
+		end := strings.Index(s, "-->")
+		if end < 0 {
+			return s, "", false
+		}
+		return s[:end+3], "", false
+	}
+
+	j := 1
+	if j < len(s) && s[j] == '/' {
+		closing = true
+		j++
+	}
+	k := j
+	for k < len(s) && (s[k] == '-' || s[k] == ':' || s[k] >= 'a' && s[k] <= 'z' || s[k] >= 'A' && s[k] <= 'Z' || s[k] >= '0' && s[k] <= '9') {
+		k++
+	}
+	if k == j || !(s[j] >= 'a' && s[j] <= 'z' || s[j] >= 'A' && s[j] <= 'Z') {
+		// Declarations such as <!DOCTYPE html>; a "<" starting no tag is text
+		if end := strings.IndexByte(s, '>'); end >= 0 && len(s) > 1 && (s[1] == '!' || s[1] == '?') {
+			return s[:end+1], "", false
+		}
+		return "<", "", false
+	}
+
+	// The tag ends at the first ">" outside quoted attribute values
+	var quote byte
+	for end := k; end < len(s); end++ {
+		switch c := s[end]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return s[:end+1], strings.ToLower(s[j:k]), closing
+		}
+	}
+	return s, strings.ToLower(s[j:k]), closing
+}
+
+// htmlIndexEndTag returns the index of the end tag of element name in s, len(s)
+// when it is missing
+func htmlIndexEndTag(s, name string) int {
+	lower := strings.ToLower(s)
+	for from := 0; ; {
+		i := strings.Index(lower[from:], "</"+name)
+		if i < 0 {
+			return len(s)
+		}
+		from += i
+		if after := from + 2 + len(name); after == len(s) || s[after] == '>' || s[after] == ' ' || s[after] == '\t' || s[after] == '\n' {
+			return from
+		}
+		from++
+	}
+}
@@ -0,0 +1,155 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+const htmlPage = `<!DOCTYPE html>
+<html><head><title>Orders</title><base href="/api/v2/">
+<script>if (a < b && c) { document.write("<a href='/nope'>x</a>") }</script>
+<style>a > b { color: red }</style></head>
+<body>
+  <a href="orders?page=2">Next
+    page</a> <a href="#top">Top</a> <a href="javascript:void(0)">JS</a>
+  <A HREF="https://example.com/docs" title="Documentation"><img src="logo.png"></A>
+  <form id="search" action="search"><input name="q" value="shoes"><input type="submit" name="go" value="Go"></form>
+  <form method="post" action="/orders" enctype="multipart/form-data">
+    <input type="hidden" name="csrf" value="t0k3n">
+    <input type="checkbox" name="gift" checked>
+    <input type="checkbox" name="express" value="yes">
+    <input type="radio" name="size" value="s"><input type="radio" name="size" value="m" checked>
+    <select name="color"><option value="red">Red<option value="blue" selected>Blue</select>
+    <select name="qty"><option>1</option><option>2</option></select>
+    <textarea name="note">Ring twice</textarea>
+    <input type="file" name="invoice">
+    <input value="unnamed">
+    <button type="submit">Order</button>
+  </form>
+</body></html>`
+
+func TestParseHTMLPage(t *testing.T) {
+	page, err := ParseHTMLPage([]byte(htmlPage))
+	if err != nil {
+		t.Fatalf("ParseHTMLPage() error = %v", err)
+	}
+
+	if page.Base != "/api/v2/" {
+		t.Errorf("Base = %q", page.Base)
+	}
+	wantLinks := []HTMLLink{
+		{Text: "Next page", Href: "orders?page=2"},
+		{Text: "Documentation", Href: "https://example.com/docs"},
+	}
+	if !reflect.DeepEqual(page.Links, wantLinks) {
+		t.Errorf("Links = %+v, want %+v", page.Links, wantLinks)
+	}
+
+	wantForms := []HTMLForm{
+		{
+			Name:    "search",
+			Method:  "GET",
+			Action:  "search",
+			Enctype: "application/x-www-form-urlencoded",
+			Fields:  []HTMLFormField{{Name: "q", Value: "shoes"}},
+		},
+		{
+			Method:  "POST",
+			Action:  "/orders",
+			Enctype: "multipart/form-data",
+			Fields: []HTMLFormField{
+				{Name: "csrf", Value: "t0k3n"},
+				{Name: "gift", Value: "on"},
+				{Name: "size", Value: "m"},
+				{Name: "color", Value: "blue"},
+				{Name: "qty", Value: "1"},
+				{Name: "note", Value: "Ring twice"},
+				{Name: "invoice", File: true},
+			},
+		},
+	}
+	if !reflect.DeepEqual(page.Forms, wantForms) {
+		t.Errorf("Forms = %+v, want %+v", page.Forms, wantForms)
+	}
+	if page.Forms[0].Multipart() || !page.Forms[1].Multipart() {
+		t.Error("Multipart() does not follow the form enctype")
+	}
+}
+
+func TestParseHTMLPage_NotHTML(t *testing.T) {
+	if _, err := ParseHTMLPage([]byte("plain text")); err == nil {
+		t.Error("ParseHTMLPage() error = nil for a body without markup")
+	}
+}
+
+func TestHTMLPage_ResolveURL(t *testing.T) {
+	tests := []struct {
+		base    string
+		pageURL string
+		ref     string
+		want    string
+	}{
+		{pageURL: "https://shop.test/orders/1", ref: "items", want: "https://shop.test/orders/items"},
+		{pageURL: "https://shop.test/orders/1", ref: "/login#form", want: "https://shop.test/login"},
+		{pageURL: "https://shop.test/orders/1?x=1", ref: "", want: "https://shop.test/orders/1?x=1"},
+		{pageURL: "https://shop.test/orders/1", ref: "//cdn.test/a", want: "https://cdn.test/a"},
+		{base: "/api/v2/", pageURL: "https://shop.test/orders/1", ref: "orders?page=2", want: "https://shop.test/api/v2/orders?page=2"},
+		{base: "https://other.test/", pageURL: "https://shop.test/", ref: "x", want: "https://other.test/x"},
+	}
+	for _, tt := range tests {
+		page := &HTMLPage{Base: tt.base}
+		if got := page.ResolveURL(tt.pageURL, tt.ref); got != tt.want {
+			t.Errorf("ResolveURL(%q, %q) with base %q = %q, want %q", tt.pageURL, tt.ref, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        bool
+	}{
+		{contentType: "text/html; charset=utf-8", body: "<p>hi</p>", want: true},
+		{contentType: "application/xhtml+xml", body: "<html/>", want: true},
+		{contentType: "", body: "\n  <!DOCTYPE HTML><html></html>", want: true},
+		{contentType: "text/plain", body: "<html><body></body></html>", want: true},
+		{contentType: "application/json", body: `{"a":1}`, want: false},
+		{contentType: "application/xml", body: "<?xml version=\"1.0\"?><a/>", want: false},
+	}
+	for _, tt := range tests {
+		if got := IsHTML(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("IsHTML(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHTML(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><meta charset="utf-8"><title>Users</title>` +
+		`<script>if (a < b) { go("</p>") }</script></head>` +
+		`<body><ul><li>Ada</li><li><a href="/grace" title="a > b">Grace</a></li></ul><br><p>Unclosed</body></html>`
+
+	want := `<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Users</title>
+    <script>if (a < b) { go("</p>") }
+    </script>
+  </head>
+  <body>
+    <ul>
+      <li>Ada</li>
+      <li>
+        <a href="/grace" title="a > b">Grace</a>
+      </li>
+    </ul>
+    <br>
+    <p>
+      Unclosed
+  </body>
+</html>`
+	if got := FormatHTML([]byte(page), "  "); got != want {
+		t.Errorf("FormatHTML() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// JSONNode is a value of a JSON document, as picked at a position of its text
+type JSONNode struct {
+	Path  string      // JSONPath of the value, such as "$.data.items[0].id"
+	Key   string      // Name of the member holding the value, or of the array holding it
+	Value interface{} // The value, numbers as json.Number
+}
+
+// jsonPathName matches the member names written ".name" in a JSONPath
+var jsonPathName = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// JSONValueAt returns the innermost value of the JSON document text at byte offset: the
+// value of the member whose key or value is there. An offset on the indentation or the
+// trailing comma of a line picks the content of the line. Returns false when text is
+// not a JSON document.
+func JSONValueAt(text string, offset int) (JSONNode, bool) {
+	if !json.Valid([]byte(text)) {
+		return JSONNode{}, false
+	}
+	s := &jsonCursorScanner{text: text, offset: lineContent(text, offset), depth: -1}
+	s.skipSpaces()
+	if !s.scanValue("$", "", s.pos, 0) || s.depth < 0 {
+		return JSONNode{}, false
+	}
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(text[s.start:s.end]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return JSONNode{}, false
+	}
+	return JSONNode{Path: s.path, Key: s.key, Value: value}, true
+}
+
+// lineContent moves an offset on the blanks or separators around the content of its
+// line onto the content
+func lineContent(text string, offset int) int {
+	offset = min(max(offset, 0), len(text))
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	lineEnd := len(text)
+	if i := strings.IndexByte(text[offset:], '\n'); i >= 0 {
+		lineEnd = offset + i
+	}
+	line := text[lineStart:lineEnd]
+	first := len(line) - len(strings.TrimLeft(line, " \t\r"))
+	last := len(strings.TrimRight(line, " \t\r,"))
+	if first >= last {
+		return offset
+	}
+	return lineStart + min(max(offset-lineStart, first), last-1)
+}
+
+// jsonCursorScanner walks a JSON document and keeps the deepest value whose member spans
+// the offset
+type jsonCursorScanner struct {
+	text   string
+	pos    int
+	offset int
+
+	depth      int // Depth of the value found, -1 when none is
+	path, key  string
+	start, end int // Span of the value found
+}
+
+// scanValue scans the value at pos, with its path and key. The value is a member of an
+// object starting at memberStart, its key, or memberStart is the value itself.
+func (s *jsonCursorScanner) scanValue(path, key string, memberStart, depth int) bool {
+	start := s.pos
+	if start >= len(s.text) {
+		return false
+	}
+	ok := true
+	switch c := s.text[start]; {
+	case c == '{':
+		ok = s.scanObject(path, depth)
+	case c == '[':
+		ok = s.scanArray(path, key, depth)
+	case c == '"':
+		ok = s.scanString()
+	default:
+		for s.pos < len(s.text) && !strings.ContainsRune(",:]} \t\r\n", rune(s.text[s.pos])) {
+			s.pos++
+		}
+		ok = s.pos > start
+	}
+	if ok && memberStart <= s.offset && s.offset < s.pos && depth > s.depth {
+		s.depth, s.path, s.key, s.start, s.end = depth, path, key, start, s.pos
+	}
+	return ok
+}
+
+// scanObject scans the object at pos
+func (s *jsonCursorScanner) scanObject(path string, depth int) bool {
+	s.pos++
+	s.skipSpaces()
+	if s.peek() == '}' {
+		s.pos++
+		return true
+	}
+	for {
+		keyStart := s.pos
+		if s.peek() != '"' || !s.scanString() {
+			return false
+		}
+		var name string
+		if err := json.Unmarshal([]byte(s.text[keyStart:s.pos]), &name); err != nil {
+			return false
+		}
+		s.skipSpaces()
+		if s.peek() != ':' {
+			return false
+		}
+		s.pos++
+		s.skipSpaces()
+		childPath := fmt.Sprintf("%s['%s']", path, strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(name))
+		if jsonPathName.MatchString(name) {
+			childPath = path + "." + name
+		}
+		if !s.scanValue(childPath, name, keyStart, depth+1) {
+			return false
+		}
+		s.skipSpaces()
+		switch s.peek() {
+		case ',':
+			s.pos++
+			s.skipSpaces()
+		case '}':
+			s.pos++
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// scanArray scans the array at pos; its items have the key of the array
+func (s *jsonCursorScanner) scanArray(path, key string, depth int) bool {
+	s.pos++
+	s.skipSpaces()
+	if s.peek() == ']' {
+		s.pos++
+		return true
+	}
+	for i := 0; ; i++ {
+		if !s.scanValue(fmt.Sprintf("%s[%d]", path, i), key, s.pos, depth+1) {
+			return false
+		}
+		s.skipSpaces()
+		switch s.peek() {
+		case ',':
+			s.pos++
+			s.skipSpaces()
+		case ']':
+			s.pos++
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// scanString scans the string at pos
+func (s *jsonCursorScanner) scanString() bool {
+	for s.pos++; s.pos < len(s.text); s.pos++ {
+		switch s.text[s.pos] {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jsonCursorScanner) peek() byte {
+	if s.pos < len(s.text) {
+		return s.text[s.pos]
+	}
+	return 0
+}
+
+func (s *jsonCursorScanner) skipSpaces() {
+	for s.pos < len(s.text) && strings.ContainsRune(" \t\r\n", rune(s.text[s.pos])) {
+		s.pos++
+	}
+}
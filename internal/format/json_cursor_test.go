@@ -0,0 +1,51 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONValueAt(t *testing.T) {
+	doc := FormatJSONValue(map[string]interface{}{
+		"data": map[string]interface{}{
+			"token": "abc.def",
+			"items": []interface{}{map[string]interface{}{"id": 42}},
+			"a b":   true,
+		},
+	})
+	// offsetOf returns the offset of column col of the first line containing text
+	offsetOf := func(text string, col int) int {
+		line := strings.Index(doc, text)
+		return strings.LastIndexByte(doc[:line], '\n') + 1 + col
+	}
+	tests := []struct {
+		name     string
+		offset   int
+		wantPath string
+		wantKey  string
+		wantText string // JSONValueText of the value
+	}{
+		{name: "on the value", offset: strings.Index(doc, "abc"), wantPath: "$.data.token", wantKey: "token", wantText: "abc.def"},
+		{name: "on the key", offset: strings.Index(doc, `"token"`) + 2, wantPath: "$.data.token", wantKey: "token", wantText: "abc.def"},
+		{name: "on the indentation", offset: offsetOf(`"token"`, 0), wantPath: "$.data.token", wantKey: "token", wantText: "abc.def"},
+		{name: "array item", offset: strings.Index(doc, "42"), wantPath: "$.data.items[0].id", wantKey: "id", wantText: "42"},
+		{name: "array", offset: strings.Index(doc, `"items"`), wantPath: "$.data.items", wantKey: "items", wantText: `[{"id":42}]`},
+		{name: "quoted name", offset: strings.Index(doc, `"a b"`), wantPath: "$.data['a b']", wantKey: "a b", wantText: "true"},
+		{name: "root", offset: 0, wantPath: "$", wantText: `{"data":{"a b":true,"items":[{"id":42}],"token":"abc.def"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, ok := JSONValueAt(doc, tt.offset)
+			if !ok {
+				t.Fatal("no value found")
+			}
+			if node.Path != tt.wantPath || node.Key != tt.wantKey || JSONValueText(node.Value) != tt.wantText {
+				t.Errorf("got %s (%s) = %s, want %s (%s) = %s", node.Path, node.Key, JSONValueText(node.Value), tt.wantPath, tt.wantKey, tt.wantText)
+			}
+		})
+	}
+
+	if _, ok := JSONValueAt(`{"data": `, 3); ok {
+		t.Error("text that is not JSON should have no values")
+	}
+}
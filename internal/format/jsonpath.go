@@ -0,0 +1,743 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// QueryJSONPath evaluates a JSONPath expression against a JSON document.
+//
+// Supported syntax: "$" root (optional), ".name", "['name']", "[0]", "[-1]",
+// "[0,2]", "[1:3]", "*" wildcards, ".." recursive descent and filters such as
+// "[?(@.age > 30)]", "[?(@.name == 'Ada')]" or "[?(@.email)]".
+//
+// Filter conditions combine with "&&" and "||". Definite paths (no wildcard,
+// list, slice, filter or recursive descent) return the matched value; other
+// paths return the array of matches. Objects keep their keys in document order.
+func QueryJSONPath(data []byte, expr string) (interface{}, error) {
+	segments, definite, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := decodeOrderedJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("body is not valid JSON: %w", err)
+	}
+
+	nodes := []interface{}{root}
+	for _, seg := range segments {
+		nodes = seg.apply(nodes)
+	}
+
+	if definite {
+		if len(nodes) == 0 {
+			return nil, fmt.Errorf("no match for %s", strings.TrimSpace(expr))
+		}
+		return nodes[0], nil
+	}
+	if nodes == nil {
+		nodes = []interface{}{}
+	}
+	return nodes, nil
+}
+
+// jsonObject is a JSON object keeping its keys in document order
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON encodes the object with its keys in document order
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalJSONValue(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalJSONValue encodes v without escaping HTML characters
+func marshalJSONValue(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// decodeOrderedJSON decodes a JSON document with objects as *jsonObject and numbers
+// as json.Number, so results render as the document wrote them
+func decodeOrderedJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the document")
+	}
+	return value, nil
+}
+
+// decodeOrderedValue decodes the next value of decoder
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := &jsonObject{values: make(map[string]interface{})}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyToken.(string)
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := object.values[key]; !ok {
+				object.keys = append(object.keys, key)
+			}
+			object.values[key] = value
+		}
+		if _, err := decoder.Token(); err != nil { // }
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := decoder.Token(); err != nil { // ]
+			return nil, err
+		}
+		return array, nil
+	}
+	return token, nil
+}
+
+// FormatJSONValue renders a query result as indented JSON
+func FormatJSONValue(v interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// JSONValueText renders a query result as plain text: strings unquoted, anything else as compact JSON
+func JSONValueText(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// jsonPathSegment selects children of each input node
+type jsonPathSegment struct {
+	recursive bool           // ".." - apply to the node and all its descendants
+	wildcard  bool           // "*"
+	names     []string       // Object keys
+	indexes   []int          // Array indexes (negative counts from the end)
+	slice     *jsonPathSlice // Array slice
+	filter    *jsonPathFilter
+}
+
+type jsonPathSlice struct {
+	start, end       int
+	hasStart, hasEnd bool
+}
+
+// jsonPathFilter holds the "||" alternatives of a filter, each a "&&" group of conditions
+type jsonPathFilter struct {
+	anyOf [][]jsonPathCondition
+}
+
+// jsonPathCondition is a comparison, or an existence check, of a path relative to "@"
+type jsonPathCondition struct {
+	path    []jsonPathSegment // Relative path from "@"
+	op      string            // Empty for existence checks
+	literal interface{}
+}
+
+// parseJSONPath splits an expression into segments and reports whether it is definite
+func parseJSONPath(expr string) ([]jsonPathSegment, bool, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case expr == "" || expr == "$":
+		return nil, true, nil
+	case strings.HasPrefix(expr, "$"):
+		expr = expr[1:]
+	case !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "["):
+		// Allow "data.items[0]" as a shorthand for "$.data.items[0]"
+		expr = "." + expr
+	}
+
+	p := &jsonPathParser{src: expr}
+	segments, err := p.parseSegments(false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	definite := true
+	for _, seg := range segments {
+		if seg.recursive || seg.wildcard || seg.slice != nil || seg.filter != nil || len(seg.names)+len(seg.indexes) > 1 {
+			definite = false
+		}
+	}
+	return segments, definite, nil
+}
+
+// jsonPathParser is a cursor over the expression source
+type jsonPathParser struct {
+	src string
+	pos int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid JSONPath at position %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// parseSegments parses segments until the end of input, or until a filter
+// operator / closing parenthesis when inFilter is set
+func (p *jsonPathParser) parseSegments(inFilter bool) ([]jsonPathSegment, error) {
+	var segments []jsonPathSegment
+	for p.pos < len(p.src) {
+		switch p.peek() {
+		case '.':
+			p.pos++
+			recursive := false
+			if p.peek() == '.' {
+				p.pos++
+				recursive = true
+			}
+			if p.peek() == '[' {
+				seg, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.recursive = recursive
+				segments = append(segments, seg)
+				continue
+			}
+			if p.peek() == '*' {
+				p.pos++
+				segments = append(segments, jsonPathSegment{recursive: recursive, wildcard: true})
+				continue
+			}
+			name := p.parseName()
+			if name == "" {
+				return nil, p.errorf("expected a property name")
+			}
+			segments = append(segments, jsonPathSegment{recursive: recursive, names: []string{name}})
+		case '[':
+			seg, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		default:
+			if inFilter {
+				return segments, nil
+			}
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+	}
+	return segments, nil
+}
+
+// parseName reads a dot-notation property name
+func (p *jsonPathParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(".[]()=!<>&| ", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parseBracket parses "[...]" starting at the opening bracket
+func (p *jsonPathParser) parseBracket() (jsonPathSegment, error) {
+	var seg jsonPathSegment
+	p.pos++ // [
+	p.skipSpaces()
+
+	switch {
+	case p.peek() == '*':
+		p.pos++
+		seg.wildcard = true
+	case p.peek() == '?':
+		filter, err := p.parseFilter()
+		if err != nil {
+			return seg, err
+		}
+		seg.filter = filter
+	case p.peek() == '\'' || p.peek() == '"':
+		for {
+			name, err := p.parseQuoted()
+			if err != nil {
+				return seg, err
+			}
+			seg.names = append(seg.names, name)
+			p.skipSpaces()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+			p.skipSpaces()
+		}
+	default:
+		end := strings.IndexByte(p.src[p.pos:], ']')
+		if end < 0 {
+			return seg, p.errorf("missing ]")
+		}
+		content := strings.TrimSpace(p.src[p.pos : p.pos+end])
+		if err := seg.parseIndexes(content); err != nil {
+			return seg, p.errorf("%v", err)
+		}
+		p.pos += end
+	}
+
+	p.skipSpaces()
+	if p.peek() != ']' {
+		return seg, p.errorf("missing ]")
+	}
+	p.pos++
+	return seg, nil
+}
+
+// parseIndexes parses "0", "-1", "0,2" or "1:3" bracket content
+func (seg *jsonPathSegment) parseIndexes(content string) error {
+	if strings.Contains(content, ":") {
+		parts := strings.Split(content, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("unsupported slice %q (steps are not supported)", content)
+		}
+		slice := &jsonPathSlice{}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return fmt.Errorf("invalid slice bound %q", part)
+			}
+			if i == 0 {
+				slice.start, slice.hasStart = n, true
+			} else {
+				slice.end, slice.hasEnd = n, true
+			}
+		}
+		seg.slice = slice
+		return nil
+	}
+
+	for _, part := range strings.Split(content, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("invalid index %q", part)
+		}
+		seg.indexes = append(seg.indexes, n)
+	}
+	return nil
+}
+
+// parseQuoted reads a single- or double-quoted string
+func (p *jsonPathParser) parseQuoted() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			sb.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseFilter parses "?(condition && condition || ...)" (parentheses optional); "&&"
+// binds tighter than "||"
+func (p *jsonPathParser) parseFilter() (*jsonPathFilter, error) {
+	p.pos++ // ?
+	p.skipSpaces()
+	parens := p.peek() == '('
+	if parens {
+		p.pos++
+		p.skipSpaces()
+	}
+
+	filter := &jsonPathFilter{anyOf: [][]jsonPathCondition{nil}}
+	for {
+		condition, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		last := len(filter.anyOf) - 1
+		filter.anyOf[last] = append(filter.anyOf[last], condition)
+
+		p.skipSpaces()
+		if strings.HasPrefix(p.src[p.pos:], "&&") {
+			p.pos += 2
+			p.skipSpaces()
+			continue
+		}
+		if strings.HasPrefix(p.src[p.pos:], "||") {
+			p.pos += 2
+			p.skipSpaces()
+			filter.anyOf = append(filter.anyOf, nil)
+			continue
+		}
+		break
+	}
+
+	if parens {
+		if p.peek() != ')' {
+			return nil, p.unexpectedInFilter("missing )")
+		}
+		p.pos++
+	} else if p.peek() != ']' {
+		return nil, p.unexpectedInFilter("missing ]")
+	}
+	return filter, nil
+}
+
+// parseCondition parses "@.path", or "@.path op literal"
+func (p *jsonPathParser) parseCondition() (jsonPathCondition, error) {
+	var condition jsonPathCondition
+	if p.peek() != '@' {
+		return condition, p.errorf("filters must start with @")
+	}
+	p.pos++
+
+	path, err := p.parseSegments(true)
+	if err != nil {
+		return condition, err
+	}
+	condition.path = path
+
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			condition.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if condition.op == "" {
+		return condition, nil
+	}
+	p.skipSpaces()
+	literal, err := p.parseLiteral()
+	if err != nil {
+		return condition, err
+	}
+	condition.literal = literal
+	return condition, nil
+}
+
+// unexpectedInFilter reports what follows a filter condition: an operator this engine
+// does not support, or else missing
+func (p *jsonPathParser) unexpectedInFilter(missing string) error {
+	rest := p.src[p.pos:]
+	if rest == "" {
+		return p.errorf("%s", missing)
+	}
+	token := rest
+	if end := strings.IndexAny(rest, " ()]'\""); end > 0 {
+		token = rest[:end]
+	}
+	if strings.ContainsAny(token[:1], "=!<>~&|") || isWord(token) {
+		return p.errorf("unsupported operator %q", token)
+	}
+	return p.errorf("%s", missing)
+}
+
+// isWord reports whether s is a word, such as the "in" or "nin" filter operators
+func isWord(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// parseLiteral reads a string, number, true, false or null
+func (p *jsonPathParser) parseLiteral() (interface{}, error) {
+	if c := p.peek(); c == '\'' || c == '"' {
+		return p.parseQuoted()
+	}
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" )]&|", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	token := p.src[start:p.pos]
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return nil, p.errorf("invalid literal %q", token)
+	}
+	return n, nil
+}
+
+// apply selects the children matched by the segment for every input node
+func (seg jsonPathSegment) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		if seg.recursive {
+			for _, descendant := range descendants(node) {
+				out = append(out, seg.selectFrom(descendant)...)
+			}
+			continue
+		}
+		out = append(out, seg.selectFrom(node)...)
+	}
+	return out
+}
+
+// selectFrom returns the children of node matched by the segment
+func (seg jsonPathSegment) selectFrom(node interface{}) []interface{} {
+	var out []interface{}
+	switch v := node.(type) {
+	case *jsonObject:
+		switch {
+		case seg.wildcard || seg.filter != nil:
+			for _, key := range v.keys {
+				if seg.filter == nil || seg.filter.matches(v.values[key]) {
+					out = append(out, v.values[key])
+				}
+			}
+		default:
+			for _, name := range seg.names {
+				if child, ok := v.values[name]; ok {
+					out = append(out, child)
+				}
+			}
+		}
+	case []interface{}:
+		switch {
+		case seg.wildcard || seg.filter != nil:
+			for _, child := range v {
+				if seg.filter == nil || seg.filter.matches(child) {
+					out = append(out, child)
+				}
+			}
+		case seg.slice != nil:
+			start, end := seg.slice.bounds(len(v))
+			for i := start; i < end; i++ {
+				out = append(out, v[i])
+			}
+		default:
+			for _, index := range seg.indexes {
+				if index < 0 {
+					index += len(v)
+				}
+				if index >= 0 && index < len(v) {
+					out = append(out, v[index])
+				}
+			}
+		}
+	}
+	return out
+}
+
+// bounds resolves the slice against an array length
+func (s *jsonPathSlice) bounds(length int) (int, int) {
+	clamp := func(n int) int {
+		if n < 0 {
+			n += length
+		}
+		return min(max(n, 0), length)
+	}
+	start, end := 0, length
+	if s.hasStart {
+		start = clamp(s.start)
+	}
+	if s.hasEnd {
+		end = clamp(s.end)
+	}
+	return start, max(start, end)
+}
+
+// descendants returns node and all nested values, depth-first
+func descendants(node interface{}) []interface{} {
+	out := []interface{}{node}
+	switch v := node.(type) {
+	case *jsonObject:
+		for _, key := range v.keys {
+			out = append(out, descendants(v.values[key])...)
+		}
+	case []interface{}:
+		for _, child := range v {
+			out = append(out, descendants(child)...)
+		}
+	}
+	return out
+}
+
+// matches evaluates the filter against a candidate node
+func (f *jsonPathFilter) matches(node interface{}) bool {
+	for _, allOf := range f.anyOf {
+		matched := true
+		for _, condition := range allOf {
+			if !condition.matches(node) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matches evaluates the condition against a candidate node
+func (c jsonPathCondition) matches(node interface{}) bool {
+	values := []interface{}{node}
+	for _, seg := range c.path {
+		values = seg.apply(values)
+	}
+	if len(values) == 0 {
+		return false
+	}
+	if c.op == "" {
+		return true
+	}
+	return compareJSON(values[0], c.op, c.literal)
+}
+
+// compareJSON compares a document value with a filter literal
+func compareJSON(value interface{}, op string, literal interface{}) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		value = f
+	}
+
+	switch l := literal.(type) {
+	case float64:
+		v, ok := value.(float64)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return v == l
+		case "!=":
+			return v != l
+		case "<":
+			return v < l
+		case "<=":
+			return v <= l
+		case ">":
+			return v > l
+		case ">=":
+			return v >= l
+		}
+	case string:
+		v, ok := value.(string)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return v == l
+		case "!=":
+			return v != l
+		case "<":
+			return v < l
+		case "<=":
+			return v <= l
+		case ">":
+			return v > l
+		case ">=":
+			return v >= l
+		}
+	default:
+		// true, false, null: equality only (objects and arrays never equal a literal)
+		switch value.(type) {
+		case bool, nil:
+		default:
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return value == literal
+		case "!=":
+			return value != literal
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+const jsonPathDoc = `{
+  "store": {
+    "name": "Books & Co",
+    "open": true,
+    "books": [
+      {"title": "Dune", "author": "Herbert", "price": 9.5, "tags": ["scifi"]},
+      {"title": "Emma", "author": "Austen", "price": 4},
+      {"title": "Ubik", "author": "Dick", "price": 12, "isbn": "0-553"}
+    ]
+  },
+  "total": 3
+}`
+
+func TestQueryJSONPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    string // JSONValueText of the result
+		wantErr bool
+	}{
+		{name: "root", expr: "$", want: ""},
+		{name: "property", expr: "$.store.name", want: "Books & Co"},
+		{name: "shorthand without root", expr: "store.name", want: "Books & Co"},
+		{name: "bracket property", expr: "$['store']['open']", want: "true"},
+		{name: "number keeps formatting", expr: "$.store.books[0].price", want: "9.5"},
+		{name: "index", expr: "$.store.books[1].title", want: "Emma"},
+		{name: "negative index", expr: "$.store.books[-1].title", want: "Ubik"},
+		{name: "object", expr: "$.store.books[0].tags", want: `["scifi"]`},
+		{name: "wildcard", expr: "$.store.books[*].author", want: `["Herbert","Austen","Dick"]`},
+		{name: "dot wildcard in document order", expr: "$.store.books[1].*", want: `["Emma","Austen",4]`},
+		{name: "object in document order", expr: "$.store.books[2]", want: `{"title":"Ubik","author":"Dick","price":12,"isbn":"0-553"}`},
+		{name: "index list", expr: "$.store.books[0,2].title", want: `["Dune","Ubik"]`},
+		{name: "slice", expr: "$.store.books[1:].title", want: `["Emma","Ubik"]`},
+		{name: "slice end", expr: "$.store.books[:-1].title", want: `["Dune","Emma"]`},
+		{name: "recursive", expr: "$..title", want: `["Dune","Emma","Ubik"]`},
+		{name: "recursive index", expr: "$..books[0].title", want: `["Dune"]`},
+		{name: "filter number", expr: "$.store.books[?(@.price > 5)].title", want: `["Dune","Ubik"]`},
+		{name: "filter string", expr: `$.store.books[?(@.author == "Austen")].price`, want: `[4]`},
+		{name: "filter single quotes no parens", expr: "$.store.books[?@.author != 'Austen'].title", want: `["Dune","Ubik"]`},
+		{name: "filter existence", expr: "$.store.books[?(@.isbn)].title", want: `["Ubik"]`},
+		{name: "filter and", expr: "$.store.books[?(@.price > 5 && @.author != 'Dick')].title", want: `["Dune"]`},
+		{name: "filter or", expr: "$.store.books[?(@.price < 5 || @.isbn)].title", want: `["Emma","Ubik"]`},
+		{name: "filter and binds tighter", expr: "$.store.books[?(@.isbn || @.price > 5 && @.tags)].title", want: `["Dune","Ubik"]`},
+		{name: "filter literal with operator", expr: `$.store.books[?(@.title == "a && b")].title`, want: `[]`},
+		{name: "no match in list", expr: "$.store.books[?(@.price > 100)]", want: `[]`},
+		{name: "no match", expr: "$.store.missing", wantErr: true},
+		{name: "index out of range", expr: "$.store.books[9]", wantErr: true},
+		{name: "unterminated bracket", expr: "$.store[0", wantErr: true},
+		{name: "invalid index", expr: "$.store.books[x]", wantErr: true},
+		{name: "invalid filter", expr: "$.store.books[?(price > 1)]", wantErr: true},
+		{name: "slice step", expr: "$.store.books[0:2:1]", wantErr: true},
+		{name: "missing condition after and", expr: "$.store.books[?(@.price > 5 &&)]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryJSONPath([]byte(jsonPathDoc), tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryJSONPath(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if tt.wantErr || tt.want == "" {
+				return
+			}
+			if text := JSONValueText(got); text != tt.want {
+				t.Errorf("QueryJSONPath(%q) = %s, want %s", tt.expr, text, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryJSONPath_UnsupportedOperator(t *testing.T) {
+	for _, expr := range []string{
+		"$.store.books[?(@.title =~ /D.*/)]",
+		"$.store.books[?(@.author in ['Dick'])]",
+		"$.store.books[?(@.price > 5 & @.isbn)]",
+	} {
+		_, err := QueryJSONPath([]byte(jsonPathDoc), expr)
+		if err == nil || !strings.Contains(err.Error(), "unsupported operator") {
+			t.Errorf("QueryJSONPath(%q) error = %v, want unsupported operator", expr, err)
+		}
+	}
+}
+
+func TestQueryJSONPath_InvalidBody(t *testing.T) {
+	if _, err := QueryJSONPath([]byte("<html>"), "$.a"); err == nil {
+		t.Error("expected error for non-JSON body")
+	}
+}
+
+func TestFormatJSONValue(t *testing.T) {
+	got := FormatJSONValue(map[string]interface{}{"a": []interface{}{1, "<b>"}})
+	want := "{\n  \"a\": [\n    1,\n    \"<b>\"\n  ]\n}"
+	if got != want {
+		t.Errorf("FormatJSONValue() = %q, want %q", got, want)
+	}
+}
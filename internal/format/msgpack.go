@@ -0,0 +1,328 @@
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// msgpackTimestampExt is the extension type reserved for timestamps
+const msgpackTimestampExt = -1
+
+// EncodeMsgpack encodes a JSON-like value (nil, bool, numbers, string, []interface{},
+// map[string]interface{}) as MessagePack
+func EncodeMsgpack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeMsgpack(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeMsgpack(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if val {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case json.Number:
+		n, err := jsonNumber(val)
+		if err != nil {
+			return err
+		}
+		return encodeMsgpack(buf, n)
+	case int:
+		writeMsgpackInt(buf, int64(val))
+	case int64:
+		writeMsgpackInt(buf, val)
+	case uint64:
+		writeMsgpackUint(buf, val)
+	case float64:
+		buf.WriteByte(0xcb)
+		writeBigEndian(buf, math.Float64bits(val), 8)
+	case string:
+		writeMsgpackLength(buf, len(val), 0xa0, 31, 0xd9, 0xda, 0xdb)
+		buf.WriteString(val)
+	case []byte:
+		writeMsgpackLength(buf, len(val), 0, -1, 0xc4, 0xc5, 0xc6)
+		buf.Write(val)
+	case []interface{}:
+		writeMsgpackLength(buf, len(val), 0x90, 15, 0, 0xdc, 0xdd)
+		for _, item := range val {
+			if err := encodeMsgpack(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		writeMsgpackLength(buf, len(val), 0x80, 15, 0, 0xde, 0xdf)
+		for _, key := range sortedKeys(val) {
+			if err := encodeMsgpack(buf, key); err != nil {
+				return err
+			}
+			if err := encodeMsgpack(buf, val[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+	return nil
+}
+
+// writeMsgpackLength writes a length header using the fix form when n <= fixMax,
+// otherwise the smallest of the 8/16/32-bit forms (a zero code means the form doesn't exist)
+func writeMsgpackLength(buf *bytes.Buffer, n int, fixCode byte, fixMax int, code8, code16, code32 byte) {
+	switch {
+	case n <= fixMax:
+		buf.WriteByte(fixCode | byte(n))
+	case code8 != 0 && n <= math.MaxUint8:
+		buf.WriteByte(code8)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(code16)
+		writeBigEndian(buf, uint64(n), 2)
+	default:
+		buf.WriteByte(code32)
+		writeBigEndian(buf, uint64(n), 4)
+	}
+}
+
+func writeMsgpackInt(buf *bytes.Buffer, n int64) {
+	switch {
+	case n >= 0:
+		writeMsgpackUint(buf, uint64(n))
+	case n >= -32:
+		buf.WriteByte(byte(n))
+	case n >= math.MinInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(n))
+	case n >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		writeBigEndian(buf, uint64(n), 2)
+	case n >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		writeBigEndian(buf, uint64(n), 4)
+	default:
+		buf.WriteByte(0xd3)
+		writeBigEndian(buf, uint64(n), 8)
+	}
+}
+
+func writeMsgpackUint(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n <= 0x7f:
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		writeBigEndian(buf, n, 2)
+	case n <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		writeBigEndian(buf, n, 4)
+	default:
+		buf.WriteByte(0xcf)
+		writeBigEndian(buf, n, 8)
+	}
+}
+
+// writeBigEndian writes the low size bytes of n in network byte order
+func writeBigEndian(buf *bytes.Buffer, n uint64, size int) {
+	for i := size - 1; i >= 0; i-- {
+		buf.WriteByte(byte(n >> (8 * uint(i))))
+	}
+}
+
+// DecodeMsgpack decodes a single MessagePack value into JSON-compatible Go values.
+// Binary data becomes a base64 string, timestamps become RFC 3339 strings and other
+// extension types become {"type": n, "data": base64}.
+func DecodeMsgpack(data []byte) (interface{}, error) {
+	r := &byteReader{data: data}
+	v, err := decodeMsgpackValue(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MessagePack: %w", err)
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("invalid MessagePack: %d trailing bytes", r.remaining())
+	}
+	return v, nil
+}
+
+func decodeMsgpackValue(r *byteReader, depth int) (interface{}, error) {
+	if depth > maxDecodeDepth {
+		return nil, errTooDeep
+	}
+	c, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0xa0 && c <= 0xbf:
+		return decodeMsgpackString(r, uint64(c&0x1f))
+	case c >= 0x90 && c <= 0x9f:
+		return decodeMsgpackArray(r, uint64(c&0x0f), depth)
+	case c >= 0x80 && c <= 0x8f:
+		return decodeMsgpackMap(r, uint64(c&0x0f), depth)
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return r.readUint(1 << (c - 0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err := r.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign-extend from size bytes
+		shift := uint(64 - 8*size)
+		return int64(u<<shift) >> shift, nil
+	case 0xca:
+		u, err := r.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return jsonFloat(float64(math.Float32frombits(uint32(u)))), nil
+	case 0xcb:
+		u, err := r.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return jsonFloat(math.Float64frombits(u)), nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.readUint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackString(r, n)
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.readUint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.readN(n)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case 0xdc, 0xdd:
+		n, err := r.readUint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackArray(r, n, depth)
+	case 0xde, 0xdf:
+		n, err := r.readUint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackMap(r, n, depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return decodeMsgpackExt(r, uint64(1)<<(c-0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.readUint(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackExt(r, n)
+	default:
+		return nil, fmt.Errorf("unknown type byte 0x%02x at offset %d", c, r.pos-1)
+	}
+}
+
+func decodeMsgpackString(r *byteReader, n uint64) (interface{}, error) {
+	b, err := r.readN(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func decodeMsgpackArray(r *byteReader, n uint64, depth int) (interface{}, error) {
+	if err := r.checkLength(n); err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, 0, n)
+	for i := uint64(0); i < n; i++ {
+		item, err := decodeMsgpackValue(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func decodeMsgpackMap(r *byteReader, n uint64, depth int) (interface{}, error) {
+	if err := r.checkLength(n); err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		key, err := decodeMsgpackValue(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeMsgpackValue(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(key)] = value
+	}
+	return m, nil
+}
+
+func decodeMsgpackExt(r *byteReader, n uint64) (interface{}, error) {
+	t, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.readN(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(t) == msgpackTimestampExt {
+		var sec int64
+		var nsec uint32
+		switch len(data) {
+		case 4:
+			sec = int64(binary.BigEndian.Uint32(data))
+		case 8:
+			v := binary.BigEndian.Uint64(data)
+			nsec = uint32(v >> 34)
+			sec = int64(v & 0x3ffffffff)
+		case 12:
+			nsec = binary.BigEndian.Uint32(data[:4])
+			sec = int64(binary.BigEndian.Uint64(data[4:]))
+		default:
+			return nil, fmt.Errorf("invalid timestamp length %d", len(data))
+		}
+		return time.Unix(sec, int64(nsec)).UTC().Format(time.RFC3339Nano), nil
+	}
+
+	return map[string]interface{}{
+		"type": int64(int8(t)),
+		"data": base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
@@ -0,0 +1,129 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// errNotObject is returned when a table row is not a JSON object
+var errNotObject = errors.New("not a JSON object")
+
+// TableData represents tabular data extracted from a response body
+type TableData struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// ParseJSONTable converts a JSON array of flat objects into table data.
+// Columns are collected from object keys in first-seen order. Returns false
+// when the body is not a non-empty array of objects with scalar values.
+func ParseJSONTable(data []byte) (*TableData, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, false
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(trimmed, &items); err != nil || len(items) == 0 {
+		return nil, false
+	}
+
+	table := &TableData{}
+	columnIndex := make(map[string]int)
+	records := make([]map[string]string, 0, len(items))
+
+	for _, item := range items {
+		keys, err := objectKeys(item)
+		if err != nil {
+			return nil, false
+		}
+
+		var obj map[string]interface{}
+		if err := decodeNumbers(item, &obj); err != nil || obj == nil {
+			return nil, false
+		}
+
+		record := make(map[string]string, len(obj))
+		for _, key := range keys {
+			cell, ok := scalarString(obj[key])
+			if !ok {
+				return nil, false
+			}
+			record[key] = cell
+			if _, seen := columnIndex[key]; !seen {
+				columnIndex[key] = len(table.Columns)
+				table.Columns = append(table.Columns, key)
+			}
+		}
+		records = append(records, record)
+	}
+
+	if len(table.Columns) == 0 {
+		return nil, false
+	}
+
+	table.Rows = make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(table.Columns))
+		for j, col := range table.Columns {
+			row[j] = record[col]
+		}
+		table.Rows[i] = row
+	}
+
+	return table, true
+}
+
+// objectKeys returns the keys of a JSON object in document order
+func objectKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errNotObject
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		keys = append(keys, key)
+
+		// Skip the value
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// decodeNumbers unmarshals JSON keeping numbers as json.Number
+func decodeNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// scalarString renders a scalar JSON value as a table cell
+func scalarString(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return val, true
+	case json.Number:
+		return val.String(), true
+	case bool:
+		return strconv.FormatBool(val), true
+	default:
+		return "", false
+	}
+}
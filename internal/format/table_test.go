@@ -0,0 +1,81 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONTable(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantOK      bool
+		wantColumns []string
+		wantRows    [][]string
+	}{
+		{
+			name:        "array of flat objects",
+			body:        `[{"id": 1, "name": "Alice", "active": true}, {"id": 2, "name": "Bob", "active": false}]`,
+			wantOK:      true,
+			wantColumns: []string{"id", "name", "active"},
+			wantRows: [][]string{
+				{"1", "Alice", "true"},
+				{"2", "Bob", "false"},
+			},
+		},
+		{
+			name:        "heterogeneous keys keep first-seen order",
+			body:        `[{"b": "x"}, {"a": 1.5, "b": "y"}, {"c": null}]`,
+			wantOK:      true,
+			wantColumns: []string{"b", "a", "c"},
+			wantRows: [][]string{
+				{"x", "", ""},
+				{"y", "1.5", ""},
+				{"", "", "null"},
+			},
+		},
+		{
+			name:   "nested object is rejected",
+			body:   `[{"id": 1, "meta": {"k": "v"}}]`,
+			wantOK: false,
+		},
+		{
+			name:   "array of scalars is rejected",
+			body:   `[1, 2, 3]`,
+			wantOK: false,
+		},
+		{
+			name:   "empty array is rejected",
+			body:   `[]`,
+			wantOK: false,
+		},
+		{
+			name:   "object is rejected",
+			body:   `{"id": 1}`,
+			wantOK: false,
+		},
+		{
+			name:   "invalid JSON is rejected",
+			body:   `[{"id": 1}`,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, ok := ParseJSONTable([]byte(tt.body))
+			if ok != tt.wantOK {
+				t.Fatalf("ParseJSONTable() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(table.Columns, tt.wantColumns) {
+				t.Errorf("Columns = %v, want %v", table.Columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(table.Rows, tt.wantRows) {
+				t.Errorf("Rows = %v, want %v", table.Rows, tt.wantRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,546 @@
+package format
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// xmlNodeKind is the kind of a node of a parsed XML document
+type xmlNodeKind int
+
+const (
+	xmlElement xmlNodeKind = iota
+	xmlText
+	xmlAttr
+)
+
+// xmlNode is an element, text or attribute of a parsed XML document.
+// The document itself is an element without name.
+type xmlNode struct {
+	kind     xmlNodeKind
+	name     string // Local name of elements and attributes
+	space    string // Namespace of elements and attributes: its URI, or its prefix when undeclared
+	value    string // Text of text nodes and attributes
+	attrs    []*xmlNode
+	children []*xmlNode
+	parent   *xmlNode
+	order    int // Position in document order
+}
+
+// text returns the string value of a node: the text it contains for elements
+func (n *xmlNode) text() string {
+	if n.kind != xmlElement {
+		return n.value
+	}
+	var b strings.Builder
+	var walk func(*xmlNode)
+	walk = func(node *xmlNode) {
+		for _, child := range node.children {
+			if child.kind == xmlText {
+				b.WriteString(child.value)
+			} else {
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(b.String())
+}
+
+// descendants returns the elements and texts below n in document order
+func (n *xmlNode) descendants() []*xmlNode {
+	var nodes []*xmlNode
+	for _, child := range n.children {
+		nodes = append(nodes, child)
+		if child.kind == xmlElement {
+			nodes = append(nodes, child.descendants()...)
+		}
+	}
+	return nodes
+}
+
+// parseXMLDocument parses an XML document. HTML pages are accepted: unclosed
+// void elements, HTML entities and unquoted attributes are tolerated.
+func parseXMLDocument(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	doc := &xmlNode{kind: xmlElement}
+	current := doc
+	order := 0
+	next := func() int {
+		order++
+		return order
+	}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("body is not valid XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlNode{kind: xmlElement, name: t.Name.Local, space: t.Name.Space, parent: current, order: next()}
+			for _, attr := range t.Attr {
+				element.attrs = append(element.attrs, &xmlNode{kind: xmlAttr, name: attr.Name.Local, space: attr.Name.Space, value: attr.Value, parent: element, order: next()})
+			}
+			current.children = append(current.children, element)
+			current = element
+		case xml.EndElement:
+			if current.parent != nil {
+				current = current.parent
+			}
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				current.children = append(current.children, &xmlNode{kind: xmlText, value: string(t), parent: current, order: next()})
+			}
+		}
+	}
+	for _, child := range doc.children {
+		if child.kind == xmlElement {
+			return doc, nil
+		}
+	}
+	return nil, fmt.Errorf("body is not valid XML: no root element")
+}
+
+// QueryXPath evaluates an XPath expression against an XML (or HTML) document and
+// returns the string values of the matched nodes: the text of elements, the value
+// of attributes.
+//
+// Supported syntax: "/" and "//" steps, element names (case-insensitive), "*",
+// "@attr", "@*", "text()", "." and "..", and predicates such as "[1]", "[last()]",
+// "[@id]", "[@id='main']", "[name!='x']", "[text()='Ada']", "[contains(@class,'btn')]"
+// or "[starts-with(@href,'/')]". Names without prefix match in any namespace; a
+// prefixed name matches the namespace the document declares for its prefix.
+// Anything else, such as functions like count() or the <, >, and and or
+// operators, is rejected rather than matching nothing.
+func QueryXPath(data []byte, expr string) ([]string, error) {
+	steps, err := parseXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseXMLDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*xmlNode{doc}
+	for _, step := range steps {
+		nodes = step.apply(nodes)
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, node.text())
+	}
+	return values, nil
+}
+
+// xpathStep is one step of a location path
+type xpathStep struct {
+	descendant bool   // Preceded by "//"
+	test       string // Node test: name, "*", "@name", "@*", "text()", "." or ".."
+	predicates []xpathPredicate
+}
+
+// xpathPredicateKind is the kind of a predicate
+type xpathPredicateKind int
+
+const (
+	xpathPosition   xpathPredicateKind = iota // [2]
+	xpathLast                                 // [last()]
+	xpathExists                               // [@id], [name]
+	xpathEquals                               // [@id='main']
+	xpathNotEquals                            // [@id!='main']
+	xpathContains                             // [contains(@class,'btn')]
+	xpathStartsWith                           // [starts-with(@href,'/')]
+)
+
+// xpathPredicate is a parsed predicate
+type xpathPredicate struct {
+	kind     xpathPredicateKind
+	position int
+	operand  string // Operand of the test: "@attr", "@*", name, "*", "text()" or "."
+	literal  string
+	number   bool // literal is a number, compared numerically
+}
+
+// xpathName matches element and attribute names, with an optional namespace prefix
+var xpathName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*(:[A-Za-z_][A-Za-z0-9_.-]*)?$`)
+
+// validNodeTest reports whether test is a node test QueryXPath supports
+func validNodeTest(test string) bool {
+	switch test {
+	case ".", "..", "*", "@*", "text()":
+		return true
+	}
+	return xpathName.MatchString(strings.TrimPrefix(test, "@"))
+}
+
+// parseXPath splits an expression into steps
+func parseXPath(expr string) ([]xpathStep, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty XPath expression")
+	}
+
+	var steps []xpathStep
+	i := 0
+	for i < len(expr) {
+		step := xpathStep{}
+		if strings.HasPrefix(expr[i:], "//") {
+			step.descendant = true
+			i += 2
+		} else if expr[i] == '/' {
+			i++
+		} else if len(steps) > 0 {
+			return nil, fmt.Errorf("unexpected %q at position %d", expr[i], i)
+		}
+
+		start := i
+		for i < len(expr) && expr[i] != '/' && expr[i] != '[' {
+			i++
+		}
+		step.test = strings.TrimSpace(expr[start:i])
+		if step.test == "" {
+			if i >= len(expr) && len(steps) == 0 && !step.descendant {
+				// "/" selects the document
+				return steps, nil
+			}
+			return nil, fmt.Errorf("missing node test at position %d", start)
+		}
+		if !validNodeTest(step.test) {
+			return nil, fmt.Errorf("unsupported XPath node test %q at position %d", step.test, start)
+		}
+
+		for i < len(expr) && expr[i] == '[' {
+			end, err := closingBracket(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			predicate, err := parseXPathPredicate(strings.TrimSpace(expr[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			step.predicates = append(step.predicates, predicate)
+			i = end + 1
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// parseXPathPredicate parses the text between the brackets of a predicate
+func parseXPathPredicate(text string) (xpathPredicate, error) {
+	unsupported := func() (xpathPredicate, error) {
+		return xpathPredicate{}, fmt.Errorf("unsupported XPath predicate [%s]", text)
+	}
+	if text == "last()" {
+		return xpathPredicate{kind: xpathLast}, nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		if n < 1 {
+			return xpathPredicate{}, fmt.Errorf("invalid XPath position [%s]: positions start at 1", text)
+		}
+		return xpathPredicate{kind: xpathPosition, position: n}, nil
+	}
+
+	// Operators are looked for outside string literals only
+	masked, err := maskXPathLiterals(text)
+	if err != nil {
+		return xpathPredicate{}, err
+	}
+	if i := strings.IndexAny(masked, "<>|+"); i >= 0 {
+		return xpathPredicate{}, fmt.Errorf("unsupported operator %q in XPath predicate [%s]", masked[i:i+1], text)
+	}
+	for _, word := range strings.FieldsFunc(masked, func(r rune) bool { return r == ' ' || r == '(' || r == ')' || r == ',' }) {
+		switch word {
+		case "and", "or", "div", "mod":
+			return xpathPredicate{}, fmt.Errorf("unsupported operator %q in XPath predicate [%s]", word, text)
+		}
+	}
+
+	for fn, kind := range map[string]xpathPredicateKind{"contains": xpathContains, "starts-with": xpathStartsWith} {
+		if !strings.HasPrefix(masked, fn+"(") || !strings.HasSuffix(masked, ")") {
+			continue
+		}
+		args := masked[len(fn)+1 : len(masked)-1]
+		comma := strings.IndexByte(args, ',')
+		if comma < 0 {
+			return unsupported()
+		}
+		operand := strings.TrimSpace(text[len(fn)+1 : len(fn)+1+comma])
+		literal, ok := xpathStringLiteral(text[len(fn)+2+comma : len(text)-1])
+		if !validOperand(operand) || !ok {
+			return unsupported()
+		}
+		return xpathPredicate{kind: kind, operand: operand, literal: literal}, nil
+	}
+
+	if i := strings.IndexByte(masked, '='); i >= 0 {
+		predicate := xpathPredicate{kind: xpathEquals}
+		end := i
+		if i > 0 && masked[i-1] == '!' {
+			predicate.kind = xpathNotEquals
+			end = i - 1
+		}
+		predicate.operand = strings.TrimSpace(text[:end])
+		right := strings.TrimSpace(text[i+1:])
+		literal, ok := xpathStringLiteral(right)
+		if !ok {
+			if _, err := strconv.ParseFloat(right, 64); err != nil {
+				return unsupported()
+			}
+			literal, predicate.number = right, true
+		}
+		if !validOperand(predicate.operand) {
+			return unsupported()
+		}
+		predicate.literal = literal
+		return predicate, nil
+	}
+
+	if !validOperand(text) {
+		return unsupported()
+	}
+	return xpathPredicate{kind: xpathExists, operand: text}, nil
+}
+
+// validOperand reports whether operand is a predicate operand QueryXPath supports
+func validOperand(operand string) bool {
+	return operand != ".." && validNodeTest(operand)
+}
+
+// maskXPathLiterals returns text with the content of its string literals replaced by
+// underscores, so operators inside them are not taken as operators
+func maskXPathLiterals(text string) (string, error) {
+	masked := []byte(text)
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		c := masked[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				masked[i] = '_'
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		}
+	}
+	if quote != 0 {
+		return "", fmt.Errorf("unterminated string in XPath predicate [%s]", text)
+	}
+	return string(masked), nil
+}
+
+// xpathStringLiteral returns the content of a quoted string literal
+func xpathStringLiteral(literal string) (string, bool) {
+	literal = strings.TrimSpace(literal)
+	if len(literal) >= 2 && (literal[0] == '\'' || literal[0] == '"') && literal[len(literal)-1] == literal[0] &&
+		!strings.ContainsRune(literal[1:len(literal)-1], rune(literal[0])) {
+		return literal[1 : len(literal)-1], true
+	}
+	return "", false
+}
+
+// closingBracket returns the index of the "]" closing the "[" at open, skipping quoted strings
+func closingBracket(expr string, open int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := open; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unclosed [ at position %d", open)
+}
+
+// apply returns the nodes selected by the step from each context node, in document order.
+// After "//" the step applies from the context nodes and each of their descendants, so
+// predicates such as [1] select among the children of each parent.
+func (s xpathStep) apply(context []*xmlNode) []*xmlNode {
+	var result []*xmlNode
+	seen := make(map[*xmlNode]bool)
+	for _, node := range context {
+		origins := []*xmlNode{node}
+		if s.descendant {
+			origins = append(origins, node.descendants()...)
+		}
+		for _, origin := range origins {
+			for _, match := range s.filter(s.candidates(origin)) {
+				if !seen[match] {
+					seen[match] = true
+					result = append(result, match)
+				}
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].order < result[j].order })
+	return result
+}
+
+// candidates returns the nodes of the step's axis from node that pass its node test:
+// the node itself, its parent, its attributes or its children
+func (s xpathStep) candidates(node *xmlNode) []*xmlNode {
+	switch s.test {
+	case ".":
+		return []*xmlNode{node}
+	case "..":
+		if node.parent == nil {
+			return nil
+		}
+		return []*xmlNode{node.parent}
+	}
+
+	var nodes []*xmlNode
+	if name, ok := strings.CutPrefix(s.test, "@"); ok {
+		for _, attr := range node.attrs {
+			if name == "*" || matchesName(attr, name) {
+				nodes = append(nodes, attr)
+			}
+		}
+		return nodes
+	}
+
+	for _, n := range node.children {
+		if matchesNodeTest(n, s.test) {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// matchesNodeTest reports whether an element or text node passes a node test
+func matchesNodeTest(n *xmlNode, test string) bool {
+	switch {
+	case test == "text()":
+		return n.kind == xmlText
+	case n.kind != xmlElement:
+		return false
+	case test == "*":
+		return true
+	default:
+		return matchesName(n, test)
+	}
+}
+
+// matchesName reports whether an element or attribute has the name of a node test,
+// case-insensitively. A name without prefix matches in any namespace; soap:Body only
+// matches Body elements of the namespace declared for soap.
+func matchesName(n *xmlNode, test string) bool {
+	prefix, local, ok := strings.Cut(test, ":")
+	if !ok {
+		return strings.EqualFold(n.name, test)
+	}
+	if !strings.EqualFold(n.name, local) {
+		return false
+	}
+	// Undeclared prefixes are kept as the namespace by the decoder
+	return n.space == prefix || n.space == n.namespaceOf(prefix)
+}
+
+// namespaceOf returns the URI declared for prefix on n or its ancestors, if any
+func (n *xmlNode) namespaceOf(prefix string) string {
+	for node := n; node != nil; node = node.parent {
+		for _, attr := range node.attrs {
+			if attr.space == "xmlns" && attr.name == prefix {
+				return attr.value
+			}
+		}
+	}
+	return ""
+}
+
+// filter applies the predicates of the step in order
+func (s xpathStep) filter(nodes []*xmlNode) []*xmlNode {
+	for _, predicate := range s.predicates {
+		var kept []*xmlNode
+		for i, node := range nodes {
+			if evalXPathPredicate(predicate, node, i+1, len(nodes)) {
+				kept = append(kept, node)
+			}
+		}
+		nodes = kept
+	}
+	return nodes
+}
+
+// evalXPathPredicate evaluates a predicate for the node at position (1-based) of size nodes
+func evalXPathPredicate(predicate xpathPredicate, node *xmlNode, position, size int) bool {
+	switch predicate.kind {
+	case xpathLast:
+		return position == size
+	case xpathPosition:
+		return position == predicate.position
+	case xpathExists:
+		return len(xpathOperand(predicate.operand, node)) > 0
+	}
+
+	for _, value := range xpathOperand(predicate.operand, node) {
+		var matched bool
+		switch predicate.kind {
+		case xpathContains:
+			matched = strings.Contains(value, predicate.literal)
+		case xpathStartsWith:
+			matched = strings.HasPrefix(value, predicate.literal)
+		case xpathEquals:
+			matched = xpathEqual(value, predicate)
+		case xpathNotEquals:
+			matched = !xpathEqual(value, predicate)
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// xpathEqual compares a value with the literal of predicate: as numbers for a number
+// literal, as strings otherwise
+func xpathEqual(value string, predicate xpathPredicate) bool {
+	if !predicate.number {
+		return value == predicate.literal
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	want, _ := strconv.ParseFloat(predicate.literal, 64)
+	return err == nil && v == want
+}
+
+// xpathOperand returns the string values a predicate operand selects from node:
+// "@attr", "text()", "." or a child element name
+func xpathOperand(operand string, node *xmlNode) []string {
+	if operand == "." {
+		return []string{node.text()}
+	}
+	var values []string
+	for _, n := range (xpathStep{test: operand}).candidates(node) {
+		values = append(values, n.text())
+	}
+	return values
+}
@@ -0,0 +1,96 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+const xpathDoc = `<?xml version="1.0"?>
+<catalog>
+  <book id="bk1" lang="en"><title>Dune</title><price>9.50</price></book>
+  <book id="bk2" lang="fr"><title>Emma</title><price>4</price></book>
+  <book id="bk3"><title>Ubik</title><price>12</price></book>
+</catalog>`
+
+const xpathHTML = `<!DOCTYPE html>
+<html><head><title>Sign in</title></head>
+<body>
+  <form action="/login"><input type="hidden" name="csrf" value="t0k3n"><br>
+  <input type="text" name="user"></form>
+  <ul><li class="item first">One</li><li class="item">Two &amp; more</li></ul>
+  <ul><li>Three</li></ul>
+</body></html>`
+
+func TestQueryXPath_Unsupported(t *testing.T) {
+	for _, expr := range []string{
+		"count(//book)",
+		"//*[local-name()='book']",
+		"//book[price > 5]",
+		"//book[price < 5]",
+		"//book[@id and @lang]",
+		"//book[@id or @lang]",
+		"//book[position()=1]",
+		"//book[0]",
+		"//book[@lang='en]",
+		"//book[@lang=en]",
+		"//book[contains(@lang)]",
+		"//book[..]",
+		"//book | //title",
+	} {
+		if _, err := QueryXPath([]byte(xpathDoc), expr); err == nil {
+			t.Errorf("QueryXPath(%q) should fail rather than return an empty match", expr)
+		}
+	}
+}
+
+func TestQueryXPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		expr    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "absolute path", doc: xpathDoc, expr: "/catalog/book/title", want: []string{"Dune", "Emma", "Ubik"}},
+		{name: "namespace prefix", doc: `<soap:Envelope xmlns:soap="urn:soap"><soap:Body><m:id xmlns:m="urn:m">7</m:id></soap:Body></soap:Envelope>`, expr: "/soap:Envelope/soap:Body/id", want: []string{"7"}},
+		{name: "prefix of declared namespace", doc: `<r xmlns:a="urn:a" xmlns:b="urn:b"><a:id>1</a:id><b:id>2</b:id></r>`, expr: "//b:id", want: []string{"2"}},
+		{name: "prefix bound to another name", doc: `<r xmlns:x="urn:a"><x:id>1</x:id></r>`, expr: "//a:id", want: []string{}},
+		{name: "descendants", doc: xpathDoc, expr: "//price", want: []string{"9.50", "4", "12"}},
+		{name: "relative path", doc: xpathDoc, expr: "catalog/book[2]/title", want: []string{"Emma"}},
+		{name: "position", doc: xpathDoc, expr: "//book[1]/title", want: []string{"Dune"}},
+		{name: "last", doc: xpathDoc, expr: "//book[last()]/@id", want: []string{"bk3"}},
+		{name: "attribute", doc: xpathDoc, expr: "//book/@id", want: []string{"bk1", "bk2", "bk3"}},
+		{name: "attribute equals", doc: xpathDoc, expr: "//book[@lang='fr']/title", want: []string{"Emma"}},
+		{name: "attribute not equals", doc: xpathDoc, expr: "//book[@lang!='fr']/title", want: []string{"Dune"}},
+		{name: "attribute exists", doc: xpathDoc, expr: "//book[@lang]/@id", want: []string{"bk1", "bk2"}},
+		{name: "child text equals", doc: xpathDoc, expr: "//book[title='Ubik']/price", want: []string{"12"}},
+		{name: "text", doc: xpathDoc, expr: "//title/text()", want: []string{"Dune", "Emma", "Ubik"}},
+		{name: "wildcard", doc: xpathDoc, expr: "/catalog/book[1]/*", want: []string{"Dune", "9.50"}},
+		{name: "parent", doc: xpathDoc, expr: "//title[.='Emma']/../@id", want: []string{"bk2"}},
+		{name: "no match", doc: xpathDoc, expr: "//author", want: []string{}},
+		{name: "html input", doc: xpathHTML, expr: "//input[@name='csrf']/@value", want: []string{"t0k3n"}},
+		{name: "html case insensitive", doc: xpathHTML, expr: "//TITLE", want: []string{"Sign in"}},
+		{name: "html first of each parent", doc: xpathHTML, expr: "//li[1]", want: []string{"One", "Three"}},
+		{name: "html contains", doc: xpathHTML, expr: "//li[contains(@class,'item')]", want: []string{"One", "Two & more"}},
+		{name: "html starts-with", doc: xpathHTML, expr: "//form[starts-with(@action,'/')]/@action", want: []string{"/login"}},
+		{name: "literal with equals sign", doc: `<r><a href="/x?y=1">one</a><a href="/z">two</a></r>`, expr: "//a[@href='/x?y=1']", want: []string{"one"}},
+		{name: "literal with not equals sign", doc: `<r><a t="a!=b">one</a><a t="c">two</a></r>`, expr: "//a[@t!='a!=b']", want: []string{"two"}},
+		{name: "number literal", doc: xpathDoc, expr: "//book[price=9.5]/@id", want: []string{"bk1"}},
+		{name: "contains literal with comma", doc: `<r><p c="a,b">x</p></r>`, expr: "//p[contains(@c,'a,b')]", want: []string{"x"}},
+		{name: "empty expression", doc: xpathDoc, expr: " ", wantErr: true},
+		{name: "unclosed predicate", doc: xpathDoc, expr: "//book[1", wantErr: true},
+		{name: "not xml", doc: "plain text", expr: "//a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryXPath([]byte(tt.doc), tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryXPath(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryXPath(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsYAMLContentType returns true for YAML media types such as application/yaml,
+// text/yaml, application/x-yaml and "+yaml" types
+func IsYAMLContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "/yaml") || strings.Contains(contentType, "/x-yaml") || strings.Contains(contentType, "+yaml")
+}
+
+// FormatYAML re-indents a YAML stream with indent spaces per level, keeping the
+// order of keys, the comments and every document of the stream
+func FormatYAML(data []byte, indent int) (string, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", nil
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(indent)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid YAML: %w", err)
+		}
+		if err := encoder.Encode(&doc); err != nil {
+			return "", fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("invalid YAML: %w", err)
+	}
+	return strings.TrimRight(out.String(), "\n"), nil
+}
@@ -0,0 +1,35 @@
+package format
+
+import "testing"
+
+func TestFormatYAML(t *testing.T) {
+	input := "name: api   # service name\nports:\n    - 80\n    - 443\nnested: {a: 1, b: [x, y]}\n---\nsecond: true\n"
+	want := "name: api # service name\nports:\n  - 80\n  - 443\nnested: {a: 1, b: [x, y]}\n---\nsecond: true"
+
+	got, err := FormatYAML([]byte(input), 2)
+	if err != nil {
+		t.Fatalf("FormatYAML() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("FormatYAML() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := FormatYAML([]byte("a: [1, 2"), 2); err == nil {
+		t.Error("FormatYAML() should fail on invalid YAML")
+	}
+}
+
+func TestIsYAMLContentType(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"application/yaml":         true,
+		"text/yaml; charset=utf-8": true,
+		"application/x-yaml":       true,
+		"application/openapi+yaml": true,
+		"application/json":         false,
+		"text/plain":               false,
+	} {
+		if got := IsYAMLContentType(contentType); got != want {
+			t.Errorf("IsYAMLContentType(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
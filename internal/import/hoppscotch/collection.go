@@ -0,0 +1,348 @@
+package hoppscotch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// hoppscotchVariable matches the <<name>> variable references of Hoppscotch
+var hoppscotchVariable = regexp.MustCompile(`<<\s*([A-Za-z0-9_.$-]+)\s*>>`)
+
+// fromHoppscotch turns the <<name>> references of s into LazyCurl's {{name}}
+func fromHoppscotch(s string) string {
+	return hoppscotchVariable.ReplaceAllString(s, "{{$1}}")
+}
+
+// ImportCollections imports a file of Hoppscotch collections and converts them to
+// LazyCurl format.
+func ImportCollections(filePath string) (*ImportResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return ImportCollectionsFromBytes(data)
+}
+
+// ImportCollectionsFromBytes imports Hoppscotch collections from raw JSON bytes: one
+// collection, or an array of them.
+func ImportCollectionsFromBytes(data []byte) (*ImportResult, error) {
+	collections, err := parseCollections(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for i := range collections {
+		if err := validateCollection(&collections[i]); err != nil {
+			return nil, err
+		}
+		result.Collections = append(result.Collections, convertCollection(&collections[i], &result.Summary))
+	}
+	return result, nil
+}
+
+// parseCollections parses JSON bytes into Collection structs.
+func parseCollections(data []byte) ([]Collection, error) {
+	var collections []Collection
+	if err := json.Unmarshal(data, &collections); err == nil {
+		if len(collections) == 0 {
+			return nil, fmt.Errorf("no collection to import")
+		}
+		return collections, nil
+	}
+	var collection Collection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return []Collection{collection}, nil
+}
+
+// validateCollection validates that the parsed data is a Hoppscotch collection.
+func validateCollection(hc *Collection) error {
+	if hc.Name == "" {
+		return fmt.Errorf("invalid collection: name is required")
+	}
+	if hc.Requests == nil && hc.Folders == nil {
+		return fmt.Errorf("not a valid Hoppscotch collection (missing requests and folders)")
+	}
+	return nil
+}
+
+// convertCollection converts a Collection to a LazyCurl CollectionFile.
+func convertCollection(hc *Collection, summary *ImportSummary) *api.CollectionFile {
+	summary.Names = append(summary.Names, hc.Name)
+
+	collection := &api.CollectionFile{Name: hc.Name}
+	for _, v := range hc.Variables {
+		if collection.Variables == nil {
+			collection.Variables = make(map[string]string)
+		}
+		collection.Variables[v.Key] = fromHoppscotch(v.value())
+	}
+
+	inherited := inheritance{}.with(hc)
+	for _, folder := range hc.Folders {
+		collection.Folders = append(collection.Folders, convertFolder(folder, inherited, summary))
+	}
+	for _, req := range hc.Requests {
+		collection.Requests = append(collection.Requests, convertRequest(req, inherited, summary))
+	}
+	return collection
+}
+
+// inheritance is what the requests of a folder or collection inherit from it and its
+// parents: auth, for requests of the "inherit" type, and headers
+type inheritance struct {
+	auth    *Auth
+	headers []KeyValue
+}
+
+// with returns the inheritance of the requests of hc, a folder or collection whose
+// parents give i
+func (i inheritance) with(hc *Collection) inheritance {
+	if hc.Auth != nil && hc.Auth.AuthType != "inherit" {
+		i.auth = hc.Auth
+	}
+	headers := make([]KeyValue, 0, len(i.headers)+len(hc.Headers))
+	for _, h := range hc.Headers {
+		if h.Active && h.Key != "" {
+			headers = append(headers, h)
+		}
+	}
+	for _, h := range i.headers {
+		if !hasKey(headers, h.Key) {
+			headers = append(headers, h)
+		}
+	}
+	i.headers = headers
+	return i
+}
+
+// convertFolder converts a nested Collection to a LazyCurl Folder.
+func convertFolder(hc Collection, parent inheritance, summary *ImportSummary) api.Folder {
+	summary.FoldersCount++
+
+	folder := api.Folder{Name: hc.Name}
+	inherited := parent.with(&hc)
+	for _, sub := range hc.Folders {
+		folder.Folders = append(folder.Folders, convertFolder(sub, inherited, summary))
+	}
+	for _, req := range hc.Requests {
+		folder.Requests = append(folder.Requests, convertRequest(req, inherited, summary))
+	}
+	return folder
+}
+
+// convertRequest converts a Request to a LazyCurl CollectionRequest.
+func convertRequest(hr Request, inherited inheritance, summary *ImportSummary) api.CollectionRequest {
+	summary.RequestsCount++
+
+	req := api.CollectionRequest{
+		ID:     api.GenerateID(),
+		Name:   hr.Name,
+		Method: api.HTTPMethod(strings.ToUpper(hr.Method)),
+	}
+
+	// The endpoint holds no query: the active params are added to the URL
+	endpoint := fromHoppscotch(hr.Endpoint)
+	var query []string
+	for _, p := range hr.Params {
+		req.Params = append(req.Params, api.KeyValueEntry{Key: fromHoppscotch(p.Key), Value: fromHoppscotch(p.Value), Enabled: p.Active})
+		if p.Active && p.Key != "" {
+			query = append(query, fromHoppscotch(p.Key)+"="+fromHoppscotch(p.Value))
+		}
+	}
+	req.URL = endpoint
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(endpoint, "?") {
+			separator = "&"
+		}
+		req.URL += separator + strings.Join(query, "&")
+	}
+
+	for _, h := range hr.Headers {
+		req.Headers = append(req.Headers, api.KeyValueEntry{Key: fromHoppscotch(h.Key), Value: fromHoppscotch(h.Value), Enabled: h.Active})
+	}
+	for _, h := range inherited.headers {
+		if !hasHeader(req.Headers, h.Key) {
+			req.Headers = append(req.Headers, api.KeyValueEntry{Key: fromHoppscotch(h.Key), Value: fromHoppscotch(h.Value), Enabled: true})
+		}
+	}
+
+	req.Body = convertBody(hr.Body, &req, summary)
+
+	auth := hr.Auth
+	if auth != nil && auth.AuthType == "inherit" {
+		auth = inherited.auth
+	}
+	if auth != nil {
+		req.Auth = convertAuth(auth, summary, hr.Name)
+	}
+
+	for _, v := range hr.RequestVariables {
+		if !v.Active || v.Key == "" {
+			continue
+		}
+		if req.Variables == nil {
+			req.Variables = make(map[string]string)
+		}
+		req.Variables[v.Key] = fromHoppscotch(v.Value)
+	}
+
+	if strings.TrimSpace(hr.PreRequestScript) != "" || strings.TrimSpace(hr.TestScript) != "" {
+		req.Scripts = &api.ScriptConfig{PreRequest: hr.PreRequestScript, PostRequest: hr.TestScript}
+		if req.Scripts.PreRequest != "" {
+			summary.AddWarningf("Request '%s' has pre-request script written for Hoppscotch (stored, review before use)", hr.Name)
+		}
+		if req.Scripts.PostRequest != "" {
+			summary.AddWarningf("Request '%s' has test script written for Hoppscotch (stored, review before use)", hr.Name)
+		}
+	}
+
+	return req
+}
+
+// convertBody converts Body to BodyConfig. Text bodies other than JSON keep their content
+// type in a Content-Type header of req.
+func convertBody(body Body, req *api.CollectionRequest, summary *ImportSummary) *api.BodyConfig {
+	if body.ContentType == nil || *body.ContentType == "" {
+		return nil
+	}
+	contentType := *body.ContentType
+
+	if contentType == "multipart/form-data" {
+		var fields []FormField
+		if err := json.Unmarshal(body.Body, &fields); err != nil {
+			summary.AddWarningf("Request '%s' has invalid form data (skipped)", req.Name)
+			return nil
+		}
+		var content []api.FormField
+		for _, f := range fields {
+			if f.IsFile {
+				summary.AddWarningf("Request '%s' has file field '%s' (files are not exported by Hoppscotch, skipped)", req.Name, f.Key)
+				continue
+			}
+			var value string
+			_ = json.Unmarshal(f.Value, &value)
+			content = append(content, api.FormField{Key: fromHoppscotch(f.Key), Value: fromHoppscotch(value), Enabled: f.Active})
+		}
+		return &api.BodyConfig{Type: api.BodyTypeFormData, Content: content}
+	}
+
+	var text string
+	if err := json.Unmarshal(body.Body, &text); err != nil {
+		summary.AddWarningf("Request '%s' has a %s body that is not text (skipped)", req.Name, contentType)
+		return nil
+	}
+	if contentType == "application/x-www-form-urlencoded" {
+		text = encodeKeyValues(text)
+	}
+	text = fromHoppscotch(text)
+
+	if strings.Contains(contentType, "json") {
+		if contentType != "application/json" && !hasHeader(req.Headers, "Content-Type") {
+			req.Headers = append(req.Headers, api.KeyValueEntry{Key: "Content-Type", Value: contentType, Enabled: true})
+		}
+		return &api.BodyConfig{Type: "json", Content: text}
+	}
+	if !hasHeader(req.Headers, "Content-Type") {
+		req.Headers = append(req.Headers, api.KeyValueEntry{Key: "Content-Type", Value: contentType, Enabled: true})
+	}
+	return &api.BodyConfig{Type: "raw", Content: text}
+}
+
+// encodeKeyValues returns the "key: value" lines of a Hoppscotch urlencoded body as a
+// key=value&... query. Lines starting with # are disabled; variable references are kept
+// as is.
+func encodeKeyValues(text string) string {
+	escape := func(s string) string {
+		if strings.Contains(s, "<<") {
+			return s
+		}
+		return url.QueryEscape(s)
+	}
+	var pairs []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, ":")
+		pairs = append(pairs, escape(strings.TrimSpace(key))+"="+escape(strings.TrimSpace(value)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// convertAuth converts Auth to AuthConfig.
+func convertAuth(auth *Auth, summary *ImportSummary, reqName string) *api.AuthConfig {
+	if !auth.AuthActive {
+		return &api.AuthConfig{Type: "none"}
+	}
+
+	switch auth.AuthType {
+	case "bearer":
+		return &api.AuthConfig{
+			Type:  "bearer",
+			Token: fromHoppscotch(auth.Token),
+		}
+
+	case "basic":
+		return &api.AuthConfig{
+			Type:     "basic",
+			Username: fromHoppscotch(auth.Username),
+			Password: fromHoppscotch(auth.Password),
+		}
+
+	case "api-key":
+		location := "header"
+		if strings.Contains(strings.ToLower(auth.AddTo), "query") {
+			location = "query"
+		}
+		return &api.AuthConfig{
+			Type:           "api_key",
+			APIKeyName:     fromHoppscotch(auth.Key),
+			APIKeyValue:    fromHoppscotch(auth.Value),
+			APIKeyLocation: location,
+		}
+
+	case "none", "inherit":
+		return &api.AuthConfig{
+			Type: "none",
+		}
+
+	case "oauth-2":
+		summary.AddWarningf("Request '%s' uses OAuth 2.0 (not supported)", reqName)
+		return nil
+
+	default:
+		summary.AddWarningf("Request '%s' uses unsupported auth type '%s'", reqName, auth.AuthType)
+		return nil
+	}
+}
+
+// hasKey reports whether values have one named key, case-insensitively
+func hasKey(values []KeyValue, key string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHeader reports whether headers set key, case-insensitively
+func hasHeader(headers []api.KeyValueEntry, key string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package hoppscotch
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestImportCollections(t *testing.T) {
+	result, err := ImportCollections(filepath.Join("testdata", "collections.json"))
+	if err != nil {
+		t.Fatalf("ImportCollections failed: %v", err)
+	}
+
+	if len(result.Collections) != 2 {
+		t.Fatalf("Expected 2 collections, got %d", len(result.Collections))
+	}
+	shop := result.Collections[0]
+	if shop.Name != "Shop API" {
+		t.Errorf("Expected name 'Shop API', got '%s'", shop.Name)
+	}
+	if shop.Variables["base_url"] != "https://shop.example.com" {
+		t.Errorf("Expected base_url variable, got %v", shop.Variables)
+	}
+	if len(shop.Folders) != 1 || len(shop.Folders[0].Requests) != 2 {
+		t.Fatalf("Expected 1 folder with 2 requests, got %+v", shop.Folders)
+	}
+	if len(shop.Requests) != 2 {
+		t.Fatalf("Expected 2 requests at the root, got %d", len(shop.Requests))
+	}
+
+	if result.Summary.RequestsCount != 5 || result.Summary.FoldersCount != 1 {
+		t.Errorf("Expected 5 requests and 1 folder, got %d and %d", result.Summary.RequestsCount, result.Summary.FoldersCount)
+	}
+	if got := result.FormatSummary(); got != `Imported "Shop API", "Status" - 5 requests, 1 folders - 3 warnings` {
+		t.Errorf("FormatSummary() = %q", got)
+	}
+}
+
+func TestImportCollections_Requests(t *testing.T) {
+	result, err := ImportCollections(filepath.Join("testdata", "collections.json"))
+	if err != nil {
+		t.Fatalf("ImportCollections failed: %v", err)
+	}
+	shop := result.Collections[0]
+
+	t.Run("params and api key", func(t *testing.T) {
+		req := shop.Requests[0]
+		if req.URL != "{{base_url}}/products?page=1&size={{size}}" {
+			t.Errorf("Expected active params in the URL, got '%s'", req.URL)
+		}
+		if len(req.Params) != 3 || req.Params[2].Enabled {
+			t.Errorf("Expected 3 params, the last disabled, got %+v", req.Params)
+		}
+		if req.Auth == nil || req.Auth.Type != "api_key" || req.Auth.APIKeyLocation != "query" || req.Auth.APIKeyValue != "{{api_key}}" {
+			t.Errorf("Expected api key auth in query, got %+v", req.Auth)
+		}
+		if req.Body != nil {
+			t.Errorf("Expected no body, got %+v", req.Body)
+		}
+	})
+
+	t.Run("urlencoded body", func(t *testing.T) {
+		req := shop.Requests[1]
+		if req.Body == nil || req.Body.Type != "raw" || req.Body.Content != "user=ada&password={{password}}" {
+			t.Errorf("Expected urlencoded raw body, got %+v", req.Body)
+		}
+		if !hasHeader(req.Headers, "Content-Type") {
+			t.Errorf("Expected Content-Type header, got %+v", req.Headers)
+		}
+		if req.Auth == nil || req.Auth.Type != "none" {
+			t.Errorf("Expected none auth, got %+v", req.Auth)
+		}
+	})
+
+	t.Run("inherited auth and headers", func(t *testing.T) {
+		req := shop.Folders[0].Requests[0]
+		if req.Method != "POST" {
+			t.Errorf("Expected method to be upper-cased, got '%s'", req.Method)
+		}
+		if req.Auth == nil || req.Auth.Type != "bearer" || req.Auth.Token != "{{token}}" {
+			t.Errorf("Expected bearer auth inherited from the collection, got %+v", req.Auth)
+		}
+		want := []api.KeyValueEntry{{Key: "X-Client", Value: "lazycurl", Enabled: true}}
+		if len(req.Headers) != len(want) || req.Headers[0] != want[0] {
+			t.Errorf("Expected own X-Client header over the inherited one, got %+v", req.Headers)
+		}
+		if req.Body == nil || req.Body.Type != "json" || req.Body.Content != `{"sku": "{{sku}}"}` {
+			t.Errorf("Expected json body, got %+v", req.Body)
+		}
+		if len(req.Variables) != 1 || req.Variables["sku"] != "A1" {
+			t.Errorf("Expected active request variables only, got %v", req.Variables)
+		}
+		if req.Scripts == nil || !strings.HasPrefix(req.Scripts.PostRequest, "pw.test") {
+			t.Errorf("Expected test script to be kept, got %+v", req.Scripts)
+		}
+	})
+
+	t.Run("form data", func(t *testing.T) {
+		req := shop.Folders[0].Requests[1]
+		fields := api.ParseFormData(req.Body.Content)
+		if req.Body.Type != api.BodyTypeFormData || len(fields) != 1 || fields[0].Key != "number" || fields[0].Value != "42" {
+			t.Errorf("Expected form data without the file field, got %+v", req.Body)
+		}
+		if req.Auth != nil {
+			t.Errorf("Expected no auth for OAuth 2.0, got %+v", req.Auth)
+		}
+		if len(req.Headers) != 1 || req.Headers[0].Key != "X-Client" || req.Headers[0].Value != "hoppscotch" {
+			t.Errorf("Expected the collection's active header, got %+v", req.Headers)
+		}
+	})
+
+	t.Run("raw body and basic auth", func(t *testing.T) {
+		req := result.Collections[1].Requests[0]
+		if req.Body == nil || req.Body.Type != "raw" || req.Body.Content != "<ping/>" {
+			t.Errorf("Expected raw body, got %+v", req.Body)
+		}
+		if len(req.Headers) != 1 || req.Headers[0].Value != "application/xml" {
+			t.Errorf("Expected Content-Type header, got %+v", req.Headers)
+		}
+		if req.Auth == nil || req.Auth.Type != "basic" || req.Auth.Username != "ops" || req.Auth.Password != "{{ops_password}}" {
+			t.Errorf("Expected basic auth, got %+v", req.Auth)
+		}
+	})
+}
+
+func TestImportCollections_Warnings(t *testing.T) {
+	result, err := ImportCollections(filepath.Join("testdata", "collections.json"))
+	if err != nil {
+		t.Fatalf("ImportCollections failed: %v", err)
+	}
+
+	want := []string{"test script", "file field 'pdf'", "OAuth 2.0"}
+	if len(result.Summary.Warnings) != len(want) {
+		t.Fatalf("Expected %d warnings, got %v", len(want), result.Summary.Warnings)
+	}
+	for i, w := range want {
+		if !strings.Contains(result.Summary.Warnings[i], w) {
+			t.Errorf("Expected warning %d to mention %q, got %q", i, w, result.Summary.Warnings[i])
+		}
+	}
+}
+
+func TestImportCollections_Single(t *testing.T) {
+	result, err := ImportCollections(filepath.Join("testdata", "single_collection.json"))
+	if err != nil {
+		t.Fatalf("ImportCollections failed: %v", err)
+	}
+	if len(result.Collections) != 1 || result.Collections[0].Name != "Echo" {
+		t.Fatalf("Expected collection 'Echo', got %+v", result.Collections)
+	}
+	if result.HasWarnings() {
+		t.Errorf("Expected no warnings, got %v", result.Summary.Warnings)
+	}
+}
+
+func TestImportCollectionsFromBytes_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid JSON", `{not json`},
+		{"empty array", `[]`},
+		{"missing name", `{"folders": [], "requests": []}`},
+		{"not a collection", `{"name": "x", "values": []}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportCollectionsFromBytes([]byte(tt.data)); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
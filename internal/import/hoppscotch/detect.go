@@ -0,0 +1,56 @@
+package hoppscotch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DetectFileType determines if a file holds Hoppscotch collections or environments.
+func DetectFileType(filePath string) (FileType, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return FileTypeUnknown, fmt.Errorf("failed to read file: %w", err)
+	}
+	return DetectFileTypeFromBytes(data), nil
+}
+
+// DetectFileTypeFromBytes determines the file type from raw JSON bytes: one collection
+// or environment, or an array of them, typed by its first element.
+func DetectFileTypeFromBytes(data []byte) FileType {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		var item map[string]json.RawMessage
+		if err := json.Unmarshal(data, &item); err != nil {
+			return FileTypeUnknown
+		}
+		items = []map[string]json.RawMessage{item}
+	}
+	if len(items) == 0 {
+		return FileTypeUnknown
+	}
+	item := items[0]
+	if _, ok := item["name"]; !ok {
+		return FileTypeUnknown
+	}
+
+	// Collections hold requests and folders as arrays
+	if isArray(item["requests"]) && isArray(item["folders"]) {
+		return FileTypeCollection
+	}
+
+	// Environments hold their variables as an array; LazyCurl's own as an object
+	if isArray(item["variables"]) {
+		if _, ok := item["requests"]; !ok {
+			return FileTypeEnvironment
+		}
+	}
+
+	return FileTypeUnknown
+}
+
+// isArray reports whether raw is a JSON array
+func isArray(raw json.RawMessage) bool {
+	var values []json.RawMessage
+	return raw != nil && json.Unmarshal(raw, &values) == nil && values != nil
+}
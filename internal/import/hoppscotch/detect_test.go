@@ -0,0 +1,48 @@
+package hoppscotch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectFileType(t *testing.T) {
+	tests := []struct {
+		file string
+		want FileType
+	}{
+		{"collections.json", FileTypeCollection},
+		{"single_collection.json", FileTypeCollection},
+		{"environments.json", FileTypeEnvironment},
+	}
+	for _, tt := range tests {
+		got, err := DetectFileType(filepath.Join("testdata", tt.file))
+		if err != nil {
+			t.Fatalf("DetectFileType(%s) failed: %v", tt.file, err)
+		}
+		if got != tt.want {
+			t.Errorf("DetectFileType(%s) = %s, want %s", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestDetectFileTypeFromBytes_Unknown(t *testing.T) {
+	tests := map[string]string{
+		"postman collection":   `{"info": {"name": "x", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`,
+		"postman environment":  `{"name": "x", "values": [], "_postman_variable_scope": "environment"}`,
+		"lazycurl environment": `{"name": "x", "variables": {"a": {"value": "1"}}}`,
+		"no name":              `[{"folders": [], "requests": []}]`,
+		"empty array":          `[]`,
+		"invalid":              `{not json`,
+	}
+	for name, data := range tests {
+		if got := DetectFileTypeFromBytes([]byte(data)); got != FileTypeUnknown {
+			t.Errorf("%s: got %s, want Unknown", name, got)
+		}
+	}
+}
+
+func TestDetectFileType_Missing(t *testing.T) {
+	if _, err := DetectFileType(filepath.Join("testdata", "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
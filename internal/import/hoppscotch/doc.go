@@ -0,0 +1,48 @@
+// Package hoppscotch provides import and export functionality for Hoppscotch JSON
+// collections and environments.
+//
+// This package enables bidirectional conversion between Hoppscotch exports and
+// LazyCurl's internal formats. It supports:
+//
+//   - Importing Hoppscotch REST collections, one or an array of them
+//   - Importing Hoppscotch environments, one or an array of them
+//   - Exporting LazyCurl collections to Hoppscotch format
+//   - Exporting LazyCurl environments to Hoppscotch format
+//   - Auto-detecting file types (collections vs environments)
+//
+// # Import Example
+//
+//	result, err := hoppscotch.ImportCollections("/path/to/hoppscotch-collections.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, w := range result.Summary.Warnings {
+//	    log.Printf("Warning: %s", w)
+//	}
+//	// Use result.Collections
+//
+// # Export Example
+//
+//	err := hoppscotch.ExportCollections(collections, "/path/to/export.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// # Supported Features
+//
+// The following Hoppscotch features are fully supported:
+//   - Collections with nested folders (unlimited depth)
+//   - Query parameters, headers and request variables with their active state
+//   - Variables: <<name>> references are converted to {{name}} and back
+//   - Body content types: JSON, text, XML, urlencoded, multipart form data
+//   - Authentication: Bearer, Basic, API Key, inherited from folders and collections
+//   - Headers set on folders and collections, copied to their requests
+//   - Collection variables, and environment variables with their secret flag
+//
+// # Unsupported Features
+//
+// The following Hoppscotch features generate warnings but don't prevent import:
+//   - Pre-request and test scripts (stored, written for the Hoppscotch API)
+//   - OAuth 2.0, Digest, AWS Signature and other auth types
+//   - File fields of multipart bodies (Hoppscotch does not export the files)
+package hoppscotch
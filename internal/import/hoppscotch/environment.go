@@ -0,0 +1,86 @@
+package hoppscotch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// ImportEnvironments imports a file of Hoppscotch environments and converts them to
+// LazyCurl format.
+func ImportEnvironments(filePath string) (*ImportResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return ImportEnvironmentsFromBytes(data)
+}
+
+// ImportEnvironmentsFromBytes imports Hoppscotch environments from raw JSON bytes: one
+// environment, or an array of them.
+func ImportEnvironmentsFromBytes(data []byte) (*ImportResult, error) {
+	environments, err := parseEnvironments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for i := range environments {
+		if err := validateEnvironment(&environments[i]); err != nil {
+			return nil, err
+		}
+		result.Environments = append(result.Environments, convertEnvironment(&environments[i], &result.Summary))
+	}
+	return result, nil
+}
+
+// parseEnvironments parses JSON bytes into Environment structs.
+func parseEnvironments(data []byte) ([]Environment, error) {
+	var environments []Environment
+	if err := json.Unmarshal(data, &environments); err == nil {
+		if len(environments) == 0 {
+			return nil, fmt.Errorf("no environment to import")
+		}
+		return environments, nil
+	}
+	var environment Environment
+	if err := json.Unmarshal(data, &environment); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return []Environment{environment}, nil
+}
+
+// validateEnvironment validates that the parsed data is a Hoppscotch environment.
+func validateEnvironment(he *Environment) error {
+	if he.Name == "" {
+		return fmt.Errorf("invalid environment: name is required")
+	}
+	return nil
+}
+
+// convertEnvironment converts an Environment to a LazyCurl EnvironmentFile.
+func convertEnvironment(he *Environment, summary *ImportSummary) *api.EnvironmentFile {
+	summary.Names = append(summary.Names, he.Name)
+
+	env := &api.EnvironmentFile{
+		Name:      he.Name,
+		Variables: make(map[string]*api.EnvironmentVariable),
+	}
+	for _, v := range he.Variables {
+		if v.Key == "" {
+			continue
+		}
+		summary.VariablesCount++
+		env.Variables[v.Key] = &api.EnvironmentVariable{
+			Value:  fromHoppscotch(v.value()),
+			Secret: v.Secret,
+			Active: true,
+		}
+		if v.Secret && v.value() == "" {
+			summary.AddWarningf("Environment '%s' has secret '%s' without value (Hoppscotch does not export secret values)", he.Name, v.Key)
+		}
+	}
+	return env
+}
@@ -0,0 +1,58 @@
+package hoppscotch
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportEnvironments(t *testing.T) {
+	result, err := ImportEnvironments(filepath.Join("testdata", "environments.json"))
+	if err != nil {
+		t.Fatalf("ImportEnvironments failed: %v", err)
+	}
+
+	if len(result.Environments) != 2 {
+		t.Fatalf("Expected 2 environments, got %d", len(result.Environments))
+	}
+	staging := result.Environments[0]
+	if staging.Name != "Staging" {
+		t.Errorf("Expected name 'Staging', got '%s'", staging.Name)
+	}
+	if v := staging.Variables["base_url"]; v == nil || v.Value != "https://staging.example.com" || !v.Active || v.Secret {
+		t.Errorf("Unexpected base_url variable: %+v", v)
+	}
+	if v := staging.Variables["token"]; v == nil || !v.Secret {
+		t.Errorf("Expected secret token variable, got %+v", v)
+	}
+
+	production := result.Environments[1]
+	if v := production.Variables["base_url"]; v == nil || v.Value != "https://{{region}}.shop.example.com" {
+		t.Errorf("Expected the current value with converted references, got %+v", v)
+	}
+
+	if len(result.Summary.Warnings) != 1 || !strings.Contains(result.Summary.Warnings[0], "'token'") {
+		t.Errorf("Expected a warning for the secret without value, got %v", result.Summary.Warnings)
+	}
+	if got := result.FormatSummary(); got != `Imported "Staging", "Production" - 3 variables - 1 warnings` {
+		t.Errorf("FormatSummary() = %q", got)
+	}
+}
+
+func TestImportEnvironmentsFromBytes_Single(t *testing.T) {
+	result, err := ImportEnvironmentsFromBytes([]byte(`{"name": "Local", "variables": [{"key": "port", "value": "8080"}]}`))
+	if err != nil {
+		t.Fatalf("ImportEnvironmentsFromBytes failed: %v", err)
+	}
+	if len(result.Environments) != 1 || result.Environments[0].Variables["port"].Value != "8080" {
+		t.Errorf("Unexpected environments: %+v", result.Environments)
+	}
+}
+
+func TestImportEnvironmentsFromBytes_Invalid(t *testing.T) {
+	for _, data := range []string{`{not json`, `[]`, `{"variables": []}`} {
+		if _, err := ImportEnvironmentsFromBytes([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package hoppscotch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// Schema versions written by the export; Hoppscotch migrates them to its current ones
+var (
+	collectionVersion  = json.RawMessage(`2`)
+	requestVersion     = json.RawMessage(`"2"`)
+	environmentVersion = json.RawMessage(`1`)
+)
+
+// lazycurlVariable matches the {{name}} and {{?name}} variable references of LazyCurl
+var lazycurlVariable = regexp.MustCompile(`\{\{\s*\??([A-Za-z0-9_.$-]+)\s*\}\}`)
+
+// toHoppscotch turns the {{name}} references of s into Hoppscotch's <<name>>. Prompt
+// variables become plain variables.
+func toHoppscotch(s string) string {
+	return lazycurlVariable.ReplaceAllString(s, "<<${1}>>")
+}
+
+// ExportCollections exports LazyCurl collections to a Hoppscotch collections file.
+func ExportCollections(collections []*api.CollectionFile, filePath string) error {
+	data, err := ExportCollectionsToBytes(collections)
+	if err != nil {
+		return err
+	}
+	return writeFile(filePath, data)
+}
+
+// ExportCollectionsToBytes exports LazyCurl collections to Hoppscotch JSON bytes, an
+// array of collections as Hoppscotch exports them.
+func ExportCollectionsToBytes(collections []*api.CollectionFile) ([]byte, error) {
+	hcs := make([]Collection, 0, len(collections))
+	for _, c := range collections {
+		hcs = append(hcs, convertToCollection(c))
+	}
+	data, err := marshal(hcs, "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal collections: %w", err)
+	}
+	return data, nil
+}
+
+// marshal returns the JSON encoding of v, indented by indent if set. Unlike
+// json.Marshal, it leaves the < and > of <<name>> references unescaped.
+func marshal(v interface{}, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// writeFile writes data to filePath, creating its directory
+func writeFile(filePath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// convertToCollection converts a LazyCurl CollectionFile to Collection.
+func convertToCollection(collection *api.CollectionFile) Collection {
+	hc := Collection{
+		V:        collectionVersion,
+		Name:     collection.Name,
+		Folders:  make([]Collection, 0, len(collection.Folders)),
+		Requests: make([]Request, 0, len(collection.Requests)),
+		Auth:     &Auth{AuthType: "none", AuthActive: true},
+		Headers:  []KeyValue{},
+	}
+	for _, key := range sortedKeys(collection.Variables) {
+		hc.Variables = append(hc.Variables, Variable{Key: key, Value: toHoppscotch(collection.Variables[key])})
+	}
+	for _, folder := range collection.Folders {
+		hc.Folders = append(hc.Folders, convertFolderToHoppscotch(folder))
+	}
+	for _, req := range collection.Requests {
+		hc.Requests = append(hc.Requests, convertRequestToHoppscotch(req))
+	}
+	return hc
+}
+
+// convertFolderToHoppscotch converts a LazyCurl Folder to a nested Collection.
+func convertFolderToHoppscotch(folder api.Folder) Collection {
+	hc := Collection{
+		V:        collectionVersion,
+		Name:     folder.Name,
+		Folders:  make([]Collection, 0, len(folder.Folders)),
+		Requests: make([]Request, 0, len(folder.Requests)),
+		Auth:     &Auth{AuthType: "inherit", AuthActive: true},
+		Headers:  []KeyValue{},
+	}
+	for _, sub := range folder.Folders {
+		hc.Folders = append(hc.Folders, convertFolderToHoppscotch(sub))
+	}
+	for _, req := range folder.Requests {
+		hc.Requests = append(hc.Requests, convertRequestToHoppscotch(req))
+	}
+	return hc
+}
+
+// convertRequestToHoppscotch converts a LazyCurl CollectionRequest to Request. The query
+// of the URL becomes params, along with the disabled params of the request.
+func convertRequestToHoppscotch(req api.CollectionRequest) Request {
+	endpoint, query, _ := strings.Cut(req.URL, "?")
+	hr := Request{
+		V:                requestVersion,
+		Name:             req.Name,
+		Method:           string(req.Method),
+		Endpoint:         toHoppscotch(endpoint),
+		Params:           []KeyValue{},
+		Headers:          []KeyValue{},
+		RequestVariables: []KeyValue{},
+		Auth:             convertAuthToHoppscotch(req.Auth),
+	}
+	if query != "" {
+		for _, pair := range strings.Split(query, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			hr.Params = append(hr.Params, KeyValue{Key: toHoppscotch(key), Value: toHoppscotch(value), Active: true})
+		}
+	}
+	for _, p := range req.Params {
+		if !p.Enabled {
+			hr.Params = append(hr.Params, KeyValue{Key: toHoppscotch(p.Key), Value: toHoppscotch(p.Value)})
+		}
+	}
+
+	headers := req.Headers
+	hr.Body, headers = convertBodyToHoppscotch(req.Body, headers)
+	for _, h := range headers {
+		hr.Headers = append(hr.Headers, KeyValue{Key: toHoppscotch(h.Key), Value: toHoppscotch(h.Value), Active: h.Enabled})
+	}
+
+	for _, key := range sortedKeys(req.Variables) {
+		hr.RequestVariables = append(hr.RequestVariables, KeyValue{Key: key, Value: toHoppscotch(req.Variables[key]), Active: true})
+	}
+
+	if req.Scripts != nil {
+		hr.PreRequestScript = req.Scripts.PreRequest
+		hr.TestScript = req.Scripts.PostRequest
+	}
+	return hr
+}
+
+// convertBodyToHoppscotch converts BodyConfig to Body, returning headers without the
+// Content-Type header it takes its content type from. GraphQL bodies are sent as JSON;
+// binary, MessagePack and CBOR bodies, which Hoppscotch cannot hold, are left out.
+func convertBodyToHoppscotch(body *api.BodyConfig, headers []api.KeyValueEntry) (Body, []api.KeyValueEntry) {
+	none := Body{Body: json.RawMessage("null")}
+	if body == nil || body.Content == nil {
+		return none, headers
+	}
+	contentType := ""
+	var rest []api.KeyValueEntry
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, "Content-Type") && h.Enabled {
+			contentType = h.Value
+			continue
+		}
+		rest = append(rest, h)
+	}
+
+	switch body.Type {
+	case api.BodyTypeFormData:
+		fields := []FormField{}
+		for _, f := range api.ParseFormData(body.Content) {
+			if f.IsFile() {
+				continue
+			}
+			value, _ := marshal(toHoppscotch(f.Value), "")
+			fields = append(fields, FormField{Key: toHoppscotch(f.Key), Value: value, Active: f.Enabled})
+		}
+		return textBody("multipart/form-data", fields), rest
+
+	case api.BodyTypeGraphQL:
+		payload, err := api.ParseGraphQLBody(body.Content).Payload()
+		if err != nil {
+			payload = map[string]interface{}{"query": api.ParseGraphQLBody(body.Content).Query}
+		}
+		data, _ := marshal(payload, "  ")
+		return textBody("application/json", toHoppscotch(string(data))), rest
+
+	case "json":
+		content, ok := body.Content.(string)
+		if !ok {
+			data, _ := marshal(body.Content, "  ")
+			content = string(data)
+		}
+		if !strings.Contains(contentType, "json") {
+			contentType = "application/json"
+		}
+		return textBody(contentType, toHoppscotch(content)), rest
+
+	case "raw":
+		content, _ := body.Content.(string)
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+			contentType = "application/x-www-form-urlencoded"
+			content = decodeKeyValues(content)
+		}
+		return textBody(contentType, toHoppscotch(content)), rest
+	}
+	return none, headers
+}
+
+// textBody returns the body of content type contentType holding content
+func textBody(contentType string, content interface{}) Body {
+	data, _ := marshal(content, "")
+	return Body{ContentType: &contentType, Body: data}
+}
+
+// decodeKeyValues returns a key=value&... query as the "key: value" lines of a
+// Hoppscotch urlencoded body
+func decodeKeyValues(query string) string {
+	unescape := func(s string) string {
+		if unescaped, err := url.QueryUnescape(s); err == nil {
+			return unescaped
+		}
+		return s
+	}
+	var lines []string
+	for _, pair := range strings.Split(strings.TrimSpace(query), "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		lines = append(lines, unescape(key)+": "+unescape(value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// convertAuthToHoppscotch converts AuthConfig to Auth. JWT auth, signed on send, has
+// no Hoppscotch equivalent and is exported as none.
+func convertAuthToHoppscotch(auth *api.AuthConfig) *Auth {
+	if auth == nil {
+		return &Auth{AuthType: "none", AuthActive: true}
+	}
+
+	switch auth.Type {
+	case "bearer":
+		return &Auth{AuthType: "bearer", AuthActive: true, Token: toHoppscotch(auth.Token)}
+
+	case "basic":
+		return &Auth{AuthType: "basic", AuthActive: true, Username: toHoppscotch(auth.Username), Password: toHoppscotch(auth.Password)}
+
+	case "api_key":
+		addTo := "Headers"
+		if auth.APIKeyLocation == "query" {
+			addTo = "Query params"
+		}
+		return &Auth{AuthType: "api-key", AuthActive: true, Key: toHoppscotch(auth.APIKeyName), Value: toHoppscotch(auth.APIKeyValue), AddTo: addTo}
+
+	default:
+		return &Auth{AuthType: "none", AuthActive: true}
+	}
+}
+
+// ExportEnvironments exports LazyCurl environments to a Hoppscotch environments file.
+func ExportEnvironments(envs []*api.EnvironmentFile, filePath string) error {
+	data, err := ExportEnvironmentsToBytes(envs)
+	if err != nil {
+		return err
+	}
+	return writeFile(filePath, data)
+}
+
+// ExportEnvironmentsToBytes exports LazyCurl environments to Hoppscotch JSON bytes, an
+// array of environments. Inactive variables are left out and, as Hoppscotch does, the
+// values of secrets.
+func ExportEnvironmentsToBytes(envs []*api.EnvironmentFile) ([]byte, error) {
+	hes := make([]Environment, 0, len(envs))
+	for _, env := range envs {
+		hes = append(hes, convertToEnvironment(env))
+	}
+	data, err := marshal(hes, "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal environments: %w", err)
+	}
+	return data, nil
+}
+
+// convertToEnvironment converts a LazyCurl EnvironmentFile to Environment.
+func convertToEnvironment(env *api.EnvironmentFile) Environment {
+	he := Environment{
+		V:         environmentVersion,
+		Name:      env.Name,
+		Variables: []Variable{},
+	}
+	for _, key := range sortedKeys(env.Variables) {
+		v := env.Variables[key]
+		if !v.Active {
+			continue
+		}
+		variable := Variable{Key: key, Value: toHoppscotch(v.Value), Secret: v.Secret}
+		if v.Secret {
+			variable.Value = ""
+		}
+		he.Variables = append(he.Variables, variable)
+	}
+	return he
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
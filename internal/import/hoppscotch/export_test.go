@@ -0,0 +1,225 @@
+package hoppscotch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestExportCollectionsToBytes(t *testing.T) {
+	collection := &api.CollectionFile{
+		Name:      "Shop",
+		Variables: map[string]string{"base_url": "https://shop.example.com"},
+		Folders: []api.Folder{{
+			Name: "Orders",
+			Requests: []api.CollectionRequest{{
+				Name:   "Create order",
+				Method: "POST",
+				URL:    "{{base_url}}/orders?dry_run=1",
+				Params: []api.KeyValueEntry{
+					{Key: "dry_run", Value: "1", Enabled: true},
+					{Key: "debug", Value: "1", Enabled: false},
+				},
+				Headers: []api.KeyValueEntry{{Key: "Content-Type", Value: "application/json", Enabled: true}},
+				Auth:    &api.AuthConfig{Type: "bearer", Token: "{{token}}"},
+				Body:    &api.BodyConfig{Type: "json", Content: `{"quantity": {{?quantity}}}`},
+				Scripts: &api.ScriptConfig{PostRequest: "lc.test('ok', () => {})"},
+			}},
+		}},
+	}
+
+	data, err := ExportCollectionsToBytes([]*api.CollectionFile{collection})
+	if err != nil {
+		t.Fatalf("ExportCollectionsToBytes failed: %v", err)
+	}
+	var hcs []Collection
+	if err := json.Unmarshal(data, &hcs); err != nil {
+		t.Fatalf("Exported JSON is invalid: %v", err)
+	}
+	if len(hcs) != 1 || hcs[0].Name != "Shop" || len(hcs[0].Folders) != 1 {
+		t.Fatalf("Unexpected collections: %+v", hcs)
+	}
+	if len(hcs[0].Variables) != 1 || hcs[0].Variables[0].Value != "https://shop.example.com" {
+		t.Errorf("Unexpected variables: %+v", hcs[0].Variables)
+	}
+	folder := hcs[0].Folders[0]
+	if folder.Auth == nil || folder.Auth.AuthType != "inherit" {
+		t.Errorf("Expected folder to inherit auth, got %+v", folder.Auth)
+	}
+
+	req := folder.Requests[0]
+	if req.Endpoint != "<<base_url>>/orders" {
+		t.Errorf("Expected endpoint without query, got '%s'", req.Endpoint)
+	}
+	wantParams := []KeyValue{{Key: "dry_run", Value: "1", Active: true}, {Key: "debug", Value: "1"}}
+	if len(req.Params) != 2 || req.Params[0] != wantParams[0] || req.Params[1] != wantParams[1] {
+		t.Errorf("Unexpected params: %+v", req.Params)
+	}
+	if len(req.Headers) != 0 {
+		t.Errorf("Expected Content-Type to move to the body, got %+v", req.Headers)
+	}
+	if req.Body.ContentType == nil || *req.Body.ContentType != "application/json" || string(req.Body.Body) != `"{\"quantity\": <<quantity>>}"` {
+		t.Errorf("Unexpected body: %+v", req.Body)
+	}
+	if req.Auth == nil || req.Auth.AuthType != "bearer" || req.Auth.Token != "<<token>>" {
+		t.Errorf("Unexpected auth: %+v", req.Auth)
+	}
+	if req.TestScript != "lc.test('ok', () => {})" {
+		t.Errorf("Expected test script to be kept, got '%s'", req.TestScript)
+	}
+}
+
+func TestConvertBodyToHoppscotch(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        *api.BodyConfig
+		headers     []api.KeyValueEntry
+		contentType string
+		content     string
+	}{
+		{
+			name:        "raw defaults to text",
+			body:        &api.BodyConfig{Type: "raw", Content: "hello"},
+			contentType: "text/plain",
+			content:     `"hello"`,
+		},
+		{
+			name:        "urlencoded",
+			body:        &api.BodyConfig{Type: "raw", Content: "user=ada&note=a%20b"},
+			headers:     []api.KeyValueEntry{{Key: "content-type", Value: "application/x-www-form-urlencoded", Enabled: true}},
+			contentType: "application/x-www-form-urlencoded",
+			content:     `"user: ada\nnote: a b"`,
+		},
+		{
+			name: "form data without files",
+			body: &api.BodyConfig{Type: api.BodyTypeFormData, Content: []api.FormField{
+				{Key: "title", Value: "Lamp", Enabled: true},
+				{Key: "photo", Value: "lamp.png", Type: api.FormFieldFile, Enabled: true},
+			}},
+			contentType: "multipart/form-data",
+			content:     `[{"key":"title","value":"Lamp","active":true,"isFile":false}]`,
+		},
+		{
+			name:        "graphql",
+			body:        &api.BodyConfig{Type: api.BodyTypeGraphQL, Content: api.GraphQLBody{Query: "{ me { id } }"}.Content()},
+			contentType: "application/json",
+			content:     `"{\n  \"query\": \"{ me { id } }\"\n}"`,
+		},
+		{
+			name:    "binary left out",
+			body:    &api.BodyConfig{Type: api.BodyTypeBinary, Content: "/tmp/a.png"},
+			content: `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, rest := convertBodyToHoppscotch(tt.body, tt.headers)
+			contentType := ""
+			if body.ContentType != nil {
+				contentType = *body.ContentType
+			}
+			if contentType != tt.contentType || string(body.Body) != tt.content {
+				t.Errorf("got %s %s, want %s %s", contentType, body.Body, tt.contentType, tt.content)
+			}
+			if tt.contentType != "" && len(rest) != 0 {
+				t.Errorf("Expected Content-Type header to be removed, got %+v", rest)
+			}
+		})
+	}
+}
+
+func TestExportEnvironments(t *testing.T) {
+	env := &api.EnvironmentFile{
+		Name: "Staging",
+		Variables: map[string]*api.EnvironmentVariable{
+			"base_url": {Value: "https://{{region}}.example.com", Active: true},
+			"token":    {Value: "s3cret", Secret: true, Active: true},
+			"old":      {Value: "x", Active: false},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "out", "environments.json")
+	if err := ExportEnvironments([]*api.EnvironmentFile{env}, path); err != nil {
+		t.Fatalf("ExportEnvironments failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if DetectFileTypeFromBytes(data) != FileTypeEnvironment {
+		t.Errorf("Exported file should be detected as environments:\n%s", data)
+	}
+
+	var hes []Environment
+	if err := json.Unmarshal(data, &hes); err != nil {
+		t.Fatalf("Exported JSON is invalid: %v", err)
+	}
+	want := []Variable{
+		{Key: "base_url", Value: "https://<<region>>.example.com"},
+		{Key: "token", Secret: true},
+	}
+	if len(hes) != 1 || len(hes[0].Variables) != len(want) {
+		t.Fatalf("Unexpected environments: %+v", hes)
+	}
+	for i, w := range want {
+		if hes[0].Variables[i] != w {
+			t.Errorf("variable %d = %+v, want %+v", i, hes[0].Variables[i], w)
+		}
+	}
+}
+
+func TestExportCollections_RoundTrip(t *testing.T) {
+	original, err := ImportCollections(filepath.Join("testdata", "collections.json"))
+	if err != nil {
+		t.Fatalf("ImportCollections failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "collections.json")
+	if err := ExportCollections(original.Collections, path); err != nil {
+		t.Fatalf("ExportCollections failed: %v", err)
+	}
+	if fileType, _ := DetectFileType(path); fileType != FileTypeCollection {
+		t.Fatalf("Exported file detected as %s", fileType)
+	}
+
+	result, err := ImportCollections(path)
+	if err != nil {
+		t.Fatalf("ImportCollections of the export failed: %v", err)
+	}
+	if result.Summary.RequestsCount != original.Summary.RequestsCount || result.Summary.FoldersCount != original.Summary.FoldersCount {
+		t.Errorf("Round trip read back %d requests and %d folders, want %d and %d",
+			result.Summary.RequestsCount, result.Summary.FoldersCount, original.Summary.RequestsCount, original.Summary.FoldersCount)
+	}
+
+	var want, got []api.CollectionRequest
+	for i, col := range original.Collections {
+		want = append(want, col.Requests...)
+		got = append(got, result.Collections[i].Requests...)
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if g.Name != w.Name || g.Method != w.Method || g.URL != w.URL {
+			t.Errorf("request %d read back as %q %s %s, want %q %s %s", i, g.Name, g.Method, g.URL, w.Name, w.Method, w.URL)
+		}
+		if (w.Body == nil) != (g.Body == nil) || (w.Body != nil && (g.Body.Type != w.Body.Type || g.Body.Content != w.Body.Content)) {
+			t.Errorf("request %d body read back as %+v, want %+v", i, g.Body, w.Body)
+		}
+		if (w.Auth == nil) != (g.Auth == nil) || (w.Auth != nil && *g.Auth != *w.Auth) {
+			t.Errorf("request %d auth read back as %+v, want %+v", i, g.Auth, w.Auth)
+		}
+		if !strings.EqualFold(headerNames(g.Headers), headerNames(w.Headers)) {
+			t.Errorf("request %d headers read back as %+v, want %+v", i, g.Headers, w.Headers)
+		}
+	}
+}
+
+// headerNames returns the keys of headers, joined
+func headerNames(headers []api.KeyValueEntry) string {
+	var names []string
+	for _, h := range headers {
+		names = append(names, h.Key)
+	}
+	return strings.Join(names, ",")
+}
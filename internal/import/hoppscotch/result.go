@@ -0,0 +1,96 @@
+package hoppscotch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// ImportResult represents the result of an import operation. A Hoppscotch file holds
+// one collection or environment, or an array of them.
+type ImportResult struct {
+	Collections  []*api.CollectionFile  // Non-empty if collection import succeeded
+	Environments []*api.EnvironmentFile // Non-empty if environment import succeeded
+	Summary      ImportSummary
+}
+
+// HasWarnings returns true if there are warnings in the summary.
+func (r *ImportResult) HasWarnings() bool {
+	return len(r.Summary.Warnings) > 0
+}
+
+// FormatSummary returns a human-readable summary string.
+func (r *ImportResult) FormatSummary() string {
+	if len(r.Collections) == 0 && len(r.Environments) == 0 {
+		return "No import performed"
+	}
+	return r.Summary.format()
+}
+
+// ImportSummary contains statistics and messages from an import operation.
+type ImportSummary struct {
+	Names          []string // Names of the collections or environments imported
+	RequestsCount  int
+	FoldersCount   int
+	VariablesCount int // Variables of the environments imported
+	Warnings       []string
+}
+
+// AddWarningf adds a warning message to the summary.
+func (s *ImportSummary) AddWarningf(format string, args ...interface{}) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, args...))
+}
+
+// format returns the summary as `Imported "A", "B" - 3 requests, 1 folders - 2 warnings`
+func (s *ImportSummary) format() string {
+	names := make([]string, len(s.Names))
+	for i, name := range s.Names {
+		names[i] = fmt.Sprintf("\"%s\"", name)
+	}
+	parts := []string{"Imported " + strings.Join(names, ", ")}
+
+	var stats []string
+	if s.RequestsCount > 0 {
+		stats = append(stats, fmt.Sprintf("%d requests", s.RequestsCount))
+	}
+	if s.FoldersCount > 0 {
+		stats = append(stats, fmt.Sprintf("%d folders", s.FoldersCount))
+	}
+	if s.VariablesCount > 0 {
+		stats = append(stats, fmt.Sprintf("%d variables", s.VariablesCount))
+	}
+	if len(stats) > 0 {
+		parts = append(parts, strings.Join(stats, ", "))
+	}
+
+	if len(s.Warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d warnings", len(s.Warnings)))
+	}
+
+	return strings.Join(parts, " - ")
+}
+
+// FileType represents the detected type of a Hoppscotch file.
+type FileType int
+
+const (
+	// FileTypeUnknown indicates the file type could not be determined.
+	FileTypeUnknown FileType = iota
+	// FileTypeCollection indicates Hoppscotch collections.
+	FileTypeCollection
+	// FileTypeEnvironment indicates Hoppscotch environments.
+	FileTypeEnvironment
+)
+
+// String returns a string representation of the FileType.
+func (t FileType) String() string {
+	switch t {
+	case FileTypeCollection:
+		return "Collection"
+	case FileTypeEnvironment:
+		return "Environment"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,97 @@
+package hoppscotch
+
+import "encoding/json"
+
+// Collection represents a Hoppscotch REST collection, or a folder of one: folders are
+// collections nested in their parent.
+type Collection struct {
+	V         json.RawMessage `json:"v,omitempty"` // Schema version, a number
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Folders   []Collection    `json:"folders"`
+	Requests  []Request       `json:"requests"`
+	Auth      *Auth           `json:"auth,omitempty"`
+	Headers   []KeyValue      `json:"headers,omitempty"`
+	Variables []Variable      `json:"variables,omitempty"`
+}
+
+// Request represents a Hoppscotch REST request.
+type Request struct {
+	V                json.RawMessage `json:"v,omitempty"` // Schema version, a number as a string
+	ID               string          `json:"id,omitempty"`
+	Name             string          `json:"name"`
+	Method           string          `json:"method"`
+	Endpoint         string          `json:"endpoint"`
+	Params           []KeyValue      `json:"params"`
+	Headers          []KeyValue      `json:"headers"`
+	PreRequestScript string          `json:"preRequestScript"`
+	TestScript       string          `json:"testScript"`
+	Auth             *Auth           `json:"auth"`
+	Body             Body            `json:"body"`
+	RequestVariables []KeyValue      `json:"requestVariables,omitempty"`
+}
+
+// KeyValue is a query parameter, header or request variable.
+type KeyValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Active bool   `json:"active"`
+}
+
+// Auth is the authentication of a request, folder or collection. Requests and folders
+// with the "inherit" type use their parent's.
+type Auth struct {
+	AuthType   string `json:"authType"` // "none", "inherit", "basic", "bearer", "api-key", "oauth-2", ...
+	AuthActive bool   `json:"authActive"`
+	Token      string `json:"token,omitempty"`    // bearer
+	Username   string `json:"username,omitempty"` // basic
+	Password   string `json:"password,omitempty"` // basic
+	Key        string `json:"key,omitempty"`      // api-key
+	Value      string `json:"value,omitempty"`    // api-key
+	AddTo      string `json:"addTo,omitempty"`    // api-key: "Headers" or "Query params" ("HEADERS", "QUERY_PARAMS" in later versions)
+}
+
+// Body is the body of a request: text for most content types, form fields for
+// multipart/form-data. A nil content type means no body.
+type Body struct {
+	ContentType *string         `json:"contentType"`
+	Body        json.RawMessage `json:"body"`
+}
+
+// FormField is a field of a multipart/form-data body. The value of file fields holds
+// the files picked in the browser, which exports do not carry.
+type FormField struct {
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+	Active bool            `json:"active"`
+	IsFile bool            `json:"isFile"`
+}
+
+// Environment represents a Hoppscotch environment.
+type Environment struct {
+	V         json.RawMessage `json:"v,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Variables []Variable      `json:"variables"`
+}
+
+// Variable is a variable of an environment or collection. Older versions hold its value
+// in Value, later ones in InitialValue and CurrentValue.
+type Variable struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	InitialValue string `json:"initialValue,omitempty"`
+	CurrentValue string `json:"currentValue,omitempty"`
+	Secret       bool   `json:"secret"`
+}
+
+// value returns the value of the variable, whichever version wrote it
+func (v Variable) value() string {
+	switch {
+	case v.Value != "":
+		return v.Value
+	case v.CurrentValue != "":
+		return v.CurrentValue
+	}
+	return v.InitialValue
+}
@@ -0,0 +1,49 @@
+// Package httpfile provides import and export functionality for .http and .rest files,
+// the request files of the VS Code REST Client and Thunder Client extensions.
+//
+// A file holds requests separated by ### lines, each a request line (METHOD URL
+// [HTTP/1.1]), headers up to a blank line, then a body:
+//
+//	@base_url = https://api.example.com
+//
+//	### Get user
+//	GET {{base_url}}/users/1
+//	Accept: application/json
+//
+//	### Create user
+//	# @name createUser
+//	POST {{base_url}}/users
+//	Content-Type: application/json
+//
+//	{"name": "Ada"}
+//
+// # Import Example
+//
+//	result, err := httpfile.ImportFile("/path/to/api.http")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, w := range result.Warnings {
+//	    log.Printf("Warning: %s", w)
+//	}
+//	// Use result.Collection
+//
+// # Export Example
+//
+//	text := httpfile.Format(collection.Variables, request)
+//
+// # Supported Features
+//
+//   - Request separators, with the text after ### naming the request
+//   - Named requests (# @name), # @no-redirect and # @prompt
+//   - File variables (@name = value), imported as collection variables
+//   - Query lines continuing the URL (?page=1, &size=10)
+//   - JSON, text, GraphQL (X-Request-Type: GraphQL) and file (< ./path) bodies
+//   - cURL commands in place of a request
+//
+// # Unsupported Features
+//
+// The following generate warnings but don't prevent import:
+//   - Request variables ({{login.response.body.$.token}}), left as is
+//   - System variables taking arguments ({{$randomInt 1 10}}, {{$dotenv NAME}})
+package httpfile
@@ -0,0 +1,197 @@
+package httpfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// multipartBoundary separates the parts of exported form-data bodies
+const multipartBoundary = "LazyCurlFormBoundary"
+
+// Format returns requests in .http format, after the definitions of variables as file
+// variables, sorted by name. Variables are kept as {{name}} references; prompt variables
+// become # @prompt comments.
+func Format(variables map[string]string, requests ...*api.CollectionRequest) string {
+	var b strings.Builder
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "@%s = %s\n", name, variables[name])
+	}
+	for i, req := range requests {
+		if i > 0 || len(names) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(FormatRequest(req))
+	}
+	return b.String()
+}
+
+// FormatRequest returns req in .http format: a ### line with its name, its request
+// line, enabled headers and auth as headers, then its body. API keys sent in the query
+// are added to the URL.
+func FormatRequest(req *api.CollectionRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n", strings.ReplaceAll(req.Name, "\n", " "))
+	if req.NoFollowRedirects {
+		b.WriteString("# @no-redirect\n")
+	}
+	for _, name := range api.FindPromptVariables(req) {
+		fmt.Fprintf(&b, "# @prompt %s\n", name)
+	}
+
+	headers := make([]api.KeyValueEntry, 0, len(req.Headers))
+	for _, h := range req.Headers {
+		if h.Enabled && h.Key != "" {
+			headers = append(headers, h)
+		}
+	}
+	url := req.URL
+	if header, query := authHeader(req.Auth); header.Key != "" && !hasHeader(headers, header.Key) {
+		headers = append(headers, header)
+	} else if query != "" {
+		if strings.Contains(url, "?") {
+			url += "&" + query
+		} else {
+			url += "?" + query
+		}
+	}
+	body, contentType := formatBody(req.Body)
+	if contentType != "" && !hasHeader(headers, "Content-Type") {
+		headers = append(headers, api.KeyValueEntry{Key: "Content-Type", Value: contentType})
+	}
+	if req.Body != nil && req.Body.Type == api.BodyTypeGraphQL {
+		headers = append(headers, api.KeyValueEntry{Key: "X-Request-Type", Value: "GraphQL"})
+	}
+
+	method := string(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+	fmt.Fprintf(&b, "%s %s\n", method, usePlainVariables(url))
+	for _, h := range headers {
+		fmt.Fprintf(&b, "%s: %s\n", h.Key, usePlainVariables(h.Value))
+	}
+	if body != "" {
+		b.WriteString("\n" + usePlainVariables(body) + "\n")
+	}
+	return b.String()
+}
+
+// authHeader returns the header sending auth, or the query parameter of an API key
+// sent in the query. JWT auth signs its token on send and has neither.
+func authHeader(auth *api.AuthConfig) (api.KeyValueEntry, string) {
+	if auth == nil {
+		return api.KeyValueEntry{}, ""
+	}
+	switch auth.Type {
+	case "bearer":
+		prefix := auth.Prefix
+		if prefix == "" {
+			prefix = "Bearer"
+		}
+		return api.KeyValueEntry{Key: "Authorization", Value: prefix + " " + auth.Token}, ""
+	case "basic":
+		// REST Client encodes "user:password" itself
+		return api.KeyValueEntry{Key: "Authorization", Value: "Basic " + auth.Username + ":" + auth.Password}, ""
+	case "api_key":
+		if auth.APIKeyName == "" {
+			return api.KeyValueEntry{}, ""
+		}
+		if auth.APIKeyLocation == "query" {
+			return api.KeyValueEntry{}, auth.APIKeyName + "=" + auth.APIKeyValue
+		}
+		return api.KeyValueEntry{Key: auth.APIKeyName, Value: auth.APIKeyValue}, ""
+	}
+	return api.KeyValueEntry{}, ""
+}
+
+// formatBody returns body as written in a .http file, with the content type it needs
+// when the request has none
+func formatBody(body *api.BodyConfig) (string, string) {
+	if body == nil || body.Content == nil {
+		return "", ""
+	}
+	switch body.Type {
+	case api.BodyTypeGraphQL:
+		gql := api.ParseGraphQLBody(body.Content)
+		if vars := strings.TrimSpace(gql.Variables); vars != "" {
+			return strings.TrimSpace(gql.Query) + "\n\n" + vars, ""
+		}
+		return strings.TrimSpace(gql.Query), ""
+	case api.BodyTypeBinary:
+		path, _ := body.Content.(string)
+		if path == "" {
+			return "", ""
+		}
+		return "< " + path, "application/octet-stream"
+	case api.BodyTypeFormData:
+		var b strings.Builder
+		for _, f := range api.ParseFormData(body.Content) {
+			if !f.Enabled {
+				continue
+			}
+			fmt.Fprintf(&b, "--%s\n", multipartBoundary)
+			if f.IsFile() {
+				fmt.Fprintf(&b, "Content-Disposition: form-data; name=\"%s\"; filename=\"%s\"\n\n< %s\n", f.Key, fileName(f.Value), f.Value)
+			} else {
+				fmt.Fprintf(&b, "Content-Disposition: form-data; name=\"%s\"\n\n%s\n", f.Key, f.Value)
+			}
+		}
+		if b.Len() == 0 {
+			return "", ""
+		}
+		fmt.Fprintf(&b, "--%s--", multipartBoundary)
+		return b.String(), "multipart/form-data; boundary=" + multipartBoundary
+	}
+
+	content, ok := body.Content.(string)
+	if !ok {
+		data, err := json.MarshalIndent(body.Content, "", "  ")
+		if err != nil {
+			return "", ""
+		}
+		content = string(data)
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", ""
+	}
+	if body.Type == "json" {
+		return strings.TrimRight(content, "\n"), "application/json"
+	}
+	return strings.TrimRight(content, "\n"), ""
+}
+
+// fileName returns the last element of a file path, with / or \ separators
+func fileName(path string) string {
+	return path[strings.LastIndexAny(path, `/\`)+1:]
+}
+
+// hasHeader reports whether headers set key, case-insensitively
+func hasHeader(headers []api.KeyValueEntry, key string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// usePlainVariables turns references to prompt variables into plain references, the
+// # @prompt comments of the request asking for them
+func usePlainVariables(s string) string {
+	return variableRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := variableRef.FindStringSubmatch(ref)[1]
+		if !api.IsPromptVariable(name) {
+			return ref
+		}
+		return "{{" + strings.TrimPrefix(name, api.PromptVariablePrefix) + "}}"
+	})
+}
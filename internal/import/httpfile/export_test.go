@@ -0,0 +1,135 @@
+package httpfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestFormatRequest(t *testing.T) {
+	req := &api.CollectionRequest{
+		Name:   "Create order",
+		Method: "POST",
+		URL:    "{{base_url}}/orders",
+		Headers: []api.KeyValueEntry{
+			{Key: "Accept", Value: "application/json", Enabled: true},
+			{Key: "X-Debug", Value: "1", Enabled: false},
+		},
+		Auth:              &api.AuthConfig{Type: "bearer", Token: "{{token}}"},
+		Body:              &api.BodyConfig{Type: "json", Content: "{\"quantity\": {{?quantity}}}\n"},
+		NoFollowRedirects: true,
+	}
+	got := FormatRequest(req)
+	want := "### Create order\n" +
+		"# @no-redirect\n" +
+		"# @prompt quantity\n" +
+		"POST {{base_url}}/orders\n" +
+		"Accept: application/json\n" +
+		"Authorization: Bearer {{token}}\n" +
+		"Content-Type: application/json\n" +
+		"\n" +
+		"{\"quantity\": {{quantity}}}\n"
+	if got != want {
+		t.Errorf("FormatRequest() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatRequest_Bodies(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *api.CollectionRequest
+		want []string
+	}{
+		{
+			name: "graphql",
+			req: &api.CollectionRequest{Name: "q", Method: "POST", URL: "http://x/graphql",
+				Body: &api.BodyConfig{Type: api.BodyTypeGraphQL, Content: api.GraphQLBody{Query: "{ me { id } }", Variables: `{"a": 1}`}.Content()}},
+			want: []string{"X-Request-Type: GraphQL\n\n{ me { id } }\n\n{\n  \"a\": 1\n}\n"},
+		},
+		{
+			name: "binary",
+			req: &api.CollectionRequest{Name: "b", Method: "PUT", URL: "http://x",
+				Body: &api.BodyConfig{Type: api.BodyTypeBinary, Content: "/tmp/a.png"}},
+			want: []string{"Content-Type: application/octet-stream\n\n< /tmp/a.png\n"},
+		},
+		{
+			name: "form-data",
+			req: &api.CollectionRequest{Name: "f", Method: "POST", URL: "http://x",
+				Body: &api.BodyConfig{Type: api.BodyTypeFormData, Content: []api.FormField{
+					{Key: "title", Value: "Lamp", Enabled: true},
+					{Key: "photo", Value: "img/lamp.png", Type: api.FormFieldFile, Enabled: true},
+					{Key: "draft", Value: "1", Enabled: false},
+				}}},
+			want: []string{
+				"Content-Type: multipart/form-data; boundary=LazyCurlFormBoundary\n",
+				"name=\"title\"\n\nLamp\n",
+				"name=\"photo\"; filename=\"lamp.png\"\n\n< img/lamp.png\n--LazyCurlFormBoundary--\n",
+			},
+		},
+		{
+			name: "api key in query",
+			req: &api.CollectionRequest{Name: "k", Method: "GET", URL: "http://x/items?page=1",
+				Auth: &api.AuthConfig{Type: "api_key", APIKeyName: "key", APIKeyValue: "{{api_key}}", APIKeyLocation: "query"}},
+			want: []string{"GET http://x/items?page=1&key={{api_key}}\n"},
+		},
+		{
+			name: "basic auth",
+			req: &api.CollectionRequest{Name: "a", Method: "GET", URL: "http://x",
+				Auth: &api.AuthConfig{Type: "basic", Username: "ada", Password: "s3cret"}},
+			want: []string{"Authorization: Basic ada:s3cret\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatRequest(tt.req)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("FormatRequest() =\n%s\nwant it to contain\n%s", got, want)
+				}
+			}
+			if strings.Contains(got, "draft") {
+				t.Errorf("disabled fields should be left out, got\n%s", got)
+			}
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	requests := []*api.CollectionRequest{
+		{Name: "Login", Method: "POST", URL: "{{base_url}}/login",
+			Auth: &api.AuthConfig{Type: "basic", Username: "{{user}}", Password: "{{password}}"},
+			Body: &api.BodyConfig{Type: "json", Content: `{"remember": true}`}},
+		{Name: "Search", Method: "POST", URL: "{{base_url}}/graphql",
+			Body: &api.BodyConfig{Type: api.BodyTypeGraphQL, Content: api.GraphQLBody{Query: "{ items { id } }"}.Content()}},
+		{Name: "Item", Method: "GET", URL: "{{base_url}}/items/{{?id}}", NoFollowRedirects: true},
+	}
+	text := Format(map[string]string{"base_url": "https://shop.example.com"}, requests...)
+	if !strings.HasPrefix(text, "@base_url = https://shop.example.com\n\n### Login\n") {
+		t.Errorf("Format() should start with the file variables, got\n%s", text)
+	}
+
+	result, err := Parse([]byte(text), "shop", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+	col := result.Collection
+	if col.Variables["base_url"] != "https://shop.example.com" || len(col.Requests) != len(requests) {
+		t.Fatalf("read back %v with %d requests", col.Variables, len(col.Requests))
+	}
+	for i, want := range requests {
+		got := col.Requests[i]
+		if got.Name != want.Name || got.Method != want.Method || got.URL != want.URL || got.NoFollowRedirects != want.NoFollowRedirects {
+			t.Errorf("request %d read back as %q %s %s, want %q %s %s", i, got.Name, got.Method, got.URL, want.Name, want.Method, want.URL)
+		}
+		if (want.Body == nil) != (got.Body == nil) || (want.Body != nil && got.Body.Type != want.Body.Type) {
+			t.Errorf("request %d body read back as %+v, want %+v", i, got.Body, want.Body)
+		}
+	}
+	if auth := col.Requests[0].Auth; auth == nil || auth.Username != "{{user}}" || auth.Password != "{{password}}" {
+		t.Errorf("basic auth read back as %+v", auth)
+	}
+}
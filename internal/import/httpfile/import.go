@@ -0,0 +1,331 @@
+package httpfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// ImportResult is a collection imported from a .http file, with what could not be
+// imported as is
+type ImportResult struct {
+	Collection *api.CollectionFile
+	Warnings   []string
+}
+
+// FormatSummary returns a human-readable summary string.
+func (r *ImportResult) FormatSummary() string {
+	parts := []string{
+		fmt.Sprintf("Imported \"%s\"", r.Collection.Name),
+		fmt.Sprintf("%d requests", len(r.Collection.Requests)),
+	}
+	if len(r.Warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d warnings", len(r.Warnings)))
+	}
+	return strings.Join(parts, " - ")
+}
+
+// addWarningf adds a warning message to the result.
+func (r *ImportResult) addWarningf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// IsHTTPFile reports whether the file at path is a .http or .rest file by its extension
+func IsHTTPFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".http" || ext == ".rest"
+}
+
+// ImportFile imports the requests of a .http or .rest file into a collection named
+// after the file. Relative paths of file bodies are resolved against its directory.
+func ImportFile(filePath string) (*ImportResult, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	return Parse(data, name, filepath.Dir(filePath))
+}
+
+var (
+	// separator matches the lines starting a request, capturing its title
+	separator = regexp.MustCompile(`^###+\s*(.*)$`)
+	// fileVariable matches "@name = value" variable definitions
+	fileVariable = regexp.MustCompile(`^@([A-Za-z_][A-Za-z0-9_.-]*)\s*=\s*(.*)$`)
+	// metadata matches "# @name value" and "// @name value" comments
+	metadata = regexp.MustCompile(`^(?:#|//)\s*@([A-Za-z-]+)\s*(.*)$`)
+	// httpVersion matches the protocol ending a request line
+	httpVersion = regexp.MustCompile(`\s+HTTP/[0-9.]+$`)
+	// variableRef matches the {{...}} references of a request
+	variableRef = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+	// requestVariable matches references to the request or response of a named request
+	requestVariable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*\.(request|response)\.`)
+)
+
+// methods are the methods a request line can start with
+var methods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true,
+	"OPTIONS": true, "TRACE": true, "CONNECT": true,
+}
+
+// Parse imports the requests of .http data into a collection called name. File
+// variables become collection variables; relative paths of file bodies are resolved
+// against dir, kept as is when dir is empty. Returns an error when data has no request.
+func Parse(data []byte, name, dir string) (*ImportResult, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff") // Byte order mark
+	lines := strings.Split(text, "\n")
+
+	result := &ImportResult{Collection: &api.CollectionFile{Name: name}}
+	start, title := 0, ""
+	for i, line := range lines {
+		if m := separator.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			result.parseBlock(lines[start:i], start+1, title, dir)
+			start, title = i+1, strings.TrimSpace(m[1])
+		}
+	}
+	result.parseBlock(lines[start:], start+1, title, dir)
+
+	if len(result.Collection.Requests) == 0 {
+		return nil, fmt.Errorf("no request found in %s", name)
+	}
+	return result, nil
+}
+
+// parseBlock adds the request of the lines between two separators, the first at line
+// number first, to the collection. Lines before the request line hold comments and
+// file variables; a block without request line only defines variables.
+func (r *ImportResult) parseBlock(lines []string, first int, title, dir string) {
+	var (
+		name       string
+		prompts    []string
+		noRedirect bool
+	)
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			m := metadata.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			switch m[1] {
+			case "name":
+				name = strings.TrimSpace(m[2])
+			case "prompt":
+				if fields := strings.Fields(m[2]); len(fields) > 0 {
+					prompts = append(prompts, fields[0])
+				}
+			case "no-redirect":
+				noRedirect = true
+			}
+			continue
+		case strings.HasPrefix(line, "@"):
+			if m := fileVariable.FindStringSubmatch(line); m != nil {
+				if r.Collection.Variables == nil {
+					r.Collection.Variables = make(map[string]string)
+				}
+				r.Collection.Variables[m[1]] = strings.TrimSpace(m[2])
+			} else {
+				r.addWarningf("line %d: invalid variable definition %q", first+i, line)
+			}
+			continue
+		}
+		break
+	}
+	if i == len(lines) {
+		return
+	}
+	if title != "" {
+		name = title
+	}
+
+	var req *api.CollectionRequest
+	if strings.HasPrefix(strings.TrimSpace(lines[i]), "curl ") {
+		curl, err := api.ParseCurlCommand(strings.TrimSpace(strings.Join(lines[i:], "\n")))
+		if err != nil {
+			r.addWarningf("line %d: skipped cURL command: %s", first+i, err)
+			return
+		}
+		req = curl
+	} else {
+		req = r.parseRequest(lines[i:], first+i, dir)
+	}
+	if name != "" {
+		req.Name = name
+	}
+	req.NoFollowRedirects = req.NoFollowRedirects || noRedirect
+	for _, prompt := range prompts {
+		usePromptVariable(req, prompt)
+	}
+	r.checkVariables(req)
+	r.Collection.Requests = append(r.Collection.Requests, *req)
+}
+
+// parseRequest returns the request of lines starting with its request line, at line
+// number first: query lines continuing the URL, headers up to a blank line, then the
+// body, trailing blank lines left out
+func (r *ImportResult) parseRequest(lines []string, first int, dir string) *api.CollectionRequest {
+	requestLine := httpVersion.ReplaceAllString(strings.TrimSpace(lines[0]), "")
+	method, url := "GET", requestLine
+	if verb, rest, ok := strings.Cut(requestLine, " "); ok && methods[strings.ToUpper(verb)] {
+		method, url = strings.ToUpper(verb), strings.TrimSpace(rest)
+	} else if methods[strings.ToUpper(requestLine)] {
+		method, url = strings.ToUpper(requestLine), ""
+		r.addWarningf("line %d: %s request without URL", first, method)
+	}
+
+	i := 1
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		url += line
+	}
+
+	req := &api.CollectionRequest{
+		ID:     api.GenerateID(),
+		Name:   method + " " + url,
+		Method: api.HTTPMethod(method),
+		URL:    url,
+	}
+	graphql := false
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			r.addWarningf("line %d: invalid header %q", first+i, line)
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if strings.EqualFold(key, "X-Request-Type") && strings.EqualFold(value, "GraphQL") {
+			graphql = true
+			continue
+		}
+		if auth := basicAuth(key, value); auth != nil {
+			req.Auth = auth
+			continue
+		}
+		req.Headers = append(req.Headers, api.KeyValueEntry{Key: key, Value: value, Enabled: true})
+	}
+
+	body := lines[min(i, len(lines)):]
+	for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+		body = body[:len(body)-1]
+	}
+	if len(body) > 0 {
+		req.Body = parseBody(strings.Join(body, "\n"), req.Headers, graphql, dir)
+	}
+	return req
+}
+
+// parseBody returns the body of a request with headers: GraphQL when the request says so,
+// a file for "< path", JSON when its content type or content is, text otherwise
+func parseBody(content string, headers []api.KeyValueEntry, graphql bool, dir string) *api.BodyConfig {
+	trimmed := strings.TrimSpace(content)
+	if graphql {
+		// The query, then its variables after a blank line
+		query, variables, _ := strings.Cut(trimmed, "\n\n")
+		gql := api.GraphQLBody{Query: strings.TrimSpace(query), Variables: strings.TrimSpace(variables)}
+		return &api.BodyConfig{Type: api.BodyTypeGraphQL, Content: gql.Content()}
+	}
+	if !strings.Contains(trimmed, "\n") && strings.HasPrefix(trimmed, "<") {
+		path := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(trimmed, "<"), "@"))
+		if dir != "" && !filepath.IsAbs(path) && !strings.Contains(path, "{{") {
+			path = filepath.Join(dir, path)
+		}
+		return &api.BodyConfig{Type: api.BodyTypeBinary, Content: path}
+	}
+	bodyType := "raw"
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, "Content-Type") && strings.Contains(strings.ToLower(h.Value), "json") {
+			bodyType = "json"
+		}
+	}
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		bodyType = "json"
+	}
+	return &api.BodyConfig{Type: bodyType, Content: content}
+}
+
+// basicAuth returns the basic auth of an Authorization header giving the credentials
+// in clear, as "Basic user:password" or "Basic user password", which REST Client
+// encodes on send. Encoded credentials are left to the header.
+func basicAuth(key, value string) *api.AuthConfig {
+	credentials, ok := strings.CutPrefix(value, "Basic ")
+	if !strings.EqualFold(key, "Authorization") || !ok {
+		return nil
+	}
+	credentials = strings.TrimSpace(credentials)
+	username, password, ok := strings.Cut(credentials, ":")
+	if !ok {
+		username, password, ok = strings.Cut(credentials, " ")
+	}
+	if !ok {
+		return nil
+	}
+	return &api.AuthConfig{Type: "basic", Username: username, Password: strings.TrimSpace(password)}
+}
+
+// usePromptVariable turns the references to name of req into references to the prompt
+// variable asked for on send
+func usePromptVariable(req *api.CollectionRequest, name string) {
+	ref := regexp.MustCompile(`\{\{\s*` + regexp.QuoteMeta(name) + `\s*\}\}`)
+	replace := func(s string) string {
+		return ref.ReplaceAllString(s, "{{"+api.PromptVariablePrefix+name+"}}")
+	}
+	req.URL = replace(req.URL)
+	for i := range req.Headers {
+		req.Headers[i].Value = replace(req.Headers[i].Value)
+	}
+	if req.Body != nil {
+		if content, ok := req.Body.Content.(string); ok {
+			req.Body.Content = replace(content)
+		}
+	}
+}
+
+// checkVariables warns about the references of req LazyCurl cannot resolve: variables
+// of other requests, and system variables taking arguments
+func (r *ImportResult) checkVariables(req *api.CollectionRequest) {
+	text := req.URL
+	for _, h := range req.Headers {
+		text += "\n" + h.Value
+	}
+	if req.Body != nil {
+		if content, ok := req.Body.Content.(string); ok {
+			text += "\n" + content
+		}
+	}
+	warned := make(map[string]bool)
+	for _, m := range variableRef.FindAllStringSubmatch(text, -1) {
+		ref := m[1]
+		if warned[ref] {
+			continue
+		}
+		switch {
+		case requestVariable.MatchString(ref):
+			r.addWarningf("%s: request variable {{%s}} is not supported, use an extraction rule instead", req.Name, ref)
+		case strings.HasPrefix(ref, "$") && strings.ContainsAny(ref, " \t"):
+			r.addWarningf("%s: system variable {{%s}} is not supported", req.Name, ref)
+		default:
+			continue
+		}
+		warned[ref] = true
+	}
+}
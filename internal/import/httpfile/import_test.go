@@ -0,0 +1,120 @@
+package httpfile
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestImportFile(t *testing.T) {
+	result, err := ImportFile(filepath.Join("testdata", "shop.http"))
+	if err != nil {
+		t.Fatalf("ImportFile() error = %v", err)
+	}
+	col := result.Collection
+	if col.Name != "shop" {
+		t.Errorf("collection name = %q, want shop", col.Name)
+	}
+	if col.Variables["base_url"] != "https://shop.example.com" || col.Variables["token"] != "abc123" {
+		t.Errorf("file variables = %v", col.Variables)
+	}
+
+	want := []struct {
+		name, method, url, bodyType string
+	}{
+		{"List products", "GET", "{{base_url}}/products?page=1&size=20", ""},
+		{"Login", "POST", "{{base_url}}/login", "json"},
+		{"createOrder", "POST", "{{base_url}}/orders", "json"},
+		{"Upload picture", "PUT", "{{base_url}}/products/1/picture", api.BodyTypeBinary},
+		{"Search", "POST", "{{base_url}}/graphql", api.BodyTypeGraphQL},
+		{"Ping", "GET", "https://shop.example.com/ping?id={{$randomInt 1 100}}", ""},
+		{"Import with cURL", "DELETE", "https://shop.example.com/cache", ""},
+	}
+	if len(col.Requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(col.Requests), len(want))
+	}
+	for i, w := range want {
+		req := col.Requests[i]
+		bodyType := ""
+		if req.Body != nil {
+			bodyType = req.Body.Type
+		}
+		if req.Name != w.name || string(req.Method) != w.method || req.URL != w.url || bodyType != w.bodyType {
+			t.Errorf("request %d = %q %s %s (%s body), want %q %s %s (%s body)", i, req.Name, req.Method, req.URL, bodyType, w.name, w.method, w.url, w.bodyType)
+		}
+		if req.ID == "" {
+			t.Errorf("request %d has no ID", i)
+		}
+	}
+
+	login := col.Requests[1]
+	if content := login.Body.Content.(string); !strings.HasPrefix(content, "{\n") || !strings.HasSuffix(content, "}") {
+		t.Errorf("login body = %q, want the JSON without trailing blank lines", content)
+	}
+
+	order := col.Requests[2]
+	if !order.NoFollowRedirects {
+		t.Error("# @no-redirect should stop following redirects")
+	}
+	if content := order.Body.Content.(string); !strings.Contains(content, "{{?quantity}}") {
+		t.Errorf("# @prompt should turn {{quantity}} into a prompt variable, got %q", content)
+	}
+
+	upload := col.Requests[3]
+	if upload.Body.Content != filepath.Join("testdata", "picture.png") {
+		t.Errorf("file body = %v, want the path resolved against the file directory", upload.Body.Content)
+	}
+	if upload.Auth == nil || upload.Auth.Type != "basic" || upload.Auth.Username != "ada" || upload.Auth.Password != "{{password}}" {
+		t.Errorf("Basic user:password header should become basic auth, got %+v", upload.Auth)
+	}
+	if len(upload.Headers) != 1 {
+		t.Errorf("upload headers = %+v, want Content-Type only", upload.Headers)
+	}
+
+	gql := api.ParseGraphQLBody(col.Requests[4].Body.Content)
+	if !strings.HasPrefix(gql.Query, "query Search") || !strings.Contains(gql.Variables, "lamp") {
+		t.Errorf("GraphQL body = %+v", gql)
+	}
+	if len(col.Requests[4].Headers) != 0 {
+		t.Errorf("X-Request-Type should be dropped, got %+v", col.Requests[4].Headers)
+	}
+
+	if len(result.Warnings) != 2 {
+		t.Fatalf("warnings = %v, want 2", result.Warnings)
+	}
+	if !strings.Contains(result.Warnings[0], "login.response.body") || !strings.Contains(result.Warnings[1], "$randomInt") {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+	if got := result.FormatSummary(); got != `Imported "shop" - 7 requests - 2 warnings` {
+		t.Errorf("FormatSummary() = %q", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := Parse([]byte("@host = localhost\n# nothing else\n"), "empty", ""); err == nil {
+		t.Error("Parse() of a file without request should fail")
+	}
+	result, err := Parse([]byte("GET http://localhost\nnot a header\n"), "bad", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "line 2: invalid header") {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+}
+
+func TestIsHTTPFile(t *testing.T) {
+	for path, want := range map[string]bool{
+		"api.http":          true,
+		"dir/API.REST":      true,
+		"api.json":          false,
+		"http":              false,
+		"requests.http.bak": false,
+	} {
+		if got := IsHTTPFile(path); got != want {
+			t.Errorf("IsHTTPFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
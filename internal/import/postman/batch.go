@@ -0,0 +1,158 @@
+package postman
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BatchFile is the import of one file of a batch.
+type BatchFile struct {
+	Path   string
+	Result *ImportResult // Nil when the import failed
+	Error  error
+}
+
+// BatchResult is the import of several files in one operation.
+type BatchResult struct {
+	Files   []BatchFile // Postman collections and environments, in path order
+	Skipped []string    // Files that are neither a Postman collection nor an environment
+}
+
+// IsBatch reports whether path names several files: a directory or a glob pattern.
+func IsBatch(path string) bool {
+	if info, err := os.Stat(path); err == nil {
+		return info.IsDir()
+	}
+	return strings.ContainsAny(path, "*?[")
+}
+
+// ExpandBatch returns the files of a batch in path order: the .json files of a directory
+// and its subdirectories, hidden ones excepted, or the files matching a glob pattern.
+func ExpandBatch(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", path, err)
+		}
+		var files []string
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				files = append(files, match)
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no file matches %s", path)
+		}
+		return files, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != path && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".json") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .json file in %s", path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ImportBatch imports each Postman collection and environment among files. A file that
+// fails to import does not stop the others.
+func ImportBatch(files []string) *BatchResult {
+	batch := &BatchResult{}
+	for _, path := range files {
+		fileType, err := DetectFileType(path)
+		if err != nil {
+			batch.Files = append(batch.Files, BatchFile{Path: path, Error: err})
+			continue
+		}
+		var result *ImportResult
+		switch fileType {
+		case FileTypeCollection:
+			result, err = ImportCollection(path)
+		case FileTypeEnvironment:
+			result, err = ImportEnvironment(path)
+		default:
+			batch.Skipped = append(batch.Skipped, path)
+			continue
+		}
+		batch.Files = append(batch.Files, BatchFile{Path: path, Result: result, Error: err})
+	}
+	return batch
+}
+
+// Collections returns the imported collections, in path order.
+func (b *BatchResult) Collections() []BatchFile {
+	return b.filter(func(f BatchFile) bool { return f.Error == nil && f.Result.Collection != nil })
+}
+
+// Environments returns the imported environments, in path order.
+func (b *BatchResult) Environments() []BatchFile {
+	return b.filter(func(f BatchFile) bool { return f.Error == nil && f.Result.Environment != nil })
+}
+
+// Failed returns the files that failed to import.
+func (b *BatchResult) Failed() []BatchFile {
+	return b.filter(func(f BatchFile) bool { return f.Error != nil })
+}
+
+// filter returns the files of the batch that keep returns true for.
+func (b *BatchResult) filter(keep func(BatchFile) bool) []BatchFile {
+	var files []BatchFile
+	for _, f := range b.Files {
+		if keep(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// Warnings returns the warnings of all imported files, each prefixed with its file name.
+func (b *BatchResult) Warnings() []string {
+	var warnings []string
+	for _, f := range b.Files {
+		if f.Error != nil {
+			continue
+		}
+		for _, w := range f.Result.Summary.Warnings {
+			warnings = append(warnings, filepath.Base(f.Path)+": "+w)
+		}
+	}
+	return warnings
+}
+
+// FormatSummary returns a one-line summary of the batch.
+func (b *BatchResult) FormatSummary() string {
+	parts := []string{fmt.Sprintf("Imported %d collections, %d environments", len(b.Collections()), len(b.Environments()))}
+	if len(b.Skipped) > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", len(b.Skipped)))
+	}
+	if failed := len(b.Failed()); failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if warnings := len(b.Warnings()); warnings > 0 {
+		parts = append(parts, fmt.Sprintf("%d warnings", warnings))
+	}
+	return strings.Join(parts, " - ")
+}
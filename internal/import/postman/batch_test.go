@@ -0,0 +1,85 @@
+package postman
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportBatch_Directory(t *testing.T) {
+	dir := t.TempDir()
+	copyFile := func(name, to string) {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, to)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, to), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	copyFile("simple_collection.json", "simple_collection.json")
+	copyFile("with_scripts.json", "nested/with_scripts.json")
+	copyFile("simple_environment.json", "env/dev.json")
+	copyFile("not_postman.json", "other.json")
+	copyFile("simple_collection.json", ".hidden/ignored.json")
+	copyFile("simple_collection.json", "README.md")
+	unnamed := `{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`
+	if err := os.WriteFile(filepath.Join(dir, "unnamed.json"), []byte(unnamed), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsBatch(dir) || IsBatch(filepath.Join(dir, "other.json")) {
+		t.Error("IsBatch() should only report directories and patterns")
+	}
+	files, err := ExpandBatch(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 {
+		t.Fatalf("ExpandBatch() = %v, want the 5 .json files outside hidden directories", files)
+	}
+
+	batch := ImportBatch(files)
+	if got := len(batch.Collections()); got != 2 {
+		t.Errorf("Collections() = %d, want 2", got)
+	}
+	if envs := batch.Environments(); len(envs) != 1 || envs[0].Result.Environment.Name != "Development" {
+		t.Errorf("Environments() = %+v, want Development", envs)
+	}
+	if len(batch.Skipped) != 1 || filepath.Base(batch.Skipped[0]) != "other.json" {
+		t.Errorf("Skipped = %v, want other.json", batch.Skipped)
+	}
+	if failed := batch.Failed(); len(failed) != 1 || filepath.Base(failed[0].Path) != "unnamed.json" {
+		t.Errorf("Failed() = %+v, want unnamed.json", failed)
+	}
+	for _, w := range batch.Warnings() {
+		if !strings.HasPrefix(w, "with_scripts.json: ") {
+			t.Errorf("warnings should be prefixed with their file, got %q", w)
+		}
+	}
+	if summary := batch.FormatSummary(); !strings.HasPrefix(summary, "Imported 2 collections, 1 environments - 1 skipped - 1 failed") {
+		t.Errorf("FormatSummary() = %q", summary)
+	}
+}
+
+func TestExpandBatch_Glob(t *testing.T) {
+	pattern := filepath.Join("testdata", "simple_*.json")
+	if !IsBatch(pattern) {
+		t.Fatal("a glob pattern should be a batch")
+	}
+	files, err := ExpandBatch(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("ExpandBatch(%q) = %v, want 2 files", pattern, files)
+	}
+	if _, err := ExpandBatch(filepath.Join("testdata", "missing_*.json")); err == nil {
+		t.Error("a pattern matching no file should fail")
+	}
+}
@@ -64,7 +64,10 @@ func convertCollection(pc *Collection) (*api.CollectionFile, *ImportSummary) {
 
 	collection := &api.CollectionFile{
 		Name:        pc.Info.Name,
-		Description: pc.Info.Description,
+		Description: string(pc.Info.Description),
+	}
+	if len(pc.Event) > 0 {
+		collection.Scripts = convertScripts(pc.Event, summary, "Collection '"+pc.Info.Name+"'")
 	}
 
 	// Convert items (requests and folders)
@@ -87,7 +90,10 @@ func convertFolder(item Item, summary *ImportSummary) api.Folder {
 
 	folder := api.Folder{
 		Name:        item.Name,
-		Description: item.Description,
+		Description: string(item.Description),
+	}
+	if len(item.Event) > 0 {
+		folder.Scripts = convertScripts(item.Event, summary, "Folder '"+item.Name+"'")
 	}
 
 	// Recursively convert nested items
@@ -117,9 +123,9 @@ func convertRequest(item Item, summary *ImportSummary) api.CollectionRequest {
 
 	// Convert description
 	if item.Description != "" {
-		req.Description = item.Description
+		req.Description = string(item.Description)
 	} else if item.Request.Description != "" {
-		req.Description = item.Request.Description
+		req.Description = string(item.Request.Description)
 	}
 
 	// Convert headers
@@ -140,7 +146,7 @@ func convertRequest(item Item, summary *ImportSummary) api.CollectionRequest {
 
 	// Handle scripts (store but warn)
 	if len(item.Event) > 0 {
-		req.Scripts = convertScripts(item.Event, summary, item.Name)
+		req.Scripts = convertScripts(item.Event, summary, "Request '"+item.Name+"'")
 	}
 
 	return req
@@ -256,33 +262,17 @@ func convertBody(body *Body, summary *ImportSummary, reqName string) *api.BodyCo
 		}
 
 	case "graphql":
-		summary.AddWarningf("Request '%s' uses GraphQL body (imported as raw JSON)", reqName)
 		if body.GraphQL == nil {
 			summary.AddWarningf("Request '%s' missing GraphQL payload", reqName)
 			return nil
 		}
-		// Convert GraphQL to JSON format using proper marshaling
-		payload := map[string]interface{}{"query": body.GraphQL.Query}
-		vars := strings.TrimSpace(body.GraphQL.Variables)
-		if vars != "" && vars != "null" {
-			var raw json.RawMessage
-			if err := json.Unmarshal([]byte(vars), &raw); err != nil {
-				summary.AddWarningf("Request '%s' has invalid GraphQL variables JSON (omitted)", reqName)
-			} else {
-				payload["variables"] = raw
-			}
-		}
-		graphqlBytes, err := json.Marshal(payload)
-		if err != nil {
-			summary.AddWarningf("Request '%s' GraphQL body could not be marshaled", reqName)
-			return &api.BodyConfig{
-				Type:    "json",
-				Content: fmt.Sprintf(`{"query": %q}`, body.GraphQL.Query),
-			}
+		gql := api.GraphQLBody{Query: body.GraphQL.Query, Variables: body.GraphQL.Variables}
+		if _, err := gql.Payload(); err != nil {
+			summary.AddWarningf("Request '%s' has invalid GraphQL variables JSON (kept as text)", reqName)
 		}
 		return &api.BodyConfig{
-			Type:    "json",
-			Content: string(graphqlBytes),
+			Type:    api.BodyTypeGraphQL,
+			Content: gql.Content(),
 		}
 
 	default:
@@ -343,7 +333,8 @@ func getAuthValue(kvs []AuthKeyValue, key string) string {
 }
 
 // convertScripts converts Event slice to ScriptConfig.
-func convertScripts(events []Event, summary *ImportSummary, reqName string) *api.ScriptConfig {
+// owner names the request, folder or collection in warnings.
+func convertScripts(events []Event, summary *ImportSummary, owner string) *api.ScriptConfig {
 	scripts := &api.ScriptConfig{}
 
 	for _, event := range events {
@@ -352,10 +343,10 @@ func convertScripts(events []Event, summary *ImportSummary, reqName string) *api
 		switch event.Listen {
 		case "prerequest":
 			scripts.PreRequest = scriptContent
-			summary.AddWarningf("Request '%s' has pre-request script (not executed)", reqName)
+			summary.AddWarningf("%s has pre-request script (not executed)", owner)
 		case "test":
 			scripts.PostRequest = scriptContent
-			summary.AddWarningf("Request '%s' has test script (not executed)", reqName)
+			summary.AddWarningf("%s has test script (not executed)", owner)
 		}
 	}
 
@@ -371,6 +371,48 @@ func TestImportCollection_EmptyCollection(t *testing.T) {
 	}
 }
 
+func TestImportCollection_DescriptionObject(t *testing.T) {
+	jsonData := []byte(`{
+		"info": {
+			"name": "Docs",
+			"description": {"content": "# Docs API", "type": "text/markdown"},
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+		},
+		"item": [
+			{
+				"name": "Users",
+				"description": "Users endpoints",
+				"item": [
+					{
+						"name": "List Users",
+						"request": {
+							"method": "GET",
+							"url": "https://example.com/users",
+							"description": {"content": "Lists the **active** users.", "type": "text/markdown"}
+						}
+					}
+				]
+			}
+		]
+	}`)
+
+	result, err := ImportCollectionFromBytes(jsonData)
+	if err != nil {
+		t.Fatalf("ImportCollectionFromBytes failed: %v", err)
+	}
+
+	if result.Collection.Description != "# Docs API" {
+		t.Errorf("Expected collection description '# Docs API', got '%s'", result.Collection.Description)
+	}
+	folder := result.Collection.Folders[0]
+	if folder.Description != "Users endpoints" {
+		t.Errorf("Expected folder description 'Users endpoints', got '%s'", folder.Description)
+	}
+	if got := folder.Requests[0].Description; got != "Lists the **active** users." {
+		t.Errorf("Expected request description 'Lists the **active** users.', got '%s'", got)
+	}
+}
+
 func TestImportCollection_InvalidJSON(t *testing.T) {
 	_, err := ImportCollection(filepath.Join("testdata", "invalid_json.json"))
 	if err == nil {
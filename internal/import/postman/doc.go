@@ -8,6 +8,7 @@
 //   - Exporting LazyCurl collections to Postman format
 //   - Exporting LazyCurl environments to Postman format
 //   - Auto-detecting file types (collection vs environment)
+//   - Importing every collection and environment of a directory or glob pattern at once
 //
 // # Import Example
 //
@@ -22,6 +23,16 @@
 //	}
 //	// Use result.Collection
 //
+// # Batch Import Example
+//
+//	files, err := postman.ExpandBatch("/path/to/exports")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	batch := postman.ImportBatch(files)
+//	log.Print(batch.FormatSummary())
+//	// Use batch.Collections() and batch.Environments()
+//
 // # Export Example
 //
 //	err := postman.ExportCollection(collection, "/path/to/export.json")
@@ -36,7 +47,7 @@
 //   - All HTTP methods (GET, POST, PUT, PATCH, DELETE, etc.)
 //   - Request headers with enabled/disabled state
 //   - Query parameters
-//   - Body types: raw (JSON, text, XML), urlencoded, formdata
+//   - Body types: raw (JSON, text, XML), urlencoded, formdata, graphql
 //   - Authentication: Bearer, Basic, API Key
 //   - Environment variables with secret/enabled flags
 //
@@ -46,6 +57,6 @@
 //   - Pre-request scripts (stored but not executed)
 //   - Test scripts (stored but not executed)
 //   - OAuth 2.0 authentication (warning generated)
-//   - GraphQL body type (imported as raw)
+//   - GraphQL variables that are not valid JSON (kept as text)
 //   - File uploads (path preserved only)
 package postman
@@ -51,11 +51,14 @@ func convertToCollection(collection *api.CollectionFile) *Collection {
 		Info: Info{
 			PostmanID:   uuid.New().String(),
 			Name:        collection.Name,
-			Description: collection.Description,
+			Description: Description(collection.Description),
 			Schema:      postmanSchemaV21,
 		},
 		Item: make([]Item, 0),
 	}
+	if collection.Scripts != nil {
+		pc.Event = convertScriptsToPostman(collection.Scripts)
+	}
 
 	// Convert folders
 	for _, folder := range collection.Folders {
@@ -74,9 +77,12 @@ func convertToCollection(collection *api.CollectionFile) *Collection {
 func convertFolderToPostman(folder api.Folder) Item {
 	item := Item{
 		Name:        folder.Name,
-		Description: folder.Description,
+		Description: Description(folder.Description),
 		Item:        make([]Item, 0),
 	}
+	if folder.Scripts != nil {
+		item.Event = convertScriptsToPostman(folder.Scripts)
+	}
 
 	// Convert nested folders
 	for _, subFolder := range folder.Folders {
@@ -95,7 +101,7 @@ func convertFolderToPostman(folder api.Folder) Item {
 func convertRequestToPostman(req api.CollectionRequest) Item {
 	postmanReq := Request{
 		Method:      string(req.Method),
-		Description: req.Description,
+		Description: Description(req.Description),
 		URL:         convertURLToPostman(req.URL, req.Params),
 		Header:      convertHeadersToPostman(req.Headers),
 	}
@@ -112,7 +118,7 @@ func convertRequestToPostman(req api.CollectionRequest) Item {
 
 	item := Item{
 		Name:        req.Name,
-		Description: req.Description,
+		Description: Description(req.Description),
 		Request:     &postmanReq,
 	}
 
@@ -240,6 +246,13 @@ func convertBodyToPostman(body *api.BodyConfig) *Body {
 			FormData: formData,
 		}
 
+	case api.BodyTypeGraphQL:
+		gql := api.ParseGraphQLBody(body.Content)
+		return &Body{
+			Mode:    "graphql",
+			GraphQL: &GraphQLBody{Query: gql.Query, Variables: gql.Variables},
+		}
+
 	case "binary":
 		src := ""
 		if s, ok := body.Content.(string); ok {
@@ -501,3 +501,96 @@ func TestExportCollection_WithScripts(t *testing.T) {
 		t.Error("Expected test event")
 	}
 }
+
+func TestExportCollection_FolderScriptsRoundTrip(t *testing.T) {
+	collection := &api.CollectionFile{
+		Name:    "Scripts Collection",
+		Scripts: &api.ScriptConfig{PreRequest: "lc.log('collection');"},
+		Folders: []api.Folder{
+			{
+				Name:    "Users",
+				Scripts: &api.ScriptConfig{PostRequest: "lc.log('folder');"},
+			},
+		},
+	}
+
+	data, err := ExportCollectionToBytes(collection)
+	if err != nil {
+		t.Fatalf("ExportCollectionToBytes failed: %v", err)
+	}
+	result, err := ImportCollectionFromBytes(data)
+	if err != nil {
+		t.Fatalf("ImportCollectionFromBytes failed: %v", err)
+	}
+
+	imported := result.Collection
+	if imported.Scripts == nil || imported.Scripts.PreRequest != "lc.log('collection');" {
+		t.Errorf("Collection scripts = %+v, want the pre-request script", imported.Scripts)
+	}
+	if len(imported.Folders) != 1 || imported.Folders[0].Scripts == nil || imported.Folders[0].Scripts.PostRequest != "lc.log('folder');" {
+		t.Errorf("Folder scripts not round-tripped: %+v", imported.Folders)
+	}
+}
+
+func TestExportCollection_GraphQLRoundTrip(t *testing.T) {
+	collection := &api.CollectionFile{
+		Name: "GraphQL",
+		Requests: []api.CollectionRequest{
+			{
+				ID:     "req_1",
+				Name:   "Get User",
+				Method: "POST",
+				URL:    "/graphql",
+				Body: &api.BodyConfig{
+					Type: api.BodyTypeGraphQL,
+					Content: api.GraphQLBody{
+						Query:     "query User($id: ID!) { user(id: $id) { name } }",
+						Variables: `{"id": "42"}`,
+					}.Content(),
+				},
+			},
+		},
+	}
+
+	data, err := ExportCollectionToBytes(collection)
+	if err != nil {
+		t.Fatalf("ExportCollectionToBytes failed: %v", err)
+	}
+
+	var exported Collection
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Exported JSON is invalid: %v", err)
+	}
+	body := exported.Item[0].Request.Body
+	if body == nil || body.Mode != "graphql" || body.GraphQL == nil {
+		t.Fatalf("Expected graphql mode body, got %+v", body)
+	}
+	if body.GraphQL.Query != "query User($id: ID!) { user(id: $id) { name } }" {
+		t.Errorf("Unexpected exported query %q", body.GraphQL.Query)
+	}
+
+	result, err := ImportCollectionFromBytes(data)
+	if err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	if result.HasWarnings() {
+		t.Errorf("Expected no warnings, got %v", result.Summary.Warnings)
+	}
+
+	req := result.Collection.Requests[0]
+	if req.Body == nil || req.Body.Type != api.BodyTypeGraphQL {
+		t.Fatalf("Expected graphql body after round-trip, got %+v", req.Body)
+	}
+	gql := api.ParseGraphQLBody(req.Body.Content)
+	if gql.Query != "query User($id: ID!) { user(id: $id) { name } }" {
+		t.Errorf("Query mismatch after round-trip: %q", gql.Query)
+	}
+	payload, err := gql.Payload()
+	if err != nil {
+		t.Fatalf("Payload failed: %v", err)
+	}
+	vars, ok := payload["variables"].(map[string]interface{})
+	if !ok || vars["id"] != "42" {
+		t.Errorf("Variables mismatch after round-trip: %v", payload["variables"])
+	}
+}
@@ -8,24 +8,25 @@ type Collection struct {
 	Item     []Item     `json:"item"`
 	Variable []Variable `json:"variable,omitempty"`
 	Auth     *Auth      `json:"auth,omitempty"`
+	Event    []Event    `json:"event,omitempty"`
 }
 
 // Info contains collection metadata.
 type Info struct {
-	PostmanID   string `json:"_postman_id,omitempty"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Schema      string `json:"schema"`
+	PostmanID   string      `json:"_postman_id,omitempty"`
+	Name        string      `json:"name"`
+	Description Description `json:"description,omitempty"`
+	Schema      string      `json:"schema"`
 }
 
 // Item represents either a request or a folder (item group).
 // If Request is nil, it's a folder containing nested Items.
 type Item struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description,omitempty"`
-	Request     *Request `json:"request,omitempty"`
-	Item        []Item   `json:"item,omitempty"`
-	Event       []Event  `json:"event,omitempty"`
+	Name        string      `json:"name"`
+	Description Description `json:"description,omitempty"`
+	Request     *Request    `json:"request,omitempty"`
+	Item        []Item      `json:"item,omitempty"`
+	Event       []Event     `json:"event,omitempty"`
 }
 
 // IsFolder returns true if this item is a folder (has no request but may have items).
@@ -35,12 +36,37 @@ func (i *Item) IsFolder() bool {
 
 // Request contains the full request definition.
 type Request struct {
-	Method      string   `json:"method"`
-	Header      []Header `json:"header,omitempty"`
-	Body        *Body    `json:"body,omitempty"`
-	URL         URL      `json:"url"`
-	Auth        *Auth    `json:"auth,omitempty"`
-	Description string   `json:"description,omitempty"`
+	Method      string      `json:"method"`
+	Header      []Header    `json:"header,omitempty"`
+	Body        *Body       `json:"body,omitempty"`
+	URL         URL         `json:"url"`
+	Auth        *Auth       `json:"auth,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+// Description is the description of a collection, folder or request, in Markdown.
+// Postman writes it either as a string or as an object with its content and type.
+type Description string
+
+// UnmarshalJSON handles Description being either a string or an object in Postman collections.
+func (d *Description) UnmarshalJSON(data []byte) error {
+	// Try string first
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*d = Description(str)
+		return nil
+	}
+
+	// Fall back to object, whose content is Markdown or plain text
+	var obj struct {
+		Content string `json:"content"`
+		Type    string `json:"type,omitempty"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*d = Description(obj.Content)
+	return nil
 }
 
 // URL contains URL with parsed components.
@@ -0,0 +1,203 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// Stages of an async job
+const (
+	JobSubmit = iota
+	JobPoll
+	JobResult
+	JobDone
+)
+
+// Job follows an async job of a collection: it sends its submit request, its poll
+// request until the job is done, then its result request. Next returns the request
+// to run and the wait before it, Record moves the job on with its result, so the
+// job can be driven by Runner.RunJob or step by step.
+type Job struct {
+	Spec   api.AsyncJob
+	Status string // Last status read from a poll response
+	Err    error  // Why the job stopped before completing
+
+	submit, poll Item
+	result       *Item
+	waits        api.JobWaits
+	stage        int
+	polls        int
+	wait         time.Duration
+	polling      time.Duration // Time spent waiting and polling, checked against the timeout
+}
+
+// NewJob prepares the async job named name of col
+func NewJob(col *api.CollectionFile, name string) (*Job, error) {
+	spec := col.FindJob(name)
+	if spec == nil {
+		return nil, fmt.Errorf("runner: job %q not found in %s", name, col.Name)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	waits, _ := spec.Waits() // Checked by Validate
+
+	j := &Job{Spec: *spec, waits: waits, wait: waits.Interval}
+	var ok bool
+	if j.submit, ok = findItem(col, spec.Submit); !ok {
+		return nil, fmt.Errorf("runner: job %s: submit request %q not found", name, spec.Submit)
+	}
+	if j.poll, ok = findItem(col, spec.Poll); !ok {
+		return nil, fmt.Errorf("runner: job %s: poll request %q not found", name, spec.Poll)
+	}
+	if spec.Result != "" {
+		result, ok := findItem(col, spec.Result)
+		if !ok {
+			return nil, fmt.Errorf("runner: job %s: result request %q not found", name, spec.Result)
+		}
+		j.result = &result
+	}
+	return j, nil
+}
+
+// Stage returns the stage of the job: JobSubmit, JobPoll, JobResult or JobDone
+func (j *Job) Stage() int {
+	return j.stage
+}
+
+// Polls returns the number of poll requests sent
+func (j *Job) Polls() int {
+	return j.polls
+}
+
+// Items returns the requests of the job, for prompt variables to be asked up front
+func (j *Job) Items() []Item {
+	items := []Item{j.submit, j.poll}
+	if j.result != nil {
+		items = append(items, *j.result)
+	}
+	return items
+}
+
+// Next returns the next request of the job and how long to wait before running it,
+// or false once the job is over
+func (j *Job) Next() (Item, time.Duration, bool) {
+	switch j.stage {
+	case JobSubmit:
+		return j.submit, 0, true
+	case JobPoll:
+		item := j.poll
+		item.Request.Name = fmt.Sprintf("%s #%d", item.Request.Name, j.polls+1)
+		return item, j.wait, true
+	case JobResult:
+		return *j.result, 0, true
+	}
+	return Item{}, 0, false
+}
+
+// Record moves the job on with the result of the request returned by Next. A request
+// that fails or gets an error status stops the job.
+func (j *Job) Record(result RequestResult) {
+	if result.Err != nil {
+		j.stop(fmt.Errorf("%s: %w", result.Item.Name(), result.Err))
+		return
+	}
+	if result.StatusCode >= 400 {
+		j.stop(fmt.Errorf("%s: %s", result.Item.Name(), result.Status))
+		return
+	}
+
+	switch j.stage {
+	case JobSubmit:
+		j.stage = JobPoll
+	case JobPoll:
+		j.polls++
+		j.polling += j.wait + result.Duration
+		status, err := j.Spec.Status(result.Response)
+		if err != nil {
+			j.stop(fmt.Errorf("no status at %s: %w", j.Spec.Until, err))
+			return
+		}
+		j.Status = status
+		switch {
+		case j.Spec.Done(status):
+			j.stage = JobResult
+			if j.result == nil {
+				j.stage = JobDone
+			}
+		case j.Spec.Failed(status):
+			j.stop(fmt.Errorf("job %s", status))
+		case j.polling >= j.waits.Timeout:
+			j.stop(fmt.Errorf("job still %q after %s", status, j.waits.Timeout))
+		default:
+			j.wait = min(j.wait*2, j.waits.MaxInterval)
+		}
+	case JobResult:
+		j.stage = JobDone
+	}
+}
+
+// stop ends the job with err
+func (j *Job) stop(err error) {
+	j.Err = err
+	j.stage = JobDone
+}
+
+// RunJob runs job to its end, waiting between polls, calling onResult (if set) after
+// each request
+func (r *Runner) RunJob(job *Job, onResult func(RequestResult)) []RequestResult {
+	r.PrepareJob()
+	sleep := r.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	var results []RequestResult
+	for {
+		item, wait, ok := job.Next()
+		if !ok {
+			return results
+		}
+		sleep(wait)
+		result := r.RunRequest(item)
+		job.Record(result)
+		results = append(results, result)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+}
+
+// PrepareJob gives the runner a working environment when it has none: the requests of
+// a job pass values such as the job ID along through environment variables
+func (r *Runner) PrepareJob() {
+	if r.Env == nil {
+		r.Env = &api.EnvironmentFile{Name: "job", Variables: make(map[string]*api.EnvironmentVariable)}
+	}
+}
+
+// findItem returns the request of col with the ID or name ref, with its folder path.
+// Requests skipped in runs are found too: job requests are often left out of runs.
+func findItem(col *api.CollectionFile, ref string) (Item, bool) {
+	if item, ok := findItemIn(col.Folders, col.Requests, nil, func(r *api.CollectionRequest) bool { return r.ID == ref }); ok {
+		return item, true
+	}
+	return findItemIn(col.Folders, col.Requests, nil, func(r *api.CollectionRequest) bool { return r.Name == ref })
+}
+
+// findItemIn searches requests, then folders recursively, for a request matching match
+func findItemIn(folders []api.Folder, requests []api.CollectionRequest, path []string, match func(*api.CollectionRequest) bool) (Item, bool) {
+	for i := range requests {
+		if match(&requests[i]) {
+			return Item{Path: path, Request: requests[i]}, true
+		}
+	}
+	for _, f := range folders {
+		sub := append(append([]string{}, path...), f.Name)
+		if item, ok := findItemIn(f.Folders, f.Requests, sub, match); ok {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
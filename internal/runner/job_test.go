@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// jobCollection returns a collection whose job submits to, polls and fetches from server
+func jobCollection(server string, spec api.AsyncJob) *api.CollectionFile {
+	spec.Name = "Export"
+	return &api.CollectionFile{
+		Name: "Reports",
+		Folders: []api.Folder{{
+			Name: "Exports",
+			Requests: []api.CollectionRequest{
+				{
+					ID: "req_submit", Name: "Start export", Method: api.POST, URL: server + "/exports", SkipInRuns: true,
+					Extract: []api.ExtractRule{{Variable: "job_id", Type: api.ExtractJSONPath, Expression: "$.id"}},
+				},
+				{ID: "req_poll", Name: "Export status", Method: api.GET, URL: server + "/exports/{{job_id}}", SkipInRuns: true},
+				{ID: "req_result", Name: "Download", Method: api.GET, URL: server + "/exports/{{job_id}}/file", SkipInRuns: true},
+			},
+		}},
+		Jobs: []api.AsyncJob{spec},
+	}
+}
+
+func TestRunner_RunJob(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/exports":
+			fmt.Fprint(w, `{"id":"j1"}`)
+		case "/exports/j1":
+			polls++
+			status := "running"
+			if polls == 3 {
+				status = "done"
+			}
+			fmt.Fprintf(w, `{"state":{"status":%q}}`, status)
+		case "/exports/j1/file":
+			fmt.Fprint(w, "report")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	col := jobCollection(server.URL, api.AsyncJob{
+		Submit: "req_submit", Poll: "Export status", Result: "req_result",
+		Until: "$.state.status", Equals: "done", Interval: "1s", MaxInterval: "3s",
+	})
+	job, err := NewJob(col, "Export")
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+
+	r := New(testBuild, nil, &api.EnvironmentFile{Name: "test"})
+	var waits []time.Duration
+	r.sleep = func(d time.Duration) { waits = append(waits, d) }
+	results := r.RunJob(job, nil)
+
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Item.Name())
+	}
+	want := []string{
+		"Exports / Start export",
+		"Exports / Export status #1",
+		"Exports / Export status #2",
+		"Exports / Export status #3",
+		"Exports / Download",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ran %v, want %v", names, want)
+	}
+	// The wait doubles after each poll, up to the max interval
+	if got := []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 0}; !reflect.DeepEqual(waits, got) {
+		t.Errorf("waits = %v, want %v", waits, got)
+	}
+	if job.Err != nil || job.Stage() != JobDone || job.Status != "done" || job.Polls() != 3 {
+		t.Errorf("job = %+v, want done after 3 polls", job)
+	}
+	if body := results[4].Response.BodyString(); body != "report" {
+		t.Errorf("result body = %q, want report", body)
+	}
+}
+
+func TestRunner_RunJobStops(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		spec    api.AsyncJob
+		polls   int
+		wantErr string
+	}{
+		{
+			name:    "failed status",
+			status:  "failed",
+			spec:    api.AsyncJob{Until: "$.status", Equals: "done", FailsOn: []string{"failed", "canceled"}},
+			polls:   1,
+			wantErr: "job failed",
+		},
+		{
+			name:    "timeout",
+			status:  "running",
+			spec:    api.AsyncJob{Until: "$.status", Equals: "done", Interval: "10s", Timeout: "30s"},
+			polls:   2,
+			wantErr: `job still "running" after 30s`,
+		},
+		{
+			name:    "missing status",
+			status:  "running",
+			spec:    api.AsyncJob{Until: "$.state", Equals: "done"},
+			polls:   1,
+			wantErr: "no status at $.state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/exports" {
+					fmt.Fprint(w, `{"id":"j1"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"status":%q}`, tt.status)
+			}))
+			defer server.Close()
+
+			tt.spec.Submit, tt.spec.Poll, tt.spec.Result = "req_submit", "req_poll", "req_result"
+			job, err := NewJob(jobCollection(server.URL, tt.spec), "Export")
+			if err != nil {
+				t.Fatalf("NewJob() error = %v", err)
+			}
+			r := New(testBuild, nil, &api.EnvironmentFile{Name: "test"})
+			r.sleep = func(time.Duration) {}
+			results := r.RunJob(job, nil)
+
+			if job.Err == nil || !strings.Contains(job.Err.Error(), tt.wantErr) {
+				t.Errorf("job error = %v, want %q", job.Err, tt.wantErr)
+			}
+			if job.Polls() != tt.polls || len(results) != tt.polls+1 {
+				t.Errorf("got %d polls and %d results, want %d polls and no result request", job.Polls(), len(results), tt.polls)
+			}
+		})
+	}
+}
+
+func TestNewJob_Errors(t *testing.T) {
+	col := jobCollection("http://localhost", api.AsyncJob{Submit: "req_submit", Poll: "Missing", Until: "$.status", Equals: "done"})
+	if _, err := NewJob(col, "Export"); err == nil || !strings.Contains(err.Error(), `poll request "Missing" not found`) {
+		t.Errorf("NewJob() error = %v, want missing poll request", err)
+	}
+	if _, err := NewJob(col, "Import"); err == nil {
+		t.Error("NewJob() should fail for an unknown job")
+	}
+	col.Jobs[0].Poll, col.Jobs[0].Interval = "req_poll", "soon"
+	if _, err := NewJob(col, "Export"); err == nil {
+		t.Error("NewJob() should fail for an invalid interval")
+	}
+}
@@ -0,0 +1,348 @@
+// Package runner executes the requests of a collection or folder in order.
+// Each request runs its pre-request script, is sent, then runs its post-response
+// script; assertion results and environment changes are collected per request.
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+)
+
+// BuildFunc resolves a collection request against environment variables into an HTTP
+// request, templating the variables of types into JSON bodies by type
+type BuildFunc func(src *api.CollectionRequest, vars, types map[string]string) (*api.Request, error)
+
+// Sender sends HTTP requests (satisfied by *api.Client)
+type Sender interface {
+	Send(req *api.Request) (*api.Response, error)
+}
+
+// protocolSender calls gRPC methods for GRPC requests and sends the others with client
+type protocolSender struct {
+	client *api.Client
+}
+
+// Send implements Sender
+func (s protocolSender) Send(req *api.Request) (*api.Response, error) {
+	if req.Method == api.GRPC {
+		return grpc.Send(req)
+	}
+	return s.client.Send(req)
+}
+
+// Item is a request scheduled for a run, with the folders leading to it
+type Item struct {
+	Path    []string // Folder names from the collection root
+	Request api.CollectionRequest
+}
+
+// Name returns the request name prefixed with its folder path
+func (i Item) Name() string {
+	if len(i.Path) == 0 {
+		return i.Request.Name
+	}
+	return strings.Join(i.Path, " / ") + " / " + i.Request.Name
+}
+
+// RequestResult is the outcome of running one request
+type RequestResult struct {
+	Item       Item
+	StatusCode int
+	Status     string
+	Duration   time.Duration
+	Assertions []api.AssertionResult
+	EnvChanges []api.EnvChange
+	Response   *api.Response // Nil when the request was not sent
+	Err        error         // Build, script or network failure
+}
+
+// Passed returns true if the request completed and all its assertions passed
+func (r RequestResult) Passed() bool {
+	return r.Err == nil && r.FailedAssertions() == 0
+}
+
+// PassedAssertions returns the number of passed assertions
+func (r RequestResult) PassedAssertions() int {
+	count := 0
+	for _, a := range r.Assertions {
+		if a.Passed {
+			count++
+		}
+	}
+	return count
+}
+
+// FailedAssertions returns the number of failed assertions
+func (r RequestResult) FailedAssertions() int {
+	return len(r.Assertions) - r.PassedAssertions()
+}
+
+// Summary aggregates the results of a run
+type Summary struct {
+	Total    int
+	Passed   int
+	Failed   int
+	Duration time.Duration
+}
+
+// Summarize aggregates results into a Summary
+func Summarize(results []RequestResult) Summary {
+	s := Summary{Total: len(results)}
+	for _, r := range results {
+		if r.Passed() {
+			s.Passed++
+		} else {
+			s.Failed++
+		}
+		s.Duration += r.Duration
+	}
+	return s
+}
+
+// Collect returns the requests of a collection, or of the folder at folderPath, in run order:
+// the run order of each folder, then tree order (sub-folders first, then requests, recursively).
+// Requests marked skip in runs are left out.
+func Collect(col *api.CollectionFile, folderPath []string) ([]Item, error) {
+	if col == nil {
+		return nil, fmt.Errorf("runner: no collection")
+	}
+
+	entries, ok := col.RunEntriesAt(folderPath)
+	if !ok {
+		// Report the first missing folder of the path
+		for i := range folderPath {
+			if _, ok := col.RunEntriesAt(folderPath[:i+1]); !ok {
+				return nil, fmt.Errorf("runner: folder %q not found in %s", strings.Join(folderPath[:i+1], "/"), col.Name)
+			}
+		}
+	}
+
+	return collectItems(entries, folderPath), nil
+}
+
+// PromptVariables returns the names of the prompt variables ({{?name}}) of items, asked
+// for once before a run, in order of first use
+func PromptVariables(items []Item) []string {
+	var names []string
+	for _, item := range items {
+		for _, name := range api.FindPromptVariables(&item.Request) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// collectItems flattens run entries below path
+func collectItems(entries []api.RunEntry, path []string) []Item {
+	var items []Item
+	for _, e := range entries {
+		switch {
+		case e.Folder != nil:
+			sub := append(append([]string{}, path...), e.Folder.Name)
+			items = append(items, collectItems(api.RunEntries(e.Folder.Folders, e.Folder.Requests, e.Folder.RunOrder), sub)...)
+		case !e.Request.SkipInRuns:
+			items = append(items, Item{Path: path, Request: *e.Request})
+		}
+	}
+	return items
+}
+
+// Runner executes requests sequentially against a working copy of an environment
+type Runner struct {
+	Build    BuildFunc
+	Sender   Sender
+	Executor api.ScriptExecutor
+	Env      *api.EnvironmentFile // Working copy, updated by script environment changes
+	Prompts  map[string]string    // Values of the prompt variables ({{?name}}), by name
+	Scopes   api.VariableScopes   // Collection variables and workspace globals; each request adds its own
+
+	// Collection holding the items, whose collection and folder scripts run around
+	// the scripts of each request; nil runs the request scripts alone
+	Collection *api.CollectionFile
+
+	sleep func(time.Duration) // Waits between the polls of a job; time.Sleep when nil
+}
+
+// New creates a runner sending with api.NewClient, and calling gRPC methods with grpc.Send.
+// The environment is cloned so that variables set by scripts carry over between
+// requests without touching env.
+func New(build BuildFunc, executor api.ScriptExecutor, env *api.EnvironmentFile) *Runner {
+	r := &Runner{
+		Build:    build,
+		Sender:   protocolSender{client: api.NewClient()},
+		Executor: executor,
+	}
+	if env != nil {
+		r.Env = env.Clone()
+	}
+	return r
+}
+
+// Run executes items in order, calling onResult (if set) after each request
+func (r *Runner) Run(items []Item, onResult func(RequestResult)) []RequestResult {
+	results := make([]RequestResult, 0, len(items))
+	for _, item := range items {
+		result := r.RunRequest(item)
+		results = append(results, result)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+	return results
+}
+
+// RunRequest executes a single item: pre-request script, send, extraction rules,
+// post-response script
+func (r *Runner) RunRequest(item Item) (result RequestResult) {
+	result.Item = item
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	req, err := r.Build(&item.Request, r.variables(item), r.scopes(item).VariableTypes(r.Env))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	var scriptReq *api.ScriptRequest
+	if script := r.script(item, true); script != "" {
+		scriptReq = api.NewScriptRequestFromHTTP(req)
+		scriptResult, err := r.Executor.ExecutePreRequest(script, scriptReq, api.EnvironmentWithScopes(r.Env, r.scopes(item)))
+		r.record(&result, scriptResult)
+		if err != nil {
+			result.Err = fmt.Errorf("pre-request script error: %w", err)
+			return result
+		}
+		applyScriptRequest(req, scriptReq)
+	}
+
+	resp, err := r.Sender.Send(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.StatusCode = resp.StatusCode
+	result.Status = resp.Status
+	result.Response = resp
+	r.extract(&result, item.Request.Extract, resp)
+
+	if script := r.script(item, false); script != "" {
+		headers := make(map[string]string)
+		for key, values := range resp.Headers {
+			if len(values) > 0 {
+				headers[key] = strings.Join(values, ", ")
+			}
+		}
+		scriptResp := api.NewScriptResponseFromData(resp.StatusCode, resp.Status, headers, resp.BodyString(), resp.Time.Milliseconds())
+		if scriptReq == nil {
+			scriptReq = api.NewScriptRequestFromHTTP(req)
+		}
+		scriptResult, err := r.Executor.ExecutePostResponse(script, scriptReq, scriptResp, api.EnvironmentWithScopes(r.Env, r.scopes(item)))
+		r.record(&result, scriptResult)
+		if err != nil {
+			result.Err = fmt.Errorf("post-response script error: %w", err)
+		}
+	}
+
+	return result
+}
+
+// script returns the pre-request or post-response script of item, chained after those
+// of its collection and folders, if any
+func (r *Runner) script(item Item, pre bool) string {
+	if r.Executor == nil {
+		return ""
+	}
+	var script string
+	if item.Request.Scripts != nil {
+		script = item.Request.Scripts.Script(pre)
+	}
+	if r.Collection != nil {
+		script = api.ChainScripts(r.Collection.InheritedScripts(item.Path), script, pre)
+	}
+	return strings.TrimSpace(script)
+}
+
+// scopes returns the variable scopes of item: its own variables, then those of the runner
+func (r *Runner) scopes(item Item) api.VariableScopes {
+	scopes := r.Scopes
+	scopes.Request = item.Request.Variables
+	return scopes
+}
+
+// variables returns the variables of item resolved across scopes, with the active
+// variables of the working environment, and the prompt variables
+func (r *Runner) variables(item Item) map[string]string {
+	var env map[string]string
+	if e := api.EnvironmentFromFile(r.Env); e != nil {
+		env = e.Variables
+	}
+	vars := r.scopes(item).Resolve(env)
+	if len(r.Prompts) == 0 {
+		return vars
+	}
+	return api.WithPromptValues(vars, r.Prompts)
+}
+
+// record stores the assertions of a script run and applies its environment changes
+func (r *Runner) record(result *RequestResult, scriptResult *api.ScriptResult) {
+	if scriptResult == nil {
+		return
+	}
+	result.Assertions = append(result.Assertions, scriptResult.Assertions...)
+	result.EnvChanges = append(result.EnvChanges, scriptResult.EnvChanges...)
+	if r.Env != nil {
+		ApplyEnvChanges(r.Env, scriptResult.EnvChanges)
+	}
+}
+
+// extract applies the extraction rules of a request to its response, before the
+// post-response script runs. A rule that finds nothing counts as a failed assertion.
+func (r *Runner) extract(result *RequestResult, rules []api.ExtractRule, resp *api.Response) {
+	changes, errs := api.ApplyExtractRules(rules, resp)
+	for _, err := range errs {
+		result.Assertions = append(result.Assertions, api.AssertionResult{
+			Name:    "extract {{" + err.Rule.Variable + "}}",
+			Message: err.Err.Error(),
+		})
+	}
+	result.EnvChanges = append(result.EnvChanges, changes...)
+	if r.Env != nil {
+		ApplyEnvChanges(r.Env, changes)
+	}
+}
+
+// applyScriptRequest copies pre-request script modifications onto req
+func applyScriptRequest(req *api.Request, scriptReq *api.ScriptRequest) {
+	if !scriptReq.IsModified() {
+		return
+	}
+	if scriptReq.URL() != "" {
+		req.URL = scriptReq.URL()
+	}
+	if scriptReq.Headers() != nil {
+		req.Headers = scriptReq.Headers()
+	}
+	if scriptReq.IsBodyModified() {
+		req.Body = scriptReq.Body()
+	}
+}
+
+// ApplyEnvChanges applies script environment changes to env
+func ApplyEnvChanges(env *api.EnvironmentFile, changes []api.EnvChange) {
+	for _, change := range changes {
+		switch change.Type {
+		case api.EnvChangeSet:
+			env.SetVariable(change.Name, change.Value)
+		case api.EnvChangeUnset:
+			env.DeleteVariable(change.Name)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// testBuild resolves only the URL, which is all these tests need
+func testBuild(src *api.CollectionRequest, vars, _ map[string]string) (*api.Request, error) {
+	url := src.URL
+	for key, value := range vars {
+		url = strings.ReplaceAll(url, "{{"+key+"}}", value)
+	}
+	if strings.Contains(url, "{{") {
+		return nil, fmt.Errorf("unresolved variable in %s", url)
+	}
+	return &api.Request{Method: src.Method, URL: url, Headers: map[string]string{}, Timeout: 5 * time.Second}, nil
+}
+
+func TestCollect(t *testing.T) {
+	col := &api.CollectionFile{
+		Name: "Shop",
+		Folders: []api.Folder{
+			{
+				Name:     "Users",
+				Folders:  []api.Folder{{Name: "Admin", Requests: []api.CollectionRequest{{Name: "Promote"}}}},
+				Requests: []api.CollectionRequest{{Name: "List"}, {Name: "Create"}},
+			},
+		},
+		Requests: []api.CollectionRequest{{Name: "Health"}},
+	}
+
+	tests := []struct {
+		name       string
+		folderPath []string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "whole collection in tree order",
+			want: []string{"Users / Admin / Promote", "Users / List", "Users / Create", "Health"},
+		},
+		{
+			name:       "folder",
+			folderPath: []string{"Users"},
+			want:       []string{"Users / Admin / Promote", "Users / List", "Users / Create"},
+		},
+		{
+			name:       "nested folder",
+			folderPath: []string{"Users", "Admin"},
+			want:       []string{"Users / Admin / Promote"},
+		},
+		{
+			name:       "missing folder",
+			folderPath: []string{"Orders"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Collect(col, tt.folderPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Collect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, item := range items {
+				names = append(names, item.Name())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Collect() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollect_RunOrder(t *testing.T) {
+	users := api.Folder{
+		Name:     "Users",
+		Requests: []api.CollectionRequest{{ID: "req_list", Name: "List"}, {ID: "req_create", Name: "Create"}},
+	}
+	requests := []api.CollectionRequest{
+		{ID: "req_health", Name: "Health"},
+		{ID: "req_login", Name: "Login"},
+		{ID: "req_reset", Name: "Reset database", SkipInRuns: true},
+	}
+
+	tests := []struct {
+		name      string
+		rootOrder []string
+		userOrder []string
+		want      []string
+	}{
+		{
+			name: "tree order without skipped requests",
+			want: []string{"Users / List", "Users / Create", "Health", "Login"},
+		},
+		{
+			name:      "listed entries first",
+			rootOrder: []string{"req_login", "Users"},
+			want:      []string{"Login", "Users / List", "Users / Create", "Health"},
+		},
+		{
+			name:      "folder order and request names",
+			userOrder: []string{"Create"},
+			want:      []string{"Users / Create", "Users / List", "Health", "Login"},
+		},
+		{
+			name:      "skipped requests stay out when listed",
+			rootOrder: []string{"req_reset", "req_unknown", "req_health"},
+			want:      []string{"Health", "Users / List", "Users / Create", "Login"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder := users
+			folder.RunOrder = tt.userOrder
+			col := &api.CollectionFile{Name: "Shop", Folders: []api.Folder{folder}, Requests: requests, RunOrder: tt.rootOrder}
+
+			items, err := Collect(col, nil)
+			if err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+			var names []string
+			for _, item := range items {
+				names = append(names, item.Name())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Collect() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"token":"abc"}`)
+		case "/me/abc":
+			fmt.Fprint(w, `{"name":"Ada"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	env := &api.EnvironmentFile{
+		Name:      "test",
+		Variables: map[string]*api.EnvironmentVariable{"base_url": {Value: server.URL, Active: true}},
+	}
+	items := []Item{
+		{Request: api.CollectionRequest{
+			Name:   "Login",
+			Method: api.GET,
+			URL:    "{{base_url}}/login",
+			Scripts: &api.ScriptConfig{PostRequest: `
+				lc.test("status is 200", function() { lc.expect(lc.response.status).toBe(200); });
+				lc.environment.set("token", lc.response.body.json().token);
+			`},
+		}},
+		{Request: api.CollectionRequest{
+			Name:   "Me",
+			Method: api.GET,
+			URL:    "{{base_url}}/me/{{token}}",
+			Scripts: &api.ScriptConfig{PostRequest: `
+				lc.test("status is 201", function() { lc.expect(lc.response.status).toBe(201); });
+			`},
+		}},
+		{Request: api.CollectionRequest{Name: "Broken", Method: api.GET, URL: "{{missing}}/x"}},
+	}
+
+	r := New(testBuild, api.NewScriptExecutor(), env)
+	var streamed int
+	results := r.Run(items, func(RequestResult) { streamed++ })
+
+	if streamed != len(items) || len(results) != len(items) {
+		t.Fatalf("got %d results (%d streamed), want %d", len(results), streamed, len(items))
+	}
+
+	if !results[0].Passed() || results[0].StatusCode != 200 || len(results[0].EnvChanges) != 1 {
+		t.Errorf("Login result = %+v", results[0])
+	}
+	// The token set by Login's script is used by Me, whose assertion fails
+	if results[1].Err != nil || results[1].StatusCode != 200 {
+		t.Errorf("Me should reach /me/abc, got %+v", results[1])
+	}
+	if results[1].Passed() || results[1].FailedAssertions() != 1 {
+		t.Errorf("Me should fail its assertion, got %+v", results[1].Assertions)
+	}
+	if results[2].Err == nil {
+		t.Error("Broken should fail to build")
+	}
+
+	summary := Summarize(results)
+	if summary.Total != 3 || summary.Passed != 1 || summary.Failed != 2 {
+		t.Errorf("Summarize() = %+v", summary)
+	}
+
+	// The caller's environment is left untouched
+	if _, ok := env.GetVariable("token"); ok {
+		t.Error("runner should work on a copy of the environment")
+	}
+	if v, _ := r.Env.GetVariable("token"); v != "abc" {
+		t.Errorf("working environment token = %q, want abc", v)
+	}
+}
+
+type failingSender struct{}
+
+func (failingSender) Send(*api.Request) (*api.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRunner_RunRequestSendError(t *testing.T) {
+	r := New(testBuild, nil, nil)
+	r.Sender = failingSender{}
+
+	result := r.RunRequest(Item{Request: api.CollectionRequest{Name: "Down", Method: api.GET, URL: "http://localhost"}})
+	if result.Passed() || result.Err == nil || result.StatusCode != 0 {
+		t.Errorf("RunRequest() = %+v, want send error", result)
+	}
+}
+
+func TestPromptVariables(t *testing.T) {
+	items := []Item{
+		{Request: api.CollectionRequest{URL: "/tickets/{{?ticket_id}}"}},
+		{Request: api.CollectionRequest{URL: "/tickets/{{?ticket_id}}/comments?by={{?author}}"}},
+		{Request: api.CollectionRequest{URL: "/health"}},
+	}
+	if got, want := PromptVariables(items), []string{"ticket_id", "author"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PromptVariables() = %v, want %v", got, want)
+	}
+}
+
+func TestRunner_RunRequestPromptValues(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	defer server.Close()
+
+	r := New(testBuild, nil, nil)
+	r.Prompts = map[string]string{"ticket_id": "42"}
+
+	result := r.RunRequest(Item{Request: api.CollectionRequest{Name: "Ticket", Method: api.GET, URL: server.URL + "/tickets/{{?ticket_id}}"}})
+	if result.Err != nil || path != "/tickets/42" {
+		t.Errorf("RunRequest() = %+v, sent to %q, want /tickets/42", result, path)
+	}
+}
+
+func TestRunner_RunRequestExtract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><input name="csrf" value="t0k3n"></body></html>`)
+	}))
+	defer server.Close()
+
+	r := New(testBuild, nil, &api.EnvironmentFile{Name: "test"})
+	result := r.RunRequest(Item{Request: api.CollectionRequest{
+		Name:   "Login page",
+		Method: api.GET,
+		URL:    server.URL,
+		Extract: []api.ExtractRule{
+			{Variable: "csrf", Type: api.ExtractXPath, Expression: "//input[@name='csrf']/@value"},
+			{Variable: "session", Type: api.ExtractRegex, Expression: `session=(\w+)`},
+		},
+	}})
+
+	if v, _ := r.Env.GetVariable("csrf"); v != "t0k3n" {
+		t.Errorf("csrf = %q, want t0k3n", v)
+	}
+	if result.Passed() || result.FailedAssertions() != 1 || result.Assertions[0].Name != "extract {{session}}" {
+		t.Errorf("unmatched rule should fail the request, got %+v", result.Assertions)
+	}
+}
+
+func TestRunner_RunRequestScopes(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	defer server.Close()
+
+	env := &api.EnvironmentFile{
+		Name:      "test",
+		Variables: map[string]*api.EnvironmentVariable{"version": {Value: "v2", Active: true}},
+	}
+	r := New(testBuild, api.NewScriptExecutor(), env)
+	r.Scopes = api.VariableScopes{
+		Collection: map[string]string{"base_url": server.URL, "version": "v1", "tenant": "shop"},
+		Globals:    map[string]string{"tenant": "acme", "id": "1"},
+	}
+
+	// Request variables override the environment, which overrides the collection and the globals
+	result := r.RunRequest(Item{Request: api.CollectionRequest{
+		Name:      "Order",
+		Method:    api.GET,
+		URL:       "{{base_url}}/{{version}}/{{tenant}}/orders/{{id}}",
+		Variables: map[string]string{"id": "42"},
+		Scripts: &api.ScriptConfig{PreRequest: `
+			lc.test("globals visible", function() { lc.expect(lc.variables.get("tenant")).toBe("shop"); });
+		`},
+	}})
+	if result.Err != nil || path != "/v2/shop/orders/42" {
+		t.Errorf("RunRequest() = %+v, sent to %q, want /v2/shop/orders/42", result, path)
+	}
+	if !result.Passed() {
+		t.Errorf("script should read the collection variable, got %+v", result.Assertions)
+	}
+}
+
+func TestRunner_RunRequestInheritedScripts(t *testing.T) {
+	var order string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = r.Header.Get("X-Order")
+	}))
+	defer server.Close()
+
+	col := &api.CollectionFile{
+		Name:    "Shop",
+		Scripts: &api.ScriptConfig{PreRequest: `lc.request.headers.set("X-Order", "collection");`},
+		Folders: []api.Folder{{
+			Name:     "Users",
+			Scripts:  &api.ScriptConfig{PostRequest: `lc.test("folder test", function () { lc.expect(lc.response.status).toBe(200); });`},
+			Requests: []api.CollectionRequest{{ID: "list", Name: "List", Method: api.GET, URL: server.URL}},
+		}},
+	}
+	items, err := Collect(col, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := New(testBuild, api.NewScriptExecutor(), nil)
+	r.Collection = col
+	result := r.RunRequest(items[0])
+	if result.Err != nil || order != "collection" {
+		t.Errorf("RunRequest() = %+v, X-Order %q, want the collection pre-request script to run", result, order)
+	}
+	if len(result.Assertions) != 1 || !result.Passed() {
+		t.Errorf("Assertions = %+v, want the folder test to pass", result.Assertions)
+	}
+}
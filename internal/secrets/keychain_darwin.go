@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit status of security(1) when no item matches
+const securityNotFound = 44
+
+// encodedPrefix marks values stored base64-encoded, so that any value survives
+// the quoting of security -i
+const encodedPrefix = "lazycurl-base64:"
+
+// macKeychain stores secrets as generic passwords of the login keychain, with security(1)
+type macKeychain struct {
+	path string
+}
+
+func newKeychain() (Store, error) {
+	path, err := exec.LookPath("security")
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return macKeychain{path: path}, nil
+}
+
+func (k macKeychain) Get(key string) (string, error) {
+	out, err := exec.Command(k.path, "find-generic-password", "-s", Service, "-a", key, "-w").Output()
+	if err != nil {
+		return "", securityError(err)
+	}
+	value := strings.TrimSuffix(string(out), "\n")
+	if encoded, ok := strings.CutPrefix(value, encodedPrefix); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("keychain: decoding %s: %w", key, err)
+		}
+		value = string(decoded)
+	}
+	return value, nil
+}
+
+func (k macKeychain) Set(key, value string) error {
+	// The value goes through stdin rather than the arguments, which other processes can read
+	encoded := encodedPrefix + base64.StdEncoding.EncodeToString([]byte(value))
+	cmd := exec.Command(k.path, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		quote(Service), quote(key), encoded))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("keychain: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (k macKeychain) Delete(key string) error {
+	err := exec.Command(k.path, "delete-generic-password", "-s", Service, "-a", key).Run()
+	if err != nil && !errors.Is(securityError(err), ErrNotFound) {
+		return securityError(err)
+	}
+	return nil
+}
+
+// securityError maps the "item not found" exit status to ErrNotFound
+func securityError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+		return ErrNotFound
+	}
+	return fmt.Errorf("keychain: %w", err)
+}
+
+// quote quotes s for the command line of security -i
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,67 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretTool stores secrets in the Secret Service (GNOME Keyring, KWallet) with
+// secret-tool(1), the command line client of libsecret
+type secretTool struct {
+	path string
+}
+
+func newKeychain() (Store, error) {
+	path, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return secretTool{path: path}, nil
+}
+
+func (s secretTool) Get(key string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.path, "lookup", "service", Service, "account", key)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// lookup exits with status 1 and no message when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", secretToolError(err, stderr.String())
+	}
+	return string(out), nil
+}
+
+func (s secretTool) Set(key, value string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.path, "store", "--label=LazyCurl: "+key, "service", Service, "account", key)
+	cmd.Stdin = strings.NewReader(value)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, stderr.String())
+	}
+	return nil
+}
+
+func (s secretTool) Delete(key string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.path, "clear", "service", Service, "account", key)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && stderr.Len() > 0 {
+		return secretToolError(err, stderr.String())
+	}
+	return nil
+}
+
+func secretToolError(err error, stderr string) error {
+	if msg := strings.TrimSpace(stderr); msg != "" {
+		return fmt.Errorf("keychain: %s", msg)
+	}
+	return fmt.Errorf("keychain: %w", err)
+}
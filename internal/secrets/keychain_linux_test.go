@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeSecretTool is a secret-tool stand-in keeping one file per account in $SECRETS_DIR
+const fakeSecretTool = `#!/bin/sh
+eval account=\"\${$#}\"
+file="$SECRETS_DIR/$(printf %s "$account" | tr '/#' '__')"
+case "$1" in
+lookup) [ -f "$file" ] || exit 1; cat "$file" ;;
+store) cat > "$file" ;;
+clear) rm -f "$file" ;;
+*) echo "unknown command $1" >&2; exit 2 ;;
+esac
+`
+
+func TestKeychain_SecretTool(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "secret-tool"), []byte(fakeSecretTool), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("SECRETS_DIR", t.TempDir())
+
+	store, err := Keychain()
+	if err != nil {
+		t.Fatalf("Keychain() error = %v", err)
+	}
+	key := "/work/.lazycurl/environments/dev.json#api_key"
+	if _, err := store.Get(key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of a missing key error = %v, want ErrNotFound", err)
+	}
+	if err := store.Set(key, "s3cr3t\nwith newline"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if value, err := store.Get(key); err != nil || value != "s3cr3t\nwith newline" {
+		t.Errorf("Get() = %q, %v", value, err)
+	}
+	if err := store.Delete(key); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := store.Delete(key); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+	if _, err := store.Get(key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if _, err := Keychain(); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Keychain() without secret-tool error = %v, want ErrUnavailable", err)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !darwin && !linux && !windows
+
+package secrets
+
+func newKeychain() (Store, error) {
+	return nil, ErrUnavailable
+}
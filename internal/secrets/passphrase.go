@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// passphraseScheme prefixes the passphrase hashes of HashPassphrase
+const passphraseScheme = "pbkdf2-sha256"
+
+// PassphraseIterations is the PBKDF2 iteration count of new passphrase hashes
+var PassphraseIterations = 600_000
+
+// HashPassphrase returns a salted hash of passphrase, stored in place of it as
+// "pbkdf2-sha256$<iterations>$<salt>$<key>"
+func HashPassphrase(passphrase string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, PassphraseIterations, 32)
+	if err != nil {
+		return "", err
+	}
+	encode := base64.RawStdEncoding.EncodeToString
+	return fmt.Sprintf("%s$%d$%s$%s", passphraseScheme, PassphraseIterations, encode(salt), encode(key)), nil
+}
+
+// CheckPassphrase reports whether passphrase matches a hash of HashPassphrase. A hash
+// that cannot be read matches no passphrase.
+func CheckPassphrase(hash, passphrase string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passphraseScheme {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, len(want))
+	return err == nil && subtle.ConstantTimeCompare(key, want) == 1
+}
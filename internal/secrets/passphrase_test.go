@@ -0,0 +1,36 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPassphrase(t *testing.T) {
+	defer func(n int) { PassphraseIterations = n }(PassphraseIterations)
+	PassphraseIterations = 1000
+
+	hash, err := HashPassphrase("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "pbkdf2-sha256$1000$") || strings.Contains(hash, "correct horse") {
+		t.Errorf("unexpected hash %q", hash)
+	}
+	if !CheckPassphrase(hash, "correct horse") {
+		t.Error("the passphrase should match its hash")
+	}
+	if CheckPassphrase(hash, "correct horse ") {
+		t.Error("another passphrase should not match")
+	}
+
+	other, err := HashPassphrase("correct horse")
+	if err != nil || other == hash {
+		t.Errorf("hashes of the same passphrase should differ by their salt, got %q", other)
+	}
+
+	for _, bad := range []string{"", "correct horse", "md5$1$c2FsdA$a2V5", "pbkdf2-sha256$x$c2FsdA$a2V5", "pbkdf2-sha256$1000$c2FsdA$"} {
+		if CheckPassphrase(bad, "correct horse") {
+			t.Errorf("CheckPassphrase(%q) should be false", bad)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package secrets stores the values of secret environment variables outside the
+// workspace files, in the OS keychain: the macOS Keychain, the Secret Service
+// (libsecret) on Linux and the Windows Credential Manager.
+// Keychain storage is opt-in through the workspace config (keychain: true).
+// It also hashes the passphrase unlocking a locked workspace.
+package secrets
+
+import (
+	"errors"
+	"sync"
+)
+
+// Service is the name LazyCurl entries are stored under in the keychain
+const Service = "lazycurl"
+
+var (
+	// ErrNotFound is returned by Get when no value is stored for the key
+	ErrNotFound = errors.New("secret not found in keychain")
+	// ErrUnavailable is returned by Keychain when the OS has no keychain LazyCurl can use
+	ErrUnavailable = errors.New("no OS keychain available")
+)
+
+// Store keeps secret values by key
+type Store interface {
+	// Get returns the value of key, or ErrNotFound
+	Get(key string) (string, error)
+	// Set stores the value of key, replacing any previous value
+	Set(key, value string) error
+	// Delete removes the value of key; deleting a missing key is not an error
+	Delete(key string) error
+}
+
+// Keychain returns the store of the OS keychain, or ErrUnavailable.
+// On Linux the secret-tool command (libsecret-tools) must be installed.
+func Keychain() (Store, error) {
+	return newKeychain()
+}
+
+// MemoryStore is a Store kept in memory, for tests
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewMemoryStore creates an empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string]string)}
+}
+
+// Get returns the value of key
+func (s *MemoryStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores the value of key
+func (s *MemoryStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+// Delete removes the value of key
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+// Len returns the number of stored values
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.values)
+}
@@ -21,13 +21,26 @@ const (
 
 // Session represents the complete application state at a point in time.
 type Session struct {
-	Version           int         `yaml:"version"`
-	LastUpdated       time.Time   `yaml:"last_updated"`
-	ActivePanel       string      `yaml:"active_panel"`
-	ActiveCollection  string      `yaml:"active_collection,omitempty"`
-	ActiveRequest     string      `yaml:"active_request,omitempty"`
-	ActiveEnvironment string      `yaml:"active_environment,omitempty"`
-	Panels            PanelsState `yaml:"panels"`
+	Version           int               `yaml:"version"`
+	LastUpdated       time.Time         `yaml:"last_updated"`
+	ActivePanel       string            `yaml:"active_panel"`
+	ActiveCollection  string            `yaml:"active_collection,omitempty"`
+	ActiveRequest     string            `yaml:"active_request,omitempty"`
+	ActiveEnvironment string            `yaml:"active_environment,omitempty"`
+	Panels            PanelsState       `yaml:"panels"`
+	PromptValues      map[string]string `yaml:"prompt_values,omitempty"` // Last value entered for each prompt variable ({{?name}})
+	Marks             map[string]Mark   `yaml:"marks,omitempty"`         // Positions saved with m{a-z}, by letter
+	// Tabs are the requests open in the Request and Response panels, when there are
+	// several; ActiveRequest and Panels hold the state of the active one
+	Tabs      []RequestTab `yaml:"tabs,omitempty"`
+	ActiveTab int          `yaml:"active_tab,omitempty"` // Index of the active tab in Tabs
+}
+
+// RequestTab is a request open in a tab of the Request and Response panels.
+type RequestTab struct {
+	RequestID   string            `yaml:"request_id,omitempty"` // "" for a tab without a saved request
+	Request     RequestPanelState `yaml:"request"`
+	ResponseTab string            `yaml:"response_tab,omitempty"`
 }
 
 // PanelsState contains state for all panels.
@@ -55,6 +68,19 @@ type RequestPanelState struct {
 type ResponsePanelState struct {
 	ActiveTab      string `yaml:"active_tab"`
 	ScrollPosition int    `yaml:"scroll_position"`
+	// TableView is true when the body tab shows array responses as a table
+	TableView bool `yaml:"table_view,omitempty"`
+	// HiddenTableColumns maps request IDs to the table columns hidden by the user
+	HiddenTableColumns map[string][]string `yaml:"hidden_table_columns,omitempty"`
+}
+
+// Mark is a position saved with m{a-z}: a collections tree node, or a request
+// with the request panel tab and body cursor.
+type Mark struct {
+	Panel  string          `yaml:"panel"`
+	NodeID string          `yaml:"node_id"`
+	Tab    string          `yaml:"tab,omitempty"`
+	Cursor *CursorPosition `yaml:"cursor,omitempty"`
 }
 
 // CursorPosition represents cursor in multi-line editor.
@@ -202,7 +228,7 @@ func (s *Session) Validate(workspacePath string) *Session {
 	}
 
 	// Validate tab values
-	validRequestTabs := map[string]bool{"params": true, "headers": true, "body": true, "auth": true, "scripts": true}
+	validRequestTabs := map[string]bool{"params": true, "headers": true, "body": true, "auth": true, "scripts": true, "settings": true}
 	if !validRequestTabs[s.Panels.Request.ActiveTab] {
 		s.Panels.Request.ActiveTab = "params"
 	}
@@ -212,5 +238,17 @@ func (s *Session) Validate(workspacePath string) *Session {
 		s.Panels.Response.ActiveTab = "body"
 	}
 
+	// Keep the active tab within the tabs
+	if s.ActiveTab < 0 || s.ActiveTab >= len(s.Tabs) {
+		s.ActiveTab = 0
+	}
+
+	// Drop marks that are not on a letter or lost their node
+	for name, mark := range s.Marks {
+		if len(name) != 1 || name[0] < 'a' || name[0] > 'z' || mark.NodeID == "" {
+			delete(s.Marks, name)
+		}
+	}
+
 	return s
 }
@@ -336,6 +336,15 @@ func TestSessionRoundTrip(t *testing.T) {
 				ScrollPosition: 25,
 			},
 		},
+		Marks: map[string]Mark{
+			"a": {Panel: "collections", NodeID: "req_login"},
+			"b": {Panel: "request", NodeID: "req_456", Tab: "body", Cursor: &CursorPosition{Line: 3, Column: 8}},
+		},
+		Tabs: []RequestTab{
+			{RequestID: "req_123", Request: RequestPanelState{ActiveTab: "body"}, ResponseTab: "headers"},
+			{RequestID: "req_456", Request: RequestPanelState{ActiveTab: "headers", URLCursor: 30}, ResponseTab: "cookies"},
+		},
+		ActiveTab: 1,
 	}
 
 	// Save
@@ -374,6 +383,15 @@ func TestSessionRoundTrip(t *testing.T) {
 		t.Errorf("Response.ActiveTab: got %s, want %s",
 			loaded.Panels.Response.ActiveTab, original.Panels.Response.ActiveTab)
 	}
+	if loaded.Marks["a"] != original.Marks["a"] {
+		t.Errorf("Marks[a]: got %+v, want %+v", loaded.Marks["a"], original.Marks["a"])
+	}
+	if mark := loaded.Marks["b"]; mark.NodeID != "req_456" || mark.Tab != "body" || mark.Cursor == nil || *mark.Cursor != (CursorPosition{Line: 3, Column: 8}) {
+		t.Errorf("Marks[b]: got %+v", mark)
+	}
+	if len(loaded.Tabs) != 2 || loaded.ActiveTab != 1 || loaded.Tabs[0].RequestID != "req_123" || loaded.Tabs[0].ResponseTab != "headers" || loaded.Tabs[1].Request.URLCursor != 30 {
+		t.Errorf("Tabs: got %+v, active %d", loaded.Tabs, loaded.ActiveTab)
+	}
 }
 
 func TestSessionValidate(t *testing.T) {
@@ -435,6 +453,41 @@ func TestSessionValidate(t *testing.T) {
 					s.Panels.Response.ScrollPosition == 0
 			},
 		},
+		{
+			name: "invalid marks are dropped",
+			session: &Session{
+				Version:     1,
+				ActivePanel: "collections",
+				Panels: PanelsState{
+					Request:  RequestPanelState{ActiveTab: "params"},
+					Response: ResponsePanelState{ActiveTab: "body"},
+				},
+				Marks: map[string]Mark{
+					"a":  {Panel: "collections", NodeID: "req_1"},
+					"A":  {Panel: "collections", NodeID: "req_2"},
+					"ab": {Panel: "collections", NodeID: "req_3"},
+					"b":  {Panel: "request"},
+				},
+			},
+			check: func(s *Session) bool {
+				_, ok := s.Marks["a"]
+				return ok && len(s.Marks) == 1
+			},
+		},
+		{
+			name: "active tab out of the tabs is reset",
+			session: &Session{
+				Version:     1,
+				ActivePanel: "request",
+				Panels: PanelsState{
+					Request:  RequestPanelState{ActiveTab: "params"},
+					Response: ResponsePanelState{ActiveTab: "body"},
+				},
+				Tabs:      []RequestTab{{RequestID: "req_1"}, {RequestID: "req_2"}},
+				ActiveTab: 2,
+			},
+			check: func(s *Session) bool { return s.ActiveTab == 0 && len(s.Tabs) == 2 },
+		},
 		{
 			name: "valid session unchanged",
 			session: &Session{
@@ -0,0 +1,229 @@
+// Package stats records local usage statistics for LazyCurl.
+// Sends are stored in .lazycurl/stats.json; nothing leaves the machine.
+// Recording is opt-in through the workspace config (stats: true).
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// FileName is the name of the stats file in the .lazycurl directory
+	FileName = "stats.json"
+	// MaxEntries is the number of sends kept; older entries are dropped first
+	MaxEntries = 5000
+)
+
+// Entry is one recorded send
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Collection string    `json:"collection,omitempty"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Method     string    `json:"method"`
+	Host       string    `json:"host"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status,omitempty"` // 0 when the request failed
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// NewEntry creates an entry for a request to rawURL. Query strings are not recorded.
+func NewEntry(at time.Time, method, rawURL string, status int, duration time.Duration) Entry {
+	e := Entry{Time: at, Method: method, Path: rawURL, Status: status, DurationMs: duration.Milliseconds()}
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		e.Host = u.Host
+		e.Path = u.Path
+		if e.Path == "" {
+			e.Path = "/"
+		}
+	}
+	return e
+}
+
+// Duration returns the response time of the send
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.DurationMs) * time.Millisecond
+}
+
+// Endpoint returns the method, host and path of the send
+func (e Entry) Endpoint() string {
+	return e.Method + " " + e.Host + e.Path
+}
+
+// Store holds the recorded sends of a workspace
+type Store struct {
+	Entries []Entry `json:"entries"`
+	path    string
+}
+
+// Path returns the stats file path of a workspace
+func Path(workspacePath string) string {
+	return filepath.Join(workspacePath, ".lazycurl", FileName)
+}
+
+// Load reads the stats of a workspace. A missing file yields an empty store.
+func Load(workspacePath string) (*Store, error) {
+	s := &Store{path: Path(workspacePath)}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read stats: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, fmt.Errorf("failed to parse stats: %w", err)
+	}
+	return s, nil
+}
+
+// Save writes the stats file
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Add records a send, dropping the oldest entries beyond MaxEntries
+func (s *Store) Add(e Entry) {
+	s.Entries = append(s.Entries, e)
+	if over := len(s.Entries) - MaxEntries; over > 0 {
+		s.Entries = append([]Entry(nil), s.Entries[over:]...)
+	}
+}
+
+// Clear removes all entries
+func (s *Store) Clear() {
+	s.Entries = nil
+}
+
+// LastSent returns the time of the latest send of each request, by request ID. Sends
+// without a request ID are skipped.
+func (s *Store) LastSent() map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, e := range s.Entries {
+		if e.RequestID != "" && e.Time.After(last[e.RequestID]) {
+			last[e.RequestID] = e.Time
+		}
+	}
+	return last
+}
+
+// Group aggregates the sends sharing a key
+type Group struct {
+	Key    string
+	Count  int
+	Errors int // Failed sends and responses with a status of 400 or more
+	Avg    time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+// By groups entries by key, most used first. Entries with an empty key are skipped.
+func (s *Store) By(key func(Entry) string) []Group {
+	index := make(map[string]int)
+	var groups []Group
+	var totals []time.Duration
+	for _, e := range s.Entries {
+		k := key(e)
+		if k == "" {
+			continue
+		}
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group{Key: k, Min: e.Duration(), Max: e.Duration()})
+			totals = append(totals, 0)
+		}
+		g := &groups[i]
+		g.Count++
+		if e.Status == 0 || e.Status >= 400 {
+			g.Errors++
+		}
+		g.Min = min(g.Min, e.Duration())
+		g.Max = max(g.Max, e.Duration())
+		totals[i] += e.Duration()
+	}
+	for i := range groups {
+		groups[i].Avg = totals[i] / time.Duration(groups[i].Count)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// ByCollection groups sends by collection name
+func (s *Store) ByCollection() []Group {
+	return s.By(func(e Entry) string { return e.Collection })
+}
+
+// ByHost groups sends by host
+func (s *Store) ByHost() []Group {
+	return s.By(func(e Entry) string { return e.Host })
+}
+
+// ByEndpoint groups sends by method, host and path
+func (s *Store) ByEndpoint() []Group {
+	return s.By(Entry.Endpoint)
+}
+
+// Day aggregates the sends of one calendar day
+type Day struct {
+	Date  time.Time // Midnight, local time
+	Count int
+	Avg   time.Duration
+}
+
+// Daily returns the sends of the last days up to now, oldest first, including days without sends
+func (s *Store) Daily(now time.Time, days int) []Day {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	result := make([]Day, days)
+	totals := make([]time.Duration, days)
+	for i := range result {
+		result[i].Date = today.AddDate(0, 0, i-days+1)
+	}
+	for _, e := range s.Entries {
+		t := e.Time.In(now.Location())
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		i := days - 1 - int(today.Sub(date).Hours()/24+0.5)
+		if i < 0 || i >= days {
+			continue
+		}
+		result[i].Count++
+		totals[i] += e.Duration()
+	}
+	for i := range result {
+		if result[i].Count > 0 {
+			result[i].Avg = totals[i] / time.Duration(result[i].Count)
+		}
+	}
+	return result
+}
+
+// Total returns the number of sends and their average response time
+func (s *Store) Total() (int, time.Duration) {
+	if len(s.Entries) == 0 {
+		return 0, 0
+	}
+	var total time.Duration
+	for _, e := range s.Entries {
+		total += e.Duration()
+	}
+	return len(s.Entries), total / time.Duration(len(s.Entries))
+}
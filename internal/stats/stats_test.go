@@ -0,0 +1,134 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantHost string
+		wantPath string
+	}{
+		{name: "query dropped", url: "https://api.example.com/users?page=2", wantHost: "api.example.com", wantPath: "/users"},
+		{name: "root", url: "http://localhost:8080", wantHost: "localhost:8080", wantPath: "/"},
+		{name: "unparsable", url: "{{base_url}}/users", wantHost: "", wantPath: "{{base_url}}/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEntry(time.Now(), "GET", tt.url, 200, 120*time.Millisecond)
+			if e.Host != tt.wantHost || e.Path != tt.wantPath || e.DurationMs != 120 {
+				t.Errorf("NewEntry() = %+v", e)
+			}
+		})
+	}
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := Load(dir)
+	if err != nil || len(s.Entries) != 0 {
+		t.Fatalf("Load() of missing file = %+v, %v", s, err)
+	}
+	s.Add(NewEntry(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), "GET", "https://api.example.com/users", 200, time.Second))
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].Endpoint() != "GET api.example.com/users" {
+		t.Errorf("Load() = %+v", loaded.Entries)
+	}
+}
+
+func TestStore_AddTrims(t *testing.T) {
+	s := &Store{}
+	for i := 0; i < MaxEntries+3; i++ {
+		s.Add(Entry{DurationMs: int64(i)})
+	}
+	if len(s.Entries) != MaxEntries || s.Entries[0].DurationMs != 3 {
+		t.Errorf("got %d entries starting at %d", len(s.Entries), s.Entries[0].DurationMs)
+	}
+}
+
+func TestStore_Groups(t *testing.T) {
+	s := &Store{Entries: []Entry{
+		{Collection: "Shop", Method: "GET", Host: "api.shop.com", Path: "/items", Status: 200, DurationMs: 100},
+		{Collection: "Shop", Method: "GET", Host: "api.shop.com", Path: "/items", Status: 500, DurationMs: 300},
+		{Collection: "Shop", Method: "POST", Host: "api.shop.com", Path: "/items", Status: 201, DurationMs: 200},
+		{Method: "GET", Host: "localhost", Path: "/", DurationMs: 50},
+	}}
+
+	collections := s.ByCollection()
+	if len(collections) != 1 || collections[0].Key != "Shop" || collections[0].Count != 3 || collections[0].Errors != 1 {
+		t.Errorf("ByCollection() = %+v", collections)
+	}
+
+	hosts := s.ByHost()
+	if len(hosts) != 2 || hosts[0].Key != "api.shop.com" || hosts[1].Errors != 1 {
+		t.Errorf("ByHost() = %+v", hosts)
+	}
+	if g := hosts[0]; g.Avg != 200*time.Millisecond || g.Min != 100*time.Millisecond || g.Max != 300*time.Millisecond {
+		t.Errorf("latency = avg %v min %v max %v", g.Avg, g.Min, g.Max)
+	}
+
+	endpoints := s.ByEndpoint()
+	if len(endpoints) != 3 || endpoints[0].Key != "GET api.shop.com/items" || endpoints[0].Count != 2 {
+		t.Errorf("ByEndpoint() = %+v", endpoints)
+	}
+
+	if count, avg := s.Total(); count != 4 || avg != 162500*time.Microsecond {
+		t.Errorf("Total() = %d, %v", count, avg)
+	}
+}
+
+func TestStore_Daily(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	s := &Store{Entries: []Entry{
+		{Time: now.Add(-time.Hour), DurationMs: 100},
+		{Time: now.Add(-2 * time.Hour), DurationMs: 300},
+		{Time: now.AddDate(0, 0, -2), DurationMs: 50},
+		{Time: now.AddDate(0, 0, -30), DurationMs: 10},
+	}}
+
+	days := s.Daily(now, 3)
+	if len(days) != 3 {
+		t.Fatalf("Daily() returned %d days", len(days))
+	}
+	want := []struct {
+		day   int
+		count int
+		avg   time.Duration
+	}{
+		{8, 1, 50 * time.Millisecond},
+		{9, 0, 0},
+		{10, 2, 200 * time.Millisecond},
+	}
+	for i, w := range want {
+		if days[i].Date.Day() != w.day || days[i].Count != w.count || days[i].Avg != w.avg {
+			t.Errorf("day %d = %+v, want %+v", i, days[i], w)
+		}
+	}
+}
+
+func TestStore_LastSent(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	s := &Store{Entries: []Entry{
+		{Time: now, RequestID: "req_1"},
+		{Time: now.Add(-time.Hour), RequestID: "req_1"},
+		{Time: now.Add(-2 * time.Hour), RequestID: "req_2"},
+		{Time: now.Add(time.Hour)},
+	}}
+
+	last := s.LastSent()
+	if len(last) != 2 || !last["req_1"].Equal(now) || !last["req_2"].Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("LastSent() = %v", last)
+	}
+}
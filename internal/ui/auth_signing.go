@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// handleSignCommand shows how the JWT auth of the open request is signed, variables
+// resolved against the active environment. A token given as argument, such as one a
+// server issued, is compared with it.
+func (m Model) handleSignCommand(args []string) (tea.Model, tea.Cmd) {
+	auth := m.requestPanel.GetAuthConfig()
+	if auth == nil || auth.Type != "jwt" {
+		m.statusBar.Info("The open request is not signed (set its auth to JWT)")
+		return m, nil
+	}
+
+	opts, err := jwtOptions(auth, m.requestVariables(m.requestPanel.GetCurrentRequestID()))
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	signing, err := api.SignJWT(opts)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	token := strings.TrimSpace(strings.Join(args, " "))
+	token = strings.TrimSpace(strings.TrimPrefix(token, auth.Prefix+" "))
+	if token != "" {
+		if _, err := signing.Compare(token); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+
+	m.responsePanel.SetJWTSigning(signing)
+	m.activePanel = ResponsePanel
+	switch check := signing.Check; {
+	case check == nil:
+		m.statusBar.Success("Signed", signing.Algorithm)
+	case check.Valid && len(check.Differences) == 0:
+		m.statusBar.Success("Token", check.Summary())
+	default:
+		m.statusBar.Error(fmt.Errorf("token %s, %d differences", check.Summary(), len(check.Differences)))
+	}
+	return m, nil
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_SignCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), t.TempDir())
+
+	model, _ := m.handleSignCommand(nil)
+	if m := model.(Model); !strings.Contains(m.statusBar.message, "not signed") {
+		t.Errorf("a request without JWT auth should be reported, got %q", m.statusBar.message)
+	}
+
+	m.requestPanel.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "1",
+		Method: api.GET,
+		URL:    "https://example.com",
+		Auth:   &api.AuthConfig{Type: "jwt", JWTKey: "secret", JWTClaims: `{"sub":"svc"}`},
+	})
+	token, err := api.GenerateJWT(api.JWTOptions{Key: "secret", Claims: `{"sub":"other"}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, _ = m.handleSignCommand([]string{"Bearer", token})
+	m = model.(Model)
+	if m.activePanel != ResponsePanel || m.responsePanel.jwtSigning == nil {
+		t.Fatal(":sign should show the signing in the Response panel")
+	}
+	view := PlainSnapshot(m.responsePanel.View(200, 40, true))
+	for _, want := range []string{"Signing input", "HMAC secret, 6 bytes", "Signature verified by the request's key", `claim "sub": "other" in the token`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	panel, _ := m.responsePanel.Update(tea.KeyMsg{Type: tea.KeyEsc}, m.globalConfig)
+	if panel.jwtSigning != nil {
+		t.Error("esc should close the signing")
+	}
+}
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"fmt"
 	"path/filepath"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -17,6 +19,8 @@ type CollectionsView struct {
 	tree            *components.Tree
 	collections     []*api.CollectionFile
 	clipboard       *components.TreeNode // For yank/paste
+	cut             bool                 // The clipboard node is moved, not copied, by a paste
+	keyMap          map[string]string    // Keys bound to tree actions, to their built-in keys
 }
 
 // NewCollectionsView creates a new collections view
@@ -39,11 +43,20 @@ func (c *CollectionsView) loadCollections() {
 		// If no collections or error, create empty tree
 		c.collections = []*api.CollectionFile{}
 		c.tree = components.NewTree(c.collections)
+		c.tree.SetKeyMap(c.keyMap)
 		return
 	}
 
 	c.collections = collections
 	c.tree = components.NewTree(collections)
+	c.tree.SetKeyMap(c.keyMap)
+}
+
+// SetKeyMap makes the keys bound to tree actions in the config perform them
+// (see components.Tree.SetKeyMap)
+func (c *CollectionsView) SetKeyMap(keyMap map[string]string) {
+	c.keyMap = keyMap
+	c.tree.SetKeyMap(keyMap)
 }
 
 // ReloadCollections reloads collections from disk while preserving tree state
@@ -90,6 +103,18 @@ func (c CollectionsView) GetTree() *components.Tree {
 // SetClipboard sets the clipboard node for copy/paste
 func (c *CollectionsView) SetClipboard(node *components.TreeNode) {
 	c.clipboard = node
+	c.cut = false
+}
+
+// CutNode sets the clipboard node to move with the next paste
+func (c *CollectionsView) CutNode(node *components.TreeNode) {
+	c.clipboard = node
+	c.cut = true
+}
+
+// IsCut reports whether the clipboard node was cut, to be moved by a paste
+func (c *CollectionsView) IsCut() bool {
+	return c.clipboard != nil && c.cut
 }
 
 // GetClipboard returns the clipboard node
@@ -184,6 +209,58 @@ func (c *CollectionsView) AddRequestToCollection(name, method, url string, paren
 	return col.Save()
 }
 
+// AddRequestAfter adds req next to the request with ID afterID, in the same folder
+func (c *CollectionsView) AddRequestAfter(afterID string, req *api.CollectionRequest) error {
+	col := c.FindCollectionByRequestID(afterID)
+	if col == nil {
+		return fmt.Errorf("request not found: %s", afterID)
+	}
+	col.AddRequestAfter(afterID, req)
+	return col.Save()
+}
+
+// SelectRequest moves the tree cursor to a request, if its folder is expanded
+func (c *CollectionsView) SelectRequest(requestID string) {
+	if c.tree == nil {
+		return
+	}
+	for i, node := range c.tree.GetVisibleItems() {
+		if node.Type == components.RequestNode && node.ID == requestID {
+			c.tree.SelectIndex(i)
+			return
+		}
+	}
+}
+
+// RevealNode expands the folders of a tree node and moves the cursor to it
+func (c *CollectionsView) RevealNode(id string) bool {
+	if c.tree == nil {
+		return false
+	}
+	return c.tree.Reveal(id)
+}
+
+// SetSendStatus sets the mark shown next to a request sent with its folder
+func (c *CollectionsView) SetSendStatus(requestID string, status components.SendStatus) {
+	if c.tree != nil {
+		c.tree.SetSendStatus(requestID, status)
+	}
+}
+
+// ClearSendStatuses removes the marks of the requests sent with their folder
+func (c *CollectionsView) ClearSendStatuses() {
+	if c.tree != nil {
+		c.tree.ClearSendStatuses()
+	}
+}
+
+// SetLastSendFailed records whether the last send of a request failed, for the tree filter
+func (c *CollectionsView) SetLastSendFailed(requestID string, failed bool) {
+	if c.tree != nil && requestID != "" {
+		c.tree.SetLastSendFailed(requestID, failed)
+	}
+}
+
 // createDefaultCollectionWithRequest creates a new collection with a request
 func (c *CollectionsView) createDefaultCollectionWithRequest(name, method, url string) error {
 	col := &api.CollectionFile{
@@ -276,20 +353,77 @@ func (c *CollectionsView) UpdateRequest(node *components.TreeNode, name, method,
 		return nil
 	}
 
+	// A linked request has its own name, its method and URL are the original's
+	if req := col.FindRequest(node.ID); req != nil && req.Link != "" {
+		col.RenameRequest(node.ID, name)
+		if err := col.Save(); err != nil {
+			return err
+		}
+		original := c.FindRequestByID(req.Link)
+		if original == nil {
+			return nil
+		}
+		col = c.FindCollectionByRequestID(req.Link)
+		col.UpdateRequest(req.Link, original.Name, api.HTTPMethod(method), url)
+		return c.saveLinked(col)
+	}
+
 	col.UpdateRequest(node.ID, name, api.HTTPMethod(method), url)
+	return c.saveLinked(col)
+}
+
+// SourceRequestID returns the ID of the request a linked request links to, whose
+// content changes apply to, or requestID itself for other requests
+func (c *CollectionsView) SourceRequestID(requestID string) string {
+	if req := c.FindRequestByID(requestID); req != nil && req.Link != "" {
+		return req.Link
+	}
+	return requestID
+}
+
+// saveLinked saves col after refreshing the requests linked to the requests it holds
+func (c *CollectionsView) saveLinked(col *api.CollectionFile) error {
+	api.ResolveLinks(c.collections)
 	return col.Save()
 }
 
+// FindRequestByID finds a request by ID across all collections
+func (c *CollectionsView) FindRequestByID(requestID string) *api.CollectionRequest {
+	if requestID == "" {
+		return nil
+	}
+	for _, col := range c.collections {
+		if req := col.FindRequest(requestID); req != nil {
+			return req
+		}
+	}
+	return nil
+}
+
+// FindCollectionByRequestID finds the collection containing a request
+func (c *CollectionsView) FindCollectionByRequestID(requestID string) *api.CollectionFile {
+	if requestID == "" {
+		return nil
+	}
+	for _, col := range c.collections {
+		if col.FindRequest(requestID) != nil {
+			return col
+		}
+	}
+	return nil
+}
+
 // UpdateRequestURLByID finds a request by ID across all collections and updates its URL
 func (c *CollectionsView) UpdateRequestURLByID(requestID, newURL string) error {
 	if requestID == "" {
 		return nil
 	}
+	requestID = c.SourceRequestID(requestID)
 
 	// Search through all collections
 	for _, col := range c.collections {
 		if col.UpdateRequestURL(requestID, newURL) {
-			return col.Save()
+			return c.saveLinked(col)
 		}
 	}
 
@@ -301,11 +435,12 @@ func (c *CollectionsView) UpdateRequestBodyByID(requestID, bodyType, content str
 	if requestID == "" {
 		return nil
 	}
+	requestID = c.SourceRequestID(requestID)
 
 	// Search through all collections
 	for _, col := range c.collections {
 		if col.UpdateRequestBody(requestID, bodyType, content) {
-			return col.Save()
+			return c.saveLinked(col)
 		}
 	}
 
@@ -317,11 +452,28 @@ func (c *CollectionsView) UpdateRequestScriptsByID(requestID, preRequest, postRe
 	if requestID == "" {
 		return nil
 	}
+	requestID = c.SourceRequestID(requestID)
 
 	// Search through all collections
 	for _, col := range c.collections {
 		if col.UpdateRequestScripts(requestID, preRequest, postRequest) {
-			return col.Save()
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestDescriptionByID finds a request by ID across all collections and updates its Markdown docs
+func (c *CollectionsView) UpdateRequestDescriptionByID(requestID, description string) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestDescription(requestID, description) {
+			return c.saveLinked(col)
 		}
 	}
 
@@ -333,11 +485,142 @@ func (c *CollectionsView) UpdateRequestAuthByID(requestID string, auth *api.Auth
 	if requestID == "" {
 		return nil
 	}
+	requestID = c.SourceRequestID(requestID)
 
 	// Search through all collections
 	for _, col := range c.collections {
 		if col.UpdateRequestAuth(requestID, auth) {
-			return col.Save()
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestHeadersByID finds a request by ID across all collections and replaces its headers
+func (c *CollectionsView) UpdateRequestHeadersByID(requestID string, headers []api.KeyValueEntry) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	// Search through all collections
+	for _, col := range c.collections {
+		if col.UpdateRequestHeaders(requestID, headers) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestBodySchemaByID finds a request by ID across all collections and attaches a JSON Schema to its body
+func (c *CollectionsView) UpdateRequestBodySchemaByID(requestID string, schema *api.JSONSchema) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	// Search through all collections
+	for _, col := range c.collections {
+		if col.UpdateRequestBodySchema(requestID, schema) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestExtractByID finds a request by ID across all collections and replaces its extraction rules
+func (c *CollectionsView) UpdateRequestExtractByID(requestID string, rules []api.ExtractRule) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestExtract(requestID, rules) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestExamplesByID finds a request by ID across all collections and replaces its frozen examples
+func (c *CollectionsView) UpdateRequestExamplesByID(requestID string, examples []api.RequestExample) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestExamples(requestID, examples) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestRedirectsByID finds a request by ID across all collections and updates its redirect settings
+func (c *CollectionsView) UpdateRequestRedirectsByID(requestID string, follow bool, maxRedirects int) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestRedirects(requestID, follow, maxRedirects) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestVariablesByID finds a request by ID across all collections and replaces its variables
+func (c *CollectionsView) UpdateRequestVariablesByID(requestID string, vars map[string]string) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestVariables(requestID, vars) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestSettingsByID finds a request by ID across all collections and updates its timeout and retry policy
+func (c *CollectionsView) UpdateRequestSettingsByID(requestID string, timeout string, retry *api.RetryPolicy) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestSettings(requestID, timeout, retry) {
+			return c.saveLinked(col)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRequestOverridesByID finds a request by ID across all collections and updates its TLS server name and Host header
+func (c *CollectionsView) UpdateRequestOverridesByID(requestID string, serverName, host string) error {
+	if requestID == "" {
+		return nil
+	}
+	requestID = c.SourceRequestID(requestID)
+
+	for _, col := range c.collections {
+		if col.UpdateRequestOverrides(requestID, serverName, host) {
+			return c.saveLinked(col)
 		}
 	}
 
@@ -361,15 +644,53 @@ func (c *CollectionsView) DeleteNode(node *components.TreeNode) error {
 		// Not implemented for safety - would need to delete the file
 		return nil
 	case components.FolderNode:
+		if err := c.unlinkRequests(requestIDs(node)...); err != nil {
+			return err
+		}
 		parentPath := c.GetFolderPath(node.Parent)
 		col.DeleteFolder(parentPath, node.Name)
 	case components.RequestNode:
+		if err := c.unlinkRequests(node.ID); err != nil {
+			return err
+		}
 		col.DeleteRequest(node.ID)
 	}
 
 	return col.Save()
 }
 
+// unlinkRequests turns the requests linked to the requests with ids into copies,
+// so that they keep their content when the originals are deleted
+func (c *CollectionsView) unlinkRequests(ids ...string) error {
+	for _, col := range api.UnlinkRequests(c.collections, ids...) {
+		if err := col.Save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LinkCount returns the number of requests linked to the requests of node
+func (c *CollectionsView) LinkCount(node *components.TreeNode) int {
+	count := 0
+	for _, id := range requestIDs(node) {
+		count += api.LinkCount(c.collections, id)
+	}
+	return count
+}
+
+// requestIDs returns the IDs of the requests of node and its subfolders
+func requestIDs(node *components.TreeNode) []string {
+	if node.Type == components.RequestNode {
+		return []string{node.ID}
+	}
+	var ids []string
+	for _, child := range node.Children {
+		ids = append(ids, requestIDs(child)...)
+	}
+	return ids
+}
+
 // DuplicateNode duplicates a tree node (request or folder)
 func (c *CollectionsView) DuplicateNode(node *components.TreeNode) error {
 	if node == nil {
@@ -395,11 +716,12 @@ func (c *CollectionsView) DuplicateNode(node *components.TreeNode) error {
 	return col.Save()
 }
 
-// PasteNode pastes clipboard content to target location
+// PasteNode pastes clipboard content to target location, as requests linked to the
+// clipboard's when link is true
 // Target logic:
 // - If target is a folder/collection: paste inside it
 // - If target is a request: paste in same folder as the request
-func (c *CollectionsView) PasteNode(clipboard *components.TreeNode, target *components.TreeNode) error {
+func (c *CollectionsView) PasteNode(clipboard *components.TreeNode, target *components.TreeNode, link bool) error {
 	if clipboard == nil {
 		return nil
 	}
@@ -417,29 +739,23 @@ func (c *CollectionsView) PasteNode(clipboard *components.TreeNode, target *comp
 		targetCol = sourceCol
 	}
 
-	// Determine target folder path based on cursor position
-	var targetFolderPath []string
-	if target != nil {
-		switch target.Type {
-		case components.CollectionNode:
-			// Paste at collection root
-			targetFolderPath = nil
-		case components.FolderNode:
-			// Paste inside the folder
-			targetFolderPath = c.GetFolderPathIncluding(target)
-		case components.RequestNode:
-			// Paste in same folder as the request
-			targetFolderPath = c.GetFolderPath(target.Parent)
-		}
-	}
+	targetFolderPath := c.pasteFolderPath(target)
 
 	// Copy based on clipboard type
 	switch clipboard.Type {
 	case components.RequestNode:
-		targetCol.CopyRequestToFolder(clipboard.ID, targetFolderPath)
+		original := sourceCol.FindRequest(clipboard.ID)
+		if original == nil {
+			return nil
+		}
+		targetCol.PasteRequest(original, targetFolderPath, link)
 	case components.FolderNode:
 		sourcePath := c.GetFolderPath(clipboard.Parent)
-		targetCol.CopyFolderToFolder(sourcePath, clipboard.Name, targetFolderPath)
+		original := sourceCol.FindFolderByName(sourcePath, clipboard.Name)
+		if original == nil {
+			return nil
+		}
+		targetCol.PasteFolder(original, targetFolderPath, link)
 	case components.CollectionNode:
 		// Cannot paste collection
 		return nil
@@ -448,6 +764,195 @@ func (c *CollectionsView) PasteNode(clipboard *components.TreeNode, target *comp
 	return targetCol.Save()
 }
 
+// pasteFolderPath returns the path of the folder to paste into at target: the folder
+// itself, the folder of a request, or the collection root
+func (c *CollectionsView) pasteFolderPath(target *components.TreeNode) []string {
+	if target == nil {
+		return nil
+	}
+	switch target.Type {
+	case components.FolderNode:
+		return c.GetFolderPathIncluding(target)
+	case components.RequestNode:
+		return c.GetFolderPath(target.Parent)
+	}
+	return nil
+}
+
+// MoveNode moves a request or folder node to the target location of a paste (see
+// PasteNode), within its collection or to another one. Requests keep their IDs, so
+// links, tabs and marks follow them. The tree is reloaded with the cursor on the
+// moved node.
+func (c *CollectionsView) MoveNode(node *components.TreeNode, target *components.TreeNode) error {
+	sourceCol := c.FindCollectionByNode(node)
+	if sourceCol == nil {
+		return nil
+	}
+	targetCol := c.FindCollectionByNode(target)
+	if targetCol == nil {
+		targetCol = sourceCol
+	}
+	targetFolderPath := c.pasteFolderPath(target)
+
+	switch node.Type {
+	case components.RequestNode:
+		req := sourceCol.TakeRequest(node.ID)
+		if req == nil {
+			return fmt.Errorf("request not found: %s", node.Name)
+		}
+		if err := targetCol.InsertRequest(req, targetFolderPath); err != nil {
+			c.ReloadCollections()
+			return err
+		}
+	case components.FolderNode:
+		sourcePath := c.GetFolderPathIncluding(node)
+		if targetCol == sourceCol && len(targetFolderPath) >= len(sourcePath) && slices.Equal(sourcePath, targetFolderPath[:len(sourcePath)]) {
+			return fmt.Errorf("cannot move %s into itself", node.Name)
+		}
+		folder := sourceCol.TakeFolder(c.GetFolderPath(node.Parent), node.Name)
+		if folder == nil {
+			return fmt.Errorf("folder not found: %s", node.Name)
+		}
+		if err := targetCol.InsertFolder(folder, targetFolderPath); err != nil {
+			c.ReloadCollections()
+			return err
+		}
+	default:
+		// A collection is a file of its own
+		return nil
+	}
+
+	if err := sourceCol.Save(); err != nil {
+		return err
+	}
+	if targetCol != sourceCol {
+		if err := targetCol.Save(); err != nil {
+			return err
+		}
+	}
+	c.ReloadCollections()
+	c.revealMoved(node, targetCol.Name, append(targetFolderPath, node.Name))
+	return nil
+}
+
+// MoveSibling moves a request or folder node by offset positions among the requests or
+// sub-folders of its folder, the order of the tree, runs and exports. The tree is
+// reloaded with the cursor on the moved node.
+func (c *CollectionsView) MoveSibling(node *components.TreeNode, offset int) error {
+	col := c.FindCollectionByNode(node)
+	if col == nil {
+		return nil
+	}
+
+	key, folder := node.ID, false
+	switch node.Type {
+	case components.CollectionNode:
+		// Collections are listed by file name
+		return nil
+	case components.FolderNode:
+		key, folder = node.Name, true
+	}
+
+	parentPath := c.GetFolderPath(node.Parent)
+	if !col.MoveSibling(parentPath, key, folder, offset) {
+		return nil
+	}
+	if err := col.Save(); err != nil {
+		return err
+	}
+	c.ReloadCollections()
+	c.revealMoved(node, col.Name, append(parentPath, node.Name))
+	return nil
+}
+
+// revealMoved moves the cursor to a node moved to another place of the reloaded tree: a
+// request by ID, a folder by collection and folder path, keeping it expanded or collapsed
+func (c *CollectionsView) revealMoved(node *components.TreeNode, collection string, folderPath []string) {
+	if node.Type == components.RequestNode {
+		c.RevealNode(node.ID)
+		return
+	}
+
+	var moved *components.TreeNode
+	for _, root := range c.tree.Root {
+		if root.Name == collection {
+			moved = root
+			break
+		}
+	}
+	for _, name := range folderPath {
+		if moved == nil {
+			return
+		}
+		var next *components.TreeNode
+		for _, child := range moved.Children {
+			if child.Type == components.FolderNode && child.Name == name {
+				next = child
+				break
+			}
+		}
+		moved = next
+	}
+	if moved != nil && c.RevealNode(moved.ID) {
+		moved.Expanded = node.Expanded
+		c.tree.Refresh()
+	}
+}
+
+// RunEntries returns the run order of the folder holding node, the root of a
+// collection node, with ok false when node is not in a collection
+func (c *CollectionsView) RunEntries(node *components.TreeNode) (entries []api.RunEntry, ok bool) {
+	col := c.FindCollectionByNode(node)
+	if col == nil {
+		return nil, false
+	}
+	return col.RunEntriesAt(c.GetFolderPath(node.Parent))
+}
+
+// MoveInRunOrder moves a folder or request node by offset positions in the run order
+// of its folder
+func (c *CollectionsView) MoveInRunOrder(node *components.TreeNode, offset int) error {
+	col := c.FindCollectionByNode(node)
+	if col == nil {
+		return nil
+	}
+
+	key := node.ID
+	switch node.Type {
+	case components.CollectionNode:
+		// A collection has no run order within the workspace
+		return nil
+	case components.FolderNode:
+		key = node.Name
+	}
+
+	col.MoveInRunOrder(c.GetFolderPath(node.Parent), key, offset)
+	return col.Save()
+}
+
+// ClearRunOrder makes the folder holding node, the root of a collection node, run in
+// tree order again
+func (c *CollectionsView) ClearRunOrder(node *components.TreeNode) error {
+	col := c.FindCollectionByNode(node)
+	if col == nil {
+		return nil
+	}
+	col.ClearRunOrder(c.GetFolderPath(node.Parent))
+	return col.Save()
+}
+
+// ToggleSkipInRuns switches whether a request is left out of collection runs, and
+// returns the new setting. A linked request has its own setting.
+func (c *CollectionsView) ToggleSkipInRuns(requestID string) (bool, error) {
+	req := c.FindRequestByID(requestID)
+	if req == nil {
+		return false, nil
+	}
+	col := c.FindCollectionByRequestID(requestID)
+	col.SetSkipInRuns(requestID, !req.SkipInRuns)
+	return req.SkipInRuns, col.Save()
+}
+
 // GetFolderPathIncluding returns the folder path including the node itself
 func (c *CollectionsView) GetFolderPathIncluding(node *components.TreeNode) []string {
 	if node == nil || node.Type != components.FolderNode {
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+func TestModel_MoveInTree(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{
+		Name: "Shop",
+		Folders: []api.Folder{
+			{Name: "Orders", Requests: []api.CollectionRequest{{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"}}},
+			{Name: "Carts"},
+		},
+		Requests: []api.CollectionRequest{
+			{ID: "health", Name: "Health", Method: api.GET, URL: "https://shop.example.com/health"},
+			{ID: "login", Name: "Login", Method: api.POST, URL: "https://shop.example.com/login"},
+		},
+	}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	collections := m.leftPanel.GetCollections()
+	// press sends a key to the tree with the cursor on the node with id, and the
+	// message it returns to the model
+	press := func(id, key string) {
+		t.Helper()
+		if !collections.RevealNode(id) {
+			t.Fatalf("no node %s in the tree", id)
+		}
+		_, cmd := collections.GetTree().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}, true)
+		if cmd == nil {
+			t.Fatalf("%s should send a message", key)
+		}
+		model, _ := m.Update(cmd())
+		m = model.(Model)
+	}
+	folderID := func(name string) string {
+		t.Helper()
+		for _, node := range collections.GetTree().GetVisibleItems() {
+			if node.Type == components.FolderNode && node.Name == name {
+				return node.ID
+			}
+		}
+		t.Fatalf("no folder %s in the tree", name)
+		return ""
+	}
+	saved := func() *api.CollectionFile {
+		t.Helper()
+		col, err := api.LoadCollection(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return col
+	}
+
+	// K moves a request up among its siblings, and the cursor with it
+	press("login", "K")
+	if col := saved(); col.Requests[0].ID != "login" || col.Requests[1].ID != "health" {
+		t.Fatalf("K should move Login first, got %+v", col.Requests)
+	}
+	if selected := collections.Selected(); selected == nil || selected.ID != "login" {
+		t.Errorf("the cursor should stay on Login, got %+v", selected)
+	}
+
+	// x then p moves the request into the folder under the cursor, keeping its ID
+	press("login", "x")
+	ordersID, cartsID := folderID("Orders"), folderID("Carts")
+	press(ordersID, "p")
+	col = saved()
+	if len(col.Requests) != 1 || col.FindFolderByName(nil, "Orders").Requests[1].ID != "login" {
+		t.Fatalf("p should move Login into Orders, got %+v", col)
+	}
+	if collections.GetClipboard() != nil || m.statusBar.message == "" {
+		t.Errorf("the clipboard should be emptied once moved, got %q", m.statusBar.message)
+	}
+
+	// A folder cannot be moved into itself
+	press(ordersID, "x")
+	press("list", "p")
+	if saved().FindFolderByName(nil, "Orders") == nil {
+		t.Error("moving a folder into itself should be refused")
+	}
+	press(cartsID, "p")
+	col = saved()
+	if len(col.Folders) != 1 || col.FindFolderByName([]string{"Carts"}, "Orders") == nil || len(col.FindFolderByName([]string{"Carts"}, "Orders").Requests) != 2 {
+		t.Errorf("p should move Orders and its requests into Carts, got %+v", col.Folders)
+	}
+	if selected := collections.Selected(); selected == nil || selected.Name != "Orders" || selected.Parent.Name != "Carts" {
+		t.Errorf("the cursor should follow Orders, got %+v", selected)
+	}
+}
@@ -47,6 +47,13 @@ func (c *CommandInput) Show() {
 	c.tempInput = ""
 }
 
+// ShowWith makes the command input visible holding text, the cursor at its end
+func (c *CommandInput) ShowWith(text string) {
+	c.Show()
+	c.input = text
+	c.cursor = len(text)
+}
+
 // Hide hides the command input
 func (c *CommandInput) Hide() {
 	c.visible = false
@@ -253,6 +260,49 @@ const (
 	CmdCollectionsShort = "col"
 	CmdImport           = "import"
 	CmdExport           = "export"
+	CmdDoctor           = "doctor"
+	CmdSign             = "sign"
+	CmdFreeze           = "freeze"
+	CmdMock             = "mock"
+	CmdChaos            = "chaos"
+	CmdTutorial         = "tutorial"
+	CmdStats            = "stats"
+	CmdLatency          = "latency"
+	CmdCompare          = "compare"
+	CmdSync             = "sync"
+	CmdGRPC             = "grpc"
+	CmdSecrets          = "secrets"
+	CmdSession          = "session"
+	CmdTLS              = "tls"
+	CmdRunOrder         = "runorder"
+	CmdSkip             = "skip"
+	CmdRedirects        = "redirects"
+	CmdExtract          = "extract"
+	CmdBody             = "body"
+	CmdSchema           = "schema"
+	CmdPoll             = "poll"
+	CmdJob              = "job"
+	CmdVars             = "vars"
+	CmdGrep             = "grep"
+	CmdHistory          = "history"
+	CmdStatusBar        = "statusbar"
+	CmdTheme            = "theme"
+	CmdSettings         = "settings"
+	CmdLock             = "lock"
+	CmdTabNew           = "tabnew"
+	CmdTabClose         = "tabclose"
+	CmdTabNext          = "tabnext"
+	CmdTabPrev          = "tabprev"
+	CmdGit              = "git"
+	CmdScripts          = "scripts"
+	CmdConsole          = "console"
+	CmdLint             = "lint"
+	CmdDiff             = "diff"
+	CmdFilter           = "filter"
+	CmdRegisters        = "registers"
+	CmdRegistersShort   = "reg"
+	CmdFind             = "find"
+	CmdRedraw           = "redraw"
 )
 
 // Workspace subcommands
@@ -261,10 +311,177 @@ const (
 	WorkspaceSwitch = "switch"
 	WorkspaceCreate = "create"
 	WorkspaceDelete = "delete"
+	WorkspaceForget = "forget"
+)
+
+// Lock subcommands
+const (
+	LockPassphrase = "passphrase"
+	LockIdle       = "idle"
+	LockOff        = "off"
+)
+
+// Git subcommands
+const (
+	GitAdd    = "add"
+	GitCommit = "commit"
+)
+
+// Scripts subcommands
+const (
+	ScriptsPre   = "pre"
+	ScriptsPost  = "post"
+	ScriptsClear = "clear"
+)
+
+// Status bar subcommands
+const (
+	StatusBarLeft  = "left"
+	StatusBarRight = "right"
+	StatusBarTime  = "time"
+	StatusBarSave  = "save"
+	StatusBarReset = "reset"
+)
+
+// Freeze subcommands
+const (
+	FreezeSecrets = "secrets"
+	FreezeList    = "list"
+	FreezeShow    = "show"
+	FreezeDelete  = "delete"
+)
+
+// Theme subcommands
+const (
+	ThemeSave  = "save"
+	ThemeReset = "reset"
+)
+
+// History subcommands
+const (
+	HistoryArchive = "archive"
+)
+
+// Diff subcommands
+const (
+	DiffBaseline = "baseline"
+	DiffClear    = "clear"
+)
+
+// Lint subcommands
+const (
+	LintAll = "all"
+)
+
+// Filter subcommands
+const (
+	FilterFailed = "failed"
+	FilterClear  = "clear"
+)
+
+// Console subcommands
+const (
+	ConsoleLevel   = "level"
+	ConsoleRequest = "request"
+	ConsoleSearch  = "search"
+	ConsoleClear   = "clear"
+)
+
+// Environment subcommands
+const (
+	EnvCheck = "check"
+	EnvHook  = "hook"
+)
+
+// Tutorial subcommands
+const (
+	TutorialNext = "next"
+	TutorialStop = "stop"
+)
+
+// Stats subcommands
+const (
+	StatsOn    = "on"
+	StatsOff   = "off"
+	StatsClear = "clear"
+)
+
+// Secrets subcommands
+const (
+	SecretsKeychain = "keychain"
+	SecretsFile     = "file"
+)
+
+// Collections subcommands
+const (
+	CollectionsSplit = "split"
+	CollectionsJoin  = "join"
+)
+
+// Session subcommands (isolated and shared are api.SessionIsolated and api.SessionShared)
+const (
+	SessionClear = "clear"
+)
+
+// TLS subcommands
+const (
+	TLSInsecure = "insecure"
+	TLSCA       = "ca"
+	TLSCert     = "cert"
+	TLSClear    = "clear"
+	TLSOn       = "on"
+	TLSOff      = "off"
+)
+
+// Vars subcommands
+const (
+	VarsSet   = "set"
+	VarsUnset = "unset"
+)
+
+// Poll subcommands
+const (
+	PollOff = "off"
+)
+
+// Run order subcommands
+const (
+	RunOrderUp    = "up"
+	RunOrderDown  = "down"
+	RunOrderFirst = "first"
+	RunOrderLast  = "last"
+	RunOrderClear = "clear"
+)
+
+// Extract subcommands
+const (
+	ExtractClear = "clear"
+)
+
+// Schema subcommands (any other argument is the path of a JSON Schema file)
+const (
+	SchemaClear = "clear"
+)
+
+// Redirects subcommands (a number sets the maximum number of redirects followed)
+const (
+	RedirectsOn  = "on"
+	RedirectsOff = "off"
 )
 
 // Import/Export subcommands
 const (
-	ImportPostman = "postman"
-	ExportPostman = "postman"
+	ImportPostman    = "postman"
+	ImportOpenAPI    = "openapi"
+	ImportCurl       = "curl"
+	ImportDotenv     = "dotenv"
+	ImportHTTP       = "http"
+	ImportHoppscotch = "hoppscotch"
+	ExportPostman    = "postman"
+	ExportCSV        = "csv"
+	ExportBody       = "body"
+	ExportInventory  = "inventory"
+	ExportDotenv     = "dotenv"
+	ExportHTTP       = "http"
+	ExportHoppscotch = "hoppscotch"
 )
@@ -0,0 +1,423 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// commandPaletteRows is the number of actions shown at once
+const commandPaletteRows = 14
+
+// paletteFocus is the panel an action of the command palette runs in
+type paletteFocus int
+
+const (
+	paletteFocusAny         paletteFocus = iota // The active panel
+	paletteFocusCollections                     // The collections tree
+	paletteFocusRequest                         // The request panel
+)
+
+// PaletteAction is an action of the command palette
+type PaletteAction struct {
+	Group   string // Section of the action, such as "Request" or "Collections"
+	Title   string
+	Hint    string // Key binding or command running the action outside the palette
+	Focus   paletteFocus
+	Msg     tea.Msg // Dispatched when chosen: the key of the binding or the command
+	Prefill string  // Command line opened instead, for commands taking arguments
+}
+
+// PaletteActionMsg is sent when an action is chosen in the command palette
+type PaletteActionMsg struct {
+	Action PaletteAction
+}
+
+// ActivateEnvironmentMsg makes the environment named Name active
+type ActivateEnvironmentMsg struct {
+	Name string
+}
+
+// CommandPalette is the modal listing every action, filtered by fuzzy search
+type CommandPalette struct {
+	visible bool
+	query   []rune
+	actions []PaletteAction
+	matches []PaletteAction
+	cursor  int
+	offset  int
+}
+
+// NewCommandPalette creates a new command palette
+func NewCommandPalette() *CommandPalette {
+	return &CommandPalette{}
+}
+
+// Show opens the palette listing actions
+func (p *CommandPalette) Show(actions []PaletteAction) {
+	p.visible = true
+	p.actions = actions
+	p.query = nil
+	p.filter()
+}
+
+// Hide closes the palette
+func (p *CommandPalette) Hide() {
+	p.visible = false
+	p.actions = nil
+	p.matches = nil
+}
+
+// IsVisible returns whether the palette is visible
+func (p *CommandPalette) IsVisible() bool {
+	return p.visible
+}
+
+// Matches returns the actions matching the query, the best first
+func (p *CommandPalette) Matches() []PaletteAction {
+	return p.matches
+}
+
+// filter keeps the actions whose group and title match the query, the best first;
+// without a query every action is listed in order
+func (p *CommandPalette) filter() {
+	p.cursor = 0
+	p.offset = 0
+	query := strings.TrimSpace(string(p.query))
+	if query == "" {
+		p.matches = p.actions
+		return
+	}
+
+	type scored struct {
+		action PaletteAction
+		score  int
+	}
+	var found []scored
+	for _, action := range p.actions {
+		if score, ok := api.FuzzyMatch(action.Group+": "+action.Title, query); ok {
+			found = append(found, scored{action, score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score > found[j].score
+	})
+	p.matches = make([]PaletteAction, len(found))
+	for i, f := range found {
+		p.matches[i] = f.action
+	}
+}
+
+// Update handles key presses: typed text filters the actions, up/down (or Ctrl+N/P)
+// move, Enter runs the action and Esc closes
+func (p *CommandPalette) Update(msg tea.KeyMsg) (*CommandPalette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		p.Hide()
+	case "enter":
+		if p.cursor >= len(p.matches) {
+			return p, nil
+		}
+		action := p.matches[p.cursor]
+		p.Hide()
+		return p, func() tea.Msg { return PaletteActionMsg{Action: action} }
+	case "down", "ctrl+n", "ctrl+j":
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "backspace":
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	case "ctrl+u":
+		p.query = nil
+		p.filter()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			p.query = append(p.query, msg.Runes...)
+			p.filter()
+		}
+	}
+
+	// Keep the cursor in the visible window
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+commandPaletteRows {
+		p.offset = p.cursor - commandPaletteRows + 1
+	}
+	return p, nil
+}
+
+// View renders the query and the matching actions with their key hints
+func (p *CommandPalette) View(screenWidth, screenHeight int) string {
+	if !p.visible {
+		return ""
+	}
+
+	width := min(72, screenWidth-4)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Lavender).
+		Width(innerWidth).
+		Align(lipgloss.Center)
+	promptStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true)
+	groupStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	selectedStyle := lipgloss.NewStyle().
+		Background(styles.Surface0).
+		Foreground(styles.Lavender).
+		Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Commands"))
+	content.WriteString("\n")
+	content.WriteString(promptStyle.Render("> ") + string(p.query) + "█")
+	content.WriteString("\n\n")
+
+	if len(p.matches) == 0 {
+		content.WriteString(helpStyle.Render("No action matches"))
+		content.WriteString("\n")
+	}
+
+	end := min(p.offset+commandPaletteRows, len(p.matches))
+	for i := p.offset; i < end; i++ {
+		action := p.matches[i]
+		group := action.Group + ": "
+		hint := action.Hint
+		titleWidth := max(innerWidth-len(group)-len(hint)-3, 4)
+		title := truncateHistoryValue(action.Title, titleWidth)
+		gap := strings.Repeat(" ", max(titleWidth-lipgloss.Width(title), 0)+1)
+
+		if i == p.cursor {
+			content.WriteString(selectedStyle.Render("▸ " + group + title + gap + hint))
+		} else {
+			content.WriteString("  " + groupStyle.Render(group) + title + gap + hintStyle.Render(hint))
+		}
+		content.WriteString("\n")
+	}
+	if len(p.matches) > commandPaletteRows {
+		content.WriteString(groupStyle.Render(fmt.Sprintf("%d/%d", p.cursor+1, len(p.matches))))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render("↑/↓: select · enter: run · ctrl+u: clear · esc: close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender).
+		Padding(1, 2).
+		Width(width).
+		Render(content.String())
+}
+
+// bindingKeyMsg returns the key message of a key binding such as "n", "ctrl+e" or
+// "alt+enter", as the terminal would send it
+func bindingKeyMsg(binding string) (tea.KeyMsg, bool) {
+	name, alt := strings.CutPrefix(binding, "alt+")
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if t != tea.KeyRunes && (tea.Key{Type: t}).String() == name {
+			return tea.KeyMsg{Type: t, Alt: alt}, true
+		}
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// keyAction returns an action sending the first key of bindings, nil when none is bound
+func keyAction(group, title string, focus paletteFocus, bindings ...string) *PaletteAction {
+	if len(bindings) == 0 {
+		return nil
+	}
+	msg, ok := bindingKeyMsg(bindings[0])
+	if !ok {
+		return nil
+	}
+	return &PaletteAction{Group: group, Title: title, Hint: bindings[0], Focus: focus, Msg: msg}
+}
+
+// commandAction returns an action running the command line text, like :text
+func commandAction(group, title, text string) *PaletteAction {
+	cmd, args := parseCommand(text)
+	return &PaletteAction{
+		Group: group,
+		Title: title,
+		Hint:  ":" + text,
+		Msg:   CommandExecuteMsg{Command: cmd, Args: args, Raw: text},
+	}
+}
+
+// promptAction returns an action opening the command line with text, for the
+// arguments of the command
+func promptAction(group, title, text string) *PaletteAction {
+	return &PaletteAction{Group: group, Title: title, Hint: ":" + text + "…", Prefill: text + " "}
+}
+
+// paletteActions lists the actions of the command palette, with the key bindings in
+// effect
+func (m Model) paletteActions() []PaletteAction {
+	kb := m.keys
+	actions := []*PaletteAction{
+		keyAction("Request", "Send request", paletteFocusAny, kb.SendRequest...),
+		commandAction("Request", "Save request", CmdWrite),
+		keyAction("Request", "Copy request as code", paletteFocusRequest, "ctrl+y"),
+		commandAction("Request", "Show variables by scope", CmdVars),
+		commandAction("Request", "Chart response times", CmdLatency),
+		commandAction("Request", "Diagnose connectivity", CmdDoctor),
+		commandAction("Request", "Debug JWT signing", CmdSign),
+		commandAction("Request", "Freeze request as an example", CmdFreeze),
+		commandAction("Request", "Open request in a new tab", CmdTabNew),
+		commandAction("Request", "Close tab", CmdTabClose),
+		commandAction("Request", "Next tab", CmdTabNext),
+		commandAction("Request", "Previous tab", CmdTabPrev),
+
+		keyAction("Collections", "New request", paletteFocusCollections, kb.NewRequest...),
+		keyAction("Collections", "New folder", paletteFocusCollections, kb.NewFolder...),
+		keyAction("Collections", "Rename", paletteFocusCollections, kb.Rename...),
+		keyAction("Collections", "Duplicate", paletteFocusCollections, kb.Duplicate...),
+		keyAction("Collections", "Delete", paletteFocusCollections, kb.DeleteRequest...),
+		keyAction("Collections", "Run collection or folder", paletteFocusCollections, kb.RunFolder...),
+		keyAction("Collections", "Send collection or folder in place", paletteFocusCollections, kb.SendFolder...),
+		keyAction("Collections", "Filter by method", paletteFocusCollections, "M"),
+		keyAction("Collections", "Show only failed requests", paletteFocusCollections, "X"),
+		commandAction("Collections", "Clear filters", CmdFilter+" clear"),
+		keyAction("Collections", "Search all collections", paletteFocusAny, kb.SearchAll...),
+		commandAction("Collections", "Lint collection", CmdLint),
+		commandAction("Collections", "Sync with OpenAPI spec", CmdSync),
+		commandAction("Collections", "Show collections", CmdCollections),
+
+		commandAction("Environment", "Show environments", CmdEnv),
+		commandAction("Environment", "Check required variables", CmdEnv+" "+EnvCheck),
+	}
+	for _, env := range m.leftPanel.GetEnvironments().GetEnvironments() {
+		action := &PaletteAction{Group: "Environment", Title: "Use " + env.Name, Msg: ActivateEnvironmentMsg{Name: env.Name}}
+		if env.Name == m.leftPanel.GetEnvironments().GetActiveEnvironmentName() {
+			action.Hint = "active"
+		}
+		actions = append(actions, action)
+	}
+
+	importCurl := commandAction("Import/Export", "Import cURL command", CmdImport+" "+ImportCurl)
+	importOpenAPI := commandAction("Import/Export", "Import OpenAPI spec", CmdImport+" "+ImportOpenAPI)
+	if len(kb.ImportCurl) > 0 {
+		importCurl.Hint = kb.ImportCurl[0]
+	}
+	if len(kb.ImportOpenAPI) > 0 {
+		importOpenAPI.Hint = kb.ImportOpenAPI[0]
+	}
+	actions = append(actions,
+		importCurl,
+		importOpenAPI,
+		promptAction("Import/Export", "Import Postman file", CmdImport+" "+ImportPostman),
+		promptAction("Import/Export", "Import .env file", CmdImport+" "+ImportDotenv),
+		promptAction("Import/Export", "Import .http file", CmdImport+" "+ImportHTTP),
+		promptAction("Import/Export", "Import Hoppscotch file", CmdImport+" "+ImportHoppscotch),
+		keyAction("Import/Export", "Copy request as cURL", paletteFocusAny, kb.ExportCurl...),
+		promptAction("Import/Export", "Export collection to Postman", CmdExport+" "+ExportPostman),
+		promptAction("Import/Export", "Export API inventory", CmdExport+" "+ExportInventory),
+		promptAction("Import/Export", "Export environment to .env", CmdExport+" "+ExportDotenv),
+		promptAction("Import/Export", "Export request to .http", CmdExport+" "+ExportHTTP),
+		promptAction("Import/Export", "Export to Hoppscotch", CmdExport+" "+ExportHoppscotch),
+
+		keyAction("View", "Toggle fullscreen", paletteFocusAny, kb.Fullscreen...),
+		keyAction("View", "Jump to element", paletteFocusAny, kb.Jump...),
+		keyAction("View", "Show keybindings", paletteFocusAny, kb.WhichKey...),
+		keyAction("View", "Redraw screen", paletteFocusAny, kb.Redraw...),
+		commandAction("View", "Usage statistics", CmdStats),
+		promptAction("View", "Switch theme", CmdTheme),
+		commandAction("View", "Settings", CmdSettings),
+		commandAction("View", "Workspaces", CmdWorkspace+" "+WorkspaceList),
+		commandAction("View", "Lock workspace", CmdLock),
+
+		commandAction("Tools", "Toggle mock mode", CmdMock),
+		commandAction("Tools", "Toggle chaos mode", CmdChaos),
+		commandAction("Tools", "Clipboard registers", CmdRegisters),
+		commandAction("Tools", "Console history", CmdHistory),
+		commandAction("Tools", "Git status", CmdGit),
+		commandAction("Tools", "Start tutorial", CmdTutorial),
+		commandAction("Tools", "Quit", CmdQuit),
+	)
+
+	var result []PaletteAction
+	for _, action := range actions {
+		if action != nil {
+			result = append(result, *action)
+		}
+	}
+	return result
+}
+
+// showCommandPalette opens the command palette
+func (m Model) showCommandPalette() (tea.Model, tea.Cmd) {
+	m.commandPalette.Show(m.paletteActions())
+	return m, nil
+}
+
+// runPaletteAction focuses the panel of action and dispatches its message in NORMAL
+// mode, as its key binding or command would, or opens the command line for it
+func (m Model) runPaletteAction(action PaletteAction) (tea.Model, tea.Cmd) {
+	switch action.Focus {
+	case paletteFocusCollections:
+		m.leftPanel.SetActiveTab(CollectionsTab)
+		m.activePanel = CollectionsPanel
+	case paletteFocusRequest:
+		m.activePanel = RequestPanel
+	}
+	if m.isFullscreen {
+		m.fullscreenPanel = m.activePanel
+	}
+
+	previous := m.mode
+	if action.Prefill != "" {
+		m.mode = CommandMode
+		m.statusBar.SetMode(CommandMode)
+		m.commandInput.ShowWith(action.Prefill)
+		return m, func() tea.Msg {
+			return ModeChangeMsg{From: previous, To: CommandMode}
+		}
+	}
+
+	m.mode = NormalMode
+	m.statusBar.SetMode(NormalMode)
+	next, cmd := m.update(action.Msg)
+	if previous != NormalMode {
+		cmd = tea.Batch(func() tea.Msg {
+			return ModeChangeMsg{From: previous, To: NormalMode}
+		}, cmd)
+	}
+	return next, cmd
+}
+
+// activateEnvironment makes the environment named name active, running its activation
+// hook
+func (m Model) activateEnvironment(name string) (tea.Model, tea.Cmd) {
+	environments := m.leftPanel.GetEnvironments()
+	previous := environments.GetActiveEnvironmentName()
+	environments.SetActiveEnvironmentName(name)
+	if environments.GetActiveEnvironmentName() != name {
+		m.statusBar.Error(fmt.Errorf("environment %s not found", name))
+		return m, nil
+	}
+	m.statusBar.Success("Environment", name)
+	return m, tea.Batch(m.environmentActivated(previous), m.markSessionDirty())
+}
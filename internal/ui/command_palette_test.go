@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestBindingKeyMsg(t *testing.T) {
+	for _, binding := range []string{"n", "Z", "?", "ctrl+e", "ctrl+s", "enter", "alt+enter", " ", "shift+tab", "f5"} {
+		msg, ok := bindingKeyMsg(binding)
+		if !ok || msg.String() != binding {
+			t.Errorf("bindingKeyMsg(%q) = %q, %v", binding, msg.String(), ok)
+		}
+	}
+	if _, ok := bindingKeyMsg("ctrl+x ctrl+s"); ok {
+		t.Error("a key sequence should not be a key message")
+	}
+}
+
+func TestCommandPalette(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var model tea.Model = NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), t.TempDir())
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// run opens the palette, filters it with query and runs the best match
+	run := func(query, want string) tea.Cmd {
+		t.Helper()
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+		palette := model.(Model).commandPalette
+		if !palette.IsVisible() {
+			t.Fatal("ctrl+p should open the command palette")
+		}
+		for _, r := range query {
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		if matches := palette.Matches(); len(matches) == 0 || matches[0].Title != want {
+			t.Fatalf("query %q should match %q first, got %+v", query, want, matches)
+		}
+		var cmd tea.Cmd
+		model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		model, cmd = model.Update(cmd())
+		return cmd
+	}
+
+	run("fullscr", "Toggle fullscreen")
+	if !model.(Model).isFullscreen {
+		t.Error("Toggle fullscreen should zoom the panel, as Z does")
+	}
+	run("fullscr", "Toggle fullscreen")
+
+	run("inventory", "Export API inventory")
+	m := model.(Model)
+	if m.mode != CommandMode || m.commandInput.GetInput() != "export inventory " {
+		t.Errorf("Export API inventory should open the command line, got mode %s and %q", m.mode, m.commandInput.GetInput())
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m = model.(Model)
+	m.activePanel = ResponsePanel
+	model = m
+	run("show coll", "Show collections")
+	if model.(Model).activePanel != CollectionsPanel {
+		t.Error("Show collections should run :collections")
+	}
+}
@@ -0,0 +1,443 @@
+package components
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// SortDirection represents the sort order of a data table column
+type SortDirection int
+
+const (
+	SortNone SortDirection = iota
+	SortAsc
+	SortDesc
+)
+
+// Column width limits for data table rendering
+const (
+	dataTableMinColWidth = 4
+	dataTableMaxColWidth = 40
+)
+
+// DataTable is a read-only, sortable and horizontally scrollable table
+// used to display tabular response data (e.g. JSON arrays of objects)
+type DataTable struct {
+	columns []string
+	rows    [][]string
+	hidden  map[string]bool
+	numeric []bool // Columns whose non-empty cells are all numbers (right-aligned)
+
+	order     []int // Row indices in display order (after sorting)
+	sortCol   int   // Index into columns, -1 when unsorted
+	sortDir   SortDirection
+	cursor    int // Selected row (display order)
+	colCursor int // Selected column (index into visible columns)
+	colOffset int // First visible column rendered (horizontal scroll)
+}
+
+// NewDataTable creates an empty data table
+func NewDataTable() *DataTable {
+	return &DataTable{
+		hidden:  make(map[string]bool),
+		sortCol: -1,
+	}
+}
+
+// SetData replaces the table contents and resets navigation and sorting.
+// Hidden columns are kept so that column selection survives new responses.
+func (t *DataTable) SetData(columns []string, rows [][]string) {
+	t.columns = columns
+	t.rows = rows
+	t.sortCol = -1
+	t.sortDir = SortNone
+	t.cursor = 0
+	t.colCursor = 0
+	t.colOffset = 0
+	t.resetOrder()
+	t.detectNumericColumns()
+}
+
+// detectNumericColumns marks columns whose non-empty cells all parse as numbers
+func (t *DataTable) detectNumericColumns() {
+	t.numeric = make([]bool, len(t.columns))
+	for col := range t.columns {
+		hasValue := false
+		isNumeric := true
+		for _, row := range t.rows {
+			cell := row[col]
+			if cell == "" {
+				continue
+			}
+			hasValue = true
+			if _, err := strconv.ParseFloat(cell, 64); err != nil {
+				isNumeric = false
+				break
+			}
+		}
+		t.numeric[col] = hasValue && isNumeric
+	}
+}
+
+// DisplayedData returns the visible columns and the rows in display order,
+// i.e. what the user currently sees (hidden columns dropped, sort applied)
+func (t *DataTable) DisplayedData() ([]string, [][]string) {
+	visible := t.VisibleColumns()
+	columns := make([]string, len(visible))
+	for i, col := range visible {
+		columns[i] = t.columns[col]
+	}
+	rows := make([][]string, len(t.order))
+	for i, idx := range t.order {
+		row := make([]string, len(visible))
+		for j, col := range visible {
+			row[j] = t.rows[idx][col]
+		}
+		rows[i] = row
+	}
+	return columns, rows
+}
+
+// Columns returns all column names
+func (t *DataTable) Columns() []string {
+	return t.columns
+}
+
+// RowCount returns the number of rows
+func (t *DataTable) RowCount() int {
+	return len(t.rows)
+}
+
+// VisibleColumns returns the indices of columns that are not hidden
+func (t *DataTable) VisibleColumns() []int {
+	visible := make([]int, 0, len(t.columns))
+	for i, col := range t.columns {
+		if !t.hidden[col] {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// HiddenColumns returns the names of hidden columns, sorted
+func (t *DataTable) HiddenColumns() []string {
+	hidden := make([]string, 0, len(t.hidden))
+	for col, isHidden := range t.hidden {
+		if isHidden {
+			hidden = append(hidden, col)
+		}
+	}
+	sort.Strings(hidden)
+	return hidden
+}
+
+// SetHiddenColumns sets which columns are hidden
+func (t *DataTable) SetHiddenColumns(columns []string) {
+	t.hidden = make(map[string]bool, len(columns))
+	for _, col := range columns {
+		t.hidden[col] = true
+	}
+	t.clampColumnCursor()
+}
+
+// HideCurrentColumn hides the selected column. The last visible column cannot be hidden.
+func (t *DataTable) HideCurrentColumn() bool {
+	visible := t.VisibleColumns()
+	if len(visible) <= 1 || t.colCursor >= len(visible) {
+		return false
+	}
+	t.hidden[t.columns[visible[t.colCursor]]] = true
+	t.clampColumnCursor()
+	return true
+}
+
+// ShowAllColumns makes every column visible again
+func (t *DataTable) ShowAllColumns() {
+	t.hidden = make(map[string]bool)
+}
+
+// CurrentColumn returns the name of the selected column
+func (t *DataTable) CurrentColumn() string {
+	visible := t.VisibleColumns()
+	if t.colCursor < 0 || t.colCursor >= len(visible) {
+		return ""
+	}
+	return t.columns[visible[t.colCursor]]
+}
+
+// MoveUp moves the row cursor up
+func (t *DataTable) MoveUp() {
+	if t.cursor > 0 {
+		t.cursor--
+	}
+}
+
+// MoveDown moves the row cursor down
+func (t *DataTable) MoveDown() {
+	if t.cursor < len(t.rows)-1 {
+		t.cursor++
+	}
+}
+
+// GoToTop moves the row cursor to the first row
+func (t *DataTable) GoToTop() {
+	t.cursor = 0
+}
+
+// GoToBottom moves the row cursor to the last row
+func (t *DataTable) GoToBottom() {
+	if len(t.rows) > 0 {
+		t.cursor = len(t.rows) - 1
+	}
+}
+
+// ColumnLeft moves the column cursor left
+func (t *DataTable) ColumnLeft() {
+	if t.colCursor > 0 {
+		t.colCursor--
+	}
+}
+
+// ColumnRight moves the column cursor right
+func (t *DataTable) ColumnRight() {
+	if t.colCursor < len(t.VisibleColumns())-1 {
+		t.colCursor++
+	}
+}
+
+// CycleSort cycles the selected column through ascending, descending and unsorted
+func (t *DataTable) CycleSort() {
+	visible := t.VisibleColumns()
+	if t.colCursor >= len(visible) {
+		return
+	}
+	col := visible[t.colCursor]
+
+	if t.sortCol != col {
+		t.sortCol = col
+		t.sortDir = SortAsc
+	} else {
+		switch t.sortDir {
+		case SortAsc:
+			t.sortDir = SortDesc
+		case SortDesc:
+			t.sortDir = SortNone
+			t.sortCol = -1
+		default:
+			t.sortDir = SortAsc
+		}
+	}
+	t.applySort()
+}
+
+// SortState returns the sorted column name and direction
+func (t *DataTable) SortState() (string, SortDirection) {
+	if t.sortCol < 0 || t.sortCol >= len(t.columns) {
+		return "", SortNone
+	}
+	return t.columns[t.sortCol], t.sortDir
+}
+
+// SelectedRow returns the selected row values, or nil if empty
+func (t *DataTable) SelectedRow() []string {
+	if t.cursor < 0 || t.cursor >= len(t.order) {
+		return nil
+	}
+	return t.rows[t.order[t.cursor]]
+}
+
+func (t *DataTable) resetOrder() {
+	t.order = make([]int, len(t.rows))
+	for i := range t.order {
+		t.order[i] = i
+	}
+}
+
+func (t *DataTable) applySort() {
+	t.resetOrder()
+	if t.sortCol < 0 || t.sortDir == SortNone {
+		return
+	}
+	col := t.sortCol
+	sort.SliceStable(t.order, func(i, j int) bool {
+		a := t.rows[t.order[i]][col]
+		b := t.rows[t.order[j]][col]
+		if t.sortDir == SortDesc {
+			return compareCells(b, a)
+		}
+		return compareCells(a, b)
+	})
+}
+
+// compareCells compares numerically when both cells are numbers, otherwise lexically
+func compareCells(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+func (t *DataTable) clampColumnCursor() {
+	visible := len(t.VisibleColumns())
+	if t.colCursor >= visible {
+		t.colCursor = visible - 1
+	}
+	if t.colCursor < 0 {
+		t.colCursor = 0
+	}
+	if t.colOffset > t.colCursor {
+		t.colOffset = t.colCursor
+	}
+}
+
+// columnWidth computes the display width of a column from its header and cells
+func (t *DataTable) columnWidth(col int) int {
+	width := lipgloss.Width(t.columns[col]) + 2 // Room for sort indicator
+	for _, row := range t.rows {
+		if w := lipgloss.Width(row[col]); w > width {
+			width = w
+		}
+	}
+	if width < dataTableMinColWidth {
+		width = dataTableMinColWidth
+	}
+	if width > dataTableMaxColWidth {
+		width = dataTableMaxColWidth
+	}
+	return width
+}
+
+// fitCell truncates or pads a cell to the given width (left-aligned)
+func fitCell(s string, width int) string {
+	return alignCell(s, width, false)
+}
+
+// alignCell truncates a cell to the given width and pads it, right-aligning when requested
+func alignCell(s string, width int, right bool) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if lipgloss.Width(s) > width {
+		runes := []rune(s)
+		for len(runes) > 0 && lipgloss.Width(string(runes)) > width-1 {
+			runes = runes[:len(runes)-1]
+		}
+		s = string(runes) + "…"
+	}
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		if right {
+			return strings.Repeat(" ", pad) + s
+		}
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// View renders the table within the given dimensions
+func (t *DataTable) View(width, height int) string {
+	if len(t.columns) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Render("No tabular data")
+	}
+
+	visible := t.VisibleColumns()
+	widths := make([]int, len(visible))
+	for i, col := range visible {
+		widths[i] = t.columnWidth(col)
+	}
+
+	// Keep the selected column in view (horizontal scroll)
+	if t.colCursor < t.colOffset {
+		t.colOffset = t.colCursor
+	}
+	for t.colOffset < t.colCursor {
+		used := 0
+		for i := t.colOffset; i <= t.colCursor; i++ {
+			used += widths[i] + 1
+		}
+		if used <= width {
+			break
+		}
+		t.colOffset++
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Blue)
+	activeHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Base).Background(styles.Lavender)
+	cellStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface1).Foreground(styles.Text)
+	activeCellStyle := lipgloss.NewStyle().Background(styles.Surface1).Foreground(styles.Lavender).Bold(true)
+
+	var result strings.Builder
+
+	// Header row
+	used := 0
+	for i := t.colOffset; i < len(visible); i++ {
+		if used+widths[i] > width && i > t.colOffset {
+			break
+		}
+		name := t.columns[visible[i]]
+		if visible[i] == t.sortCol {
+			switch t.sortDir {
+			case SortAsc:
+				name += " ▲"
+			case SortDesc:
+				name += " ▼"
+			}
+		}
+		cell := fitCell(name, widths[i])
+		if i == t.colCursor {
+			result.WriteString(activeHeaderStyle.Render(cell))
+		} else {
+			result.WriteString(headerStyle.Render(cell))
+		}
+		result.WriteString(" ")
+		used += widths[i] + 1
+	}
+	result.WriteString("\n")
+	result.WriteString(strings.Repeat("─", width))
+	result.WriteString("\n")
+
+	// Body rows
+	visibleRows := height - 2
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	startIdx := 0
+	if t.cursor >= visibleRows {
+		startIdx = t.cursor - visibleRows + 1
+	}
+
+	for r := startIdx; r < len(t.order) && r < startIdx+visibleRows; r++ {
+		row := t.rows[t.order[r]]
+		used := 0
+		for i := t.colOffset; i < len(visible); i++ {
+			if used+widths[i] > width && i > t.colOffset {
+				break
+			}
+			cell := alignCell(row[visible[i]], widths[i], t.numeric[visible[i]])
+			switch {
+			case r == t.cursor && i == t.colCursor:
+				result.WriteString(activeCellStyle.Render(cell))
+			case r == t.cursor:
+				result.WriteString(selectedStyle.Render(cell))
+			default:
+				result.WriteString(cellStyle.Render(cell))
+			}
+			if r == t.cursor {
+				result.WriteString(selectedStyle.Render(" "))
+			} else {
+				result.WriteString(" ")
+			}
+			used += widths[i] + 1
+		}
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
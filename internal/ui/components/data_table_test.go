@@ -0,0 +1,93 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestDataTable() *DataTable {
+	table := NewDataTable()
+	table.SetData(
+		[]string{"id", "name", "score"},
+		[][]string{
+			{"1", "bob", "10"},
+			{"2", "alice", "9"},
+			{"3", "Carol", "100"},
+		},
+	)
+	return table
+}
+
+func TestDataTable_CycleSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    int
+		presses   int
+		wantFirst string
+		wantDir   SortDirection
+	}{
+		{name: "numeric ascending", column: 2, presses: 1, wantFirst: "alice", wantDir: SortAsc},
+		{name: "numeric descending", column: 2, presses: 2, wantFirst: "Carol", wantDir: SortDesc},
+		{name: "back to unsorted", column: 2, presses: 3, wantFirst: "bob", wantDir: SortNone},
+		{name: "case-insensitive text", column: 1, presses: 1, wantFirst: "alice", wantDir: SortAsc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newTestDataTable()
+			for i := 0; i < tt.column; i++ {
+				table.ColumnRight()
+			}
+			for i := 0; i < tt.presses; i++ {
+				table.CycleSort()
+			}
+
+			if got := table.SelectedRow()[1]; got != tt.wantFirst {
+				t.Errorf("first row name = %q, want %q", got, tt.wantFirst)
+			}
+			if _, dir := table.SortState(); dir != tt.wantDir {
+				t.Errorf("sort direction = %v, want %v", dir, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestDataTable_HideColumns(t *testing.T) {
+	table := newTestDataTable()
+
+	table.ColumnRight()
+	if !table.HideCurrentColumn() {
+		t.Fatal("HideCurrentColumn() = false, want true")
+	}
+	if got := table.HiddenColumns(); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("HiddenColumns() = %v, want [name]", got)
+	}
+	if got := table.CurrentColumn(); got != "score" {
+		t.Errorf("CurrentColumn() = %q, want score", got)
+	}
+
+	// The last visible column cannot be hidden
+	table.HideCurrentColumn()
+	if table.HideCurrentColumn() {
+		t.Error("HideCurrentColumn() hid the last visible column")
+	}
+	if got := len(table.VisibleColumns()); got != 1 {
+		t.Errorf("visible columns = %d, want 1", got)
+	}
+
+	table.ShowAllColumns()
+	if got := len(table.VisibleColumns()); got != 3 {
+		t.Errorf("visible columns after ShowAllColumns = %d, want 3", got)
+	}
+}
+
+func TestDataTable_SetDataKeepsHiddenColumns(t *testing.T) {
+	table := newTestDataTable()
+	table.SetHiddenColumns([]string{"id"})
+
+	table.SetData([]string{"id", "name"}, [][]string{{"1", "x"}})
+
+	if got := table.HiddenColumns(); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("HiddenColumns() = %v, want [id]", got)
+	}
+}
@@ -1,6 +1,8 @@
 package components
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,9 +22,6 @@ const (
 	DialogKeyValue // For key-value input (Request panel)
 )
 
-// HTTP methods for request creation
-var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-
 // Dialog represents a modal dialog component
 type Dialog struct {
 	visible     bool
@@ -38,11 +37,24 @@ type Dialog struct {
 	context     interface{} // Generic context for callbacks
 
 	// For new request dialog
-	methodIndex int    // Selected HTTP method index
-	urlValue    string // URL endpoint (also used as "value" for key-value dialogs)
-	focusField  int    // 0=name/key, 1=method, 2=url/value
+	methods     []string // HTTP methods to choose from
+	methodIndex int      // Selected HTTP method index
+	urlValue    string   // URL endpoint (also used as "value" for key-value dialogs)
+	focusField  int      // 0=name/key, 1=method, 2=url/value
+
+	// Completion of the key-value dialog fields
+	completer       KeyValueCompleter
+	suggestions     []string // Completions of the focused field
+	suggestionIndex int      // Selected completion
 }
 
+// KeyValueCompleter returns the completions of the focused field of a key-value dialog:
+// the value field when valueField is set, the key field otherwise
+type KeyValueCompleter func(key, value string, valueField bool) []string
+
+// maxSuggestions is the number of completions shown under a field
+const maxSuggestions = 5
+
 // DialogResultMsg is sent when a dialog is completed
 type DialogResultMsg struct {
 	Action    string
@@ -95,6 +107,41 @@ func (d *Dialog) ShowKeyValue(title, defaultKey, defaultValue, action string, ct
 	d.context = ctx
 	d.targetNode = nil
 	d.focusField = 0 // Start on key field
+	d.completer = nil
+	d.suggestions = nil
+}
+
+// SetCompleter completes the fields of the key-value dialog shown with c
+func (d *Dialog) SetCompleter(c KeyValueCompleter) {
+	d.completer = c
+	d.refreshSuggestions()
+}
+
+// refreshSuggestions computes the completions of the focused key-value field
+func (d *Dialog) refreshSuggestions() {
+	d.suggestions, d.suggestionIndex = nil, 0
+	if d.completer != nil && d.dialogType == DialogKeyValue {
+		d.suggestions = d.completer(d.inputValue, d.urlValue, d.focusField == 1)
+	}
+}
+
+// acceptSuggestion replaces the focused key-value field with the selected completion.
+// Returns false when there is none or the field already holds it.
+func (d *Dialog) acceptSuggestion() bool {
+	if d.dialogType != DialogKeyValue || len(d.suggestions) == 0 {
+		return false
+	}
+	suggestion := d.suggestions[d.suggestionIndex]
+	if suggestion == d.getCurrentValue() {
+		return false
+	}
+	if d.focusField == 1 {
+		d.urlValue = suggestion
+	} else {
+		d.inputValue = suggestion
+	}
+	d.cursorPos = len(suggestion)
+	return true
 }
 
 // ShowNewRequest shows a new request dialog with method selector and URL
@@ -105,6 +152,7 @@ func (d *Dialog) ShowNewRequest(action string, node *TreeNode) {
 	d.message = ""
 	d.inputValue = "New Request"
 	d.cursorPos = len(d.inputValue)
+	d.methods = styles.Methods()
 	d.methodIndex = 0 // GET by default
 	d.urlValue = "{{base_url}}/endpoint"
 	d.action = action
@@ -137,14 +185,14 @@ func (d *Dialog) ShowEditRequest(node *TreeNode) {
 	d.message = ""
 	d.inputValue = node.Name
 	d.cursorPos = len(d.inputValue)
-	// Find method index
-	d.methodIndex = 0
-	for i, m := range httpMethods {
-		if m == node.HTTPMethod {
-			d.methodIndex = i
-			break
-		}
+	// Find method index; a method without a badge is kept as a choice
+	d.methods = styles.Methods()
+	d.methodIndex = slices.Index(d.methods, node.HTTPMethod)
+	if d.methodIndex < 0 && node.HTTPMethod != "" {
+		d.methods = append(d.methods, node.HTTPMethod)
+		d.methodIndex = len(d.methods) - 1
 	}
+	d.methodIndex = max(d.methodIndex, 0)
 	d.urlValue = node.URL
 	d.action = "edit_request"
 	d.targetNode = node
@@ -169,6 +217,23 @@ func (d *Dialog) Update(msg tea.Msg) (*Dialog, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if d.dialogType == DialogKeyValue {
+			// Choose a completion without recomputing them
+			switch msg.String() {
+			case "ctrl+n":
+				if len(d.suggestions) > 0 {
+					d.suggestionIndex = (d.suggestionIndex + 1) % len(d.suggestions)
+				}
+				return d, nil
+			case "ctrl+p":
+				if len(d.suggestions) > 0 {
+					d.suggestionIndex = (d.suggestionIndex + len(d.suggestions) - 1) % len(d.suggestions)
+				}
+				return d, nil
+			}
+			defer d.refreshSuggestions()
+		}
+
 		switch msg.String() {
 		case "esc":
 			// Cancel dialog
@@ -189,7 +254,7 @@ func (d *Dialog) Update(msg tea.Msg) (*Dialog, tea.Cmd) {
 			method := ""
 			url := ""
 			if d.dialogType == DialogNewRequest || d.dialogType == DialogEditRequest {
-				method = httpMethods[d.methodIndex]
+				method = d.methods[d.methodIndex]
 				url = d.urlValue
 			} else if d.dialogType == DialogKeyValue {
 				// For key-value dialogs, URL field holds the value
@@ -208,6 +273,10 @@ func (d *Dialog) Update(msg tea.Msg) (*Dialog, tea.Cmd) {
 			}
 
 		case "tab", "down":
+			// Tab completes the focused key-value field first
+			if msg.String() == "tab" && d.acceptSuggestion() {
+				break
+			}
 			// Move to next field in request dialogs
 			if d.dialogType == DialogNewRequest || d.dialogType == DialogEditRequest {
 				d.focusField = (d.focusField + 1) % 3
@@ -263,7 +332,7 @@ func (d *Dialog) Update(msg tea.Msg) (*Dialog, tea.Cmd) {
 		case "h":
 			if (d.dialogType == DialogNewRequest || d.dialogType == DialogEditRequest) && d.focusField == 1 {
 				// Change method with h/l on method selector
-				d.methodIndex = (d.methodIndex + len(httpMethods) - 1) % len(httpMethods)
+				d.methodIndex = (d.methodIndex + len(d.methods) - 1) % len(d.methods)
 			} else {
 				// Type 'h' in text field
 				d.insertChar("h")
@@ -279,7 +348,7 @@ func (d *Dialog) Update(msg tea.Msg) (*Dialog, tea.Cmd) {
 		case "l":
 			if (d.dialogType == DialogNewRequest || d.dialogType == DialogEditRequest) && d.focusField == 1 {
 				// Change method with h/l on method selector
-				d.methodIndex = (d.methodIndex + 1) % len(httpMethods)
+				d.methodIndex = (d.methodIndex + 1) % len(d.methods)
 			} else {
 				// Type 'l' in text field
 				d.insertChar("l")
@@ -578,8 +647,8 @@ func (d *Dialog) renderConfirmForm(width int) string {
 // renderMethodSelector renders the HTTP method selector
 func (d *Dialog) renderMethodSelector(width int, active bool) string {
 	// Only show the selected method with arrows for navigation
-	method := httpMethods[d.methodIndex]
-	bg, fg := d.getMethodColors(method)
+	method := d.methods[d.methodIndex]
+	bg, fg := styles.MethodColors(method)
 
 	methodStyle := lipgloss.NewStyle().
 		Background(bg).
@@ -597,28 +666,6 @@ func (d *Dialog) renderMethodSelector(width int, active bool) string {
 	return content
 }
 
-// getMethodColors returns the background and foreground colors for an HTTP method
-func (d *Dialog) getMethodColors(method string) (lipgloss.Color, lipgloss.Color) {
-	switch method {
-	case "GET":
-		return styles.MethodGetBg, styles.MethodGetFg
-	case "POST":
-		return styles.MethodPostBg, styles.MethodPostFg
-	case "PUT":
-		return styles.MethodPutBg, styles.MethodPutFg
-	case "PATCH":
-		return styles.MethodPatchBg, styles.MethodPatchFg
-	case "DELETE":
-		return styles.MethodDeleteBg, styles.MethodDeleteFg
-	case "HEAD":
-		return styles.MethodHeadBg, styles.MethodHeadFg
-	case "OPTIONS":
-		return styles.MethodOptionsBg, styles.MethodOptionsFg
-	default:
-		return styles.Surface1, styles.Text
-	}
-}
-
 // renderKeyValueForm renders a key-value input form
 func (d *Dialog) renderKeyValueForm(width int) string {
 	var content strings.Builder
@@ -645,6 +692,7 @@ func (d *Dialog) renderKeyValueForm(width int) string {
 	if d.focusField == 0 {
 		keyInput = d.renderWithCursor(d.inputValue, d.cursorPos)
 		content.WriteString(activeInputStyle.Render(keyInput))
+		content.WriteString(d.renderSuggestions(width))
 	} else {
 		content.WriteString(inputStyle.Render(keyInput))
 	}
@@ -656,6 +704,7 @@ func (d *Dialog) renderKeyValueForm(width int) string {
 	if d.focusField == 1 {
 		valueInput = d.renderWithCursor(d.urlValue, d.cursorPos)
 		content.WriteString(activeInputStyle.Render(valueInput))
+		content.WriteString(d.renderSuggestions(width))
 	} else {
 		content.WriteString(inputStyle.Render(valueInput))
 	}
@@ -668,11 +717,47 @@ func (d *Dialog) renderKeyValueForm(width int) string {
 		Italic(true).
 		Width(width).
 		Align(lipgloss.Center)
-	content.WriteString(helpStyle.Render("Tab: switch field"))
+	if d.completer != nil {
+		content.WriteString(helpStyle.Render("Tab: complete/switch field · Ctrl+N/P: choose"))
+	} else {
+		content.WriteString(helpStyle.Render("Tab: switch field"))
+	}
 
 	return content.String()
 }
 
+// renderSuggestions renders the completions of the focused key-value field, a window
+// of them around the selected one
+func (d *Dialog) renderSuggestions(width int) string {
+	if len(d.suggestions) == 0 {
+		return ""
+	}
+	start := max(0, min(d.suggestionIndex-maxSuggestions+1, len(d.suggestions)-maxSuggestions))
+	end := min(len(d.suggestions), start+maxSuggestions)
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Width(width).
+		Padding(0, 1)
+	selectedStyle := itemStyle.
+		Foreground(styles.Lavender).
+		Background(styles.Surface1).
+		Bold(true)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		if i == d.suggestionIndex {
+			lines = append(lines, selectedStyle.Render(d.suggestions[i]))
+		} else {
+			lines = append(lines, itemStyle.Render(d.suggestions[i]))
+		}
+	}
+	if len(d.suggestions) > maxSuggestions {
+		lines = append(lines, itemStyle.Italic(true).Render(fmt.Sprintf("%d/%d", d.suggestionIndex+1, len(d.suggestions))))
+	}
+	return "\n" + strings.Join(lines, "\n")
+}
+
 // renderWithCursor renders text with a cursor at the specified position
 func (d *Dialog) renderWithCursor(text string, cursorPos int) string {
 	if cursorPos >= len(text) {
@@ -0,0 +1,69 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDialog_KeyValueCompletion(t *testing.T) {
+	d := NewDialog()
+	d.ShowKeyValue("New Entry", "", "", "request_new", nil)
+	d.SetCompleter(func(key, value string, valueField bool) []string {
+		if valueField {
+			if key == "Accept" {
+				return []string{"*/*", "application/json"}
+			}
+			return nil
+		}
+		if key == "" {
+			return nil
+		}
+		return []string{"Accept", "Accept-Encoding"}
+	})
+	send := func(msg tea.KeyMsg) {
+		d, _ = d.Update(msg)
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	typeText("acc")
+	if len(d.suggestions) != 2 {
+		t.Fatalf("suggestions = %v", d.suggestions)
+	}
+	if view := d.View(80, 30); !strings.Contains(view, "Accept-Encoding") {
+		t.Errorf("dialog should list the completions:\n%s", view)
+	}
+
+	// Tab completes the selected key, then switches to the value once it is complete
+	send(tea.KeyMsg{Type: tea.KeyCtrlN})
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if d.inputValue != "Accept-Encoding" || d.focusField != 0 {
+		t.Fatalf("key = %q, focus %d; want Accept-Encoding completed", d.inputValue, d.focusField)
+	}
+	d.inputValue = "Accept"
+	d.refreshSuggestions()
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if d.focusField != 1 {
+		t.Fatal("tab on a complete key should switch to the value")
+	}
+	if len(d.suggestions) != 2 || d.suggestions[0] != "*/*" {
+		t.Errorf("value suggestions = %v", d.suggestions)
+	}
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if d.urlValue != "application/json" {
+		t.Errorf("value = %q, want application/json", d.urlValue)
+	}
+
+	// Dialogs shown without a completer do not complete
+	d.ShowKeyValue("New Entry", "acc", "", "request_new", nil)
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if d.inputValue != "acc" || d.focusField != 1 {
+		t.Errorf("key = %q, focus %d; want tab to switch fields", d.inputValue, d.focusField)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/clipboard"
+	"github.com/kbrdn1/LazyCurl/internal/format"
 	"github.com/kbrdn1/LazyCurl/pkg/styles"
 )
 
@@ -33,7 +35,7 @@ const (
 	EditorInsertMode
 )
 
-// EditorFormatMsg is sent when JSON is formatted
+// EditorFormatMsg is sent when JSON or XML is formatted
 type EditorFormatMsg struct {
 	Success bool
 	Error   string
@@ -74,7 +76,7 @@ type Editor struct {
 	height     int        // Available height
 	width      int        // Available width
 	readOnly   bool       // Whether the editor is read-only
-	syntaxType string     // "json", "javascript", "text"
+	syntaxType string     // "json", "xml", "javascript", "text"
 	mode       EditorMode // Current vim mode (NORMAL/INSERT)
 
 	// Search state
@@ -94,6 +96,12 @@ type Editor struct {
 	// External editor state
 	externalEditorEnabled bool              // Whether external editor is enabled for this editor
 	externalEditorField   api.EditableField // Which field this editor represents (body/headers)
+
+	// Rows marked as changed in the gutter, cleared with the content
+	changedLines map[int]bool
+
+	// Ctrl+R was pressed in INSERT mode: the next digit inserts that clipboard register
+	pendingRegister bool
 }
 
 // NewEditor creates a new editor component
@@ -123,6 +131,15 @@ func (e *Editor) SetContent(content string) {
 	e.cursorRow = 0
 	e.cursorCol = 0
 	e.scrollY = 0
+	e.changedLines = nil
+}
+
+// SetChangedLines marks rows as changed in the gutter until the content is replaced
+func (e *Editor) SetChangedLines(rows []int) {
+	e.changedLines = make(map[int]bool, len(rows))
+	for _, row := range rows {
+		e.changedLines[row] = true
+	}
 }
 
 // GetContent returns the editor content as a single string
@@ -176,6 +193,36 @@ func (e *Editor) FormatJSON() tea.Cmd {
 	}
 }
 
+// FormatXML formats the content as XML with proper indentation
+func (e *Editor) FormatXML() tea.Cmd {
+	content := e.GetContent()
+	if content == "" {
+		return nil
+	}
+
+	formatted, err := format.FormatXML([]byte(content), "  ")
+	if err != nil {
+		return func() tea.Msg {
+			return EditorFormatMsg{Success: false, Error: err.Error()}
+		}
+	}
+	e.SetContent(formatted)
+
+	return func() tea.Msg {
+		return EditorFormatMsg{Success: true}
+	}
+}
+
+// SetSyntaxType changes the syntax used for highlighting and formatting
+func (e *Editor) SetSyntaxType(syntaxType string) {
+	e.syntaxType = syntaxType
+}
+
+// GetSyntaxType returns the syntax used for highlighting and formatting
+func (e *Editor) GetSyntaxType() string {
+	return e.syntaxType
+}
+
 // Update handles editor messages
 func (e *Editor) Update(msg tea.Msg, allowInput bool) (*Editor, tea.Cmd) {
 	// Handle search messages first (they come from the search input component)
@@ -413,10 +460,13 @@ func (e *Editor) handleNormalMode(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 	case "Q":
 		return e, func() tea.Msg { return EditorQuitMsg{} }
 
-	// Format JSON (key feature!)
+	// Format JSON or XML (key feature!)
 	case "F":
-		if e.syntaxType == "json" {
+		switch e.syntaxType {
+		case "json":
 			return e, e.FormatJSON()
+		case "xml":
+			return e, e.FormatXML()
 		}
 
 	// Toggle preview mode (show resolved variables)
@@ -472,6 +522,21 @@ func (e *Editor) handleInsertMode(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 		}
 	}
 
+	// Ctrl+R {0-9} inserts a clipboard register, like in vim
+	if e.pendingRegister {
+		e.pendingRegister = false
+		if key := msg.String(); len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+			if reg, ok := clipboard.Get(int(key[0] - '0')); ok {
+				e.insertText(reg.Content)
+			}
+			return e, nil
+		}
+	}
+	if msg.String() == "ctrl+r" {
+		e.pendingRegister = true
+		return e, nil
+	}
+
 	switch msg.Type {
 	case tea.KeyEsc:
 		// Exit INSERT mode, go to NORMAL mode
@@ -585,6 +650,26 @@ func (e *Editor) handleInsertMode(msg tea.KeyMsg) (*Editor, tea.Cmd) {
 	return e, nil
 }
 
+// insertText inserts text, which may span lines, at the cursor and moves the cursor
+// after it
+func (e *Editor) insertText(text string) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	line := e.content[e.cursorRow]
+	before, after := line[:e.cursorCol], line[e.cursorCol:]
+
+	lines[0] = before + lines[0]
+	last := len(lines) - 1
+	e.cursorCol = len(lines[last])
+	lines[last] += after
+
+	content := make([]string, 0, len(e.content)+last)
+	content = append(content, e.content[:e.cursorRow]...)
+	content = append(content, lines...)
+	e.content = append(content, e.content[e.cursorRow+1:]...)
+	e.cursorRow += last
+	e.scrollIntoView()
+}
+
 // moveToNextWord moves cursor to the start of the next word
 func (e *Editor) moveToNextWord() {
 	line := e.content[e.cursorRow]
@@ -748,6 +833,9 @@ func (e *Editor) View(width, height int, active bool) string {
 	cursorLineStyle := lipgloss.NewStyle().
 		Background(styles.Surface0)
 
+	changedLineStyle := lipgloss.NewStyle().
+		Foreground(styles.Yellow)
+
 	// Cursor styles
 	normalCursorStyle := lipgloss.NewStyle().
 		Background(styles.Text).
@@ -811,7 +899,11 @@ func (e *Editor) View(width, height int, active bool) string {
 			rightInd = "▶"
 		}
 
-		line := leftInd + lineNum + " │ " + content + rightInd
+		separator := " │ "
+		if e.changedLines[i] {
+			separator = changedLineStyle.Render(" ┃ ")
+		}
+		line := leftInd + lineNum + separator + content + rightInd
 
 		if active && i == e.cursorRow {
 			line = cursorLineStyle.Width(width).Render(line)
@@ -838,8 +930,8 @@ func (e *Editor) View(width, height int, active bool) string {
 func (e *Editor) renderLineWithMatches(displayContent string, row int, displayStart int, textStyle lipgloss.Style) string {
 	if e.searchQuery == "" || len(e.searchMatches) == 0 {
 		// No search, use normal rendering
-		if e.syntaxType == "json" {
-			return e.highlightJSON(displayContent)
+		if highlight := e.highlighter(); highlight != nil {
+			return highlight(displayContent)
 		} else if e.syntaxType == "javascript" {
 			return e.highlightJS(displayContent)
 		}
@@ -874,8 +966,8 @@ func (e *Editor) renderLineWithMatches(displayContent string, row int, displaySt
 
 	if len(lineMatches) == 0 {
 		// No visible matches, use normal rendering
-		if e.syntaxType == "json" {
-			return e.highlightJSON(displayContent)
+		if highlight := e.highlighter(); highlight != nil {
+			return highlight(displayContent)
 		} else if e.syntaxType == "javascript" {
 			return e.highlightJS(displayContent)
 		}
@@ -899,8 +991,8 @@ func (e *Editor) renderLineWithMatches(displayContent string, row int, displaySt
 		// Render text before match
 		if pos < match.ColStart {
 			beforeText := displayContent[pos:match.ColStart]
-			if e.syntaxType == "json" {
-				result.WriteString(e.highlightJSON(beforeText))
+			if highlight := e.highlighter(); highlight != nil {
+				result.WriteString(highlight(beforeText))
 			} else {
 				result.WriteString(textStyle.Render(beforeText))
 			}
@@ -920,8 +1012,8 @@ func (e *Editor) renderLineWithMatches(displayContent string, row int, displaySt
 	// Render remaining text
 	if pos < len(displayContent) {
 		afterText := displayContent[pos:]
-		if e.syntaxType == "json" {
-			result.WriteString(e.highlightJSON(afterText))
+		if highlight := e.highlighter(); highlight != nil {
+			result.WriteString(highlight(afterText))
 		} else {
 			result.WriteString(textStyle.Render(afterText))
 		}
@@ -1017,8 +1109,8 @@ func (e *Editor) renderLineWithCursorAndMatches(displayContent string, row int,
 				// Start of new match
 				if pos <= i {
 					text := displayContent[pos : i+1]
-					if e.syntaxType == "json" {
-						result.WriteString(e.highlightJSON(text))
+					if highlight := e.highlighter(); highlight != nil {
+						result.WriteString(highlight(text))
 					} else {
 						result.WriteString(textStyle.Render(text))
 					}
@@ -1039,8 +1131,8 @@ func (e *Editor) renderLineWithCursorAndMatches(displayContent string, row int,
 				result.WriteString(matchStyle.Render(text))
 			}
 		} else {
-			if e.syntaxType == "json" {
-				result.WriteString(e.highlightJSON(text))
+			if highlight := e.highlighter(); highlight != nil {
+				result.WriteString(highlight(text))
 			} else {
 				result.WriteString(textStyle.Render(text))
 			}
@@ -1068,19 +1160,19 @@ func (e *Editor) renderLineWithCursorAtPos(line string, cursorPos int, normalSty
 		cursorPos = 0
 	}
 
-	if e.syntaxType == "json" {
+	if highlight := e.highlighter(); highlight != nil {
 		if cursorPos < len(line) {
 			before := line[:cursorPos]
 			cursorChar := string(line[cursorPos])
 			after := line[cursorPos+1:]
 
-			result.WriteString(e.highlightJSON(before))
+			result.WriteString(highlight(before))
 			result.WriteString(cursorStyle.Render(cursorChar))
 			if len(after) > 0 {
-				result.WriteString(e.highlightJSON(after))
+				result.WriteString(highlight(after))
 			}
 		} else {
-			result.WriteString(e.highlightJSON(line))
+			result.WriteString(highlight(line))
 			result.WriteString(cursorStyle.Render(" "))
 		}
 	} else {
@@ -1166,6 +1258,47 @@ func (e *Editor) renderModeIndicator(width int, active bool) string {
 	return barStyle.Render(content)
 }
 
+// highlighter returns the function highlighting the editor's syntax, nil for plain
+// text. HTML is highlighted as XML markup.
+func (e *Editor) highlighter() func(string) string {
+	switch e.syntaxType {
+	case "json":
+		return e.highlightJSON
+	case "xml", "html":
+		return e.highlightXML
+	case "yaml":
+		return e.highlightYAML
+	case "markdown":
+		return e.highlightMarkdown
+	}
+	return nil
+}
+
+// Markdown lines highlighted by highlightMarkdown
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdQuote   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdFence   = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// highlightMarkdown highlights the headings, quotes, code fences and list markers of a
+// Markdown line
+func (e *Editor) highlightMarkdown(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case mdHeading.MatchString(trimmed):
+		return lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true).Render(line)
+	case mdFence.MatchString(line), mdQuote.MatchString(line):
+		return lipgloss.NewStyle().Foreground(styles.Subtext0).Render(line)
+	}
+	if m := mdBullet.FindStringSubmatchIndex(line); m != nil {
+		marker := lipgloss.NewStyle().Foreground(styles.Peach)
+		return line[:m[3]] + marker.Render(line[m[3]:m[4]]) + line[m[4]:]
+	}
+	return line
+}
+
 // highlightJSON applies basic JSON syntax highlighting with variable support
 func (e *Editor) highlightJSON(line string) string {
 	// First, find all variable positions in the line
@@ -1177,8 +1310,6 @@ func (e *Editor) highlightJSON(line string) string {
 	numberStyle := lipgloss.NewStyle().Foreground(styles.Peach)
 	boolStyle := lipgloss.NewStyle().Foreground(styles.Mauve)
 	punctStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
-	variableStyle := lipgloss.NewStyle().Foreground(styles.URLVariable).Bold(true)
-	previewStyle := lipgloss.NewStyle().Foreground(styles.Green).Background(styles.Surface0)
 
 	// Helper to check if position is inside a variable
 	isInVariable := func(pos int) (bool, int, int) {
@@ -1200,38 +1331,7 @@ func (e *Editor) highlightJSON(line string) string {
 		inVar, varStart, varEnd := isInVariable(i)
 		if inVar && i == varStart {
 			// Render the entire variable
-			varText := line[varStart:varEnd]
-
-			// In preview mode, show resolved value
-			if e.previewMode {
-				varName := extractVariableName(varText)
-				var resolved bool
-				var value string
-
-				// Check system variables first
-				if strings.HasPrefix(varName, "$") {
-					if sysValue := api.GetSystemVariable(varName); sysValue != "" {
-						value = sysValue
-						resolved = true
-					}
-				}
-
-				// Check environment variables if not resolved
-				if !resolved && e.variableValues != nil {
-					if envValue, exists := e.variableValues[varName]; exists {
-						value = envValue
-						resolved = true
-					}
-				}
-
-				if resolved {
-					result.WriteString(previewStyle.Render(value))
-				} else {
-					result.WriteString(variableStyle.Render(varText))
-				}
-			} else {
-				result.WriteString(variableStyle.Render(varText))
-			}
+			result.WriteString(e.renderVariable(line[varStart:varEnd]))
 			i = varEnd
 			continue
 		}
@@ -1297,6 +1397,240 @@ func (e *Editor) highlightJSON(line string) string {
 	return result.String()
 }
 
+// renderVariable renders a {{variable}}, or its resolved value in preview mode
+func (e *Editor) renderVariable(varText string) string {
+	variableStyle := lipgloss.NewStyle().Foreground(styles.URLVariable).Bold(true)
+	previewStyle := lipgloss.NewStyle().Foreground(styles.Green).Background(styles.Surface0)
+	if !e.previewMode {
+		return variableStyle.Render(varText)
+	}
+
+	varName := extractVariableName(varText)
+
+	// Check system variables first
+	if strings.HasPrefix(varName, "$") {
+		if sysValue := api.GetSystemVariable(varName); sysValue != "" {
+			return previewStyle.Render(sysValue)
+		}
+	}
+
+	// Check environment variables if not resolved
+	if e.variableValues != nil {
+		if envValue, exists := e.variableValues[varName]; exists {
+			return previewStyle.Render(envValue)
+		}
+	}
+
+	// Template helpers such as {{now}}
+	if value, resolved := api.EvalTemplateFunction(varName); resolved {
+		return previewStyle.Render(value)
+	}
+	return variableStyle.Render(varText)
+}
+
+// highlightXML applies basic XML syntax highlighting with variable support.
+// Lines are highlighted on their own: a tag spanning several lines is only
+// recognized on its first line.
+func (e *Editor) highlightXML(line string) string {
+	tagStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	attrStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Green)
+	punctStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	commentStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Text)
+
+	isNameChar := func(c byte) bool {
+		return c == ':' || c == '_' || c == '-' || c == '.' ||
+			c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+	}
+
+	var result strings.Builder
+	inTag := false
+	i := 0
+	for i < len(line) {
+		rest := line[i:]
+
+		if loc := editorVariablePattern.FindStringIndex(rest); loc != nil && loc[0] == 0 {
+			result.WriteString(e.renderVariable(rest[:loc[1]]))
+			i += loc[1]
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += 3
+			}
+			result.WriteString(commentStyle.Render(rest[:end]))
+			i += end
+		case !inTag && rest[0] == '<':
+			// "<", "</", "<?" or "<!" followed by the tag name
+			j := 1
+			for j < len(rest) && (rest[j] == '/' || rest[j] == '?' || rest[j] == '!') {
+				j++
+			}
+			k := j
+			for k < len(rest) && isNameChar(rest[k]) {
+				k++
+			}
+			result.WriteString(punctStyle.Render(rest[:j]))
+			result.WriteString(tagStyle.Render(rest[j:k]))
+			inTag = true
+			i += k
+		case inTag && (strings.HasPrefix(rest, "/>") || strings.HasPrefix(rest, "?>")):
+			result.WriteString(punctStyle.Render(rest[:2]))
+			inTag = false
+			i += 2
+		case inTag && rest[0] == '>':
+			result.WriteString(punctStyle.Render(">"))
+			inTag = false
+			i++
+		case inTag && (rest[0] == '"' || rest[0] == '\''):
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += 2
+			}
+			result.WriteString(valueStyle.Render(rest[:end]))
+			i += end
+		case inTag && isNameChar(rest[0]):
+			j := 0
+			for j < len(rest) && isNameChar(rest[j]) {
+				j++
+			}
+			result.WriteString(attrStyle.Render(rest[:j]))
+			i += j
+		case inTag:
+			result.WriteString(punctStyle.Render(rest[:1]))
+			i++
+		default:
+			// Text content up to the next tag or variable
+			end := strings.IndexAny(rest[1:], "<{")
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end++
+			}
+			result.WriteString(textStyle.Render(rest[:end]))
+			i += end
+		}
+	}
+
+	return result.String()
+}
+
+// yamlNumberPattern matches YAML integers and floats
+var yamlNumberPattern = regexp.MustCompile(`^[-+]?(\d[\d_]*(\.\d*)?([eE][-+]?\d+)?|\.\d+|0x[0-9a-fA-F]+|\.inf|\.nan)$`)
+
+// highlightYAML applies basic YAML syntax highlighting: keys, scalars, comments,
+// list markers and document markers
+func (e *Editor) highlightYAML(line string) string {
+	keyStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	punctStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	commentStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var result strings.Builder
+	rest := line
+	indent := func() {
+		trimmed := strings.TrimLeft(rest, " \t")
+		result.WriteString(rest[:len(rest)-len(trimmed)])
+		rest = trimmed
+	}
+
+	indent()
+	if rest == "---" || rest == "..." || strings.HasPrefix(rest, "--- ") {
+		return result.String() + punctStyle.Render(rest)
+	}
+	for rest == "-" || strings.HasPrefix(rest, "- ") {
+		result.WriteString(punctStyle.Render("-"))
+		rest = rest[1:]
+		indent()
+	}
+	if strings.HasPrefix(rest, "#") {
+		return result.String() + commentStyle.Render(rest)
+	}
+	if end := yamlKeyEnd(rest); end > 0 {
+		result.WriteString(keyStyle.Render(rest[:end]))
+		result.WriteString(punctStyle.Render(":"))
+		rest = rest[end+1:]
+		indent()
+	}
+
+	// A comment starts with " #" outside quotes
+	value, comment := rest, ""
+	var quote byte
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+		} else if (c == '"' || c == '\'') && i == 0 {
+			quote = c
+		} else if c == '#' && i > 0 && (rest[i-1] == ' ' || rest[i-1] == '\t') {
+			value, comment = rest[:i], rest[i:]
+			break
+		}
+	}
+	result.WriteString(yamlScalarStyle(strings.TrimSpace(value)).Render(value))
+	if comment != "" {
+		result.WriteString(commentStyle.Render(comment))
+	}
+	return result.String()
+}
+
+// yamlKeyEnd returns the index of the colon ending the mapping key starting s, 0
+// when s holds no key
+func yamlKeyEnd(s string) int {
+	if s == "" || s[0] == '{' || s[0] == '[' {
+		return 0
+	}
+	from := 0
+	if s[0] == '"' || s[0] == '\'' {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return 0
+		}
+		from = end + 2
+	}
+	end := strings.Index(s[from:], ": ")
+	if end < 0 {
+		if !strings.HasSuffix(s, ":") {
+			return 0
+		}
+		end = len(s) - 1 - from
+	}
+	end += from
+	if strings.Contains(s[:end], " #") {
+		return 0
+	}
+	return end
+}
+
+// yamlScalarStyle returns the style of a YAML value: quoted and plain strings,
+// numbers, booleans and null, anchors, aliases and tags
+func yamlScalarStyle(value string) lipgloss.Style {
+	switch {
+	case value == "":
+		return lipgloss.NewStyle()
+	case value[0] == '|' || value[0] == '>':
+		return lipgloss.NewStyle().Foreground(styles.Subtext1)
+	case value[0] == '&' || value[0] == '*' || value[0] == '!':
+		return lipgloss.NewStyle().Foreground(styles.Mauve)
+	case yamlNumberPattern.MatchString(value):
+		return lipgloss.NewStyle().Foreground(styles.Peach)
+	}
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return lipgloss.NewStyle().Foreground(styles.Mauve)
+	}
+	return lipgloss.NewStyle().Foreground(styles.Green)
+}
+
 // highlightJS applies basic JavaScript syntax highlighting
 func (e *Editor) highlightJS(line string) string {
 	// Simple keyword highlighting
@@ -1624,6 +1958,11 @@ func (e *Editor) replaceVariables(text string) string {
 			}
 		}
 
+		// Check template helpers (e.g., {{now}})
+		if value, ok := api.EvalTemplateFunction(varName); ok {
+			return value
+		}
+
 		return match // Keep original if not found
 	})
 }
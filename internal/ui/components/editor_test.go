@@ -1,9 +1,12 @@
 package components
 
 import (
+	"io"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/clipboard"
 )
 
 // TestEditor_EmptyContent verifies editor handles empty content without panics
@@ -730,3 +733,84 @@ func containsDateFormat(s string) bool {
 	}
 	return dashCount >= 2
 }
+
+// TestEditor_FormatXML verifies F pretty-prints XML content and reports invalid XML
+func TestEditor_FormatXML(t *testing.T) {
+	editor := NewEditor(`<a><b id="1">x</b></a>`, "xml")
+
+	_, cmd := editor.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}}, true)
+	if cmd == nil {
+		t.Fatal("F did not return a command, expected EditorFormatMsg")
+	}
+	if msg, ok := cmd().(EditorFormatMsg); !ok || !msg.Success {
+		t.Errorf("expected successful EditorFormatMsg, got %#v", cmd())
+	}
+	if want := "<a>\n  <b id=\"1\">x</b>\n</a>"; editor.GetContent() != want {
+		t.Errorf("content = %q, want %q", editor.GetContent(), want)
+	}
+
+	editor.SetContent("<a><b></a")
+	_, cmd = editor.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}}, true)
+	if msg, ok := cmd().(EditorFormatMsg); !ok || msg.Success || msg.Error == "" {
+		t.Errorf("expected failed EditorFormatMsg, got %#v", msg)
+	}
+	if editor.GetContent() != "<a><b></a" {
+		t.Error("invalid XML should be left unchanged")
+	}
+}
+
+// TestEditor_HighlightXML verifies XML highlighting keeps the text of the line
+func TestEditor_HighlightXML(t *testing.T) {
+	editor := NewEditor("", "xml")
+	line := `<m:Price currency="EUR">{{amount}}</m:Price> <!-- note -->`
+	if got := editor.highlightXML(line); got != line {
+		t.Errorf("highlightXML() text = %q, want %q", got, line)
+	}
+}
+
+// TestEditor_HighlightYAML verifies YAML highlighting keeps the text of the line
+func TestEditor_HighlightYAML(t *testing.T) {
+	editor := NewEditor("", "yaml")
+	for _, line := range []string{
+		`  - name: "Ada: Lovelace" # first`,
+		`count: 42`,
+		`--- `,
+		`  - &anchor plain value`,
+		`url: http://example.com/#top`,
+	} {
+		if got := editor.highlightYAML(line); got != line {
+			t.Errorf("highlightYAML() text = %q, want %q", got, line)
+		}
+	}
+}
+
+func TestEditor_InsertRegister(t *testing.T) {
+	clipboard.Init(clipboard.ModeOSC52, io.Discard)
+	clipboard.Copy("Header", "X-Id: 1")
+	clipboard.Copy("Body", "{\n  \"a\": 1\n}")
+
+	e := NewEditor("ab", "text")
+	e.cursorCol = 1
+	e.mode = EditorInsertMode
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlR},
+		{Type: tea.KeyRunes, Runes: []rune{'0'}},
+	} {
+		e, _ = e.Update(key, true)
+	}
+	if got := e.GetContent(); got != "a{\n  \"a\": 1\n}b" {
+		t.Errorf("content after Ctrl+R 0 = %q", got)
+	}
+	if e.cursorRow != 2 || e.cursorCol != 1 {
+		t.Errorf("cursor = %d:%d, want 2:1", e.cursorRow, e.cursorCol)
+	}
+
+	// An empty register inserts nothing; another key after Ctrl+R is typed as usual
+	e, _ = e.Update(tea.KeyMsg{Type: tea.KeyCtrlR}, true)
+	e, _ = e.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}}, true)
+	e, _ = e.Update(tea.KeyMsg{Type: tea.KeyCtrlR}, true)
+	e, _ = e.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}, true)
+	if got := e.GetContent(); got != "a{\n  \"a\": 1\n}xb" {
+		t.Errorf("content = %q", got)
+	}
+}
@@ -0,0 +1,274 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// filePickerRows is the number of entries shown at once
+const filePickerRows = 12
+
+// FilePickerResultMsg is sent when a file is chosen in the file picker
+type FilePickerResultMsg struct {
+	Action  string
+	Path    string      // Absolute path of the chosen file
+	Context interface{} // Generic context for callbacks
+}
+
+// fileEntry is a file or directory listed by the picker
+type fileEntry struct {
+	name string
+	dir  bool
+	size int64
+}
+
+// FilePicker is a modal browsing the filesystem to choose a file
+type FilePicker struct {
+	visible    bool
+	title      string
+	dir        string
+	entries    []fileEntry
+	cursor     int
+	offset     int
+	showHidden bool
+	err        error
+	action     string
+	context    interface{}
+}
+
+// NewFilePicker creates a new file picker
+func NewFilePicker() *FilePicker {
+	return &FilePicker{}
+}
+
+// Show opens the picker in dir (the working directory when empty). The chosen file is
+// reported by a FilePickerResultMsg carrying action and ctx.
+func (p *FilePicker) Show(title, dir, action string, ctx interface{}) {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	p.visible = true
+	p.title = title
+	p.action = action
+	p.context = ctx
+	p.open(dir)
+}
+
+// Hide hides the picker
+func (p *FilePicker) Hide() {
+	p.visible = false
+}
+
+// IsVisible returns whether the picker is visible
+func (p *FilePicker) IsVisible() bool {
+	return p.visible
+}
+
+// Dir returns the directory being browsed
+func (p *FilePicker) Dir() string {
+	return p.dir
+}
+
+// open lists dir, keeping the previous listing when it cannot be read
+func (p *FilePicker) open(dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	entries := make([]fileEntry, 0, len(items)+1)
+	if parent := filepath.Dir(dir); parent != dir {
+		entries = append(entries, fileEntry{name: "..", dir: true})
+	}
+	var listed []fileEntry
+	for _, item := range items {
+		if !p.showHidden && strings.HasPrefix(item.Name(), ".") {
+			continue
+		}
+		entry := fileEntry{name: item.Name(), dir: item.IsDir()}
+		if info, err := item.Info(); err == nil {
+			entry.size = info.Size()
+			// Follow symlinks to directories
+			if info.Mode()&os.ModeSymlink != 0 {
+				if target, err := os.Stat(filepath.Join(dir, item.Name())); err == nil {
+					entry.dir = target.IsDir()
+					entry.size = target.Size()
+				}
+			}
+		}
+		listed = append(listed, entry)
+	}
+	sort.SliceStable(listed, func(i, j int) bool {
+		if listed[i].dir != listed[j].dir {
+			return listed[i].dir
+		}
+		return strings.ToLower(listed[i].name) < strings.ToLower(listed[j].name)
+	})
+
+	p.dir = dir
+	p.entries = append(entries, listed...)
+	p.cursor = 0
+	p.offset = 0
+	p.err = nil
+}
+
+// Update handles key presses: j/k move, Enter/l opens a directory or chooses a file,
+// h/Backspace goes to the parent directory, ~ to the home directory, . toggles hidden
+// files and Esc cancels
+func (p *FilePicker) Update(msg tea.KeyMsg) (*FilePicker, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		p.Hide()
+	case "j", "down":
+		if p.cursor < len(p.entries)-1 {
+			p.cursor++
+		}
+	case "k", "up":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "g":
+		p.cursor = 0
+	case "G":
+		p.cursor = max(len(p.entries)-1, 0)
+	case "h", "left", "backspace":
+		p.open(filepath.Dir(p.dir))
+	case "~":
+		if home, err := os.UserHomeDir(); err == nil {
+			p.open(home)
+		}
+	case ".":
+		p.showHidden = !p.showHidden
+		p.open(p.dir)
+	case "enter", "l", "right":
+		if p.cursor >= len(p.entries) {
+			return p, nil
+		}
+		entry := p.entries[p.cursor]
+		path := filepath.Join(p.dir, entry.name)
+		if entry.dir {
+			p.open(path)
+			return p, nil
+		}
+		p.Hide()
+		action, ctx := p.action, p.context
+		return p, func() tea.Msg {
+			return FilePickerResultMsg{Action: action, Path: path, Context: ctx}
+		}
+	}
+
+	// Keep the cursor in the visible window
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+filePickerRows {
+		p.offset = p.cursor - filePickerRows + 1
+	}
+	return p, nil
+}
+
+// View renders the picker
+func (p *FilePicker) View(screenWidth, screenHeight int) string {
+	if !p.visible {
+		return ""
+	}
+
+	width := 64
+	if width > screenWidth-4 {
+		width = screenWidth - 4
+	}
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Lavender).
+		Width(innerWidth).
+		Align(lipgloss.Center)
+	dirStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	folderStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	fileStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	sizeStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	selectedStyle := lipgloss.NewStyle().
+		Background(styles.Surface0).
+		Foreground(styles.Lavender).
+		Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(p.title))
+	content.WriteString("\n")
+	content.WriteString(dirStyle.Render(truncateLeft(p.dir, innerWidth)))
+	content.WriteString("\n\n")
+
+	if len(p.entries) == 0 {
+		content.WriteString(helpStyle.Render("Empty directory"))
+		content.WriteString("\n")
+	}
+	end := min(p.offset+filePickerRows, len(p.entries))
+	for i := p.offset; i < end; i++ {
+		entry := p.entries[i]
+		name := entry.name
+		size := ""
+		if entry.dir {
+			name += "/"
+		} else {
+			size = api.FormatSize(entry.size)
+		}
+		nameWidth := innerWidth - len(size) - 3
+		if len([]rune(name)) > nameWidth {
+			name = string([]rune(name)[:max(nameWidth-1, 0)]) + "…"
+		}
+
+		if i == p.cursor {
+			line := "▸ " + name + strings.Repeat(" ", max(nameWidth-len([]rune(name)), 0)) + " " + size
+			content.WriteString(selectedStyle.Render(line))
+		} else {
+			style := fileStyle
+			if entry.dir {
+				style = folderStyle
+			}
+			content.WriteString("  " + style.Render(name) + strings.Repeat(" ", max(nameWidth-len([]rune(name)), 0)) + " " + sizeStyle.Render(size))
+		}
+		content.WriteString("\n")
+	}
+
+	if p.err != nil {
+		content.WriteString("\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(styles.Red).Render(p.err.Error()))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render("enter: open/choose · h: parent · ~: home · .: hidden · esc: cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender).
+		Padding(1, 2).
+		Width(width).
+		Render(content.String())
+}
+
+// truncateLeft keeps the end of s within width, marking the cut with "…"
+func truncateLeft(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width || width < 2 {
+		return s
+	}
+	return "…" + string(runes[len(runes)-width+1:])
+}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkBlocks are the bar heights used by Sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the last width values as a one-line bar chart scaled between
+// their min and max
+func Sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	lo, hi := bounds(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// brailleDots maps a dot position within a braille cell ([x][y], 2 columns by 4 rows)
+// to its bit in the U+2800 block
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// BrailleChart plots the last width*2 values as a line chart of height rows using
+// braille dots (2 values per character, 4 vertical steps per row), scaled between the
+// values' min and max. The chart is right-aligned so the latest value is at the right
+// edge. Each mark is drawn as a dotted horizontal line. Rows are returned top to bottom.
+func BrailleChart(values []float64, width, height int, marks ...float64) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	cols, dots := width*2, height*4
+	if len(values) > cols {
+		values = values[len(values)-cols:]
+	}
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = make([]rune, width)
+	}
+	set := func(x, y int) {
+		grid[y/4][x/2] |= brailleDots[x%2][y%4]
+	}
+
+	lo, hi := bounds(values)
+	for _, mark := range marks {
+		y := ChartRow(mark, lo, hi, dots)
+		for x := 0; x < cols; x += 2 {
+			set(x, y)
+		}
+	}
+
+	offset := cols - len(values)
+	prev := -1
+	for i, v := range values {
+		x := offset + i
+		y := ChartRow(v, lo, hi, dots)
+		from, to := y, y
+		if prev >= 0 {
+			// Join with the previous point so steep changes stay visible
+			from, to = min(prev, y), max(prev, y)
+		}
+		for dy := from; dy <= to; dy++ {
+			set(x, dy)
+		}
+		prev = y
+	}
+
+	rows := make([]string, height)
+	for i, row := range grid {
+		var b strings.Builder
+		for _, cell := range row {
+			b.WriteRune(0x2800 + cell)
+		}
+		rows[i] = b.String()
+	}
+	return rows
+}
+
+// ChartRow returns the dot row (0 at the top) of v on a chart of dots rows scaled
+// between lo and hi. Values outside the range are clamped.
+func ChartRow(v, lo, hi float64, dots int) int {
+	if hi <= lo {
+		return dots / 2
+	}
+	ratio := math.Max(0, math.Min(1, (v-lo)/(hi-lo)))
+	return dots - 1 - int(math.Round(ratio*float64(dots-1)))
+}
+
+// bounds returns the min and max of values
+func bounds(values []float64) (lo, hi float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lo, hi = values[0], values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return lo, hi
+}
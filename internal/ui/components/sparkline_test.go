@@ -0,0 +1,103 @@
+package components
+
+import (
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{name: "scaled to range", values: []float64{10, 20, 30, 40, 50, 60, 70, 80}, width: 10, want: "▁▂▃▄▅▆▇█"},
+		{name: "keeps latest values", values: []float64{80, 10, 80}, width: 2, want: "▁█"},
+		{name: "flat", values: []float64{5, 5, 5}, width: 10, want: "▅▅▅"},
+		{name: "empty", values: nil, width: 10, want: ""},
+		{name: "no width", values: []float64{1}, width: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrailleChart(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		height int
+		marks  []float64
+		want   []string
+	}{
+		{
+			// Low then high: left column bottom dot, right column joined from bottom to top
+			name:   "rising pair",
+			values: []float64{0, 10},
+			width:  1, height: 1,
+			want: []string{"⣸"},
+		},
+		{
+			name:   "two rows",
+			values: []float64{10, 0},
+			width:  1, height: 2,
+			want: []string{"⢹", "⢸"},
+		},
+		{
+			name:   "dotted mark",
+			values: []float64{0, 0, 0, 0},
+			width:  2, height: 1,
+			marks: []float64{0},
+			want:  []string{"⠤⠤"},
+		},
+		{
+			name:   "right-aligned",
+			values: []float64{0, 10},
+			width:  2, height: 1,
+			want: []string{"⠀⣸"},
+		},
+		{
+			name:   "keeps latest values",
+			values: []float64{99, 0, 10},
+			width:  1, height: 1,
+			want: []string{"⣸"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BrailleChart(tt.values, tt.width, tt.height, tt.marks...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("BrailleChart() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("row %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChartRow(t *testing.T) {
+	tests := []struct {
+		v, lo, hi float64
+		want      int
+	}{
+		{v: 0, lo: 0, hi: 10, want: 7},
+		{v: 10, lo: 0, hi: 10, want: 0},
+		{v: 20, lo: 0, hi: 10, want: 0},
+		{v: 5, lo: 5, hi: 5, want: 4},
+	}
+	for _, tt := range tests {
+		if got := ChartRow(tt.v, tt.lo, tt.hi, 8); got != tt.want {
+			t.Errorf("ChartRow(%v, %v, %v) = %d, want %d", tt.v, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
@@ -12,6 +12,7 @@ type KeyValuePair struct {
 	Key     string
 	Value   string
 	Enabled bool
+	File    bool // Form-data file field: Value is the path of the uploaded file
 }
 
 // Table represents an editable table component
@@ -29,6 +29,9 @@ type TreeNode struct {
 	Expanded   bool        // Whether folder is expanded
 	HTTPMethod string      // HTTP method (only for RequestNode)
 	URL        string      // Request URL (only for RequestNode)
+	Linked     bool        // Whether the request links to another one (only for RequestNode)
+	SkipInRuns bool        // Whether the request is left out of collection runs (only for RequestNode)
+	Scripted   bool        // Whether scripts run around the requests it holds (only for CollectionNode and FolderNode)
 	Depth      int         // Nesting level (0 = root)
 	Parent     *TreeNode   // Reference to parent node
 }
@@ -43,6 +46,11 @@ type Tree struct {
 	scrollOffset int          // Scroll position for tall trees
 	search       *SearchInput // Search input
 	searchQuery  string       // Current search filter
+
+	sendStatus map[string]SendStatus // Marks of the requests sent with their folder, by request ID
+	filter     TreeFilter            // Requests shown, on top of the search
+	lastFailed map[string]bool       // Whether the last send of a request failed, by request ID
+	keyMap     map[string]string     // Keys bound to actions in the config, to the built-in key of the action
 }
 
 // TreeSelectionMsg is sent when a request is selected
@@ -77,9 +85,21 @@ type TreeYankMsg struct {
 	Node *TreeNode
 }
 
+// TreeCutMsg is sent when a node is cut, to move it with the next paste
+type TreeCutMsg struct {
+	Node *TreeNode
+}
+
+// TreeMoveMsg is sent to move a node among its siblings
+type TreeMoveMsg struct {
+	Node   *TreeNode
+	Offset int // -1 moves the node up, 1 down
+}
+
 // TreePasteMsg is sent when paste is requested
 type TreePasteMsg struct {
 	TargetNode *TreeNode // Where to paste
+	Link       bool      // Paste requests linked to the clipboard's instead of copies
 }
 
 // TreeNewRequestMsg is sent to create a new request
@@ -102,6 +122,27 @@ type TreeEditRequestMsg struct {
 	Node *TreeNode
 }
 
+// TreeRunMsg is sent to run every request of a collection or folder
+type TreeRunMsg struct {
+	Node *TreeNode // Collection or folder to run
+}
+
+// TreeSendAllMsg is sent to send every request of a collection or folder in place
+type TreeSendAllMsg struct {
+	Node *TreeNode // Collection or folder to send
+}
+
+// SendStatus is the mark shown next to a request sent with its folder
+type SendStatus int
+
+const (
+	SendStatusNone    SendStatus = iota
+	SendStatusPending            // Waiting for the requests before it
+	SendStatusSending            // Being sent
+	SendStatusPassed             // Answered, with every assertion passing
+	SendStatusFailed             // Not answered, or with a failing assertion
+)
+
 // NewTree creates a new tree from collections
 func NewTree(collections []*api.CollectionFile) *Tree {
 	t := &Tree{
@@ -123,6 +164,7 @@ func buildTree(collections []*api.CollectionFile) []*TreeNode {
 			Name:     col.Name,
 			Type:     CollectionNode,
 			Expanded: true, // Collections start expanded
+			Scripted: !col.Scripts.Empty(),
 			Depth:    0,
 		}
 		node.Children = buildFolders(col.Folders, 1, node)
@@ -141,6 +183,7 @@ func buildFolders(folders []api.Folder, depth int, parent *TreeNode) []*TreeNode
 			Name:     f.Name,
 			Type:     FolderNode,
 			Expanded: false, // Folders start collapsed
+			Scripted: !f.Scripts.Empty(),
 			Depth:    depth,
 			Parent:   parent,
 		}
@@ -161,6 +204,8 @@ func buildRequests(requests []api.CollectionRequest, depth int, parent *TreeNode
 			Type:       RequestNode,
 			HTTPMethod: string(r.Method),
 			URL:        r.URL,
+			Linked:     r.Link != "",
+			SkipInRuns: r.SkipInRuns,
 			Depth:      depth,
 			Parent:     parent,
 		})
@@ -191,15 +236,15 @@ func (t *Tree) Refresh() {
 
 // flattenNode recursively adds visible nodes to the list
 func (t *Tree) flattenNode(node *TreeNode) {
-	// If searching, check if this node or any descendant matches
-	if t.searchQuery != "" {
+	// If searching or filtering, check if this node or any descendant matches
+	if t.narrowed() {
 		if !t.nodeMatchesSearch(node) {
 			return
 		}
 	}
 
 	t.visible = append(t.visible, node)
-	if node.Expanded || t.searchQuery != "" {
+	if node.Expanded || t.narrowed() {
 		// When searching, show all matching descendants regardless of expanded state
 		for _, child := range node.Children {
 			t.flattenNode(child)
@@ -207,10 +252,10 @@ func (t *Tree) flattenNode(node *TreeNode) {
 	}
 }
 
-// nodeMatchesSearch checks if node or any descendant matches the search query
+// nodeMatchesSearch checks if node or any descendant matches the search query and the filter
 func (t *Tree) nodeMatchesSearch(node *TreeNode) bool {
 	// Check if this node matches
-	if MatchesQuery(node.Name, t.searchQuery) {
+	if t.nodeMatches(node) {
 		return true
 	}
 
@@ -346,12 +391,13 @@ func (t *Tree) IsSearching() bool {
 }
 
 // moveToFirstMatch moves cursor to the first node that directly matches the search query
+// and the filter
 func (t *Tree) moveToFirstMatch() {
-	if t.searchQuery == "" {
+	if !t.narrowed() {
 		return
 	}
 	for i, node := range t.visible {
-		if MatchesQuery(node.Name, t.searchQuery) {
+		if t.nodeMatches(node) {
 			t.cursor = i
 			t.selected = node
 			t.scrollIntoView()
@@ -368,7 +414,7 @@ func (t *Tree) nextMatch() {
 	// Start from cursor + 1, wrap around
 	for i := 1; i <= len(t.visible); i++ {
 		idx := (t.cursor + i) % len(t.visible)
-		if MatchesQuery(t.visible[idx].Name, t.searchQuery) {
+		if t.nodeMatches(t.visible[idx]) {
 			t.cursor = idx
 			t.selected = t.visible[idx]
 			t.scrollIntoView()
@@ -385,7 +431,7 @@ func (t *Tree) prevMatch() {
 	// Start from cursor - 1, wrap around
 	for i := 1; i <= len(t.visible); i++ {
 		idx := (t.cursor - i + len(t.visible)) % len(t.visible)
-		if MatchesQuery(t.visible[idx].Name, t.searchQuery) {
+		if t.nodeMatches(t.visible[idx]) {
 			t.cursor = idx
 			t.selected = t.visible[idx]
 			t.scrollIntoView()
@@ -434,7 +480,11 @@ func (t *Tree) Update(msg tea.Msg, allowNavigation bool) (*Tree, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
+		key := msg.String()
+		if builtin, ok := t.keyMap[key]; ok {
+			key = builtin
+		}
+		switch key {
 		case "j", "down":
 			t.Down()
 		case "k", "up":
@@ -508,6 +558,29 @@ func (t *Tree) Update(msg tea.Msg, allowNavigation bool) (*Tree, tea.Cmd) {
 			return t, func() tea.Msg {
 				return TreePasteMsg{TargetNode: t.selected}
 			}
+		case "P":
+			// Paste from clipboard as requests linked to the clipboard's
+			return t, func() tea.Msg {
+				return TreePasteMsg{TargetNode: t.selected, Link: true}
+			}
+		case "x":
+			// Cut selected node, to move it with the next paste
+			if t.selected != nil {
+				return t, func() tea.Msg {
+					return TreeCutMsg{Node: t.selected}
+				}
+			}
+		case "J", "K":
+			// Move selected node down or up among its siblings
+			if t.selected != nil {
+				offset := 1
+				if key == "K" {
+					offset = -1
+				}
+				return t, func() tea.Msg {
+					return TreeMoveMsg{Node: t.selected, Offset: offset}
+				}
+			}
 		case "n":
 			// In search mode: next match, otherwise: new request
 			if t.HasSearchQuery() {
@@ -533,6 +606,20 @@ func (t *Tree) Update(msg tea.Msg, allowNavigation bool) (*Tree, tea.Cmd) {
 					return TreeDuplicateMsg{Node: t.selected}
 				}
 			}
+		case "r":
+			// Run the selected collection/folder (or the folder of the selected request)
+			if node := t.getParentFolder(); node != nil {
+				return t, func() tea.Msg {
+					return TreeRunMsg{Node: node}
+				}
+			}
+		case "S":
+			// Send every request of the selected collection/folder without the runner view
+			if node := t.getParentFolder(); node != nil {
+				return t, func() tea.Msg {
+					return TreeSendAllMsg{Node: node}
+				}
+			}
 		case "c":
 			// Edit request (only for RequestNode)
 			if t.selected != nil && t.selected.Type == RequestNode {
@@ -555,19 +642,35 @@ func (t *Tree) Update(msg tea.Msg, allowNavigation bool) (*Tree, tea.Cmd) {
 			// Open search
 			t.search.Show()
 			return t, nil
+		case "M":
+			// Show only the requests of the next method
+			t.cycleMethodFilter()
+		case "X":
+			// Show only the requests whose last send failed
+			t.SetFilter(TreeFilter{Method: t.filter.Method, Failed: !t.filter.Failed})
 		case "esc":
-			// Clear search filter if active
+			// Clear search filter if active, then the method and failed filter
 			if t.searchQuery != "" {
 				t.searchQuery = ""
 				t.Refresh()
 				return t, nil
 			}
+			if !t.filter.IsZero() {
+				t.SetFilter(TreeFilter{})
+				return t, nil
+			}
 		}
 	}
 
 	return t, nil
 }
 
+// SetKeyMap makes keys perform the action of a built-in key: {"a": "n"} makes a create
+// a request. Built-in keys keep working unless mapped to another action.
+func (t *Tree) SetKeyMap(keyMap map[string]string) {
+	t.keyMap = keyMap
+}
+
 // getParentFolder returns the appropriate parent folder for new items
 func (t *Tree) getParentFolder() *TreeNode {
 	if t.selected == nil {
@@ -588,7 +691,7 @@ func (t *Tree) View(width, height int, active bool) string {
 	// Count matches for search display
 	matchCount := 0
 	totalCount := t.countAllNodes()
-	if t.searchQuery != "" {
+	if t.narrowed() {
 		matchCount = t.countDirectMatches()
 	}
 
@@ -597,7 +700,7 @@ func (t *Tree) View(width, height int, active bool) string {
 		searchBox := t.search.ViewCompact(width, matchCount, totalCount)
 		output = append(output, searchBox)
 		height -= lipgloss.Height(searchBox) + 1
-	} else if t.searchQuery != "" {
+	} else if t.narrowed() {
 		// Show compact filter indicator with count
 		filterStyle := lipgloss.NewStyle().
 			Foreground(styles.Yellow)
@@ -606,7 +709,14 @@ func (t *Tree) View(width, height int, active bool) string {
 		escStyle := lipgloss.NewStyle().
 			Foreground(styles.Subtext0).
 			Italic(true)
-		filterText := filterStyle.Render("/"+t.searchQuery) + countStyle.Render(fmt.Sprintf(" %d/%d", matchCount, totalCount)) + escStyle.Render(" esc")
+		var parts []string
+		if t.searchQuery != "" {
+			parts = append(parts, "/"+t.searchQuery)
+		}
+		if !t.filter.IsZero() {
+			parts = append(parts, "["+t.filter.String()+"]")
+		}
+		filterText := filterStyle.Render(strings.Join(parts, " ")) + countStyle.Render(fmt.Sprintf(" %d/%d", matchCount, totalCount)) + escStyle.Render(" esc")
 		output = append(output, filterText)
 		height--
 	}
@@ -618,7 +728,7 @@ func (t *Tree) View(width, height int, active bool) string {
 			Foreground(styles.Subtext0).
 			Width(width).
 			Align(lipgloss.Center)
-		if t.searchQuery != "" {
+		if t.narrowed() {
 			output = append(output, emptyStyle.Render("No matches found"))
 		} else {
 			output = append(output, emptyStyle.Render("No collections found\n\nPress 'n' to create one\nor add files to:\n.lazycurl/collections/"))
@@ -653,16 +763,16 @@ func (t *Tree) countAllNodes() int {
 	return count
 }
 
-// countDirectMatches counts nodes that directly match the search query
+// countDirectMatches counts nodes that directly match the search query and the filter
 func (t *Tree) countDirectMatches() int {
-	if t.searchQuery == "" {
+	if !t.narrowed() {
 		return 0
 	}
 	count := 0
 	var countMatches func([]*TreeNode)
 	countMatches = func(nodes []*TreeNode) {
 		for _, node := range nodes {
-			if MatchesQuery(node.Name, t.searchQuery) {
+			if t.nodeMatches(node) {
 				count++
 			}
 			countMatches(node.Children)
@@ -737,11 +847,25 @@ func (t *Tree) renderNode(node *TreeNode, width int, selected bool, panelActive
 		prefixLen := lipgloss.Width(prefix)
 		methodLen := lipgloss.Width(methodBadge)
 		availableNameWidth := width - prefixLen - methodLen - 2 // 2 spaces
+		marks := ""
+		if node.Linked {
+			marks += " ↗"
+		}
+		if node.SkipInRuns {
+			marks += " ⊘"
+			if !isSearching {
+				nameStyle = nameStyle.Foreground(styles.MutedColor)
+			}
+		}
+		if mark := renderSendStatus(t.sendStatus[node.ID]); mark != "" {
+			marks += " " + mark
+		}
+		availableNameWidth -= lipgloss.Width(marks)
 		name := node.Name
 		if availableNameWidth > 0 && len(name) > availableNameWidth {
 			name = name[:availableNameWidth] // Truncate without ellipsis
 		}
-		content = fmt.Sprintf("%s %s %s", prefix, methodBadge, nameStyle.Render(name))
+		content = fmt.Sprintf("%s %s %s%s", prefix, methodBadge, nameStyle.Render(name), marks)
 	} else {
 		iconStyle := lipgloss.NewStyle()
 		nameStyle := lipgloss.NewStyle()
@@ -754,15 +878,19 @@ func (t *Tree) renderNode(node *TreeNode, width int, selected bool, panelActive
 				nameStyle = nameStyle.Foreground(styles.SearchDimmed)
 			}
 		}
-		// Calculate available width for name: width - prefix - icon
+		marks := ""
+		if node.Scripted {
+			marks = " " + lipgloss.NewStyle().Foreground(styles.Subtext0).Render("ƒ")
+		}
+		// Calculate available width for name: width - prefix - icon - marks
 		prefixLen := lipgloss.Width(prefix)
 		iconLen := lipgloss.Width(icon)
-		availableNameWidth := width - prefixLen - iconLen
+		availableNameWidth := width - prefixLen - iconLen - lipgloss.Width(marks)
 		name := node.Name
 		if availableNameWidth > 0 && len(name) > availableNameWidth {
 			name = name[:availableNameWidth] // Truncate without ellipsis
 		}
-		content = fmt.Sprintf("%s%s%s", prefix, iconStyle.Render(icon), nameStyle.Render(name))
+		content = fmt.Sprintf("%s%s%s%s", prefix, iconStyle.Render(icon), nameStyle.Render(name), marks)
 	}
 
 	// Apply selection styling based on node type and selection state
@@ -781,6 +909,34 @@ func (t *Tree) renderNode(node *TreeNode, width int, selected bool, panelActive
 	return style.Render(content)
 }
 
+// renderSendStatus returns the mark of a request sent with its folder, "" for none
+func renderSendStatus(status SendStatus) string {
+	switch status {
+	case SendStatusPending:
+		return lipgloss.NewStyle().Foreground(styles.Subtext0).Render("·")
+	case SendStatusSending:
+		return lipgloss.NewStyle().Foreground(styles.Yellow).Render("◌")
+	case SendStatusPassed:
+		return lipgloss.NewStyle().Foreground(styles.Green).Render("✓")
+	case SendStatusFailed:
+		return lipgloss.NewStyle().Foreground(styles.Red).Render("✗")
+	}
+	return ""
+}
+
+// SetSendStatus sets the mark shown next to a request sent with its folder
+func (t *Tree) SetSendStatus(requestID string, status SendStatus) {
+	if t.sendStatus == nil {
+		t.sendStatus = make(map[string]SendStatus)
+	}
+	t.sendStatus[requestID] = status
+}
+
+// ClearSendStatuses removes the marks of the requests sent with their folder
+func (t *Tree) ClearSendStatuses() {
+	t.sendStatus = nil
+}
+
 // renderMethodBadge returns a styled HTTP method badge
 func (t *Tree) renderMethodBadge(method string, dimmed bool) string {
 	var bg, fg lipgloss.Color
@@ -790,24 +946,7 @@ func (t *Tree) renderMethodBadge(method string, dimmed bool) string {
 		bg = styles.SearchDimmed
 		fg = styles.Mantle
 	} else {
-		switch method {
-		case "GET":
-			bg, fg = styles.MethodGetBg, styles.MethodGetFg
-		case "POST":
-			bg, fg = styles.MethodPostBg, styles.MethodPostFg
-		case "PUT":
-			bg, fg = styles.MethodPutBg, styles.MethodPutFg
-		case "DELETE":
-			bg, fg = styles.MethodDeleteBg, styles.MethodDeleteFg
-		case "PATCH":
-			bg, fg = styles.MethodPatchBg, styles.MethodPatchFg
-		case "HEAD":
-			bg, fg = styles.MethodHeadBg, styles.MethodHeadFg
-		case "OPTIONS":
-			bg, fg = styles.MethodOptionsBg, styles.MethodOptionsFg
-		default:
-			bg, fg = styles.Surface1, styles.Text
-		}
+		bg, fg = styles.MethodColors(method)
 	}
 
 	style := lipgloss.NewStyle().
@@ -815,7 +954,7 @@ func (t *Tree) renderMethodBadge(method string, dimmed bool) string {
 		Foreground(fg).
 		Padding(0, 1)
 
-	return style.Render(method)
+	return style.Render(styles.MethodLabel(method))
 }
 
 // SetHeight sets the available height for the tree
@@ -830,6 +969,10 @@ type TreeState struct {
 	SelectedID    string          // ID of selected node
 	CursorPos     int             // Cursor position
 	ScrollOffset  int             // Scroll offset
+
+	SendStatus map[string]SendStatus // Marks of the requests sent with their folder
+	Filter     TreeFilter
+	LastFailed map[string]bool // Whether the last send of a request failed
 }
 
 // SaveState captures the current state of the tree
@@ -838,6 +981,9 @@ func (t *Tree) SaveState() *TreeState {
 		ExpandedNodes: make(map[string]bool),
 		CursorPos:     t.cursor,
 		ScrollOffset:  t.scrollOffset,
+		SendStatus:    t.sendStatus,
+		Filter:        t.filter,
+		LastFailed:    t.lastFailed,
 	}
 
 	// Save expanded state for all nodes
@@ -871,6 +1017,9 @@ func (t *Tree) RestoreState(state *TreeState) {
 
 	// Restore expanded state
 	t.restoreExpandedState(t.Root, state.ExpandedNodes)
+	t.sendStatus = state.SendStatus
+	t.filter = state.Filter
+	t.lastFailed = state.LastFailed
 
 	// Refresh visible list
 	t.Refresh()
@@ -1004,6 +1153,26 @@ func (t *Tree) findNodeByIDRecursive(nodes []*TreeNode, id string) *TreeNode {
 	return nil
 }
 
+// Reveal expands the folders containing the node with the given ID and selects it.
+// It returns false when the node is not in the tree or hidden by a search.
+func (t *Tree) Reveal(id string) bool {
+	node := t.FindNodeByID(id)
+	if node == nil {
+		return false
+	}
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		parent.Expanded = true
+	}
+	t.Refresh()
+	for i, visible := range t.visible {
+		if visible == node {
+			t.SelectIndex(i)
+			return true
+		}
+	}
+	return false
+}
+
 // SelectIndex selects an item by its visual index in the visible items.
 // This is used by jump mode to navigate directly to a target.
 func (t *Tree) SelectIndex(index int) {
@@ -0,0 +1,121 @@
+package components
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// TreeFilter narrows the tree to some requests, on top of the name search. Folders are
+// shown when they hold a request the filter keeps.
+type TreeFilter struct {
+	Method string // Only requests of this method, "" for any
+	Failed bool   // Only requests whose last send failed
+}
+
+// IsZero returns true if the filter keeps every request
+func (f TreeFilter) IsZero() bool {
+	return f.Method == "" && !f.Failed
+}
+
+// String describes the filter, such as "POST failed"
+func (f TreeFilter) String() string {
+	var parts []string
+	if f.Method != "" {
+		parts = append(parts, f.Method)
+	}
+	if f.Failed {
+		parts = append(parts, "failed")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Filter returns the filter of the tree
+func (t *Tree) Filter() TreeFilter {
+	return t.filter
+}
+
+// SetFilter narrows the tree to the requests f keeps
+func (t *Tree) SetFilter(f TreeFilter) {
+	f.Method = strings.ToUpper(f.Method)
+	t.filter = f
+	t.Refresh()
+	t.moveToFirstMatch()
+}
+
+// SetLastSendFailed records whether the last send of a request failed, for the Failed
+// filter
+func (t *Tree) SetLastSendFailed(requestID string, failed bool) {
+	if t.lastFailed == nil {
+		t.lastFailed = make(map[string]bool)
+	}
+	t.lastFailed[requestID] = failed
+	if t.filter.Failed {
+		t.Refresh()
+	}
+}
+
+// narrowed returns true if the search or the filter hide some nodes
+func (t *Tree) narrowed() bool {
+	return t.searchQuery != "" || !t.filter.IsZero()
+}
+
+// nodeMatches checks if a node itself matches the search query and the filter. With a
+// filter, only requests match.
+func (t *Tree) nodeMatches(node *TreeNode) bool {
+	if !t.filter.IsZero() {
+		if node.Type != RequestNode {
+			return false
+		}
+		if t.filter.Method != "" && !strings.EqualFold(node.HTTPMethod, t.filter.Method) {
+			return false
+		}
+		if t.filter.Failed && !t.lastFailed[node.ID] {
+			return false
+		}
+	}
+	return MatchesQuery(node.Name, t.searchQuery)
+}
+
+// cycleMethodFilter moves the method filter to the next method of the requests in the
+// tree, then back to any method
+func (t *Tree) cycleMethodFilter() {
+	methods := t.requestMethods()
+	next := ""
+	if i := slices.Index(methods, t.filter.Method); i+1 < len(methods) {
+		next = methods[i+1]
+	}
+	t.SetFilter(TreeFilter{Method: next, Failed: t.filter.Failed})
+}
+
+// requestMethods returns the methods of the requests in the tree, in the order of the
+// method picker
+func (t *Tree) requestMethods() []string {
+	found := make(map[string]bool)
+	var collect func([]*TreeNode)
+	collect = func(nodes []*TreeNode) {
+		for _, node := range nodes {
+			if node.Type == RequestNode {
+				found[strings.ToUpper(node.HTTPMethod)] = true
+			}
+			collect(node.Children)
+		}
+	}
+	collect(t.Root)
+
+	var methods []string
+	for _, method := range styles.Methods() {
+		if found[method] {
+			methods = append(methods, method)
+			delete(found, method)
+		}
+	}
+	var others []string
+	for method := range found {
+		others = append(others, method)
+	}
+	sort.Strings(others)
+	return append(methods, others...)
+}
@@ -0,0 +1,163 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestTree_SendAll(t *testing.T) {
+	tree := NewTree([]*api.CollectionFile{{
+		Name: "Shop",
+		Requests: []api.CollectionRequest{
+			{ID: "req_1", Name: "List", Method: api.GET, URL: "/items"},
+			{ID: "req_2", Name: "Create", Method: api.POST, URL: "/items"},
+		},
+	}})
+
+	// S on a request sends its folder
+	tree.Down()
+	_, cmd := tree.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")}, true)
+	if cmd == nil {
+		t.Fatal("S emitted no command")
+	}
+	if msg, ok := cmd().(TreeSendAllMsg); !ok || msg.Node.Type != CollectionNode {
+		t.Errorf("S emitted %#v, want TreeSendAllMsg for the collection", cmd())
+	}
+
+	tree.SetSendStatus("req_1", SendStatusPassed)
+	tree.SetSendStatus("req_2", SendStatusFailed)
+	view := tree.View(40, 10, true)
+	if !strings.Contains(view, "List ✓") || !strings.Contains(view, "Create ✗") {
+		t.Errorf("tree should mark sent requests:\n%s", view)
+	}
+
+	// Marks survive a reload of the tree
+	state := tree.SaveState()
+	tree = NewTree([]*api.CollectionFile{{Name: "Shop", Requests: []api.CollectionRequest{{ID: "req_1", Name: "List", Method: api.GET}}}})
+	tree.RestoreState(state)
+	if !strings.Contains(tree.View(40, 10, true), "List ✓") {
+		t.Error("marks should be restored with the tree state")
+	}
+
+	tree.ClearSendStatuses()
+	if strings.Contains(tree.View(40, 10, true), "✓") {
+		t.Error("ClearSendStatuses() should remove the marks")
+	}
+}
+
+func TestTree_SetKeyMap(t *testing.T) {
+	tree := NewTree([]*api.CollectionFile{{
+		Name:     "Shop",
+		Requests: []api.CollectionRequest{{ID: "req_1", Name: "List", Method: api.GET}},
+	}})
+	tree.SetKeyMap(map[string]string{"a": "n", "n": "R"})
+	tree.Down()
+
+	_, cmd := tree.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, true)
+	if cmd == nil {
+		t.Fatal("a emitted no command")
+	}
+	if _, ok := cmd().(TreeNewRequestMsg); !ok {
+		t.Errorf("a emitted %#v, want TreeNewRequestMsg", cmd())
+	}
+	_, cmd = tree.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, true)
+	if cmd == nil {
+		t.Fatal("n emitted no command")
+	}
+	if _, ok := cmd().(TreeRenameMsg); !ok {
+		t.Errorf("n emitted %#v, want TreeRenameMsg once mapped to rename", cmd())
+	}
+}
+
+func TestTree_Reveal(t *testing.T) {
+	tree := NewTree([]*api.CollectionFile{{
+		Name: "Shop",
+		Folders: []api.Folder{{
+			Name:    "Auth",
+			Folders: []api.Folder{{Name: "Tokens", Requests: []api.CollectionRequest{{ID: "req_login", Name: "Login", Method: api.POST}}}},
+		}},
+		Requests: []api.CollectionRequest{{ID: "req_1", Name: "List", Method: api.GET}},
+	}})
+
+	if !tree.Reveal("req_login") {
+		t.Fatal("Reveal() should find a request in a collapsed folder")
+	}
+	if selected := tree.Selected(); selected == nil || selected.ID != "req_login" {
+		t.Errorf("Reveal() selected %v, want req_login", selected)
+	}
+	if !strings.Contains(tree.View(40, 10, true), "Tokens") {
+		t.Error("Reveal() should expand the folders of the node")
+	}
+	if tree.Reveal("missing") {
+		t.Error("Reveal() should fail for an unknown node")
+	}
+}
+
+func TestTree_Filter(t *testing.T) {
+	tree := NewTree([]*api.CollectionFile{{
+		Name: "Shop",
+		Folders: []api.Folder{{
+			Name: "Orders",
+			Requests: []api.CollectionRequest{
+				{ID: "req_3", Name: "Create order", Method: api.POST},
+				{ID: "req_4", Name: "List orders", Method: api.GET},
+			},
+		}},
+		Requests: []api.CollectionRequest{
+			{ID: "req_1", Name: "List items", Method: api.GET},
+			{ID: "req_2", Name: "Create item", Method: api.POST},
+		},
+	}})
+	names := func() []string {
+		var names []string
+		for _, node := range tree.GetVisibleItems() {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+	key := func(k string) {
+		tree.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}, true)
+	}
+
+	// M cycles through the methods of the tree, showing collapsed folders holding matches
+	key("M")
+	if got := strings.Join(names(), ","); got != "Shop,Orders,List orders,List items" {
+		t.Errorf("GET filter shows %s", got)
+	}
+	key("M")
+	if got := strings.Join(names(), ","); got != "Shop,Orders,Create order,Create item" {
+		t.Errorf("POST filter shows %s", got)
+	}
+
+	// X keeps the requests whose last send failed, on top of the method and the search
+	tree.SetLastSendFailed("req_2", true)
+	tree.SetLastSendFailed("req_3", true)
+	tree.SetLastSendFailed("req_3", false)
+	key("X")
+	if got := strings.Join(names(), ","); got != "Shop,Create item" {
+		t.Errorf("POST failed filter shows %s", got)
+	}
+	if view := tree.View(40, 10, true); !strings.Contains(view, "[POST failed]") {
+		t.Errorf("tree should show the filter:\n%s", view)
+	}
+	tree.Update(SearchUpdateMsg{Query: "order"}, true)
+	if len(names()) != 0 {
+		t.Errorf("search and filter show %v, want nothing", names())
+	}
+
+	// Filters survive a reload of the tree; esc clears the search, then the filters
+	state := tree.SaveState()
+	tree = NewTree([]*api.CollectionFile{{Name: "Shop", Requests: []api.CollectionRequest{{ID: "req_2", Name: "Create item", Method: api.POST}}}})
+	tree.RestoreState(state)
+	if got := strings.Join(names(), ","); got != "Shop,Create item" {
+		t.Errorf("restored filter shows %s", got)
+	}
+	tree.Update(tea.KeyMsg{Type: tea.KeyEsc}, true)
+	if !tree.Filter().IsZero() || len(names()) != 2 {
+		t.Errorf("esc should clear the filter, filter = %q, shown %v", tree.Filter(), names())
+	}
+}
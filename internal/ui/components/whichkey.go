@@ -1,6 +1,7 @@
 package components
 
 import (
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,8 +12,9 @@ import (
 
 // KeyBinding represents a single keybinding with its description
 type KeyBinding struct {
-	Key  string
-	Desc string
+	Key     string
+	Desc    string
+	Actions []string // Configurable actions of the keys, whose bindings replace Key (see SetKeyMap)
 }
 
 // KeyGroup represents a group of related keybindings
@@ -38,28 +40,37 @@ const (
 	ContextDialog            KeyContext = "dialog"
 	ContextModal             KeyContext = "modal"
 	// Request panel tab contexts
-	ContextRequestParams  KeyContext = "request_params"
-	ContextRequestAuth    KeyContext = "request_auth"
-	ContextRequestHeaders KeyContext = "request_headers"
-	ContextRequestBody    KeyContext = "request_body"
-	ContextRequestScripts KeyContext = "request_scripts"
+	ContextRequestParams   KeyContext = "request_params"
+	ContextRequestAuth     KeyContext = "request_auth"
+	ContextRequestHeaders  KeyContext = "request_headers"
+	ContextRequestBody     KeyContext = "request_body"
+	ContextRequestScripts  KeyContext = "request_scripts"
+	ContextRequestSettings KeyContext = "request_settings"
+	ContextRequestCapture  KeyContext = "request_capture"
+	ContextRequestDocs     KeyContext = "request_docs"
 	// Response panel tab contexts
-	ContextConsole KeyContext = "console"
+	ContextConsole       KeyContext = "console"
+	ContextResponseTable KeyContext = "response_table"
+	ContextResponseLinks KeyContext = "response_links"
 	// Jump mode context
 	ContextJump KeyContext = "jump"
 )
 
 // WhichKey manages keybinding hints display
 type WhichKey struct {
-	visible  bool
-	context  KeyContext
-	bindings map[KeyContext][]KeyGroup
+	visible   bool
+	context   KeyContext
+	bindings  map[KeyContext][]KeyGroup
+	closeKeys []string
+	moreKey   string // Key showing all bindings, hinted when the status bar hints are cut
 }
 
 // NewWhichKey creates a new WhichKey component
 func NewWhichKey() *WhichKey {
 	w := &WhichKey{
-		bindings: make(map[KeyContext][]KeyGroup),
+		bindings:  make(map[KeyContext][]KeyGroup),
+		closeKeys: []string{"esc", "?", "q"},
+		moreKey:   "?",
 	}
 	w.initBindings()
 	return w
@@ -73,8 +84,8 @@ func (w *WhichKey) initBindings() {
 			Name: "Mode",
 			Bindings: []KeyBinding{
 				{Key: "esc", Desc: "Normal"},
-				{Key: ":", Desc: "Command"},
-				{Key: "q", Desc: "Quit"},
+				{Key: ":", Desc: "Command", Actions: []string{"command_mode"}},
+				{Key: "q", Desc: "Quit", Actions: []string{"quit"}},
 			},
 		},
 	}
@@ -84,10 +95,14 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Navigation",
 			Bindings: []KeyBinding{
-				{Key: "j/k", Desc: "Up/Down"},
+				{Key: "j/k", Desc: "Up/Down", Actions: []string{"navigate_down", "navigate_up"}},
 				{Key: "h/l", Desc: "Collapse/Expand"},
 				{Key: "g/G", Desc: "Top/Bottom"},
 				{Key: "/", Desc: "Search"},
+				{Key: "ctrl+k", Desc: "Search all", Actions: []string{"search_all"}},
+				{Key: "ctrl+p", Desc: "Commands", Actions: []string{"command_palette"}},
+				{Key: "M", Desc: "Filter by method"},
+				{Key: "X", Desc: "Only failed"},
 			},
 		},
 		{
@@ -100,12 +115,14 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Actions",
 			Bindings: []KeyBinding{
-				{Key: "n", Desc: "New Request"},
-				{Key: "N", Desc: "New Folder"},
+				{Key: "n", Desc: "New Request", Actions: []string{"new_request"}},
+				{Key: "N", Desc: "New Folder", Actions: []string{"new_folder"}},
 				{Key: "c/i", Desc: "Edit"},
-				{Key: "R", Desc: "Rename"},
-				{Key: "d", Desc: "Delete"},
-				{Key: "D", Desc: "Duplicate"},
+				{Key: "R", Desc: "Rename", Actions: []string{"rename"}},
+				{Key: "d", Desc: "Delete", Actions: []string{"delete_request"}},
+				{Key: "D", Desc: "Duplicate", Actions: []string{"duplicate"}},
+				{Key: "r", Desc: "Run collection/folder", Actions: []string{"run_folder"}},
+				{Key: "S", Desc: "Send collection/folder", Actions: []string{"send_folder"}},
 			},
 		},
 		{
@@ -113,12 +130,22 @@ func (w *WhichKey) initBindings() {
 			Bindings: []KeyBinding{
 				{Key: "y", Desc: "Yank"},
 				{Key: "p", Desc: "Paste"},
+				{Key: "P", Desc: "Paste as link"},
+				{Key: "x", Desc: "Cut"},
+				{Key: "J/K", Desc: "Move down/up"},
+			},
+		},
+		{
+			Name: "Marks",
+			Bindings: []KeyBinding{
+				{Key: "m{a-z}", Desc: "Set mark"},
+				{Key: "'{a-z}", Desc: "Jump to mark"},
 			},
 		},
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
@@ -147,9 +174,11 @@ func (w *WhichKey) initBindings() {
 				{Key: "n", Desc: "New Variable"},
 				{Key: "N", Desc: "New Environment"},
 				{Key: "c/i", Desc: "Edit Value"},
+				{Key: "H", Desc: "Value History"},
 				{Key: "R", Desc: "Rename"},
 				{Key: "d", Desc: "Delete"},
 				{Key: "D", Desc: "Duplicate"},
+				{Key: "I/E", Desc: "Import/Export .env"},
 			},
 		},
 		{
@@ -157,13 +186,14 @@ func (w *WhichKey) initBindings() {
 			Bindings: []KeyBinding{
 				{Key: "a/A", Desc: "Active"},
 				{Key: "s", Desc: "Secret"},
+				{Key: "t", Desc: "Type"},
 				{Key: "S/enter", Desc: "Select Env"},
 			},
 		},
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
@@ -181,14 +211,22 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Actions",
 			Bindings: []KeyBinding{
-				{Key: "ctrl+s", Desc: "Send"},
-				{Key: "i", Desc: "Insert mode"},
+				{Key: "ctrl+s", Desc: "Send", Actions: []string{"send_request"}},
+				{Key: "i", Desc: "Insert mode", Actions: []string{"insert_mode"}},
+				{Key: "ctrl+y", Desc: "Copy as code"},
+			},
+		},
+		{
+			Name: "Marks",
+			Bindings: []KeyBinding{
+				{Key: "m{a-z}", Desc: "Set mark"},
+				{Key: "'{a-z}", Desc: "Jump to mark"},
 			},
 		},
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
@@ -219,10 +257,74 @@ func (w *WhichKey) initBindings() {
 				{Key: "Ctrl+C", Desc: "Copy all"},
 			},
 		},
+		{
+			Name: "Body",
+			Bindings: []KeyBinding{
+				{Key: "t", Desc: "Table view"},
+				{Key: "r", Desc: "Raw/formatted"},
+				{Key: "J", Desc: "JSONPath/XPath query"},
+				{Key: "S", Desc: "Save value as variable"},
+				{Key: "o", Desc: "HTML links/forms"},
+				{Key: "W", Desc: "Save to file"},
+			},
+		},
+		{
+			Name: "Help",
+			Bindings: []KeyBinding{
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
+			},
+		},
+	}
+
+	// Normal mode - Response body table view
+	w.bindings[ContextResponseTable] = []KeyGroup{
+		{
+			Name: "Navigation",
+			Bindings: []KeyBinding{
+				{Key: "j/k", Desc: "Row up/down"},
+				{Key: "w/b", Desc: "Next/Prev column"},
+				{Key: "g/G", Desc: "Top/Bottom"},
+			},
+		},
+		{
+			Name: "Table",
+			Bindings: []KeyBinding{
+				{Key: "s", Desc: "Sort column"},
+				{Key: "x", Desc: "Hide column"},
+				{Key: "X", Desc: "Show all columns"},
+				{Key: "E", Desc: "Export CSV"},
+				{Key: "t", Desc: "Raw view"},
+			},
+		},
+		{
+			Name: "Help",
+			Bindings: []KeyBinding{
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
+			},
+		},
+	}
+
+	// Normal mode - Response body links and forms of an HTML page
+	w.bindings[ContextResponseLinks] = []KeyGroup{
+		{
+			Name: "Navigation",
+			Bindings: []KeyBinding{
+				{Key: "j/k", Desc: "Select link/form"},
+				{Key: "g/G", Desc: "First/Last"},
+			},
+		},
+		{
+			Name: "Links",
+			Bindings: []KeyBinding{
+				{Key: "enter", Desc: "New request"},
+				{Key: "y", Desc: "Copy URL"},
+				{Key: "o/esc", Desc: "Close"},
+			},
+		},
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
@@ -341,6 +443,7 @@ func (w *WhichKey) initBindings() {
 				{Key: "c/i", Desc: "Edit"},
 				{Key: "d", Desc: "Delete"},
 				{Key: "space", Desc: "Toggle"},
+				{Key: "B", Desc: "Bulk edit"},
 				{Key: "H/L", Desc: "Panel"},
 				{Key: "tab", Desc: "Next tab"},
 			},
@@ -356,7 +459,7 @@ func (w *WhichKey) initBindings() {
 				{Key: "i/c/Enter", Desc: "Edit"},
 				{Key: "H/L", Desc: "Panel"},
 				{Key: "tab", Desc: "Next tab"},
-				{Key: "ctrl+s", Desc: "Send"},
+				{Key: "ctrl+s", Desc: "Send", Actions: []string{"send_request"}},
 			},
 		},
 	}
@@ -370,6 +473,7 @@ func (w *WhichKey) initBindings() {
 				{Key: "c/i", Desc: "Edit"},
 				{Key: "d", Desc: "Delete"},
 				{Key: "space", Desc: "Toggle"},
+				{Key: "B", Desc: "Bulk edit"},
 				{Key: "H/L", Desc: "Panel"},
 				{Key: "tab", Desc: "Next tab"},
 			},
@@ -382,12 +486,40 @@ func (w *WhichKey) initBindings() {
 			Bindings: []KeyBinding{
 				{Key: "h/l", Desc: "Cursor"},
 				{Key: "j/k", Desc: "Up/Down"},
+				{Key: "[/]", Desc: "GraphQL query/vars"},
 				{Key: "i", Desc: "Insert mode"},
 				{Key: "ctrl+f", Desc: "Format"},
 				{Key: "H/L", Desc: "Panel"},
 				{Key: "tab", Desc: "Next tab"},
 			},
 		},
+		{
+			Name: "Form Data",
+			Bindings: []KeyBinding{
+				{Key: "n", Desc: "New text field"},
+				{Key: "N", Desc: "New file field"},
+				{Key: "o", Desc: "Choose file"},
+				{Key: "t", Desc: "Text/file"},
+				{Key: "s", Desc: "Toggle"},
+			},
+		},
+		{
+			Name: "Binary",
+			Bindings: []KeyBinding{
+				{Key: "o", Desc: "Choose file"},
+				{Key: "c", Desc: "Type path"},
+			},
+		},
+		{
+			Name: "Body Builder",
+			Bindings: []KeyBinding{
+				{Key: "B", Desc: "Open/build body"},
+				{Key: "enter", Desc: "Edit value"},
+				{Key: "h/l", Desc: "Cycle enum"},
+				{Key: "d", Desc: "Clear value"},
+				{Key: "esc", Desc: "Back to editor"},
+			},
+		},
 	}
 
 	w.bindings[ContextRequestScripts] = []KeyGroup{
@@ -404,6 +536,47 @@ func (w *WhichKey) initBindings() {
 		},
 	}
 
+	w.bindings[ContextRequestSettings] = []KeyGroup{
+		{
+			Name: "Settings",
+			Bindings: []KeyBinding{
+				{Key: "j/k", Desc: "Navigate"},
+				{Key: "h/l", Desc: "Change backoff"},
+				{Key: "i/c/Enter", Desc: "Edit"},
+				{Key: "H/L", Desc: "Panel"},
+				{Key: "tab", Desc: "Next tab"},
+			},
+		},
+	}
+
+	w.bindings[ContextRequestCapture] = []KeyGroup{
+		{
+			Name: "Capture",
+			Bindings: []KeyBinding{
+				{Key: "j/k", Desc: "Up/Down"},
+				{Key: "n", Desc: "New capture"},
+				{Key: "c/i", Desc: "Edit"},
+				{Key: "d", Desc: "Delete"},
+				{Key: "s", Desc: "Toggle"},
+				{Key: "H/L", Desc: "Panel"},
+				{Key: "tab", Desc: "Next tab"},
+			},
+		},
+	}
+
+	w.bindings[ContextRequestDocs] = []KeyGroup{
+		{
+			Name: "Docs",
+			Bindings: []KeyBinding{
+				{Key: "Enter", Desc: "Edit Markdown"},
+				{Key: "Esc", Desc: "Show rendered"},
+				{Key: "j/k", Desc: "Scroll"},
+				{Key: "H/L", Desc: "Panel"},
+				{Key: "tab", Desc: "Next tab"},
+			},
+		},
+	}
+
 	// Console tab context
 	w.bindings[ContextConsole] = []KeyGroup{
 		{
@@ -417,7 +590,8 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Actions",
 			Bindings: []KeyBinding{
-				{Key: "R", Desc: "Resend request"},
+				{Key: "R", Desc: "Resend (as sent)"},
+				{Key: "r", Desc: "Resend (current env)"},
 			},
 		},
 		{
@@ -435,7 +609,7 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
@@ -452,12 +626,41 @@ func (w *WhichKey) initBindings() {
 		{
 			Name: "Help",
 			Bindings: []KeyBinding{
-				{Key: "?", Desc: "Show all keys"},
+				{Key: "?", Desc: "Show all keys", Actions: []string{"which_key"}},
 			},
 		},
 	}
 }
 
+// SetKeyMap shows the keys bound to the actions of the bindings, by action name.
+// Bindings whose actions have no keys are hidden.
+func (w *WhichKey) SetKeyMap(keymap map[string][]string) {
+	for _, groups := range w.bindings {
+		for g := range groups {
+			bindings := groups[g].Bindings[:0]
+			for _, binding := range groups[g].Bindings {
+				if len(binding.Actions) > 0 {
+					var keys []string
+					for _, action := range binding.Actions {
+						keys = append(keys, keymap[action]...)
+					}
+					if len(keys) == 0 {
+						continue
+					}
+					binding.Key = strings.Join(keys, "/")
+				}
+				bindings = append(bindings, binding)
+			}
+			groups[g].Bindings = bindings
+		}
+	}
+	w.closeKeys = append([]string{"esc", "q"}, keymap["which_key"]...)
+	w.moreKey = ""
+	if keys := keymap["which_key"]; len(keys) > 0 {
+		w.moreKey = keys[0]
+	}
+}
+
 // Show displays the WhichKey modal
 func (w *WhichKey) Show() {
 	w.visible = true
@@ -496,8 +699,7 @@ func (w *WhichKey) Update(msg tea.Msg) (*WhichKey, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc", "?", "q":
+		if slices.Contains(w.closeKeys, msg.String()) {
 			w.Hide()
 		}
 	}
@@ -516,7 +718,7 @@ func (w *WhichKey) GetHintsForStatusBar(ctx KeyContext) string {
 	for _, group := range groups {
 		for _, binding := range group.Bindings {
 			// Skip "Show all keys" hint for statusbar
-			if binding.Key == "?" {
+			if slices.Contains(binding.Actions, "which_key") {
 				continue
 			}
 			hints = append(hints, binding.Key+":"+binding.Desc)
@@ -528,7 +730,10 @@ func (w *WhichKey) GetHintsForStatusBar(ctx KeyContext) string {
 	if len(result) > 100 {
 		// Take first few hints
 		shortHints := hints[:min(5, len(hints))]
-		result = strings.Join(shortHints, " │ ") + " │ ?:More"
+		result = strings.Join(shortHints, " │ ")
+		if w.moreKey != "" {
+			result += " │ " + w.moreKey + ":More"
+		}
 	}
 
 	return " " + result
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+// defaultHistoryEntries is the number of console entries kept without a history config
+const defaultHistoryEntries = 1000
+
+// historyRetention returns the console history retention of the global config, and
+// whether dropped entries are archived
+func historyRetention(cfg *config.GlobalConfig) (api.HistoryRetention, bool) {
+	retention := api.HistoryRetention{MaxEntries: defaultHistoryEntries}
+	if cfg == nil || cfg.History == nil {
+		return retention, false
+	}
+	if cfg.History.MaxEntries > 0 {
+		retention.MaxEntries = cfg.History.MaxEntries
+	}
+	retention.MaxAge = cfg.History.MaxAge
+	retention.MaxBytes = int64(cfg.History.MaxSizeMB) << 20
+	return retention, cfg.History.Archive
+}
+
+// historyArchiver returns the archiver writing console entries to the workspace archive
+func (m *Model) historyArchiver() api.HistoryArchiver {
+	return workspaceArchiver(m.workspacePath)
+}
+
+// workspaceArchiver returns the archiver writing console entries to the archive of a
+// workspace
+func workspaceArchiver(workspacePath string) api.HistoryArchiver {
+	dir := api.HistoryArchiveDir(workspacePath)
+	return func(entries []api.ConsoleEntry) error {
+		_, err := api.ArchiveHistory(dir, entries)
+		return err
+	}
+}
+
+// loadHistory returns the console history saved by earlier sessions of a workspace,
+// without the entries now beyond the retention, which are archived first when enabled.
+// A file that fails to load keeps the entries read.
+func loadHistory(workspacePath string, retention api.HistoryRetention, archive bool) *api.ConsoleHistory {
+	history := api.NewConsoleHistory(math.MaxInt) // Limited by the retention
+	path := api.HistoryFile(workspacePath)
+	entries, _ := api.LoadHistory(path)
+	history.Load(entries)
+
+	var archiver api.HistoryArchiver
+	if archive {
+		archiver = workspaceArchiver(workspacePath)
+	}
+	if dropped, err := history.Prune(retention, time.Now(), archiver); err == nil && len(dropped) > 0 {
+		_ = api.SaveHistory(path, history.GetAll()) // Saved again after the next send otherwise
+	}
+	return history
+}
+
+// pruneHistory drops the console entries beyond the retention, archiving them first
+// when enabled
+func (m *Model) pruneHistory() {
+	var archive api.HistoryArchiver
+	if m.archiveHistory {
+		archive = m.historyArchiver()
+	}
+	if _, err := m.consoleHistory.Prune(m.historyRetention, time.Now(), archive); err != nil {
+		m.statusBar.Error(fmt.Errorf("console history kept: %w", err))
+	}
+}
+
+// saveHistory writes the console history to the workspace, for the next sessions
+func (m *Model) saveHistory() {
+	if err := api.SaveHistory(api.HistoryFile(m.workspacePath), m.consoleHistory.GetAll()); err != nil {
+		m.statusBar.Error(err)
+	}
+}
+
+// handleHistoryCommand shows the size and retention of the console history, or archives
+// the entries older than an age (all of them by default) and drops them
+func (m Model) handleHistoryCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.Info(fmt.Sprintf("Console history: %d entries · %s · keeps %s",
+			m.consoleHistory.Len(), formatBytes(m.consoleHistory.BodySize()), m.describeRetention()))
+		return m, nil
+	}
+	if strings.ToLower(args[0]) != HistoryArchive || len(args) > 2 {
+		m.statusBar.Info("Usage: :history | :history archive [age]")
+		return m, nil
+	}
+
+	var age time.Duration
+	if len(args) == 2 {
+		var err error
+		if age, err = time.ParseDuration(args[1]); err != nil || age < 0 {
+			m.statusBar.Info("Age must be a duration such as 30m or 24h")
+			return m, nil
+		}
+	}
+	archived, err := m.consoleHistory.ArchiveBefore(time.Now().Add(-age), m.historyArchiver())
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if len(archived) == 0 {
+		m.statusBar.Info("No console entries to archive")
+		return m, nil
+	}
+	m.saveHistory()
+	dir, _ := filepath.Rel(m.workspacePath, api.HistoryArchiveDir(m.workspacePath))
+	m.statusBar.Success("Archived", fmt.Sprintf("%d console entries to %s", len(archived), dir))
+	return m, nil
+}
+
+// describeRetention describes the limits of the console history
+func (m Model) describeRetention() string {
+	r := m.historyRetention
+	limits := []string{fmt.Sprintf("%d entries", r.MaxEntries)}
+	if r.MaxAge > 0 {
+		limits = append(limits, r.MaxAge.String())
+	}
+	if r.MaxBytes > 0 {
+		limits = append(limits, formatBytes(r.MaxBytes)+" of bodies")
+	}
+	description := strings.Join(limits, ", ")
+	if m.archiveHistory {
+		description += ", archiving older ones"
+	}
+	return description
+}
+
+// handleGrepCommand searches the response bodies of the console history and lists
+// the matching entries in the Console tab
+func (m Model) handleGrepCommand(args []string) (tea.Model, tea.Cmd) {
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		m.statusBar.Info("Usage: :grep <text> | :grep /regex/")
+		return m, nil
+	}
+	re, err := api.CompileHistorySearch(query)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	var matches []api.HistoryMatch
+	if m.consoleHistory != nil {
+		matches = m.consoleHistory.Search(re)
+	}
+	if len(matches) == 0 {
+		m.statusBar.Info(fmt.Sprintf("No response in the console history matches %s", query))
+		return m, nil
+	}
+	m.responsePanel.ShowConsoleSearch(query, matches)
+	m.activePanel = ResponsePanel
+	m.statusBar.Success("Found", fmt.Sprintf("%d responses matching %s", len(matches), query))
+	return m, nil
+}
+
+// handleConsoleCommand shows the filter of the Console tab, or narrows it by log level,
+// request or text; each subcommand replaces its part of the filter
+func (m Model) handleConsoleCommand(args []string) (tea.Model, tea.Cmd) {
+	filter := m.responsePanel.GetConsoleFilter()
+	if len(args) == 0 {
+		m.statusBar.Info("Console filter: " + filter.String())
+		return m, nil
+	}
+
+	value := strings.TrimSpace(strings.Join(args[1:], " "))
+	switch strings.ToLower(args[0]) {
+	case ConsoleLevel:
+		if value == "" {
+			filter.Levels = nil
+			break
+		}
+		levels, err := api.ParseConsoleLogLevels(value)
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		filter.Levels = levels
+	case ConsoleRequest:
+		filter.Request = value
+	case ConsoleSearch:
+		filter.Text = value
+	case ConsoleClear:
+		filter = api.ConsoleFilter{}
+	default:
+		m.statusBar.Info("Usage: :console [level <log,info,warn,error,debug> | request <text> | search <text> | clear]")
+		return m, nil
+	}
+
+	m.responsePanel.SetConsoleFilter(filter)
+	m.activePanel = ResponsePanel
+	if filter.IsZero() {
+		m.statusBar.Info("Console shows all entries")
+		return m, nil
+	}
+	shown := len(m.consoleHistory.Filter(filter))
+	m.statusBar.Success("Filtered console", fmt.Sprintf("%d entries · %s", shown, filter))
+	return m, nil
+}
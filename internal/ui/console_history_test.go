@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_ConsoleHistoryPersists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	send := &pendingSend{request: &api.Request{Method: api.POST, URL: "https://shop.example.com/orders"}}
+	m.startSend(send)
+	update(HTTPResponseMsg{Response: &api.Response{StatusCode: 201, Status: "201 Created", Body: []byte(`{"id": "ord_42"}`)}, SendID: send.id})
+	if m.consoleHistory.Len() != 1 {
+		t.Fatalf("the send should be logged, got %d entries", m.consoleHistory.Len())
+	}
+
+	// The next session starts with the entries of this one, and :grep searches them
+	m = NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	entries := m.consoleHistory.GetAll()
+	if len(entries) != 1 || entries[0].Request.URL != "https://shop.example.com/orders" || entries[0].GetStatusCode() != 201 {
+		t.Fatalf("the console history should be restored, got %+v", entries)
+	}
+	update(CommandExecuteMsg{Command: CmdGrep, Args: []string{"ord_42"}})
+	if !strings.Contains(m.statusBar.message, "1 responses") {
+		t.Errorf(":grep should find the response of the earlier session, got %q", m.statusBar.message)
+	}
+}
+
+func TestModel_ConsoleHistoryRetention(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	old := api.NewConsoleEntry(&api.Request{Method: api.GET, URL: "https://shop.example.com/old"}, &api.Response{StatusCode: 200}, nil, 0)
+	old.Timestamp = time.Now().Add(-48 * time.Hour)
+	recent := api.NewConsoleEntry(&api.Request{Method: api.GET, URL: "https://shop.example.com/recent"}, &api.Response{StatusCode: 200}, nil, 0)
+	if err := api.SaveHistory(api.HistoryFile(workspace), []api.ConsoleEntry{*old, *recent}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultGlobalConfig()
+	cfg.History = &config.HistoryConfig{MaxAge: 24 * time.Hour, Archive: true}
+
+	// The saved history is pruned when it is loaded, archiving the entries dropped
+	m := NewModel(cfg, config.DefaultWorkspaceConfig(), workspace)
+	if entries := m.consoleHistory.GetAll(); len(entries) != 1 || entries[0].ID != recent.ID {
+		t.Fatalf("the entries older than max_age should be dropped, got %+v", entries)
+	}
+	if saved, _ := api.LoadHistory(api.HistoryFile(workspace)); len(saved) != 1 || saved[0].ID != recent.ID {
+		t.Errorf("the history file should keep the recent entry only, got %+v", saved)
+	}
+	bundle := filepath.Join(api.HistoryArchiveDir(workspace), "history-"+old.Timestamp.Format("2006-01-02")+".jsonl.gz")
+	if archived, err := api.ReadHistoryArchive(bundle); err != nil || len(archived) != 1 || archived[0].ID != old.ID {
+		t.Errorf("the dropped entry should be archived, got %+v, %v", archived, err)
+	}
+
+	// :history archive empties the history file too
+	model, _ := m.Update(CommandExecuteMsg{Command: CmdHistory, Args: []string{HistoryArchive}})
+	m = model.(Model)
+	if saved, _ := api.LoadHistory(api.HistoryFile(workspace)); m.consoleHistory.Len() != 0 || len(saved) != 0 {
+		t.Errorf(":history archive should clear the saved history, got %d entries", len(saved))
+	}
+}
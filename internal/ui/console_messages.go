@@ -8,7 +8,10 @@ import (
 
 // ResendRequestMsg signals that a request should be resent
 type ResendRequestMsg struct {
-	Request *api.Request // Request to resend
+	Request    *api.Request           // Request to resend, as originally sent
+	Source     *api.CollectionRequest // Unresolved request the original was built from
+	Variables  *api.VariableSnapshot  // Variable values the original was sent with
+	CurrentEnv bool                   // Rebuild Source with the current environment instead of replaying Request
 }
 
 // CopyToClipboardMsg signals content should be copied to clipboard
@@ -17,6 +20,12 @@ type CopyToClipboardMsg struct {
 	Label   string // What was copied (for status message)
 }
 
+// ConsoleDiffMsg signals that two console entries should be diffed
+type ConsoleDiffMsg struct {
+	BaseID  string // Entry marked first, compared against
+	OtherID string // Entry marked second
+}
+
 // ConsoleStatusMsg displays a temporary status in the console
 type ConsoleStatusMsg struct {
 	Message  string
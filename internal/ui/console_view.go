@@ -19,6 +19,16 @@ type ConsoleView struct {
 	expandedEntry *string // ID of expanded entry (nil = list view)
 	width         int     // Available width
 	height        int     // Available height
+
+	// Response body search (:grep); empty search lists all entries
+	search  string
+	matches []api.HistoryMatch
+
+	// Entries listed without a search (:console)
+	filter api.ConsoleFilter
+
+	// Entry marked with d, diffed with the next one marked
+	diffBase string
 }
 
 // NewConsoleView creates a new console view
@@ -37,6 +47,11 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 	}
 
 	maxIdx := history.Len() - 1
+	if c.search != "" {
+		maxIdx = len(c.matches) - 1
+	} else if !c.filter.IsZero() {
+		maxIdx = max(0, len(history.Filter(c.filter))-1)
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -46,17 +61,15 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			case "esc", "h", "q":
 				c.expandedEntry = nil
 				return c, nil
-			case "R":
-				// Resend from expanded view
-				if entry, ok := history.GetByIndex(c.cursor); ok && entry.Request != nil {
+			case "R", "r":
+				// Resend from expanded view (r: with the current environment)
+				if entry, ok := c.selected(history); ok && entry.Request != nil && entry.Hook == "" {
 					c.expandedEntry = nil
-					return c, func() tea.Msg {
-						return ResendRequestMsg{Request: entry.Request}
-					}
+					return c, resendEntryCmd(entry, msg.String() == "r")
 				}
 			case "H":
 				// Copy headers
-				if entry, ok := history.GetByIndex(c.cursor); ok {
+				if entry, ok := c.selected(history); ok {
 					return c, func() tea.Msg {
 						return CopyToClipboardMsg{
 							Content: entry.CopyHeaders(),
@@ -66,7 +79,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 				}
 			case "B":
 				// Copy body
-				if entry, ok := history.GetByIndex(c.cursor); ok {
+				if entry, ok := c.selected(history); ok {
 					return c, func() tea.Msg {
 						return CopyToClipboardMsg{
 							Content: entry.CopyBody(),
@@ -76,7 +89,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 				}
 			case "E":
 				// Copy error
-				if entry, ok := history.GetByIndex(c.cursor); ok {
+				if entry, ok := c.selected(history); ok {
 					if entry.HasError() {
 						return c, func() tea.Msg {
 							return CopyToClipboardMsg{
@@ -94,7 +107,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 				}
 			case "A":
 				// Copy all
-				if entry, ok := history.GetByIndex(c.cursor); ok {
+				if entry, ok := c.selected(history); ok {
 					return c, func() tea.Msg {
 						return CopyToClipboardMsg{
 							Content: entry.CopyAll(),
@@ -108,6 +121,13 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 
 		// List view navigation
 		switch msg.String() {
+		case "esc":
+			// Leave the search results, then the filter
+			if c.search != "" {
+				c.ClearSearch()
+			} else if !c.filter.IsZero() {
+				c.SetFilter(api.ConsoleFilter{})
+			}
 		case "j", "down":
 			if c.cursor < maxIdx {
 				c.cursor++
@@ -123,19 +143,22 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			c.cursor = maxIdx
 		case "enter", "l":
 			// Expand selected entry
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				c.expandedEntry = &entry.ID
 			}
-		case "R":
-			// Resend selected request
-			if entry, ok := history.GetByIndex(c.cursor); ok && entry.Request != nil {
-				return c, func() tea.Msg {
-					return ResendRequestMsg{Request: entry.Request}
-				}
+		case "R", "r":
+			// Resend selected request with its original values (R) or the current environment (r)
+			if entry, ok := c.selected(history); ok && entry.Request != nil && entry.Hook == "" {
+				return c, resendEntryCmd(entry, msg.String() == "r")
+			}
+		case "d":
+			// Mark the entry to diff, then diff it with the next entry marked
+			if entry, ok := c.selected(history); ok {
+				return c.markDiff(entry)
 			}
 		case "U":
 			// Copy URL
-			if entry, ok := history.GetByIndex(c.cursor); ok && entry.Request != nil {
+			if entry, ok := c.selected(history); ok && entry.Request != nil {
 				return c, func() tea.Msg {
 					return CopyToClipboardMsg{
 						Content: entry.Request.URL,
@@ -145,7 +168,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "H":
 			// Copy headers
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				return c, func() tea.Msg {
 					return CopyToClipboardMsg{
 						Content: entry.CopyHeaders(),
@@ -155,7 +178,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "B":
 			// Copy body
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				return c, func() tea.Msg {
 					return CopyToClipboardMsg{
 						Content: entry.CopyBody(),
@@ -165,7 +188,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "E":
 			// Copy error
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				if entry.HasError() {
 					return c, func() tea.Msg {
 						return CopyToClipboardMsg{
@@ -183,7 +206,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "C":
 			// Copy cookies
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				cookies := entry.CopyCookies()
 				if cookies != "" {
 					return c, func() tea.Msg {
@@ -202,7 +225,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "I":
 			// Copy info
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				return c, func() tea.Msg {
 					return CopyToClipboardMsg{
 						Content: entry.CopyInfo(),
@@ -212,7 +235,7 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 			}
 		case "A":
 			// Copy all
-			if entry, ok := history.GetByIndex(c.cursor); ok {
+			if entry, ok := c.selected(history); ok {
 				return c, func() tea.Msg {
 					return CopyToClipboardMsg{
 						Content: entry.CopyAll(),
@@ -226,6 +249,45 @@ func (c ConsoleView) Update(msg tea.Msg, history *api.ConsoleHistory, cfg *confi
 	return c, nil
 }
 
+// markDiff marks entry as the base of a diff, or diffs it with the entry marked before;
+// marking the same entry again removes the mark
+func (c ConsoleView) markDiff(entry *api.ConsoleEntry) (ConsoleView, tea.Cmd) {
+	if entry.Response == nil {
+		return c, func() tea.Msg {
+			return ConsoleStatusMsg{Message: "No response to diff", Type: StatusInfo}
+		}
+	}
+	switch c.diffBase {
+	case "":
+		c.diffBase = entry.ID
+		return c, func() tea.Msg {
+			return ConsoleStatusMsg{Message: "Marked for diff, press d on another entry", Type: StatusInfo}
+		}
+	case entry.ID:
+		c.diffBase = ""
+		return c, func() tea.Msg {
+			return ConsoleStatusMsg{Message: "Diff mark removed", Type: StatusInfo}
+		}
+	}
+	base, other := c.diffBase, entry.ID
+	c.diffBase = ""
+	return c, func() tea.Msg {
+		return ConsoleDiffMsg{BaseID: base, OtherID: other}
+	}
+}
+
+// resendEntryCmd returns a command that resends a history entry
+func resendEntryCmd(entry *api.ConsoleEntry, currentEnv bool) tea.Cmd {
+	return func() tea.Msg {
+		return ResendRequestMsg{
+			Request:    entry.Request,
+			Source:     entry.Source,
+			Variables:  entry.Variables,
+			CurrentEnv: currentEnv,
+		}
+	}
+}
+
 // View renders the console content
 func (c ConsoleView) View(width, height int, history *api.ConsoleHistory, active bool) string {
 	c.width = width
@@ -245,10 +307,15 @@ func (c ConsoleView) View(width, height int, history *api.ConsoleHistory, active
 	return c.renderListView(width, height, history)
 }
 
-// renderListView renders the console list
+// renderListView renders the console list, each request followed by the output of
+// its scripts
 func (c *ConsoleView) renderListView(width, height int, history *api.ConsoleHistory) string {
+	if c.search != "" {
+		return c.renderSearchView(width, height, history)
+	}
+
 	var result strings.Builder
-	entries := history.GetReversed()
+	entries := history.Filter(c.filter)
 
 	// Column widths
 	const timeCol = 8     // "HH:MM:SS"
@@ -260,8 +327,21 @@ func (c *ConsoleView) renderListView(width, height int, history *api.ConsoleHist
 		urlWidth = 10
 	}
 
-	// Render header with consistent spacing
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	visibleRows := height - 2 // Account for header and separator
+	if !c.filter.IsZero() {
+		result.WriteString(headerStyle.Render(fmt.Sprintf("Filter %s · %d of %d entries", c.filter, len(entries), history.Len())))
+		result.WriteString(hintStyle.Render("  (esc: all entries)"))
+		result.WriteString("\n")
+		visibleRows--
+		if len(entries) == 0 {
+			result.WriteString(hintStyle.Render("No entries match the filter."))
+			return result.String()
+		}
+	}
+
+	// Render header with consistent spacing
 	durHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Teal)
 	sizeHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Peach)
 
@@ -274,36 +354,122 @@ func (c *ConsoleView) renderListView(width, height int, history *api.ConsoleHist
 	result.WriteString(strings.Repeat("─", width))
 	result.WriteString("\n")
 
-	// Calculate visible range
-	visibleRows := height - 2 // Account for header and separator
 	if visibleRows < 1 {
 		visibleRows = 1
 	}
 
+	// Each entry takes a line, and one per line of script output shown
+	logs := make([][]api.ConsoleLogEntry, len(entries))
+	for i := range entries {
+		logs[i] = c.filter.Logs(&entries[i])
+	}
+
 	// Adjust scroll offset to keep cursor visible
+	if c.cursor < c.scrollOffset {
+		c.scrollOffset = c.cursor
+	}
+	for c.scrollOffset < c.cursor {
+		lines := 0
+		for i := c.scrollOffset; i <= c.cursor; i++ {
+			lines += 1 + len(logs[i])
+		}
+		if lines-len(logs[c.cursor]) <= visibleRows {
+			break
+		}
+		c.scrollOffset++
+	}
+
+	// Render entries
+	formatter := NewScriptConsoleFormatter()
+	lines := 0
+	for i := c.scrollOffset; i < len(entries) && lines < visibleRows; i++ {
+		result.WriteString(c.renderEntryRow(&entries[i], width, i == c.cursor))
+		result.WriteString("\n")
+		lines++
+		for _, log := range logs[i] {
+			if lines >= visibleRows {
+				break
+			}
+			result.WriteString(formatter.FormatEntry(log, width))
+			result.WriteString("\n")
+			lines++
+		}
+	}
+
+	return result.String()
+}
+
+// renderSearchView renders the entries matching the search, each with the text
+// around its first match
+func (c *ConsoleView) renderSearchView(width, height int, history *api.ConsoleHistory) string {
+	var result strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	result.WriteString(headerStyle.Render(fmt.Sprintf("Search %q · %d responses", c.search, len(c.matches))))
+	result.WriteString(hintStyle.Render("  (enter: open · esc: all entries)"))
+	result.WriteString("\n")
+	result.WriteString(strings.Repeat("─", width))
+	result.WriteString("\n")
+
+	// Each match takes two lines: the entry and its snippet
+	visibleRows := (height - 2) / 2
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
 	if c.cursor >= c.scrollOffset+visibleRows {
 		c.scrollOffset = c.cursor - visibleRows + 1
 	}
 	if c.cursor < c.scrollOffset {
 		c.scrollOffset = c.cursor
 	}
+	endIdx := min(c.scrollOffset+visibleRows, len(c.matches))
 
-	endIdx := c.scrollOffset + visibleRows
-	if endIdx > len(entries) {
-		endIdx = len(entries)
-	}
-
-	// Render entries
+	snippetStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	matchStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Base).Background(styles.Yellow)
+	countStyle := lipgloss.NewStyle().Foreground(styles.Peach)
 	for i := c.scrollOffset; i < endIdx; i++ {
-		entry := entries[i]
-		row := c.renderEntryRow(&entry, width, i == c.cursor)
-		result.WriteString(row)
+		match := c.matches[i]
+		entry, ok := history.Get(match.ID)
+		if !ok {
+			continue
+		}
+		result.WriteString(c.renderEntryRow(entry, width, i == c.cursor))
+		result.WriteString("\n")
+
+		count := ""
+		if match.Count > 1 {
+			count = fmt.Sprintf(" (+%d)", match.Count-1)
+		}
+		avail := width - 4 - lipgloss.Width(match.Match) - lipgloss.Width(count)
+		before, after := match.Before, match.After
+		if avail < lipgloss.Width(before)+lipgloss.Width(after) {
+			before = truncateLeft(before, avail/2)
+			after = truncateURL(after, max(4, avail-lipgloss.Width(before)))
+		}
+		result.WriteString("    ")
+		result.WriteString(snippetStyle.Render(before))
+		result.WriteString(matchStyle.Render(match.Match))
+		result.WriteString(snippetStyle.Render(after))
+		result.WriteString(countStyle.Render(count))
 		result.WriteString("\n")
 	}
 
 	return result.String()
 }
 
+// truncateLeft keeps the last maxWidth characters of text, starting with "..."
+func truncateLeft(text string, maxWidth int) string {
+	runes := []rune(text)
+	if len(runes) <= maxWidth {
+		return text
+	}
+	if maxWidth <= 3 {
+		return "..."
+	}
+	return "..." + string(runes[len(runes)-maxWidth+3:])
+}
+
 // renderEntryRow renders a single console entry row
 func (c *ConsoleView) renderEntryRow(entry *api.ConsoleEntry, width int, selected bool) string {
 	// Get status code and colors - same badge style as StatusBadge and Collections
@@ -315,6 +481,11 @@ func (c *ConsoleView) renderEntryRow(entry *api.ConsoleEntry, width int, selecte
 		statusStr = "Err"
 		statusBg = styles.Red
 		statusFg = styles.Base
+	} else if entry.Hook != "" {
+		// Activation hooks have no status code
+		statusStr = "OK"
+		statusBg = styles.Status2xxBg
+		statusFg = styles.Status2xxFg
 	} else {
 		statusStr = fmt.Sprintf("%d", statusCode)
 		switch {
@@ -337,8 +508,8 @@ func (c *ConsoleView) renderEntryRow(entry *api.ConsoleEntry, width int, selecte
 	}
 
 	// Method badge with same style as Collections panel
-	methodStr := string(entry.Request.Method)
-	methodBg, methodFg := c.getMethodColors(methodStr)
+	methodStr := styles.MethodLabel(string(entry.Request.Method))
+	methodBg, methodFg := styles.MethodColors(string(entry.Request.Method))
 
 	// Column widths (same as header)
 	const timeCol = 8
@@ -359,6 +530,10 @@ func (c *ConsoleView) renderEntryRow(entry *api.ConsoleEntry, width int, selecte
 	// Time in gray (no icon)
 	timeStr := entry.FormatTimestamp()
 	timeStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	if entry.ID == c.diffBase {
+		// Marked as the base of a diff
+		timeStyle = timeStyle.Foreground(styles.Yellow).Bold(true)
+	}
 	timeText := timeStyle.Render(timeStr)
 
 	// Duration and size with icons and colors
@@ -425,7 +600,7 @@ func (c *ConsoleView) renderEntryRow(entry *api.ConsoleEntry, width int, selecte
 
 // renderExpandedView renders the expanded entry details
 func (c *ConsoleView) renderExpandedView(width, height int, history *api.ConsoleHistory) string {
-	entry, ok := history.GetByIndex(c.cursor)
+	entry, ok := c.selected(history)
 	if !ok {
 		return "Entry not found"
 	}
@@ -440,7 +615,7 @@ func (c *ConsoleView) renderExpandedView(width, height int, history *api.Console
 
 	if entry.Request != nil {
 		// Method badge with same style as Collections panel
-		methodBg, methodFg := c.getMethodColors(string(entry.Request.Method))
+		methodBg, methodFg := styles.MethodColors(string(entry.Request.Method))
 		methodStyle := lipgloss.NewStyle().
 			Background(methodBg).
 			Foreground(methodFg).
@@ -448,7 +623,13 @@ func (c *ConsoleView) renderExpandedView(width, height int, history *api.Console
 		result.WriteString(methodStyle.Render(string(entry.Request.Method)))
 		result.WriteString(" ")
 		result.WriteString(entry.Request.URL)
-		result.WriteString("\n\n")
+		result.WriteString("\n")
+		if sentWith := entry.SentWith(); sentWith != "" {
+			sentWithStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+			result.WriteString(sentWithStyle.Render("Sent with " + sentWith))
+			result.WriteString("\n")
+		}
+		result.WriteString("\n")
 
 		if len(entry.Request.Headers) > 0 {
 			headerLabelStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
@@ -464,7 +645,7 @@ func (c *ConsoleView) renderExpandedView(width, height int, history *api.Console
 			headerLabelStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
 			result.WriteString(headerLabelStyle.Render("Body:"))
 			result.WriteString("\n")
-			result.WriteString(fmt.Sprintf("  %v\n", entry.Request.Body))
+			result.WriteString(fmt.Sprintf("  %s\n", entry.Request.BodyText()))
 		}
 	}
 
@@ -514,15 +695,20 @@ func (c *ConsoleView) renderExpandedView(width, height int, history *api.Console
 			result.WriteString("\n")
 		}
 
-		if entry.Response.Body != "" {
+		if len(entry.Response.Body) > 0 {
 			headerLabelStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
 			result.WriteString(headerLabelStyle.Render("Body:"))
 			result.WriteString("\n")
-			body := entry.Response.Body
-			// Truncate body preview
-			maxBodyLen := 500
-			if len(body) > maxBodyLen {
-				body = body[:maxBodyLen] + "..."
+			var body string
+			if entry.Response.IsBinary() {
+				body = api.BinarySummary(entry.Response.ContentType(), entry.Response.Body)
+			} else {
+				// Truncate body preview
+				preview, truncated := api.TruncateText(entry.Response.Body, 500)
+				body = string(preview)
+				if truncated {
+					body += "..."
+				}
 			}
 			result.WriteString(fmt.Sprintf("  %s\n", body))
 		}
@@ -536,37 +722,64 @@ func (c *ConsoleView) renderExpandedView(width, height int, history *api.Console
 	return result.String()
 }
 
-// getMethodColors returns the background and foreground colors for an HTTP method
-func (c *ConsoleView) getMethodColors(method string) (lipgloss.Color, lipgloss.Color) {
-	switch method {
-	case "GET":
-		return styles.MethodGetBg, styles.MethodGetFg
-	case "POST":
-		return styles.MethodPostBg, styles.MethodPostFg
-	case "PUT":
-		return styles.MethodPutBg, styles.MethodPutFg
-	case "PATCH":
-		return styles.MethodPatchBg, styles.MethodPatchFg
-	case "DELETE":
-		return styles.MethodDeleteBg, styles.MethodDeleteFg
-	case "HEAD":
-		return styles.MethodHeadBg, styles.MethodHeadFg
-	case "OPTIONS":
-		return styles.MethodOptionsBg, styles.MethodOptionsFg
-	default:
-		return styles.Surface1, styles.Text
-	}
-}
-
 // GetSelectedEntry returns the currently selected entry
 func (c *ConsoleView) GetSelectedEntry(history *api.ConsoleHistory) *api.ConsoleEntry {
 	if history == nil || history.IsEmpty() {
 		return nil
 	}
-	entry, _ := history.GetByIndex(c.cursor)
+	entry, _ := c.selected(history)
 	return entry
 }
 
+// selected returns the entry under the cursor, among the search results when searching
+// and the entries shown with the filter otherwise
+func (c *ConsoleView) selected(history *api.ConsoleHistory) (*api.ConsoleEntry, bool) {
+	if c.search == "" && c.filter.IsZero() {
+		return history.GetByIndex(c.cursor)
+	}
+	if c.search == "" {
+		entries := history.Filter(c.filter)
+		if c.cursor < 0 || c.cursor >= len(entries) {
+			return nil, false
+		}
+		return &entries[c.cursor], true
+	}
+	if c.cursor < 0 || c.cursor >= len(c.matches) {
+		return nil, false
+	}
+	return history.Get(c.matches[c.cursor].ID)
+}
+
+// SetSearch lists the entries matching a response body search
+func (c *ConsoleView) SetSearch(query string, matches []api.HistoryMatch) {
+	c.Reset()
+	c.search = query
+	c.matches = matches
+}
+
+// ClearSearch goes back to the list of all entries
+func (c *ConsoleView) ClearSearch() {
+	c.Reset()
+	c.search = ""
+	c.matches = nil
+}
+
+// SetFilter lists the entries shown with filter, all of them for a zero filter
+func (c *ConsoleView) SetFilter(filter api.ConsoleFilter) {
+	c.Reset()
+	c.filter = filter
+}
+
+// Filter returns the filter of the listed entries
+func (c *ConsoleView) Filter() api.ConsoleFilter {
+	return c.filter
+}
+
+// IsSearching returns true if the list shows search results
+func (c *ConsoleView) IsSearching() bool {
+	return c.search != ""
+}
+
 // IsExpanded returns true if viewing entry details
 func (c *ConsoleView) IsExpanded() bool {
 	return c.expandedEntry != nil
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// EnvironmentDotenvMsg asks for the .env file to import into an environment of the
+// Environments panel, or to export it to
+type EnvironmentDotenvMsg struct {
+	Environment string // Name of the environment; empty imports into a new one
+	Export      bool
+}
+
+// ImportDotenv sets the variables of a .env file in the environment name, created when
+// there is none by that name. Returns the number of variables added and updated.
+func (e *EnvironmentsView) ImportDotenv(vars []api.DotenvVariable, name string) (added, updated int, created bool, err error) {
+	var env *api.EnvironmentFile
+	for _, candidate := range e.environments {
+		if candidate.Name == name {
+			env = candidate
+		}
+	}
+	if env == nil {
+		env = &api.EnvironmentFile{Name: name, Variables: make(map[string]*api.EnvironmentVariable)}
+		created = true
+	}
+	added, updated = env.ImportDotenv(vars)
+	if err := e.saveEnvironmentFrom(env, api.EnvSourceImport); err != nil {
+		return 0, 0, false, err
+	}
+	if created {
+		active := e.activeEnvName
+		e.loadEnvironments()
+		e.SetActiveEnvironmentName(active)
+	} else {
+		e.buildTree()
+		e.refresh()
+	}
+	return added, updated, created, nil
+}
+
+// showDotenvDialog asks for the .env file of msg
+func (m *Model) showDotenvDialog(msg EnvironmentDotenvMsg) {
+	if msg.Export {
+		m.dialog.ShowInput("Export .env", "Write the active variables of "+msg.Environment+" to:", ".env", "export_dotenv", msg.Environment)
+		return
+	}
+	target := "a new environment named after the file"
+	if msg.Environment != "" {
+		target = msg.Environment
+	}
+	m.dialog.ShowInput("Import .env", "Import the variables of a .env file into "+target+":", ".env", "import_dotenv", msg.Environment)
+}
+
+// importDotenv imports the .env file at path into the environment name, or one named
+// after the file (see api.DotenvEnvironmentName)
+func (m Model) importDotenv(path, name string) (tea.Model, tea.Cmd) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to read .env file: %w", err))
+		return m, nil
+	}
+	vars, err := api.ParseDotenv(data)
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("%s: %w", path, err))
+		return m, nil
+	}
+	if name == "" {
+		name = api.DotenvEnvironmentName(path)
+	}
+	added, updated, created, err := m.leftPanel.GetEnvironments().ImportDotenv(vars, name)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if created {
+		m.statusBar.Success("Imported", fmt.Sprintf("%s into new environment %s (%d variables)", path, name, added))
+	} else {
+		m.statusBar.Success("Imported", fmt.Sprintf("%s into %s (%d added, %d updated)", path, name, added, updated))
+	}
+	return m, nil
+}
+
+// exportDotenv writes the active variables of the environment name, or of the active
+// environment, to the .env file at path
+func (m Model) exportDotenv(path, name string) (tea.Model, tea.Cmd) {
+	envs := m.leftPanel.GetEnvironments()
+	env := envs.GetActiveEnvironment()
+	if name != "" {
+		env = nil
+		for _, candidate := range envs.GetEnvironments() {
+			if candidate.Name == name {
+				env = candidate
+			}
+		}
+	}
+	if env == nil {
+		if name == "" {
+			m.statusBar.Info("No active environment to export")
+		} else {
+			m.statusBar.Error(fmt.Errorf("environment not found: %s", name))
+		}
+		return m, nil
+	}
+	if err := os.WriteFile(path, api.FormatDotenv(env), 0600); err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to write .env file: %w", err))
+		return m, nil
+	}
+	m.statusBar.Success("Exported", env.Name+" to "+path)
+	return m, nil
+}
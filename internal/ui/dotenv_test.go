@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_Dotenv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	dev := &api.EnvironmentFile{Name: "dev", Variables: map[string]*api.EnvironmentVariable{
+		"base_url": {Value: "http://localhost:3000", Active: true},
+	}}
+	if err := api.SaveEnvironment(dev, filepath.Join(workspace, ".lazycurl", "environments", "dev.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".env.staging", []byte("BASE_URL=https://staging.example.com\nexport API_TOKEN=\"t0k3n\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	update := func(msg tea.Msg) tea.Cmd {
+		model, cmd := m.Update(msg)
+		m = model.(Model)
+		return cmd
+	}
+	envs := m.leftPanel.GetEnvironments()
+
+	// :import dotenv creates an environment named after the file
+	update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportDotenv, ".env.staging"}})
+	var staging *api.EnvironmentFile
+	for _, env := range envs.GetEnvironments() {
+		if env.Name == "staging" {
+			staging = env
+		}
+	}
+	if staging == nil || staging.Variables["API_TOKEN"].Value != "t0k3n" || !staging.Variables["API_TOKEN"].Secret {
+		t.Fatalf("the .env file should be imported as a new environment, got %q", m.statusBar.message)
+	}
+	if envs.GetActiveEnvironmentName() != "dev" {
+		t.Errorf("the import should keep the active environment, got %q", envs.GetActiveEnvironmentName())
+	}
+
+	// I in the Environments panel imports into the environment under the cursor
+	if err := os.WriteFile(".env", []byte("base_url=http://localhost:8080\nuser=ada\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.activePanel = CollectionsPanel
+	m.leftPanel.SetActiveTab(EnvironmentsTab)
+	cmd := update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	if cmd == nil {
+		t.Fatal("I should ask for the .env file")
+	}
+	update(cmd())
+	if !m.dialog.IsVisible() {
+		t.Fatal("I should ask for the .env file")
+	}
+	m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	update(cmd())
+	if base := envs.GetActiveEnvironmentVariables()["base_url"]; base != "http://localhost:8080" || !strings.Contains(m.statusBar.message, "1 added, 1 updated") {
+		t.Errorf("the .env file should update dev, got %q (%q)", base, m.statusBar.message)
+	}
+
+	// :export dotenv writes the active environment
+	update(CommandExecuteMsg{Command: CmdExport, Args: []string{ExportDotenv, "dev.env"}})
+	data, err := os.ReadFile("dev.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "base_url=http://localhost:8080\nuser=ada\n") {
+		t.Errorf("the export should hold the variables of dev, got\n%s", data)
+	}
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// envHistoryWidth is the width of the variable history box
+const envHistoryWidth = 72
+
+// snapshotEnvironment remembers the saved values of env, to find the variables
+// changed by the next save
+func (e *EnvironmentsView) snapshotEnvironment(env *api.EnvironmentFile) {
+	if e.savedValues == nil {
+		e.savedValues = make(map[string]map[string]string)
+	}
+	values := make(map[string]string, len(env.Variables))
+	for name, v := range env.Variables {
+		if !v.Secret && !v.Keychain {
+			values[name] = v.Value
+		}
+	}
+	e.savedValues[env.Name] = values
+}
+
+// recordHistory adds the variables of env changed since it was last saved to the
+// variable history. The value a variable had before its first recorded change is
+// kept too, so that it can be restored.
+func (e *EnvironmentsView) recordHistory(env *api.EnvironmentFile, source string) {
+	if e.history == nil {
+		return
+	}
+	saved := e.savedValues[env.Name]
+	now := time.Now()
+	changed := false
+	for name, v := range env.Variables {
+		if v.Secret || v.Keychain {
+			continue
+		}
+		previous, known := saved[name]
+		if known && previous == v.Value {
+			continue
+		}
+		if known && len(e.history.Values(env.Name, name)) == 0 {
+			e.history.Record(env.Name, name, api.EnvValue{Value: previous})
+		}
+		if e.history.Record(env.Name, name, api.EnvValue{Value: v.Value, Time: now, Source: source}) {
+			changed = true
+		}
+	}
+	e.snapshotEnvironment(env)
+	if changed {
+		_ = e.history.Save() // Error intentionally ignored: the history is a convenience
+	}
+}
+
+// renameEnvironmentHistory keeps the variable history of a renamed environment
+func (e *EnvironmentsView) renameEnvironmentHistory(oldName, newName string) {
+	if values, ok := e.savedValues[oldName]; ok {
+		delete(e.savedValues, oldName)
+		e.savedValues[newName] = values
+	}
+	if e.history != nil {
+		e.history.RenameEnvironment(oldName, newName)
+		_ = e.history.Save() // Error intentionally ignored: the history is a convenience
+	}
+}
+
+// renameVariableHistory keeps the history of a renamed variable
+func (e *EnvironmentsView) renameVariableHistory(env, oldName, newName string) {
+	if values, ok := e.savedValues[env][oldName]; ok {
+		delete(e.savedValues[env], oldName)
+		e.savedValues[env][newName] = values
+	}
+	if e.history != nil {
+		e.history.RenameVariable(env, oldName, newName)
+		_ = e.history.Save() // Error intentionally ignored: the history is a convenience
+	}
+}
+
+// showHistory opens the history of the variable of node
+func (e *EnvironmentsView) showHistory(node *EnvTreeNode) {
+	e.historyNode = node
+	e.historyCursor = 0
+}
+
+// historyValues returns the recorded values of the variable of the history box,
+// newest first
+func (e *EnvironmentsView) historyValues() []api.EnvValue {
+	if e.history == nil || e.historyNode == nil {
+		return nil
+	}
+	values := e.history.Values(e.historyNode.EnvFile.Name, e.historyNode.Name)
+	newest := make([]api.EnvValue, len(values))
+	for i, v := range values {
+		newest[len(values)-1-i] = v
+	}
+	return newest
+}
+
+// updateHistory handles the keys of the history box: j/k select a value, enter
+// restores it and esc closes the box
+func (e *EnvironmentsView) updateHistory(msg tea.KeyMsg) {
+	values := e.historyValues()
+	switch msg.String() {
+	case "j", "down":
+		if e.historyCursor < len(values)-1 {
+			e.historyCursor++
+		}
+	case "k", "up":
+		if e.historyCursor > 0 {
+			e.historyCursor--
+		}
+	case "enter":
+		if e.historyCursor < len(values) {
+			e.rollbackVariable(e.historyNode, values[e.historyCursor].Value)
+		}
+		e.historyNode = nil
+	case "esc", "q":
+		e.historyNode = nil
+	}
+}
+
+// rollbackVariable restores a previous value of the variable of node
+func (e *EnvironmentsView) rollbackVariable(node *EnvTreeNode, value string) {
+	env := e.getEnvForNode(node)
+	if env == nil || node.Variable == nil || node.Variable.Value == value {
+		return
+	}
+	node.Variable.Value = value
+	_ = e.saveEnvironmentFrom(env, api.EnvSourceRollback) // Error intentionally ignored for UI responsiveness
+}
+
+// renderHistory renders the history box of a variable
+func (e *EnvironmentsView) renderHistory(screenWidth int) string {
+	width := envHistoryWidth
+	if width > screenWidth-4 {
+		width = screenWidth - 4
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Lavender).
+		Width(width - 4).
+		Align(lipgloss.Center)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	sourceStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("History: " + e.historyNode.EnvFile.Name + "/" + e.historyNode.Name))
+	content.WriteString("\n\n")
+
+	values := e.historyValues()
+	if len(values) == 0 {
+		content.WriteString(hintStyle.Render("No recorded changes"))
+		content.WriteString("\n")
+	}
+	for i, v := range values {
+		prefix := "  "
+		if i == e.historyCursor {
+			prefix = "> "
+		}
+		when := "before"
+		if !v.Time.IsZero() {
+			when = v.Time.Local().Format("2006-01-02 15:04:05")
+		}
+		source := v.Source
+		if source == "" {
+			source = "-"
+		}
+		marker := " "
+		if v.Value == e.historyNode.Variable.Value {
+			marker = "●"
+		}
+		head := fmt.Sprintf("%s%s %-19s  %-20s ", prefix, marker, when, source)
+		value := truncateHistoryValue(v.Value, width-4-lipgloss.Width(head))
+		line := prefix + marker + " " + timeStyle.Render(fmt.Sprintf("%-19s", when)) + "  " +
+			sourceStyle.Render(fmt.Sprintf("%-20s", source)) + " " + valueStyle.Render(value)
+		if i == e.historyCursor {
+			line = lipgloss.NewStyle().Background(styles.Surface1).Width(width - 4).Render(line)
+		}
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(hintStyle.Render("enter: restore · esc: close · ● current value"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender).
+		Padding(1, 2).
+		Width(width)
+	return modalStyle.Render(content.String())
+}
+
+// truncateHistoryValue shortens a value to fit width on a single line
+func truncateHistoryValue(value string, width int) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	if width < 2 {
+		return ""
+	}
+	runes := []rune(value)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return value
+}
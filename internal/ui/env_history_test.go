@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestEnvironmentsView_VariableHistory(t *testing.T) {
+	workspace := t.TempDir()
+	envPath := filepath.Join(workspace, ".lazycurl", "environments", "dev.json")
+	env := &api.EnvironmentFile{Name: "dev", Variables: map[string]*api.EnvironmentVariable{
+		"token":    {Value: "original", Active: true},
+		"password": {Value: "hunter2", Secret: true, Active: true},
+	}}
+	if err := api.SaveEnvironment(env, envPath); err != nil {
+		t.Fatal(err)
+	}
+
+	view := NewEnvironmentsView(workspace)
+	active := view.GetActiveEnvironment()
+	active.SetVariable("token", "from-script")
+	active.SetVariable("password", "changed")
+	if err := view.SaveActiveEnvironment(api.EnvSourcePostScript); err != nil {
+		t.Fatalf("SaveActiveEnvironment() error = %v", err)
+	}
+
+	values := view.history.Values("dev", "token")
+	if len(values) != 2 || values[0].Value != "original" || values[1].Value != "from-script" || values[1].Source != api.EnvSourcePostScript {
+		t.Fatalf("token history = %+v, want the original and the script value", values)
+	}
+	if got := view.history.Values("dev", "password"); got != nil {
+		t.Errorf("secret variables should not be recorded, got %+v", got)
+	}
+
+	// Restore the original value from the history box
+	var node *EnvTreeNode
+	for _, n := range view.tree[0].Children {
+		if n.Name == "token" {
+			node = n
+		}
+	}
+	view.showHistory(node)
+	if !view.HasActiveModal() {
+		t.Fatal("the history box should take the keys")
+	}
+	view.updateHistory(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	view.updateHistory(tea.KeyMsg{Type: tea.KeyEnter})
+	if view.HasActiveModal() {
+		t.Error("enter should close the history box")
+	}
+
+	reloaded, err := api.LoadEnvironment(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Variables["token"].Value; got != "original" {
+		t.Errorf("token = %q after rollback, want original", got)
+	}
+	history, _ := api.LoadEnvHistory(workspace)
+	values = history.Values("dev", "token")
+	if last := values[len(values)-1]; last.Value != "original" || last.Source != api.EnvSourceRollback {
+		t.Errorf("latest history value = %+v, want the rollback", last)
+	}
+}
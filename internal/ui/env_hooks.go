@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+)
+
+// EnvironmentHookMsg is sent when the activation hook of an environment has run
+type EnvironmentHookMsg struct {
+	Environment string
+	Description string
+	Process     *api.HookProcess // Command still running in the background, nil otherwise
+	Logs        []api.ConsoleLogEntry
+	Changes     []api.EnvChange // Variables set by the script
+	Error       error
+	Duration    time.Duration
+}
+
+// RunEnvironmentHookCmd creates a command running the activation hook of env: its
+// command in dir, then its script
+func RunEnvironmentHookCmd(executor api.ScriptExecutor, env *api.EnvironmentFile, dir string) tea.Cmd {
+	hook := *env.OnActivate
+	return func() tea.Msg {
+		start := time.Now()
+		msg := EnvironmentHookMsg{Environment: env.Name, Description: hookDescription(env.Name, hook)}
+
+		if hook.Command != "" {
+			process, err := api.StartHookCommand(hook.Command, dir, []string{"LAZYCURL_ENVIRONMENT=" + env.Name})
+			if err != nil {
+				msg.Error = err
+				msg.Duration = time.Since(start)
+				return msg
+			}
+			logs, running, err := process.Wait(api.HookWait)
+			msg.Logs = logs
+			if err != nil {
+				msg.Error = fmt.Errorf("hook command: %w", err)
+				msg.Duration = time.Since(start)
+				return msg
+			}
+			if running {
+				msg.Process = process
+			}
+		}
+
+		if hook.Script != "" {
+			result, err := executor.ExecutePreRequest(hook.Script, nil, api.EnvironmentFromFile(env))
+			if result != nil {
+				msg.Logs = append(msg.Logs, result.ConsoleOutput...)
+				msg.Changes = result.EnvChanges
+			}
+			if err != nil {
+				msg.Error = fmt.Errorf("hook script: %w", err)
+			}
+		}
+		msg.Duration = time.Since(start)
+		return msg
+	}
+}
+
+// hookDescription describes an activation hook in the Console tab
+func hookDescription(environment string, hook api.EnvironmentHook) string {
+	switch {
+	case hook.Command != "" && hook.Script != "":
+		return environment + " activated: " + hook.Command + " + script"
+	case hook.Command != "":
+		return environment + " activated: " + hook.Command
+	default:
+		return environment + " activated: script"
+	}
+}
+
+// environmentActivated runs the activation hook of the active environment when it is
+// not previous, the environment active before
+func (m *Model) environmentActivated(previous string) tea.Cmd {
+	if m.leftPanel.GetEnvironments().GetActiveEnvironmentName() == previous {
+		return nil
+	}
+	return m.runActivationHook()
+}
+
+// runActivationHook stops the command left running by the hook of the environment
+// active before, and runs the activation hook of the active environment
+func (m *Model) runActivationHook() tea.Cmd {
+	m.stopHookProcess()
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	if env == nil || env.OnActivate.IsZero() {
+		return nil
+	}
+	m.statusBar.Info("Running the activation hook of " + env.Name + "...")
+	return RunEnvironmentHookCmd(m.scriptExecutor, env.Clone(), m.workspacePath)
+}
+
+// stopHookProcess stops the command left running by an activation hook, if any
+func (m *Model) stopHookProcess() {
+	if m.hookProcess != nil {
+		m.hookProcess.Stop()
+		m.hookProcess = nil
+	}
+}
+
+// handleEnvironmentHook logs an activation hook run to the Console tab and keeps the
+// variables its script set, if its environment is still active
+func (m Model) handleEnvironmentHook(msg EnvironmentHookMsg) (tea.Model, tea.Cmd) {
+	envs := m.leftPanel.GetEnvironments()
+	active := envs.GetActiveEnvironmentName() == msg.Environment
+
+	logs := msg.Logs
+	if msg.Process != nil {
+		if active {
+			m.hookProcess = msg.Process
+			logs = append(logs, api.ConsoleLogEntry{
+				Level:     api.LogLevelInfo,
+				Message:   "Command still running in the background, until another environment is activated or LazyCurl quits",
+				Timestamp: time.Now(),
+			})
+		} else {
+			msg.Process.Stop()
+		}
+	}
+	if msg.Error != nil {
+		logs = append(logs, api.ConsoleLogEntry{Level: api.LogLevelError, Message: msg.Error.Error(), Timestamp: time.Now()})
+	}
+	m.consoleHistory.Add(*api.NewHookConsoleEntry(msg.Environment, msg.Description, logs, msg.Error, msg.Duration))
+	m.pruneHistory()
+	m.saveHistory()
+
+	if active && len(msg.Changes) > 0 {
+		runner.ApplyEnvChanges(envs.GetActiveEnvironment(), msg.Changes)
+		if err := envs.SaveActiveEnvironment(api.EnvSourceHook); err != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
+			return m, nil
+		}
+	}
+
+	if msg.Error != nil {
+		m.statusBar.Error(fmt.Errorf("activation hook of %s: %w", msg.Environment, msg.Error))
+	} else {
+		m.statusBar.Success("Activated", msg.Environment)
+	}
+	return m, nil
+}
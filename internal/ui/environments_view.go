@@ -67,6 +67,12 @@ type EnvironmentsView struct {
 	editModal   *components.Modal
 	renameModal *components.Modal
 	pendingNode *EnvTreeNode // Node being acted upon
+
+	// Variable history
+	history       *api.EnvHistory
+	savedValues   map[string]map[string]string // Values of each environment when last saved
+	historyNode   *EnvTreeNode                 // Variable whose history is shown
+	historyCursor int
 }
 
 // NewEnvironmentsView creates a new environments view
@@ -99,6 +105,7 @@ func NewEnvironmentsView(workspacePath string) *EnvironmentsView {
 	})
 	ev.renameModal = components.NewInputModal("Rename", "New Name", "", "rename")
 
+	ev.history, _ = api.LoadEnvHistory(workspacePath) // A broken history file starts a new history
 	ev.loadEnvironments()
 
 	return ev
@@ -115,6 +122,10 @@ func (e *EnvironmentsView) loadEnvironments() {
 	}
 
 	e.environments = envs
+	e.savedValues = nil
+	for _, env := range envs {
+		e.snapshotEnvironment(env)
+	}
 	e.buildTree()
 	e.refresh()
 
@@ -262,12 +273,22 @@ func (e *EnvironmentsView) envNameExists(name string) bool {
 	return false
 }
 
-// saveEnvironment saves an environment to disk
+// saveEnvironment saves an environment edited in the panel to disk
 func (e *EnvironmentsView) saveEnvironment(env *api.EnvironmentFile) error {
+	return e.saveEnvironmentFrom(env, api.EnvSourceEdit)
+}
+
+// saveEnvironmentFrom saves an environment to disk, recording the variables changed
+// by source in the variable history
+func (e *EnvironmentsView) saveEnvironmentFrom(env *api.EnvironmentFile, source string) error {
 	if env.FilePath == "" {
 		env.FilePath = filepath.Join(e.environmentsPath, strings.ToLower(strings.ReplaceAll(env.Name, " ", "-"))+".json")
 	}
-	return api.SaveEnvironment(env, env.FilePath)
+	if err := api.SaveEnvironment(env, env.FilePath); err != nil {
+		return err
+	}
+	e.recordHistory(env, source)
+	return nil
 }
 
 // hasActiveModal returns true if any modal is visible
@@ -276,7 +297,8 @@ func (e *EnvironmentsView) hasActiveModal() bool {
 		e.newVarModal.IsVisible() ||
 		e.newEnvModal.IsVisible() ||
 		e.editModal.IsVisible() ||
-		e.renameModal.IsVisible()
+		e.renameModal.IsVisible() ||
+		e.historyNode != nil
 }
 
 // IsSearching returns true if search is active
@@ -408,6 +430,11 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 		}
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && e.historyNode != nil {
+		e.updateHistory(keyMsg)
+		return e, nil
+	}
+
 	switch msg := msg.(type) {
 	case components.ModalCloseMsg:
 		return e.handleModalClose(msg)
@@ -465,6 +492,16 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 				}
 			}
 
+		case "t":
+			// Cycle the type of a variable among those its value is valid for
+			if node := e.getCurrentNode(); node != nil && node.Type == VarNode {
+				env := e.getEnvForNode(node)
+				if env != nil {
+					node.Variable.Type = nextVariableType(node.Variable)
+					_ = e.saveEnvironment(env) // Error intentionally ignored for UI responsiveness
+				}
+			}
+
 		case "a", "A":
 			// Toggle active for variable, or select env
 			if node := e.getCurrentNode(); node != nil {
@@ -523,6 +560,12 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 				e.editModal.Show()
 			}
 
+		case "H":
+			// Show the previous values of a variable
+			if node := e.getCurrentNode(); node != nil && node.Type == VarNode {
+				e.showHistory(node)
+			}
+
 		case "R":
 			// Rename
 			if node := e.getCurrentNode(); node != nil {
@@ -589,8 +632,9 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 							Value:  node.Variable.Value,
 							Secret: node.Variable.Secret,
 							Active: node.Variable.Active,
+							Type:   node.Variable.Type,
 						}
-						if err := api.SaveEnvironment(targetEnv, targetEnv.FilePath); err == nil {
+						if err := e.saveEnvironment(targetEnv); err == nil {
 							e.loadEnvironments()
 						}
 					}
@@ -611,6 +655,7 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 						Value:  node.Variable.Value,
 						Secret: node.Variable.Secret,
 						Active: node.Variable.Active,
+						Type:   node.Variable.Type,
 					}
 				}
 			}
@@ -640,6 +685,7 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 							Value:  e.clipboard.VarData.Value,
 							Secret: e.clipboard.VarData.Secret,
 							Active: e.clipboard.VarData.Active,
+							Type:   e.clipboard.VarData.Type,
 						})
 						_ = e.saveEnvironment(targetEnv) // Error intentionally ignored for UI responsiveness
 						e.buildTree()
@@ -675,6 +721,16 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 			e.newEnvModal.SetFieldValue("description", "")
 			e.newEnvModal.Show()
 
+		case "I", "E":
+			// Import a .env file into the environment, or export it to one
+			dotenv := EnvironmentDotenvMsg{Export: msg.String() == "E"}
+			if env := e.getEnvForNode(e.getCurrentNode()); env != nil {
+				dotenv.Environment = env.Name
+			} else if dotenv.Export {
+				return e, nil
+			}
+			return e, func() tea.Msg { return dotenv }
+
 		case "g":
 			e.cursor = 0
 			e.scrollIntoView()
@@ -700,6 +756,32 @@ func (e EnvironmentsView) Update(msg tea.Msg, cfg *config.GlobalConfig) (Environ
 	return e, nil
 }
 
+// EnvironmentErrorMsg reports an edit of the Environments panel that was refused
+type EnvironmentErrorMsg struct {
+	Err error
+}
+
+// nextVariableType returns the type after the one of v in api.VariableTypes that its
+// value is valid for, empty for a string
+func nextVariableType(v *api.EnvironmentVariable) string {
+	current := 0
+	for i, typ := range api.VariableTypes {
+		if typ == v.ValueType() {
+			current = i
+		}
+	}
+	for i := 1; i < len(api.VariableTypes); i++ {
+		typ := api.VariableTypes[(current+i)%len(api.VariableTypes)]
+		if typ == api.VariableTypeString {
+			return ""
+		}
+		if api.ValidateVariableValue(typ, v.Value) == nil {
+			return typ
+		}
+	}
+	return ""
+}
+
 // handleModalClose handles modal close events
 func (e EnvironmentsView) handleModalClose(msg components.ModalCloseMsg) (EnvironmentsView, tea.Cmd) {
 	if !msg.Result.Confirmed {
@@ -715,6 +797,7 @@ func (e EnvironmentsView) handleModalClose(msg components.ModalCloseMsg) (Enviro
 				if e.pendingNode.EnvFile.FilePath != "" {
 					_ = os.Remove(e.pendingNode.EnvFile.FilePath)
 				}
+				_ = api.DeleteEnvironmentSecrets(e.pendingNode.EnvFile, api.SecretStore)
 				// Clear active environment if it was the deleted one
 				if e.activeEnvName == e.pendingNode.Name {
 					e.activeEnvName = ""
@@ -745,8 +828,14 @@ func (e EnvironmentsView) handleModalClose(msg components.ModalCloseMsg) (Enviro
 	case "edit":
 		if e.pendingNode != nil && e.pendingNode.Type == VarNode {
 			env := e.getEnvForNode(e.pendingNode)
+			value := msg.Result.Values["value"].(string)
+			if err := api.ValidateVariableValue(e.pendingNode.Variable.Type, value); err != nil {
+				err = fmt.Errorf("%s: %w", e.pendingNode.Name, err)
+				e.pendingNode = nil
+				return e, func() tea.Msg { return EnvironmentErrorMsg{Err: err} }
+			}
 			if env != nil {
-				e.pendingNode.Variable.Value = msg.Result.Values["value"].(string)
+				e.pendingNode.Variable.Value = value
 				e.pendingNode.Variable.Secret = msg.Result.Values["secret"].(bool)
 				e.pendingNode.Variable.Active = msg.Result.Values["active"].(bool)
 				_ = e.saveEnvironment(env) // Error intentionally ignored for UI responsiveness
@@ -759,6 +848,7 @@ func (e EnvironmentsView) handleModalClose(msg components.ModalCloseMsg) (Enviro
 			if newName != "" && newName != e.pendingNode.Name {
 				env := e.getEnvForNode(e.pendingNode)
 				if e.pendingNode.Type == EnvNode {
+					e.renameEnvironmentHistory(env.Name, newName)
 					env.Name = newName
 					_ = e.saveEnvironment(env) // Error intentionally ignored for UI responsiveness
 				} else if env != nil {
@@ -766,6 +856,7 @@ func (e EnvironmentsView) handleModalClose(msg components.ModalCloseMsg) (Enviro
 					v := env.Variables[e.pendingNode.Name]
 					delete(env.Variables, e.pendingNode.Name)
 					env.Variables[newName] = v
+					e.renameVariableHistory(env.Name, e.pendingNode.Name, newName)
 					_ = e.saveEnvironment(env) // Error intentionally ignored for UI responsiveness
 				}
 				e.buildTree()
@@ -1025,8 +1116,14 @@ func (e *EnvironmentsView) renderNode(node *EnvTreeNode, width int, selected boo
 		// Pad key to align values
 		keyPadded := key + strings.Repeat(" ", keyWidth-len(key))
 
+		// Typed variables show their type after the value
+		typeTag := ""
+		if node.Variable.Type != "" {
+			typeTag = " " + node.Variable.Type
+		}
+
 		// Calculate remaining width for value
-		valueWidth := availableWidth - keyWidth
+		valueWidth := availableWidth - keyWidth - len(typeTag)
 		if valueWidth < 3 {
 			valueWidth = 3
 		}
@@ -1035,7 +1132,8 @@ func (e *EnvironmentsView) renderNode(node *EnvTreeNode, width int, selected boo
 			value = value[:valueWidth]
 		}
 
-		content = linePrefix + checkStyle.Render(checkbox) + " " + keyStyle.Render(keyPadded) + "   " + valueStyle.Render(value)
+		content = linePrefix + checkStyle.Render(checkbox) + " " + keyStyle.Render(keyPadded) + "   " + valueStyle.Render(value) +
+			lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true).Render(typeTag)
 	}
 
 	// Apply selection styling
@@ -1069,6 +1167,9 @@ func (e *EnvironmentsView) RenderModal(screenWidth, screenHeight int) string {
 	if e.renameModal.IsVisible() {
 		return e.renameModal.View(screenWidth, screenHeight)
 	}
+	if e.historyNode != nil {
+		return e.renderHistory(screenWidth)
+	}
 	return ""
 }
 
@@ -1077,6 +1178,11 @@ func (e *EnvironmentsView) HasActiveModal() bool {
 	return e.hasActiveModal()
 }
 
+// GetEnvironments returns the loaded environments
+func (e *EnvironmentsView) GetEnvironments() []*api.EnvironmentFile {
+	return e.environments
+}
+
 // GetActiveEnvironment returns the currently active environment
 func (e *EnvironmentsView) GetActiveEnvironment() *api.EnvironmentFile {
 	for _, env := range e.environments {
@@ -1123,13 +1229,14 @@ func (e *EnvironmentsView) GetActiveEnvironmentVariables() map[string]string {
 	return vars
 }
 
-// SaveActiveEnvironment saves the active environment to disk
-func (e *EnvironmentsView) SaveActiveEnvironment() error {
+// SaveActiveEnvironment saves the active environment to disk, recording the
+// variables changed by source (one of the api.EnvSource values) in their history
+func (e *EnvironmentsView) SaveActiveEnvironment(source string) error {
 	env := e.GetActiveEnvironment()
 	if env == nil || env.FilePath == "" {
 		return nil
 	}
-	return api.SaveEnvironment(env, env.FilePath)
+	return e.saveEnvironmentFrom(env, source)
 }
 
 // GetBreadcrumb returns the breadcrumb path for the current cursor position
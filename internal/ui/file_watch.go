@@ -0,0 +1,410 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// watchInterval is how long a change waits while a dialog is open or the workspace is
+// locked, and how often the files are polled when they cannot be watched
+const watchInterval = time.Second
+
+// watchDebounce is how long the watcher waits for more changes after one, so that a git
+// pull or an editor writing a file in several steps reloads it once
+const watchDebounce = 100 * time.Millisecond
+
+// WatchEventMsg is sent when the collection or environment files changed on disk
+type WatchEventMsg struct {
+	watcher *fileWatcher // Watcher that saw the change, stale after a workspace switch
+}
+
+// WatchTickMsg is sent when the collection and environment files are due a check
+type WatchTickMsg struct{}
+
+// fileWatcher notifies the changes of the collection and environment files of a
+// workspace, with the request files of collections saved a file per request
+type fileWatcher struct {
+	watcher      *fsnotify.Watcher
+	lazycurl     string // .lazycurl directory, watched for the directories created later
+	collections  string
+	environments string
+}
+
+// watchFiles returns the watcher of the files of a workspace, nil when the system cannot
+// watch them: they are polled every watchInterval then
+func watchFiles(workspacePath string) *fileWatcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	collections, environments := watchDirs(workspacePath)
+	w := &fileWatcher{watcher: watcher, lazycurl: filepath.Dir(collections), collections: collections, environments: environments}
+	if err := watcher.Add(w.lazycurl); err != nil {
+		_ = watcher.Close()
+		return nil
+	}
+	w.add(collections)
+	w.add(environments)
+	return w
+}
+
+// add watches dir, and the request directories of dir when it is the collections one.
+// A directory that does not exist yet is added once created.
+func (w *fileWatcher) add(dir string) {
+	if err := w.watcher.Add(dir); err != nil || dir != w.collections {
+		return
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			_ = w.watcher.Add(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+// relevant reports whether event changes a watched file, adding the directories it creates
+func (w *fileWatcher) relevant(event fsnotify.Event) bool {
+	dir := filepath.Dir(event.Name)
+	if dir == w.lazycurl && event.Name != w.collections && event.Name != w.environments {
+		return false // Session, statistics and other workspace files
+	}
+	if event.Has(fsnotify.Create) && dir != w.environments {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.add(event.Name)
+		}
+	}
+	return true
+}
+
+// wait returns the command waiting for the next change of the files, then for the
+// changes following it within watchDebounce. Errors, such as missed events, count as a
+// change so the files are checked. The command returns nil once the watcher is closed.
+func (w *fileWatcher) wait() tea.Cmd {
+	return func() tea.Msg {
+		var debounce <-chan time.Time
+		for {
+			select {
+			case event, ok := <-w.watcher.Events:
+				if !ok {
+					return nil
+				}
+				if w.relevant(event) {
+					debounce = time.After(watchDebounce)
+				}
+			case _, ok := <-w.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				debounce = time.After(watchDebounce)
+			case <-debounce:
+				return WatchEventMsg{watcher: w}
+			}
+		}
+	}
+}
+
+// close stops watching the files
+func (w *fileWatcher) close() {
+	if w != nil {
+		_ = w.watcher.Close()
+	}
+}
+
+// fileStamp is what tells a file changed: its modification time and size
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// fileStamps are the stamps of the JSON files of some directories, by path
+type fileStamps map[string]fileStamp
+
+// stampFiles returns the stamps of the collection and environment files of a workspace,
+// with the request files of collections saved a file per request (see
+// api.RequestsDir). Missing directories have none.
+func stampFiles(workspacePath string) fileStamps {
+	collectionsPath, environmentsPath := watchDirs(workspacePath)
+	stamps := make(fileStamps)
+	stampDir(stamps, collectionsPath, 1)
+	stampDir(stamps, environmentsPath, 0)
+	return stamps
+}
+
+// stampDir adds the stamps of the JSON files of dir to stamps, and of its subdirectories
+// down to depth
+func stampDir(stamps fileStamps, dir string, depth int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			if depth > 0 {
+				stampDir(stamps, path, depth-1)
+			}
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+}
+
+// changed returns the paths of the files added, removed or modified in newer, sorted
+func (s fileStamps) changed(newer fileStamps) []string {
+	var paths []string
+	for path, stamp := range newer {
+		if old, ok := s[path]; !ok || !old.modTime.Equal(stamp.modTime) || old.size != stamp.size {
+			paths = append(paths, path)
+		}
+	}
+	for path := range s {
+		if _, ok := newer[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// waitFileChange returns the command waiting for the files to change, or to be polled
+// again without a watcher
+func (m Model) waitFileChange() tea.Cmd {
+	if m.watcher == nil {
+		return watchTick()
+	}
+	return m.watcher.wait()
+}
+
+// watchTick returns the command checking the files again after watchInterval
+func watchTick() tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		return WatchTickMsg{}
+	})
+}
+
+// sameJSON reports whether a and b encode to the same JSON
+func sameJSON(a, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// reloadContext is the context of the dialog asking whether to reload collections changed
+// on disk over the unsaved edits of their requests
+type reloadContext struct {
+	files []string      // Names of the changed files
+	paths []string      // Paths of the changed collection files
+	tabs  []*requestTab // Tabs of the changed requests with unsaved edits
+}
+
+// watchDirs returns the directories of the collection and environment files of a workspace
+func watchDirs(workspacePath string) (string, string) {
+	lazycurl := filepath.Join(workspacePath, ".lazycurl")
+	return filepath.Join(lazycurl, "collections"), filepath.Join(lazycurl, "environments")
+}
+
+// handleWatchEvent checks the files the watcher reported a change of, then waits for
+// the next change
+func (m Model) handleWatchEvent(msg WatchEventMsg) (tea.Model, tea.Cmd) {
+	if msg.watcher != m.watcher {
+		return m, nil // The watcher of the workspace open before
+	}
+	m, cmd := m.checkWatchedFiles()
+	return m, tea.Batch(cmd, m.watcher.wait())
+}
+
+// handleWatchTick checks the files again once a change waited for a dialog or the lock
+// screen, or polls them without a watcher
+func (m Model) handleWatchTick() (tea.Model, tea.Cmd) {
+	m.watchPending = false
+	m, cmd := m.checkWatchedFiles()
+	if m.watcher == nil && !m.watchPending {
+		cmd = tea.Batch(cmd, watchTick())
+	}
+	return m, cmd
+}
+
+// checkWatchedFiles reloads the collections and environments changed on disk by
+// something else than LazyCurl: a git pull, another editor. Files LazyCurl saved itself
+// match what it has loaded and are left alone. Changed requests open in tabs are loaded
+// again, but reloading over unsaved edits is confirmed first. The check waits while a
+// dialog is open or the workspace is locked.
+func (m Model) checkWatchedFiles() (Model, tea.Cmd) {
+	if m.dialog.IsVisible() || m.lockScreen.IsLocked() || m.leftPanel.GetEnvironments().HasActiveModal() {
+		if m.watchPending {
+			return m, nil
+		}
+		m.watchPending = true
+		return m, watchTick()
+	}
+	collectionsPath, environmentsPath := watchDirs(m.workspacePath)
+	stamps := stampFiles(m.workspacePath)
+	paths := m.watched.changed(stamps)
+	m.watched = stamps
+	for i, path := range paths {
+		// A changed request file changes its collection
+		if dir := filepath.Dir(path); dir != collectionsPath && dir != environmentsPath {
+			paths[i] = dir + ".json"
+		}
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var files, collectionFiles []string
+	reloadEnvironments := false
+	for _, path := range paths {
+		if filepath.Dir(path) == environmentsPath {
+			if m.environmentChanged(path) {
+				reloadEnvironments = true
+				files = append(files, filepath.Base(path))
+			}
+		} else if m.collectionChanged(path) {
+			collectionFiles = append(collectionFiles, path)
+			files = append(files, filepath.Base(path))
+		}
+	}
+	if len(files) == 0 {
+		return m, nil
+	}
+
+	if reloadEnvironments {
+		envs := m.leftPanel.GetEnvironments()
+		active := envs.GetActiveEnvironmentName()
+		envs.ReloadEnvironments()
+		envs.SetActiveEnvironmentName(active)
+	}
+	if len(collectionFiles) == 0 {
+		m.statusBar.Info("Reloaded " + strings.Join(files, ", ") + " (changed on disk)")
+		return m, nil
+	}
+
+	changed, dirty := m.changedTabs(collectionFiles)
+	if len(dirty) == 0 {
+		m.reloadCollections(changed)
+		m.statusBar.Info("Reloaded " + strings.Join(files, ", ") + " (changed on disk)")
+		return m, nil
+	}
+	message := fmt.Sprintf("%s changed on disk while %d requests have unsaved changes. Reload and discard them?", strings.Join(files, ", "), len(dirty))
+	if len(dirty) == 1 {
+		message = fmt.Sprintf("%s changed on disk while %s has unsaved changes. Reload and discard them?", strings.Join(files, ", "), dirty[0].name())
+	}
+	m.dialog.ShowConfirm("Changed on Disk", message+" (esc keeps them, :w saves them over the file)", "reload_changed", reloadContext{files: files, paths: collectionFiles, tabs: dirty})
+	return m, nil
+}
+
+// collectionChanged reports whether the collection file at path differs from the
+// collection loaded from it, or was added or removed
+func (m Model) collectionChanged(path string) bool {
+	var loaded *api.CollectionFile
+	for _, col := range m.leftPanel.GetCollections().GetCollections() {
+		if col.FilePath == path {
+			loaded = col
+		}
+	}
+	onDisk, err := api.LoadCollection(path)
+	if err != nil {
+		return loaded != nil && os.IsNotExist(err)
+	}
+	return loaded == nil || !sameJSON(loaded, onDisk)
+}
+
+// environmentChanged reports whether the environment file at path differs from the
+// environment loaded from it, or was added or removed
+func (m Model) environmentChanged(path string) bool {
+	var loaded *api.EnvironmentFile
+	for _, env := range m.leftPanel.GetEnvironments().GetEnvironments() {
+		if env.FilePath == path {
+			loaded = env
+		}
+	}
+	onDisk, err := api.LoadEnvironment(path)
+	if err != nil {
+		return loaded != nil && os.IsNotExist(err)
+	}
+	return loaded == nil || !sameJSON(loaded, onDisk)
+}
+
+// changedTabs returns the tabs of the requests whose saved version in the collection files
+// at paths differs from the loaded one, and those of them with unsaved edits
+func (m Model) changedTabs(paths []string) (changed, dirty []*requestTab) {
+	collections := m.leftPanel.GetCollections()
+	onDisk := make(map[string]*api.CollectionFile)
+	for _, path := range paths {
+		onDisk[path], _ = api.LoadCollection(path) // A removed collection has no requests
+	}
+	for _, tab := range m.tabs {
+		requestID := tab.request.GetCurrentRequestID()
+		col := collections.FindCollectionByRequestID(requestID)
+		if col == nil {
+			continue
+		}
+		newer, ok := onDisk[col.FilePath]
+		if !ok {
+			continue
+		}
+		var saved *api.CollectionRequest
+		if newer != nil {
+			saved = newer.FindRequest(requestID)
+		}
+		if saved != nil && sameJSON(saved, col.FindRequest(requestID)) {
+			continue
+		}
+		changed = append(changed, tab)
+		if tab.request.IsDirty() {
+			dirty = append(dirty, tab)
+		}
+	}
+	return changed, dirty
+}
+
+// reloadCollections loads the collections again and the requests of tabs from them,
+// but for tabs with unsaved edits
+func (m *Model) reloadCollections(tabs []*requestTab) {
+	collections := m.leftPanel.GetCollections()
+	collections.ReloadCollections()
+	for _, tab := range tabs {
+		if tab.request.IsDirty() {
+			continue
+		}
+		if req := collections.FindRequestByID(tab.request.GetCurrentRequestID()); req != nil {
+			tab.request.LoadCollectionRequest(req)
+		}
+	}
+	m.statusBar.SetMethod(m.requestPanel.GetMethod())
+}
+
+// handleReloadChanged reloads collections changed on disk once the dialog of ctx is
+// answered: confirmed, the unsaved edits of their requests are discarded, otherwise
+// they are kept for :w to save over the new version
+func (m Model) handleReloadChanged(ctx reloadContext, confirmed bool) (tea.Model, tea.Cmd) {
+	if confirmed {
+		for _, tab := range ctx.tabs {
+			tab.request.ClearEdits()
+		}
+	}
+	changed, _ := m.changedTabs(ctx.paths)
+	m.reloadCollections(changed)
+	if confirmed {
+		m.statusBar.Info("Reloaded " + strings.Join(ctx.files, ", ") + " (changed on disk)")
+	} else {
+		m.statusBar.Info("Kept the unsaved changes; :w saves them over " + strings.Join(ctx.files, ", "))
+	}
+	return m, nil
+}
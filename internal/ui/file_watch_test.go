@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+func TestModel_WatchFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	envPath := filepath.Join(workspace, ".lazycurl", "environments", "dev.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+	}}
+	env := &api.EnvironmentFile{Name: "dev", Variables: map[string]*api.EnvironmentVariable{
+		"host": {Value: "shop.example.com", Active: true},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.SaveEnvironment(env, envPath); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	// Files are stamped with their modification time, so each edit is a second later
+	edited := time.Now()
+	editOutside := func(url string) {
+		t.Helper()
+		col.Requests[0].URL = url
+		if err := api.SaveCollection(col, path); err != nil {
+			t.Fatal(err)
+		}
+		edited = edited.Add(time.Second)
+		if err := os.Chtimes(path, edited, edited); err != nil {
+			t.Fatal(err)
+		}
+	}
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+
+	editOutside("https://shop.example.com/orders?page=2")
+	update(WatchTickMsg{})
+	if m.requestPanel.GetURL() != "https://shop.example.com/orders?page=2" || !strings.Contains(m.statusBar.message, "shop.json") {
+		t.Fatalf("an edit outside LazyCurl should load the request again, got %q (%q)", m.requestPanel.GetURL(), m.statusBar.message)
+	}
+
+	// Saves of LazyCurl are not reloaded
+	m.requestPanel.SetURL("https://shop.example.com/carts")
+	update(RequestURLChangedMsg{URL: "https://shop.example.com/carts"})
+	update(CommandExecuteMsg{Command: CmdWrite})
+	message := m.statusBar.message
+	update(WatchTickMsg{})
+	if m.statusBar.message != message {
+		t.Errorf("a save should not be reloaded, got %q", m.statusBar.message)
+	}
+
+	// Reloading over unsaved edits is confirmed; esc keeps them
+	m.requestPanel.SetURL("https://shop.example.com/carts/1")
+	update(RequestURLChangedMsg{URL: "https://shop.example.com/carts/1"})
+	editOutside("https://shop.example.com/orders?page=3")
+	update(WatchTickMsg{})
+	if !m.dialog.IsVisible() {
+		t.Fatal("reloading over unsaved changes should be confirmed")
+	}
+	var cmd tea.Cmd
+	m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	update(cmd())
+	if m.requestPanel.GetURL() != "https://shop.example.com/carts/1" || !m.requestPanel.IsDirty() {
+		t.Errorf("esc should keep the unsaved changes, got %q", m.requestPanel.GetURL())
+	}
+	if req := m.leftPanel.GetCollections().FindRequestByID("list"); req.URL != "https://shop.example.com/orders?page=3" {
+		t.Errorf("the collection should be reloaded, got %q", req.URL)
+	}
+
+	editOutside("https://shop.example.com/orders?page=4")
+	update(WatchTickMsg{})
+	m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	update(cmd())
+	if m.requestPanel.GetURL() != "https://shop.example.com/orders?page=4" || m.requestPanel.IsDirty() {
+		t.Errorf("enter should discard the unsaved changes, got %q", m.requestPanel.GetURL())
+	}
+
+	// Environments are reloaded too
+	env.Variables["host"].Value = "staging.shop.example.com"
+	if err := api.SaveEnvironment(env, envPath); err != nil {
+		t.Fatal(err)
+	}
+	edited = edited.Add(time.Second)
+	if err := os.Chtimes(envPath, edited, edited); err != nil {
+		t.Fatal(err)
+	}
+	update(WatchTickMsg{})
+	if host := m.leftPanel.GetEnvironments().GetActiveEnvironmentVariables()["host"]; host != "staging.shop.example.com" {
+		t.Errorf("an environment edited outside LazyCurl should be reloaded, got %q", host)
+	}
+}
+
+func TestModel_WatchFilesNotified(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	if m.watcher == nil {
+		t.Skip("the files cannot be watched on this system")
+	}
+	defer m.watcher.close()
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	model, _ = m.Update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	m = model.(Model)
+
+	// An edit outside LazyCurl is reported without waiting for a tick
+	col.Requests[0].URL = "https://shop.example.com/orders?page=2"
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- m.waitFileChange()() }()
+	select {
+	case msg := <-msgs:
+		if _, ok := msg.(WatchEventMsg); !ok {
+			t.Fatalf("the watcher sent %T, want WatchEventMsg", msg)
+		}
+		model, _ = m.Update(msg)
+		m = model.(Model)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the watcher should report the edit")
+	}
+	if m.requestPanel.GetURL() != "https://shop.example.com/orders?page=2" {
+		t.Errorf("the edit should load the request again, got %q", m.requestPanel.GetURL())
+	}
+
+	// Once closed, the watcher stops waiting
+	m.watcher.close()
+	if msg := m.watcher.wait()(); msg != nil {
+		t.Errorf("a closed watcher should send nothing, got %T", msg)
+	}
+}
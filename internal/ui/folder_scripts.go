@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// scriptsEdit is a collection or folder script open in the external editor
+type scriptsEdit struct {
+	col        *api.CollectionFile
+	folderPath []string // nil for the collection's own scripts
+	pre        bool
+}
+
+// owner names the collection or folder of the edited script
+func (e *scriptsEdit) owner() string {
+	if len(e.folderPath) == 0 {
+		return e.col.Name
+	}
+	return e.col.Name + " / " + strings.Join(e.folderPath, " / ")
+}
+
+// scripts returns the scripts of the edited collection or folder, nil when it has none
+// or the folder no longer exists
+func (e *scriptsEdit) scripts() *api.ScriptConfig {
+	if len(e.folderPath) == 0 {
+		return e.col.Scripts
+	}
+	if folder := e.col.FindFolderByName(e.folderPath[:len(e.folderPath)-1], e.folderPath[len(e.folderPath)-1]); folder != nil {
+		return folder.Scripts
+	}
+	return nil
+}
+
+// save replaces the edited script and saves the collection
+func (e *scriptsEdit) save(script string) error {
+	return e.replace(e.scripts().SetScript(e.pre, script))
+}
+
+// replace replaces the scripts of the edited collection or folder and saves the collection
+func (e *scriptsEdit) replace(scripts *api.ScriptConfig) error {
+	if len(e.folderPath) == 0 {
+		e.col.Scripts = scripts
+		return e.col.Save()
+	}
+	folder := e.col.FindFolderByName(e.folderPath[:len(e.folderPath)-1], e.folderPath[len(e.folderPath)-1])
+	if folder == nil {
+		return fmt.Errorf("folder %s not found", e.owner())
+	}
+	folder.Scripts = scripts
+	return e.col.Save()
+}
+
+// inheritedScript returns the pre-request (pre) or post-response script of a send of
+// the request with id, chained after the scripts of its collection and folders. The
+// default script of the Scripts tab counts as none.
+func (m Model) inheritedScript(requestID, script string, pre bool) string {
+	kind := "post"
+	if pre {
+		kind = "pre"
+	}
+	if isDefaultScript(script, kind) {
+		script = ""
+	}
+	col := m.leftPanel.GetCollections().FindCollectionByRequestID(requestID)
+	if col == nil {
+		return script
+	}
+	return api.ChainScripts(col.InheritedScripts(col.RequestFolderPath(requestID)), script, pre)
+}
+
+// selectedScriptsOwner returns the collection or folder selected in the Collections
+// panel, the folder holding a selected request
+func (m Model) selectedScriptsOwner() (*api.CollectionFile, []string) {
+	collections := m.leftPanel.GetCollections()
+	node := collections.Selected()
+	col := collections.FindCollectionByNode(node)
+	if col == nil {
+		return nil, nil
+	}
+	if node.Type == components.FolderNode {
+		return col, collections.GetFolderPathIncluding(node)
+	}
+	return col, collections.GetFolderPath(node)
+}
+
+// handleScriptsCommand shows the scripts run around the open request, or edits or
+// clears the scripts of the collection or folder selected in the Collections panel
+func (m Model) handleScriptsCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.Info(m.describeScripts())
+		return m, nil
+	}
+
+	col, folderPath := m.selectedScriptsOwner()
+	if col == nil {
+		m.statusBar.Info("Select a collection or folder to edit its scripts")
+		return m, nil
+	}
+	edit := &scriptsEdit{col: col, folderPath: folderPath}
+
+	switch strings.ToLower(args[0]) {
+	case ScriptsPre, ScriptsPost:
+		edit.pre = strings.ToLower(args[0]) == ScriptsPre
+		field := api.EditableFieldPostResponseScript
+		if edit.pre {
+			field = api.EditableFieldPreRequestScript
+		}
+		m.scriptsEdit = edit
+		return m.openExternalEditor(components.ExternalEditorRequestMsg{
+			Field:       field,
+			Content:     edit.scripts().Script(edit.pre),
+			ContentType: api.ContentTypeJS,
+		})
+	case ScriptsClear:
+		if edit.scripts().Empty() {
+			m.statusBar.Info(edit.owner() + " has no scripts")
+			return m, nil
+		}
+		if err := edit.replace(nil); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.leftPanel.GetCollections().ReloadCollections()
+		m.statusBar.Success("Cleared scripts", edit.owner())
+		return m, nil
+	}
+
+	m.statusBar.Info("Usage: :scripts [pre | post | clear]")
+	return m, nil
+}
+
+// handleScriptsEdited saves a collection or folder script edited in the external editor
+func (m Model) handleScriptsEdited(msg components.ExternalEditorFinishedMsg) (tea.Model, tea.Cmd) {
+	edit := m.scriptsEdit
+	m.scriptsEdit = nil
+	switch {
+	case edit == nil:
+		return m, nil
+	case msg.Err != nil:
+		m.statusBar.Error(msg.Err)
+		return m, nil
+	case !msg.Changed:
+		m.statusBar.Info("Editor closed (no changes)")
+		return m, nil
+	}
+
+	if err := edit.save(msg.Content); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.leftPanel.GetCollections().ReloadCollections()
+	kind := "post-response"
+	if edit.pre {
+		kind = "pre-request"
+	}
+	m.statusBar.Success("Saved "+kind+" script", edit.owner())
+	return m, nil
+}
+
+// describeScripts lists the scripts run around a send of the open request, in order
+func (m Model) describeScripts() string {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	var levels []string
+	if col := m.leftPanel.GetCollections().FindCollectionByRequestID(requestID); col != nil {
+		for _, level := range col.InheritedScripts(col.RequestFolderPath(requestID)) {
+			levels = append(levels, level.Owner+" ("+scriptKinds(level.Scripts)+")")
+		}
+	}
+	own := api.ScriptConfig{}
+	if script := m.requestPanel.GetPreRequestScript(); !isDefaultScript(script, "pre") {
+		own.PreRequest = script
+	}
+	if script := m.requestPanel.GetPostRequestScript(); !isDefaultScript(script, "post") {
+		own.PostRequest = script
+	}
+	if !own.Empty() {
+		levels = append(levels, "request ("+scriptKinds(own)+")")
+	}
+	if len(levels) == 0 {
+		return "No scripts run around this request (:scripts pre|post to edit those of the selected folder)"
+	}
+	return "Scripts: " + strings.Join(levels, " → ")
+}
+
+// scriptKinds describes which scripts of s have content: "pre", "post" or "pre, post"
+func scriptKinds(s api.ScriptConfig) string {
+	var kinds []string
+	if strings.TrimSpace(s.PreRequest) != "" {
+		kinds = append(kinds, ScriptsPre)
+	}
+	if strings.TrimSpace(s.PostRequest) != "" {
+		kinds = append(kinds, ScriptsPost)
+	}
+	return strings.Join(kinds, ", ")
+}
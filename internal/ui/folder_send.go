@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// folderSend sends the requests of a collection or folder one after the other,
+// marking them in the Collections tree instead of opening the runner view
+type folderSend struct {
+	id     int
+	title  string
+	runner *runner.Runner
+	items  []runner.Item
+	failed int
+	start  time.Time
+}
+
+// FolderSendStepCmd sends a request of a folder send in the background
+func FolderSendStepCmd(r *runner.Runner, sendID, index int, item runner.Item) tea.Cmd {
+	return func() tea.Msg {
+		return FolderSendStepMsg{SendID: sendID, Index: index, Result: r.RunRequest(item)}
+	}
+}
+
+// startFolderSend sends every request of a collection or folder node in place. Requests
+// with prompt variables are run in the runner view, which asks for their values.
+func (m Model) startFolderSend(node *components.TreeNode) (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections()
+	col := collections.FindCollectionByNode(node)
+	if col == nil {
+		m.statusBar.Info("No collection to send")
+		return m, nil
+	}
+
+	// Ask for required variables of the collection before sending it
+	if !m.requiredVarsDismissed && m.promptMissingVariables(col) {
+		return m, nil
+	}
+
+	folderPath := collections.GetFolderPathIncluding(node)
+	items, err := runner.Collect(col, folderPath)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if len(items) == 0 {
+		m.statusBar.Info("No requests to send")
+		return m, nil
+	}
+
+	title := col.Name
+	if len(folderPath) > 0 {
+		title += " / " + strings.Join(folderPath, " / ")
+	}
+	if len(runner.PromptVariables(items)) > 0 {
+		return m.runItems(title, items, nil)
+	}
+
+	// A new send replaces the folder send in progress
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	m.folderSendSeq++
+	m.folderSend = &folderSend{
+		id:     m.folderSendSeq,
+		title:  title,
+		runner: runner.New(BuildStoredHTTPRequest, api.NewScriptExecutor(), env),
+		items:  items,
+		start:  time.Now(),
+	}
+	m.folderSend.runner.Scopes = m.runScopes(col)
+	m.folderSend.runner.Collection = col
+	collections.ClearSendStatuses()
+	for _, item := range items {
+		collections.SetSendStatus(item.Request.ID, components.SendStatusPending)
+	}
+	collections.SetSendStatus(items[0].Request.ID, components.SendStatusSending)
+	m.statusBar.Info(fmt.Sprintf("Sending %d requests of %s...", len(items), title))
+	return m, FolderSendStepCmd(m.folderSend.runner, m.folderSend.id, 0, items[0])
+}
+
+// handleFolderSendStep marks a request of the folder send with its result, then
+// sends the next one
+func (m Model) handleFolderSendStep(msg FolderSendStepMsg) (tea.Model, tea.Cmd) {
+	send := m.folderSend
+	if send == nil || send.id != msg.SendID {
+		return m, nil
+	}
+	collections := m.leftPanel.GetCollections()
+	status := components.SendStatusPassed
+	if !msg.Result.Passed() {
+		status = components.SendStatusFailed
+		send.failed++
+	}
+	collections.SetSendStatus(msg.Result.Item.Request.ID, status)
+	collections.SetLastSendFailed(msg.Result.Item.Request.ID, resultFailed(msg.Result))
+	m.applyEnvChanges(msg.Result.EnvChanges)
+
+	if next := msg.Index + 1; next < len(send.items) {
+		collections.SetSendStatus(send.items[next].Request.ID, components.SendStatusSending)
+		return m, FolderSendStepCmd(send.runner, send.id, next, send.items[next])
+	}
+
+	m.folderSend = nil
+	total := len(send.items)
+	if send.failed == 0 {
+		m.statusBar.Success("Sent", fmt.Sprintf("%s: %d/%d passed in %s", send.title, total, total, formatDuration(time.Since(send.start))))
+	} else {
+		m.statusBar.ShowMessage(fmt.Sprintf("⚠ Sent %s: %d/%d passed", send.title, total-send.failed, total), 3*time.Second)
+	}
+	return m, nil
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/vcs"
+)
+
+// gitStatusInterval is the minimum time between two background git status checks
+const gitStatusInterval = 10 * time.Second
+
+// GitStatusMsg is sent with the git state of the workspace
+type GitStatusMsg struct {
+	Status vcs.Status
+	Err    error
+	Report string // Action reported in the status bar ("" for background checks)
+}
+
+// GitCommitMsg is sent when a commit of the .lazycurl directory completes
+type GitCommitMsg struct {
+	Hash    string
+	Message string
+	Err     error
+}
+
+// gitStatusCmd reads the git state of the workspace, after staging its .lazycurl
+// directory when stage is set
+func gitStatusCmd(workspacePath string, stage bool, report string) tea.Cmd {
+	return func() tea.Msg {
+		if stage {
+			if err := vcs.Stage(workspacePath); err != nil {
+				return GitStatusMsg{Err: err, Report: report}
+			}
+		}
+		status, err := vcs.GetStatus(workspacePath)
+		return GitStatusMsg{Status: status, Err: err, Report: report}
+	}
+}
+
+// gitCommitCmd commits the changes of the .lazycurl directory of the workspace
+func gitCommitCmd(workspacePath, message string) tea.Cmd {
+	return func() tea.Msg {
+		hash, err := vcs.Commit(workspacePath, message)
+		return GitCommitMsg{Hash: hash, Message: message, Err: err}
+	}
+}
+
+// refreshGitStatus returns a command checking the git state for the branch segment,
+// at most every gitStatusInterval
+func (m *Model) refreshGitStatus() tea.Cmd {
+	if !m.statusBar.Shows(config.SegmentBranch) || m.statusBar.branch == "" || time.Since(m.gitCheckedAt) < gitStatusInterval {
+		return nil
+	}
+	m.gitCheckedAt = time.Now()
+	return gitStatusCmd(m.workspacePath, false, "")
+}
+
+// handleGitCommand shows the git state of the workspace, or stages or commits the
+// changes of its .lazycurl directory
+func (m Model) handleGitCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m, gitStatusCmd(m.workspacePath, false, "Git")
+	}
+	switch strings.ToLower(args[0]) {
+	case GitAdd:
+		return m, gitStatusCmd(m.workspacePath, true, "Staged")
+	case GitCommit:
+		message := strings.Trim(strings.Join(args[1:], " "), `"'`)
+		if message == "" {
+			m.statusBar.Info("Usage: :git commit <message>")
+			return m, nil
+		}
+		m.statusBar.Info("Committing " + vcs.Dir + "...")
+		return m, gitCommitCmd(m.workspacePath, message)
+	}
+	m.statusBar.Info("Usage: :git | :git add | :git commit <message>")
+	return m, nil
+}
+
+// handleGitStatus updates the branch segment, and reports the state when asked for
+func (m Model) handleGitStatus(msg GitStatusMsg) (tea.Model, tea.Cmd) {
+	if msg.Err == nil {
+		m.statusBar.SetBranch(msg.Status.Branch)
+		m.statusBar.SetDirty(msg.Status.Dirty())
+	}
+	if msg.Report == "" {
+		return m, nil
+	}
+	switch {
+	case msg.Err != nil:
+		m.statusBar.Error(msg.Err)
+	case msg.Status.Dirty():
+		m.statusBar.Info(fmt.Sprintf("%s: %s · %d changed files in %s (:git commit <message>)", msg.Report, msg.Status.Branch, len(msg.Status.Changes), vcs.Dir))
+	default:
+		m.statusBar.Info(fmt.Sprintf("%s: %s · %s has no changes", msg.Report, msg.Status.Branch, vcs.Dir))
+	}
+	return m, nil
+}
+
+// handleGitCommit reports a commit of the .lazycurl directory
+func (m Model) handleGitCommit(msg GitCommitMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.Err, vcs.ErrNoChanges):
+		m.statusBar.Info("Nothing to commit: " + msg.Err.Error())
+		return m, nil
+	case msg.Err != nil:
+		m.statusBar.Error(msg.Err)
+		return m, nil
+	}
+	m.statusBar.SetDirty(false)
+	m.statusBar.Success("Committed", msg.Hash+" "+msg.Message)
+	return m, gitStatusCmd(m.workspacePath, false, "")
+}
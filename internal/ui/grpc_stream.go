@@ -0,0 +1,147 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+)
+
+// GRPCStreamStartedMsg is sent when a gRPC server or bidi streaming call starts
+type GRPCStreamStartedMsg struct {
+	Response *api.Response // Response head, without messages
+	Stream   *grpc.Stream
+	SendID   int // Send the stream answers
+}
+
+// GRPCStreamMessageMsg is sent for every message received on a gRPC stream
+type GRPCStreamMessageMsg struct {
+	Stream  *grpc.Stream
+	Message grpc.StreamMessage
+}
+
+// GRPCStreamClosedMsg is sent when a gRPC streaming call ends, with every received
+// message as body
+type GRPCStreamClosedMsg struct {
+	Stream   *grpc.Stream
+	Response *api.Response
+	Error    error
+}
+
+// GRPCStreamSendMsg asks to send a JSON message on the gRPC stream of the current response
+type GRPCStreamSendMsg struct {
+	Body string
+}
+
+// GRPCStreamSentMsg is sent once a message typed in the send box is sent, or failed to be
+type GRPCStreamSentMsg struct {
+	Stream  *grpc.Stream
+	Message grpc.StreamMessage
+	Error   error
+}
+
+// GRPCStreamCloseSendMsg asks to end the messages the client sends on the gRPC stream
+type GRPCStreamCloseSendMsg struct{}
+
+// WaitGRPCStreamCmd creates a command waiting for the next message of a gRPC stream
+func WaitGRPCStreamCmd(stream *grpc.Stream) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream.Messages
+		if !ok {
+			return GRPCStreamClosedMsg{Stream: stream, Response: stream.Response(), Error: stream.Err()}
+		}
+		return GRPCStreamMessageMsg{Stream: stream, Message: msg}
+	}
+}
+
+// SendGRPCStreamMessageCmd creates a command sending the JSON message body on a gRPC stream
+func SendGRPCStreamMessageCmd(stream *grpc.Stream, body string) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := stream.Send(body)
+		return GRPCStreamSentMsg{Stream: stream, Message: msg, Error: err}
+	}
+}
+
+// handleGRPCStream shows the messages of a gRPC streaming call as they arrive, and
+// sends those typed in the send box until the call ends
+func (m Model) handleGRPCStream(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case GRPCStreamStartedMsg:
+		send, latest := m.sends.finish(msg.SendID)
+		if !latest {
+			// Stream of a send replaced by a newer one
+			msg.Stream.Close()
+			return m, m.sendNextQueued(send)
+		}
+		m.uploadProgress = nil
+		m.grpcStream = msg.Stream
+		m.streamSend = send
+		m.responsePanel.SetRequestID(send.requestID)
+		m.responsePanel.SetResponse(
+			msg.Response.StatusCode,
+			msg.Response.Status,
+			joinHeaders(msg.Response.Headers),
+			map[string]string{},
+			nil,
+			formatDuration(msg.Response.Time),
+			formatBytes(0),
+		)
+		m.responsePanel.StartMessageStream(msg.Stream.CanSend())
+		m.activePanel = ResponsePanel
+		if msg.Stream.CanSend() {
+			m.statusBar.Info("Streaming messages... (enter: send a message, x: stop)")
+		} else {
+			m.statusBar.Info("Streaming messages... (x: stop)")
+		}
+		next := m.sendNextQueued(send)
+		return m, tea.Batch(WaitGRPCStreamCmd(msg.Stream), next)
+
+	case GRPCStreamMessageMsg:
+		if msg.Stream != m.grpcStream {
+			return m, nil // Stream replaced by a newer send
+		}
+		m.responsePanel.AppendStreamMessage(msg.Message)
+		return m, WaitGRPCStreamCmd(msg.Stream)
+
+	case GRPCStreamSendMsg:
+		if m.grpcStream == nil {
+			return m, nil
+		}
+		return m, SendGRPCStreamMessageCmd(m.grpcStream, msg.Body)
+
+	case GRPCStreamSentMsg:
+		if msg.Stream != m.grpcStream {
+			return m, nil
+		}
+		if msg.Error != nil {
+			m.statusBar.Error(msg.Error)
+			return m, nil
+		}
+		m.responsePanel.AppendStreamMessage(msg.Message)
+		return m, nil
+
+	case GRPCStreamCloseSendMsg:
+		if m.grpcStream == nil {
+			return m, nil
+		}
+		if err := m.grpcStream.CloseSend(); err != nil {
+			m.statusBar.Error(err)
+		} else {
+			m.statusBar.Info("Sending ended, waiting for the server...")
+		}
+		m.responsePanel.CloseSendBox()
+		return m, nil
+
+	case GRPCStreamClosedMsg:
+		if msg.Stream != m.grpcStream {
+			return m, nil
+		}
+		send := m.streamSend
+		m.grpcStream = nil
+		m.streamSend = nil
+		m.responsePanel.EndEventStream()
+		// Finish like any response so the call is logged, tested and kept as body
+		return m.completeSend(send, true, HTTPResponseMsg{Response: msg.Response, Error: msg.Error})
+	}
+	return m, nil
+}
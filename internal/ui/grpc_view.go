@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// grpcReflectionTimeout bounds listing the methods of a server
+const grpcReflectionTimeout = 10 * time.Second
+
+// ListGRPCMethodsCmd lists the methods of the server of target by reflection
+func ListGRPCMethodsCmd(target grpc.Target) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), grpcReflectionTimeout)
+		defer cancel()
+		methods, err := grpc.ListMethods(ctx, target)
+		return GRPCMethodsLoadedMsg{Target: target, Methods: methods, Error: err}
+	}
+}
+
+// GRPCView is the fullscreen panel listing the methods of a gRPC server, found by reflection
+type GRPCView struct {
+	visible bool
+	target  grpc.Target
+	loading bool
+	err     error
+	methods []*grpc.Method
+	cursor  int
+}
+
+// NewGRPCView creates a new gRPC view
+func NewGRPCView() *GRPCView {
+	return &GRPCView{}
+}
+
+// Show displays the view while the methods of the server of target load
+func (v *GRPCView) Show(target grpc.Target) {
+	v.visible = true
+	v.target = target
+	v.loading = true
+	v.err = nil
+	v.methods = nil
+	v.cursor = 0
+}
+
+// SetMethods shows the methods of the server, or the error listing them
+func (v *GRPCView) SetMethods(target grpc.Target, methods []*grpc.Method, err error) {
+	if target != v.target {
+		return // Methods of a server closed since
+	}
+	v.loading = false
+	v.methods = methods
+	v.err = err
+	// Select the method of the current request
+	for i, m := range methods {
+		if m.Service == target.Service && m.Name == target.Method {
+			v.cursor = i
+		}
+	}
+}
+
+// Hide closes the view
+func (v *GRPCView) Hide() {
+	v.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *GRPCView) IsVisible() bool {
+	return v.visible
+}
+
+// Title returns the URL of the server
+func (v *GRPCView) Title() string {
+	return v.target.ServerURL()
+}
+
+// Selected returns the method under the cursor
+func (v *GRPCView) Selected() *grpc.Method {
+	if v.cursor < 0 || v.cursor >= len(v.methods) {
+		return nil
+	}
+	return v.methods[v.cursor]
+}
+
+// Update handles key input
+func (v *GRPCView) Update(msg tea.KeyMsg) (*GRPCView, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if v.cursor < len(v.methods)-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "g":
+		v.cursor = 0
+	case "G":
+		v.cursor = max(len(v.methods)-1, 0)
+	case "r":
+		if !v.loading {
+			v.Show(v.target)
+			return v, ListGRPCMethodsCmd(v.target)
+		}
+	case "enter":
+		if method := v.Selected(); method != nil {
+			v.Hide()
+			url := v.target.MethodURL(method.FullName())
+			return v, func() tea.Msg { return GRPCMethodSelectedMsg{URL: url, Method: method} }
+		}
+	case "q", "esc":
+		v.Hide()
+	}
+	return v, nil
+}
+
+// View renders the methods grouped by service and the messages of the selected method
+func (v *GRPCView) View(width, height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	serviceStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface0).Bold(true)
+
+	var result strings.Builder
+	switch {
+	case v.loading:
+		result.WriteString(mutedStyle.Render("Listing services by reflection..."))
+		return result.String()
+	case v.err != nil:
+		result.WriteString(lipgloss.NewStyle().Foreground(styles.Red).Render(v.err.Error()))
+		result.WriteString("\n\n")
+		result.WriteString(hintStyle.Render("r: retry  q: close"))
+		return result.String()
+	case len(v.methods) == 0:
+		result.WriteString("The server has no services.\n\n")
+		result.WriteString(hintStyle.Render("q: close"))
+		return result.String()
+	}
+
+	// Methods, with their service as a heading, scrolled to keep the cursor visible
+	var lines []string
+	cursorLine := 0
+	service := ""
+	for i, m := range v.methods {
+		if m.Service != service {
+			service = m.Service
+			lines = append(lines, serviceStyle.Render(service))
+		}
+		line := fmt.Sprintf("  %s %s", m.Name, mutedStyle.Render("("+m.Kind()+")"))
+		if i == v.cursor {
+			cursorLine = len(lines)
+			line = selectedStyle.Render(fmt.Sprintf("▸ %s", m.Name)) + " " + mutedStyle.Render("("+m.Kind()+")")
+		}
+		lines = append(lines, line)
+	}
+
+	details := v.renderMethod(v.Selected())
+	listHeight := max(height-len(details)-3, 3)
+	offset := 0
+	if cursorLine >= listHeight {
+		offset = cursorLine - listHeight + 1
+	}
+	end := min(offset+listHeight, len(lines))
+	result.WriteString(strings.Join(lines[offset:end], "\n"))
+	result.WriteString("\n")
+	result.WriteString(mutedStyle.Render(strings.Repeat("─", width)))
+	result.WriteString("\n")
+	result.WriteString(strings.Join(details, "\n"))
+	result.WriteString("\n\n")
+	result.WriteString(hintStyle.Render("j/k: navigate  Enter: use method  r: reload  q: close"))
+	return result.String()
+}
+
+// renderMethod returns the lines describing the request and response messages of m
+func (v *GRPCView) renderMethod(m *grpc.Method) []string {
+	if m == nil {
+		return nil
+	}
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	typeStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+
+	lines := []string{
+		fmt.Sprintf("%s %s", typeStyle.Render("rpc"), m.FullName()),
+		fmt.Sprintf("  request  %s", typeStyle.Render(m.Input.Name)),
+	}
+	for _, f := range m.Input.Fields {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("    %s %s = %d", f.TypeString(), f.JSONName, f.Number)))
+	}
+	lines = append(lines, fmt.Sprintf("  response %s", typeStyle.Render(m.Output.Name)))
+	return lines
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+)
+
+func testGRPCMethods() []*grpc.Method {
+	request := &grpc.Message{Name: "demo.HelloRequest", Fields: []*grpc.Field{
+		{Name: "name", JSONName: "name", Number: 1, Type: grpc.TypeString},
+		{Name: "ids", JSONName: "ids", Number: 2, Type: grpc.TypeInt64, Repeated: true},
+	}}
+	reply := &grpc.Message{Name: "demo.HelloReply"}
+	return []*grpc.Method{
+		{Name: "SayHello", Service: "demo.Greeter", Input: request, Output: reply},
+		{Name: "Count", Service: "demo.Greeter", Input: request, Output: reply, ServerStreaming: true},
+		{Name: "Check", Service: "grpc.health.v1.Health", Input: reply, Output: reply},
+	}
+}
+
+func TestGRPCView(t *testing.T) {
+	target, _ := grpc.ParseTarget("grpc://localhost:50051/demo.Greeter/Count")
+	v := NewGRPCView()
+	v.Show(target)
+	if view := v.View(80, 20); !strings.Contains(view, "Listing services") {
+		t.Errorf("view should show loading:\n%s", view)
+	}
+
+	// Methods of another server are ignored
+	other, _ := grpc.ParseTarget("grpc://localhost:9000")
+	v.SetMethods(other, testGRPCMethods(), nil)
+	if v.Selected() != nil {
+		t.Fatal("methods of another server should be ignored")
+	}
+
+	v.SetMethods(target, testGRPCMethods(), nil)
+	if v.Title() != "grpc://localhost:50051" {
+		t.Errorf("Title() = %q", v.Title())
+	}
+	if m := v.Selected(); m == nil || m.Name != "Count" {
+		t.Fatalf("the method of the current request should be selected, got %+v", m)
+	}
+	view := v.View(80, 30)
+	for _, want := range []string{"demo.Greeter", "SayHello", "(server streaming)", "grpc.health.v1.Health", "rpc demo.Greeter/Count", "repeated int64 ids = 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	v, _ = v.Update(keyMsg("k"))
+	v, cmd := v.Update(keyMsg("enter"))
+	if v.IsVisible() || cmd == nil {
+		t.Fatal("enter should close the view and select the method")
+	}
+	msg, ok := cmd().(GRPCMethodSelectedMsg)
+	if !ok || msg.URL != "grpc://localhost:50051/demo.Greeter/SayHello" || msg.Method.Name != "SayHello" {
+		t.Errorf("selected = %+v", msg)
+	}
+}
+
+func TestGRPCView_Error(t *testing.T) {
+	target, _ := grpc.ParseTarget("grpc://localhost:50051")
+	v := NewGRPCView()
+	v.Show(target)
+	v.SetMethods(target, nil, grpc.ErrNoReflection)
+	if view := v.View(80, 20); !strings.Contains(view, "does not support reflection") || !strings.Contains(view, "r: retry") {
+		t.Errorf("view should show the error:\n%s", view)
+	}
+
+	_, cmd := v.Update(keyMsg("r"))
+	if cmd == nil || !strings.Contains(v.View(80, 20), "Listing services") {
+		t.Error("r should list the methods again")
+	}
+	v.SetMethods(target, nil, errors.New("connection refused"))
+	if _, cmd := v.Update(keyMsg("enter")); cmd != nil {
+		t.Error("enter without methods should do nothing")
+	}
+}
+
+func TestRequestView_SetGRPCMethod(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{ID: "1", Method: api.POST, URL: "https://example.com"})
+
+	if !r.SetGRPCMethod("grpc://localhost:50051/demo.Greeter/SayHello", "{\n  \"name\": \"\"\n}") {
+		t.Error("the default body should be replaced by the template")
+	}
+	if r.GetMethod() != "GRPC" || r.GetURL() != "grpc://localhost:50051/demo.Greeter/SayHello" {
+		t.Errorf("method %s, URL %s", r.GetMethod(), r.GetURL())
+	}
+	if !strings.Contains(r.GetBodyContent(), `"name"`) {
+		t.Errorf("body = %q", r.GetBodyContent())
+	}
+
+	// An edited body is kept
+	if r.SetGRPCMethod("grpc://localhost:50051/demo.Greeter/Count", "{}") {
+		t.Error("an edited body should be kept")
+	}
+	if !strings.Contains(r.GetBodyContent(), `"name"`) {
+		t.Errorf("body = %q", r.GetBodyContent())
+	}
+}
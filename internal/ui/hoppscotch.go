@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/import/hoppscotch"
+)
+
+// importHoppscotch imports the collections or environments of the Hoppscotch export at
+// path into the workspace
+func (m Model) importHoppscotch(path string) (tea.Model, tea.Cmd) {
+	fileType, err := hoppscotch.DetectFileType(path)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	var result *hoppscotch.ImportResult
+	switch fileType {
+	case hoppscotch.FileTypeCollection:
+		result, err = hoppscotch.ImportCollections(path)
+	case hoppscotch.FileTypeEnvironment:
+		result, err = hoppscotch.ImportEnvironments(path)
+	default:
+		err = fmt.Errorf("not a Hoppscotch collections or environments export")
+	}
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to import %s: %w", path, err))
+		return m, nil
+	}
+
+	for _, col := range result.Collections {
+		if err := SaveImportedCollection(col, m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	for _, env := range result.Environments {
+		if err := SaveImportedEnvironment(env, m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	if len(result.Collections) > 0 {
+		m.leftPanel.GetCollections().ReloadCollections()
+	}
+	if len(result.Environments) > 0 {
+		m.leftPanel.GetEnvironments().ReloadEnvironments()
+	}
+	m.statusBar.Success("Imported", result.FormatSummary())
+	return m, nil
+}
+
+// exportHoppscotch writes all collections, or all environments, to the Hoppscotch file at
+// path, ready for Hoppscotch's import
+func (m Model) exportHoppscotch(path string, environments bool) (tea.Model, tea.Cmd) {
+	if environments {
+		envs := m.leftPanel.GetEnvironments().GetEnvironments()
+		if len(envs) == 0 {
+			m.statusBar.Info("No environment to export")
+			return m, nil
+		}
+		if err := hoppscotch.ExportEnvironments(envs, path); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Exported", fmt.Sprintf("%d environments to %s", len(envs), path))
+		return m, nil
+	}
+
+	collections := m.leftPanel.GetCollections().GetCollections()
+	if len(collections) == 0 {
+		m.statusBar.Info("No collection to export")
+		return m, nil
+	}
+	if err := hoppscotch.ExportCollections(collections, path); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.statusBar.Success("Exported", fmt.Sprintf("%d collections to %s", len(collections), path))
+	return m, nil
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/import/hoppscotch"
+)
+
+func TestModel_Hoppscotch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	collections := `[{"v": 2, "name": "Shop", "folders": [], "requests": [
+		{"v": "1", "name": "List products", "method": "GET", "endpoint": "<<base_url>>/products", "params": [], "headers": [],
+		 "preRequestScript": "", "testScript": "", "auth": {"authType": "none", "authActive": true}, "body": {"contentType": null, "body": null}}
+	]}]`
+	environments := `[{"name": "Staging", "variables": [{"key": "base_url", "value": "https://staging.example.com", "secret": false}]}]`
+	if err := os.WriteFile("collections.json", []byte(collections), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("environments.json", []byte(environments), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+
+	// :import hoppscotch detects collections and environments
+	update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportHoppscotch, "collections.json"}})
+	cols := m.leftPanel.GetCollections().GetCollections()
+	if len(cols) != 1 || cols[0].Name != "Shop" || len(cols[0].Requests) != 1 {
+		t.Fatalf("the collections should be imported, got %q", m.statusBar.message)
+	}
+	if url := cols[0].Requests[0].URL; url != "{{base_url}}/products" {
+		t.Errorf("imported URL = %q", url)
+	}
+	update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportHoppscotch, "environments.json"}})
+	found := false
+	for _, env := range m.leftPanel.GetEnvironments().GetEnvironments() {
+		found = found || env.Name == "Staging" && env.Variables["base_url"] != nil
+	}
+	if !found {
+		t.Fatalf("the environment should be imported, got %q", m.statusBar.message)
+	}
+
+	// Anything else is reported
+	update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportHoppscotch, "missing.json"}})
+	if !strings.Contains(m.statusBar.message, "missing.json") {
+		t.Errorf("a missing file should be reported, got %q", m.statusBar.message)
+	}
+
+	// :export hoppscotch writes all collections, or all environments
+	update(CommandExecuteMsg{Command: CmdExport, Args: []string{ExportHoppscotch, "out.json"}})
+	if fileType, err := hoppscotch.DetectFileType("out.json"); err != nil || fileType != hoppscotch.FileTypeCollection {
+		t.Errorf("the collections should be exported, got %q: %v", m.statusBar.message, err)
+	}
+	update(CommandExecuteMsg{Command: CmdExport, Args: []string{ExportHoppscotch, "envs.json", "environments"}})
+	if fileType, err := hoppscotch.DetectFileType("envs.json"); err != nil || fileType != hoppscotch.FileTypeEnvironment {
+		t.Errorf("the environments should be exported, got %q: %v", m.statusBar.message, err)
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/import/httpfile"
+)
+
+// importHTTPFile imports the requests of the .http or .rest file at path into a new
+// collection named after the file
+func (m Model) importHTTPFile(path string) (tea.Model, tea.Cmd) {
+	result, err := httpfile.ImportFile(path)
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to import %s: %w", path, err))
+		return m, nil
+	}
+	if err := SaveImportedCollection(result.Collection, m.workspacePath); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.leftPanel.GetCollections().ReloadCollections()
+	m.statusBar.Success("Imported", result.FormatSummary())
+	return m, nil
+}
+
+// exportHTTPFile writes the open request, with its unsaved edits, to the .http file at
+// path. A new file starts with the collection variables the request uses; an existing
+// one gets the request appended.
+func (m Model) exportHTTPFile(path string) (tea.Model, tea.Cmd) {
+	req := m.buildCollectionRequest()
+	if req == nil {
+		m.statusBar.Info("No request to export")
+		return m, nil
+	}
+	if req.Body != nil {
+		req.Body.Type = m.requestPanel.GetBodyType().String()
+	}
+	collections := m.leftPanel.GetCollections()
+	var variables map[string]string
+	if saved := collections.FindRequestByID(req.ID); saved != nil {
+		req.Name = saved.Name
+		req.NoFollowRedirects = saved.NoFollowRedirects
+	}
+	if col := collections.FindCollectionByRequestID(req.ID); col != nil {
+		text := httpfile.FormatRequest(req)
+		for name, value := range col.Variables {
+			if strings.Contains(text, "{{"+name+"}}") {
+				if variables == nil {
+					variables = make(map[string]string)
+				}
+				variables[name] = value
+			}
+		}
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		m.statusBar.Error(fmt.Errorf("failed to read .http file: %w", err))
+		return m, nil
+	}
+	var data string
+	if len(existing) == 0 {
+		data = httpfile.Format(variables, req)
+	} else {
+		data = string(existing)
+		if !strings.HasSuffix(data, "\n") {
+			data += "\n"
+		}
+		data += "\n" + httpfile.FormatRequest(req)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to write .http file: %w", err))
+		return m, nil
+	}
+	if len(existing) == 0 {
+		m.statusBar.Success("Exported", req.Name+" to "+path)
+	} else {
+		m.statusBar.Success("Exported", req.Name+" to the end of "+path)
+	}
+	return m, nil
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_HTTPFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	t.Chdir(workspace)
+	data := "@base_url = https://shop.example.com\n@unused = 1\n\n### Create order\nPOST {{base_url}}/orders\nContent-Type: application/json\n\n{\"sku\": \"A1\"}\n"
+	if err := os.WriteFile("shop.http", []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+
+	// :import http saves the requests as a new collection named after the file
+	update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportHTTP, "shop.http"}})
+	collections := m.leftPanel.GetCollections().GetCollections()
+	if len(collections) != 1 || collections[0].Name != "shop" || len(collections[0].Requests) != 1 {
+		t.Fatalf("the .http file should be imported as a collection, got %q", m.statusBar.message)
+	}
+	req := collections[0].Requests[0]
+	if req.Name != "Create order" || req.Body == nil || req.Body.Type != "json" {
+		t.Errorf("imported request = %+v", req)
+	}
+
+	// :export http writes the open request with the collection variables it uses
+	m.requestPanel.LoadCollectionRequest(&req)
+	update(CommandExecuteMsg{Command: CmdExport, Args: []string{ExportHTTP, "out.http"}})
+	exported, err := os.ReadFile("out.http")
+	if err != nil {
+		t.Fatalf("the request should be exported, got %q: %v", m.statusBar.message, err)
+	}
+	want := "@base_url = https://shop.example.com\n\n### Create order\nPOST {{base_url}}/orders\nContent-Type: application/json\n\n{\"sku\": \"A1\"}\n"
+	if string(exported) != want {
+		t.Errorf("exported =\n%s\nwant\n%s", exported, want)
+	}
+
+	// Exporting to an existing file appends the request
+	update(CommandExecuteMsg{Command: CmdExport, Args: []string{ExportHTTP, "out.http"}})
+	exported, _ = os.ReadFile("out.http")
+	if strings.Count(string(exported), "### Create order") != 2 || strings.Count(string(exported), "@base_url") != 1 {
+		t.Errorf("the request should be appended, got\n%s", exported)
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// InventoryExportedMsg is sent when the API inventory has been written to a file
+type InventoryExportedMsg struct {
+	FilePath string
+	Requests int
+	Error    error
+}
+
+// ExportInventoryCmd writes the API inventory to a file: JSON for a .json file, CSV
+// otherwise
+func ExportInventoryCmd(entries []api.InventoryEntry, outputPath string) tea.Cmd {
+	return func() tea.Msg {
+		var buf bytes.Buffer
+		write := api.WriteInventoryCSV
+		if strings.EqualFold(filepath.Ext(outputPath), ".json") {
+			write = api.WriteInventoryJSON
+		}
+		if err := write(entries, &buf); err != nil {
+			return InventoryExportedMsg{Error: fmt.Errorf("failed to encode inventory: %w", err)}
+		}
+
+		if dir := filepath.Dir(outputPath); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return InventoryExportedMsg{Error: fmt.Errorf("failed to create directory: %w", err)}
+			}
+		}
+		if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+			return InventoryExportedMsg{Error: fmt.Errorf("failed to write inventory file: %w", err)}
+		}
+		return InventoryExportedMsg{FilePath: outputPath, Requests: len(entries)}
+	}
+}
+
+// lastTested returns the latest send of each request by ID: from the usage statistics
+// when enabled, otherwise from the console history
+func (m *Model) lastTested() map[string]time.Time {
+	if m.stats != nil {
+		return m.stats.LastSent()
+	}
+
+	last := make(map[string]time.Time)
+	for _, entry := range m.consoleHistory.GetAll() {
+		if entry.Source != nil && entry.Timestamp.After(last[entry.Source.ID]) {
+			last[entry.Source.ID] = entry.Timestamp
+		}
+	}
+	return last
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+)
+
+// jobRun is the async job run shown in the runner view
+type jobRun struct {
+	runID int
+	col   *api.CollectionFile
+	job   *runner.Job
+}
+
+// JobWaitMsg is sent when the wait before the next poll of an async job is over
+type JobWaitMsg struct {
+	RunID int
+}
+
+// handleJobCommand runs an async job of the current collection, or lists its jobs
+func (m Model) handleJobCommand(args []string) (tea.Model, tea.Cmd) {
+	col := m.currentCollection()
+	if col == nil {
+		m.statusBar.Info("Select a collection to run its jobs")
+		return m, nil
+	}
+	if len(args) == 0 {
+		if len(col.Jobs) == 0 {
+			m.statusBar.Info(col.Name + " has no jobs")
+			return m, nil
+		}
+		names := make([]string, len(col.Jobs))
+		for i, job := range col.Jobs {
+			names[i] = job.Name
+		}
+		m.statusBar.Info("Jobs: " + strings.Join(names, ", ") + " (:job <name> to run)")
+		return m, nil
+	}
+	return m.startJob(col, strings.Join(args, " "))
+}
+
+// startJob runs the async job name of col in the runner view
+func (m Model) startJob(col *api.CollectionFile, name string) (tea.Model, tea.Cmd) {
+	job, err := runner.NewJob(col, name)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if len(runner.PromptVariables(job.Items())) > 0 {
+		m.statusBar.Info("Jobs with prompt variables cannot be run")
+		return m, nil
+	}
+
+	// The run uses its own script executor as scripts execute outside the update loop
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	m.activeRunner = runner.New(BuildStoredHTTPRequest, api.NewScriptExecutor(), env)
+	m.activeRunner.PrepareJob()
+	m.activeRunner.Scopes = m.runScopes(col)
+	m.activeRunner.Collection = col
+	runID := m.runnerView.StartJob(col.Name + " / job " + name)
+	m.job = &jobRun{runID: runID, col: col, job: job}
+	m.runnerView.SetJobState("submitting")
+	m.statusBar.Info("Running job " + name + "...")
+	return m, m.jobStep()
+}
+
+// jobStep runs the next request of the async job, after its wait
+func (m *Model) jobStep() tea.Cmd {
+	item, wait, ok := m.job.job.Next()
+	if !ok {
+		return nil
+	}
+	if wait > 0 {
+		runID := m.job.runID
+		m.runnerView.SetJobState(fmt.Sprintf("%s · next poll in %s", m.jobStatus(), wait))
+		return tea.Tick(wait, func(time.Time) tea.Msg { return JobWaitMsg{RunID: runID} })
+	}
+	return m.runJobItem(item)
+}
+
+// runJobItem adds item to the runner view and runs it
+func (m *Model) runJobItem(item runner.Item) tea.Cmd {
+	index := len(m.runnerView.Items())
+	m.runnerView.AddItem(m.job.runID, item)
+	return RunnerStepCmd(m.activeRunner, m.job.runID, index, item)
+}
+
+// handleJobWait runs the poll request once its wait is over, unless the job was stopped
+func (m Model) handleJobWait(msg JobWaitMsg) (tea.Model, tea.Cmd) {
+	if m.job == nil || m.job.runID != msg.RunID || !m.runnerView.IsRunning() {
+		return m, nil
+	}
+	if m.runnerView.IsCanceled() {
+		return m.finishJob()
+	}
+	item, _, ok := m.job.job.Next()
+	if !ok {
+		return m, nil
+	}
+	m.runnerView.SetJobState(m.jobStatus() + " · polling")
+	return m, m.runJobItem(item)
+}
+
+// handleJobStep moves the async job on with the result of its last request
+func (m Model) handleJobStep(msg RunnerStepMsg) (tea.Model, tea.Cmd) {
+	m.applyEnvChanges(msg.Result.EnvChanges)
+	m.job.job.Record(msg.Result)
+	if m.job.job.Stage() == runner.JobDone || m.runnerView.IsCanceled() {
+		return m.finishJob()
+	}
+	if m.job.job.Stage() == runner.JobResult {
+		m.runnerView.SetJobState(m.jobStatus() + " · fetching result")
+	}
+	return m, m.jobStep()
+}
+
+// finishJob ends the async job run and reports its outcome
+func (m Model) finishJob() (tea.Model, tea.Cmd) {
+	job := m.job.job
+	m.runnerView.FinishJob(m.job.runID)
+	switch {
+	case job.Err != nil:
+		m.runnerView.SetJobState("failed: " + job.Err.Error())
+		m.statusBar.Error(fmt.Errorf("job %s: %w", job.Spec.Name, job.Err))
+	case job.Stage() != runner.JobDone:
+		m.runnerView.SetJobState("stopped · " + m.jobStatus())
+		m.statusBar.Info("Job " + job.Spec.Name + " stopped")
+	default:
+		m.runnerView.SetJobState(fmt.Sprintf("%s after %d polls", job.Status, job.Polls()))
+		m.statusBar.Success("Job", fmt.Sprintf("%s %s after %d polls", job.Spec.Name, job.Status, job.Polls()))
+	}
+	return m, nil
+}
+
+// jobStatus describes the last status of the async job
+func (m Model) jobStatus() string {
+	if m.job.job.Polls() == 0 {
+		return "submitted"
+	}
+	return fmt.Sprintf("%q after %d polls", m.job.job.Status, m.job.job.Polls())
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+// treeActionKeys are the built-in keys of the collection tree for the actions the
+// config can bind
+var treeActionKeys = map[string]string{
+	"navigate_up":    "k",
+	"navigate_down":  "j",
+	"select":         "enter",
+	"new_request":    "n",
+	"delete_request": "d",
+	"new_folder":     "N",
+	"rename":         "R",
+	"duplicate":      "D",
+	"run_folder":     "r",
+	"send_folder":    "S",
+}
+
+// loadKeyBindings returns the key bindings in effect, from the global config and the
+// keymap file. The error reports a keymap that fails to load, unknown actions, and keys
+// bound to several actions.
+func loadKeyBindings(globalConfig *config.GlobalConfig) (config.KeyBindings, error) {
+	var problems []string
+	keymap, err := config.LoadKeymap(config.GetKeymapPath())
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	keys, err := config.EffectiveKeyBindings(globalConfig, keymap)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if conflicts := keys.Conflicts(); len(conflicts) > 0 {
+		parts := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			parts[i] = fmt.Sprintf("%s (%s)", c.Key, strings.Join(c.Actions, ", "))
+		}
+		problems = append(problems, "key conflicts: "+strings.Join(parts, ", "))
+	}
+	if len(problems) > 0 {
+		return keys, fmt.Errorf("keymap: %s", strings.Join(problems, "; "))
+	}
+	return keys, nil
+}
+
+// treeKeyMap returns the keys bound to tree actions, to the built-in key of their
+// action in the tree
+func treeKeyMap(keys config.KeyBindings) map[string]string {
+	keyMap := make(map[string]string)
+	for action, builtin := range treeActionKeys {
+		for _, key := range keys.Keys(action) {
+			if key != builtin {
+				keyMap[key] = builtin
+			}
+		}
+	}
+	return keyMap
+}
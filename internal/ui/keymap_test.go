@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// writeKeymap writes the keymap file of the home directory home
+func writeKeymap(t *testing.T, home, keymap string) {
+	t.Helper()
+	path := filepath.Join(home, ".config", "lazycurl", "keymap.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(keymap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestModel_Keymap(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeKeymap(t, home, "fullscreen: [z]\nnew_request: [a]\nwhich_key: []\nreload: [ctrl+r]\n")
+
+	var model tea.Model = NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), t.TempDir())
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m := model.(Model)
+	if !strings.Contains(m.statusBar.message, "unknown keymap actions: reload") {
+		t.Errorf("unknown actions should be reported, got %q", m.statusBar.message)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if !model.(Model).isFullscreen {
+		t.Error("z should toggle fullscreen, as the keymap binds it")
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if model.(Model).whichKey.IsVisible() {
+		t.Error("? should do nothing once which_key is unbound")
+	}
+
+	hints := m.whichKey.GetHintsForStatusBar(components.ContextNormalRequest)
+	if !strings.Contains(hints, "ctrl+s:Send") || strings.Contains(hints, "?") {
+		t.Errorf("WhichKey hints should follow the keymap, got %q", hints)
+	}
+	m.whichKey.SetContext(components.ContextNormalCollections)
+	m.whichKey.Show()
+	if view := PlainSnapshot(m.whichKey.View(120, 40)); !strings.Contains(view, " a New Request") {
+		t.Errorf("WhichKey should show the keys of the keymap:\n%s", view)
+	}
+	if keyMap := treeKeyMap(m.keys); keyMap["a"] != "n" {
+		t.Errorf("a should create requests in the tree, got %v", keyMap)
+	}
+}
+
+func TestLoadKeyBindings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	keys, err := loadKeyBindings(config.DefaultGlobalConfig())
+	if err != nil {
+		t.Fatalf("the default bindings should load without a keymap file, got %v", err)
+	}
+	if strings.Join(keys.Jump, ",") != "f" {
+		t.Errorf("unset actions should take their default keys, got %q", keys.Jump)
+	}
+
+	writeKeymap(t, home, "save_request: [ctrl+s]\n")
+	if _, err := loadKeyBindings(config.DefaultGlobalConfig()); err == nil || !strings.Contains(err.Error(), "ctrl+s (send_request, save_request)") {
+		t.Errorf("a key bound twice should be a conflict, got %v", err)
+	}
+
+	writeKeymap(t, home, "quit: q\n")
+	if _, err := loadKeyBindings(config.DefaultGlobalConfig()); err == nil || !strings.Contains(err.Error(), "keymap.yaml") {
+		t.Errorf("an invalid keymap should be reported with its file, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// LatencySample is one send of a request
+type LatencySample struct {
+	Time     time.Time
+	Duration time.Duration
+	Failed   bool // No response was received
+}
+
+// LatencyView is the fullscreen panel charting the response times of a request
+type LatencyView struct {
+	visible bool
+	title   string
+	samples []LatencySample
+}
+
+// NewLatencyView creates a new latency view
+func NewLatencyView() *LatencyView {
+	return &LatencyView{}
+}
+
+// Show displays the samples of the request named title, oldest first
+func (v *LatencyView) Show(title string, samples []LatencySample) {
+	v.visible = true
+	v.title = title
+	v.samples = samples
+}
+
+// Hide closes the view
+func (v *LatencyView) Hide() {
+	v.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *LatencyView) IsVisible() bool {
+	return v.visible
+}
+
+// Title returns the name of the charted request
+func (v *LatencyView) Title() string {
+	return v.title
+}
+
+// Update handles key input
+func (v *LatencyView) Update(msg tea.KeyMsg) (*LatencyView, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		v.Hide()
+	}
+	return v, nil
+}
+
+// latencyStats returns the min, max and average duration of samples
+func latencyStats(samples []LatencySample) (lo, hi, avg time.Duration) {
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	lo, hi = samples[0].Duration, samples[0].Duration
+	var total time.Duration
+	for _, s := range samples {
+		lo = min(lo, s.Duration)
+		hi = max(hi, s.Duration)
+		total += s.Duration
+	}
+	return lo, hi, total / time.Duration(len(samples))
+}
+
+// View renders the summary, a braille chart with min/avg/max markers and a sparkline
+func (v *LatencyView) View(width, height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var result strings.Builder
+	if len(v.samples) == 0 {
+		result.WriteString("No sends recorded for this request yet.\n")
+		result.WriteString(hintStyle.Render("q: close"))
+		return result.String()
+	}
+
+	lo, hi, avg := latencyStats(v.samples)
+	failed := 0
+	for _, s := range v.samples {
+		if s.Failed {
+			failed++
+		}
+	}
+	last := v.samples[len(v.samples)-1]
+	summary := fmt.Sprintf("%d sends · min %s · avg %s · max %s · last %s",
+		len(v.samples), formatDuration(lo), formatDuration(avg), formatDuration(hi), formatDuration(last.Duration))
+	if failed > 0 {
+		summary += lipgloss.NewStyle().Foreground(styles.Red).Render(fmt.Sprintf(" · %d failed", failed))
+	}
+	result.WriteString(summary)
+	result.WriteString("\n\n")
+
+	// Left axis labels take labelWidth columns; the chart keeps the latest 2 samples per column
+	const labelWidth = 12
+	chartWidth := max(width-labelWidth-1, 4)
+	chartHeight := max(height-7, 2)
+	shown := v.samples
+	if len(shown) > chartWidth*2 {
+		shown = shown[len(shown)-chartWidth*2:]
+	}
+	values := make([]float64, len(shown))
+	for i, s := range shown {
+		values[i] = float64(s.Duration)
+	}
+	shownLo, shownHi, shownAvg := latencyStats(shown)
+	rows := components.BrailleChart(values, chartWidth, chartHeight, float64(shownAvg))
+
+	labels := make([]string, chartHeight)
+	avgRow := components.ChartRow(float64(shownAvg), float64(shownLo), float64(shownHi), chartHeight*4) / 4
+	labels[avgRow] = "avg " + formatDuration(shownAvg)
+	labels[chartHeight-1] = "min " + formatDuration(shownLo)
+	labels[0] = "max " + formatDuration(shownHi)
+
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	for i, row := range rows {
+		axis := "│"
+		if labels[i] != "" {
+			axis = "┤"
+		}
+		result.WriteString(mutedStyle.Render(fmt.Sprintf("%*s %s", labelWidth-1, labels[i], axis)))
+		result.WriteString(lineStyle.Render(row))
+		result.WriteString("\n")
+	}
+
+	// Time axis: first and last charted sends, below where the right-aligned line starts and ends
+	result.WriteString(mutedStyle.Render(strings.Repeat(" ", labelWidth) + "└" + strings.Repeat("─", chartWidth)))
+	result.WriteString("\n")
+	first, end := shown[0].Time.Format("01-02 15:04"), shown[len(shown)-1].Time.Format("01-02 15:04")
+	start := (chartWidth*2 - len(shown)) / 2
+	gap := chartWidth + 1 - start - len(first) - len(end)
+	if gap < 1 {
+		start, gap = 0, max(chartWidth+1-len(first)-len(end), 1)
+	}
+	result.WriteString(mutedStyle.Render(strings.Repeat(" ", labelWidth+start) + first + strings.Repeat(" ", gap) + end))
+	result.WriteString("\n")
+
+	// Compact trend of all sends
+	all := make([]float64, len(v.samples))
+	for i, s := range v.samples {
+		all[i] = float64(s.Duration)
+	}
+	result.WriteString(mutedStyle.Render(fmt.Sprintf("%*s ", labelWidth, "trend")))
+	result.WriteString(lineStyle.Render(components.Sparkline(all, chartWidth)))
+	result.WriteString("\n")
+
+	result.WriteString(hintStyle.Render("q: close"))
+	return result.String()
+}
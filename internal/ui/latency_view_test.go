@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyView(t *testing.T) {
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	durations := []time.Duration{100, 300, 200, 150}
+	var samples []LatencySample
+	for i, d := range durations {
+		samples = append(samples, LatencySample{Time: start.Add(time.Duration(i) * time.Hour), Duration: d * time.Millisecond})
+	}
+	samples[1].Failed = true
+
+	tests := []struct {
+		name    string
+		samples []LatencySample
+		want    []string
+	}{
+		{
+			name:    "chart with markers",
+			samples: samples,
+			want: []string{
+				"4 sends · min 100ms · avg 187ms · max 300ms · last 150ms", "1 failed",
+				"max 300ms ┤", "avg 187ms ┤", "min 100ms ┤",
+				"03-10 09:00", "03-10 12:00", "trend",
+			},
+		},
+		{
+			name:    "no samples",
+			samples: nil,
+			want:    []string{"No sends recorded"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewLatencyView()
+			v.Show("List users", tt.samples)
+			view := v.View(80, 20)
+			for _, want := range tt.want {
+				if !strings.Contains(view, want) {
+					t.Errorf("view missing %q:\n%s", want, view)
+				}
+			}
+		})
+	}
+}
+
+func TestLatencyView_Hide(t *testing.T) {
+	v := NewLatencyView()
+	v.Show("List users", nil)
+	if !v.IsVisible() || v.Title() != "List users" {
+		t.Fatal("view should be visible with its title after Show")
+	}
+	v, _ = v.Update(keyMsg("esc"))
+	if v.IsVisible() {
+		t.Error("esc should hide the view")
+	}
+}
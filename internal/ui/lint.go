@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+// NewLintOptions converts the lint config of a workspace, nil using the defaults
+func NewLintOptions(cfg *config.LintConfig) api.LintOptions {
+	if cfg == nil {
+		return api.LintOptions{}
+	}
+	return api.LintOptions{Rules: cfg.Rules, BaseURLVariable: cfg.BaseURLVariable}
+}
+
+// handleLintCommand checks the collection selected in the Collections panel (the one
+// of the open request otherwise), or every collection, and shows the report over the
+// Body tab
+func (m Model) handleLintCommand(args []string) (tea.Model, tea.Cmd) {
+	collectionsView := m.leftPanel.GetCollections()
+	var collections []*api.CollectionFile
+	switch {
+	case len(args) == 1 && strings.ToLower(args[0]) == LintAll:
+		collections = collectionsView.GetCollections()
+	case len(args) == 0:
+		col := collectionsView.FindCollectionByNode(collectionsView.Selected())
+		if col == nil {
+			col = collectionsView.FindCollectionByRequestID(m.requestPanel.GetCurrentRequestID())
+		}
+		if col != nil {
+			collections = []*api.CollectionFile{col}
+		}
+	default:
+		m.statusBar.Info("Usage: :lint [all]")
+		return m, nil
+	}
+	if len(collections) == 0 {
+		m.statusBar.Info("No collection to lint")
+		return m, nil
+	}
+
+	var lintConfig *config.LintConfig
+	if m.workspaceConfig != nil {
+		lintConfig = m.workspaceConfig.Lint
+	}
+	report, err := api.Lint(collections, NewLintOptions(lintConfig))
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("lint config: %w", err))
+		return m, nil
+	}
+
+	m.responsePanel.SetLintReport(report)
+	m.activePanel = ResponsePanel
+	if report.OK() {
+		m.statusBar.Success("Linted", report.Summary())
+	} else {
+		m.statusBar.Error(fmt.Errorf("lint: %s", report.Summary()))
+	}
+	return m, nil
+}
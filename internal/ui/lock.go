@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// LockCheckMsg is sent when the workspace may have been idle long enough to lock
+type LockCheckMsg struct {
+	Seq int // Checks scheduled before the latest one are ignored
+}
+
+// PassphraseEnteredMsg is sent when a passphrase is entered in the lock screen
+type PassphraseEnteredMsg struct {
+	Passphrase string
+	New        bool // Entered twice as the new passphrase of the workspace, rather than to unlock it
+}
+
+// What the lock screen asks for
+const (
+	lockPromptUnlock  = iota // The passphrase unlocking the workspace
+	lockPromptNew            // A new passphrase
+	lockPromptConfirm        // The new passphrase again
+)
+
+// LockScreen is the passphrase prompt shown over the masked screen while the workspace is
+// locked, and when a new passphrase is set
+type LockScreen struct {
+	visible bool
+	prompt  int
+	input   []rune
+	first   string // New passphrase waiting for its confirmation
+	err     string
+}
+
+// NewLockScreen creates a new lock screen
+func NewLockScreen() *LockScreen {
+	return &LockScreen{}
+}
+
+// ShowUnlock locks the workspace until its passphrase is entered
+func (l *LockScreen) ShowUnlock() {
+	*l = LockScreen{visible: true, prompt: lockPromptUnlock}
+}
+
+// ShowNewPassphrase asks for a new passphrase, twice
+func (l *LockScreen) ShowNewPassphrase() {
+	*l = LockScreen{visible: true, prompt: lockPromptNew}
+}
+
+// Hide closes the lock screen
+func (l *LockScreen) Hide() {
+	*l = LockScreen{}
+}
+
+// IsVisible returns whether the lock screen is visible
+func (l *LockScreen) IsVisible() bool {
+	return l.visible
+}
+
+// IsLocked returns whether the workspace is locked
+func (l *LockScreen) IsLocked() bool {
+	return l.visible && l.prompt == lockPromptUnlock
+}
+
+// Fail reports a passphrase that does not unlock the workspace
+func (l *LockScreen) Fail(message string) {
+	l.input = nil
+	l.err = message
+}
+
+// Update handles key input. Esc cancels a new passphrase but leaves the workspace locked.
+func (l *LockScreen) Update(msg tea.KeyMsg) (*LockScreen, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		if !l.IsLocked() {
+			l.Hide()
+		}
+	case tea.KeyCtrlU:
+		l.input = nil
+	case tea.KeyBackspace:
+		if len(l.input) > 0 {
+			l.input = l.input[:len(l.input)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		l.input = append(l.input, msg.Runes...)
+		l.err = ""
+	case tea.KeyEnter:
+		return l, l.submit()
+	}
+	return l, nil
+}
+
+// submit sends the entered passphrase, once confirmed when it is a new one
+func (l *LockScreen) submit() tea.Cmd {
+	passphrase := string(l.input)
+	l.input = nil
+	if passphrase == "" {
+		l.err = "Enter a passphrase"
+		return nil
+	}
+	switch l.prompt {
+	case lockPromptNew:
+		l.first = passphrase
+		l.prompt = lockPromptConfirm
+		l.err = ""
+		return nil
+	case lockPromptConfirm:
+		if passphrase != l.first {
+			l.first = ""
+			l.prompt = lockPromptNew
+			l.err = "The passphrases differ, enter it again"
+			return nil
+		}
+		l.Hide()
+		return func() tea.Msg { return PassphraseEnteredMsg{Passphrase: passphrase, New: true} }
+	}
+	return func() tea.Msg { return PassphraseEnteredMsg{Passphrase: passphrase} }
+}
+
+// View renders the passphrase prompt, the entered characters shown as dots
+func (l *LockScreen) View(screenWidth int) string {
+	width := min(48, screenWidth-4)
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Lavender).Width(width - 4).Align(lipgloss.Center)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	inputStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	errStyle := lipgloss.NewStyle().Foreground(styles.Red)
+
+	title, text := "Workspace Locked", "Enter the passphrase of the workspace"
+	switch l.prompt {
+	case lockPromptNew:
+		title, text = "Set Passphrase", "Enter the passphrase unlocking the workspace"
+	case lockPromptConfirm:
+		title, text = "Set Passphrase", "Enter the passphrase again"
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(title) + "\n\n")
+	content.WriteString(textStyle.Render(text) + "\n")
+	content.WriteString(inputStyle.Render("> "+strings.Repeat("•", len(l.input))+"█") + "\n")
+	if l.err != "" {
+		content.WriteString(errStyle.Render(l.err) + "\n")
+	}
+	hint := "enter: unlock · ctrl+c: quit"
+	if !l.IsLocked() {
+		hint = "enter: confirm · esc: cancel"
+	}
+	content.WriteString("\n" + textStyle.Italic(true).Render(hint))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender).
+		Padding(1, 2).
+		Width(width).
+		Render(content.String())
+}
+
+// lockCheck returns the command checking for idleness after d, nil when the workspace
+// does not lock
+func lockCheck(d time.Duration, seq int) tea.Cmd {
+	if d <= 0 {
+		return nil
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return LockCheckMsg{Seq: seq}
+	})
+}
+
+// scheduleLockCheck returns the command checking for idleness with the lock config of the
+// workspace, replacing the check scheduled before
+func (m *Model) scheduleLockCheck() tea.Cmd {
+	m.lockSeq++
+	return lockCheck(m.workspaceConfig.AutoLock(), m.lockSeq)
+}
+
+// handleLockCheck locks the workspace when there was no input for its lock.idle time,
+// or checks again when there could be none
+func (m Model) handleLockCheck(msg LockCheckMsg) (tea.Model, tea.Cmd) {
+	idle := m.workspaceConfig.AutoLock()
+	if msg.Seq != m.lockSeq || idle <= 0 || m.lockScreen.IsLocked() {
+		return m, nil
+	}
+	if remaining := idle - time.Since(m.lastInput); remaining > 0 {
+		return m, lockCheck(remaining, m.lockSeq)
+	}
+	m.lockScreen.ShowUnlock()
+	return m, nil
+}
+
+// handleLockedInput passes keys to the passphrase prompt of the locked workspace; the
+// rest of the screen takes no input until it is unlocked, but ctrl+c quits
+func (m Model) handleLockedInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if key.String() == "ctrl+c" {
+		return m.saveSessionAndQuit()
+	}
+	var cmd tea.Cmd
+	m.lockScreen, cmd = m.lockScreen.Update(key)
+	return m, cmd
+}
+
+// handlePassphraseEntered unlocks the workspace, or sets its new passphrase
+func (m Model) handlePassphraseEntered(msg PassphraseEnteredMsg) (tea.Model, tea.Cmd) {
+	if !msg.New {
+		if m.workspaceConfig.Lock == nil || !secrets.CheckPassphrase(m.workspaceConfig.Lock.Passphrase, msg.Passphrase) {
+			m.lockScreen.Fail("Wrong passphrase")
+			return m, nil
+		}
+		m.lockScreen.Hide()
+		m.lastInput = time.Now()
+		return m, m.scheduleLockCheck()
+	}
+
+	hash, err := secrets.HashPassphrase(msg.Passphrase)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if m.workspaceConfig.Lock == nil {
+		m.workspaceConfig.Lock = &config.LockConfig{}
+	}
+	m.workspaceConfig.Lock.Passphrase = hash
+	if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if m.workspaceConfig.Lock.Idle == 0 {
+		m.statusBar.Info("Passphrase set. :lock locks the workspace, :lock idle 10m locks it after 10 minutes idle")
+	} else {
+		m.statusBar.Success("Passphrase set", "locks after "+m.workspaceConfig.Lock.Idle.String()+" idle")
+	}
+	return m, m.scheduleLockCheck()
+}
+
+// handleLockCommand locks the workspace now, sets its passphrase or idle time, or
+// turns the lock off
+func (m Model) handleLockCommand(args []string) (tea.Model, tea.Cmd) {
+	lock := m.workspaceConfig.Lock
+	if len(args) == 0 {
+		if lock == nil || lock.Passphrase == "" {
+			m.statusBar.Info("Set the passphrase unlocking the workspace first with :lock passphrase")
+			return m, nil
+		}
+		m.lockScreen.ShowUnlock()
+		return m, nil
+	}
+
+	switch args[0] {
+	case LockPassphrase:
+		m.lockScreen.ShowNewPassphrase()
+		return m, nil
+
+	case LockIdle:
+		i := slices.IndexFunc(config.Settings, func(s config.Setting) bool { return s.Key == "lock.idle" })
+		if len(args) == 1 {
+			idle := config.Settings[i].Get(m.globalConfig, m.workspaceConfig)
+			if idle == "" {
+				idle = "off"
+			}
+			m.statusBar.Info("Auto-lock after idle: " + idle)
+			return m, nil
+		}
+		value := args[1]
+		if value == LockOff {
+			value = ""
+		}
+		return m.handleSettingChanged(SettingChangedMsg{Setting: config.Settings[i], Value: value})
+
+	case LockOff:
+		m.workspaceConfig.Lock = nil
+		if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.lockSeq++ // Cancels the scheduled check
+		m.statusBar.Success("Lock", "off")
+		return m, nil
+
+	default:
+		m.statusBar.Info("Usage: :lock [passphrase | idle <duration|off> | off]")
+		return m, nil
+	}
+}
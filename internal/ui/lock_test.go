@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
+)
+
+// enterPassphrase types a passphrase in the lock screen and handles what it sends
+func enterPassphrase(t *testing.T, m Model, passphrase string) Model {
+	t.Helper()
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(passphrase)})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		model, _ = model.Update(cmd())
+	}
+	return model.(Model)
+}
+
+func TestModel_Lock(t *testing.T) {
+	defer func(n int) { secrets.PassphraseIterations = n }(secrets.PassphraseIterations)
+	secrets.PassphraseIterations = 1000
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	env := &api.EnvironmentFile{Name: "dev", Variables: map[string]*api.EnvironmentVariable{
+		"token": {Value: "s3cr3t-value", Secret: true, Active: true},
+	}}
+	if err := api.SaveEnvironment(env, filepath.Join(workspace, ".lazycurl", "environments", "dev.json")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	run := func(args ...string) {
+		model, _ := m.Update(CommandExecuteMsg{Command: CmdLock, Args: args})
+		m = model.(Model)
+	}
+
+	run()
+	if m.lockScreen.IsVisible() {
+		t.Fatal(":lock should not lock a workspace without passphrase")
+	}
+
+	// The new passphrase is entered twice and only its hash is saved
+	run(LockPassphrase)
+	m = enterPassphrase(t, m, "open sesame")
+	m = enterPassphrase(t, m, "open sesame")
+	if m.lockScreen.IsVisible() {
+		t.Fatalf("the confirmed passphrase should close the prompt: %s", m.lockScreen.err)
+	}
+	run(LockIdle, "5m")
+	saved, err := config.LoadWorkspaceConfig(workspace)
+	if err != nil || saved.Lock == nil || saved.Lock.Idle != 5*time.Minute || !secrets.CheckPassphrase(saved.Lock.Passphrase, "open sesame") {
+		t.Fatalf("the lock should be saved to the workspace config, got %+v, %v", saved.Lock, err)
+	}
+
+	// Idle checks lock once there was no input for the idle time
+	model, _ = m.Update(LockCheckMsg{Seq: m.lockSeq})
+	if m = model.(Model); m.lockScreen.IsLocked() {
+		t.Fatal("the workspace should not lock before the idle time")
+	}
+	m.lastInput = time.Now().Add(-6 * time.Minute)
+	model, _ = m.Update(LockCheckMsg{Seq: m.lockSeq - 1})
+	if m = model.(Model); m.lockScreen.IsLocked() {
+		t.Fatal("a check replaced by a later one should be ignored")
+	}
+	model, _ = m.Update(LockCheckMsg{Seq: m.lockSeq})
+	if m = model.(Model); !m.lockScreen.IsLocked() {
+		t.Fatal("the workspace should lock after the idle time")
+	}
+
+	// Secret values are masked and keys only reach the prompt
+	m.statusBar.Info("token s3cr3t-value")
+	view := PlainSnapshot(m.View())
+	if !strings.Contains(view, "Workspace Locked") || strings.Contains(view, "s3cr3t-value") {
+		t.Errorf("the locked screen should mask secret values:\n%s", view)
+	}
+	m = enterPassphrase(t, m, ":q")
+	if !m.lockScreen.IsLocked() || m.quitting {
+		t.Fatal("a wrong passphrase should keep the workspace locked")
+	}
+	if view := PlainSnapshot(m.View()); !strings.Contains(view, "Wrong passphrase") {
+		t.Errorf("a wrong passphrase should be reported:\n%s", view)
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = model.(Model); !m.lockScreen.IsLocked() {
+		t.Fatal("esc should not unlock the workspace")
+	}
+	m = enterPassphrase(t, m, "open sesame")
+	if m.lockScreen.IsVisible() {
+		t.Fatal("the passphrase should unlock the workspace")
+	}
+
+	run(LockOff)
+	if saved, _ := config.LoadWorkspaceConfig(workspace); saved.Lock != nil || m.workspaceConfig.AutoLock() != 0 {
+		t.Errorf(":lock off should remove the lock, got %+v", saved.Lock)
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/session"
+)
+
+// Panels of a mark
+const (
+	markPanelCollections = "collections"
+	markPanelRequest     = "request"
+)
+
+// startsMark returns true when key starts a mark command: m sets a mark and ' jumps
+// to one, in NORMAL mode on the collections tree or the request panel
+func (m *Model) startsMark(key string) bool {
+	if (key != "m" && key != "'") || m.mode != NormalMode || m.session == nil {
+		return false
+	}
+	switch m.activePanel {
+	case CollectionsPanel:
+		return m.leftPanel.GetActiveTab() == CollectionsTab && !m.leftPanel.IsSearching()
+	case RequestPanel:
+		return m.requestPanel.AcceptsMarks()
+	}
+	return false
+}
+
+// completeMark sets or jumps to the mark named by key, the letter after m or '
+func (m *Model) completeMark(key string) (Model, tea.Cmd) {
+	command := m.pendingMark
+	m.pendingMark = ""
+	if len(key) != 1 || key[0] < 'a' || key[0] > 'z' {
+		return *m, nil
+	}
+	if command == "'" {
+		return m.jumpToMark(key)
+	}
+	if !m.setMark(key) {
+		return *m, nil
+	}
+	return *m, m.markSessionDirty()
+}
+
+// setMark saves the position of the active panel under name: the tree node under
+// the cursor, or the request with its tab and body cursor
+func (m *Model) setMark(name string) bool {
+	var mark session.Mark
+	var label string
+	switch m.activePanel {
+	case CollectionsPanel:
+		node := m.leftPanel.GetCollections().Selected()
+		if node == nil {
+			m.statusBar.Info("Nothing to mark")
+			return false
+		}
+		mark = session.Mark{Panel: markPanelCollections, NodeID: node.ID}
+		label = node.Name
+	case RequestPanel:
+		requestID := m.requestPanel.GetCurrentRequestID()
+		if requestID == "" {
+			m.statusBar.Info("Only requests of a collection can be marked")
+			return false
+		}
+		state := m.requestPanel.GetSessionState()
+		mark = session.Mark{Panel: markPanelRequest, NodeID: requestID, Tab: state.ActiveTab}
+		label = fmt.Sprintf("%s %s", m.requestPanel.GetMethod(), m.requestPanel.GetURL())
+		if state.ActiveTab == "body" && state.BodyCursor != nil {
+			mark.Cursor = state.BodyCursor
+			label = fmt.Sprintf("%s (body %d:%d)", label, state.BodyCursor.Line+1, state.BodyCursor.Column+1)
+		}
+	default:
+		return false
+	}
+
+	if m.session.Marks == nil {
+		m.session.Marks = make(map[string]session.Mark)
+	}
+	m.session.Marks[name] = mark
+	m.statusBar.Success("Mark '"+name, label)
+	return true
+}
+
+// jumpToMark shows the position saved under name: it selects the node in the tree,
+// loads its request and, for a request mark, restores the tab and body cursor
+func (m *Model) jumpToMark(name string) (Model, tea.Cmd) {
+	mark, ok := m.session.Marks[name]
+	if !ok {
+		m.statusBar.Info(fmt.Sprintf("Mark '%s is not set", name))
+		return *m, nil
+	}
+
+	collections := m.leftPanel.GetCollections()
+	revealed := collections.RevealNode(mark.NodeID)
+	req := collections.FindRequestByID(mark.NodeID)
+	if !revealed && req == nil {
+		m.statusBar.Error(fmt.Errorf("mark '%s: %s no longer exists", name, mark.NodeID))
+		return *m, nil
+	}
+
+	// Keep unsaved edits when the request is already loaded
+	if req != nil && req.ID != m.requestPanel.GetCurrentRequestID() {
+		if m.loadsOver(req.ID) {
+			return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.jumpToMark(name) }})
+		}
+		if !m.showRequestTab(req.ID) {
+			m.requestPanel.LoadCollectionRequest(req)
+		}
+		m.statusBar.SetMethod(string(req.Method))
+		if revealed {
+			m.statusBar.SetBreadcrumb(buildBreadcrumb(collections.Selected())...)
+		}
+	}
+
+	if mark.Panel == markPanelRequest && req != nil {
+		m.requestPanel.SetPosition(mark.Tab, mark.Cursor)
+		m.activePanel = RequestPanel
+	} else {
+		m.leftPanel.SetActiveTab(CollectionsTab)
+		m.activePanel = CollectionsPanel
+	}
+	if m.isFullscreen {
+		m.fullscreenPanel = m.activePanel
+	}
+	return *m, m.markSessionDirty()
+}
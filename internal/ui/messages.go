@@ -2,6 +2,10 @@ package ui
 
 import (
 	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+	"github.com/kbrdn1/LazyCurl/internal/import/postman"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
 )
 
 // CurlImportedMsg is sent when a cURL command is successfully imported
@@ -43,6 +47,26 @@ type HideOpenAPIImportModalMsg struct{}
 // OpenAPISpinnerTickMsg is sent to animate the import spinner
 type OpenAPISpinnerTickMsg struct{}
 
+// OpenAPISpecLoadedMsg is sent when a spec URL has been downloaded for import
+type OpenAPISpecLoadedMsg struct {
+	Importer *api.OpenAPIImporter
+	Error    error
+}
+
+// OpenAPISyncPreviewMsg is sent when a collection has been compared with the latest version of its spec
+type OpenAPISyncPreviewMsg struct {
+	Collection *api.CollectionFile // Re-synced copy of the collection, not saved yet
+	Result     api.OpenAPISyncResult
+	Error      error
+}
+
+// OpenAPISyncedMsg is sent when a re-synced collection has been saved
+type OpenAPISyncedMsg struct {
+	Collection *api.CollectionFile
+	Result     api.OpenAPISyncResult
+	Error      error
+}
+
 // OpenAPIImportCompleteMsg is sent when async import completes
 type OpenAPIImportCompleteMsg struct {
 	Collection *api.CollectionFile
@@ -58,6 +82,12 @@ type PostmanImportedMsg struct {
 	IsEnv       bool
 }
 
+// PostmanBatchImportedMsg is sent when the Postman files of a directory or glob pattern
+// are imported
+type PostmanBatchImportedMsg struct {
+	Batch *postman.BatchResult
+}
+
 // PostmanExportedMsg is sent when a collection/environment is exported
 type PostmanExportedMsg struct {
 	Success  bool
@@ -69,3 +99,116 @@ type PostmanExportedMsg struct {
 type PostmanImportErrorMsg struct {
 	Error error
 }
+
+// ResponseTableChangedMsg is sent when the response table view or its column selection changes
+type ResponseTableChangedMsg struct{}
+
+// ResponseExportCSVMsg requests exporting the response table to a CSV file
+type ResponseExportCSVMsg struct{}
+
+// CheckRequiredVariablesMsg requests validating the active environment against the collections' required variables
+type CheckRequiredVariablesMsg struct{}
+
+// ResponseQuerySaveMsg requests saving a JSONPath query result into an environment variable
+type ResponseQuerySaveMsg struct {
+	Value string
+}
+
+// ResponseValueSaveMsg requests saving the JSON value under the cursor of the response
+// body into a variable
+type ResponseValueSaveMsg struct {
+	Path  string // JSONPath of the value; empty when the cursor is not on one
+	Key   string // Name of the member holding the value
+	Value string
+}
+
+// ResponseFollowLinkMsg requests a GET request for a link of an HTML response
+type ResponseFollowLinkMsg struct {
+	Page *format.HTMLPage
+	Link format.HTMLLink
+}
+
+// ResponseSubmitFormMsg requests a request submitting a form of an HTML response
+type ResponseSubmitFormMsg struct {
+	Page *format.HTMLPage
+	Form format.HTMLForm
+}
+
+// ResponseSaveToFileMsg requests saving the response body to a file
+type ResponseSaveToFileMsg struct{}
+
+// ResponseSavedToFileMsg is sent when the response body has been written to a file
+type ResponseSavedToFileMsg struct {
+	FilePath string
+	Bytes    int64
+	Error    error
+}
+
+// RequestCopyAsCodeMsg requests copying the current request as a code snippet
+type RequestCopyAsCodeMsg struct{}
+
+// ResponseCSVExportedMsg is sent when the response table has been written to a CSV file
+type ResponseCSVExportedMsg struct {
+	FilePath string
+	Rows     int
+	Error    error
+}
+
+// ResponseFixtureComparedMsg is sent when the response body has been compared with a fixture file
+type ResponseFixtureComparedMsg struct {
+	Path    string
+	Diff    *format.BodyDiff
+	Missing bool // The fixture file does not exist
+	Error   error
+}
+
+// ResponseFixtureOverwriteMsg requests overwriting the compared fixture file with the response body
+type ResponseFixtureOverwriteMsg struct {
+	Path string
+}
+
+// ResponseFixtureSavedMsg is sent when the response body has been written to a fixture file
+type ResponseFixtureSavedMsg struct {
+	Path  string
+	Error error
+}
+
+// DoctorReportMsg is sent when a :doctor connectivity diagnosis completes
+type DoctorReportMsg struct {
+	Report *api.DoctorReport
+}
+
+// RevealRequestMsg moves the Collections panel cursor to a request
+type RevealRequestMsg struct {
+	RequestID string
+}
+
+// RunnerStepMsg is sent when the collection runner completes a request
+type RunnerStepMsg struct {
+	RunID  int
+	Index  int
+	Result runner.RequestResult
+}
+
+// FolderSendStepMsg is sent when a request sent with its folder completes
+type FolderSendStepMsg struct {
+	SendID int
+	Index  int
+	Result runner.RequestResult
+}
+
+// RunnerRerunMsg requests running the requests of the runner view again
+type RunnerRerunMsg struct{}
+
+// GRPCMethodsLoadedMsg is sent when the methods of a gRPC server have been listed by reflection
+type GRPCMethodsLoadedMsg struct {
+	Target  grpc.Target
+	Methods []*grpc.Method
+	Error   error
+}
+
+// GRPCMethodSelectedMsg requests calling a gRPC method from the current request
+type GRPCMethodSelectedMsg struct {
+	URL    string // grpc://host:port/package.Service/Method
+	Method *grpc.Method
+}
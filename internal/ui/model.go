@@ -4,20 +4,31 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
-	"golang.design/x/clipboard"
 
 	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+	"github.com/kbrdn1/LazyCurl/internal/clipboard"
+	"github.com/kbrdn1/LazyCurl/internal/codegen"
 	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+	"github.com/kbrdn1/LazyCurl/internal/import/postman"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/internal/secrets"
 	"github.com/kbrdn1/LazyCurl/internal/session"
+	"github.com/kbrdn1/LazyCurl/internal/stats"
 	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+	"github.com/kbrdn1/LazyCurl/internal/vcs"
 	"github.com/kbrdn1/LazyCurl/pkg/styles"
 )
 
@@ -45,8 +56,32 @@ const response = pm.response.json();`
 type HTTPResponseMsg struct {
 	Response *api.Response
 	Error    error
+	SendID   int // Send the response answers
 }
 
+// EventStreamStartedMsg is sent when a request answers with a Server-Sent Events stream
+type EventStreamStartedMsg struct {
+	Response *api.Response // Response head, without body
+	Stream   *api.EventStream
+	SendID   int // Send the stream answers
+}
+
+// EventStreamEventMsg is sent for every event received on a stream
+type EventStreamEventMsg struct {
+	Stream *api.EventStream
+	Event  api.SSEEvent
+}
+
+// EventStreamClosedMsg is sent when a stream ends, with the whole stream as body
+type EventStreamClosedMsg struct {
+	Stream   *api.EventStream
+	Response *api.Response
+	Error    error
+}
+
+// EventStreamStopMsg asks to stop the stream of the current response
+type EventStreamStopMsg struct{}
+
 // HTTPSendingMsg is sent when an HTTP request starts
 type HTTPSendingMsg struct{}
 
@@ -60,6 +95,7 @@ type PreRequestScriptResultMsg struct {
 	Error          error
 	OriginalReq    *api.Request
 	PreRequestBody string // Original body before script modification
+	SendID         int    // Send the script runs for
 }
 
 // PostResponseScriptResultMsg is sent when post-response script execution completes
@@ -75,25 +111,69 @@ func loaderTickCmd() tea.Cmd {
 	})
 }
 
-// SendHTTPRequestCmd creates a command to send an HTTP request
+// SendHTTPRequestCmd creates a command to send an HTTP request, or to call a gRPC method
 func SendHTTPRequestCmd(req *api.Request) tea.Cmd {
 	return func() tea.Msg {
+		if req.Method == api.GRPC {
+			resp, stream, err := grpc.SendStream(req)
+			if stream != nil {
+				return GRPCStreamStartedMsg{Response: resp, Stream: stream}
+			}
+			return HTTPResponseMsg{Response: resp, Error: err}
+		}
 		client := api.NewClient()
-		resp, err := client.Send(req)
+		resp, stream, err := client.SendStream(req)
+		if stream != nil {
+			return EventStreamStartedMsg{Response: resp, Stream: stream}
+		}
+		return HTTPResponseMsg{Response: resp, Error: err}
+	}
+}
+
+// WaitEventStreamCmd creates a command waiting for the next event of a stream
+func WaitEventStreamCmd(stream *api.EventStream) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-stream.Events
+		if !ok {
+			return EventStreamClosedMsg{Stream: stream, Response: stream.Response(), Error: stream.Err()}
+		}
+		return EventStreamEventMsg{Stream: stream, Event: event}
+	}
+}
+
+// MockResponseCmd creates a command that answers with a mock rule's canned response
+func MockResponseCmd(response api.MockResponse) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := response.Build()
+		if err == nil && resp.Time > 0 {
+			time.Sleep(resp.Time)
+		}
+		return HTTPResponseMsg{Response: resp, Error: err}
+	}
+}
+
+// ChaosSendCmd creates a command that sends an HTTP request with a chaos fault applied
+func ChaosSendCmd(injector *api.ChaosInjector, req *api.Request, fault api.ChaosFault, cfg api.ChaosConfig) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := injector.SendWithChaos(api.NewClient(), req, fault, cfg)
 		return HTTPResponseMsg{Response: resp, Error: err}
 	}
 }
 
+// RunnerStepCmd creates a command running the request at index of a collection run
+func RunnerStepCmd(r *runner.Runner, runID, index int, item runner.Item) tea.Cmd {
+	return func() tea.Msg {
+		return RunnerStepMsg{RunID: runID, Index: index, Result: r.RunRequest(item)}
+	}
+}
+
 // ExecutePreRequestScriptCmd creates a command to execute pre-request script
-func ExecutePreRequestScriptCmd(executor api.ScriptExecutor, script string, req *api.Request, envFile *api.EnvironmentFile) tea.Cmd {
+func ExecutePreRequestScriptCmd(executor api.ScriptExecutor, script string, req *api.Request, env *api.Environment) tea.Cmd {
 	return func() tea.Msg {
 		// Convert api.Request to api.ScriptRequest
 		scriptReq := api.NewScriptRequestFromHTTP(req)
 		originalBody := scriptReq.Body()
 
-		// Convert EnvironmentFile to Environment for executor
-		env := api.EnvironmentFromFile(envFile)
-
 		// Execute the pre-request script
 		result, err := executor.ExecutePreRequest(script, scriptReq, env)
 
@@ -108,11 +188,8 @@ func ExecutePreRequestScriptCmd(executor api.ScriptExecutor, script string, req
 }
 
 // ExecutePostResponseScriptCmd creates a command to execute post-response script
-func ExecutePostResponseScriptCmd(executor api.ScriptExecutor, script string, req *api.ScriptRequest, resp *api.ScriptResponse, envFile *api.EnvironmentFile) tea.Cmd {
+func ExecutePostResponseScriptCmd(executor api.ScriptExecutor, script string, req *api.ScriptRequest, resp *api.ScriptResponse, env *api.Environment) tea.Cmd {
 	return func() tea.Msg {
-		// Convert EnvironmentFile to Environment for executor
-		env := api.EnvironmentFromFile(envFile)
-
 		// Execute the post-response script
 		result, err := executor.ExecutePostResponse(script, req, resp, env)
 
@@ -134,6 +211,30 @@ type requestDialogContext struct {
 	Value string
 }
 
+// completeHeader completes the name and value of a header in the key-value dialog
+func completeHeader(key, value string, valueField bool) []string {
+	if valueField {
+		return api.HeaderValueCompletions(key, value)
+	}
+	return api.HeaderNameCompletions(key)
+}
+
+// requiredVarsContext tracks the guided dialog filling missing required variables
+type requiredVarsContext struct {
+	Pending []api.VariableRequirement
+	Index   int // Requirement currently asked for
+	Filled  int // Number of variables saved so far
+}
+
+// promptVarsContext tracks the dialog asking for the prompt variables ({{?name}}) of a send or run
+type promptVarsContext struct {
+	Names  []string
+	Index  int               // Variable currently asked for
+	Values map[string]string // Values entered so far, by name
+	Title  string            // Title of the run to start
+	Items  []runner.Item     // Requests of the run to start, nil for a send
+}
+
 const (
 	CollectionsPanel PanelType = iota
 	RequestPanel
@@ -153,11 +254,14 @@ type Model struct {
 	ready       bool
 	zoneManager *zone.Manager
 	layoutMode  LayoutMode
+	resizeSeq   int // Counts resizes, to redraw once they settle
 
 	// Panels
 	leftPanel     *LeftPanel
-	requestPanel  *RequestView
-	responsePanel *ResponseView
+	requestPanel  *RequestView  // Request panel of the active tab
+	responsePanel *ResponseView // Response panel of the active tab
+	tabs          []*requestTab // Requests open in tabs (:tabnew)
+	activeTab     int
 
 	// Mode system
 	mode         Mode
@@ -168,19 +272,54 @@ type Model struct {
 	// Dialog and WhichKey
 	dialog   *components.Dialog
 	whichKey *components.WhichKey
+	keys     config.KeyBindings // Key bindings in effect, from the config and the keymap file
+
+	// File picker of form-data file fields and binary bodies
+	filePicker  *components.FilePicker
+	lastFileDir string // Directory of the last file picked
+
+	// Search palette finding requests across collections
+	searchPalette *SearchPalette
+
+	// Command palette listing every action
+	commandPalette *CommandPalette
+
+	// Progress of the binary body being uploaded, nil when not sending a file
+	uploadProgress *api.UploadProgress
 
 	// HTTP client
-	httpClient *api.Client
-	isSending  bool
+	httpClient  *api.Client
+	sends       *sendTracker     // Sends in flight, and sends queued behind a send of the same request
+	eventStream *api.EventStream // Open Server-Sent Events stream of the current response
+	grpcStream  *grpc.Stream     // Open gRPC streaming call of the current response
+	streamSend  *pendingSend     // Send answered by eventStream or grpcStream
 
 	// Fullscreen mode
 	isFullscreen    bool
 	fullscreenPanel PanelType
 
+	// Mock mode: requests with a matching mock rule get its canned response
+	mockMode bool
+
+	// Chaos mode: inject latency, dropped connections or 5xx responses into sends
+	chaosMode     bool
+	chaosConfig   api.ChaosConfig
+	chaosInjector *api.ChaosInjector
+
+	// Set when the missing variables dialog is canceled so sends are not interrupted again
+	requiredVarsDismissed bool
+
+	// Values entered for the prompt variables ({{?name}}) of the next send
+	pendingPrompts map[string]string
+
 	// Console history
-	consoleHistory *api.ConsoleHistory
-	lastRequest    *api.Request // Track the last sent request for console logging
-	requestStart   time.Time    // Track when request started for duration calculation
+	consoleHistory   *api.ConsoleHistory
+	historyRetention api.HistoryRetention // Limits applied after each send
+	archiveHistory   bool                 // Archive the entries dropped by the retention
+	consoleEntryID   string               // Console entry of the latest send, for its script output
+
+	// Last background git status check of the branch segment
+	gitCheckedAt time.Time
 
 	// Session persistence
 	session          *session.Session
@@ -190,9 +329,63 @@ type Model struct {
 	importModal        *ImportModalModel
 	openAPIImportModal *OpenAPIImportModal
 
+	// Collection runner
+	runnerView   *RunnerView
+	activeRunner *runner.Runner
+	job          *jobRun // Async job run in the runner view (:job), nil for collection runs
+
+	// Requests of a folder sent in place (S in the Collections tree)
+	folderSend    *folderSend
+	folderSendSeq int
+
+	// Request re-sent at an interval (:poll), nil when not polling
+	poll    *responsePoll
+	pollSeq int
+
+	// Interactive :tutorial
+	tutorial *Tutorial
+
+	// Local usage statistics (store is nil unless enabled in the workspace config)
+	stats     *stats.Store
+	statsView *StatsView
+
+	// Response time chart of a request (:latency)
+	latencyView *LatencyView
+
+	// Methods of a gRPC server (:grpc)
+	grpcView *GRPCView
+
+	// Global and workspace config editor (:settings)
+	settingsView *SettingsView
+
+	// Picker of the recent workspaces (:ws list)
+	workspaceView *WorkspaceView
+
+	// Idle lock of the workspace (:lock)
+	lockScreen *LockScreen
+	lastInput  time.Time // Time of the last key or mouse input
+	lockSeq    int       // Sequence of the latest scheduled LockCheckMsg
+
+	// Collection and environment files as last checked for changes made outside LazyCurl
+	watched      fileStamps
+	watcher      *fileWatcher // Notifies the changes of the files, nil when they are polled
+	watchPending bool         // Whether a change waits for a dialog or the lock screen to close
+
+	// Keys pressed last, for the key sequences of tabs
+	lastKey     string
+	previousKey string
+
+	// Screen state hiding secret values (protect_secrets)
+	blurred  bool // Whether the terminal lost focus
+	quitting bool // Whether LazyCurl is exiting
+
+	// First key of a mark command ("m" sets a mark, "'" jumps to one), waiting for its letter
+	pendingMark string
+
 	// External editor state
 	externalEditorActive bool              // Whether external editor is currently open
 	externalEditorInfo   *api.TempFileInfo // Temp file info for cleanup
+	scriptsEdit          *scriptsEdit      // Collection or folder script open in the external editor
 
 	// Script execution
 	scriptExecutor         api.ScriptExecutor
@@ -201,8 +394,15 @@ type Model struct {
 	postResponseConsole    []api.ConsoleLogEntry // Console output from post-response script
 	preRequestAssertions   []api.AssertionResult // Assertions from pre-request script
 	postResponseAssertions []api.AssertionResult // Assertions from post-response script
-	pendingScriptReq       *api.ScriptRequest    // Script request stored for post-response script
-	postResponseScript     string                // Post-response script to execute after HTTP response
+
+	// Command left running by the activation hook of the active environment
+	hookProcess *api.HookProcess
+
+	// Script sessions (lc.globals, lc.cookies) of isolated collections, by file path
+	sessionExecutors map[string]api.ScriptExecutor
+
+	// Workspace globals (.lazycurl/globals.json), the least specific variable scope
+	globals *api.Globals
 }
 
 // NewModel creates a new application model
@@ -224,41 +424,102 @@ func NewModel(globalConfig *config.GlobalConfig, workspaceConfig *config.Workspa
 		activePanel = ResponsePanel
 	}
 
+	// Secret variables are kept in the OS keychain when enabled, before environments load
+	var keychainErr error
+	if workspaceConfig.Keychain {
+		api.SecretStore, keychainErr = secrets.Keychain()
+	}
+
+	// The workspace proxy overrides the global one; without either the environment variables apply
+	var proxyErr error
+	if proxy := config.EffectiveProxy(globalConfig, workspaceConfig); proxy != nil {
+		api.DefaultProxy = &api.Proxy{URL: proxy.URL, NoProxy: proxy.NoProxy}
+		if proxyErr = api.DefaultProxy.Validate(); proxyErr != nil {
+			api.DefaultProxy = nil
+		}
+	}
+
+	// CA files and client certificates of the workspace
+	var tlsErr error
+	if workspaceConfig.TLS != nil {
+		api.DefaultTLS = NewTLSConfig(workspacePath, workspaceConfig.TLS)
+		if tlsErr = api.DefaultTLS.Validate(); tlsErr != nil {
+			api.DefaultTLS = nil
+		}
+	}
+
+	// Units of durations and sizes
+	api.DefaultUnits = displayUnits(globalConfig.Units)
+
+	// Colors of the theme and badges of custom HTTP methods, before the panels render them
+	themeErr := ApplyTheme(globalConfig.Theme)
+
+	// Key bindings of the config, overridden by the keymap file
+	keys, keysErr := loadKeyBindings(globalConfig)
+	whichKey := components.NewWhichKey()
+	whichKey.SetKeyMap(keys.ByAction())
+
 	// Create panels
 	leftPanel := NewLeftPanel(workspacePath)
-	requestPanel := NewRequestView()
-	responsePanel := NewResponseView()
+	leftPanel.GetCollections().SetKeyMap(treeKeyMap(keys))
 
 	// Apply session state to panels
 	leftPanel.SetSessionState(sess.Panels.Collections)
-	requestPanel.SetSessionState(sess.Panels.Request)
-	responsePanel.SetSessionState(sess.Panels.Response)
 
 	// Restore active environment
 	if sess.ActiveEnvironment != "" {
 		leftPanel.GetEnvironments().SetActiveEnvironmentName(sess.ActiveEnvironment)
 	}
 
-	// Restore active request (find in tree and load FULL request from collection)
-	if sess.ActiveRequest != "" {
-		collections := leftPanel.GetCollections().GetCollections()
-		for _, coll := range collections {
-			if req := coll.FindRequest(sess.ActiveRequest); req != nil {
-				requestPanel.LoadCollectionRequest(req)
-				break
-			}
-		}
-	}
+	// Restore the open requests (loading the FULL request from its collection)
+	tabs, activeTab := openSessionTabs(sess, leftPanel.GetCollections())
+	requestPanel, responsePanel := tabs[activeTab].request, tabs[activeTab].response
 
 	// Create status bar and set initial state
 	statusBar := NewStatusBar("v1.0.0")
 	if sess.ActiveEnvironment != "" {
 		statusBar.SetEnvironment(sess.ActiveEnvironment)
 	}
+	statusBar.SetProxy(api.ActiveProxy())
+	layout := config.EffectiveStatusBar(globalConfig)
+	statusBar.SetLayout(layout)
+	if unknown := layout.UnknownSegments(); len(unknown) > 0 {
+		statusBar.Error(fmt.Errorf("unknown status bar segments: %s", strings.Join(unknown, ", ")))
+	}
+	statusBar.SetWorkspace(workspaceConfig.Name)
+	if statusBar.Shows(config.SegmentBranch) {
+		statusBar.SetBranch(vcs.Branch(workspacePath))
+	}
+	if proxyErr != nil {
+		statusBar.Error(proxyErr)
+	}
+	if tlsErr != nil {
+		statusBar.Error(fmt.Errorf("TLS config ignored: %w", tlsErr))
+	}
+	if keychainErr != nil {
+		statusBar.Error(fmt.Errorf("secrets stay in environment files: %w", keychainErr))
+	}
+	if themeErr != nil {
+		statusBar.Error(fmt.Errorf("theme: %w", themeErr))
+	}
+	if keysErr != nil {
+		statusBar.Error(keysErr)
+	}
 
 	// Collections directory for OpenAPI import
 	collectionsDir := filepath.Join(workspacePath, ".lazycurl", "collections")
 
+	globals, _ := api.LoadGlobals(workspacePath)                   // A broken globals file starts empty
+	api.DefaultScriptStore, _ = api.LoadScriptStore(workspacePath) // So does a broken lc.store file
+	api.DefaultScriptModulesDir = api.ScriptModulesDir(workspacePath)
+
+	// Usage statistics are opt-in; a file that fails to load is replaced on the next save
+	var usage *stats.Store
+	if workspaceConfig.Stats {
+		usage, _ = stats.Load(workspacePath)
+	}
+	retention, archiveHistory := historyRetention(globalConfig)
+
 	return Model{
 		globalConfig:       globalConfig,
 		workspaceConfig:    workspaceConfig,
@@ -268,34 +529,183 @@ func NewModel(globalConfig *config.GlobalConfig, workspaceConfig *config.Workspa
 		leftPanel:          leftPanel,
 		requestPanel:       requestPanel,
 		responsePanel:      responsePanel,
+		tabs:               tabs,
+		activeTab:          activeTab,
 		mode:               NormalMode,
 		jumpMode:           NewJumpMode(),
 		statusBar:          statusBar,
 		commandInput:       NewCommandInput(),
 		dialog:             components.NewDialog(),
-		whichKey:           components.NewWhichKey(),
+		whichKey:           whichKey,
+		keys:               keys,
+		filePicker:         components.NewFilePicker(),
+		searchPalette:      NewSearchPalette(),
+		commandPalette:     NewCommandPalette(),
 		httpClient:         api.NewClient(),
-		isSending:          false,
-		consoleHistory:     api.NewConsoleHistory(1000),
+		sends:              newSendTracker(),
+		consoleHistory:     loadHistory(workspacePath, retention, archiveHistory),
+		historyRetention:   retention,
+		archiveHistory:     archiveHistory,
 		session:            sess,
 		importModal:        NewImportModal(),
 		openAPIImportModal: NewOpenAPIImportModal(collectionsDir),
+		runnerView:         NewRunnerView(),
+		tutorial:           NewTutorial(),
+		stats:              usage,
+		statsView:          NewStatsView(),
+		latencyView:        NewLatencyView(),
+		grpcView:           NewGRPCView(),
+		settingsView:       NewSettingsView(),
+		workspaceView:      NewWorkspaceView(),
+		lockScreen:         NewLockScreen(),
+		watched:            stampFiles(workspacePath),
+		watcher:            watchFiles(workspacePath),
+		lastInput:          time.Now(),
 		scriptExecutor:     api.NewScriptExecutor(),
+		sessionExecutors:   make(map[string]api.ScriptExecutor),
+		chaosConfig:        api.DefaultChaosConfig(),
+		chaosInjector:      api.NewChaosInjector(time.Now().UnixNano()),
+		globals:            globals,
 	}
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	// Initialize clipboard (ignore error - clipboard may not be available on all systems)
-	_ = clipboard.Init()
-	return nil
+	// Without a system clipboard (or over SSH), copies go to the terminal with OSC 52
+	clipboard.Init(m.globalConfig.Clipboard, os.Stdout)
+	// Check the active environment against the collections' required variables
+	return tea.Batch(func() tea.Msg {
+		return CheckRequiredVariablesMsg{}
+	}, statusBarTick(m.statusBar.GetLayout(), time.Now()), m.gitStatusOnStart(),
+		lockCheck(m.workspaceConfig.AutoLock(), m.lockSeq), m.waitFileChange())
+}
+
+// gitStatusOnStart returns a command checking the git state for the branch segment
+func (m Model) gitStatusOnStart() tea.Cmd {
+	if !m.statusBar.Shows(config.SegmentBranch) || m.statusBar.branch == "" {
+		return nil
+	}
+	return gitStatusCmd(m.workspacePath, false, "")
 }
 
-// Update handles messages and updates the model
+// Update handles messages and updates the model, then checks the progress of an active :tutorial
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if next, ok := model.(Model); ok && next.tutorial.IsActive() {
+		next.advanceTutorial()
+		return next, cmd
+	}
+	return model, cmd
+}
+
+// update handles messages and updates the model
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Update WhichKey context based on current state
 	m.updateWhichKeyContext()
 
+	// Input of the locked workspace only reaches the passphrase prompt
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		m.lastInput = time.Now()
+		if m.lockScreen.IsVisible() {
+			return m.handleLockedInput(msg)
+		}
+		if key, ok := msg.(tea.KeyMsg); ok {
+			m.previousKey, m.lastKey = m.lastKey, key.String() // For the two-key gt and ]b
+		}
+	}
+
+	// Responses to the sends of a hidden tab are shown in its panels
+	if send := m.sendOf(msg); send != nil && send.tab != nil && !m.shows(send.tab) {
+		return m.updateInTab(send.tab, msg)
+	}
+
+	// Event streams are read while modals are open so they never stall
+	switch msg := msg.(type) {
+	case LockCheckMsg:
+		return m.handleLockCheck(msg)
+
+	case WatchEventMsg:
+		return m.handleWatchEvent(msg)
+
+	case WatchTickMsg:
+		return m.handleWatchTick()
+
+	case PassphraseEnteredMsg:
+		return m.handlePassphraseEntered(msg)
+
+	case tea.BlurMsg:
+		// Terminal focus is reported with protect_secrets, which hides secrets meanwhile
+		m.blurred = true
+		return m, nil
+
+	case tea.FocusMsg:
+		m.blurred = false
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		// Resizes are laid out even under modals, which would keep a stale size otherwise
+		return m.handleResize(msg)
+
+	case ResizeSettledMsg:
+		if msg.Seq != m.resizeSeq {
+			return m, nil // Still resizing
+		}
+		return m, tea.ClearScreen
+
+	case EventStreamStartedMsg:
+		// Server-Sent Events response: show events as they arrive until the stream ends
+		send, latest := m.sends.finish(msg.SendID)
+		if !latest {
+			// Stream of a send replaced by a newer one
+			msg.Stream.Close()
+			return m, m.sendNextQueued(send)
+		}
+		m.uploadProgress = nil
+		m.eventStream = msg.Stream
+		m.streamSend = send
+		m.responsePanel.SetRequestID(send.requestID)
+		m.responsePanel.SetResponse(
+			msg.Response.StatusCode,
+			msg.Response.Status,
+			joinHeaders(msg.Response.Headers),
+			map[string]string{},
+			nil,
+			formatDuration(msg.Response.Time),
+			formatBytes(0),
+		)
+		m.responsePanel.StartEventStream()
+		m.activePanel = ResponsePanel
+		m.statusBar.Info("Streaming events... (x: stop)")
+		next := m.sendNextQueued(send)
+		return m, tea.Batch(WaitEventStreamCmd(msg.Stream), next)
+
+	case EventStreamEventMsg:
+		if msg.Stream != m.eventStream {
+			return m, nil // Stream replaced by a newer send
+		}
+		m.responsePanel.AppendEvent(msg.Event)
+		return m, WaitEventStreamCmd(msg.Stream)
+
+	case EventStreamClosedMsg:
+		if msg.Stream != m.eventStream {
+			return m, nil
+		}
+		send := m.streamSend
+		m.eventStream = nil
+		m.streamSend = nil
+		m.responsePanel.EndEventStream()
+		// Finish like any response so the stream is logged, tested and kept as body
+		next, cmd := m.completeSend(send, true, HTTPResponseMsg{Response: msg.Response})
+		if msg.Error != nil {
+			next.statusBar.Error(fmt.Errorf("stream interrupted: %w", msg.Error))
+		}
+		return next, cmd
+
+	case GRPCStreamStartedMsg, GRPCStreamMessageMsg, GRPCStreamSendMsg, GRPCStreamSentMsg, GRPCStreamCloseSendMsg, GRPCStreamClosedMsg:
+		return m.handleGRPCStream(msg)
+	}
+
 	// Handle WhichKey modal input first if visible
 	if m.whichKey.IsVisible() {
 		switch msg := msg.(type) {
@@ -312,8 +722,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.importModal, cmd = m.importModal.Update(msg)
 			return m, cmd
-		case tea.WindowSizeMsg:
-			m.importModal.SetSize(msg.Width, msg.Height)
 		}
 		return m, nil
 	}
@@ -325,16 +733,101 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.openAPIImportModal, cmd = m.openAPIImportModal.Update(msg)
 			return m, cmd
-		case tea.WindowSizeMsg:
-			m.openAPIImportModal.SetSize(msg.Width, msg.Height)
+		case OpenAPISpinnerTickMsg, OpenAPISpecLoadedMsg, OpenAPIImportCompleteMsg, OpenAPISyncPreviewMsg:
+			// Background loading and import progress
+			var cmd tea.Cmd
+			m.openAPIImportModal, cmd = m.openAPIImportModal.Update(msg)
+			return m, cmd
 		}
 		return m, nil
 	}
 
+	// Handle collection runner input if visible (run progress messages are handled below)
+	if m.runnerView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.runnerView, cmd = m.runnerView.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle stats view input if visible
+	if m.statsView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.statsView, cmd = m.statsView.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle latency chart input if visible
+	if m.latencyView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.latencyView, cmd = m.latencyView.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle gRPC methods input if visible
+	if m.grpcView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.grpcView, cmd = m.grpcView.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle settings input if visible
+	if m.settingsView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.settingsView, cmd = m.settingsView.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle workspace picker input if visible
+	if m.workspaceView.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.workspaceView, cmd = m.workspaceView.Update(msg)
+			return m, cmd
+		}
+	}
+
 	// Handle environment modal input first if visible
 	if m.leftPanel.GetEnvironments().HasActiveModal() {
+		previous := m.leftPanel.GetEnvironments().GetActiveEnvironmentName()
 		*m.leftPanel.GetEnvironments(), _ = m.leftPanel.GetEnvironments().Update(msg, m.globalConfig)
-		return m, nil
+		return m, m.environmentActivated(previous)
+	}
+
+	// Handle search palette input first if visible
+	if m.searchPalette.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.searchPalette, cmd = m.searchPalette.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle command palette input first if visible
+	if m.commandPalette.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.commandPalette, cmd = m.commandPalette.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Handle file picker input first if visible
+	if m.filePicker.IsVisible() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.filePicker, cmd = m.filePicker.Update(msg)
+			return m, cmd
+		}
 	}
 
 	// Handle dialog input first if visible
@@ -376,7 +869,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case components.EditorQuitMsg:
 		// Editor requested to quit the application (Q key in NORMAL mode)
-		return m.saveSessionAndQuit()
+		return m.quit()
 
 	case components.ExternalEditorRequestMsg:
 		// Handle external editor request
@@ -390,6 +883,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			_ = api.CleanupTempFile(m.externalEditorInfo)
 			m.externalEditorInfo = nil
 		}
+		if msg.Field == api.EditableFieldPreRequestScript || msg.Field == api.EditableFieldPostResponseScript {
+			return m.handleScriptsEdited(msg)
+		}
 		// Show status message
 		if msg.Err != nil {
 			m.statusBar.Error(msg.Err)
@@ -406,6 +902,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case components.ExternalEditorErrorMsg:
 		// Handle external editor error
 		m.externalEditorActive = false
+		m.scriptsEdit = nil
 		// Cleanup temp file if present
 		if m.externalEditorInfo != nil {
 			_ = api.CleanupTempFile(m.externalEditorInfo)
@@ -415,6 +912,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusBar.Error(msg.Err)
 		return m, nil
 
+	case StatusBarTickMsg:
+		return m.handleStatusBarTick()
+
+	case GitStatusMsg:
+		return m.handleGitStatus(msg)
+
+	case GitCommitMsg:
+		return m.handleGitCommit(msg)
+
+	case SettingChangedMsg:
+		return m.handleSettingChanged(msg)
+
+	case WorkspaceActionMsg:
+		return m.handleWorkspaceCommand([]string{msg.Action, msg.Path})
+
 	case SessionSaveTickMsg:
 		// Handle debounced session save
 		// Only save if this tick matches the current dirty time (debounce)
@@ -428,35 +940,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleDialogResult(msg)
 
 	case tea.KeyMsg:
-		// CTRL+C always quits (save session first)
+		// CTRL+C quits, once confirmed when requests have unsaved edits (save session first)
 		if msg.String() == "ctrl+c" {
-			return m.saveSessionAndQuit()
+			return m.quit()
 		}
 
 		// CTRL+S sends HTTP request from ANY context (global handler)
-		if msg.String() == "ctrl+s" {
+		if m.matchKey(msg.String(), m.keys.SendRequest) {
 			return m.sendHTTPRequest()
 		}
 
 		// CTRL+I opens import cURL modal (global handler)
-		if m.matchKey(msg.String(), m.globalConfig.KeyBindings.ImportCurl) {
+		if m.matchKey(msg.String(), m.keys.ImportCurl) {
 			m.importModal.SetSize(m.width, m.height)
 			m.importModal.Show()
 			return m, nil
 		}
 
 		// CTRL+O opens import OpenAPI modal (global handler)
-		if m.matchKey(msg.String(), m.globalConfig.KeyBindings.ImportOpenAPI) {
-			m.openAPIImportModal.SetSize(m.width, m.height)
-			m.openAPIImportModal.Show()
+		if m.matchKey(msg.String(), m.keys.ImportOpenAPI) {
+			m.showOpenAPIImport("")
 			return m, nil
 		}
 
 		// CTRL+E exports current request as cURL (global handler)
-		if m.matchKey(msg.String(), m.globalConfig.KeyBindings.ExportCurl) {
+		if m.matchKey(msg.String(), m.keys.ExportCurl) {
 			return m.exportCurlCommand()
 		}
 
+		// CTRL+K searches the requests of all collections (global handler)
+		if m.matchKey(msg.String(), m.keys.SearchAll) {
+			return m.showSearchPalette("")
+		}
+
+		// CTRL+P lists every action in the command palette (global handler)
+		if m.matchKey(msg.String(), m.keys.CommandPalette) {
+			return m.showCommandPalette()
+		}
+
+		// CTRL+L redraws a garbled screen (global handler)
+		if m.matchKey(msg.String(), m.keys.Redraw) {
+			return m.redraw()
+		}
+
 		// Handle COMMAND mode input first (forward all keys except escape)
 		if m.mode == CommandMode {
 			if msg.String() == "esc" {
@@ -474,6 +1000,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Query bar or gRPC send box has focus - forward all keys to it (including esc)
+		if m.activePanel == ResponsePanel && (m.responsePanel.IsQueryEditing() || m.responsePanel.IsSendEditing()) {
+			var cmd tea.Cmd
+			*m.responsePanel, cmd = m.responsePanel.UpdateWithHistory(msg, m.globalConfig, m.consoleHistory)
+			return m, cmd
+		}
+
+		// Letter of a mark command (any other key cancels it)
+		if m.pendingMark != "" {
+			return m.completeMark(msg.String())
+		}
+
 		// Handle Escape key - exit fullscreen, jump mode, or return to NORMAL mode
 		if msg.String() == "esc" {
 			// Exit jump mode first if active
@@ -521,8 +1059,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Check if request panel is editing URL - if so, forward all keys to it
-		if m.activePanel == RequestPanel && m.requestPanel.IsEditingURL() {
+		// m{a-z} sets a mark and '{a-z} jumps to one
+		if m.startsMark(msg.String()) {
+			m.pendingMark = msg.String()
+			return m, nil
+		}
+
+		// gt and ]b show the next tab, gT and [b the previous one
+		if delta := tabKey(m.previousKey, msg.String()); delta != 0 && m.acceptsTabKeys() {
+			return m.cycleTab(delta)
+		}
+
+		// Check if request panel is editing URL or a Settings field - if so, forward all keys to it
+		if m.activePanel == RequestPanel && (m.requestPanel.IsEditingURL() || m.requestPanel.IsSettingsEditing() || m.requestPanel.IsBodyBuilderEditing()) {
 			var cmd tea.Cmd
 			*m.requestPanel, cmd = m.requestPanel.Update(msg, m.globalConfig)
 			return m, cmd
@@ -558,31 +1107,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Handle mode transitions from NORMAL mode
 		if m.mode == NormalMode {
-			switch msg.String() {
-			case "c", "i":
-				// In Collections panel, c/i edits the selected request
-				// In other panels, i enters INSERT mode
-				if m.activePanel == CollectionsPanel {
-					// Let the panel handle c/i for editing requests
-					// This will be forwarded to tree.go which emits TreeEditRequestMsg
-					break
-				}
-				// Only 'i' enters INSERT mode (not 'c')
-				if msg.String() == "i" {
-					m.mode = InsertMode
-					m.statusBar.SetMode(InsertMode)
-					return m, func() tea.Msg {
-						return ModeChangeMsg{From: NormalMode, To: InsertMode}
-					}
+			key := msg.String()
+			// i enters INSERT mode; in the Collections panel the tree handles c/i to
+			// edit the selected request (TreeEditRequestMsg)
+			if m.matchKey(key, m.keys.InsertMode) && m.activePanel != CollectionsPanel {
+				m.mode = InsertMode
+				m.statusBar.SetMode(InsertMode)
+				return m, func() tea.Msg {
+					return ModeChangeMsg{From: NormalMode, To: InsertMode}
 				}
-			case "v":
+			}
+			if m.matchKey(key, m.keys.ViewMode) {
 				// Transition to VIEW mode
 				m.mode = ViewMode
 				m.statusBar.SetMode(ViewMode)
 				return m, func() tea.Msg {
 					return ModeChangeMsg{From: NormalMode, To: ViewMode}
 				}
-			case ":":
+			}
+			if m.matchKey(key, m.keys.CommandMode) {
 				// Transition to COMMAND mode and show input
 				m.mode = CommandMode
 				m.statusBar.SetMode(CommandMode)
@@ -593,32 +1136,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Check for quit in NORMAL mode
-			if m.matchKey(msg.String(), m.globalConfig.KeyBindings.Quit) {
-				return m.saveSessionAndQuit()
+			if m.matchKey(key, m.keys.Quit) {
+				return m.quit()
 			}
 
 			// ? to show WhichKey modal
-			if msg.String() == "?" {
+			if m.matchKey(key, m.keys.WhichKey) {
 				m.whichKey.Show()
 				return m, nil
 			}
 
 			// f to activate jump mode (current panel only)
-			if msg.String() == "f" {
+			if m.matchKey(key, m.keys.Jump) {
 				return m.activateJumpMode(false)
 			}
 
 			// F (Shift+f) to activate cross-panel jump mode
-			if msg.String() == "F" {
+			if m.matchKey(key, m.keys.JumpAll) {
 				return m.activateJumpMode(true)
 			}
 
 			// Z to toggle fullscreen (zoom) for current panel
-			if msg.String() == "Z" {
+			if m.matchKey(key, m.keys.Fullscreen) {
 				m.toggleFullscreen()
 				return m, nil
 			}
 
+			// CTRL+W saves the request, like :w
+			if m.matchKey(key, m.keys.SaveRequest) {
+				m.statusBar.Success("Saved", "request")
+				return m, nil
+			}
+
+			// e switches the left panel between collections and environments
+			if m.matchKey(key, m.keys.ToggleEnvs) {
+				if m.leftPanel.GetActiveTab() == CollectionsTab {
+					m.leftPanel.SetActiveTab(EnvironmentsTab)
+				} else {
+					m.leftPanel.SetActiveTab(CollectionsTab)
+				}
+				return m, nil
+			}
+
+			// Panel focus keys (unbound by default)
+			for panel, keys := range map[PanelType][]string{
+				CollectionsPanel: m.keys.FocusCollections,
+				RequestPanel:     m.keys.FocusRequest,
+				ResponsePanel:    m.keys.FocusResponse,
+			} {
+				if m.matchKey(key, keys) {
+					m.activePanel = panel
+					if m.isFullscreen {
+						m.fullscreenPanel = m.activePanel
+					}
+					return m, m.markSessionDirty()
+				}
+			}
+
 			// Tab switching with 1/2 (when left panel is active)
 			if m.activePanel == CollectionsPanel {
 				if msg.String() == "1" {
@@ -637,7 +1211,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// IMPORTANT: In CollectionsPanel, let the tree handle l/h first for expand/collapse
 			if m.mode.AllowsNavigation() && !m.leftPanel.IsSearching() {
 				// Left navigation (h) - in CollectionsPanel, only navigate if at root level collapsed folder
-				if m.matchKey(msg.String(), m.globalConfig.KeyBindings.NavigateLeft) {
+				if m.matchKey(msg.String(), m.keys.NavigateLeft) {
 					// In CollectionsPanel, h should collapse folders, not navigate panels
 					// Only navigate panels from Request or Response panels
 					if m.activePanel > CollectionsPanel {
@@ -651,7 +1225,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// In CollectionsPanel, let tree handle h for collapse
 				}
 				// Right navigation (l) - in CollectionsPanel, let tree handle it
-				if m.matchKey(msg.String(), m.globalConfig.KeyBindings.NavigateRight) {
+				if m.matchKey(msg.String(), m.keys.NavigateRight) {
 					// In CollectionsPanel, l should expand folders or select requests
 					// Only navigate panels from Request panel
 					if m.activePanel == RequestPanel {
@@ -672,7 +1246,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.mode == ViewMode {
 			if m.mode.AllowsNavigation() {
 				// Same logic as NORMAL mode - let tree handle h/l in CollectionsPanel
-				if m.matchKey(msg.String(), m.globalConfig.KeyBindings.NavigateLeft) {
+				if m.matchKey(msg.String(), m.keys.NavigateLeft) {
 					if m.activePanel > CollectionsPanel {
 						m.activePanel--
 						if m.isFullscreen {
@@ -681,7 +1255,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, m.markSessionDirty()
 					}
 				}
-				if m.matchKey(msg.String(), m.globalConfig.KeyBindings.NavigateRight) {
+				if m.matchKey(msg.String(), m.keys.NavigateRight) {
 					if m.activePanel == RequestPanel {
 						m.activePanel++
 						if m.isFullscreen {
@@ -706,7 +1280,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			found := false
 			for _, coll := range collections {
 				if req := coll.FindRequest(msg.Node.ID); req != nil {
-					m.requestPanel.LoadCollectionRequest(req)
+					if m.loadsOver(req.ID) {
+						return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.update(msg) }})
+					}
+					// A request open in another tab shows that tab, and unsaved edits are kept
+					if !m.showRequestTab(req.ID) && !m.requestPanel.IsDirty() {
+						m.requestPanel.LoadCollectionRequest(req)
+					}
 					found = true
 					break
 				}
@@ -751,9 +1331,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case components.RequestNode:
 				nodeType = "request"
 			}
+			message := "Are you sure you want to delete '" + msg.Node.Name + "'?"
+			if n := m.leftPanel.GetCollections().LinkCount(msg.Node); n > 0 {
+				message += fmt.Sprintf(" %d linked request(s) will become copies.", n)
+			}
 			m.dialog.ShowConfirm(
 				"Delete "+nodeType,
-				"Are you sure you want to delete '"+msg.Node.Name+"'?",
+				message,
 				"delete",
 				msg.Node,
 			)
@@ -790,6 +1374,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case components.TreeRunMsg:
+		// Run every request of the collection/folder in the runner view
+		if msg.Node != nil {
+			return m.startCollectionRun(msg.Node)
+		}
+		return m, nil
+
+	case components.TreeSendAllMsg:
+		// Send every request of the collection/folder, marking them in the tree
+		if msg.Node != nil {
+			return m.startFolderSend(msg.Node)
+		}
+		return m, nil
+
+	case FolderSendStepMsg:
+		return m.handleFolderSendStep(msg)
+
+	case RunnerRerunMsg:
+		if m.job != nil {
+			return m.startJob(m.job.col, m.job.job.Spec.Name)
+		}
+		return m.runItems(m.runnerView.Title(), m.runnerView.Items(), nil)
+
+	case RunnerStepMsg:
+		if !m.runnerView.AddResult(msg.RunID, msg.Index, msg.Result) {
+			return m, nil
+		}
+		m.leftPanel.GetCollections().SetLastSendFailed(msg.Result.Item.Request.ID, resultFailed(msg.Result))
+
+		if m.job != nil && m.job.runID == msg.RunID {
+			return m.handleJobStep(msg)
+		}
+
+		m.applyEnvChanges(msg.Result.EnvChanges)
+
+		if next, ok := m.runnerView.Next(); ok {
+			return m, RunnerStepCmd(m.activeRunner, msg.RunID, next, m.runnerView.Items()[next])
+		}
+
+		summary := m.runnerView.Summary()
+		if summary.Failed == 0 {
+			m.statusBar.Success("Run", fmt.Sprintf("%d/%d passed in %s", summary.Passed, summary.Total, formatDuration(summary.Duration)))
+		} else {
+			m.statusBar.ShowMessage(fmt.Sprintf("⚠ Run: %d/%d passed", summary.Passed, summary.Total), 3*time.Second)
+		}
+		return m, nil
+
 	case components.TreeYankMsg:
 		// Handle yank (copy) to clipboard
 		if msg.Node != nil {
@@ -798,21 +1429,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case components.TreePasteMsg:
-		// Handle paste from clipboard with smart targeting
-		clipboard := m.leftPanel.GetCollections().GetClipboard()
-		if clipboard == nil {
-			m.statusBar.Info("Nothing to paste")
+	case components.TreeCutMsg:
+		// Handle cut to clipboard, moved by the next paste
+		if msg.Node == nil {
 			return m, nil
 		}
-
-		if err := m.leftPanel.GetCollections().PasteNode(clipboard, msg.TargetNode); err != nil {
-			m.statusBar.Error(err)
+		if msg.Node.Type == components.CollectionNode {
+			m.statusBar.Info("Collections cannot be moved")
 			return m, nil
 		}
-
-		m.statusBar.Success("Pasted", clipboard.Name)
-		m.leftPanel.GetCollections().ReloadCollections()
+		m.leftPanel.GetCollections().CutNode(msg.Node)
+		m.statusBar.Success("Cut", msg.Node.Name+" (p to move it)")
+		return m, nil
+
+	case components.TreeMoveMsg:
+		// Handle reordering among siblings
+		if msg.Node != nil {
+			if err := m.leftPanel.GetCollections().MoveSibling(msg.Node, msg.Offset); err != nil {
+				m.statusBar.Error(err)
+			}
+		}
+		return m, nil
+
+	case components.TreePasteMsg:
+		// Handle paste from clipboard with smart targeting
+		clipboard := m.leftPanel.GetCollections().GetClipboard()
+		if clipboard == nil {
+			m.statusBar.Info("Nothing to paste")
+			return m, nil
+		}
+
+		if m.leftPanel.GetCollections().IsCut() {
+			if msg.Link {
+				m.statusBar.Info("Cut requests are moved with p, yank them to link them")
+				return m, nil
+			}
+			if err := m.leftPanel.GetCollections().MoveNode(clipboard, msg.TargetNode); err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+			m.leftPanel.GetCollections().SetClipboard(nil)
+			m.statusBar.Success("Moved", clipboard.Name)
+			return m, nil
+		}
+
+		if err := m.leftPanel.GetCollections().PasteNode(clipboard, msg.TargetNode, msg.Link); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+
+		if msg.Link {
+			m.statusBar.Success("Linked", clipboard.Name)
+		} else {
+			m.statusBar.Success("Pasted", clipboard.Name)
+		}
+		m.leftPanel.GetCollections().ReloadCollections()
 		return m, nil
 
 	// === REQUEST PANEL MESSAGES ===
@@ -847,6 +1518,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"request_edit",
 			&requestDialogContext{Tab: msg.Tab, Index: msg.Index},
 		)
+		if msg.Tab == "Headers" {
+			m.dialog.SetCompleter(completeHeader)
+		}
 		return m, nil
 
 	case RequestNewMsg:
@@ -858,11 +1532,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"request_new",
 			&requestDialogContext{Tab: msg.Tab},
 		)
+		if msg.Tab == "Headers" {
+			m.dialog.SetCompleter(completeHeader)
+		}
 		return m, nil
 
 	case RequestDuplicateMsg:
 		// Handle duplicate - directly duplicate without dialog
 		m.requestPanel.DuplicateRow(msg.Index)
+		if msg.Tab == "Body" {
+			m.saveFormData()
+		} else if msg.Tab == "Headers" {
+			m.saveHeaders()
+		} else if msg.Tab == "Capture" {
+			m.saveCaptureRules()
+		}
 		m.statusBar.Success("Duplicated", "entry")
 		return m, nil
 
@@ -880,11 +1564,73 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.requestPanel.AddRow(clipboard.Key+"_copy", clipboard.Value)
+		if msg.Tab == "Body" {
+			m.saveFormData()
+		} else if msg.Tab == "Headers" {
+			m.saveHeaders()
+		} else if msg.Tab == "Capture" {
+			m.saveCaptureRules()
+		}
 		m.statusBar.Success("Pasted", clipboard.Key)
 		return m, nil
 
+	case RequestFilePickMsg:
+		// Choose the file of a form-data field, starting next to its current file
+		dir := m.lastFileDir
+		if msg.Path != "" {
+			dir = filepath.Dir(api.ExpandHome(msg.Path))
+		}
+		m.filePicker.Show("Choose a file", dir, "form_file", msg.Index)
+		return m, nil
+
+	case RequestBinaryPickMsg:
+		// Choose the file of a binary body, starting next to the current one
+		dir := m.lastFileDir
+		if msg.Path != "" && !strings.Contains(msg.Path, "{{") {
+			dir = filepath.Dir(api.ExpandHome(msg.Path))
+		}
+		m.filePicker.Show("Choose the body file", dir, "binary_file", nil)
+		return m, nil
+
+	case RequestBinaryEditMsg:
+		m.dialog.ShowInput(
+			"Body File",
+			"Path of the file sent as the body ({{variables}} allowed):",
+			msg.Path,
+			"binary_path",
+			nil,
+		)
+		return m, nil
+
+	case SearchPaletteSelectMsg:
+		return m.openSearchResult(msg.RequestID)
+
+	case PaletteActionMsg:
+		return m.runPaletteAction(msg.Action)
+
+	case ActivateEnvironmentMsg:
+		return m.activateEnvironment(msg.Name)
+
+	case components.FilePickerResultMsg:
+		m.lastFileDir = filepath.Dir(msg.Path)
+		switch msg.Action {
+		case "form_file":
+			index, _ := msg.Context.(int)
+			m.requestPanel.SetFormFile(index, msg.Path)
+			m.saveFormData()
+			m.statusBar.Success("File", filepath.Base(msg.Path))
+		case "binary_file":
+			m.requestPanel.SetBinaryPath(msg.Path)
+			m.saveBinaryBody()
+			m.statusBar.Success("Body file", filepath.Base(msg.Path))
+		}
+		return m, nil
+
 	case RequestURLChangedMsg:
 		// Handle URL change from request panel
+		if m.holdEdit(editURL) {
+			return m, nil
+		}
 		requestID := m.requestPanel.GetCurrentRequestID()
 		if requestID != "" {
 			if err := m.leftPanel.GetCollections().UpdateRequestURLByID(requestID, msg.URL); err != nil {
@@ -897,34 +1643,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case RequestParamToggleMsg:
-		// Handle param toggle - sync URL and save
+		// Handle param toggle - sync URL and save, or save the headers or Capture rules
+		if msg.Tab == "Params" {
+			m.syncParamsAndSave()
+		} else if msg.Tab == "Headers" {
+			m.saveHeaders()
+		} else if msg.Tab == "Capture" {
+			m.saveCaptureRules()
+		}
+		return m, nil
+
+	case RequestBulkEditedMsg:
+		// Bulk edit applied - sync the query params to the URL and save
 		if msg.Tab == "Params" {
 			m.syncParamsAndSave()
+			m.statusBar.Success("Updated", fmt.Sprintf("%d query params", msg.Rows))
+		} else {
+			m.saveHeaders()
+			m.statusBar.Success("Updated", fmt.Sprintf("%d headers", msg.Rows))
 		}
 		return m, nil
 
 	case RequestBodyChangedMsg:
 		// Handle body content change - save to collection
+		m.saveBody(msg.BodyType, msg.Content)
+		return m, nil
+
+	case RequestBodyBuiltMsg:
+		// Body generated by the body builder - save it like an edit of the body
+		if msg.Err != nil {
+			m.statusBar.Error(msg.Err)
+			return m, nil
+		}
+		m.statusBar.Success("Body built", fmt.Sprintf("%d fields", msg.Fields))
+		m.saveBody(msg.BodyType, msg.Content)
+		return m, nil
+
+	case RequestScriptsChangedMsg:
+		// Handle scripts content change - save to collection
+		if m.holdEdit(editScripts) {
+			return m, nil
+		}
 		requestID := m.requestPanel.GetCurrentRequestID()
 		if requestID != "" {
-			if err := m.leftPanel.GetCollections().UpdateRequestBodyByID(requestID, msg.BodyType, msg.Content); err != nil {
+			if err := m.leftPanel.GetCollections().UpdateRequestScriptsByID(requestID, msg.PreRequest, msg.PostRequest); err != nil {
 				m.statusBar.Error(err)
 			}
 		}
 		return m, nil
 
-	case RequestScriptsChangedMsg:
-		// Handle scripts content change - save to collection
+	case RequestDescriptionChangedMsg:
+		// Handle docs change - save to collection
+		if m.holdEdit(editDocs) {
+			return m, nil
+		}
 		requestID := m.requestPanel.GetCurrentRequestID()
 		if requestID != "" {
-			if err := m.leftPanel.GetCollections().UpdateRequestScriptsByID(requestID, msg.PreRequest, msg.PostRequest); err != nil {
+			if err := m.leftPanel.GetCollections().UpdateRequestDescriptionByID(requestID, msg.Description); err != nil {
 				m.statusBar.Error(err)
+			} else {
+				m.statusBar.Success("Saved", "docs")
 			}
 		}
 		return m, nil
 
 	case RequestAuthChangedMsg:
 		// Handle auth configuration change - save to collection
+		if m.holdEdit(editAuth) {
+			return m, nil
+		}
 		requestID := m.requestPanel.GetCurrentRequestID()
 		if requestID != "" {
 			if err := m.leftPanel.GetCollections().UpdateRequestAuthByID(requestID, msg.Auth); err != nil {
@@ -933,30 +1720,86 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case RequestSettingsChangedMsg:
+		// Handle timeout, retry policy and override changes - save to collection
+		if msg.Err != nil {
+			m.statusBar.Error(msg.Err)
+			return m, nil
+		}
+		if m.holdEdit(editSettings) {
+			return m, nil
+		}
+		requestID := m.requestPanel.GetCurrentRequestID()
+		if requestID != "" {
+			collections := m.leftPanel.GetCollections()
+			if err := collections.UpdateRequestSettingsByID(requestID, msg.Timeout, msg.Retry); err != nil {
+				m.statusBar.Error(err)
+			} else if err := collections.UpdateRequestOverridesByID(requestID, msg.ServerName, msg.Host); err != nil {
+				m.statusBar.Error(err)
+			}
+		}
+		return m, nil
+
 	case ResendRequestMsg:
 		// Resend a request from console history
-		if msg.Request != nil {
-			m.isSending = true
-			m.lastRequest = msg.Request
-			m.requestStart = time.Now()
-			m.responsePanel.ClearResponse()
-			m.responsePanel.SetLoading(true)
-			m.statusBar.Info("Resending request...")
-			return m, tea.Batch(SendHTTPRequestCmd(msg.Request), loaderTickCmd())
+		req := msg.Request
+		variables := msg.Variables
+		if msg.CurrentEnv {
+			if msg.Source == nil {
+				m.statusBar.Info("This entry can only be resent with its original values")
+				return m, nil
+			}
+			// Re-resolve the original request against the current environment
+			environments := m.leftPanel.GetEnvironments()
+			built, err := buildHTTPRequestFrom(msg.Source, m.requestVariables(msg.Source.ID), m.variableTypes(msg.Source.ID))
+			if err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+			req = built
+			variables = api.NewVariableSnapshot(msg.Source, environments.GetActiveEnvironment())
+		}
+		if req != nil {
+			send := &pendingSend{request: req, source: msg.Source, variables: variables}
+			if msg.Source != nil {
+				send.requestID = msg.Source.ID
+			}
+			if m.sends.busy(send.requestID) {
+				return m, m.submitSend(send)
+			}
+			cmd := m.startSend(send)
+			if msg.CurrentEnv {
+				m.statusBar.Info("Resending request with current environment...")
+			} else {
+				m.statusBar.Info("Resending request...")
+			}
+			return m, cmd
 		}
 		return m, nil
 
 	case CopyToClipboardMsg:
 		// Copy content to clipboard
 		if msg.Content != "" {
-			clipboard.Write(clipboard.FmtText, []byte(msg.Content))
-			// Note: clipboard.Write doesn't return an error in this library version
-			m.statusBar.Success("Copied", msg.Label)
+			m.statusBar.Success("Copied", msg.Label+copyMethodNote(clipboard.Copy(msg.Label, msg.Content)))
 		} else {
 			m.statusBar.Info("Nothing to copy")
 		}
 		return m, nil
 
+	case EnvironmentHookMsg:
+		return m.handleEnvironmentHook(msg)
+
+	case ConsoleDiffMsg:
+		return m.diffConsoleEntries(msg)
+
+	case EnvironmentErrorMsg:
+		m.statusBar.Error(msg.Err)
+		return m, nil
+
+	case EnvironmentDotenvMsg:
+		m.showDotenvDialog(msg)
+		return m, nil
+
 	case ConsoleStatusMsg:
 		// Display status message from console
 		switch msg.Type {
@@ -974,6 +1817,168 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.responsePanel.tabs.SetActive(3) // Console is tab index 3
 		return m, nil
 
+	case ResponseTableChangedMsg:
+		// Persist table view mode and per-request column selection
+		return m, m.markSessionDirty()
+
+	case ResponseExportCSVMsg:
+		// Ask for the destination file of the CSV export
+		if !m.responsePanel.HasTable() {
+			m.statusBar.Info("Response is not tabular")
+			return m, nil
+		}
+		m.dialog.ShowInput(
+			"Export CSV",
+			"Save table as:",
+			"response.csv",
+			"export_csv",
+			nil,
+		)
+		return m, nil
+
+	case CheckRequiredVariablesMsg:
+		m.promptMissingVariables(m.leftPanel.GetCollections().GetCollections()...)
+		return m, nil
+
+	case ResponseQuerySaveMsg:
+		// Ask for the variable receiving the query result
+		m.askVariableName("the query result", "", msg.Value)
+		return m, nil
+
+	case ResponseValueSaveMsg:
+		// Ask for the variable receiving the value under the cursor
+		if msg.Path == "" {
+			m.statusBar.Info("The cursor is not on a JSON value")
+			return m, nil
+		}
+		m.askVariableName(msg.Path, msg.Key, msg.Value)
+		return m, nil
+
+	case ResponseFollowLinkMsg:
+		// Create a GET request for the selected link of the HTML response
+		return m.openFollowUpRequest(linkRequest(msg.Page, m.responseURL(), msg.Link, m.responseSource()))
+
+	case ResponseSubmitFormMsg:
+		// Create a request submitting the selected form of the HTML response
+		return m.openFollowUpRequest(formRequest(msg.Page, m.responseURL(), msg.Form, m.responseSource()))
+
+	case ResponseSaveToFileMsg:
+		// Ask for the destination file of the response body
+		if m.responsePanel.GetStatusCode() == 0 {
+			m.statusBar.Info("No response to save")
+			return m, nil
+		}
+		m.dialog.ShowInput(
+			"Save Response",
+			"Save body as:",
+			m.responseFileName(),
+			"save_response",
+			nil,
+		)
+		return m, nil
+
+	case ResponseSavedToFileMsg:
+		if msg.Error != nil {
+			m.statusBar.Error(msg.Error)
+		} else {
+			m.statusBar.Success("Saved", api.FormatSize(msg.Bytes)+" to "+msg.FilePath)
+		}
+		return m, nil
+
+	case RequestCopyAsCodeMsg:
+		// Ask which language to generate the snippet in
+		if m.requestPanel.GetURL() == "" {
+			m.statusBar.Info("No request to export")
+			return m, nil
+		}
+		m.dialog.ShowInput(
+			"Copy as Code",
+			"Language (curl, fetch, python, httpie):",
+			string(codegen.Curl),
+			"copy_as_code",
+			nil,
+		)
+		return m, nil
+
+	case GRPCMethodsLoadedMsg:
+		m.grpcView.SetMethods(msg.Target, msg.Methods, msg.Error)
+		return m, nil
+
+	case GRPCMethodSelectedMsg:
+		return m.useGRPCMethod(msg)
+
+	case DoctorReportMsg:
+		m.responsePanel.SetDoctorReport(msg.Report)
+		m.activePanel = ResponsePanel
+		if msg.Report.OK() {
+			m.statusBar.Success("Diagnosed", msg.Report.Host+": "+msg.Report.Summary())
+		} else {
+			m.statusBar.Error(fmt.Errorf("doctor: %s", msg.Report.Summary()))
+		}
+		return m, nil
+
+	case RevealRequestMsg:
+		if m.leftPanel.GetCollections().RevealNode(msg.RequestID) {
+			m.activePanel = CollectionsPanel
+		}
+		return m, nil
+
+	case ResponseFixtureOverwriteMsg:
+		m.dialog.ShowConfirm(
+			"Overwrite Fixture",
+			"Replace "+msg.Path+" with the response body?",
+			"overwrite_fixture",
+			msg.Path,
+		)
+		return m, nil
+
+	case ResponseFixtureComparedMsg:
+		// Show the diff, or offer to create a missing fixture from the body
+		switch {
+		case msg.Missing:
+			m.dialog.ShowConfirm(
+				"Create Fixture",
+				msg.Path+" does not exist. Save the response body to it?",
+				"overwrite_fixture",
+				msg.Path,
+			)
+		case msg.Error != nil:
+			m.statusBar.Error(msg.Error)
+		default:
+			m.responsePanel.SetBodyDiff(msg.Path, msg.Diff)
+			m.activePanel = ResponsePanel
+			if msg.Diff.Equal() {
+				m.statusBar.Success("Compared", "body matches "+msg.Path)
+			} else {
+				m.statusBar.Info(fmt.Sprintf("%d differences with %s", len(msg.Diff.Changes), msg.Path))
+			}
+		}
+		return m, nil
+
+	case ResponseFixtureSavedMsg:
+		if msg.Error != nil {
+			m.statusBar.Error(msg.Error)
+			return m, nil
+		}
+		m.statusBar.Success("Saved fixture", msg.Path)
+		return m.compareWithFixture(msg.Path)
+
+	case InventoryExportedMsg:
+		if msg.Error != nil {
+			m.statusBar.Error(msg.Error)
+		} else {
+			m.statusBar.Success("Exported", fmt.Sprintf("%d requests to %s", msg.Requests, msg.FilePath))
+		}
+		return m, nil
+
+	case ResponseCSVExportedMsg:
+		if msg.Error != nil {
+			m.statusBar.Error(msg.Error)
+		} else {
+			m.statusBar.Success("Exported", fmt.Sprintf("%d rows to %s", msg.Rows, msg.FilePath))
+		}
+		return m, nil
+
 	case SwitchToResponseTabMsg:
 		// Switch response panel to Body tab
 		m.responsePanel.tabs.SetActive(0) // Body is tab index 0
@@ -992,6 +1997,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case CurlImportedMsg:
 		// Handle successful cURL import
+		if msg.Request != nil && m.requestPanel.IsDirty() {
+			return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.update(msg) }})
+		}
 		if msg.Request != nil {
 			m.requestPanel.LoadCollectionRequest(msg.Request)
 			m.statusBar.Success("Imported", msg.Request.Name)
@@ -1013,8 +2021,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ShowOpenAPIImportModalMsg:
 		// Show the OpenAPI import modal
-		m.openAPIImportModal.SetSize(m.width, m.height)
-		m.openAPIImportModal.Show()
+		m.showOpenAPIImport("")
 		return m, nil
 
 	case HideOpenAPIImportModalMsg:
@@ -1035,6 +2042,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case OpenAPISyncedMsg:
+		// Handle a collection re-synced with its OpenAPI spec
+		if msg.Error != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save collection: %w", msg.Error))
+			return m, nil
+		}
+		if msg.Result.Empty() {
+			m.statusBar.Info(msg.Collection.Name + " is up to date with its spec")
+			return m, nil
+		}
+		m.leftPanel.GetCollections().ReloadCollections()
+		m.statusBar.Success("Synced", fmt.Sprintf("%s (+%d -%d operations)", msg.Collection.Name, len(msg.Result.Added), len(msg.Result.Removed)))
+		return m, nil
+
 	case PostmanImportedMsg:
 		// Handle successful Postman import
 		if msg.IsEnv {
@@ -1061,6 +2082,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+
+	case PostmanBatchImportedMsg:
+		return m.handlePostmanBatchImported(msg)
+
 	case PostmanExportedMsg:
 		// Handle Postman export result
 		if msg.Error != nil {
@@ -1077,31 +2102,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case HTTPSendingMsg:
 		// HTTP request is being sent
-		m.isSending = true
 		m.statusBar.Info("Sending request...")
 		m.responsePanel.ClearResponse()
 		m.responsePanel.SetLoading(true)
 		return m, loaderTickCmd()
 
 	case LoaderTickMsg:
-		// Animate the loader if still loading
-		if m.responsePanel.IsLoading() {
-			m.responsePanel.TickLoader()
+		// Animate the loaders of the tabs still loading
+		loading := false
+		for _, tab := range m.tabs {
+			if tab.response.IsLoading() {
+				tab.response.TickLoader()
+				loading = true
+			}
+		}
+		if loading {
+			if m.uploadProgress != nil && m.uploadProgress.Total() > 0 {
+				m.statusBar.Info("Uploading " + m.uploadProgress.String())
+			}
 			return m, loaderTickCmd()
 		}
 		return m, nil
 
 	case PreRequestScriptResultMsg:
 		// Pre-request script completed
+		if !m.sends.isLatest(msg.SendID) {
+			// The send was replaced by a newer one before reaching the network
+			send, _ := m.sends.finish(msg.SendID)
+			return m, m.sendNextQueued(send)
+		}
 		if msg.Error != nil {
-			m.isSending = false
+			send, _ := m.sends.finish(msg.SendID)
 			m.responsePanel.SetLoading(false)
 			m.statusBar.Error(fmt.Errorf("pre-request script error: %w", msg.Error))
 			// Store error info for display
 			if msg.Result != nil && msg.Result.Error != nil {
 				m.preRequestConsole = msg.Result.ConsoleOutput
 			}
-			return m, nil
+			return m, m.sendNextQueued(send)
 		}
 
 		// Store console output and assertions from pre-request script
@@ -1131,7 +2169,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							delete(env.Variables, change.Name)
 						}
 					}
-					if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(); err != nil {
+					if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(api.EnvSourcePreScript); err != nil {
 						m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
 					}
 				}
@@ -1156,11 +2194,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Store the script request for post-response script
-		m.pendingScriptReq = msg.ModifiedReq
+		send := m.sends.inFlight[msg.SendID]
+		if send == nil {
+			return m, nil
+		}
+		send.scriptRequest = msg.ModifiedReq
 
 		// Now send the actual HTTP request
 		m.statusBar.Info("Sending request...")
-		return m, tea.Batch(SendHTTPRequestCmd(modifiedReq), loaderTickCmd())
+		return m, tea.Batch(withSendID(m.sendRequestCmd(send, modifiedReq), msg.SendID), loaderTickCmd())
 
 	case PostResponseScriptResultMsg:
 		// Post-response script completed
@@ -1171,6 +2213,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Store console output and assertions from post-response script
 		if msg.Result != nil {
 			m.postResponseConsole = msg.Result.ConsoleOutput
+			if m.consoleHistory != nil && len(msg.Result.ConsoleOutput) > 0 {
+				m.consoleHistory.AddLogs(m.consoleEntryID, msg.Result.ConsoleOutput)
+				m.saveHistory()
+			}
 			m.postResponseAssertions = msg.Result.Assertions
 			m.lastScriptResult = msg.Result
 
@@ -1197,7 +2243,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 					// Save the environment changes
-					if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(); err != nil {
+					if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(api.EnvSourcePostScript); err != nil {
 						m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
 					}
 				}
@@ -1230,122 +2276,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
-	case HTTPResponseMsg:
-		// HTTP response received
-		m.isSending = false
-		m.responsePanel.SetLoading(false)
-		duration := time.Since(m.requestStart)
-
-		// Log to console history
-		if m.lastRequest != nil && m.consoleHistory != nil {
-			entry := api.NewConsoleEntry(m.lastRequest, msg.Response, msg.Error, duration)
-			m.consoleHistory.Add(*entry)
+	case EventStreamStopMsg:
+		if m.eventStream != nil {
+			m.eventStream.Close()
 		}
-
-		if msg.Error != nil {
-			m.statusBar.Error(msg.Error)
-			return m, nil
+		if m.grpcStream != nil {
+			m.grpcStream.Close()
 		}
-		if msg.Response != nil {
-			// Parse headers into simple map
-			headers := make(map[string]string)
-			for key, values := range msg.Response.Headers {
-				if len(values) > 0 {
-					headers[key] = strings.Join(values, ", ")
-				}
-			}
-
-			// Parse cookies from Set-Cookie headers
-			cookies := make(map[string]string)
-			if cookieHeaders, ok := msg.Response.Headers["Set-Cookie"]; ok {
-				for _, cookie := range cookieHeaders {
-					// Parse "name=value; attributes" format
-					parts := strings.SplitN(cookie, "=", 2)
-					if len(parts) == 2 {
-						name := parts[0]
-						valueParts := strings.SplitN(parts[1], ";", 2)
-						cookies[name] = valueParts[0]
-					}
-				}
-			}
-
-			// Format time and size
-			timeStr := formatDuration(msg.Response.Time)
-			sizeStr := formatBytes(msg.Response.Size)
-
-			// Update response panel
-			m.responsePanel.SetResponse(
-				msg.Response.StatusCode,
-				msg.Response.Status,
-				headers,
-				cookies,
-				msg.Response.Body,
-				timeStr,
-				sizeStr,
-			)
+		return m, nil
 
-			// Update status bar with HTTP status
-			statusText := ""
-			switch {
-			case msg.Response.StatusCode >= 200 && msg.Response.StatusCode < 300:
-				statusText = "OK"
-			case msg.Response.StatusCode >= 300 && msg.Response.StatusCode < 400:
-				statusText = "Redirect"
-			case msg.Response.StatusCode >= 400 && msg.Response.StatusCode < 500:
-				statusText = "Client Error"
-			case msg.Response.StatusCode >= 500:
-				statusText = "Server Error"
-			}
-			m.statusBar.SetHTTPStatus(msg.Response.StatusCode, statusText)
-
-			// Focus response panel
-			m.activePanel = ResponsePanel
-			m.statusBar.Success("Response", fmt.Sprintf("%d %s in %s", msg.Response.StatusCode, statusText, timeStr))
-
-			// Execute post-response script if present
-			if m.postResponseScript != "" && !isDefaultScript(m.postResponseScript, "post") {
-				// Build ScriptResponse from HTTP response using factory function
-				scriptResp := api.NewScriptResponseFromData(
-					msg.Response.StatusCode,
-					msg.Response.Status,
-					headers,
-					msg.Response.Body,
-					msg.Response.Time.Milliseconds(),
-				)
-
-				// Get active environment
-				env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	case HTTPResponseMsg:
+		return m.handleHTTPResponse(msg)
 
-				// Use pendingScriptReq if available, otherwise create from lastRequest
-				scriptReq := m.pendingScriptReq
-				if scriptReq == nil && m.lastRequest != nil {
-					scriptReq = api.NewScriptRequestFromHTTP(m.lastRequest)
-				}
+	case PollTickMsg:
+		return m.handlePollTick(msg)
 
-				m.statusBar.Info("Running post-response script...")
-				return m, ExecutePostResponseScriptCmd(m.scriptExecutor, m.postResponseScript, scriptReq, scriptResp, env)
-			}
-		}
-		return m, nil
+	case JobWaitMsg:
+		return m.handleJobWait(msg)
 
 	case CommandExecuteMsg:
 		// Handle command execution
 		return m.handleCommand(msg)
 
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.ready = true
-		// Update layout mode based on terminal size
-		m.layoutMode = m.detectLayoutMode()
-		return m, nil
 	}
 
 	// Update active panel (pass mode context)
 	var cmd tea.Cmd
 	switch m.activePanel {
 	case CollectionsPanel:
+		previous := m.leftPanel.GetEnvironments().GetActiveEnvironmentName()
 		*m.leftPanel, cmd = m.leftPanel.Update(msg, m.globalConfig)
+		if hook := m.environmentActivated(previous); hook != nil {
+			cmd = tea.Batch(cmd, hook)
+		}
 	case RequestPanel:
 		*m.requestPanel, cmd = m.requestPanel.Update(msg, m.globalConfig)
 	case ResponsePanel:
@@ -1426,7 +2389,7 @@ func (m Model) renderVerticalLayout() string {
 		topRightHeight-2,
 		m.activePanel == RequestPanel,
 	)
-	requestPanel := m.renderPanel("Request", requestContent, rightWidth, topRightHeight, m.activePanel == RequestPanel)
+	requestPanel := m.renderPanel(m.requestPanelTitle(rightWidth), requestContent, rightWidth, topRightHeight, m.activePanel == RequestPanel)
 
 	// Response panel (bottom right)
 	responseContent := m.responsePanel.ViewWithHistory(
@@ -1488,7 +2451,7 @@ func (m Model) renderHorizontalLayout() string {
 		requestHeight-2,
 		m.activePanel == RequestPanel,
 	)
-	requestPanel := m.renderPanel("Request", requestContent, panelWidth, requestHeight, m.activePanel == RequestPanel)
+	requestPanel := m.renderPanel(m.requestPanelTitle(panelWidth), requestContent, panelWidth, requestHeight, m.activePanel == RequestPanel)
 
 	// Response panel (bottom)
 	responseContent := m.responsePanel.ViewWithHistory(
@@ -1534,7 +2497,7 @@ func (m Model) renderFullscreenLayout() string {
 		return m.renderPanelWithTabs(m.leftPanel, content, panelWidth, contentHeight, true)
 
 	case RequestPanel:
-		panelTitle = "Request"
+		panelTitle = m.requestPanelTitle(panelWidth)
 		panelContent = m.requestPanel.View(
 			panelWidth-4,
 			contentHeight-2,
@@ -1563,23 +2526,63 @@ func (m Model) renderFullscreenLayout() string {
 	return m.renderPanel(panelTitle, panelContent, panelWidth, contentHeight, true)
 }
 
-// View renders the model
+// View renders the model. With protect_secrets, secret values are hidden while the
+// terminal is not focused and the last frame before exiting is blank. They are also
+// hidden while the workspace is locked.
 func (m Model) View() string {
+	if m.quitting && m.protectsSecrets() {
+		return ""
+	}
+	if m.blurred && m.protectsSecrets() {
+		if m.globalConfig.ProtectSecrets == config.ProtectSecretsClear {
+			return m.renderHiddenScreen()
+		}
+		return maskSecrets(m.renderFrame(), m.secretValues())
+	}
+	if m.lockScreen.IsLocked() {
+		return maskSecrets(m.renderFrame(), m.secretValues())
+	}
+	return m.renderFrame()
+}
+
+// renderFrame renders the panels, overlays and status bar
+func (m Model) renderFrame() string {
 	if !m.ready {
 		return "Initializing LazyCurl..."
 	}
 
-	// Check minimum terminal size
-	if m.width < MinTerminalWidth || m.height < MinTerminalHeight {
-		warningStyle := lipgloss.NewStyle().
-			Foreground(styles.Yellow).
-			Bold(true)
-		return warningStyle.Render("Terminal too small. Please resize to at least 80x24.")
+	// Below the minimum terminal size only the active panel is shown, down to a floor
+	compact := m.isCompact()
+	if m.width < CompactMinWidth || m.height < CompactMinHeight {
+		return m.renderTooSmall()
+	}
+
+	// The tutorial card sits above the status bar; panels share the remaining height
+	var tutorialCard string
+	if m.tutorial.IsActive() {
+		tutorialCard = m.tutorial.View(m.width)
+		m.height -= lipgloss.Height(tutorialCard)
 	}
 
 	// Render main content based on layout mode
 	var mainContent string
-	if m.isFullscreen {
+	if m.runnerView.IsVisible() {
+		// The collection runner takes the whole screen above the status bar
+		mainContent = m.renderPanel("Runner: "+m.runnerView.Title(), m.runnerView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if m.statsView.IsVisible() {
+		mainContent = m.renderPanel("Stats", m.statsView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if m.latencyView.IsVisible() {
+		mainContent = m.renderPanel("Latency: "+m.latencyView.Title(), m.latencyView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if m.grpcView.IsVisible() {
+		mainContent = m.renderPanel("gRPC: "+m.grpcView.Title(), m.grpcView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if m.settingsView.IsVisible() {
+		mainContent = m.renderPanel("Settings", m.settingsView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if m.workspaceView.IsVisible() {
+		mainContent = m.renderPanel("Workspaces", m.workspaceView.View(m.width-4, m.height-3), m.width, m.height-1, true)
+	} else if compact {
+		m.fullscreenPanel = m.activePanel
+		mainContent = m.renderFullscreenLayout()
+	} else if m.isFullscreen {
 		// Fullscreen mode - render only the active panel
 		mainContent = m.renderFullscreenLayout()
 	} else if m.layoutMode == HorizontalLayout {
@@ -1592,11 +2595,16 @@ func (m Model) View() string {
 	var bottomBar string
 	if m.commandInput.IsVisible() {
 		bottomBar = m.commandInput.View(m.width)
+	} else if compact {
+		bottomBar = m.renderCompactBar()
 	} else {
 		bottomBar = m.renderStatusBar()
 	}
 
 	// Join without extra spacing
+	if tutorialCard != "" {
+		mainContent += "\n" + tutorialCard
+	}
 	result := mainContent + "\n" + bottomBar
 
 	// Apply jump mode overlay if active
@@ -1611,6 +2619,21 @@ func (m Model) View() string {
 		result = m.overlayDialog(result, dialogView)
 	}
 
+	// Overlay file picker if visible
+	if m.filePicker.IsVisible() {
+		result = m.overlayDialog(result, m.filePicker.View(m.width, m.height))
+	}
+
+	// Overlay command palette if visible
+	if m.commandPalette.IsVisible() {
+		result = m.overlayDialog(result, m.commandPalette.View(m.width, m.height))
+	}
+
+	// Overlay search palette if visible
+	if m.searchPalette.IsVisible() {
+		result = m.overlayDialog(result, m.searchPalette.View(m.width, m.height))
+	}
+
 	// Overlay environment modal if visible
 	if m.leftPanel.GetEnvironments().HasActiveModal() {
 		modalView := m.leftPanel.GetEnvironments().RenderModal(m.width, m.height)
@@ -1637,6 +2660,11 @@ func (m Model) View() string {
 		result = m.overlayDialog(result, openAPIView)
 	}
 
+	// Overlay the passphrase prompt of :lock last, above everything
+	if m.lockScreen.IsVisible() {
+		result = m.overlayDialog(result, m.lockScreen.View(m.width))
+	}
+
 	return result
 }
 
@@ -1644,7 +2672,9 @@ func (m Model) View() string {
 func (m Model) renderPanelWithTabs(lp *LeftPanel, content string, width, height int, active bool) string {
 	var borderColor lipgloss.Color
 
-	if active {
+	if m.isTutorialTarget(CollectionsPanel) {
+		borderColor = styles.Yellow
+	} else if active {
 		borderColor = styles.Lavender
 	} else {
 		borderColor = styles.Surface0
@@ -1696,7 +2726,14 @@ func (m Model) renderPanel(title string, content string, width, height int, acti
 	var borderColor lipgloss.Color
 	var titleFg lipgloss.Color
 
-	if active {
+	// The Request and Response panels are highlighted when the tutorial points at them
+	tutorialTarget := (strings.HasPrefix(title, "Request") && m.isTutorialTarget(RequestPanel)) ||
+		(title == "Response" && m.isTutorialTarget(ResponsePanel))
+
+	if tutorialTarget {
+		borderColor = styles.Yellow
+		titleFg = styles.Yellow
+	} else if active {
 		borderColor = styles.Lavender
 		titleFg = styles.Lavender
 	} else {
@@ -1768,7 +2805,7 @@ func (m Model) renderStatusBar() string {
 	m.statusBar.SetEnvironment(envName)
 
 	// Update environment variables in request panel for preview mode
-	envVars := m.leftPanel.GetEnvironments().GetActiveEnvironmentVariables()
+	envVars := m.requestVariables(m.requestPanel.GetCurrentRequestID())
 	m.requestPanel.SetEnvironmentVariables(envVars)
 
 	// Update fullscreen state
@@ -1819,16 +2856,15 @@ func (m Model) handleCommand(msg CommandExecuteMsg) (tea.Model, tea.Cmd) {
 	switch msg.Command {
 	case CmdQuit, CmdQuitLong:
 		// :q or :quit - exit application (save session first)
-		return m.saveSessionAndQuit()
+		return m.quit()
 
 	case CmdWrite, CmdWriteLong:
-		// :w or :write - save current request
-		m.statusBar.Success("Saved", "request")
-		return m, nil
+		// :w or :write - save the edits of the current request
+		return m.write()
 
 	case CmdWriteQuit:
-		// :wq - save and quit (save session first)
-		return m.saveSessionAndQuit()
+		// :wq - save the edits of every request and quit (save session first)
+		return m.writeAllAndQuit()
 
 	case CmdWorkspace, CmdWorkspaceShort:
 		// :workspace or :ws - workspace management
@@ -1836,7 +2872,7 @@ func (m Model) handleCommand(msg CommandExecuteMsg) (tea.Model, tea.Cmd) {
 
 	case CmdHelp:
 		// :help - show help
-		m.statusBar.Info(":q quit | :w save | :ws workspace | :env environments")
+		m.statusBar.Info(":q quit | :w save | :ws workspace | :env environments | :doctor diagnose host | :mock toggle mocks | :chaos inject faults")
 		return m, nil
 
 	case CmdSet:
@@ -1847,6 +2883,23 @@ func (m Model) handleCommand(msg CommandExecuteMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case CmdEnv:
+		// :env hook - run the activation hook of the active environment again
+		if len(msg.Args) > 0 && strings.ToLower(msg.Args[0]) == EnvHook {
+			env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+			if env == nil || env.OnActivate.IsZero() {
+				m.statusBar.Info("The active environment has no activation hook")
+				return m, nil
+			}
+			return m, m.runActivationHook()
+		}
+		// :env check - validate the active environment against required variables
+		if len(msg.Args) > 0 && strings.ToLower(msg.Args[0]) == EnvCheck {
+			m.requiredVarsDismissed = false
+			if !m.promptMissingVariables(m.leftPanel.GetCollections().GetCollections()...) {
+				m.statusBar.Success("Environment", "all required variables are set")
+			}
+			return m, nil
+		}
 		// :env - switch to environments tab
 		m.leftPanel.SetActiveTab(EnvironmentsTab)
 		m.activePanel = CollectionsPanel
@@ -1854,124 +2907,1284 @@ func (m Model) handleCommand(msg CommandExecuteMsg) (tea.Model, tea.Cmd) {
 
 	case CmdCollections, CmdCollectionsShort:
 		// :collections or :col - switch to collections tab
+		if len(msg.Args) > 0 {
+			return m.handleCollectionsCommand(msg.Args)
+		}
 		m.leftPanel.SetActiveTab(CollectionsTab)
 		m.activePanel = CollectionsPanel
 		return m, nil
 
 	case CmdImport:
 		// :import - import files (postman)
-		return m.handleImportCommand(msg.Args)
+		return m.handleImportCommand(msg.Args, msg.Raw)
+
+	case CmdExport:
+		// :export - export files (postman)
+		return m.handleExportCommand(msg.Args)
+
+	case CmdDoctor:
+		// :doctor [url] - diagnose connectivity to the current request's host
+		return m.handleDoctorCommand(msg.Args)
+
+	case CmdMock:
+		// :mock [on|off] - toggle answering requests from their mock rules
+		return m.handleMockCommand(msg.Args)
+
+	case CmdChaos:
+		// :chaos [on|off] [rate] [faults] - toggle fault injection
+		return m.handleChaosCommand(msg.Args)
+
+	case CmdTutorial:
+		// :tutorial [next|stop] - guided walkthrough of the core flows
+		return m.handleTutorialCommand(msg.Args)
+
+	case CmdStats:
+		// :stats [on|off|clear] - local usage statistics
+		return m.handleStatsCommand(msg.Args)
+
+	case CmdLatency:
+		// :latency - response time chart of the selected request
+		return m.handleLatencyCommand()
+
+	case CmdSync:
+		// :sync - re-sync a collection with the OpenAPI spec it was imported from
+		return m.handleSyncCommand()
+
+	case CmdGRPC:
+		// :grpc [grpc://host:port] - list the methods of a gRPC server by reflection
+		return m.handleGRPCCommand(msg.Args)
+
+	case CmdSession:
+		// :session [isolated|shared|clear] - script session (globals, cookies) of the collection
+		return m.handleSessionCommand(msg.Args)
+
+	case CmdSecrets:
+		// :secrets [keychain|file] - where the values of secret variables are stored
+		return m.handleSecretsCommand(msg.Args)
+
+	case CmdFreeze:
+		// :freeze [secrets] [name] | list | show [n] | delete [n] - examples of the open request as sent
+		return m.handleFreezeCommand(msg.Args)
+
+	case CmdSign:
+		// :sign [token] - how the JWT of the open request is signed, compared with token
+		return m.handleSignCommand(msg.Args)
+
+	case CmdTLS:
+		// :tls [insecure on|off | ca <file>|clear | cert <host> <cert> <key>|clear] - TLS config of the workspace
+		return m.handleTLSCommand(msg.Args)
+
+	case CmdRunOrder:
+		// :runorder [up|down|first|last|clear] - run order of the selected folder or request
+		return m.handleRunOrderCommand(msg.Args)
+
+	case CmdSkip:
+		// :skip - leave the selected request out of collection runs, or include it again
+		return m.handleSkipCommand()
+
+	case CmdExtract:
+		// :extract [<var> <type>[@header] <expression> | clear] - extraction rules of the open request
+		return m.handleExtractCommand(msg.Args)
+
+	case CmdBody:
+		// :body [<type>] - show or change the body type of the open request
+		return m.handleBodyCommand(msg.Args)
+
+	case CmdSchema:
+		// :schema [<file> | clear] - JSON Schema of the body of the open request, for the body builder
+		return m.handleSchemaCommand(msg.Args)
+
+	case CmdRedirects:
+		// :redirects [on|off|<max>] - redirect settings of the open request
+		return m.handleRedirectsCommand(msg.Args)
+
+	case CmdPoll:
+		// :poll [interval|off] - re-send the open request at an interval
+		return m.handlePollCommand(msg.Args)
+
+	case CmdVars:
+		// :vars [name | <scope> set|unset <name> [value]] - variables of the open request by scope
+		return m.handleVarsCommand(msg.Args)
+
+	case CmdGit:
+		// :git [add | commit <message>] - git state of the workspace, stage or commit .lazycurl
+		return m.handleGitCommand(msg.Args)
+
+	case CmdScripts:
+		// :scripts [pre | post | clear] - scripts run around the open request, edit those of the selected folder
+		return m.handleScriptsCommand(msg.Args)
+
+	case CmdTheme:
+		// :theme [name | save | reset] - list the themes, switch to one, keep it in the config
+		return m.handleThemeCommand(msg.Args)
+
+	case CmdTabNew:
+		// :tabnew - open the request selected in the tree in a new tab
+		return m.openTab()
+
+	case CmdTabClose:
+		// :tabclose - close the active tab
+		return m.closeTab()
+
+	case CmdTabNext:
+		// :tabnext - show the next tab (gt, ]b)
+		return m.cycleTab(1)
+
+	case CmdTabPrev:
+		// :tabprev - show the previous tab (gT, [b)
+		return m.cycleTab(-1)
+
+	case CmdLock:
+		// :lock [passphrase|idle|off] - lock the workspace until its passphrase is entered
+		return m.handleLockCommand(msg.Args)
+
+	case CmdSettings:
+		// :settings - edit the global and workspace configs
+		m.settingsView.Show(m.globalConfig, m.workspaceConfig)
+		return m, nil
+
+	case CmdStatusBar:
+		// :statusbar [left|right <segments> | time <layout> | save | reset] - arrange the status bar
+		return m.handleStatusBarCommand(msg.Args)
+
+	case CmdHistory:
+		// :history [archive [age]] - console history size and retention, or archive old entries
+		return m.handleHistoryCommand(msg.Args)
+
+	case CmdGrep:
+		// :grep <text|/regex/> - search the response bodies of the console history
+		return m.handleGrepCommand(msg.Args)
+
+	case CmdLint:
+		// :lint [all] - check the selected collection, or all of them, against the lint rules
+		return m.handleLintCommand(msg.Args)
+
+	case CmdFilter:
+		// :filter [<method>] [failed] | clear - show only some requests in the collections tree
+		return m.handleFilterCommand(msg.Args)
+
+	case CmdConsole:
+		// :console [level <levels> | request <text> | search <text> | clear] - filter the Console tab
+		return m.handleConsoleCommand(msg.Args)
+
+	case CmdRegisters, CmdRegistersShort:
+		// :registers [n] - list the copied values, or copy register n back to the clipboard
+		return m.handleRegistersCommand(msg.Args)
+
+	case CmdFind:
+		// :find [text] - search the requests of all collections by name, URL, header and body
+		return m.showSearchPalette(strings.Join(msg.Args, " "))
+
+	case CmdRedraw:
+		// :redraw - clear the screen and draw it again
+		return m.redraw()
+
+	case CmdJob:
+		// :job [name] - run an async job of the current collection, or list its jobs
+		return m.handleJobCommand(msg.Args)
+
+	case CmdDiff:
+		// :diff [baseline | clear] - diff the response with its baseline or the previous one
+		return m.handleDiffCommand(msg.Args)
+
+	case CmdCompare:
+		// :compare <file> - diff the response body against a fixture file
+		path := strings.TrimSpace(strings.Join(msg.Args, " "))
+		if path == "" {
+			m.statusBar.Info("Usage: :compare <file>")
+			return m, nil
+		}
+		return m.compareWithFixture(path)
+
+	default:
+		// Unknown command
+		m.statusBar.Info("Unknown command: " + msg.Command)
+		return m, nil
+	}
+}
+
+// handleTutorialCommand starts, skips a step of, or stops the tutorial
+func (m Model) handleTutorialCommand(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0:
+		m.tutorial.Start(m.tutorialSnapshot())
+		m.statusBar.Info("Tutorial started")
+	case args[0] == TutorialNext && m.tutorial.IsActive():
+		m.tutorial.Skip(m.tutorialSnapshot())
+		if !m.tutorial.IsActive() {
+			m.statusBar.Success("Tutorial", "completed")
+		}
+	case args[0] == TutorialStop:
+		m.tutorial.Stop()
+		m.statusBar.Info("Tutorial stopped")
+	default:
+		m.statusBar.Info("Usage: :tutorial [next|stop]")
+	}
+	return m, nil
+}
+
+// handleStatsCommand shows, enables, disables or clears local usage statistics
+func (m Model) handleStatsCommand(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0:
+		if m.stats == nil {
+			m.statusBar.Info("Stats are off (:stats on to record requests locally)")
+			return m, nil
+		}
+		m.statsView.Show(m.stats, time.Now())
+	case args[0] == StatsOn || args[0] == StatsOff:
+		enabled := args[0] == StatsOn
+		m.workspaceConfig.Stats = enabled
+		if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		if !enabled {
+			m.stats = nil
+			m.statusBar.Info("Stats off (recorded data is kept)")
+			return m, nil
+		}
+		if m.stats == nil {
+			m.stats, _ = stats.Load(m.workspacePath)
+		}
+		m.statusBar.Success("Stats", "on (stored in .lazycurl/"+stats.FileName+")")
+	case args[0] == StatsClear && m.stats != nil:
+		m.stats.Clear()
+		if err := m.stats.Save(); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Stats", "cleared")
+	default:
+		m.statusBar.Info("Usage: :stats [on|off|clear]")
+	}
+	return m, nil
+}
+
+// handleSecretsCommand shows where the values of secret variables are stored, or moves
+// them to the OS keychain or back to the environment files
+func (m Model) handleSecretsCommand(args []string) (tea.Model, tea.Cmd) {
+	envs := m.leftPanel.GetEnvironments().GetEnvironments()
+	keychainCount := func() int {
+		count := 0
+		for _, env := range envs {
+			count += env.KeychainVariableCount()
+		}
+		return count
+	}
+
+	switch {
+	case len(args) == 0:
+		if api.SecretStore == nil {
+			m.statusBar.Info("Secrets are stored in environment files (:secrets keychain to move them to the OS keychain)")
+		} else {
+			m.statusBar.Info(fmt.Sprintf("Secrets are stored in the OS keychain (%d variables)", keychainCount()))
+		}
+	case args[0] == SecretsKeychain:
+		store, err := secrets.Keychain()
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		api.SecretStore = store
+		for _, env := range envs {
+			if err := api.SaveEnvironment(env, env.FilePath); err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+		}
+		m.workspaceConfig.Keychain = true
+		if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Secrets", fmt.Sprintf("%d variables in the OS keychain", keychainCount()))
+	case args[0] == SecretsFile:
+		store := api.SecretStore
+		if store == nil {
+			m.statusBar.Info("Secrets are already stored in environment files")
+			return m, nil
+		}
+		// Write the values to the files before removing them from the keychain
+		api.SecretStore = nil
+		for _, env := range envs {
+			if err := api.SaveEnvironment(env, env.FilePath); err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+			if err := api.DeleteEnvironmentSecrets(env, store); err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+		}
+		m.workspaceConfig.Keychain = false
+		if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Secrets", "moved to environment files")
+	default:
+		m.statusBar.Info("Usage: :secrets [keychain|file]")
+	}
+	return m, nil
+}
+
+// responseFileName suggests the file to save the response body to, from its
+// Content-Disposition header or the URL it was loaded from
+func (m Model) responseFileName() string {
+	return api.DownloadFileName(
+		m.responsePanel.GetHeader("Content-Disposition"),
+		m.responsePanel.GetHeader("Content-Type"),
+		m.responseURL(),
+	)
+}
+
+// saveResponseBody writes the response body to path, asking first when the file exists
+func (m Model) saveResponseBody(path string) (tea.Model, tea.Cmd) {
+	if _, err := os.Stat(api.ExpandHome(path)); err == nil {
+		m.dialog.ShowConfirm(
+			"Overwrite File",
+			path+" exists. Replace it with the response body?",
+			"overwrite_response",
+			path,
+		)
+		return m, nil
+	}
+	return m, SaveResponseBodyCmd(m.responsePanel.GetBody(), m.responsePanel.GetBodyFile(), path)
+}
+
+// compareWithFixture diffs the response body against the fixture at path in the background
+func (m Model) compareWithFixture(path string) (tea.Model, tea.Cmd) {
+	if m.responsePanel.GetStatusCode() == 0 {
+		m.statusBar.Info("No response to compare")
+		return m, nil
+	}
+	return m, CompareFixture(path, m.fixturePath(path), m.responsePanel.CompareBody())
+}
+
+// fixturePath resolves a :compare fixture path relative to the workspace
+func (m *Model) fixturePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(m.workspacePath, path)
+}
+
+// handleGRPCCommand lists the methods of the gRPC server at the URL argument, or of
+// the server of the current request
+func (m Model) handleGRPCCommand(args []string) (tea.Model, tea.Cmd) {
+	rawURL := m.requestPanel.GetURL()
+	if len(args) > 0 {
+		rawURL = args[0]
+	}
+	vars := m.requestVariables(m.requestPanel.GetCurrentRequestID())
+	rawURL = replaceVariables(rawURL, vars)
+	if !grpc.IsGRPCURL(rawURL) {
+		m.statusBar.Info("Usage: :grpc [grpc://host:port] (grpcs:// for TLS)")
+		return m, nil
+	}
+	target, err := grpc.ParseTarget(rawURL)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	m.grpcView.Show(target)
+	return m, ListGRPCMethodsCmd(target)
+}
+
+// useGRPCMethod makes the current request a call of the selected gRPC method. An
+// empty body is replaced by a template of the method's request message.
+func (m Model) useGRPCMethod(msg GRPCMethodSelectedMsg) (tea.Model, tea.Cmd) {
+	filled := m.requestPanel.SetGRPCMethod(msg.URL, string(grpc.Template(msg.Method.Input)))
+	m.statusBar.SetMethod(string(api.GRPC))
+	m.activePanel = RequestPanel
+
+	// Save the change to the request's collection
+	collections := m.leftPanel.GetCollections()
+	requestID := collections.SourceRequestID(m.requestPanel.GetCurrentRequestID())
+	if saved := collections.FindRequestByID(requestID); saved != nil {
+		col := collections.FindCollectionByRequestID(requestID)
+		col.UpdateRequest(requestID, saved.Name, api.GRPC, msg.URL)
+		if filled {
+			col.UpdateRequestBody(requestID, "json", m.requestPanel.GetBodyContent())
+		}
+		if err := col.Save(); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		collections.ReloadCollections()
+	}
+
+	m.statusBar.Success("gRPC", msg.Method.FullName())
+	return m, nil
+}
+
+// handleLatencyCommand charts the response times of the request selected in the
+// Collections panel, or of the request open in the Request panel
+func (m Model) handleLatencyCommand() (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if m.activePanel == CollectionsPanel {
+		if node := m.leftPanel.GetCollections().Selected(); node != nil && node.Type == components.RequestNode {
+			requestID = node.ID
+		}
+	}
+	req := m.leftPanel.GetCollections().FindRequestByID(requestID)
+	if req == nil {
+		m.statusBar.Info("Select or open a saved request to chart its latency")
+		return m, nil
+	}
+
+	samples := m.latencySamples(requestID)
+	if len(samples) == 0 {
+		m.statusBar.Info("No sends of " + req.Name + " yet")
+		return m, nil
+	}
+	m.latencyView.Show(req.Name, samples)
+	return m, nil
+}
+
+// latencySamples returns the sends of a request, oldest first: from the usage statistics
+// when enabled (they persist across sessions), otherwise from the console history
+func (m *Model) latencySamples(requestID string) []LatencySample {
+	var samples []LatencySample
+	if m.stats != nil {
+		for _, e := range m.stats.Entries {
+			if e.RequestID == requestID {
+				samples = append(samples, LatencySample{Time: e.Time, Duration: e.Duration(), Failed: e.Status == 0})
+			}
+		}
+		return samples
+	}
+
+	for _, entry := range m.consoleHistory.GetAll() {
+		if entry.Source == nil || entry.Source.ID != requestID {
+			continue
+		}
+		sample := LatencySample{Time: entry.Timestamp, Duration: entry.Duration, Failed: entry.Response == nil}
+		if entry.Response != nil {
+			sample.Duration = entry.Response.Time
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// recordStats adds an answered request to the usage statistics, if enabled
+func (m *Model) recordStats(req *api.Request, source *api.CollectionRequest, resp *api.Response, duration time.Duration) {
+	if m.stats == nil || req == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+		duration = resp.Time
+	}
+	entry := stats.NewEntry(time.Now(), string(req.Method), req.URL, status, duration)
+	if source != nil {
+		entry.RequestID = source.ID
+		if col := m.leftPanel.GetCollections().FindCollectionByRequestID(source.ID); col != nil {
+			entry.Collection = col.Name
+		}
+	}
+	m.stats.Add(entry)
+	if err := m.stats.Save(); err != nil {
+		m.statusBar.Error(err)
+	}
+}
+
+// advanceTutorial moves the tutorial to its next step once the current one is done
+func (m *Model) advanceTutorial() {
+	step := m.tutorial.Step()
+	if !m.tutorial.Advance(m.tutorialSnapshot()) {
+		return
+	}
+	if m.tutorial.IsActive() {
+		m.statusBar.Success("Tutorial", fmt.Sprintf("step %d done", step+1))
+	} else {
+		m.statusBar.Success("Tutorial", "completed")
+	}
+}
+
+// tutorialSnapshot captures the state tutorial steps are validated against
+func (m *Model) tutorialSnapshot() TutorialSnapshot {
+	snapshot := TutorialSnapshot{
+		Responses:   m.consoleHistory.Len(),
+		Assertions:  len(m.preRequestAssertions) + len(m.postResponseAssertions),
+		ActivePanel: m.activePanel,
+		ResponseTab: m.responsePanel.GetActiveTab(),
+	}
+	for _, col := range m.leftPanel.GetCollections().GetCollections() {
+		snapshot.Requests += countCollectionRequests(col)
+	}
+	for _, env := range m.leftPanel.GetEnvironments().GetEnvironments() {
+		snapshot.Variables += len(env.Variables)
+	}
+	return snapshot
+}
+
+// isTutorialTarget reports whether the active tutorial step highlights panel
+func (m Model) isTutorialTarget(panel PanelType) bool {
+	target, ok := m.tutorial.Target()
+	return ok && target == panel
+}
+
+// handleMockCommand enables, disables or toggles mock mode
+func (m Model) handleMockCommand(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0:
+		m.mockMode = !m.mockMode
+	case args[0] == "on":
+		m.mockMode = true
+	case args[0] == "off":
+		m.mockMode = false
+	default:
+		m.statusBar.Info("Usage: :mock [on|off]")
+		return m, nil
+	}
+
+	m.statusBar.SetMockMode(m.mockMode)
+	if !m.mockMode {
+		m.statusBar.Info("Mock mode off")
+		return m, nil
+	}
+
+	status := "Mock mode on (no rules for this request)"
+	if saved := m.leftPanel.GetCollections().FindRequestByID(m.requestPanel.GetCurrentRequestID()); saved != nil && len(saved.Mocks) > 0 {
+		status = fmt.Sprintf("Mock mode on (%d rule(s) for this request)", len(saved.Mocks))
+	}
+	m.statusBar.Info(status)
+	return m, nil
+}
+
+// handleChaosCommand enables, disables or reconfigures chaos mode.
+// Without arguments it toggles; a rate or faults (see api.ParseChaosArgs) enable it.
+func (m Model) handleChaosCommand(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0:
+		m.chaosMode = !m.chaosMode
+	case args[0] == "off":
+		m.chaosMode = false
+	default:
+		if args[0] == "on" {
+			args = args[1:]
+		}
+		cfg, err := api.ParseChaosArgs(args, m.chaosConfig)
+		if err != nil {
+			m.statusBar.Error(fmt.Errorf("%w (usage: :chaos [on|off] [rate%%] [latency[=2s]] [drop] [5xx])", err))
+			return m, nil
+		}
+		m.chaosConfig = cfg
+		m.chaosMode = true
+	}
+
+	if !m.chaosMode {
+		m.statusBar.SetChaos("")
+		m.statusBar.Info("Chaos mode off")
+		return m, nil
+	}
+
+	m.statusBar.SetChaos(fmt.Sprintf("%d%%", m.chaosConfig.Rate))
+	m.statusBar.Info("Chaos mode on: " + m.chaosConfig.String())
+	return m, nil
+}
+
+// handleDoctorCommand runs a connectivity diagnosis for a URL,
+// defaulting to the current request URL resolved against the active environment
+func (m Model) handleDoctorCommand(args []string) (tea.Model, tea.Cmd) {
+	rawURL := strings.Join(args, " ")
+	if rawURL == "" {
+		rawURL = m.requestPanel.GetURL()
+	}
+	if rawURL == "" {
+		m.statusBar.Info("Usage: :doctor [url] (or open a request)")
+		return m, nil
+	}
+
+	envVars := m.requestVariables(m.requestPanel.GetCurrentRequestID())
+	rawURL = replaceVariables(rawURL, envVars)
+
+	m.statusBar.Info("Diagnosing " + rawURL + "...")
+	return m, RunDoctorCmd(rawURL)
+}
+
+// handleImportCommand processes import subcommands
+func (m Model) handleImportCommand(args []string, raw string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.Info("Usage: :import postman <file|dir|glob> | :import openapi [file|url] | :import curl [command] | :import dotenv <file> [environment] | :import http <file> | :import hoppscotch <file>")
+		return m, nil
+	}
+
+	switch args[0] {
+	case ImportPostman:
+		// :import postman <file|dir|glob> - import Postman collections and environments
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :import postman <file|dir|glob>")
+			return m, nil
+		}
+		filePath := args[1]
+		m.statusBar.Info("Importing " + filePath + "...")
+		if postman.IsBatch(filePath) {
+			// A directory or glob pattern imports all its collections and environments
+			return m, ImportPostmanFiles(filePath)
+		}
+		return m, ImportPostmanFile(filePath)
+
+	case ImportOpenAPI:
+		// :import openapi [file|url] - open the OpenAPI import modal
+		m.showOpenAPIImport(strings.Join(args[1:], " "))
+		return m, nil
+
+	case ImportDotenv:
+		// :import dotenv <file> [environment] - import a .env file into an environment
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :import dotenv <file> [environment]")
+			return m, nil
+		}
+		return m.importDotenv(args[1], strings.Join(args[2:], " "))
+
+	case ImportHTTP:
+		// :import http <file> - import a .http/.rest file (VS Code REST Client) as a collection
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :import http <file>")
+			return m, nil
+		}
+		return m.importHTTPFile(strings.Join(args[1:], " "))
+
+	case ImportHoppscotch:
+		// :import hoppscotch <file> - import Hoppscotch collections or environments
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :import hoppscotch <file>")
+			return m, nil
+		}
+		return m.importHoppscotch(strings.Join(args[1:], " "))
+
+	case ImportCurl:
+		// :import curl - paste a cURL command into the import modal
+		if len(args) < 2 {
+			m.importModal.SetSize(m.width, m.height)
+			m.importModal.Show()
+			return m, nil
+		}
+		// :import curl <command> - parse the rest of the line (quoting preserved)
+		curlCmd := strings.TrimSpace(raw)
+		curlCmd = strings.TrimSpace(curlCmd[strings.Index(curlCmd, args[0]):])
+		request, err := api.ParseCurlCommand(curlCmd)
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			return CurlImportedMsg{Request: request}
+		}
+
+	default:
+		m.statusBar.Info("Unknown import type: " + args[0] + ". Use: :import postman <file> | :import openapi [file|url] | :import curl [command] | :import dotenv <file> | :import http <file> | :import hoppscotch <file>")
+		return m, nil
+	}
+}
+
+// showOpenAPIImport opens the OpenAPI import modal, prefilled with location if set
+func (m *Model) showOpenAPIImport(location string) {
+	m.openAPIImportModal.SetSize(m.width, m.height)
+	m.openAPIImportModal.SetEnvironment(m.leftPanel.GetEnvironments().GetActiveEnvironment())
+	m.openAPIImportModal.Show()
+	if location != "" {
+		m.openAPIImportModal.SetLocation(location)
+	}
+}
+
+// handleSyncCommand re-syncs the selected collection (or the current request's) with the
+// OpenAPI spec it was imported from
+func (m Model) handleSyncCommand() (tea.Model, tea.Cmd) {
+	col := m.currentCollection()
+	if col == nil {
+		m.statusBar.Info("Select a collection to sync")
+		return m, nil
+	}
+	if col.OpenAPISource == nil {
+		m.statusBar.Info(col.Name + " was not imported from an OpenAPI spec")
+		return m, nil
+	}
+
+	m.openAPIImportModal.SetSize(m.width, m.height)
+	m.openAPIImportModal.SetEnvironment(m.leftPanel.GetEnvironments().GetActiveEnvironment())
+	return m, m.openAPIImportModal.ShowSync(col)
+}
+
+// currentCollection returns the collection selected in the Collections panel, or the
+// collection of the request in the Request panel
+func (m Model) currentCollection() *api.CollectionFile {
+	collections := m.leftPanel.GetCollections()
+	if m.activePanel == CollectionsPanel {
+		if node := collections.Selected(); node != nil {
+			if col := collections.FindCollectionByNode(node); col != nil {
+				return col
+			}
+		}
+	}
+	return collections.FindCollectionByRequestID(m.requestPanel.GetCurrentRequestID())
+}
+
+// sessionExecutor returns the script executor holding the lc.globals and lc.cookies of the
+// request: its collection's own session when isolated, otherwise the shared one
+func (m Model) sessionExecutor(requestID string) api.ScriptExecutor {
+	col := m.leftPanel.GetCollections().FindCollectionByRequestID(requestID)
+	if col == nil || !col.IsolatedSession(m.workspaceConfig.IsolateSessions) {
+		return m.scriptExecutor
+	}
+	executor, ok := m.sessionExecutors[col.FilePath]
+	if !ok {
+		executor = api.NewScriptExecutor()
+		m.sessionExecutors[col.FilePath] = executor
+	}
+	return executor
+}
+
+// NewTLSConfig converts the TLS config of a workspace, resolving its paths
+func NewTLSConfig(workspacePath string, cfg *config.TLSConfig) *api.TLSConfig {
+	t := &api.TLSConfig{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	for _, path := range cfg.CACerts {
+		t.CACerts = append(t.CACerts, config.ResolvePath(workspacePath, path))
+	}
+	for _, cc := range cfg.ClientCerts {
+		t.ClientCerts = append(t.ClientCerts, api.ClientCert{
+			Host: cc.Host,
+			Cert: config.ResolvePath(workspacePath, cc.Cert),
+			Key:  config.ResolvePath(workspacePath, cc.Key),
+		})
+	}
+	return t
+}
+
+// handleTLSCommand shows the TLS config of the workspace, or edits it: skip certificate
+// verification, add CA files or client certificates. The config is applied to the next
+// requests once the files load.
+func (m Model) handleTLSCommand(args []string) (tea.Model, tea.Cmd) {
+	cfg := config.TLSConfig{}
+	if m.workspaceConfig.TLS != nil {
+		cfg = *m.workspaceConfig.TLS
+		cfg.CACerts = slices.Clone(cfg.CACerts)
+		cfg.ClientCerts = slices.Clone(cfg.ClientCerts)
+	}
+
+	var result string
+	switch {
+	case len(args) == 0:
+		verification := "on"
+		if cfg.InsecureSkipVerify {
+			verification = "off (insecure)"
+		}
+		m.statusBar.Info(fmt.Sprintf("TLS: %d CA files, %d client certificates, certificate verification %s",
+			len(cfg.CACerts), len(cfg.ClientCerts), verification))
+		return m, nil
+
+	case args[0] == TLSInsecure && len(args) == 2 && (args[1] == TLSOn || args[1] == TLSOff):
+		cfg.InsecureSkipVerify = args[1] == TLSOn
+		result = "certificate verification on"
+		if cfg.InsecureSkipVerify {
+			result = "certificate verification off"
+		}
+
+	case args[0] == TLSCA && len(args) == 2:
+		if args[1] == TLSClear {
+			cfg.CACerts = nil
+			result = "CA files removed"
+		} else {
+			cfg.CACerts = append(cfg.CACerts, args[1])
+			result = "CA file " + args[1] + " added"
+		}
+
+	case args[0] == TLSCert && (len(args) == 3 && args[2] == TLSClear || len(args) == 4):
+		host := args[1]
+		cfg.ClientCerts = slices.DeleteFunc(cfg.ClientCerts, func(cc config.ClientCertConfig) bool {
+			return strings.EqualFold(cc.Host, host)
+		})
+		result = "client certificate for " + host + " removed"
+		if len(args) == 4 {
+			cfg.ClientCerts = append(cfg.ClientCerts, config.ClientCertConfig{Host: host, Cert: args[2], Key: args[3]})
+			result = "client certificate for " + host + " added"
+		}
+
+	default:
+		m.statusBar.Info("Usage: :tls [insecure on|off | ca <file>|clear | cert <host> <cert> <key>|clear]")
+		return m, nil
+	}
+
+	// Files that do not load are reported and the config is left unchanged
+	tlsConfig := NewTLSConfig(m.workspacePath, &cfg)
+	if err := tlsConfig.Validate(); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.workspaceConfig.TLS = &cfg
+	if tlsConfig.IsDefault() {
+		m.workspaceConfig.TLS, tlsConfig = nil, nil
+	}
+	if err := m.workspaceConfig.Save(m.workspacePath); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	api.DefaultTLS = tlsConfig
+	m.statusBar.Success("TLS", result)
+	return m, nil
+}
+
+// handleRunOrderCommand shows the run order of the folder holding the node selected in
+// the Collections panel, moves the node in it, or resets it to the tree order
+func (m Model) handleRunOrderCommand(args []string) (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections()
+	node := collections.Selected()
+	entries, ok := collections.RunEntries(node)
+	if !ok {
+		m.statusBar.Info("Select a folder or request in the Collections panel")
+		return m, nil
+	}
+
+	if len(args) == 0 {
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			name := e.Name()
+			if e.Request != nil && e.Request.SkipInRuns {
+				name += " (skipped)"
+			}
+			names = append(names, name)
+		}
+		m.statusBar.Info("Run order: " + strings.Join(names, " → "))
+		return m, nil
+	}
+
+	if node.Type == components.CollectionNode && args[0] != RunOrderClear {
+		m.statusBar.Info("Select a folder or request to move it in the run order")
+		return m, nil
+	}
+
+	var err error
+	switch args[0] {
+	case RunOrderUp:
+		err = collections.MoveInRunOrder(node, -1)
+	case RunOrderDown:
+		err = collections.MoveInRunOrder(node, 1)
+	case RunOrderFirst:
+		err = collections.MoveInRunOrder(node, -len(entries))
+	case RunOrderLast:
+		err = collections.MoveInRunOrder(node, len(entries))
+	case RunOrderClear:
+		err = collections.ClearRunOrder(node)
+	default:
+		m.statusBar.Info("Usage: :runorder [up|down|first|last|clear]")
+		return m, nil
+	}
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.statusBar.Success("Run order "+args[0], node.Name)
+	return m, nil
+}
+
+// handleSkipCommand toggles whether the request selected in the Collections panel, or
+// open in the Request panel, is left out of collection runs
+func (m Model) handleSkipCommand() (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections()
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if m.activePanel == CollectionsPanel {
+		if node := collections.Selected(); node != nil && node.Type == components.RequestNode {
+			requestID = node.ID
+		}
+	}
+	req := collections.FindRequestByID(requestID)
+	if req == nil {
+		m.statusBar.Info("Select or open a saved request to skip it in runs")
+		return m, nil
+	}
+
+	skip, err := collections.ToggleSkipInRuns(requestID)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if skip {
+		m.statusBar.Success("Skipped in runs", req.Name)
+	} else {
+		m.statusBar.Success("Included in runs", req.Name)
+	}
+	collections.ReloadCollections()
+	return m, nil
+}
+
+// handleBodyCommand shows the body type of the request open in the Request panel, or
+// changes it (json, form-data, raw, binary, msgpack, cbor, graphql, none)
+func (m Model) handleBodyCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.Info("Body: " + m.requestPanel.GetBodyType().String())
+		return m, nil
+	}
+
+	bodyType, ok := ParseBodyType(args[0])
+	if !ok {
+		m.statusBar.Info("Usage: :body json|form-data|raw|binary|msgpack|cbor|graphql|none")
+		return m, nil
+	}
+	m.requestPanel.SetBodyType(bodyType)
+
+	// A binary body needs its file first; it is saved once chosen
+	if bodyType == BinaryBody && m.requestPanel.GetBinaryPath() == "" {
+		m.filePicker.Show("Choose the body file", m.lastFileDir, "binary_file", nil)
+		return m, nil
+	}
+
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if requestID != "" {
+		typeName := strings.ToLower(bodyType.String())
+		if err := m.leftPanel.GetCollections().UpdateRequestBodyByID(requestID, typeName, m.requestPanel.GetBodyContent()); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	m.statusBar.Success("Body", bodyType.String())
+	return m, nil
+}
+
+// saveBody saves the body of the request open in the Request panel, or holds the edit
+// until :w
+func (m *Model) saveBody(bodyType, content string) {
+	if m.holdEdit(editBody) {
+		return
+	}
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if requestID != "" {
+		if err := m.leftPanel.GetCollections().UpdateRequestBodyByID(requestID, bodyType, content); err != nil {
+			m.statusBar.Error(err)
+		}
+	}
+}
+
+// handleSchemaCommand shows whether the request open in the Request panel has a body
+// schema, attaches the JSON Schema of a file to its body, or detaches it
+func (m Model) handleSchemaCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	req := m.editedRequest(requestID)
+	if req == nil {
+		m.statusBar.Info("Open a saved request to change its body schema")
+		return m, nil
+	}
+
+	var schema *api.JSONSchema
+	switch {
+	case len(args) == 0:
+		if req.BodySchema == nil {
+			m.statusBar.Info("No body schema. Usage: :schema <file>|clear")
+		} else {
+			m.statusBar.Info(fmt.Sprintf("Body schema: %d fields (B in the Body tab builds the body)", len(req.BodySchema.Properties)))
+		}
+		return m, nil
+	case len(args) == 1 && args[0] == SchemaClear:
+		// Detach the schema
+	default:
+		var err error
+		if schema, err = api.LoadJSONSchema(api.ExpandHome(strings.Join(args, " "))); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+
+	if !m.holdEdit(editSchema) {
+		if err := m.leftPanel.GetCollections().UpdateRequestBodySchemaByID(requestID, schema); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	m.requestPanel.SetBodySchema(schema)
+	if schema == nil {
+		m.statusBar.Success("Cleared body schema", req.Name)
+	} else {
+		m.statusBar.Success("Attached body schema", req.Name)
+	}
+	return m, nil
+}
+
+// handleExtractCommand lists the extraction rules of the request open in the Request
+// panel, adds one, or removes them all
+func (m Model) handleExtractCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	req := m.editedRequest(requestID)
+	if req == nil {
+		m.statusBar.Info("Open a saved request to change its extraction rules")
+		return m, nil
+	}
+
+	switch {
+	case len(args) == 0:
+		if len(req.Extract) == 0 {
+			m.statusBar.Info("No extraction rules. Usage: :extract <var> jsonpath|regex|xpath[@header] <expression>")
+			return m, nil
+		}
+		labels := make([]string, 0, len(req.Extract))
+		for _, rule := range req.Extract {
+			labels = append(labels, rule.Label())
+		}
+		m.statusBar.Info("Extract: " + strings.Join(labels, " | "))
+		return m, nil
+
+	case len(args) == 1 && args[0] == ExtractClear:
+		if !m.holdEdit(editCapture) {
+			if err := m.leftPanel.GetCollections().UpdateRequestExtractByID(requestID, nil); err != nil {
+				m.statusBar.Error(err)
+				return m, nil
+			}
+		}
+		m.requestPanel.SetCaptureRules(nil)
+		m.statusBar.Success("Cleared extraction rules", req.Name)
+		return m, nil
+
+	case len(args) < 3:
+		m.statusBar.Info("Usage: :extract <var> jsonpath|regex|xpath[@header] <expression>")
+		return m, nil
+	}
+
+	ruleType, header, _ := strings.Cut(args[1], "@")
+	rule := api.ExtractRule{
+		Variable:   args[0],
+		Type:       strings.ToLower(ruleType),
+		Header:     header,
+		Expression: strings.Join(args[2:], " "),
+	}
+	if err := rule.Validate(); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	rules := append(slices.Clone(req.Extract), rule)
+	if !m.holdEdit(editCapture) {
+		if err := m.leftPanel.GetCollections().UpdateRequestExtractByID(requestID, rules); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	m.requestPanel.SetCaptureRules(rules)
+	m.statusBar.Success("Added extraction rule", rule.Label())
+	return m, nil
+}
+
+// applyExtractRules stores the values read by the extraction rules of the request of a
+// send in the active environment
+func (m *Model) applyExtractRules(send *pendingSend, resp *api.Response) {
+	if send.source == nil {
+		return
+	}
+	saved := m.editedRequest(send.source.ID)
+	if saved == nil || len(saved.Extract) == 0 {
+		return
+	}
 
-	case CmdExport:
-		// :export - export files (postman)
-		return m.handleExportCommand(msg.Args)
+	changes, errs := api.ApplyExtractRules(saved.Extract, resp)
+	environments := m.leftPanel.GetEnvironments()
+	env := environments.GetActiveEnvironment()
+	if env == nil {
+		m.statusBar.Info("No active environment to store extracted values")
+		return
+	}
+	if len(changes) > 0 {
+		runner.ApplyEnvChanges(env, changes)
+		if err := environments.SaveActiveEnvironment(api.EnvSourceExtract); err != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
+			return
+		}
+	}
+	if len(errs) > 0 {
+		m.statusBar.Error(errs[0])
+		return
+	}
 
-	default:
-		// Unknown command
-		m.statusBar.Info("Unknown command: " + msg.Command)
-		return m, nil
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		names = append(names, "{{"+change.Name+"}}")
 	}
+	m.statusBar.Success("Extracted", strings.Join(names, ", "))
 }
 
-// handleWorkspaceCommand processes workspace subcommands
-func (m Model) handleWorkspaceCommand(args []string) (tea.Model, tea.Cmd) {
-	if len(args) == 0 {
-		// Show current workspace
-		m.statusBar.Success("Workspace", m.workspaceConfig.Name)
+// handleRedirectsCommand shows the redirect settings of the request open in the Request
+// panel, follows redirects again, stops following them, or sets the maximum followed
+func (m Model) handleRedirectsCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	req := m.editedRequest(requestID)
+	if req == nil {
+		m.statusBar.Info("Open a saved request to change its redirect settings")
 		return m, nil
 	}
 
-	switch args[0] {
-	case WorkspaceList:
-		// :workspace list - list all workspaces
-		workspaces := m.globalConfig.Workspaces
-		if len(workspaces) == 0 {
-			m.statusBar.Info("No recent workspaces")
+	if len(args) == 0 {
+		if req.NoFollowRedirects {
+			m.statusBar.Info("Redirects: not followed")
 		} else {
-			// Show first few workspaces
-			msg := ""
-			for i, ws := range workspaces {
-				if i > 2 {
-					msg += "..."
-					break
-				}
-				if i > 0 {
-					msg += ", "
-				}
-				msg += ws
-			}
-			m.statusBar.Success("Workspaces", msg)
+			m.statusBar.Info(fmt.Sprintf("Redirects: followed (max %d)", api.MaxRedirectsOrDefault(req.MaxRedirects)))
 		}
 		return m, nil
+	}
 
-	case WorkspaceSwitch:
-		// :workspace switch <name> - switch workspace
-		if len(args) < 2 {
-			m.statusBar.Info("Usage: :ws switch <name>")
+	follow, maxRedirects := !req.NoFollowRedirects, req.MaxRedirects
+	switch args[0] {
+	case RedirectsOn:
+		follow = true
+	case RedirectsOff:
+		follow = false
+	default:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			m.statusBar.Info("Usage: :redirects [on|off|<max>]")
 			return m, nil
 		}
-		// TODO: Implement actual workspace switching
-		m.statusBar.Success("Switching", args[1])
-		return m, nil
+		follow, maxRedirects = true, n
+	}
 
-	case WorkspaceCreate:
-		// :workspace create <name> - create new workspace
-		if len(args) < 2 {
-			m.statusBar.Info("Usage: :ws create <name>")
+	held := m.holdCommandEdit(requestID, editRedirects, func(held *api.CollectionRequest) {
+		held.NoFollowRedirects, held.MaxRedirects = !follow, maxRedirects
+	})
+	if !held {
+		if err := m.leftPanel.GetCollections().UpdateRequestRedirectsByID(requestID, follow, maxRedirects); err != nil {
+			m.statusBar.Error(err)
 			return m, nil
 		}
-		// TODO: Implement actual workspace creation
-		m.statusBar.Success("Created", args[1])
-		return m, nil
+	}
+	if follow {
+		m.statusBar.Success("Following redirects", fmt.Sprintf("max %d", api.MaxRedirectsOrDefault(maxRedirects)))
+	} else {
+		m.statusBar.Success("Not following redirects", req.Name)
+	}
+	return m, nil
+}
 
-	case WorkspaceDelete:
-		// :workspace delete <name> - delete workspace
-		if len(args) < 2 {
-			m.statusBar.Info("Usage: :ws delete <name>")
-			return m, nil
+// handleSessionCommand shows the script session of the current collection, isolates it,
+// shares it with the other collections, or clears it
+func (m Model) handleSessionCommand(args []string) (tea.Model, tea.Cmd) {
+	col := m.currentCollection()
+	if len(args) > 0 && args[0] == SessionClear {
+		if col != nil && col.IsolatedSession(m.workspaceConfig.IsolateSessions) {
+			delete(m.sessionExecutors, col.FilePath)
+			m.statusBar.Success("Session cleared", col.Name)
+		} else {
+			m.scriptExecutor = api.NewScriptExecutor()
+			m.statusBar.Success("Session cleared", "shared")
 		}
-		// TODO: Implement actual workspace deletion
-		m.statusBar.Success("Deleted", args[1])
-		return m, nil
-
-	default:
-		m.statusBar.Info("Unknown: " + args[0])
 		return m, nil
 	}
-}
-
-// handleImportCommand processes import subcommands
-func (m Model) handleImportCommand(args []string) (tea.Model, tea.Cmd) {
-	if len(args) == 0 {
-		m.statusBar.Info("Usage: :import postman <file>")
+	if col == nil {
+		m.statusBar.Info("Select a collection")
 		return m, nil
 	}
 
-	switch args[0] {
-	case ImportPostman:
-		// :import postman <file> - import Postman collection or environment
-		if len(args) < 2 {
-			m.statusBar.Info("Usage: :import postman <file>")
+	switch {
+	case len(args) == 0:
+		if col.IsolatedSession(m.workspaceConfig.IsolateSessions) {
+			m.statusBar.Info(col.Name + " has its own session (globals and cookies)")
+		} else {
+			m.statusBar.Info(col.Name + " shares the session (globals and cookies) of other collections")
+		}
+	case args[0] == api.SessionIsolated || args[0] == api.SessionShared:
+		col.Session = args[0]
+		if err := col.Save(); err != nil {
+			m.statusBar.Error(err)
 			return m, nil
 		}
-		filePath := args[1]
-		m.statusBar.Info("Importing " + filePath + "...")
-		return m, ImportPostmanFile(filePath)
-
+		delete(m.sessionExecutors, col.FilePath) // An isolated session starts empty
+		m.statusBar.Success("Session "+args[0], col.Name)
 	default:
-		m.statusBar.Info("Unknown import type: " + args[0] + ". Use: :import postman <file>")
+		m.statusBar.Info("Usage: :session [isolated|shared|clear]")
+	}
+	return m, nil
+}
+
+// handleCollectionsCommand saves the current collection with a file per request
+// (:col split), or in a single file again (:col join)
+func (m Model) handleCollectionsCommand(args []string) (tea.Model, tea.Cmd) {
+	if args[0] != CollectionsSplit && args[0] != CollectionsJoin {
+		m.statusBar.Info("Usage: :col [split|join]")
+		return m, nil
+	}
+	col := m.currentCollection()
+	if col == nil {
+		m.statusBar.Info("Select a collection")
+		return m, nil
+	}
+	split := args[0] == CollectionsSplit
+	if err := col.SetSplitRequests(split); err != nil {
+		m.statusBar.Error(err)
 		return m, nil
 	}
+	if split {
+		m.statusBar.Success("One file per request", col.Name+" → "+filepath.Base(api.RequestsDir(col.FilePath))+"/")
+	} else {
+		m.statusBar.Success("Single file", filepath.Base(col.FilePath))
+	}
+	return m, nil
 }
 
 // handleExportCommand processes export subcommands
 func (m Model) handleExportCommand(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		m.statusBar.Info("Usage: :export postman <file>")
+		m.statusBar.Info("Usage: :export postman|csv|body|inventory|dotenv|http|hoppscotch <file>")
 		return m, nil
 	}
 
 	switch args[0] {
+	case ExportCSV:
+		// :export csv <file> - export the tabular response (CSV or JSON array) to CSV
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :export csv <file>")
+			return m, nil
+		}
+		table, ok := m.responsePanel.TableData()
+		if !ok {
+			m.statusBar.Info("Response is not tabular")
+			return m, nil
+		}
+		return m, ExportTableToCSV(table, args[1])
+
+	case ExportBody:
+		// :export body [file] - save the response body, asking for the file when omitted
+		if m.responsePanel.GetStatusCode() == 0 {
+			m.statusBar.Info("No response to save")
+			return m, nil
+		}
+		if len(args) < 2 {
+			return m, func() tea.Msg { return ResponseSaveToFileMsg{} }
+		}
+		return m.saveResponseBody(strings.Join(args[1:], " "))
+
+	case ExportInventory:
+		// :export inventory <file> - export the API inventory of all collections, JSON for a .json file
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :export inventory <file>")
+			return m, nil
+		}
+		entries := api.Inventory(m.leftPanel.GetCollections().GetCollections(), m.lastTested())
+		return m, ExportInventoryCmd(entries, strings.Join(args[1:], " "))
+
+	case ExportDotenv:
+		// :export dotenv <file> [environment] - write an environment, the active one by default, to a .env file
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :export dotenv <file> [environment]")
+			return m, nil
+		}
+		return m.exportDotenv(args[1], strings.Join(args[2:], " "))
+
+	case ExportHTTP:
+		// :export http <file> - write the open request to a .http file, appended when it exists
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :export http <file>")
+			return m, nil
+		}
+		return m.exportHTTPFile(strings.Join(args[1:], " "))
+
+	case ExportHoppscotch:
+		// :export hoppscotch <file> [environments] - export all collections, or all environments, to Hoppscotch format
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :export hoppscotch <file> [environments]")
+			return m, nil
+		}
+		return m.exportHoppscotch(args[1], len(args) > 2 && args[2] == "environments")
+
 	case ExportPostman:
 		// :export postman <file> - export current collection to Postman format
 		if len(args) < 2 {
@@ -1996,7 +4209,7 @@ func (m Model) handleExportCommand(args []string) (tea.Model, tea.Cmd) {
 		return m, ExportCollectionToPostman(collections[0], outputPath)
 
 	default:
-		m.statusBar.Info("Unknown export type: " + args[0] + ". Use: :export postman <file>")
+		m.statusBar.Info("Unknown export type: " + args[0] + ". Use: :export postman|csv|body|inventory|dotenv|http|hoppscotch <file>")
 		return m, nil
 	}
 }
@@ -2004,11 +4217,26 @@ func (m Model) handleExportCommand(args []string) (tea.Model, tea.Cmd) {
 // handleDialogResult processes dialog results
 func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, tea.Cmd) {
 	if !msg.Confirmed {
+		if msg.Action == "required_var" {
+			// Don't ask again before every send; :env check asks again
+			m.requiredVarsDismissed = true
+		}
+		if ctx, ok := msg.Context.(reloadContext); ok && msg.Action == "reload_changed" {
+			return m.handleReloadChanged(ctx, false)
+		}
 		m.statusBar.Info("Canceled")
 		return m, nil
 	}
 
 	switch msg.Action {
+	case "discard_changes":
+		if ctx, ok := msg.Context.(unsavedContext); ok {
+			return m.discardEdits(ctx)
+		}
+	case "reload_changed":
+		if ctx, ok := msg.Context.(reloadContext); ok {
+			return m.handleReloadChanged(ctx, true)
+		}
 	case "rename":
 		if msg.Node != nil && msg.Value != "" {
 			m.performRename(msg.Node, msg.Value)
@@ -2030,7 +4258,67 @@ func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, te
 			m.performEditRequest(msg.Node, msg.Value, msg.Method, msg.URL)
 		}
 
+	case "export_csv":
+		if msg.Value != "" {
+			table, _ := m.responsePanel.TableData()
+			return m, ExportTableToCSV(table, msg.Value)
+		}
+
+	case "copy_as_code":
+		return m.copyRequestAsCode(msg.Value)
+
+	case "required_var":
+		if ctx, ok := msg.Context.(*requiredVarsContext); ok {
+			return m.fillRequiredVariable(ctx, msg.Value)
+		}
+
+	case "prompt_var":
+		if ctx, ok := msg.Context.(*promptVarsContext); ok {
+			return m.fillPromptVariable(ctx, msg.Value)
+		}
+
+	case "import_dotenv":
+		if name, ok := msg.Context.(string); ok && msg.Value != "" {
+			return m.importDotenv(msg.Value, name)
+		}
+	case "export_dotenv":
+		if name, ok := msg.Context.(string); ok && msg.Value != "" {
+			return m.exportDotenv(msg.Value, name)
+		}
+
+	case "save_variable":
+		if value, ok := msg.Context.(string); ok && msg.Value != "" {
+			return m.saveResponseValue(msg.Value, value)
+		}
+
+	case "save_response":
+		if msg.Value != "" {
+			return m.saveResponseBody(msg.Value)
+		}
+
+	case "overwrite_response":
+		if path, ok := msg.Context.(string); ok {
+			return m, SaveResponseBodyCmd(m.responsePanel.GetBody(), m.responsePanel.GetBodyFile(), path)
+		}
+
+	case "workspace_delete":
+		if path, ok := msg.Context.(string); ok {
+			return m.deleteWorkspace(path)
+		}
+
+	case "overwrite_fixture":
+		if path, ok := msg.Context.(string); ok {
+			return m, WriteFixture(path, m.fixturePath(path), m.responsePanel.CompareBody())
+		}
+
 	// === REQUEST PANEL ACTIONS ===
+	case "binary_path":
+		m.requestPanel.SetBinaryPath(msg.Value)
+		m.saveBinaryBody()
+		if path := m.requestPanel.GetBinaryPath(); path != "" {
+			m.statusBar.Success("Body file", path)
+		}
+
 	case "request_rename":
 		if ctx, ok := msg.Context.(*requestDialogContext); ok && msg.Value != "" {
 			m.requestPanel.RenameRow(ctx.Index, msg.Value)
@@ -2040,6 +4328,12 @@ func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, te
 				m.syncParamsAndSave()
 			} else if ctx.Tab == "PathParams" {
 				m.syncPathParamsAndSave(ctx.Index, msg.Value)
+			} else if ctx.Tab == "Body" {
+				m.saveFormData()
+			} else if ctx.Tab == "Headers" {
+				m.saveHeaders()
+			} else if ctx.Tab == "Capture" {
+				m.saveCaptureRules()
 			}
 		}
 	case "request_delete":
@@ -2052,6 +4346,12 @@ func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, te
 			} else if ctx.Tab == "PathParams" {
 				// Remove path param from URL
 				m.removePathParamFromURL(ctx.Key)
+			} else if ctx.Tab == "Body" {
+				m.saveFormData()
+			} else if ctx.Tab == "Headers" {
+				m.saveHeaders()
+			} else if ctx.Tab == "Capture" {
+				m.saveCaptureRules()
 			}
 		}
 	case "request_edit":
@@ -2062,6 +4362,12 @@ func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, te
 			// Sync params to URL and save if Params tab
 			if ctx.Tab == "Params" {
 				m.syncParamsAndSave()
+			} else if ctx.Tab == "Body" {
+				m.saveFormData()
+			} else if ctx.Tab == "Headers" {
+				m.saveHeaders()
+			} else if ctx.Tab == "Capture" {
+				m.saveCaptureRules()
 			}
 			// Note: PathParams edit updates the value, not the key (which is in URL)
 		}
@@ -2079,6 +4385,12 @@ func (m Model) handleDialogResult(msg components.DialogResultMsg) (tea.Model, te
 				// Sync params to URL and save if Params tab
 				if ctx.Tab == "Params" {
 					m.syncParamsAndSave()
+				} else if ctx.Tab == "Body" {
+					m.saveFormData()
+				} else if ctx.Tab == "Headers" {
+					m.saveHeaders()
+				} else if ctx.Tab == "Capture" {
+					m.saveCaptureRules()
 				}
 			}
 		}
@@ -2173,6 +4485,9 @@ func (m *Model) performDuplicate(node *components.TreeNode) {
 func (m *Model) syncParamsAndSave() {
 	// Update URL from params
 	newURL := m.requestPanel.SyncURLFromParams()
+	if m.holdEdit(editURL) {
+		return
+	}
 
 	// Save to collection
 	requestID := m.requestPanel.GetCurrentRequestID()
@@ -2185,6 +4500,62 @@ func (m *Model) syncParamsAndSave() {
 	}
 }
 
+// saveFormData saves the fields of a form-data body to the collection
+func (m *Model) saveFormData() {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if requestID == "" || m.requestPanel.GetBodyType() != FormDataBody || m.holdEdit(editBody) {
+		return
+	}
+	if err := m.leftPanel.GetCollections().UpdateRequestBodyByID(requestID, api.BodyTypeFormData, m.requestPanel.GetBodyContent()); err != nil {
+		m.statusBar.Error(err)
+	}
+}
+
+// saveHeaders saves the rows of the Headers tab to the collection
+func (m *Model) saveHeaders() {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if requestID == "" || m.holdEdit(editHeaders) {
+		return
+	}
+	if err := m.leftPanel.GetCollections().UpdateRequestHeadersByID(requestID, m.requestPanel.GetHeaders()); err != nil {
+		m.statusBar.Error(err)
+	}
+}
+
+// saveCaptureRules saves the rows of the Capture tab as the extraction rules of the
+// request, restoring the saved rules when a row is invalid
+func (m *Model) saveCaptureRules() {
+	collections := m.leftPanel.GetCollections()
+	requestID := m.requestPanel.GetCurrentRequestID()
+	req := collections.FindRequestByID(requestID)
+	if req == nil {
+		m.statusBar.Info("Open a saved request to capture response values")
+		return
+	}
+	if m.holdEdit(editCapture) {
+		return
+	}
+	rules, err := m.requestPanel.GetCaptureRules()
+	if err == nil {
+		err = collections.UpdateRequestExtractByID(requestID, rules)
+	}
+	if err != nil {
+		m.statusBar.Error(err)
+		m.requestPanel.SetCaptureRules(req.Extract)
+	}
+}
+
+// saveBinaryBody saves the file of a binary body to the collection
+func (m *Model) saveBinaryBody() {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	if requestID == "" || m.requestPanel.GetBodyType() != BinaryBody || m.holdEdit(editBody) {
+		return
+	}
+	if err := m.leftPanel.GetCollections().UpdateRequestBodyByID(requestID, api.BodyTypeBinary, m.requestPanel.GetBodyContent()); err != nil {
+		m.statusBar.Error(err)
+	}
+}
+
 // syncPathParamsAndSave syncs a renamed path param to the URL and saves
 func (m *Model) syncPathParamsAndSave(index int, newKey string) {
 	// Get old key from path params table before rename
@@ -2215,7 +4586,7 @@ func (m *Model) removePathParamFromURL(paramKey string) {
 // saveURLToCollection saves the current URL to the collection file
 func (m *Model) saveURLToCollection() {
 	requestID := m.requestPanel.GetCurrentRequestID()
-	if requestID != "" {
+	if requestID != "" && !m.holdEdit(editURL) {
 		url := m.requestPanel.GetURL()
 		if err := m.leftPanel.GetCollections().UpdateRequestURLByID(requestID, url); err != nil {
 			m.statusBar.Error(err)
@@ -2329,82 +4700,508 @@ func (m *Model) updateWhichKeyContext() {
 				m.whichKey.SetContext(components.ContextRequestBody)
 			case "Scripts":
 				m.whichKey.SetContext(components.ContextRequestScripts)
+			case "Settings":
+				m.whichKey.SetContext(components.ContextRequestSettings)
+			case "Capture":
+				m.whichKey.SetContext(components.ContextRequestCapture)
+			case "Docs":
+				m.whichKey.SetContext(components.ContextRequestDocs)
 			default:
 				m.whichKey.SetContext(components.ContextNormalRequest)
 			}
 		case ResponsePanel:
 			if m.responsePanel.GetActiveTab() == "Console" {
 				m.whichKey.SetContext(components.ContextConsole)
+			} else if m.responsePanel.GetActiveTab() == "Body" && m.responsePanel.IsTableView() {
+				m.whichKey.SetContext(components.ContextResponseTable)
+			} else if m.responsePanel.GetActiveTab() == "Body" && m.responsePanel.IsLinksView() {
+				m.whichKey.SetContext(components.ContextResponseLinks)
 			} else {
 				m.whichKey.SetContext(components.ContextNormalResponse)
 			}
-		default:
-			m.whichKey.SetContext(components.ContextGlobal)
+		default:
+			m.whichKey.SetContext(components.ContextGlobal)
+		}
+	default:
+		m.whichKey.SetContext(components.ContextGlobal)
+	}
+}
+
+// GetWhichKeyHints returns the current WhichKey hints for the statusbar
+func (m *Model) GetWhichKeyHints() string {
+	return m.whichKey.GetHintsForStatusBar(m.whichKey.GetContext())
+}
+
+// sendHTTPRequest builds and sends an HTTP request from the current request panel state
+func (m Model) sendHTTPRequest() (tea.Model, tea.Cmd) {
+	// Check if a request is loaded
+	url := m.requestPanel.GetURL()
+	if url == "" {
+		m.statusBar.Info("No URL to send")
+		return m, nil
+	}
+
+	// Check if this request is already sending, unless duplicate sends are queued
+	if m.sends.busy(m.requestPanel.GetCurrentRequestID()) && !m.queueDuplicateSends() {
+		m.statusBar.Info("Request already in progress...")
+		return m, nil
+	}
+
+	// Ask for required variables of the request's collection before sending
+	if !m.requiredVarsDismissed {
+		collections := m.leftPanel.GetCollections()
+		if col := collections.FindCollectionByRequestID(m.requestPanel.GetCurrentRequestID()); col != nil && m.promptMissingVariables(col) {
+			return m, nil
+		}
+	}
+
+	// Ask for the prompt variables of the request before every send
+	src := m.requestSource()
+	prompts := m.pendingPrompts
+	m.pendingPrompts = nil
+	if names := api.FindPromptVariables(src); len(names) > 0 && prompts == nil {
+		m.showPromptVariable(&promptVarsContext{Names: names, Values: make(map[string]string)})
+		return m, nil
+	}
+
+	// Build the HTTP request
+	environments := m.leftPanel.GetEnvironments()
+	vars := m.requestVariables(src.ID)
+	if len(prompts) > 0 {
+		vars = api.WithPromptValues(vars, prompts)
+	}
+	req, err := buildHTTPRequestFrom(src, vars, m.variableTypes(src.ID))
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	send := &pendingSend{
+		requestID:    src.ID,
+		request:      req,
+		source:       src,
+		variables:    api.NewVariableSnapshot(src, environments.GetActiveEnvironment()),
+		preRequest:   m.requestPanel.GetPreRequestScript(),
+		postResponse: m.requestPanel.GetPostRequestScript(),
+	}
+	return m, m.submitSend(send)
+}
+
+// queueDuplicateSends reports whether sends of a request already in flight are queued
+// rather than ignored
+func (m *Model) queueDuplicateSends() bool {
+	return m.globalConfig != nil && m.globalConfig.DuplicateSends == config.DuplicateSendsQueue
+}
+
+// submitSend starts a send, or queues it when a send of the same request is in flight
+// and duplicate sends are queued
+func (m *Model) submitSend(send *pendingSend) tea.Cmd {
+	if send.tab == nil {
+		send.tab = m.currentTab()
+	}
+	if !m.sends.busy(send.requestID) {
+		return m.startSend(send)
+	}
+	if !m.queueDuplicateSends() {
+		m.statusBar.Info("Request already in progress...")
+		return nil
+	}
+	waiting := m.sends.enqueue(send)
+	m.updateSendsStatus()
+	m.statusBar.Info(fmt.Sprintf("Request queued (%d waiting)", waiting))
+	return nil
+}
+
+// startSend sends a request, after its pre-request script when it has one. The send
+// becomes the latest of its tab: the Response panel of the tab waits for its response.
+func (m *Model) startSend(send *pendingSend) tea.Cmd {
+	if send.tab == nil {
+		send.tab = m.currentTab()
+	}
+	// A new send replaces the open event stream or gRPC call
+	if m.eventStream != nil {
+		m.eventStream.Close()
+		m.eventStream = nil
+		m.streamSend = nil
+	}
+	if m.grpcStream != nil {
+		m.grpcStream.Close()
+		m.grpcStream = nil
+		m.streamSend = nil
+	}
+
+	// Clear previous script results and pending request
+	m.preRequestConsole = nil
+	m.postResponseConsole = nil
+	m.preRequestAssertions = nil
+	m.postResponseAssertions = nil
+	m.lastScriptResult = nil
+
+	// Update state to sending
+	id := m.sends.start(send)
+	preRequest := m.inheritedScript(send.requestID, send.preRequest, true)
+	// A polled request keeps its previous response on screen until the new one arrives
+	if send.poll == 0 {
+		send.tab.response.ClearResponse()
+		send.tab.response.ClearTestResults()
+		send.tab.response.SetLoading(true)
+	}
+	m.updateSendsStatus()
+
+	// If there's a pre-request script, execute it first
+	if preRequest != "" {
+		env := api.EnvironmentWithScopes(m.leftPanel.GetEnvironments().GetActiveEnvironment(), m.variableScopes(send.requestID))
+		m.statusBar.Info("Running pre-request script...")
+		return tea.Batch(withSendID(ExecutePreRequestScriptCmd(m.sessionExecutor(send.requestID), preRequest, send.request, env), id), loaderTickCmd())
+	}
+
+	// No pre-request script, send request directly
+	m.statusBar.Info("Sending request...")
+	return tea.Batch(withSendID(m.sendRequestCmd(send, send.request), id), loaderTickCmd())
+}
+
+// sendNextQueued starts the next queued send of the request of a completed send
+func (m *Model) sendNextQueued(send *pendingSend) tea.Cmd {
+	if send != nil {
+		if next := m.sends.next(send.requestID); next != nil {
+			return m.startSend(next)
+		}
+	}
+	m.updateSendsStatus()
+	return nil
+}
+
+// updateSendsStatus shows the sends in flight and queued in the status bar
+func (m *Model) updateSendsStatus() {
+	m.statusBar.SetSends(m.sends.counts())
+}
+
+// startCollectionRun runs every request of a collection or folder node in the runner view
+func (m Model) startCollectionRun(node *components.TreeNode) (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections()
+	col := collections.FindCollectionByNode(node)
+	if col == nil {
+		m.statusBar.Info("No collection to run")
+		return m, nil
+	}
+
+	// Ask for required variables of the collection before running it
+	if !m.requiredVarsDismissed && m.promptMissingVariables(col) {
+		return m, nil
+	}
+
+	folderPath := collections.GetFolderPathIncluding(node)
+	items, err := runner.Collect(col, folderPath)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	title := col.Name
+	if len(folderPath) > 0 {
+		title += " / " + strings.Join(folderPath, " / ")
+	}
+	return m.runItems(title, items, nil)
+}
+
+// applyEnvChanges keeps the variables set by the scripts of a run, as a single send does
+func (m *Model) applyEnvChanges(changes []api.EnvChange) {
+	if len(changes) == 0 {
+		return
+	}
+	if env := m.leftPanel.GetEnvironments().GetActiveEnvironment(); env != nil {
+		runner.ApplyEnvChanges(env, changes)
+		if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(api.EnvSourceRun); err != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
+		}
+	}
+}
+
+// runItems starts a run of items against the active environment,
+// with the values of its prompt variables, asked for first when nil.
+func (m Model) runItems(title string, items []runner.Item, prompts map[string]string) (tea.Model, tea.Cmd) {
+	if len(items) == 0 {
+		m.statusBar.Info("No requests to run")
+		return m, nil
+	}
+
+	// Ask for the prompt variables of the run once, before its first request
+	names := runner.PromptVariables(items)
+	if len(names) > 0 && prompts == nil {
+		m.showPromptVariable(&promptVarsContext{Names: names, Values: make(map[string]string), Title: title, Items: items})
+		return m, nil
+	}
+
+	// The run uses its own script executor as scripts execute outside the update loop
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	m.activeRunner = runner.New(BuildStoredHTTPRequest, api.NewScriptExecutor(), env)
+	m.activeRunner.Prompts = prompts
+	col := m.leftPanel.GetCollections().FindCollectionByRequestID(items[0].Request.ID)
+	m.activeRunner.Scopes = m.runScopes(col)
+	m.activeRunner.Collection = col
+	m.job = nil
+	runID := m.runnerView.Start(title, items)
+	m.statusBar.Info(fmt.Sprintf("Running %d requests...", len(items)))
+	return m, RunnerStepCmd(m.activeRunner, runID, 0, items[0])
+}
+
+// sendRequestCmd sends req, the request of send, over the network, or answers it with the
+// first matching mock rule of the request being sent when mock mode is enabled.
+// In chaos mode a share of the sends get a fault injected.
+func (m *Model) sendRequestCmd(send *pendingSend, req *api.Request) tea.Cmd {
+	if m.mockMode && send.source != nil {
+		if saved := m.leftPanel.GetCollections().FindRequestByID(send.source.ID); saved != nil {
+			if rule := api.FindMockRule(saved.Mocks, req); rule != nil {
+				m.statusBar.Info("Mocked: " + rule.Label())
+				return MockResponseCmd(rule.Response)
+			}
+		}
+	}
+	// Faults are injected in HTTP exchanges, which gRPC calls do not go through
+	if m.chaosMode && req.Method != api.GRPC {
+		if fault := m.chaosInjector.Pick(m.chaosConfig); fault != api.ChaosNone {
+			m.statusBar.Info("Chaos: injecting " + string(fault))
+			return ChaosSendCmd(m.chaosInjector, req, fault, m.chaosConfig)
 		}
-	default:
-		m.whichKey.SetContext(components.ContextGlobal)
 	}
+	// Report the progress of file uploads in the status bar
+	if file, ok := req.Body.(*api.FileBody); ok {
+		file.Progress = &api.UploadProgress{}
+		m.uploadProgress = file.Progress
+	}
+	return SendHTTPRequestCmd(req)
 }
 
-// GetWhichKeyHints returns the current WhichKey hints for the statusbar
-func (m *Model) GetWhichKeyHints() string {
-	return m.whichKey.GetHintsForStatusBar(m.whichKey.GetContext())
+// responseURL returns the URL the current response was loaded from: the last URL of
+// its redirect chain, or the URL of the request sent
+func (m Model) responseURL() string {
+	if chain := m.responsePanel.GetRedirects(); len(chain) > 0 {
+		return chain[len(chain)-1].URL
+	}
+	if shown := m.currentTab().shown; shown != nil {
+		return shown.request.URL
+	}
+	return ""
 }
 
-// sendHTTPRequest builds and sends an HTTP request from the current request panel state
-func (m Model) sendHTTPRequest() (tea.Model, tea.Cmd) {
-	// Check if a request is loaded
-	url := m.requestPanel.GetURL()
-	if url == "" {
-		m.statusBar.Info("No URL to send")
-		return m, nil
+// responseSource returns the unresolved form of the request the current response
+// answers, nil when it is not a saved request
+func (m Model) responseSource() *api.CollectionRequest {
+	if shown := m.currentTab().shown; shown != nil {
+		return shown.source
 	}
+	return nil
+}
 
-	// Check if already sending
-	if m.isSending {
-		m.statusBar.Info("Request already in progress...")
+// openFollowUpRequest saves a request created from a link or form of the response next
+// to the request the response belongs to, and opens it in the Request panel. When that
+// request is not saved in a collection, the new request is only opened.
+func (m Model) openFollowUpRequest(req *api.CollectionRequest) (tea.Model, tea.Cmd) {
+	if m.requestPanel.IsDirty() {
+		return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.openFollowUpRequest(req) }})
+	}
+	collections := m.leftPanel.GetCollections()
+	sourceID := m.responsePanel.GetRequestID()
+	if collections.FindRequestByID(sourceID) != nil {
+		if err := collections.AddRequestAfter(sourceID, req); err != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save request: %w", err))
+			return m, nil
+		}
+		collections.ReloadCollections()
+		collections.SelectRequest(req.ID)
+		if saved := collections.FindRequestByID(req.ID); saved != nil {
+			req = saved
+		}
+		m.statusBar.Success("Created", req.Name)
+	} else {
+		m.statusBar.Info("Opened " + req.Name + " (not saved in a collection)")
+	}
+
+	m.requestPanel.LoadCollectionRequest(req)
+	m.statusBar.SetMethod(string(req.Method))
+	m.activePanel = RequestPanel
+	return m, nil
+}
+
+// handleHTTPResponse completes the send a response answers
+func (m Model) handleHTTPResponse(msg HTTPResponseMsg) (tea.Model, tea.Cmd) {
+	send, latest := m.sends.finish(msg.SendID)
+	if send == nil {
 		return m, nil
 	}
+	return m.completeSend(send, latest, msg)
+}
 
-	// Build the HTTP request
-	req := m.buildHTTPRequest()
-	if req == nil {
-		m.statusBar.Info("Could not build request")
+// completeSend logs the response of a send and shows it when the send is the latest,
+// then starts the next queued send of the request
+func (m Model) completeSend(send *pendingSend, latest bool, msg HTTPResponseMsg) (Model, tea.Cmd) {
+	entryID := m.logSend(send, msg.Response, msg.Error, time.Since(send.start))
+	var cmd tea.Cmd
+	if latest {
+		// The entry of the latest send holds the output of its scripts
+		m.consoleEntryID = entryID
+		if entryID != "" && len(m.preRequestConsole) > 0 {
+			m.consoleHistory.AddLogs(entryID, m.preRequestConsole)
+			m.saveHistory()
+		}
+		m, cmd = m.showHTTPResponse(send, msg)
+	}
+	next := m.sendNextQueued(send)
+	return m, tea.Batch(cmd, next)
+}
+
+// logSend adds a completed send to the console history and the usage statistics, and
+// returns the ID of its console entry ("" without history)
+func (m *Model) logSend(send *pendingSend, resp *api.Response, err error, duration time.Duration) string {
+	var id string
+	if m.consoleHistory != nil {
+		entry := api.NewConsoleEntry(send.request, resp, err, duration)
+		entry.Source = send.source
+		entry.Variables = send.variables
+		id = m.consoleHistory.Add(*entry)
+		m.pruneHistory()
+		m.saveHistory()
+	}
+	if send.source != nil {
+		m.leftPanel.GetCollections().SetLastSendFailed(send.source.ID, err != nil || resp == nil || resp.StatusCode >= 400)
+	}
+	m.recordStats(send.request, send.source, resp, duration)
+	return id
+}
+
+// showHTTPResponse shows the response of the latest send and runs its post-response script
+func (m Model) showHTTPResponse(send *pendingSend, msg HTTPResponseMsg) (Model, tea.Cmd) {
+	m.responsePanel.SetLoading(false)
+	upload := m.uploadProgress
+	m.uploadProgress = nil
+	send.tab.shown = send
+
+	if msg.Error != nil {
+		// Show the failure breakdown in the Response panel, with a short status line
+		ne := api.DiagnoseNetworkError(msg.Error, send.request.URL)
+		m.responsePanel.SetNetworkError(ne)
+		m.statusBar.Error(ne)
 		return m, nil
 	}
+	if msg.Response != nil {
+		headers := joinHeaders(msg.Response.Headers)
+
+		cookies := responseCookies(msg.Response.Headers)
+
+		// Format time and size
+		timeStr := formatDuration(msg.Response.Time)
+		sizeStr := formatBytes(msg.Response.Size)
+
+		// Update response panel
+		polled := m.poll != nil && send.poll == m.poll.id
+		cursorRow, cursorCol := m.responsePanel.BodyCursor()
+		m.responsePanel.SetRequestID(send.requestID)
+		m.responsePanel.SetResponse(
+			msg.Response.StatusCode,
+			msg.Response.Status,
+			headers,
+			cookies,
+			msg.Response.Body,
+			timeStr,
+			sizeStr,
+		)
+		if msg.Response.Spooled() {
+			m.responsePanel.SetSpooledBody(msg.Response.BodyFile, msg.Response.Size)
+		}
+		m.responsePanel.SetRedirects(msg.Response.Redirects)
+		if polled {
+			m.showPollResponse(cursorRow, cursorCol)
+		}
 
-	// Clear previous script results and pending request
-	m.preRequestConsole = nil
-	m.postResponseConsole = nil
-	m.preRequestAssertions = nil
-	m.postResponseAssertions = nil
-	m.lastScriptResult = nil
-	m.pendingScriptReq = nil // Reset to avoid stale request in post-response scripts
+		// Update status bar with HTTP status
+		statusText := ""
+		switch {
+		case msg.Response.StatusCode >= 200 && msg.Response.StatusCode < 300:
+			statusText = "OK"
+		case msg.Response.StatusCode >= 300 && msg.Response.StatusCode < 400:
+			statusText = "Redirect"
+		case msg.Response.StatusCode >= 400 && msg.Response.StatusCode < 500:
+			statusText = "Client Error"
+		case msg.Response.StatusCode >= 500:
+			statusText = "Server Error"
+		}
+		m.statusBar.SetHTTPStatus(msg.Response.StatusCode, statusText)
 
-	// Update state to sending
-	m.isSending = true
-	m.lastRequest = req         // Track request for console logging
-	m.requestStart = time.Now() // Track start time for duration
-	m.responsePanel.ClearResponse()
-	m.responsePanel.ClearTestResults()
-	m.responsePanel.SetLoading(true)
-
-	// Get scripts
-	preRequestScript := m.requestPanel.GetPreRequestScript()
-	m.postResponseScript = m.requestPanel.GetPostRequestScript()
-
-	// Get active environment
-	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+		// Focus response panel, except for the responses of a poll
+		if !polled {
+			m.activePanel = ResponsePanel
+		}
+		detail := fmt.Sprintf("%d %s in %s", msg.Response.StatusCode, statusText, timeStr)
+		if msg.Response.Attempts > 1 {
+			detail += fmt.Sprintf(" (%d attempts)", msg.Response.Attempts)
+		}
+		if upload != nil {
+			detail += ", uploaded " + api.FormatSize(upload.Total())
+		}
+		m.statusBar.Success("Response", detail)
 
-	// If there's a pre-request script, execute it first
-	if preRequestScript != "" && !isDefaultScript(preRequestScript, "pre") {
-		m.statusBar.Info("Running pre-request script...")
-		return m, tea.Batch(ExecutePreRequestScriptCmd(m.scriptExecutor, preRequestScript, req, env), loaderTickCmd())
+		// Store response values in the environment before the post-response script runs
+		m.applyExtractRules(send, msg.Response)
+
+		// Execute post-response script if present
+		script := m.inheritedScript(send.requestID, send.postResponse, false)
+		if script != "" && !isDefaultScript(script, "post") {
+			// Build ScriptResponse from HTTP response using factory function
+			scriptResp := api.NewScriptResponseFromData(
+				msg.Response.StatusCode,
+				msg.Response.Status,
+				headers,
+				msg.Response.BodyString(),
+				msg.Response.Time.Milliseconds(),
+			)
+
+			// Get active environment, with the other variable scopes of the request
+			var scopes api.VariableScopes
+			if send.source != nil {
+				scopes = m.variableScopes(send.source.ID)
+			}
+			env := api.EnvironmentWithScopes(m.leftPanel.GetEnvironments().GetActiveEnvironment(), scopes)
+
+			// Use the request as the pre-request script left it if available
+			scriptReq := send.scriptRequest
+			if scriptReq == nil {
+				scriptReq = api.NewScriptRequestFromHTTP(send.request)
+			}
+
+			m.statusBar.Info("Running post-response script...")
+			executor := m.scriptExecutor
+			if send.source != nil {
+				executor = m.sessionExecutor(send.source.ID)
+			}
+			return m, ExecutePostResponseScriptCmd(executor, script, scriptReq, scriptResp, env)
+		}
 	}
+	return m, nil
+}
 
-	// No pre-request script, send request directly
-	m.statusBar.Info("Sending request...")
-	return m, tea.Batch(SendHTTPRequestCmd(req), loaderTickCmd())
+// joinHeaders flattens response headers into a map, joining repeated values
+func joinHeaders(headers map[string][]string) map[string]string {
+	joined := make(map[string]string)
+	for key, values := range headers {
+		if len(values) > 0 {
+			joined[key] = strings.Join(values, ", ")
+		}
+	}
+	return joined
+}
+
+// responseCookies returns the cookies set by the Set-Cookie headers of a response, by name
+func responseCookies(headers map[string][]string) map[string]string {
+	cookies := make(map[string]string)
+	for _, cookie := range headers["Set-Cookie"] {
+		// Parse "name=value; attributes" format
+		parts := strings.SplitN(cookie, "=", 2)
+		if len(parts) == 2 {
+			valueParts := strings.SplitN(parts[1], ";", 2)
+			cookies[parts[0]] = valueParts[0]
+		}
+	}
+	return cookies
 }
 
 // isDefaultScript checks if a script is the default placeholder script
@@ -2417,29 +5214,90 @@ func isDefaultScript(script string, scriptType string) bool {
 	return trimmedScript == strings.TrimSpace(defaultPostResponseScript)
 }
 
-// buildHTTPRequest constructs an API Request from the current RequestView state
-func (m *Model) buildHTTPRequest() *api.Request {
-	method := m.requestPanel.GetMethod()
-	url := m.requestPanel.GetURL()
-
-	// Replace environment variables in URL
-	envVars := m.leftPanel.GetEnvironments().GetActiveEnvironmentVariables()
-	url = replaceVariables(url, envVars)
+// requestSource captures the current RequestView state with variables unresolved.
+// Console history keeps it so entries can be replayed against the current environment.
+func (m *Model) requestSource() *api.CollectionRequest {
+	src := &api.CollectionRequest{
+		ID:     m.requestPanel.GetCurrentRequestID(),
+		Method: api.HTTPMethod(m.requestPanel.GetMethod()),
+		URL:    m.requestPanel.GetURL(),
+		Auth:   m.requestPanel.GetAuthConfig(),
+	}
+	src.Timeout, src.Retry = m.requestPanel.GetSettings()
+	src.ServerName, src.Host = m.requestPanel.GetOverrides()
+
+	// Redirect settings and variables are not edited in the Request panel (see :redirects and :vars)
+	if saved := m.editedRequest(src.ID); saved != nil {
+		src.NoFollowRedirects = saved.NoFollowRedirects
+		src.MaxRedirects = saved.MaxRedirects
+		src.Variables = saved.Variables
+	}
 
-	// Build headers map from headers table
-	headers := make(map[string]string)
 	headersTable := m.requestPanel.GetHeadersTable()
 	if headersTable != nil {
 		for _, row := range headersTable.Rows {
-			if row.Enabled && row.Key != "" {
-				value := replaceVariables(row.Value, envVars)
-				headers[row.Key] = value
+			if row.Key != "" {
+				src.Headers = append(src.Headers, api.KeyValueEntry{
+					Key:     row.Key,
+					Value:   row.Value,
+					Enabled: row.Enabled,
+				})
 			}
 		}
 	}
 
+	if bodyContent := m.requestPanel.GetBodyContent(); bodyContent != "" {
+		bodyType := "raw"
+		if wireFormat, ok := m.requestPanel.GetBodyType().WireFormat(); ok {
+			bodyType = string(wireFormat)
+		} else if m.requestPanel.GetBodyType() == GraphQLBody {
+			bodyType = api.BodyTypeGraphQL
+		} else if m.requestPanel.GetBodyType() == FormDataBody {
+			bodyType = api.BodyTypeFormData
+		} else if m.requestPanel.GetBodyType() == BinaryBody {
+			bodyType = api.BodyTypeBinary
+		}
+		src.Body = &api.BodyConfig{Type: bodyType, Content: bodyContent}
+	}
+
+	return src
+}
+
+// jwtOptions resolves the JWT auth of a request against envVars
+func jwtOptions(auth *api.AuthConfig, envVars map[string]string) (api.JWTOptions, error) {
+	var expiresIn time.Duration
+	if auth.JWTExpiresIn != "" {
+		d, err := time.ParseDuration(replaceVariables(auth.JWTExpiresIn, envVars))
+		if err != nil {
+			return api.JWTOptions{}, fmt.Errorf("jwt: invalid expiry %q: %w", auth.JWTExpiresIn, err)
+		}
+		expiresIn = d
+	}
+	return api.JWTOptions{
+		Algorithm: auth.JWTAlgorithm,
+		Key:       replaceVariables(auth.JWTKey, envVars),
+		Claims:    replaceVariables(auth.JWTClaims, envVars),
+		ExpiresIn: expiresIn,
+	}, nil
+}
+
+// buildHTTPRequestFrom resolves an unresolved request against envVars, the variables of
+// types being templated into JSON bodies by type.
+// Returns an error when a JWT cannot be signed or a msgpack/cbor body cannot be encoded.
+func buildHTTPRequestFrom(src *api.CollectionRequest, envVars, types map[string]string) (*api.Request, error) {
+	// Replace environment variables in URL
+	url := replaceVariables(src.URL, envVars)
+
+	// Build headers map from enabled headers
+	headers := make(map[string]string)
+	for _, h := range src.Headers {
+		if h.Enabled && h.Key != "" {
+			headers[h.Key] = replaceVariables(h.Value, envVars)
+		}
+	}
+
 	// Add auth headers
-	authConfig := m.requestPanel.GetAuthConfig()
+	authConfig := src.Auth
 	if authConfig != nil {
 		switch authConfig.Type {
 		case "bearer":
@@ -2449,6 +5307,21 @@ func (m *Model) buildHTTPRequest() *api.Request {
 			}
 			token := replaceVariables(authConfig.Token, envVars)
 			headers["Authorization"] = prefix + " " + token
+		case "jwt":
+			// Sign a fresh token for every send
+			opts, err := jwtOptions(authConfig, envVars)
+			if err != nil {
+				return nil, err
+			}
+			token, err := api.GenerateJWT(opts)
+			if err != nil {
+				return nil, err
+			}
+			prefix := authConfig.Prefix
+			if prefix == "" {
+				prefix = "Bearer"
+			}
+			headers["Authorization"] = prefix + " " + token
 		case "basic":
 			username := replaceVariables(authConfig.Username, envVars)
 			password := replaceVariables(authConfig.Password, envVars)
@@ -2472,26 +5345,124 @@ func (m *Model) buildHTTPRequest() *api.Request {
 
 	// Get body content
 	var body interface{}
-	bodyContent := m.requestPanel.GetBodyContent()
-	if bodyContent != "" {
+	if src.Body != nil && src.Body.Type == api.BodyTypeGraphQL {
+		// GraphQL bodies are sent as a JSON {"query", "variables"} document
+		gql := api.ParseGraphQLBody(src.Body.Content)
+		gql.Query = replaceVariables(gql.Query, envVars)
+		gql.Variables = replaceVariables(api.UnquoteTypedPlaceholders(gql.Variables, types, envVars), envVars)
+		payload, err := gql.Payload()
+		if err != nil {
+			return nil, err
+		}
+		body = payload
+		if !hasHeader(headers, "Content-Type") {
+			headers["Content-Type"] = "application/json"
+		}
+	} else if src.Body != nil && src.Body.Type == api.BodyTypeFormData {
+		// Form-data bodies are sent as multipart/form-data, the client sets the boundary
+		fields := api.ParseFormData(src.Body.Content)
+		resolved := make([]api.FormField, 0, len(fields))
+		for _, field := range fields {
+			field.Key = replaceVariables(field.Key, envVars)
+			field.Value = replaceVariables(field.Value, envVars)
+			resolved = append(resolved, field)
+		}
+		body = &api.MultipartForm{Fields: resolved}
+	} else if src.Body != nil && src.Body.Type == api.BodyTypeBinary {
+		// Binary bodies are streamed from their file
+		path, _ := src.Body.Content.(string)
+		path = strings.TrimSpace(replaceVariables(path, envVars))
+		if path == "" {
+			return nil, fmt.Errorf("binary body: no file selected")
+		}
+		body = &api.FileBody{Path: path}
+	} else if src.Body != nil {
+		bodyContent, _ := src.Body.Content.(string)
+		if bodyType, ok := ParseBodyType(src.Body.Type); ok && bodyType.IsJSONAuthored() {
+			bodyContent = api.UnquoteTypedPlaceholders(bodyContent, types, envVars)
+		}
 		bodyContent = replaceVariables(bodyContent, envVars)
-		// Try to parse as JSON for proper serialization
-		var jsonBody interface{}
-		if err := json.Unmarshal([]byte(bodyContent), &jsonBody); err == nil {
-			body = jsonBody
-		} else {
-			// Use raw string as body
+		// msgpack/cbor body types name the binary encoding of the JSON source
+		if wireFormat := format.BinaryFormat(src.Body.Type); wireFormat.ContentType() != "" {
+			// Body is authored as JSON and sent in its binary encoding
+			encoded, err := format.EncodeJSON(wireFormat, []byte(bodyContent))
+			if err != nil {
+				return nil, err
+			}
+			body = encoded
+			if !hasHeader(headers, "Content-Type") {
+				headers["Content-Type"] = wireFormat.ContentType()
+			}
+		} else if src.Method == api.GRPC {
+			// gRPC messages are encoded from the JSON text, which keeps 64-bit integers exact
 			body = bodyContent
+		} else {
+			// Try to parse as JSON for proper serialization
+			var jsonBody interface{}
+			if err := json.Unmarshal([]byte(bodyContent), &jsonBody); err == nil {
+				body = jsonBody
+			} else {
+				// Use raw string as body
+				body = bodyContent
+				if rawBodySyntax(bodyContent) == "xml" && !hasHeader(headers, "Content-Type") {
+					headers["Content-Type"] = "application/xml"
+				}
+			}
+		}
+	}
+
+	timeout, err := api.ParseTimeout(src.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if src.Retry != nil {
+		if err := src.Retry.Validate(); err != nil {
+			return nil, err
 		}
 	}
 
 	return &api.Request{
-		Method:  api.HTTPMethod(method),
+		Method:  src.Method,
 		URL:     url,
 		Headers: headers,
 		Body:    body,
-		Timeout: 30 * time.Second,
+		Timeout: timeout,
+
+		NoFollowRedirects: src.NoFollowRedirects,
+		MaxRedirects:      src.MaxRedirects,
+		Retry:             src.Retry.Clone(),
+
+		ServerName: replaceVariables(src.ServerName, envVars),
+		Host:       replaceVariables(src.Host, envVars),
+	}, nil
+}
+
+// BuildStoredHTTPRequest resolves a request as saved in a collection file, whose JSON
+// bodies may be stored as objects rather than the text edited in the Request panel.
+// It is the runner.BuildFunc used by the collection runner and `lazycurl run`.
+func BuildStoredHTTPRequest(src *api.CollectionRequest, envVars, types map[string]string) (*api.Request, error) {
+	if src.Body != nil && src.Body.Type != api.BodyTypeGraphQL {
+		if _, ok := src.Body.Content.(string); !ok && src.Body.Content != nil {
+			data, err := json.Marshal(src.Body.Content)
+			if err != nil {
+				return nil, fmt.Errorf("invalid body: %w", err)
+			}
+			normalized := *src
+			normalized.Body = &api.BodyConfig{Type: src.Body.Type, Content: string(data)}
+			src = &normalized
+		}
 	}
+	return buildHTTPRequestFrom(src, envVars, types)
+}
+
+// hasHeader reports whether headers contains name (case-insensitive)
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
 }
 
 // replaceVariables replaces {{variable}} patterns with environment values
@@ -2501,34 +5472,29 @@ func replaceVariables(input string, vars map[string]string) string {
 		placeholder := "{{" + key + "}}"
 		result = strings.ReplaceAll(result, placeholder, value)
 	}
+	// System variables and template helpers ({{$uuid}}, {{now "rfc1123" "UTC"}})
+	if strings.Contains(result, "{{") {
+		result = api.ReplaceVariables(result, nil)
+	}
 	return result
 }
 
-// formatDuration formats a duration for display
-func formatDuration(d time.Duration) string {
-	if d < time.Millisecond {
-		return fmt.Sprintf("%dμs", d.Microseconds())
+// displayUnits returns the units durations and sizes are displayed in by cfg
+func displayUnits(cfg *config.UnitsConfig) api.Units {
+	if cfg == nil {
+		return api.Units{}
 	}
-	if d < time.Second {
-		return fmt.Sprintf("%dms", d.Milliseconds())
-	}
-	return fmt.Sprintf("%.2fs", d.Seconds())
+	return api.Units{SecondsFrom: cfg.SecondsFrom, Sizes: cfg.Sizes, DecimalSeparator: cfg.DecimalSeparator}
+}
+
+// formatDuration formats a duration for display in the units of the config
+func formatDuration(d time.Duration) string {
+	return api.FormatDuration(d)
 }
 
-// formatBytes formats bytes for display
+// formatBytes formats bytes for display in the units of the config
 func formatBytes(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-	)
-	switch {
-	case bytes < KB:
-		return fmt.Sprintf("%dB", bytes)
-	case bytes < MB:
-		return fmt.Sprintf("%.1fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%.1fMB", float64(bytes)/MB)
-	}
+	return api.DefaultUnits.Size(bytes)
 }
 
 // SessionSaveTickMsg is sent when the debounced save timer fires
@@ -2576,6 +5542,24 @@ func (m *Model) saveSession() {
 	m.session.Panels.Collections = m.leftPanel.GetSessionState()
 	m.session.Panels.Request = m.requestPanel.GetSessionState()
 	m.session.Panels.Response = m.responsePanel.GetSessionState()
+	for _, tab := range m.tabs {
+		// Each tab remembers the table columns hidden in its own responses
+		for id, columns := range tab.response.GetSessionState().HiddenTableColumns {
+			if _, ok := m.session.Panels.Response.HiddenTableColumns[id]; !ok {
+				if m.session.Panels.Response.HiddenTableColumns == nil {
+					m.session.Panels.Response.HiddenTableColumns = make(map[string][]string)
+				}
+				m.session.Panels.Response.HiddenTableColumns[id] = columns
+			}
+		}
+	}
+
+	// Save the open tabs
+	m.session.Tabs = m.sessionTabs()
+	m.session.ActiveTab = 0
+	if m.session.Tabs != nil {
+		m.session.ActiveTab = m.activeTab
+	}
 
 	// Note: LastUpdated is set by session.Save()
 
@@ -2586,6 +5570,9 @@ func (m *Model) saveSession() {
 // saveSessionAndQuit saves the session and returns the quit command
 func (m *Model) saveSessionAndQuit() (Model, tea.Cmd) {
 	m.saveSession()
+	m.stopHookProcess()
+	m.watcher.close()
+	m.quitting = true
 	return *m, tea.Quit
 }
 
@@ -2613,13 +5600,169 @@ func (m Model) exportCurlCommand() (tea.Model, tea.Cmd) {
 	}
 
 	// Copy to clipboard
-	clipboard.Write(clipboard.FmtText, []byte(curlCmd))
+	clipboard.Copy("cURL command", curlCmd)
 
 	return m, func() tea.Msg {
 		return CurlExportedMsg{Success: true}
 	}
 }
 
+// copyRequestAsCode copies the current request, with variables and auth resolved,
+// to the clipboard as a snippet in the given language
+func (m Model) copyRequestAsCode(language string) (tea.Model, tea.Cmd) {
+	lang, err := codegen.ParseLanguage(language)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	src := m.requestSource()
+	req, err := buildHTTPRequestFrom(src, m.requestVariables(src.ID), m.variableTypes(src.ID))
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	snippet, err := codegen.Generate(lang, req)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	label := lang.Label() + " snippet"
+	m.statusBar.Success("Copied", label+copyMethodNote(clipboard.Copy(label, snippet)))
+	return m, nil
+}
+
+// promptMissingVariables opens the guided dialog for required variables the active environment lacks.
+// It returns true if variables are missing.
+func (m *Model) promptMissingVariables(collections ...*api.CollectionFile) bool {
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	missing := api.MissingVariables(env, collections...)
+	if len(missing) == 0 {
+		return false
+	}
+	if env == nil {
+		m.statusBar.Info(fmt.Sprintf("%d required variables need an active environment", len(missing)))
+		return false
+	}
+
+	m.showRequiredVariablePrompt(&requiredVarsContext{Pending: missing})
+	return true
+}
+
+// showRequiredVariablePrompt asks for the value of the current required variable
+func (m *Model) showRequiredVariablePrompt(ctx *requiredVarsContext) {
+	req := ctx.Pending[ctx.Index]
+	message := fmt.Sprintf("Value for {{%s}}", req.Name)
+	if req.Description != "" {
+		message += " - " + req.Description
+	}
+	if req.Secret {
+		message += " (secret)"
+	}
+	m.dialog.ShowInput(
+		fmt.Sprintf("Missing Variable (%d/%d)", ctx.Index+1, len(ctx.Pending)),
+		message+":",
+		"",
+		"required_var",
+		ctx,
+	)
+}
+
+// fillRequiredVariable saves a value entered in the guided dialog and moves to the next variable.
+// An empty value skips the variable.
+func (m Model) fillRequiredVariable(ctx *requiredVarsContext, value string) (tea.Model, tea.Cmd) {
+	environments := m.leftPanel.GetEnvironments()
+	env := environments.GetActiveEnvironment()
+	if env == nil {
+		m.statusBar.Info("No active environment")
+		return m, nil
+	}
+
+	if value != "" {
+		api.FillRequirement(env, ctx.Pending[ctx.Index], value)
+		if err := environments.SaveActiveEnvironment(api.EnvSourceRequired); err != nil {
+			m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
+			return m, nil
+		}
+		ctx.Filled++
+	}
+
+	ctx.Index++
+	if ctx.Index < len(ctx.Pending) {
+		m.showRequiredVariablePrompt(ctx)
+		return m, nil
+	}
+
+	if skipped := len(ctx.Pending) - ctx.Filled; skipped > 0 {
+		m.statusBar.Info(fmt.Sprintf("Saved %d variables in %s, %d still missing", ctx.Filled, env.Name, skipped))
+		return m, nil
+	}
+	m.statusBar.Success("Saved", fmt.Sprintf("%d variables in %s", ctx.Filled, env.Name))
+	return m, nil
+}
+
+// showPromptVariable asks for the value of the current prompt variable, filled with its last value
+func (m *Model) showPromptVariable(ctx *promptVarsContext) {
+	name := ctx.Names[ctx.Index]
+	last := ""
+	if m.session != nil {
+		last = m.session.PromptValues[name]
+	}
+	m.dialog.ShowInput(
+		fmt.Sprintf("Prompt Variable (%d/%d)", ctx.Index+1, len(ctx.Names)),
+		fmt.Sprintf("Value for {{%s%s}}:", api.PromptVariablePrefix, name),
+		last,
+		"prompt_var",
+		ctx,
+	)
+}
+
+// fillPromptVariable records a value entered in the prompt dialog and, once every prompt
+// variable has a value, sends the request or starts the run.
+// Values are remembered in the session, never in the environment.
+func (m Model) fillPromptVariable(ctx *promptVarsContext, value string) (tea.Model, tea.Cmd) {
+	name := ctx.Names[ctx.Index]
+	ctx.Values[name] = value
+	if m.session != nil {
+		if m.session.PromptValues == nil {
+			m.session.PromptValues = make(map[string]string)
+		}
+		m.session.PromptValues[name] = value
+	}
+
+	ctx.Index++
+	if ctx.Index < len(ctx.Names) {
+		m.showPromptVariable(ctx)
+		return m, nil
+	}
+
+	if ctx.Items != nil {
+		return m.runItems(ctx.Title, ctx.Items, ctx.Values)
+	}
+	m.pendingPrompts = ctx.Values
+	return m.sendHTTPRequest()
+}
+
+// saveQueryResultToEnv stores a query result or a value of the response body in the active environment and persists it
+func (m Model) saveQueryResultToEnv(name, value string) (tea.Model, tea.Cmd) {
+	name = strings.TrimSpace(name)
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironment()
+	if env == nil {
+		m.statusBar.Info("No active environment")
+		return m, nil
+	}
+
+	env.SetVariable(name, value)
+	if err := m.leftPanel.GetEnvironments().SaveActiveEnvironment(api.EnvSourceQuery); err != nil {
+		m.statusBar.Error(fmt.Errorf("failed to save environment: %w", err))
+		return m, nil
+	}
+	m.statusBar.Success("Saved", fmt.Sprintf("{{%s}} in %s", name, env.Name))
+	return m, nil
+}
+
 // buildCollectionRequest builds a CollectionRequest from the current RequestView state
 func (m *Model) buildCollectionRequest() *api.CollectionRequest {
 	method := m.requestPanel.GetMethod()
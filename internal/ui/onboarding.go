@@ -0,0 +1,446 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/import/postman"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// OnboardingStep is a page of the first-run wizard
+type OnboardingStep int
+
+// Onboarding steps, in order
+const (
+	OnboardingWelcome OnboardingStep = iota // Introduction
+	OnboardingTheme                         // Pick a theme
+	OnboardingKeys                          // Pick a key binding preset
+	OnboardingImport                        // Optionally import a Postman export
+	OnboardingSample                        // Optionally create the sample workspace
+	OnboardingSummary                       // Review choices before applying
+)
+
+// OnboardingResult holds the choices made in the wizard
+type OnboardingResult struct {
+	Theme            string // One of config.ThemeNames
+	KeyBindingPreset string // One of config.KeyBindingPresetNames
+	PostmanPath      string // Postman collection or environment to import ("" to skip)
+	CreateSample     bool   // Create the demo collection and environment in the workspace
+}
+
+// OnboardingModel is the first-run wizard, run as its own Bubble Tea program before the main UI
+type OnboardingModel struct {
+	step          OnboardingStep
+	workspacePath string
+	themeCursor   int
+	keysCursor    int
+	sampleCursor  int // 0=create, 1=skip
+	pathInput     textinput.Model
+	error         string
+	done          bool // Choices confirmed
+	canceled      bool // Wizard skipped with ctrl+c
+	width         int
+	height        int
+}
+
+// NewOnboardingModel creates the wizard for the given workspace
+func NewOnboardingModel(workspacePath string) OnboardingModel {
+	ti := textinput.New()
+	ti.Placeholder = "Path to a Postman collection or environment (optional)"
+	ti.CharLimit = 500
+	ti.Width = 60
+
+	return OnboardingModel{
+		step:          OnboardingWelcome,
+		workspacePath: workspacePath,
+		pathInput:     ti,
+		width:         80,
+		height:        24,
+	}
+}
+
+// Init implements tea.Model
+func (m OnboardingModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.canceled = true
+			return m, tea.Quit
+		case "esc":
+			if m.step > OnboardingWelcome {
+				m.setStep(m.step - 1)
+			}
+			return m, nil
+		case "enter":
+			return m.next()
+		}
+
+		if m.step == OnboardingImport {
+			var cmd tea.Cmd
+			m.pathInput, cmd = m.pathInput.Update(msg)
+			m.error = ""
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "j", "down":
+			m.moveCursor(1)
+		case "k", "up":
+			m.moveCursor(-1)
+		}
+	}
+
+	return m, nil
+}
+
+// next validates the current step and advances, quitting after the summary
+func (m OnboardingModel) next() (tea.Model, tea.Cmd) {
+	switch m.step {
+	case OnboardingImport:
+		if path := m.postmanPath(); path != "" {
+			if _, err := postman.DetectFileType(path); err != nil {
+				m.error = err.Error()
+				return m, nil
+			}
+		}
+	case OnboardingSummary:
+		m.done = true
+		return m, tea.Quit
+	}
+	m.setStep(m.step + 1)
+	return m, nil
+}
+
+// setStep switches page, focusing the path input on the import page
+func (m *OnboardingModel) setStep(step OnboardingStep) {
+	m.step = step
+	m.error = ""
+	if step == OnboardingImport {
+		m.pathInput.Focus()
+	} else {
+		m.pathInput.Blur()
+	}
+}
+
+// moveCursor moves the selection of the current choice page
+func (m *OnboardingModel) moveCursor(delta int) {
+	clamp := func(v, n int) int { return max(0, min(v, n-1)) }
+	switch m.step {
+	case OnboardingTheme:
+		m.themeCursor = clamp(m.themeCursor+delta, len(config.ThemeNames))
+	case OnboardingKeys:
+		m.keysCursor = clamp(m.keysCursor+delta, len(config.KeyBindingPresetNames))
+	case OnboardingSample:
+		m.sampleCursor = clamp(m.sampleCursor+delta, 2)
+	}
+}
+
+// postmanPath returns the entered import path with "~" expanded
+func (m OnboardingModel) postmanPath() string {
+	path := strings.TrimSpace(m.pathInput.Value())
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// Result returns the choices made, or false if the wizard was not completed
+func (m OnboardingModel) Result() (OnboardingResult, bool) {
+	if !m.done {
+		return OnboardingResult{}, false
+	}
+	return OnboardingResult{
+		Theme:            config.ThemeNames[m.themeCursor],
+		KeyBindingPreset: config.KeyBindingPresetNames[m.keysCursor],
+		PostmanPath:      m.postmanPath(),
+		CreateSample:     m.sampleCursor == 0,
+	}, true
+}
+
+// Canceled returns true if the wizard was skipped with ctrl+c
+func (m OnboardingModel) Canceled() bool {
+	return m.canceled
+}
+
+// View implements tea.Model
+func (m OnboardingModel) View() string {
+	modalWidth := min(76, m.width-4)
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Lavender)
+	stepStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Text).Width(modalWidth - 6)
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Subtext1).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).MarginTop(1)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.Red).Bold(true)
+	modalStyle := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Welcome to LazyCurl"))
+	content.WriteString("  ")
+	content.WriteString(stepStyle.Render(fmt.Sprintf("Step %d/%d", int(m.step)+1, int(OnboardingSummary)+1)))
+	content.WriteString("\n\n")
+
+	help := "Enter: Next • Esc: Back • Ctrl+C: Skip setup"
+	switch m.step {
+	case OnboardingWelcome:
+		content.WriteString(textStyle.Render("This short setup creates your configuration in " + config.GetGlobalConfigPath() + ". You can change everything later by editing that file or running `lazycurl setup`."))
+		help = "Enter: Start • Ctrl+C: Skip setup"
+
+	case OnboardingTheme:
+		content.WriteString(labelStyle.Render("Theme"))
+		content.WriteString("\n")
+		content.WriteString(renderOnboardingChoices(config.ThemeNames, m.themeCursor))
+
+	case OnboardingKeys:
+		content.WriteString(labelStyle.Render("Key bindings"))
+		content.WriteString("\n")
+		content.WriteString(renderOnboardingChoices([]string{
+			"vim - h/l switch panels, q quits",
+			"default - shift+arrows switch panels, q or ctrl+q quits",
+		}, m.keysCursor))
+
+	case OnboardingImport:
+		content.WriteString(labelStyle.Render("Import from Postman"))
+		content.WriteString("\n")
+		content.WriteString(textStyle.Render("Enter the path of an exported collection or environment, or leave empty to skip."))
+		content.WriteString("\n\n")
+		content.WriteString(m.pathInput.View())
+		if m.error != "" {
+			content.WriteString("\n")
+			content.WriteString(errorStyle.Render("⚠ " + m.error))
+		}
+
+	case OnboardingSample:
+		content.WriteString(labelStyle.Render("Sample workspace"))
+		content.WriteString("\n")
+		content.WriteString(textStyle.Render("Add a demo collection and environment to " + m.workspacePath))
+		content.WriteString("\n")
+		content.WriteString(renderOnboardingChoices([]string{"Create sample workspace", "Skip"}, m.sampleCursor))
+
+	case OnboardingSummary:
+		result := OnboardingResult{
+			Theme:            config.ThemeNames[m.themeCursor],
+			KeyBindingPreset: config.KeyBindingPresetNames[m.keysCursor],
+			PostmanPath:      m.postmanPath(),
+			CreateSample:     m.sampleCursor == 0,
+		}
+		importPath, sample := "skip", "skip"
+		if result.PostmanPath != "" {
+			importPath = result.PostmanPath
+		}
+		if result.CreateSample {
+			sample = "create"
+		}
+		for _, row := range [][2]string{
+			{"Theme", result.Theme},
+			{"Key bindings", result.KeyBindingPreset},
+			{"Postman import", importPath},
+			{"Sample workspace", sample},
+		} {
+			content.WriteString(labelStyle.Render(fmt.Sprintf("%-18s", row[0])))
+			content.WriteString(textStyle.Inline(true).Render(row[1]))
+			content.WriteString("\n")
+		}
+		help = "Enter: Finish • Esc: Back"
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render(help))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(content.String()))
+}
+
+// renderOnboardingChoices renders a vertical list with the selected entry highlighted
+func renderOnboardingChoices(choices []string, cursor int) string {
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.Green).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(styles.Text)
+
+	var b strings.Builder
+	for i, choice := range choices {
+		if i == cursor {
+			b.WriteString(selectedStyle.Render("❯ " + choice))
+		} else {
+			b.WriteString(normalStyle.Render("  " + choice))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ApplyOnboarding saves the global config for the choices made in the wizard, then imports the
+// Postman file and creates the sample workspace if requested
+func ApplyOnboarding(result OnboardingResult, workspacePath string) (*config.GlobalConfig, error) {
+	cfg := config.DefaultGlobalConfig()
+	if theme, ok := config.ThemePreset(result.Theme); ok {
+		cfg.Theme = theme
+	}
+	if kb, ok := config.KeyBindingPreset(result.KeyBindingPreset); ok {
+		cfg.KeyBindingPreset = result.KeyBindingPreset
+		cfg.KeyBindings = kb
+	}
+	if err := cfg.Save(); err != nil {
+		return cfg, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if result.PostmanPath != "" {
+		if err := importPostmanInto(result.PostmanPath, workspacePath); err != nil {
+			return cfg, err
+		}
+	}
+
+	if result.CreateSample {
+		if err := CreateSampleWorkspace(workspacePath); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// importPostmanInto imports a Postman collection or environment into the workspace
+func importPostmanInto(path, workspacePath string) error {
+	fileType, err := postman.DetectFileType(path)
+	if err != nil {
+		return fmt.Errorf("failed to detect file type: %w", err)
+	}
+
+	switch fileType {
+	case postman.FileTypeCollection:
+		result, err := postman.ImportCollection(path)
+		if err != nil {
+			return fmt.Errorf("failed to import collection: %w", err)
+		}
+		return SaveImportedCollection(result.Collection, workspacePath)
+	case postman.FileTypeEnvironment:
+		result, err := postman.ImportEnvironment(path)
+		if err != nil {
+			return fmt.Errorf("failed to import environment: %w", err)
+		}
+		return SaveImportedEnvironment(result.Environment, workspacePath)
+	default:
+		return fmt.Errorf("unrecognized file format: not a valid Postman collection or environment")
+	}
+}
+
+// CreateSampleWorkspace initializes the workspace (if needed) and adds a demo collection and environment
+func CreateSampleWorkspace(workspacePath string) error {
+	configPath := filepath.Join(workspacePath, ".lazycurl", "config.yaml")
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		ws := config.DefaultWorkspaceConfig()
+		ws.Name = filepath.Base(workspacePath)
+		ws.DefaultEnv = "demo"
+		if err := ws.Save(workspacePath); err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+	}
+
+	if err := SaveImportedCollection(sampleCollection(), workspacePath); err != nil {
+		return fmt.Errorf("failed to create demo collection: %w", err)
+	}
+	if err := SaveImportedEnvironment(sampleEnvironment(), workspacePath); err != nil {
+		return fmt.Errorf("failed to create demo environment: %w", err)
+	}
+	return nil
+}
+
+// sampleCollection returns the demo collection, targeting the public JSONPlaceholder API
+func sampleCollection() *api.CollectionFile {
+	return &api.CollectionFile{
+		Name:        "Demo API",
+		Description: "Sample requests against JSONPlaceholder (https://jsonplaceholder.typicode.com)",
+		RequiredVariables: []api.VariableRequirement{
+			{Name: "base_url", Description: "API root"},
+		},
+		Folders: []api.Folder{
+			{
+				Name: "Posts",
+				Requests: []api.CollectionRequest{
+					{
+						ID:     "req_demo_list_posts",
+						Name:   "List posts",
+						Method: api.GET,
+						URL:    "{{base_url}}/posts",
+						Params: []api.KeyValueEntry{
+							{Key: "userId", Value: "{{user_id}}", Enabled: true},
+						},
+						Scripts: &api.ScriptConfig{
+							PostRequest: "lc.test(\"Status is 200\", function () {\n  lc.expect(lc.response.status).toBe(200);\n});\n",
+						},
+					},
+					{
+						ID:     "req_demo_get_post",
+						Name:   "Get post",
+						Method: api.GET,
+						URL:    "{{base_url}}/posts/1",
+						Scripts: &api.ScriptConfig{
+							PostRequest: "lc.test(\"Post has a title\", function () {\n  lc.expect(lc.response.json().title).toBeDefined();\n});\n",
+						},
+					},
+					{
+						ID:     "req_demo_create_post",
+						Name:   "Create post",
+						Method: api.POST,
+						URL:    "{{base_url}}/posts",
+						Headers: []api.KeyValueEntry{
+							{Key: "Content-Type", Value: "application/json", Enabled: true},
+						},
+						Body: &api.BodyConfig{
+							Type: "json",
+							Content: map[string]interface{}{
+								"title":  "Hello from LazyCurl",
+								"body":   "Created with the demo collection",
+								"userId": 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		Requests: []api.CollectionRequest{
+			{
+				ID:     "req_demo_get_user",
+				Name:   "Get user",
+				Method: api.GET,
+				URL:    "{{base_url}}/users/{{user_id}}",
+			},
+		},
+	}
+}
+
+// sampleEnvironment returns the environment used by the demo collection
+func sampleEnvironment() *api.EnvironmentFile {
+	return &api.EnvironmentFile{
+		Name:        "demo",
+		Description: "Variables for the Demo API collection",
+		Variables: map[string]*api.EnvironmentVariable{
+			"base_url": {Value: "https://jsonplaceholder.typicode.com", Active: true},
+			"user_id":  {Value: "1", Active: true},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func pressOnboarding(m OnboardingModel, keys ...tea.KeyMsg) OnboardingModel {
+	for _, key := range keys {
+		next, _ := m.Update(key)
+		m = next.(OnboardingModel)
+	}
+	return m
+}
+
+var (
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+)
+
+func TestOnboardingModel_Result(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want OnboardingResult
+	}{
+		{
+			name: "defaults",
+			keys: []tea.KeyMsg{keyEnter, keyEnter, keyEnter, keyEnter, keyEnter, keyEnter},
+			want: OnboardingResult{Theme: config.ThemeDark, KeyBindingPreset: config.KeyBindingPresetVim, CreateSample: true},
+		},
+		{
+			name: "light theme, default keys, no sample",
+			keys: []tea.KeyMsg{keyEnter, keyDown, keyEnter, keyDown, keyEnter, keyEnter, keyDown, keyEnter, keyEnter},
+			want: OnboardingResult{Theme: config.ThemeLight, KeyBindingPreset: config.KeyBindingPresetDefault},
+		},
+		{
+			name: "back keeps choices",
+			keys: []tea.KeyMsg{keyEnter, keyDown, keyEnter, keyEsc, keyEnter, keyEnter, keyEnter, keyEnter, keyEnter},
+			want: OnboardingResult{Theme: config.ThemeLight, KeyBindingPreset: config.KeyBindingPresetVim, CreateSample: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pressOnboarding(NewOnboardingModel(t.TempDir()), tt.keys...)
+			got, ok := m.Result()
+			if !ok {
+				t.Fatalf("wizard not completed, step = %d", m.step)
+			}
+			if got != tt.want {
+				t.Errorf("Result() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnboardingModel_InvalidImportPath(t *testing.T) {
+	m := pressOnboarding(NewOnboardingModel(t.TempDir()), keyEnter, keyEnter, keyEnter)
+	if m.step != OnboardingImport {
+		t.Fatalf("step = %d, want import", m.step)
+	}
+
+	m = pressOnboarding(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("missing.json")}, keyEnter)
+	if m.step != OnboardingImport || m.error == "" {
+		t.Errorf("expected to stay on import with an error, step = %d, error = %q", m.step, m.error)
+	}
+}
+
+func TestOnboardingModel_Cancel(t *testing.T) {
+	m := pressOnboarding(NewOnboardingModel(t.TempDir()), keyEnter, tea.KeyMsg{Type: tea.KeyCtrlC})
+	if _, ok := m.Result(); ok || !m.Canceled() {
+		t.Error("ctrl+c should skip the wizard without a result")
+	}
+}
+
+func TestApplyOnboarding(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+
+	cfg, err := ApplyOnboarding(OnboardingResult{
+		Theme:            config.ThemeLight,
+		KeyBindingPreset: config.KeyBindingPresetDefault,
+		PostmanPath:      filepath.Join("..", "import", "postman", "testdata", "simple_collection.json"),
+		CreateSample:     true,
+	}, workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !config.GlobalConfigExists() {
+		t.Fatal("global config was not saved")
+	}
+	saved, err := config.LoadGlobalConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Theme.Name != config.ThemeLight || saved.KeyBindingPreset != config.KeyBindingPresetDefault || saved.KeyBindings.NavigateLeft[0] != "shift+left" {
+		t.Errorf("saved config = %+v", saved)
+	}
+	if cfg.Theme.Name != saved.Theme.Name {
+		t.Errorf("returned theme %q, saved %q", cfg.Theme.Name, saved.Theme.Name)
+	}
+
+	collections, err := api.LoadAllCollections(filepath.Join(workspace, ".lazycurl", "collections"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 2 {
+		t.Fatalf("got %d collections, want imported + demo", len(collections))
+	}
+
+	env, err := api.LoadEnvironment(filepath.Join(workspace, ".lazycurl", "environments", "demo.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := api.MissingVariables(env, sampleCollection()); len(missing) != 0 {
+		t.Errorf("demo environment misses %+v", missing)
+	}
+
+	if _, err := os.Stat(filepath.Join(workspace, ".lazycurl", "config.yaml")); err != nil {
+		t.Errorf("workspace config not created: %v", err)
+	}
+}
@@ -29,11 +29,20 @@ const (
 	StateConfirmOverwrite                           // Asking to confirm overwrite
 	StateImporting                                  // Importing in progress
 	StateError                                      // Error occurred
+	StateSyncPreview                                // Showing the operations a :sync adds and removes
 )
 
 // OpenAPIImportModal handles the OpenAPI import modal
 type OpenAPIImportModal struct {
 	pathInput      textinput.Model
+	headerInput    textinput.Model // Optional auth header sent when fetching a spec URL
+	headerFocused  bool
+	env            *api.EnvironmentFile  // Resolves {{variables}} in spec URLs and headers
+	source         api.OpenAPISource     // Where the loaded spec comes from
+	status         string                // What the spinner is waiting for
+	syncing        bool                  // Whether the modal re-syncs a collection instead of importing
+	syncCollection *api.CollectionFile   // Collection re-synced with its spec (StateSyncPreview)
+	syncResult     api.OpenAPISyncResult // Operations added and removed by the sync
 	state          OpenAPIImportState
 	preview        *api.ImportPreview
 	importer       *api.OpenAPIImporter
@@ -61,8 +70,14 @@ func NewOpenAPIImportModal(collectionsDir string) *OpenAPIImportModal {
 	ti.CharLimit = 500
 	ti.Width = 60
 
+	hi := textinput.New()
+	hi.Placeholder = "Authorization: Bearer {{token}} (optional, for URLs)"
+	hi.CharLimit = 500
+	hi.Width = 60
+
 	return &OpenAPIImportModal{
 		pathInput:      ti,
+		headerInput:    hi,
 		state:          StateInputPath,
 		visible:        false,
 		width:          80,
@@ -79,15 +94,72 @@ func (m *OpenAPIImportModal) Show() {
 	m.preview = nil
 	m.importer = nil
 	m.filePath = ""
+	m.source = api.OpenAPISource{}
+	m.syncing = false
+	m.syncCollection = nil
 	m.pathInput.Reset()
-	m.pathInput.Focus()
+	m.headerInput.Reset()
+	m.focusHeader(false)
+}
+
+// SetEnvironment sets the environment resolving {{variables}} in spec URLs and headers
+func (m *OpenAPIImportModal) SetEnvironment(env *api.EnvironmentFile) {
+	m.env = env
+}
+
+// SetLocation prefills the spec file path or URL
+func (m *OpenAPIImportModal) SetLocation(location string) {
+	m.pathInput.SetValue(location)
+	m.pathInput.CursorEnd()
+}
+
+// ShowSync opens the modal to re-sync collection with the spec it was imported from.
+// The returned command fetches the spec; the changes are shown before they are saved.
+func (m *OpenAPIImportModal) ShowSync(collection *api.CollectionFile) tea.Cmd {
+	m.Show()
+	m.pathInput.Blur()
+	m.syncing = true
+	m.source = *collection.OpenAPISource
+	m.state = StateImporting
+	m.importing = true
+	m.spinnerFrame = 0
+	m.status = "Fetching " + m.source.Location + "..."
+
+	source, env := m.source, m.env
+	syncCmd := func() tea.Msg {
+		importer, err := api.NewOpenAPIImporterFromSource(source, env)
+		if err != nil {
+			return OpenAPISyncPreviewMsg{Error: err}
+		}
+		fresh, err := importer.ToCollection(api.ImportOptions{IncludeExamples: true})
+		if err != nil {
+			return OpenAPISyncPreviewMsg{Error: err}
+		}
+		synced, result := api.SyncOpenAPICollection(collection, fresh)
+		return OpenAPISyncPreviewMsg{Collection: synced, Result: result}
+	}
+	return tea.Batch(m.spinnerTick(), syncCmd)
+}
+
+// focusHeader moves the focus between the path and header inputs
+func (m *OpenAPIImportModal) focusHeader(header bool) {
+	m.headerFocused = header
+	if header {
+		m.pathInput.Blur()
+		m.headerInput.Focus()
+	} else {
+		m.headerInput.Blur()
+		m.pathInput.Focus()
+	}
 }
 
 // Hide hides the modal
 func (m *OpenAPIImportModal) Hide() {
 	m.visible = false
 	m.error = ""
+	m.importing = false
 	m.pathInput.Blur()
+	m.headerInput.Blur()
 }
 
 // IsVisible returns whether the modal is visible
@@ -105,6 +177,7 @@ func (m *OpenAPIImportModal) SetSize(width, height int) {
 	inputWidth := modalWidth - 10
 
 	m.pathInput.Width = inputWidth
+	m.headerInput.Width = inputWidth
 }
 
 // Update handles messages for the OpenAPI import modal
@@ -121,6 +194,21 @@ func (m *OpenAPIImportModal) Update(msg tea.Msg) (*OpenAPIImportModal, tea.Cmd)
 		}
 		return m, nil
 
+	case OpenAPISpecLoadedMsg:
+		m.importing = false
+		return m.showPreview(msg.Importer, msg.Error)
+
+	case OpenAPISyncPreviewMsg:
+		m.importing = false
+		if msg.Error != nil {
+			m.setLoadError(msg.Error)
+			return m, nil
+		}
+		m.syncCollection = msg.Collection
+		m.syncResult = msg.Result
+		m.state = StateSyncPreview
+		return m, nil
+
 	case OpenAPIImportCompleteMsg:
 		m.importing = false
 		if msg.Error != nil {
@@ -153,6 +241,13 @@ func (m *OpenAPIImportModal) Update(msg tea.Msg) (*OpenAPIImportModal, tea.Cmd)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
+			if m.syncing {
+				// Cancel a sync
+				m.Hide()
+				return m, func() tea.Msg {
+					return HideOpenAPIImportModalMsg{}
+				}
+			}
 			if m.state == StatePreview || m.state == StateConfirmOverwrite {
 				// Go back to input
 				m.state = StateInputPath
@@ -182,6 +277,9 @@ func (m *OpenAPIImportModal) Update(msg tea.Msg) (*OpenAPIImportModal, tea.Cmd)
 			case StateConfirmOverwrite:
 				// Confirm with selected choice
 				return m.executeImport()
+
+			case StateSyncPreview:
+				return m.applySync()
 			}
 
 		case "tab", "left", "right":
@@ -190,6 +288,10 @@ func (m *OpenAPIImportModal) Update(msg tea.Msg) (*OpenAPIImportModal, tea.Cmd)
 				m.overwriteChoice = 1 - m.overwriteChoice
 				return m, nil
 			}
+			if m.state == StateInputPath && msg.String() == "tab" {
+				m.focusHeader(!m.headerFocused)
+				return m, nil
+			}
 
 		case "o", "O":
 			// Quick key for overwrite
@@ -207,10 +309,14 @@ func (m *OpenAPIImportModal) Update(msg tea.Msg) (*OpenAPIImportModal, tea.Cmd)
 		}
 	}
 
-	// Pass other messages to text input when in input state
+	// Pass other messages to the focused text input when in input state
 	if m.state == StateInputPath {
 		var cmd tea.Cmd
-		m.pathInput, cmd = m.pathInput.Update(msg)
+		if m.headerFocused {
+			m.headerInput, cmd = m.headerInput.Update(msg)
+		} else {
+			m.pathInput, cmd = m.pathInput.Update(msg)
+		}
 		return m, cmd
 	}
 
@@ -224,14 +330,39 @@ func (m *OpenAPIImportModal) spinnerTick() tea.Cmd {
 	})
 }
 
-// loadSpec loads and previews the OpenAPI spec
+// loadSpec loads and previews the OpenAPI spec. Spec URLs are downloaded in the background.
 func (m *OpenAPIImportModal) loadSpec() (*OpenAPIImportModal, tea.Cmd) {
 	path := strings.TrimSpace(m.pathInput.Value())
 	if path == "" {
-		m.error = "Please enter a file path"
+		m.error = "Please enter a file path or URL"
 		return m, nil
 	}
 
+	if api.IsRemoteSpec(path) {
+		m.source = api.OpenAPISource{Location: path}
+		if line := strings.TrimSpace(m.headerInput.Value()); line != "" {
+			header, err := api.ParseHeaderLine(line)
+			if err != nil {
+				m.error = err.Error()
+				return m, nil
+			}
+			m.source.Headers = []api.KeyValueEntry{header}
+		}
+		m.filePath = path
+		m.state = StateImporting
+		m.importing = true
+		m.spinnerFrame = 0
+		m.status = "Fetching " + path + "..."
+		m.pathInput.Blur()
+		m.headerInput.Blur()
+
+		source, env := m.source, m.env
+		return m, tea.Batch(m.spinnerTick(), func() tea.Msg {
+			importer, err := api.NewOpenAPIImporterFromSource(source, env)
+			return OpenAPISpecLoadedMsg{Importer: importer, Error: err}
+		})
+	}
+
 	// Expand ~ to home directory
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
@@ -249,36 +380,24 @@ func (m *OpenAPIImportModal) loadSpec() (*OpenAPIImportModal, tea.Cmd) {
 	}
 
 	m.filePath = path
+	m.source = api.OpenAPISource{Location: path}
 
 	// Create importer
 	importer, err := api.NewOpenAPIImporterFromFile(path)
+	return m.showPreview(importer, err)
+}
+
+// showPreview previews the spec loaded by importer, or shows the error that prevented loading it
+func (m *OpenAPIImportModal) showPreview(importer *api.OpenAPIImporter, err error) (*OpenAPIImportModal, tea.Cmd) {
 	if err != nil {
-		var importErr *api.ImportError
-		if errors.As(err, &importErr) {
-			m.error = importErr.Message
-			if importErr.Details != "" {
-				m.error += "\n" + importErr.Details
-			}
-		} else {
-			m.error = err.Error()
-		}
-		m.state = StateError
+		m.setLoadError(err)
 		return m, nil
 	}
 
 	// Get preview
 	preview, err := importer.Preview()
 	if err != nil {
-		var importErr *api.ImportError
-		if errors.As(err, &importErr) {
-			m.error = importErr.Message
-			if importErr.Details != "" {
-				m.error += "\n" + importErr.Details
-			}
-		} else {
-			m.error = err.Error()
-		}
-		m.state = StateError
+		m.setLoadError(err)
 		return m, nil
 	}
 
@@ -287,10 +406,40 @@ func (m *OpenAPIImportModal) loadSpec() (*OpenAPIImportModal, tea.Cmd) {
 	m.state = StatePreview
 	m.error = ""
 	m.pathInput.Blur()
+	m.headerInput.Blur()
 
 	return m, nil
 }
 
+// setLoadError shows an error that prevented loading a spec
+func (m *OpenAPIImportModal) setLoadError(err error) {
+	var importErr *api.ImportError
+	if errors.As(err, &importErr) {
+		m.error = importErr.Message
+		if importErr.Details != "" {
+			m.error += "\n" + importErr.Details
+		}
+	} else {
+		m.error = err.Error()
+	}
+	m.state = StateError
+}
+
+// applySync saves the re-synced collection, or closes the modal when the spec has not changed
+func (m *OpenAPIImportModal) applySync() (*OpenAPIImportModal, tea.Cmd) {
+	collection, result := m.syncCollection, m.syncResult
+	m.Hide()
+	if result.Empty() {
+		return m, func() tea.Msg {
+			return OpenAPISyncedMsg{Collection: collection, Result: result}
+		}
+	}
+	return m, func() tea.Msg {
+		err := api.SaveCollection(collection, collection.FilePath)
+		return OpenAPISyncedMsg{Collection: collection, Result: result, Error: err}
+	}
+}
+
 // checkConflictAndImport checks for existing collections and handles conflicts
 func (m *OpenAPIImportModal) checkConflictAndImport() (*OpenAPIImportModal, tea.Cmd) {
 	if m.importer == nil {
@@ -345,6 +494,7 @@ func (m *OpenAPIImportModal) executeImport() (*OpenAPIImportModal, tea.Cmd) {
 	m.state = StateImporting
 	m.importing = true
 	m.spinnerFrame = 0
+	m.status = "Importing specification..."
 
 	// Determine save path based on conflict resolution
 	var savePath string
@@ -365,6 +515,7 @@ func (m *OpenAPIImportModal) executeImport() (*OpenAPIImportModal, tea.Cmd) {
 	// Start async import
 	importer := m.importer
 	collectionsDir := m.collectionsDir
+	source := m.source
 
 	importCmd := func() tea.Msg {
 		// Perform import
@@ -383,6 +534,7 @@ func (m *OpenAPIImportModal) executeImport() (*OpenAPIImportModal, tea.Cmd) {
 		}
 
 		collection.FilePath = savePath
+		collection.OpenAPISource = &source
 		if err := api.SaveCollection(collection, savePath); err != nil {
 			return OpenAPIImportCompleteMsg{
 				Error: fmt.Errorf("failed to save collection: %w", err),
@@ -448,18 +600,40 @@ func (m *OpenAPIImportModal) View() string {
 	var content strings.Builder
 
 	// Title
-	content.WriteString(titleStyle.Render("📄 Import OpenAPI Specification"))
+	if m.syncing {
+		content.WriteString(titleStyle.Render("🔄 Sync OpenAPI Collection"))
+	} else {
+		content.WriteString(titleStyle.Render("📄 Import OpenAPI Specification"))
+	}
 	content.WriteString("\n")
 
 	switch m.state {
 	case StateInputPath, StateError:
-		content.WriteString(subtitleStyle.Render("Enter the path to your OpenAPI 3.x specification file"))
+		if m.syncing {
+			content.WriteString(labelStyle.Render("Spec: "))
+			content.WriteString(valueStyle.Render(m.source.Location))
+			if m.error != "" {
+				content.WriteString("\n")
+				content.WriteString(errorStyle.Render("⚠ " + m.error))
+			}
+			content.WriteString("\n")
+			content.WriteString(helpStyle.Render("Esc: Close"))
+			break
+		}
+
+		content.WriteString(subtitleStyle.Render("Enter the path or URL of an OpenAPI 3.x spec, Swagger UI or Redoc page"))
 		content.WriteString("\n\n")
 
 		// Path input
-		content.WriteString(labelStyle.Render("File Path:"))
+		content.WriteString(labelStyle.Render("File Path or URL:"))
 		content.WriteString("\n")
 		content.WriteString(m.pathInput.View())
+		content.WriteString("\n")
+
+		// Auth header for spec URLs
+		content.WriteString(labelStyle.Render("Auth Header:"))
+		content.WriteString("\n")
+		content.WriteString(m.headerInput.View())
 
 		// Error message
 		if m.error != "" {
@@ -469,7 +643,47 @@ func (m *OpenAPIImportModal) View() string {
 
 		// Help text
 		content.WriteString("\n")
-		content.WriteString(helpStyle.Render("Enter: Load & Preview • Esc: Cancel"))
+		content.WriteString(helpStyle.Render("Enter: Load & Preview • Tab: Switch field • Esc: Cancel"))
+
+	case StateSyncPreview:
+		content.WriteString(labelStyle.Render("Spec: "))
+		content.WriteString(valueStyle.Render(m.source.Location))
+		content.WriteString("\n\n")
+
+		if m.syncResult.Empty() {
+			content.WriteString(successStyle.Render("✓ " + m.syncCollection.Name + " is up to date with its spec"))
+			content.WriteString("\n")
+			content.WriteString(helpStyle.Render("Enter/Esc: Close"))
+			break
+		}
+
+		removedStyle := lipgloss.NewStyle().Foreground(styles.Red)
+		content.WriteString(fmt.Sprintf("%s added, %s removed\n\n",
+			successStyle.Render(fmt.Sprintf("%d", len(m.syncResult.Added))),
+			removedStyle.Render(fmt.Sprintf("%d", len(m.syncResult.Removed)))))
+
+		// Keep the list within the modal; the rest is summarized
+		maxLines := max(m.height-16, 4)
+		lines := 0
+		writeOperations := func(requests []api.CollectionRequest, sign string, style lipgloss.Style) {
+			for i, req := range requests {
+				if lines == maxLines {
+					content.WriteString(subtitleStyle.Render(fmt.Sprintf("  ... and %d more", len(requests)-i)))
+					content.WriteString("\n")
+					return
+				}
+				content.WriteString(style.Render(sign + " " + req.Operation))
+				content.WriteString(subtitleStyle.Render("  " + req.Name))
+				content.WriteString("\n")
+				lines++
+			}
+		}
+		writeOperations(m.syncResult.Added, "+", successStyle)
+		writeOperations(m.syncResult.Removed, "-", removedStyle)
+
+		content.WriteString(subtitleStyle.Render("Requests still in the spec keep your edits; requests added by hand are kept."))
+		content.WriteString("\n")
+		content.WriteString(helpStyle.Render("Enter: Apply • Esc: Cancel"))
 
 	case StatePreview:
 		if m.preview == nil {
@@ -606,7 +820,7 @@ func (m *OpenAPIImportModal) View() string {
 		spinner := spinnerFrames[m.spinnerFrame]
 		content.WriteString(spinnerStyle.Render(spinner))
 		content.WriteString(" ")
-		content.WriteString(subtitleStyle.Render("Importing specification..."))
+		content.WriteString(subtitleStyle.Render(m.status))
 		content.WriteString("\n\n")
 
 		// Progress details
@@ -1,9 +1,16 @@
 package ui
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
 )
 
 func TestSanitizeFilename(t *testing.T) {
@@ -217,3 +224,108 @@ func TestOpenAPIImportModal_OverwriteChoice(t *testing.T) {
 		}
 	})
 }
+
+// runModalCmd runs cmd and returns the first message that is not a spinner tick
+func runModalCmd(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c == nil {
+				continue
+			}
+			if m := c(); m != nil {
+				if _, tick := m.(OpenAPISpinnerTickMsg); !tick {
+					return m
+				}
+			}
+		}
+		t.Fatal("batch has no result message")
+	}
+	return msg
+}
+
+func TestOpenAPIImportModal_URLAndSync(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("..", "..", "testdata", "openapi", "minimal-3.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "k3y" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(spec)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	modal := NewOpenAPIImportModal(dir)
+	modal.SetEnvironment(&api.EnvironmentFile{Variables: map[string]*api.EnvironmentVariable{
+		"api_key": {Value: "k3y", Active: true},
+	}})
+	modal.Show()
+	modal.SetLocation(server.URL + "/openapi.json")
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyTab})
+	modal.headerInput.SetValue("X-Api-Key: {{api_key}}")
+
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	loaded, ok := runModalCmd(t, cmd).(OpenAPISpecLoadedMsg)
+	if !ok || loaded.Error != nil {
+		t.Fatalf("load = %+v", loaded)
+	}
+	modal, _ = modal.Update(loaded)
+	if modal.state != StatePreview || modal.preview.Title != "Minimal API" {
+		t.Fatalf("state = %v, error = %q", modal.state, modal.error)
+	}
+
+	_, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	done, ok := runModalCmd(t, cmd).(OpenAPIImportCompleteMsg)
+	if !ok || done.Error != nil {
+		t.Fatalf("import = %+v", done)
+	}
+
+	// The source keeps the header unresolved so the key is not written to the collection
+	col, err := api.LoadCollection(done.SavePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.OpenAPISource == nil || col.OpenAPISource.Location != server.URL+"/openapi.json" ||
+		len(col.OpenAPISource.Headers) != 1 || col.OpenAPISource.Headers[0].Value != "{{api_key}}" {
+		t.Fatalf("OpenAPISource = %+v", col.OpenAPISource)
+	}
+
+	// The spec drops POST /users and adds GET /status
+	spec = []byte(`{"openapi":"3.0.3","info":{"title":"Minimal API","version":"2"},"servers":[{"url":"https://api.example.com/v1"}],"paths":{` +
+		`"/health":{"get":{"summary":"Health check endpoint","tags":["System"],"responses":{"200":{"description":"OK"}}}},` +
+		`"/users":{"get":{"summary":"List all users","tags":["Users"],"responses":{"200":{"description":"OK"}}}},` +
+		`"/status":{"get":{"summary":"Status","tags":["System"],"responses":{"200":{"description":"OK"}}}}}}`)
+
+	preview, ok := runModalCmd(t, modal.ShowSync(col)).(OpenAPISyncPreviewMsg)
+	if !ok || preview.Error != nil {
+		t.Fatalf("sync = %+v", preview)
+	}
+	modal, _ = modal.Update(preview)
+	view := modal.View()
+	for _, want := range []string{"Sync OpenAPI Collection", "+ GET /status", "- POST /users"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	_, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	synced, ok := runModalCmd(t, cmd).(OpenAPISyncedMsg)
+	if !ok || synced.Error != nil {
+		t.Fatalf("synced = %+v", synced)
+	}
+	if modal.IsVisible() {
+		t.Error("modal should close after applying the sync")
+	}
+	saved, _ := api.LoadCollection(done.SavePath)
+	if n := countCollectionRequests(saved); n != 3 {
+		t.Errorf("saved collection has %d requests, want 3", n)
+	}
+}
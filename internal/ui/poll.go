@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+const (
+	// defaultPollInterval is the interval of :poll without argument
+	defaultPollInterval = 5 * time.Second
+	// minPollInterval is the shortest interval accepted by :poll
+	minPollInterval = 500 * time.Millisecond
+)
+
+// responsePoll re-sends a request at a fixed interval. The Response panel follows its
+// responses, with the lines changed since the previous response highlighted.
+type responsePoll struct {
+	id           int
+	source       *api.CollectionRequest // Request sent, as open when the poll started
+	preRequest   string
+	postResponse string
+	tab          *requestTab // Tab whose Response panel follows the poll
+	interval     time.Duration
+	count        int    // Responses received
+	previous     string // Body shown for the previous response
+}
+
+// PollTickMsg is sent when the next send of a poll is due
+type PollTickMsg struct {
+	PollID int
+}
+
+// pollTick returns a command that fires when the next send of a poll is due
+func pollTick(id int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return PollTickMsg{PollID: id}
+	})
+}
+
+// handlePollCommand starts polling the open request, or stops polling
+func (m Model) handlePollCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 0 && strings.ToLower(args[0]) == PollOff {
+		if m.poll == nil {
+			m.statusBar.Info("Not polling")
+			return m, nil
+		}
+		m.stopPoll()
+		m.statusBar.Info("Polling stopped")
+		return m, nil
+	}
+
+	interval := defaultPollInterval
+	if len(args) > 0 {
+		d, err := time.ParseDuration(args[0])
+		if err != nil || d < minPollInterval {
+			m.statusBar.Info(fmt.Sprintf("Usage: :poll [interval|off] (interval of at least %s, e.g. 2s)", minPollInterval))
+			return m, nil
+		}
+		interval = d
+	}
+
+	if m.requestPanel.GetURL() == "" {
+		m.statusBar.Info("No URL to poll")
+		return m, nil
+	}
+	src := m.requestSource()
+	if len(api.FindPromptVariables(src)) > 0 {
+		m.statusBar.Info("Requests with prompt variables cannot be polled")
+		return m, nil
+	}
+
+	m.pollSeq++
+	m.poll = &responsePoll{
+		id:           m.pollSeq,
+		source:       src,
+		preRequest:   m.requestPanel.GetPreRequestScript(),
+		postResponse: m.requestPanel.GetPostRequestScript(),
+		tab:          m.currentTab(),
+		interval:     interval,
+	}
+	m.activePanel = ResponsePanel
+	m.responsePanel.SetPollNotice(fmt.Sprintf("Polling every %s", interval))
+	return m, m.sendPoll()
+}
+
+// handlePollTick sends the poll again, unless it stopped or its previous send is still
+// in flight
+func (m Model) handlePollTick(msg PollTickMsg) (tea.Model, tea.Cmd) {
+	if m.poll == nil || m.poll.id != msg.PollID {
+		return m, nil
+	}
+	return m, m.sendPoll()
+}
+
+// sendPoll sends the polled request with the active environment and schedules the next send
+func (m *Model) sendPoll() tea.Cmd {
+	next := pollTick(m.poll.id, m.poll.interval)
+	if m.sends.busy(m.poll.source.ID) {
+		return next
+	}
+
+	environments := m.leftPanel.GetEnvironments()
+	req, err := buildHTTPRequestFrom(m.poll.source, m.requestVariables(m.poll.source.ID), m.variableTypes(m.poll.source.ID))
+	if err != nil {
+		m.stopPoll()
+		m.statusBar.Error(err)
+		return nil
+	}
+	send := &pendingSend{
+		requestID:    m.poll.source.ID,
+		request:      req,
+		source:       m.poll.source,
+		variables:    api.NewVariableSnapshot(m.poll.source, environments.GetActiveEnvironment()),
+		preRequest:   m.poll.preRequest,
+		postResponse: m.poll.postResponse,
+		poll:         m.poll.id,
+		tab:          m.poll.tab,
+	}
+	return tea.Batch(m.startSend(send), next)
+}
+
+// stopPoll stops polling; the last response stays in the Response panel
+func (m *Model) stopPoll() {
+	m.poll = nil
+	m.responsePanel.SetPollNotice("")
+}
+
+// showPollResponse highlights the lines of the body changed since the previous response
+// of the poll. The body cursor keeps its position across responses.
+func (m *Model) showPollResponse(row, col int) {
+	m.poll.count++
+	notice := fmt.Sprintf("Polling every %s · #%d", m.poll.interval, m.poll.count)
+	if m.poll.count > 1 {
+		changed := m.responsePanel.HighlightChanges(m.poll.previous)
+		m.responsePanel.SetBodyCursor(row, col)
+		switch changed {
+		case 0:
+			notice += " · no changes"
+		case 1:
+			notice += " · 1 line changed"
+		default:
+			notice += fmt.Sprintf(" · %d lines changed", changed)
+		}
+	}
+	m.poll.previous = m.responsePanel.BodyText()
+	m.responsePanel.SetPollNotice(notice)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -50,6 +51,47 @@ func ImportPostmanFile(filePath string) tea.Cmd {
 	}
 }
 
+// ImportPostmanFiles imports the Postman collections and environments of a directory or
+// glob pattern.
+func ImportPostmanFiles(path string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := postman.ExpandBatch(path)
+		if err != nil {
+			return PostmanImportErrorMsg{Error: err}
+		}
+		return PostmanBatchImportedMsg{Batch: postman.ImportBatch(files)}
+	}
+}
+
+// handlePostmanBatchImported saves the collections and environments of a batch import to
+// the workspace and reports the batch in the status bar
+func (m Model) handlePostmanBatchImported(msg PostmanBatchImportedMsg) (tea.Model, tea.Cmd) {
+	batch := msg.Batch
+	var failed []string
+	for _, f := range batch.Failed() {
+		failed = append(failed, filepath.Base(f.Path)+": "+f.Error.Error())
+	}
+	for _, f := range batch.Collections() {
+		if err := SaveImportedCollection(f.Result.Collection, m.workspacePath); err != nil {
+			failed = append(failed, filepath.Base(f.Path)+": "+err.Error())
+		}
+	}
+	for _, f := range batch.Environments() {
+		if err := SaveImportedEnvironment(f.Result.Environment, m.workspacePath); err != nil {
+			failed = append(failed, filepath.Base(f.Path)+": "+err.Error())
+		}
+	}
+	m.leftPanel.GetCollections().ReloadCollections()
+	m.leftPanel.GetEnvironments().ReloadEnvironments()
+
+	if len(failed) > 0 {
+		m.statusBar.Error(fmt.Errorf("%s; %s", batch.FormatSummary(), strings.Join(failed, "; ")))
+		return m, nil
+	}
+	m.statusBar.Success("Imported", batch.FormatSummary())
+	return m, nil
+}
+
 // ExportCollectionToPostman exports a LazyCurl collection to Postman format.
 func ExportCollectionToPostman(collection *api.CollectionFile, outputPath string) tea.Cmd {
 	return func() tea.Msg {
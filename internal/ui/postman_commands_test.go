@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_ImportPostmanDirectory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	exports := t.TempDir()
+	testdata := filepath.Join("..", "import", "postman", "testdata")
+	for _, name := range []string{"simple_collection.json", "simple_environment.json", "not_postman.json"} {
+		data, err := os.ReadFile(filepath.Join(testdata, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(exports, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+
+	model, cmd := m.Update(CommandExecuteMsg{Command: CmdImport, Args: []string{ImportPostman, exports}})
+	if cmd == nil {
+		t.Fatal(":import postman <dir> should import the directory")
+	}
+	model, _ = model.Update(cmd())
+	m = model.(Model)
+
+	if msg := m.statusBar.message; !strings.Contains(msg, "Imported 1 collections, 1 environments - 1 skipped") {
+		t.Errorf("the batch should be summarized, got %q", msg)
+	}
+	for _, path := range []string{"collections/Simple_API.json", "environments/Development.json"} {
+		if _, err := os.Stat(filepath.Join(workspace, ".lazycurl", path)); err != nil {
+			t.Errorf("the import should save %s: %v", path, err)
+		}
+	}
+	if len(m.leftPanel.GetCollections().GetCollections()) != 1 {
+		t.Error("the imported collection should be loaded")
+	}
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/clipboard"
+)
+
+// registerPreviewLength is the length of the register contents listed by :registers
+const registerPreviewLength = 24
+
+// copyMethodNote returns a note for the status bar when a copy went through OSC 52,
+// which the terminal may not support
+func copyMethodNote(method string) string {
+	if method == clipboard.MethodOSC52 {
+		return " (via OSC 52)"
+	}
+	return ""
+}
+
+// handleRegistersCommand lists the clipboard registers, or copies one back to the
+// clipboard so it can be pasted with the terminal
+func (m Model) handleRegistersCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		registers := clipboard.All()
+		if len(registers) == 0 {
+			m.statusBar.Info("No registers yet: copied values are kept in registers 0-9")
+			return m, nil
+		}
+		parts := make([]string, len(registers))
+		for i, reg := range registers {
+			parts[i] = fmt.Sprintf("%d %s %q", i, reg.Label, registerPreview(reg.Content))
+		}
+		m.statusBar.Info("Registers: " + strings.Join(parts, " · ") + " (:reg <n> to copy back)")
+		return m, nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 || n >= clipboard.RegisterCount {
+		m.statusBar.Info("Usage: :registers [0-9]")
+		return m, nil
+	}
+	reg, method, ok := clipboard.Put(n)
+	if !ok {
+		m.statusBar.Info(fmt.Sprintf("Register %d is empty", n))
+		return m, nil
+	}
+	m.statusBar.Success("Copied", reg.Label+copyMethodNote(method))
+	return m, nil
+}
+
+// registerPreview returns the first line of content, shortened
+func registerPreview(content string) string {
+	line, _, multiline := strings.Cut(strings.TrimSpace(content), "\n")
+	if runes := []rune(line); len(runes) > registerPreviewLength {
+		line, multiline = string(runes[:registerPreviewLength]), true
+	}
+	if multiline {
+		line += "…"
+	}
+	return line
+}
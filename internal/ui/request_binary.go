@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// RequestBinaryPickMsg asks for the file sent as a binary body. Path is the current file.
+type RequestBinaryPickMsg struct {
+	Path string
+}
+
+// RequestBinaryEditMsg asks to type the path of the file sent as a binary body,
+// for paths with {{variables}}
+type RequestBinaryEditMsg struct {
+	Path string
+}
+
+// GetBinaryPath returns the path of the file sent as a binary body
+func (r *RequestView) GetBinaryPath() string {
+	return r.binaryPath
+}
+
+// SetBinaryPath sets the path of the file sent as a binary body
+func (r *RequestView) SetBinaryPath(path string) {
+	r.binaryPath = strings.TrimSpace(path)
+}
+
+// handleBinaryKeys handles the keys of the binary body: o chooses the file, c/i types
+// its path. Returns false for other keys.
+func (r RequestView) handleBinaryKeys(msg tea.KeyMsg) (RequestView, tea.Cmd, bool) {
+	path := r.binaryPath
+	switch msg.String() {
+	case "o", "enter":
+		return r, func() tea.Msg {
+			return RequestBinaryPickMsg{Path: path}
+		}, true
+	case "c", "i":
+		return r, func() tea.Msg {
+			return RequestBinaryEditMsg{Path: path}
+		}, true
+	}
+	return r, nil, false
+}
+
+// renderBinaryBody renders the file sent as a binary body, with its size and type
+func (r *RequestView) renderBinaryBody(width int) string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Width(10)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	pathStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	errStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+	hint := hintStyle.Render("o: choose file · c: type path ({{variables}} allowed)")
+
+	if r.binaryPath == "" {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Width(width).
+			Align(lipgloss.Center).
+			Padding(2, 0)
+		return emptyStyle.Render("No file selected\n\nPress o to choose the file sent as the body")
+	}
+
+	var result strings.Builder
+	result.WriteString(labelStyle.Render("File") + pathStyle.Render(r.binaryPath))
+	result.WriteString("\n")
+
+	switch {
+	case strings.Contains(r.binaryPath, "{{"):
+		result.WriteString(labelStyle.Render("") + hintStyle.Render("Variables are resolved when the request is sent"))
+		result.WriteString("\n")
+	default:
+		path := api.ExpandHome(r.binaryPath)
+		info, err := os.Stat(path)
+		if err != nil {
+			result.WriteString(labelStyle.Render("") + errStyle.Render(err.Error()))
+			result.WriteString("\n")
+			break
+		}
+		if info.IsDir() {
+			result.WriteString(labelStyle.Render("") + errStyle.Render(path+" is a directory"))
+			result.WriteString("\n")
+			break
+		}
+		result.WriteString(labelStyle.Render("Size") + valueStyle.Render(api.FormatSize(info.Size())))
+		result.WriteString("\n")
+		result.WriteString(labelStyle.Render("Type") + valueStyle.Render(fileContentType(path)))
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(hint)
+	return result.String()
+}
+
+// fileContentType returns the media type a file is sent with when no Content-Type
+// header is set
+func fileContentType(path string) string {
+	head := make([]byte, 512)
+	file, err := os.Open(path)
+	if err != nil {
+		return api.DetectFileContentType(path, nil)
+	}
+	defer file.Close()
+	n, _ := file.Read(head)
+	return api.DetectFileContentType(path, head[:n])
+}
@@ -0,0 +1,559 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// maxBuilderDepth is the depth of nested objects walked by the body builder
+const maxBuilderDepth = 6
+
+// RequestBodyBuiltMsg is sent when the body builder generates the JSON body, or fails to
+// open
+type RequestBodyBuiltMsg struct {
+	BodyType string
+	Content  string
+	Fields   int   // Fields sent in the body
+	Err      error // Why the builder did not open
+}
+
+// builderField is a value of the body builder: a property of an object, or the body
+// itself
+type builderField struct {
+	name     string
+	depth    int
+	schema   *api.JSONSchema
+	required bool
+	value    string          // Text of the value: a string as is, other values as JSON
+	set      bool            // Sent in the body; required fields always are
+	fields   []*builderField // Properties of an object
+}
+
+// isObject returns true for a field edited through its properties
+func (f *builderField) isObject() bool {
+	return f.schema.ValueType() == "object" && len(f.schema.Properties) > 0
+}
+
+// typeLabel describes the type of the field: "string", "enum", "array<integer>"...
+func (f *builderField) typeLabel() string {
+	s := f.schema
+	switch {
+	case len(s.Enum) > 0:
+		return "enum"
+	case s.ValueType() == "array" && s.Items != nil && s.Items.ValueType() != "":
+		return "array<" + string(s.Items.ValueType()) + ">"
+	case s.ValueType() == "string" && s.Format != "":
+		return "string:" + s.Format
+	case s.ValueType() == "":
+		return "any"
+	}
+	return string(s.ValueType())
+}
+
+// bodyBuilder is a form over the fields of the JSON Schema of a body, generating the
+// body from the values entered
+type bodyBuilder struct {
+	root    *builderField
+	rows    []*builderField // Fields in display order
+	cursor  int
+	editing bool
+	buffer  string // Text of the field being edited
+	err     string // Error of the last edit
+}
+
+// newBodyBuilder returns a builder over schema, filled with the values of the JSON
+// body content when it parses
+func newBodyBuilder(schema *api.JSONSchema, content string) *bodyBuilder {
+	b := &bodyBuilder{root: newBuilderField("body", schema, true, -1)}
+	var value interface{}
+	if json.Unmarshal([]byte(content), &value) == nil {
+		b.root.fill(value)
+	}
+	if b.root.isObject() {
+		b.flatten(b.root.fields)
+	} else {
+		b.root.depth = 0
+		b.rows = []*builderField{b.root}
+	}
+	return b
+}
+
+// newBuilderField returns the field of schema and, for an object, of its properties
+func newBuilderField(name string, schema *api.JSONSchema, required bool, depth int) *builderField {
+	f := &builderField{name: name, depth: depth, schema: schema, required: required}
+	if f.isObject() && depth < maxBuilderDepth {
+		for _, prop := range schema.PropertyNames() {
+			f.fields = append(f.fields, newBuilderField(prop, schema.Properties[prop], schema.IsRequired(prop), depth+1))
+		}
+	}
+	return f
+}
+
+// fill sets the values of the field from a decoded JSON value
+func (f *builderField) fill(value interface{}) {
+	if obj, ok := value.(map[string]interface{}); ok && len(f.fields) > 0 {
+		for _, field := range f.fields {
+			if v, ok := obj[field.name]; ok {
+				field.fill(v)
+			}
+		}
+		return
+	}
+	if text, ok := value.(string); ok && (f.schema.ValueType() == "string" || f.schema.ValueType() == "" || isTemplate(text)) {
+		f.value = text
+	} else if data, err := json.Marshal(value); err == nil {
+		f.value = string(data)
+	}
+	f.set = true
+}
+
+// flatten lists fields and their properties in display order
+func (b *bodyBuilder) flatten(fields []*builderField) {
+	for _, f := range fields {
+		b.rows = append(b.rows, f)
+		b.flatten(f.fields)
+	}
+}
+
+// isTemplate reports whether text holds a {{variable}}, sent as is whatever the type
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// fieldJSON returns the JSON of a value of schema entered as text. A {{variable}} is
+// sent as a string, unquoted when its variable is typed (see api.UnquoteTypedPlaceholders).
+func fieldJSON(schema *api.JSONSchema, text string) ([]byte, error) {
+	if isTemplate(text) {
+		return json.Marshal(text)
+	}
+	var value interface{}
+	switch typ := schema.ValueType(); typ {
+	case "string":
+		value = text
+	case "integer":
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", text)
+		}
+		value = n
+	case "number":
+		n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		value = n
+	case "boolean":
+		if text != "true" && text != "false" {
+			return nil, fmt.Errorf("%q is not true or false", text)
+		}
+		value = text == "true"
+	default:
+		// Arrays, objects without properties and untyped values are entered as JSON;
+		// untyped text that is not JSON is a string
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			if typ != "" {
+				return nil, fmt.Errorf("invalid JSON %s: %w", typ, err)
+			}
+			value = text
+		}
+	}
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		return nil, fmt.Errorf("%s is not one of the allowed values", text)
+	}
+	return json.Marshal(value)
+}
+
+// enumContains reports whether value is one of the values of enum, compared as JSON
+func enumContains(enum []interface{}, value interface{}) bool {
+	data, _ := json.Marshal(value)
+	for _, option := range enum {
+		if other, _ := json.Marshal(option); bytes.Equal(data, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// sent reports whether the field is in the body: set, required, or an object with a
+// property set
+func (f *builderField) sent() bool {
+	if f.set || f.required {
+		return true
+	}
+	for _, field := range f.fields {
+		if field.sent() {
+			return true
+		}
+	}
+	return false
+}
+
+// json returns the JSON of the field, the default of its schema for a required field
+// left empty
+func (f *builderField) json() ([]byte, error) {
+	if len(f.fields) > 0 {
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		first := true
+		for _, field := range f.fields {
+			if !field.sent() {
+				continue
+			}
+			value, err := field.json()
+			if err != nil {
+				return nil, err
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			name, _ := json.Marshal(field.name)
+			buf.Write(name)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+	if !f.set {
+		return json.Marshal(f.schema.DefaultValue())
+	}
+	value, err := fieldJSON(f.schema, f.value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.name, err)
+	}
+	return value, nil
+}
+
+// generate returns the JSON body of the values entered, indented, and the number of
+// fields it holds
+func (b *bodyBuilder) generate() (string, int, error) {
+	data, err := b.root.json()
+	if err != nil {
+		return "", 0, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return "", 0, err
+	}
+	return buf.String(), b.root.leaves(), nil
+}
+
+// leaves returns the number of values of the field in the body
+func (f *builderField) leaves() int {
+	if len(f.fields) == 0 {
+		return 1
+	}
+	count := 0
+	for _, field := range f.fields {
+		if field.sent() {
+			count += field.leaves()
+		}
+	}
+	return count
+}
+
+// selected returns the field under the cursor
+func (b *bodyBuilder) selected() *builderField {
+	if b.cursor < 0 || b.cursor >= len(b.rows) {
+		return nil
+	}
+	return b.rows[b.cursor]
+}
+
+// cycle sets the next or previous value of an enum or boolean field, and returns false
+// for other fields
+func (b *bodyBuilder) cycle(step int) bool {
+	f := b.selected()
+	if f == nil {
+		return false
+	}
+	var options []interface{}
+	switch {
+	case len(f.schema.Enum) > 0:
+		options = f.schema.Enum
+	case f.schema.ValueType() == "boolean":
+		options = []interface{}{false, true}
+	default:
+		return false
+	}
+
+	// An optional field can also be left out, before the first value
+	first := 0
+	if !f.required {
+		options = append([]interface{}{nil}, options...)
+		first = 1
+	}
+	index := -1
+	if f.set {
+		if current, err := fieldJSON(f.schema, f.value); err == nil {
+			for i := first; i < len(options); i++ {
+				if data, _ := json.Marshal(options[i]); bytes.Equal(current, data) {
+					index = i
+				}
+			}
+		}
+	} else if !f.required {
+		index = 0
+	}
+	switch {
+	case index < 0 && step > 0:
+		index = 0
+	case index < 0:
+		index = len(options) - 1
+	default:
+		index = (index + step + len(options)) % len(options)
+	}
+	b.err = ""
+	if index < first {
+		f.value, f.set = "", false
+		return true
+	}
+	if text, ok := options[index].(string); ok {
+		f.value = text
+	} else {
+		data, _ := json.Marshal(options[index])
+		f.value = string(data)
+	}
+	f.set = true
+	return true
+}
+
+// IsBodyBuilding returns true if the Body tab shows the body builder
+func (r *RequestView) IsBodyBuilding() bool {
+	return r.bodyBuilder != nil && r.tabs.GetActive() == "Body"
+}
+
+// IsBodyBuilderEditing returns true if a value of the body builder is being edited
+func (r *RequestView) IsBodyBuilderEditing() bool {
+	return r.IsBodyBuilding() && r.bodyBuilder.editing
+}
+
+// GetBodySchema returns the JSON Schema of the body, nil when it has none
+func (r *RequestView) GetBodySchema() *api.JSONSchema {
+	return r.bodySchema
+}
+
+// SetBodySchema sets the JSON Schema walked by the body builder, closing the builder
+func (r *RequestView) SetBodySchema(schema *api.JSONSchema) {
+	r.bodySchema = schema
+	r.bodyBuilder = nil
+}
+
+// startBodyBuilder shows the fields of the body schema in place of the body editor
+func (r *RequestView) startBodyBuilder() tea.Cmd {
+	var err error
+	switch {
+	case !r.bodyType.IsJSONAuthored():
+		err = fmt.Errorf("the body builder needs a JSON body")
+	case r.bodySchema == nil:
+		err = fmt.Errorf("the request has no body schema (:schema <file> attaches one)")
+	}
+	if err != nil {
+		return func() tea.Msg { return RequestBodyBuiltMsg{Err: err} }
+	}
+	r.bodyBuilder = newBodyBuilder(r.bodySchema, r.bodyEditor.GetContent())
+	return nil
+}
+
+// finishBodyBuilder replaces the body with the JSON generated by the builder and shows
+// the editor again. An invalid value keeps the builder open on it.
+func (r *RequestView) finishBodyBuilder() tea.Cmd {
+	b := r.bodyBuilder
+	content, fields, err := b.generate()
+	if err != nil {
+		b.err = err.Error()
+		return nil
+	}
+	r.bodyBuilder = nil
+	r.bodyEditor.SetContent(content)
+	msg := RequestBodyBuiltMsg{BodyType: r.bodyType.String(), Content: content, Fields: fields}
+	return func() tea.Msg { return msg }
+}
+
+// handleBodyBuilderInput handles keys while the body builder is shown: B generates the
+// body, esc goes back to the editor without it, and switching tabs generates it first
+func (r RequestView) handleBodyBuilderInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
+	b := r.bodyBuilder
+	if b.editing {
+		return r.handleBodyBuilderEdit(msg)
+	}
+
+	switch key := msg.String(); key {
+	case "j", "down":
+		b.cursor = min(b.cursor+1, len(b.rows)-1)
+	case "k", "up":
+		b.cursor = max(b.cursor-1, 0)
+	case "g":
+		b.cursor = 0
+	case "G":
+		b.cursor = len(b.rows) - 1
+	case "enter", "i", "c":
+		if f := b.selected(); f != nil && len(f.fields) == 0 {
+			b.editing, b.buffer = true, f.value
+		}
+	case " ", "l", "right":
+		b.cycle(1)
+	case "h", "left":
+		b.cycle(-1)
+	case "d", "x":
+		// Leave the value out, or use the default of a required value
+		if f := b.selected(); f != nil && len(f.fields) == 0 {
+			f.value, f.set = "", false
+			b.err = ""
+		}
+	case "esc":
+		r.bodyBuilder = nil
+	case "B":
+		return r, r.finishBodyBuilder()
+	case "tab", "shift+tab", "1", "2", "3", "4", "5", "6", "7", "8":
+		cmd := r.finishBodyBuilder()
+		if r.bodyBuilder != nil {
+			return r, nil
+		}
+		switch key {
+		case "tab":
+			r.tabs.Next()
+		case "shift+tab":
+			r.tabs.Previous()
+		default:
+			r.tabs.SetActive(int(key[0] - '1'))
+		}
+		return r, cmd
+	}
+	return r, nil
+}
+
+// handleBodyBuilderEdit handles text input when editing a value of the body builder
+func (r RequestView) handleBodyBuilderEdit(msg tea.KeyMsg) (RequestView, tea.Cmd) {
+	b := r.bodyBuilder
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Discard the edit
+		b.editing, b.buffer = false, ""
+
+	case tea.KeyEnter:
+		f := b.selected()
+		if _, err := fieldJSON(f.schema, b.buffer); err != nil {
+			b.err = fmt.Sprintf("%s: %v", f.name, err)
+			return r, nil
+		}
+		f.value, f.set = b.buffer, true
+		b.editing, b.buffer, b.err = false, "", ""
+
+	case tea.KeyBackspace:
+		if runes := []rune(b.buffer); len(runes) > 0 {
+			b.buffer = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeyRunes:
+		b.buffer += string(msg.Runes)
+
+	case tea.KeySpace:
+		b.buffer += " "
+	}
+	return r, nil
+}
+
+// renderBodyBuilder renders the fields of the body builder (Settings tab style)
+func (r *RequestView) renderBodyBuilder(width, height int) string {
+	b := r.bodyBuilder
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	requiredStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	typeStyle := lipgloss.NewStyle().Foreground(styles.Teal)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	defaultStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	selectedStyle := lipgloss.NewStyle().
+		Background(styles.Surface0).
+		Foreground(styles.Lavender).
+		Bold(true)
+	editingStyle := lipgloss.NewStyle().
+		Background(styles.Surface1).
+		Foreground(styles.Green)
+	arrowStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	helpStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+
+	nameWidth := 0
+	for _, f := range b.rows {
+		nameWidth = max(nameWidth, 2*f.depth+lipgloss.Width(f.name)+2)
+	}
+
+	// Keep the cursor in view above the error and help lines
+	visible := max(1, height-3)
+	start := max(0, b.cursor-visible+1)
+	end := min(len(b.rows), start+visible)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		f := b.rows[i]
+		isSelected := i == b.cursor
+		var line strings.Builder
+		if isSelected {
+			line.WriteString(arrowStyle.Render("▸ "))
+		} else {
+			line.WriteString("  ")
+		}
+
+		name := strings.Repeat("  ", f.depth) + f.name
+		marker := " "
+		if f.required {
+			marker = requiredStyle.Render("*")
+		}
+		line.WriteString(nameStyle.Render(name) + marker + strings.Repeat(" ", nameWidth-lipgloss.Width(name)-1))
+		line.WriteString(typeStyle.Render(fmt.Sprintf("%-16s", f.typeLabel())))
+
+		var value string
+		style := valueStyle
+		switch {
+		case isSelected && b.editing:
+			line.WriteString(editingStyle.Render(b.buffer + "█"))
+			lines = append(lines, line.String())
+			continue
+		case len(f.fields) > 0:
+			value = ""
+		case f.set:
+			value = f.value
+			if len(f.schema.Enum) > 0 || f.schema.ValueType() == "boolean" {
+				value = "◀ " + value + " ▶"
+			}
+		case f.required:
+			data, _ := json.Marshal(f.schema.DefaultValue())
+			value, style = string(data)+" (default)", defaultStyle
+		default:
+			value, style = "—", defaultStyle
+		}
+		if len(f.schema.Enum) > 0 && !f.set {
+			var options []string
+			for _, option := range f.schema.Enum {
+				options = append(options, fmt.Sprint(option))
+			}
+			value += " " + strings.Join(options, "|")
+		}
+		if isSelected {
+			style = selectedStyle
+		}
+		line.WriteString(style.Render(value))
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line.String()))
+	}
+
+	status := ""
+	if b.err != "" {
+		status = errorStyle.Render(b.err)
+	} else if f := b.selected(); f != nil && f.schema.Description != "" {
+		status = helpStyle.Render(f.schema.Description)
+	}
+	return strings.Join(lines, "\n") + "\n\n" + status + "\n" +
+		helpStyle.Render("* required · enter edits · h/l cycles · d clears · B builds the body · esc back to the editor")
+}
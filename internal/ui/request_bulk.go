@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// bulkDisabledPrefix starts the lines of disabled rows in bulk edit
+const bulkDisabledPrefix = "//"
+
+// RequestBulkEditedMsg is sent when a bulk edit of the Headers or query Params table
+// is applied
+type RequestBulkEditedMsg struct {
+	Tab  string // "Params" or "Headers"
+	Rows int
+}
+
+// formatBulkRows writes rows as bulk edit text: "key: value" per line, disabled rows
+// commented out with //
+func formatBulkRows(rows []components.KeyValuePair) string {
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		line := row.Key + ": " + row.Value
+		if !row.Enabled {
+			line = bulkDisabledPrefix + " " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseBulkRows reads bulk edit text back into rows. Blank lines and lines without a
+// key are skipped; a line without ":" is a key with an empty value.
+func parseBulkRows(text string) []components.KeyValuePair {
+	var rows []components.KeyValuePair
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		enabled := true
+		if rest, ok := strings.CutPrefix(line, bulkDisabledPrefix); ok {
+			line, enabled = strings.TrimSpace(rest), false
+		}
+		key, value, _ := strings.Cut(line, ":")
+		if key = strings.TrimSpace(key); key == "" {
+			continue
+		}
+		rows = append(rows, components.KeyValuePair{Key: key, Value: strings.TrimSpace(value), Enabled: enabled})
+	}
+	return rows
+}
+
+// IsBulkEditing returns true if the active tab shows its table as bulk edit text
+func (r *RequestView) IsBulkEditing() bool {
+	return r.bulkEditor != nil && r.tabs.GetActive() == r.bulkTab
+}
+
+// bulkTable returns the table being bulk edited
+func (r *RequestView) bulkTable() *components.Table {
+	if r.bulkTab == "Params" {
+		return r.paramsTable
+	}
+	return r.headersTable
+}
+
+// startBulkEdit shows the table of the active tab as text, the query params in the
+// Params tab
+func (r *RequestView) startBulkEdit() {
+	r.bulkTab = r.tabs.GetActive()
+	if r.bulkTab == "Params" {
+		r.paramsSection = QueryParamsSection
+	}
+	r.bulkEditor = components.NewEditor(formatBulkRows(r.bulkTable().Rows), "text")
+}
+
+// finishBulkEdit replaces the rows of the bulk edited table with the text
+func (r *RequestView) finishBulkEdit() tea.Cmd {
+	if r.bulkEditor == nil {
+		return nil
+	}
+	table := r.bulkTable()
+	table.Rows = parseBulkRows(r.bulkEditor.GetContent())
+	table.Cursor = max(0, min(table.Cursor, table.RowCount()-1))
+	if table.RowCount() == 0 {
+		table.Cursor = -1
+	}
+	msg := RequestBulkEditedMsg{Tab: r.bulkTab, Rows: table.RowCount()}
+	r.bulkEditor, r.bulkTab = nil, ""
+	return func() tea.Msg { return msg }
+}
+
+// handleBulkEditInput handles keys while bulk editing: the editor gets them all in
+// INSERT mode; in NORMAL mode B applies the text, and switching tabs applies it first
+func (r RequestView) handleBulkEditInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
+	if r.bulkEditor.GetMode() == components.EditorInsertMode || r.bulkEditor.IsSearching() {
+		var cmd tea.Cmd
+		r.bulkEditor, cmd = r.bulkEditor.Update(msg, true)
+		return r, cmd
+	}
+
+	switch key := msg.String(); key {
+	case "B":
+		return r, r.finishBulkEdit()
+	case "tab", "shift+tab", "1", "2", "3", "4", "5", "6", "7", "8":
+		cmd := r.finishBulkEdit()
+		switch key {
+		case "tab":
+			r.tabs.Next()
+		case "shift+tab":
+			r.tabs.Previous()
+		default:
+			r.tabs.SetActive(int(key[0] - '1'))
+		}
+		return r, cmd
+	}
+	var cmd tea.Cmd
+	r.bulkEditor, cmd = r.bulkEditor.Update(msg, true)
+	return r, cmd
+}
+
+// renderBulkEdit renders the bulk edit text of the active tab
+func (r *RequestView) renderBulkEdit(width, height int) string {
+	helpStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+
+	return r.bulkEditor.View(width, max(1, height-2), true) + "\n\n" +
+		helpStyle.Render("key: value per line · // disables a line · B applies (NORMAL mode)")
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// GetCaptureRules returns the extraction rules of the Capture tab, or the error of the
+// first invalid row
+func (r *RequestView) GetCaptureRules() ([]api.ExtractRule, error) {
+	var rules []api.ExtractRule
+	for _, row := range r.captureTable.Rows {
+		rule, err := api.ParseExtractRule(row.Key, row.Value)
+		if err != nil {
+			return nil, fmt.Errorf("capture %s: %w", row.Key, err)
+		}
+		rule.Disabled = !row.Enabled
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// SetCaptureRules shows rules in the Capture tab
+func (r *RequestView) SetCaptureRules(rules []api.ExtractRule) {
+	cursor := r.captureTable.Cursor
+	r.captureTable.Rows = nil
+	for _, rule := range rules {
+		r.captureTable.AddRowWithState(rule.Variable, rule.Source(), !rule.Disabled)
+	}
+	r.captureTable.Cursor = max(0, min(cursor, r.captureTable.RowCount()-1))
+}
+
+// renderCaptureTab renders the Capture tab: the response values stored in variables
+// after each send
+func (r *RequestView) renderCaptureTab(width, height int, active bool) string {
+	helpStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+
+	if r.captureTable.RowCount() == 0 {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Width(width).
+			Align(lipgloss.Center).
+			Padding(2, 0)
+
+		return emptyStyle.Render("No captured values\n\nPress n to store a response value in a variable") + "\n" +
+			helpStyle.Render("Variable: auth_token · Expression: $.token (JSONPath), or regex/xpath[@Header] <expression>")
+	}
+
+	var result strings.Builder
+	result.WriteString(r.renderTableEnvStyle(r.captureTable, width, max(1, height-2), active))
+	result.WriteString("\n\n")
+	result.WriteString(helpStyle.Render("Stored in the active environment after each send · $.path for JSONPath, regex or xpath[@Header] <expression> otherwise"))
+	return result.String()
+}
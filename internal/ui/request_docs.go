@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// docsRender is the Markdown docs rendered at a width with a glamour style
+type docsRender struct {
+	markdown string
+	width    int
+	style    string
+	out      string
+}
+
+// RequestDescriptionChangedMsg is sent when the Markdown docs of the request are edited
+type RequestDescriptionChangedMsg struct {
+	Description string
+}
+
+// GetDescription returns the Markdown docs of the Docs tab
+func (r *RequestView) GetDescription() string {
+	return strings.TrimSpace(r.docsEditor.GetContent())
+}
+
+// SetDescription shows description in the Docs tab, rendered
+func (r *RequestView) SetDescription(description string) {
+	r.docsEditor = components.NewEditor(description, "markdown")
+	r.docsSaved = description
+	r.docsEditing = false
+	r.docsScroll = 0
+}
+
+// IsDocsEditing returns true if the Docs tab shows its Markdown source in the editor
+func (r *RequestView) IsDocsEditing() bool {
+	return r.docsEditing && r.tabs.GetActive() == "Docs"
+}
+
+// finishDocsEdit goes back to the rendered docs, and reports them if they changed
+func (r *RequestView) finishDocsEdit() tea.Cmd {
+	r.docsEditing = false
+	return r.docsChanged()
+}
+
+// docsChanged reports the docs if they changed since they were last reported
+func (r *RequestView) docsChanged() tea.Cmd {
+	description := r.GetDescription()
+	if description == r.docsSaved {
+		return nil
+	}
+	r.docsSaved = description
+	return func() tea.Msg { return RequestDescriptionChangedMsg{Description: description} }
+}
+
+// handleDocsInput handles keys in the Docs tab. While editing, the editor gets them all
+// in INSERT mode; in NORMAL mode Esc shows the rendered docs and switching tabs saves
+// them first. Otherwise Enter edits the docs and j/k scroll them; other keys are not
+// handled.
+func (r RequestView) handleDocsInput(msg tea.KeyMsg) (RequestView, tea.Cmd, bool) {
+	key := msg.String()
+	if !r.docsEditing {
+		switch key {
+		case "enter":
+			r.docsEditing = true
+		case "j", "down":
+			r.docsScroll++
+		case "k", "up":
+			r.docsScroll = max(0, r.docsScroll-1)
+		case "g":
+			r.docsScroll = 0
+		default:
+			return r, nil, false
+		}
+		return r, nil, true
+	}
+
+	editor := r.docsEditor
+	if editor.GetMode() != components.EditorInsertMode && !editor.IsSearching() {
+		switch key {
+		case "esc":
+			if !editor.HasSearchQuery() {
+				return r, r.finishDocsEdit(), true
+			}
+		case "tab", "shift+tab", "1", "2", "3", "4", "5", "6", "7", "8":
+			cmd := r.finishDocsEdit()
+			switch key {
+			case "tab":
+				r.tabs.Next()
+			case "shift+tab":
+				r.tabs.Previous()
+			default:
+				r.tabs.SetActive(int(key[0] - '1'))
+			}
+			return r, cmd, true
+		}
+	}
+	var cmd tea.Cmd
+	r.docsEditor, cmd = editor.Update(msg, true)
+	return r, cmd, true
+}
+
+// renderDocsTab renders the Docs tab: the Markdown source in the editor while editing,
+// otherwise the rendered docs
+func (r *RequestView) renderDocsTab(width, height int, active bool) string {
+	helpStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+
+	if r.docsEditing {
+		return r.docsEditor.View(width, max(1, height-2), active) + "\n\n" +
+			helpStyle.Render("Markdown · Esc (NORMAL mode) shows the rendered docs")
+	}
+
+	description := r.GetDescription()
+	if description == "" {
+		return helpStyle.Render("No docs for this request. Press Enter to write them in Markdown.")
+	}
+	lines := strings.Split(r.renderDocs(description, width-1), "\n")
+	visible := max(1, height-2)
+	r.docsScroll = max(0, min(r.docsScroll, len(lines)-visible))
+	end := min(len(lines), r.docsScroll+visible)
+	return strings.Join(lines[r.docsScroll:end], "\n") + "\n\n" +
+		helpStyle.Render("Enter edit · j/k scroll")
+}
+
+// renderDocs renders the Markdown docs with glamour, wrapped to width, in its dark or
+// light style after the background of the theme. Docs glamour fails to render are shown
+// as written.
+func (r *RequestView) renderDocs(markdown string, width int) string {
+	style := glamourstyles.DarkStyle
+	if lightBackground(styles.Base) {
+		style = glamourstyles.LightStyle
+	}
+	if cached := r.docsRender; cached.markdown == markdown && cached.width == width && cached.style == style {
+		return cached.out
+	}
+
+	out := markdown
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width),
+	)
+	if err == nil {
+		if rendered, err := renderer.Render(markdown); err == nil {
+			out = strings.Trim(rendered, "\n")
+		}
+	}
+	r.docsRender = docsRender{markdown: markdown, width: width, style: style, out: out}
+	return out
+}
+
+// lightBackground reports whether a background color is light
+func lightBackground(color lipgloss.Color) bool {
+	red, green, blue, _ := color.RGBA()
+	return 299*red+587*green+114*blue > 500*0xffff
+}
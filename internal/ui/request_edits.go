@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// requestEdits is the set of parts of a request edited and not saved to its collection yet
+type requestEdits uint16
+
+const (
+	editURL requestEdits = 1 << iota
+	editHeaders
+	editBody
+	editScripts
+	editDocs
+	editAuth
+	editSettings
+	editCapture
+	editRedirects
+	editVariables
+	editSchema
+	editExamples
+)
+
+// commandEdits are the parts of a request edited with commands rather than in the Request
+// panel (:redirects, :vars request, :schema, :freeze), or with both (:extract)
+const commandEdits = editCapture | editRedirects | editVariables | editSchema | editExamples
+
+// dirtyMark follows the name of a request with unsaved edits
+const dirtyMark = "●"
+
+// MarkEdited records that a part of the request was edited and not saved yet
+func (r *RequestView) MarkEdited(edit requestEdits) {
+	r.edits |= edit
+}
+
+// IsDirty returns true when the request has edits not saved to its collection
+func (r *RequestView) IsDirty() bool {
+	return r.edits != 0
+}
+
+// ClearEdits forgets the unsaved edits, once saved or discarded
+func (r *RequestView) ClearEdits() {
+	r.edits = 0
+}
+
+// withHeld returns saved with the parts edited with commands and not saved yet
+func (r *RequestView) withHeld(saved *api.CollectionRequest) *api.CollectionRequest {
+	if r.edits&commandEdits == 0 {
+		return saved
+	}
+	req := *saved
+	if r.edits&editRedirects != 0 {
+		req.NoFollowRedirects, req.MaxRedirects = r.held.NoFollowRedirects, r.held.MaxRedirects
+	}
+	if r.edits&editVariables != 0 {
+		req.Variables = r.held.Variables
+	}
+	if r.edits&editSchema != 0 {
+		req.BodySchema = r.GetBodySchema()
+	}
+	if r.edits&editExamples != 0 {
+		req.Examples = r.held.Examples
+	}
+	if r.edits&editCapture != 0 {
+		if rules, err := r.GetCaptureRules(); err == nil {
+			req.Extract = rules
+		}
+	}
+	return &req
+}
+
+// GetHeaders returns the rows of the Headers tab
+func (r *RequestView) GetHeaders() []api.KeyValueEntry {
+	var headers []api.KeyValueEntry
+	for _, row := range r.headersTable.Rows {
+		if row.Key != "" {
+			headers = append(headers, api.KeyValueEntry{Key: row.Key, Value: row.Value, Enabled: row.Enabled})
+		}
+	}
+	return headers
+}
+
+// unsavedContext is the context of the dialog asking to discard unsaved edits: what to
+// carry on with once they are discarded
+type unsavedContext struct {
+	quit    bool                             // Quit, dropping the edits of every tab
+	leave   string                           // What proceed does when it drops the edits of every tab
+	proceed func(Model) (tea.Model, tea.Cmd) // Carry on once the edits of the active tab are dropped
+}
+
+// holdEdit records an edit of the current request to save on :w, and returns true, unless
+// edits are saved as they are made or the request is not saved in a collection
+func (m *Model) holdEdit(edit requestEdits) bool {
+	if (m.globalConfig != nil && m.globalConfig.AutoSave) || m.requestPanel.GetCurrentRequestID() == "" {
+		return false
+	}
+	m.requestPanel.MarkEdited(edit)
+	return true
+}
+
+// editedRequest returns the saved request with id, with the parts its tab edited with
+// commands and holds until :w; nil when the request is not saved in a collection
+func (m Model) editedRequest(requestID string) *api.CollectionRequest {
+	saved := m.leftPanel.GetCollections().FindRequestByID(requestID)
+	if saved == nil {
+		return nil
+	}
+	if i := m.tabOf(requestID); i >= 0 {
+		return m.tabs[i].request.withHeld(saved)
+	}
+	return saved
+}
+
+// holdCommandEdit records an edit of the open request made with a command to save on
+// :w, applying it to the held copy, and returns true, unless edits are saved as they
+// are made or requestID is not the open request
+func (m *Model) holdCommandEdit(requestID string, edit requestEdits, apply func(held *api.CollectionRequest)) bool {
+	if requestID != m.requestPanel.GetCurrentRequestID() || !m.holdEdit(edit) {
+		return false
+	}
+	apply(&m.requestPanel.held)
+	return true
+}
+
+// loadsOver reports whether loading the request of ID requestID in the active tab drops
+// unsaved edits: the tab has some and the request is not open in a tab
+func (m Model) loadsOver(requestID string) bool {
+	return m.requestPanel.IsDirty() && m.tabOf(requestID) < 0
+}
+
+// confirmDiscard asks whether to drop the unsaved edits of the active tab, or of every
+// tab when quitting or leaving the workspace, before carrying on
+func (m *Model) confirmDiscard(ctx unsavedContext) (Model, tea.Cmd) {
+	message := m.currentTab().name() + " has unsaved changes. Discard them?"
+	if ctx.quit {
+		ctx.leave = "quit"
+	}
+	if ctx.leave != "" {
+		message = fmt.Sprintf("%d requests have unsaved changes. Discard them and %s?", m.dirtyTabs(), ctx.leave)
+		if m.dirtyTabs() == 1 {
+			message = "A request has unsaved changes. Discard them and " + ctx.leave + "?"
+		}
+	}
+	m.dialog.ShowConfirm("Unsaved Changes", message+" (:w saves them)", "discard_changes", ctx)
+	return *m, nil
+}
+
+// dirtyTabs returns the number of tabs with unsaved edits
+func (m Model) dirtyTabs() int {
+	count := 0
+	for _, tab := range m.tabs {
+		if tab.request.IsDirty() {
+			count++
+		}
+	}
+	return count
+}
+
+// quit quits, once confirmed when requests have unsaved edits
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.dirtyTabs() > 0 {
+		return m.confirmDiscard(unsavedContext{quit: true})
+	}
+	return m.saveSessionAndQuit()
+}
+
+// discardEdits carries on with what a confirmed dialog of unsavedContext asked about
+func (m Model) discardEdits(ctx unsavedContext) (tea.Model, tea.Cmd) {
+	if ctx.quit {
+		return m.saveSessionAndQuit()
+	}
+	m.requestPanel.ClearEdits()
+	if ctx.proceed == nil {
+		return m, nil
+	}
+	return ctx.proceed(m)
+}
+
+// writeRequest saves the unsaved edits of the request of a tab to its collection
+func (m *Model) writeRequest(tab *requestTab) error {
+	r := tab.request
+	requestID := r.GetCurrentRequestID()
+	collections := m.leftPanel.GetCollections()
+	var err error
+	if r.edits&editURL != 0 && err == nil {
+		err = collections.UpdateRequestURLByID(requestID, r.GetURL())
+	}
+	if r.edits&editHeaders != 0 && err == nil {
+		err = collections.UpdateRequestHeadersByID(requestID, r.GetHeaders())
+	}
+	if r.edits&editBody != 0 && err == nil {
+		err = collections.UpdateRequestBodyByID(requestID, r.GetBodyType().String(), r.GetBodyContent())
+	}
+	if r.edits&editScripts != 0 && err == nil {
+		err = collections.UpdateRequestScriptsByID(requestID, r.GetPreRequestScript(), r.GetPostRequestScript())
+	}
+	if r.edits&editDocs != 0 && err == nil {
+		err = collections.UpdateRequestDescriptionByID(requestID, r.GetDescription())
+	}
+	if r.edits&editAuth != 0 && err == nil {
+		err = collections.UpdateRequestAuthByID(requestID, r.GetAuthConfig())
+	}
+	if r.edits&editSettings != 0 && err == nil {
+		timeout, retry := r.GetSettings()
+		serverName, host := r.GetOverrides()
+		if err = collections.UpdateRequestSettingsByID(requestID, timeout, retry); err == nil {
+			err = collections.UpdateRequestOverridesByID(requestID, serverName, host)
+		}
+	}
+	if r.edits&editCapture != 0 && err == nil {
+		var rules []api.ExtractRule
+		if rules, err = r.GetCaptureRules(); err == nil {
+			err = collections.UpdateRequestExtractByID(requestID, rules)
+		}
+	}
+	if r.edits&editRedirects != 0 && err == nil {
+		err = collections.UpdateRequestRedirectsByID(requestID, !r.held.NoFollowRedirects, r.held.MaxRedirects)
+	}
+	if r.edits&editVariables != 0 && err == nil {
+		err = collections.UpdateRequestVariablesByID(requestID, r.held.Variables)
+	}
+	if r.edits&editSchema != 0 && err == nil {
+		err = collections.UpdateRequestBodySchemaByID(requestID, r.GetBodySchema())
+	}
+	if r.edits&editExamples != 0 && err == nil {
+		err = collections.UpdateRequestExamplesByID(requestID, r.held.Examples)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", tab.name(), err)
+	}
+	r.ClearEdits()
+	collections.ReloadCollections()
+	return nil
+}
+
+// write saves the unsaved edits of the active tab (:w)
+func (m Model) write() (tea.Model, tea.Cmd) {
+	tab := m.currentTab()
+	switch {
+	case tab.request.GetCurrentRequestID() == "":
+		m.statusBar.Info("The request is not saved in a collection")
+	case !tab.request.IsDirty():
+		m.statusBar.Info("No unsaved changes")
+	default:
+		if err := m.writeRequest(tab); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Saved", tab.name())
+	}
+	return m, nil
+}
+
+// writeAllAndQuit saves the unsaved edits of every tab and quits (:wq). A failed save
+// keeps LazyCurl open.
+func (m Model) writeAllAndQuit() (tea.Model, tea.Cmd) {
+	for _, tab := range m.tabs {
+		if !tab.request.IsDirty() {
+			continue
+		}
+		if err := m.writeRequest(tab); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+	return m.saveSessionAndQuit()
+}
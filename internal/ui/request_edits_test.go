@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+func TestModel_UnsavedEdits(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+		{ID: "create", Name: "Create order", Method: api.POST, URL: "https://shop.example.com/orders"},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultGlobalConfig()
+	m := NewModel(cfg, config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	confirm := func(key tea.KeyType) {
+		t.Helper()
+		if !m.dialog.IsVisible() {
+			t.Fatal("unsaved changes should be confirmed")
+		}
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: key})
+		model, _ := m.Update(cmd())
+		m = model.(Model)
+	}
+	saved := func(id string) *api.CollectionRequest {
+		t.Helper()
+		col, err := api.LoadCollection(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return col.FindRequest(id)
+	}
+
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	setURL := func(url string) {
+		m.requestPanel.SetURL(url)
+		update(RequestURLChangedMsg{URL: url})
+	}
+	setURL("https://shop.example.com/orders?page=2")
+	m.requestPanel.SetPosition("headers", nil)
+	update(components.DialogResultMsg{Action: "request_new", Confirmed: true, Value: "X-Trace", URL: "on", Context: &requestDialogContext{Tab: "Headers"}})
+	if req := saved("list"); req.URL != "https://shop.example.com/orders" || len(req.Headers) != 0 {
+		t.Fatalf("edits should be held until :w, got %+v", req)
+	}
+	if view := PlainSnapshot(m.View()); !strings.Contains(view, "Request "+dirtyMark) {
+		t.Errorf("the Request panel title should mark unsaved changes:\n%s", view)
+	}
+
+	// Quitting and switching requests ask first; esc keeps the edits
+	update(CommandExecuteMsg{Command: CmdQuit})
+	confirm(tea.KeyEsc)
+	if m.quitting || !m.requestPanel.IsDirty() {
+		t.Fatal("esc should keep the unsaved changes")
+	}
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "create", Type: components.RequestNode}})
+	confirm(tea.KeyEsc)
+	if m.requestPanel.GetCurrentRequestID() != "list" {
+		t.Fatal("esc should stay on the edited request")
+	}
+
+	update(CommandExecuteMsg{Command: CmdWrite})
+	req := saved("list")
+	if req.URL != "https://shop.example.com/orders?page=2" || len(req.Headers) == 0 || req.Headers[len(req.Headers)-1].Key != "X-Trace" {
+		t.Fatalf(":w should save the URL and headers, got %+v", req)
+	}
+	if m.requestPanel.IsDirty() || !strings.Contains(m.statusBar.message, "List orders") {
+		t.Errorf(":w should clear the unsaved changes, got %q", m.statusBar.message)
+	}
+
+	// Discarding loads the other request without saving
+	setURL("https://shop.example.com/carts")
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "create", Type: components.RequestNode}})
+	confirm(tea.KeyEnter)
+	if m.requestPanel.GetCurrentRequestID() != "create" || saved("list").URL != "https://shop.example.com/orders?page=2" {
+		t.Errorf("enter should discard the changes and load the selected request, got %s", m.requestPanel.GetCurrentRequestID())
+	}
+
+	// With auto_save, edits are saved as they are made
+	cfg.AutoSave = true
+	setURL("https://shop.example.com/orders/new")
+	if m.requestPanel.IsDirty() || saved("create").URL != "https://shop.example.com/orders/new" {
+		t.Error("auto_save should save the URL at once")
+	}
+}
+
+func TestModel_HeldCommandEdits(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "create", Name: "Create order", Method: api.POST, URL: "https://shop.example.com/orders"},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	schemaPath := filepath.Join(workspace, "order.schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "properties": {"qty": {"type": "integer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	run := func(handle func(Model, []string) (tea.Model, tea.Cmd), args ...string) {
+		model, _ := handle(m, args)
+		m = model.(Model)
+	}
+	update := func(msg tea.Msg) tea.Cmd {
+		model, cmd := m.Update(msg)
+		m = model.(Model)
+		return cmd
+	}
+	saved := func() *api.CollectionRequest {
+		t.Helper()
+		col, err := api.LoadCollection(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return col.FindRequest("create")
+	}
+
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "create", Type: components.RequestNode}})
+	run(Model.handleRedirectsCommand, RedirectsOff)
+	run(Model.handleVarsCommand, api.ScopeRequest, VarsSet, "qty", "2")
+	run(Model.handleSchemaCommand, schemaPath)
+	run(Model.handleExtractCommand, "order_id", "jsonpath", "$.id")
+	run(Model.handleFreezeCommand)
+	if req := saved(); req.NoFollowRedirects || req.Variables != nil || req.BodySchema != nil || req.Extract != nil || req.Examples != nil {
+		t.Fatalf("command edits should be held until :w, got %+v", req)
+	}
+	if !m.requestPanel.IsDirty() {
+		t.Fatal("command edits should mark the request as edited")
+	}
+	// The held edits apply to the request before :w
+	if req := m.editedRequest("create"); !req.NoFollowRedirects || req.Variables["qty"] != "2" || req.BodySchema == nil || len(req.Extract) != 1 || len(req.Examples) != 1 {
+		t.Errorf("the held edits should apply to the request, got %+v", req)
+	}
+
+	// ctrl+c asks before dropping them
+	if cmd := update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil || m.quitting || !m.dialog.IsVisible() {
+		t.Fatal("ctrl+c should confirm the unsaved changes")
+	}
+	var cmd tea.Cmd
+	m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	update(cmd())
+
+	update(CommandExecuteMsg{Command: CmdWrite})
+	req := saved()
+	if !req.NoFollowRedirects || req.Variables["qty"] != "2" || req.BodySchema == nil || len(req.Extract) != 1 || len(req.Examples) != 1 {
+		t.Errorf(":w should save the command edits, got %+v", req)
+	}
+	if m.requestPanel.IsDirty() {
+		t.Error(":w should clear the unsaved changes")
+	}
+}
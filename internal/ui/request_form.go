@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// RequestFilePickMsg asks for a file to upload in a form-data body: for the field at
+// Index, or for a new field when Index is -1. Path is the current file of the field.
+type RequestFilePickMsg struct {
+	Index int
+	Path  string
+}
+
+// GetFormFields returns the fields of the form-data body
+func (r *RequestView) GetFormFields() []api.FormField {
+	fields := make([]api.FormField, 0, len(r.formTable.Rows))
+	for _, row := range r.formTable.Rows {
+		field := api.FormField{Key: row.Key, Value: row.Value, Enabled: row.Enabled}
+		if row.File {
+			field.Type = api.FormFieldFile
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// formDataContent returns the form fields as stored in a collection (a JSON list)
+func (r *RequestView) formDataContent() string {
+	data, err := json.Marshal(r.GetFormFields())
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// loadFormFields fills the form table from the content of a form-data body
+func (r *RequestView) loadFormFields(content interface{}) {
+	r.formTable.Rows = nil
+	for _, field := range api.ParseFormData(content) {
+		r.formTable.Rows = append(r.formTable.Rows, components.KeyValuePair{
+			Key:     field.Key,
+			Value:   field.Value,
+			Enabled: field.Enabled,
+			File:    field.IsFile(),
+		})
+	}
+	r.formTable.Cursor = min(0, len(r.formTable.Rows)-1)
+}
+
+// SetFormFile makes the field at index upload the file at path, or adds a file field
+// named after the file when index is -1
+func (r *RequestView) SetFormFile(index int, path string) {
+	if index < 0 || index >= len(r.formTable.Rows) {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		r.formTable.Rows = append(r.formTable.Rows, components.KeyValuePair{Key: name, Value: path, Enabled: true, File: true})
+		r.formTable.Cursor = len(r.formTable.Rows) - 1
+		return
+	}
+	r.formTable.Rows[index].Value = path
+	r.formTable.Rows[index].File = true
+}
+
+// handleFormKeys handles the keys specific to the form-data editor: N adds a file field,
+// o picks the file of the current field, t switches it between text and file, s toggles
+// it. Returns false for keys left to the table navigation and actions.
+func (r RequestView) handleFormKeys(msg tea.KeyMsg) (RequestView, tea.Cmd, bool) {
+	table := r.formTable
+	hasRow := table.Cursor >= 0 && table.Cursor < table.RowCount()
+
+	switch msg.String() {
+	case "N":
+		return r, func() tea.Msg {
+			return RequestFilePickMsg{Index: -1}
+		}, true
+	case "o":
+		if hasRow {
+			index := table.Cursor
+			path := ""
+			if table.Rows[index].File {
+				path = table.Rows[index].Value
+			}
+			return r, func() tea.Msg {
+				return RequestFilePickMsg{Index: index, Path: path}
+			}, true
+		}
+		return r, nil, true
+	case "t":
+		if hasRow {
+			table.Rows[table.Cursor].File = !table.Rows[table.Cursor].File
+			return r, r.emitFormChanged(), true
+		}
+		return r, nil, true
+	case "s", "S":
+		if hasRow {
+			table.ToggleCurrentEnabled()
+			return r, r.emitFormChanged(), true
+		}
+		return r, nil, true
+	}
+	return r, nil, false
+}
+
+// emitFormChanged returns a command saving the form-data body
+func (r *RequestView) emitFormChanged() tea.Cmd {
+	content := r.formDataContent()
+	return func() tea.Msg {
+		return RequestBodyChangedMsg{BodyType: api.BodyTypeFormData, Content: content}
+	}
+}
+
+// renderFormDataBody renders the fields of a form-data body
+func (r *RequestView) renderFormDataBody(width, height int, active bool) string {
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+
+	if r.formTable.RowCount() == 0 {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Width(width).
+			Align(lipgloss.Center).
+			Padding(2, 0)
+		return emptyStyle.Render("No form fields\n\nPress n to add a text field, N to add a file")
+	}
+
+	table := r.renderTableEnvStyle(r.formTable, width, height-2, active)
+	return table + "\n\n" + hintStyle.Render("n: text field · N: file · o: choose file · t: text/file · s: toggle")
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// handleFreezeCommand freezes the open request as an example, resolved against the
+// active environment with its secrets redacted, or lists, shows and deletes its examples
+func (m Model) handleFreezeCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	req := m.editedRequest(requestID)
+	if req == nil {
+		m.statusBar.Info("Open a saved request to freeze it")
+		return m, nil
+	}
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case FreezeList:
+		if len(req.Examples) == 0 {
+			m.statusBar.Info("No examples. Usage: :freeze [name] freezes the request")
+			return m, nil
+		}
+		labels := make([]string, len(req.Examples))
+		for i, example := range req.Examples {
+			labels[i] = fmt.Sprintf("%d %s", i+1, example.Label())
+		}
+		m.statusBar.Info("Examples: " + strings.Join(labels, " | "))
+		return m, nil
+
+	case FreezeShow, FreezeDelete:
+		index, ok := exampleIndex(args[1:], len(req.Examples))
+		if !ok {
+			m.statusBar.Info(fmt.Sprintf("Usage: :freeze %s [n] (1-%d, the last one by default)", sub, len(req.Examples)))
+			return m, nil
+		}
+		example := req.Examples[index]
+		if sub == FreezeShow {
+			m.responsePanel.SetExample(&example)
+			m.activePanel = ResponsePanel
+			return m, nil
+		}
+		examples := slices.Delete(slices.Clone(req.Examples), index, index+1)
+		if err := m.saveExamples(requestID, examples); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Deleted example", example.Name)
+		return m, nil
+	}
+
+	redact := true
+	if sub == FreezeSecrets {
+		redact = false
+		args = args[1:]
+	}
+
+	src := m.requestSource()
+	built, err := buildHTTPRequestFrom(src, m.requestVariables(requestID), m.variableTypes(requestID))
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	var redaction *api.Redaction
+	if redact {
+		redaction = &api.Redaction{Secrets: m.secretValues()}
+		if src.Auth != nil && src.Auth.Type == "api_key" {
+			redaction.Names = append(redaction.Names, replaceVariables(src.Auth.APIKeyName, m.requestVariables(requestID)))
+		}
+	}
+	example, err := api.FreezeRequest(built, redaction)
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	example.Name = strings.Join(args, " ")
+	if example.Name == "" {
+		example.Name = fmt.Sprintf("Example %d", len(req.Examples)+1)
+	}
+	example.Frozen = time.Now().UTC().Truncate(time.Second) // Whole seconds in UTC, for readable collection diffs
+	example.Environment = m.leftPanel.GetEnvironments().GetActiveEnvironmentName()
+
+	examples := append(slices.Clone(req.Examples), *example)
+	if err := m.saveExamples(requestID, examples); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.responsePanel.SetExample(example)
+	m.activePanel = ResponsePanel
+	if redact {
+		m.statusBar.Success("Froze", example.Name+" (secrets redacted)")
+	} else {
+		m.statusBar.Success("Froze", example.Name+" (with secrets)")
+	}
+	return m, nil
+}
+
+// saveExamples saves the examples of the request with id, or holds them until :w
+func (m *Model) saveExamples(requestID string, examples []api.RequestExample) error {
+	if m.holdCommandEdit(requestID, editExamples, func(held *api.CollectionRequest) { held.Examples = examples }) {
+		return nil
+	}
+	return m.leftPanel.GetCollections().UpdateRequestExamplesByID(requestID, examples)
+}
+
+// exampleIndex returns the index of the example numbered by args, from 1, or of the last
+// of count examples when args is empty
+func exampleIndex(args []string, count int) (int, bool) {
+	if count == 0 || len(args) > 1 {
+		return 0, false
+	}
+	if len(args) == 0 {
+		return count - 1, true
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > count {
+		return 0, false
+	}
+	return n - 1, true
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_FreezeCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{{
+		ID:      "create",
+		Name:    "Create order",
+		Method:  api.POST,
+		URL:     "https://shop.example.com/orders",
+		Headers: []api.KeyValueEntry{{Key: "X-Trace", Value: "{{trace}}", Enabled: true}},
+		Auth:    &api.AuthConfig{Type: "bearer", Token: "tok-123456"},
+	}}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+
+	model, _ := m.handleFreezeCommand(nil)
+	if msg := model.(Model).statusBar.message; !strings.Contains(msg, "Open a saved request") {
+		t.Errorf(":freeze without a request should be reported, got %q", msg)
+	}
+
+	req := m.leftPanel.GetCollections().FindRequestByID("create")
+	m.requestPanel.LoadCollectionRequest(req)
+	model, _ = m.handleFreezeCommand([]string{"checkout", "bug"})
+	m = model.(Model)
+	model, _ = m.handleFreezeCommand([]string{FreezeSecrets})
+	m = model.(Model)
+
+	// The examples are held with the other edits until :w
+	if saved, _ := api.LoadCollection(path); len(saved.FindRequest("create").Examples) != 0 || !m.requestPanel.IsDirty() {
+		t.Fatal(":freeze should hold the examples until :w")
+	}
+	model, _ = m.Update(CommandExecuteMsg{Command: CmdWrite})
+	m = model.(Model)
+	saved, err := api.LoadCollection(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	examples := saved.FindRequest("create").Examples
+	if len(examples) != 2 || examples[0].Name != "checkout bug" || examples[1].Name != "Example 2" {
+		t.Fatalf("the examples should be saved with the request, got %+v", examples)
+	}
+	if got := examples[0].Headers["Authorization"]; got != "Bearer <redacted>" || !examples[0].Redacted {
+		t.Errorf("secrets should be redacted by default, got %q", got)
+	}
+	if got := examples[1].Headers["Authorization"]; got != "Bearer tok-123456" || examples[1].Redacted {
+		t.Errorf(":freeze secrets should keep secrets, got %q", got)
+	}
+
+	model, _ = m.handleFreezeCommand([]string{FreezeShow, "1"})
+	m = model.(Model)
+	view := PlainSnapshot(m.responsePanel.View(120, 30, true))
+	if !strings.Contains(view, "Frozen request") || !strings.Contains(view, "POST https://shop.example.com/orders") {
+		t.Errorf(":freeze show should show the example:\n%s", view)
+	}
+
+	model, _ = m.handleFreezeCommand([]string{FreezeDelete, "3"})
+	if msg := model.(Model).statusBar.message; !strings.Contains(msg, "Usage: :freeze delete [n] (1-2") {
+		t.Errorf("an unknown example should be reported, got %q", msg)
+	}
+	model, _ = m.handleFreezeCommand([]string{FreezeDelete})
+	model, _ = model.(Model).Update(CommandExecuteMsg{Command: CmdWrite})
+	if saved, _ := api.LoadCollection(path); len(saved.FindRequest("create").Examples) != 1 {
+		t.Error(":freeze delete should delete the last example")
+	}
+}
@@ -0,0 +1,367 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// SettingsField represents which field is selected in the Settings tab
+type SettingsField int
+
+const (
+	SettingsFieldTimeout SettingsField = iota
+	SettingsFieldRetries
+	SettingsFieldBackoff
+	SettingsFieldRetryDelay
+	SettingsFieldRetryOn
+	SettingsFieldServerName
+	SettingsFieldHost
+)
+
+// settingsFields lists the fields of the Settings tab in display order
+var settingsFields = []SettingsField{
+	SettingsFieldTimeout,
+	SettingsFieldRetries,
+	SettingsFieldBackoff,
+	SettingsFieldRetryDelay,
+	SettingsFieldRetryOn,
+	SettingsFieldServerName,
+	SettingsFieldHost,
+}
+
+// RequestSettingsChangedMsg is sent when the timeout, retry policy or overrides are
+// modified in the Settings tab. Err is set, and the change discarded, when the entered
+// value is invalid.
+type RequestSettingsChangedMsg struct {
+	Timeout    string
+	Retry      *api.RetryPolicy
+	ServerName string
+	Host       string
+	Err        error
+}
+
+// IsSettingsEditing returns true if editing a field in the Settings tab
+func (r *RequestView) IsSettingsEditing() bool {
+	return r.settingsEditing
+}
+
+// GetSettings returns the timeout and retry policy of the Settings tab.
+// The policy is nil when no retries are set.
+func (r *RequestView) GetSettings() (string, *api.RetryPolicy) {
+	if r.settingsRetry.Count <= 0 {
+		return r.settingsTimeout, nil
+	}
+	return r.settingsTimeout, r.settingsRetry.Clone()
+}
+
+// GetOverrides returns the TLS server name and Host header of the Settings tab, empty
+// when the URL host is sent
+func (r *RequestView) GetOverrides() (serverName, host string) {
+	return r.settingsSNI, r.settingsHost
+}
+
+// loadSettingsFromRequest loads the timeout, retry policy and overrides of a CollectionRequest
+func (r *RequestView) loadSettingsFromRequest(req *api.CollectionRequest) {
+	r.settingsTimeout = ""
+	r.settingsRetry = api.RetryPolicy{}
+	r.settingsSNI = ""
+	r.settingsHost = ""
+	r.settingsField = SettingsFieldTimeout
+	r.settingsEditing = false
+	r.settingsBuffer = ""
+
+	if req == nil {
+		return
+	}
+	r.settingsTimeout = req.Timeout
+	r.settingsSNI = req.ServerName
+	r.settingsHost = req.Host
+	if req.Retry != nil {
+		r.settingsRetry = *req.Retry.Clone()
+	}
+}
+
+// settingsFieldText returns the editable text of a field
+func (r *RequestView) settingsFieldText(field SettingsField) string {
+	switch field {
+	case SettingsFieldTimeout:
+		return r.settingsTimeout
+	case SettingsFieldRetries:
+		return strconv.Itoa(r.settingsRetry.Count)
+	case SettingsFieldRetryDelay:
+		return r.settingsRetry.Delay
+	case SettingsFieldRetryOn:
+		return api.FormatStatusCodes(r.settingsRetry.OnStatus)
+	case SettingsFieldServerName:
+		return r.settingsSNI
+	case SettingsFieldHost:
+		return r.settingsHost
+	}
+	return ""
+}
+
+// applySettingsField validates text and stores it in field
+func (r *RequestView) applySettingsField(field SettingsField, text string) error {
+	text = strings.TrimSpace(text)
+	retry := *r.settingsRetry.Clone()
+
+	switch field {
+	case SettingsFieldTimeout:
+		if _, err := api.ParseTimeout(text); err != nil {
+			return err
+		}
+		r.settingsTimeout = text
+		return nil
+	case SettingsFieldServerName:
+		if strings.ContainsAny(text, " /") {
+			return fmt.Errorf("invalid TLS server name %q", text)
+		}
+		r.settingsSNI = text
+		return nil
+	case SettingsFieldHost:
+		if strings.ContainsAny(text, " /") {
+			return fmt.Errorf("invalid Host header %q", text)
+		}
+		r.settingsHost = text
+		return nil
+	case SettingsFieldRetries:
+		count := 0
+		if text != "" {
+			n, err := strconv.Atoi(text)
+			if err != nil {
+				return fmt.Errorf("invalid retry count %q", text)
+			}
+			count = n
+		}
+		retry.Count = count
+	case SettingsFieldRetryDelay:
+		retry.Delay = text
+	case SettingsFieldRetryOn:
+		codes, err := api.ParseStatusCodes(text)
+		if err != nil {
+			return err
+		}
+		retry.OnStatus = codes
+	}
+
+	if err := retry.Validate(); err != nil {
+		return err
+	}
+	r.settingsRetry = retry
+	return nil
+}
+
+// cycleBackoff moves the backoff strategy step positions in api.BackoffStrategies
+func (r *RequestView) cycleBackoff(step int) {
+	strategies := api.BackoffStrategies
+	idx := 0
+	for i, strategy := range strategies {
+		if strategy == r.settingsRetry.Backoff {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + step + len(strategies)) % len(strategies)
+	r.settingsRetry.Backoff = strategies[idx]
+}
+
+// handleSettingsInput handles keyboard input in the Settings tab
+func (r RequestView) handleSettingsInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
+	if r.settingsEditing {
+		return r.handleSettingsFieldEdit(msg)
+	}
+
+	switch msg.String() {
+	case "tab":
+		r.tabs.Next()
+	case "shift+tab":
+		r.tabs.Previous()
+	case "1", "2", "3", "4", "5", "6", "7", "8":
+		idx, _ := strconv.Atoi(msg.String())
+		r.tabs.SetActive(idx - 1)
+	case "j", "down":
+		if int(r.settingsField) < len(settingsFields)-1 {
+			r.settingsField++
+		}
+	case "k", "up":
+		if r.settingsField > 0 {
+			r.settingsField--
+		}
+	case "h", "left":
+		if r.settingsField == SettingsFieldBackoff {
+			r.cycleBackoff(-1)
+			return r, r.emitSettingsChanged(nil)
+		}
+	case "l", "right":
+		if r.settingsField == SettingsFieldBackoff {
+			r.cycleBackoff(1)
+			return r, r.emitSettingsChanged(nil)
+		}
+	case "enter", "i", "c":
+		if r.settingsField != SettingsFieldBackoff {
+			r.settingsEditing = true
+			r.settingsBuffer = r.settingsFieldText(r.settingsField)
+		}
+	}
+	return r, nil
+}
+
+// handleSettingsFieldEdit handles text input when editing a Settings field
+func (r RequestView) handleSettingsFieldEdit(msg tea.KeyMsg) (RequestView, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Discard the edit
+		r.settingsEditing = false
+		r.settingsBuffer = ""
+		return r, nil
+
+	case tea.KeyEnter:
+		r.settingsEditing = false
+		err := r.applySettingsField(r.settingsField, r.settingsBuffer)
+		r.settingsBuffer = ""
+		return r, r.emitSettingsChanged(err)
+
+	case tea.KeyBackspace:
+		if runes := []rune(r.settingsBuffer); len(runes) > 0 {
+			r.settingsBuffer = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeyRunes:
+		r.settingsBuffer += string(msg.Runes)
+
+	case tea.KeySpace:
+		r.settingsBuffer += " "
+	}
+	return r, nil
+}
+
+// emitSettingsChanged returns a command to emit the settings changed message, or the
+// error of an invalid edit
+func (r *RequestView) emitSettingsChanged(err error) tea.Cmd {
+	if err != nil {
+		return func() tea.Msg {
+			return RequestSettingsChangedMsg{Err: err}
+		}
+	}
+	timeout, retry := r.GetSettings()
+	serverName, host := r.GetOverrides()
+	return func() tea.Msg {
+		return RequestSettingsChangedMsg{Timeout: timeout, Retry: retry, ServerName: serverName, Host: host}
+	}
+}
+
+// renderSettingsTab renders the Settings tab (Authorization tab style)
+func (r *RequestView) renderSettingsTab(width, height int) string {
+	var result strings.Builder
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Width(16)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	selectedStyle := lipgloss.NewStyle().
+		Background(styles.Surface0).
+		Foreground(styles.Lavender).
+		Bold(true)
+	editingStyle := lipgloss.NewStyle().
+		Background(styles.Surface1).
+		Foreground(styles.Green)
+	arrowStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	defaultStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	separatorStyle := lipgloss.NewStyle().Foreground(styles.Surface0)
+
+	labels := map[SettingsField]string{
+		SettingsFieldTimeout:    "Timeout",
+		SettingsFieldRetries:    "Retries",
+		SettingsFieldBackoff:    "Backoff",
+		SettingsFieldRetryDelay: "Retry Delay",
+		SettingsFieldRetryOn:    "Retry On Status",
+		SettingsFieldServerName: "TLS Server Name",
+		SettingsFieldHost:       "Host Header",
+	}
+
+	for _, field := range settingsFields {
+		isSelected := r.settingsField == field
+		var line strings.Builder
+
+		if isSelected {
+			line.WriteString(arrowStyle.Render("▸ "))
+		} else {
+			line.WriteString("  ")
+		}
+		line.WriteString(labelStyle.Render(labels[field]))
+
+		if field == SettingsFieldBackoff {
+			backoff := r.settingsRetry.Backoff
+			if backoff == "" {
+				backoff = api.BackoffFixed
+			}
+			if isSelected {
+				line.WriteString(selectedStyle.Render(fmt.Sprintf("◀ %s ▶", backoff)))
+			} else {
+				line.WriteString(valueStyle.Render(backoff))
+			}
+			result.WriteString(line.String())
+			result.WriteString("\n")
+			continue
+		}
+
+		if isSelected && r.settingsEditing {
+			line.WriteString(editingStyle.Render(r.settingsBuffer + "█"))
+			result.WriteString(line.String())
+			result.WriteString("\n")
+			continue
+		}
+
+		text := r.settingsFieldText(field)
+		style := valueStyle
+		if text == "" {
+			style = defaultStyle
+			switch field {
+			case SettingsFieldTimeout:
+				text = api.DefaultTimeout.String() + " (default)"
+			case SettingsFieldRetryDelay:
+				text = api.DefaultRetryDelay.String() + " (default)"
+			case SettingsFieldRetryOn:
+				text = "(network errors only)"
+			case SettingsFieldServerName, SettingsFieldHost:
+				text = "(URL host)"
+			}
+		}
+		if isSelected {
+			style = selectedStyle
+		}
+		line.WriteString(style.Render(text))
+		result.WriteString(line.String())
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(separatorStyle.Render(strings.Repeat("─", width)))
+	result.WriteString("\n\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Italic(true)
+	if r.settingsRetry.Count <= 0 {
+		result.WriteString(helpStyle.Render("The request is sent once · Set Retries to resend it on network errors"))
+	} else {
+		var delays []string
+		for n := 1; n <= r.settingsRetry.Count && n <= 5; n++ {
+			delays = append(delays, r.settingsRetry.DelayBefore(n).String())
+		}
+		if r.settingsRetry.Count > 5 {
+			delays = append(delays, "…")
+		}
+		result.WriteString(helpStyle.Render(fmt.Sprintf("Up to %d retries, waiting %s · Network errors are always retried",
+			r.settingsRetry.Count, strings.Join(delays, ", "))))
+	}
+
+	return result.String()
+}
@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/session"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// requestTabNameWidth is the widest a request name is shown in the tabs of the Request panel
+const requestTabNameWidth = 20
+
+// requestTab is a request open in a tab, with the state of its Request and Response panels
+type requestTab struct {
+	request  *RequestView
+	response *ResponseView
+	shown    *pendingSend // Send whose response the Response panel shows
+}
+
+// newRequestTab opens a tab with the saved request of ID requestID, or an empty one
+func newRequestTab(collections *CollectionsView, requestState session.RequestPanelState, responseState session.ResponsePanelState, requestID string) *requestTab {
+	tab := &requestTab{request: NewRequestView(), response: NewResponseView()}
+	tab.request.SetSessionState(requestState)
+	tab.response.SetSessionState(responseState)
+	if req := collections.FindRequestByID(requestID); req != nil {
+		tab.request.LoadCollectionRequest(req)
+	}
+	return tab
+}
+
+// openSessionTabs opens the tabs of a session, and returns them with the index of the active
+// one. A session without tabs opens its active request in a single tab.
+func openSessionTabs(sess *session.Session, collections *CollectionsView) ([]*requestTab, int) {
+	if len(sess.Tabs) == 0 {
+		return []*requestTab{newRequestTab(collections, sess.Panels.Request, sess.Panels.Response, sess.ActiveRequest)}, 0
+	}
+	tabs := make([]*requestTab, len(sess.Tabs))
+	for i, saved := range sess.Tabs {
+		requestState, responseState := saved.Request, sess.Panels.Response
+		responseState.ActiveTab = saved.ResponseTab
+		if i == sess.ActiveTab {
+			requestState = sess.Panels.Request
+			responseState = sess.Panels.Response
+		} else {
+			responseState.ScrollPosition = 0
+		}
+		tabs[i] = newRequestTab(collections, requestState, responseState, saved.RequestID)
+	}
+	return tabs, sess.ActiveTab
+}
+
+// name returns the label of the tab in the Request panel title
+func (t *requestTab) name() string {
+	name := t.request.GetCurrentRequestName()
+	if name == "" && t.request.GetURL() != "" {
+		name = t.request.GetURL()
+	}
+	if name == "" {
+		return "(empty)"
+	}
+	if len([]rune(name)) > requestTabNameWidth {
+		return truncateURL(name, requestTabNameWidth)
+	}
+	return name
+}
+
+// currentTab returns the tab shown in the Request and Response panels
+func (m Model) currentTab() *requestTab {
+	return m.tabs[m.activeTab]
+}
+
+// shows reports whether the panels show tab
+func (m Model) shows(tab *requestTab) bool {
+	return tab.request == m.requestPanel
+}
+
+// tabOf returns the index of the tab where the request of ID requestID is open, -1
+// when none is
+func (m Model) tabOf(requestID string) int {
+	if requestID == "" {
+		return -1
+	}
+	for i, tab := range m.tabs {
+		if tab.request.GetCurrentRequestID() == requestID {
+			return i
+		}
+	}
+	return -1
+}
+
+// showTab shows the tab at index i in the Request and Response panels
+func (m *Model) showTab(i int) tea.Cmd {
+	m.activeTab = i
+	tab := m.tabs[i]
+	m.requestPanel, m.responsePanel = tab.request, tab.response
+	m.statusBar.SetMethod(tab.request.GetMethod())
+	return m.markSessionDirty()
+}
+
+// showRequestTab shows the tab where the request of ID requestID is open, if another
+// tab than the active one. It returns false when there is none.
+func (m *Model) showRequestTab(requestID string) bool {
+	i := m.tabOf(requestID)
+	if i < 0 || i == m.activeTab {
+		return false
+	}
+	m.showTab(i)
+	return true
+}
+
+// cycleTab shows the next tab (delta 1) or the previous one (delta -1), wrapping around
+func (m Model) cycleTab(delta int) (tea.Model, tea.Cmd) {
+	if len(m.tabs) < 2 {
+		m.statusBar.Info("Only one request is open. :tabnew opens another")
+		return m, nil
+	}
+	cmd := m.showTab((m.activeTab + delta + len(m.tabs)) % len(m.tabs))
+	return m, cmd
+}
+
+// openTab opens a tab after the active one, with the request selected in the Collections
+// tree if any, and shows it. A request already open shows its tab instead.
+func (m Model) openTab() (tea.Model, tea.Cmd) {
+	var req *api.CollectionRequest
+	collections := m.leftPanel.GetCollections()
+	if node := collections.Selected(); node != nil && node.Type == components.RequestNode {
+		req = collections.FindRequestByID(node.ID)
+	}
+	if req != nil {
+		if i := m.tabOf(req.ID); i >= 0 {
+			cmd := m.showTab(i)
+			m.statusBar.Info(req.Name + " is already open")
+			return m, cmd
+		}
+	}
+
+	state := m.requestPanel.GetSessionState()
+	tab := newRequestTab(collections, session.RequestPanelState{ActiveTab: state.ActiveTab}, session.ResponsePanelState{ActiveTab: "body"}, "")
+	if req != nil {
+		tab.request.LoadCollectionRequest(req)
+	}
+	m.tabs = append(m.tabs[:m.activeTab+1], append([]*requestTab{tab}, m.tabs[m.activeTab+1:]...)...)
+	cmd := m.showTab(m.activeTab + 1)
+	if req != nil {
+		m.activePanel = RequestPanel
+	} else {
+		m.activePanel = CollectionsPanel // Pick the request of the empty tab
+	}
+	return m, cmd
+}
+
+// closeTab closes the active tab and shows the next one, once confirmed when it has
+// unsaved edits. The last tab stays open.
+func (m Model) closeTab() (tea.Model, tea.Cmd) {
+	if len(m.tabs) < 2 {
+		m.statusBar.Info("The last tab cannot be closed")
+		return m, nil
+	}
+	tab := m.currentTab()
+	if tab.request.IsDirty() {
+		return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.closeTab() }})
+	}
+	// An event stream or gRPC call answering the tab ends with it
+	if m.streamSend != nil && m.streamSend.tab == tab {
+		if m.eventStream != nil {
+			m.eventStream.Close()
+			m.eventStream = nil
+		}
+		if m.grpcStream != nil {
+			m.grpcStream.Close()
+			m.grpcStream = nil
+		}
+		m.streamSend = nil
+	}
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	cmd := m.showTab(min(m.activeTab, len(m.tabs)-1))
+	m.statusBar.Info("Closed " + tab.name())
+	return m, cmd
+}
+
+// acceptsTabKeys reports whether gt, gT, ]b and [b switch tabs: in NORMAL mode, with
+// several tabs, outside text inputs
+func (m Model) acceptsTabKeys() bool {
+	if m.mode != NormalMode || len(m.tabs) < 2 {
+		return false
+	}
+	switch m.activePanel {
+	case CollectionsPanel:
+		return !m.leftPanel.IsSearching()
+	case RequestPanel:
+		return m.requestPanel.AcceptsMarks()
+	case ResponsePanel:
+		return !m.responsePanel.IsSearching()
+	}
+	return false
+}
+
+// tabKey returns the tab switch of a key following the previous one: 1 for gt and ]b,
+// -1 for gT and [b, 0 for other keys
+func tabKey(previous, key string) int {
+	switch previous + key {
+	case "gt", "]b":
+		return 1
+	case "gT", "[b":
+		return -1
+	}
+	return 0
+}
+
+// sendOf returns the send a message answers, nil for other messages
+func (m Model) sendOf(msg tea.Msg) *pendingSend {
+	switch msg := msg.(type) {
+	case HTTPResponseMsg:
+		return m.sends.inFlight[msg.SendID]
+	case EventStreamStartedMsg:
+		return m.sends.inFlight[msg.SendID]
+	case GRPCStreamStartedMsg:
+		return m.sends.inFlight[msg.SendID]
+	case PreRequestScriptResultMsg:
+		return m.sends.inFlight[msg.SendID]
+	case EventStreamEventMsg, EventStreamClosedMsg, GRPCStreamMessageMsg, GRPCStreamSendMsg, GRPCStreamSentMsg, GRPCStreamCloseSendMsg, GRPCStreamClosedMsg:
+		return m.streamSend
+	}
+	return nil
+}
+
+// updateInTab handles a message answering a send of a hidden tab with the panels of that
+// tab, so that its response is shown there, then shows the active tab again
+func (m Model) updateInTab(tab *requestTab, msg tea.Msg) (tea.Model, tea.Cmd) {
+	active, activePanel := m.currentTab(), m.activePanel
+	m.requestPanel, m.responsePanel = tab.request, tab.response
+	model, cmd := m.update(msg)
+	next, ok := model.(Model)
+	if !ok {
+		return model, cmd
+	}
+	next.requestPanel, next.responsePanel = active.request, active.response
+	next.activePanel = activePanel
+	next.statusBar.SetMethod(active.request.GetMethod())
+	if _, ok := msg.(HTTPResponseMsg); ok {
+		for i := range next.tabs {
+			if next.tabs[i] == tab {
+				next.statusBar.Info(fmt.Sprintf("Response received in tab %d (%s)", i+1, tab.name()))
+			}
+		}
+	}
+	return next, cmd
+}
+
+// requestPanelTitle returns the title of the Request panel: with several tabs, their
+// numbers and names, the active one in brackets. Requests with unsaved edits are marked.
+func (m Model) requestPanelTitle(width int) string {
+	if len(m.tabs) < 2 {
+		if m.requestPanel.IsDirty() {
+			return "Request " + dirtyMark
+		}
+		return "Request"
+	}
+	labels := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		labels[i] = fmt.Sprintf("%d %s", i+1, tab.name())
+		if tab.request.IsDirty() {
+			labels[i] += " " + dirtyMark
+		}
+		if i == m.activeTab {
+			labels[i] = "[" + labels[i] + "]"
+		}
+	}
+	title := "Request " + strings.Join(labels, " ")
+	if maxWidth := width - 6; len([]rune(title)) > maxWidth {
+		return truncateURL(title, maxWidth)
+	}
+	return title
+}
+
+// sessionTabs returns the tabs to save in the session, nil with a single tab
+func (m Model) sessionTabs() []session.RequestTab {
+	if len(m.tabs) < 2 {
+		return nil
+	}
+	tabs := make([]session.RequestTab, len(m.tabs))
+	for i, tab := range m.tabs {
+		tabs[i] = session.RequestTab{
+			RequestID:   tab.request.GetCurrentRequestID(),
+			Request:     tab.request.GetSessionState(),
+			ResponseTab: tab.response.GetSessionState().ActiveTab,
+		}
+	}
+	return tabs
+}
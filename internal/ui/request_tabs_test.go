@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+func TestModel_RequestTabs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+		{ID: "create", Name: "Create order", Method: api.POST, URL: "https://shop.example.com/orders"},
+	}}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	keys := func(keys ...string) {
+		for _, key := range keys {
+			update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+	collections := m.leftPanel.GetCollections()
+
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	update(CommandExecuteMsg{Command: CmdTabClose})
+	if len(m.tabs) != 1 || !strings.Contains(m.statusBar.message, "last tab") {
+		t.Fatalf("the last tab should stay open, got %d tabs, %q", len(m.tabs), m.statusBar.message)
+	}
+
+	// :tabnew opens the request selected in the tree after the active tab
+	collections.SelectRequest("create")
+	update(CommandExecuteMsg{Command: CmdTabNew})
+	if len(m.tabs) != 2 || m.activeTab != 1 || m.requestPanel.GetCurrentRequestID() != "create" {
+		t.Fatalf(":tabnew should open the selected request in a second tab, got %d tabs, tab %d", len(m.tabs), m.activeTab)
+	}
+	if view := PlainSnapshot(m.View()); !strings.Contains(view, "Request 1 List orders [2 Create order]") {
+		t.Errorf("the Request panel title should list the tabs:\n%s", view)
+	}
+
+	// A send of the second tab answers in its panels while the first is shown
+	send := &pendingSend{
+		requestID: "create",
+		request:   &api.Request{Method: api.POST, URL: "https://shop.example.com/orders"},
+		source:    collections.FindRequestByID("create"),
+	}
+	m.startSend(send)
+	m.activePanel = RequestPanel
+	keys("g", "T")
+	if m.activeTab != 0 || m.requestPanel.GetCurrentRequestID() != "list" {
+		t.Fatalf("gT should show the first tab, got tab %d", m.activeTab)
+	}
+	update(HTTPResponseMsg{Response: &api.Response{StatusCode: 201, Status: "201 Created", Body: []byte(`{"id":7}`)}, SendID: send.id})
+	if m.tabs[1].response.GetStatusCode() != 201 || m.responsePanel.GetStatusCode() != 0 {
+		t.Errorf("the response should be shown in the tab of its send, got %d in tab 2, %d in tab 1",
+			m.tabs[1].response.GetStatusCode(), m.responsePanel.GetStatusCode())
+	}
+	if m.activeTab != 0 || m.requestPanel.GetCurrentRequestID() != "list" || !strings.Contains(m.statusBar.message, "Response received in tab 2") {
+		t.Errorf("the first tab should stay shown with a notice, got tab %d, %q", m.activeTab, m.statusBar.message)
+	}
+
+	keys("]", "b")
+	if m.activeTab != 1 || m.responsePanel.GetStatusCode() != 201 {
+		t.Fatalf("]b should show the second tab with its response, got tab %d", m.activeTab)
+	}
+
+	// Selecting a request open in another tab shows that tab
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	if m.activeTab != 0 || len(m.tabs) != 2 {
+		t.Errorf("selecting an open request should show its tab, got tab %d of %d", m.activeTab, len(m.tabs))
+	}
+
+	// The tabs are restored with the session
+	keys("g", "t")
+	m.saveSession()
+	restored := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	if len(restored.tabs) != 2 || restored.activeTab != 1 || restored.tabs[0].request.GetCurrentRequestID() != "list" || restored.requestPanel.GetCurrentRequestID() != "create" {
+		t.Errorf("the session should restore the tabs, got %d tabs, tab %d", len(restored.tabs), restored.activeTab)
+	}
+
+	update(CommandExecuteMsg{Command: CmdTabClose})
+	if len(m.tabs) != 1 || m.requestPanel.GetCurrentRequestID() != "list" {
+		t.Errorf(":tabclose should close the active tab, got %d tabs", len(m.tabs))
+	}
+}
+
+func TestModel_RequestTabsOverlappingSends(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+		{ID: "create", Name: "Create order", Method: api.POST, URL: "https://shop.example.com/orders",
+			Extract: []api.ExtractRule{{Variable: "order_id", Type: api.ExtractJSONPath, Expression: "$.id"}}},
+	}}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+	env := &api.EnvironmentFile{Name: "dev", Variables: map[string]*api.EnvironmentVariable{}}
+	if err := api.SaveEnvironment(env, filepath.Join(workspace, ".lazycurl", "environments", "dev.json")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	update := func(msg tea.Msg) tea.Cmd {
+		model, cmd := m.Update(msg)
+		m = model.(Model)
+		return cmd
+	}
+	collections := m.leftPanel.GetCollections()
+	sendOf := func(id string) *pendingSend {
+		src := collections.FindRequestByID(id)
+		return &pendingSend{requestID: id, request: &api.Request{Method: src.Method, URL: src.URL}, source: src}
+	}
+
+	// Tab 2 sends create, then tab 1 sends list before create is answered
+	update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	collections.SelectRequest("create")
+	update(CommandExecuteMsg{Command: CmdTabNew})
+	create := sendOf("create")
+	create.postResponse = `lc.test("created", () => lc.expect(lc.response.status).toBe(201));`
+	m.startSend(create)
+	m.activePanel = RequestPanel
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	list := sendOf("list")
+	m.startSend(list)
+
+	// The response of create runs the capture rules and script of create
+	cmd := update(HTTPResponseMsg{Response: &api.Response{StatusCode: 201, Status: "201 Created", Body: []byte(`{"id":7}`)}, SendID: create.id})
+	if m.tabs[1].response.GetStatusCode() != 201 {
+		t.Fatalf("the response should be shown in the tab of create, got %d", m.tabs[1].response.GetStatusCode())
+	}
+	if v := m.leftPanel.GetEnvironments().GetActiveEnvironment().Variables["order_id"]; v == nil || v.Value != "7" {
+		t.Errorf("the capture rules of create should apply to its response, got %+v", v)
+	}
+	if cmd == nil {
+		t.Error("the post-response script of create should run on its response")
+	}
+	if cmd := update(HTTPResponseMsg{Response: &api.Response{StatusCode: 200, Status: "200 OK", Body: []byte(`{"id":1}`)}, SendID: list.id}); cmd != nil {
+		t.Error("list has no post-response script")
+	}
+	if v := m.leftPanel.GetEnvironments().GetActiveEnvironment().Variables["order_id"]; v == nil || v.Value != "7" {
+		t.Errorf("list has no capture rules, got %+v", v)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/kbrdn1/LazyCurl/internal/api"
 	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/format"
 	"github.com/kbrdn1/LazyCurl/internal/session"
 	"github.com/kbrdn1/LazyCurl/internal/ui/components"
 	"github.com/kbrdn1/LazyCurl/pkg/styles"
@@ -25,6 +26,9 @@ const (
 	FormDataBody
 	RawBody
 	BinaryBody
+	MsgpackBody
+	CBORBody
+	GraphQLBody
 )
 
 // String returns the display name for the body type
@@ -40,11 +44,58 @@ func (b BodyType) String() string {
 		return "raw"
 	case BinaryBody:
 		return "binary"
+	case MsgpackBody:
+		return "msgpack"
+	case CBORBody:
+		return "cbor"
+	case GraphQLBody:
+		return "graphql"
 	default:
 		return "none"
 	}
 }
 
+// ParseBodyType returns the body type named name ("json", "form-data", ...)
+func ParseBodyType(name string) (BodyType, bool) {
+	for b := NoneBody; b <= GraphQLBody; b++ {
+		if strings.EqualFold(b.String(), name) {
+			return b, true
+		}
+	}
+	return NoneBody, false
+}
+
+// IsJSONAuthored returns true if the body is edited as JSON in the body editor
+func (b BodyType) IsJSONAuthored() bool {
+	return b == JSONBody || b == MsgpackBody || b == CBORBody
+}
+
+// HasEditor returns true if the Body tab edits the body in an editor
+func (b BodyType) HasEditor() bool {
+	return b.IsJSONAuthored() || b == GraphQLBody || b == RawBody
+}
+
+// rawBodySyntax returns the editor syntax of a raw body: "xml" for XML documents
+// (such as SOAP envelopes), "text" otherwise
+func rawBodySyntax(content string) string {
+	if strings.HasPrefix(strings.TrimSpace(content), "<") {
+		return "xml"
+	}
+	return "text"
+}
+
+// WireFormat returns the binary encoding applied to the JSON body on send, if any
+func (b BodyType) WireFormat() (format.BinaryFormat, bool) {
+	switch b {
+	case MsgpackBody:
+		return format.FormatMsgpack, true
+	case CBORBody:
+		return format.FormatCBOR, true
+	default:
+		return "", false
+	}
+}
+
 // === REQUEST ACTION MESSAGES ===
 // These are sent to the parent model to handle dialogs
 
@@ -143,6 +194,14 @@ const (
 	PostRequestSection
 )
 
+// GraphQLSection represents which editor is active in the Body tab of a GraphQL request
+type GraphQLSection int
+
+const (
+	GraphQLQuerySection GraphQLSection = iota
+	GraphQLVariablesSection
+)
+
 // AuthType represents the type of authentication
 type AuthType int
 
@@ -151,6 +210,7 @@ const (
 	AuthBearer
 	AuthBasic
 	AuthAPIKey
+	AuthJWT
 )
 
 // String returns the display name for the auth type
@@ -164,6 +224,8 @@ func (a AuthType) String() string {
 		return "Basic Auth"
 	case AuthAPIKey:
 		return "API Key"
+	case AuthJWT:
+		return "JWT Bearer"
 	default:
 		return "No Auth"
 	}
@@ -181,6 +243,10 @@ const (
 	AuthFieldAPIKeyName
 	AuthFieldAPIKeyValue
 	AuthFieldAPIKeyLocation
+	AuthFieldJWTAlgorithm
+	AuthFieldJWTKey
+	AuthFieldJWTClaims
+	AuthFieldJWTExpiresIn
 )
 
 // RequestView represents the request builder panel
@@ -191,8 +257,17 @@ type RequestView struct {
 	paramsTable  *components.Table // Query params
 	pathParams   *components.Table // Path params (:id, :slug, etc.)
 	headersTable *components.Table
-	bodyEditor   *components.Editor
+	formTable    *components.Table  // Fields of a form-data body
+	captureTable *components.Table  // Extraction rules of the Capture tab
+	binaryPath   string             // File sent as a binary body
+	bodyEditor   *components.Editor // Body, or the query of a GraphQL body
 	bodyType     BodyType
+	bodySchema   *api.JSONSchema // Schema of a JSON body, nil when it has none
+	bodyBuilder  *bodyBuilder    // Form over bodySchema shown in place of bodyEditor, nil when not shown
+
+	// GraphQL body: the query lives in bodyEditor, the variables in their own JSON editor
+	graphqlVariablesEditor *components.Editor
+	graphqlSection         GraphQLSection
 
 	// Authorization tab
 	authType           AuthType
@@ -203,9 +278,22 @@ type RequestView struct {
 	authAPIKeyName     string
 	authAPIKeyValue    string
 	authAPIKeyLocation string // "header" or "query"
+	authJWTAlgorithm   string // "HS256", "RS256" or "ES256"
+	authJWTKey         string
+	authJWTClaims      string
+	authJWTExpiresIn   string
 	authField          AuthField
 	authEditing        bool // Whether we're editing a field
 
+	// Settings tab
+	settingsTimeout string          // Timeout duration text, empty for api.DefaultTimeout
+	settingsRetry   api.RetryPolicy // Count 0 sends once
+	settingsSNI     string          // TLS server name, empty for the URL host
+	settingsHost    string          // Host header, empty for the URL host
+	settingsField   SettingsField
+	settingsEditing bool
+	settingsBuffer  string // Text of the field being edited
+
 	// Scripts tab editors
 	preRequestEditor  *components.Editor
 	postRequestEditor *components.Editor
@@ -214,9 +302,22 @@ type RequestView struct {
 	// Params tab section (Query or Path)
 	paramsSection ParamsSection
 
+	// Bulk edit of the Headers or query Params table, nil when not bulk editing
+	bulkEditor *components.Editor
+	bulkTab    string // Tab of the table being bulk edited
+
+	// Docs tab: the Markdown description of the request, rendered unless edited
+	docsEditor  *components.Editor
+	docsSaved   string // Description last loaded or reported
+	docsEditing bool
+	docsScroll  int
+	docsRender  docsRender // Last rendering, reused while the docs, width and theme are unchanged
+
 	// Current request tracking (for saving changes)
 	currentRequestID   string
 	currentRequestName string
+	edits              requestEdits          // Parts edited and not saved yet (auto_save off)
+	held               api.CollectionRequest // Redirect settings, variables and examples edited with commands, until :w
 
 	// URL editing state
 	editingURL bool
@@ -244,6 +345,9 @@ func NewRequestView() *RequestView {
 		"Headers",
 		"Body",
 		"Scripts",
+		"Settings",
+		"Capture",
+		"Docs",
 	})
 
 	paramsTable := components.NewTable([]string{"", "Key", "Value"})
@@ -284,8 +388,11 @@ const response = pm.response.json();`, "javascript")
 		paramsTable:        paramsTable,
 		pathParams:         pathParams,
 		headersTable:       headersTable,
+		formTable:          components.NewTable([]string{"", "Key", "Value"}),
+		captureTable:       components.NewTable([]string{"", "Variable", "Expression"}),
 		bodyEditor:         bodyEditor,
 		bodyType:           JSONBody,
+		docsEditor:         components.NewEditor("", "markdown"),
 		authType:           AuthNone,
 		authToken:          "",
 		authPrefix:         "Bearer",
@@ -294,11 +401,15 @@ const response = pm.response.json();`, "javascript")
 		authAPIKeyName:     "",
 		authAPIKeyValue:    "",
 		authAPIKeyLocation: "header",
+		authJWTAlgorithm:   api.JWTAlgorithmHS256,
 		authField:          AuthFieldType,
 		paramsSection:      QueryParamsSection,
 		preRequestEditor:   preRequestEditor,
 		postRequestEditor:  postRequestEditor,
 		scriptsSection:     PreRequestSection,
+
+		graphqlVariablesEditor: components.NewEditor("{\n\n}", "json"),
+		graphqlSection:         GraphQLQuerySection,
 	}
 
 	// Add default headers like Postman
@@ -326,6 +437,13 @@ func (r *RequestView) getCurrentTable() *components.Table {
 		return r.paramsTable
 	case "Headers":
 		return r.headersTable
+	case "Body":
+		if r.bodyType == FormDataBody {
+			return r.formTable
+		}
+		return nil
+	case "Capture":
+		return r.captureTable
 	default:
 		return nil
 	}
@@ -397,18 +515,38 @@ func (r *RequestView) DuplicateRow(index int) {
 		row := table.Rows[index]
 		newKey := row.Key + "_copy"
 		table.AddRow(newKey, row.Value)
+		table.Rows[len(table.Rows)-1].File = row.File
 	}
 }
 
-// IsEditorActive returns true if an editor tab (Body or Scripts) is active
+// IsEditorActive returns true if an editor tab (Body with an editable body type, or Scripts)
+// or a bulk edit is active
 func (r *RequestView) IsEditorActive() bool {
 	tab := r.tabs.GetActive()
-	return tab == "Body" || tab == "Scripts"
+	return (tab == "Body" && r.bodyType.HasEditor()) || tab == "Scripts" || r.IsBulkEditing() || r.IsDocsEditing()
 }
 
 // IsEditorInInsertMode returns true if the body editor is in INSERT mode
 func (r *RequestView) IsEditorInInsertMode() bool {
-	return r.bodyEditor.GetMode() == components.EditorInsertMode
+	return r.activeBodyEditor().GetMode() == components.EditorInsertMode
+}
+
+// activeBodyEditor returns the editor shown in the Body tab (the variables editor in the
+// Variables section of a GraphQL body)
+func (r *RequestView) activeBodyEditor() *components.Editor {
+	if r.bodyType == GraphQLBody && r.graphqlSection == GraphQLVariablesSection {
+		return r.graphqlVariablesEditor
+	}
+	return r.bodyEditor
+}
+
+// setActiveBodyEditor stores the editor returned by an Update of activeBodyEditor
+func (r *RequestView) setActiveBodyEditor(editor *components.Editor) {
+	if r.bodyType == GraphQLBody && r.graphqlSection == GraphQLVariablesSection {
+		r.graphqlVariablesEditor = editor
+		return
+	}
+	r.bodyEditor = editor
 }
 
 // IsScriptsEditorInInsertMode returns true if the active scripts editor is in INSERT mode
@@ -419,6 +557,28 @@ func (r *RequestView) IsScriptsEditorInInsertMode() bool {
 	return r.postRequestEditor.GetMode() == components.EditorInsertMode
 }
 
+// AcceptsMarks returns true when no text is typed in the active tab, so that m and '
+// set and jump to marks
+func (r *RequestView) AcceptsMarks() bool {
+	if r.editingURL || r.authEditing || r.IsSettingsEditing() || r.IsBodyBuilderEditing() {
+		return false
+	}
+	switch r.tabs.GetActive() {
+	case "Body":
+		editor := r.activeBodyEditor()
+		return !r.bodyType.HasEditor() || (!r.IsEditorInInsertMode() && !editor.IsSearching())
+	case "Scripts":
+		return !r.IsScriptsEditorInInsertMode() && !r.GetActiveScriptsEditor().IsSearching()
+	}
+	if r.IsBulkEditing() {
+		return r.bulkEditor.GetMode() != components.EditorInsertMode && !r.bulkEditor.IsSearching()
+	}
+	if r.IsDocsEditing() {
+		return r.docsEditor.GetMode() != components.EditorInsertMode && !r.docsEditor.IsSearching()
+	}
+	return true
+}
+
 // IsAuthEditing returns true if editing a field in Authorization tab
 func (r *RequestView) IsAuthEditing() bool {
 	return r.authEditing
@@ -456,6 +616,19 @@ func (r *RequestView) GetAuthConfig() *api.AuthConfig {
 			APIKeyValue:    r.authAPIKeyValue,
 			APIKeyLocation: location,
 		}
+	case AuthJWT:
+		prefix := r.authPrefix
+		if prefix == "" {
+			prefix = "Bearer"
+		}
+		return &api.AuthConfig{
+			Type:         "jwt",
+			Prefix:       prefix,
+			JWTAlgorithm: r.authJWTAlgorithm,
+			JWTKey:       r.authJWTKey,
+			JWTClaims:    r.authJWTClaims,
+			JWTExpiresIn: r.authJWTExpiresIn,
+		}
 	}
 	return nil
 }
@@ -471,6 +644,8 @@ func (r *RequestView) getVisibleAuthFields() []AuthField {
 		return []AuthField{AuthFieldType, AuthFieldUsername, AuthFieldPassword}
 	case AuthAPIKey:
 		return []AuthField{AuthFieldType, AuthFieldAPIKeyName, AuthFieldAPIKeyValue, AuthFieldAPIKeyLocation}
+	case AuthJWT:
+		return []AuthField{AuthFieldType, AuthFieldJWTAlgorithm, AuthFieldJWTKey, AuthFieldJWTClaims, AuthFieldJWTExpiresIn, AuthFieldPrefix}
 	}
 	return []AuthField{AuthFieldType}
 }
@@ -507,8 +682,9 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			r.bodyEditor.SetContent(msg.Content)
 			// Emit body changed message
 			bodyType := r.bodyType.String()
+			content := r.GetBodyContent()
 			return r, func() tea.Msg {
-				return RequestBodyChangedMsg{BodyType: bodyType, Content: msg.Content}
+				return RequestBodyChangedMsg{BodyType: bodyType, Content: content}
 			}
 		}
 		return r, nil
@@ -519,9 +695,19 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 
 	case components.SearchUpdateMsg, components.SearchCloseMsg:
 		// Forward search messages to the active editor
-		if r.tabs.GetActive() == "Body" && r.bodyType == JSONBody {
-			editor, cmd := r.bodyEditor.Update(msg, true)
-			r.bodyEditor = editor
+		if r.IsBulkEditing() {
+			var cmd tea.Cmd
+			r.bulkEditor, cmd = r.bulkEditor.Update(msg, true)
+			return r, cmd
+		}
+		if r.IsDocsEditing() {
+			var cmd tea.Cmd
+			r.docsEditor, cmd = r.docsEditor.Update(msg, true)
+			return r, cmd
+		}
+		if r.tabs.GetActive() == "Body" && r.bodyType.HasEditor() {
+			editor, cmd := r.activeBodyEditor().Update(msg, true)
+			r.setActiveBodyEditor(editor)
 			return r, cmd
 		}
 		if r.tabs.GetActive() == "Scripts" {
@@ -540,7 +726,7 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 		// Handle format result from editor - also emit body changed
 		if msg.Success && r.tabs.GetActive() == "Body" {
 			bodyType := r.bodyType.String()
-			content := r.bodyEditor.GetContent()
+			content := r.GetBodyContent()
 			return r, func() tea.Msg {
 				return RequestBodyChangedMsg{BodyType: bodyType, Content: content}
 			}
@@ -549,12 +735,22 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 
 	case components.EditorContentChangedMsg:
 		// Handle content changes from body editor
-		if r.tabs.GetActive() == "Body" && r.bodyType == JSONBody {
+		if r.tabs.GetActive() == "Body" && r.bodyType.HasEditor() {
 			bodyType := r.bodyType.String()
+			content := msg.Content
+			if r.bodyType == GraphQLBody {
+				content = r.GetBodyContent()
+			} else if r.bodyType == RawBody {
+				r.bodyEditor.SetSyntaxType(rawBodySyntax(content))
+			}
 			return r, func() tea.Msg {
-				return RequestBodyChangedMsg{BodyType: bodyType, Content: msg.Content}
+				return RequestBodyChangedMsg{BodyType: bodyType, Content: content}
 			}
 		}
+		// Handle docs changes
+		if r.IsDocsEditing() {
+			return r, r.docsChanged()
+		}
 		// Handle scripts content changes
 		if r.tabs.GetActive() == "Scripts" {
 			return r, func() tea.Msg {
@@ -572,13 +768,38 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			return r.handleURLInput(msg)
 		}
 
-		// If in Body tab with JSON body type, forward to editor
-		if r.tabs.GetActive() == "Body" && r.bodyType == JSONBody {
+		// CTRL+Y copies the request as a code snippet from any tab
+		if msg.String() == "ctrl+y" {
+			return r, func() tea.Msg {
+				return RequestCopyAsCodeMsg{}
+			}
+		}
+
+		// Bulk edit of the Headers or query Params table
+		if r.IsBulkEditing() {
+			return r.handleBulkEditInput(msg)
+		}
+
+		// Docs tab: rendered Markdown, or its source in the editor
+		if r.tabs.GetActive() == "Docs" {
+			if view, cmd, handled := r.handleDocsInput(msg); handled {
+				return view, cmd
+			}
+		}
+
+		// Body builder: the fields of the body schema
+		if r.IsBodyBuilding() {
+			return r.handleBodyBuilderInput(msg)
+		}
+
+		// If in Body tab with an editable body type, forward to editor
+		if r.tabs.GetActive() == "Body" && r.bodyType.HasEditor() {
+			activeEditor := r.activeBodyEditor()
 			// Only intercept tab switching and send request when in NORMAL mode and not searching
-			if r.bodyEditor.GetMode() == components.EditorInsertMode || r.bodyEditor.IsSearching() {
+			if activeEditor.GetMode() == components.EditorInsertMode || activeEditor.IsSearching() {
 				// In INSERT mode or searching, forward everything to editor
-				editor, cmd := r.bodyEditor.Update(msg, true)
-				r.bodyEditor = editor
+				editor, cmd := activeEditor.Update(msg, true)
+				r.setActiveBodyEditor(editor)
 				return r, cmd
 			}
 
@@ -590,7 +811,7 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			case "shift+tab":
 				r.tabs.Previous()
 				return r, nil
-			case "1", "2", "3", "4", "5":
+			case "1", "2", "3", "4", "5", "6", "7", "8":
 				// Allow number-based tab switching
 				switch msg.String() {
 				case "1":
@@ -603,15 +824,42 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 					r.tabs.SetActive(3)
 				case "5":
 					r.tabs.SetActive(4)
+				case "6":
+					r.tabs.SetActive(5)
+				case "7":
+					r.tabs.SetActive(6)
+				case "8":
+					r.tabs.SetActive(7)
 				}
 				return r, nil
+			case "[", "]":
+				// Switch between the query and variables of a GraphQL body
+				if r.bodyType == GraphQLBody {
+					if msg.String() == "[" {
+						r.graphqlSection = GraphQLQuerySection
+					} else {
+						r.graphqlSection = GraphQLVariablesSection
+					}
+					return r, nil
+				}
+				editor, cmd := activeEditor.Update(msg, true)
+				r.setActiveBodyEditor(editor)
+				return r, cmd
+			case "B":
+				// Build a JSON body from its schema
+				if r.bodyType != GraphQLBody {
+					return r, r.startBodyBuilder()
+				}
+				editor, cmd := activeEditor.Update(msg, true)
+				r.setActiveBodyEditor(editor)
+				return r, cmd
 			case "ctrl+s":
 				// TODO: Send HTTP request
 				return r, nil
 			default:
 				// Forward to editor for NORMAL mode commands
-				editor, cmd := r.bodyEditor.Update(msg, true)
-				r.bodyEditor = editor
+				editor, cmd := activeEditor.Update(msg, true)
+				r.setActiveBodyEditor(editor)
 				return r, cmd
 			}
 		}
@@ -639,7 +887,7 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			case "shift+tab":
 				r.tabs.Previous()
 				return r, nil
-			case "1", "2", "3", "4", "5":
+			case "1", "2", "3", "4", "5", "6", "7", "8":
 				// Allow number-based tab switching
 				switch msg.String() {
 				case "1":
@@ -652,6 +900,12 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 					r.tabs.SetActive(3)
 				case "5":
 					r.tabs.SetActive(4)
+				case "6":
+					r.tabs.SetActive(5)
+				case "7":
+					r.tabs.SetActive(6)
+				case "8":
+					r.tabs.SetActive(7)
 				}
 				return r, nil
 			case "[":
@@ -682,6 +936,11 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			return r.handleAuthInput(msg)
 		}
 
+		// If in Settings tab, handle settings-specific keys
+		if r.tabs.GetActive() == "Settings" {
+			return r.handleSettingsInput(msg)
+		}
+
 		// Handle send request
 		if msg.String() == "ctrl+s" {
 			// TODO: Send HTTP request
@@ -695,7 +954,7 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			return r, nil
 		}
 
-		// Tab navigation with numbers 1-5 (NORMAL mode)
+		// Tab navigation with numbers 1-8 (NORMAL mode)
 		switch msg.String() {
 		case "tab":
 			r.tabs.Next()
@@ -711,6 +970,12 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			r.tabs.SetActive(3) // Body
 		case "5":
 			r.tabs.SetActive(4) // Scripts
+		case "6":
+			r.tabs.SetActive(5) // Settings
+		case "7":
+			r.tabs.SetActive(6) // Capture
+		case "8":
+			r.tabs.SetActive(7) // Docs
 		}
 
 		// Handle Params tab section switching with h/l when in Params tab
@@ -739,6 +1004,20 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 			}
 		}
 
+		// Form-data body: file fields and toggles
+		if r.tabs.GetActive() == "Body" && r.bodyType == FormDataBody {
+			if view, cmd, handled := r.handleFormKeys(msg); handled {
+				return view, cmd
+			}
+		}
+
+		// Binary body: file selection
+		if r.tabs.GetActive() == "Body" && r.bodyType == BinaryBody {
+			if view, cmd, handled := r.handleBinaryKeys(msg); handled {
+				return view, cmd
+			}
+		}
+
 		// Navigation and actions for table tabs (like Collections)
 		table := r.getCurrentTable()
 		if table != nil {
@@ -838,12 +1117,18 @@ func (r RequestView) Update(msg tea.Msg, cfg *config.GlobalConfig) (RequestView,
 					}
 				}
 
+			case "B":
+				// Bulk edit the headers or query params as text
+				if r.tabs.GetActive() == "Headers" || r.tabs.GetActive() == "Params" {
+					r.startBulkEdit()
+				}
+
 			case "s", "S":
 				// Toggle enabled state of current row
 				if table.Cursor >= 0 && table.Cursor < table.RowCount() {
 					table.ToggleCurrentEnabled()
-					// Send message to sync params if in Params tab
-					if r.tabs.GetActive() == "Params" {
+					// Send message to sync params if in Params tab, or save the Capture rules
+					if r.tabs.GetActive() == "Params" || r.tabs.GetActive() == "Capture" {
 						return r, func() tea.Msg {
 							return RequestParamToggleMsg{Tab: r.getTabName()}
 						}
@@ -937,7 +1222,7 @@ func (r RequestView) handleAuthInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
 	case "shift+tab":
 		r.tabs.Previous()
 		return r, nil
-	case "1", "2", "3", "4", "5":
+	case "1", "2", "3", "4", "5", "6", "7", "8":
 		// Allow number-based tab switching
 		switch msg.String() {
 		case "1":
@@ -950,6 +1235,12 @@ func (r RequestView) handleAuthInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
 			r.tabs.SetActive(3)
 		case "5":
 			r.tabs.SetActive(4)
+		case "6":
+			r.tabs.SetActive(5)
+		case "7":
+			r.tabs.SetActive(6)
+		case "8":
+			r.tabs.SetActive(7)
 		}
 		return r, nil
 	case "j", "down":
@@ -974,7 +1265,7 @@ func (r RequestView) handleAuthInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
 			if r.authType > AuthNone {
 				r.authType--
 			} else {
-				r.authType = AuthAPIKey
+				r.authType = AuthJWT
 			}
 			// Reset field to type when changing auth type
 			r.authField = AuthFieldType
@@ -990,11 +1281,16 @@ func (r RequestView) handleAuthInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
 			}
 			return r, r.emitAuthChanged()
 		}
+		// For JWT algorithm, cycle backward
+		if r.authField == AuthFieldJWTAlgorithm {
+			r.authJWTAlgorithm = cycleJWTAlgorithm(r.authJWTAlgorithm, -1)
+			return r, r.emitAuthChanged()
+		}
 		return r, nil
 	case "l", "right":
 		// For type field, cycle auth types forward
 		if r.authField == AuthFieldType {
-			if r.authType < AuthAPIKey {
+			if r.authType < AuthJWT {
 				r.authType++
 			} else {
 				r.authType = AuthNone
@@ -1013,10 +1309,15 @@ func (r RequestView) handleAuthInput(msg tea.KeyMsg) (RequestView, tea.Cmd) {
 			}
 			return r, r.emitAuthChanged()
 		}
+		// For JWT algorithm, cycle forward
+		if r.authField == AuthFieldJWTAlgorithm {
+			r.authJWTAlgorithm = cycleJWTAlgorithm(r.authJWTAlgorithm, 1)
+			return r, r.emitAuthChanged()
+		}
 		return r, nil
 	case "enter", "i", "c":
-		// Enter edit mode for editable fields (not type or location)
-		if r.authField != AuthFieldType && r.authField != AuthFieldAPIKeyLocation {
+		// Enter edit mode for editable fields (not type, location or algorithm)
+		if r.authField != AuthFieldType && r.authField != AuthFieldAPIKeyLocation && r.authField != AuthFieldJWTAlgorithm {
 			r.authEditing = true
 		}
 		return r, nil
@@ -1064,6 +1365,18 @@ func (r RequestView) handleAuthFieldEdit(msg tea.KeyMsg) (RequestView, tea.Cmd)
 			if len(r.authAPIKeyValue) > 0 {
 				r.authAPIKeyValue = r.authAPIKeyValue[:len(r.authAPIKeyValue)-1]
 			}
+		case AuthFieldJWTKey:
+			if len(r.authJWTKey) > 0 {
+				r.authJWTKey = r.authJWTKey[:len(r.authJWTKey)-1]
+			}
+		case AuthFieldJWTClaims:
+			if len(r.authJWTClaims) > 0 {
+				r.authJWTClaims = r.authJWTClaims[:len(r.authJWTClaims)-1]
+			}
+		case AuthFieldJWTExpiresIn:
+			if len(r.authJWTExpiresIn) > 0 {
+				r.authJWTExpiresIn = r.authJWTExpiresIn[:len(r.authJWTExpiresIn)-1]
+			}
 		}
 		return r, nil
 
@@ -1083,6 +1396,12 @@ func (r RequestView) handleAuthFieldEdit(msg tea.KeyMsg) (RequestView, tea.Cmd)
 			r.authAPIKeyName += char
 		case AuthFieldAPIKeyValue:
 			r.authAPIKeyValue += char
+		case AuthFieldJWTKey:
+			r.authJWTKey += char
+		case AuthFieldJWTClaims:
+			r.authJWTClaims += char
+		case AuthFieldJWTExpiresIn:
+			r.authJWTExpiresIn += char
 		}
 		return r, nil
 
@@ -1101,6 +1420,10 @@ func (r RequestView) handleAuthFieldEdit(msg tea.KeyMsg) (RequestView, tea.Cmd)
 			r.authAPIKeyName += " "
 		case AuthFieldAPIKeyValue:
 			r.authAPIKeyValue += " "
+		case AuthFieldJWTKey:
+			r.authJWTKey += " "
+		case AuthFieldJWTClaims:
+			r.authJWTClaims += " "
 		}
 		return r, nil
 	}
@@ -1108,6 +1431,20 @@ func (r RequestView) handleAuthFieldEdit(msg tea.KeyMsg) (RequestView, tea.Cmd)
 	return r, nil
 }
 
+// cycleJWTAlgorithm returns the JWT algorithm step positions away from current
+func cycleJWTAlgorithm(current string, step int) string {
+	algorithms := api.JWTAlgorithms
+	idx := 0
+	for i, alg := range algorithms {
+		if alg == current {
+			idx = i
+			break
+		}
+	}
+	idx = (idx + step + len(algorithms)) % len(algorithms)
+	return algorithms[idx]
+}
+
 // emitAuthChanged returns a command to emit auth changed message
 func (r *RequestView) emitAuthChanged() tea.Cmd {
 	auth := r.GetAuthConfig()
@@ -1492,35 +1829,13 @@ func renderTextWithVariables(text string, baseStyle, variableStyle lipgloss.Styl
 	return result.String()
 }
 
-// getMethodStyle returns the style for the HTTP method badge
-func (r *RequestView) getMethodStyle() (lipgloss.Color, lipgloss.Color) {
-	switch r.method {
-	case api.GET:
-		return styles.MethodGetBg, styles.MethodGetFg
-	case api.POST:
-		return styles.MethodPostBg, styles.MethodPostFg
-	case api.PUT:
-		return styles.MethodPutBg, styles.MethodPutFg
-	case api.PATCH:
-		return styles.MethodPatchBg, styles.MethodPatchFg
-	case api.DELETE:
-		return styles.MethodDeleteBg, styles.MethodDeleteFg
-	case api.HEAD:
-		return styles.MethodHeadBg, styles.MethodHeadFg
-	case api.OPTIONS:
-		return styles.MethodOptionsBg, styles.MethodOptionsFg
-	default:
-		return styles.MethodGetBg, styles.MethodGetFg
-	}
-}
-
 // View renders the request view
 func (r RequestView) View(width, height int, active bool) string {
 	var result strings.Builder
 
 	// === REQUEST URL LINE ===
 	// Method badge (same style as Collections panel badges)
-	bg, fg := r.getMethodStyle()
+	bg, fg := styles.MethodColors(string(r.method))
 	methodStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(fg).
@@ -1567,9 +1882,15 @@ func (r RequestView) View(width, height int, active bool) string {
 	case "Headers":
 		tabContent = r.renderHeadersTab(width, contentHeight, active)
 	case "Body":
-		tabContent = r.renderBodyTab(width, contentHeight)
+		tabContent = r.renderBodyTab(width, contentHeight, active)
 	case "Scripts":
 		tabContent = r.renderScriptsTab(width, contentHeight)
+	case "Settings":
+		tabContent = r.renderSettingsTab(width, contentHeight)
+	case "Capture":
+		tabContent = r.renderCaptureTab(width, contentHeight, active)
+	case "Docs":
+		tabContent = r.renderDocsTab(width, contentHeight, active)
 	default:
 		tabContent = "Select a tab to configure the request"
 	}
@@ -1617,7 +1938,9 @@ func (r *RequestView) renderParamsTab(width, height int, active bool) string {
 	// Subtract 2 for section tabs line and separator line
 	contentHeight := height - 2
 
-	if r.paramsSection == PathParamsSection {
+	if r.IsBulkEditing() {
+		result.WriteString(r.renderBulkEdit(width, contentHeight))
+	} else if r.paramsSection == PathParamsSection {
 		if r.pathParams.RowCount() == 0 {
 			emptyStyle := lipgloss.NewStyle().
 				Foreground(styles.Subtext0).
@@ -1770,6 +2093,27 @@ func (r *RequestView) renderAuthTab(width, height int) string {
 			} else {
 				line.WriteString(valueStyle.Render(location))
 			}
+
+		case AuthFieldJWTAlgorithm:
+			line.WriteString(labelStyle.Render("Algorithm"))
+			algorithmText := fmt.Sprintf("◀ %s ▶", r.authJWTAlgorithm)
+			if isSelected {
+				line.WriteString(selectedStyle.Render(algorithmText))
+			} else {
+				line.WriteString(valueStyle.Render(r.authJWTAlgorithm))
+			}
+
+		case AuthFieldJWTKey:
+			line.WriteString(labelStyle.Render("Signing Key"))
+			line.WriteString(renderAuthValue(r.authJWTKey, isSelected, r.authEditing, true))
+
+		case AuthFieldJWTClaims:
+			line.WriteString(labelStyle.Render("Claims"))
+			line.WriteString(renderAuthValue(r.authJWTClaims, isSelected, r.authEditing, false))
+
+		case AuthFieldJWTExpiresIn:
+			line.WriteString(labelStyle.Render("Expires In"))
+			line.WriteString(renderAuthValue(r.authJWTExpiresIn, isSelected, r.authEditing, false))
 		}
 
 		result.WriteString(line.String())
@@ -1811,6 +2155,18 @@ func (r *RequestView) renderAuthTab(width, height int) string {
 		} else {
 			result.WriteString(helpStyle.Render(fmt.Sprintf("Query: ?%s=<value>", keyName)))
 		}
+	case AuthJWT:
+		prefix := r.authPrefix
+		if prefix == "" {
+			prefix = "Bearer"
+		}
+		result.WriteString(helpStyle.Render(fmt.Sprintf("Header: Authorization: %s <JWT signed with %s at send time>", prefix, r.authJWTAlgorithm)))
+		result.WriteString("\n")
+		keyHint := "Signing key: shared secret"
+		if r.authJWTAlgorithm != api.JWTAlgorithmHS256 {
+			keyHint = "Signing key: PEM private key or path to a .pem file"
+		}
+		result.WriteString(helpStyle.Render(keyHint + " · Claims: JSON object, iat added automatically · Expires In: e.g. 5m"))
 	}
 
 	return result.String()
@@ -1818,6 +2174,9 @@ func (r *RequestView) renderAuthTab(width, height int) string {
 
 // renderHeadersTab renders the HTTP Headers tab (Envs style)
 func (r *RequestView) renderHeadersTab(width, height int, active bool) string {
+	if r.IsBulkEditing() {
+		return r.renderBulkEdit(width, height)
+	}
 	if r.headersTable.RowCount() == 0 {
 		emptyStyle := lipgloss.NewStyle().
 			Foreground(styles.Subtext0).
@@ -1832,7 +2191,7 @@ func (r *RequestView) renderHeadersTab(width, height int, active bool) string {
 }
 
 // renderBodyTab renders the Request Body tab
-func (r *RequestView) renderBodyTab(width, height int) string {
+func (r *RequestView) renderBodyTab(width, height int, active bool) string {
 	// Body content based on type - use full height for editor
 	if r.bodyType == NoneBody {
 		emptyStyle := lipgloss.NewStyle().
@@ -1841,7 +2200,15 @@ func (r *RequestView) renderBodyTab(width, height int) string {
 			Align(lipgloss.Center).
 			Padding(2, 0)
 		return emptyStyle.Render("No body content for this request")
-	} else if r.bodyType == JSONBody {
+	} else if r.bodyType == GraphQLBody {
+		return r.renderGraphQLBody(width, height)
+	} else if r.bodyType == FormDataBody {
+		return r.renderFormDataBody(width, height, active)
+	} else if r.bodyType == BinaryBody {
+		return r.renderBinaryBody(width)
+	} else if r.IsBodyBuilding() {
+		return r.renderBodyBuilder(width, height)
+	} else if r.bodyType.HasEditor() {
 		// Use full available height for the editor
 		return r.bodyEditor.View(width, height, true)
 	}
@@ -1854,6 +2221,49 @@ func (r *RequestView) renderBodyTab(width, height int) string {
 	return placeholderStyle.Render(fmt.Sprintf("%s editor not yet implemented", r.bodyType.String()))
 }
 
+// renderGraphQLBody renders the Query / Variables sections of a GraphQL body
+func (r *RequestView) renderGraphQLBody(width, height int) string {
+	var result strings.Builder
+
+	sectionHeaderActive := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Lavender).
+		Background(styles.Surface0).
+		Padding(0, 1)
+
+	sectionHeaderInactive := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Padding(0, 1)
+
+	separatorStyle := lipgloss.NewStyle().Foreground(styles.Surface0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Surface1)
+
+	// Section tabs: [Query] | [Variables] with bracket hints
+	for i, name := range []string{"Query", "Variables"} {
+		if i > 0 {
+			result.WriteString(separatorStyle.Render("  │  "))
+		}
+		if GraphQLSection(i) == r.graphqlSection {
+			result.WriteString(hintStyle.Render("[ "))
+			result.WriteString(sectionHeaderActive.Render(name))
+			result.WriteString(hintStyle.Render(" ]"))
+		} else {
+			result.WriteString("  ")
+			result.WriteString(sectionHeaderInactive.Render(name))
+			result.WriteString("  ")
+		}
+	}
+	result.WriteString("\n")
+
+	result.WriteString(separatorStyle.Render(strings.Repeat("─", width)))
+	result.WriteString("\n")
+
+	// Subtract 2 for section tabs line and separator line
+	result.WriteString(r.activeBodyEditor().View(width, height-2, true))
+
+	return result.String()
+}
+
 // renderScriptsTab renders the Scripts tab
 func (r *RequestView) renderScriptsTab(width, height int) string {
 	var result strings.Builder
@@ -1953,13 +2363,22 @@ func (r *RequestView) renderTableEnvStyle(table *components.Table, width, height
 			valueWidth = 3
 		}
 
-		// Value (highlight variables, dimmed if disabled)
+		// Value (highlight variables, dimmed if disabled; form files shown as @path)
 		value := row.Value
+		if row.File {
+			value = "@" + value
+		}
 		// Truncate value to fit (no ellipsis - just cut)
 		if len(value) > valueWidth {
 			value = value[:valueWidth]
 		}
-		if strings.Contains(row.Value, "{{") {
+		if row.File {
+			valueStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+			if !row.Enabled {
+				valueStyle = valueStyle.Foreground(styles.Subtext0)
+			}
+			line.WriteString(valueStyle.Render(value))
+		} else if strings.Contains(row.Value, "{{") {
 			valueStyle := lipgloss.NewStyle().Foreground(styles.URLVariable)
 			if !row.Enabled {
 				valueStyle = valueStyle.Foreground(styles.Subtext0)
@@ -2026,23 +2445,9 @@ func (r *RequestView) LoadRequest(id, name, method, url string) {
 	r.currentRequestID = id
 	r.currentRequestName = name
 
-	// Set HTTP method
-	switch method {
-	case "GET":
-		r.method = api.GET
-	case "POST":
-		r.method = api.POST
-	case "PUT":
-		r.method = api.PUT
-	case "DELETE":
-		r.method = api.DELETE
-	case "PATCH":
-		r.method = api.PATCH
-	case "HEAD":
-		r.method = api.HEAD
-	case "OPTIONS":
-		r.method = api.OPTIONS
-	default:
+	// Set HTTP method; custom methods are kept as they are
+	r.method = api.HTTPMethod(strings.ToUpper(method))
+	if r.method == "" {
 		r.method = api.GET
 	}
 
@@ -2062,25 +2467,94 @@ func (r *RequestView) GetCurrentRequestID() string {
 	return r.currentRequestID
 }
 
+// GetCurrentRequestName returns the name of the currently loaded request
+func (r *RequestView) GetCurrentRequestName() string {
+	return r.currentRequestName
+}
+
 // SetURL sets the URL without clearing params or headers
 func (r *RequestView) SetURL(url string) {
 	r.url = url
 	r.ParseURLParams()
 }
 
+// SetGRPCMethod makes the request a call of the gRPC method at url. A blank body (or
+// the default "{}") is replaced by template. Returns true if the body was replaced.
+func (r *RequestView) SetGRPCMethod(url, template string) bool {
+	r.method = api.GRPC
+	r.SetURL(url)
+
+	body := strings.Join(strings.Fields(r.GetBodyContent()), "")
+	if body != "" && body != "{}" {
+		return false
+	}
+	r.bodyType = JSONBody
+	r.bodyEditor = components.NewEditor(template, "json")
+	return true
+}
+
 // GetHeadersTable returns the headers table for HTTP request building
 func (r *RequestView) GetHeadersTable() *components.Table {
 	return r.headersTable
 }
 
+// GetBodyType returns the type of the request body
+func (r *RequestView) GetBodyType() BodyType {
+	return r.bodyType
+}
+
+// SetBodyType changes the type of the body. Switching to form-data turns a JSON object
+// in the body editor into text fields; the editor content is kept for the other types.
+func (r *RequestView) SetBodyType(bodyType BodyType) {
+	if bodyType == FormDataBody && r.bodyType != FormDataBody && r.formTable.RowCount() == 0 {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(r.bodyEditor.GetContent()), &fields); err == nil {
+			r.loadFormFields(fields)
+		}
+	}
+	switch {
+	case bodyType == RawBody:
+		r.bodyEditor.SetSyntaxType(rawBodySyntax(r.bodyEditor.GetContent()))
+	case bodyType.IsJSONAuthored():
+		r.bodyEditor.SetSyntaxType("json")
+	}
+	r.bodyType = bodyType
+}
+
 // GetBodyContent returns the body content from the body editor
+// GraphQL bodies are returned as their stored JSON form ({"query", "variables"}),
+// binary bodies as the path of their file.
 func (r *RequestView) GetBodyContent() string {
-	if r.bodyType == NoneBody {
+	switch r.bodyType {
+	case NoneBody:
 		return ""
+	case FormDataBody:
+		return r.formDataContent()
+	case BinaryBody:
+		return r.binaryPath
+	case GraphQLBody:
+		gql := r.GetGraphQLBody()
+		if strings.TrimSpace(gql.Query) == "" && strings.TrimSpace(gql.Variables) == "" {
+			return ""
+		}
+		data, err := json.Marshal(gql.Content())
+		if err != nil {
+			return ""
+		}
+		return string(data)
 	}
 	return r.bodyEditor.GetContent()
 }
 
+// GetGraphQLBody returns the query and variables of a GraphQL body
+func (r *RequestView) GetGraphQLBody() api.GraphQLBody {
+	vars := r.graphqlVariablesEditor.GetContent()
+	if strings.TrimSpace(vars) == "{}" || strings.Join(strings.Fields(vars), "") == "{}" {
+		vars = ""
+	}
+	return api.GraphQLBody{Query: r.bodyEditor.GetContent(), Variables: vars}
+}
+
 // GetPreRequestScript returns the pre-request script content
 func (r *RequestView) GetPreRequestScript() string {
 	return r.preRequestEditor.GetContent()
@@ -2146,6 +2620,12 @@ func (r *RequestView) LoadCollectionRequest(req *api.CollectionRequest) {
 	// Store current request info for saving changes
 	r.currentRequestID = req.ID
 	r.currentRequestName = req.Name
+	r.edits = 0
+
+	// Drop a bulk edit or the body builder of the previous request
+	r.bulkEditor, r.bulkTab = nil, ""
+	r.SetBodySchema(req.BodySchema)
+	r.SetDescription(req.Description)
 
 	// Set HTTP method
 	r.method = req.Method
@@ -2186,6 +2666,8 @@ func (r *RequestView) LoadCollectionRequest(req *api.CollectionRequest) {
 	}
 
 	// Load body content
+	r.loadFormFields(nil)
+	r.binaryPath = ""
 	if req.Body != nil {
 		r.bodyType = JSONBody // Default to JSON
 		switch req.Body.Type {
@@ -2197,24 +2679,52 @@ func (r *RequestView) LoadCollectionRequest(req *api.CollectionRequest) {
 			r.bodyType = FormDataBody
 		case "binary":
 			r.bodyType = BinaryBody
+		case "msgpack":
+			r.bodyType = MsgpackBody
+		case "cbor":
+			r.bodyType = CBORBody
+		case api.BodyTypeGraphQL:
+			r.bodyType = GraphQLBody
 		case "none":
 			r.bodyType = NoneBody
 		}
 
-		// Convert body content to string for editor
-		var bodyContent string
-		switch content := req.Body.Content.(type) {
-		case string:
-			bodyContent = content
-		case map[string]interface{}, []interface{}:
-			// Re-encode JSON content
-			if jsonBytes, err := json.MarshalIndent(content, "", "  "); err == nil {
-				bodyContent = string(jsonBytes)
-			}
+		// Form-data bodies fill the form table, binary bodies name their file
+		if r.bodyType == FormDataBody {
+			r.loadFormFields(req.Body.Content)
+		} else if r.bodyType == BinaryBody {
+			r.binaryPath, _ = req.Body.Content.(string)
 		}
 
-		if bodyContent != "" {
-			r.bodyEditor = components.NewEditor(bodyContent, "json")
+		// GraphQL bodies fill the query and variables editors
+		r.graphqlSection = GraphQLQuerySection
+		r.graphqlVariablesEditor = components.NewEditor("{\n\n}", "json")
+		if r.bodyType == GraphQLBody {
+			gql := api.ParseGraphQLBody(req.Body.Content)
+			r.bodyEditor = components.NewEditor(gql.Query, "graphql")
+			if gql.Variables != "" {
+				r.graphqlVariablesEditor = components.NewEditor(gql.Variables, "json")
+			}
+		} else {
+			// Convert body content to string for editor
+			var bodyContent string
+			switch content := req.Body.Content.(type) {
+			case string:
+				bodyContent = content
+			case map[string]interface{}, []interface{}:
+				// Re-encode JSON content
+				if jsonBytes, err := json.MarshalIndent(content, "", "  "); err == nil {
+					bodyContent = string(jsonBytes)
+				}
+			}
+
+			if bodyContent != "" {
+				syntax := "json"
+				if r.bodyType == RawBody {
+					syntax = rawBodySyntax(bodyContent)
+				}
+				r.bodyEditor = components.NewEditor(bodyContent, syntax)
+			}
 		}
 	} else {
 		// No body - set empty editor
@@ -2257,6 +2767,14 @@ func (r *RequestView) LoadCollectionRequest(req *api.CollectionRequest) {
 
 	// Load auth configuration
 	r.loadAuthFromRequest(req)
+
+	// Load timeout and retry settings
+	r.loadSettingsFromRequest(req)
+
+	// Load extraction rules
+	r.captureTable.Rows = nil
+	r.captureTable.Cursor = 0
+	r.SetCaptureRules(req.Extract)
 }
 
 // loadAuthFromRequest loads authentication configuration from a CollectionRequest
@@ -2270,6 +2788,10 @@ func (r *RequestView) loadAuthFromRequest(req *api.CollectionRequest) {
 	r.authAPIKeyName = ""
 	r.authAPIKeyValue = ""
 	r.authAPIKeyLocation = "header"
+	r.authJWTAlgorithm = api.JWTAlgorithmHS256
+	r.authJWTKey = ""
+	r.authJWTClaims = ""
+	r.authJWTExpiresIn = ""
 	r.authField = AuthFieldType
 	r.authEditing = false
 
@@ -2298,60 +2820,57 @@ func (r *RequestView) loadAuthFromRequest(req *api.CollectionRequest) {
 		if auth.APIKeyLocation != "" {
 			r.authAPIKeyLocation = auth.APIKeyLocation
 		}
+	case "jwt":
+		r.authType = AuthJWT
+		if auth.Prefix != "" {
+			r.authPrefix = auth.Prefix
+		}
+		if auth.JWTAlgorithm != "" {
+			r.authJWTAlgorithm = strings.ToUpper(auth.JWTAlgorithm)
+		}
+		r.authJWTKey = auth.JWTKey
+		r.authJWTClaims = auth.JWTClaims
+		r.authJWTExpiresIn = auth.JWTExpiresIn
 	default:
 		r.authType = AuthNone
 	}
 }
 
+// requestTabNames are the session names of the request tabs, by tab index
+var requestTabNames = []string{"params", "auth", "headers", "body", "scripts", "settings", "capture", "docs"}
+
 // SetSessionState applies session state to the request panel
 func (r *RequestView) SetSessionState(state session.RequestPanelState) {
-	// Set active tab (order: Params=0, Authorization=1, Headers=2, Body=3, Scripts=4)
-	tabIndex := 0
-	switch state.ActiveTab {
-	case "params":
-		tabIndex = 0
-	case "auth":
-		tabIndex = 1
-	case "headers":
-		tabIndex = 2
-	case "body":
-		tabIndex = 3
-	case "scripts":
-		tabIndex = 4
-	}
-	r.tabs.SetActive(tabIndex)
-
 	// Restore URL cursor position
 	if state.URLCursor >= 0 {
 		r.urlCursor = state.URLCursor
 	}
+	r.SetPosition(state.ActiveTab, state.BodyCursor)
+}
 
-	// Restore body cursor position
-	if state.BodyCursor != nil && r.bodyEditor != nil {
-		r.bodyEditor.SetCursorPosition(state.BodyCursor.Line, state.BodyCursor.Column)
+// SetPosition shows a tab by its session name and moves the body cursor, when given
+func (r *RequestView) SetPosition(tab string, bodyCursor *session.CursorPosition) {
+	tabIndex := 0
+	for i, name := range requestTabNames {
+		if name == tab {
+			tabIndex = i
+		}
+	}
+	r.tabs.SetActive(tabIndex)
+
+	if bodyCursor != nil && r.bodyEditor != nil {
+		r.bodyEditor.SetCursorPosition(bodyCursor.Line, bodyCursor.Column)
 	}
 }
 
 // GetSessionState returns the current session state for the request panel
 func (r *RequestView) GetSessionState() session.RequestPanelState {
 	state := session.RequestPanelState{
+		ActiveTab: "params",
 		URLCursor: r.urlCursor,
 	}
-
-	// Get active tab name (order: Params=0, Authorization=1, Headers=2, Body=3, Scripts=4)
-	switch r.tabs.ActiveIndex {
-	case 0:
-		state.ActiveTab = "params"
-	case 1:
-		state.ActiveTab = "auth"
-	case 2:
-		state.ActiveTab = "headers"
-	case 3:
-		state.ActiveTab = "body"
-	case 4:
-		state.ActiveTab = "scripts"
-	default:
-		state.ActiveTab = "params"
+	if r.tabs.ActiveIndex >= 0 && r.tabs.ActiveIndex < len(requestTabNames) {
+		state.ActiveTab = requestTabNames[r.tabs.ActiveIndex]
 	}
 
 	// Get body cursor position
@@ -2368,7 +2887,7 @@ func (r *RequestView) GetSessionState() session.RequestPanelState {
 
 // JumpTo jumps to a specific element by its ID (tab name, field, etc.)
 func (r *RequestView) JumpTo(elementID string) {
-	// Handle tab navigation (indices: 0=Params, 1=Authorization, 2=Headers, 3=Body, 4=Scripts)
+	// Handle tab navigation (indices: 0=Params, 1=Authorization, 2=Headers, 3=Body, 4=Scripts, 5=Settings, 6=Capture, 7=Docs)
 	switch elementID {
 	case "tab-params":
 		r.tabs.SetActive(0)
@@ -2380,6 +2899,12 @@ func (r *RequestView) JumpTo(elementID string) {
 		r.tabs.SetActive(3)
 	case "tab-scripts":
 		r.tabs.SetActive(4)
+	case "tab-settings":
+		r.tabs.SetActive(5)
+	case "tab-capture":
+		r.tabs.SetActive(6)
+	case "tab-docs":
+		r.tabs.SetActive(7)
 	case "url":
 		r.editingURL = true
 	}
@@ -2391,8 +2916,8 @@ func (r *RequestView) GetJumpTargets(startRow, startCol int) []JumpTarget {
 	var targets []JumpTarget
 
 	// Tab targets - Row 1 is the tabs row (after panel header)
-	tabNames := []string{"tab-params", "tab-auth", "tab-headers", "tab-body", "tab-scripts"}
-	tabLabels := []string{"Params", "Authorization", "Headers", "Body", "Scripts"}
+	tabNames := []string{"tab-params", "tab-auth", "tab-headers", "tab-body", "tab-scripts", "tab-settings", "tab-capture", "tab-docs"}
+	tabLabels := []string{"Params", "Authorization", "Headers", "Body", "Scripts", "Settings", "Capture", "Docs"}
 	tabCol := startCol + 1 // Start after border
 
 	// Tab separator width: " | " = 3 characters between tabs
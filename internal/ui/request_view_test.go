@@ -0,0 +1,512 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestRequestView_GraphQLBody(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Name:   "User",
+		Method: api.POST,
+		URL:    "https://example.com/graphql",
+		Body: &api.BodyConfig{
+			Type: api.BodyTypeGraphQL,
+			Content: map[string]interface{}{
+				"query":     "query { user(id: $id) { name } }",
+				"variables": map[string]interface{}{"id": "42"},
+			},
+		},
+	})
+
+	if r.GetBodyType() != GraphQLBody {
+		t.Fatalf("body type = %v, want graphql", r.GetBodyType())
+	}
+	gql := r.GetGraphQLBody()
+	if gql.Query != "query { user(id: $id) { name } }" {
+		t.Errorf("query = %q", gql.Query)
+	}
+
+	var stored map[string]interface{}
+	if err := json.Unmarshal([]byte(r.GetBodyContent()), &stored); err != nil {
+		t.Fatalf("body content is not JSON: %v", err)
+	}
+	if vars, ok := stored["variables"].(map[string]interface{}); !ok || vars["id"] != "42" {
+		t.Errorf("variables = %v", stored["variables"])
+	}
+
+	// ] switches to the variables editor, [ back to the query
+	r.tabs.SetActive(3)
+	view := *r
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")}, nil)
+	if view.graphqlSection != GraphQLVariablesSection || view.activeBodyEditor() != view.graphqlVariablesEditor {
+		t.Error("] should select the variables editor")
+	}
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")}, nil)
+	if view.graphqlSection != GraphQLQuerySection || view.activeBodyEditor() != view.bodyEditor {
+		t.Error("[ should select the query editor")
+	}
+}
+
+func TestRequestView_GraphQLEmptyVariables(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.POST,
+		URL:    "https://example.com/graphql",
+		Body:   &api.BodyConfig{Type: api.BodyTypeGraphQL, Content: "{ ping }"},
+	})
+
+	gql := r.GetGraphQLBody()
+	if gql.Query != "{ ping }" || gql.Variables != "" {
+		t.Errorf("got %+v, want query only", gql)
+	}
+}
+
+func TestRequestView_SettingsTab(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:      "req_1",
+		Method:  api.GET,
+		URL:     "https://example.com",
+		Timeout: "5s",
+		Retry:   &api.RetryPolicy{Count: 2, OnStatus: []int{503}},
+	})
+
+	timeout, retry := r.GetSettings()
+	if timeout != "5s" || retry == nil || retry.Count != 2 || !retry.RetriesStatus(503) {
+		t.Fatalf("GetSettings() = %q, %+v", timeout, retry)
+	}
+
+	r.tabs.SetActive(5)
+	view := *r
+	keys := func(s string) {
+		for _, k := range s {
+			view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{k}}, nil)
+		}
+	}
+	enter := func() tea.Msg {
+		var cmd tea.Cmd
+		view, cmd = view.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	// Backoff cycles with l
+	keys("jjl")
+	if view.settingsRetry.Backoff != api.BackoffLinear {
+		t.Errorf("backoff = %q, want linear", view.settingsRetry.Backoff)
+	}
+
+	// Retry on: replace the status codes
+	keys("jj")
+	enter()
+	view.settingsBuffer = ""
+	keys("429, 502")
+	msg, ok := enter().(RequestSettingsChangedMsg)
+	if !ok || msg.Err != nil || msg.Retry == nil || api.FormatStatusCodes(msg.Retry.OnStatus) != "429, 502" {
+		t.Errorf("retry on change = %+v", msg)
+	}
+
+	// Invalid timeouts are reported and discarded
+	keys("kkkk")
+	enter()
+	view.settingsBuffer = ""
+	keys("soon")
+	msg, _ = enter().(RequestSettingsChangedMsg)
+	if msg.Err == nil || view.settingsTimeout != "5s" {
+		t.Errorf("invalid timeout: err = %v, timeout = %q", msg.Err, view.settingsTimeout)
+	}
+
+	// Zero retries remove the policy
+	keys("j")
+	enter()
+	view.settingsBuffer = ""
+	keys("0")
+	msg, _ = enter().(RequestSettingsChangedMsg)
+	if msg.Err != nil || msg.Retry != nil {
+		t.Errorf("zero retries = %+v, want no policy", msg)
+	}
+
+	// The TLS server name and Host header default to the URL host
+	keys("jjjj")
+	enter()
+	keys("{{sni}}")
+	msg, _ = enter().(RequestSettingsChangedMsg)
+	keys("j")
+	enter()
+	keys("api.example.com")
+	msg, _ = enter().(RequestSettingsChangedMsg)
+	if msg.Err != nil || msg.ServerName != "{{sni}}" || msg.Host != "api.example.com" {
+		t.Errorf("overrides = %+v", msg)
+	}
+	enter()
+	view.settingsBuffer = ""
+	keys("api.example.com/v1")
+	if msg, _ = enter().(RequestSettingsChangedMsg); msg.Err == nil || view.settingsHost != "api.example.com" {
+		t.Errorf("invalid host: err = %v, host = %q", msg.Err, view.settingsHost)
+	}
+}
+
+func TestRequestView_CaptureTab(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.POST,
+		URL:    "https://example.com/login",
+		Extract: []api.ExtractRule{
+			{Variable: "auth_token", Type: api.ExtractJSONPath, Expression: "$.token"},
+			{Variable: "order_id", Type: api.ExtractRegex, Header: "Location", Expression: `/orders/(\d+)`, Disabled: true},
+		},
+	})
+
+	r.tabs.SetActive(6)
+	if r.GetActiveTab() != "Capture" || r.getCurrentTable() != r.captureTable {
+		t.Fatalf("tab 7 = %q, want the Capture table", r.GetActiveTab())
+	}
+	if rows := r.captureTable.Rows; len(rows) != 2 || rows[0].Value != "$.token" || rows[1].Value != `regex@Location /orders/(\d+)` || rows[1].Enabled {
+		t.Fatalf("capture rows = %+v", rows)
+	}
+
+	// Rows added in the tab become rules, toggled rows disabled ones
+	r.AddRow("user_id", "$.user.id")
+	view, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}}, nil)
+	if msg, ok := cmd().(RequestParamToggleMsg); !ok || msg.Tab != "Capture" {
+		t.Errorf("toggle = %+v, want a Capture toggle", msg)
+	}
+	rules, err := view.GetCaptureRules()
+	if err != nil || len(rules) != 3 || !rules[0].Disabled || !rules[1].Disabled || rules[2].Variable != "user_id" || rules[2].Type != api.ExtractJSONPath {
+		t.Errorf("GetCaptureRules() = %+v, %v", rules, err)
+	}
+
+	view.AddRow("csrf", "css input[name=csrf]")
+	if _, err := view.GetCaptureRules(); err == nil || !strings.Contains(err.Error(), "capture csrf") {
+		t.Errorf("GetCaptureRules() error = %v, want an invalid csrf row", err)
+	}
+}
+
+func TestRequestView_FormDataBody(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.POST,
+		URL:    "https://example.com/upload",
+		Body: &api.BodyConfig{
+			Type: api.BodyTypeFormData,
+			Content: []interface{}{
+				map[string]interface{}{"key": "name", "value": "Ada", "enabled": true},
+				map[string]interface{}{"key": "avatar", "value": "~/a.png", "type": "file", "enabled": false},
+			},
+		},
+	})
+
+	if r.GetBodyType() != FormDataBody || r.formTable.RowCount() != 2 {
+		t.Fatalf("body type = %v with %d fields, want form-data with 2", r.GetBodyType(), r.formTable.RowCount())
+	}
+	if fields := api.ParseFormData(r.GetBodyContent()); len(fields) != 2 || !fields[1].IsFile() || fields[1].Enabled {
+		t.Errorf("body content = %s", r.GetBodyContent())
+	}
+
+	r.tabs.SetActive(3)
+	view := *r
+	press := func(key string) tea.Msg {
+		var cmd tea.Cmd
+		view, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}, nil)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	// o picks the file of the current field, N a file for a new field
+	view.formTable.Cursor = 1
+	if msg, ok := press("o").(RequestFilePickMsg); !ok || msg.Index != 1 || msg.Path != "~/a.png" {
+		t.Errorf("o = %+v", msg)
+	}
+	if msg, ok := press("N").(RequestFilePickMsg); !ok || msg.Index != -1 {
+		t.Errorf("N = %+v", msg)
+	}
+
+	// t switches the current field between text and file, and saves the form
+	msg, ok := press("t").(RequestBodyChangedMsg)
+	if !ok || msg.BodyType != api.BodyTypeFormData || view.formTable.Rows[1].File {
+		t.Errorf("t = %+v, file = %v", msg, view.formTable.Rows[1].File)
+	}
+
+	view.SetFormFile(-1, "/tmp/report.pdf")
+	last := view.formTable.Rows[view.formTable.RowCount()-1]
+	if last.Key != "report" || last.Value != "/tmp/report.pdf" || !last.File || !last.Enabled {
+		t.Errorf("new file field = %+v", last)
+	}
+}
+
+func TestRequestView_SetBodyTypeFormData(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.POST,
+		URL:    "https://example.com",
+		Body:   &api.BodyConfig{Type: "json", Content: map[string]interface{}{"name": "Ada", "age": 36}},
+	})
+
+	r.SetBodyType(FormDataBody)
+	fields := r.GetFormFields()
+	if len(fields) != 2 || fields[0].Key != "age" || fields[0].Value != "36" || fields[1].Value != "Ada" {
+		t.Errorf("fields = %+v", fields)
+	}
+}
+
+func TestRequestView_RawXMLBody(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.POST,
+		URL:    "https://example.com/soap",
+		Body:   &api.BodyConfig{Type: "raw", Content: `<Envelope><Body><GetUser id="{{id}}"/></Body></Envelope>`},
+	})
+
+	if !r.GetBodyType().HasEditor() || r.bodyEditor.GetSyntaxType() != "xml" {
+		t.Fatalf("raw XML body: editor = %v, syntax = %q", r.GetBodyType().HasEditor(), r.bodyEditor.GetSyntaxType())
+	}
+
+	r.tabs.SetActive(3)
+	view := *r
+	view, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")}, nil)
+	if cmd == nil {
+		t.Fatal("F should format the XML body")
+	}
+	want := "<Envelope>\n  <Body>\n    <GetUser id=\"{{id}}\"/>\n  </Body>\n</Envelope>"
+	if view.GetBodyContent() != want {
+		t.Errorf("formatted body = %q, want %q", view.GetBodyContent(), want)
+	}
+}
+
+func TestRequestView_BinaryBody(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Method: api.PUT,
+		URL:    "https://example.com/upload",
+		Body:   &api.BodyConfig{Type: api.BodyTypeBinary, Content: "{{dir}}/photo.png"},
+	})
+
+	if r.GetBodyType() != BinaryBody || r.GetBodyContent() != "{{dir}}/photo.png" {
+		t.Fatalf("body = %v %q, want binary file", r.GetBodyType(), r.GetBodyContent())
+	}
+
+	r.tabs.SetActive(3)
+	view := *r
+	press := func(key string) tea.Msg {
+		var cmd tea.Cmd
+		view, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}, nil)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+	if msg, ok := press("o").(RequestBinaryPickMsg); !ok || msg.Path != "{{dir}}/photo.png" {
+		t.Errorf("o = %#v", msg)
+	}
+	if msg, ok := press("c").(RequestBinaryEditMsg); !ok || msg.Path != "{{dir}}/photo.png" {
+		t.Errorf("c = %#v", msg)
+	}
+
+	// Another request without body forgets the file
+	r.LoadCollectionRequest(&api.CollectionRequest{ID: "req_2", Method: api.GET, URL: "https://example.com"})
+	if r.GetBinaryPath() != "" {
+		t.Errorf("binary path = %q after loading another request", r.GetBinaryPath())
+	}
+}
+
+func TestRequestView_BulkEdit(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:     "req_1",
+		Name:   "Users",
+		Method: api.GET,
+		URL:    "https://example.com/users",
+		Headers: []api.KeyValueEntry{
+			{Key: "Accept", Value: "*/*", Enabled: true},
+			{Key: "X-Debug", Value: "1", Enabled: false},
+		},
+	})
+	r.tabs.SetActive(2) // Headers
+
+	view := *r
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}, nil)
+	if !view.IsBulkEditing() || !view.IsEditorActive() {
+		t.Fatal("B should bulk edit the headers")
+	}
+	if got, want := view.bulkEditor.GetContent(), "Accept: */*\n// X-Debug: 1"; got != want {
+		t.Errorf("bulk text = %q, want %q", got, want)
+	}
+
+	view.bulkEditor.SetContent("Accept: application/json\n\n  //X-Debug:1\nAuthorization: Bearer a:b\nX-Empty\n: no key")
+	view, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}, nil)
+	if view.IsBulkEditing() {
+		t.Fatal("B in NORMAL mode should apply the bulk edit")
+	}
+	if msg, ok := cmd().(RequestBulkEditedMsg); !ok || msg.Tab != "Headers" || msg.Rows != 4 {
+		t.Errorf("cmd = %#v, want RequestBulkEditedMsg for 4 headers", msg)
+	}
+	want := []struct {
+		key, value string
+		enabled    bool
+	}{
+		{"Accept", "application/json", true},
+		{"X-Debug", "1", false},
+		{"Authorization", "Bearer a:b", true},
+		{"X-Empty", "", true},
+	}
+	rows := view.headersTable.Rows
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v", rows)
+	}
+	for i, w := range want {
+		if rows[i].Key != w.key || rows[i].Value != w.value || rows[i].Enabled != w.enabled {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], w)
+		}
+	}
+
+	// Switching tabs applies the text; the Params tab edits the query params
+	view.tabs.SetActive(0)
+	view.paramsSection = PathParamsSection
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}, nil)
+	if !view.IsBulkEditing() || view.paramsSection != QueryParamsSection {
+		t.Fatal("B in the Params tab should bulk edit the query params")
+	}
+	view.bulkEditor.SetContent("page: 2")
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyTab}, nil)
+	if view.IsBulkEditing() || view.GetActiveTab() != "Authorization" {
+		t.Errorf("tab should apply the bulk edit and switch tabs, tab = %s", view.GetActiveTab())
+	}
+	if !strings.HasSuffix(view.BuildURLFromParams(), "?page=2") {
+		t.Errorf("URL = %s, want the page query param", view.BuildURLFromParams())
+	}
+}
+
+func TestRequestView_Docs(t *testing.T) {
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID:          "req_1",
+		Name:        "Users",
+		Method:      api.GET,
+		URL:         "https://example.com/users",
+		Description: "# Users\n\nLists the **active** users.",
+	})
+	view := *r
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("8")}, nil)
+	if view.GetActiveTab() != "Docs" {
+		t.Fatalf("8 should show the Docs tab, tab = %s", view.GetActiveTab())
+	}
+	if rendered := PlainSnapshot(view.renderDocsTab(60, 10, true)); !strings.Contains(rendered, "Lists the active users.") || strings.Contains(rendered, "**") {
+		t.Errorf("Docs tab should render the Markdown:\n%s", rendered)
+	}
+
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+	if !view.IsDocsEditing() || !view.IsEditorActive() {
+		t.Fatal("Enter should edit the docs")
+	}
+	view.docsEditor.SetContent("Lists users.")
+	view, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEsc}, nil)
+	if view.IsDocsEditing() {
+		t.Fatal("Esc in NORMAL mode should show the rendered docs")
+	}
+	if msg, ok := cmd().(RequestDescriptionChangedMsg); !ok || msg.Description != "Lists users." {
+		t.Errorf("cmd = %#v, want RequestDescriptionChangedMsg", msg)
+	}
+
+	// Unchanged docs are not saved again
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+	if _, cmd = view.Update(tea.KeyMsg{Type: tea.KeyEsc}, nil); cmd != nil {
+		t.Error("leaving unchanged docs should not save them")
+	}
+}
+
+func TestRequestView_BodyBuilder(t *testing.T) {
+	schema, err := api.ParseJSONSchema([]byte(`{
+		"type": "object",
+		"required": ["name", "status"],
+		"properties": {
+			"name": {"type": "string"},
+			"status": {"enum": ["available", "sold"]},
+			"price": {"type": "number"},
+			"owner": {"type": "object", "properties": {"id": {"type": "integer"}, "vip": {"type": "boolean"}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRequestView()
+	r.LoadCollectionRequest(&api.CollectionRequest{
+		ID: "req_1", Name: "Create pet", Method: api.POST, URL: "https://example.com/pets",
+		Body:       &api.BodyConfig{Type: "json", Content: map[string]interface{}{"name": "Rex"}},
+		BodySchema: schema,
+	})
+	r.tabs.SetActive(3) // Body
+
+	view := *r
+	press := func(keys ...string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			switch key {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "backspace":
+				msg = tea.KeyMsg{Type: tea.KeyBackspace}
+			}
+			view, cmd = view.Update(msg, nil)
+		}
+		return cmd
+	}
+	press("B")
+	if !view.IsBodyBuilding() {
+		t.Fatal("B should open the body builder")
+	}
+	// Rows: name*, status*, owner, id, vip, price
+	if snapshot := PlainSnapshot(view.View(100, 20, true)); !strings.Contains(snapshot, "Rex") || !strings.Contains(snapshot, "available|sold") {
+		t.Errorf("the builder should show the body values and enum options:\n%s", snapshot)
+	}
+
+	press("j", "l", "j", "j", "enter", "4", "2", "enter", "j", "l", "j", "enter", "x", "enter")
+	if view.bodyBuilder.err == "" || !view.IsBodyBuilderEditing() {
+		t.Fatal("a value that is not a number should be refused")
+	}
+	press("backspace", "9", ".", "5", "enter")
+
+	cmd := press("B")
+	if view.IsBodyBuilding() {
+		t.Fatal("B should build the body")
+	}
+	msg, ok := cmd().(RequestBodyBuiltMsg)
+	if !ok || msg.Fields != 5 {
+		t.Fatalf("cmd = %#v, want RequestBodyBuiltMsg for 5 fields", msg)
+	}
+	want := `{
+  "name": "Rex",
+  "status": "available",
+  "owner": {
+    "id": 42,
+    "vip": false
+  },
+  "price": 9.5
+}`
+	if msg.Content != want || view.GetBodyContent() != want {
+		t.Errorf("body = %s, want %s", msg.Content, want)
+	}
+
+	// Without a schema, B says how to attach one
+	view.SetBodySchema(nil)
+	if msg, ok := press("B")().(RequestBodyBuiltMsg); !ok || msg.Err == nil || view.IsBodyBuilding() {
+		t.Errorf("B without a schema should fail, got %#v", msg)
+	}
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// resizeSettleDelay is how long the terminal size must stay the same after a resize
+// before the screen is cleared and drawn again
+const resizeSettleDelay = 150 * time.Millisecond
+
+// Below the minimum terminal size, the active panel is shown alone down to this size
+const (
+	CompactMinWidth  = 30
+	CompactMinHeight = 8
+)
+
+// ResizeSettledMsg is sent resizeSettleDelay after a resize; Seq tells whether another
+// resize came meanwhile
+type ResizeSettledMsg struct {
+	Seq int
+}
+
+// handleResize lays out the panels for the new terminal size. A burst of resizes, such
+// as dragging a tmux pane border, ends with a full redraw once the size settles: the
+// terminal may have reflowed the intermediate frames into garbage the renderer does
+// not know about.
+func (m Model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
+	changed := msg.Width != m.width || msg.Height != m.height
+	m.width = msg.Width
+	m.height = msg.Height
+	m.ready = true
+	// Update layout mode based on terminal size
+	m.layoutMode = m.detectLayoutMode()
+	if m.importModal.IsVisible() {
+		m.importModal.SetSize(msg.Width, msg.Height)
+	}
+	if m.openAPIImportModal.IsVisible() {
+		m.openAPIImportModal.SetSize(msg.Width, msg.Height)
+	}
+	if !changed {
+		return m, nil
+	}
+
+	m.resizeSeq++
+	seq := m.resizeSeq
+	return m, tea.Tick(resizeSettleDelay, func(time.Time) tea.Msg {
+		return ResizeSettledMsg{Seq: seq}
+	})
+}
+
+// redraw clears the screen and draws it again, querying the terminal size in case a
+// resize was missed, as when attaching to a tmux or screen session from another terminal
+func (m Model) redraw() (tea.Model, tea.Cmd) {
+	return m, tea.Batch(tea.ClearScreen, tea.WindowSize())
+}
+
+// isCompact returns true when the terminal is below the minimum size, where only the
+// active panel is shown
+func (m Model) isCompact() bool {
+	return m.width < MinTerminalWidth || m.height < MinTerminalHeight
+}
+
+// renderTooSmall renders the notice shown when the terminal cannot even hold the
+// active panel, cut to fit
+func (m Model) renderTooSmall() string {
+	warningStyle := lipgloss.NewStyle().
+		Foreground(styles.Yellow).
+		Bold(true)
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("%dx%d, needs %dx%d", m.width, m.height, CompactMinWidth, CompactMinHeight),
+	}
+	for i, line := range lines {
+		lines[i] = truncateHistoryValue(line, m.width)
+	}
+	return warningStyle.Render(strings.Join(lines[:min(len(lines), max(m.height, 1))], "\n"))
+}
+
+// renderCompactBar renders the bottom line shown instead of the status bar below the
+// minimum terminal size
+func (m Model) renderCompactBar() string {
+	barStyle := lipgloss.NewStyle().
+		Foreground(styles.Yellow).
+		Width(m.width)
+	text := fmt.Sprintf(" %s · %dx%d · %dx%d shows all panels", m.mode, m.width, m.height, MinTerminalWidth, MinTerminalHeight)
+	return barStyle.Render(truncateHistoryValue(text, m.width))
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_Resize(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	var model tea.Model = NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+
+	resize := func(width, height int) tea.Cmd {
+		t.Helper()
+		var cmd tea.Cmd
+		model, cmd = model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+		return cmd
+	}
+
+	first := resize(120, 40)
+	resize(100, 30)
+	last := resize(90, 28)
+	if _, cmd := model.Update(first()); cmd != nil {
+		t.Error("a resize followed by others should not redraw")
+	}
+	if _, cmd := model.Update(last()); cmd == nil {
+		t.Error("the last resize should redraw once settled")
+	}
+	if cmd := resize(90, 28); cmd != nil {
+		t.Error("the same size should not redraw")
+	}
+
+	// Resizes under a modal are not lost
+	m := model.(Model)
+	m.importModal.Show()
+	model = m
+	resize(110, 35)
+	if m := model.(Model); m.width != 110 || m.height != 35 {
+		t.Errorf("size under the import modal = %dx%d, want 110x35", m.width, m.height)
+	}
+	m = model.(Model)
+	m.importModal.Hide()
+	model = m
+
+	// Below the minimum size, the active panel is shown alone
+	resize(60, 16)
+	view := model.View()
+	if !strings.Contains(view, "Collections") || !strings.Contains(view, "80x24 shows all panels") {
+		t.Errorf("compact view should show the collections panel and a notice:\n%s", view)
+	}
+	if strings.Contains(view, "Response") {
+		t.Errorf("compact view should only show the active panel:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 16 {
+		t.Errorf("compact view has %d lines, want at most 16", lines)
+	}
+
+	resize(20, 5)
+	if view := model.View(); !strings.Contains(view, "Terminal too small") {
+		t.Errorf("tiny terminal should show a notice:\n%s", view)
+	}
+}
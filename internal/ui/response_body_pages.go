@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// lazyFormatBodySize is the largest body pretty-printed when received.
+// Larger bodies are shown as received until F pretty-prints them.
+const lazyFormatBodySize = 256 * 1024
+
+// SetSpooledBody sets the temporary file holding the whole body when it was too
+// large to be kept in memory. The body set by SetResponse is then only its
+// beginning: the Body tab shows the file one page at a time.
+func (r *ResponseView) SetSpooledBody(file string, size int64) {
+	r.bodyFile = file
+	r.bodySize = size
+	if file == "" || r.isBinary || r.decodedFrom != "" {
+		return
+	}
+
+	// Nothing can be parsed from the beginning of a document
+	r.jsonBody = nil
+	r.xmlBody = nil
+	r.htmlPage = nil
+	r.formatPending = false
+	r.formattedBody = ""
+	r.bodyRaw = false
+	r.tableAvailable = false
+	r.isCSV = false
+	r.bodyTable.SetData(nil, nil)
+	r.loadBodyPage(0)
+}
+
+// GetBodyFile returns the temporary file holding a spooled body, "" when the body
+// is held in memory
+func (r *ResponseView) GetBodyFile() string {
+	return r.bodyFile
+}
+
+// isPaged returns true if the body is too large to be shown at once
+func (r *ResponseView) isPaged() bool {
+	return !r.isBinary && r.decodedFrom == "" && (r.bodyOffset > 0 || r.bodyPageEnd < r.bodySize)
+}
+
+// loadBodyPage shows the page of the body starting at offset: up to
+// api.MaxDisplayBodySize bytes, ending after a complete line when it can.
+// It returns the page.
+func (r *ResponseView) loadBodyPage(offset int64) []byte {
+	data, err := api.ReadBodyRange(r.body, r.bodyFile, offset, api.MaxDisplayBodySize+1)
+	if err != nil {
+		r.bodyEditor.SetContent(err.Error())
+		return nil
+	}
+	if len(data) > api.MaxDisplayBodySize {
+		data, _ = api.TruncateText(data, api.MaxDisplayBodySize)
+		if i := bytes.LastIndexByte(data, '\n'); i > 0 {
+			data = data[:i+1]
+		}
+	}
+	r.bodyOffset = offset
+	r.bodyPageEnd = offset + int64(len(data))
+	r.bodyEditor.SetContent(string(data))
+	return data
+}
+
+// updateBodyPages handles the keys paging through a large body: ] shows the next
+// page and [ the previous one. It returns false for other keys.
+func (r *ResponseView) updateBodyPages(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "]":
+		if r.bodyPageEnd < r.bodySize {
+			r.bodyPageStarts = append(r.bodyPageStarts, r.bodyOffset)
+			r.loadBodyPage(r.bodyPageEnd)
+		}
+		return true
+	case "[":
+		if n := len(r.bodyPageStarts); n > 0 {
+			offset := r.bodyPageStarts[n-1]
+			r.bodyPageStarts = r.bodyPageStarts[:n-1]
+			r.loadBodyPage(offset)
+		}
+		return true
+	}
+	return false
+}
+
+// formatBody pretty-prints a complete body by its syntax, keeping the result for the
+// raw toggle (r). Bodies that cannot be parsed are shown as received.
+func (r *ResponseView) formatBody() {
+	r.formatPending = false
+	r.bodyRaw = false
+	formatted := ""
+	switch r.bodyEditor.GetSyntaxType() {
+	case "xml":
+		f, err := format.FormatXML(r.body, "  ")
+		if err != nil {
+			return
+		}
+		formatted = f
+		r.queryEditor.SetSyntaxType("text")
+		r.xmlBody = r.body
+	case "html":
+		formatted = format.FormatHTML(r.body, "  ")
+	case "yaml":
+		f, err := format.FormatYAML(r.body, 2)
+		if err != nil {
+			return
+		}
+		formatted = f
+	default:
+		if !json.Valid(r.body) {
+			return
+		}
+		r.bodyEditor.SetContent(string(r.body))
+		r.bodyEditor.FormatJSON()
+		formatted = r.bodyEditor.GetContent()
+	}
+	r.bodyEditor.SetContent(formatted)
+	r.formattedBody = formatted
+}
+
+// toggleRawBody switches a pretty-printed body between its formatted view and the
+// body as received, keeping its highlighting
+func (r *ResponseView) toggleRawBody() {
+	r.bodyRaw = !r.bodyRaw
+	if r.bodyRaw {
+		r.bodyEditor.SetContent(string(r.body))
+	} else {
+		r.bodyEditor.SetContent(r.formattedBody)
+	}
+}
+
+// bodyNotice returns the line shown above the body: the poll state, then for large
+// bodies the part of the body shown, or whether the body waits to be pretty-printed
+func (r *ResponseView) bodyNotice() string {
+	notice := r.bodyViewNotice()
+	if r.pollNotice == "" {
+		return notice
+	}
+	poll := lipgloss.NewStyle().Foreground(styles.Yellow).Render(r.pollNotice)
+	if notice == "" {
+		return poll + lipgloss.NewStyle().Foreground(styles.Subtext0).Render(" · :poll off to stop")
+	}
+	return poll + " · " + notice
+}
+
+// bodyViewNotice returns the part of a large body shown, or how the body is formatted
+func (r *ResponseView) bodyViewNotice() string {
+	noticeStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	if r.isPaged() {
+		notice := noticeStyle.Render(fmt.Sprintf("Showing %s–%s of %s",
+			api.FormatSize(r.bodyOffset), api.FormatSize(r.bodyPageEnd), api.FormatSize(r.bodySize)))
+		return notice + hintStyle.Render(" · ]: next page · [: previous page")
+	}
+	if r.formatPending {
+		return noticeStyle.Render(api.FormatSize(r.bodySize)+" shown as received") + hintStyle.Render(" · F: pretty-print")
+	}
+	if r.bodyRaw {
+		return noticeStyle.Render("Raw body") + hintStyle.Render(" · r: formatted")
+	}
+	return ""
+}
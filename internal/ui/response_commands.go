@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+)
+
+// RunDoctorCmd diagnoses connectivity to the host of rawURL in the background.
+func RunDoctorCmd(rawURL string) tea.Cmd {
+	return func() tea.Msg {
+		return DoctorReportMsg{Report: api.RunDoctor(rawURL, api.DoctorOptions{})}
+	}
+}
+
+// ExportTableToCSV writes response table data to a CSV file.
+func ExportTableToCSV(table *format.TableData, outputPath string) tea.Cmd {
+	return func() tea.Msg {
+		if table == nil {
+			return ResponseCSVExportedMsg{Error: fmt.Errorf("no tabular response to export")}
+		}
+
+		data, err := table.ToCSV()
+		if err != nil {
+			return ResponseCSVExportedMsg{Error: fmt.Errorf("failed to encode CSV: %w", err)}
+		}
+
+		if dir := filepath.Dir(outputPath); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return ResponseCSVExportedMsg{Error: fmt.Errorf("failed to create directory: %w", err)}
+			}
+		}
+
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
+			return ResponseCSVExportedMsg{Error: fmt.Errorf("failed to write CSV file: %w", err)}
+		}
+
+		return ResponseCSVExportedMsg{FilePath: outputPath, Rows: len(table.Rows)}
+	}
+}
+
+// SaveResponseBodyCmd streams a response body unchanged to a file, so binary bodies
+// are saved byte for byte. bodyFile holds the body when it was spooled to disk.
+func SaveResponseBodyCmd(body []byte, bodyFile, outputPath string) tea.Cmd {
+	return func() tea.Msg {
+		source, err := api.OpenBodySource(body, bodyFile)
+		if err != nil {
+			return ResponseSavedToFileMsg{FilePath: outputPath, Error: err}
+		}
+		defer source.Close()
+		written, err := api.SaveResponseBody(outputPath, source)
+		return ResponseSavedToFileMsg{FilePath: outputPath, Bytes: written, Error: err}
+	}
+}
+
+// CompareFixture diffs a response body against a fixture file.
+// path is the fixture as given to :compare and filePath where it resolves.
+func CompareFixture(path, filePath string, body []byte) tea.Cmd {
+	return func() tea.Msg {
+		expected, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			return ResponseFixtureComparedMsg{Path: path, Missing: true}
+		}
+		if err != nil {
+			return ResponseFixtureComparedMsg{Path: path, Error: fmt.Errorf("failed to read fixture: %w", err)}
+		}
+		return ResponseFixtureComparedMsg{Path: path, Diff: format.DiffBodies(expected, body)}
+	}
+}
+
+// WriteFixture writes a response body to a fixture file, pretty-printing JSON.
+// path is the fixture as given to :compare and filePath where it resolves.
+func WriteFixture(path, filePath string, body []byte) tea.Cmd {
+	return func() tea.Msg {
+		data := body
+		if pretty, err := format.FormatJSON(body, "  "); err == nil && pretty != "" {
+			data = []byte(pretty + "\n")
+		}
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return ResponseFixtureSavedMsg{Path: path, Error: fmt.Errorf("failed to create directory: %w", err)}
+		}
+		if err := os.WriteFile(filePath, data, 0644); err != nil {
+			return ResponseFixtureSavedMsg{Path: path, Error: fmt.Errorf("failed to write fixture: %w", err)}
+		}
+		return ResponseFixtureSavedMsg{Path: path}
+	}
+}
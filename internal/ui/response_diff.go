@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+)
+
+// handleDiffCommand diffs the response shown with the baseline of its request, or with
+// the response before it in the console history; :diff baseline saves the response as
+// the baseline and :diff clear removes it
+func (m Model) handleDiffCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.responsePanel.GetRequestID()
+	if m.responsePanel.GetStatusCode() == 0 {
+		m.statusBar.Info("No response to diff")
+		return m, nil
+	}
+	path := api.BaselinePath(m.workspacePath, requestID)
+
+	switch {
+	case len(args) == 1 && strings.ToLower(args[0]) == DiffBaseline:
+		if requestID == "" {
+			m.statusBar.Info("Only responses of saved requests have a baseline")
+			return m, nil
+		}
+		if err := api.SaveBaseline(path, m.responsePanel.CurrentResponse(), time.Now()); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Saved", "response as the baseline")
+		return m, nil
+
+	case len(args) == 1 && strings.ToLower(args[0]) == DiffClear:
+		if requestID == "" {
+			m.statusBar.Info("No baseline to remove")
+			return m, nil
+		}
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				m.statusBar.Info("No baseline to remove")
+			} else {
+				m.statusBar.Error(err)
+			}
+			return m, nil
+		}
+		m.statusBar.Success("Removed", "baseline")
+		return m, nil
+
+	case len(args) > 0:
+		m.statusBar.Info("Usage: :diff [baseline | clear]")
+		return m, nil
+	}
+
+	current := m.responsePanel.CurrentResponse()
+	if requestID != "" {
+		baseline, err := api.LoadBaseline(path)
+		switch {
+		case err == nil:
+			saved := "baseline " + baseline.Saved.Local().Format("2006-01-02 15:04")
+			return m.showResponseDiff(saved, "response", api.DiffResponses(baseline.Response(), current))
+		case !errors.Is(err, os.ErrNotExist):
+			m.statusBar.Error(err)
+			return m, nil
+		}
+	}
+
+	// Without a baseline, the response before the latest one of the request
+	var previous *api.ConsoleEntry
+	latest := true
+	for _, entry := range m.consoleHistory.GetReversed() {
+		if requestID == "" || entry.Source == nil || entry.Source.ID != requestID || entry.Response == nil {
+			continue
+		}
+		if latest {
+			latest = false
+			continue
+		}
+		previous = &entry
+		break
+	}
+	if previous == nil {
+		m.statusBar.Info("No baseline or earlier response to diff with (:diff baseline saves one)")
+		return m, nil
+	}
+	return m.showResponseDiff(consoleEntryLabel(previous), "response", api.DiffResponses(previous.Response, current))
+}
+
+// diffConsoleEntries diffs the responses of two console entries marked in the Console tab
+func (m Model) diffConsoleEntries(msg ConsoleDiffMsg) (tea.Model, tea.Cmd) {
+	base, okBase := m.consoleHistory.Get(msg.BaseID)
+	other, okOther := m.consoleHistory.Get(msg.OtherID)
+	if !okBase || !okOther || base.Response == nil || other.Response == nil {
+		m.statusBar.Info("The entries to diff are no longer in the console")
+		return m, nil
+	}
+	return m.showResponseDiff(consoleEntryLabel(base), consoleEntryLabel(other), api.DiffResponses(base.Response, other.Response))
+}
+
+// showResponseDiff shows a diff of two responses over the Body tab
+func (m Model) showResponseDiff(base, other string, diff *format.BodyDiff) (tea.Model, tea.Cmd) {
+	m.responsePanel.SetResponseDiff(base, other, diff)
+	m.activePanel = ResponsePanel
+	if diff.Equal() {
+		m.statusBar.Success("Diffed", "responses match")
+	} else {
+		m.statusBar.Info(fmt.Sprintf("%d differences with %s", len(diff.Changes), base))
+	}
+	return m, nil
+}
+
+// consoleEntryLabel describes a console entry in a diff: its request and time
+func consoleEntryLabel(entry *api.ConsoleEntry) string {
+	name := ""
+	switch {
+	case entry.Source != nil && entry.Source.Name != "":
+		name = entry.Source.Name
+	case entry.Request != nil:
+		name = string(entry.Request.Method) + " " + entry.Request.URL
+	}
+	return strings.TrimSpace(name + " " + entry.FormatTimestamp())
+}
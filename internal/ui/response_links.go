@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// maxFollowUpNameLength is the longest name given to a request created from a link or form
+const maxFollowUpNameLength = 60
+
+// HasLinks returns true if the body is an HTML page with links or forms
+func (r *ResponseView) HasLinks() bool {
+	return r.htmlPage != nil
+}
+
+// IsLinksView returns true if the Body tab lists the links and forms beside the body
+func (r *ResponseView) IsLinksView() bool {
+	return r.htmlPage != nil && r.linksView
+}
+
+// linksCount returns the number of entries of the links view: links, then forms
+func (r *ResponseView) linksCount() int {
+	if r.htmlPage == nil {
+		return 0
+	}
+	return len(r.htmlPage.Links) + len(r.htmlPage.Forms)
+}
+
+// updateLinks handles keys while the links view is open: j/k select a link or form,
+// enter follows it, y copies its URL, esc or o closes the view
+func (r ResponseView) updateLinks(msg tea.KeyMsg) (ResponseView, tea.Cmd) {
+	page := r.htmlPage
+	count := r.linksCount()
+	switch msg.String() {
+	case "j", "down":
+		if r.linksCursor < count-1 {
+			r.linksCursor++
+		}
+	case "k", "up":
+		if r.linksCursor > 0 {
+			r.linksCursor--
+		}
+	case "g":
+		r.linksCursor = 0
+	case "G":
+		r.linksCursor = max(count-1, 0)
+	case "esc", "o":
+		r.linksView = false
+	case "enter":
+		if r.linksCursor < len(page.Links) {
+			link := page.Links[r.linksCursor]
+			return r, func() tea.Msg {
+				return ResponseFollowLinkMsg{Page: page, Link: link}
+			}
+		}
+		form := page.Forms[r.linksCursor-len(page.Links)]
+		return r, func() tea.Msg {
+			return ResponseSubmitFormMsg{Page: page, Form: form}
+		}
+	case "y", "Y":
+		ref := ""
+		if r.linksCursor < len(page.Links) {
+			ref = page.Links[r.linksCursor].Href
+		} else {
+			ref = page.Forms[r.linksCursor-len(page.Links)].Action
+		}
+		return r, func() tea.Msg {
+			return CopyToClipboardMsg{
+				Content: ref,
+				Label:   "Link",
+			}
+		}
+	}
+	return r, nil
+}
+
+// renderBodyWithLinks renders the body with the list of its links and forms on the right
+func (r *ResponseView) renderBodyWithLinks(width, height int) string {
+	sideWidth := min(max(width*2/5, 24), width/2)
+	body := r.bodyEditor.View(width-sideWidth-1, height, true)
+	side := lipgloss.NewStyle().
+		Width(sideWidth).
+		Height(height).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(styles.Surface1).
+		PaddingLeft(1).
+		Render(r.renderLinks(sideWidth-2, height))
+	return lipgloss.JoinHorizontal(lipgloss.Top, body, side)
+}
+
+// renderLinks renders the links then the forms of the page, one per line
+func (r *ResponseView) renderLinks(width, height int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	methodStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	hrefStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface1)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	page := r.htmlPage
+	var lines []string
+	selectedLine := 0
+	addSection := func(title string, count int) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("%s (%d)", title, count)))
+	}
+
+	addSection("Links", len(page.Links))
+	for i, link := range page.Links {
+		text := link.Text
+		if text == "" {
+			text = link.Href
+		}
+		line := truncateURL(text, width)
+		if avail := width - lipgloss.Width(line) - 1; link.Text != "" && avail > 3 {
+			line += " " + hrefStyle.Render(truncateURL(link.Href, avail))
+		}
+		if i == r.linksCursor {
+			line = selectedStyle.Render(line)
+			selectedLine = len(lines)
+		}
+		lines = append(lines, line)
+	}
+
+	addSection("Forms", len(page.Forms))
+	for i, form := range page.Forms {
+		action := form.Action
+		if action == "" {
+			action = "(this page)"
+		}
+		line := methodStyle.Render(form.Method) + " " + truncateURL(action, max(width-len(form.Method)-1, 4))
+		if fields := fmt.Sprintf(" %d fields", len(form.Fields)); lipgloss.Width(line+fields) <= width {
+			line += hrefStyle.Render(fields)
+		}
+		if len(page.Links)+i == r.linksCursor {
+			line = selectedStyle.Render(line)
+			selectedLine = len(lines)
+		}
+		lines = append(lines, line)
+	}
+
+	// The hint takes 2 lines; keep the selected entry visible
+	rows := max(height-2, 1)
+	start := max(selectedLine-rows+1, 0)
+	end := min(start+rows, len(lines))
+	return strings.Join(lines[start:end], "\n") + "\n\n" + hintStyle.Render("enter: new request · y: copy · o: close")
+}
+
+// newFollowUpRequest returns a request created from a response of source, the request
+// the page answered. It keeps the headers but Content-Type and the auth of source, or
+// gets the default headers without a source.
+func newFollowUpRequest(name string, method api.HTTPMethod, target string, source *api.CollectionRequest) *api.CollectionRequest {
+	req := &api.CollectionRequest{
+		ID:     api.GenerateID(),
+		Name:   name,
+		Method: method,
+		URL:    target,
+	}
+	if source == nil {
+		req.Headers = []api.KeyValueEntry{
+			{Key: "Accept", Value: "*/*", Enabled: true},
+			{Key: "User-Agent", Value: "LazyCurl/1.0", Enabled: true},
+		}
+		return req
+	}
+	for _, header := range source.Headers {
+		if !strings.EqualFold(header.Key, "Content-Type") {
+			req.Headers = append(req.Headers, header)
+		}
+	}
+	if source.Auth != nil {
+		auth := *source.Auth
+		req.Auth = &auth
+	}
+	return req
+}
+
+// followUpName returns the name of a request created from a link or form: its text,
+// or the path of its URL
+func followUpName(text, target string) string {
+	name := strings.TrimSpace(text)
+	if name == "" {
+		if u, err := url.Parse(target); err == nil && u.Path != "" {
+			name = u.Path
+		} else {
+			name = target
+		}
+	}
+	if runes := []rune(name); len(runes) > maxFollowUpNameLength {
+		name = string(runes[:maxFollowUpNameLength-1]) + "…"
+	}
+	return name
+}
+
+// linkRequest returns a GET request for a link of the page loaded from pageURL
+func linkRequest(page *format.HTMLPage, pageURL string, link format.HTMLLink, source *api.CollectionRequest) *api.CollectionRequest {
+	target := page.ResolveURL(pageURL, link.Href)
+	return newFollowUpRequest(followUpName(link.Text, target), api.GET, target, source)
+}
+
+// formRequest returns a request submitting a form of the page loaded from pageURL with
+// its initial values. GET forms send the fields in the query string; other forms are
+// POSTed as a form-data body (multipart forms) or an url-encoded raw body.
+func formRequest(page *format.HTMLPage, pageURL string, form format.HTMLForm, source *api.CollectionRequest) *api.CollectionRequest {
+	target := page.ResolveURL(pageURL, form.Action)
+	req := newFollowUpRequest(followUpName(form.Name, target), api.POST, target, source)
+
+	if form.Method == "GET" {
+		req.Method = api.GET
+		if u, err := url.Parse(target); err == nil && len(form.Fields) > 0 {
+			// Submitting a GET form replaces the query string of its action
+			u.RawQuery = encodeFormFields(form.Fields)
+			req.URL = u.String()
+		}
+		return req
+	}
+
+	if form.Multipart() {
+		fields := make([]api.FormField, 0, len(form.Fields))
+		for _, field := range form.Fields {
+			formField := api.FormField{Key: field.Name, Value: field.Value, Enabled: true}
+			if field.File {
+				formField.Type = api.FormFieldFile
+			}
+			fields = append(fields, formField)
+		}
+		req.Body = &api.BodyConfig{Type: api.BodyTypeFormData, Content: fields}
+		return req
+	}
+
+	req.Headers = append(req.Headers, api.KeyValueEntry{Key: "Content-Type", Value: "application/x-www-form-urlencoded", Enabled: true})
+	req.Body = &api.BodyConfig{Type: "raw", Content: encodeFormFields(form.Fields)}
+	return req
+}
+
+// encodeFormFields encodes form fields as an url-encoded query, in the form's order
+func encodeFormFields(fields []format.HTMLFormField) string {
+	pairs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		pairs = append(pairs, url.QueryEscape(field.Name)+"="+url.QueryEscape(field.Value))
+	}
+	return strings.Join(pairs, "&")
+}
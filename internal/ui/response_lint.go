@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// updateLintReport handles keys while the Body tab shows a :lint report
+func (r ResponseView) updateLintReport(msg tea.KeyMsg) (ResponseView, tea.Cmd) {
+	findings := r.lintReport.Findings
+	switch msg.String() {
+	case "j", "down":
+		if r.lintCursor < len(findings)-1 {
+			r.lintCursor++
+		}
+	case "k", "up":
+		if r.lintCursor > 0 {
+			r.lintCursor--
+		}
+	case "g":
+		r.lintCursor = 0
+	case "G":
+		r.lintCursor = max(0, len(findings)-1)
+	case "enter":
+		// Show the request of the finding in the Collections panel
+		if r.lintCursor < len(findings) {
+			requestID := findings[r.lintCursor].RequestID
+			return r, func() tea.Msg { return RevealRequestMsg{RequestID: requestID} }
+		}
+	case "y", "Y":
+		report := r.lintReport.Text()
+		return r, func() tea.Msg {
+			return CopyToClipboardMsg{
+				Content: report,
+				Label:   "Lint report",
+			}
+		}
+	case "esc":
+		r.lintReport = nil
+	}
+	return r, nil
+}
+
+// renderLintReport renders the findings of a :lint report, one per line
+func (r *ResponseView) renderLintReport(width, height int) string {
+	report := r.lintReport
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Text).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	severityStyles := map[api.LintSeverity]lipgloss.Style{
+		api.LintError:   lipgloss.NewStyle().Foreground(styles.Red),
+		api.LintWarning: lipgloss.NewStyle().Foreground(styles.Yellow),
+	}
+	severityIcons := map[api.LintSeverity]string{
+		api.LintError:   "✗",
+		api.LintWarning: "!",
+	}
+
+	var result strings.Builder
+	result.WriteString(titleStyle.Render("Lint report"))
+	summaryStyle := lipgloss.NewStyle().Foreground(styles.Green)
+	if !report.OK() {
+		summaryStyle = severityStyles[api.LintError]
+	} else if report.Count(api.LintWarning) > 0 {
+		summaryStyle = severityStyles[api.LintWarning]
+	}
+	result.WriteString(" " + summaryStyle.Render(report.Summary()))
+	result.WriteString("\n\n")
+
+	// Keep the selected finding within the lines left by the title and hint
+	visible := max(height-4, 1)
+	start := max(0, r.lintCursor-visible+1)
+	end := min(len(report.Findings), start+visible)
+	for i := start; i < end; i++ {
+		finding := report.Findings[i]
+		line := severityStyles[finding.Severity].Render(severityIcons[finding.Severity]+" ") +
+			nameStyle.Render(finding.Collection+" › "+finding.Request) +
+			detailStyle.Render(fmt.Sprintf(": %s (%s)", finding.Message, finding.Rule))
+		if lipgloss.Width(line) > width {
+			line = truncateURL(severityIcons[finding.Severity]+" "+finding.Collection+" › "+finding.Request+": "+finding.Message, width)
+		}
+		if i == r.lintCursor {
+			line = lipgloss.NewStyle().Background(styles.Surface1).Render(line + strings.Repeat(" ", max(0, width-lipgloss.Width(line))))
+		}
+		result.WriteString(line)
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	hint := "y: copy report · esc: close"
+	if len(report.Findings) > 0 {
+		hint = "enter: show request · " + hint
+	}
+	result.WriteString(hintStyle.Render(hint))
+	return result.String()
+}
@@ -1,15 +1,20 @@
 package ui
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
 	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/format"
 	"github.com/kbrdn1/LazyCurl/internal/session"
 	"github.com/kbrdn1/LazyCurl/internal/ui/components"
 	"github.com/kbrdn1/LazyCurl/pkg/styles"
@@ -69,7 +74,7 @@ type ResponseView struct {
 	status       string
 	headers      map[string]string
 	cookies      map[string]string
-	body         string
+	body         []byte
 	time         string
 	size         string
 	tabs         *components.Tabs
@@ -91,6 +96,69 @@ type ResponseView struct {
 	// Test results from script assertions
 	testResults       []api.AssertionResult
 	testResultsCursor int // Cursor for navigating test results
+
+	// Table view for JSON array responses
+	bodyTable      *components.DataTable
+	tableView      bool                // Whether the Body tab shows the table instead of raw JSON
+	tableAvailable bool                // Whether the current body can be rendered as a table
+	isCSV          bool                // Whether the current body is CSV/TSV (table view by default)
+	isBinary       bool                // Whether the current body is binary (shown as hex preview)
+	formatPending  bool                // Whether a large body waits for F to be pretty-printed
+	formattedBody  string              // Pretty-printed body, "" when the body is shown as received
+	bodyRaw        bool                // Whether a pretty-printed body is shown as received
+	csvRaw         bool                // Whether a CSV body is shown as raw text
+	decodedFrom    string              // Binary format the displayed JSON was decoded from (e.g. "CBOR")
+	requestID      string              // Request the current response belongs to
+	networkError   *api.NetworkError   // Connection failure of the last request (no response received)
+	doctorReport   *api.DoctorReport   // :doctor report shown over the Body tab until dismissed
+	lintReport     *api.LintReport     // :lint report shown over the Body tab until dismissed
+	jwtSigning     *api.JWTSigning     // :sign breakdown shown over the Body tab until dismissed
+	example        *api.RequestExample // :freeze example shown over the Body tab until dismissed
+	lintCursor     int                 // Selected finding of the lint report
+	bodyDiff       *format.BodyDiff    // :compare or :diff result shown over the Body tab until dismissed
+	diffPath       string              // Fixture file the body was compared with, or the responses diffed
+	diffOffset     int                 // First visible change of the diff
+	diffResponses  bool                // The diff compares two responses, not the body with a fixture
+	diffSplit      bool                // Show the diff side by side
+	hiddenColumns  map[string][]string // Hidden table columns per request ID
+	redirects      []api.RedirectHop   // Redirect chain of the response, shown in the Headers tab
+	pollNotice     string              // Poll state shown above the body while the request is polled (:poll)
+
+	// Text bodies larger than api.MaxDisplayBodySize are shown one page at a time
+	bodyFile       string  // Temporary file holding a spooled body ("" when held in memory)
+	bodySize       int64   // Size of the whole body
+	bodyOffset     int64   // Offset of the page shown
+	bodyPageEnd    int64   // Offset following the page shown
+	bodyPageStarts []int64 // Offsets of the pages before the page shown, for [
+
+	// Server-Sent Events response shown as a list over the Body tab
+	events       []api.SSEEvent // Received events (nil when the response is not a stream)
+	streaming    bool           // Whether the stream is still open
+	eventsCursor int            // Selected event
+	eventsFollow bool           // Whether the selection follows new events
+	eventsRaw    bool           // Whether the Body tab shows the raw stream instead of the list
+
+	// gRPC streaming call shown as a list of messages over the Body tab, like events
+	messageStream bool   // Whether the events are the messages of a gRPC call
+	sendBox       bool   // Whether the call takes messages typed in the send box
+	sendEditing   bool   // Whether the send box has focus
+	sendInput     string // JSON message typed in the send box
+	sendCursor    int    // Cursor position in the send box
+
+	// JSONPath (or XPath for XML bodies) query bar over the Body tab
+	jsonBody     []byte             // JSON document queried by the bar (nil when the body is not JSON)
+	xmlBody      []byte             // XML document queried by the bar (nil when the body is not XML)
+	queryEditing bool               // Whether the query bar has focus
+	query        string             // Current JSONPath or XPath expression
+	queryCursor  int                // Cursor position in the expression
+	queryResult  interface{}        // Result of the current expression ([]string for XPath)
+	queryErr     error              // Evaluation error of the current expression
+	queryEditor  *components.Editor // Read-only view of the query result
+
+	// Links and forms of an HTML body, listed beside it
+	htmlPage    *format.HTMLPage // nil when the body is not an HTML page with links or forms
+	linksView   bool             // Whether the links side view is open
+	linksCursor int              // Selected link, or form after the links
 }
 
 // NewResponseView creates a new response view
@@ -107,12 +175,15 @@ func NewResponseView() *ResponseView {
 	bodyEditor := components.NewEditor("", "json")
 	bodyEditor.SetReadOnly(true)
 
+	queryEditor := components.NewEditor("", "json")
+	queryEditor.SetReadOnly(true)
+
 	return &ResponseView{
 		statusCode:        0,
 		status:            "No response yet",
 		headers:           make(map[string]string),
 		cookies:           make(map[string]string),
-		body:              "",
+		body:              nil,
 		time:              "0ms",
 		size:              "0B",
 		tabs:              tabs,
@@ -126,6 +197,9 @@ func NewResponseView() *ResponseView {
 		consoleView:       NewConsoleView(),
 		testResults:       []api.AssertionResult{},
 		testResultsCursor: 0,
+		bodyTable:         components.NewDataTable(),
+		hiddenColumns:     make(map[string][]string),
+		queryEditor:       queryEditor,
 	}
 }
 
@@ -138,8 +212,13 @@ func (r ResponseView) Update(msg tea.Msg, cfg *config.GlobalConfig) (ResponseVie
 func (r ResponseView) UpdateWithHistory(msg tea.Msg, cfg *config.GlobalConfig, history *api.ConsoleHistory) (ResponseView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case components.SearchUpdateMsg, components.SearchCloseMsg:
-		// Forward search messages to body editor
+		// Forward search messages to the editor shown in the Body tab
 		if r.tabs.GetActive() == "Body" {
+			if r.IsQueryApplied() {
+				editor, cmd := r.queryEditor.Update(msg, false)
+				r.queryEditor = editor
+				return r, cmd
+			}
 			editor, cmd := r.bodyEditor.Update(msg, false)
 			r.bodyEditor = editor
 			return r, cmd
@@ -149,8 +228,18 @@ func (r ResponseView) UpdateWithHistory(msg tea.Msg, cfg *config.GlobalConfig, h
 	case tea.KeyMsg:
 		activeTab := r.tabs.GetActive()
 
+		// The query bar takes every key while it has focus
+		if activeTab == "Body" && r.queryEditing {
+			r.updateQueryInput(msg)
+			return r, nil
+		}
+		// So does the send box of a gRPC stream
+		if activeTab == "Body" && r.sendEditing {
+			return r, r.updateSendInput(msg)
+		}
+
 		// Tab navigation with Tab key - but not when searching
-		if !r.bodyEditor.IsSearching() {
+		if !r.bodyEditor.IsSearching() && !r.queryEditor.IsSearching() {
 			switch msg.String() {
 			case "tab":
 				r.tabs.Next()
@@ -176,9 +265,183 @@ func (r ResponseView) UpdateWithHistory(msg tea.Msg, cfg *config.GlobalConfig, h
 			}
 		}
 
+		// Stop an open event stream from any tab
+		if r.streaming && msg.String() == "x" {
+			return r, func() tea.Msg {
+				return EventStreamStopMsg{}
+			}
+		}
+
+		// Save the body to a file from any tab
+		if msg.String() == "W" && r.statusCode != 0 && !r.bodyEditor.IsSearching() && !r.queryEditor.IsSearching() {
+			return r, func() tea.Msg {
+				return ResponseSaveToFileMsg{}
+			}
+		}
+
 		// Tab-specific navigation
 		switch activeTab {
 		case "Body":
+			if r.doctorReport != nil {
+				switch msg.String() {
+				case "y", "Y":
+					report := r.doctorReport.Text()
+					return r, func() tea.Msg {
+						return CopyToClipboardMsg{
+							Content: report,
+							Label:   "Doctor report",
+						}
+					}
+				case "esc":
+					r.doctorReport = nil
+				}
+				return r, nil
+			}
+			if r.jwtSigning != nil {
+				switch msg.String() {
+				case "y", "Y":
+					report := r.jwtSigning.Text()
+					return r, func() tea.Msg {
+						return CopyToClipboardMsg{
+							Content: report,
+							Label:   "JWT signing",
+						}
+					}
+				case "esc":
+					r.jwtSigning = nil
+				}
+				return r, nil
+			}
+			if r.example != nil {
+				switch msg.String() {
+				case "y", "Y":
+					text := r.example.Text()
+					return r, func() tea.Msg {
+						return CopyToClipboardMsg{Content: text, Label: "Example"}
+					}
+				case "c":
+					curl := api.GenerateCurlFromRequest(r.example.Request())
+					return r, func() tea.Msg {
+						return CopyToClipboardMsg{Content: curl, Label: "cURL"}
+					}
+				case "esc":
+					r.example = nil
+				}
+				return r, nil
+			}
+			if r.lintReport != nil {
+				return r.updateLintReport(msg)
+			}
+			if r.bodyDiff != nil {
+				return r.updateBodyDiff(msg)
+			}
+			if !r.bodyEditor.IsSearching() && r.isPaged() && r.updateBodyPages(msg) {
+				return r, nil
+			}
+			if !r.bodyEditor.IsSearching() && r.formatPending && msg.String() == "F" {
+				r.formatBody()
+				return r, nil
+			}
+			if !r.bodyEditor.IsSearching() && r.formattedBody != "" && msg.String() == "r" {
+				r.toggleRawBody()
+				return r, nil
+			}
+			if r.events != nil && !r.streaming && !r.bodyEditor.IsSearching() && msg.String() == "r" {
+				r.eventsRaw = !r.eventsRaw
+				return r, nil
+			}
+			if r.events != nil && !r.eventsRaw {
+				return r.updateEvents(msg)
+			}
+			if r.networkError != nil {
+				switch msg.String() {
+				case "y", "Y":
+					details := r.networkError.Text()
+					return r, func() tea.Msg {
+						return CopyToClipboardMsg{
+							Content: details,
+							Label:   "Error details",
+						}
+					}
+				}
+				return r, nil
+			}
+			if r.IsQueryApplied() {
+				if !r.queryEditor.IsSearching() {
+					switch msg.String() {
+					case "J":
+						r.queryEditing = true
+						r.queryCursor = len(r.query)
+						return r, nil
+					case "y", "Y":
+						if r.queryErr != nil {
+							return r, nil
+						}
+						value := r.queryResultText()
+						return r, func() tea.Msg {
+							return CopyToClipboardMsg{
+								Content: value,
+								Label:   "Query result",
+							}
+						}
+					case "S":
+						if r.queryErr != nil {
+							return r, nil
+						}
+						value := r.queryResultText()
+						return r, func() tea.Msg {
+							return ResponseQuerySaveMsg{Value: value}
+						}
+					case "esc":
+						r.clearQuery()
+						return r, nil
+					}
+				}
+				editor, cmd := r.queryEditor.Update(msg, false)
+				r.queryEditor = editor
+				return r, cmd
+			}
+			if !r.bodyEditor.IsSearching() && r.htmlPage != nil {
+				if msg.String() == "o" && !r.linksView {
+					r.linksView = true
+					return r, nil
+				}
+				if r.linksView {
+					return r.updateLinks(msg)
+				}
+			}
+			if !r.bodyEditor.IsSearching() && !r.IsTableView() && r.jsonBody != nil && msg.String() == "S" {
+				node, ok := r.jsonValueAtCursor()
+				return r, func() tea.Msg {
+					if !ok {
+						return ResponseValueSaveMsg{}
+					}
+					return ResponseValueSaveMsg{Path: node.Path, Key: node.Key, Value: format.JSONValueText(node.Value)}
+				}
+			}
+			if !r.bodyEditor.IsSearching() && !r.IsTableView() && (r.jsonBody != nil || r.xmlBody != nil) && msg.String() == "J" {
+				r.queryEditing = true
+				r.queryCursor = len(r.query)
+				return r, nil
+			}
+			if !r.bodyEditor.IsSearching() && r.tableAvailable {
+				switch msg.String() {
+				case "t":
+					if r.isCSV {
+						r.csvRaw = !r.csvRaw
+						return r, nil
+					}
+					r.tableView = !r.tableView
+					return r, responseTableChangedCmd()
+				case "E":
+					return r, func() tea.Msg {
+						return ResponseExportCSVMsg{}
+					}
+				}
+				if r.tableView {
+					return r.updateTable(msg)
+				}
+			}
 			// Forward all keys to body editor for vim-like navigation
 			editor, cmd := r.bodyEditor.Update(msg, false) // Read-only navigation
 			r.bodyEditor = editor
@@ -254,6 +517,231 @@ func (r ResponseView) UpdateWithHistory(msg tea.Msg, cfg *config.GlobalConfig, h
 	return r, nil
 }
 
+// updateTable handles keys while the Body tab is in table view
+func (r ResponseView) updateTable(msg tea.KeyMsg) (ResponseView, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		r.bodyTable.MoveDown()
+	case "k", "up":
+		r.bodyTable.MoveUp()
+	case "g":
+		r.bodyTable.GoToTop()
+	case "G":
+		r.bodyTable.GoToBottom()
+	case "w", "right":
+		r.bodyTable.ColumnRight()
+	case "b", "left":
+		r.bodyTable.ColumnLeft()
+	case "s":
+		r.bodyTable.CycleSort()
+	case "x":
+		if r.bodyTable.HideCurrentColumn() {
+			r.storeHiddenColumns()
+			return r, responseTableChangedCmd()
+		}
+	case "X":
+		r.bodyTable.ShowAllColumns()
+		r.storeHiddenColumns()
+		return r, responseTableChangedCmd()
+	}
+	return r, nil
+}
+
+// updateQueryInput edits the JSONPath or XPath expression and re-evaluates it on every change
+func (r *ResponseView) updateQueryInput(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc":
+		r.clearQuery()
+		return
+	case "enter":
+		r.queryEditing = false
+		if r.query == "" {
+			r.clearQuery()
+		}
+		return
+	case "backspace":
+		if r.queryCursor > 0 {
+			r.query = r.query[:r.queryCursor-1] + r.query[r.queryCursor:]
+			r.queryCursor--
+		}
+	case "left":
+		if r.queryCursor > 0 {
+			r.queryCursor--
+		}
+		return
+	case "right":
+		if r.queryCursor < len(r.query) {
+			r.queryCursor++
+		}
+		return
+	case "home", "ctrl+a":
+		r.queryCursor = 0
+		return
+	case "end", "ctrl+e":
+		r.queryCursor = len(r.query)
+		return
+	case "ctrl+u":
+		r.query = ""
+		r.queryCursor = 0
+	default:
+		char := msg.String()
+		if msg.Type == tea.KeySpace {
+			char = " "
+		}
+		if len(char) != 1 {
+			return
+		}
+		r.query = r.query[:r.queryCursor] + char + r.query[r.queryCursor:]
+		r.queryCursor++
+	}
+	r.evaluateQuery()
+}
+
+// evaluateQuery runs the current expression against the JSON body, or the XML body
+// as XPath
+func (r *ResponseView) evaluateQuery() {
+	r.queryResult = nil
+	r.queryErr = nil
+	if strings.TrimSpace(r.query) == "" {
+		r.queryEditor.SetContent("")
+		return
+	}
+	if r.xmlBody != nil {
+		values, err := format.QueryXPath(r.xmlBody, r.query)
+		if err == nil && len(values) == 0 {
+			err = fmt.Errorf("no match for %s", strings.TrimSpace(r.query))
+		}
+		if err != nil {
+			r.queryErr = err
+			return
+		}
+		r.queryResult = values
+		r.queryEditor.SetContent(strings.Join(values, "\n"))
+		return
+	}
+	result, err := format.QueryJSONPath(r.jsonBody, r.query)
+	if err != nil {
+		r.queryErr = err
+		return
+	}
+	r.queryResult = result
+	r.queryEditor.SetContent(format.FormatJSONValue(result))
+}
+
+// queryResultText returns the result of the current expression as plain text. XPath
+// matches are listed one per line.
+func (r *ResponseView) queryResultText() string {
+	if values, ok := r.queryResult.([]string); ok {
+		return strings.Join(values, "\n")
+	}
+	return format.JSONValueText(r.queryResult)
+}
+
+// clearQuery closes the query bar and shows the full body again
+func (r *ResponseView) clearQuery() {
+	r.queryEditing = false
+	r.query = ""
+	r.queryCursor = 0
+	r.queryResult = nil
+	r.queryErr = nil
+	r.queryEditor.SetContent("")
+}
+
+// IsSearching returns true while the body or the query result is searched
+func (r *ResponseView) IsSearching() bool {
+	return r.bodyEditor.IsSearching() || r.queryEditor.IsSearching()
+}
+
+// IsQueryEditing returns true if the query bar has focus
+func (r *ResponseView) IsQueryEditing() bool {
+	return r.queryEditing && r.tabs.GetActive() == "Body"
+}
+
+// IsQueryApplied returns true if the Body tab shows a query result
+func (r *ResponseView) IsQueryApplied() bool {
+	return r.query != "" || r.queryEditing
+}
+
+// storeHiddenColumns records the table column selection for the current request
+func (r *ResponseView) storeHiddenColumns() {
+	if r.requestID == "" {
+		return
+	}
+	hidden := r.bodyTable.HiddenColumns()
+	if len(hidden) == 0 {
+		delete(r.hiddenColumns, r.requestID)
+		return
+	}
+	r.hiddenColumns[r.requestID] = hidden
+}
+
+// responseTableChangedCmd notifies the model that table view state should be persisted
+func responseTableChangedCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ResponseTableChangedMsg{}
+	}
+}
+
+// IsTableView returns true when the Body tab currently renders a table
+func (r *ResponseView) IsTableView() bool {
+	if !r.tableAvailable {
+		return false
+	}
+	if r.isCSV {
+		return !r.csvRaw
+	}
+	return r.tableView
+}
+
+// HasTable returns true when the current response can be shown as a table
+func (r *ResponseView) HasTable() bool {
+	return r.tableAvailable
+}
+
+// TableData returns the table as currently displayed (visible columns, sort order)
+func (r *ResponseView) TableData() (*format.TableData, bool) {
+	if !r.tableAvailable {
+		return nil, false
+	}
+	columns, rows := r.bodyTable.DisplayedData()
+	return &format.TableData{Columns: columns, Rows: rows}, true
+}
+
+// loadTable shows the given table data in the Body tab table view
+func (r *ResponseView) loadTable(table *format.TableData) {
+	r.tableAvailable = true
+	r.bodyTable.SetData(table.Columns, table.Rows)
+	r.bodyTable.SetHiddenColumns(r.hiddenColumns[r.requestID])
+}
+
+// SetRequestID sets the request the next response belongs to (used for per-request table columns)
+func (r *ResponseView) SetRequestID(id string) {
+	r.requestID = id
+}
+
+// GetRequestID returns the request the current response belongs to
+func (r *ResponseView) GetRequestID() string {
+	return r.requestID
+}
+
+// ShowConsoleSearch opens the Console tab on the entries matching a response body search
+func (r *ResponseView) ShowConsoleSearch(query string, matches []api.HistoryMatch) {
+	r.consoleView.SetSearch(query, matches)
+	r.tabs.SetActive(4) // Console
+}
+
+// SetConsoleFilter opens the Console tab on the entries shown with filter
+func (r *ResponseView) SetConsoleFilter(filter api.ConsoleFilter) {
+	r.consoleView.ClearSearch()
+	r.consoleView.SetFilter(filter)
+	r.tabs.SetActive(4) // Console
+}
+
+// GetConsoleFilter returns the filter of the Console tab
+func (r *ResponseView) GetConsoleFilter() api.ConsoleFilter {
+	return r.consoleView.Filter()
+}
+
 // GetActiveTab returns the currently active tab name
 func (r *ResponseView) GetActiveTab() string {
 	return r.tabs.GetActive()
@@ -290,6 +778,13 @@ func (r ResponseView) ViewWithHistory(width, height int, active bool, history *a
 		timeText := timeStyle.Render(fmt.Sprintf("%s %s", timeIcon, r.time))
 		sizeText := sizeStyle.Render(fmt.Sprintf("%s %s", sizeIcon, r.size))
 		rightPart := timeText + "  " + sizeText
+		if r.events != nil {
+			eventsText := fmt.Sprintf("⚡ %d %s", len(r.events), r.streamItems())
+			if r.streaming {
+				eventsText = "● live  " + eventsText
+			}
+			rightPart = lipgloss.NewStyle().Foreground(styles.Green).Render(eventsText) + "  " + rightPart
+		}
 
 		// Calculate padding to align right part to the right
 		statusLen := lipgloss.Width(statusPart)
@@ -338,6 +833,20 @@ func (r ResponseView) ViewWithHistory(width, height int, active bool, history *a
 			Foreground(styles.Blue).
 			Italic(true)
 		tabContent = loadingStyle.Render("Waiting for response...")
+	} else if r.doctorReport != nil && activeTab == "Body" {
+		tabContent = r.renderDoctorReport(width)
+	} else if r.jwtSigning != nil && activeTab == "Body" {
+		tabContent = r.renderJWTSigning(width)
+	} else if r.example != nil && activeTab == "Body" {
+		tabContent = r.renderExample(width, contentHeight)
+	} else if r.lintReport != nil && activeTab == "Body" {
+		tabContent = r.renderLintReport(width, contentHeight)
+	} else if r.bodyDiff != nil && activeTab == "Body" {
+		tabContent = r.renderBodyDiff(width, contentHeight)
+	} else if r.events != nil && !r.eventsRaw && activeTab == "Body" {
+		tabContent = r.renderEvents(width, contentHeight)
+	} else if r.networkError != nil {
+		tabContent = r.renderNetworkError(width)
 	} else if r.statusCode == 0 {
 		tabContent = lipgloss.NewStyle().
 			Foreground(styles.Subtext0).
@@ -361,15 +870,608 @@ func (r ResponseView) ViewWithHistory(width, height int, active bool, history *a
 }
 
 func (r *ResponseView) renderBodyTab(width, height int) string {
-	if r.body == "" {
+	if len(r.body) == 0 {
 		return lipgloss.NewStyle().
 			Foreground(styles.Subtext0).
 			Render("No body content")
 	}
 
+	if r.IsQueryApplied() {
+		return r.renderQuery(width, height)
+	}
+
+	if r.IsTableView() {
+		return r.renderBodyTable(width, height)
+	}
+
+	if r.IsLinksView() {
+		return r.renderBodyWithLinks(width, height)
+	}
+
+	if notice := r.bodyNotice(); notice != "" {
+		return notice + "\n" + r.bodyEditor.View(width, height-1, true)
+	}
+
+	if r.decodedFrom != "" {
+		notice := lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Render(fmt.Sprintf("Decoded from %s (%s)", r.decodedFrom, api.FormatSize(int64(len(r.body)))))
+		return notice + "\n" + r.bodyEditor.View(width, height-1, true)
+	}
+
 	return r.bodyEditor.View(width, height, true)
 }
 
+// renderQuery renders the JSONPath or XPath query bar and the result of the expression
+func (r *ResponseView) renderQuery(width, height int) string {
+	prefixStyle := lipgloss.NewStyle().Foreground(styles.Yellow).Bold(true)
+	inputStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	cursorStyle := lipgloss.NewStyle().Foreground(styles.Green).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+
+	language, example := "JSONPath ", "$.data[0].id"
+	if r.xmlBody != nil {
+		language, example = "XPath ", "//item[1]/@id"
+	}
+	bar := prefixStyle.Render(language)
+	if r.queryEditing {
+		bar += inputStyle.Render(r.query[:r.queryCursor]) + cursorStyle.Render("█") + inputStyle.Render(r.query[r.queryCursor:])
+	} else {
+		bar += inputStyle.Render(r.query) + hintStyle.Render("  y copy · S save to env · J edit · esc clear")
+	}
+	bar = lipgloss.NewStyle().MaxWidth(width).Render(bar)
+
+	switch {
+	case r.queryErr != nil:
+		errStyle := lipgloss.NewStyle().Foreground(styles.Red).Width(max(width-2, 10))
+		return bar + "\n" + errStyle.Render(r.queryErr.Error())
+	case r.queryResult == nil && strings.TrimSpace(r.query) == "":
+		return bar + "\n" + hintStyle.Render("Type an expression such as "+example)
+	}
+	return bar + "\n" + r.queryEditor.View(width, height-1, true)
+}
+
+// renderNetworkError renders the breakdown of a request that failed before a response was received
+func (r *ResponseView) renderNetworkError(width int) string {
+	ne := r.networkError
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Red).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	wrap := lipgloss.NewStyle().Width(max(width-2, 10))
+
+	var result strings.Builder
+	result.WriteString(titleStyle.Render("✗ " + ne.Title()))
+	result.WriteString("\n")
+	result.WriteString(wrap.Render(valueStyle.Render(ne.Summary)))
+	result.WriteString("\n\n")
+
+	if ne.URL != "" {
+		result.WriteString(labelStyle.Render("URL:   ") + valueStyle.Render(ne.URL) + "\n")
+	}
+	if ne.Host != "" {
+		result.WriteString(labelStyle.Render("Host:  ") + valueStyle.Render(ne.Host) + "\n")
+	}
+	if ne.Proxy != "" {
+		result.WriteString(labelStyle.Render("Proxy: ") + valueStyle.Render(ne.Proxy) + "\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(labelStyle.Render("Details:"))
+	result.WriteString("\n")
+	result.WriteString(wrap.Render(lipgloss.NewStyle().Foreground(styles.Peach).Render(ne.Details)))
+	result.WriteString("\n")
+
+	if len(ne.Suggestions) > 0 {
+		result.WriteString("\n")
+		result.WriteString(labelStyle.Render("Suggestions:"))
+		result.WriteString("\n")
+		for _, suggestion := range ne.Suggestions {
+			result.WriteString(wrap.Render(valueStyle.Render("  • " + suggestion)))
+			result.WriteString("\n")
+		}
+	}
+
+	result.WriteString("\n")
+	result.WriteString(hintStyle.Render("y: copy details"))
+	return result.String()
+}
+
+// updateBodyDiff handles keys while a :compare diff is shown
+func (r ResponseView) updateBodyDiff(msg tea.KeyMsg) (ResponseView, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if r.diffOffset < len(r.bodyDiff.Changes)-1 {
+			r.diffOffset++
+		}
+	case "k", "up":
+		if r.diffOffset > 0 {
+			r.diffOffset--
+		}
+	case "g":
+		r.diffOffset = 0
+	case "G":
+		r.diffOffset = max(len(r.bodyDiff.Changes)-1, 0)
+	case "y", "Y":
+		diff := r.bodyDiff.Text()
+		return r, func() tea.Msg {
+			return CopyToClipboardMsg{
+				Content: diff,
+				Label:   "Diff",
+			}
+		}
+	case "s":
+		r.diffSplit = !r.diffSplit
+	case "w":
+		if !r.diffResponses && !r.bodyDiff.Equal() {
+			path := r.diffPath
+			return r, func() tea.Msg {
+				return ResponseFixtureOverwriteMsg{Path: path}
+			}
+		}
+	case "esc":
+		r.bodyDiff = nil
+	}
+	return r, nil
+}
+
+// renderBodyDiff renders the differences between the fixture and the response body, or
+// between two responses
+func (r *ResponseView) renderBodyDiff(width, height int) string {
+	diff := r.bodyDiff
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	kindStyles := map[format.DiffKind]lipgloss.Style{
+		format.DiffAdded:   lipgloss.NewStyle().Foreground(styles.Green),
+		format.DiffRemoved: lipgloss.NewStyle().Foreground(styles.Red),
+		format.DiffChanged: lipgloss.NewStyle().Foreground(styles.Yellow),
+	}
+	kindIcons := map[format.DiffKind]string{
+		format.DiffAdded:   "+",
+		format.DiffRemoved: "-",
+		format.DiffChanged: "~",
+	}
+
+	var result strings.Builder
+	title, match := "Compare with", "✓ Body matches the fixture"
+	if r.diffResponses {
+		title, match = "Diff", "✓ Responses match"
+	}
+	result.WriteString(titleStyle.Render(title))
+	result.WriteString(detailStyle.Render(" " + r.diffPath))
+	result.WriteString("\n\n")
+
+	if diff.Equal() {
+		result.WriteString(kindStyles[format.DiffAdded].Bold(true).Render(match))
+		result.WriteString("\n\n")
+		result.WriteString(hintStyle.Render("esc: close"))
+		return result.String()
+	}
+
+	mode := "lines"
+	if diff.Structural {
+		mode = "JSON values"
+	}
+	result.WriteString(fmt.Sprintf("%d differences in %s: %s added, %s removed, %s changed",
+		len(diff.Changes), mode,
+		kindStyles[format.DiffAdded].Render(fmt.Sprintf("%d", diff.Count(format.DiffAdded))),
+		kindStyles[format.DiffRemoved].Render(fmt.Sprintf("%d", diff.Count(format.DiffRemoved))),
+		kindStyles[format.DiffChanged].Render(fmt.Sprintf("%d", diff.Count(format.DiffChanged)))))
+	result.WriteString("\n\n")
+
+	// Title, summary, blank lines and hints take 6 lines
+	rows := max(height-6, 1)
+	if r.diffSplit {
+		result.WriteString(r.renderDiffColumns(diff, width, rows-1, kindStyles, kindIcons))
+	}
+	for i := r.diffOffset; !r.diffSplit && i < len(diff.Changes) && i < r.diffOffset+rows; i++ {
+		c := diff.Changes[i]
+		var value string
+		switch c.Kind {
+		case format.DiffAdded:
+			value = c.Actual
+		case format.DiffRemoved:
+			value = c.Expected
+		default:
+			value = c.Expected + " → " + c.Actual
+		}
+		line := fmt.Sprintf("%s %s: %s", kindIcons[c.Kind], c.Path, value)
+		if len(line) > width && width > 3 {
+			line = line[:width-3] + "..."
+		}
+		result.WriteString(kindStyles[c.Kind].Render(line))
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	if r.diffResponses {
+		result.WriteString(hintStyle.Render("j/k: scroll · s: side by side · y: copy diff · esc: close"))
+	} else {
+		result.WriteString(hintStyle.Render("j/k: scroll · s: side by side · w: overwrite fixture with this body · y: copy diff · esc: close"))
+	}
+	return result.String()
+}
+
+// renderDiffColumns renders rows changes of the diff side by side: the path, the value
+// before and the value after
+func (r *ResponseView) renderDiffColumns(diff *format.BodyDiff, width, rows int, kindStyles map[format.DiffKind]lipgloss.Style, kindIcons map[format.DiffKind]string) string {
+	before, after := "Fixture", "Response"
+	if r.diffResponses {
+		before = "Base"
+	}
+	colWidth := max((width-8)/3, 4)
+	column := func(text string) string {
+		if lipgloss.Width(text) > colWidth {
+			text = truncateURL(text, colWidth)
+		}
+		return fmt.Sprintf("%-*s", colWidth, text)
+	}
+	separatorStyle := lipgloss.NewStyle().Foreground(styles.Surface1)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Subtext0)
+
+	var result strings.Builder
+	result.WriteString(headerStyle.Render("  " + column("Path")))
+	result.WriteString(separatorStyle.Render(" │ "))
+	result.WriteString(headerStyle.Render(column(before)))
+	result.WriteString(separatorStyle.Render(" │ "))
+	result.WriteString(headerStyle.Render(column(after)))
+	result.WriteString("\n")
+	for i := r.diffOffset; i < len(diff.Changes) && i < r.diffOffset+rows; i++ {
+		c := diff.Changes[i]
+		style := kindStyles[c.Kind]
+		result.WriteString(style.Render(kindIcons[c.Kind] + " " + column(c.Path)))
+		result.WriteString(separatorStyle.Render(" │ "))
+		result.WriteString(kindStyles[format.DiffRemoved].Render(column(c.Expected)))
+		result.WriteString(separatorStyle.Render(" │ "))
+		result.WriteString(kindStyles[format.DiffAdded].Render(column(c.Actual)))
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// updateEvents handles keys while the Body tab lists the events of a stream
+func (r ResponseView) updateEvents(msg tea.KeyMsg) (ResponseView, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if r.eventsCursor < len(r.events)-1 {
+			r.eventsCursor++
+		}
+		r.eventsFollow = r.eventsCursor == len(r.events)-1
+	case "k", "up":
+		if r.eventsCursor > 0 {
+			r.eventsCursor--
+		}
+		r.eventsFollow = false
+	case "g":
+		r.eventsCursor = 0
+		r.eventsFollow = false
+	case "G":
+		r.eventsCursor = max(len(r.events)-1, 0)
+		r.eventsFollow = true
+	case "y", "Y":
+		if len(r.events) == 0 {
+			return r, nil
+		}
+		data := r.events[r.eventsCursor].Data
+		return r, func() tea.Msg {
+			return CopyToClipboardMsg{
+				Content: data,
+				Label:   "Event data",
+			}
+		}
+	case "enter":
+		if r.sendBox {
+			r.sendEditing = true
+		}
+	case "ctrl+d":
+		if r.sendBox {
+			return r, func() tea.Msg {
+				return GRPCStreamCloseSendMsg{}
+			}
+		}
+	}
+	return r, nil
+}
+
+// updateSendInput edits the message of the send box; enter sends it
+func (r *ResponseView) updateSendInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		r.sendEditing = false
+	case "enter":
+		body := strings.TrimSpace(r.sendInput)
+		if body == "" {
+			return nil
+		}
+		r.sendInput = ""
+		r.sendCursor = 0
+		return func() tea.Msg {
+			return GRPCStreamSendMsg{Body: body}
+		}
+	case "backspace":
+		if r.sendCursor > 0 {
+			r.sendInput = r.sendInput[:r.sendCursor-1] + r.sendInput[r.sendCursor:]
+			r.sendCursor--
+		}
+	case "left":
+		if r.sendCursor > 0 {
+			r.sendCursor--
+		}
+	case "right":
+		if r.sendCursor < len(r.sendInput) {
+			r.sendCursor++
+		}
+	case "home", "ctrl+a":
+		r.sendCursor = 0
+	case "end", "ctrl+e":
+		r.sendCursor = len(r.sendInput)
+	case "ctrl+u":
+		r.sendInput = ""
+		r.sendCursor = 0
+	default:
+		char := msg.String()
+		if msg.Type == tea.KeySpace {
+			char = " "
+		}
+		if len(char) != 1 {
+			return nil
+		}
+		r.sendInput = r.sendInput[:r.sendCursor] + char + r.sendInput[r.sendCursor:]
+		r.sendCursor++
+	}
+	return nil
+}
+
+// streamItems names the items of the stream shown
+func (r *ResponseView) streamItems() string {
+	if r.messageStream {
+		return "messages"
+	}
+	return "events"
+}
+
+// renderEvents renders the events of a stream, one per line
+func (r *ResponseView) renderEvents(width, height int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	liveStyle := lipgloss.NewStyle().Foreground(styles.Green).Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	eventStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface1)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var result strings.Builder
+	result.WriteString(titleStyle.Render(fmt.Sprintf("%d %s", len(r.events), r.streamItems())))
+	if r.streaming {
+		result.WriteString(liveStyle.Render("  ● streaming"))
+	} else {
+		result.WriteString(timeStyle.Render("  stream closed"))
+	}
+	result.WriteString("\n\n")
+
+	if len(r.events) == 0 {
+		result.WriteString(timeStyle.Render("Waiting for " + r.streamItems() + "..."))
+		result.WriteString("\n")
+	}
+
+	// Title, blank lines and hints take 4 lines, the send box one more; keep the
+	// selected event visible
+	rows := max(height-4, 1)
+	if r.sendBox {
+		rows = max(height-5, 1)
+	}
+	start := max(r.eventsCursor-rows+1, 0)
+	for i := start; i < len(r.events) && i < start+rows; i++ {
+		e := r.events[i]
+		at := fmt.Sprintf("+%-8s ", formatDuration(e.At))
+		name := e.Event + " "
+		if e.ID != "" {
+			name += "#" + e.ID + " "
+		}
+		data := strings.ReplaceAll(e.Data, "\n", " ⏎ ")
+		if avail := width - lipgloss.Width(at+name); lipgloss.Width(data) > avail {
+			data = truncateURL(data, avail)
+		}
+		line := timeStyle.Render(at) + eventStyle.Render(name) + data
+		if i == r.eventsCursor {
+			line = selectedStyle.Render(line)
+		}
+		result.WriteString(line)
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	if r.sendBox {
+		result.WriteString(r.renderSendBox(width))
+		result.WriteString("\n")
+	}
+	hint := "j/k: select · y: copy data"
+	switch {
+	case r.sendEditing:
+		hint = "enter: send · esc: leave the send box"
+	case r.sendBox:
+		hint += " · enter: send a message · ctrl+d: end sending · x: stop stream"
+	case r.streaming:
+		hint += " · x: stop stream"
+	default:
+		hint += " · r: raw body"
+	}
+	result.WriteString(hintStyle.Render(hint))
+	return result.String()
+}
+
+// renderSendBox renders the input of the messages sent on a gRPC stream
+func (r *ResponseView) renderSendBox(width int) string {
+	prefixStyle := lipgloss.NewStyle().Foreground(styles.Yellow).Bold(true)
+	inputStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	cursorStyle := lipgloss.NewStyle().Foreground(styles.Green).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+
+	box := prefixStyle.Render("→ ")
+	switch {
+	case r.sendEditing:
+		box += inputStyle.Render(r.sendInput[:r.sendCursor]) + cursorStyle.Render("█") + inputStyle.Render(r.sendInput[r.sendCursor:])
+	case r.sendInput != "":
+		box += inputStyle.Render(r.sendInput)
+	default:
+		box += hintStyle.Render(`{"field": "value"}`)
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(box)
+}
+
+// renderDoctorReport renders the step-by-step :doctor connectivity report
+func (r *ResponseView) renderDoctorReport(width int) string {
+	report := r.doctorReport
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Text).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	statusStyles := map[api.DoctorStatus]lipgloss.Style{
+		api.DoctorPass: lipgloss.NewStyle().Foreground(styles.Green),
+		api.DoctorWarn: lipgloss.NewStyle().Foreground(styles.Yellow),
+		api.DoctorFail: lipgloss.NewStyle().Foreground(styles.Red),
+		api.DoctorSkip: lipgloss.NewStyle().Foreground(styles.Subtext0),
+	}
+	statusIcons := map[api.DoctorStatus]string{
+		api.DoctorPass: "✓",
+		api.DoctorWarn: "!",
+		api.DoctorFail: "✗",
+		api.DoctorSkip: "-",
+	}
+
+	var result strings.Builder
+	result.WriteString(titleStyle.Render("Connectivity report"))
+	result.WriteString(detailStyle.Render(" " + report.URL))
+	result.WriteString("\n\n")
+
+	detailWidth := max(width-12, 10)
+	for _, step := range report.Steps {
+		line := statusStyles[step.Status].Render(statusIcons[step.Status]+" ") + nameStyle.Render(fmt.Sprintf("%-6s", step.Name))
+		detail := step.Detail
+		if step.Duration > 0 {
+			detail += fmt.Sprintf(" (%s)", formatDuration(step.Duration))
+		}
+		detail = lipgloss.NewStyle().Width(detailWidth).Render(detailStyle.Render(detail))
+		result.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, line+" ", detail))
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	summaryStyle := statusStyles[api.DoctorPass]
+	if !report.OK() {
+		summaryStyle = statusStyles[api.DoctorFail]
+	} else if report.Count(api.DoctorWarn) > 0 {
+		summaryStyle = statusStyles[api.DoctorWarn]
+	}
+	result.WriteString(summaryStyle.Bold(true).Render(report.Summary()))
+	result.WriteString("\n\n")
+	result.WriteString(hintStyle.Render("y: copy report · esc: close"))
+	return result.String()
+}
+
+// renderJWTSigning renders the steps of a :sign JWT signing, and the token compared with it
+func (r *ResponseView) renderJWTSigning(width int) string {
+	signing := r.jwtSigning
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Text).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	detailWidth := max(width-15, 10)
+	var result strings.Builder
+	writeStep := func(name, detail string) {
+		line := nameStyle.Render(fmt.Sprintf("%-13s", name))
+		detail = lipgloss.NewStyle().Width(detailWidth).Render(detailStyle.Render(detail))
+		result.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, line+" ", detail))
+		result.WriteString("\n")
+	}
+
+	result.WriteString(titleStyle.Render("JWT signing"))
+	result.WriteString(detailStyle.Render(" " + signing.Algorithm))
+	result.WriteString("\n\n")
+	writeStep("Header", signing.Header)
+	writeStep("Claims", signing.Claims)
+	writeStep("Signing input", signing.SigningInput)
+	if digest := signing.Digest(); digest != "" {
+		writeStep("SHA-256", digest)
+	}
+	writeStep("Key", signing.KeyInfo())
+	writeStep("Signature", hex.EncodeToString(signing.Signature))
+	writeStep("Token", signing.Token)
+
+	if check := signing.Check; check != nil {
+		result.WriteString("\n")
+		result.WriteString(titleStyle.Render("Compared token"))
+		result.WriteString("\n\n")
+		writeStep("Header", check.Header)
+		writeStep("Claims", check.Claims)
+		result.WriteString("\n")
+		summaryStyle := lipgloss.NewStyle().Foreground(styles.Green).Bold(true)
+		if !check.Valid {
+			summaryStyle = summaryStyle.Foreground(styles.Red)
+		}
+		result.WriteString(summaryStyle.Render("Signature " + check.Summary()))
+		result.WriteString("\n")
+		for _, diff := range check.Differences {
+			result.WriteString(lipgloss.NewStyle().Width(width).Render(
+				lipgloss.NewStyle().Foreground(styles.Yellow).Render("! ") + detailStyle.Render(diff)))
+			result.WriteString("\n")
+		}
+	}
+
+	result.WriteString("\n")
+	result.WriteString(hintStyle.Render("y: copy · esc: close"))
+	return result.String()
+}
+
+// renderExample renders a frozen request example as an HTTP message
+func (r *ResponseView) renderExample(width, height int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Blue).Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	lines := strings.Split(r.example.Text(), "\n")
+	var result strings.Builder
+	result.WriteString(titleStyle.Render("Frozen request"))
+	result.WriteString(detailStyle.Render(" " + strings.TrimPrefix(lines[0], "# ")))
+	result.WriteString("\n\n")
+
+	body := lines[1:]
+	if maxLines := height - 4; maxLines > 0 && len(body) > maxLines {
+		body = append(body[:maxLines-1], "…")
+	}
+	for _, line := range body {
+		result.WriteString(textStyle.MaxWidth(width).Render(line))
+		result.WriteString("\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(hintStyle.Render("y: copy · c: copy as cURL · esc: close"))
+	return result.String()
+}
+
+func (r *ResponseView) renderBodyTable(width, height int) string {
+	var result strings.Builder
+
+	infoStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	info := fmt.Sprintf("%d rows · %d/%d columns", r.bodyTable.RowCount(),
+		len(r.bodyTable.VisibleColumns()), len(r.bodyTable.Columns()))
+	if col, dir := r.bodyTable.SortState(); dir != components.SortNone {
+		order := "asc"
+		if dir == components.SortDesc {
+			order = "desc"
+		}
+		info += fmt.Sprintf(" · sorted by %s (%s)", col, order)
+	}
+	result.WriteString(infoStyle.Render(info))
+	result.WriteString("\n")
+
+	result.WriteString(r.bodyTable.View(width, height-1))
+	return result.String()
+}
+
 func (r *ResponseView) renderCookiesTab(width, height int) string {
 	var result strings.Builder
 
@@ -435,6 +1537,13 @@ func (r *ResponseView) renderCookiesTab(width, height int) string {
 func (r *ResponseView) renderHeadersTab(width, height int) string {
 	var result strings.Builder
 
+	// The redirect chain goes above the headers of the final response
+	if len(r.redirects) > 0 {
+		section := r.renderRedirects(width)
+		result.WriteString(section)
+		height -= lipgloss.Height(section)
+	}
+
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(styles.Blue)
@@ -494,13 +1603,42 @@ func (r *ResponseView) renderHeadersTab(width, height int) string {
 	return result.String()
 }
 
+// renderRedirects renders the redirect chain, one request and its status per line
+func (r *ResponseView) renderRedirects(width int) string {
+	var result strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Blue)
+	result.WriteString(headerStyle.Render(fmt.Sprintf("Redirects (%d)", len(r.redirects)-1)))
+	result.WriteString("\n")
+
+	urlStyle := lipgloss.NewStyle().Foreground(styles.Subtext1)
+	for i, hop := range r.redirects {
+		badge := NewStatusBadge(hop.StatusCode)
+		status := lipgloss.NewStyle().Foreground(badge.BgColor).Render(fmt.Sprintf("%d", hop.StatusCode))
+		url := hop.URL
+		if urlWidth := width - 10; urlWidth > 0 && len(url) > urlWidth {
+			url = url[:urlWidth]
+		}
+		result.WriteString(fmt.Sprintf("%3d. %s ", i+1, status))
+		result.WriteString(urlStyle.Render(url))
+		result.WriteString("\n")
+	}
+	result.WriteString("\n")
+
+	return result.String()
+}
+
 func (r *ResponseView) renderTestsTab(width, height int) string {
 	var result strings.Builder
 
 	// Summary header
 	passed := 0
 	failed := 0
+	var total time.Duration
 	for _, test := range r.testResults {
+		total += test.Duration
 		if test.Passed {
 			passed++
 		} else {
@@ -517,6 +1655,9 @@ func (r *ResponseView) renderTestsTab(width, height int) string {
 		summary := fmt.Sprintf("Tests: %s passed, %s failed",
 			passedStyle.Render(fmt.Sprintf("%d", passed)),
 			failedStyle.Render(fmt.Sprintf("%d", failed)))
+		if total > 0 {
+			summary += summaryStyle.Render(" · " + formatDuration(total))
+		}
 		result.WriteString(summary)
 		result.WriteString("\n")
 		result.WriteString(strings.Repeat("─", width))
@@ -553,20 +1694,25 @@ func (r *ResponseView) renderTestsTab(width, height int) string {
 			icon = failIcon
 		}
 
-		// Test name
+		// Test name, with the time its lc.test function took on the right
 		nameStyle := lipgloss.NewStyle().Foreground(styles.Text)
+		duration := ""
+		if test.Duration > 0 {
+			duration = formatDuration(test.Duration)
+		}
 		name := test.Name
-		maxNameWidth := width - 4 // Icon + space + padding
-		if len(name) > maxNameWidth && maxNameWidth > 0 {
+		maxNameWidth := width - 4 - len(duration) // Icon + space + padding
+		if len(name) > maxNameWidth && maxNameWidth > 3 {
 			name = name[:maxNameWidth-3] + "..."
 		}
+		gap := max(1, width-2-lipgloss.Width(name)-len(duration)-1)
 
 		// Highlight selected row
 		if i == r.testResultsCursor {
 			rowStyle := lipgloss.NewStyle().
 				Background(styles.Surface1).
 				Foreground(styles.Text)
-			row := fmt.Sprintf("%s %s", icon, name)
+			row := fmt.Sprintf("%s %s%s%s", icon, name, strings.Repeat(" ", gap), duration)
 			// Pad to full width
 			if lipgloss.Width(row) < width {
 				row += strings.Repeat(" ", width-lipgloss.Width(row))
@@ -574,6 +1720,9 @@ func (r *ResponseView) renderTestsTab(width, height int) string {
 			result.WriteString(rowStyle.Render(row))
 		} else {
 			result.WriteString(fmt.Sprintf("%s %s", icon, nameStyle.Render(name)))
+			if duration != "" {
+				result.WriteString(strings.Repeat(" ", gap) + summaryStyle.Render(duration))
+			}
 		}
 		result.WriteString("\n")
 
@@ -596,8 +1745,10 @@ func (r *ResponseView) renderTestsTab(width, height int) string {
 	return result.String()
 }
 
-// SetResponse updates the response view with new data
-func (r *ResponseView) SetResponse(statusCode int, status string, headers map[string]string, cookies map[string]string, body string, time string, size string) {
+// SetResponse updates the response view with new data.
+// The body is kept as raw bytes; binary bodies are displayed as a hex preview and
+// text bodies larger than api.MaxDisplayBodySize are shown one page at a time.
+func (r *ResponseView) SetResponse(statusCode int, status string, headers map[string]string, cookies map[string]string, body []byte, time string, size string) {
 	r.statusCode = statusCode
 	r.status = status
 	r.headers = headers
@@ -607,11 +1758,21 @@ func (r *ResponseView) SetResponse(statusCode int, status string, headers map[st
 	r.size = size
 	r.statusBadge = NewStatusBadge(statusCode)
 	r.isLoading = false // Clear loading state when response is received
+	r.networkError = nil
+	r.doctorReport = nil
+	r.jwtSigning = nil
+	r.example = nil
+	r.lintReport = nil
+	r.bodyDiff = nil
+	r.jsonBody = nil
+	r.xmlBody = nil
+	r.htmlPage = nil
+	r.linksCursor = 0
+	r.redirects = nil
+	r.clearQuery()
+	r.bodyEditor.SetSyntaxType("json")
+	r.queryEditor.SetSyntaxType("json")
 
-	// Update body editor with response body and auto-format JSON
-	r.bodyEditor.SetContent(body)
-
-	// Check if content type is JSON and auto-format
 	contentType := ""
 	for k, v := range headers {
 		if strings.ToLower(k) == "content-type" {
@@ -619,9 +1780,91 @@ func (r *ResponseView) SetResponse(statusCode int, status string, headers map[st
 			break
 		}
 	}
-	if strings.Contains(contentType, "json") || strings.HasPrefix(strings.TrimSpace(body), "{") || strings.HasPrefix(strings.TrimSpace(body), "[") {
-		// Auto-format JSON for better readability
-		r.bodyEditor.FormatJSON()
+
+	r.tableAvailable = false
+	r.isCSV = false
+	r.csvRaw = false
+	r.isBinary = api.IsBinaryBody(contentType, body)
+	r.formatPending = false
+	r.formattedBody = ""
+	r.bodyRaw = false
+	r.decodedFrom = ""
+	r.bodyFile = ""
+	r.bodySize = int64(len(body))
+	r.bodyOffset = 0
+	r.bodyPageEnd = r.bodySize
+	r.bodyPageStarts = nil
+
+	if wireFormat, ok := format.BinaryFormatFromContentType(contentType); ok && len(body) > 0 {
+		// Show MessagePack/CBOR bodies as JSON; fall back to the hex preview if decoding fails
+		if decoded, err := format.DecodeToJSON(wireFormat, body); err == nil {
+			r.decodedFrom = wireFormat.DisplayName()
+			r.bodyEditor.SetContent(string(decoded))
+			r.jsonBody = decoded
+			if table, ok := format.ParseJSONTable(decoded); ok {
+				r.loadTable(table)
+			}
+		} else {
+			r.bodyEditor.SetContent(api.BinaryPreview(contentType, body))
+		}
+	} else if r.isBinary {
+		// Never run binary data through text formatting
+		r.bodyEditor.SetContent(api.BinaryPreview(contentType, body))
+	} else {
+		// Update body editor with the first page of the body
+		display := r.loadBodyPage(0)
+		truncated := r.bodyPageEnd < r.bodySize
+		lazy := len(body) > lazyFormatBodySize
+
+		// Highlight the body by its syntax and pretty-print it; large bodies on demand (F).
+		// Only complete bodies can be parsed.
+		trimmed := strings.TrimSpace(string(display))
+		syntax := ""
+		switch {
+		case strings.Contains(contentType, "json") || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+			syntax = "json"
+			if !truncated && json.Valid(body) {
+				r.jsonBody = body
+			}
+		case format.IsXMLContentType(contentType) || strings.HasPrefix(trimmed, "<?xml"):
+			syntax = "xml"
+		case format.IsHTML(contentType, display):
+			syntax = "html"
+		case format.IsYAMLContentType(contentType):
+			syntax = "yaml"
+		}
+		if syntax != "" {
+			r.bodyEditor.SetSyntaxType(syntax)
+		}
+		if syntax != "" && !truncated {
+			if lazy {
+				r.formatPending = true
+			} else {
+				r.formatBody()
+			}
+		}
+
+		// List the links and forms of HTML pages
+		if !truncated && format.IsHTML(contentType, body) {
+			if page, err := format.ParseHTMLPage(body); err == nil && len(page.Links)+len(page.Forms) > 0 {
+				r.htmlPage = page
+			}
+		}
+
+		// Offer a table view for CSV/TSV bodies and arrays of flat objects
+		if !truncated {
+			if delimiter, ok := format.CSVDelimiter(contentType); ok {
+				if table, err := format.ParseCSVTable(body, delimiter); err == nil {
+					r.isCSV = true
+					r.loadTable(table)
+				}
+			} else if table, ok := format.ParseJSONTable(body); ok {
+				r.loadTable(table)
+			}
+		}
+	}
+	if !r.tableAvailable {
+		r.bodyTable.SetData(nil, nil)
 	}
 
 	// Sort header and cookie keys for stable iteration
@@ -648,17 +1891,264 @@ func (r *ResponseView) ClearResponse() {
 	r.status = "No response yet"
 	r.headers = make(map[string]string)
 	r.cookies = make(map[string]string)
-	r.body = ""
+	r.body = nil
+	r.isBinary = false
+	r.formatPending = false
+	r.formattedBody = ""
+	r.bodyRaw = false
+	r.decodedFrom = ""
+	r.bodyFile = ""
+	r.bodySize = 0
+	r.bodyOffset = 0
+	r.bodyPageEnd = 0
+	r.bodyPageStarts = nil
+	r.networkError = nil
+	r.doctorReport = nil
+	r.jwtSigning = nil
+	r.example = nil
+	r.lintReport = nil
+	r.bodyDiff = nil
+	r.jsonBody = nil
+	r.xmlBody = nil
+	r.htmlPage = nil
+	r.redirects = nil
+	r.events = nil
+	r.streaming = false
+	r.eventsRaw = false
+	r.messageStream = false
+	r.CloseSendBox()
+	r.clearQuery()
 	r.time = "0ms"
 	r.size = "0B"
 	r.statusBadge = NewStatusBadge(0)
 	r.bodyEditor.SetContent("")
+	r.tableAvailable = false
+	r.isCSV = false
+	r.bodyTable.SetData(nil, nil)
 	r.headersKeys = []string{}
 	r.cookiesKeys = []string{}
 	r.headersCursor = 0
 	r.cookiesCursor = 0
 }
 
+// SetRedirects sets the redirect chain of the current response
+func (r *ResponseView) SetRedirects(chain []api.RedirectHop) {
+	r.redirects = chain
+}
+
+// GetRedirects returns the redirect chain of the current response
+func (r *ResponseView) GetRedirects() []api.RedirectHop {
+	return r.redirects
+}
+
+// SetNetworkError replaces the response with the breakdown of a failed request.
+// The Body tab is shown unless the Console tab is active.
+func (r *ResponseView) SetNetworkError(ne *api.NetworkError) {
+	r.ClearResponse()
+	r.isLoading = false
+	r.networkError = ne
+	if r.tabs.GetActive() != "Console" {
+		r.tabs.SetActive(0)
+	}
+}
+
+// SetDoctorReport shows a :doctor report in the Body tab until dismissed or a new response arrives
+func (r *ResponseView) SetDoctorReport(report *api.DoctorReport) {
+	r.doctorReport = report
+	r.queryEditing = false
+	r.tabs.SetActive(0)
+}
+
+// SetJWTSigning shows a :sign breakdown in the Body tab until dismissed or a new response arrives
+func (r *ResponseView) SetJWTSigning(signing *api.JWTSigning) {
+	r.jwtSigning = signing
+	r.queryEditing = false
+	r.tabs.SetActive(0)
+}
+
+// SetExample shows a frozen request example in the Body tab until dismissed or a new response arrives
+func (r *ResponseView) SetExample(example *api.RequestExample) {
+	r.example = example
+	r.queryEditing = false
+	r.tabs.SetActive(0)
+}
+
+// SetLintReport shows a :lint report in the Body tab until dismissed or a new response arrives
+func (r *ResponseView) SetLintReport(report *api.LintReport) {
+	r.lintReport = report
+	r.lintCursor = 0
+	r.queryEditing = false
+	r.tabs.SetActive(0)
+}
+
+// SetBodyDiff shows the comparison of the body with the fixture at path in the Body tab
+// until dismissed or a new response arrives
+func (r *ResponseView) SetBodyDiff(path string, diff *format.BodyDiff) {
+	r.bodyDiff = diff
+	r.diffPath = path
+	r.diffOffset = 0
+	r.diffResponses = false
+	r.queryEditing = false
+	r.tabs.SetActive(0)
+}
+
+// SetResponseDiff shows the comparison of two responses, described by base and other,
+// in the Body tab until dismissed or a new response arrives
+func (r *ResponseView) SetResponseDiff(base, other string, diff *format.BodyDiff) {
+	r.SetBodyDiff(base+" → "+other, diff)
+	r.diffResponses = true
+}
+
+// CurrentResponse returns the response shown, with the body compared by :compare
+func (r *ResponseView) CurrentResponse() *api.Response {
+	headers := make(map[string][]string, len(r.headers))
+	for name, value := range r.headers {
+		headers[name] = []string{value}
+	}
+	return &api.Response{StatusCode: r.statusCode, Status: r.status, Headers: headers, Body: r.CompareBody()}
+}
+
+// SetPollNotice sets the line shown above the body while the request is polled, "" to hide it
+func (r *ResponseView) SetPollNotice(notice string) {
+	r.pollNotice = notice
+}
+
+// BodyText returns the body as shown in the Body tab
+func (r *ResponseView) BodyText() string {
+	return r.bodyEditor.GetContent()
+}
+
+// HighlightChanges marks the lines of the body that are not in previous, the body shown
+// before, and returns their number
+func (r *ResponseView) HighlightChanges(previous string) int {
+	rows := format.ChangedLines(previous, r.bodyEditor.GetContent())
+	r.bodyEditor.SetChangedLines(rows)
+	return len(rows)
+}
+
+// BodyCursor returns the cursor position in the body
+func (r *ResponseView) BodyCursor() (row, col int) {
+	return r.bodyEditor.GetCursorPosition()
+}
+
+// jsonValueAtCursor returns the JSON value under the cursor of the body. Returns false
+// when the body shown is not a JSON document, such as a page of a large body.
+func (r *ResponseView) jsonValueAtCursor() (format.JSONNode, bool) {
+	text := r.BodyText()
+	row, col := r.BodyCursor()
+	offset := col
+	for i, line := range strings.Split(text, "\n") {
+		if i == row {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return format.JSONValueAt(text, offset)
+}
+
+// SetBodyCursor moves the cursor in the body, to keep the position across responses
+func (r *ResponseView) SetBodyCursor(row, col int) {
+	r.bodyEditor.SetCursorPosition(row, col)
+}
+
+// StartEventStream shows the response as a stream of events, appended as they arrive
+func (r *ResponseView) StartEventStream() {
+	r.events = []api.SSEEvent{}
+	r.streaming = true
+	r.eventsCursor = 0
+	r.eventsFollow = true
+	r.eventsRaw = false
+}
+
+// AppendEvent adds an event received on the stream
+func (r *ResponseView) AppendEvent(event api.SSEEvent) {
+	r.events = append(r.events, event)
+	if r.eventsFollow {
+		r.eventsCursor = len(r.events) - 1
+	}
+}
+
+// EndEventStream marks the stream as closed; the events stay listed
+func (r *ResponseView) EndEventStream() {
+	r.streaming = false
+	r.CloseSendBox()
+}
+
+// StartMessageStream shows the response as the messages of a gRPC streaming call,
+// appended as they are received or sent. Calls that take more messages from the client
+// get a send box when canSend is true.
+func (r *ResponseView) StartMessageStream(canSend bool) {
+	r.StartEventStream()
+	r.messageStream = true
+	r.sendBox = canSend
+}
+
+// AppendStreamMessage adds a message received or sent on the gRPC stream
+func (r *ResponseView) AppendStreamMessage(msg grpc.StreamMessage) {
+	direction := "←"
+	if msg.Sent {
+		direction = "→"
+	}
+	r.AppendEvent(api.SSEEvent{Event: direction, Data: msg.Data, At: msg.At})
+}
+
+// CloseSendBox removes the send box, once the client sends no more messages
+func (r *ResponseView) CloseSendBox() {
+	r.sendBox = false
+	r.sendEditing = false
+	r.sendInput = ""
+	r.sendCursor = 0
+}
+
+// IsSendEditing returns true if the send box of a gRPC stream has focus
+func (r *ResponseView) IsSendEditing() bool {
+	return r.sendEditing && r.tabs.GetActive() == "Body"
+}
+
+// IsStreaming returns whether the response is an open event stream
+func (r *ResponseView) IsStreaming() bool {
+	return r.streaming
+}
+
+// GetEvents returns the events received on the stream of the response
+func (r *ResponseView) GetEvents() []api.SSEEvent {
+	return r.events
+}
+
+// CompareBody returns the body compared against fixtures: the JSON document
+// (decoded from binary formats such as CBOR) when there is one, otherwise the raw body
+func (r *ResponseView) CompareBody() []byte {
+	if r.jsonBody != nil {
+		return r.jsonBody
+	}
+	return r.body
+}
+
+// GetNetworkError returns the connection failure of the last request, or nil
+func (r *ResponseView) GetNetworkError() *api.NetworkError {
+	return r.networkError
+}
+
+// GetBody returns the raw response body bytes
+func (r *ResponseView) GetBody() []byte {
+	return r.body
+}
+
+// GetHeader returns the value of a response header, matched case-insensitively
+func (r *ResponseView) GetHeader(name string) string {
+	for key, value := range r.headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// IsBinary returns whether the current response body is binary
+func (r *ResponseView) IsBinary() bool {
+	return r.isBinary
+}
+
 // GetStatusCode returns the current status code
 func (r *ResponseView) GetStatusCode() int {
 	return r.statusCode
@@ -733,12 +2223,26 @@ func (r *ResponseView) SetSessionState(state session.ResponsePanelState) {
 	if state.ScrollPosition >= 0 {
 		r.scrollOffset = state.ScrollPosition
 	}
+
+	// Restore table view preferences
+	r.tableView = state.TableView
+	r.hiddenColumns = make(map[string][]string, len(state.HiddenTableColumns))
+	for id, columns := range state.HiddenTableColumns {
+		r.hiddenColumns[id] = columns
+	}
 }
 
 // GetSessionState returns the current session state for the response panel
 func (r *ResponseView) GetSessionState() session.ResponsePanelState {
 	state := session.ResponsePanelState{
 		ScrollPosition: r.scrollOffset,
+		TableView:      r.tableView,
+	}
+	if len(r.hiddenColumns) > 0 {
+		state.HiddenTableColumns = make(map[string][]string, len(r.hiddenColumns))
+		for id, columns := range r.hiddenColumns {
+			state.HiddenTableColumns[id] = columns
+		}
 	}
 
 	// Get active tab name
@@ -0,0 +1,677 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/api/grpc"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/format"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+func typeKeys(r ResponseView, keys ...string) ResponseView {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		r, _ = r.Update(msg, nil)
+	}
+	return r
+}
+
+func TestResponseView_JSONPathQuery(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"data":[{"id":7,"name":"Ada"},{"id":8,"name":"Grace"}]}`), "1ms", "1B")
+
+	r = typeKeys(r, "J", "$", ".", "d", "a", "t", "a", "[", "1", "]", ".", "n", "a", "m", "e")
+	if !r.IsQueryEditing() {
+		t.Fatal("expected query bar to have focus")
+	}
+	if r.queryErr != nil || r.queryResult != "Grace" {
+		t.Fatalf("live result = %v, err = %v", r.queryResult, r.queryErr)
+	}
+
+	r = typeKeys(r, "enter")
+	if r.IsQueryEditing() || !r.IsQueryApplied() {
+		t.Fatal("enter should keep the result and release focus")
+	}
+
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")}, nil)
+	if cmd == nil {
+		t.Fatal("S should request saving the result")
+	}
+	if msg, ok := cmd().(ResponseQuerySaveMsg); !ok || msg.Value != "Grace" {
+		t.Errorf("S emitted %#v", cmd())
+	}
+
+	r = typeKeys(r, "J", "backspace", "backspace", "backspace", "backspace", "backspace", "backspace")
+	if r.queryErr == nil {
+		t.Error("expected error for incomplete expression")
+	}
+
+	r = typeKeys(r, "esc")
+	if r.IsQueryApplied() || r.query != "" {
+		t.Error("esc should clear the query")
+	}
+}
+
+func TestResponseView_SaveValueAtCursor(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"data":[{"id":7,"name":"Ada"}],"token":"abc.def"}`), "1ms", "1B")
+	save := func() ResponseValueSaveMsg {
+		t.Helper()
+		_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")}, nil)
+		if cmd == nil {
+			t.Fatal("S should request saving the value under the cursor")
+		}
+		msg, ok := cmd().(ResponseValueSaveMsg)
+		if !ok {
+			t.Fatalf("S emitted %#v", cmd())
+		}
+		return msg
+	}
+
+	for row, line := range strings.Split(r.BodyText(), "\n") {
+		if strings.Contains(line, `"token"`) {
+			r.SetBodyCursor(row, 0)
+		}
+	}
+	if msg := save(); msg.Path != "$.token" || msg.Key != "token" || msg.Value != "abc.def" {
+		t.Errorf("S on the token line saved %+v", msg)
+	}
+	for row, line := range strings.Split(r.BodyText(), "\n") {
+		if strings.Contains(line, `"id"`) {
+			r.SetBodyCursor(row, strings.Index(line, "7"))
+		}
+	}
+	if msg := save(); msg.Path != "$.data[0].id" || msg.Value != "7" {
+		t.Errorf("S on an id saved %+v", msg)
+	}
+}
+
+func TestResponseView_JSONPathQueryUnavailable(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/plain"}, nil, []byte("hello"), "1ms", "1B")
+
+	r = typeKeys(r, "J")
+	if r.IsQueryEditing() {
+		t.Error("query bar should not open for non-JSON bodies")
+	}
+}
+
+func TestResponseView_XPathQuery(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/xml; charset=utf-8"}, nil,
+		[]byte(`<?xml version="1.0"?><soap:Envelope xmlns:soap="urn:soap"><soap:Body><item id="1">Ada</item><item id="2">Grace</item></soap:Body></soap:Envelope>`), "1ms", "1B")
+
+	if !strings.Contains(r.bodyEditor.GetContent(), "\n    <item id=\"1\">Ada</item>") {
+		t.Errorf("body not pretty-printed:\n%s", r.bodyEditor.GetContent())
+	}
+	if r.bodyEditor.GetSyntaxType() != "xml" {
+		t.Errorf("syntax = %q, want xml", r.bodyEditor.GetSyntaxType())
+	}
+
+	r = typeKeys(r, "J", "/", "/", "i", "t", "e", "m", "/", "@", "i", "d")
+	values, ok := r.queryResult.([]string)
+	if r.queryErr != nil || !ok || strings.Join(values, ",") != "1,2" {
+		t.Fatalf("live result = %v, err = %v", r.queryResult, r.queryErr)
+	}
+
+	r = typeKeys(r, "enter")
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, nil)
+	if msg, ok := cmd().(CopyToClipboardMsg); !ok || msg.Content != "1\n2" {
+		t.Errorf("y emitted %#v", cmd())
+	}
+
+	r = typeKeys(r, "J", "backspace", "backspace", "x")
+	if r.queryErr == nil {
+		t.Error("expected no match error")
+	}
+
+	// A JSON response afterwards is queried with JSONPath again
+	r = typeKeys(r, "esc")
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil, []byte(`{"id":1}`), "1ms", "1B")
+	if r.xmlBody != nil || r.bodyEditor.GetSyntaxType() != "json" {
+		t.Error("XML state should be reset by a JSON response")
+	}
+}
+
+func TestResponseView_BodyDiff(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"id":7,"name":"Bob"}`), "1ms", "1B")
+
+	dir := t.TempDir()
+	fixture := filepath.Join(dir, "user.json")
+	if err := os.WriteFile(fixture, []byte("{\n  \"id\": 7,\n  \"name\": \"Ada\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := CompareFixture("user.json", fixture, r.CompareBody())().(ResponseFixtureComparedMsg)
+	if !ok || msg.Error != nil || msg.Missing || len(msg.Diff.Changes) != 1 {
+		t.Fatalf("CompareFixture() = %+v", msg)
+	}
+	r.SetBodyDiff(msg.Path, msg.Diff)
+	if view := r.renderBodyDiff(80, 20); !strings.Contains(view, `~ $.name: "Ada" → "Bob"`) {
+		t.Errorf("diff view missing change:\n%s", view)
+	}
+
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")}, nil)
+	if cmd == nil {
+		t.Fatal("w should request overwriting the fixture")
+	}
+	if msg, ok := cmd().(ResponseFixtureOverwriteMsg); !ok || msg.Path != "user.json" {
+		t.Errorf("w emitted %#v", cmd())
+	}
+
+	// Overwriting pretty-prints the body, after which it matches
+	if saved, ok := WriteFixture("user.json", fixture, r.CompareBody())().(ResponseFixtureSavedMsg); !ok || saved.Error != nil {
+		t.Fatalf("WriteFixture() = %+v", saved)
+	}
+	data, _ := os.ReadFile(fixture)
+	if string(data) != "{\n  \"id\": 7,\n  \"name\": \"Bob\"\n}\n" {
+		t.Errorf("fixture = %q", data)
+	}
+	msg, _ = CompareFixture("user.json", fixture, r.CompareBody())().(ResponseFixtureComparedMsg)
+	if !msg.Diff.Equal() {
+		t.Errorf("expected a match after overwrite, got %+v", msg.Diff.Changes)
+	}
+
+	r = typeKeys(r, "esc")
+	if r.bodyDiff != nil {
+		t.Error("esc should close the diff")
+	}
+}
+
+func TestCompareFixture_Missing(t *testing.T) {
+	msg, ok := CompareFixture("missing.json", filepath.Join(t.TempDir(), "missing.json"), []byte("{}"))().(ResponseFixtureComparedMsg)
+	if !ok || !msg.Missing {
+		t.Errorf("CompareFixture() = %+v, want Missing", msg)
+	}
+}
+
+func TestResponseView_EventStream(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/event-stream"}, nil, nil, "1ms", "0B")
+	r.StartEventStream()
+	r.AppendEvent(api.SSEEvent{Event: "message", Data: "first"})
+	r.AppendEvent(api.SSEEvent{ID: "2", Event: "update", Data: "line 1\nline 2"})
+
+	view := r.View(80, 20, true)
+	for _, want := range []string{"● live", "2 events", "update #2 line 1 ⏎ line 2", "x: stop stream"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// The selection follows new events until moved up
+	r = typeKeys(r, "k")
+	r.AppendEvent(api.SSEEvent{Event: "message", Data: "third"})
+	if r.eventsCursor != 0 {
+		t.Errorf("eventsCursor = %d, want 0", r.eventsCursor)
+	}
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, nil)
+	if msg, ok := cmd().(CopyToClipboardMsg); !ok || msg.Content != "first" {
+		t.Errorf("y emitted %#v", cmd())
+	}
+
+	_, cmd = r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, nil)
+	if cmd == nil {
+		t.Fatal("x should stop the stream")
+	}
+	if _, ok := cmd().(EventStreamStopMsg); !ok {
+		t.Errorf("x emitted %#v", cmd())
+	}
+
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/event-stream"}, nil, []byte("data: first\n\n"), "1s", "13B")
+	r.EndEventStream()
+	if r.IsStreaming() || len(r.GetEvents()) != 3 {
+		t.Fatalf("events should stay listed after the stream ends, got %d", len(r.GetEvents()))
+	}
+	if view := r.View(80, 20, true); !strings.Contains(view, "stream closed") {
+		t.Errorf("view missing closed state:\n%s", view)
+	}
+
+	r = typeKeys(r, "r")
+	if view := r.View(80, 20, true); !strings.Contains(view, "data: first") {
+		t.Errorf("r should show the raw body:\n%s", view)
+	}
+
+	r.ClearResponse()
+	if r.GetEvents() != nil {
+		t.Error("ClearResponse should drop the events")
+	}
+}
+
+func TestResponseView_MessageStream(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "bidi streaming", nil, nil, nil, "1ms", "0B")
+	r.StartMessageStream(true)
+	r.AppendStreamMessage(grpc.StreamMessage{Data: `{"name":"hi"}`, Sent: true, At: time.Millisecond})
+	r.AppendStreamMessage(grpc.StreamMessage{Data: `{"message":"hi"}`, At: 2 * time.Millisecond})
+
+	view := r.View(100, 20, true)
+	for _, want := range []string{"2 messages", `→ {"name":"hi"}`, `← {"message":"hi"}`, "enter: send a message"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// enter focuses the send box, where enter sends the typed message
+	r = typeKeys(r, "enter", "{", "}")
+	if !r.IsSendEditing() {
+		t.Fatal("enter should focus the send box")
+	}
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+	if msg, ok := cmd().(GRPCStreamSendMsg); !ok || msg.Body != "{}" {
+		t.Errorf("enter emitted %#v", cmd())
+	}
+
+	r = typeKeys(r, "esc")
+	_, cmd = r.Update(tea.KeyMsg{Type: tea.KeyCtrlD}, nil)
+	if cmd == nil {
+		t.Fatal("ctrl+d should end sending")
+	}
+	if _, ok := cmd().(GRPCStreamCloseSendMsg); !ok {
+		t.Errorf("ctrl+d emitted %#v", cmd())
+	}
+
+	r.EndEventStream()
+	if view := r.View(100, 20, true); strings.Contains(view, "enter: send") {
+		t.Errorf("the send box should close with the stream:\n%s", view)
+	}
+}
+
+func TestResponseView_Redirects(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/plain"}, nil, []byte("done"), "1ms", "4B")
+	r.SetRedirects([]api.RedirectHop{
+		{URL: "http://example.com/old", StatusCode: 301},
+		{URL: "https://example.com/new", StatusCode: 200},
+	})
+
+	headers := r.renderHeadersTab(80, 20)
+	for _, want := range []string{"Redirects (1)", "301", "http://example.com/old", "https://example.com/new", "Content-Type"} {
+		if !strings.Contains(headers, want) {
+			t.Errorf("Headers tab missing %q:\n%s", want, headers)
+		}
+	}
+
+	r.SetResponse(200, "200 OK", nil, nil, nil, "1ms", "0B")
+	if len(r.GetRedirects()) != 0 || strings.Contains(r.renderHeadersTab(80, 20), "Redirects") {
+		t.Error("a new response should clear the redirect chain")
+	}
+}
+
+func TestResponseView_TestsTab(t *testing.T) {
+	r := *NewResponseView()
+	r.SetTestResults([]api.AssertionResult{
+		{Name: "Status is 200", Passed: true, Duration: 2 * time.Millisecond},
+		{Name: "Has token", Passed: false, Message: "Expected undefined to be truthy", Duration: 500 * time.Microsecond},
+	})
+
+	tab := r.renderTestsTab(80, 20)
+	for _, want := range []string{"1", "passed", "failed", "2ms", "Status is 200", "500µs", "Has token"} {
+		if !strings.Contains(tab, want) {
+			t.Errorf("Tests tab missing %q:\n%s", want, tab)
+		}
+	}
+
+	r.testResultsCursor = 1
+	if tab := r.renderTestsTab(80, 20); !strings.Contains(tab, "Expected undefined to be truthy") {
+		t.Errorf("Tests tab should show the failure of the selected test:\n%s", tab)
+	}
+}
+
+func TestResponseView_ConsoleSearch(t *testing.T) {
+	history := api.NewConsoleHistory(10)
+	add := func(url, body string) {
+		history.Add(*api.NewConsoleEntry(&api.Request{Method: api.GET, URL: url}, &api.Response{StatusCode: 200, Body: []byte(body)}, nil, time.Millisecond))
+	}
+	add("http://test.com/orders", `{"id": "ord_42"}`)
+	add("http://test.com/users", `{"name": "ada"}`)
+	add("http://test.com/orders/ord_42", `{"id": "ord_42", "total": 12}`)
+
+	re, _ := api.CompileHistorySearch("ORD_42")
+	r := *NewResponseView()
+	r.ShowConsoleSearch("ORD_42", history.Search(re))
+	if r.GetActiveTab() != "Console" {
+		t.Fatalf("active tab = %q, want Console", r.GetActiveTab())
+	}
+	view := r.consoleView.View(100, 20, history, true)
+	if !strings.Contains(view, `Search "ORD_42" · 2 responses`) || strings.Contains(view, "/users") || !strings.Contains(view, `"total": 12}`) {
+		t.Errorf("search results:\n%s", view)
+	}
+
+	// Enter opens the second match, the oldest entry
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("j")}, {Type: tea.KeyEnter}} {
+		r, _ = r.UpdateWithHistory(key, nil, history)
+	}
+	if entry := r.consoleView.GetSelectedEntry(history); entry == nil || entry.Request.URL != "http://test.com/orders" || !r.consoleView.IsExpanded() {
+		t.Fatalf("selected entry = %+v, want the expanded oldest entry", entry)
+	}
+
+	// Esc collapses the entry, then leaves the search
+	r, _ = r.UpdateWithHistory(tea.KeyMsg{Type: tea.KeyEsc}, nil, history)
+	r, _ = r.UpdateWithHistory(tea.KeyMsg{Type: tea.KeyEsc}, nil, history)
+	if r.consoleView.IsSearching() || r.consoleView.IsExpanded() {
+		t.Error("esc should collapse the entry, then leave the search")
+	}
+	if view := r.consoleView.View(100, 20, history, true); !strings.Contains(view, "/users") {
+		t.Errorf("console should list all entries again:\n%s", view)
+	}
+}
+
+func TestResponseView_HTMLLinks(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/html; charset=utf-8"}, nil, []byte(`<!DOCTYPE html>
+<html><body>
+  <a href="/orders/2">Order 2</a>
+  <form method="post" action="/orders"><input name="qty" value="1"></form>
+</body></html>`), "1ms", "1B")
+
+	if !r.HasLinks() || r.IsLinksView() {
+		t.Fatal("expected links, with the side view closed")
+	}
+	r = typeKeys(r, "o")
+	if !r.IsLinksView() {
+		t.Fatal("o should open the links view")
+	}
+	view := r.renderBodyTab(100, 20)
+	for _, want := range []string{"Links (1)", "Order 2", "/orders/2", "Forms (1)", "POST /orders", "1 fields"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("links view missing %q:\n%s", want, view)
+		}
+	}
+
+	_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+	if msg, ok := cmd().(ResponseFollowLinkMsg); !ok || msg.Link.Href != "/orders/2" {
+		t.Errorf("enter on a link emitted %#v", cmd())
+	}
+	r = typeKeys(r, "j")
+	_, cmd = r.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil)
+	if msg, ok := cmd().(ResponseSubmitFormMsg); !ok || msg.Form.Action != "/orders" {
+		t.Errorf("enter on a form emitted %#v", cmd())
+	}
+
+	r = typeKeys(r, "esc")
+	if r.IsLinksView() {
+		t.Error("esc should close the links view")
+	}
+
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil, []byte(`{"id":1}`), "1ms", "1B")
+	if r.HasLinks() {
+		t.Error("a JSON response should not list links")
+	}
+}
+
+func TestFollowUpRequests(t *testing.T) {
+	page := &format.HTMLPage{}
+	source := &api.CollectionRequest{
+		Headers: []api.KeyValueEntry{
+			{Key: "Content-Type", Value: "application/json", Enabled: true},
+			{Key: "Authorization", Value: "Bearer {{token}}", Enabled: true},
+		},
+		Auth: &api.AuthConfig{Type: "bearer", Token: "{{token}}"},
+	}
+	pageURL := "https://shop.test/orders?page=1"
+
+	link := linkRequest(page, pageURL, format.HTMLLink{Href: "orders/2#items"}, source)
+	if link.Method != api.GET || link.URL != "https://shop.test/orders/2" || link.Name != "/orders/2" {
+		t.Errorf("link request = %s %s (%s)", link.Method, link.URL, link.Name)
+	}
+	if len(link.Headers) != 1 || link.Headers[0].Key != "Authorization" || link.Auth == source.Auth || link.Auth.Token != "{{token}}" {
+		t.Errorf("link request headers = %+v, auth = %+v", link.Headers, link.Auth)
+	}
+
+	fields := []format.HTMLFormField{{Name: "q", Value: "red shoes"}, {Name: "page", Value: "2"}}
+	search := formRequest(page, pageURL, format.HTMLForm{Name: "search", Method: "GET", Action: "/search?old=1", Fields: fields}, nil)
+	if search.Method != api.GET || search.URL != "https://shop.test/search?q=red+shoes&page=2" || search.Name != "search" || search.Body != nil {
+		t.Errorf("GET form request = %s %s (%s)", search.Method, search.URL, search.Name)
+	}
+
+	post := formRequest(page, pageURL, format.HTMLForm{Method: "POST", Enctype: "application/x-www-form-urlencoded", Fields: fields}, source)
+	if post.Method != api.POST || post.URL != pageURL || post.Body.Type != "raw" || post.Body.Content != "q=red+shoes&page=2" {
+		t.Errorf("POST form request = %s %s body %+v", post.Method, post.URL, post.Body)
+	}
+	if ct := post.Headers[len(post.Headers)-1]; ct.Key != "Content-Type" || ct.Value != "application/x-www-form-urlencoded" {
+		t.Errorf("POST form headers = %+v", post.Headers)
+	}
+
+	upload := formRequest(page, pageURL, format.HTMLForm{Method: "POST", Action: "/upload", Enctype: "multipart/form-data", Fields: []format.HTMLFormField{
+		{Name: "title", Value: "Invoice"},
+		{Name: "file", File: true},
+	}}, source)
+	want := []api.FormField{
+		{Key: "title", Value: "Invoice", Enabled: true},
+		{Key: "file", Type: api.FormFieldFile, Enabled: true},
+	}
+	if upload.Body.Type != api.BodyTypeFormData || !reflect.DeepEqual(upload.Body.Content, want) {
+		t.Errorf("multipart form body = %+v", upload.Body)
+	}
+}
+
+func TestResponseView_SaveToFile(t *testing.T) {
+	r := *NewResponseView()
+	if _, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")}, nil); cmd != nil {
+		t.Error("W should do nothing without a response")
+	}
+
+	r.SetResponse(200, "200 OK", map[string]string{"content-disposition": `attachment; filename="logo.png"`}, nil, []byte("\x89PNG\r\n"), "1ms", "6B")
+	if got := r.GetHeader("Content-Disposition"); got != `attachment; filename="logo.png"` {
+		t.Errorf("GetHeader() = %q, want the header whatever its case", got)
+	}
+	for _, tab := range []int{0, 2} {
+		r.tabs.SetActive(tab)
+		_, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")}, nil)
+		if cmd == nil {
+			t.Fatalf("W emitted no command on tab %d", tab)
+		}
+		if _, ok := cmd().(ResponseSaveToFileMsg); !ok {
+			t.Errorf("W on tab %d emitted %#v", tab, cmd())
+		}
+	}
+}
+
+func TestResponseView_LargeBodyPages(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n"
+	body := []byte(strings.Repeat(line, api.MaxDisplayBodySize/100*2+50))
+
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/plain"}, nil, body, "1ms", "2MB")
+	if !r.isPaged() {
+		t.Fatal("a body larger than a page should be paged")
+	}
+	if !strings.Contains(r.renderBodyTab(120, 20), "Showing 0B–") {
+		t.Errorf("Body tab should show the part of the body shown:\n%s", r.renderBodyTab(120, 20))
+	}
+	if r.bodyPageEnd%int64(len(line)) != 0 {
+		t.Errorf("page should end after a complete line, ends at %d", r.bodyPageEnd)
+	}
+
+	first := r.bodyPageEnd
+	r = typeKeys(r, "]", "]", "]")
+	if r.bodyPageEnd != r.bodySize {
+		t.Errorf("] should reach the last page, ends at %d of %d", r.bodyPageEnd, r.bodySize)
+	}
+	r = typeKeys(r, "[", "[", "[")
+	if r.bodyOffset != 0 || r.bodyPageEnd != first {
+		t.Errorf("[ should go back to the first page, got %d-%d", r.bodyOffset, r.bodyPageEnd)
+	}
+}
+
+func TestResponseView_SpooledBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "body")
+	full := []byte(`[` + strings.Repeat(`{"id":1},`, api.MaxDisplayBodySize/4) + `{"id":2}]`)
+	if err := os.WriteFile(file, full, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil, full[:api.MaxDisplayBodySize], "1ms", "2MB")
+	r.SetSpooledBody(file, int64(len(full)))
+	if r.jsonBody != nil || r.HasTable() {
+		t.Error("the beginning of a spooled body should not be parsed")
+	}
+	if r.GetBodyFile() != file || !r.isPaged() {
+		t.Fatal("a spooled body should be paged from its file")
+	}
+	for r.bodyPageEnd < r.bodySize {
+		r = typeKeys(r, "]")
+	}
+	if !strings.HasSuffix(r.bodyEditor.GetContent(), `{"id":2}]`) {
+		t.Error("the last page should be read from the file")
+	}
+}
+
+func TestResponseView_LazyFormat(t *testing.T) {
+	body := []byte(`[` + strings.Repeat(`{"id":1},`, lazyFormatBodySize/9) + `{"id":2}]`)
+
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil, body, "1ms", "300KB")
+	if !r.formatPending || strings.Count(r.bodyEditor.GetContent(), "\n") != 0 {
+		t.Fatal("a large JSON body should be shown as received")
+	}
+	if r.jsonBody == nil {
+		t.Error("a large JSON body should still be queryable")
+	}
+	if !strings.Contains(r.renderBodyTab(120, 20), "F: pretty-print") {
+		t.Error("Body tab should offer to pretty-print the body")
+	}
+	r = typeKeys(r, "F")
+	if r.formatPending || strings.Count(r.bodyEditor.GetContent(), "\n") < 3 {
+		t.Error("F should pretty-print the body")
+	}
+}
+
+func TestResponseView_FormattedBodies(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "text/html; charset=utf-8"}, nil,
+		[]byte(`<html><body><h1>Hi</h1></body></html>`), "1ms", "1B")
+	if r.bodyEditor.GetSyntaxType() != "html" {
+		t.Errorf("syntax = %q, want html", r.bodyEditor.GetSyntaxType())
+	}
+	if want := "<html>\n  <body>\n    <h1>Hi</h1>\n  </body>\n</html>"; r.bodyEditor.GetContent() != want {
+		t.Errorf("HTML body not pretty-printed:\n%s", r.bodyEditor.GetContent())
+	}
+
+	// r shows the body as received, then the formatted body again
+	r = typeKeys(r, "r")
+	if r.bodyEditor.GetContent() != `<html><body><h1>Hi</h1></body></html>` || !strings.Contains(r.bodyNotice(), "Raw body") {
+		t.Errorf("r should show the raw body, got %q", r.bodyEditor.GetContent())
+	}
+	r = typeKeys(r, "r")
+	if !strings.Contains(r.bodyEditor.GetContent(), "\n    <h1>Hi</h1>") || r.bodyNotice() != "" {
+		t.Error("r should show the formatted body again")
+	}
+
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/yaml"}, nil,
+		[]byte("users:\n    - name: Ada\n"), "1ms", "1B")
+	if r.bodyEditor.GetSyntaxType() != "yaml" || r.bodyEditor.GetContent() != "users:\n  - name: Ada" {
+		t.Errorf("YAML body = %q (%s)", r.bodyEditor.GetContent(), r.bodyEditor.GetSyntaxType())
+	}
+
+	// Invalid YAML is shown as received, without the raw toggle
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/yaml"}, nil,
+		[]byte("a: [1, 2"), "1ms", "1B")
+	r = typeKeys(r, "r")
+	if r.bodyEditor.GetContent() != "a: [1, 2" || r.bodyRaw {
+		t.Errorf("invalid YAML = %q, raw = %v", r.bodyEditor.GetContent(), r.bodyRaw)
+	}
+}
+
+func TestResponseView_PollChanges(t *testing.T) {
+	r := *NewResponseView()
+	r.SetPollNotice("Polling every 5s")
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"id":1,"status":"pending"}`), "1ms", "1B")
+	previous := r.BodyText()
+
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"id":1,"status":"done"}`), "1ms", "1B")
+	if changed := r.HighlightChanges(previous); changed != 1 {
+		t.Errorf("HighlightChanges() = %d, want 1", changed)
+	}
+	view := r.renderBodyTab(60, 10)
+	if !strings.Contains(view, "Polling every 5s") || !strings.Contains(view, `┃   "status": "done"`) {
+		t.Errorf("body should show the poll notice and mark the changed line:\n%s", view)
+	}
+
+	// A new response clears the marks
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"id":2}`), "1ms", "1B")
+	if strings.Contains(r.renderBodyTab(60, 10), "┃") {
+		t.Error("marks should be cleared by a new body")
+	}
+}
+
+func TestResponseView_ResponseDiff(t *testing.T) {
+	r := *NewResponseView()
+	r.SetResponse(200, "200 OK", map[string]string{"Content-Type": "application/json"}, nil,
+		[]byte(`{"id":7,"name":"Bob"}`), "1ms", "1B")
+
+	base := &api.Response{StatusCode: 200, Status: "200 OK", Headers: map[string][]string{"Content-Type": {"application/json"}}, Body: []byte(`{"id":7,"name":"Ada"}`)}
+	r.SetResponseDiff("baseline", "response", api.DiffResponses(base, r.CurrentResponse()))
+	if view := r.renderBodyDiff(80, 20); !strings.Contains(view, `~ $.name: "Ada" → "Bob"`) || !strings.Contains(view, "baseline → response") {
+		t.Errorf("diff view missing change:\n%s", view)
+	}
+
+	// Responses cannot be written back like fixtures
+	if _, cmd := r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")}, nil); cmd != nil {
+		t.Errorf("w emitted %#v for a response diff", cmd())
+	}
+
+	r = typeKeys(r, "s")
+	view := r.renderBodyDiff(80, 20)
+	if !strings.Contains(view, "Base") || !strings.Contains(view, `"Ada"`) || strings.Contains(view, "→ \"Bob\"") {
+		t.Errorf("side by side view:\n%s", view)
+	}
+}
+
+func TestModel_SaveResponseValue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	path := filepath.Join(workspace, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "login", Name: "Login", Method: api.POST, URL: "https://shop.example.com/login"},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "login", Type: components.RequestNode}})
+	m = model.(Model)
+
+	model, _ = m.Update(ResponseValueSaveMsg{Path: "$.token", Key: "token", Value: "abc.def"})
+	m = model.(Model)
+	if !m.dialog.IsVisible() {
+		t.Fatal("the variable name should be asked for")
+	}
+	model, _ = m.Update(components.DialogResultMsg{Action: "save_variable", Confirmed: true, Value: "collection:token", Context: "abc.def"})
+	m = model.(Model)
+	saved, err := api.LoadCollection(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Variables["token"] != "abc.def" {
+		t.Errorf("collection:token should save a collection variable, got %v (%q)", saved.Variables, m.statusBar.message)
+	}
+}
@@ -0,0 +1,287 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// RunnerView is the fullscreen panel showing the progress and results of a collection run
+type RunnerView struct {
+	visible  bool
+	title    string
+	runID    int
+	items    []runner.Item
+	results  []runner.RequestResult
+	running  bool
+	canceled bool
+	cursor   int
+	job      bool   // Requests are added as the async job runs
+	jobState string // Progress of the async job, shown in the summary line
+}
+
+// NewRunnerView creates a new runner view
+func NewRunnerView() *RunnerView {
+	return &RunnerView{}
+}
+
+// Start shows the view for a new run of items and returns the run ID
+func (v *RunnerView) Start(title string, items []runner.Item) int {
+	v.runID++
+	v.visible = true
+	v.title = title
+	v.items = items
+	v.results = make([]runner.RequestResult, 0, len(items))
+	v.running = len(items) > 0
+	v.canceled = false
+	v.cursor = 0
+	v.job = false
+	v.jobState = ""
+	return v.runID
+}
+
+// StartJob shows the view for a run of an async job, whose requests are added with
+// AddItem as the job goes, and returns the run ID
+func (v *RunnerView) StartJob(title string) int {
+	runID := v.Start(title, nil)
+	v.running = true
+	v.job = true
+	return runID
+}
+
+// AddItem adds the next request of the async job run runID
+func (v *RunnerView) AddItem(runID int, item runner.Item) {
+	if runID == v.runID {
+		v.items = append(v.items, item)
+	}
+}
+
+// SetJobState sets the progress of the async job shown in the summary line
+func (v *RunnerView) SetJobState(state string) {
+	v.jobState = state
+}
+
+// FinishJob ends the async job run runID
+func (v *RunnerView) FinishJob(runID int) {
+	if runID == v.runID {
+		v.running = false
+	}
+}
+
+// AddResult records the result of the request at index.
+// Results of a previous run are ignored and false is returned.
+func (v *RunnerView) AddResult(runID, index int, result runner.RequestResult) bool {
+	if runID != v.runID || index != len(v.results) {
+		return false
+	}
+	v.results = append(v.results, result)
+	v.cursor = index
+	if len(v.results) == len(v.items) && !v.job {
+		v.running = false
+		v.canceled = false
+	} else if v.canceled {
+		v.running = false
+	}
+	return true
+}
+
+// Next returns the index of the next request to run, if the run continues
+func (v *RunnerView) Next() (int, bool) {
+	if !v.running {
+		return 0, false
+	}
+	return len(v.results), true
+}
+
+// Cancel stops the run once the current request completes
+func (v *RunnerView) Cancel() {
+	if v.running {
+		v.canceled = true
+	}
+}
+
+// Hide closes the view, canceling a run in progress
+func (v *RunnerView) Hide() {
+	v.Cancel()
+	v.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *RunnerView) IsVisible() bool {
+	return v.visible
+}
+
+// IsRunning returns whether requests are still being run
+func (v *RunnerView) IsRunning() bool {
+	return v.running
+}
+
+// IsCanceled returns whether the last run was stopped before completing
+func (v *RunnerView) IsCanceled() bool {
+	return v.canceled
+}
+
+// Title returns the name of the collection or folder being run
+func (v *RunnerView) Title() string {
+	return v.title
+}
+
+// Items returns the requests of the current run
+func (v *RunnerView) Items() []runner.Item {
+	return v.items
+}
+
+// Summary returns the aggregated results so far
+func (v *RunnerView) Summary() runner.Summary {
+	return runner.Summarize(v.results)
+}
+
+// Update handles key input
+func (v *RunnerView) Update(msg tea.KeyMsg) (*RunnerView, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if v.cursor < len(v.results)-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "g":
+		v.cursor = 0
+	case "G":
+		v.cursor = max(len(v.results)-1, 0)
+	case "x":
+		v.Cancel()
+	case "r":
+		if !v.running {
+			return v, func() tea.Msg { return RunnerRerunMsg{} }
+		}
+	case "q", "esc":
+		v.Hide()
+	}
+	return v, nil
+}
+
+// View renders the run progress, one row per request, and the details of the selected request
+func (v *RunnerView) View(width, height int) string {
+	passStyle := lipgloss.NewStyle().Foreground(styles.Green)
+	failStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var result strings.Builder
+
+	// Summary line
+	summary := v.Summary()
+	counts := fmt.Sprintf("%s passed, %s failed",
+		passStyle.Bold(true).Render(fmt.Sprintf("%d", summary.Passed)),
+		failStyle.Bold(true).Render(fmt.Sprintf("%d", summary.Failed)))
+	switch {
+	case v.job && v.running:
+		result.WriteString(fmt.Sprintf("Job: %s · %s", v.jobState, counts))
+	case v.job:
+		result.WriteString(fmt.Sprintf("Job %s · %d requests · %s · %s", v.jobState, len(v.items), counts, formatDuration(summary.Duration)))
+	case v.running:
+		result.WriteString(fmt.Sprintf("Running %d/%d · %s", len(v.results)+1, len(v.items), counts))
+	case v.canceled:
+		result.WriteString(fmt.Sprintf("Stopped after %d/%d · %s · %s", len(v.results), len(v.items), counts, formatDuration(summary.Duration)))
+	default:
+		result.WriteString(fmt.Sprintf("Done: %d requests · %s · %s", len(v.items), counts, formatDuration(summary.Duration)))
+	}
+	result.WriteString("\n")
+	result.WriteString(mutedStyle.Render(strings.Repeat("─", width)))
+	result.WriteString("\n")
+
+	// Reserve space for the summary, separators, details and hints
+	details := v.renderDetails(width)
+	listHeight := max(height-4-lipgloss.Height(details), 1)
+	start := 0
+	if v.cursor >= listHeight {
+		start = v.cursor - listHeight + 1
+	}
+
+	nameWidth := max(width-30, 10)
+	for i := start; i < len(v.items) && i < start+listHeight; i++ {
+		item := v.items[i]
+		icon, status, duration := mutedStyle.Render("·"), "", ""
+		if i < len(v.results) {
+			res := v.results[i]
+			icon = passStyle.Render("✓")
+			if !res.Passed() {
+				icon = failStyle.Render("✗")
+			}
+			if res.StatusCode > 0 {
+				status = fmt.Sprintf("%d", res.StatusCode)
+			} else if res.Err != nil {
+				status = "ERR"
+			}
+			if len(res.Assertions) > 0 {
+				status += fmt.Sprintf(" %d/%d", res.PassedAssertions(), len(res.Assertions))
+			}
+			duration = formatDuration(res.Duration)
+		} else if v.running && i == len(v.results) {
+			icon = lipgloss.NewStyle().Foreground(styles.Yellow).Render("…")
+		}
+
+		name := fmt.Sprintf("%-7s %s", item.Request.Method, item.Name())
+		if len(name) > nameWidth {
+			name = name[:nameWidth-3] + "..."
+		}
+		row := fmt.Sprintf("%s %-*s %-10s %8s", icon, nameWidth, name, status, duration)
+		if i == v.cursor && i < len(v.results) {
+			row = lipgloss.NewStyle().Background(styles.Surface1).Width(width).Render(row)
+		} else if i >= len(v.results) {
+			row = mutedStyle.Render(row)
+		}
+		result.WriteString(row)
+		result.WriteString("\n")
+	}
+
+	if details != "" {
+		result.WriteString(mutedStyle.Render(strings.Repeat("─", width)))
+		result.WriteString("\n")
+		result.WriteString(details)
+		result.WriteString("\n")
+	}
+
+	hint := "j/k: move · x: stop · q: close"
+	if !v.running {
+		hint = "j/k: move · r: run again · q: close"
+	}
+	result.WriteString(hintStyle.Render(hint))
+	return result.String()
+}
+
+// renderDetails renders the error and failed assertions of the selected request
+func (v *RunnerView) renderDetails(width int) string {
+	if v.cursor >= len(v.results) {
+		return ""
+	}
+	res := v.results[v.cursor]
+	if res.Passed() {
+		return ""
+	}
+
+	failStyle := lipgloss.NewStyle().Foreground(styles.Red).Width(max(width, 10))
+	var lines []string
+	if res.Err != nil {
+		lines = append(lines, failStyle.Render("✗ "+res.Err.Error()))
+	}
+	for _, a := range res.Assertions {
+		if a.Passed {
+			continue
+		}
+		line := "✗ " + a.Name
+		if a.Message != "" {
+			line += ": " + a.Message
+		}
+		lines = append(lines, failStyle.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
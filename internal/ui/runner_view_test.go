@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+)
+
+func TestRunnerView_Progress(t *testing.T) {
+	items := []runner.Item{
+		{Request: api.CollectionRequest{Name: "One"}},
+		{Request: api.CollectionRequest{Name: "Two"}},
+		{Request: api.CollectionRequest{Name: "Three"}},
+	}
+
+	v := NewRunnerView()
+	oldRun := v.Start("Shop", items)
+	runID := v.Start("Shop", items)
+
+	if v.AddResult(oldRun, 0, runner.RequestResult{}) {
+		t.Error("results of a previous run should be ignored")
+	}
+	if next, ok := v.Next(); !ok || next != 0 {
+		t.Fatalf("Next() = %d, %v, want 0, true", next, ok)
+	}
+
+	if !v.AddResult(runID, 0, runner.RequestResult{StatusCode: 200}) {
+		t.Fatal("expected result to be recorded")
+	}
+	if next, ok := v.Next(); !ok || next != 1 {
+		t.Fatalf("Next() = %d, %v, want 1, true", next, ok)
+	}
+
+	// x stops the run once the in-flight request completes
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	v.AddResult(runID, 1, runner.RequestResult{Err: errors.New("timeout")})
+	if _, ok := v.Next(); ok || v.IsRunning() || !v.IsCanceled() {
+		t.Error("run should stop after cancel")
+	}
+
+	summary := v.Summary()
+	if summary.Total != 2 || summary.Passed != 1 || summary.Failed != 1 {
+		t.Errorf("Summary() = %+v", summary)
+	}
+
+	_, cmd := v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatal("r should request a new run")
+	}
+	if _, ok := cmd().(RunnerRerunMsg); !ok {
+		t.Errorf("r emitted %#v", cmd())
+	}
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if v.IsVisible() {
+		t.Error("esc should close the runner")
+	}
+}
+
+func TestBuildStoredHTTPRequest(t *testing.T) {
+	src := &api.CollectionRequest{
+		Method: api.POST,
+		URL:    "{{base_url}}/users",
+		Body:   &api.BodyConfig{Type: "json", Content: map[string]interface{}{"name": "{{name}}"}},
+	}
+
+	req, err := BuildStoredHTTPRequest(src, map[string]string{"base_url": "https://example.com", "name": "Ada"}, nil)
+	if err != nil {
+		t.Fatalf("BuildStoredHTTPRequest() error = %v", err)
+	}
+	if req.URL != "https://example.com/users" {
+		t.Errorf("URL = %q", req.URL)
+	}
+	body, ok := req.Body.(map[string]interface{})
+	if !ok || body["name"] != "Ada" {
+		t.Errorf("Body = %#v, want resolved JSON object", req.Body)
+	}
+	if _, ok := src.Body.Content.(map[string]interface{}); !ok {
+		t.Error("source request should not be modified")
+	}
+}
+
+func TestRunnerView_Job(t *testing.T) {
+	v := NewRunnerView()
+	runID := v.StartJob("Reports / job Export")
+	if !v.IsRunning() {
+		t.Fatal("job run should be running before its first request")
+	}
+
+	// Requests are added as the job goes; the run only ends with FinishJob
+	v.AddItem(runID, runner.Item{Request: api.CollectionRequest{Name: "Start export"}})
+	v.AddResult(runID, 0, runner.RequestResult{StatusCode: 202})
+	if !v.IsRunning() {
+		t.Error("job run should keep running once its requests completed")
+	}
+	v.AddItem(runID-1, runner.Item{})
+	if len(v.Items()) != 1 {
+		t.Errorf("items of a previous run should be ignored, got %d items", len(v.Items()))
+	}
+
+	v.SetJobState("done after 1 polls")
+	v.FinishJob(runID)
+	if v.IsRunning() {
+		t.Error("FinishJob should end the run")
+	}
+	if view := v.View(80, 20); !strings.Contains(view, "Job done after 1 polls") {
+		t.Errorf("view should show the job state, got:\n%s", view)
+	}
+}
+
+func TestBuildStoredHTTPRequest_TypedVariables(t *testing.T) {
+	src := &api.CollectionRequest{
+		Method: api.POST,
+		URL:    "https://example.com/search",
+		Body: &api.BodyConfig{Type: "json", Content: map[string]interface{}{
+			"page_size": "{{page_size}}", "exact": "{{exact}}", "filter": "{{filter}}", "query": "{{query}}",
+		}},
+	}
+	vars := map[string]string{"page_size": "20", "exact": "true", "filter": `{"status":"open"}`, "query": "42"}
+	types := map[string]string{"page_size": api.VariableTypeNumber, "exact": api.VariableTypeBoolean, "filter": api.VariableTypeJSON}
+
+	req, err := BuildStoredHTTPRequest(src, vars, types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body.(map[string]interface{})
+	filter, _ := body["filter"].(map[string]interface{})
+	if body["page_size"] != float64(20) || body["exact"] != true || filter["status"] != "open" || body["query"] != "42" {
+		t.Errorf("Body = %#v, want typed values unquoted and strings kept", body)
+	}
+}
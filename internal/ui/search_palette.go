@@ -0,0 +1,291 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// searchPaletteRows is the number of results shown at once
+const searchPaletteRows = 10
+
+// searchPalettePreviewLines is the number of body lines in the preview
+const searchPalettePreviewLines = 6
+
+// SearchPaletteSelectMsg is sent when a request is chosen in the search palette
+type SearchPaletteSelectMsg struct {
+	RequestID string
+}
+
+// SearchPalette is the modal searching the requests of every collection by name, URL,
+// header value and body, previewing the selected one
+type SearchPalette struct {
+	visible     bool
+	query       []rune
+	collections []*api.CollectionFile
+	results     []api.SearchResult
+	cursor      int
+	offset      int
+}
+
+// NewSearchPalette creates a new search palette
+func NewSearchPalette() *SearchPalette {
+	return &SearchPalette{}
+}
+
+// Show opens the palette over collections, searching for query
+func (p *SearchPalette) Show(collections []*api.CollectionFile, query string) {
+	p.visible = true
+	p.collections = collections
+	p.query = []rune(query)
+	p.search()
+}
+
+// Hide closes the palette
+func (p *SearchPalette) Hide() {
+	p.visible = false
+	p.collections = nil
+	p.results = nil
+}
+
+// IsVisible returns whether the palette is visible
+func (p *SearchPalette) IsVisible() bool {
+	return p.visible
+}
+
+// Query returns the search query
+func (p *SearchPalette) Query() string {
+	return string(p.query)
+}
+
+// Results returns the requests matching the query, the best first
+func (p *SearchPalette) Results() []api.SearchResult {
+	return p.results
+}
+
+// search runs the query again and selects the best result
+func (p *SearchPalette) search() {
+	p.results = api.SearchRequests(p.collections, string(p.query))
+	p.cursor = 0
+	p.offset = 0
+}
+
+// Update handles key presses: typed text refines the search, up/down (or Ctrl+N/P)
+// move, Enter chooses the request and Esc closes
+func (p *SearchPalette) Update(msg tea.KeyMsg) (*SearchPalette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		p.Hide()
+	case "enter":
+		if p.cursor >= len(p.results) {
+			return p, nil
+		}
+		id := p.results[p.cursor].Request.ID
+		p.Hide()
+		return p, func() tea.Msg { return SearchPaletteSelectMsg{RequestID: id} }
+	case "down", "ctrl+n", "ctrl+j":
+		if p.cursor < len(p.results)-1 {
+			p.cursor++
+		}
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "backspace":
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.search()
+		}
+	case "ctrl+u":
+		p.query = nil
+		p.search()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			p.query = append(p.query, msg.Runes...)
+			p.search()
+		}
+	}
+
+	// Keep the cursor in the visible window
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+searchPaletteRows {
+		p.offset = p.cursor - searchPaletteRows + 1
+	}
+	return p, nil
+}
+
+// View renders the query, the results and the preview of the selected request
+func (p *SearchPalette) View(screenWidth, screenHeight int) string {
+	if !p.visible {
+		return ""
+	}
+
+	width := min(96, screenWidth-4)
+	innerWidth := width - 4
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Lavender).
+		Width(innerWidth).
+		Align(lipgloss.Center)
+	promptStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true)
+	pathStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	fieldStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	selectedStyle := lipgloss.NewStyle().
+		Background(styles.Surface0).
+		Foreground(styles.Lavender).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Surface1)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Search Collections"))
+	content.WriteString("\n")
+	content.WriteString(promptStyle.Render("> ") + string(p.query) + "█")
+	content.WriteString("\n\n")
+
+	switch {
+	case len(p.query) == 0:
+		content.WriteString(helpStyle.Render("Type to search request names, URLs, header values and bodies"))
+		content.WriteString("\n")
+	case len(p.results) == 0:
+		content.WriteString(helpStyle.Render("No request matches"))
+		content.WriteString("\n")
+	}
+
+	end := min(p.offset+searchPaletteRows, len(p.results))
+	for i := p.offset; i < end; i++ {
+		result := p.results[i]
+		method := fmt.Sprintf("%-6s", result.Request.Method)
+		location := strings.Join(append([]string{result.Collection}, result.Folder...), " / ")
+		field := ""
+		if result.Field != api.SearchFieldName {
+			field = " [" + result.Field + "]"
+		}
+		// The name and the location share the width left by the marker, method and field
+		available := innerWidth - len(method) - len(field) - 5
+		name := truncateHistoryValue(result.Request.Name, max(available-min(lipgloss.Width(location), available/2), 4))
+		location = truncateHistoryValue(location, max(available-lipgloss.Width(name), 4))
+
+		if i == p.cursor {
+			content.WriteString(selectedStyle.Render("▸ " + method + " " + name + field + "  " + location))
+		} else {
+			methodStyle := lipgloss.NewStyle().Foreground(styles.Method(string(result.Request.Method)).Bg).Bold(true)
+			content.WriteString("  " + methodStyle.Render(method) + " " + name + fieldStyle.Render(field) + "  " + pathStyle.Render(location))
+		}
+		content.WriteString("\n")
+	}
+	if len(p.results) > searchPaletteRows {
+		content.WriteString(pathStyle.Render(fmt.Sprintf("%d/%d", p.cursor+1, len(p.results))))
+		content.WriteString("\n")
+	}
+
+	if p.cursor < len(p.results) {
+		content.WriteString(mutedStyle.Render(strings.Repeat("─", innerWidth)))
+		content.WriteString("\n")
+		content.WriteString(p.preview(p.results[p.cursor], innerWidth))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(helpStyle.Render("↑/↓: select · enter: open · ctrl+u: clear · esc: close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Lavender).
+		Padding(1, 2).
+		Width(width).
+		Render(content.String())
+}
+
+// preview renders the URL, the matching header or body line and the start of the body
+// of the result's request
+func (p *SearchPalette) preview(result api.SearchResult, width int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	matchStyle := lipgloss.NewStyle().Foreground(styles.Peach)
+	codeStyle := lipgloss.NewStyle().Foreground(styles.Text)
+
+	req := result.Request
+	lines := []string{truncateHistoryValue(string(req.Method)+" "+req.URL, width)}
+	if result.Field == api.SearchFieldHeader || result.Field == api.SearchFieldBody {
+		lines = append(lines, labelStyle.Render(result.Field+": ")+matchStyle.Render(truncateHistoryValue(result.Snippet, width-len(result.Field)-2)))
+	}
+	if req.Body != nil && req.Body.Content != nil {
+		body := strings.Split(strings.TrimSpace(bodyPreviewText(req.Body)), "\n")
+		for i, line := range body {
+			if i == searchPalettePreviewLines {
+				lines = append(lines, labelStyle.Render("…"))
+				break
+			}
+			lines = append(lines, codeStyle.Render(truncateHistoryValue(line, width)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bodyPreviewText returns the body content as text: strings as written, other content
+// as indented JSON
+func bodyPreviewText(body *api.BodyConfig) string {
+	if s, ok := body.Content.(string); ok {
+		return s
+	}
+	data, err := json.MarshalIndent(body.Content, "", "  ")
+	if err != nil {
+		return fmt.Sprint(body.Content)
+	}
+	return string(data)
+}
+
+// showSearchPalette opens the search palette over the collections of the workspace
+func (m Model) showSearchPalette(query string) (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections().GetCollections()
+	if len(collections) == 0 {
+		m.statusBar.Info("No collections to search")
+		return m, nil
+	}
+	m.searchPalette.Show(collections, query)
+	return m, nil
+}
+
+// openSearchResult selects the request chosen in the search palette in the tree and
+// loads it in the request panel
+func (m Model) openSearchResult(requestID string) (tea.Model, tea.Cmd) {
+	collections := m.leftPanel.GetCollections()
+	req := collections.FindRequestByID(requestID)
+	if req == nil {
+		m.statusBar.Error(fmt.Errorf("request %s no longer exists", requestID))
+		return m, nil
+	}
+
+	if req.ID != m.requestPanel.GetCurrentRequestID() && m.loadsOver(req.ID) {
+		return m.confirmDiscard(unsavedContext{proceed: func(m Model) (tea.Model, tea.Cmd) { return m.openSearchResult(requestID) }})
+	}
+	m.leftPanel.SetActiveTab(CollectionsTab)
+	revealed := collections.RevealNode(requestID)
+	// Keep unsaved edits when the request is already loaded
+	if req.ID != m.requestPanel.GetCurrentRequestID() {
+		if !m.showRequestTab(req.ID) {
+			m.requestPanel.LoadCollectionRequest(req)
+		}
+		m.statusBar.SetMethod(string(req.Method))
+		if revealed {
+			m.statusBar.SetBreadcrumb(buildBreadcrumb(collections.Selected())...)
+		}
+	}
+	m.activePanel = RequestPanel
+	if m.isFullscreen {
+		m.fullscreenPanel = m.activePanel
+	}
+	return m, m.markSessionDirty()
+}
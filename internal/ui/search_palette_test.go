@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+func TestSearchPalette(t *testing.T) {
+	collections := []*api.CollectionFile{{
+		Name: "Shop",
+		Folders: []api.Folder{{
+			Name: "Orders",
+			Requests: []api.CollectionRequest{
+				{ID: "create", Name: "Create order", Method: api.POST, URL: "{{base_url}}/orders",
+					Body: &api.BodyConfig{Type: "json", Content: map[string]interface{}{"sku": "widget-42"}}},
+				{ID: "list", Name: "List orders", Method: api.GET, URL: "{{base_url}}/orders"},
+			},
+		}},
+	}}
+
+	p := NewSearchPalette()
+	p.Show(collections, "")
+	for _, r := range "widget" {
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if p.Query() != "widget" || len(p.Results()) != 1 {
+		t.Fatalf("query %q found %d results, want the request whose body holds widget", p.Query(), len(p.Results()))
+	}
+
+	view := p.View(100, 40)
+	for _, want := range []string{"Create order [body]", "Shop / Orders", `"sku": "widget-42"`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should show %q:\n%s", want, view)
+		}
+	}
+
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range "orders" {
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.IsVisible() {
+		t.Error("Enter should close the palette")
+	}
+	if msg, ok := cmd().(SearchPaletteSelectMsg); !ok || msg.RequestID != "create" {
+		t.Errorf("Enter on the second result = %#v, want Create order", msg)
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// secretMinLength is the length of the shortest secret value masked on screen:
+// shorter values would mask unrelated text
+const secretMinLength = 4
+
+// ansiSequencePattern matches the CSI and OSC escape sequences of a rendered frame
+var ansiSequencePattern = regexp.MustCompile(`\x1b\[[0-9;:?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+
+// protectsSecrets returns true if secret values must be hidden from the screen when
+// the terminal loses focus and when LazyCurl exits
+func (m Model) protectsSecrets() bool {
+	mode := m.globalConfig.ProtectSecrets
+	return mode == config.ProtectSecretsMask || mode == config.ProtectSecretsClear
+}
+
+// secretValues returns the values of the secret variables of every environment,
+// longest first so that a value containing another one is masked whole
+func (m Model) secretValues() []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, env := range m.leftPanel.GetEnvironments().GetEnvironments() {
+		for _, v := range env.Variables {
+			if v.Secret && len(v.Value) >= secretMinLength && !seen[v.Value] {
+				seen[v.Value] = true
+				values = append(values, v.Value)
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// maskSecrets overwrites every secret value of a rendered frame with asterisks of
+// the same width, so the layout is kept. A value split by styling is masked in the
+// frame without styling.
+func maskSecrets(frame string, secrets []string) string {
+	if len(secrets) == 0 {
+		return frame
+	}
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, strings.Repeat("*", lipgloss.Width(secret)))
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	masked := replacer.Replace(frame)
+	plain := ansiSequencePattern.ReplaceAllString(masked, "")
+	for _, secret := range secrets {
+		if strings.Contains(plain, secret) {
+			return replacer.Replace(plain)
+		}
+	}
+	return masked
+}
+
+// renderHiddenScreen renders the screen shown instead of the panels while the
+// terminal is not focused, with protect_secrets: clear
+func (m Model) renderHiddenScreen() string {
+	text := lipgloss.NewStyle().Foreground(styles.Subtext0).Render("LazyCurl is hidden while the terminal is not focused")
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, text)
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	secrets := []string{"s3cr3t-token-long", "s3cr3t"}
+
+	// Values are masked with the same width, longest first
+	frame := "\x1b[32mAuthorization: Bearer s3cr3t-token-long\x1b[0m │ pw=s3cr3t"
+	got := maskSecrets(frame, secrets)
+	want := "\x1b[32mAuthorization: Bearer *****************\x1b[0m │ pw=******"
+	if got != want {
+		t.Errorf("maskSecrets() = %q, want %q", got, want)
+	}
+
+	// A value split by styling is masked in the frame without styling
+	split := "token: \x1b[1ms3cr\x1b[0m3t-token-long"
+	if got := maskSecrets(split, secrets); got != "token: *****************" {
+		t.Errorf("maskSecrets() = %q, want the plain masked frame", got)
+	}
+
+	if got := maskSecrets(frame, nil); got != frame {
+		t.Error("maskSecrets() without secrets should keep the frame")
+	}
+	if strings.Contains(maskSecrets("a\x1b]8;;https://x/s3cr3t\x07link\x1b]8;;\x07", secrets), "s3cr3t") {
+		t.Error("maskSecrets() should mask values inside escape sequences too")
+	}
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// pendingSend is a request sent from the Request panel or the Console, from the send
+// to its response
+type pendingSend struct {
+	id            int
+	requestID     string                 // Collection request sent, "" for unsaved requests
+	request       *api.Request           // Request built with the active environment
+	source        *api.CollectionRequest // Unresolved form of request
+	variables     *api.VariableSnapshot  // Variable values request was built with
+	preRequest    string                 // Script run before sending
+	postResponse  string                 // Script run on the response
+	scriptRequest *api.ScriptRequest     // Request as the pre-request script left it
+	poll          int                    // Poll the send belongs to (:poll), 0 for other sends
+	tab           *requestTab            // Tab whose panels show the response
+	start         time.Time
+}
+
+// sendTracker follows the sends in flight. The panels of a tab show its latest send;
+// the responses of earlier sends are only logged. Sends of a request already in flight
+// can wait in a queue until it completes.
+type sendTracker struct {
+	seq      int                 // ID of the latest send
+	latest   map[*requestTab]int // ID of the latest send of each tab
+	inFlight map[int]*pendingSend
+	queue    []*pendingSend
+}
+
+// newSendTracker creates a tracker without sends
+func newSendTracker() *sendTracker {
+	return &sendTracker{latest: make(map[*requestTab]int), inFlight: make(map[int]*pendingSend)}
+}
+
+// start records send as the latest send in flight of its tab and returns its ID
+func (t *sendTracker) start(send *pendingSend) int {
+	t.seq++
+	send.id = t.seq
+	send.start = time.Now()
+	t.inFlight[send.id] = send
+	t.latest[send.tab] = send.id
+	return send.id
+}
+
+// finish removes a send from the sends in flight. It returns the send, nil when it is
+// not in flight, and whether it is the latest send of its tab.
+func (t *sendTracker) finish(id int) (*pendingSend, bool) {
+	send, ok := t.inFlight[id]
+	if !ok {
+		return nil, false
+	}
+	delete(t.inFlight, id)
+	latest := t.latest[send.tab] == id
+	if latest {
+		delete(t.latest, send.tab)
+	}
+	return send, latest
+}
+
+// isLatest reports whether id is the ID of the latest send of its tab
+func (t *sendTracker) isLatest(id int) bool {
+	send, ok := t.inFlight[id]
+	return ok && t.latest[send.tab] == id
+}
+
+// busy reports whether a send of a request is in flight
+func (t *sendTracker) busy(requestID string) bool {
+	for _, send := range t.inFlight {
+		if send.requestID == requestID {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue adds a send of a request in flight to the queue and returns the number of
+// sends of that request waiting
+func (t *sendTracker) enqueue(send *pendingSend) int {
+	t.queue = append(t.queue, send)
+	waiting := 0
+	for _, queued := range t.queue {
+		if queued.requestID == send.requestID {
+			waiting++
+		}
+	}
+	return waiting
+}
+
+// next removes the first queued send of a request from the queue and returns it, nil
+// when none waits
+func (t *sendTracker) next(requestID string) *pendingSend {
+	for i, send := range t.queue {
+		if send.requestID == requestID {
+			t.queue = append(t.queue[:i], t.queue[i+1:]...)
+			return send
+		}
+	}
+	return nil
+}
+
+// counts returns the number of sends in flight and queued
+func (t *sendTracker) counts() (inFlight, queued int) {
+	return len(t.inFlight), len(t.queue)
+}
+
+// withSendID stamps the result of a send command with the ID of the send
+func withSendID(cmd tea.Cmd, id int) tea.Cmd {
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case HTTPResponseMsg:
+			msg.SendID = id
+			return msg
+		case EventStreamStartedMsg:
+			msg.SendID = id
+			return msg
+		case GRPCStreamStartedMsg:
+			msg.SendID = id
+			return msg
+		case PreRequestScriptResultMsg:
+			msg.SendID = id
+			return msg
+		default:
+			return msg
+		}
+	}
+}
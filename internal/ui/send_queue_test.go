@@ -0,0 +1,51 @@
+package ui
+
+import "testing"
+
+func TestSendTracker(t *testing.T) {
+	tracker := newSendTracker()
+
+	first := tracker.start(&pendingSend{requestID: "req_1"})
+	second := tracker.start(&pendingSend{requestID: "req_2"})
+	if !tracker.busy("req_1") || !tracker.busy("req_2") || tracker.busy("req_3") {
+		t.Error("busy() should report the requests with a send in flight")
+	}
+	if tracker.isLatest(first) || !tracker.isLatest(second) {
+		t.Error("isLatest() should only report the last send started")
+	}
+
+	// A queued send waits for the send of its request
+	if waiting := tracker.enqueue(&pendingSend{requestID: "req_1"}); waiting != 1 {
+		t.Errorf("enqueue() = %d waiting, want 1", waiting)
+	}
+	if waiting := tracker.enqueue(&pendingSend{requestID: "req_1"}); waiting != 2 {
+		t.Errorf("enqueue() = %d waiting, want 2", waiting)
+	}
+	if inFlight, queued := tracker.counts(); inFlight != 2 || queued != 2 {
+		t.Errorf("counts() = %d, %d, want 2, 2", inFlight, queued)
+	}
+
+	send, latest := tracker.finish(first)
+	if send == nil || latest {
+		t.Fatalf("finish(first) = %v, %v, want the send, not latest", send, latest)
+	}
+	if tracker.busy("req_1") {
+		t.Error("busy() should be false once the send of the request finished")
+	}
+	if next := tracker.next("req_2"); next != nil {
+		t.Error("next() should not return the sends of other requests")
+	}
+	if next := tracker.next("req_1"); next == nil || next.requestID != "req_1" {
+		t.Errorf("next() = %v, want the queued send of req_1", next)
+	}
+	if _, queued := tracker.counts(); queued != 1 {
+		t.Errorf("counts() = %d queued, want 1", queued)
+	}
+
+	if send, latest := tracker.finish(second); send == nil || !latest {
+		t.Errorf("finish(second) = %v, %v, want the latest send", send, latest)
+	}
+	if send, _ := tracker.finish(second); send != nil {
+		t.Error("finish() should return nil for a send no longer in flight")
+	}
+}
@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/clipboard"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/stats"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// SettingChangedMsg is sent when a setting is edited in the settings view
+type SettingChangedMsg struct {
+	Setting config.Setting
+	Value   string
+}
+
+// SettingsView is the fullscreen panel editing the global and workspace configs
+type SettingsView struct {
+	visible   bool
+	global    *config.GlobalConfig
+	workspace *config.WorkspaceConfig
+	selected  int    // Index in config.Settings
+	offset    int    // First visible row
+	editing   bool   // The selected text setting is being edited
+	input     []rune // Value being edited
+}
+
+// NewSettingsView creates a new settings view
+func NewSettingsView() *SettingsView {
+	return &SettingsView{}
+}
+
+// Show displays the settings of the global and workspace configs
+func (v *SettingsView) Show(global *config.GlobalConfig, workspace *config.WorkspaceConfig) {
+	v.visible = true
+	v.global = global
+	v.workspace = workspace
+	v.editing = false
+}
+
+// Hide closes the view
+func (v *SettingsView) Hide() {
+	v.visible = false
+	v.editing = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *SettingsView) IsVisible() bool {
+	return v.visible
+}
+
+// Selected returns the selected setting
+func (v *SettingsView) Selected() config.Setting {
+	return config.Settings[v.selected]
+}
+
+// Update handles key input. Enter toggles on/off settings, cycles through the options
+// of choices and edits text settings; the new value is sent as a SettingChangedMsg.
+func (v *SettingsView) Update(msg tea.KeyMsg) (*SettingsView, tea.Cmd) {
+	if v.editing {
+		return v.updateInput(msg)
+	}
+
+	setting := v.Selected()
+	switch msg.String() {
+	case "q", "esc":
+		v.Hide()
+	case "j", "down":
+		v.selected = min(v.selected+1, len(config.Settings)-1)
+	case "k", "up":
+		v.selected = max(v.selected-1, 0)
+	case "g", "home":
+		v.selected = 0
+	case "G", "end":
+		v.selected = len(config.Settings) - 1
+	case "enter", " ":
+		value := setting.Get(v.global, v.workspace)
+		switch setting.Kind {
+		case config.SettingBool:
+			if value == config.SettingOn {
+				return v, settingChanged(setting, config.SettingOff)
+			}
+			return v, settingChanged(setting, config.SettingOn)
+		case config.SettingChoice:
+			next := (slices.Index(setting.Options, value) + 1) % len(setting.Options)
+			return v, settingChanged(setting, setting.Options[next])
+		default:
+			v.editing = true
+			v.input = []rune(value)
+		}
+	}
+	return v, nil
+}
+
+// updateInput handles key input while a text setting is edited
+func (v *SettingsView) updateInput(msg tea.KeyMsg) (*SettingsView, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.editing = false
+	case tea.KeyEnter:
+		v.editing = false
+		return v, settingChanged(v.Selected(), string(v.input))
+	case tea.KeyBackspace:
+		if len(v.input) > 0 {
+			v.input = v.input[:len(v.input)-1]
+		}
+	case tea.KeyCtrlU:
+		v.input = nil
+	case tea.KeySpace:
+		v.input = append(v.input, ' ')
+	case tea.KeyRunes:
+		v.input = append(v.input, msg.Runes...)
+	}
+	return v, nil
+}
+
+// settingChanged returns a command sending a SettingChangedMsg
+func settingChanged(setting config.Setting, value string) tea.Cmd {
+	return func() tea.Msg {
+		return SettingChangedMsg{Setting: setting, Value: value}
+	}
+}
+
+// View renders the settings grouped by config file, with the description of the
+// selected one
+func (v *SettingsView) View(width, height int) string {
+	keyStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	unsetStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	groupStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Lavender).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	keyWidth := 0
+	for _, setting := range config.Settings {
+		keyWidth = max(keyWidth, len(setting.Key))
+	}
+
+	// Rows of settings, with a heading before each config file
+	var rows []string
+	selectedRow := 0
+	scope := ""
+	for i, setting := range config.Settings {
+		if setting.Scope != scope {
+			scope = setting.Scope
+			if len(rows) > 0 {
+				rows = append(rows, "")
+			}
+			rows = append(rows, groupStyle.Render(settingsScopeTitle(scope)))
+		}
+
+		value := setting.Get(v.global, v.workspace)
+		rendered := valueStyle.Render(value)
+		switch {
+		case i == v.selected && v.editing:
+			rendered = valueStyle.Render(string(v.input) + "█")
+		case value == "":
+			rendered = unsetStyle.Render("(not set)")
+		}
+		line := fmt.Sprintf("%-*s  ", keyWidth, setting.Key)
+		if i == v.selected {
+			selectedRow = len(rows)
+			rows = append(rows, selectedStyle.Render(line)+rendered)
+		} else {
+			rows = append(rows, keyStyle.Render(line)+rendered)
+		}
+	}
+
+	// Keep the selected row in view, leaving room for the description and hints
+	visible := max(height-3, 1)
+	if selectedRow < v.offset {
+		v.offset = selectedRow
+	}
+	if selectedRow >= v.offset+visible {
+		v.offset = selectedRow - visible + 1
+	}
+	end := min(v.offset+visible, len(rows))
+
+	var result strings.Builder
+	for _, row := range rows[v.offset:end] {
+		result.WriteString(row)
+		result.WriteString("\n")
+	}
+	result.WriteString("\n")
+
+	setting := v.Selected()
+	desc := setting.Desc
+	if setting.Kind == config.SettingChoice {
+		desc += ": " + strings.Join(setting.Options, ", ")
+	}
+	result.WriteString(mutedStyle.Render(desc))
+	result.WriteString("\n")
+	if v.editing {
+		result.WriteString(hintStyle.Render("enter: save · esc: cancel · ctrl+u: clear"))
+	} else {
+		result.WriteString(hintStyle.Render("j/k: select · enter: edit · q: close"))
+	}
+	return result.String()
+}
+
+// settingsScopeTitle returns the heading of the settings saved to the config of scope
+func settingsScopeTitle(scope string) string {
+	if scope == config.ScopeWorkspace {
+		return "Workspace (.lazycurl/config.yaml)"
+	}
+	return "Global (" + config.GetGlobalConfigPath() + ")"
+}
+
+// handleSettingChanged sets a setting edited in the settings view, applies it without
+// restarting and saves the config it belongs to. A value that does not apply, such as
+// an unknown theme or an invalid proxy, leaves the config unchanged.
+func (m Model) handleSettingChanged(msg SettingChangedMsg) (tea.Model, tea.Cmd) {
+	setting := msg.Setting
+	previous := setting.Get(m.globalConfig, m.workspaceConfig)
+	if err := setting.Set(m.globalConfig, m.workspaceConfig, msg.Value); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if err := m.applySetting(setting); err != nil {
+		_ = setting.Set(m.globalConfig, m.workspaceConfig, previous)
+		m.statusBar.Error(fmt.Errorf("%s: %w", setting.Key, err))
+		return m, nil
+	}
+
+	var err error
+	if setting.Scope == config.ScopeWorkspace {
+		err = m.workspaceConfig.Save(m.workspacePath)
+	} else {
+		err = m.globalConfig.Save()
+	}
+	if err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	value := setting.Get(m.globalConfig, m.workspaceConfig)
+	if value == "" {
+		value = "(not set)"
+	}
+	m.statusBar.Success("Saved", setting.Key+" = "+value)
+	if setting.Key == "lock.idle" {
+		return m, m.scheduleLockCheck()
+	}
+	return m, nil
+}
+
+// applySetting applies the value of a setting to the running app. Settings read when
+// used, such as duplicate_sends, need nothing.
+func (m *Model) applySetting(setting config.Setting) error {
+	switch setting.Key {
+	case "theme.name":
+		theme, err := loadTheme(m.globalConfig.Theme)
+		if theme == nil {
+			return err
+		}
+		styles.Apply(theme)
+
+	case "proxy.url", "proxy.no_proxy":
+		var proxy *api.Proxy
+		if cfg := config.EffectiveProxy(m.globalConfig, m.workspaceConfig); cfg != nil {
+			proxy = &api.Proxy{URL: cfg.URL, NoProxy: cfg.NoProxy}
+			if err := proxy.Validate(); err != nil {
+				return err
+			}
+		}
+		api.DefaultProxy = proxy
+
+	case "tls.insecure_skip_verify":
+		var tlsConfig *api.TLSConfig
+		if m.workspaceConfig.TLS != nil {
+			tlsConfig = NewTLSConfig(m.workspacePath, m.workspaceConfig.TLS)
+			if err := tlsConfig.Validate(); err != nil {
+				return err
+			}
+		}
+		api.DefaultTLS = tlsConfig
+
+	case "clipboard":
+		clipboard.Init(m.globalConfig.Clipboard, os.Stdout)
+
+	case "units.seconds_from", "units.sizes", "units.decimal_separator":
+		api.DefaultUnits = displayUnits(m.globalConfig.Units)
+
+	case "name":
+		m.statusBar.SetWorkspace(m.workspaceConfig.Name)
+
+	case "stats":
+		if !m.workspaceConfig.Stats {
+			m.stats = nil
+		} else if m.stats == nil {
+			m.stats, _ = stats.Load(m.workspacePath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// selectSetting selects the setting of scope with the given key in the settings view
+func selectSetting(t *testing.T, v *SettingsView, scope, key string) {
+	t.Helper()
+	i := slices.IndexFunc(config.Settings, func(s config.Setting) bool { return s.Scope == scope && s.Key == key })
+	if i < 0 {
+		t.Fatalf("no %s setting %s", scope, key)
+	}
+	v.selected = i
+}
+
+// editSetting sends keys to the settings view and applies the setting it changes
+func editSetting(t *testing.T, m Model, keys ...tea.KeyMsg) Model {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, key := range keys {
+		m.settingsView, cmd = m.settingsView.Update(key)
+	}
+	if cmd == nil {
+		t.Fatal("the last key should change the setting")
+	}
+	model, _ := m.Update(cmd())
+	return model.(Model)
+}
+
+func TestModel_SettingsView(t *testing.T) {
+	defer styles.Apply(styles.Current())
+	defer func(proxy *api.Proxy) { api.DefaultProxy = proxy }(api.DefaultProxy)
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model, _ = model.Update(CommandExecuteMsg{Command: CmdSettings})
+	m = model.(Model)
+	if !m.settingsView.IsVisible() {
+		t.Fatal(":settings should open the settings view")
+	}
+	view := PlainSnapshot(m.View())
+	for _, want := range []string{"Global (", "Workspace (.lazycurl/config.yaml)", "theme.name", "dark", "tls.insecure_skip_verify"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("the settings view should show %q:\n%s", want, view)
+		}
+	}
+
+	// Text settings are edited in place and applied without restarting
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	selectSetting(t, m.settingsView, config.ScopeGlobal, "theme.name")
+	m = editSetting(t, m, enter, tea.KeyMsg{Type: tea.KeyCtrlU}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("nord")}, enter)
+	if styles.Current().Name() != styles.ThemeNord {
+		t.Errorf("theme.name should switch the theme, got %s", styles.Current().Name())
+	}
+	saved, err := config.LoadGlobalConfig()
+	if err != nil || saved.Theme.Name != styles.ThemeNord {
+		t.Errorf("theme.name should be saved to the global config, got %+v, %v", saved, err)
+	}
+
+	// Values that do not apply leave the config unchanged
+	m = editSetting(t, m, enter, tea.KeyMsg{Type: tea.KeyCtrlU}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("solarized")}, enter)
+	if m.globalConfig.Theme.Name != styles.ThemeNord || !strings.Contains(m.statusBar.message, "unknown theme") {
+		t.Errorf("an unknown theme should be reported and not kept, got %q, %q", m.globalConfig.Theme.Name, m.statusBar.message)
+	}
+
+	selectSetting(t, m.settingsView, config.ScopeWorkspace, "proxy.url")
+	m = editSetting(t, m, enter, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("http://proxy.local:3128")}, enter)
+	if m.workspaceConfig.Proxy == nil || api.DefaultProxy == nil || api.DefaultProxy.URL != "http://proxy.local:3128" {
+		t.Errorf("proxy.url should set the proxy of the workspace, got %+v", m.workspaceConfig.Proxy)
+	}
+	ws, err := config.LoadWorkspaceConfig(workspace)
+	if err != nil || ws.Proxy == nil || ws.Proxy.URL != "http://proxy.local:3128" {
+		t.Errorf("proxy.url should be saved to the workspace config, got %+v, %v", ws, err)
+	}
+
+	// Enter toggles on/off settings and cycles through choices
+	selectSetting(t, m.settingsView, config.ScopeWorkspace, "isolate_sessions")
+	m = editSetting(t, m, enter)
+	if !m.workspaceConfig.IsolateSessions {
+		t.Error("enter should turn isolate_sessions on")
+	}
+	selectSetting(t, m.settingsView, config.ScopeGlobal, "duplicate_sends")
+	m = editSetting(t, m, enter)
+	if m.globalConfig.DuplicateSends != config.DuplicateSendsQueue {
+		t.Errorf("enter should select the next option, got %q", m.globalConfig.DuplicateSends)
+	}
+
+	defer func(units api.Units) { api.DefaultUnits = units }(api.DefaultUnits)
+	selectSetting(t, m.settingsView, config.ScopeGlobal, "units.sizes")
+	m = editSetting(t, m, enter)
+	if api.DefaultUnits.Sizes != config.SizesIEC || formatBytes(2048) != "2.0KiB" {
+		t.Errorf("units.sizes should apply to the sizes shown, got %+v", api.DefaultUnits)
+	}
+
+	selectSetting(t, m.settingsView, config.ScopeGlobal, "script.timeout")
+	m = editSetting(t, m, enter, tea.KeyMsg{Type: tea.KeyCtrlU}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("soon")}, enter)
+	if !strings.Contains(m.statusBar.message, "not a duration") || m.globalConfig.Script.Timeout != config.DefaultScriptTimeout {
+		t.Errorf("an invalid duration should be reported, got %q", m.statusBar.message)
+	}
+
+	m.settingsView, _ = m.settingsView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if m.settingsView.IsVisible() {
+		t.Error("q should close the settings view")
+	}
+}
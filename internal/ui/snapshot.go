@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// SnapshotOptions describes the panel rendered by Snapshot
+type SnapshotOptions struct {
+	Panel     PanelType
+	Tab       string        // Tab of the Request or Response panel; the current one when empty
+	RequestID string        // Request selected in the tree and loaded in the Request panel (optional)
+	Response  *api.Response // Response shown in the Response panel (optional)
+	Err       error         // Send failure shown in the Response panel when there is no response
+	Width     int
+	Height    int
+}
+
+// Snapshot renders one panel alone at the given size, as it shows in fullscreen, for
+// documentation and bug reports. The values of secret variables are masked.
+func (m Model) Snapshot(opts SnapshotOptions) (string, error) {
+	if opts.Width < CompactMinWidth || opts.Height < CompactMinHeight {
+		return "", fmt.Errorf("snapshot size %dx%d is below %dx%d", opts.Width, opts.Height, CompactMinWidth, CompactMinHeight)
+	}
+
+	url := ""
+	if opts.RequestID != "" {
+		collections := m.leftPanel.GetCollections()
+		req := collections.FindRequestByID(opts.RequestID)
+		if req == nil {
+			return "", fmt.Errorf("request %s not found", opts.RequestID)
+		}
+		m.leftPanel.SetActiveTab(CollectionsTab)
+		collections.RevealNode(opts.RequestID)
+		m.requestPanel.LoadCollectionRequest(req)
+		m.responsePanel.SetRequestID(req.ID)
+		url = req.URL
+	}
+
+	switch {
+	case opts.Response != nil:
+		resp := opts.Response
+		m.responsePanel.SetResponse(resp.StatusCode, resp.Status, joinHeaders(resp.Headers), responseCookies(resp.Headers),
+			resp.Body, formatDuration(resp.Time), formatBytes(resp.Size))
+		m.responsePanel.SetRedirects(resp.Redirects)
+	case opts.Err != nil:
+		m.responsePanel.SetNetworkError(api.DiagnoseNetworkError(opts.Err, url))
+	}
+
+	if opts.Tab != "" {
+		var tabs []string
+		switch opts.Panel {
+		case RequestPanel:
+			tabs = m.requestPanel.tabs.Items
+		case ResponsePanel:
+			tabs = m.responsePanel.tabs.Items
+		default:
+			return "", fmt.Errorf("only the request and response panels have tabs")
+		}
+		index := -1
+		for i, name := range tabs {
+			if strings.EqualFold(name, opts.Tab) {
+				index = i
+			}
+		}
+		if index < 0 {
+			return "", fmt.Errorf("unknown tab %q, expected one of: %s", opts.Tab, strings.Join(tabs, ", "))
+		}
+		if opts.Panel == RequestPanel {
+			m.requestPanel.tabs.SetActive(index)
+		} else {
+			m.responsePanel.tabs.SetActive(index)
+		}
+	}
+
+	m.width = opts.Width
+	m.height = opts.Height + 1 // The status bar line is left out
+	m.fullscreenPanel = opts.Panel
+	return maskSecrets(m.renderFullscreenLayout(), m.secretValues()), nil
+}
+
+// PlainSnapshot returns a snapshot without its colors and text styles
+func PlainSnapshot(snapshot string) string {
+	return ansiSequencePattern.ReplaceAllString(snapshot, "")
+}
+
+// SVG snapshot geometry, in pixels: a cell is 0.6em wide in monospace fonts
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgCellHeight = 18
+	svgPadding    = 12
+)
+
+// svgStyle is the text style set by the SGR sequences of a snapshot
+type svgStyle struct {
+	fg, bg                                      string // Hex colors; the default when empty
+	bold, faint, italic, underline, strike, rev bool
+}
+
+// svgRun is text of one style on a line
+type svgRun struct {
+	style svgStyle
+	text  strings.Builder
+	col   int // First cell
+	cells int
+}
+
+// WriteSnapshotSVG writes a snapshot as an SVG image with its colors, on the theme
+// background
+func WriteSnapshotSVG(w io.Writer, snapshot string) error {
+	lines := strings.Split(snapshot, "\n")
+	cols := 0
+	for _, line := range lines {
+		cols = max(cols, lipgloss.Width(line))
+	}
+	width := svgNumber(float64(cols)*svgCellWidth + 2*svgPadding)
+	height := svgNumber(float64(len(lines)*svgCellHeight + 2*svgPadding))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", styles.Base)
+	fmt.Fprintf(&b, `<g font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+
+	var style svgStyle
+	for row, line := range lines {
+		var runs []*svgRun
+		col := 0
+		rest := line
+		for rest != "" {
+			if loc := ansiSequencePattern.FindStringIndex(rest); loc != nil && loc[0] == 0 {
+				seq := rest[:loc[1]]
+				if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+					style = style.apply(seq[2 : len(seq)-1])
+				}
+				rest = rest[loc[1]:]
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(rest)
+			rest = rest[size:]
+			cells := lipgloss.Width(string(r))
+			if len(runs) == 0 || runs[len(runs)-1].style != style {
+				runs = append(runs, &svgRun{style: style, col: col})
+			}
+			run := runs[len(runs)-1]
+			run.text.WriteRune(r)
+			run.cells += cells
+			col += cells
+		}
+		for _, run := range runs {
+			writeSVGRun(&b, run, row)
+		}
+	}
+
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSVGRun writes the background and the text of a run
+func writeSVGRun(b *strings.Builder, run *svgRun, row int) {
+	fg, bg := run.style.fg, run.style.bg
+	if fg == "" {
+		fg = string(styles.Text)
+	}
+	if run.style.rev {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = string(styles.Base)
+		}
+	}
+	x := svgNumber(svgPadding + float64(run.col)*svgCellWidth)
+	y := float64(svgPadding + row*svgCellHeight)
+	length := svgNumber(float64(run.cells) * svgCellWidth)
+	if bg != "" {
+		fmt.Fprintf(b, `<rect x="%s" y="%s" width="%s" height="%d" fill="%s"/>`+"\n", x, svgNumber(y), length, svgCellHeight, bg)
+	}
+	text := run.text.String()
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	attrs := fmt.Sprintf(`x="%s" y="%s" textLength="%s" lengthAdjust="spacingAndGlyphs" fill="%s"`, x, svgNumber(y+svgCellHeight*0.75), length, fg)
+	if run.style.bold {
+		attrs += ` font-weight="bold"`
+	}
+	if run.style.italic {
+		attrs += ` font-style="italic"`
+	}
+	if run.style.faint {
+		attrs += ` fill-opacity="0.6"`
+	}
+	switch {
+	case run.style.underline && run.style.strike:
+		attrs += ` text-decoration="underline line-through"`
+	case run.style.underline:
+		attrs += ` text-decoration="underline"`
+	case run.style.strike:
+		attrs += ` text-decoration="line-through"`
+	}
+	fmt.Fprintf(b, "<text %s>%s</text>\n", attrs, html.EscapeString(text))
+}
+
+// apply returns the style after the SGR parameters params, such as "1;38;2;205;214;244"
+func (s svgStyle) apply(params string) svgStyle {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			s = svgStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.rev = true
+		case code == 9:
+			s.strike = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.rev = false
+		case code == 29:
+			s.strike = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiColor(code - 30)
+		case code >= 90 && code <= 97:
+			s.fg = ansiColor(code - 90 + 8)
+		case code >= 40 && code <= 47:
+			s.bg = ansiColor(code - 40)
+		case code >= 100 && code <= 107:
+			s.bg = ansiColor(code - 100 + 8)
+		case code == 39:
+			s.fg = ""
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			color, used := extendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+	return s
+}
+
+// extendedColor reads a 256-color ("5;n") or true color ("2;r;g;b") parameter, returning
+// the color and the number of parameters used
+func extendedColor(params []string) (string, int) {
+	value := func(i int) int {
+		if i >= len(params) {
+			return 0
+		}
+		n, _ := strconv.Atoi(params[i])
+		return n
+	}
+	switch value(0) {
+	case 5:
+		return ansiColor(value(1)), min(2, len(params))
+	case 2:
+		return fmt.Sprintf("#%02x%02x%02x", value(1), value(2), value(3)), min(4, len(params))
+	}
+	return "", min(1, len(params))
+}
+
+// ansiBasicColors returns the 16 basic terminal colors, from the theme palette
+func ansiBasicColors() []lipgloss.Color {
+	return []lipgloss.Color{
+		styles.Surface1, styles.Red, styles.Green, styles.Yellow, styles.Blue, styles.Pink, styles.Teal, styles.Subtext1,
+		styles.Subtext0, styles.Red, styles.Green, styles.Yellow, styles.Blue, styles.Pink, styles.Teal, styles.Text,
+	}
+}
+
+// ansiColor returns the hex color of a 256-color palette index
+func ansiColor(index int) string {
+	switch {
+	case index < 16:
+		return string(ansiBasicColors()[max(index, 0)])
+	case index < 232:
+		// 6x6x6 color cube
+		index -= 16
+		level := func(n int) int {
+			if n == 0 {
+				return 0
+			}
+			return 55 + n*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(index/36), level(index/6%6), level(index%6))
+	case index < 256:
+		gray := 8 + (index-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+	return ""
+}
+
+// svgNumber formats a coordinate with at most two decimals
+func svgNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+func TestModel_Snapshot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	workspace := t.TempDir()
+	col := &api.CollectionFile{Name: "Shop", Folders: []api.Folder{{
+		Name: "Orders",
+		Requests: []api.CollectionRequest{
+			{ID: "create", Name: "Create order", Method: api.POST, URL: "{{base_url}}/orders"},
+		},
+	}}}
+	if err := api.SaveCollection(col, filepath.Join(workspace, ".lazycurl", "collections", "shop.json")); err != nil {
+		t.Fatal(err)
+	}
+	model := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), workspace)
+
+	tree, err := model.Snapshot(SnapshotOptions{Panel: CollectionsPanel, RequestID: "create", Width: 50, Height: 10})
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	tree = PlainSnapshot(tree)
+	if lines := strings.Split(tree, "\n"); len(lines) != 10 || !strings.Contains(tree, "Create order") {
+		t.Errorf("tree snapshot should be 10 lines showing the revealed request, got %d:\n%s", len(lines), tree)
+	}
+
+	resp := &api.Response{
+		StatusCode: 201,
+		Status:     "201 Created",
+		Headers:    http.Header{"X-Order": {"42"}},
+		Body:       []byte(`{"id": 42}`),
+		Time:       12 * time.Millisecond,
+		Size:       10,
+	}
+	headers, err := model.Snapshot(SnapshotOptions{Panel: ResponsePanel, Tab: "headers", RequestID: "create", Response: resp, Width: 60, Height: 12})
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	headers = PlainSnapshot(headers)
+	for _, want := range []string{"201", "X-Order", "42"} {
+		if !strings.Contains(headers, want) {
+			t.Errorf("response headers snapshot should show %q:\n%s", want, headers)
+		}
+	}
+
+	if _, err := model.Snapshot(SnapshotOptions{Panel: ResponsePanel, Tab: "Timeline", Width: 60, Height: 12}); err == nil || !strings.Contains(err.Error(), "Headers") {
+		t.Errorf("an unknown tab should fail listing the tabs, got %v", err)
+	}
+	if _, err := model.Snapshot(SnapshotOptions{Panel: RequestPanel, RequestID: "missing", Width: 60, Height: 12}); err == nil {
+		t.Error("Snapshot() should fail for an unknown request")
+	}
+}
+
+func TestWriteSnapshotSVG(t *testing.T) {
+	snapshot := "\x1b[1;38;2;180;190;254mTitle\x1b[0m <ok>\n\x1b[42m  \x1b[0m 界"
+	var b strings.Builder
+	if err := WriteSnapshotSVG(&b, snapshot); err != nil {
+		t.Fatalf("WriteSnapshotSVG() error = %v", err)
+	}
+	svg := b.String()
+	for _, want := range []string{
+		`fill="#b4befe" font-weight="bold">Title</text>`,
+		`> &lt;ok&gt;</text>`,
+		`<rect x="12" y="30" width="16.8" height="18" fill="#a6e3a1"/>`,
+		// A wide character takes two cells
+		`x="28.8" y="43.5" textLength="25.2"`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG should contain %s:\n%s", want, svg)
+		}
+	}
+	if strings.Contains(svg, "\x1b") {
+		t.Error("SVG should not contain escape sequences")
+	}
+}
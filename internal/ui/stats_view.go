@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/stats"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// statsDays is the number of days shown in the daily chart
+const statsDays = 14
+
+// statsTables lists the tables of the stats view in tab order
+var statsTables = []string{"Collections", "Hosts", "Endpoints"}
+
+// StatsView is the fullscreen panel showing local usage statistics
+type StatsView struct {
+	visible bool
+	store   *stats.Store
+	now     time.Time
+	table   int // Index in statsTables
+	offset  int // First visible table row
+}
+
+// NewStatsView creates a new stats view
+func NewStatsView() *StatsView {
+	return &StatsView{}
+}
+
+// Show displays the statistics of store as of now
+func (v *StatsView) Show(store *stats.Store, now time.Time) {
+	v.visible = true
+	v.store = store
+	v.now = now
+	v.offset = 0
+}
+
+// Hide closes the view
+func (v *StatsView) Hide() {
+	v.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *StatsView) IsVisible() bool {
+	return v.visible
+}
+
+// groups returns the rows of the selected table
+func (v *StatsView) groups() []stats.Group {
+	if v.store == nil {
+		return nil
+	}
+	switch v.table {
+	case 1:
+		return v.store.ByHost()
+	case 2:
+		return v.store.ByEndpoint()
+	default:
+		return v.store.ByCollection()
+	}
+}
+
+// Update handles key input
+func (v *StatsView) Update(msg tea.KeyMsg) (*StatsView, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "l", "right":
+		v.table = (v.table + 1) % len(statsTables)
+		v.offset = 0
+	case "shift+tab", "h", "left":
+		v.table = (v.table + len(statsTables) - 1) % len(statsTables)
+		v.offset = 0
+	case "j", "down":
+		if v.offset < len(v.groups())-1 {
+			v.offset++
+		}
+	case "k", "up":
+		if v.offset > 0 {
+			v.offset--
+		}
+	case "g":
+		v.offset = 0
+	case "q", "esc":
+		v.Hide()
+	}
+	return v, nil
+}
+
+// View renders the totals, a chart of sends per day and the selected table
+func (v *StatsView) View(width, height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+	barStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	separator := mutedStyle.Render(strings.Repeat("─", width))
+
+	var result strings.Builder
+
+	// Totals
+	count, avg := 0, time.Duration(0)
+	if v.store != nil {
+		count, avg = v.store.Total()
+	}
+	if count == 0 {
+		result.WriteString("No requests recorded yet. Requests sent from the Request panel are counted here.\n")
+	} else {
+		since := v.store.Entries[0].Time.Format("2006-01-02")
+		result.WriteString(fmt.Sprintf("%d requests · avg %s · since %s\n", count, formatDuration(avg), since))
+	}
+	result.WriteString(separator)
+	result.WriteString("\n")
+
+	// Requests per day
+	var days []stats.Day
+	if v.store != nil {
+		days = v.store.Daily(v.now, statsDays)
+	}
+	peak := 1
+	for _, d := range days {
+		peak = max(peak, d.Count)
+	}
+	barWidth := max(width-24, 5)
+	result.WriteString(mutedStyle.Render(fmt.Sprintf("Last %d days", statsDays)))
+	result.WriteString("\n")
+	for _, d := range days {
+		bar := strings.Repeat("█", d.Count*barWidth/peak)
+		if d.Count > 0 && bar == "" {
+			bar = "▏"
+		}
+		latency := ""
+		if d.Count > 0 {
+			latency = formatDuration(d.Avg)
+		}
+		result.WriteString(fmt.Sprintf("%s %s %4d %8s\n",
+			mutedStyle.Render(d.Date.Format("01-02")),
+			barStyle.Render(fmt.Sprintf("%-*s", barWidth, bar)),
+			d.Count, latency))
+	}
+	result.WriteString(separator)
+	result.WriteString("\n")
+
+	// Table tabs
+	var tabs []string
+	for i, name := range statsTables {
+		if i == v.table {
+			tabs = append(tabs, lipgloss.NewStyle().Foreground(styles.Lavender).Bold(true).Render("["+name+"]"))
+		} else {
+			tabs = append(tabs, mutedStyle.Render(" "+name+" "))
+		}
+	}
+	result.WriteString(strings.Join(tabs, " "))
+	result.WriteString("\n")
+
+	keyWidth := max(width-46, 10)
+	result.WriteString(mutedStyle.Render(fmt.Sprintf("%-*s %6s %6s %8s %8s %8s", keyWidth, "Name", "Count", "Errors", "Avg", "Min", "Max")))
+	result.WriteString("\n")
+
+	// Summary, chart, separators, tabs, header and hints take the rest of the height
+	groups := v.groups()
+	rows := max(height-len(days)-8, 1)
+	for i := v.offset; i < len(groups) && i < v.offset+rows; i++ {
+		g := groups[i]
+		key := g.Key
+		if len(key) > keyWidth {
+			key = key[:keyWidth-3] + "..."
+		}
+		errors := fmt.Sprintf("%d", g.Errors)
+		if g.Errors > 0 {
+			errors = lipgloss.NewStyle().Foreground(styles.Red).Render(fmt.Sprintf("%6d", g.Errors))
+		}
+		result.WriteString(fmt.Sprintf("%-*s %6d %6s %8s %8s %8s\n", keyWidth, key, g.Count, errors,
+			formatDuration(g.Avg), formatDuration(g.Min), formatDuration(g.Max)))
+	}
+	if len(groups) == 0 {
+		result.WriteString(mutedStyle.Render("Nothing to show"))
+		result.WriteString("\n")
+	}
+
+	result.WriteString(hintStyle.Render("tab: switch table · j/k: scroll · q: close"))
+	return result.String()
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/stats"
+)
+
+func TestStatsView(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := &stats.Store{Entries: []stats.Entry{
+		{Time: now.Add(-time.Hour), Collection: "Shop", Method: "GET", Host: "api.shop.com", Path: "/items", Status: 200, DurationMs: 100},
+		{Time: now.Add(-time.Hour), Collection: "Shop", Method: "GET", Host: "api.shop.com", Path: "/items", Status: 500, DurationMs: 300},
+	}}
+
+	v := NewStatsView()
+	v.Show(store, now)
+	if !v.IsVisible() {
+		t.Fatal("view should be visible after Show")
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want []string
+	}{
+		{name: "collections table", want: []string{"2 requests · avg 200ms", "03-10", "[Collections]", "Shop"}},
+		{name: "hosts table", key: "tab", want: []string{"[Hosts]", "api.shop.com"}},
+		{name: "endpoints table", key: "tab", want: []string{"[Endpoints]", "GET api.shop.com/items"}},
+		{name: "wraps to collections", key: "tab", want: []string{"[Collections]"}},
+		{name: "back to endpoints", key: "shift+tab", want: []string{"[Endpoints]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.key != "" {
+				v, _ = v.Update(keyMsg(tt.key))
+			}
+			view := v.View(100, 40)
+			for _, want := range tt.want {
+				if !strings.Contains(view, want) {
+					t.Errorf("view missing %q:\n%s", want, view)
+				}
+			}
+		})
+	}
+
+	v, _ = v.Update(keyMsg("q"))
+	if v.IsVisible() {
+		t.Error("q should hide the view")
+	}
+}
+
+func TestStatsView_Empty(t *testing.T) {
+	v := NewStatsView()
+	v.Show(&stats.Store{}, time.Now())
+	if view := v.View(80, 30); !strings.Contains(view, "No requests recorded yet") {
+		t.Errorf("unexpected empty view:\n%s", view)
+	}
+}
+
+// keyMsg builds a key message for s, as typed by the user
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
@@ -2,11 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/kbrdn1/LazyCurl/internal/config"
 	"github.com/kbrdn1/LazyCurl/pkg/styles"
 )
 
@@ -27,6 +29,17 @@ type StatusBar struct {
 	environment  string    // Active environment name
 	hints        string    // Dynamic keybinding hints
 	isFullscreen bool      // Whether fullscreen mode is active
+	isMockMode   bool      // Whether mock mode is active
+	chaos        string    // Chaos mode label (empty = off)
+	proxy        string    // Proxy host (empty = direct)
+	inFlight     int       // Number of sends in flight
+	queued       int       // Number of sends waiting for a send of the same request
+	workspace    string    // Workspace name
+	branch       string    // Git branch of the workspace directory (empty = none)
+	dirty        bool      // Whether the .lazycurl directory has uncommitted changes
+
+	layout config.StatusBarConfig // Segments around the middle content
+	now    func() time.Time       // Clock of the time segment
 }
 
 // NewStatusBar creates a new status bar
@@ -35,9 +48,41 @@ func NewStatusBar(version string) *StatusBar {
 		mode:       NormalMode,
 		version:    version,
 		breadcrumb: []string{},
+		layout:     config.DefaultStatusBarConfig(),
+		now:        time.Now,
 	}
 }
 
+// SetLayout arranges the segments of the status bar
+func (s *StatusBar) SetLayout(layout config.StatusBarConfig) {
+	s.layout = layout
+}
+
+// GetLayout returns the arrangement of the status bar segments
+func (s *StatusBar) GetLayout() config.StatusBarConfig {
+	return s.layout
+}
+
+// Shows returns true if the segment is part of the layout
+func (s *StatusBar) Shows(segment string) bool {
+	return slices.Contains(s.layout.Left, segment) || slices.Contains(s.layout.Right, segment)
+}
+
+// SetWorkspace updates the workspace segment
+func (s *StatusBar) SetWorkspace(name string) {
+	s.workspace = name
+}
+
+// SetBranch updates the git branch segment
+func (s *StatusBar) SetBranch(branch string) {
+	s.branch = branch
+}
+
+// SetDirty marks the git branch segment when the .lazycurl directory has uncommitted changes
+func (s *StatusBar) SetDirty(dirty bool) {
+	s.dirty = dirty
+}
+
 // SetMode updates the mode indicator
 func (s *StatusBar) SetMode(mode Mode) {
 	s.mode = mode
@@ -80,6 +125,27 @@ func (s *StatusBar) SetFullscreen(fullscreen bool) {
 	s.isFullscreen = fullscreen
 }
 
+// SetMockMode sets the mock mode indicator
+func (s *StatusBar) SetMockMode(enabled bool) {
+	s.isMockMode = enabled
+}
+
+// SetProxy sets the proxy indicator (empty hides it)
+func (s *StatusBar) SetProxy(host string) {
+	s.proxy = host
+}
+
+// SetChaos sets the chaos mode indicator (empty hides it)
+func (s *StatusBar) SetChaos(label string) {
+	s.chaos = label
+}
+
+// SetSends sets the sends indicator, shown with several sends in flight or queued ones
+func (s *StatusBar) SetSends(inFlight, queued int) {
+	s.inFlight = inFlight
+	s.queued = queued
+}
+
 // ShowMessage displays a temporary status message
 func (s *StatusBar) ShowMessage(msg string, duration time.Duration) {
 	s.message = msg
@@ -115,59 +181,15 @@ func (s *StatusBar) View(width int) string {
 		s.message = ""
 	}
 
-	// Mode badge (always first)
-	modeBadge := s.mode.Color().Render(s.mode.String())
-	modeWidth := lipgloss.Width(modeBadge)
-
-	// HTTP method badge (if present, after mode)
-	var methodBadge string
-	methodWidth := 0
-	if s.httpMethod != "" {
-		methodBadge = s.renderMethodBadge()
-		methodWidth = lipgloss.Width(methodBadge)
-	}
-
-	// Fullscreen badge (if active)
-	var fullscreenBadge string
-	fullscreenWidth := 0
-	if s.isFullscreen {
-		fullscreenStyle := lipgloss.NewStyle().
-			Foreground(styles.Crust).
-			Background(styles.Mauve).
-			Bold(true).
-			Padding(0, 1)
-		fullscreenBadge = fullscreenStyle.Render("FULLSCREEN")
-		fullscreenWidth = lipgloss.Width(fullscreenBadge)
-	}
-
-	// Environment badge (right side)
-	var envBadge string
-	envWidth := 0
-	if s.environment != "" {
-		envStyle := lipgloss.NewStyle().
-			Foreground(styles.Green).
-			Bold(true).
-			Padding(0, 1)
-		envBadge = envStyle.Render(s.environment)
-		envWidth = lipgloss.Width(envBadge)
-	} else {
-		envStyle := lipgloss.NewStyle().
-			Foreground(styles.Subtext0).
-			Padding(0, 1)
-		envBadge = envStyle.Render("NONE")
-		envWidth = lipgloss.Width(envBadge)
-	}
-
-	// HTTP status badge (if present, right side)
-	var statusBadge string
-	statusWidth := 0
-	if s.httpStatus > 0 {
-		statusBadge = s.renderHTTPStatus()
-		statusWidth = lipgloss.Width(statusBadge)
-	}
+	// Segments before and after the middle content, in the configured order
+	left := s.renderSegments(s.layout.Left)
+	right := s.renderSegments(s.layout.Right)
 
 	// Calculate middle content width
-	usedWidth := modeWidth + methodWidth + fullscreenWidth + envWidth + statusWidth
+	usedWidth := 0
+	for _, segment := range append(slices.Clone(left), right...) {
+		usedWidth += lipgloss.Width(segment)
+	}
 	middleWidth := width - usedWidth
 	if middleWidth < 0 {
 		middleWidth = 0
@@ -223,22 +245,129 @@ func (s *StatusBar) View(width int) string {
 	}
 	middleContent := middleStyle.Render(middleText)
 
-	// Join all parts: Mode | Method | Fullscreen | Middle | Env | Status
-	var parts []string
-	parts = append(parts, modeBadge)
-	if methodBadge != "" {
-		parts = append(parts, methodBadge)
-	}
-	if fullscreenBadge != "" {
-		parts = append(parts, fullscreenBadge)
+	// Join all parts: left segments | Middle | right segments
+	parts := append(left, middleContent)
+	parts = append(parts, right...)
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// renderSegments renders the shown segments among names, in order
+func (s *StatusBar) renderSegments(names []string) []string {
+	var segments []string
+	for _, name := range names {
+		if segment := s.renderSegment(name); segment != "" {
+			segments = append(segments, segment)
+		}
 	}
-	parts = append(parts, middleContent)
-	parts = append(parts, envBadge)
-	if statusBadge != "" {
-		parts = append(parts, statusBadge)
+	return segments
+}
+
+// renderSegment renders a segment, or "" when it has nothing to show
+func (s *StatusBar) renderSegment(name string) string {
+	badgeStyle := func(bg lipgloss.Color) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Foreground(styles.Crust).
+			Background(bg).
+			Bold(true).
+			Padding(0, 1)
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+	switch name {
+	case config.SegmentMode:
+		return s.mode.Color().Render(s.mode.String())
+
+	case config.SegmentMethod:
+		if s.httpMethod == "" {
+			return ""
+		}
+		return s.renderMethodBadge()
+
+	case config.SegmentFullscreen:
+		if !s.isFullscreen {
+			return ""
+		}
+		return badgeStyle(styles.Mauve).Render("FULLSCREEN")
+
+	case config.SegmentMock:
+		if !s.isMockMode {
+			return ""
+		}
+		return badgeStyle(styles.Peach).Render("MOCK")
+
+	case config.SegmentChaos:
+		if s.chaos == "" {
+			return ""
+		}
+		return badgeStyle(styles.Red).Render("CHAOS " + s.chaos)
+
+	case config.SegmentSends:
+		// Shown when several sends are in flight or some are queued
+		if s.inFlight <= 1 && s.queued == 0 {
+			return ""
+		}
+		label := fmt.Sprintf("SENDING %d", s.inFlight)
+		if s.queued > 0 {
+			label += fmt.Sprintf(" · %d QUEUED", s.queued)
+		}
+		return badgeStyle(styles.Blue).Render(label)
+
+	case config.SegmentProxy:
+		if s.proxy == "" {
+			return ""
+		}
+		return badgeStyle(styles.Sapphire).Render("PROXY " + s.proxy)
+
+	case config.SegmentEnvironment:
+		if s.environment == "" {
+			return lipgloss.NewStyle().
+				Foreground(styles.Subtext0).
+				Padding(0, 1).
+				Render("NONE")
+		}
+		return lipgloss.NewStyle().
+			Foreground(styles.Green).
+			Bold(true).
+			Padding(0, 1).
+			Render(s.environment)
+
+	case config.SegmentStatus:
+		if s.httpStatus == 0 {
+			return ""
+		}
+		return s.renderHTTPStatus()
+
+	case config.SegmentWorkspace:
+		if s.workspace == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().
+			Foreground(styles.Lavender).
+			Bold(true).
+			Padding(0, 1).
+			Render(s.workspace)
+
+	case config.SegmentBranch:
+		if s.branch == "" {
+			return ""
+		}
+		if s.dirty {
+			return lipgloss.NewStyle().
+				Foreground(styles.Peach).
+				Padding(0, 1).
+				Render("⎇ " + s.branch + "*")
+		}
+		return lipgloss.NewStyle().
+			Foreground(styles.Mauve).
+			Padding(0, 1).
+			Render("⎇ " + s.branch)
+
+	case config.SegmentTime:
+		return lipgloss.NewStyle().
+			Foreground(styles.Subtext0).
+			Padding(0, 1).
+			Render(s.now().Format(s.layout.TimeFormat))
+	}
+	return ""
 }
 
 // renderHTTPStatus renders the HTTP status badge with color coding
@@ -279,34 +408,7 @@ func (s *StatusBar) renderHTTPStatus() string {
 
 // renderMethodBadge renders the HTTP method badge
 func (s *StatusBar) renderMethodBadge() string {
-	var bgColor, fgColor lipgloss.Color
-
-	switch s.httpMethod {
-	case "GET":
-		bgColor = styles.MethodGetBg
-		fgColor = styles.MethodGetFg
-	case "POST":
-		bgColor = styles.MethodPostBg
-		fgColor = styles.MethodPostFg
-	case "PUT":
-		bgColor = styles.MethodPutBg
-		fgColor = styles.MethodPutFg
-	case "DELETE":
-		bgColor = styles.MethodDeleteBg
-		fgColor = styles.MethodDeleteFg
-	case "PATCH":
-		bgColor = styles.MethodPatchBg
-		fgColor = styles.MethodPatchFg
-	case "HEAD":
-		bgColor = styles.MethodHeadBg
-		fgColor = styles.MethodHeadFg
-	case "OPTIONS":
-		bgColor = styles.MethodOptionsBg
-		fgColor = styles.MethodOptionsFg
-	default:
-		bgColor = styles.Surface1
-		fgColor = styles.Text
-	}
+	bgColor, fgColor := styles.MethodColors(s.httpMethod)
 
 	style := lipgloss.NewStyle().
 		Background(bgColor).
@@ -314,7 +416,7 @@ func (s *StatusBar) renderMethodBadge() string {
 		Bold(true).
 		Padding(0, 1)
 
-	return style.Render(s.httpMethod)
+	return style.Render(styles.MethodLabel(s.httpMethod))
 }
 
 // GetMode returns the current mode
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/vcs"
+)
+
+// StatusBarTickMsg is sent to refresh the time and git branch segments
+type StatusBarTickMsg struct{}
+
+// statusBarTick returns a command that fires on the next second when the time segment
+// shows seconds, on the next minute otherwise
+func statusBarTick(layout config.StatusBarConfig, now time.Time) tea.Cmd {
+	next := now.Truncate(time.Minute).Add(time.Minute)
+	if strings.Contains(layout.TimeFormat, "05") {
+		next = now.Truncate(time.Second).Add(time.Second)
+	}
+	return tea.Tick(next.Sub(now), func(time.Time) tea.Msg {
+		return StatusBarTickMsg{}
+	})
+}
+
+// handleStatusBarTick refreshes the git branch segment and schedules the next tick
+func (m Model) handleStatusBarTick() (tea.Model, tea.Cmd) {
+	if m.statusBar.Shows(config.SegmentBranch) {
+		m.statusBar.SetBranch(vcs.Branch(m.workspacePath))
+	}
+	gitStatus := m.refreshGitStatus()
+	return m, tea.Batch(statusBarTick(m.statusBar.GetLayout(), time.Now()), gitStatus)
+}
+
+// handleStatusBarCommand shows the status bar layout, or previews a change to it until
+// it is saved to the global config or reset
+func (m Model) handleStatusBarCommand(args []string) (tea.Model, tea.Cmd) {
+	layout := m.statusBar.GetLayout()
+	if len(args) == 0 {
+		m.statusBar.Info(fmt.Sprintf("Left: %s · Right: %s · Segments: %s",
+			strings.Join(layout.Left, " "), strings.Join(layout.Right, " "), strings.Join(config.StatusBarSegments, " ")))
+		return m, nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case StatusBarLeft, StatusBarRight:
+		var segments []string
+		for _, arg := range args[1:] {
+			for _, name := range strings.Split(arg, ",") {
+				if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+					segments = append(segments, name)
+				}
+			}
+		}
+		if len(segments) == 0 {
+			m.statusBar.Info("Usage: :statusbar left|right <segment> [segment...]")
+			return m, nil
+		}
+		preview := layout
+		if strings.ToLower(args[0]) == StatusBarLeft {
+			preview.Left = segments
+		} else {
+			preview.Right = segments
+		}
+		if unknown := preview.UnknownSegments(); len(unknown) > 0 {
+			m.statusBar.Info(fmt.Sprintf("Unknown segments: %s (segments: %s)", strings.Join(unknown, ", "), strings.Join(config.StatusBarSegments, " ")))
+			return m, nil
+		}
+		m.previewStatusBar(preview)
+		m.statusBar.Success("Status bar "+strings.ToLower(args[0]), strings.Join(segments, " ")+" (:statusbar save to keep)")
+
+	case StatusBarTime:
+		if len(args) < 2 {
+			m.statusBar.Info("Usage: :statusbar time <layout> (Go layout, e.g. 15:04:05)")
+			return m, nil
+		}
+		layout.TimeFormat = strings.Join(args[1:], " ")
+		m.previewStatusBar(layout)
+		m.statusBar.Success("Status bar time", layout.TimeFormat+" (:statusbar save to keep)")
+
+	case StatusBarReset:
+		m.previewStatusBar(config.EffectiveStatusBar(m.globalConfig))
+		m.statusBar.Info("Status bar layout reset to the config")
+
+	case StatusBarSave:
+		saved := layout
+		m.globalConfig.StatusBar = &saved
+		if err := m.globalConfig.Save(); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Saved", "status bar layout")
+
+	default:
+		m.statusBar.Info("Usage: :statusbar [left|right <segments> | time <layout> | save | reset]")
+	}
+	return m, nil
+}
+
+// previewStatusBar shows the status bar with layout
+func (m *Model) previewStatusBar(layout config.StatusBarConfig) {
+	showedBranch := m.statusBar.Shows(config.SegmentBranch)
+	m.statusBar.SetLayout(layout)
+	if !showedBranch && slices.Contains(append(slices.Clone(layout.Left), layout.Right...), config.SegmentBranch) {
+		m.statusBar.SetBranch(vcs.Branch(m.workspacePath))
+	}
+}
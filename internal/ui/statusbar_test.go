@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
 )
 
 // =============================================================================
@@ -834,3 +836,121 @@ func TestStatusBarSetFullscreen(t *testing.T) {
 		t.Error("View() should not contain FULLSCREEN after clearing")
 	}
 }
+
+// Mock mode badge test
+func TestStatusBarSetMockMode(t *testing.T) {
+	s := NewStatusBar("v0.1.0")
+
+	if strings.Contains(s.View(100), "MOCK") {
+		t.Error("View() should not contain MOCK when not set")
+	}
+
+	s.SetMockMode(true)
+	if !strings.Contains(s.View(100), "MOCK") {
+		t.Error("View() should contain MOCK when set")
+	}
+
+	s.SetMockMode(false)
+	if strings.Contains(s.View(100), "MOCK") {
+		t.Error("View() should not contain MOCK after clearing")
+	}
+}
+
+// Chaos mode badge test
+func TestStatusBarSetChaos(t *testing.T) {
+	s := NewStatusBar("v0.1.0")
+
+	if strings.Contains(s.View(100), "CHAOS") {
+		t.Error("View() should not contain CHAOS when not set")
+	}
+
+	s.SetChaos("20%")
+	if !strings.Contains(s.View(100), "CHAOS 20%") {
+		t.Error("View() should contain CHAOS 20% when set")
+	}
+
+	s.SetChaos("")
+	if strings.Contains(s.View(100), "CHAOS") {
+		t.Error("View() should not contain CHAOS after clearing")
+	}
+}
+
+// Proxy badge test
+func TestStatusBarSetProxy(t *testing.T) {
+	s := NewStatusBar("v0.1.0")
+
+	if strings.Contains(s.View(100), "PROXY") {
+		t.Error("View() should not contain PROXY when not set")
+	}
+
+	s.SetProxy("proxy.corp:3128")
+	if !strings.Contains(s.View(120), "PROXY proxy.corp:3128") {
+		t.Error("View() should contain PROXY proxy.corp:3128 when set")
+	}
+
+	s.SetProxy("")
+	if strings.Contains(s.View(100), "PROXY") {
+		t.Error("View() should not contain PROXY after clearing")
+	}
+}
+
+// Sends badge test
+func TestStatusBarSetSends(t *testing.T) {
+	s := NewStatusBar("v0.1.0")
+
+	s.SetSends(1, 0)
+	if strings.Contains(s.View(120), "SENDING") {
+		t.Error("View() should not contain SENDING with a single send in flight")
+	}
+
+	s.SetSends(2, 0)
+	if !strings.Contains(s.View(120), "SENDING 2") {
+		t.Error("View() should contain SENDING 2 with two sends in flight")
+	}
+
+	s.SetSends(1, 3)
+	if !strings.Contains(s.View(120), "SENDING 1 · 3 QUEUED") {
+		t.Error("View() should contain the queue depth when sends are queued")
+	}
+
+	s.SetSends(0, 0)
+	if strings.Contains(s.View(120), "SENDING") {
+		t.Error("View() should not contain SENDING after the sends complete")
+	}
+}
+
+// Layout tests
+func TestStatusBarLayout(t *testing.T) {
+	s := NewStatusBar("v0.1.0")
+	s.now = func() time.Time { return time.Date(2026, 1, 2, 9, 41, 7, 0, time.UTC) }
+	s.SetEnvironment("dev")
+	s.SetWorkspace("shop-api")
+	s.SetBranch("feature/cart")
+	s.SetLayout(config.StatusBarConfig{
+		Left:       []string{config.SegmentWorkspace, config.SegmentMode},
+		Right:      []string{config.SegmentBranch, config.SegmentTime},
+		TimeFormat: "15:04:05",
+	})
+
+	view := s.View(140)
+	for _, want := range []string{"shop-api", "NORMAL", "⎇ feature/cart", "09:41:07"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "shop-api") > strings.Index(view, "NORMAL") {
+		t.Error("View() should follow the order of the layout")
+	}
+	if strings.Contains(view, "dev") {
+		t.Error("View() should hide the segments left out of the layout")
+	}
+
+	s.SetDirty(true)
+	if !strings.Contains(s.View(140), "⎇ feature/cart*") {
+		t.Error("View() should mark the branch when .lazycurl has uncommitted changes")
+	}
+
+	if unknown := (config.StatusBarConfig{Left: []string{"mode", "weather"}}).UnknownSegments(); len(unknown) != 1 || unknown[0] != "weather" {
+		t.Errorf("UnknownSegments() = %v, want [weather]", unknown)
+	}
+}
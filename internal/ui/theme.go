@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// hexColorPattern matches "#RGB" and "#RRGGBB" colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// parseColor checks a theme color: a hex color or an ANSI color number (0-255)
+func parseColor(text string) (lipgloss.Color, error) {
+	if text == "" || hexColorPattern.MatchString(text) {
+		return lipgloss.Color(text), nil
+	}
+	if n, err := strconv.Atoi(text); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(text), nil
+	}
+	return "", fmt.Errorf("invalid color %q (#RRGGBB or 0-255)", text)
+}
+
+// ApplyMethodTheme sets the badges of the HTTP methods of the theme. Methods with an
+// invalid color are skipped and reported.
+func ApplyMethodTheme(methods map[string]config.MethodTheme) error {
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var firstErr error
+	for _, name := range names {
+		theme := methods[name]
+		bg, err := parseColor(theme.Color)
+		if err == nil {
+			var fg lipgloss.Color
+			if fg, err = parseColor(theme.TextColor); err == nil {
+				styles.SetMethodStyle(name, styles.MethodStyle{Bg: bg, Fg: fg, Label: theme.Label})
+				continue
+			}
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("method %s: %w", name, err)
+		}
+	}
+	return firstErr
+}
+
+// ApplyTheme draws the UI with the theme of the config, and sets the badges of its
+// methods. A theme that fails to load leaves the current one, and invalid colors are
+// skipped; both are reported.
+func ApplyTheme(cfg config.ThemeConfig) error {
+	theme, err := loadTheme(cfg)
+	if theme != nil {
+		styles.Apply(theme)
+	}
+	if methodErr := ApplyMethodTheme(cfg.Methods); err == nil {
+		err = methodErr
+	}
+	return err
+}
+
+// loadTheme returns the theme named by the config, built-in or from a theme file, with
+// the colors of the config over its own
+func loadTheme(cfg config.ThemeConfig) (styles.Theme, error) {
+	themes, err := availableThemes()
+	name := strings.ToLower(strings.TrimSpace(cfg.Name))
+	var theme styles.Theme
+	if name == "" {
+		theme = styles.Themes()[0]
+	} else if theme = findTheme(themes, name); theme == nil {
+		unknown := fmt.Errorf("unknown theme %q (themes: %s)", cfg.Name, strings.Join(themeNames(themes), ", "))
+		if err != nil {
+			unknown = fmt.Errorf("%w; %v", unknown, err)
+		}
+		return nil, unknown
+	}
+
+	palette := theme.Palette()
+	overrides := []struct{ role, color string }{
+		{"lavender", cfg.PrimaryColor},
+		{"blue", cfg.SecondaryColor},
+		{"pink", cfg.AccentColor},
+		{"surface0", cfg.BorderColor},
+		{"green", cfg.ActiveColor},
+	}
+	for _, o := range overrides {
+		if o.color == "" {
+			continue
+		}
+		color, colorErr := parseColor(o.color)
+		if colorErr != nil {
+			if err == nil {
+				err = colorErr
+			}
+			continue
+		}
+		palette.Set(o.role, color)
+	}
+	return styles.NewTheme(theme.Name(), palette), err
+}
+
+// availableThemes returns the built-in themes, then those of the theme files. Theme
+// files that fail to load are reported in the error and left out.
+func availableThemes() ([]styles.Theme, error) {
+	themes := styles.Themes()
+	files, err := config.LoadThemes(config.GetThemesPath())
+	for _, file := range files {
+		theme, fileErr := themeFromFile(file)
+		if fileErr != nil {
+			if err == nil {
+				err = fileErr
+			}
+			continue
+		}
+		themes = append(themes, theme)
+	}
+	return themes, err
+}
+
+// themeFromFile builds the theme of a theme file over the theme it extends, Catppuccin
+// Mocha by default
+func themeFromFile(file config.ThemeFile) (styles.Theme, error) {
+	base := styles.Themes()[0]
+	if file.Extends != "" {
+		var ok bool
+		if base, ok = styles.BuiltinTheme(file.Extends); !ok {
+			return nil, fmt.Errorf("theme %s: unknown theme %q to extend", file.Name, file.Extends)
+		}
+	}
+
+	palette := base.Palette()
+	roles := make([]string, 0, len(file.Colors))
+	for role := range file.Colors {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		color, err := parseColor(file.Colors[role])
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %s: %w", file.Name, role, err)
+		}
+		if !palette.Set(role, color) {
+			return nil, fmt.Errorf("theme %s: unknown color %q (colors: %s)", file.Name, role, strings.Join(styles.PaletteRoles, ", "))
+		}
+	}
+	return styles.NewTheme(strings.ToLower(file.Name), palette), nil
+}
+
+// findTheme returns the theme with the given name or alias, the last one of that name
+// so theme files can replace built-in themes; nil when there is none
+func findTheme(themes []styles.Theme, name string) styles.Theme {
+	for i := len(themes) - 1; i >= 0; i-- {
+		if themes[i].Name() == name {
+			return themes[i]
+		}
+	}
+	if theme, ok := styles.BuiltinTheme(name); ok {
+		return theme
+	}
+	return nil
+}
+
+// themeNames returns the names of themes
+func themeNames(themes []styles.Theme) []string {
+	names := make([]string, len(themes))
+	for i, theme := range themes {
+		names[i] = theme.Name()
+	}
+	return names
+}
+
+// handleThemeCommand lists the themes, switches to one until LazyCurl exits, or keeps
+// the current one in the global config
+func (m Model) handleThemeCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		themes, err := availableThemes()
+		if err != nil {
+			m.statusBar.Error(fmt.Errorf("theme: %w", err))
+			return m, nil
+		}
+		m.statusBar.Info(fmt.Sprintf("Theme: %s · Themes: %s", styles.Current().Name(), strings.Join(themeNames(themes), " ")))
+		return m, nil
+	}
+
+	switch name := strings.ToLower(args[0]); name {
+	case ThemeReset:
+		if err := ApplyTheme(m.globalConfig.Theme); err != nil {
+			m.statusBar.Error(fmt.Errorf("theme: %w", err))
+			return m, nil
+		}
+		m.statusBar.Info("Theme reset to the config")
+
+	case ThemeSave:
+		m.globalConfig.Theme.Name = styles.Current().Name()
+		if err := m.globalConfig.Save(); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Saved", "theme "+styles.Current().Name())
+
+	default:
+		cfg := m.globalConfig.Theme
+		cfg.Name = name
+		theme, err := loadTheme(cfg)
+		if theme == nil {
+			m.statusBar.Error(fmt.Errorf("theme: %w", err))
+			return m, nil
+		}
+		styles.Apply(theme)
+		m.statusBar.Success("Theme", theme.Name()+" (:theme save to keep)")
+	}
+	return m, nil
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// writeTheme writes a theme file of the home directory home
+func writeTheme(t *testing.T, home, file, content string) {
+	t.Helper()
+	path := filepath.Join(home, ".config", "lazycurl", "themes", file)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	defer styles.Apply(styles.Current())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeTheme(t, home, "dracula.yaml", "extends: catppuccin-latte\ncolors:\n  base: \"#282a36\"\n  lavender: \"#bd93f9\"\n")
+	writeTheme(t, home, "broken.yml", "colors:\n  background: \"#000000\"\n")
+
+	err := ApplyTheme(config.ThemeConfig{Name: "dracula", BorderColor: "#44475a"})
+	if err == nil || !strings.Contains(err.Error(), `theme broken: unknown color "background"`) {
+		t.Errorf("the broken theme file should be reported, got %v", err)
+	}
+	if styles.Current().Name() != "dracula" || styles.Base != "#282a36" || styles.Lavender != "#bd93f9" {
+		t.Errorf("theme = %s, base %s, lavender %s", styles.Current().Name(), styles.Base, styles.Lavender)
+	}
+	if styles.Text != "#4c4f69" || styles.Surface0 != "#44475a" {
+		t.Errorf("colors left out should come from the extended theme and the config, got text %s, surface0 %s", styles.Text, styles.Surface0)
+	}
+
+	if err := ApplyTheme(config.ThemeConfig{Name: "solarized"}); err == nil || !strings.Contains(err.Error(), "unknown theme") {
+		t.Errorf("an unknown theme should be reported, got %v", err)
+	}
+	if styles.Current().Name() != "dracula" {
+		t.Errorf("an unknown theme should keep the current one, got %s", styles.Current().Name())
+	}
+}
+
+func TestModel_ThemeCommand(t *testing.T) {
+	defer styles.Apply(styles.Current())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), t.TempDir())
+
+	model, _ := m.handleThemeCommand(nil)
+	if msg := model.(Model).statusBar.message; !strings.Contains(msg, "Theme: catppuccin-mocha") || !strings.Contains(msg, "gruvbox") {
+		t.Errorf(":theme should list the themes, got %q", msg)
+	}
+
+	model, _ = m.handleThemeCommand([]string{"nord"})
+	if styles.Current().Name() != styles.ThemeNord || styles.Base != "#2e3440" {
+		t.Errorf(":theme nord should switch the theme, got %s", styles.Current().Name())
+	}
+	m = model.(Model)
+	if _, err := os.Stat(config.GetGlobalConfigPath()); err == nil {
+		t.Error("switching the theme should not save the config")
+	}
+
+	m.handleThemeCommand([]string{ThemeSave})
+	saved, err := config.LoadGlobalConfig()
+	if err != nil || saved.Theme.Name != styles.ThemeNord {
+		t.Errorf(":theme save should keep the theme in the config, got %+v, %v", saved, err)
+	}
+
+	// Colors the presets of earlier releases wrote are not applied
+	saved.Theme = config.ThemeConfig{Name: config.ThemeDark, PrimaryColor: "#7D56F4", SecondaryColor: "#00D9FF", AccentColor: "#FF6B6B", BorderColor: "#3C3C3C", ActiveColor: "#00FF00"}
+	if err := saved.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := config.LoadGlobalConfig(); loaded.Theme.PrimaryColor != "" {
+		t.Errorf("legacy preset colors should be dropped, got %+v", loaded.Theme)
+	}
+}
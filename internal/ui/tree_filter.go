@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/runner"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// resultFailed returns true if a request of a run or folder send failed: not answered,
+// answered with a 4xx or 5xx status, or with a failing assertion
+func resultFailed(result runner.RequestResult) bool {
+	return !result.Passed() || result.StatusCode >= 400
+}
+
+// handleFilterCommand narrows the collections tree to the requests of a method and/or
+// whose last send failed. Each argument sets its part of the filter and keeps the other.
+func (m Model) handleFilterCommand(args []string) (tea.Model, tea.Cmd) {
+	tree := m.leftPanel.GetCollections().GetTree()
+	if tree == nil {
+		return m, nil
+	}
+	filter := tree.Filter()
+	if len(args) == 0 {
+		if filter.IsZero() {
+			m.statusBar.Info("Collections show all requests")
+		} else {
+			m.statusBar.Info("Collections filter: " + filter.String())
+		}
+		return m, nil
+	}
+
+	for _, arg := range args {
+		switch strings.ToLower(arg) {
+		case FilterFailed:
+			filter.Failed = true
+		case FilterClear:
+			filter = components.TreeFilter{}
+		default:
+			filter.Method = strings.ToUpper(arg)
+		}
+	}
+	tree.SetFilter(filter)
+	m.leftPanel.SetActiveTab(CollectionsTab)
+	m.activePanel = CollectionsPanel
+	if filter.IsZero() {
+		m.statusBar.Info("Collections show all requests")
+	} else {
+		m.statusBar.Success("Filtered collections", filter.String())
+	}
+	return m, nil
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// TutorialSnapshot captures the application state that tutorial steps are validated against
+type TutorialSnapshot struct {
+	Requests    int       // Requests across all collections
+	Variables   int       // Variables across all environments
+	Responses   int       // Requests sent (console history entries)
+	Assertions  int       // Assertions reported by the scripts of the last send
+	ActivePanel PanelType // Focused panel
+	ResponseTab string    // Active tab of the Response panel
+}
+
+// TutorialStep is one guided step of the :tutorial walkthrough
+type TutorialStep struct {
+	Title        string
+	Instructions []string
+	Target       PanelType // Panel highlighted while the step is active
+	// Done reports whether the step is complete, comparing the state when the step
+	// started with the current state
+	Done func(start, now TutorialSnapshot) bool
+}
+
+// tutorialSteps returns the core flows covered by :tutorial
+func tutorialSteps() []TutorialStep {
+	return []TutorialStep{
+		{
+			Title: "Create a request",
+			Instructions: []string{
+				"Focus Collections (H) and press n.",
+				"Name it, keep GET and enter https://jsonplaceholder.typicode.com/todos/1",
+			},
+			Target: CollectionsPanel,
+			Done: func(start, now TutorialSnapshot) bool {
+				return now.Requests > start.Requests
+			},
+		},
+		{
+			Title: "Set an environment variable",
+			Instructions: []string{
+				"Press 2 for Environments. Press N to create an environment if there is none,",
+				"then n to add a variable such as base_url. Use {{base_url}} in URLs, headers and bodies.",
+			},
+			Target: CollectionsPanel,
+			Done: func(start, now TutorialSnapshot) bool {
+				return now.Variables > start.Variables
+			},
+		},
+		{
+			Title: "Send the request",
+			Instructions: []string{
+				"Press 1 for Collections, open your request with Enter,",
+				"then press ctrl+s to send it.",
+			},
+			Target: RequestPanel,
+			Done: func(start, now TutorialSnapshot) bool {
+				return now.Responses > start.Responses
+			},
+		},
+		{
+			Title: "Read the response",
+			Instructions: []string{
+				"The Response panel shows the status, time, size and body.",
+				"Press 3 to open its Headers tab.",
+			},
+			Target: ResponsePanel,
+			Done: func(start, now TutorialSnapshot) bool {
+				return now.ActivePanel == ResponsePanel && now.ResponseTab == "Headers"
+			},
+		},
+		{
+			Title: "Write a test",
+			Instructions: []string{
+				"In the Request panel press 5 for Scripts, ] for Post-request, i to edit, and add:",
+				`lc.test("status is 200", function() { lc.expect(lc.response.status).toBe(200); });`,
+				"Press Esc, then ctrl+s. Results show in the Response Tests tab.",
+			},
+			Target: RequestPanel,
+			Done: func(start, now TutorialSnapshot) bool {
+				return now.Responses > start.Responses && now.Assertions > 0
+			},
+		},
+	}
+}
+
+// Tutorial tracks progress through the :tutorial walkthrough
+type Tutorial struct {
+	active bool
+	step   int
+	start  TutorialSnapshot // State when the current step started
+	steps  []TutorialStep
+}
+
+// NewTutorial creates an inactive tutorial
+func NewTutorial() *Tutorial {
+	return &Tutorial{steps: tutorialSteps()}
+}
+
+// Start begins the tutorial from the first step
+func (t *Tutorial) Start(now TutorialSnapshot) {
+	t.active = true
+	t.step = 0
+	t.start = now
+}
+
+// Stop ends the tutorial
+func (t *Tutorial) Stop() {
+	t.active = false
+}
+
+// IsActive returns whether the tutorial is running
+func (t *Tutorial) IsActive() bool {
+	return t.active
+}
+
+// Step returns the index of the current step
+func (t *Tutorial) Step() int {
+	return t.step
+}
+
+// Target returns the panel to highlight for the current step
+func (t *Tutorial) Target() (PanelType, bool) {
+	if !t.active {
+		return 0, false
+	}
+	return t.steps[t.step].Target, true
+}
+
+// Advance moves to the next step when the current one is complete.
+// Returns true if the step was completed; the tutorial stops after the last step.
+func (t *Tutorial) Advance(now TutorialSnapshot) bool {
+	if !t.active || !t.steps[t.step].Done(t.start, now) {
+		return false
+	}
+	t.Skip(now)
+	return true
+}
+
+// Skip moves to the next step without validating the current one
+func (t *Tutorial) Skip(now TutorialSnapshot) {
+	if !t.active {
+		return
+	}
+	t.step++
+	t.start = now
+	if t.step >= len(t.steps) {
+		t.step = 0
+		t.active = false
+	}
+}
+
+// View renders the card describing the current step
+func (t *Tutorial) View(width int) string {
+	if !t.active {
+		return ""
+	}
+	step := t.steps[t.step]
+
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Yellow).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Tutorial %d/%d · %s", t.step+1, len(t.steps), step.Title)))
+	for _, line := range step.Instructions {
+		content.WriteString("\n")
+		content.WriteString(textStyle.Render(line))
+	}
+	content.WriteString("\n")
+	content.WriteString(hintStyle.Render(":tutorial next to skip · :tutorial stop to exit"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Yellow).
+		Padding(0, 1).
+		Width(max(width-2, 10)).
+		Render(content.String())
+}
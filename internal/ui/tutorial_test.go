@@ -0,0 +1,91 @@
+package ui
+
+import "testing"
+
+func TestTutorial_Steps(t *testing.T) {
+	tests := []struct {
+		name   string
+		target PanelType
+		before TutorialSnapshot // Does not complete the step
+		after  TutorialSnapshot // Completes the step
+	}{
+		{
+			name:   "create a request",
+			target: CollectionsPanel,
+			before: TutorialSnapshot{Requests: 2},
+			after:  TutorialSnapshot{Requests: 3},
+		},
+		{
+			name:   "set an environment variable",
+			target: CollectionsPanel,
+			before: TutorialSnapshot{Requests: 3},
+			after:  TutorialSnapshot{Requests: 3, Variables: 1},
+		},
+		{
+			name:   "send the request",
+			target: RequestPanel,
+			before: TutorialSnapshot{Requests: 3, Variables: 1},
+			after:  TutorialSnapshot{Requests: 3, Variables: 1, Responses: 1},
+		},
+		{
+			name:   "read the response",
+			target: ResponsePanel,
+			before: TutorialSnapshot{Responses: 1, ActivePanel: ResponsePanel, ResponseTab: "Body"},
+			after:  TutorialSnapshot{Responses: 1, ActivePanel: ResponsePanel, ResponseTab: "Headers"},
+		},
+		{
+			name:   "write a test",
+			target: RequestPanel,
+			before: TutorialSnapshot{Responses: 2},
+			after:  TutorialSnapshot{Responses: 2, Assertions: 1},
+		},
+	}
+
+	tutorial := NewTutorial()
+	tutorial.Start(TutorialSnapshot{Requests: 2})
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tutorial.Step() != i {
+				t.Fatalf("step = %d, want %d", tutorial.Step(), i)
+			}
+			if target, ok := tutorial.Target(); !ok || target != tt.target {
+				t.Errorf("target = %v, want %v", target, tt.target)
+			}
+			if tutorial.Advance(tt.before) {
+				t.Fatal("step should not complete yet")
+			}
+			if !tutorial.Advance(tt.after) {
+				t.Fatal("step should complete")
+			}
+		})
+	}
+
+	if tutorial.IsActive() {
+		t.Error("tutorial should stop after the last step")
+	}
+	if _, ok := tutorial.Target(); ok {
+		t.Error("no panel should be highlighted once the tutorial is done")
+	}
+}
+
+func TestTutorial_SkipAndStop(t *testing.T) {
+	tutorial := NewTutorial()
+	if tutorial.Advance(TutorialSnapshot{Requests: 1}) {
+		t.Error("inactive tutorial should not advance")
+	}
+
+	tutorial.Start(TutorialSnapshot{})
+	tutorial.Skip(TutorialSnapshot{})
+	if tutorial.Step() != 1 {
+		t.Errorf("step after skip = %d, want 1", tutorial.Step())
+	}
+	if tutorial.View(80) == "" {
+		t.Error("active tutorial should render its card")
+	}
+
+	tutorial.Stop()
+	if tutorial.IsActive() || tutorial.View(80) != "" {
+		t.Error("stopped tutorial should be hidden")
+	}
+}
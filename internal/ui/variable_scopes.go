@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+)
+
+// globalVariables returns the workspace globals
+func (m Model) globalVariables() map[string]string {
+	if m.globals == nil {
+		return nil
+	}
+	return m.globals.Variables
+}
+
+// variableScopes returns the variable scopes of the request with id: its own
+// variables, those of its collection and the workspace globals
+func (m Model) variableScopes(requestID string) api.VariableScopes {
+	scopes := api.VariableScopes{Globals: m.globalVariables()}
+	collections := m.leftPanel.GetCollections()
+	if req := m.editedRequest(requestID); req != nil {
+		scopes.Request = req.Variables
+	}
+	if col := collections.FindCollectionByRequestID(requestID); col != nil {
+		scopes.Collection = col.Variables
+	}
+	return scopes
+}
+
+// runScopes returns the variable scopes shared by the requests of col in a run; each
+// request adds its own variables
+func (m Model) runScopes(col *api.CollectionFile) api.VariableScopes {
+	scopes := api.VariableScopes{Globals: m.globalVariables()}
+	if col != nil {
+		scopes.Collection = col.Variables
+	}
+	return scopes
+}
+
+// requestVariables returns the variables of the request with id resolved across
+// scopes, with the active environment
+func (m Model) requestVariables(requestID string) map[string]string {
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironmentVariables()
+	return m.variableScopes(requestID).Resolve(env)
+}
+
+// variableTypes returns the types of the typed variables of the active environment the
+// request with id takes its values from
+func (m Model) variableTypes(requestID string) map[string]string {
+	return m.variableScopes(requestID).VariableTypes(m.leftPanel.GetEnvironments().GetActiveEnvironment())
+}
+
+// handleVarsCommand shows the variables of the open request by scope or the scope a
+// variable resolves from, or sets or unsets a request, collection or global variable
+func (m Model) handleVarsCommand(args []string) (tea.Model, tea.Cmd) {
+	requestID := m.requestPanel.GetCurrentRequestID()
+	scopes := m.variableScopes(requestID)
+	env := m.leftPanel.GetEnvironments().GetActiveEnvironmentVariables()
+
+	switch len(args) {
+	case 0:
+		m.statusBar.Info(fmt.Sprintf("Variables: %d request · %d environment · %d collection · %d globals",
+			len(scopes.Request), len(env), len(scopes.Collection), len(scopes.Globals)))
+		return m, nil
+	case 1:
+		value, scope, ok := scopes.Lookup(args[0], env)
+		if !ok {
+			m.statusBar.Info("{{" + args[0] + "}} is not defined")
+			return m, nil
+		}
+		if env := m.leftPanel.GetEnvironments().GetActiveEnvironment(); scope == api.ScopeEnvironment && env != nil && env.Variables[args[0]].Secret {
+			value = "••••••"
+		}
+		m.statusBar.Info(fmt.Sprintf("{{%s}} = %s (%s)", args[0], value, scope))
+		return m, nil
+	}
+
+	scope, action, name := strings.ToLower(args[0]), strings.ToLower(args[1]), ""
+	if len(args) > 2 {
+		name = args[2]
+	}
+	value := strings.Join(args[min(len(args), 3):], " ")
+	if name == "" || (action != VarsSet && action != VarsUnset) || (action == VarsSet && len(args) < 4) {
+		m.statusBar.Info("Usage: :vars [name] | :vars request|collection|globals set <name> <value> | unset <name>")
+		return m, nil
+	}
+
+	var vars map[string]string
+	switch scope {
+	case api.ScopeRequest:
+		vars = maps.Clone(scopes.Request)
+	case api.ScopeCollection:
+		vars = maps.Clone(scopes.Collection)
+	case api.ScopeGlobals:
+		vars = maps.Clone(scopes.Globals)
+	default:
+		m.statusBar.Info("Scopes: request, collection, globals (environment variables are edited in the Environments panel)")
+		return m, nil
+	}
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	if action == VarsSet {
+		vars[name] = value
+	} else {
+		delete(vars, name)
+	}
+	if len(vars) == 0 {
+		vars = nil
+	}
+
+	if err := m.saveScopeVariables(scope, requestID, vars); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	if action == VarsSet {
+		m.statusBar.Success("Set "+scope+" variable", name)
+	} else {
+		m.statusBar.Success("Unset "+scope+" variable", name)
+	}
+	return m, nil
+}
+
+// saveScopeVariables replaces and saves the variables of a scope of the request with id
+func (m *Model) saveScopeVariables(scope, requestID string, vars map[string]string) error {
+	collections := m.leftPanel.GetCollections()
+	switch scope {
+	case api.ScopeRequest:
+		if collections.FindRequestByID(requestID) == nil {
+			return fmt.Errorf("open a saved request to change its variables")
+		}
+		if m.holdCommandEdit(requestID, editVariables, func(held *api.CollectionRequest) { held.Variables = vars }) {
+			return nil
+		}
+		return collections.UpdateRequestVariablesByID(requestID, vars)
+	case api.ScopeCollection:
+		col := collections.FindCollectionByRequestID(requestID)
+		if col == nil {
+			return fmt.Errorf("open a saved request to change the variables of its collection")
+		}
+		col.Variables = vars
+		return col.Save()
+	default:
+		if m.globals == nil {
+			m.globals, _ = api.LoadGlobals(m.workspacePath)
+		}
+		m.globals.Variables = vars
+		if vars == nil {
+			m.globals.Variables = make(map[string]string)
+		}
+		return m.globals.Save()
+	}
+}
+
+// askVariableName asks for the variable receiving value, a part of the response
+// described by label, suggesting name. A scope prefix saves it in another scope than
+// the active environment.
+func (m *Model) askVariableName(label, name, value string) {
+	m.dialog.ShowInput(
+		"Save as Variable",
+		"Variable for "+label+" (request:, collection: or globals: prefix for another scope):",
+		name,
+		"save_variable",
+		value,
+	)
+}
+
+// saveResponseValue saves a value of the response into the variable input names: a
+// name, in the active environment, or a scope and a name, "collection:token"
+func (m Model) saveResponseValue(input, value string) (tea.Model, tea.Cmd) {
+	scope, name := api.ScopeEnvironment, strings.TrimSpace(input)
+	if prefix, rest, ok := strings.Cut(name, ":"); ok {
+		switch prefix = strings.ToLower(strings.TrimSpace(prefix)); prefix {
+		case api.ScopeRequest, api.ScopeCollection, api.ScopeGlobals, api.ScopeEnvironment:
+			scope, name = prefix, strings.TrimSpace(rest)
+		}
+	}
+	if name == "" {
+		m.statusBar.Info("No variable name")
+		return m, nil
+	}
+	if scope == api.ScopeEnvironment {
+		return m.saveQueryResultToEnv(name, value)
+	}
+
+	requestID := m.requestPanel.GetCurrentRequestID()
+	scopes := m.variableScopes(requestID)
+	var vars map[string]string
+	switch scope {
+	case api.ScopeRequest:
+		vars = maps.Clone(scopes.Request)
+	case api.ScopeCollection:
+		vars = maps.Clone(scopes.Collection)
+	default:
+		vars = maps.Clone(scopes.Globals)
+	}
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	vars[name] = value
+	if err := m.saveScopeVariables(scope, requestID, vars); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.statusBar.Success("Saved", fmt.Sprintf("{{%s}} in %s variables", name, scope))
+	return m, nil
+}
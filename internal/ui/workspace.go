@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+)
+
+// handleWorkspaceCommand shows the open workspace, opens the picker of the recent ones,
+// or switches to, creates, deletes or forgets a workspace
+func (m Model) handleWorkspaceCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.Success("Workspace", m.workspaceConfig.Name+" ("+m.workspacePath+")")
+		return m, nil
+	}
+
+	target := strings.Join(args[1:], " ")
+	switch args[0] {
+	case WorkspaceList:
+		m.workspaceView.Show(m.globalConfig, m.workspacePath)
+		return m, nil
+
+	case WorkspaceSwitch:
+		if target == "" {
+			m.statusBar.Info("Usage: :ws switch <name|dir>")
+			return m, nil
+		}
+		path, err := m.findWorkspace(target)
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		return m.leaveWorkspace(path)
+
+	case WorkspaceCreate:
+		if target == "" {
+			m.statusBar.Info("Usage: :ws create <dir> [name]")
+			return m, nil
+		}
+		path := config.ResolvePath(m.workspacePath, args[1])
+		name := strings.Join(args[2:], " ")
+		if name == "" {
+			name = filepath.Base(path)
+		}
+		if _, err := config.CreateWorkspace(path, name); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		return m.leaveWorkspace(path)
+
+	case WorkspaceDelete:
+		if target == "" {
+			m.statusBar.Info("Usage: :ws delete <name|dir>")
+			return m, nil
+		}
+		path, err := m.findWorkspace(target)
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		if path == m.workspacePath {
+			m.statusBar.Info("Switch to another workspace before deleting this one")
+			return m, nil
+		}
+		m.dialog.ShowConfirm(
+			"Delete Workspace",
+			"Delete "+filepath.Join(path, ".lazycurl")+"? Its collections, environments and session are removed.",
+			"workspace_delete",
+			path,
+		)
+		return m, nil
+
+	case WorkspaceForget:
+		if target == "" {
+			m.statusBar.Info("Usage: :ws forget <name|dir>")
+			return m, nil
+		}
+		path, err := m.findWorkspace(target)
+		if err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.globalConfig.ForgetWorkspace(path)
+		if err := m.globalConfig.Save(); err != nil {
+			m.statusBar.Error(err)
+			return m, nil
+		}
+		m.statusBar.Success("Removed from recent workspaces", path)
+		return m, nil
+
+	default:
+		m.statusBar.Info("Usage: :ws [list | switch <name|dir> | create <dir> [name] | delete <name|dir> | forget <name|dir>]")
+		return m, nil
+	}
+}
+
+// findWorkspace returns the path of the recent workspace with the given path, directory
+// name or config name, or of the workspace in the directory target, relative to the open
+// workspace
+func (m Model) findWorkspace(target string) (string, error) {
+	for _, entry := range recentWorkspaces(m.globalConfig) {
+		if entry.Path == target || entry.Name == target || filepath.Base(entry.Path) == target {
+			return entry.Path, nil
+		}
+	}
+	path := config.ResolvePath(m.workspacePath, target)
+	if config.WorkspaceExists(path) {
+		return filepath.Clean(path), nil
+	}
+	return "", fmt.Errorf("no workspace %s (:ws create %s creates one)", target, target)
+}
+
+// leaveWorkspace switches to the workspace at path, once confirmed when requests have
+// unsaved edits
+func (m Model) leaveWorkspace(path string) (tea.Model, tea.Cmd) {
+	if m.dirtyTabs() > 0 && path != m.workspacePath {
+		return m.confirmDiscard(unsavedContext{
+			leave:   "switch workspace",
+			proceed: func(m Model) (tea.Model, tea.Cmd) { return m.switchWorkspace(path) },
+		})
+	}
+	return m.switchWorkspace(path)
+}
+
+// switchWorkspace closes the open workspace, saving its session, and opens the one at
+// path with its collections, environments and session, as if LazyCurl started there
+func (m Model) switchWorkspace(path string) (tea.Model, tea.Cmd) {
+	if path == m.workspacePath {
+		m.statusBar.Info("Already in workspace " + m.workspaceConfig.Name)
+		return m, nil
+	}
+	if inFlight, _ := m.sends.counts(); inFlight > 0 {
+		m.statusBar.Info(fmt.Sprintf("Wait for the %d requests in flight before switching workspace", inFlight))
+		return m, nil
+	}
+	workspaceConfig, err := config.LoadWorkspaceConfig(path)
+	if err != nil {
+		m.statusBar.Error(fmt.Errorf("workspace %s: %w", path, err))
+		return m, nil
+	}
+	// Relative paths of file pickers and imports resolve from the workspace
+	if err := os.Chdir(path); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+
+	// Tear down the open workspace
+	m.saveSession()
+	m.stopHookProcess()
+	m.watcher.close()
+	if m.eventStream != nil {
+		m.eventStream.Close()
+	}
+	if m.grpcStream != nil {
+		m.grpcStream.Close()
+	}
+	api.SecretStore, api.DefaultProxy, api.DefaultTLS = nil, nil, nil
+
+	m.globalConfig.TouchWorkspace(path, time.Now())
+	saveErr := m.globalConfig.Save()
+
+	next := NewModel(m.globalConfig, workspaceConfig, path)
+	switch {
+	case saveErr != nil:
+		next.statusBar.Error(saveErr)
+	case next.statusBar.message == "":
+		next.statusBar.Success("Workspace", workspaceConfig.Name)
+	}
+	sized, cmd := next.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return sized, tea.Batch(next.Init(), cmd)
+}
+
+// deleteWorkspace removes the .lazycurl directory of the workspace at path and forgets it
+func (m Model) deleteWorkspace(path string) (tea.Model, tea.Cmd) {
+	if err := os.RemoveAll(filepath.Join(path, ".lazycurl")); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.globalConfig.ForgetWorkspace(path)
+	if err := m.globalConfig.Save(); err != nil {
+		m.statusBar.Error(err)
+		return m, nil
+	}
+	m.statusBar.Success("Deleted workspace", path)
+	return m, nil
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kbrdn1/LazyCurl/internal/api"
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/internal/ui/components"
+)
+
+// runWorkspaceCommand runs :ws with args and returns the resulting model
+func runWorkspaceCommand(t *testing.T, m Model, args ...string) Model {
+	t.Helper()
+	model, _ := m.Update(CommandExecuteMsg{Command: CmdWorkspace, Args: args})
+	return model.(Model)
+}
+
+func TestModel_WorkspaceSwitcher(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	first := t.TempDir()
+	t.Chdir(first)
+	if _, err := config.CreateWorkspace(first, "first"); err != nil {
+		t.Fatal(err)
+	}
+	global := config.DefaultGlobalConfig()
+	global.TouchWorkspace(first, time.Now()) // As at startup
+	m := NewModel(global, config.DefaultWorkspaceConfig(), first)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+
+	// create makes the workspace and switches to it
+	second := filepath.Join(t.TempDir(), "second")
+	m = runWorkspaceCommand(t, m, WorkspaceCreate, second, "Second API")
+	if m.workspacePath != second || m.workspaceConfig.Name != "Second API" {
+		t.Fatalf(":ws create should switch to the new workspace, got %s (%s): %s", m.workspacePath, m.workspaceConfig.Name, m.statusBar.message)
+	}
+	if !config.WorkspaceExists(second) {
+		t.Error(":ws create should write the workspace config")
+	}
+	if m.globalConfig.Workspaces[0] != second || m.globalConfig.WorkspacesUsed[second].IsZero() {
+		t.Errorf("the new workspace should be the most recent, got %v", m.globalConfig.Workspaces)
+	}
+	m = runWorkspaceCommand(t, m, WorkspaceCreate, second)
+	if !strings.Contains(m.statusBar.message, "already") {
+		t.Errorf("creating an existing workspace should fail, got %q", m.statusBar.message)
+	}
+
+	// switch finds recent workspaces by name
+	m = runWorkspaceCommand(t, m, WorkspaceSwitch, "first")
+	if m.workspacePath != first {
+		t.Fatalf(":ws switch first should switch back, got %s: %s", m.workspacePath, m.statusBar.message)
+	}
+
+	// list opens the picker with the recent workspaces, most recent first
+	m = runWorkspaceCommand(t, m, WorkspaceList)
+	if !m.workspaceView.IsVisible() {
+		t.Fatal(":ws list should open the workspace picker")
+	}
+	view := PlainSnapshot(m.View())
+	for _, want := range []string{"Workspaces", "● first", "Second API", second} {
+		if !strings.Contains(view, want) {
+			t.Errorf("the picker should show %q:\n%s", want, view)
+		}
+	}
+
+	// Enter on the second entry switches to it
+	var cmd tea.Cmd
+	m.workspaceView, _ = m.workspaceView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.workspaceView, cmd = m.workspaceView.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = m.Update(cmd())
+	m = model.(Model)
+	if m.workspacePath != second || m.workspaceView.IsVisible() {
+		t.Fatalf("enter should switch to the selected workspace, got %s", m.workspacePath)
+	}
+
+	// The open workspace cannot be deleted; another one is after confirming
+	m = runWorkspaceCommand(t, m, WorkspaceDelete, "Second API")
+	if m.dialog.IsVisible() {
+		t.Error("the open workspace should not be deleted")
+	}
+	m = runWorkspaceCommand(t, m, WorkspaceDelete, "first")
+	if !m.dialog.IsVisible() {
+		t.Fatal(":ws delete should ask for confirmation")
+	}
+	var dialogCmd tea.Cmd
+	m.dialog, dialogCmd = m.dialog.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = m.Update(dialogCmd())
+	m = model.(Model)
+	if config.WorkspaceExists(first) || len(m.globalConfig.Workspaces) != 1 {
+		t.Errorf(":ws delete should remove and forget the workspace, got %v", m.globalConfig.Workspaces)
+	}
+
+	m = runWorkspaceCommand(t, m, WorkspaceForget, second)
+	if len(m.globalConfig.Workspaces) != 0 {
+		t.Errorf(":ws forget should remove the workspace from the list, got %v", m.globalConfig.Workspaces)
+	}
+	saved, err := config.LoadGlobalConfig()
+	if err != nil || len(saved.Workspaces) != 0 {
+		t.Errorf("the recent workspaces should be saved, got %+v, %v", saved, err)
+	}
+}
+
+func TestModel_WorkspaceSwitchUnsavedEdits(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	first := t.TempDir()
+	t.Chdir(first)
+	if _, err := config.CreateWorkspace(first, "first"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(first, ".lazycurl", "collections", "shop.json")
+	col := &api.CollectionFile{Name: "Shop", Requests: []api.CollectionRequest{
+		{ID: "list", Name: "List orders", Method: api.GET, URL: "https://shop.example.com/orders"},
+	}}
+	if err := api.SaveCollection(col, path); err != nil {
+		t.Fatal(err)
+	}
+	second := filepath.Join(t.TempDir(), "second")
+	if _, err := config.CreateWorkspace(second, "second"); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(config.DefaultGlobalConfig(), config.DefaultWorkspaceConfig(), first)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(Model)
+	model, _ = m.Update(components.TreeSelectionMsg{Node: &components.TreeNode{ID: "list", Type: components.RequestNode}})
+	m = model.(Model)
+	m.requestPanel.SetURL("https://shop.example.com/orders?page=2")
+	model, _ = m.Update(RequestURLChangedMsg{URL: "https://shop.example.com/orders?page=2"})
+	m = model.(Model)
+	answer := func(key tea.KeyType) {
+		t.Helper()
+		if !m.dialog.IsVisible() || !strings.Contains(PlainSnapshot(m.View()), "switch workspace") {
+			t.Fatal(":ws switch should confirm the unsaved changes")
+		}
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(tea.KeyMsg{Type: key})
+		model, _ := m.Update(cmd())
+		m = model.(Model)
+	}
+
+	// esc stays in the workspace with the edits
+	m = runWorkspaceCommand(t, m, WorkspaceSwitch, second)
+	answer(tea.KeyEsc)
+	if m.workspacePath != first || !m.requestPanel.IsDirty() || m.requestPanel.GetURL() != "https://shop.example.com/orders?page=2" {
+		t.Fatalf("esc should keep the workspace and its unsaved changes, got %s", m.workspacePath)
+	}
+
+	// enter drops them and switches
+	m = runWorkspaceCommand(t, m, WorkspaceSwitch, second)
+	answer(tea.KeyEnter)
+	if m.workspacePath != second {
+		t.Fatalf("enter should switch workspace, got %s: %s", m.workspacePath, m.statusBar.message)
+	}
+	if saved, _ := api.LoadCollection(path); saved.FindRequest("list").URL != "https://shop.example.com/orders" {
+		t.Error("the discarded edits should not be saved")
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kbrdn1/LazyCurl/internal/config"
+	"github.com/kbrdn1/LazyCurl/pkg/styles"
+)
+
+// WorkspaceActionMsg is sent when a workspace is switched to, deleted or forgotten from
+// the workspace picker
+type WorkspaceActionMsg struct {
+	Action string // WorkspaceSwitch, WorkspaceDelete or WorkspaceForget
+	Path   string
+}
+
+// workspaceEntry is a recent workspace of the picker
+type workspaceEntry struct {
+	Path    string
+	Name    string    // Name in its config, the directory name when it has none
+	Used    time.Time // When it was last opened, zero when unknown
+	Missing bool      // Its directory no longer holds a workspace
+}
+
+// WorkspaceView is the fullscreen picker of the recent workspaces
+type WorkspaceView struct {
+	visible  bool
+	current  string // Path of the open workspace
+	entries  []workspaceEntry
+	selected int
+	offset   int // First visible entry
+}
+
+// NewWorkspaceView creates a new workspace picker
+func NewWorkspaceView() *WorkspaceView {
+	return &WorkspaceView{}
+}
+
+// Show lists the recent workspaces of global, most recent first, marking the one at current
+func (v *WorkspaceView) Show(global *config.GlobalConfig, current string) {
+	v.visible = true
+	v.current = current
+	v.entries = recentWorkspaces(global)
+	v.selected = 0
+	v.offset = 0
+}
+
+// Hide closes the view
+func (v *WorkspaceView) Hide() {
+	v.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (v *WorkspaceView) IsVisible() bool {
+	return v.visible
+}
+
+// recentWorkspaces returns the recent workspaces of global with their names
+func recentWorkspaces(global *config.GlobalConfig) []workspaceEntry {
+	entries := make([]workspaceEntry, 0, len(global.Workspaces))
+	for _, path := range global.Workspaces {
+		entry := workspaceEntry{Path: path, Name: filepath.Base(path), Used: global.WorkspacesUsed[path]}
+		if !config.WorkspaceExists(path) {
+			entry.Missing = true
+		} else if ws, err := config.LoadWorkspaceConfig(path); err == nil && ws.Name != "" {
+			entry.Name = ws.Name
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Update handles key input: enter switches to the selected workspace, d deletes it and
+// x removes it from the list
+func (v *WorkspaceView) Update(msg tea.KeyMsg) (*WorkspaceView, tea.Cmd) {
+	action := ""
+	switch msg.String() {
+	case "q", "esc":
+		v.Hide()
+	case "j", "down":
+		v.selected = min(v.selected+1, max(len(v.entries)-1, 0))
+	case "k", "up":
+		v.selected = max(v.selected-1, 0)
+	case "enter":
+		action = WorkspaceSwitch
+	case "d":
+		action = WorkspaceDelete
+	case "x":
+		action = WorkspaceForget
+	}
+	if action == "" || len(v.entries) == 0 {
+		return v, nil
+	}
+
+	path := v.entries[v.selected].Path
+	if action == WorkspaceForget {
+		v.entries = append(v.entries[:v.selected], v.entries[v.selected+1:]...)
+		v.selected = max(min(v.selected, len(v.entries)-1), 0)
+	} else {
+		v.Hide()
+	}
+	return v, func() tea.Msg {
+		return WorkspaceActionMsg{Action: action, Path: path}
+	}
+}
+
+// View renders the recent workspaces with when they were last opened
+func (v *WorkspaceView) View(width, height int) string {
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Text)
+	currentStyle := lipgloss.NewStyle().Foreground(styles.Green)
+	missingStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	selectedStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Lavender).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Subtext0)
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Subtext0).Italic(true)
+
+	var result strings.Builder
+	if len(v.entries) == 0 {
+		result.WriteString("No recent workspaces. :ws create <dir> creates one, :ws switch <dir> opens one.\n")
+		result.WriteString(hintStyle.Render("q: close"))
+		return result.String()
+	}
+
+	nameWidth := 0
+	for _, entry := range v.entries {
+		nameWidth = max(nameWidth, lipgloss.Width(entry.Name))
+	}
+	nameWidth = min(nameWidth, max(width/3, 12))
+
+	visible := max(height-2, 1)
+	if v.selected < v.offset {
+		v.offset = v.selected
+	}
+	if v.selected >= v.offset+visible {
+		v.offset = v.selected - visible + 1
+	}
+	end := min(v.offset+visible, len(v.entries))
+
+	for i := v.offset; i < end; i++ {
+		entry := v.entries[i]
+		marker := "  "
+		if entry.Path == v.current {
+			marker = currentStyle.Render("● ")
+		}
+		name := entry.Name
+		if lipgloss.Width(name) > nameWidth {
+			name = truncateURL(name, nameWidth)
+		}
+		name = fmt.Sprintf("%-*s", nameWidth, name)
+		if i == v.selected {
+			name = selectedStyle.Render(name)
+		} else {
+			name = nameStyle.Render(name)
+		}
+		used := "never opened    "
+		if !entry.Used.IsZero() {
+			used = entry.Used.Local().Format("2006-01-02 15:04")
+		}
+		path := entry.Path
+		if entry.Missing {
+			path = missingStyle.Render(path + " (missing)")
+		} else {
+			path = mutedStyle.Render(path)
+		}
+		result.WriteString(marker + name + "  " + mutedStyle.Render(used) + "  " + path + "\n")
+	}
+
+	result.WriteString("\n")
+	result.WriteString(hintStyle.Render("j/k: select · enter: switch · d: delete · x: remove from list · q: close"))
+	return result.String()
+}
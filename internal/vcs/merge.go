@@ -0,0 +1,75 @@
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeDriver is the name of the git merge driver of collection files
+const MergeDriver = "lazycurl"
+
+// MergeDriverCommand is the command git runs to merge a collection file: %O is the
+// common ancestor, %A ours, where the result goes, %B theirs and %P the file's path
+const MergeDriverCommand = "lazycurl merge-collections %O %A %B %P"
+
+// mergeAttributes is the .gitattributes line routing collection files to the driver
+const mergeAttributes = Dir + "/collections/*.json merge=" + MergeDriver
+
+// InstallMergeDriver sets up the merge driver of collection files in the git
+// repository of the workspace: it declares the driver in the repository config, which
+// each clone needs, and routes collection files to it in the .gitattributes file of
+// the workspace, which is committed
+func InstallMergeDriver(workspacePath string) error {
+	if Branch(workspacePath) == "" {
+		return ErrNotRepository
+	}
+	if _, err := git(workspacePath, "config", "merge."+MergeDriver+".name", "LazyCurl collection merge"); err != nil {
+		return err
+	}
+	if _, err := git(workspacePath, "config", "merge."+MergeDriver+".driver", MergeDriverCommand); err != nil {
+		return err
+	}
+
+	path := filepath.Join(workspacePath, ".gitattributes")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == mergeAttributes {
+			return nil
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, mergeAttributes+"\n"...)
+	return os.WriteFile(path, data, 0644)
+}
+
+// MergeFile merges the changes from base to theirs into ours line by line, the way git
+// merges text files, leaving conflict markers in ours. Returns the number of conflicts.
+func MergeFile(ours, base, theirs string) (int, error) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return 0, errors.New("git is not installed")
+	}
+	cmd := exec.Command(path, "merge-file", "-L", "ours", "-L", "base", "-L", "theirs", ours, base, theirs)
+	out, err := cmd.CombinedOutput()
+	// merge-file exits with the number of conflicts, or a negative status on error
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return 0, fmt.Errorf("git merge-file: %s", msg)
+		}
+		return 0, fmt.Errorf("git merge-file: %w", err)
+	}
+	return 0, nil
+}
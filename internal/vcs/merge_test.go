@@ -0,0 +1,65 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallMergeDriver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	workspace := t.TempDir()
+	if _, err := git(workspace, "init", "--quiet"); err != nil {
+		t.Fatal(err)
+	}
+	attributes := filepath.Join(workspace, ".gitattributes")
+	_ = os.WriteFile(attributes, []byte("*.png binary"), 0644)
+
+	// Installing twice adds the attributes line once
+	for range 2 {
+		if err := InstallMergeDriver(workspace); err != nil {
+			t.Fatalf("InstallMergeDriver() error = %v", err)
+		}
+	}
+	data, _ := os.ReadFile(attributes)
+	if string(data) != "*.png binary\n.lazycurl/collections/*.json merge=lazycurl\n" {
+		t.Errorf(".gitattributes = %q", data)
+	}
+	if driver, _ := git(workspace, "config", "merge.lazycurl.driver"); strings.TrimSpace(driver) != MergeDriverCommand {
+		t.Errorf("merge.lazycurl.driver = %q", driver)
+	}
+}
+
+func TestMergeFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("base", "a\nb\nc\n")
+	ours := write("ours", "a\nours\nc\n")
+	theirs := write("theirs", "a\ntheirs\nc\n")
+
+	conflicts, err := MergeFile(ours, base, theirs)
+	if err != nil || conflicts != 1 {
+		t.Fatalf("MergeFile() = %d, %v, want 1 conflict", conflicts, err)
+	}
+	if data, _ := os.ReadFile(ours); !strings.Contains(string(data), "<<<<<<< ours") {
+		t.Errorf("merged file has no conflict markers:\n%s", data)
+	}
+
+	ours = write("ours", "a\nb\nc\nd\n")
+	if conflicts, err := MergeFile(ours, base, theirs); err != nil || conflicts != 0 {
+		t.Errorf("MergeFile() = %d, %v, want a clean merge", conflicts, err)
+	}
+}
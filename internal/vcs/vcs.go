@@ -0,0 +1,152 @@
+// Package vcs versions the LazyCurl files of a workspace with git: it reads the
+// branch of the workspace, and stages and commits the changes of its .lazycurl
+// directory. Only the .lazycurl directory is ever staged or committed.
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Dir is the directory of the workspace whose changes are staged and committed
+const Dir = ".lazycurl"
+
+var (
+	// ErrNotRepository is returned when the workspace is not in a git repository
+	ErrNotRepository = errors.New("workspace is not in a git repository")
+	// ErrNoChanges is returned by Commit when the .lazycurl directory has no changes
+	ErrNoChanges = errors.New("no changes in " + Dir)
+)
+
+// Status is the git state of a workspace
+type Status struct {
+	Branch  string   // Checked-out branch, or short commit hash when detached
+	Changes []string // Changed files of the .lazycurl directory, relative to the repository
+}
+
+// Dirty returns true if the .lazycurl directory has uncommitted changes
+func (s Status) Dirty() bool {
+	return len(s.Changes) > 0
+}
+
+// Branch returns the branch checked out in the git repository holding dir, the short
+// commit hash when the HEAD is detached, or "" outside a repository. It reads the .git
+// directory, so git does not need to be installed.
+func Branch(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitPath); err == nil {
+			gitDir := gitPath
+			if !info.IsDir() {
+				// Worktrees and submodules have a "gitdir: <path>" file
+				data, err := os.ReadFile(gitPath)
+				if err != nil {
+					return ""
+				}
+				target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+				if !ok {
+					return ""
+				}
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(dir, target)
+				}
+				gitDir = target
+			}
+			head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+			if err != nil {
+				return ""
+			}
+			ref := strings.TrimSpace(string(head))
+			if branch, ok := strings.CutPrefix(ref, "ref: refs/heads/"); ok {
+				return branch
+			}
+			return ref[:min(7, len(ref))]
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// GetStatus returns the branch of the workspace and the changes of its .lazycurl directory
+func GetStatus(workspacePath string) (Status, error) {
+	status := Status{Branch: Branch(workspacePath)}
+	if status.Branch == "" {
+		return status, ErrNotRepository
+	}
+	out, err := git(workspacePath, "status", "--porcelain", "--untracked-files=all", "--", Dir)
+	if err != nil {
+		return status, err
+	}
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		// "XY path", or "XY old -> new" for renames
+		if len(line) > 3 {
+			path := line[3:]
+			if _, renamed, ok := strings.Cut(path, " -> "); ok {
+				path = renamed
+			}
+			status.Changes = append(status.Changes, strings.Trim(path, `"`))
+		}
+	}
+	return status, nil
+}
+
+// Stage stages the changes of the .lazycurl directory of the workspace
+func Stage(workspacePath string) error {
+	if Branch(workspacePath) == "" {
+		return ErrNotRepository
+	}
+	_, err := git(workspacePath, "add", "--all", "--", Dir)
+	return err
+}
+
+// Commit stages the changes of the .lazycurl directory of the workspace and commits
+// them, and only them, with message. Returns the short hash of the commit.
+func Commit(workspacePath, message string) (string, error) {
+	if strings.TrimSpace(message) == "" {
+		return "", errors.New("commit message is empty")
+	}
+	if err := Stage(workspacePath); err != nil {
+		return "", err
+	}
+	// diff --quiet exits with 1 when there are staged changes
+	if _, err := git(workspacePath, "diff", "--cached", "--quiet", "--", Dir); err == nil {
+		return "", ErrNoChanges
+	}
+	if _, err := git(workspacePath, "commit", "--quiet", "--message", message, "--", Dir); err != nil {
+		return "", err
+	}
+	hash, err := git(workspacePath, "rev-parse", "--short", "HEAD")
+	return strings.TrimSpace(hash), err
+}
+
+// git runs a git command in dir and returns its output, or an error with what git
+// printed on failure
+func git(dir string, args ...string) (string, error) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return "", errors.New("git is not installed")
+	}
+	cmd := exec.Command(path, append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,84 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestBranch(t *testing.T) {
+	repo := t.TempDir()
+	workspace := filepath.Join(repo, "api", "workspace")
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := Branch(workspace); got != "" {
+		t.Errorf("Branch() = %q outside a repository", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	head := filepath.Join(repo, ".git", "HEAD")
+	_ = os.WriteFile(head, []byte("ref: refs/heads/feature/cart\n"), 0644)
+	if got := Branch(workspace); got != "feature/cart" {
+		t.Errorf("Branch() = %q, want feature/cart", got)
+	}
+	_ = os.WriteFile(head, []byte("4f2a9c1d8e7b6a5f4e3d2c1b0a9f8e7d6c5b4a39\n"), 0644)
+	if got := Branch(workspace); got != "4f2a9c1" {
+		t.Errorf("Branch() = %q for a detached HEAD, want 4f2a9c1", got)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	workspace := t.TempDir()
+	if _, err := GetStatus(workspace); !errors.Is(err, ErrNotRepository) {
+		t.Fatalf("GetStatus() error = %v, want ErrNotRepository", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch", "main"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if _, err := git(workspace, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(name, content string) {
+		path := filepath.Join(workspace, name)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".lazycurl/collections/api.json", `{"name": "API"}`)
+	write("main.go", "package main")
+
+	status, err := GetStatus(workspace)
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if status.Branch != "main" || !status.Dirty() || len(status.Changes) != 1 || status.Changes[0] != ".lazycurl/collections/api.json" {
+		t.Errorf("GetStatus() = %+v", status)
+	}
+
+	hash, err := Commit(workspace, "Add API collection")
+	if err != nil || hash == "" {
+		t.Fatalf("Commit() = %q, %v", hash, err)
+	}
+	if status, _ := GetStatus(workspace); status.Dirty() {
+		t.Errorf("status after commit = %+v", status)
+	}
+	// Files outside .lazycurl are left alone
+	if out, _ := git(workspace, "status", "--porcelain", "--", "main.go"); out != "?? main.go\n" {
+		t.Errorf("main.go status = %q, want untracked", out)
+	}
+	if _, err := Commit(workspace, "Nothing"); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("Commit() error = %v, want ErrNoChanges", err)
+	}
+}
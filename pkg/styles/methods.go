@@ -0,0 +1,83 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MethodStyle is the badge of an HTTP method
+type MethodStyle struct {
+	Bg    lipgloss.Color
+	Fg    lipgloss.Color
+	Label string // Short text of the badge, the method itself when empty
+}
+
+// builtinMethods lists the methods LazyCurl knows, in selector order
+var builtinMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "GRPC"}
+
+// customMethods lists the methods added with SetMethodStyle, in the order they were added
+var customMethods []string
+
+// methodStyles holds the badge of each known method
+var methodStyles = map[string]MethodStyle{
+	"GET":     {Bg: MethodGetBg, Fg: MethodGetFg},
+	"POST":    {Bg: MethodPostBg, Fg: MethodPostFg},
+	"PUT":     {Bg: MethodPutBg, Fg: MethodPutFg},
+	"PATCH":   {Bg: MethodPatchBg, Fg: MethodPatchFg},
+	"DELETE":  {Bg: MethodDeleteBg, Fg: MethodDeleteFg},
+	"HEAD":    {Bg: MethodHeadBg, Fg: MethodHeadFg},
+	"OPTIONS": {Bg: MethodOptionsBg, Fg: MethodOptionsFg},
+	"GRPC":    {Bg: MethodGRPCBg, Fg: MethodGRPCFg},
+}
+
+// Method returns the badge of a method; methods without one get a neutral badge
+func Method(method string) MethodStyle {
+	if style, ok := methodStyles[strings.ToUpper(method)]; ok {
+		return style
+	}
+	return MethodStyle{Bg: Surface1, Fg: Text}
+}
+
+// MethodColors returns the background and foreground colors of a method's badge
+func MethodColors(method string) (lipgloss.Color, lipgloss.Color) {
+	style := Method(method)
+	return style.Bg, style.Fg
+}
+
+// MethodLabel returns the text of a method's badge
+func MethodLabel(method string) string {
+	if label := Method(method).Label; label != "" {
+		return label
+	}
+	return method
+}
+
+// SetMethodStyle sets the badge of a method. Empty fields keep the current badge; a
+// method not known yet is added to Methods.
+func SetMethodStyle(method string, style MethodStyle) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return
+	}
+	current, known := methodStyles[method]
+	if !known {
+		current = Method(method)
+		customMethods = append(customMethods, method)
+	}
+	if style.Bg != "" {
+		current.Bg = style.Bg
+	}
+	if style.Fg != "" {
+		current.Fg = style.Fg
+	}
+	if style.Label != "" {
+		current.Label = style.Label
+	}
+	methodStyles[method] = current
+}
+
+// Methods returns the methods requests can use: the built-in ones, then the custom ones
+func Methods() []string {
+	return append(append([]string{}, builtinMethods...), customMethods...)
+}
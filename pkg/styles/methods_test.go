@@ -0,0 +1,42 @@
+package styles
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetMethodStyle(t *testing.T) {
+	defer func(styles map[string]MethodStyle, custom []string) {
+		methodStyles, customMethods = styles, custom
+	}(maps.Clone(methodStyles), customMethods)
+
+	if bg, fg := MethodColors("PURGE"); bg != Surface1 || fg != Text {
+		t.Errorf("unknown method colors = %s, %s; want neutral badge", bg, fg)
+	}
+
+	SetMethodStyle("purge", MethodStyle{Bg: "#ff0000", Label: "PRG"})
+	if bg, fg := MethodColors("PURGE"); bg != "#ff0000" || fg != Text {
+		t.Errorf("PURGE colors = %s, %s; want #ff0000, %s", bg, fg, Text)
+	}
+	if got := MethodLabel("PURGE"); got != "PRG" {
+		t.Errorf("PURGE label = %q, want PRG", got)
+	}
+	if got := Methods(); !slices.Equal(got[len(got)-1:], []string{"PURGE"}) || len(got) != len(builtinMethods)+1 {
+		t.Errorf("Methods() = %v, want built-in methods then PURGE", got)
+	}
+
+	// Overriding a built-in method keeps the fields not set
+	SetMethodStyle("GET", MethodStyle{Bg: lipgloss.Color("#00ff00")})
+	if bg, fg := MethodColors("GET"); bg != "#00ff00" || fg != MethodGetFg {
+		t.Errorf("GET colors = %s, %s; want #00ff00, %s", bg, fg, MethodGetFg)
+	}
+	if got := MethodLabel("GET"); got != "GET" {
+		t.Errorf("GET label = %q, want GET", got)
+	}
+	if got := len(Methods()); got != len(builtinMethods)+1 {
+		t.Errorf("len(Methods()) = %d after overriding GET, want %d", got, len(builtinMethods)+1)
+	}
+}
@@ -3,7 +3,7 @@ package styles
 import "github.com/charmbracelet/lipgloss"
 
 var (
-	// Catppuccin Mocha Colors
+	// Colors of the current theme, Catppuccin Mocha until Apply (see theme.go)
 	// Base colors
 	Base   = lipgloss.Color("#1e1e2e") // background
 	Mantle = lipgloss.Color("#181825") // darker background
@@ -75,6 +75,8 @@ var (
 	MethodDeleteFg  = lipgloss.Color("#FFFFFF") // White
 	MethodOptionsBg = lipgloss.Color("#a48e85") // Brown/Taupe
 	MethodOptionsFg = lipgloss.Color("#FFFFFF") // White
+	MethodGRPCBg    = lipgloss.Color("#2f8f8f") // Teal
+	MethodGRPCFg    = lipgloss.Color("#FFFFFF") // White
 
 	// HTTP status colors - response types
 	Status2xxBg = lipgloss.Color("#4c8c49") // Green (success)
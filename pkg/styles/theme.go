@@ -0,0 +1,247 @@
+package styles
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names. "dark" and "light" are aliases of the Catppuccin Mocha and
+// Latte themes.
+const (
+	ThemeCatppuccinMocha     = "catppuccin-mocha"
+	ThemeCatppuccinMacchiato = "catppuccin-macchiato"
+	ThemeCatppuccinFrappe    = "catppuccin-frappe"
+	ThemeCatppuccinLatte     = "catppuccin-latte"
+	ThemeGruvbox             = "gruvbox"
+	ThemeNord                = "nord"
+)
+
+// Palette is the set of colors a theme draws the UI with. Colors are named after their
+// role in the Catppuccin palette the UI was designed with.
+type Palette struct {
+	Base     lipgloss.Color // background
+	Mantle   lipgloss.Color // darker background
+	Crust    lipgloss.Color // darkest background
+	Text     lipgloss.Color // main text
+	Subtext1 lipgloss.Color // dimmed text
+	Subtext0 lipgloss.Color // more dimmed
+	Surface0 lipgloss.Color // borders
+	Surface1 lipgloss.Color // lighter borders
+	Lavender lipgloss.Color // primary accent
+	Mauve    lipgloss.Color // secondary accent
+	Pink     lipgloss.Color // tertiary accent
+	Red      lipgloss.Color // errors
+	Peach    lipgloss.Color // warnings
+	Yellow   lipgloss.Color // highlights
+	Green    lipgloss.Color // success/active
+	Teal     lipgloss.Color // info
+	Sky      lipgloss.Color // links
+	Sapphire lipgloss.Color // special
+	Blue     lipgloss.Color // primary actions
+}
+
+// PaletteRoles lists the color roles of a palette, as named in theme files
+var PaletteRoles = []string{
+	"base", "mantle", "crust", "text", "subtext1", "subtext0", "surface0", "surface1",
+	"lavender", "mauve", "pink", "red", "peach", "yellow", "green", "teal", "sky", "sapphire", "blue",
+}
+
+// color returns the color of a palette role, nil for an unknown role
+func (p *Palette) color(role string) *lipgloss.Color {
+	switch role {
+	case "base":
+		return &p.Base
+	case "mantle":
+		return &p.Mantle
+	case "crust":
+		return &p.Crust
+	case "text":
+		return &p.Text
+	case "subtext1":
+		return &p.Subtext1
+	case "subtext0":
+		return &p.Subtext0
+	case "surface0":
+		return &p.Surface0
+	case "surface1":
+		return &p.Surface1
+	case "lavender":
+		return &p.Lavender
+	case "mauve":
+		return &p.Mauve
+	case "pink":
+		return &p.Pink
+	case "red":
+		return &p.Red
+	case "peach":
+		return &p.Peach
+	case "yellow":
+		return &p.Yellow
+	case "green":
+		return &p.Green
+	case "teal":
+		return &p.Teal
+	case "sky":
+		return &p.Sky
+	case "sapphire":
+		return &p.Sapphire
+	case "blue":
+		return &p.Blue
+	}
+	return nil
+}
+
+// Set sets the color of a role; it returns false for an unknown role
+func (p *Palette) Set(role string, color lipgloss.Color) bool {
+	c := p.color(strings.ToLower(strings.TrimSpace(role)))
+	if c == nil {
+		return false
+	}
+	*c = color
+	return true
+}
+
+// Theme is a named palette
+type Theme interface {
+	Name() string
+	Palette() Palette
+}
+
+// paletteTheme is a theme holding its palette
+type paletteTheme struct {
+	name    string
+	palette Palette
+}
+
+func (t paletteTheme) Name() string     { return t.name }
+func (t paletteTheme) Palette() Palette { return t.palette }
+
+// NewTheme returns a theme drawing the UI with palette
+func NewTheme(name string, palette Palette) Theme {
+	return paletteTheme{name: name, palette: palette}
+}
+
+// builtinThemes holds the built-in themes in display order
+var builtinThemes = []Theme{
+	NewTheme(ThemeCatppuccinMocha, Palette{
+		Base: "#1e1e2e", Mantle: "#181825", Crust: "#11111b",
+		Text: "#cdd6f4", Subtext1: "#bac2de", Subtext0: "#a6adc8",
+		Surface0: "#313244", Surface1: "#45475a",
+		Lavender: "#b4befe", Mauve: "#cba6f7", Pink: "#f5c2e7", Red: "#f38ba8", Peach: "#fab387",
+		Yellow: "#f9e2af", Green: "#a6e3a1", Teal: "#94e2d5", Sky: "#89dceb", Sapphire: "#74c7ec", Blue: "#89b4fa",
+	}),
+	NewTheme(ThemeCatppuccinMacchiato, Palette{
+		Base: "#24273a", Mantle: "#1e2030", Crust: "#181926",
+		Text: "#cad3f5", Subtext1: "#b8c0e0", Subtext0: "#a5adcb",
+		Surface0: "#363a4f", Surface1: "#494d64",
+		Lavender: "#b7bdf8", Mauve: "#c6a0f6", Pink: "#f5bde6", Red: "#ed8796", Peach: "#f5a97f",
+		Yellow: "#eed49f", Green: "#a6da95", Teal: "#8bd5ca", Sky: "#91d7e3", Sapphire: "#7dc4e4", Blue: "#8aadf4",
+	}),
+	NewTheme(ThemeCatppuccinFrappe, Palette{
+		Base: "#303446", Mantle: "#292c3c", Crust: "#232634",
+		Text: "#c6d0f5", Subtext1: "#b5bfe2", Subtext0: "#a5adce",
+		Surface0: "#414559", Surface1: "#51576d",
+		Lavender: "#babbf1", Mauve: "#ca9ee6", Pink: "#f4b8e4", Red: "#e78284", Peach: "#ef9f76",
+		Yellow: "#e5c890", Green: "#a6d189", Teal: "#81c8be", Sky: "#99d1db", Sapphire: "#85c1dc", Blue: "#8caaee",
+	}),
+	NewTheme(ThemeCatppuccinLatte, Palette{
+		Base: "#eff1f5", Mantle: "#e6e9ef", Crust: "#dce0e8",
+		Text: "#4c4f69", Subtext1: "#5c5f77", Subtext0: "#6c6f85",
+		Surface0: "#ccd0da", Surface1: "#bcc0cc",
+		Lavender: "#7287fd", Mauve: "#8839ef", Pink: "#ea76cb", Red: "#d20f39", Peach: "#fe640b",
+		Yellow: "#df8e1d", Green: "#40a02b", Teal: "#179299", Sky: "#04a5e5", Sapphire: "#209fb5", Blue: "#1e66f5",
+	}),
+	NewTheme(ThemeGruvbox, Palette{
+		Base: "#282828", Mantle: "#1d2021", Crust: "#151718",
+		Text: "#ebdbb2", Subtext1: "#d5c4a1", Subtext0: "#bdae93",
+		Surface0: "#3c3836", Surface1: "#504945",
+		Lavender: "#d3869b", Mauve: "#b16286", Pink: "#d3869b", Red: "#fb4934", Peach: "#fe8019",
+		Yellow: "#fabd2f", Green: "#b8bb26", Teal: "#8ec07c", Sky: "#83a598", Sapphire: "#689d6a", Blue: "#83a598",
+	}),
+	NewTheme(ThemeNord, Palette{
+		Base: "#2e3440", Mantle: "#272c36", Crust: "#242933",
+		Text: "#eceff4", Subtext1: "#e5e9f0", Subtext0: "#d8dee9",
+		Surface0: "#3b4252", Surface1: "#434c5e",
+		Lavender: "#88c0d0", Mauve: "#b48ead", Pink: "#b48ead", Red: "#bf616a", Peach: "#d08770",
+		Yellow: "#ebcb8b", Green: "#a3be8c", Teal: "#8fbcbb", Sky: "#88c0d0", Sapphire: "#81a1c1", Blue: "#5e81ac",
+	}),
+}
+
+// themeAliases maps the theme names of the first releases to built-in themes
+var themeAliases = map[string]string{
+	"dark":  ThemeCatppuccinMocha,
+	"light": ThemeCatppuccinLatte,
+}
+
+// current is the theme the UI is drawn with
+var current = builtinThemes[0]
+
+// Themes returns the built-in themes in display order
+func Themes() []Theme {
+	return slices.Clone(builtinThemes)
+}
+
+// BuiltinTheme returns the built-in theme with the given name or alias
+func BuiltinTheme(name string) (Theme, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := themeAliases[name]; ok {
+		name = alias
+	}
+	for _, theme := range builtinThemes {
+		if theme.Name() == name {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+// Current returns the theme the UI is drawn with
+func Current() Theme {
+	return current
+}
+
+// Apply draws the UI with theme from the next render: it sets the palette colors, the
+// colors derived from them and the base styles. Badges of methods, statuses and modes
+// keep their colors, which read on any background.
+func Apply(theme Theme) {
+	current = theme
+	p := theme.Palette()
+
+	Base, Mantle, Crust = p.Base, p.Mantle, p.Crust
+	Text, Subtext1, Subtext0 = p.Text, p.Subtext1, p.Subtext0
+	Surface0, Surface1 = p.Surface0, p.Surface1
+	Lavender, Mauve, Pink, Red, Peach = p.Lavender, p.Mauve, p.Pink, p.Red, p.Peach
+	Yellow, Green, Teal, Sky, Sapphire, Blue = p.Yellow, p.Green, p.Teal, p.Sky, p.Sapphire, p.Blue
+
+	PrimaryColor = Lavender
+	SecondaryColor = Blue
+	AccentColor = Mauve
+	TextColor = Text
+	MutedColor = Subtext0
+	BorderColor = Surface0
+	ActiveColor = Lavender
+
+	SelectedPanelBg, SelectedPanelFg = Lavender, Blue
+	SelectedRequestBg, SelectedRequestFg = Surface1, Lavender
+	CurrentCollectionBg, CurrentCollectionFg = Lavender, Blue
+	CollectionNotCurrentBg, CollectionNotCurrentFg = Surface0, Text
+	CheckboxOn = Green
+	SearchDimmed = Surface1
+	URLBase = Text
+
+	TitleStyle = TitleStyle.Foreground(Lavender).Background(Mantle)
+	ActiveTitleStyle = ActiveTitleStyle.Foreground(Lavender).Background(Mantle)
+	BoxStyle = BoxStyle.BorderForeground(Surface0)
+	ActiveBorderStyle = ActiveBorderStyle.BorderForeground(Lavender)
+	InactiveBorderStyle = InactiveBorderStyle.BorderForeground(Surface0)
+	StatusBarStyle = StatusBarStyle.Foreground(Text).Background(Mantle)
+	ItemStyle = ItemStyle.Foreground(Text)
+	SelectedItemStyle = SelectedItemStyle.Foreground(Lavender)
+	SeparatorStyle = SeparatorStyle.Foreground(Surface0)
+	HelpStyle = HelpStyle.Foreground(Subtext0)
+	ErrorStyle = ErrorStyle.Foreground(Red)
+	SuccessStyle = SuccessStyle.Foreground(Green)
+	InfoStyle = InfoStyle.Foreground(Blue)
+}
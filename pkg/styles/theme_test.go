@@ -0,0 +1,45 @@
+package styles
+
+import "testing"
+
+func TestBuiltinTheme(t *testing.T) {
+	for alias, name := range map[string]string{"dark": ThemeCatppuccinMocha, "Light": ThemeCatppuccinLatte, "nord": ThemeNord} {
+		theme, ok := BuiltinTheme(alias)
+		if !ok || theme.Name() != name {
+			t.Errorf("BuiltinTheme(%q) = %v, %v; want %s", alias, theme, ok, name)
+		}
+	}
+	if _, ok := BuiltinTheme("solarized"); ok {
+		t.Error("BuiltinTheme(solarized) should not exist")
+	}
+
+	// The default theme is the palette the colors start with
+	mocha, _ := BuiltinTheme(ThemeCatppuccinMocha)
+	if p := mocha.Palette(); p.Base != Base || p.Lavender != Lavender || p.Blue != Blue {
+		t.Errorf("Catppuccin Mocha palette differs from the default colors: %+v", p)
+	}
+}
+
+func TestApply(t *testing.T) {
+	defer Apply(Current())
+
+	latte, _ := BuiltinTheme(ThemeCatppuccinLatte)
+	Apply(latte)
+	if Current().Name() != ThemeCatppuccinLatte || Base != "#eff1f5" || Text != "#4c4f69" {
+		t.Errorf("Apply(latte) = %s, base %s, text %s", Current().Name(), Base, Text)
+	}
+	if SelectedRequestBg != Surface1 || BorderColor != Surface0 || ActiveBorderStyle.GetBorderTopForeground() != Lavender {
+		t.Error("colors and styles derived from the palette should follow the theme")
+	}
+
+	palette := latte.Palette()
+	if !palette.Set("Base", "#000000") || palette.Base != "#000000" {
+		t.Errorf("Set(Base) = %s", palette.Base)
+	}
+	if palette.Set("background", "#000000") {
+		t.Error("Set should reject unknown roles")
+	}
+	if latte.Palette().Base != "#eff1f5" {
+		t.Error("changing a palette copy should not change the theme")
+	}
+}